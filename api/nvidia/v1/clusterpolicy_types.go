@@ -19,14 +19,23 @@ package v1
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	kata_v1alpha1 "github.com/NVIDIA/k8s-kata-manager/api/v1alpha1/config"
 	upgrade_v1alpha1 "github.com/NVIDIA/k8s-operator-libs/api/upgrade/v1alpha1"
 	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/utils/ptr"
+
+	"github.com/NVIDIA/gpu-operator/internal/consts"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -47,6 +56,15 @@ type ClusterPolicySpec struct {
 	Operator OperatorSpec `json:"operator"`
 	// Daemonset defines common configuration for all Daemonsets
 	Daemonsets DaemonsetsSpec `json:"daemonsets"`
+	// NodeSelector scopes the ClusterPolicy to the GPU nodes matching the selector. The operands of these
+	// nodes are deployed as configured by the scoped ClusterPolicy, while the operands of the other GPU nodes
+	// are deployed as configured by the ClusterPolicy without node selector. The node selectors of the scoped
+	// ClusterPolicies must not match the same node.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Node Selector"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 	// Driver component spec
 	Driver DriverSpec `json:"driver"`
 	// Toolkit component spec
@@ -97,6 +115,88 @@ type ClusterPolicySpec struct {
 	HostPaths HostPathsSpec `json:"hostPaths,omitempty"`
 	// KataSandboxDevicePlugin component spec
 	KataSandboxDevicePlugin KataDevicePluginSpec `json:"kataSandboxDevicePlugin,omitempty"`
+	// ImageResolution defines how operand image references are resolved before rendering
+	ImageResolution *ImageResolutionSpec `json:"imageResolution,omitempty"`
+	// ImageMirror maps registry prefixes to the mirror prefixes replacing them in the images of all operands,
+	// including init containers, e.g. "nvcr.io/nvidia": "registry.local/nvidia". Prefixes match whole path
+	// components of the image repository, and the longest matching prefix is used.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Image Mirror"
+	ImageMirror map[string]string `json:"imageMirror,omitempty"`
+	// ImagePullSecrets defines the image pull secrets attached to all operands, on top of the image pull
+	// secrets of each operand
+	// +kubebuilder:validation:Optional
+	ImagePullSecrets *ImagePullSecretsSpec `json:"imagePullSecrets,omitempty"`
+	// RegistryClient defines how the operator queries the registries, e.g. to resolve the image digests or
+	// probe the precompiled driver images
+	// +kubebuilder:validation:Optional
+	RegistryClient *RegistryClientSpec `json:"registryClient,omitempty"`
+	// FleetReport defines the periodic summary report of the GPU nodes
+	FleetReport *FleetReportSpec `json:"fleetReport,omitempty"`
+	// DownloadCache defines the in-cluster caching proxy for driver downloads
+	DownloadCache *DownloadCacheSpec `json:"downloadCache,omitempty"`
+	// HealthCheck defines the GPU health check reacting to critical XID errors
+	HealthCheck *GPUHealthCheckSpec `json:"healthCheck,omitempty"`
+	// NVLinkFabric defines the registration of the nodes of multi-node NVLink fabrics
+	NVLinkFabric *NVLinkFabricSpec `json:"nvlinkFabric,omitempty"`
+	// MPSControlDaemon defines the deployment of the MPS control daemon serving the GPUs shared through MPS
+	MPSControlDaemon *MPSControlDaemonSpec `json:"mpsControlDaemon,omitempty"`
+	// ImagePrePull defines the pre-pull of the driver and toolkit images ahead of their upgrade
+	ImagePrePull *ImagePrePullSpec `json:"imagePrePull,omitempty"`
+	// SafeMode defines the pause of the operand rollouts when they correlate with crash looping operand pods
+	SafeMode *SafeModeSpec `json:"safeMode,omitempty"`
+	// UsageAccounting defines the periodic export of the GPU usage records for chargeback
+	UsageAccounting *UsageAccountingSpec `json:"usageAccounting,omitempty"`
+	// Windows defines the deployment of the device plugin and GPU Feature Discovery to the Windows GPU nodes
+	Windows *WindowsSpec `json:"windows,omitempty"`
+	// NodeFeatureDiscovery defines the deployment of Node Feature Discovery by the operator
+	NodeFeatureDiscovery *NodeFeatureDiscoverySpec `json:"nodeFeatureDiscovery,omitempty"`
+	// ExternalGates defines prerequisites managed outside of the operator, e.g. the MOFED driver deployed by
+	// the NVIDIA Network Operator, which must be met before the operands of the gated states are deployed
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	ExternalGates []ExternalGateSpec `json:"externalGates,omitempty"`
+	// TenantNamespaces lists the namespaces of the tenants of a multi-tenant cluster. The device plugin and
+	// DCGM Exporter of the GPU nodes of a tenant are deployed in its namespace, with namespace-scoped RBAC.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	TenantNamespaces []TenantNamespaceSpec `json:"tenantNamespaces,omitempty"`
+	// ConsumerGPUs defines the handling of the GPU nodes with consumer GPUs, e.g. GeForce GPUs, which do not
+	// support the datacenter features some operands rely on
+	// +kubebuilder:validation:Optional
+	ConsumerGPUs *ConsumerGPUsSpec `json:"consumerGPUs,omitempty"`
+
+	// Proxy defines the proxy and the trusted CA bundle injected into the operands downloading or building
+	// software: the driver, the container toolkit and the vGPU manager. The registry requests of the operator
+	// also go through the proxy and trust the CA bundle.
+	// +kubebuilder:validation:Optional
+	Proxy *ProxySpec `json:"proxy,omitempty"`
+
+	// ReadinessHysteresis debounces the operand readiness changes reported in the state, the conditions and the
+	// node statuses of the ClusterPolicy
+	// +kubebuilder:validation:Optional
+	ReadinessHysteresis *ReadinessHysteresisSpec `json:"readinessHysteresis,omitempty"`
+
+	// AutoscalingHints defines the publishing of the GPU resources expected on the nodes of each instance type,
+	// so that node autoscalers can scale GPU node groups from zero
+	// +kubebuilder:validation:Optional
+	AutoscalingHints *AutoscalingHintsSpec `json:"autoscalingHints,omitempty"`
+
+	// Notifications defines the HTTP endpoints notified of the lifecycle events of the GPU nodes, so that
+	// inventory and incident management systems stay in sync without polling
+	// +kubebuilder:validation:Optional
+	Notifications *NotificationsSpec `json:"notifications,omitempty"`
+
+	// Teardown defines the staged removal of the operands when the ClusterPolicy is deleted
+	// +kubebuilder:validation:Optional
+	Teardown *TeardownSpec `json:"teardown,omitempty"`
+
+	// Devel defines the settings of development clusters without GPU hardware
+	// +kubebuilder:validation:Optional
+	Devel *DevelSpec `json:"devel,omitempty"`
 }
 
 // Runtime defines container runtime type
@@ -238,95 +338,1640 @@ type HostPathsSpec struct {
 	KubeletRootDir string `json:"kubeletRootDir,omitempty"`
 }
 
-// EnvVar represents an environment variable present in a Container.
-type EnvVar struct {
-	// Name of the environment variable.
-	Name string `json:"name"`
+// ImageResolutionPolicy defines how operand image references are rendered
+type ImageResolutionPolicy string
 
-	// Value of the environment variable.
-	Value string `json:"value,omitempty"`
+const (
+	// ImageResolutionTag renders operand images with the configured tags
+	ImageResolutionTag ImageResolutionPolicy = "Tag"
+	// ImageResolutionDigest resolves operand image tags to sha256 digests at reconcile time
+	// and renders operand images pinned to the digests
+	ImageResolutionDigest ImageResolutionPolicy = "Digest"
+)
+
+// ImageResolutionSpec defines how operand image references are resolved before rendering
+type ImageResolutionSpec struct {
+	// Policy indicates whether operand images are rendered with tags or pinned to digests.
+	// When set to Digest, every operand image tag is resolved to a sha256 digest by querying
+	// the registry with the image pull secrets configured for the operand.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Tag;Digest
+	// +kubebuilder:default=Tag
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Image Resolution Policy"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:select:Tag,urn:alm:descriptor:com.tectonic.ui:select:Digest"
+	Policy ImageResolutionPolicy `json:"policy,omitempty"`
 }
 
-// ResourceRequirements describes the compute resource requirements.
-type ResourceRequirements struct {
-	// Limits describes the maximum amount of compute resources allowed.
-	// More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
-	// +optional
-	Limits corev1.ResourceList `json:"limits,omitempty"`
-	// Requests describes the minimum amount of compute resources required.
-	// If Requests is omitted for a container, it defaults to Limits if that is explicitly specified,
-	// otherwise to an implementation-defined value. Requests cannot exceed Limits.
-	// More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
-	// +optional
-	Requests corev1.ResourceList `json:"requests,omitempty"`
+// ImagePullSecretsSpec defines the image pull secrets attached to all operands. The operator aggregates the
+// listed secrets with the image pull secrets of the listed service accounts of the operator namespace, so that
+// the operands follow the secrets rotated by registry credential systems on the service accounts.
+type ImagePullSecretsSpec struct {
+	// Secrets lists the names of image pull secrets of the operator namespace attached to all operands
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Image pull secrets of all operands"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	Secrets []string `json:"secrets,omitempty"`
+
+	// ServiceAccounts lists the service accounts of the operator namespace whose image pull secrets are attached
+	// to all operands. The default service account is used when not set, an empty list disables the aggregation.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={"default"}
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Service accounts providing image pull secrets"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
+	ServiceAccounts []string `json:"serviceAccounts,omitempty"`
 }
 
-// SandboxWorkloadsSpec describes configuration for handling sandbox workloads (i.e. Virtual Machines)
-type SandboxWorkloadsSpec struct {
-	// Enabled indicates if the GPU Operator should manage additional operands required
-	// for sandbox workloads (i.e. VFIO Manager, vGPU Manager, and additional device plugins)
-	Enabled *bool `json:"enabled,omitempty"`
-	// DefaultWorkload indicates the default GPU workload type to configure
-	// worker nodes in the cluster for
-	// +kubebuilder:validation:Enum=container;vm-passthrough;vm-vgpu
-	// +kubebuilder:default=container
-	DefaultWorkload string `json:"defaultWorkload,omitempty"`
-	// Mode indicates the sandbox mode. Accepted values are "kubevirt"
-	// and "kata". The default value is "kubevirt".
-	// +kubebuilder:validation:Enum=kubevirt;kata
-	// +kubebuilder:default=kubevirt
-	Mode string `json:"mode,omitempty"`
+// GetServiceAccounts returns the service accounts whose image pull secrets are attached to all operands
+func (s *ImagePullSecretsSpec) GetServiceAccounts() []string {
+	if s == nil {
+		return nil
+	}
+	if s.ServiceAccounts == nil {
+		return []string{"default"}
+	}
+	return s.ServiceAccounts
 }
 
-// PSPSpec describes configuration for PodSecurityPolicies to apply for all Pods
-type PSPSpec struct {
-	// Enabled indicates if PodSecurityPolicies needs to be enabled for all Pods
+// RegistryClientSpec defines how the operator queries the registries. The requests of all features are sent
+// through a shared client, rate limited per registry and retried with jittered exponential backoff. The
+// requests go through the proxy and trust the CA bundle of spec.proxy when set.
+type RegistryClientSpec struct {
+	// RequestsPerSecond is the maximum rate of the requests sent to each registry
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	RequestsPerSecond int `json:"requestsPerSecond,omitempty"`
+
+	// Burst is the number of requests sent at once to a registry before the rate limit applies
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	Burst int `json:"burst,omitempty"`
+
+	// MaxRetries is the number of times a failed request is retried. The requests for missing images, or
+	// rejected because of the credentials, are not retried.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	MaxRetries *int `json:"maxRetries,omitempty"`
+}
+
+// GetRequestsPerSecond returns the maximum rate of the requests sent to each registry, 10 by default
+func (r *RegistryClientSpec) GetRequestsPerSecond() int {
+	if r == nil || r.RequestsPerSecond <= 0 {
+		return 10
+	}
+	return r.RequestsPerSecond
+}
+
+// GetBurst returns the number of requests sent at once to a registry, 10 by default
+func (r *RegistryClientSpec) GetBurst() int {
+	if r == nil || r.Burst <= 0 {
+		return 10
+	}
+	return r.Burst
+}
+
+// GetMaxRetries returns the number of times a failed request is retried, 3 by default
+func (r *RegistryClientSpec) GetMaxRetries() int {
+	if r == nil || r.MaxRetries == nil || *r.MaxRetries < 0 {
+		return 3
+	}
+	return *r.MaxRetries
+}
+
+// DownloadCacheSpec defines the in-cluster caching proxy deployed by the operator. When enabled, the driver
+// containers use the proxy for the package and runfile downloads of their driver builds, so that each
+// download leaves the cluster once. Only plain HTTP downloads are cached, HTTPS requests are tunneled.
+type DownloadCacheSpec struct {
+	// Enabled indicates if the download cache is deployed and used by the driver containers
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable the download cache"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	Enabled *bool `json:"enabled,omitempty"`
+
+	// Download cache image repository
+	// +kubebuilder:validation:Optional
+	Repository string `json:"repository,omitempty"`
+
+	// Download cache image name
+	// +kubebuilder:validation:Pattern=[a-zA-Z0-9\-]+
+	Image string `json:"image,omitempty"`
+
+	// Download cache image tag
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+
+	// Image pull policy
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Image Pull Policy"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:imagePullPolicy"
+	ImagePullPolicy string `json:"imagePullPolicy,omitempty"`
+
+	// Image pull secrets
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Image pull secrets"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+
+	// Optional: Define resources requests and limits for the download cache pod
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Resource Requirements"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:resourceRequirements"
+	Resources *ResourceRequirements `json:"resources,omitempty"`
+
+	// Optional: List of environment variables
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
+	Env []EnvVar `json:"env,omitempty"`
+
+	// StorageSize is the size of the cache. Defaults to 20Gi.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Storage Size"
+	StorageSize *resource.Quantity `json:"storageSize,omitempty"`
+
+	// PersistentVolumeClaim is the name of an existing PersistentVolumeClaim in the operator namespace
+	// holding the cache, so that the cache survives restarts. An emptyDir volume is used if not specified.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Persistent Volume Claim"
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+
+	// UpstreamProxy is the host:port of a proxy the cache forwards its requests to, e.g. the egress proxy of
+	// the cluster. Requests are sent directly to the origin servers if not specified.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Upstream Proxy"
+	UpstreamProxy string `json:"upstreamProxy,omitempty"`
 }
 
-// PSASpec describes configuration for PodSecurityAdmission to apply for all Pods
-type PSASpec struct {
-	// Enabled indicates if PodSecurityAdmission configuration needs to be enabled for all Pods
+// IsEnabled returns true if the download cache is enabled
+func (d *DownloadCacheSpec) IsEnabled() bool {
+	if d == nil || d.Enabled == nil {
+		// download cache is disabled by default
+		return false
+	}
+	return *d.Enabled
+}
+
+// GetStorageSize returns the size of the download cache
+func (d *DownloadCacheSpec) GetStorageSize() resource.Quantity {
+	if d == nil || d.StorageSize == nil {
+		return resource.MustParse("20Gi")
+	}
+	return *d.StorageSize
+}
+
+// GPUHealthCheckSpec defines the GPU health check deployed by the operator. The health check watches the
+// kernel log of the GPU nodes for critical XID errors and labels the nodes reporting one with
+// nvidia.com/gpu.unhealthy=true. The device plugin is then paused on those nodes until the label is removed,
+// which the health check does once the node has been rebooted.
+type GPUHealthCheckSpec struct {
+	// Enabled indicates if the GPU health check is deployed
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable the GPU health check"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	Enabled *bool `json:"enabled,omitempty"`
+
+	// GPU health check image repository
+	// +kubebuilder:validation:Optional
+	Repository string `json:"repository,omitempty"`
+
+	// GPU health check image name
+	// +kubebuilder:validation:Pattern=[a-zA-Z0-9\-]+
+	Image string `json:"image,omitempty"`
+
+	// GPU health check image tag
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+
+	// Image pull policy
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Image Pull Policy"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:imagePullPolicy"
+	ImagePullPolicy string `json:"imagePullPolicy,omitempty"`
+
+	// Image pull secrets
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Image pull secrets"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+
+	// Optional: Define resources requests and limits for each pod
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Resource Requirements"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:resourceRequirements"
+	Resources *ResourceRequirements `json:"resources,omitempty"`
+
+	// Optional: List of environment variables
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
+	Env []EnvVar `json:"env,omitempty"`
+
+	// CriticalXIDs is the list of XID errors marking a node unhealthy. The XIDs reporting uncorrectable
+	// memory errors, NVLink errors, GSP errors and GPUs fallen off the bus are used if not specified.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Critical XIDs"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
+	CriticalXIDs []int32 `json:"criticalXIDs,omitempty"`
+
+	// Taint indicates if unhealthy nodes are also tainted with nvidia.com/gpu.unhealthy:NoSchedule, so
+	// that no new pods are scheduled on them
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Taint unhealthy nodes"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Taint *bool `json:"taint,omitempty"`
 }
 
-// DaemonsetsSpec indicates common configuration for all Daemonsets managed by GPU Operator
-type DaemonsetsSpec struct {
-	// Optional: Map of string keys and values that can be used to organize and categorize
-	// (scope and select) objects. May match selectors of replication controllers
-	// and services.
-	Labels map[string]string `json:"labels,omitempty"`
+// IsEnabled returns true if the GPU health check is enabled
+func (h *GPUHealthCheckSpec) IsEnabled() bool {
+	if h == nil || h.Enabled == nil {
+		// GPU health check is disabled by default
+		return false
+	}
+	return *h.Enabled
+}
 
-	// Optional: Annotations is an unstructured key value map stored with a resource that may be
-	// set by external tools to store and retrieve arbitrary metadata. They are not
-	// queryable and should be preserved when modifying objects.
-	Annotations map[string]string `json:"annotations,omitempty"`
+// IsTaintEnabled returns true if unhealthy nodes are tainted
+func (h *GPUHealthCheckSpec) IsTaintEnabled() bool {
+	if h == nil || h.Taint == nil {
+		// unhealthy nodes are not tainted by default
+		return false
+	}
+	return *h.Taint
+}
 
-	// Optional: Set tolerations
+// NVLinkFabricSpec defines the registration of the GPU nodes of NVSwitch-based multi-node NVLink fabrics.
+// The nodes of a fabric share the same nvidia.com/gpu.fabric.domain label value, and the nodes labeled
+// nvidia.com/gpu.fabric.leader=true register first. The other nodes of the fabric wait for their leaders,
+// and the driver of every node is only reported ready once its NVLink fabric registration completed with
+// the full bandwidth available.
+type NVLinkFabricSpec struct {
+	// Enabled indicates if the NVLink fabric registration is deployed
+	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Tolerations"
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:io.kubernetes:Tolerations"
-	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable the NVLink fabric registration"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// NVLink fabric registration image repository
+	// +kubebuilder:validation:Optional
+	Repository string `json:"repository,omitempty"`
+
+	// NVLink fabric registration image name
+	// +kubebuilder:validation:Pattern=[a-zA-Z0-9\-]+
+	Image string `json:"image,omitempty"`
+
+	// NVLink fabric registration image tag
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
 
+	// Image pull policy
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="PriorityClassName"
-	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Image Pull Policy"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:imagePullPolicy"
+	ImagePullPolicy string `json:"imagePullPolicy,omitempty"`
 
+	// Image pull secrets
 	// +kubebuilder:validation:Optional
-	// +kubebuilder:default=RollingUpdate
-	// +kubebuilder:validation:Enum=RollingUpdate;OnDelete
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="UpdateStrategy for all Daemonsets"
-	UpdateStrategy string `json:"updateStrategy,omitempty"`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Image pull secrets"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
 
-	// Optional: Configuration for rolling update of all DaemonSet pods
+	// Optional: Define resources requests and limits for each pod
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Rolling update configuration for all DaemonSet pods"
-	RollingUpdate *RollingUpdateSpec `json:"rollingUpdate,omitempty"`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Resource Requirements"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:resourceRequirements"
+	Resources *ResourceRequirements `json:"resources,omitempty"`
+
+	// Optional: List of environment variables
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
+	Env []EnvVar `json:"env,omitempty"`
+
+	// NVLink fabric container startup probe settings
+	StartupProbe *ContainerProbeSpec `json:"startupProbe,omitempty"`
+
+	// NVLink fabric container liveness probe settings
+	LivenessProbe *ContainerProbeSpec `json:"livenessProbe,omitempty"`
+
+	// NVLink fabric container readiness probe settings
+	ReadinessProbe *ContainerProbeSpec `json:"readinessProbe,omitempty"`
+
+	// PartitionConfig is the name of the ConfigMap holding the fabric manager configuration, including the
+	// fabric partitions, under the fabricmanager.cfg key. The configuration is mounted into the driver
+	// container, where the fabric manager runs. The configuration of the driver image is used if not set.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Fabric Manager Partition Config"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	PartitionConfig string `json:"partitionConfig,omitempty"`
+}
+
+// IsEnabled returns true if the NVLink fabric registration is enabled
+func (f *NVLinkFabricSpec) IsEnabled() bool {
+	if f == nil || f.Enabled == nil {
+		// NVLink fabric registration is disabled by default
+		return false
+	}
+	return *f.Enabled
+}
+
+// ImagePrePullSpec defines the pre-pull of the driver and toolkit images configured in the ClusterPolicy onto
+// the GPU nodes. A low-priority DaemonSet pulls the images through init containers ahead of the upgrade window,
+// so that the driver upgrade of the nodes incurs nearly no image pull delay. Once the images are pulled, the
+// pulled images are recorded in the nvidia.com/gpu.prepull.driver-image and nvidia.com/gpu.prepull.toolkit-image
+// annotations of the node. The images of pre-compiled drivers, which depend on the kernel of the node, are only
+// pre-pulled for the kernel versions announced for an upcoming OS upgrade, see DriverSpec.OSUpgradePreStaging.
+// The images of the drivers managed through the NVIDIADriver CRD are not pre-pulled.
+type ImagePrePullSpec struct {
+	// Enabled indicates if the driver and toolkit images are pre-pulled
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable the image pre-pull"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Image pre-pull image repository, the image recording the pulled images on the node
+	// +kubebuilder:validation:Optional
+	Repository string `json:"repository,omitempty"`
+
+	// Image pre-pull image name
+	// +kubebuilder:validation:Pattern=[a-zA-Z0-9\-]+
+	Image string `json:"image,omitempty"`
+
+	// Image pre-pull image tag
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+
+	// Image pull policy
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Image Pull Policy"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:imagePullPolicy"
+	ImagePullPolicy string `json:"imagePullPolicy,omitempty"`
+
+	// Image pull secrets
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Image pull secrets"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+
+	// Optional: Define resources requests and limits for each pod
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Resource Requirements"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:resourceRequirements"
+	Resources *ResourceRequirements `json:"resources,omitempty"`
+
+	// Optional: List of environment variables
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
+	Env []EnvVar `json:"env,omitempty"`
+
+	// PriorityClassName is the priority class of the image pre-pull pods. The pods have the default
+	// priority of the cluster if not set, so that they never preempt workloads.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Priority Class Name"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// IsEnabled returns true if the image pre-pull is enabled
+func (p *ImagePrePullSpec) IsEnabled() bool {
+	if p == nil || p.Enabled == nil {
+		// image pre-pull is disabled by default
+		return false
+	}
+	return *p.Enabled
+}
+
+// WindowsSpec defines the deployment of the operands to the Windows GPU nodes, labeled kubernetes.io/os=windows.
+// The GPU driver is pre-installed on the Windows hosts, so only the Windows builds of the device plugin and GPU
+// Feature Discovery are deployed to them, as HostProcess containers. The other operands, e.g. the driver and the
+// container toolkit, are never deployed to the Windows nodes. When disabled, no operand is deployed to them.
+type WindowsSpec struct {
+	// Enabled indicates if the operands are deployed to the Windows GPU nodes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable the Windows GPU nodes"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// DevicePlugin defines the Windows build of the device plugin
+	// +kubebuilder:validation:Optional
+	DevicePlugin *WindowsComponentSpec `json:"devicePlugin,omitempty"`
+
+	// GPUFeatureDiscovery defines the Windows build of GPU Feature Discovery
+	// +kubebuilder:validation:Optional
+	GPUFeatureDiscovery *WindowsComponentSpec `json:"gfd,omitempty"`
+}
+
+// WindowsComponentSpec defines an operand deployed to the Windows GPU nodes
+type WindowsComponentSpec struct {
+	// Enabled indicates if the operand is deployed to the Windows GPU nodes, defaults to true
+	// +kubebuilder:validation:Optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Windows image repository
+	// +kubebuilder:validation:Optional
+	Repository string `json:"repository,omitempty"`
+
+	// Windows image name
+	// +kubebuilder:validation:Pattern=[a-zA-Z0-9\-]+
+	Image string `json:"image,omitempty"`
+
+	// Windows image tag
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+
+	// Image pull policy
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Image Pull Policy"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:imagePullPolicy"
+	ImagePullPolicy string `json:"imagePullPolicy,omitempty"`
+
+	// Image pull secrets
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Image pull secrets"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+
+	// Optional: Define resources requests and limits for each pod
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Resource Requirements"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:resourceRequirements"
+	Resources *ResourceRequirements `json:"resources,omitempty"`
+
+	// Optional: List of arguments
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Arguments"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
+	Args []string `json:"args,omitempty"`
+
+	// Optional: List of environment variables
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
+	Env []EnvVar `json:"env,omitempty"`
+}
+
+// IsEnabled returns true if the operands are deployed to the Windows GPU nodes
+func (w *WindowsSpec) IsEnabled() bool {
+	if w == nil || w.Enabled == nil {
+		// the Windows GPU nodes are disabled by default
+		return false
+	}
+	return *w.Enabled
+}
+
+// IsDevicePluginEnabled returns true if the Windows device plugin is deployed to the Windows GPU nodes
+func (w *WindowsSpec) IsDevicePluginEnabled() bool {
+	return w.IsEnabled() && w.DevicePlugin.isEnabled()
+}
+
+// IsGPUFeatureDiscoveryEnabled returns true if the Windows GPU Feature Discovery is deployed to the Windows GPU nodes
+func (w *WindowsSpec) IsGPUFeatureDiscoveryEnabled() bool {
+	return w.IsEnabled() && w.GPUFeatureDiscovery.isEnabled()
+}
+
+func (c *WindowsComponentSpec) isEnabled() bool {
+	if c == nil || c.Enabled == nil {
+		// the operands are enabled by default on the enabled Windows GPU nodes
+		return true
+	}
+	return *c.Enabled
+}
+
+// NodeFeatureDiscoverySpec defines the deployment of Node Feature Discovery (NFD) by the operator. The operator
+// discovers the GPU nodes from the NFD labels, so it waits for the labels when NFD is not deployed in the cluster.
+// When managed, the operator deploys the NFD master and workers itself, with the feature sources labeling the GPUs,
+// the kernel, the OS and the CPU security features only. The NFD CustomResourceDefinitions must be installed.
+type NodeFeatureDiscoverySpec struct {
+	// Managed indicates if the operator deploys and owns Node Feature Discovery, rather than depending on an
+	// external deployment of it
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Deploy Node Feature Discovery"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Managed *bool `json:"managed,omitempty"`
+
+	// Node Feature Discovery image repository
+	// +kubebuilder:validation:Optional
+	Repository string `json:"repository,omitempty"`
+
+	// Node Feature Discovery image name
+	// +kubebuilder:validation:Pattern=[a-zA-Z0-9\-]+
+	Image string `json:"image,omitempty"`
+
+	// Node Feature Discovery image tag
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+
+	// Image pull policy
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Image Pull Policy"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:imagePullPolicy"
+	ImagePullPolicy string `json:"imagePullPolicy,omitempty"`
+
+	// Image pull secrets
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Image pull secrets"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+
+	// Optional: Define resources requests and limits for the NFD master and worker pods
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Resource Requirements"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:resourceRequirements"
+	Resources *ResourceRequirements `json:"resources,omitempty"`
+}
+
+// IsManaged returns true if the operator deploys Node Feature Discovery
+func (n *NodeFeatureDiscoverySpec) IsManaged() bool {
+	if n == nil || n.Managed == nil {
+		// Node Feature Discovery is deployed externally by default
+		return false
+	}
+	return *n.Managed
+}
+
+// SafeModeSpec defines the safe mode of the operator. When the pods of the latest revision of the operand
+// DaemonSets, that is the revision rolled out by the operator, are crash looping on a share of the GPU nodes
+// reaching the threshold, the operator enters the safe mode: it stops creating and updating the operand
+// DaemonSets and the driver upgrades, and reports the SafeMode condition and a warning event on the
+// ClusterPolicy. The operator leaves the safe mode once the nvidia.com/gpu-operator.safe-mode.acknowledged
+// annotation is set on the ClusterPolicy; the acknowledged revisions do not enter the safe mode again.
+type SafeModeSpec struct {
+	// Enabled indicates if the operator enters the safe mode on operand crash loops
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable the safe mode"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// CrashLoopThreshold is the percentage of GPU nodes with crash looping operand pods entering the safe mode
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=30
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Crash loop threshold (%)"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	CrashLoopThreshold *int32 `json:"crashLoopThreshold,omitempty"`
+}
+
+// IsEnabled returns true if the safe mode is enabled
+func (s *SafeModeSpec) IsEnabled() bool {
+	if s == nil || s.Enabled == nil {
+		// safe mode is disabled by default
+		return false
+	}
+	return *s.Enabled
+}
+
+// GetCrashLoopThreshold returns the percentage of GPU nodes with crash looping operand pods entering the safe mode
+func (s *SafeModeSpec) GetCrashLoopThreshold() int32 {
+	if s == nil || s.CrashLoopThreshold == nil {
+		return 30
+	}
+	return *s.CrashLoopThreshold
+}
+
+// TeardownSpec defines the staged removal of the operands when the ClusterPolicy is deleted. The operator holds
+// the ClusterPolicy with a finalizer and, optionally after evicting the GPU workloads, removes the device plugin
+// and the other operands using the GPUs, then the container toolkit, then the driver, and verifies that the
+// NVIDIA kernel modules are unloaded from the GPU nodes before releasing it. The operator must keep running until
+// the ClusterPolicy is gone.
+type TeardownSpec struct {
+	// Enabled indicates if the operands are removed in stages when the ClusterPolicy is deleted
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable the staged teardown"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// EvictGPUWorkloads indicates if the GPU workloads are evicted from the GPU nodes before the operands are removed
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Evict the GPU workloads"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	EvictGPUWorkloads *bool `json:"evictGPUWorkloads,omitempty"`
+
+	// PhaseTimeoutSeconds is the time given to each phase to complete, e.g. to the GPU workloads to be evicted
+	// or to the NVIDIA kernel modules to be unloaded, before the teardown moves on to the next phase
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=30
+	// +kubebuilder:default=300
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Phase timeout (seconds)"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	PhaseTimeoutSeconds int `json:"phaseTimeoutSeconds,omitempty"`
+}
+
+// IsEnabled returns true if the operands are removed in stages when the ClusterPolicy is deleted
+func (t *TeardownSpec) IsEnabled() bool {
+	if t == nil || t.Enabled == nil {
+		// the staged teardown is disabled by default
+		return false
+	}
+	return *t.Enabled
+}
+
+// IsEvictGPUWorkloadsEnabled returns true if the GPU workloads are evicted before the operands are removed
+func (t *TeardownSpec) IsEvictGPUWorkloadsEnabled() bool {
+	if t == nil || t.EvictGPUWorkloads == nil {
+		return false
+	}
+	return *t.EvictGPUWorkloads
+}
+
+// GetPhaseTimeout returns the time given to each phase of the teardown to complete
+func (t *TeardownSpec) GetPhaseTimeout() time.Duration {
+	if t == nil || t.PhaseTimeoutSeconds == 0 {
+		return 300 * time.Second
+	}
+	return time.Duration(t.PhaseTimeoutSeconds) * time.Second
+}
+
+// DevelSpec defines the settings of development clusters without GPU hardware
+type DevelSpec struct {
+	// MockGPUs defines the mock GPUs advertised on the nodes without GPU
+	// +kubebuilder:validation:Optional
+	MockGPUs *MockGPUsSpec `json:"mockGPUs,omitempty"`
+}
+
+// MockGPUsSpec defines the mock GPUs advertised on the nodes without GPU, so that the scheduling and quota
+// logic of GPU applications can be developed on clusters without GPU hardware, e.g. kind clusters. A fake
+// device plugin advertises the configured nvidia.com/gpu capacity on the selected nodes and sets the GPU
+// labels GPU Feature Discovery sets on GPU nodes, along with the nvidia.com/gpu.mock=true label marking the
+// nodes as mock. The GPUs cannot be used by the pods scheduled onto them. All other operands are disabled
+// while the mock GPUs are enabled.
+type MockGPUsSpec struct {
+	// Enabled indicates if mock GPUs are advertised on the nodes without GPU
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable the mock GPUs"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// NodeSelector selects the nodes advertising mock GPUs, among the nodes without GPU. All nodes without
+	// GPU advertise mock GPUs if not set.
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Count is the number of mock GPUs advertised by each node
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Mock GPUs per node"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	Count int `json:"count,omitempty"`
+
+	// Product is the GPU product advertised in the nvidia.com/gpu.product label of the nodes
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=NVIDIA-Mock-GPU
+	Product string `json:"product,omitempty"`
+
+	// MemoryMiB is the GPU memory advertised in the nvidia.com/gpu.memory label of the nodes, in MiB
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=16384
+	MemoryMiB int `json:"memoryMiB,omitempty"`
+}
+
+// IsMockGPUsEnabled returns true if mock GPUs are advertised on the nodes without GPU
+func (d *DevelSpec) IsMockGPUsEnabled() bool {
+	if d == nil {
+		return false
+	}
+	return d.MockGPUs.IsEnabled()
+}
+
+// GetMockGPUs returns the mock GPUs spec, nil if not set
+func (d *DevelSpec) GetMockGPUs() *MockGPUsSpec {
+	if d == nil {
+		return nil
+	}
+	return d.MockGPUs
+}
+
+// IsEnabled returns true if mock GPUs are advertised on the nodes without GPU
+func (m *MockGPUsSpec) IsEnabled() bool {
+	if m == nil || m.Enabled == nil {
+		// mock GPUs are disabled by default
+		return false
+	}
+	return *m.Enabled
+}
+
+// GetCount returns the number of mock GPUs advertised by each node
+func (m *MockGPUsSpec) GetCount() int {
+	if m == nil || m.Count == 0 {
+		return 1
+	}
+	return m.Count
+}
+
+// GetProduct returns the GPU product advertised by the nodes with mock GPUs
+func (m *MockGPUsSpec) GetProduct() string {
+	if m == nil || m.Product == "" {
+		return "NVIDIA-Mock-GPU"
+	}
+	return m.Product
+}
+
+// GetMemoryMiB returns the GPU memory, in MiB, advertised by the nodes with mock GPUs
+func (m *MockGPUsSpec) GetMemoryMiB() int {
+	if m == nil || m.MemoryMiB == 0 {
+		return 16384
+	}
+	return m.MemoryMiB
+}
+
+// ContainerLogSpec defines the verbosity and the size limits of the log output of an operand container
+type ContainerLogSpec struct {
+	// Level is the verbosity of the log output of the container, propagated to the container through the
+	// LOG_LEVEL env
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=error;warning;info;debug
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Log level"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Level string `json:"level,omitempty"`
+
+	// Rotation caps the log output the container writes to the container logs of the node, the further output
+	// being written to log files rotated in the container
+	// +kubebuilder:validation:Optional
+	Rotation *LogRotationSpec `json:"rotation,omitempty"`
+}
+
+// LogRotationSpec defines the size limits of the log output of an operand container. The container command is
+// wrapped by a POSIX shell script writing the output to log files in an emptyDir volume, rotated once they reach
+// MaxSizeMB, and forwarding the first MaxSizeMB of the output of each container start to the container logs. The
+// container image must provide /bin/sh, mkfifo and mv.
+type LogRotationSpec struct {
+	// Enabled indicates if the log output of the container is capped and rotated
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable the log rotation"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// MaxSizeMB is the size of the log output forwarded to the container logs, and of each rotated log file
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=10
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Maximum log size (MB)"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MaxSizeMB int `json:"maxSizeMB,omitempty"`
+
+	// MaxFiles is the number of rotated log files kept next to the current log file
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=3
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Maximum rotated log files"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MaxFiles int `json:"maxFiles,omitempty"`
+}
+
+// IsRotationEnabled returns true if the log output of the container is capped and rotated
+func (l *ContainerLogSpec) IsRotationEnabled() bool {
+	if l == nil || l.Rotation == nil || l.Rotation.Enabled == nil {
+		return false
+	}
+	return *l.Rotation.Enabled
+}
+
+// GetMaxSizeBytes returns the size of the log output forwarded to the container logs, and of each rotated log file
+func (r *LogRotationSpec) GetMaxSizeBytes() int64 {
+	if r == nil || r.MaxSizeMB == 0 {
+		return 10 << 20
+	}
+	return int64(r.MaxSizeMB) << 20
+}
+
+// GetMaxFiles returns the number of rotated log files kept next to the current log file
+func (r *LogRotationSpec) GetMaxFiles() int {
+	if r == nil || r.MaxFiles == 0 {
+		return 3
+	}
+	return r.MaxFiles
+}
+
+// MOFEDExternalGateName is the name of the external gate holding the driver until the MOFED driver deployed by
+// the NVIDIA Network Operator is ready, added when GPUDirect RDMA is enabled without a MOFED driver installed on
+// the hosts. An external gate of the same name replaces it.
+const MOFEDExternalGateName = "mofed"
+
+// ExternalGateSpec defines a prerequisite managed outside of the operator. The operands of the gated states are
+// not deployed until either the resource reports the condition, or all GPU nodes match the node selector.
+// +kubebuilder:validation:XValidation:rule="has(self.resource) != has(self.nodeSelector)",message="exactly one of resource and nodeSelector must be set"
+type ExternalGateSpec struct {
+	// Name identifies the external gate in the status of the ClusterPolicy
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Enabled indicates if the external gate holds the gated states
+	// +kubebuilder:validation:Optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// States lists the states whose operands are deployed once the external gate is open, e.g. state-driver
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	States []string `json:"states"`
+
+	// Resource references an object which must exist, and report the condition when set, for the gate to open.
+	// The operator must be allowed to get the object.
+	// +kubebuilder:validation:Optional
+	Resource *ExternalGateResourceReference `json:"resource,omitempty"`
+
+	// NodeSelector selects the labels all GPU nodes must have for the gate to open
+	// +kubebuilder:validation:Optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// TimeoutSeconds is the time after which a closed gate stops holding the gated states, which are then
+	// deployed and a warning event is reported. The gate holds the states until it opens when unset.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// TenantNamespaceSpec defines the namespace of a tenant, in which the device plugin and DCGM Exporter of the GPU
+// nodes of the tenant are deployed. The namespace must exist, along with the image pull secrets and the device
+// plugin configuration ConfigMap referenced by the ClusterPolicy.
+type TenantNamespaceSpec struct {
+	// Name of the namespace of the tenant
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// NodeSelector selects the GPU nodes of the tenant, a GPU node cannot belong to several tenants
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinProperties=1
+	NodeSelector map[string]string `json:"nodeSelector"`
+}
+
+// ConsumerGPUPolicy is the handling of the GPU nodes with consumer GPUs
+// +kubebuilder:validation:Enum=skip;deploy;block
+type ConsumerGPUPolicy string
+
+const (
+	// ConsumerGPUPolicySkip labels the GPU nodes with consumer GPUs, and does not deploy the operands to them
+	ConsumerGPUPolicySkip ConsumerGPUPolicy = "skip"
+	// ConsumerGPUPolicyDeploy deploys the operands to the GPU nodes with consumer GPUs, except the operands
+	// relying on datacenter features: the MIG Manager, the GPU health check and the NVLink fabric manager. A
+	// warning event is reported on the nodes.
+	ConsumerGPUPolicyDeploy ConsumerGPUPolicy = "deploy"
+	// ConsumerGPUPolicyBlock fails the reconciliation of the ClusterPolicy as long as GPU nodes with consumer
+	// GPUs are found
+	ConsumerGPUPolicyBlock ConsumerGPUPolicy = "block"
+)
+
+// ConsumerGPUsSpec defines the handling of the GPU nodes with consumer GPUs. The consumer GPUs are detected from
+// their PCI device IDs, labeled by NFD as nvidia.com/gpu.pci-device-<device ID>, or from the product name
+// labeled by GPU Feature Discovery.
+type ConsumerGPUsSpec struct {
+	// Policy is the handling of the GPU nodes with consumer GPUs, deploy by default
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=deploy
+	Policy ConsumerGPUPolicy `json:"policy,omitempty"`
+
+	// DeviceIDs lists PCI device IDs of consumer GPUs, in lowercase hexadecimal, detected in addition to the
+	// device IDs known by the operator
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:items:Pattern=`^[0-9a-f]{4}$`
+	DeviceIDs []string `json:"deviceIDs,omitempty"`
+}
+
+// GetPolicy returns the handling of the GPU nodes with consumer GPUs
+func (c *ConsumerGPUsSpec) GetPolicy() ConsumerGPUPolicy {
+	if c == nil || c.Policy == "" {
+		return ConsumerGPUPolicyDeploy
+	}
+	return c.Policy
+}
+
+// GetDeviceIDs returns the additional PCI device IDs of consumer GPUs
+func (c *ConsumerGPUsSpec) GetDeviceIDs() []string {
+	if c == nil {
+		return nil
+	}
+	return c.DeviceIDs
+}
+
+// ProxySpec defines the cluster-wide proxy and trust configuration of the operands downloading or building
+// software, also used by the operator for its registry requests. The proxy environment variables set on a
+// component through its env take precedence, and the download cache, when enabled, takes precedence over the
+// proxy of the driver.
+type ProxySpec struct {
+	// HTTPProxy is the URL of the proxy of the HTTP requests, set as HTTP_PROXY and http_proxy
+	// +kubebuilder:validation:Optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the URL of the proxy of the HTTPS requests, set as HTTPS_PROXY and https_proxy
+	// +kubebuilder:validation:Optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy is the comma-separated list of hosts and domains not proxied, set as NO_PROXY and no_proxy
+	// +kubebuilder:validation:Optional
+	NoProxy string `json:"noProxy,omitempty"`
+
+	// TrustedCA references the ConfigMap of the operator namespace holding the CA bundle trusted by the
+	// operands, e.g. the CA of a TLS intercepting proxy
+	// +kubebuilder:validation:Optional
+	TrustedCA *TrustedCASpec `json:"trustedCA,omitempty"`
+}
+
+// TrustedCASpec references a CA bundle stored in a ConfigMap
+type TrustedCASpec struct {
+	// Name of the ConfigMap
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Key of the PEM encoded CA bundle in the ConfigMap, ca-bundle.crt by default
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=ca-bundle.crt
+	Key string `json:"key,omitempty"`
+}
+
+// GetTrustedCA returns the trusted CA bundle reference, nil if none is set
+func (p *ProxySpec) GetTrustedCA() *TrustedCASpec {
+	if p == nil || p.TrustedCA == nil || p.TrustedCA.Name == "" {
+		return nil
+	}
+	return p.TrustedCA
+}
+
+// HasProxyEnv returns true if any proxy environment variable is set
+func (p *ProxySpec) HasProxyEnv() bool {
+	return p != nil && (p.HTTPProxy != "" || p.HTTPSProxy != "" || p.NoProxy != "")
+}
+
+// GetKey returns the key of the CA bundle in the ConfigMap
+func (t *TrustedCASpec) GetKey() string {
+	if t.Key == "" {
+		return "ca-bundle.crt"
+	}
+	return t.Key
+}
+
+// ReadinessHysteresisSpec defines how long a readiness change of the operands must persist before it is
+// reported, so that a restarting operand pod does not make the reported readiness oscillate. The readiness
+// first observed for the ClusterPolicy or a node is reported immediately.
+type ReadinessHysteresisSpec struct {
+	// NotReadySeconds is the duration, in seconds, the operands must stay not ready before they are reported
+	// not ready. Not ready operands are reported immediately when 0.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	NotReadySeconds int32 `json:"notReadySeconds,omitempty"`
+
+	// ReadySeconds is the duration, in seconds, the operands reported not ready must stay ready before they
+	// are reported ready again. Ready operands are reported immediately when 0.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	ReadySeconds int32 `json:"readySeconds,omitempty"`
+}
+
+// GetDelay returns how long the operands must be observed ready, or not ready, before it is reported
+func (r *ReadinessHysteresisSpec) GetDelay(ready bool) time.Duration {
+	if r == nil {
+		return 0
+	}
+	if ready {
+		return time.Duration(r.ReadySeconds) * time.Second
+	}
+	return time.Duration(r.NotReadySeconds) * time.Second
+}
+
+// AutoscalingHintsSpec defines the publishing of the extended resources the NVIDIA Device Plugin is expected to
+// advertise on the GPU nodes, computed from their GPUs and the MIG and sharing configuration. The resources are
+// published in the nvidia.com/gpu.autoscaling-allocatable node annotation, and for each instance type in the
+// nvidia-gpu-autoscaling-hints ConfigMap of the operator namespace, which keeps the instance types without
+// nodes so that node autoscalers can scale their node groups from zero.
+type AutoscalingHintsSpec struct {
+	// Enabled enables the publishing of the autoscaling hints
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable autoscaling hints"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// InstanceTypeLabel is the node label holding the instance type of the nodes
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=node.kubernetes.io/instance-type
+	InstanceTypeLabel string `json:"instanceTypeLabel,omitempty"`
+}
+
+// IsEnabled returns true if the autoscaling hints are published
+func (a *AutoscalingHintsSpec) IsEnabled() bool {
+	if a == nil || a.Enabled == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *a.Enabled
+}
+
+// GetInstanceTypeLabel returns the node label holding the instance type of the nodes
+func (a *AutoscalingHintsSpec) GetInstanceTypeLabel() string {
+	if a == nil || a.InstanceTypeLabel == "" {
+		return corev1.LabelInstanceTypeStable
+	}
+	return a.InstanceTypeLabel
+}
+
+// NodeLifecycleEventType is the type of a lifecycle event of a GPU node
+// +kubebuilder:validation:Enum=GPUStackReady;DriverUpgraded;ValidationFailed;GPUQuarantined
+type NodeLifecycleEventType string
+
+const (
+	// GPUStackReadyEvent is sent when the container toolkit and the device plugin of a node are ready and the
+	// node passes the operator validations
+	GPUStackReadyEvent NodeLifecycleEventType = "GPUStackReady"
+	// DriverUpgradedEvent is sent when the driver version installed on a node changed
+	DriverUpgradedEvent NodeLifecycleEventType = "DriverUpgraded"
+	// ValidationFailedEvent is sent when a node no longer passes the operator validations outside of a driver
+	// upgrade
+	ValidationFailedEvent NodeLifecycleEventType = "ValidationFailed"
+	// GPUQuarantinedEvent is sent when the device plugin of a node is paused by the GPU health check after a
+	// critical XID error
+	GPUQuarantinedEvent NodeLifecycleEventType = "GPUQuarantined"
+)
+
+// NotificationsSpec defines the sinks the lifecycle events of the GPU nodes are posted to as JSON
+type NotificationsSpec struct {
+	// Sinks lists the HTTP endpoints notified of the lifecycle events
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	Sinks []NotificationSinkSpec `json:"sinks,omitempty"`
+}
+
+// NotificationSinkSpec defines an HTTP endpoint the lifecycle events are posted to
+type NotificationSinkSpec struct {
+	// Name of the sink
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// URL the events are posted to
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// Events lists the types of the events posted to the sink, all the events are posted when empty
+	// +kubebuilder:validation:Optional
+	Events []NodeLifecycleEventType `json:"events,omitempty"`
+
+	// HeadersSecretName is the name of a Secret of the operator namespace whose keys and values are sent as
+	// HTTP headers, e.g. an Authorization header
+	// +kubebuilder:validation:Optional
+	HeadersSecretName string `json:"headersSecretName,omitempty"`
+
+	// TimeoutSeconds bounds each post to the sink, defaults to 10
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// GetSinks returns the sinks of the lifecycle events, nil if none is set
+func (n *NotificationsSpec) GetSinks() []NotificationSinkSpec {
+	if n == nil {
+		return nil
+	}
+	return n.Sinks
+}
+
+// Accepts returns true if the events of the given type are posted to the sink
+func (s *NotificationSinkSpec) Accepts(eventType NodeLifecycleEventType) bool {
+	return len(s.Events) == 0 || slices.Contains(s.Events, eventType)
+}
+
+// GetTimeout returns the duration bounding each post to the sink
+func (s *NotificationSinkSpec) GetTimeout() time.Duration {
+	if s.TimeoutSeconds <= 0 {
+		return 10 * time.Second
+	}
+	return time.Duration(s.TimeoutSeconds) * time.Second
+}
+
+// ExternalGateResourceReference references the object of an external gate
+type ExternalGateResourceReference struct {
+	// APIVersion of the object, e.g. apps/v1
+	// +kubebuilder:validation:Required
+	APIVersion string `json:"apiVersion"`
+	// Kind of the object, e.g. DaemonSet
+	// +kubebuilder:validation:Required
+	Kind string `json:"kind"`
+	// Namespace of the object, unset for cluster-scoped objects
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+	// Name of the object
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// ConditionType is the type of the condition the object must report with the True status in
+	// status.conditions. The object only needs to exist when unset.
+	// +kubebuilder:validation:Optional
+	ConditionType string `json:"conditionType,omitempty"`
+}
+
+// IsEnabled returns true if the external gate holds the gated states
+func (g *ExternalGateSpec) IsEnabled() bool {
+	if g.Enabled == nil {
+		// external gates are enabled by default
+		return true
+	}
+	return *g.Enabled
+}
+
+// GetTimeout returns the time after which a closed gate stops holding the gated states, 0 when it holds them
+// until it opens
+func (g *ExternalGateSpec) GetTimeout() time.Duration {
+	if g.TimeoutSeconds == nil {
+		return 0
+	}
+	return time.Duration(*g.TimeoutSeconds) * time.Second
+}
+
+// GetExternalGates returns the external gates of the ClusterPolicy, along with the gate holding the driver until
+// the MOFED driver is ready when GPUDirect RDMA is enabled without a MOFED driver installed on the hosts
+func (c *ClusterPolicySpec) GetExternalGates() []ExternalGateSpec {
+	gates := c.ExternalGates
+	rdma := c.Driver.GPUDirectRDMA
+	if rdma == nil || !rdma.IsEnabled() || rdma.IsHostMOFED() {
+		return gates
+	}
+	for _, gate := range gates {
+		if gate.Name == MOFEDExternalGateName {
+			return gates
+		}
+	}
+	mofedGate := ExternalGateSpec{
+		Name:   MOFEDExternalGateName,
+		States: []string{"state-driver"},
+		NodeSelector: &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key:      "network.nvidia.com/operator.mofed.wait",
+				Operator: metav1.LabelSelectorOpNotIn,
+				Values:   []string{"true"},
+			}},
+		},
+		// the driver containers wait for the MOFED driver on their node, the gate only avoids deploying them
+		// while the Network Operator deploys the MOFED driver
+		TimeoutSeconds: ptr.To(int32(600)),
+	}
+	return append(slices.Clone(gates), mofedGate)
+}
+
+// DrainFailurePolicy defines how a node is handled when it cannot be drained during a driver upgrade
+type DrainFailurePolicy string
+
+const (
+	// DrainFailurePolicyFail moves the node to the upgrade-failed state
+	DrainFailurePolicyFail DrainFailurePolicy = "Fail"
+	// DrainFailurePolicyRebootRequired proceeds with the driver upgrade without draining the node,
+	// and labels the node as requiring a reboot for the new driver to be loaded
+	DrainFailurePolicyRebootRequired DrainFailurePolicy = "RebootRequired"
+)
+
+// DriverUpgradeType is the type of the driver upgraded on a node
+type DriverUpgradeType string
+
+const (
+	// DriverUpgradeTypeGPU is the data center driver of the nodes without vGPU
+	DriverUpgradeTypeGPU DriverUpgradeType = "gpu"
+	// DriverUpgradeTypeVGPU is the vGPU guest driver of the nodes labeled with nvidia.com/vgpu.present=true
+	DriverUpgradeTypeVGPU DriverUpgradeType = "vgpu"
+	// DriverUpgradeTypeVGPUHostManager is the vGPU manager of the nodes configured for vm-vgpu workloads
+	DriverUpgradeTypeVGPUHostManager DriverUpgradeType = "vgpu-host-manager"
+)
+
+// DriverUpgradePoliciesSpec holds the auto-upgrade settings of each driver type, so that the upgrades of the
+// vGPU host manager, which evict the VMs of the nodes to be migrated, do not impose their settings on the
+// upgrades of the GPU driver, which only drain the GPU pods
+type DriverUpgradePoliciesSpec struct {
+	// GPU overrides the upgradePolicy of the driver on the nodes without vGPU
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="GPU driver upgrade policy"
+	GPU *upgrade_v1alpha1.DriverUpgradePolicySpec `json:"gpu,omitempty"`
+
+	// VGPU overrides the upgradePolicy of the driver on the nodes running the vGPU guest driver
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="vGPU guest driver upgrade policy"
+	VGPU *upgrade_v1alpha1.DriverUpgradePolicySpec `json:"vgpu,omitempty"`
+
+	// VGPUHostManager sets the auto-upgrade settings of the vGPU manager. The vGPU manager upgrades are only
+	// managed when set, and do not default to the upgradePolicy of the driver.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="vGPU host manager upgrade policy"
+	VGPUHostManager *upgrade_v1alpha1.DriverUpgradePolicySpec `json:"vgpuHostManager,omitempty"`
+}
+
+// DriverDrainPolicySpec defines how workloads are evicted from a node before its driver is upgraded.
+// Pods are evicted through the eviction API, so that PodDisruptionBudgets are honored.
+type DriverDrainPolicySpec struct {
+	// GracePeriodSeconds overrides the termination grace period of the evicted pods.
+	// The grace period of each pod is used if not specified.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Grace Period Seconds"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+
+	// SkipNamespaces lists the namespaces whose pods are not evicted when a node is drained
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Skip Namespaces"
+	SkipNamespaces []string `json:"skipNamespaces,omitempty"`
+
+	// FailurePolicy indicates how a node is handled when it cannot be drained within the drain timeout,
+	// e.g. because a PodDisruptionBudget does not allow the eviction of its pods
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Fail;RebootRequired
+	// +kubebuilder:default=Fail
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Failure Policy"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:select:Fail,urn:alm:descriptor:com.tectonic.ui:select:RebootRequired"
+	FailurePolicy DrainFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// GetGracePeriodSeconds returns the grace period of the evicted pods, or -1 to use the grace period of each pod
+func (d *DriverDrainPolicySpec) GetGracePeriodSeconds() int {
+	if d == nil || d.GracePeriodSeconds == nil {
+		return -1
+	}
+	return int(*d.GracePeriodSeconds)
+}
+
+// GetFailurePolicy returns how a node is handled when it cannot be drained
+func (d *DriverDrainPolicySpec) GetFailurePolicy() DrainFailurePolicy {
+	if d == nil || d.FailurePolicy == "" {
+		return DrainFailurePolicyFail
+	}
+	return d.FailurePolicy
+}
+
+// FleetReportSpec defines the periodic GPU fleet summary report. Each report is written to a
+// ConfigMap in the operator namespace, so that it can be consumed by downstream automation.
+type FleetReportSpec struct {
+	// Enabled indicates if the fleet report is generated
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable the GPU fleet report"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Interval is the duration between two reports, defaults to 24h
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Fleet report interval"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// HistoryLimit is the number of reports retained, older reports are deleted. Defaults to 7.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Number of fleet reports retained"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:podCount"
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+}
+
+// UsageAccountingFormat is the format of the usage records written to the usage report ConfigMaps
+type UsageAccountingFormat string
+
+const (
+	// UsageAccountingFormatJSON writes the usage records as a JSON document
+	UsageAccountingFormatJSON UsageAccountingFormat = "json"
+	// UsageAccountingFormatCSV writes the usage records as CSV, one row per record
+	UsageAccountingFormatCSV UsageAccountingFormat = "csv"
+)
+
+// UsageAccountingSpec defines the periodic export of the GPU usage of the pods, for chargeback. Each report
+// records, per pod and GPU resource, the devices allocated to the pod, including the MIG devices and the shares
+// of time-sliced or MPS GPUs, their equivalent in full GPUs and the GPU-seconds over the report period, with the
+// GPU utilization reported by DCGM Exporter when it is deployed. The reports are written to ConfigMaps in the
+// operator namespace, and the GPUs allocated per namespace are exported as operator metrics.
+type UsageAccountingSpec struct {
+	// Enabled indicates if the usage reports are generated
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable the GPU usage accounting"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Interval is the period covered by each report, defaults to 1h
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Usage report interval"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// HistoryLimit is the number of reports retained, older reports are deleted. Defaults to 24.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Number of usage reports retained"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:podCount"
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+
+	// Format is the format of the usage records, defaults to json
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=json;csv
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Usage report format"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:select:json,urn:alm:descriptor:com.tectonic.ui:select:csv"
+	Format UsageAccountingFormat `json:"format,omitempty"`
+}
+
+// EnvVar represents an environment variable present in a Container.
+type EnvVar struct {
+	// Name of the environment variable.
+	Name string `json:"name"`
+
+	// Value of the environment variable.
+	Value string `json:"value,omitempty"`
+}
+
+// ResourceRequirements describes the compute resource requirements.
+type ResourceRequirements struct {
+	// Limits describes the maximum amount of compute resources allowed.
+	// More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+	// +optional
+	Limits corev1.ResourceList `json:"limits,omitempty"`
+	// Requests describes the minimum amount of compute resources required.
+	// If Requests is omitted for a container, it defaults to Limits if that is explicitly specified,
+	// otherwise to an implementation-defined value. Requests cannot exceed Limits.
+	// More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+	// +optional
+	Requests corev1.ResourceList `json:"requests,omitempty"`
+}
+
+// SandboxWorkloadsSpec describes configuration for handling sandbox workloads (i.e. Virtual Machines)
+type SandboxWorkloadsSpec struct {
+	// Enabled indicates if the GPU Operator should manage additional operands required
+	// for sandbox workloads (i.e. VFIO Manager, vGPU Manager, and additional device plugins)
+	Enabled *bool `json:"enabled,omitempty"`
+	// DefaultWorkload indicates the default GPU workload type to configure
+	// worker nodes in the cluster for
+	// +kubebuilder:validation:Enum=container;vm-passthrough;vm-vgpu
+	// +kubebuilder:default=container
+	DefaultWorkload string `json:"defaultWorkload,omitempty"`
+	// Mode indicates the sandbox mode. Accepted values are "kubevirt"
+	// and "kata". The default value is "kubevirt". The mode of a node is
+	// overridden with the nvidia.com/gpu.sandbox.mode label.
+	// +kubebuilder:validation:Enum=kubevirt;kata
+	// +kubebuilder:default=kubevirt
+	Mode string `json:"mode,omitempty"`
+	// Kata configures the Kata Containers RuntimeClasses when Mode is "kata"
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Kata Containers configuration"
+	Kata *KataRuntimeSpec `json:"kata,omitempty"`
+}
+
+// KataRuntimeSpec defines how the Kata Containers RuntimeClasses are managed. The Kata artifacts
+// (hypervisor, guest kernel and image, runtime shims) are installed on the nodes by a separate
+// DaemonSet, e.g. kata-deploy, whose readiness is verified before the RuntimeClasses are created.
+type KataRuntimeSpec struct {
+	// ManageRuntimeClasses indicates if the operator creates and manages the Kata RuntimeClasses.
+	// When disabled, the RuntimeClasses are expected to pre-exist and their absence is reported
+	// in the ClusterPolicy conditions.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Manage Kata RuntimeClasses"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	ManageRuntimeClasses *bool `json:"manageRuntimeClasses,omitempty"`
+
+	// RuntimeClasses lists the Kata RuntimeClasses, defaults to kata-qemu-nvidia-gpu,
+	// kata-qemu-nvidia-gpu-snp and kata-qemu-nvidia-gpu-tdx
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Kata RuntimeClasses"
+	RuntimeClasses []KataRuntimeClassSpec `json:"runtimeClasses,omitempty"`
+
+	// ArtifactsDaemonSet references the DaemonSet installing the Kata artifacts on the nodes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Kata artifacts DaemonSet"
+	ArtifactsDaemonSet *KataArtifactsDaemonSetSpec `json:"artifactsDaemonSet,omitempty"`
+}
+
+// KataRuntimeClassSpec defines a Kata RuntimeClass
+type KataRuntimeClassSpec struct {
+	// Name of the RuntimeClass
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Handler of the RuntimeClass as configured in the container runtime, defaults to the name
+	// +kubebuilder:validation:Optional
+	Handler string `json:"handler,omitempty"`
+
+	// NodeSelector restricts pods of the RuntimeClass to the nodes with the Kata artifacts installed
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// KataArtifactsDaemonSetSpec references the DaemonSet installing the Kata artifacts on the nodes
+type KataArtifactsDaemonSetSpec struct {
+	// Name of the DaemonSet, defaults to kata-deploy
+	// +kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
+
+	// Namespace of the DaemonSet, defaults to kata-system
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// PSPSpec describes configuration for PodSecurityPolicies to apply for all Pods
+type PSPSpec struct {
+	// Enabled indicates if PodSecurityPolicies needs to be enabled for all Pods
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// PSASpec describes configuration for PodSecurityAdmission to apply for all Pods
+type PSASpec struct {
+	// Enabled indicates if PodSecurityAdmission configuration needs to be enabled for all Pods
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// ManageNamespaceLabels indicates if the operator sets the pod-security.kubernetes.io/enforce label of
+	// the operator namespace to privileged, which the operands require, so that a restrictive cluster-wide
+	// PodSecurity default does not reject the operand pods. The audit and warn labels are left unchanged.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Manage namespace PodSecurity labels"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	ManageNamespaceLabels *bool `json:"manageNamespaceLabels,omitempty"`
+}
+
+// DaemonsetsSpec indicates common configuration for all Daemonsets managed by GPU Operator
+type DaemonsetsSpec struct {
+	// Optional: Map of string keys and values that can be used to organize and categorize
+	// (scope and select) objects. May match selectors of replication controllers
+	// and services.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Optional: Annotations is an unstructured key value map stored with a resource that may be
+	// set by external tools to store and retrieve arbitrary metadata. They are not
+	// queryable and should be preserved when modifying objects.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Optional: Set tolerations
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Tolerations"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:io.kubernetes:Tolerations"
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="PriorityClassName"
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// SchedulerName is the name of the scheduler placing the operand pods, defaults to the
+	// default scheduler. It can be overridden per operand.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="SchedulerName"
+	SchedulerName string `json:"schedulerName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=RollingUpdate
+	// +kubebuilder:validation:Enum=RollingUpdate;OnDelete
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="UpdateStrategy for all Daemonsets"
+	UpdateStrategy string `json:"updateStrategy,omitempty"`
+
+	// Optional: Configuration for rolling update of all DaemonSet pods
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Rolling update configuration for all DaemonSet pods"
+	RollingUpdate *RollingUpdateSpec `json:"rollingUpdate,omitempty"`
+
+	// Optional: Set pod-level security context for all DaemonSet pods (applies as defaults to all containers)
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// ConfigMapUpdateStrategy is how the ConfigMaps rendered by the operator for the operands are updated.
+	// InPlace updates the ConfigMaps, Immutable creates an immutable ConfigMap named after the digest of its
+	// content on every change and points the operands to it, deleting the previous ConfigMaps once no pod
+	// mounts them. Defaults to InPlace.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=InPlace;Immutable
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="ConfigMap update strategy of the operands"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:select:InPlace,urn:alm:descriptor:com.tectonic.ui:select:Immutable"
+	ConfigMapUpdateStrategy ConfigMapUpdateStrategy `json:"configMapUpdateStrategy,omitempty"`
+
+	// StartupTaints defines the tolerations of the startup taints set on new nodes by node autoscalers, e.g.
+	// Karpenter, added to all operands, and the removal of the bootstrap taints of the nodes passing validation
+	// +kubebuilder:validation:Optional
+	StartupTaints *StartupTaintsSpec `json:"startupTaints,omitempty"`
+}
+
+// ConfigMapUpdateStrategy is the update strategy of the ConfigMaps rendered for the operands
+type ConfigMapUpdateStrategy string
+
+const (
+	// ConfigMapUpdateInPlace updates the rendered ConfigMaps in place
+	ConfigMapUpdateInPlace ConfigMapUpdateStrategy = "InPlace"
+	// ConfigMapUpdateImmutable renders an immutable ConfigMap per content, named after the content digest
+	ConfigMapUpdateImmutable ConfigMapUpdateStrategy = "Immutable"
+)
+
+// StartupTaintPolicy selects the startup taints tolerated by all operands
+type StartupTaintPolicy string
+
+const (
+	// StartupTaintPolicyNone tolerates the bootstrap taints only
+	StartupTaintPolicyNone StartupTaintPolicy = "None"
+	// StartupTaintPolicyAutoscaler tolerates the startup taints set by the node autoscalers
+	StartupTaintPolicyAutoscaler StartupTaintPolicy = "Autoscaler"
+)
+
+// StartupTaintsSpec defines the handling of the taints set on the GPU nodes until they are ready. Node
+// autoscalers bring nodes up with startup taints keeping the workloads away until the nodes are set up, the
+// operands tolerate them so that the GPU stack is deployed on the nodes meanwhile.
+type StartupTaintsSpec struct {
+	// Policy selects the startup taints tolerated by all operands. Autoscaler tolerates nvidia.com/gpu:NoSchedule,
+	// karpenter.sh/unregistered:NoExecute and node.cloudprovider.kubernetes.io/uninitialized:NoSchedule, None
+	// tolerates the bootstrap taints only.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=None;Autoscaler
+	// +kubebuilder:default=Autoscaler
+	Policy StartupTaintPolicy `json:"policy,omitempty"`
+
+	// BootstrapTaints lists the keys of the taints removed by the operator from a node once its operator-validator
+	// pod is ready, i.e. once the GPU stack of the node passed validation. The operands tolerate the bootstrap
+	// taints with any value and effect.
+	// +kubebuilder:validation:Optional
+	BootstrapTaints []string `json:"bootstrapTaints,omitempty"`
+}
+
+// autoscalerStartupTaints are the startup taints tolerated by the Autoscaler startup taint policy
+var autoscalerStartupTaints = []corev1.Taint{
+	{Key: "nvidia.com/gpu", Effect: corev1.TaintEffectNoSchedule},
+	{Key: "karpenter.sh/unregistered", Effect: corev1.TaintEffectNoExecute},
+	{Key: "node.cloudprovider.kubernetes.io/uninitialized", Effect: corev1.TaintEffectNoSchedule},
+}
+
+// GetTolerations returns the tolerations of the startup taints added to all operands
+func (s *StartupTaintsSpec) GetTolerations() []corev1.Toleration {
+	if s == nil {
+		return nil
+	}
+	var tolerations []corev1.Toleration
+	if s.Policy != StartupTaintPolicyNone {
+		for _, taint := range autoscalerStartupTaints {
+			tolerations = append(tolerations, corev1.Toleration{Key: taint.Key, Operator: corev1.TolerationOpExists, Effect: taint.Effect})
+		}
+	}
+	for _, key := range s.BootstrapTaints {
+		tolerations = append(tolerations, corev1.Toleration{Key: key, Operator: corev1.TolerationOpExists})
+	}
+	return tolerations
+}
 
-	// Optional: Set pod-level security context for all DaemonSet pods (applies as defaults to all containers)
-	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+// GetBootstrapTaints returns the keys of the taints removed from the nodes passing validation
+func (s *StartupTaintsSpec) GetBootstrapTaints() []string {
+	if s == nil {
+		return nil
+	}
+	return s.BootstrapTaints
 }
 
 // Deprecated: InitContainerSpec describes configuration for initContainer image used with all components
@@ -353,6 +1998,37 @@ type InitContainerSpec struct {
 	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
 }
 
+// ValidatorImageSpec defines the validator image of the nodes of an architecture or OS
+type ValidatorImageSpec struct {
+	// Validator image repository
+	// +kubebuilder:validation:Optional
+	Repository string `json:"repository,omitempty"`
+
+	// Validator image name
+	// +kubebuilder:validation:Pattern=[a-zA-Z0-9\-]+
+	Image string `json:"image"`
+
+	// Validator image tag
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+}
+
+// ArchImageSpec overrides the image of a component on the nodes of an architecture, the unset fields
+// defaulting to the ones of the component
+type ArchImageSpec struct {
+	// Image repository
+	// +kubebuilder:validation:Optional
+	Repository string `json:"repository,omitempty"`
+
+	// Image name
+	// +kubebuilder:validation:Optional
+	Image string `json:"image,omitempty"`
+
+	// Image tag
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+}
+
 // ValidatorSpec describes configuration options for validation pod
 type ValidatorSpec struct {
 	// Plugin validator spec
@@ -376,6 +2052,9 @@ type ValidatorSpec struct {
 	// VGPUDevices validator spec
 	VGPUDevices VGPUDevicesValidatorSpec `json:"vgpuDevices,omitempty"`
 
+	// CCManager validator spec
+	CCManager CCManagerValidatorSpec `json:"ccManager,omitempty"`
+
 	// Validator image repository
 	// +kubebuilder:validation:Optional
 	Repository string `json:"repository,omitempty"`
@@ -388,6 +2067,16 @@ type ValidatorSpec struct {
 	// +kubebuilder:validation:Optional
 	Version string `json:"version,omitempty"`
 
+	// Images selects the validator image of the nodes per architecture and OS, keyed by the architecture of
+	// the node (e.g. arm64), its OS as <ID><VERSION_ID> of its os-release (e.g. rhel9.4), or both (e.g.
+	// arm64/rhel9.4). The most specific key matching a node is selected, the nodes matching no key run the
+	// validator image above. The driver and vGPU Manager DaemonSets always run the validator image above.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Validator images per architecture and OS"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	Images map[string]ValidatorImageSpec `json:"images,omitempty"`
+
 	// Image pull policy
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
@@ -426,10 +2115,62 @@ type ValidatorSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable hostNetwork for NVIDIA Operator Validator"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	HostNetwork *bool `json:"hostNetwork,omitempty"`
+
+	// SchedulerName is the name of the scheduler placing the Validator pods, overriding the
+	// scheduler configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Scheduler name for NVIDIA Operator Validator"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	SchedulerName string `json:"schedulerName,omitempty"`
+
+	// PriorityClassName is the name of the priority class of the Validator pods, overriding the
+	// priority class configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Priority class name for NVIDIA Operator Validator"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// RuntimeClassName is the name of the runtime class of the Validator pods, overriding the
+	// runtime class set by the operator
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Runtime class name for NVIDIA Operator Validator"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	RuntimeClassName string `json:"runtimeClassName,omitempty"`
+
+	// Tolerations are added to the tolerations of the Validator pods, allowing them to run on tainted nodes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Tolerations for NVIDIA Operator Validator"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:io.kubernetes:Tolerations"
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// NodeAffinity is merged into the node affinity of the Validator pods. Its required terms must not
+	// conflict with the node labels selected by the operator.
+	// +kubebuilder:validation:Optional
+	NodeAffinity *corev1.NodeAffinity `json:"nodeAffinity,omitempty"`
+
+	// FailureDomainLabel is the node label used to group validation results by failure domain (e.g. zone or rack),
+	// so that a failing domain can be identified. Defaults to topology.kubernetes.io/zone.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Failure domain node label for validation results"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
+	FailureDomainLabel string `json:"failureDomainLabel,omitempty"`
 }
 
 // PluginValidatorSpec defines validator spec for NVIDIA Device Plugin
 type PluginValidatorSpec struct {
+	ValidatorComponentSpec `json:",inline"`
+
 	// Optional: List of environment variables
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
@@ -439,6 +2180,8 @@ type PluginValidatorSpec struct {
 
 // ToolkitValidatorSpec defines validator spec for NVIDIA Container Toolkit
 type ToolkitValidatorSpec struct {
+	ValidatorComponentSpec `json:",inline"`
+
 	// Optional: List of environment variables
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
@@ -448,15 +2191,38 @@ type ToolkitValidatorSpec struct {
 
 // DriverValidatorSpec defines validator spec for NVIDIA Driver validation
 type DriverValidatorSpec struct {
+	ValidatorComponentSpec `json:",inline"`
+
 	// Optional: List of environment variables
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
 	Env []EnvVar `json:"env,omitempty"`
+
+	// CUDACompatLabels indicates if the nodes are labeled with the version of their driver and the newest
+	// CUDA runtime version supported by the driver once the driver is ready, e.g.
+	// nvidia.com/cuda.driver-version.major=535 and nvidia.com/cuda.runtime-version.max=12.2, so that
+	// workloads requiring a newer CUDA runtime can be kept off the nodes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Label nodes with CUDA compatibility"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	CUDACompatLabels *bool `json:"cudaCompatLabels,omitempty"`
+}
+
+// IsCUDACompatLabelsEnabled returns true if the nodes are labeled with the CUDA compatibility of their driver
+func (d *DriverValidatorSpec) IsCUDACompatLabelsEnabled() bool {
+	if d.CUDACompatLabels == nil {
+		// nodes are not labeled by default
+		return false
+	}
+	return *d.CUDACompatLabels
 }
 
 // CUDAValidatorSpec defines validator spec for CUDA validation workload pod
 type CUDAValidatorSpec struct {
+	ValidatorComponentSpec `json:",inline"`
+
 	// Optional: List of environment variables
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
@@ -466,6 +2232,8 @@ type CUDAValidatorSpec struct {
 
 // VFIOPCIValidatorSpec defines validator spec for NVIDIA VFIO-PCI device validation
 type VFIOPCIValidatorSpec struct {
+	ValidatorComponentSpec `json:",inline"`
+
 	// Optional: List of environment variables
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
@@ -475,6 +2243,8 @@ type VFIOPCIValidatorSpec struct {
 
 // VGPUManagerValidatorSpec defines validator spec for NVIDIA vGPU Manager
 type VGPUManagerValidatorSpec struct {
+	ValidatorComponentSpec `json:",inline"`
+
 	// Optional: List of environment variables
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
@@ -484,6 +2254,19 @@ type VGPUManagerValidatorSpec struct {
 
 // VGPUDevicesValidatorSpec defines validator spec for NVIDIA vGPU device validator
 type VGPUDevicesValidatorSpec struct {
+	ValidatorComponentSpec `json:",inline"`
+
+	// Optional: List of environment variables
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
+	Env []EnvVar `json:"env,omitempty"`
+}
+
+// CCManagerValidatorSpec defines validator spec for NVIDIA Confidential Computing Manager
+type CCManagerValidatorSpec struct {
+	ValidatorComponentSpec `json:",inline"`
+
 	// Optional: List of environment variables
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
@@ -491,11 +2274,52 @@ type VGPUDevicesValidatorSpec struct {
 	Env []EnvVar `json:"env,omitempty"`
 }
 
+// ValidatorComponentSpec defines the options common to the validation of all components
+type ValidatorComponentSpec struct {
+	// Enabled indicates if the component is validated. When disabled, the component is reported
+	// as ready without being validated, which is intended for troubleshooting a failing validation.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable the validation of the component"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Timeout is the maximum duration of the validation of the component, after which the validation
+	// fails and is retried. The validation is not bounded if not set.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Validation timeout"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
 // MIGSpec defines the configuration for MIG support
 type MIGSpec struct {
 	// Optional: MIGStrategy to apply for GFD and NVIDIA Device Plugin
 	// +kubebuilder:validation:Enum=none;single;mixed
 	Strategy MIGStrategy `json:"strategy,omitempty"`
+
+	// Optional: ConfigSelectors maps groups of nodes to the MIG configuration applied to them by default.
+	// The first selector matching the labels of a MIG-capable node determines the value of its
+	// nvidia.com/mig.config label. Nodes not matched by any selector use the MIG Manager default.
+	// Labels applied by the operator follow later changes of the selectors, while labels set by other
+	// means are left unchanged.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="MIG Config Selectors"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	ConfigSelectors []MIGConfigSelector `json:"configSelectors,omitempty"`
+}
+
+// MIGConfigSelector selects the MIG configuration applied by default to a group of nodes
+type MIGConfigSelector struct {
+	// NodeSelector specifies the labels a node must have to be assigned this MIG configuration
+	// +kubebuilder:validation:MinProperties=1
+	NodeSelector map[string]string `json:"nodeSelector"`
+
+	// Config is the name of the MIG configuration profile to apply, as defined in the MIG Manager config
+	// +kubebuilder:validation:MinLength=1
+	Config string `json:"config"`
 }
 
 // DriverManagerSpec describes configuration for NVIDIA Driver Manager(initContainer)
@@ -555,6 +2379,10 @@ type ContainerProbeSpec struct {
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:Minimum=1
 	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+	// Command executed in the container by the probe, in place of the probe handler of the operand.
+	// A probe the operand does not define is only added when a command is set.
+	// +kubebuilder:validation:Optional
+	Command []string `json:"command,omitempty"`
 }
 
 // DriverSpec defines the properties for NVIDIA Driver deployment
@@ -571,6 +2399,18 @@ type DriverSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	UsePrecompiled *bool `json:"usePrecompiled,omitempty"`
 
+	// OSUpgradePreStaging indicates if the driver DaemonSets of the OS version announced for the upcoming OS
+	// upgrade of the nodes are created ahead of the upgrade. The target kernel version of pre-compiled drivers
+	// is announced through the nvidia.com/gpu.os-upgrade.target-kernel annotation of the nodes, and the
+	// target RHCOS version through the nvidia.com/gpu.os-upgrade.target-rhcos annotation or a pending
+	// MachineConfigPool rollout on OpenShift. The pre-compiled driver images of the target kernel versions are
+	// pre-pulled as well when the image pre-pull is enabled.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Pre-stage the NVIDIA Driver for upcoming OS upgrades"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	OSUpgradePreStaging *bool `json:"osUpgradePreStaging,omitempty"`
+
 	// Deprecated: This field is no longer honored by the gpu-operator. Please use KernelModuleType instead.
 	// UseOpenKernelModules indicates if the open GPU kernel modules should be used
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
@@ -608,6 +2448,14 @@ type DriverSpec struct {
 	// Driver auto-upgrade settings
 	UpgradePolicy *upgrade_v1alpha1.DriverUpgradePolicySpec `json:"upgradePolicy,omitempty"`
 
+	// UpgradePolicies overrides the driver auto-upgrade settings per driver type
+	// +kubebuilder:validation:Optional
+	UpgradePolicies *DriverUpgradePoliciesSpec `json:"upgradePolicies,omitempty"`
+
+	// DrainPolicy extends the node drain settings of the driver auto-upgrade
+	// +kubebuilder:validation:Optional
+	DrainPolicy *DriverDrainPolicySpec `json:"drainPolicy,omitempty"`
+
 	// NVIDIA Driver image repository
 	// +kubebuilder:validation:Optional
 	Repository string `json:"repository,omitempty"`
@@ -685,12 +2533,64 @@ type DriverSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Name of the Kubernetes Secret with secret environment variables for the NVIDIA Driver"
 	SecretEnv string `json:"secretEnv,omitempty"`
 
+	// Optional: ModuleCache configures caching of kernel modules built on the node in an OCI registry
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Kernel Module Cache Configuration For NVIDIA Driver Container"
+	ModuleCache *DriverModuleCacheSpec `json:"moduleCache,omitempty"`
+
 	// HostNetwork indicates whether the Driver pod uses the host's network namespace.
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable hostNetwork for NVIDIA Driver"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	HostNetwork *bool `json:"hostNetwork,omitempty"`
+
+	// SchedulerName is the name of the scheduler placing the Driver pods, overriding the
+	// scheduler configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Scheduler name for NVIDIA Driver"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	SchedulerName string `json:"schedulerName,omitempty"`
+
+	// PriorityClassName is the name of the priority class of the Driver pods, overriding the
+	// priority class configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Priority class name for NVIDIA Driver"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// RuntimeClassName is the name of the runtime class of the Driver pods, overriding the
+	// runtime class set by the operator
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Runtime class name for NVIDIA Driver"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	RuntimeClassName string `json:"runtimeClassName,omitempty"`
+
+	// Tolerations are added to the tolerations of the Driver pods, allowing them to run on tainted nodes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Tolerations for NVIDIA Driver"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:io.kubernetes:Tolerations"
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// NodeAffinity is merged into the node affinity of the Driver pods. Its required terms must not
+	// conflict with the node labels selected by the operator.
+	// +kubebuilder:validation:Optional
+	NodeAffinity *corev1.NodeAffinity `json:"nodeAffinity,omitempty"`
+
+	// Logging configures the verbosity and the size limits of the log output of the driver container, which
+	// can fill the disk of the nodes with small root volumes while the driver is built
+	// +kubebuilder:validation:Optional
+	Logging *ContainerLogSpec `json:"logging,omitempty"`
 }
 
 // VGPUManagerSpec defines the properties for the NVIDIA vGPU Manager deployment
@@ -759,6 +2659,48 @@ type VGPUManagerSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable hostNetwork for NVIDIA vGPU Manager"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	HostNetwork *bool `json:"hostNetwork,omitempty"`
+
+	// SchedulerName is the name of the scheduler placing the vGPU Manager pods, overriding the
+	// scheduler configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Scheduler name for NVIDIA vGPU Manager"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	SchedulerName string `json:"schedulerName,omitempty"`
+
+	// PriorityClassName is the name of the priority class of the vGPU Manager pods, overriding the
+	// priority class configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Priority class name for NVIDIA vGPU Manager"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// RuntimeClassName is the name of the runtime class of the vGPU Manager pods, overriding the
+	// runtime class set by the operator
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Runtime class name for NVIDIA vGPU Manager"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	RuntimeClassName string `json:"runtimeClassName,omitempty"`
+
+	// Tolerations are added to the tolerations of the vGPU Manager pods, allowing them to run on tainted nodes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Tolerations for NVIDIA vGPU Manager"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:io.kubernetes:Tolerations"
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// NodeAffinity is merged into the node affinity of the vGPU Manager pods. Its required terms must not
+	// conflict with the node labels selected by the operator.
+	// +kubebuilder:validation:Optional
+	NodeAffinity *corev1.NodeAffinity `json:"nodeAffinity,omitempty"`
 }
 
 // ToolkitSpec defines the properties for NVIDIA Container Toolkit deployment
@@ -781,6 +2723,15 @@ type ToolkitSpec struct {
 	// +kubebuilder:validation:Optional
 	Version string `json:"version,omitempty"`
 
+	// ArchImages overrides the NVIDIA Container Toolkit image on the nodes of an architecture, keyed by the
+	// kubernetes.io/arch label of the node (e.g. arm64), for the images not published for all architectures.
+	// The DaemonSet is deployed once per architecture of the GPU nodes set here.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="NVIDIA Container Toolkit images per architecture"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	ArchImages map[string]ArchImageSpec `json:"archImages,omitempty"`
+
 	// Image pull policy
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
@@ -813,6 +2764,15 @@ type ToolkitSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
 	Env []EnvVar `json:"env,omitempty"`
 
+	// NVIDIA Container Toolkit container startup probe settings
+	StartupProbe *ContainerProbeSpec `json:"startupProbe,omitempty"`
+
+	// NVIDIA Container Toolkit container liveness probe settings
+	LivenessProbe *ContainerProbeSpec `json:"livenessProbe,omitempty"`
+
+	// NVIDIA Container Toolkit container readiness probe settings
+	ReadinessProbe *ContainerProbeSpec `json:"readinessProbe,omitempty"`
+
 	// Toolkit install directory on the host
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=/usr/local/nvidia
@@ -827,6 +2787,100 @@ type ToolkitSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable hostNetwork for NVIDIA Container Toolkit"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	HostNetwork *bool `json:"hostNetwork,omitempty"`
+
+	// SchedulerName is the name of the scheduler placing the Container Toolkit pods, overriding the
+	// scheduler configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Scheduler name for NVIDIA Container Toolkit"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	SchedulerName string `json:"schedulerName,omitempty"`
+
+	// PriorityClassName is the name of the priority class of the Container Toolkit pods, overriding the
+	// priority class configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Priority class name for NVIDIA Container Toolkit"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// RuntimeClassName is the name of the runtime class of the Container Toolkit pods, overriding the
+	// runtime class set by the operator
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Runtime class name for NVIDIA Container Toolkit"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	RuntimeClassName string `json:"runtimeClassName,omitempty"`
+
+	// Tolerations are added to the tolerations of the Container Toolkit pods, allowing them to run on tainted nodes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Tolerations for NVIDIA Container Toolkit"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:io.kubernetes:Tolerations"
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// NodeAffinity is merged into the node affinity of the Container Toolkit pods. Its required terms must not
+	// conflict with the node labels selected by the operator.
+	// +kubebuilder:validation:Optional
+	NodeAffinity *corev1.NodeAffinity `json:"nodeAffinity,omitempty"`
+
+	// RuntimeRollback configures the restoration of the containerd configuration when containerd does not
+	// come back healthy after the Container Toolkit restarted it
+	// +kubebuilder:validation:Optional
+	RuntimeRollback *RuntimeRollbackSpec `json:"runtimeRollback,omitempty"`
+
+	// CRIOConfigMode selects how the Container Toolkit configures cri-o. In the default mode an OCI hook is
+	// installed, or the NVIDIA runtime handlers are configured when CDI is enabled. In the drop-in mode the
+	// NVIDIA runtime handlers are configured in a drop-in file of the cri-o configuration directory only, the
+	// main cri-o configuration being mounted read-only, and the drop-in file is removed with the toolkit pods.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="cri-o configuration mode of NVIDIA Container Toolkit"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:select:default,urn:alm:descriptor:com.tectonic.ui:select:drop-in"
+	CRIOConfigMode CRIOConfigMode `json:"crioConfigMode,omitempty"`
+
+	// Logging configures the verbosity and the size limits of the log output of the container toolkit container
+	// +kubebuilder:validation:Optional
+	Logging *ContainerLogSpec `json:"logging,omitempty"`
+}
+
+// CRIOConfigMode is how the Container Toolkit configures cri-o
+// +kubebuilder:validation:Enum=default;drop-in
+type CRIOConfigMode string
+
+const (
+	// CRIOConfigModeDefault installs an OCI hook, or configures the NVIDIA runtime handlers when CDI is enabled
+	CRIOConfigModeDefault CRIOConfigMode = "default"
+	// CRIOConfigModeDropIn configures the NVIDIA runtime handlers in a drop-in file of the cri-o configuration
+	// directory only
+	CRIOConfigModeDropIn CRIOConfigMode = "drop-in"
+)
+
+// RuntimeRollbackSpec defines the rollback of the container runtime configuration applied by the Container Toolkit
+type RuntimeRollbackSpec struct {
+	// Enabled indicates if the containerd configuration is backed up before the Container Toolkit updates it,
+	// and restored when containerd is not healthy within the timeout after its restart
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable the rollback of the containerd configuration"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// TimeoutSeconds is the time containerd is given to become healthy after its restart before its
+	// configuration is restored
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=120
+	// +kubebuilder:validation:Minimum=10
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Timeout for containerd to become healthy after its restart"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
 }
 
 // DevicePluginSpec defines the properties for NVIDIA Device Plugin deployment
@@ -849,6 +2903,15 @@ type DevicePluginSpec struct {
 	// +kubebuilder:validation:Optional
 	Version string `json:"version,omitempty"`
 
+	// ArchImages overrides the NVIDIA Device Plugin image on the nodes of an architecture, keyed by the
+	// kubernetes.io/arch label of the node (e.g. arm64), for the images not published for all architectures.
+	// The DaemonSet is deployed once per architecture of the GPU nodes set here.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="NVIDIA Device Plugin images per architecture"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	ArchImages map[string]ArchImageSpec `json:"archImages,omitempty"`
+
 	// Image pull policy
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
@@ -875,28 +2938,128 @@ type DevicePluginSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
 	Args []string `json:"args,omitempty"`
 
-	// Optional: List of environment variables
+	// Optional: List of environment variables
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
+	Env []EnvVar `json:"env,omitempty"`
+
+	// NVIDIA Device Plugin container startup probe settings
+	StartupProbe *ContainerProbeSpec `json:"startupProbe,omitempty"`
+
+	// NVIDIA Device Plugin container liveness probe settings
+	LivenessProbe *ContainerProbeSpec `json:"livenessProbe,omitempty"`
+
+	// NVIDIA Device Plugin container readiness probe settings
+	ReadinessProbe *ContainerProbeSpec `json:"readinessProbe,omitempty"`
+
+	// Optional: Configuration for the NVIDIA Device Plugin via the ConfigMap
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Configuration for the NVIDIA Device Plugin via the ConfigMap"
+	Config *DevicePluginConfig `json:"config,omitempty"`
+
+	// Optional: MPS related configuration for the NVIDIA Device Plugin
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="MPS related configuration for the NVIDIA Device Plugin"
+	MPS *MPSConfig `json:"mps,omitempty"`
+
+	// Optional: Time-slicing configuration for the NVIDIA Device Plugin.
+	// The operator renders it into a ConfigMap it manages; it is ignored if a custom ConfigMap is set in config.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Time-slicing configuration for the NVIDIA Device Plugin"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	TimeSlicing *TimeSlicingConfig `json:"timeSlicing,omitempty"`
+
+	// HostNetwork indicates whether the Device Plugin pod uses the host's network namespace.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable hostNetwork for NVIDIA Device Plugin"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	HostNetwork *bool `json:"hostNetwork,omitempty"`
+
+	// SchedulerName is the name of the scheduler placing the Device Plugin pods, overriding the
+	// scheduler configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Scheduler name for NVIDIA Device Plugin"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	SchedulerName string `json:"schedulerName,omitempty"`
+
+	// PriorityClassName is the name of the priority class of the Device Plugin pods, overriding the
+	// priority class configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Priority class name for NVIDIA Device Plugin"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// RuntimeClassName is the name of the runtime class of the Device Plugin pods, overriding the
+	// runtime class set by the operator
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Runtime class name for NVIDIA Device Plugin"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	RuntimeClassName string `json:"runtimeClassName,omitempty"`
+
+	// Tolerations are added to the tolerations of the Device Plugin pods, allowing them to run on tainted nodes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Tolerations for NVIDIA Device Plugin"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:io.kubernetes:Tolerations"
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// NodeAffinity is merged into the node affinity of the Device Plugin pods. Its required terms must not
+	// conflict with the node labels selected by the operator.
+	// +kubebuilder:validation:Optional
+	NodeAffinity *corev1.NodeAffinity `json:"nodeAffinity,omitempty"`
+
+	// Optional: Recovery of the GPU resources of the nodes after kubelet restarts, restarting the Device Plugin
+	// pods of the nodes whose GPU resources do not reappear in time
+	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
-	Env []EnvVar `json:"env,omitempty"`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Kubelet Restart Recovery Configuration"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	KubeletRestartRecovery *KubeletRestartRecoveryConfig `json:"kubeletRestartRecovery,omitempty"`
+}
 
-	// Optional: Configuration for the NVIDIA Device Plugin via the ConfigMap
+// KubeletRestartRecoveryConfig defines the recovery of the GPU resources of a node after a kubelet restart,
+// detected from a change of the boot ID of the node or of the start of its Ready condition. The device plugins
+// re-register with the restarted kubelet, when the GPU resources of the node are still not allocatable after the
+// timeout, the NVIDIA Device Plugin and Sandbox Device Plugin pods of the node are restarted.
+type KubeletRestartRecoveryConfig struct {
+	// Enable restarting the device plugin pods of the nodes whose GPU resources are lost after a kubelet restart
+	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Configuration for the NVIDIA Device Plugin via the ConfigMap"
-	Config *DevicePluginConfig `json:"config,omitempty"`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable Kubelet Restart Recovery"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
 
-	// Optional: MPS related configuration for the NVIDIA Device Plugin
+	// Time in seconds the GPU resources of a node are waited for after a kubelet restart or a restart of the
+	// device plugin pods, before the device plugin pods are restarted
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=120
+	// +kubebuilder:validation:Minimum=30
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="MPS related configuration for the NVIDIA Device Plugin"
-	MPS *MPSConfig `json:"mps,omitempty"`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Timeout Seconds"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
 
-	// HostNetwork indicates whether the Device Plugin pod uses the host's network namespace.
+	// Maximum number of restarts of the device plugin pods of a node after a kubelet restart, after which the
+	// node is left as is and a warning event is emitted
 	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable hostNetwork for NVIDIA Device Plugin"
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
-	HostNetwork *bool `json:"hostNetwork,omitempty"`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Maximum Restarts"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MaxRestarts int `json:"maxRestarts,omitempty"`
 }
 
 // DevicePluginConfig defines ConfigMap name for NVIDIA Device Plugin config
@@ -915,6 +3078,33 @@ type DevicePluginConfig struct {
 	Default string `json:"default,omitempty"`
 }
 
+// TimeSlicingConfig defines the time-slicing configuration for the NVIDIA Device Plugin
+type TimeSlicingConfig struct {
+	// RenameByDefault advertises shared resources as <resource-name>.shared instead of <resource-name>
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Rename shared resources by default"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	RenameByDefault bool `json:"renameByDefault,omitempty"`
+
+	// Resources lists the resources shared through time-slicing and their number of replicas
+	// +kubebuilder:validation:MinItems=1
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Time-sliced resources"
+	Resources []TimeSlicingResource `json:"resources"`
+}
+
+// TimeSlicingResource defines how a resource advertised by the NVIDIA Device Plugin is shared through time-slicing
+type TimeSlicingResource struct {
+	// Name of the resource to share, e.g. nvidia.com/gpu
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Replicas is the number of shared replicas advertised for each device of the resource
+	// +kubebuilder:validation:Minimum=2
+	Replicas int `json:"replicas"`
+}
+
 // MPSConfig defines MPS related configuration for the NVIDIA Device Plugin
 type MPSConfig struct {
 	// Root defines the MPS root path on the host
@@ -924,6 +3114,70 @@ type MPSConfig struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="MPS root path on the host"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
 	Root string `json:"root,omitempty"`
+
+	// RenameByDefault advertises shared resources as <resource-name>.shared instead of <resource-name>
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Rename shared resources by default"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	RenameByDefault bool `json:"renameByDefault,omitempty"`
+
+	// Resources lists the resources shared through MPS and their number of replicas. The operator renders them
+	// into a ConfigMap it manages; they are ignored if a custom ConfigMap is set in config. MIG devices cannot
+	// be shared through MPS.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="MPS shared resources"
+	Resources []MPSResource `json:"resources,omitempty"`
+}
+
+// MPSResource defines how a resource advertised by the NVIDIA Device Plugin is shared through MPS
+type MPSResource struct {
+	// Name of the resource to share, e.g. nvidia.com/gpu
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Replicas is the number of shared replicas advertised for each device of the resource, each replica
+	// getting an equal share of the device memory and compute
+	// +kubebuilder:validation:Minimum=2
+	Replicas int `json:"replicas"`
+}
+
+// MPSControlDaemonSpec defines the deployment of the MPS control daemon. The daemon runs on the GPU nodes
+// labeled nvidia.com/mps.capable=true by the device plugin, which are not partitioned with MIG, and uses the
+// image of the NVIDIA Device Plugin.
+type MPSControlDaemonSpec struct {
+	// Enabled indicates if the MPS control daemon is deployed on the MPS capable nodes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable the MPS control daemon"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// NodeSelector restricts the MPS control daemon to a group of nodes, on top of the MPS capable nodes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Node selector for the MPS control daemon"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Optional: Define resources requests and limits for each pod, overriding the ones of the NVIDIA Device Plugin
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Resource Requirements"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:resourceRequirements"
+	Resources *ResourceRequirements `json:"resources,omitempty"`
+
+	// Optional: List of arguments
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Arguments"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
+	Args []string `json:"args,omitempty"`
+
+	// Optional: List of environment variables
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
+	Env []EnvVar `json:"env,omitempty"`
 }
 
 // SandboxDevicePluginSpec defines the properties for the NVIDIA Sandbox Device Plugin deployment
@@ -984,6 +3238,48 @@ type SandboxDevicePluginSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable hostNetwork for NVIDIA Sandbox Device Plugin"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	HostNetwork *bool `json:"hostNetwork,omitempty"`
+
+	// SchedulerName is the name of the scheduler placing the Sandbox Device Plugin pods, overriding the
+	// scheduler configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Scheduler name for NVIDIA Sandbox Device Plugin"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	SchedulerName string `json:"schedulerName,omitempty"`
+
+	// PriorityClassName is the name of the priority class of the Sandbox Device Plugin pods, overriding the
+	// priority class configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Priority class name for NVIDIA Sandbox Device Plugin"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// RuntimeClassName is the name of the runtime class of the Sandbox Device Plugin pods, overriding the
+	// runtime class set by the operator
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Runtime class name for NVIDIA Sandbox Device Plugin"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	RuntimeClassName string `json:"runtimeClassName,omitempty"`
+
+	// Tolerations are added to the tolerations of the Sandbox Device Plugin pods, allowing them to run on tainted nodes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Tolerations for NVIDIA Sandbox Device Plugin"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:io.kubernetes:Tolerations"
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// NodeAffinity is merged into the node affinity of the Sandbox Device Plugin pods. Its required terms must not
+	// conflict with the node labels selected by the operator.
+	// +kubebuilder:validation:Optional
+	NodeAffinity *corev1.NodeAffinity `json:"nodeAffinity,omitempty"`
 }
 
 // DCGMExporterSpec defines the properties for NVIDIA DCGM Exporter deployment
@@ -1006,6 +3302,15 @@ type DCGMExporterSpec struct {
 	// +kubebuilder:validation:Optional
 	Version string `json:"version,omitempty"`
 
+	// ArchImages overrides the DCGM Exporter image on the nodes of an architecture, keyed by the
+	// kubernetes.io/arch label of the node (e.g. arm64), for the images not published for all architectures.
+	// The DaemonSet is deployed once per architecture of the GPU nodes set here.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="DCGM Exporter images per architecture"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	ArchImages map[string]ArchImageSpec `json:"archImages,omitempty"`
+
 	// Image pull policy
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
@@ -1043,6 +3348,15 @@ type DCGMExporterSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
 	Env []EnvVar `json:"env,omitempty"`
 
+	// NVIDIA DCGM Exporter container startup probe settings
+	StartupProbe *ContainerProbeSpec `json:"startupProbe,omitempty"`
+
+	// NVIDIA DCGM Exporter container liveness probe settings
+	LivenessProbe *ContainerProbeSpec `json:"livenessProbe,omitempty"`
+
+	// NVIDIA DCGM Exporter container readiness probe settings
+	ReadinessProbe *ContainerProbeSpec `json:"readinessProbe,omitempty"`
+
 	// Optional: Custom metrics configuration for NVIDIA DCGM Exporter
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Custom Metrics Configuration For DCGM Exporter"
@@ -1072,6 +3386,48 @@ type DCGMExporterSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	HostNetwork *bool `json:"hostNetwork,omitempty"`
 
+	// SchedulerName is the name of the scheduler placing the DCGM-Exporter pods, overriding the
+	// scheduler configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Scheduler name for NVIDIA DCGM Exporter"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	SchedulerName string `json:"schedulerName,omitempty"`
+
+	// PriorityClassName is the name of the priority class of the DCGM-Exporter pods, overriding the
+	// priority class configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Priority class name for NVIDIA DCGM Exporter"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// RuntimeClassName is the name of the runtime class of the DCGM-Exporter pods, overriding the
+	// runtime class set by the operator
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Runtime class name for NVIDIA DCGM Exporter"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	RuntimeClassName string `json:"runtimeClassName,omitempty"`
+
+	// Tolerations are added to the tolerations of the DCGM-Exporter pods, allowing them to run on tainted nodes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Tolerations for NVIDIA DCGM Exporter"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:io.kubernetes:Tolerations"
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// NodeAffinity is merged into the node affinity of the DCGM-Exporter pods. Its required terms must not
+	// conflict with the node labels selected by the operator.
+	// +kubebuilder:validation:Optional
+	NodeAffinity *corev1.NodeAffinity `json:"nodeAffinity,omitempty"`
+
 	// Optional: HPC job mapping configuration for NVIDIA DCGM Exporter
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
@@ -1102,6 +3458,73 @@ type DCGMExporterSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Pod label allowlist regex"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
 	PodLabelAllowlistRegex []string `json:"podLabelAllowlistRegex,omitempty"`
+
+	// Optional: Attribution of the GPU metrics of NVIDIA DCGM Exporter to the pods using the GPUs. When set, the
+	// operator manages the mount of the kubelet pod-resources socket, the environment and the RBAC of the exporter.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Pod Attribution Configuration"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	PodAttribution *DCGMExporterPodAttributionConfig `json:"podAttribution,omitempty"`
+
+	// Optional: Idle node hints published from the GPU utilization reported by NVIDIA DCGM Exporter
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Idle Node Hints Configuration"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	IdleNodeHints *DCGMExporterIdleNodeHintsConfig `json:"idleNodeHints,omitempty"`
+}
+
+// DCGMExporterIdleNodeHintsConfig defines the configuration of the nvidia.com/gpu.idle-since
+// and nvidia.com/gpu.idle-duration node annotations, which report since when and for how long
+// all GPUs of a node have been idle
+type DCGMExporterIdleNodeHintsConfig struct {
+	// Enable publishing the nvidia.com/gpu.idle-since and nvidia.com/gpu.idle-duration node annotations
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable Idle Node Hints"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// GPU utilization percentage at or below which a GPU is considered idle
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=0
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Idle Utilization Threshold"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	UtilizationThreshold int `json:"utilizationThreshold,omitempty"`
+
+	// Interval in seconds at which GPU utilization is sampled and the annotations are refreshed
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Minimum=10
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Sampling Interval Seconds"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	IntervalSeconds int `json:"intervalSeconds,omitempty"`
+}
+
+// DCGMExporterPodAttributionConfig defines the attribution of the GPU metrics of NVIDIA DCGM Exporter to pods
+type DCGMExporterPodAttributionConfig struct {
+	// Enable attributing GPU metrics to pods. When enabled, the kubelet pod-resources socket is mounted
+	// from the kubelet root directory and the exporter is allowed to read pods. When disabled, the socket
+	// is not mounted and the exporter only reports GPU metrics.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable Pod Attribution"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// GPUIDType is the GPU identifier matched against the devices the kubelet reports for each pod,
+	// uid for whole GPUs or device-name when GPUs are shared or partitioned
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=uid;device-name
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="GPU ID Type"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	GPUIDType string `json:"gpuIDType,omitempty"`
 }
 
 // DCGMExporterHPCJobMappingConfig defines HPC job mapping configuration for NVIDIA DCGM Exporter
@@ -1167,6 +3590,15 @@ type DCGMSpec struct {
 	// +kubebuilder:validation:Optional
 	Version string `json:"version,omitempty"`
 
+	// ArchImages overrides the DCGM image on the nodes of an architecture, keyed by the
+	// kubernetes.io/arch label of the node (e.g. arm64), for the images not published for all architectures.
+	// The DaemonSet is deployed once per architecture of the GPU nodes set here.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="DCGM images per architecture"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	ArchImages map[string]ArchImageSpec `json:"archImages,omitempty"`
+
 	// Image pull policy
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
@@ -1199,6 +3631,15 @@ type DCGMSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
 	Env []EnvVar `json:"env,omitempty"`
 
+	// NVIDIA DCGM container startup probe settings
+	StartupProbe *ContainerProbeSpec `json:"startupProbe,omitempty"`
+
+	// NVIDIA DCGM container liveness probe settings
+	LivenessProbe *ContainerProbeSpec `json:"livenessProbe,omitempty"`
+
+	// NVIDIA DCGM container readiness probe settings
+	ReadinessProbe *ContainerProbeSpec `json:"readinessProbe,omitempty"`
+
 	// Deprecated: HostPort represents host port that needs to be bound for DCGM engine (Default: 5555)
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Host port to bind for DCGM engine"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:number"
@@ -1210,6 +3651,48 @@ type DCGMSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable hostNetwork for NVIDIA DCGM"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	HostNetwork *bool `json:"hostNetwork,omitempty"`
+
+	// SchedulerName is the name of the scheduler placing the DCGM pods, overriding the
+	// scheduler configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Scheduler name for NVIDIA DCGM"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	SchedulerName string `json:"schedulerName,omitempty"`
+
+	// PriorityClassName is the name of the priority class of the DCGM pods, overriding the
+	// priority class configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Priority class name for NVIDIA DCGM"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// RuntimeClassName is the name of the runtime class of the DCGM pods, overriding the
+	// runtime class set by the operator
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Runtime class name for NVIDIA DCGM"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	RuntimeClassName string `json:"runtimeClassName,omitempty"`
+
+	// Tolerations are added to the tolerations of the DCGM pods, allowing them to run on tainted nodes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Tolerations for NVIDIA DCGM"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:io.kubernetes:Tolerations"
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// NodeAffinity is merged into the node affinity of the DCGM pods. Its required terms must not
+	// conflict with the node labels selected by the operator.
+	// +kubebuilder:validation:Optional
+	NodeAffinity *corev1.NodeAffinity `json:"nodeAffinity,omitempty"`
 }
 
 // NodeStatusExporterSpec defines the properties for node-status-exporter state
@@ -1264,12 +3747,63 @@ type NodeStatusExporterSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
 	Env []EnvVar `json:"env,omitempty"`
 
+	// Node Status Exporter container startup probe settings
+	StartupProbe *ContainerProbeSpec `json:"startupProbe,omitempty"`
+
+	// Node Status Exporter container liveness probe settings
+	LivenessProbe *ContainerProbeSpec `json:"livenessProbe,omitempty"`
+
+	// Node Status Exporter container readiness probe settings
+	ReadinessProbe *ContainerProbeSpec `json:"readinessProbe,omitempty"`
+
 	// HostNetwork indicates whether the Node Status Exporter pod uses the host's network namespace.
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable hostNetwork for NVIDIA Node Status Exporter"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	HostNetwork *bool `json:"hostNetwork,omitempty"`
+
+	// SchedulerName is the name of the scheduler placing the Node Status Exporter pods, overriding the
+	// scheduler configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Scheduler name for NVIDIA Node Status Exporter"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	SchedulerName string `json:"schedulerName,omitempty"`
+
+	// PriorityClassName is the name of the priority class of the Node Status Exporter pods, overriding the
+	// priority class configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Priority class name for NVIDIA Node Status Exporter"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// RuntimeClassName is the name of the runtime class of the Node Status Exporter pods, overriding the
+	// runtime class set by the operator
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Runtime class name for NVIDIA Node Status Exporter"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	RuntimeClassName string `json:"runtimeClassName,omitempty"`
+
+	// Tolerations are added to the tolerations of the Node Status Exporter pods, allowing them to run on tainted nodes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Tolerations for NVIDIA Node Status Exporter"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:io.kubernetes:Tolerations"
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// NodeAffinity is merged into the node affinity of the Node Status Exporter pods. Its required terms must not
+	// conflict with the node labels selected by the operator.
+	// +kubebuilder:validation:Optional
+	NodeAffinity *corev1.NodeAffinity `json:"nodeAffinity,omitempty"`
 }
 
 // DriverRepoConfigSpec defines custom repo configuration for NVIDIA Driver container
@@ -1295,22 +3829,81 @@ type DriverLicensingConfigSpec struct {
 	// Deprecated: ConfigMapName has been deprecated in favour of SecretName. Please use secrets to handle the licensing server configuration more securely
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="ConfigMap Name"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="ConfigMap Name"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// SecretName indicates the name of the secret containing the licensing token
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Secret Name"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	SecretName string `json:"secretName,omitempty"`
+
+	// NLSEnabled indicates if NVIDIA Licensing System is used for licensing.
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable NVIDIA Licensing System licensing"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	NLSEnabled *bool `json:"nlsEnabled,omitempty"`
+
+	// Monitor configures the periodic check of the reachability of the license server from the GPU nodes
+	// +kubebuilder:validation:Optional
+	Monitor *LicenseServerMonitorSpec `json:"monitor,omitempty"`
+}
+
+// LicenseServerMonitorSpec defines the monitoring of the reachability of the vGPU license server. A probe pod
+// is periodically run on a GPU node to open a connection to the license server, and the outcome is reported
+// by the LicenseServerUnreachable condition of the ClusterPolicy.
+type LicenseServerMonitorSpec struct {
+	// Enabled indicates if the reachability of the license server is monitored
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable license server monitoring"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Endpoint is the host:port of the NLS or DLS license server. Defaults to the ServerAddress and ServerPort
+	// of the gridd.conf of the licensing configuration, the endpoint must be set when licensing through NLS.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="License server endpoint"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Interval is the period between two checks of the license server, defaults to 5m
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="License server check interval"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// DriverModuleCacheSpec defines the configuration for caching kernel modules built on the node.
+// After a successful build, the driver container pushes the kernel modules as an OCI artifact
+// keyed by (driver version, kernel version, architecture). Nodes with the same key pull the
+// cached artifact instead of building the modules again.
+type DriverModuleCacheSpec struct {
+	// Enabled indicates if caching of built kernel modules is enabled
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable kernel module caching"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Repository is the OCI repository the kernel module artifacts are pushed to and pulled from,
+	// e.g. registry.local:5000/nvidia/driver-modules
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Repository"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
-	ConfigMapName string `json:"configMapName,omitempty"`
+	Repository string `json:"repository,omitempty"`
 
-	// SecretName indicates the name of the secret containing the licensing token
+	// SecretName is the name of a kubernetes.io/dockerconfigjson Secret with credentials for the repository
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Secret Name"
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:io.kubernetes:Secret"
 	SecretName string `json:"secretName,omitempty"`
-
-	// NLSEnabled indicates if NVIDIA Licensing System is used for licensing.
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable NVIDIA Licensing System licensing"
-	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
-	NLSEnabled *bool `json:"nlsEnabled,omitempty"`
 }
 
 // VirtualTopologyConfigSpec defines virtual topology daemon configuration with NVIDIA vGPU
@@ -1323,13 +3916,33 @@ type VirtualTopologyConfigSpec struct {
 	Config string `json:"config,omitempty"`
 }
 
-// KernelModuleConfigSpec defines custom configuration parameters for the NVIDIA Driver
+// KernelModuleConfigSpec defines custom configuration parameters for the NVIDIA Driver. The parameters are either
+// provided in a ConfigMap or set per kernel module, in which case the operator renders them in a ConfigMap.
+// +kubebuilder:validation:XValidation:rule="!has(self.name) || size(self.name) == 0 || (!has(self.nvidia) && !has(self.nvidiaUVM) && !has(self.nvidiaPeermem))",message="name cannot be set together with the parameters of the kernel modules"
 type KernelModuleConfigSpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="ConfigMap Name"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
 	Name string `json:"name,omitempty"`
+
+	// Optional: NVIDIA holds the parameters of the nvidia kernel module, e.g. NVreg_EnableGpuFirmware: "0"
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Parameters of the nvidia kernel module"
+	NVIDIA map[string]string `json:"nvidia,omitempty"`
+
+	// Optional: NVIDIAUVM holds the parameters of the nvidia_uvm kernel module
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Parameters of the nvidia_uvm kernel module"
+	NVIDIAUVM map[string]string `json:"nvidiaUVM,omitempty"`
+
+	// Optional: NVIDIAPeermem holds the parameters of the nvidia_peermem kernel module
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Parameters of the nvidia_peermem kernel module"
+	NVIDIAPeermem map[string]string `json:"nvidiaPeermem,omitempty"`
 }
 
 // RollingUpdateSpec defines configuration for the rolling update of all DaemonSet pods
@@ -1361,6 +3974,15 @@ type GPUFeatureDiscoverySpec struct {
 	// +kubebuilder:validation:Optional
 	Version string `json:"version,omitempty"`
 
+	// ArchImages overrides the GFD image on the nodes of an architecture, keyed by the
+	// kubernetes.io/arch label of the node (e.g. arm64), for the images not published for all architectures.
+	// The DaemonSet is deployed once per architecture of the GPU nodes set here.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="GFD images per architecture"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	ArchImages map[string]ArchImageSpec `json:"archImages,omitempty"`
+
 	// Image pull policy
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
@@ -1393,12 +4015,63 @@ type GPUFeatureDiscoverySpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
 	Env []EnvVar `json:"env,omitempty"`
 
+	// GPU Feature Discovery container startup probe settings
+	StartupProbe *ContainerProbeSpec `json:"startupProbe,omitempty"`
+
+	// GPU Feature Discovery container liveness probe settings
+	LivenessProbe *ContainerProbeSpec `json:"livenessProbe,omitempty"`
+
+	// GPU Feature Discovery container readiness probe settings
+	ReadinessProbe *ContainerProbeSpec `json:"readinessProbe,omitempty"`
+
 	// HostNetwork indicates whether the GPU Feature Discovery pod uses the host's network namespace.
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable hostNetwork for GPU Feature Discovery"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	HostNetwork *bool `json:"hostNetwork,omitempty"`
+
+	// SchedulerName is the name of the scheduler placing the GPU Feature Discovery pods, overriding the
+	// scheduler configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Scheduler name for GPU Feature Discovery"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	SchedulerName string `json:"schedulerName,omitempty"`
+
+	// PriorityClassName is the name of the priority class of the GPU Feature Discovery pods, overriding the
+	// priority class configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Priority class name for GPU Feature Discovery"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// RuntimeClassName is the name of the runtime class of the GPU Feature Discovery pods, overriding the
+	// runtime class set by the operator
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Runtime class name for GPU Feature Discovery"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	RuntimeClassName string `json:"runtimeClassName,omitempty"`
+
+	// Tolerations are added to the tolerations of the GPU Feature Discovery pods, allowing them to run on tainted nodes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Tolerations for GPU Feature Discovery"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:io.kubernetes:Tolerations"
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// NodeAffinity is merged into the node affinity of the GPU Feature Discovery pods. Its required terms must not
+	// conflict with the node labels selected by the operator.
+	// +kubebuilder:validation:Optional
+	NodeAffinity *corev1.NodeAffinity `json:"nodeAffinity,omitempty"`
 }
 
 // MIGManagerSpec defines the properties for deploying NVIDIA MIG Manager
@@ -1421,6 +4094,15 @@ type MIGManagerSpec struct {
 	// +kubebuilder:validation:Optional
 	Version string `json:"version,omitempty"`
 
+	// ArchImages overrides the MIG Manager image on the nodes of an architecture, keyed by the
+	// kubernetes.io/arch label of the node (e.g. arm64), for the images not published for all architectures.
+	// The DaemonSet is deployed once per architecture of the GPU nodes set here.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="MIG Manager images per architecture"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	ArchImages map[string]ArchImageSpec `json:"archImages,omitempty"`
+
 	// Image pull policy
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
@@ -1453,6 +4135,15 @@ type MIGManagerSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
 	Env []EnvVar `json:"env,omitempty"`
 
+	// NVIDIA MIG Manager container startup probe settings
+	StartupProbe *ContainerProbeSpec `json:"startupProbe,omitempty"`
+
+	// NVIDIA MIG Manager container liveness probe settings
+	LivenessProbe *ContainerProbeSpec `json:"livenessProbe,omitempty"`
+
+	// NVIDIA MIG Manager container readiness probe settings
+	ReadinessProbe *ContainerProbeSpec `json:"readinessProbe,omitempty"`
+
 	// Optional: Custom mig-parted configuration for NVIDIA MIG Manager container
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Custom mig-parted configuration for NVIDIA MIG Manager container"
@@ -1469,6 +4160,48 @@ type MIGManagerSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable hostNetwork for NVIDIA MIG Manager"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	HostNetwork *bool `json:"hostNetwork,omitempty"`
+
+	// SchedulerName is the name of the scheduler placing the MIG Manager pods, overriding the
+	// scheduler configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Scheduler name for NVIDIA MIG Manager"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	SchedulerName string `json:"schedulerName,omitempty"`
+
+	// PriorityClassName is the name of the priority class of the MIG Manager pods, overriding the
+	// priority class configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Priority class name for NVIDIA MIG Manager"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// RuntimeClassName is the name of the runtime class of the MIG Manager pods, overriding the
+	// runtime class set by the operator
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Runtime class name for NVIDIA MIG Manager"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	RuntimeClassName string `json:"runtimeClassName,omitempty"`
+
+	// Tolerations are added to the tolerations of the MIG Manager pods, allowing them to run on tainted nodes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Tolerations for NVIDIA MIG Manager"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:io.kubernetes:Tolerations"
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// NodeAffinity is merged into the node affinity of the MIG Manager pods. Its required terms must not
+	// conflict with the node labels selected by the operator.
+	// +kubebuilder:validation:Optional
+	NodeAffinity *corev1.NodeAffinity `json:"nodeAffinity,omitempty"`
 }
 
 // GPUDirectRDMASpec defines the properties for nvidia-peermem deployment
@@ -1483,6 +4216,49 @@ type GPUDirectRDMASpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Use MOFED drivers directly installed on the host to enable GPUDirect RDMA"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	UseHostMOFED *bool `json:"useHostMofed,omitempty"`
+
+	// NetworkAttachments pairs each GPU with its closest RDMA NIC and renders the SR-IOV networks of the pairs
+	// +kubebuilder:validation:Optional
+	NetworkAttachments *GPUDirectRDMANetworkAttachmentsSpec `json:"networkAttachments,omitempty"`
+}
+
+// GPUDirectRDMANetworkAttachmentsSpec defines the NetworkAttachmentDefinitions and the SR-IOV network node policies
+// rendered for the GPU-NIC pairs of the GPU nodes. The pairs are discovered from the PCIe topology of the nodes
+// by the operator validator, which labels every node with the RDMA device of its GPUs. The GPUs with the same
+// index form a rail, for which a NetworkAttachmentDefinition is rendered when the Multus API is served, along
+// with an SriovNetworkNodePolicy selecting the NICs of the rail when the SR-IOV network operator API is served.
+type GPUDirectRDMANetworkAttachmentsSpec struct {
+	// Enabled indicates if the GPU-NIC pairs are discovered and their networks rendered
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable the GPU-NIC network attachments"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Namespace of the NetworkAttachmentDefinitions, the operator namespace by default
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// ResourcePrefix is the prefix of the resources advertised by the SR-IOV device plugin for the rails
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=nvidia.com
+	ResourcePrefix string `json:"resourcePrefix,omitempty"`
+
+	// SRIOVOperatorNamespace is the namespace of the SR-IOV network operator, where the SriovNetworkNodePolicies
+	// are rendered
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=sriov-network-operator
+	SRIOVOperatorNamespace string `json:"sriovOperatorNamespace,omitempty"`
+
+	// NumVFs is the number of virtual functions created on every NIC of the rails
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=8
+	NumVFs int `json:"numVfs,omitempty"`
+
+	// IPAM is the JSON IPAM configuration of the CNI configuration of the NetworkAttachmentDefinitions
+	// +kubebuilder:validation:Optional
+	IPAM string `json:"ipam,omitempty"`
 }
 
 // GPUDirectStorageSpec defines the properties for NVIDIA GPUDirect Storage Driver deployment(Experimental)
@@ -1530,6 +4306,15 @@ type GPUDirectStorageSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment Variables"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
 	Env []EnvVar `json:"env,omitempty"`
+
+	// RequireSupportedHardware indicates if the GPUDirect Storage driver is only loaded on the nodes with NVMe
+	// or InfiniBand devices, detected when the driver pod starts. The nodes are labeled with
+	// nvidia.com/gpu.gds.capable, and the other nodes run the driver without GPUDirect Storage.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Require supported hardware"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	RequireSupportedHardware *bool `json:"requireSupportedHardware,omitempty"`
 }
 
 // GDRCopySpec defines the properties for NVIDIA GDRCopy driver (gdrdrv) deployment
@@ -1675,6 +4460,48 @@ type KataDevicePluginSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable hostNetwork for NVIDIA Kata Sandbox Device Plugin"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	HostNetwork *bool `json:"hostNetwork,omitempty"`
+
+	// SchedulerName is the name of the scheduler placing the Kata Sandbox Device Plugin pods, overriding the
+	// scheduler configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Scheduler name for NVIDIA Kata Sandbox Device Plugin"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	SchedulerName string `json:"schedulerName,omitempty"`
+
+	// PriorityClassName is the name of the priority class of the Kata Sandbox Device Plugin pods, overriding the
+	// priority class configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Priority class name for NVIDIA Kata Sandbox Device Plugin"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// RuntimeClassName is the name of the runtime class of the Kata Sandbox Device Plugin pods, overriding the
+	// runtime class set by the operator
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Runtime class name for NVIDIA Kata Sandbox Device Plugin"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	RuntimeClassName string `json:"runtimeClassName,omitempty"`
+
+	// Tolerations are added to the tolerations of the Kata Sandbox Device Plugin pods, allowing them to run on tainted nodes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Tolerations for NVIDIA Kata Sandbox Device Plugin"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:io.kubernetes:Tolerations"
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// NodeAffinity is merged into the node affinity of the Kata Sandbox Device Plugin pods. Its required terms must not
+	// conflict with the node labels selected by the operator.
+	// +kubebuilder:validation:Optional
+	NodeAffinity *corev1.NodeAffinity `json:"nodeAffinity,omitempty"`
 }
 
 // KataManagerSpec defines the configuration for the kata-manager which prepares NVIDIA-specific kata runtimes
@@ -1807,6 +4634,57 @@ type CCManagerSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable hostNetwork for NVIDIA CC Manager"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	HostNetwork *bool `json:"hostNetwork,omitempty"`
+
+	// SchedulerName is the name of the scheduler placing the CC Manager pods, overriding the
+	// scheduler configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Scheduler name for NVIDIA CC Manager"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	SchedulerName string `json:"schedulerName,omitempty"`
+
+	// PriorityClassName is the name of the priority class of the CC Manager pods, overriding the
+	// priority class configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Priority class name for NVIDIA CC Manager"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// RuntimeClassName is the name of the runtime class of the CC Manager pods, overriding the
+	// runtime class set by the operator
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Runtime class name for NVIDIA CC Manager"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	RuntimeClassName string `json:"runtimeClassName,omitempty"`
+
+	// Tolerations are added to the tolerations of the CC Manager pods, allowing them to run on tainted nodes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Tolerations for NVIDIA CC Manager"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:io.kubernetes:Tolerations"
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// NodeAffinity is merged into the node affinity of the CC Manager pods. Its required terms must not
+	// conflict with the node labels selected by the operator.
+	// +kubebuilder:validation:Optional
+	NodeAffinity *corev1.NodeAffinity `json:"nodeAffinity,omitempty"`
+
+	// CCCapableNodesOnly restricts the deployment of CC Manager to the nodes whose CPU supports
+	// confidential computing, i.e. AMD SEV-SNP or Intel TDX as detected by Node Feature Discovery.
+	// Disabled by default, in which case CC Manager is deployed to all the nodes selected for it
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Deploy CC Manager only on CC-capable nodes"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	CCCapableNodesOnly *bool `json:"ccCapableNodesOnly,omitempty"`
 }
 
 // VFIOManagerSpec defines the properties for deploying VFIO-PCI manager
@@ -1870,6 +4748,48 @@ type VFIOManagerSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable hostNetwork for NVIDIA VFIO Manager"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	HostNetwork *bool `json:"hostNetwork,omitempty"`
+
+	// SchedulerName is the name of the scheduler placing the VFIO Manager pods, overriding the
+	// scheduler configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Scheduler name for NVIDIA VFIO Manager"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	SchedulerName string `json:"schedulerName,omitempty"`
+
+	// PriorityClassName is the name of the priority class of the VFIO Manager pods, overriding the
+	// priority class configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Priority class name for NVIDIA VFIO Manager"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// RuntimeClassName is the name of the runtime class of the VFIO Manager pods, overriding the
+	// runtime class set by the operator
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Runtime class name for NVIDIA VFIO Manager"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	RuntimeClassName string `json:"runtimeClassName,omitempty"`
+
+	// Tolerations are added to the tolerations of the VFIO Manager pods, allowing them to run on tainted nodes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Tolerations for NVIDIA VFIO Manager"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:io.kubernetes:Tolerations"
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// NodeAffinity is merged into the node affinity of the VFIO Manager pods. Its required terms must not
+	// conflict with the node labels selected by the operator.
+	// +kubebuilder:validation:Optional
+	NodeAffinity *corev1.NodeAffinity `json:"nodeAffinity,omitempty"`
 }
 
 // VGPUDeviceManagerSpec defines the properties for deploying NVIDIA vGPU Device Manager
@@ -1935,6 +4855,79 @@ type VGPUDeviceManagerSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable hostNetwork for NVIDIA vGPU Device Manager"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	HostNetwork *bool `json:"hostNetwork,omitempty"`
+
+	// SchedulerName is the name of the scheduler placing the vGPU Device Manager pods, overriding the
+	// scheduler configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Scheduler name for NVIDIA vGPU Device Manager"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	SchedulerName string `json:"schedulerName,omitempty"`
+
+	// PriorityClassName is the name of the priority class of the vGPU Device Manager pods, overriding the
+	// priority class configured for all operand DaemonSets
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Priority class name for NVIDIA vGPU Device Manager"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// RuntimeClassName is the name of the runtime class of the vGPU Device Manager pods, overriding the
+	// runtime class set by the operator
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Runtime class name for NVIDIA vGPU Device Manager"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	RuntimeClassName string `json:"runtimeClassName,omitempty"`
+
+	// Tolerations are added to the tolerations of the vGPU Device Manager pods, allowing them to run on tainted nodes
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Tolerations for NVIDIA vGPU Device Manager"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:io.kubernetes:Tolerations"
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// NodeAffinity is merged into the node affinity of the vGPU Device Manager pods. Its required terms must not
+	// conflict with the node labels selected by the operator.
+	// +kubebuilder:validation:Optional
+	NodeAffinity *corev1.NodeAffinity `json:"nodeAffinity,omitempty"`
+
+	// Optional: Profiles declares the vGPU type profiles of the vm-vgpu nodes. The operator adds the profiles to
+	// the default vGPU devices ConfigMap and sets the nvidia.com/vgpu.config label of the vm-vgpu nodes matched
+	// by the node selector of a profile. Profiles cannot be combined with a custom vGPU devices ConfigMap.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="vGPU Type Profiles"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	Profiles []VGPUProfileSpec `json:"profiles,omitempty"`
+}
+
+// VGPUProfileSpec declares a vGPU type profile and the nodes it is applied to
+type VGPUProfileSpec struct {
+	// Name is the name of the profile in the vGPU devices configuration, and the value of the
+	// nvidia.com/vgpu.config label of the nodes it is applied to
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9]([-a-zA-Z0-9_.]*[a-zA-Z0-9])?$`
+	Name string `json:"name"`
+
+	// NodeSelector specifies the labels a vm-vgpu node must have to be assigned this profile. The first profile
+	// matching the labels of a node is applied. A profile without node selector is only added to the vGPU
+	// devices configuration.
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// VGPUDevices maps the vGPU types created on all GPUs of the node to the number of vGPU devices of each type
+	// +kubebuilder:validation:MinProperties=1
+	VGPUDevices map[string]int32 `json:"vgpuDevices"`
 }
 
 // VGPUDevicesConfigSpec defines vGPU devices configuration for NVIDIA vGPU Device Manager container
@@ -1979,6 +4972,15 @@ type CDIConfigSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable NRI as an additional mechanism for injecting CDI devices to gpu management containers."
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	NRIPluginEnabled *bool `json:"nriPluginEnabled,omitempty"`
+
+	// SandboxEnabled indicates whether the sandbox-device-plugin should generate CDI specs for VFIO-bound GPUs,
+	// allowing KubeVirt (v1.3 and newer) to consume passthrough devices through CDI rather than the legacy device-plugin API.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable CDI spec generation for VFIO-bound GPUs in sandbox workloads"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	SandboxEnabled *bool `json:"sandboxEnabled,omitempty"`
 }
 
 // MIGStrategy indicates MIG mode
@@ -2017,13 +5019,215 @@ type ClusterPolicyStatus struct {
 	Namespace string `json:"namespace,omitempty"`
 	// Conditions is a list of conditions representing the ClusterPolicy's current state.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// ValidationDomains reports the validation results of GPU nodes grouped by failure domain
+	// +kubebuilder:validation:Optional
+	ValidationDomains []ValidationDomainStatus `json:"validationDomains,omitempty"`
+	// Nodes reports the readiness of the operands deployed on each GPU node
+	// +kubebuilder:validation:Optional
+	Nodes []NodeOperandStatus `json:"nodes,omitempty"`
+	// SkippedValidations lists the components whose validation is disabled, and which are reported as ready without being validated
+	// +kubebuilder:validation:Optional
+	SkippedValidations []string `json:"skippedValidations,omitempty"`
+	// DriverUpgrade reports the progress of the driver upgrades managed by the operator, and previews the GPU
+	// workloads impacted by the next upgrade batch
+	// +kubebuilder:validation:Optional
+	DriverUpgrade *DriverUpgradeStatus `json:"driverUpgrade,omitempty"`
+	// SafeMode reports the safe mode entered on operand crash loops
+	// +kubebuilder:validation:Optional
+	SafeMode *SafeModeStatus `json:"safeMode,omitempty"`
+	// ExternalGates reports the external gates holding states
+	// +kubebuilder:validation:Optional
+	ExternalGates []ExternalGateStatus `json:"externalGates,omitempty"`
+	// RecreatedOperands reports the latest operand objects deleted and recreated by the operator, as a rendered
+	// change touched one of their immutable fields
+	// +kubebuilder:validation:Optional
+	RecreatedOperands []RecreatedOperandStatus `json:"recreatedOperands,omitempty"`
+	// Teardown reports the progress of the staged removal of the operands of the deleted ClusterPolicy
+	// +kubebuilder:validation:Optional
+	Teardown *TeardownStatus `json:"teardown,omitempty"`
+}
+
+// TeardownPhase is a phase of the staged removal of the operands
+type TeardownPhase string
+
+const (
+	// TeardownEvictingWorkloads is the phase evicting the GPU workloads from the GPU nodes
+	TeardownEvictingWorkloads TeardownPhase = "EvictingWorkloads"
+	// TeardownRemovingDevicePlugin is the phase removing the device plugin and the other operands using the GPUs
+	TeardownRemovingDevicePlugin TeardownPhase = "RemovingDevicePlugin"
+	// TeardownRemovingToolkit is the phase removing the container toolkit
+	TeardownRemovingToolkit TeardownPhase = "RemovingToolkit"
+	// TeardownRemovingDriver is the phase removing the driver and the vGPU manager
+	TeardownRemovingDriver TeardownPhase = "RemovingDriver"
+	// TeardownVerifyingModuleUnload is the phase verifying that the NVIDIA kernel modules are unloaded
+	TeardownVerifyingModuleUnload TeardownPhase = "VerifyingModuleUnload"
+)
+
+// TeardownStatus reports the progress of the staged removal of the operands
+type TeardownStatus struct {
+	// Phase is the current phase of the teardown
+	// +kubebuilder:validation:Enum=EvictingWorkloads;RemovingDevicePlugin;RemovingToolkit;RemovingDriver;VerifyingModuleUnload
+	Phase TeardownPhase `json:"phase"`
+	// PhaseStartTime is the time the current phase started
+	PhaseStartTime metav1.Time `json:"phaseStartTime"`
+	// Pending lists the objects the current phase is waiting for, e.g. the DaemonSets being removed or the GPU
+	// nodes with NVIDIA kernel modules still loaded
+	// +kubebuilder:validation:Optional
+	Pending []string `json:"pending,omitempty"`
+}
+
+// RecreatedOperandStatus reports an operand object deleted to be recreated by the operator
+type RecreatedOperandStatus struct {
+	// Kind is the kind of the recreated object
+	Kind string `json:"kind"`
+	// Name is the name of the recreated object
+	Name string `json:"name"`
+	// Fields lists the immutable fields changed by the rendered object
+	// +kubebuilder:validation:Optional
+	Fields []string `json:"fields,omitempty"`
+	// OrphanedDependents indicates whether the dependents of the object, such as the pods of a DaemonSet, were
+	// orphaned to be adopted by the recreated object instead of being deleted
+	// +kubebuilder:validation:Optional
+	OrphanedDependents bool `json:"orphanedDependents,omitempty"`
+	// DeletionTime is the time the object was deleted to be recreated
+	DeletionTime metav1.Time `json:"deletionTime"`
+}
+
+// ExternalGateStatus reports an external gate
+type ExternalGateStatus struct {
+	// Name of the external gate
+	Name string `json:"name"`
+	// Open indicates that the external gate does not hold its states, as it is met or timed out
+	Open bool `json:"open"`
+	// TimedOut indicates that the external gate was not met within its timeout
+	// +kubebuilder:validation:Optional
+	TimedOut bool `json:"timedOut,omitempty"`
+	// WaitingSince is the time the external gate was first found not met
+	// +kubebuilder:validation:Optional
+	WaitingSince *metav1.Time `json:"waitingSince,omitempty"`
+	// Message explains why the external gate is not met
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
+// SafeModeStatus reports the safe mode of the operator
+type SafeModeStatus struct {
+	// Active indicates that the operand rollouts are stopped until the safe mode is acknowledged
+	Active bool `json:"active"`
+	// Since is the time the safe mode was entered
+	// +kubebuilder:validation:Optional
+	Since *metav1.Time `json:"since,omitempty"`
+	// CrashLoopingNodes is the number of GPU nodes with crash looping operand pods when the safe mode was entered
+	// +kubebuilder:validation:Optional
+	CrashLoopingNodes int `json:"crashLoopingNodes,omitempty"`
+	// GPUNodes is the number of GPU nodes when the safe mode was entered
+	// +kubebuilder:validation:Optional
+	GPUNodes int `json:"gpuNodes,omitempty"`
+	// Revisions lists the crash looping operand DaemonSet revisions, as <DaemonSet>/<revision hash>
+	// +kubebuilder:validation:Optional
+	Revisions []string `json:"revisions,omitempty"`
+	// AcknowledgedRevisions lists the acknowledged crash looping revisions, which do not enter the safe mode again
+	// +kubebuilder:validation:Optional
+	AcknowledgedRevisions []string `json:"acknowledgedRevisions,omitempty"`
+}
+
+// IsActive returns true if the operand rollouts are stopped by the safe mode
+func (s *SafeModeStatus) IsActive() bool {
+	return s != nil && s.Active
+}
+
+// DriverUpgradeStatus reports the progress of the driver upgrades, and the GPU workloads they impact
+type DriverUpgradeStatus struct {
+	// PendingNodes is the number of nodes waiting for a driver upgrade
+	PendingNodes int `json:"pendingNodes"`
+	// InProgressNodes is the number of nodes being upgraded
+	InProgressNodes int `json:"inProgressNodes"`
+	// PendingGPUPods is the number of pods requesting GPUs on the nodes waiting for a driver upgrade
+	PendingGPUPods int `json:"pendingGPUPods"`
+	// NextBatch lists the nodes the next upgrade batch starts upgrading, with the GPU pods the batch evicts from them
+	// +kubebuilder:validation:Optional
+	NextBatch []DriverUpgradeNodeStatus `json:"nextBatch,omitempty"`
+}
+
+// DriverUpgradeNodeStatus reports the GPU workloads of a node to upgrade
+type DriverUpgradeNodeStatus struct {
+	// Name is the name of the node
+	Name string `json:"name"`
+	// GPUPods is the number of pods requesting GPUs on the node
+	GPUPods int `json:"gpuPods"`
+}
+
+// ValidationDomainStatus reports the validation results of the GPU nodes of a failure domain
+type ValidationDomainStatus struct {
+	// Domain is the value of the failure domain node label
+	Domain string `json:"domain"`
+	// Nodes is the number of GPU nodes in the failure domain that are validated by the operator
+	Nodes int `json:"nodes"`
+	// ValidatedNodes is the number of GPU nodes in the failure domain that passed validation
+	ValidatedNodes int `json:"validatedNodes"`
+	// FailedNodeCount is the number of GPU nodes in the failure domain that have not passed validation
+	// +kubebuilder:validation:Optional
+	FailedNodeCount int `json:"failedNodeCount,omitempty"`
+	// FailedNodes lists the GPU nodes in the failure domain that have not passed validation, truncated
+	// to the first 100 in name order
+	// +kubebuilder:validation:Optional
+	FailedNodes []string `json:"failedNodes,omitempty"`
+}
+
+// NodeOperandStatus summarizes the state of the operands deployed on a GPU node
+type NodeOperandStatus struct {
+	// Name is the name of the GPU node
+	Name string `json:"name"`
+	// DriverVersion is the version of the NVIDIA driver installed on the node, as reported by GPU Feature Discovery
+	// +kubebuilder:validation:Optional
+	DriverVersion string `json:"driverVersion,omitempty"`
+	// ToolkitReady indicates whether the container toolkit pod on the node is ready
+	ToolkitReady bool `json:"toolkitReady"`
+	// DevicePluginReady indicates whether the device plugin pod on the node is ready
+	DevicePluginReady bool `json:"devicePluginReady"`
+	// Validated indicates whether the node passed the operator validations
+	Validated bool `json:"validated"`
+	// UpgradeState is the driver upgrade state of the node, empty when no upgrade is managed for the node
+	// +kubebuilder:validation:Optional
+	UpgradeState string `json:"upgradeState,omitempty"`
+	// GDSState is the state of the GPUDirect Storage driver (nvidia-fs) on the node, empty when GPUDirect
+	// Storage is disabled
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Loaded;NotLoaded;Unsupported
+	GDSState GDSState `json:"gdsState,omitempty"`
+	// VGPUConfig is the vGPU type profile requested for the node through the nvidia.com/vgpu.config label, empty
+	// when the vGPU Device Manager is disabled
+	// +kubebuilder:validation:Optional
+	VGPUConfig string `json:"vgpuConfig,omitempty"`
+	// VGPUConfigState is the state of the vGPU type profile on the node as reported by the vGPU Device Manager,
+	// empty when the vGPU Device Manager is disabled
+	// +kubebuilder:validation:Optional
+	VGPUConfigState string `json:"vgpuConfigState,omitempty"`
+	// OperandsDisableAfter is the deadline after which the operands are removed from the node, as requested with
+	// the nvidia.com/gpu.operands.disable-after annotation, the GPU workloads of the node being drained until then.
+	// Empty when no opt-out is pending on the node.
+	// +kubebuilder:validation:Optional
+	OperandsDisableAfter *metav1.Time `json:"operandsDisableAfter,omitempty"`
 }
 
+// GDSState is the state of the GPUDirect Storage driver on a node
+type GDSState string
+
+const (
+	// GDSLoaded indicates the nvidia-fs kernel module is loaded
+	GDSLoaded GDSState = "Loaded"
+	// GDSNotLoaded indicates the nvidia-fs kernel module is not loaded yet
+	GDSNotLoaded GDSState = "NotLoaded"
+	// GDSUnsupported indicates the node has no hardware supported by GPUDirect Storage
+	GDSUnsupported GDSState = "Unsupported"
+)
+
 // +genclient
 // +genclient:nonNamespaced
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.state`,priority=0
 // +kubebuilder:printcolumn:name="Age",type=string,JSONPath=`.metadata.creationTimestamp`,priority=0
 
@@ -2045,6 +5249,26 @@ type ClusterPolicyList struct {
 	Items           []ClusterPolicy `json:"items"`
 }
 
+// IsScoped returns true if the ClusterPolicy is scoped to the GPU nodes matching its node selector
+func (p *ClusterPolicy) IsScoped() bool {
+	return len(p.Spec.NodeSelector) > 0
+}
+
+// ValidateNodeSelector rejects node selectors using the operator-managed routing label, and scoped
+// ClusterPolicies whose name is not a valid value of the routing label
+func (p *ClusterPolicy) ValidateNodeSelector() error {
+	if !p.IsScoped() {
+		return nil
+	}
+	if _, ok := p.Spec.NodeSelector[consts.ClusterPolicyOwnerLabel]; ok {
+		return fmt.Errorf("ClusterPolicy %q nodeSelector cannot use reserved label %q", p.Name, consts.ClusterPolicyOwnerLabel)
+	}
+	if errs := validation.IsValidLabelValue(p.Name); len(errs) > 0 {
+		return fmt.Errorf("scoped ClusterPolicy name %q is invalid: %s", p.Name, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
 // SetStatus sets state and namespace of ClusterPolicy instance
 func (p *ClusterPolicy) SetStatus(s State, ns string) {
 	p.Status.State = s
@@ -2115,6 +5339,9 @@ func ImagePath(spec interface{}) (string, error) {
 	case *ValidatorSpec:
 		config := spec.(*ValidatorSpec)
 		return imagePath(config.Repository, config.Image, config.Version, "VALIDATOR_IMAGE")
+	case *ValidatorImageSpec:
+		config := spec.(*ValidatorImageSpec)
+		return imagePath(config.Repository, config.Image, config.Version, "VALIDATOR_IMAGE")
 	case *InitContainerSpec:
 		config := spec.(*InitContainerSpec)
 		return imagePath(config.Repository, config.Image, config.Version, "CUDA_BASE_IMAGE")
@@ -2124,6 +5351,21 @@ func ImagePath(spec interface{}) (string, error) {
 	case *DriverManagerSpec:
 		config := spec.(*DriverManagerSpec)
 		return imagePath(config.Repository, config.Image, config.Version, "DRIVER_MANAGER_IMAGE")
+	case *DownloadCacheSpec:
+		config := spec.(*DownloadCacheSpec)
+		return imagePath(config.Repository, config.Image, config.Version, "DOWNLOAD_CACHE_IMAGE")
+	case *NodeFeatureDiscoverySpec:
+		config := spec.(*NodeFeatureDiscoverySpec)
+		return imagePath(config.Repository, config.Image, config.Version, "NFD_IMAGE")
+	case *GPUHealthCheckSpec:
+		config := spec.(*GPUHealthCheckSpec)
+		return imagePath(config.Repository, config.Image, config.Version, "VALIDATOR_IMAGE")
+	case *NVLinkFabricSpec:
+		config := spec.(*NVLinkFabricSpec)
+		return imagePath(config.Repository, config.Image, config.Version, "VALIDATOR_IMAGE")
+	case *ImagePrePullSpec:
+		config := spec.(*ImagePrePullSpec)
+		return imagePath(config.Repository, config.Image, config.Version, "VALIDATOR_IMAGE")
 	case *GPUDirectStorageSpec:
 		config := spec.(*GPUDirectStorageSpec)
 		return imagePath(config.Repository, config.Image, config.Version, "GDS_IMAGE")
@@ -2193,6 +5435,15 @@ func (d *DriverSpec) UsePrecompiledDrivers() bool {
 	return *d.UsePrecompiled
 }
 
+// IsOSUpgradePreStagingEnabled returns true if the driver is pre-staged for the upcoming OS upgrades of the nodes
+func (d *DriverSpec) IsOSUpgradePreStagingEnabled() bool {
+	if d.OSUpgradePreStaging == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *d.OSUpgradePreStaging
+}
+
 // OpenKernelModulesEnabled returns true if driver install is enabled using open GPU kernel modules
 func (d *DriverSpec) OpenKernelModulesEnabled() bool {
 	return d.KernelModuleType == "open"
@@ -2206,6 +5457,16 @@ func (d *DriverSpec) IsVGPULicensingEnabled() bool {
 	return d.LicensingConfig.ConfigMapName != "" || d.LicensingConfig.SecretName != ""
 }
 
+// IsModuleCacheEnabled returns true if caching of built kernel modules is enabled.
+// Module caching is only relevant when the driver is compiled on the node.
+func (d *DriverSpec) IsModuleCacheEnabled() bool {
+	if d.ModuleCache == nil || d.ModuleCache.Enabled == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *d.ModuleCache.Enabled && d.ModuleCache.Repository != "" && !d.UsePrecompiledDrivers()
+}
+
 // IsAutoUpgradeEnabled returns true if auto upgrade is enabled
 func (d *DriverSpec) IsAutoUpgradeEnabled() bool {
 	if d.UpgradePolicy == nil {
@@ -2214,6 +5475,33 @@ func (d *DriverSpec) IsAutoUpgradeEnabled() bool {
 	return d.UpgradePolicy.AutoUpgrade
 }
 
+// GetUpgradePolicy returns the auto-upgrade settings of the driver type, the gpu and vgpu driver types
+// defaulting to the upgradePolicy of the driver
+func (d *DriverSpec) GetUpgradePolicy(driverType DriverUpgradeType) *upgrade_v1alpha1.DriverUpgradePolicySpec {
+	switch driverType {
+	case DriverUpgradeTypeVGPUHostManager:
+		if d.UpgradePolicies == nil {
+			return nil
+		}
+		return d.UpgradePolicies.VGPUHostManager
+	case DriverUpgradeTypeGPU:
+		if d.UpgradePolicies != nil && d.UpgradePolicies.GPU != nil {
+			return d.UpgradePolicies.GPU
+		}
+	case DriverUpgradeTypeVGPU:
+		if d.UpgradePolicies != nil && d.UpgradePolicies.VGPU != nil {
+			return d.UpgradePolicies.VGPU
+		}
+	}
+	return d.UpgradePolicy
+}
+
+// IsAutoUpgradeEnabledFor returns true if auto upgrade is enabled for the driver type
+func (d *DriverSpec) IsAutoUpgradeEnabledFor(driverType DriverUpgradeType) bool {
+	policy := d.GetUpgradePolicy(driverType)
+	return policy != nil && policy.AutoUpgrade
+}
+
 // IsEnabled returns true if device-plugin is enabled(default) through gpu-operator
 func (p *DevicePluginSpec) IsEnabled() bool {
 	if p.Enabled == nil {
@@ -2223,6 +5511,36 @@ func (p *DevicePluginSpec) IsEnabled() bool {
 	return *p.Enabled
 }
 
+// IsKubeletRestartRecoveryEnabled returns true if the device plugin pods are restarted when the GPU resources of
+// a node are lost after a kubelet restart
+func (p *DevicePluginSpec) IsKubeletRestartRecoveryEnabled() bool {
+	if p.KubeletRestartRecovery == nil || p.KubeletRestartRecovery.Enabled == nil {
+		// default is true if not specified by user
+		return true
+	}
+	return *p.KubeletRestartRecovery.Enabled
+}
+
+// GetKubeletRestartRecoveryTimeoutSeconds returns the time in seconds the GPU resources of a node are waited for
+// after a kubelet restart, before the device plugin pods are restarted
+func (p *DevicePluginSpec) GetKubeletRestartRecoveryTimeoutSeconds() int {
+	if p.KubeletRestartRecovery == nil || p.KubeletRestartRecovery.TimeoutSeconds < 30 {
+		// default is 120 seconds if not specified by user
+		return 120
+	}
+	return p.KubeletRestartRecovery.TimeoutSeconds
+}
+
+// GetKubeletRestartRecoveryMaxRestarts returns the maximum number of restarts of the device plugin pods of a node
+// after a kubelet restart
+func (p *DevicePluginSpec) GetKubeletRestartRecoveryMaxRestarts() int {
+	if p.KubeletRestartRecovery == nil || p.KubeletRestartRecovery.MaxRestarts < 1 {
+		// default is 3 if not specified by user
+		return 3
+	}
+	return p.KubeletRestartRecovery.MaxRestarts
+}
+
 // IsEnabled returns true if dcgm-exporter is enabled(default) through gpu-operator
 func (e *DCGMExporterSpec) IsEnabled() bool {
 	if e.Enabled == nil {
@@ -2276,6 +5594,24 @@ func (e *DCGMExporterSpec) IsPodLabelsEnabled() bool {
 	return *e.EnablePodLabels
 }
 
+// IsIdleNodeHintsEnabled returns true if idle node hints are published from DCGM Exporter metrics
+func (e *DCGMExporterSpec) IsIdleNodeHintsEnabled() bool {
+	if !e.IsEnabled() || e.IdleNodeHints == nil || e.IdleNodeHints.Enabled == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *e.IdleNodeHints.Enabled
+}
+
+// GetIdleNodeHintsIntervalSeconds returns the interval in seconds at which idle node hints are refreshed
+func (e *DCGMExporterSpec) GetIdleNodeHintsIntervalSeconds() int {
+	if e.IdleNodeHints == nil || e.IdleNodeHints.IntervalSeconds < 10 {
+		// default is 60 seconds if not specified by user
+		return 60
+	}
+	return e.IdleNodeHints.IntervalSeconds
+}
+
 // IsPodUIDEnabled returns true if pod-UID enrichment is enabled for DCGM Exporter
 func (e *DCGMExporterSpec) IsPodUIDEnabled() bool {
 	if e.EnablePodUID == nil {
@@ -2288,7 +5624,52 @@ func (e *DCGMExporterSpec) IsPodUIDEnabled() bool {
 // IsKubernetesPodMetadataEnabled returns true if any Kubernetes pod metadata
 // enrichment is enabled for DCGM Exporter.
 func (e *DCGMExporterSpec) IsKubernetesPodMetadataEnabled() bool {
-	return e.IsPodLabelsEnabled() || e.IsPodUIDEnabled()
+	return e.IsPodLabelsEnabled() || e.IsPodUIDEnabled() || e.IsPodAttributionEnabled()
+}
+
+// IsPodAttributionEnabled returns true if pod attribution is explicitly enabled for DCGM Exporter
+func (e *DCGMExporterSpec) IsPodAttributionEnabled() bool {
+	if e.PodAttribution == nil || e.PodAttribution.Enabled == nil {
+		return false
+	}
+	return *e.PodAttribution.Enabled
+}
+
+// IsPodAttributionDisabled returns true if pod attribution is explicitly disabled for DCGM Exporter.
+// The kubelet pod-resources socket is mounted unless pod attribution is explicitly disabled.
+func (e *DCGMExporterSpec) IsPodAttributionDisabled() bool {
+	if e.PodAttribution == nil || e.PodAttribution.Enabled == nil {
+		return false
+	}
+	return !*e.PodAttribution.Enabled
+}
+
+// ValidatePodAttribution checks that the pod attribution of DCGM Exporter is consistent with the kubelet
+// root directory, where the pod-resources socket is mounted from, and with the other exporter settings
+func (e *DCGMExporterSpec) ValidatePodAttribution(kubeletRootDir string) error {
+	if e.PodAttribution == nil {
+		return nil
+	}
+	if e.IsPodAttributionEnabled() && len(kubeletRootDir) > 0 && !filepath.IsAbs(kubeletRootDir) {
+		return fmt.Errorf("the kubelet root directory %q must be an absolute path for DCGM Exporter pod attribution", kubeletRootDir)
+	}
+	if e.IsPodAttributionDisabled() && (e.IsPodLabelsEnabled() || e.IsPodUIDEnabled()) {
+		return fmt.Errorf("DCGM Exporter pod labels and pod UID cannot be enabled when pod attribution is disabled")
+	}
+	for _, env := range e.Env {
+		if env.Name == "DCGM_EXPORTER_KUBERNETES" && env.Value != strconv.FormatBool(e.IsPodAttributionEnabled()) {
+			return fmt.Errorf("the DCGM_EXPORTER_KUBERNETES environment variable of DCGM Exporter conflicts with podAttribution.enabled")
+		}
+	}
+	return nil
+}
+
+// GetPodAttributionGPUIDType returns the GPU identifier used to attribute GPU metrics to pods
+func (e *DCGMExporterSpec) GetPodAttributionGPUIDType() string {
+	if e.PodAttribution == nil {
+		return ""
+	}
+	return e.PodAttribution.GPUIDType
 }
 
 // IsEnabled returns true if gpu-feature-discovery is enabled(default) through gpu-operator
@@ -2327,6 +5708,44 @@ func (v *VGPUDeviceManagerSpec) IsEnabled() bool {
 	return *v.Enabled
 }
 
+// GetProfileForNode returns the name of the first vGPU type profile whose node selector matches the node
+// labels, or an empty string if no profile matches
+func (v *VGPUDeviceManagerSpec) GetProfileForNode(nodeLabels map[string]string) string {
+	for _, profile := range v.Profiles {
+		if len(profile.NodeSelector) == 0 {
+			continue
+		}
+		matches := true
+		for key, value := range profile.NodeSelector {
+			if nodeLabels[key] != value {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return profile.Name
+		}
+	}
+	return ""
+}
+
+// HasProfile returns true if a vGPU type profile with the given name is declared
+func (v *VGPUDeviceManagerSpec) HasProfile(name string) bool {
+	return slices.ContainsFunc(v.Profiles, func(profile VGPUProfileSpec) bool { return profile.Name == name })
+}
+
+// ValidateProfiles returns an error if vGPU type profiles are declared together with a custom vGPU devices
+// ConfigMap, which is not managed by the operator
+func (v *VGPUDeviceManagerSpec) ValidateProfiles() error {
+	if len(v.Profiles) == 0 {
+		return nil
+	}
+	if v.Config != nil && v.Config.Name != "" {
+		return fmt.Errorf("vGPU type profiles cannot be combined with the custom vGPU devices ConfigMap %s", v.Config.Name)
+	}
+	return nil
+}
+
 // IsEnabled returns true if container-toolkit install is enabled(default) through gpu-operator
 func (t *ToolkitSpec) IsEnabled() bool {
 	if t.Enabled == nil {
@@ -2336,6 +5755,22 @@ func (t *ToolkitSpec) IsEnabled() bool {
 	return *t.Enabled
 }
 
+// IsRuntimeRollbackEnabled returns true if the containerd configuration is restored when containerd does
+// not come back healthy after the Container Toolkit restarted it
+func (t *ToolkitSpec) IsRuntimeRollbackEnabled() bool {
+	if t.RuntimeRollback == nil || t.RuntimeRollback.Enabled == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *t.RuntimeRollback.Enabled
+}
+
+// IsCRIODropInConfigEnabled returns true if the Container Toolkit configures the NVIDIA runtime handlers of cri-o
+// in a drop-in file only
+func (t *ToolkitSpec) IsCRIODropInConfigEnabled() bool {
+	return t.CRIOConfigMode == CRIOConfigModeDropIn
+}
+
 // IsEnabled returns true if the cluster intends to run GPU accelerated
 // workloads in sandboxed environments (VMs).
 func (s *SandboxWorkloadsSpec) IsEnabled() bool {
@@ -2346,6 +5781,49 @@ func (s *SandboxWorkloadsSpec) IsEnabled() bool {
 	return *s.Enabled
 }
 
+// IsManageRuntimeClassesEnabled returns true if the operator manages the Kata RuntimeClasses
+func (k *KataRuntimeSpec) IsManageRuntimeClassesEnabled() bool {
+	if k == nil || k.ManageRuntimeClasses == nil {
+		// Kata RuntimeClasses are managed by default
+		return true
+	}
+	return *k.ManageRuntimeClasses
+}
+
+// GetRuntimeClasses returns the Kata RuntimeClasses, with their handlers defaulted
+func (k *KataRuntimeSpec) GetRuntimeClasses() []KataRuntimeClassSpec {
+	var runtimeClasses []KataRuntimeClassSpec
+	if k == nil || len(k.RuntimeClasses) == 0 {
+		for _, name := range []string{"kata-qemu-nvidia-gpu", "kata-qemu-nvidia-gpu-snp", "kata-qemu-nvidia-gpu-tdx"} {
+			runtimeClasses = append(runtimeClasses, KataRuntimeClassSpec{Name: name})
+		}
+	} else {
+		for _, rc := range k.RuntimeClasses {
+			runtimeClasses = append(runtimeClasses, *rc.DeepCopy())
+		}
+	}
+	for i := range runtimeClasses {
+		if runtimeClasses[i].Handler == "" {
+			runtimeClasses[i].Handler = runtimeClasses[i].Name
+		}
+	}
+	return runtimeClasses
+}
+
+// GetArtifactsDaemonSet returns the name and namespace of the DaemonSet installing the Kata artifacts
+func (k *KataRuntimeSpec) GetArtifactsDaemonSet() (string, string) {
+	name, namespace := "kata-deploy", "kata-system"
+	if k != nil && k.ArtifactsDaemonSet != nil {
+		if k.ArtifactsDaemonSet.Name != "" {
+			name = k.ArtifactsDaemonSet.Name
+		}
+		if k.ArtifactsDaemonSet.Namespace != "" {
+			namespace = k.ArtifactsDaemonSet.Namespace
+		}
+	}
+	return name, namespace
+}
+
 // IsEnabled returns true if the sandbox device plugin is enabled through gpu-operator
 func (s *SandboxDevicePluginSpec) IsEnabled() bool {
 	if s.Enabled == nil {
@@ -2373,6 +5851,15 @@ func (p *PSASpec) IsEnabled() bool {
 	return *p.Enabled
 }
 
+// IsManageNamespaceLabelsEnabled returns true if the operator sets the PodSecurity enforce label of its namespace
+func (p *PSASpec) IsManageNamespaceLabelsEnabled() bool {
+	if p.ManageNamespaceLabels == nil {
+		// namespace labels are not managed by default
+		return false
+	}
+	return *p.ManageNamespaceLabels
+}
+
 // IsEnabled returns true if mig-manager is enabled(default) through gpu-operator
 func (m *MIGManagerSpec) IsEnabled() bool {
 	if m.Enabled == nil {
@@ -2410,6 +5897,47 @@ func (g *GPUDirectRDMASpec) IsHostMOFED() bool {
 	return g.IsEnabled() && *g.UseHostMOFED
 }
 
+// IsNetworkAttachmentsEnabled returns true if the networks of the GPU-NIC pairs are rendered
+func (g *GPUDirectRDMASpec) IsNetworkAttachmentsEnabled() bool {
+	if g.NetworkAttachments == nil || g.NetworkAttachments.Enabled == nil {
+		// the network attachments are disabled by default
+		return false
+	}
+	return g.IsEnabled() && *g.NetworkAttachments.Enabled
+}
+
+// GetNetworkAttachments returns the network attachments of the GPU-NIC pairs, empty when not set
+func (g *GPUDirectRDMASpec) GetNetworkAttachments() *GPUDirectRDMANetworkAttachmentsSpec {
+	if g == nil || g.NetworkAttachments == nil {
+		return &GPUDirectRDMANetworkAttachmentsSpec{}
+	}
+	return g.NetworkAttachments
+}
+
+// GetResourcePrefix returns the prefix of the resources advertised for the rails
+func (n *GPUDirectRDMANetworkAttachmentsSpec) GetResourcePrefix() string {
+	if n.ResourcePrefix == "" {
+		return "nvidia.com"
+	}
+	return n.ResourcePrefix
+}
+
+// GetSRIOVOperatorNamespace returns the namespace of the SR-IOV network operator
+func (n *GPUDirectRDMANetworkAttachmentsSpec) GetSRIOVOperatorNamespace() string {
+	if n.SRIOVOperatorNamespace == "" {
+		return "sriov-network-operator"
+	}
+	return n.SRIOVOperatorNamespace
+}
+
+// GetNumVFs returns the number of virtual functions created on the NICs of the rails
+func (n *GPUDirectRDMANetworkAttachmentsSpec) GetNumVFs() int {
+	if n.NumVFs <= 0 {
+		return 8
+	}
+	return n.NumVFs
+}
+
 // IsEnabled returns true if GPUDirect Storage are enabled through gpu-operator
 func (gds *GPUDirectStorageSpec) IsEnabled() bool {
 	if gds.Enabled == nil {
@@ -2419,6 +5947,293 @@ func (gds *GPUDirectStorageSpec) IsEnabled() bool {
 	return *gds.Enabled
 }
 
+// IsSupportedHardwareRequired returns true if GPUDirect Storage is only enabled on nodes with supported hardware
+func (gds *GPUDirectStorageSpec) IsSupportedHardwareRequired() bool {
+	if gds.RequireSupportedHardware == nil {
+		// GPUDirect Storage is enabled on all GPU nodes by default
+		return false
+	}
+	return *gds.RequireSupportedHardware
+}
+
+// IsEnabled returns true if the fleet report is enabled
+func (f *FleetReportSpec) IsEnabled() bool {
+	if f == nil || f.Enabled == nil {
+		// fleet report is disabled by default
+		return false
+	}
+	return *f.Enabled
+}
+
+// GetInterval returns the duration between two fleet reports
+func (f *FleetReportSpec) GetInterval() time.Duration {
+	if f == nil || f.Interval == nil || f.Interval.Duration <= 0 {
+		// default is a daily report if not specified by user
+		return 24 * time.Hour
+	}
+	return f.Interval.Duration
+}
+
+// GetHistoryLimit returns the number of fleet reports retained
+func (f *FleetReportSpec) GetHistoryLimit() int {
+	if f == nil || f.HistoryLimit == nil || *f.HistoryLimit < 1 {
+		// default is a week of daily reports if not specified by user
+		return 7
+	}
+	return int(*f.HistoryLimit)
+}
+
+// IsEnabled returns true if the usage accounting is enabled
+func (u *UsageAccountingSpec) IsEnabled() bool {
+	if u == nil || u.Enabled == nil {
+		// usage accounting is disabled by default
+		return false
+	}
+	return *u.Enabled
+}
+
+// GetInterval returns the period covered by each usage report
+func (u *UsageAccountingSpec) GetInterval() time.Duration {
+	if u == nil || u.Interval == nil || u.Interval.Duration <= 0 {
+		// default is an hourly report if not specified by user
+		return time.Hour
+	}
+	return u.Interval.Duration
+}
+
+// GetHistoryLimit returns the number of usage reports retained
+func (u *UsageAccountingSpec) GetHistoryLimit() int {
+	if u == nil || u.HistoryLimit == nil || *u.HistoryLimit < 1 {
+		// default is a day of hourly reports if not specified by user
+		return 24
+	}
+	return int(*u.HistoryLimit)
+}
+
+// GetFormat returns the format of the usage records
+func (u *UsageAccountingSpec) GetFormat() UsageAccountingFormat {
+	if u == nil || u.Format == "" {
+		return UsageAccountingFormatJSON
+	}
+	return u.Format
+}
+
+// GetConfigForNode returns the MIG configuration of the first config selector matching
+// the node labels, or an empty string if no selector matches
+func (m *MIGSpec) GetConfigForNode(nodeLabels map[string]string) string {
+	for _, selector := range m.ConfigSelectors {
+		matches := true
+		for key, value := range selector.NodeSelector {
+			if nodeLabels[key] != value {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return selector.Config
+		}
+	}
+	return ""
+}
+
+// IsTimeSlicingEnabled returns true if the time-slicing configuration of the NVIDIA Device Plugin is managed by the operator
+func (p *DevicePluginSpec) IsTimeSlicingEnabled() bool {
+	if p.TimeSlicing == nil || len(p.TimeSlicing.Resources) == 0 {
+		return false
+	}
+	// a custom ConfigMap takes precedence over the time-slicing configuration
+	return p.Config == nil || p.Config.Name == ""
+}
+
+// IsMPSSharingEnabled returns true if the MPS sharing configuration of the NVIDIA Device Plugin is managed by the operator
+func (p *DevicePluginSpec) IsMPSSharingEnabled() bool {
+	if p.MPS == nil || len(p.MPS.Resources) == 0 {
+		return false
+	}
+	// a custom ConfigMap takes precedence over the MPS sharing configuration
+	return p.Config == nil || p.Config.Name == ""
+}
+
+// IsSharingManaged returns true if the sharing configuration of the NVIDIA Device Plugin, through time-slicing
+// or MPS, is rendered by the operator
+func (p *DevicePluginSpec) IsSharingManaged() bool {
+	return p.IsTimeSlicingEnabled() || p.IsMPSSharingEnabled()
+}
+
+// IsEnabled returns true if the MPS control daemon is enabled
+func (m *MPSControlDaemonSpec) IsEnabled() bool {
+	if m == nil || m.Enabled == nil {
+		// the MPS control daemon is enabled by default
+		return true
+	}
+	return *m.Enabled
+}
+
+// IsEnabled returns true if the validation of the component is enabled
+func (v *ValidatorComponentSpec) IsEnabled() bool {
+	if v.Enabled == nil {
+		// validation is enabled by default
+		return true
+	}
+	return *v.Enabled
+}
+
+// GetComponentSpec returns the validation options of a component, or nil for an unknown component
+func (v *ValidatorSpec) GetComponentSpec(component string) *ValidatorComponentSpec {
+	switch component {
+	case "driver":
+		return &v.Driver.ValidatorComponentSpec
+	case "toolkit":
+		return &v.Toolkit.ValidatorComponentSpec
+	case "cuda":
+		return &v.CUDA.ValidatorComponentSpec
+	case "plugin":
+		return &v.Plugin.ValidatorComponentSpec
+	case "vfio-pci":
+		return &v.VFIOPCI.ValidatorComponentSpec
+	case "vgpu-manager":
+		return &v.VGPUManager.ValidatorComponentSpec
+	case "vgpu-devices":
+		return &v.VGPUDevices.ValidatorComponentSpec
+	case "cc-manager":
+		return &v.CCManager.ValidatorComponentSpec
+	}
+	return nil
+}
+
+// GetSkippedComponents returns the components whose validation is disabled
+func (v *ValidatorSpec) GetSkippedComponents() []string {
+	var skipped []string
+	for _, component := range []string{"driver", "toolkit", "cuda", "plugin", "vfio-pci", "vgpu-manager", "vgpu-devices", "cc-manager"} {
+		if !v.GetComponentSpec(component).IsEnabled() {
+			skipped = append(skipped, component)
+		}
+	}
+	return skipped
+}
+
+// GetFailureDomainLabel returns the node label used to group validation results by failure domain
+func (v *ValidatorSpec) GetFailureDomainLabel() string {
+	if v.FailureDomainLabel == "" {
+		// default is the well-known zone label if not specified by user
+		return corev1.LabelTopologyZone
+	}
+	return v.FailureDomainLabel
+}
+
+// SelectImage returns the key of the validator image selected for the nodes of the given architecture and OS,
+// which is empty when they run the validator image of the spec
+func (v *ValidatorSpec) SelectImage(arch, os string) string {
+	for _, key := range []string{arch + "/" + os, os, arch} {
+		if _, ok := v.Images[key]; ok && key != "" {
+			return key
+		}
+	}
+	return ""
+}
+
+// ValidateImages checks that every validator image is set, and that its key can be used to name the DaemonSets
+// running it and to label the nodes selecting it
+func (v *ValidatorSpec) ValidateImages() error {
+	for key, image := range v.Images {
+		parts := strings.Split(key, "/")
+		if len(parts) > 2 || slices.Contains(parts, "") {
+			return fmt.Errorf("invalid validator image key %q, expected <arch>, <os> or <arch>/<os>", key)
+		}
+		value := ValidatorImageLabelValue(key)
+		if errs := append(validation.IsValidLabelValue(value), validation.IsDNS1123Subdomain(value)...); len(errs) > 0 {
+			return fmt.Errorf("invalid validator image key %q: %s", key, strings.Join(errs, ", "))
+		}
+		if image.Image == "" {
+			return fmt.Errorf("the validator image of %q is not set", key)
+		}
+	}
+	return nil
+}
+
+// ValidatorImageLabelValue returns the value of the node label selecting the validator image of the given key
+func ValidatorImageLabelValue(key string) string {
+	return strings.ReplaceAll(key, "/", "-")
+}
+
+// ArchImagePath returns the image of the component on the nodes of the given architecture, which is the image
+// of the component when it has no image for the architecture
+func ArchImagePath(spec interface{}, arch string) (string, error) {
+	var archImages map[string]ArchImageSpec
+	var image ArchImageSpec
+	var imagePathEnvName string
+	switch config := spec.(type) {
+	case *ToolkitSpec:
+		archImages, imagePathEnvName = config.ArchImages, "CONTAINER_TOOLKIT_IMAGE"
+		image = ArchImageSpec{Repository: config.Repository, Image: config.Image, Version: config.Version}
+	case *DevicePluginSpec:
+		archImages, imagePathEnvName = config.ArchImages, "DEVICE_PLUGIN_IMAGE"
+		image = ArchImageSpec{Repository: config.Repository, Image: config.Image, Version: config.Version}
+	case *DCGMExporterSpec:
+		archImages, imagePathEnvName = config.ArchImages, "DCGM_EXPORTER_IMAGE"
+		image = ArchImageSpec{Repository: config.Repository, Image: config.Image, Version: config.Version}
+	case *DCGMSpec:
+		archImages, imagePathEnvName = config.ArchImages, "DCGM_IMAGE"
+		image = ArchImageSpec{Repository: config.Repository, Image: config.Image, Version: config.Version}
+	case *GPUFeatureDiscoverySpec:
+		archImages, imagePathEnvName = config.ArchImages, "GFD_IMAGE"
+		image = ArchImageSpec{Repository: config.Repository, Image: config.Image, Version: config.Version}
+	case *MIGManagerSpec:
+		archImages, imagePathEnvName = config.ArchImages, "MIG_MANAGER_IMAGE"
+		image = ArchImageSpec{Repository: config.Repository, Image: config.Image, Version: config.Version}
+	default:
+		return "", fmt.Errorf("per-architecture images are not supported for %T", spec)
+	}
+
+	override, ok := archImages[arch]
+	if !ok {
+		return ImagePath(spec)
+	}
+	if override.Repository != "" {
+		image.Repository = override.Repository
+	}
+	if override.Image != "" {
+		image.Image = override.Image
+	}
+	if override.Version != "" {
+		image.Version = override.Version
+	}
+	return imagePath(image.Repository, image.Image, image.Version, imagePathEnvName)
+}
+
+// ValidateArchImages checks that the architectures of the per-architecture images of the components can be used
+// to name the DaemonSets running them and to select the nodes of the architecture
+func (c *ClusterPolicySpec) ValidateArchImages() error {
+	components := map[string]map[string]ArchImageSpec{
+		"toolkit":      c.Toolkit.ArchImages,
+		"devicePlugin": c.DevicePlugin.ArchImages,
+		"dcgmExporter": c.DCGMExporter.ArchImages,
+		"dcgm":         c.DCGM.ArchImages,
+		"gfd":          c.GPUFeatureDiscovery.ArchImages,
+		"migManager":   c.MIGManager.ArchImages,
+	}
+	for component, archImages := range components {
+		for arch, image := range archImages {
+			if errs := validation.IsDNS1123Label(arch); len(errs) > 0 {
+				return fmt.Errorf("invalid %s image architecture %q: %s", component, arch, strings.Join(errs, ", "))
+			}
+			if image == (ArchImageSpec{}) {
+				return fmt.Errorf("the %s image of architecture %q is not set", component, arch)
+			}
+		}
+	}
+	return nil
+}
+
+// IsDigestPinningEnabled returns true if operand images should be pinned to digests
+func (c *ClusterPolicySpec) IsDigestPinningEnabled() bool {
+	if c.ImageResolution == nil {
+		// images are rendered with tags by default
+		return false
+	}
+	return c.ImageResolution.Policy == ImageResolutionDigest
+}
+
 // IsGDRCopyEnabled returns true if GDRCopy is enabled through gpu-operator
 func (c *ClusterPolicySpec) IsGDRCopyEnabled() bool {
 	if c.GDRCopy == nil {
@@ -2464,6 +6279,29 @@ func (l *DriverLicensingConfigSpec) IsNLSEnabled() bool {
 	return *l.NLSEnabled
 }
 
+// IsMonitorEnabled returns true if the reachability of the license server is monitored
+func (l *DriverLicensingConfigSpec) IsMonitorEnabled() bool {
+	if l == nil || l.Monitor == nil || l.Monitor.Enabled == nil {
+		// the license server is not monitored by default
+		return false
+	}
+	return *l.Monitor.Enabled
+}
+
+// GetInterval returns the period between two checks of the license server
+func (m *LicenseServerMonitorSpec) GetInterval() time.Duration {
+	if m == nil || m.Interval == nil || m.Interval.Duration <= 0 {
+		return 5 * time.Minute
+	}
+	return m.Interval.Duration
+}
+
+// IsConfigMapImmutable returns true if the ConfigMaps rendered for the operands are immutable and renamed on
+// changes
+func (d *DaemonsetsSpec) IsConfigMapImmutable() bool {
+	return d.ConfigMapUpdateStrategy == ConfigMapUpdateImmutable
+}
+
 // IsEnabled returns true if CDI is enabled as a mechanism for
 // providing GPU access to containers
 func (c *CDIConfigSpec) IsEnabled() bool {
@@ -2473,6 +6311,15 @@ func (c *CDIConfigSpec) IsEnabled() bool {
 	return *c.Enabled
 }
 
+// IsSandboxEnabled returns true if CDI specs should be generated for VFIO-bound GPUs
+// by the sandbox-device-plugin
+func (c *CDIConfigSpec) IsSandboxEnabled() bool {
+	if c.SandboxEnabled == nil {
+		return false
+	}
+	return *c.SandboxEnabled
+}
+
 // IsNRIPluginEnabled returns true if NRI Plugin is enabled as a mechanism for
 // injecting CDI devices to containers
 func (c *CDIConfigSpec) IsNRIPluginEnabled() bool {
@@ -2499,6 +6346,15 @@ func (c *CCManagerSpec) IsEnabled() bool {
 	return *c.Enabled
 }
 
+// IsCCCapableNodesOnly returns true if CC Manager is only deployed to nodes
+// with a SEV-SNP or TDX capable CPU. Default is false if not specified by user.
+func (c *CCManagerSpec) IsCCCapableNodesOnly() bool {
+	if c.CCCapableNodesOnly == nil {
+		return false
+	}
+	return *c.CCCapableNodesOnly
+}
+
 // +kubebuilder:object:generate=false
 type ConfigWithName interface {
 	GetName() string
@@ -2524,3 +6380,40 @@ func (c *MIGPartedConfigSpec) GetName() string {
 func (c *VGPUDevicesConfigSpec) GetName() string {
 	return ptr.Deref(c, VGPUDevicesConfigSpec{}).Name
 }
+
+// HasModuleParameters returns true if the parameters of a kernel module are set
+func (k *KernelModuleConfigSpec) HasModuleParameters() bool {
+	if k == nil {
+		return false
+	}
+	return len(k.NVIDIA) > 0 || len(k.NVIDIAUVM) > 0 || len(k.NVIDIAPeermem) > 0
+}
+
+// ModuleParameterFiles returns the configuration files of the kernel modules read by the driver container, keyed
+// by file name. Each file lists the parameters of a module, one key=value per line sorted by key.
+func (k *KernelModuleConfigSpec) ModuleParameterFiles() map[string]string {
+	if !k.HasModuleParameters() {
+		return nil
+	}
+	files := map[string]string{}
+	for file, params := range map[string]map[string]string{
+		"nvidia.conf":         k.NVIDIA,
+		"nvidia-uvm.conf":     k.NVIDIAUVM,
+		"nvidia-peermem.conf": k.NVIDIAPeermem,
+	} {
+		if len(params) == 0 {
+			continue
+		}
+		keys := make([]string, 0, len(params))
+		for key := range params {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var sb strings.Builder
+		for _, key := range keys {
+			fmt.Fprintf(&sb, "%s=%s\n", key, params[key])
+		}
+		files[file] = sb.String()
+	}
+	return files
+}
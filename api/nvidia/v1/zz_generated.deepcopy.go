@@ -29,6 +29,41 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArchImageSpec) DeepCopyInto(out *ArchImageSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArchImageSpec.
+func (in *ArchImageSpec) DeepCopy() *ArchImageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArchImageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingHintsSpec) DeepCopyInto(out *AutoscalingHintsSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingHintsSpec.
+func (in *AutoscalingHintsSpec) DeepCopy() *AutoscalingHintsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingHintsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CCManagerSpec) DeepCopyInto(out *CCManagerSpec) {
 	*out = *in
@@ -62,6 +97,23 @@ func (in *CCManagerSpec) DeepCopyInto(out *CCManagerSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.CCCapableNodesOnly != nil {
+		in, out := &in.CCCapableNodesOnly, &out.CCCapableNodesOnly
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeAffinity != nil {
+		in, out := &in.NodeAffinity, &out.NodeAffinity
+		*out = new(corev1.NodeAffinity)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CCManagerSpec.
@@ -74,6 +126,27 @@ func (in *CCManagerSpec) DeepCopy() *CCManagerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CCManagerValidatorSpec) DeepCopyInto(out *CCManagerValidatorSpec) {
+	*out = *in
+	in.ValidatorComponentSpec.DeepCopyInto(&out.ValidatorComponentSpec)
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]EnvVar, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CCManagerValidatorSpec.
+func (in *CCManagerValidatorSpec) DeepCopy() *CCManagerValidatorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CCManagerValidatorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CDIConfigSpec) DeepCopyInto(out *CDIConfigSpec) {
 	*out = *in
@@ -92,6 +165,11 @@ func (in *CDIConfigSpec) DeepCopyInto(out *CDIConfigSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.SandboxEnabled != nil {
+		in, out := &in.SandboxEnabled, &out.SandboxEnabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CDIConfigSpec.
@@ -107,6 +185,7 @@ func (in *CDIConfigSpec) DeepCopy() *CDIConfigSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CUDAValidatorSpec) DeepCopyInto(out *CUDAValidatorSpec) {
 	*out = *in
+	in.ValidatorComponentSpec.DeepCopyInto(&out.ValidatorComponentSpec)
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
 		*out = make([]EnvVar, len(*in))
@@ -188,6 +267,13 @@ func (in *ClusterPolicySpec) DeepCopyInto(out *ClusterPolicySpec) {
 	*out = *in
 	in.Operator.DeepCopyInto(&out.Operator)
 	in.Daemonsets.DeepCopyInto(&out.Daemonsets)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	in.Driver.DeepCopyInto(&out.Driver)
 	in.Toolkit.DeepCopyInto(&out.Toolkit)
 	in.DevicePlugin.DeepCopyInto(&out.DevicePlugin)
@@ -195,7 +281,7 @@ func (in *ClusterPolicySpec) DeepCopyInto(out *ClusterPolicySpec) {
 	in.DCGM.DeepCopyInto(&out.DCGM)
 	in.NodeStatusExporter.DeepCopyInto(&out.NodeStatusExporter)
 	in.GPUFeatureDiscovery.DeepCopyInto(&out.GPUFeatureDiscovery)
-	out.MIG = in.MIG
+	in.MIG.DeepCopyInto(&out.MIG)
 	in.MIGManager.DeepCopyInto(&out.MIGManager)
 	in.PSP.DeepCopyInto(&out.PSP)
 	in.PSA.DeepCopyInto(&out.PSA)
@@ -220,6 +306,127 @@ func (in *ClusterPolicySpec) DeepCopyInto(out *ClusterPolicySpec) {
 	in.CCManager.DeepCopyInto(&out.CCManager)
 	out.HostPaths = in.HostPaths
 	in.KataSandboxDevicePlugin.DeepCopyInto(&out.KataSandboxDevicePlugin)
+	if in.ImageResolution != nil {
+		in, out := &in.ImageResolution, &out.ImageResolution
+		*out = new(ImageResolutionSpec)
+		**out = **in
+	}
+	if in.ImageMirror != nil {
+		in, out := &in.ImageMirror, &out.ImageMirror
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = new(ImagePullSecretsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RegistryClient != nil {
+		in, out := &in.RegistryClient, &out.RegistryClient
+		*out = new(RegistryClientSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FleetReport != nil {
+		in, out := &in.FleetReport, &out.FleetReport
+		*out = new(FleetReportSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DownloadCache != nil {
+		in, out := &in.DownloadCache, &out.DownloadCache
+		*out = new(DownloadCacheSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(GPUHealthCheckSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NVLinkFabric != nil {
+		in, out := &in.NVLinkFabric, &out.NVLinkFabric
+		*out = new(NVLinkFabricSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MPSControlDaemon != nil {
+		in, out := &in.MPSControlDaemon, &out.MPSControlDaemon
+		*out = new(MPSControlDaemonSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePrePull != nil {
+		in, out := &in.ImagePrePull, &out.ImagePrePull
+		*out = new(ImagePrePullSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SafeMode != nil {
+		in, out := &in.SafeMode, &out.SafeMode
+		*out = new(SafeModeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UsageAccounting != nil {
+		in, out := &in.UsageAccounting, &out.UsageAccounting
+		*out = new(UsageAccountingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = new(WindowsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeFeatureDiscovery != nil {
+		in, out := &in.NodeFeatureDiscovery, &out.NodeFeatureDiscovery
+		*out = new(NodeFeatureDiscoverySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalGates != nil {
+		in, out := &in.ExternalGates, &out.ExternalGates
+		*out = make([]ExternalGateSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TenantNamespaces != nil {
+		in, out := &in.TenantNamespaces, &out.TenantNamespaces
+		*out = make([]TenantNamespaceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConsumerGPUs != nil {
+		in, out := &in.ConsumerGPUs, &out.ConsumerGPUs
+		*out = new(ConsumerGPUsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessHysteresis != nil {
+		in, out := &in.ReadinessHysteresis, &out.ReadinessHysteresis
+		*out = new(ReadinessHysteresisSpec)
+		**out = **in
+	}
+	if in.AutoscalingHints != nil {
+		in, out := &in.AutoscalingHints, &out.AutoscalingHints
+		*out = new(AutoscalingHintsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = new(NotificationsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Teardown != nil {
+		in, out := &in.Teardown, &out.Teardown
+		*out = new(TeardownSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Devel != nil {
+		in, out := &in.Devel, &out.Devel
+		*out = new(DevelSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPolicySpec.
@@ -242,6 +449,54 @@ func (in *ClusterPolicyStatus) DeepCopyInto(out *ClusterPolicyStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ValidationDomains != nil {
+		in, out := &in.ValidationDomains, &out.ValidationDomains
+		*out = make([]ValidationDomainStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NodeOperandStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SkippedValidations != nil {
+		in, out := &in.SkippedValidations, &out.SkippedValidations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DriverUpgrade != nil {
+		in, out := &in.DriverUpgrade, &out.DriverUpgrade
+		*out = new(DriverUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SafeMode != nil {
+		in, out := &in.SafeMode, &out.SafeMode
+		*out = new(SafeModeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalGates != nil {
+		in, out := &in.ExternalGates, &out.ExternalGates
+		*out = make([]ExternalGateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RecreatedOperands != nil {
+		in, out := &in.RecreatedOperands, &out.RecreatedOperands
+		*out = make([]RecreatedOperandStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Teardown != nil {
+		in, out := &in.Teardown, &out.Teardown
+		*out = new(TeardownStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPolicyStatus.
@@ -294,9 +549,54 @@ func (in *ComponentCommonSpec) DeepCopy() *ComponentCommonSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGPUsSpec) DeepCopyInto(out *ConsumerGPUsSpec) {
+	*out = *in
+	if in.DeviceIDs != nil {
+		in, out := &in.DeviceIDs, &out.DeviceIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerGPUsSpec.
+func (in *ConsumerGPUsSpec) DeepCopy() *ConsumerGPUsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGPUsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerLogSpec) DeepCopyInto(out *ContainerLogSpec) {
+	*out = *in
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(LogRotationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerLogSpec.
+func (in *ContainerLogSpec) DeepCopy() *ContainerLogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerLogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerProbeSpec) DeepCopyInto(out *ContainerProbeSpec) {
 	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerProbeSpec.
@@ -329,6 +629,26 @@ func (in *DCGMExporterHPCJobMappingConfig) DeepCopy() *DCGMExporterHPCJobMapping
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DCGMExporterIdleNodeHintsConfig) DeepCopyInto(out *DCGMExporterIdleNodeHintsConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DCGMExporterIdleNodeHintsConfig.
+func (in *DCGMExporterIdleNodeHintsConfig) DeepCopy() *DCGMExporterIdleNodeHintsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DCGMExporterIdleNodeHintsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DCGMExporterMetricsConfig) DeepCopyInto(out *DCGMExporterMetricsConfig) {
 	*out = *in
@@ -344,6 +664,26 @@ func (in *DCGMExporterMetricsConfig) DeepCopy() *DCGMExporterMetricsConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DCGMExporterPodAttributionConfig) DeepCopyInto(out *DCGMExporterPodAttributionConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DCGMExporterPodAttributionConfig.
+func (in *DCGMExporterPodAttributionConfig) DeepCopy() *DCGMExporterPodAttributionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DCGMExporterPodAttributionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DCGMExporterServiceConfig) DeepCopyInto(out *DCGMExporterServiceConfig) {
 	*out = *in
@@ -372,6 +712,13 @@ func (in *DCGMExporterSpec) DeepCopyInto(out *DCGMExporterSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ArchImages != nil {
+		in, out := &in.ArchImages, &out.ArchImages
+		*out = make(map[string]ArchImageSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
 		*out = make([]string, len(*in))
@@ -399,6 +746,21 @@ func (in *DCGMExporterSpec) DeepCopyInto(out *DCGMExporterSpec) {
 		*out = make([]EnvVar, len(*in))
 		copy(*out, *in)
 	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(ContainerProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ContainerProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(ContainerProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MetricsConfig != nil {
 		in, out := &in.MetricsConfig, &out.MetricsConfig
 		*out = new(DCGMExporterMetricsConfig)
@@ -444,6 +806,28 @@ func (in *DCGMExporterSpec) DeepCopyInto(out *DCGMExporterSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PodAttribution != nil {
+		in, out := &in.PodAttribution, &out.PodAttribution
+		*out = new(DCGMExporterPodAttributionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IdleNodeHints != nil {
+		in, out := &in.IdleNodeHints, &out.IdleNodeHints
+		*out = new(DCGMExporterIdleNodeHintsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeAffinity != nil {
+		in, out := &in.NodeAffinity, &out.NodeAffinity
+		*out = new(corev1.NodeAffinity)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DCGMExporterSpec.
@@ -464,6 +848,13 @@ func (in *DCGMSpec) DeepCopyInto(out *DCGMSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ArchImages != nil {
+		in, out := &in.ArchImages, &out.ArchImages
+		*out = make(map[string]ArchImageSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
 		*out = make([]string, len(*in))
@@ -484,11 +875,38 @@ func (in *DCGMSpec) DeepCopyInto(out *DCGMSpec) {
 		*out = make([]EnvVar, len(*in))
 		copy(*out, *in)
 	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(ContainerProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ContainerProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(ContainerProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.HostNetwork != nil {
 		in, out := &in.HostNetwork, &out.HostNetwork
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeAffinity != nil {
+		in, out := &in.NodeAffinity, &out.NodeAffinity
+		*out = new(corev1.NodeAffinity)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DCGMSpec.
@@ -535,6 +953,11 @@ func (in *DaemonsetsSpec) DeepCopyInto(out *DaemonsetsSpec) {
 		*out = new(corev1.PodSecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.StartupTaints != nil {
+		in, out := &in.StartupTaints, &out.StartupTaints
+		*out = new(StartupTaintsSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaemonsetsSpec.
@@ -547,6 +970,26 @@ func (in *DaemonsetsSpec) DeepCopy() *DaemonsetsSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DevelSpec) DeepCopyInto(out *DevelSpec) {
+	*out = *in
+	if in.MockGPUs != nil {
+		in, out := &in.MockGPUs, &out.MockGPUs
+		*out = new(MockGPUsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevelSpec.
+func (in *DevelSpec) DeepCopy() *DevelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DevelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DevicePluginConfig) DeepCopyInto(out *DevicePluginConfig) {
 	*out = *in
@@ -570,6 +1013,13 @@ func (in *DevicePluginSpec) DeepCopyInto(out *DevicePluginSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ArchImages != nil {
+		in, out := &in.ArchImages, &out.ArchImages
+		*out = make(map[string]ArchImageSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
 		*out = make([]string, len(*in))
@@ -590,6 +1040,21 @@ func (in *DevicePluginSpec) DeepCopyInto(out *DevicePluginSpec) {
 		*out = make([]EnvVar, len(*in))
 		copy(*out, *in)
 	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(ContainerProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ContainerProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(ContainerProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Config != nil {
 		in, out := &in.Config, &out.Config
 		*out = new(DevicePluginConfig)
@@ -598,13 +1063,35 @@ func (in *DevicePluginSpec) DeepCopyInto(out *DevicePluginSpec) {
 	if in.MPS != nil {
 		in, out := &in.MPS, &out.MPS
 		*out = new(MPSConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TimeSlicing != nil {
+		in, out := &in.TimeSlicing, &out.TimeSlicing
+		*out = new(TimeSlicingConfig)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.HostNetwork != nil {
 		in, out := &in.HostNetwork, &out.HostNetwork
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeAffinity != nil {
+		in, out := &in.NodeAffinity, &out.NodeAffinity
+		*out = new(corev1.NodeAffinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeletRestartRecovery != nil {
+		in, out := &in.KubeletRestartRecovery, &out.KubeletRestartRecovery
+		*out = new(KubeletRestartRecoveryConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevicePluginSpec.
@@ -617,6 +1104,46 @@ func (in *DevicePluginSpec) DeepCopy() *DevicePluginSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DownloadCacheSpec) DeepCopyInto(out *DownloadCacheSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]EnvVar, len(*in))
+		copy(*out, *in)
+	}
+	if in.StorageSize != nil {
+		in, out := &in.StorageSize, &out.StorageSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DownloadCacheSpec.
+func (in *DownloadCacheSpec) DeepCopy() *DownloadCacheSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DownloadCacheSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DriverCertConfigSpec) DeepCopyInto(out *DriverCertConfigSpec) {
 	*out = *in
@@ -632,6 +1159,31 @@ func (in *DriverCertConfigSpec) DeepCopy() *DriverCertConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverDrainPolicySpec) DeepCopyInto(out *DriverDrainPolicySpec) {
+	*out = *in
+	if in.GracePeriodSeconds != nil {
+		in, out := &in.GracePeriodSeconds, &out.GracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SkipNamespaces != nil {
+		in, out := &in.SkipNamespaces, &out.SkipNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverDrainPolicySpec.
+func (in *DriverDrainPolicySpec) DeepCopy() *DriverDrainPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverDrainPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DriverLicensingConfigSpec) DeepCopyInto(out *DriverLicensingConfigSpec) {
 	*out = *in
@@ -640,6 +1192,11 @@ func (in *DriverLicensingConfigSpec) DeepCopyInto(out *DriverLicensingConfigSpec
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Monitor != nil {
+		in, out := &in.Monitor, &out.Monitor
+		*out = new(LicenseServerMonitorSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverLicensingConfigSpec.
@@ -677,6 +1234,26 @@ func (in *DriverManagerSpec) DeepCopy() *DriverManagerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverModuleCacheSpec) DeepCopyInto(out *DriverModuleCacheSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverModuleCacheSpec.
+func (in *DriverModuleCacheSpec) DeepCopy() *DriverModuleCacheSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverModuleCacheSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DriverRepoConfigSpec) DeepCopyInto(out *DriverRepoConfigSpec) {
 	*out = *in
@@ -705,6 +1282,11 @@ func (in *DriverSpec) DeepCopyInto(out *DriverSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.OSUpgradePreStaging != nil {
+		in, out := &in.OSUpgradePreStaging, &out.OSUpgradePreStaging
+		*out = new(bool)
+		**out = **in
+	}
 	if in.UseOpenKernelModules != nil {
 		in, out := &in.UseOpenKernelModules, &out.UseOpenKernelModules
 		*out = new(bool)
@@ -718,17 +1300,17 @@ func (in *DriverSpec) DeepCopyInto(out *DriverSpec) {
 	if in.StartupProbe != nil {
 		in, out := &in.StartupProbe, &out.StartupProbe
 		*out = new(ContainerProbeSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.LivenessProbe != nil {
 		in, out := &in.LivenessProbe, &out.LivenessProbe
 		*out = new(ContainerProbeSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.ReadinessProbe != nil {
 		in, out := &in.ReadinessProbe, &out.ReadinessProbe
 		*out = new(ContainerProbeSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.GPUDirectRDMA != nil {
 		in, out := &in.GPUDirectRDMA, &out.GPUDirectRDMA
@@ -740,6 +1322,16 @@ func (in *DriverSpec) DeepCopyInto(out *DriverSpec) {
 		*out = new(v1alpha1.DriverUpgradePolicySpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.UpgradePolicies != nil {
+		in, out := &in.UpgradePolicies, &out.UpgradePolicies
+		*out = new(DriverUpgradePoliciesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DrainPolicy != nil {
+		in, out := &in.DrainPolicy, &out.DrainPolicy
+		*out = new(DriverDrainPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
 		*out = make([]string, len(*in))
@@ -784,13 +1376,35 @@ func (in *DriverSpec) DeepCopyInto(out *DriverSpec) {
 	if in.KernelModuleConfig != nil {
 		in, out := &in.KernelModuleConfig, &out.KernelModuleConfig
 		*out = new(KernelModuleConfigSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ModuleCache != nil {
+		in, out := &in.ModuleCache, &out.ModuleCache
+		*out = new(DriverModuleCacheSpec)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.HostNetwork != nil {
 		in, out := &in.HostNetwork, &out.HostNetwork
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeAffinity != nil {
+		in, out := &in.NodeAffinity, &out.NodeAffinity
+		*out = new(corev1.NodeAffinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Logging != nil {
+		in, out := &in.Logging, &out.Logging
+		*out = new(ContainerLogSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverSpec.
@@ -803,14 +1417,85 @@ func (in *DriverSpec) DeepCopy() *DriverSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverUpgradeNodeStatus) DeepCopyInto(out *DriverUpgradeNodeStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverUpgradeNodeStatus.
+func (in *DriverUpgradeNodeStatus) DeepCopy() *DriverUpgradeNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverUpgradeNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverUpgradePoliciesSpec) DeepCopyInto(out *DriverUpgradePoliciesSpec) {
+	*out = *in
+	if in.GPU != nil {
+		in, out := &in.GPU, &out.GPU
+		*out = new(v1alpha1.DriverUpgradePolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VGPU != nil {
+		in, out := &in.VGPU, &out.VGPU
+		*out = new(v1alpha1.DriverUpgradePolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VGPUHostManager != nil {
+		in, out := &in.VGPUHostManager, &out.VGPUHostManager
+		*out = new(v1alpha1.DriverUpgradePolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverUpgradePoliciesSpec.
+func (in *DriverUpgradePoliciesSpec) DeepCopy() *DriverUpgradePoliciesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverUpgradePoliciesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverUpgradeStatus) DeepCopyInto(out *DriverUpgradeStatus) {
+	*out = *in
+	if in.NextBatch != nil {
+		in, out := &in.NextBatch, &out.NextBatch
+		*out = make([]DriverUpgradeNodeStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverUpgradeStatus.
+func (in *DriverUpgradeStatus) DeepCopy() *DriverUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DriverValidatorSpec) DeepCopyInto(out *DriverValidatorSpec) {
 	*out = *in
+	in.ValidatorComponentSpec.DeepCopyInto(&out.ValidatorComponentSpec)
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
 		*out = make([]EnvVar, len(*in))
 		copy(*out, *in)
 	}
+	if in.CUDACompatLabels != nil {
+		in, out := &in.CUDACompatLabels, &out.CUDACompatLabels
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverValidatorSpec.
@@ -839,67 +1524,111 @@ func (in *EnvVar) DeepCopy() *EnvVar {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GDRCopySpec) DeepCopyInto(out *GDRCopySpec) {
+func (in *ExternalGateResourceReference) DeepCopyInto(out *ExternalGateResourceReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalGateResourceReference.
+func (in *ExternalGateResourceReference) DeepCopy() *ExternalGateResourceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalGateResourceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalGateSpec) DeepCopyInto(out *ExternalGateSpec) {
 	*out = *in
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
 		**out = **in
 	}
-	if in.ImagePullSecrets != nil {
-		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+	if in.States != nil {
+		in, out := &in.States, &out.States
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Args != nil {
-		in, out := &in.Args, &out.Args
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Resource != nil {
+		in, out := &in.Resource, &out.Resource
+		*out = new(ExternalGateResourceReference)
+		**out = **in
 	}
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]EnvVar, len(*in))
-		copy(*out, *in)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GDRCopySpec.
-func (in *GDRCopySpec) DeepCopy() *GDRCopySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalGateSpec.
+func (in *ExternalGateSpec) DeepCopy() *ExternalGateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GDRCopySpec)
+	out := new(ExternalGateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GPUDirectRDMASpec) DeepCopyInto(out *GPUDirectRDMASpec) {
+func (in *ExternalGateStatus) DeepCopyInto(out *ExternalGateStatus) {
+	*out = *in
+	if in.WaitingSince != nil {
+		in, out := &in.WaitingSince, &out.WaitingSince
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalGateStatus.
+func (in *ExternalGateStatus) DeepCopy() *ExternalGateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalGateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetReportSpec) DeepCopyInto(out *FleetReportSpec) {
 	*out = *in
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
 		**out = **in
 	}
-	if in.UseHostMOFED != nil {
-		in, out := &in.UseHostMOFED, &out.UseHostMOFED
-		*out = new(bool)
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUDirectRDMASpec.
-func (in *GPUDirectRDMASpec) DeepCopy() *GPUDirectRDMASpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetReportSpec.
+func (in *FleetReportSpec) DeepCopy() *FleetReportSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GPUDirectRDMASpec)
+	out := new(FleetReportSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GPUDirectStorageSpec) DeepCopyInto(out *GPUDirectStorageSpec) {
+func (in *GDRCopySpec) DeepCopyInto(out *GDRCopySpec) {
 	*out = *in
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
@@ -923,24 +1652,661 @@ func (in *GPUDirectStorageSpec) DeepCopyInto(out *GPUDirectStorageSpec) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUDirectStorageSpec.
-func (in *GPUDirectStorageSpec) DeepCopy() *GPUDirectStorageSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GDRCopySpec.
+func (in *GDRCopySpec) DeepCopy() *GDRCopySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GPUDirectStorageSpec)
+	out := new(GDRCopySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUDirectRDMANetworkAttachmentsSpec) DeepCopyInto(out *GPUDirectRDMANetworkAttachmentsSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUDirectRDMANetworkAttachmentsSpec.
+func (in *GPUDirectRDMANetworkAttachmentsSpec) DeepCopy() *GPUDirectRDMANetworkAttachmentsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUDirectRDMANetworkAttachmentsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUDirectRDMASpec) DeepCopyInto(out *GPUDirectRDMASpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UseHostMOFED != nil {
+		in, out := &in.UseHostMOFED, &out.UseHostMOFED
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = new(GPUDirectRDMANetworkAttachmentsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUDirectRDMASpec.
+func (in *GPUDirectRDMASpec) DeepCopy() *GPUDirectRDMASpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUDirectRDMASpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUDirectStorageSpec) DeepCopyInto(out *GPUDirectStorageSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]EnvVar, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequireSupportedHardware != nil {
+		in, out := &in.RequireSupportedHardware, &out.RequireSupportedHardware
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUDirectStorageSpec.
+func (in *GPUDirectStorageSpec) DeepCopy() *GPUDirectStorageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUDirectStorageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUFeatureDiscoverySpec) DeepCopyInto(out *GPUFeatureDiscoverySpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ArchImages != nil {
+		in, out := &in.ArchImages, &out.ArchImages
+		*out = make(map[string]ArchImageSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]EnvVar, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(ContainerProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ContainerProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(ContainerProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostNetwork != nil {
+		in, out := &in.HostNetwork, &out.HostNetwork
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeAffinity != nil {
+		in, out := &in.NodeAffinity, &out.NodeAffinity
+		*out = new(corev1.NodeAffinity)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUFeatureDiscoverySpec.
+func (in *GPUFeatureDiscoverySpec) DeepCopy() *GPUFeatureDiscoverySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUFeatureDiscoverySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUHealthCheckSpec) DeepCopyInto(out *GPUHealthCheckSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]EnvVar, len(*in))
+		copy(*out, *in)
+	}
+	if in.CriticalXIDs != nil {
+		in, out := &in.CriticalXIDs, &out.CriticalXIDs
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.Taint != nil {
+		in, out := &in.Taint, &out.Taint
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUHealthCheckSpec.
+func (in *GPUHealthCheckSpec) DeepCopy() *GPUHealthCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUHealthCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostPathsSpec) DeepCopyInto(out *HostPathsSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostPathsSpec.
+func (in *HostPathsSpec) DeepCopy() *HostPathsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HostPathsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePrePullSpec) DeepCopyInto(out *ImagePrePullSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]EnvVar, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePrePullSpec.
+func (in *ImagePrePullSpec) DeepCopy() *ImagePrePullSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePrePullSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullSecretsSpec) DeepCopyInto(out *ImagePullSecretsSpec) {
+	*out = *in
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullSecretsSpec.
+func (in *ImagePullSecretsSpec) DeepCopy() *ImagePullSecretsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullSecretsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageResolutionSpec) DeepCopyInto(out *ImageResolutionSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageResolutionSpec.
+func (in *ImageResolutionSpec) DeepCopy() *ImageResolutionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageResolutionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSpec) DeepCopyInto(out *ImageSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSpec.
+func (in *ImageSpec) DeepCopy() *ImageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InitContainerSpec) DeepCopyInto(out *InitContainerSpec) {
+	*out = *in
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InitContainerSpec.
+func (in *InitContainerSpec) DeepCopy() *InitContainerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InitContainerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KataArtifactsDaemonSetSpec) DeepCopyInto(out *KataArtifactsDaemonSetSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KataArtifactsDaemonSetSpec.
+func (in *KataArtifactsDaemonSetSpec) DeepCopy() *KataArtifactsDaemonSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KataArtifactsDaemonSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KataDevicePluginSpec) DeepCopyInto(out *KataDevicePluginSpec) {
+	*out = *in
+	out.ImageSpec = in.ImageSpec
+	in.ComponentCommonSpec.DeepCopyInto(&out.ComponentCommonSpec)
+	if in.HostNetwork != nil {
+		in, out := &in.HostNetwork, &out.HostNetwork
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeAffinity != nil {
+		in, out := &in.NodeAffinity, &out.NodeAffinity
+		*out = new(corev1.NodeAffinity)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KataDevicePluginSpec.
+func (in *KataDevicePluginSpec) DeepCopy() *KataDevicePluginSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KataDevicePluginSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KataManagerSpec) DeepCopyInto(out *KataManagerSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(config.Config)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]EnvVar, len(*in))
+		copy(*out, *in)
+	}
+	if in.HostNetwork != nil {
+		in, out := &in.HostNetwork, &out.HostNetwork
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KataManagerSpec.
+func (in *KataManagerSpec) DeepCopy() *KataManagerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KataManagerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KataRuntimeClassSpec) DeepCopyInto(out *KataRuntimeClassSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KataRuntimeClassSpec.
+func (in *KataRuntimeClassSpec) DeepCopy() *KataRuntimeClassSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KataRuntimeClassSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KataRuntimeSpec) DeepCopyInto(out *KataRuntimeSpec) {
+	*out = *in
+	if in.ManageRuntimeClasses != nil {
+		in, out := &in.ManageRuntimeClasses, &out.ManageRuntimeClasses
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RuntimeClasses != nil {
+		in, out := &in.RuntimeClasses, &out.RuntimeClasses
+		*out = make([]KataRuntimeClassSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ArtifactsDaemonSet != nil {
+		in, out := &in.ArtifactsDaemonSet, &out.ArtifactsDaemonSet
+		*out = new(KataArtifactsDaemonSetSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KataRuntimeSpec.
+func (in *KataRuntimeSpec) DeepCopy() *KataRuntimeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KataRuntimeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KernelModuleConfigSpec) DeepCopyInto(out *KernelModuleConfigSpec) {
+	*out = *in
+	if in.NVIDIA != nil {
+		in, out := &in.NVIDIA, &out.NVIDIA
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NVIDIAUVM != nil {
+		in, out := &in.NVIDIAUVM, &out.NVIDIAUVM
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NVIDIAPeermem != nil {
+		in, out := &in.NVIDIAPeermem, &out.NVIDIAPeermem
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KernelModuleConfigSpec.
+func (in *KernelModuleConfigSpec) DeepCopy() *KernelModuleConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KernelModuleConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletRestartRecoveryConfig) DeepCopyInto(out *KubeletRestartRecoveryConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletRestartRecoveryConfig.
+func (in *KubeletRestartRecoveryConfig) DeepCopy() *KubeletRestartRecoveryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletRestartRecoveryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LicenseServerMonitorSpec) DeepCopyInto(out *LicenseServerMonitorSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LicenseServerMonitorSpec.
+func (in *LicenseServerMonitorSpec) DeepCopy() *LicenseServerMonitorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LicenseServerMonitorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogRotationSpec) DeepCopyInto(out *LogRotationSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogRotationSpec.
+func (in *LogRotationSpec) DeepCopy() *LogRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LogRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MIGConfigSelector) DeepCopyInto(out *MIGConfigSelector) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MIGConfigSelector.
+func (in *MIGConfigSelector) DeepCopy() *MIGConfigSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(MIGConfigSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MIGGPUClientsConfigSpec) DeepCopyInto(out *MIGGPUClientsConfigSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MIGGPUClientsConfigSpec.
+func (in *MIGGPUClientsConfigSpec) DeepCopy() *MIGGPUClientsConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MIGGPUClientsConfigSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GPUFeatureDiscoverySpec) DeepCopyInto(out *GPUFeatureDiscoverySpec) {
+func (in *MIGManagerSpec) DeepCopyInto(out *MIGManagerSpec) {
 	*out = *in
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ArchImages != nil {
+		in, out := &in.ArchImages, &out.ArchImages
+		*out = make(map[string]ArchImageSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
 		*out = make([]string, len(*in))
@@ -961,112 +2327,131 @@ func (in *GPUFeatureDiscoverySpec) DeepCopyInto(out *GPUFeatureDiscoverySpec) {
 		*out = make([]EnvVar, len(*in))
 		copy(*out, *in)
 	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(ContainerProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ContainerProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(ContainerProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(MIGPartedConfigSpec)
+		**out = **in
+	}
+	if in.GPUClientsConfig != nil {
+		in, out := &in.GPUClientsConfig, &out.GPUClientsConfig
+		*out = new(MIGGPUClientsConfigSpec)
+		**out = **in
+	}
 	if in.HostNetwork != nil {
 		in, out := &in.HostNetwork, &out.HostNetwork
 		*out = new(bool)
 		**out = **in
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUFeatureDiscoverySpec.
-func (in *GPUFeatureDiscoverySpec) DeepCopy() *GPUFeatureDiscoverySpec {
-	if in == nil {
-		return nil
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeAffinity != nil {
+		in, out := &in.NodeAffinity, &out.NodeAffinity
+		*out = new(corev1.NodeAffinity)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(GPUFeatureDiscoverySpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HostPathsSpec) DeepCopyInto(out *HostPathsSpec) {
-	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostPathsSpec.
-func (in *HostPathsSpec) DeepCopy() *HostPathsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MIGManagerSpec.
+func (in *MIGManagerSpec) DeepCopy() *MIGManagerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(HostPathsSpec)
+	out := new(MIGManagerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageSpec) DeepCopyInto(out *ImageSpec) {
+func (in *MIGPartedConfigSpec) DeepCopyInto(out *MIGPartedConfigSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSpec.
-func (in *ImageSpec) DeepCopy() *ImageSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MIGPartedConfigSpec.
+func (in *MIGPartedConfigSpec) DeepCopy() *MIGPartedConfigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ImageSpec)
+	out := new(MIGPartedConfigSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InitContainerSpec) DeepCopyInto(out *InitContainerSpec) {
+func (in *MIGSpec) DeepCopyInto(out *MIGSpec) {
 	*out = *in
-	if in.ImagePullSecrets != nil {
-		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.ConfigSelectors != nil {
+		in, out := &in.ConfigSelectors, &out.ConfigSelectors
+		*out = make([]MIGConfigSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InitContainerSpec.
-func (in *InitContainerSpec) DeepCopy() *InitContainerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MIGSpec.
+func (in *MIGSpec) DeepCopy() *MIGSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InitContainerSpec)
+	out := new(MIGSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KataDevicePluginSpec) DeepCopyInto(out *KataDevicePluginSpec) {
+func (in *MPSConfig) DeepCopyInto(out *MPSConfig) {
 	*out = *in
-	out.ImageSpec = in.ImageSpec
-	in.ComponentCommonSpec.DeepCopyInto(&out.ComponentCommonSpec)
-	if in.HostNetwork != nil {
-		in, out := &in.HostNetwork, &out.HostNetwork
-		*out = new(bool)
-		**out = **in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]MPSResource, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KataDevicePluginSpec.
-func (in *KataDevicePluginSpec) DeepCopy() *KataDevicePluginSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MPSConfig.
+func (in *MPSConfig) DeepCopy() *MPSConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(KataDevicePluginSpec)
+	out := new(MPSConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KataManagerSpec) DeepCopyInto(out *KataManagerSpec) {
+func (in *MPSControlDaemonSpec) DeepCopyInto(out *MPSControlDaemonSpec) {
 	*out = *in
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
 		**out = **in
 	}
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
-		*out = new(config.Config)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ImagePullSecrets != nil {
-		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
@@ -1083,55 +2468,62 @@ func (in *KataManagerSpec) DeepCopyInto(out *KataManagerSpec) {
 		*out = make([]EnvVar, len(*in))
 		copy(*out, *in)
 	}
-	if in.HostNetwork != nil {
-		in, out := &in.HostNetwork, &out.HostNetwork
-		*out = new(bool)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KataManagerSpec.
-func (in *KataManagerSpec) DeepCopy() *KataManagerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MPSControlDaemonSpec.
+func (in *MPSControlDaemonSpec) DeepCopy() *MPSControlDaemonSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KataManagerSpec)
+	out := new(MPSControlDaemonSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KernelModuleConfigSpec) DeepCopyInto(out *KernelModuleConfigSpec) {
+func (in *MPSResource) DeepCopyInto(out *MPSResource) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KernelModuleConfigSpec.
-func (in *KernelModuleConfigSpec) DeepCopy() *KernelModuleConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MPSResource.
+func (in *MPSResource) DeepCopy() *MPSResource {
 	if in == nil {
 		return nil
 	}
-	out := new(KernelModuleConfigSpec)
+	out := new(MPSResource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MIGGPUClientsConfigSpec) DeepCopyInto(out *MIGGPUClientsConfigSpec) {
+func (in *MockGPUsSpec) DeepCopyInto(out *MockGPUsSpec) {
 	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MIGGPUClientsConfigSpec.
-func (in *MIGGPUClientsConfigSpec) DeepCopy() *MIGGPUClientsConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MockGPUsSpec.
+func (in *MockGPUsSpec) DeepCopy() *MockGPUsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MIGGPUClientsConfigSpec)
+	out := new(MockGPUsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MIGManagerSpec) DeepCopyInto(out *MIGManagerSpec) {
+func (in *NVLinkFabricSpec) DeepCopyInto(out *NVLinkFabricSpec) {
 	*out = *in
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
@@ -1148,84 +2540,83 @@ func (in *MIGManagerSpec) DeepCopyInto(out *MIGManagerSpec) {
 		*out = new(ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Args != nil {
-		in, out := &in.Args, &out.Args
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
 		*out = make([]EnvVar, len(*in))
 		copy(*out, *in)
 	}
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
-		*out = new(MIGPartedConfigSpec)
-		**out = **in
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(ContainerProbeSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.GPUClientsConfig != nil {
-		in, out := &in.GPUClientsConfig, &out.GPUClientsConfig
-		*out = new(MIGGPUClientsConfigSpec)
-		**out = **in
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ContainerProbeSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.HostNetwork != nil {
-		in, out := &in.HostNetwork, &out.HostNetwork
-		*out = new(bool)
-		**out = **in
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(ContainerProbeSpec)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MIGManagerSpec.
-func (in *MIGManagerSpec) DeepCopy() *MIGManagerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NVLinkFabricSpec.
+func (in *NVLinkFabricSpec) DeepCopy() *NVLinkFabricSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MIGManagerSpec)
+	out := new(NVLinkFabricSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MIGPartedConfigSpec) DeepCopyInto(out *MIGPartedConfigSpec) {
+func (in *NodeFeatureDiscoverySpec) DeepCopyInto(out *NodeFeatureDiscoverySpec) {
 	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MIGPartedConfigSpec.
-func (in *MIGPartedConfigSpec) DeepCopy() *MIGPartedConfigSpec {
-	if in == nil {
-		return nil
+	if in.Managed != nil {
+		in, out := &in.Managed, &out.Managed
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(MIGPartedConfigSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MIGSpec) DeepCopyInto(out *MIGSpec) {
-	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MIGSpec.
-func (in *MIGSpec) DeepCopy() *MIGSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeFeatureDiscoverySpec.
+func (in *NodeFeatureDiscoverySpec) DeepCopy() *NodeFeatureDiscoverySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MIGSpec)
+	out := new(NodeFeatureDiscoverySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MPSConfig) DeepCopyInto(out *MPSConfig) {
+func (in *NodeOperandStatus) DeepCopyInto(out *NodeOperandStatus) {
 	*out = *in
+	if in.OperandsDisableAfter != nil {
+		in, out := &in.OperandsDisableAfter, &out.OperandsDisableAfter
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MPSConfig.
-func (in *MPSConfig) DeepCopy() *MPSConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeOperandStatus.
+func (in *NodeOperandStatus) DeepCopy() *NodeOperandStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(MPSConfig)
+	out := new(NodeOperandStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1258,19 +2649,88 @@ func (in *NodeStatusExporterSpec) DeepCopyInto(out *NodeStatusExporterSpec) {
 		*out = make([]EnvVar, len(*in))
 		copy(*out, *in)
 	}
-	if in.HostNetwork != nil {
-		in, out := &in.HostNetwork, &out.HostNetwork
-		*out = new(bool)
-		**out = **in
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(ContainerProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ContainerProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(ContainerProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostNetwork != nil {
+		in, out := &in.HostNetwork, &out.HostNetwork
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeAffinity != nil {
+		in, out := &in.NodeAffinity, &out.NodeAffinity
+		*out = new(corev1.NodeAffinity)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeStatusExporterSpec.
+func (in *NodeStatusExporterSpec) DeepCopy() *NodeStatusExporterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeStatusExporterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationSinkSpec) DeepCopyInto(out *NotificationSinkSpec) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]NodeLifecycleEventType, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationSinkSpec.
+func (in *NotificationSinkSpec) DeepCopy() *NotificationSinkSpec {
+	if in == nil {
+		return nil
 	}
+	out := new(NotificationSinkSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeStatusExporterSpec.
-func (in *NodeStatusExporterSpec) DeepCopy() *NodeStatusExporterSpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationsSpec) DeepCopyInto(out *NotificationsSpec) {
+	*out = *in
+	if in.Sinks != nil {
+		in, out := &in.Sinks, &out.Sinks
+		*out = make([]NotificationSinkSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationsSpec.
+func (in *NotificationsSpec) DeepCopy() *NotificationsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeStatusExporterSpec)
+	out := new(NotificationsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1339,6 +2799,11 @@ func (in *PSASpec) DeepCopyInto(out *PSASpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ManageNamespaceLabels != nil {
+		in, out := &in.ManageNamespaceLabels, &out.ManageNamespaceLabels
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PSASpec.
@@ -1374,6 +2839,7 @@ func (in *PSPSpec) DeepCopy() *PSPSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PluginValidatorSpec) DeepCopyInto(out *PluginValidatorSpec) {
 	*out = *in
+	in.ValidatorComponentSpec.DeepCopyInto(&out.ValidatorComponentSpec)
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
 		*out = make([]EnvVar, len(*in))
@@ -1391,6 +2857,82 @@ func (in *PluginValidatorSpec) DeepCopy() *PluginValidatorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxySpec) DeepCopyInto(out *ProxySpec) {
+	*out = *in
+	if in.TrustedCA != nil {
+		in, out := &in.TrustedCA, &out.TrustedCA
+		*out = new(TrustedCASpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxySpec.
+func (in *ProxySpec) DeepCopy() *ProxySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessHysteresisSpec) DeepCopyInto(out *ReadinessHysteresisSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessHysteresisSpec.
+func (in *ReadinessHysteresisSpec) DeepCopy() *ReadinessHysteresisSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessHysteresisSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecreatedOperandStatus) DeepCopyInto(out *RecreatedOperandStatus) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.DeletionTime.DeepCopyInto(&out.DeletionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecreatedOperandStatus.
+func (in *RecreatedOperandStatus) DeepCopy() *RecreatedOperandStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RecreatedOperandStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryClientSpec) DeepCopyInto(out *RegistryClientSpec) {
+	*out = *in
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryClientSpec.
+func (in *RegistryClientSpec) DeepCopy() *RegistryClientSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryClientSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceRequirements) DeepCopyInto(out *ResourceRequirements) {
 	*out = *in
@@ -1435,6 +2977,80 @@ func (in *RollingUpdateSpec) DeepCopy() *RollingUpdateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeRollbackSpec) DeepCopyInto(out *RuntimeRollbackSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeRollbackSpec.
+func (in *RuntimeRollbackSpec) DeepCopy() *RuntimeRollbackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeRollbackSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SafeModeSpec) DeepCopyInto(out *SafeModeSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CrashLoopThreshold != nil {
+		in, out := &in.CrashLoopThreshold, &out.CrashLoopThreshold
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SafeModeSpec.
+func (in *SafeModeSpec) DeepCopy() *SafeModeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SafeModeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SafeModeStatus) DeepCopyInto(out *SafeModeStatus) {
+	*out = *in
+	if in.Since != nil {
+		in, out := &in.Since, &out.Since
+		*out = (*in).DeepCopy()
+	}
+	if in.Revisions != nil {
+		in, out := &in.Revisions, &out.Revisions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AcknowledgedRevisions != nil {
+		in, out := &in.AcknowledgedRevisions, &out.AcknowledgedRevisions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SafeModeStatus.
+func (in *SafeModeStatus) DeepCopy() *SafeModeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SafeModeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SandboxDevicePluginSpec) DeepCopyInto(out *SandboxDevicePluginSpec) {
 	*out = *in
@@ -1468,6 +3084,18 @@ func (in *SandboxDevicePluginSpec) DeepCopyInto(out *SandboxDevicePluginSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeAffinity != nil {
+		in, out := &in.NodeAffinity, &out.NodeAffinity
+		*out = new(corev1.NodeAffinity)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SandboxDevicePluginSpec.
@@ -1488,6 +3116,11 @@ func (in *SandboxWorkloadsSpec) DeepCopyInto(out *SandboxWorkloadsSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Kata != nil {
+		in, out := &in.Kata, &out.Kata
+		*out = new(KataRuntimeSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SandboxWorkloadsSpec.
@@ -1501,44 +3134,167 @@ func (in *SandboxWorkloadsSpec) DeepCopy() *SandboxWorkloadsSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceMonitorConfig) DeepCopyInto(out *ServiceMonitorConfig) {
+func (in *ServiceMonitorConfig) DeepCopyInto(out *ServiceMonitorConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HonorLabels != nil {
+		in, out := &in.HonorLabels, &out.HonorLabels
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AdditionalLabels != nil {
+		in, out := &in.AdditionalLabels, &out.AdditionalLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Relabelings != nil {
+		in, out := &in.Relabelings, &out.Relabelings
+		*out = make([]*monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(monitoringv1.RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMonitorConfig.
+func (in *ServiceMonitorConfig) DeepCopy() *ServiceMonitorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceMonitorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StartupTaintsSpec) DeepCopyInto(out *StartupTaintsSpec) {
+	*out = *in
+	if in.BootstrapTaints != nil {
+		in, out := &in.BootstrapTaints, &out.BootstrapTaints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StartupTaintsSpec.
+func (in *StartupTaintsSpec) DeepCopy() *StartupTaintsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StartupTaintsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimeSlicingConfig) DeepCopyInto(out *TimeSlicingConfig) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]TimeSlicingResource, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeSlicingConfig.
+func (in *TimeSlicingConfig) DeepCopy() *TimeSlicingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TimeSlicingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimeSlicingResource) DeepCopyInto(out *TimeSlicingResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeSlicingResource.
+func (in *TimeSlicingResource) DeepCopy() *TimeSlicingResource {
+	if in == nil {
+		return nil
+	}
+	out := new(TimeSlicingResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeardownSpec) DeepCopyInto(out *TeardownSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EvictGPUWorkloads != nil {
+		in, out := &in.EvictGPUWorkloads, &out.EvictGPUWorkloads
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeardownSpec.
+func (in *TeardownSpec) DeepCopy() *TeardownSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TeardownSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeardownStatus) DeepCopyInto(out *TeardownStatus) {
+	*out = *in
+	in.PhaseStartTime.DeepCopyInto(&out.PhaseStartTime)
+	if in.Pending != nil {
+		in, out := &in.Pending, &out.Pending
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeardownStatus.
+func (in *TeardownStatus) DeepCopy() *TeardownStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TeardownStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantNamespaceSpec) DeepCopyInto(out *TenantNamespaceSpec) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.HonorLabels != nil {
-		in, out := &in.HonorLabels, &out.HonorLabels
-		*out = new(bool)
-		**out = **in
-	}
-	if in.AdditionalLabels != nil {
-		in, out := &in.AdditionalLabels, &out.AdditionalLabels
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.Relabelings != nil {
-		in, out := &in.Relabelings, &out.Relabelings
-		*out = make([]*monitoringv1.RelabelConfig, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(monitoringv1.RelabelConfig)
-				(*in).DeepCopyInto(*out)
-			}
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMonitorConfig.
-func (in *ServiceMonitorConfig) DeepCopy() *ServiceMonitorConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantNamespaceSpec.
+func (in *TenantNamespaceSpec) DeepCopy() *TenantNamespaceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceMonitorConfig)
+	out := new(TenantNamespaceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1551,6 +3307,13 @@ func (in *ToolkitSpec) DeepCopyInto(out *ToolkitSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ArchImages != nil {
+		in, out := &in.ArchImages, &out.ArchImages
+		*out = make(map[string]ArchImageSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
 		*out = make([]string, len(*in))
@@ -1571,11 +3334,48 @@ func (in *ToolkitSpec) DeepCopyInto(out *ToolkitSpec) {
 		*out = make([]EnvVar, len(*in))
 		copy(*out, *in)
 	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(ContainerProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ContainerProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(ContainerProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.HostNetwork != nil {
 		in, out := &in.HostNetwork, &out.HostNetwork
 		*out = new(bool)
 		**out = **in
 	}
+	if in.RuntimeRollback != nil {
+		in, out := &in.RuntimeRollback, &out.RuntimeRollback
+		*out = new(RuntimeRollbackSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeAffinity != nil {
+		in, out := &in.NodeAffinity, &out.NodeAffinity
+		*out = new(corev1.NodeAffinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Logging != nil {
+		in, out := &in.Logging, &out.Logging
+		*out = new(ContainerLogSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolkitSpec.
@@ -1591,6 +3391,7 @@ func (in *ToolkitSpec) DeepCopy() *ToolkitSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ToolkitValidatorSpec) DeepCopyInto(out *ToolkitValidatorSpec) {
 	*out = *in
+	in.ValidatorComponentSpec.DeepCopyInto(&out.ValidatorComponentSpec)
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
 		*out = make([]EnvVar, len(*in))
@@ -1608,6 +3409,51 @@ func (in *ToolkitValidatorSpec) DeepCopy() *ToolkitValidatorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrustedCASpec) DeepCopyInto(out *TrustedCASpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrustedCASpec.
+func (in *TrustedCASpec) DeepCopy() *TrustedCASpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TrustedCASpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsageAccountingSpec) DeepCopyInto(out *UsageAccountingSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageAccountingSpec.
+func (in *UsageAccountingSpec) DeepCopy() *UsageAccountingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UsageAccountingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VFIOManagerSpec) DeepCopyInto(out *VFIOManagerSpec) {
 	*out = *in
@@ -1642,6 +3488,18 @@ func (in *VFIOManagerSpec) DeepCopyInto(out *VFIOManagerSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeAffinity != nil {
+		in, out := &in.NodeAffinity, &out.NodeAffinity
+		*out = new(corev1.NodeAffinity)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VFIOManagerSpec.
@@ -1657,6 +3515,7 @@ func (in *VFIOManagerSpec) DeepCopy() *VFIOManagerSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VFIOPCIValidatorSpec) DeepCopyInto(out *VFIOPCIValidatorSpec) {
 	*out = *in
+	in.ValidatorComponentSpec.DeepCopyInto(&out.ValidatorComponentSpec)
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
 		*out = make([]EnvVar, len(*in))
@@ -1712,6 +3571,25 @@ func (in *VGPUDeviceManagerSpec) DeepCopyInto(out *VGPUDeviceManagerSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeAffinity != nil {
+		in, out := &in.NodeAffinity, &out.NodeAffinity
+		*out = new(corev1.NodeAffinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]VGPUProfileSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VGPUDeviceManagerSpec.
@@ -1742,6 +3620,7 @@ func (in *VGPUDevicesConfigSpec) DeepCopy() *VGPUDevicesConfigSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VGPUDevicesValidatorSpec) DeepCopyInto(out *VGPUDevicesValidatorSpec) {
 	*out = *in
+	in.ValidatorComponentSpec.DeepCopyInto(&out.ValidatorComponentSpec)
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
 		*out = make([]EnvVar, len(*in))
@@ -1791,13 +3670,25 @@ func (in *VGPUManagerSpec) DeepCopyInto(out *VGPUManagerSpec) {
 	if in.KernelModuleConfig != nil {
 		in, out := &in.KernelModuleConfig, &out.KernelModuleConfig
 		*out = new(KernelModuleConfigSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.HostNetwork != nil {
 		in, out := &in.HostNetwork, &out.HostNetwork
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeAffinity != nil {
+		in, out := &in.NodeAffinity, &out.NodeAffinity
+		*out = new(corev1.NodeAffinity)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VGPUManagerSpec.
@@ -1813,6 +3704,7 @@ func (in *VGPUManagerSpec) DeepCopy() *VGPUManagerSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VGPUManagerValidatorSpec) DeepCopyInto(out *VGPUManagerValidatorSpec) {
 	*out = *in
+	in.ValidatorComponentSpec.DeepCopyInto(&out.ValidatorComponentSpec)
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
 		*out = make([]EnvVar, len(*in))
@@ -1830,6 +3722,95 @@ func (in *VGPUManagerValidatorSpec) DeepCopy() *VGPUManagerValidatorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VGPUProfileSpec) DeepCopyInto(out *VGPUProfileSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.VGPUDevices != nil {
+		in, out := &in.VGPUDevices, &out.VGPUDevices
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VGPUProfileSpec.
+func (in *VGPUProfileSpec) DeepCopy() *VGPUProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VGPUProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationDomainStatus) DeepCopyInto(out *ValidationDomainStatus) {
+	*out = *in
+	if in.FailedNodes != nil {
+		in, out := &in.FailedNodes, &out.FailedNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationDomainStatus.
+func (in *ValidationDomainStatus) DeepCopy() *ValidationDomainStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationDomainStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidatorComponentSpec) DeepCopyInto(out *ValidatorComponentSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidatorComponentSpec.
+func (in *ValidatorComponentSpec) DeepCopy() *ValidatorComponentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidatorComponentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidatorImageSpec) DeepCopyInto(out *ValidatorImageSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidatorImageSpec.
+func (in *ValidatorImageSpec) DeepCopy() *ValidatorImageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidatorImageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValidatorSpec) DeepCopyInto(out *ValidatorSpec) {
 	*out = *in
@@ -1840,6 +3821,14 @@ func (in *ValidatorSpec) DeepCopyInto(out *ValidatorSpec) {
 	in.VFIOPCI.DeepCopyInto(&out.VFIOPCI)
 	in.VGPUManager.DeepCopyInto(&out.VGPUManager)
 	in.VGPUDevices.DeepCopyInto(&out.VGPUDevices)
+	in.CCManager.DeepCopyInto(&out.CCManager)
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make(map[string]ValidatorImageSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
 		*out = make([]string, len(*in))
@@ -1865,6 +3854,18 @@ func (in *ValidatorSpec) DeepCopyInto(out *ValidatorSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeAffinity != nil {
+		in, out := &in.NodeAffinity, &out.NodeAffinity
+		*out = new(corev1.NodeAffinity)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidatorSpec.
@@ -1891,3 +3892,73 @@ func (in *VirtualTopologyConfigSpec) DeepCopy() *VirtualTopologyConfigSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WindowsComponentSpec) DeepCopyInto(out *WindowsComponentSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]EnvVar, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WindowsComponentSpec.
+func (in *WindowsComponentSpec) DeepCopy() *WindowsComponentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WindowsComponentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WindowsSpec) DeepCopyInto(out *WindowsSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DevicePlugin != nil {
+		in, out := &in.DevicePlugin, &out.DevicePlugin
+		*out = new(WindowsComponentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GPUFeatureDiscovery != nil {
+		in, out := &in.GPUFeatureDiscovery, &out.GPUFeatureDiscovery
+		*out = new(WindowsComponentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WindowsSpec.
+func (in *WindowsSpec) DeepCopy() *WindowsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WindowsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,141 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DriverUpgradeCRDName is the kind of the DriverUpgrade custom resource
+	DriverUpgradeCRDName = "DriverUpgrade"
+
+	// DriverUpgradeConditionComplete is the condition reporting whether the driver of all nodes is upgraded
+	DriverUpgradeConditionComplete = "Complete"
+)
+
+// DriverUpgradePhase is the phase of the driver upgrade of a set of nodes
+// +kubebuilder:validation:Enum=Pending;InProgress;Done;Failed
+type DriverUpgradePhase string
+
+const (
+	// DriverUpgradePhasePending means nodes wait for a driver upgrade and no node is being upgraded
+	DriverUpgradePhasePending DriverUpgradePhase = "Pending"
+	// DriverUpgradePhaseInProgress means the driver of some nodes is being upgraded
+	DriverUpgradePhaseInProgress DriverUpgradePhase = "InProgress"
+	// DriverUpgradePhaseDone means the driver of all nodes is up to date
+	DriverUpgradePhaseDone DriverUpgradePhase = "Done"
+	// DriverUpgradePhaseFailed means the driver upgrade of some nodes failed
+	DriverUpgradePhaseFailed DriverUpgradePhase = "Failed"
+)
+
+// DriverUpgradeNodePhase is the phase of the driver upgrade of a node
+// +kubebuilder:validation:Enum=Pending;Cordoned;Draining;Upgrading;Validating;Done;Failed
+type DriverUpgradeNodePhase string
+
+const (
+	// DriverUpgradeNodePhasePending means the node waits for its driver upgrade
+	DriverUpgradeNodePhasePending DriverUpgradeNodePhase = "Pending"
+	// DriverUpgradeNodePhaseCordoned means the node is cordoned, the upgrade waits for the jobs of the node
+	// to complete
+	DriverUpgradeNodePhaseCordoned DriverUpgradeNodePhase = "Cordoned"
+	// DriverUpgradeNodePhaseDraining means the GPU pods of the node are deleted, or the node is drained
+	DriverUpgradeNodePhaseDraining DriverUpgradeNodePhase = "Draining"
+	// DriverUpgradeNodePhaseUpgrading means the driver pod of the node is restarted with the new driver
+	DriverUpgradeNodePhaseUpgrading DriverUpgradeNodePhase = "Upgrading"
+	// DriverUpgradeNodePhaseValidating means the new driver is validated before the node is uncordoned
+	DriverUpgradeNodePhaseValidating DriverUpgradeNodePhase = "Validating"
+	// DriverUpgradeNodePhaseDone means the driver of the node is up to date
+	DriverUpgradeNodePhaseDone DriverUpgradeNodePhase = "Done"
+	// DriverUpgradeNodePhaseFailed means the driver upgrade of the node failed
+	DriverUpgradeNodePhaseFailed DriverUpgradeNodePhase = "Failed"
+)
+
+// DriverUpgradeNodeStatus defines the driver upgrade progress of a node
+type DriverUpgradeNodeStatus struct {
+	// Name is the name of the node
+	Name string `json:"name"`
+	// DriverType is the type of driver upgraded on the node
+	// +kubebuilder:validation:Optional
+	DriverType string `json:"driverType,omitempty"`
+	// Phase is the phase of the driver upgrade of the node
+	Phase DriverUpgradeNodePhase `json:"phase"`
+	// State is the upgrade state of the node, as held by its nvidia.com/gpu-driver-upgrade-state label
+	// +kubebuilder:validation:Optional
+	State string `json:"state,omitempty"`
+	// LastTransitionTime is the last time the phase of the node changed
+	// +kubebuilder:validation:Optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// DriverUpgradeStatus defines the observed progress of the driver upgrades
+type DriverUpgradeStatus struct {
+	// Phase is the phase of the driver upgrade of all nodes
+	// +kubebuilder:validation:Optional
+	Phase DriverUpgradePhase `json:"phase,omitempty"`
+	// TotalNodes is the number of nodes whose driver upgrades are managed
+	// +kubebuilder:validation:Optional
+	TotalNodes int `json:"totalNodes,omitempty"`
+	// PendingNodes is the number of nodes waiting for their driver upgrade
+	// +kubebuilder:validation:Optional
+	PendingNodes int `json:"pendingNodes,omitempty"`
+	// InProgressNodes is the number of nodes whose driver is being upgraded
+	// +kubebuilder:validation:Optional
+	InProgressNodes int `json:"inProgressNodes,omitempty"`
+	// DoneNodes is the number of nodes whose driver is up to date
+	// +kubebuilder:validation:Optional
+	DoneNodes int `json:"doneNodes,omitempty"`
+	// FailedNodes is the number of nodes whose driver upgrade failed
+	// +kubebuilder:validation:Optional
+	FailedNodes int `json:"failedNodes,omitempty"`
+	// Nodes lists the driver upgrade progress of each node, ordered by name
+	// +kubebuilder:validation:Optional
+	Nodes []DriverUpgradeNodeStatus `json:"nodes,omitempty"`
+	// Conditions is a list of conditions representing the DriverUpgrade's current state. The Complete
+	// condition is true once the driver of all nodes is up to date.
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +genclient
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced,shortName={"drvup"}
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`,priority=0
+//+kubebuilder:printcolumn:name="Done",type=integer,JSONPath=`.status.doneNodes`,priority=0
+//+kubebuilder:printcolumn:name="Total",type=integer,JSONPath=`.status.totalNodes`,priority=0
+//+kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failedNodes`,priority=1
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,priority=0
+
+// DriverUpgrade is the Schema for the driverupgrades API. The operator maintains a DriverUpgrade in its
+// namespace for the ClusterPolicy, or for each NVIDIADriver, whose automatic driver upgrades are enabled,
+// reporting the progress of the upgrades so that external tools can gate on their completion.
+type DriverUpgrade struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status DriverUpgradeStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DriverUpgradeList contains a list of DriverUpgrade
+type DriverUpgradeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DriverUpgrade `json:"items"`
+}
@@ -0,0 +1,129 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// GPUAllocationPolicyCRDName is the kind of the GPUAllocationPolicy custom resource
+	GPUAllocationPolicyCRDName = "GPUAllocationPolicy"
+
+	// GPUResourceName is the name of the resource requested for full GPUs
+	GPUResourceName corev1.ResourceName = "nvidia.com/gpu"
+	// SharedGPUResourceName is the name of the resource requested for time-sliced GPU replicas when the
+	// time-slicing configuration of the device plugin renames the shared resources
+	SharedGPUResourceName corev1.ResourceName = "nvidia.com/gpu.shared"
+	// MIGResourcePrefix prefixes the MIG profile in the name of the resource requested for MIG devices with the
+	// mixed MIG strategy
+	MIGResourcePrefix = "nvidia.com/mig-"
+)
+
+// GPUAllocationPolicySpec defines the GPU resources the pods of a set of namespaces may request. The operator
+// expresses the policy as a ResourceQuota and a LimitRange in each selected namespace. When several policies
+// select a namespace, the most restrictive limits apply.
+type GPUAllocationPolicySpec struct {
+	// NamespaceSelector selects the namespaces governed by the policy. An empty selector selects all namespaces.
+	// +kubebuilder:validation:Optional
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Quota limits the GPU resources requested by all pods of each selected namespace
+	// +kubebuilder:validation:Optional
+	Quota *GPUResourceLimits `json:"quota,omitempty"`
+
+	// ContainerLimits limits the GPU resources requested by each container of the selected namespaces
+	// +kubebuilder:validation:Optional
+	ContainerLimits *GPUResourceLimits `json:"containerLimits,omitempty"`
+}
+
+// GPUResourceLimits defines the maximum amount of each kind of GPU resource
+type GPUResourceLimits struct {
+	// GPUs is the maximum number of full GPUs, requested as nvidia.com/gpu
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	GPUs *int64 `json:"gpus,omitempty"`
+
+	// SharedGPUs is the maximum number of time-sliced GPU replicas, requested as nvidia.com/gpu.shared when the
+	// time-slicing configuration of the device plugin renames the shared resources
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	SharedGPUs *int64 `json:"sharedGPUs,omitempty"`
+
+	// MIGDevices maps MIG profiles, such as 1g.10gb, to the maximum number of MIG devices of the profile,
+	// requested as nvidia.com/mig-<profile> with the mixed MIG strategy
+	// +kubebuilder:validation:Optional
+	MIGDevices map[string]int64 `json:"migDevices,omitempty"`
+}
+
+// ResourceList returns the GPU resources limited, or nil if no limit is set
+func (l *GPUResourceLimits) ResourceList() corev1.ResourceList {
+	if l == nil {
+		return nil
+	}
+	resources := corev1.ResourceList{}
+	if l.GPUs != nil {
+		resources[GPUResourceName] = *resource.NewQuantity(*l.GPUs, resource.DecimalSI)
+	}
+	if l.SharedGPUs != nil {
+		resources[SharedGPUResourceName] = *resource.NewQuantity(*l.SharedGPUs, resource.DecimalSI)
+	}
+	for profile, count := range l.MIGDevices {
+		resources[corev1.ResourceName(MIGResourcePrefix+profile)] = *resource.NewQuantity(count, resource.DecimalSI)
+	}
+	if len(resources) == 0 {
+		return nil
+	}
+	return resources
+}
+
+// GPUAllocationPolicyStatus defines the observed state of GPUAllocationPolicy
+type GPUAllocationPolicyStatus struct {
+	// Namespaces lists the namespaces governed by the policy
+	// +kubebuilder:validation:Optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Conditions is a list of conditions representing the GPUAllocationPolicy's current state.
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster,shortName={"gap"}
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,priority=0
+
+// GPUAllocationPolicy is the Schema for the gpuallocationpolicies API
+type GPUAllocationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GPUAllocationPolicySpec   `json:"spec,omitempty"`
+	Status GPUAllocationPolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// GPUAllocationPolicyList contains a list of GPUAllocationPolicy
+type GPUAllocationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GPUAllocationPolicy `json:"items"`
+}
@@ -0,0 +1,43 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+)
+
+func TestGPUResourceLimitsResourceList(t *testing.T) {
+	var nilLimits *GPUResourceLimits
+	require.Nil(t, nilLimits.ResourceList())
+	require.Nil(t, (&GPUResourceLimits{}).ResourceList())
+
+	limits := &GPUResourceLimits{
+		GPUs:       ptr.To[int64](4),
+		SharedGPUs: ptr.To[int64](0),
+		MIGDevices: map[string]int64{"3g.40gb": 2},
+	}
+	resources := limits.ResourceList()
+	require.Len(t, resources, 3)
+	require.True(t, resource.MustParse("4").Equal(resources[GPUResourceName]))
+	require.True(t, resource.MustParse("0").Equal(resources[SharedGPUResourceName]))
+	require.True(t, resource.MustParse("2").Equal(resources[corev1.ResourceName("nvidia.com/mig-3g.40gb")]))
+}
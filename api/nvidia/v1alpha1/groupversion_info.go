@@ -39,6 +39,8 @@ var (
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion, &NVIDIADriver{}, &NVIDIADriverList{})
 	scheme.AddKnownTypes(SchemeGroupVersion, &GPUCluster{}, &GPUClusterList{})
+	scheme.AddKnownTypes(SchemeGroupVersion, &GPUAllocationPolicy{}, &GPUAllocationPolicyList{})
+	scheme.AddKnownTypes(SchemeGroupVersion, &DriverUpgrade{}, &DriverUpgradeList{})
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
 }
@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/regclient/regclient/types/ref"
@@ -64,6 +65,12 @@ type NVIDIADriverSpec struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="usePrecompiled is an immutable field. Please create a new NvidiaDriver resource instead when you want to change this setting."
 	UsePrecompiled *bool `json:"usePrecompiled,omitempty"`
 
+	// PrecompiledFallback defines how the driver is deployed to the nodes whose kernel has no precompiled
+	// driver image when usePrecompiled is enabled. Without fallbacks, the precompiled driver image is
+	// deployed regardless of its existence.
+	// +kubebuilder:validation:Optional
+	PrecompiledFallback *PrecompiledFallbackSpec `json:"precompiledFallback,omitempty"`
+
 	// Deprecated: This field is no longer honored by the gpu-operator. Please use KernelModuleType instead.
 	// UseOpenKernelModules indicates if the open GPU kernel modules should be used
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
@@ -146,6 +153,15 @@ type NVIDIADriverSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
 	Env []EnvVar `json:"env,omitempty"`
 
+	// Optional: NodePoolOverrides override the configuration of the driver for the node pools of an OS or of a
+	// kernel flavor. Only the DaemonSets of the matching node pools change when an override changes, so that the
+	// driver upgrades are restricted to their nodes.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Node Pool Overrides"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	NodePoolOverrides []DriverNodePoolOverrideSpec `json:"nodePoolOverrides,omitempty"`
+
 	// Optional: Custom repo configuration for NVIDIA Driver container
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Custom Repo Configuration For NVIDIA Driver Container"
@@ -176,6 +192,11 @@ type NVIDIADriverSpec struct {
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Name of the Kubernetes Secret with secret environment variables for the NVIDIA Driver"
 	SecretEnv string `json:"secretEnv,omitempty"`
 
+	// Optional: ModuleCache configures caching of kernel modules built on the node in an OCI registry
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Kernel Module Cache Configuration For NVIDIA Driver Container"
+	ModuleCache *DriverModuleCacheSpec `json:"moduleCache,omitempty"`
+
 	// UpgradePolicy allows to control automatic upgrade of the driver on nodes
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Driver Upgrade Policy"
@@ -281,6 +302,25 @@ type EnvVar struct {
 	Value string `json:"value,omitempty"`
 }
 
+// DriverNodePoolOverrideSpec overrides the configuration of the driver for the node pools matching the OS and the
+// kernel flavor. An unset OS or kernel flavor matches the node pools of all the OSes or kernel flavors.
+// +kubebuilder:validation:XValidation:rule="has(self.os) || has(self.kernelFlavor)",message="os or kernelFlavor must be set"
+type DriverNodePoolOverrideSpec struct {
+	// OS of the node pools, as <os_release.ID><os_release.VERSION_ID> e.g. ubuntu22.04
+	// +kubebuilder:validation:Optional
+	OS string `json:"os,omitempty"`
+
+	// KernelFlavor of the node pools
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=generic;realtime;lowlatency;aws;azure;gcp;oracle
+	KernelFlavor string `json:"kernelFlavor,omitempty"`
+
+	// Optional: Env holds the environment variables of the driver container on the node pools, overriding the
+	// variables of the same name of spec.env
+	// +kubebuilder:validation:Optional
+	Env []EnvVar `json:"env,omitempty"`
+}
+
 // ContainerProbeSpec defines the properties for configuring container probes
 type ContainerProbeSpec struct {
 	// Number of seconds after the container has started before liveness probes are initiated.
@@ -418,13 +458,33 @@ type GDRCopySpec struct {
 	Env []EnvVar `json:"env,omitempty"`
 }
 
-// KernelModuleConfigSpec defines custom configuration parameters for the NVIDIA Driver
+// KernelModuleConfigSpec defines custom configuration parameters for the NVIDIA Driver. The parameters are either
+// provided in a ConfigMap or set per kernel module, in which case the operator renders them in a ConfigMap.
+// +kubebuilder:validation:XValidation:rule="!has(self.name) || size(self.name) == 0 || (!has(self.nvidia) && !has(self.nvidiaUVM) && !has(self.nvidiaPeermem))",message="name cannot be set together with the parameters of the kernel modules"
 type KernelModuleConfigSpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="ConfigMap Name"
 	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
 	Name string `json:"name,omitempty"`
+
+	// Optional: NVIDIA holds the parameters of the nvidia kernel module, e.g. NVreg_EnableGpuFirmware: "0"
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Parameters of the nvidia kernel module"
+	NVIDIA map[string]string `json:"nvidia,omitempty"`
+
+	// Optional: NVIDIAUVM holds the parameters of the nvidia_uvm kernel module
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Parameters of the nvidia_uvm kernel module"
+	NVIDIAUVM map[string]string `json:"nvidiaUVM,omitempty"`
+
+	// Optional: NVIDIAPeermem holds the parameters of the nvidia_peermem kernel module
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Parameters of the nvidia_peermem kernel module"
+	NVIDIAPeermem map[string]string `json:"nvidiaPeermem,omitempty"`
 }
 
 // VirtualTopologyConfigSpec defines virtual topology daemon configuration with NVIDIA vGPU
@@ -455,6 +515,34 @@ type DriverRepoConfigSpec struct {
 	Name string `json:"name,omitempty"`
 }
 
+// DriverModuleCacheSpec defines the configuration for caching kernel modules built on the node.
+// After a successful build, the driver container pushes the kernel modules as an OCI artifact
+// keyed by (driver version, kernel version, architecture). Nodes with the same key pull the
+// cached artifact instead of building the modules again.
+type DriverModuleCacheSpec struct {
+	// Enabled indicates if caching of built kernel modules is enabled
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Enable kernel module caching"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Repository is the OCI repository the kernel module artifacts are pushed to and pulled from,
+	// e.g. registry.local:5000/nvidia/driver-modules
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Repository"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Repository string `json:"repository,omitempty"`
+
+	// SecretName is the name of a kubernetes.io/dockerconfigjson Secret with credentials for the repository
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Secret Name"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	SecretName string `json:"secretName,omitempty"`
+}
+
 // DriverLicensingConfigSpec defines licensing server configuration for NVIDIA Driver container
 type DriverLicensingConfigSpec struct {
 	// +kubebuilder:validation:Optional
@@ -477,6 +565,77 @@ type DriverLicensingConfigSpec struct {
 	NLSEnabled *bool `json:"nlsEnabled,omitempty"`
 }
 
+// PrecompiledFallbackSpec defines the fallbacks applied when no precompiled driver image exists for a kernel
+// +kubebuilder:validation:XValidation:rule="!has(self.fallbacks) || !self.fallbacks.exists(f, f == 'build') || has(self.build)",message="build must be set when the build fallback is used"
+type PrecompiledFallbackSpec struct {
+	// Fallbacks lists the fallbacks tried in order when the precompiled driver image of a kernel does not exist.
+	// compileFromSource deploys the driver image compiling the driver on the node, if this image exists.
+	// build builds the precompiled driver image of the kernel on the cluster with a Job and deploys it once pushed,
+	// no driver being deployed to the nodes until then.
+	// skip deploys no driver to the nodes and labels them with nvidia.com/gpu.driver.precompiled-unavailable=true.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=3
+	// +kubebuilder:validation:items:Enum=compileFromSource;build;skip
+	Fallbacks []PrecompiledFallbackMode `json:"fallbacks,omitempty"`
+
+	// Version is the driver version compiled from source, e.g. 580.95.05. Defaults to the version of the
+	// NVIDIADriver, which must then be a full driver version rather than a driver branch.
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+
+	// Build configures the Jobs building the precompiled driver images of the kernels with the build fallback
+	// +kubebuilder:validation:Optional
+	Build *DriverBuildSpec `json:"build,omitempty"`
+}
+
+// DriverBuildSpec configures the Jobs building the precompiled driver image of a kernel on the cluster. The image
+// is built from the precompiled Dockerfile of the OS in the build context and pushed to the repository, e.g. of an
+// in-cluster registry, as <repository>/<image>:<version>-<kernel>-<os>.
+type DriverBuildSpec struct {
+	// Repository the built driver images are pushed to and pulled from, e.g. registry.kube-system.svc:5000/nvidia
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Repository string `json:"repository"`
+
+	// Insecure pushes and pulls the built images over plain HTTP, e.g. for an in-cluster registry without TLS
+	// +kubebuilder:validation:Optional
+	Insecure bool `json:"insecure,omitempty"`
+
+	// PushSecret is the name of the Secret of type kubernetes.io/dockerconfigjson holding the credentials pushing
+	// to the repository, in the operator namespace
+	// +kubebuilder:validation:Optional
+	PushSecret string `json:"pushSecret,omitempty"`
+
+	// BuilderImage is the image of the Kaniko executor building the driver images
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="gcr.io/kaniko-project/executor:v1.23.2"
+	BuilderImage string `json:"builderImage,omitempty"`
+
+	// Context is the Kaniko build context holding the precompiled Dockerfiles of the driver container, under the
+	// <os>/precompiled directories
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="git://github.com/NVIDIA/gpu-driver-container.git#refs/heads/main"
+	Context string `json:"context,omitempty"`
+
+	// Optional: Define resources requests and limits for the build containers
+	// +kubebuilder:validation:Optional
+	Resources *ResourceRequirements `json:"resources,omitempty"`
+}
+
+// PrecompiledFallbackMode defines how the driver is deployed to the nodes of a kernel
+type PrecompiledFallbackMode string
+
+const (
+	// PrecompiledMode deploys the precompiled driver image of the kernel
+	PrecompiledMode PrecompiledFallbackMode = "precompiled"
+	// CompileFromSourceFallback deploys the driver image compiling the driver on the node
+	CompileFromSourceFallback PrecompiledFallbackMode = "compileFromSource"
+	// BuildFallback builds the precompiled driver image of the kernel on the cluster and deploys it once pushed
+	BuildFallback PrecompiledFallbackMode = "build"
+	// SkipFallback deploys no driver and labels the nodes
+	SkipFallback PrecompiledFallbackMode = "skip"
+)
+
 // DriverType defines NVIDIA driver type
 type DriverType string
 
@@ -512,6 +671,39 @@ type NVIDIADriverStatus struct {
 	Namespace string `json:"namespace,omitempty"`
 	// Conditions is a list of conditions representing the NVIDIADriver's current state.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Precompiled reports how the driver is deployed to the nodes of each kernel version when precompiled
+	// drivers are enabled
+	Precompiled []PrecompiledKernelStatus `json:"precompiled,omitempty"`
+	// QuarantinedNodes lists the nodes selected by the NVIDIADriver which are deployed no driver because they
+	// miss the node labels needed to select their driver, e.g. the NFD labels of their operating system. Only
+	// the first nodes by name are listed when many nodes are quarantined.
+	QuarantinedNodes []QuarantinedNode `json:"quarantinedNodes,omitempty"`
+}
+
+// QuarantinedNode is a node deployed no driver until its labels are fixed
+type QuarantinedNode struct {
+	// Name is the name of the node
+	Name string `json:"name"`
+	// Reason explains why the node is quarantined
+	Reason string `json:"reason"`
+}
+
+// PrecompiledKernelStatus reports how the driver is deployed to the nodes running a kernel version
+type PrecompiledKernelStatus struct {
+	// KernelVersion is the kernel version of the nodes
+	KernelVersion string `json:"kernelVersion"`
+	// OSVersion is the operating system of the nodes, e.g. ubuntu22.04
+	OSVersion string `json:"osVersion"`
+	// Mode is how the driver is deployed to the nodes
+	// +kubebuilder:validation:Enum=precompiled;compileFromSource;build;skip
+	Mode PrecompiledFallbackMode `json:"mode"`
+	// Image is the driver image deployed to the nodes, unset when the nodes are skipped
+	Image string `json:"image,omitempty"`
+	// Message explains why the precompiled driver image is not deployed
+	Message string `json:"message,omitempty"`
+	// BuildJob is the name of the Job building the driver image of the kernel with the build fallback, unset once
+	// the image is built
+	BuildJob string `json:"buildJob,omitempty"`
 }
 
 // +genclient
@@ -574,6 +766,23 @@ func (d *NVIDIADriverSpec) UsePrecompiledDrivers() bool {
 	return *d.UsePrecompiled
 }
 
+// GetDriverBuildSpec returns the configuration of the Jobs building the precompiled driver images, nil when the
+// build fallback is not configured
+func (d *NVIDIADriverSpec) GetDriverBuildSpec() *DriverBuildSpec {
+	if d.PrecompiledFallback == nil {
+		return nil
+	}
+	return d.PrecompiledFallback.Build
+}
+
+// GetPrecompiledFallbacks returns the fallbacks applied when no precompiled driver image exists for a kernel
+func (d *NVIDIADriverSpec) GetPrecompiledFallbacks() []PrecompiledFallbackMode {
+	if d.PrecompiledFallback == nil {
+		return nil
+	}
+	return d.PrecompiledFallback.Fallbacks
+}
+
 // GetNodeSelector returns node selector labels for NVIDIA driver installation
 func (d *NVIDIADriver) GetNodeSelector() map[string]string {
 	ns := d.Spec.NodeSelector
@@ -780,6 +989,16 @@ func (d *NVIDIADriverSpec) IsCertConfigEnabled() bool {
 	return d.CertConfig.Name != ""
 }
 
+// IsModuleCacheEnabled returns true if caching of built kernel modules is enabled.
+// Module caching is only relevant when the driver is compiled on the node.
+func (d *NVIDIADriverSpec) IsModuleCacheEnabled() bool {
+	if d.ModuleCache == nil || d.ModuleCache.Enabled == nil {
+		// default is false if not specified by user
+		return false
+	}
+	return *d.ModuleCache.Enabled && d.ModuleCache.Repository != "" && !d.UsePrecompiledDrivers()
+}
+
 // IsNLSEnabled returns true if NLS should be used for licensing the driver
 func (l *DriverLicensingConfigSpec) IsNLSEnabled() bool {
 	if l.NLSEnabled == nil {
@@ -897,3 +1116,40 @@ func getDefaultDrainSpec() *DrainSpec {
 		DeleteEmptyDir: false,
 	}
 }
+
+// HasModuleParameters returns true if the parameters of a kernel module are set
+func (k *KernelModuleConfigSpec) HasModuleParameters() bool {
+	if k == nil {
+		return false
+	}
+	return len(k.NVIDIA) > 0 || len(k.NVIDIAUVM) > 0 || len(k.NVIDIAPeermem) > 0
+}
+
+// ModuleParameterFiles returns the configuration files of the kernel modules read by the driver container, keyed
+// by file name. Each file lists the parameters of a module, one key=value per line sorted by key.
+func (k *KernelModuleConfigSpec) ModuleParameterFiles() map[string]string {
+	if !k.HasModuleParameters() {
+		return nil
+	}
+	files := map[string]string{}
+	for file, params := range map[string]map[string]string{
+		"nvidia.conf":         k.NVIDIA,
+		"nvidia-uvm.conf":     k.NVIDIAUVM,
+		"nvidia-peermem.conf": k.NVIDIAPeermem,
+	} {
+		if len(params) == 0 {
+			continue
+		}
+		keys := make([]string, 0, len(params))
+		for key := range params {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var sb strings.Builder
+		for _, key := range keys {
+			fmt.Fprintf(&sb, "%s=%s\n", key, params[key])
+		}
+		files[file] = sb.String()
+	}
+	return files
+}
@@ -416,3 +416,23 @@ func TestGDRCopyGetImagePath(t *testing.T) {
 		})
 	}
 }
+
+func TestKernelModuleConfigModuleParameterFiles(t *testing.T) {
+	var nilConfig *KernelModuleConfigSpec
+	require.False(t, nilConfig.HasModuleParameters())
+	require.Nil(t, nilConfig.ModuleParameterFiles())
+
+	config := &KernelModuleConfigSpec{Name: "kernel-module-params"}
+	require.False(t, config.HasModuleParameters())
+	require.Nil(t, config.ModuleParameterFiles())
+
+	config = &KernelModuleConfigSpec{
+		NVIDIA:        map[string]string{"NVreg_RegistryDwords": "RMIntrLockingMode=1", "NVreg_EnableGpuFirmware": "0"},
+		NVIDIAPeermem: map[string]string{"peerdirect_support": "1"},
+	}
+	require.True(t, config.HasModuleParameters())
+	require.Equal(t, map[string]string{
+		"nvidia.conf":         "NVreg_EnableGpuFirmware=0\nNVreg_RegistryDwords=RMIntrLockingMode=1\n",
+		"nvidia-peermem.conf": "peerdirect_support=1\n",
+	}, config.ModuleParameterFiles())
+}
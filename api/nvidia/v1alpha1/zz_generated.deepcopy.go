@@ -190,6 +190,26 @@ func (in *DRADriverSpec) DeepCopy() *DRADriverSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverBuildSpec) DeepCopyInto(out *DriverBuildSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverBuildSpec.
+func (in *DriverBuildSpec) DeepCopy() *DriverBuildSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverBuildSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DriverCertConfigSpec) DeepCopyInto(out *DriverCertConfigSpec) {
 	*out = *in
@@ -250,6 +270,46 @@ func (in *DriverManagerSpec) DeepCopy() *DriverManagerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverModuleCacheSpec) DeepCopyInto(out *DriverModuleCacheSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverModuleCacheSpec.
+func (in *DriverModuleCacheSpec) DeepCopy() *DriverModuleCacheSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverModuleCacheSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverNodePoolOverrideSpec) DeepCopyInto(out *DriverNodePoolOverrideSpec) {
+	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]EnvVar, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverNodePoolOverrideSpec.
+func (in *DriverNodePoolOverrideSpec) DeepCopy() *DriverNodePoolOverrideSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverNodePoolOverrideSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DriverRepoConfigSpec) DeepCopyInto(out *DriverRepoConfigSpec) {
 	*out = *in
@@ -265,6 +325,80 @@ func (in *DriverRepoConfigSpec) DeepCopy() *DriverRepoConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverUpgrade) DeepCopyInto(out *DriverUpgrade) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverUpgrade.
+func (in *DriverUpgrade) DeepCopy() *DriverUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DriverUpgrade) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverUpgradeList) DeepCopyInto(out *DriverUpgradeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DriverUpgrade, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverUpgradeList.
+func (in *DriverUpgradeList) DeepCopy() *DriverUpgradeList {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverUpgradeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DriverUpgradeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverUpgradeNodeStatus) DeepCopyInto(out *DriverUpgradeNodeStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverUpgradeNodeStatus.
+func (in *DriverUpgradeNodeStatus) DeepCopy() *DriverUpgradeNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverUpgradeNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DriverUpgradePolicySpec) DeepCopyInto(out *DriverUpgradePolicySpec) {
 	*out = *in
@@ -300,6 +434,35 @@ func (in *DriverUpgradePolicySpec) DeepCopy() *DriverUpgradePolicySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverUpgradeStatus) DeepCopyInto(out *DriverUpgradeStatus) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]DriverUpgradeNodeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverUpgradeStatus.
+func (in *DriverUpgradeStatus) DeepCopy() *DriverUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvVar) DeepCopyInto(out *EnvVar) {
 	*out = *in
@@ -350,6 +513,118 @@ func (in *GDRCopySpec) DeepCopy() *GDRCopySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUAllocationPolicy) DeepCopyInto(out *GPUAllocationPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUAllocationPolicy.
+func (in *GPUAllocationPolicy) DeepCopy() *GPUAllocationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUAllocationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GPUAllocationPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUAllocationPolicyList) DeepCopyInto(out *GPUAllocationPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GPUAllocationPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUAllocationPolicyList.
+func (in *GPUAllocationPolicyList) DeepCopy() *GPUAllocationPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUAllocationPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GPUAllocationPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUAllocationPolicySpec) DeepCopyInto(out *GPUAllocationPolicySpec) {
+	*out = *in
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	if in.Quota != nil {
+		in, out := &in.Quota, &out.Quota
+		*out = new(GPUResourceLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContainerLimits != nil {
+		in, out := &in.ContainerLimits, &out.ContainerLimits
+		*out = new(GPUResourceLimits)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUAllocationPolicySpec.
+func (in *GPUAllocationPolicySpec) DeepCopy() *GPUAllocationPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUAllocationPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUAllocationPolicyStatus) DeepCopyInto(out *GPUAllocationPolicyStatus) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUAllocationPolicyStatus.
+func (in *GPUAllocationPolicyStatus) DeepCopy() *GPUAllocationPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUAllocationPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GPUCluster) DeepCopyInto(out *GPUCluster) {
 	*out = *in
@@ -459,6 +734,38 @@ func (in *GPUClusterStatus) DeepCopy() *GPUClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUResourceLimits) DeepCopyInto(out *GPUResourceLimits) {
+	*out = *in
+	if in.GPUs != nil {
+		in, out := &in.GPUs, &out.GPUs
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SharedGPUs != nil {
+		in, out := &in.SharedGPUs, &out.SharedGPUs
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MIGDevices != nil {
+		in, out := &in.MIGDevices, &out.MIGDevices
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUResourceLimits.
+func (in *GPUResourceLimits) DeepCopy() *GPUResourceLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUResourceLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GPUDirectRDMASpec) DeepCopyInto(out *GPUDirectRDMASpec) {
 	*out = *in
@@ -522,6 +829,27 @@ func (in *GPUDirectStorageSpec) DeepCopy() *GPUDirectStorageSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KernelModuleConfigSpec) DeepCopyInto(out *KernelModuleConfigSpec) {
 	*out = *in
+	if in.NVIDIA != nil {
+		in, out := &in.NVIDIA, &out.NVIDIA
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NVIDIAUVM != nil {
+		in, out := &in.NVIDIAUVM, &out.NVIDIAUVM
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NVIDIAPeermem != nil {
+		in, out := &in.NVIDIAPeermem, &out.NVIDIAPeermem
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KernelModuleConfigSpec.
@@ -601,6 +929,11 @@ func (in *NVIDIADriverSpec) DeepCopyInto(out *NVIDIADriverSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.PrecompiledFallback != nil {
+		in, out := &in.PrecompiledFallback, &out.PrecompiledFallback
+		*out = new(PrecompiledFallbackSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.UseOpenKernelModules != nil {
 		in, out := &in.UseOpenKernelModules, &out.UseOpenKernelModules
 		*out = new(bool)
@@ -657,6 +990,13 @@ func (in *NVIDIADriverSpec) DeepCopyInto(out *NVIDIADriverSpec) {
 		*out = make([]EnvVar, len(*in))
 		copy(*out, *in)
 	}
+	if in.NodePoolOverrides != nil {
+		in, out := &in.NodePoolOverrides, &out.NodePoolOverrides
+		*out = make([]DriverNodePoolOverrideSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.RepoConfig != nil {
 		in, out := &in.RepoConfig, &out.RepoConfig
 		*out = new(DriverRepoConfigSpec)
@@ -680,7 +1020,12 @@ func (in *NVIDIADriverSpec) DeepCopyInto(out *NVIDIADriverSpec) {
 	if in.KernelModuleConfig != nil {
 		in, out := &in.KernelModuleConfig, &out.KernelModuleConfig
 		*out = new(KernelModuleConfigSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ModuleCache != nil {
+		in, out := &in.ModuleCache, &out.ModuleCache
+		*out = new(DriverModuleCacheSpec)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.UpgradePolicy != nil {
 		in, out := &in.UpgradePolicy, &out.UpgradePolicy
@@ -752,6 +1097,16 @@ func (in *NVIDIADriverStatus) DeepCopyInto(out *NVIDIADriverStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Precompiled != nil {
+		in, out := &in.Precompiled, &out.Precompiled
+		*out = make([]PrecompiledKernelStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.QuarantinedNodes != nil {
+		in, out := &in.QuarantinedNodes, &out.QuarantinedNodes
+		*out = make([]QuarantinedNode, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NVIDIADriverStatus.
@@ -764,6 +1119,61 @@ func (in *NVIDIADriverStatus) DeepCopy() *NVIDIADriverStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrecompiledFallbackSpec) DeepCopyInto(out *PrecompiledFallbackSpec) {
+	*out = *in
+	if in.Fallbacks != nil {
+		in, out := &in.Fallbacks, &out.Fallbacks
+		*out = make([]PrecompiledFallbackMode, len(*in))
+		copy(*out, *in)
+	}
+	if in.Build != nil {
+		in, out := &in.Build, &out.Build
+		*out = new(DriverBuildSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrecompiledFallbackSpec.
+func (in *PrecompiledFallbackSpec) DeepCopy() *PrecompiledFallbackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrecompiledFallbackSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrecompiledKernelStatus) DeepCopyInto(out *PrecompiledKernelStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrecompiledKernelStatus.
+func (in *PrecompiledKernelStatus) DeepCopy() *PrecompiledKernelStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PrecompiledKernelStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuarantinedNode) DeepCopyInto(out *QuarantinedNode) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuarantinedNode.
+func (in *QuarantinedNode) DeepCopy() *QuarantinedNode {
+	if in == nil {
+		return nil
+	}
+	out := new(QuarantinedNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceRequirements) DeepCopyInto(out *ResourceRequirements) {
 	*out = *in
@@ -0,0 +1,207 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+// DeprecatedSpecAnnotationKey is the annotation of the v2 ClusterPolicies preserving the deprecated v1 fields
+// dropped by the v2 API, so that the ClusterPolicies updated through the v2 API keep them
+const DeprecatedSpecAnnotationKey = "nvidia.com/clusterpolicy.v1-deprecated-spec"
+
+// deprecatedSpec holds the v1 fields dropped by the v2 API
+type deprecatedSpec struct {
+	PSP         gpuv1.PSPSpec         `json:"psp,omitempty"`
+	KataManager gpuv1.KataManagerSpec `json:"kataManager,omitempty"`
+}
+
+// ConvertTo converts the v2 ClusterPolicy to the v1 hub
+func (src *ClusterPolicy) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*gpuv1.ClusterPolicy)
+	if !ok {
+		return fmt.Errorf("unexpected ClusterPolicy hub type %T", dstRaw)
+	}
+	in := src.DeepCopy()
+	dst.ObjectMeta = in.ObjectMeta
+	dst.Status = in.Status
+	dst.Spec = gpuv1.ClusterPolicySpec{
+		Operator:                in.Spec.Operator,
+		Daemonsets:              in.Spec.Daemonsets,
+		NodeSelector:            in.Spec.NodeSelector,
+		Driver:                  in.Spec.Driver.DriverSpec,
+		GPUDirectStorage:        in.Spec.Driver.GPUDirectStorage,
+		GDRCopy:                 in.Spec.Driver.GDRCopy,
+		DownloadCache:           in.Spec.Driver.DownloadCache,
+		Toolkit:                 in.Spec.Runtime.Toolkit,
+		CDI:                     in.Spec.Runtime.CDI,
+		DevicePlugin:            in.Spec.DevicePlugin,
+		GPUFeatureDiscovery:     in.Spec.GPUFeatureDiscovery,
+		MIG:                     in.Spec.MIG,
+		MIGManager:              in.Spec.MIGManager,
+		DCGM:                    in.Spec.Monitoring.DCGM,
+		DCGMExporter:            in.Spec.Monitoring.DCGMExporter,
+		NodeStatusExporter:      in.Spec.Monitoring.NodeStatusExporter,
+		HealthCheck:             in.Spec.Monitoring.HealthCheck,
+		FleetReport:             in.Spec.Monitoring.FleetReport,
+		UsageAccounting:         in.Spec.Monitoring.UsageAccounting,
+		SandboxWorkloads:        in.Spec.Sandbox.SandboxWorkloadsSpec,
+		VFIOManager:             in.Spec.Sandbox.VFIOManager,
+		SandboxDevicePlugin:     in.Spec.Sandbox.SandboxDevicePlugin,
+		KataSandboxDevicePlugin: in.Spec.Sandbox.KataSandboxDevicePlugin,
+		VGPUManager:             in.Spec.Sandbox.VGPUManager,
+		VGPUDeviceManager:       in.Spec.Sandbox.VGPUDeviceManager,
+		CCManager:               in.Spec.Sandbox.CCManager,
+		PSA:                     in.Spec.PSA,
+		Validator:               in.Spec.Validator,
+		HostPaths:               in.Spec.HostPaths,
+		ImageResolution:         in.Spec.ImageResolution,
+		ImageMirror:             in.Spec.ImageMirror,
+		ImagePullSecrets:        in.Spec.ImagePullSecrets,
+		RegistryClient:          in.Spec.RegistryClient,
+		NVLinkFabric:            in.Spec.NVLinkFabric,
+		MPSControlDaemon:        in.Spec.MPSControlDaemon,
+		ImagePrePull:            in.Spec.ImagePrePull,
+		SafeMode:                in.Spec.SafeMode,
+		Windows:                 in.Spec.Windows,
+		NodeFeatureDiscovery:    in.Spec.NodeFeatureDiscovery,
+		ExternalGates:           in.Spec.ExternalGates,
+		TenantNamespaces:        in.Spec.TenantNamespaces,
+		ConsumerGPUs:            in.Spec.ConsumerGPUs,
+		Proxy:                   in.Spec.Proxy,
+		ReadinessHysteresis:     in.Spec.ReadinessHysteresis,
+		AutoscalingHints:        in.Spec.AutoscalingHints,
+		Notifications:           in.Spec.Notifications,
+		Teardown:                in.Spec.Teardown,
+		Devel:                   in.Spec.Devel,
+	}
+	return restoreDeprecatedSpec(&dst.ObjectMeta, &dst.Spec)
+}
+
+// ConvertFrom converts the v1 hub to the v2 ClusterPolicy
+func (dst *ClusterPolicy) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*gpuv1.ClusterPolicy)
+	if !ok {
+		return fmt.Errorf("unexpected ClusterPolicy hub type %T", srcRaw)
+	}
+	in := src.DeepCopy()
+	dst.ObjectMeta = in.ObjectMeta
+	dst.Status = in.Status
+	dst.Spec = ClusterPolicySpec{
+		Operator:     in.Spec.Operator,
+		Daemonsets:   in.Spec.Daemonsets,
+		NodeSelector: in.Spec.NodeSelector,
+		Driver: DriverGroupSpec{
+			DriverSpec:       in.Spec.Driver,
+			GPUDirectStorage: in.Spec.GPUDirectStorage,
+			GDRCopy:          in.Spec.GDRCopy,
+			DownloadCache:    in.Spec.DownloadCache,
+		},
+		Runtime: RuntimeGroupSpec{
+			Toolkit: in.Spec.Toolkit,
+			CDI:     in.Spec.CDI,
+		},
+		DevicePlugin:        in.Spec.DevicePlugin,
+		GPUFeatureDiscovery: in.Spec.GPUFeatureDiscovery,
+		MIG:                 in.Spec.MIG,
+		MIGManager:          in.Spec.MIGManager,
+		Monitoring: MonitoringGroupSpec{
+			DCGM:               in.Spec.DCGM,
+			DCGMExporter:       in.Spec.DCGMExporter,
+			NodeStatusExporter: in.Spec.NodeStatusExporter,
+			HealthCheck:        in.Spec.HealthCheck,
+			FleetReport:        in.Spec.FleetReport,
+			UsageAccounting:    in.Spec.UsageAccounting,
+		},
+		Sandbox: SandboxGroupSpec{
+			SandboxWorkloadsSpec:    in.Spec.SandboxWorkloads,
+			VFIOManager:             in.Spec.VFIOManager,
+			SandboxDevicePlugin:     in.Spec.SandboxDevicePlugin,
+			KataSandboxDevicePlugin: in.Spec.KataSandboxDevicePlugin,
+			VGPUManager:             in.Spec.VGPUManager,
+			VGPUDeviceManager:       in.Spec.VGPUDeviceManager,
+			CCManager:               in.Spec.CCManager,
+		},
+		PSA:                  in.Spec.PSA,
+		Validator:            in.Spec.Validator,
+		HostPaths:            in.Spec.HostPaths,
+		ImageResolution:      in.Spec.ImageResolution,
+		ImageMirror:          in.Spec.ImageMirror,
+		ImagePullSecrets:     in.Spec.ImagePullSecrets,
+		RegistryClient:       in.Spec.RegistryClient,
+		NVLinkFabric:         in.Spec.NVLinkFabric,
+		MPSControlDaemon:     in.Spec.MPSControlDaemon,
+		ImagePrePull:         in.Spec.ImagePrePull,
+		SafeMode:             in.Spec.SafeMode,
+		Windows:              in.Spec.Windows,
+		NodeFeatureDiscovery: in.Spec.NodeFeatureDiscovery,
+		ExternalGates:        in.Spec.ExternalGates,
+		TenantNamespaces:     in.Spec.TenantNamespaces,
+		ConsumerGPUs:         in.Spec.ConsumerGPUs,
+		Proxy:                in.Spec.Proxy,
+		ReadinessHysteresis:  in.Spec.ReadinessHysteresis,
+		AutoscalingHints:     in.Spec.AutoscalingHints,
+		Notifications:        in.Spec.Notifications,
+		Teardown:             in.Spec.Teardown,
+		Devel:                in.Spec.Devel,
+	}
+	return preserveDeprecatedSpec(&dst.ObjectMeta, &in.Spec)
+}
+
+// preserveDeprecatedSpec stores the deprecated fields of the v1 spec in the annotation of the v2 ClusterPolicy
+func preserveDeprecatedSpec(meta *metav1.ObjectMeta, spec *gpuv1.ClusterPolicySpec) error {
+	delete(meta.Annotations, DeprecatedSpecAnnotationKey)
+	deprecated := deprecatedSpec{PSP: spec.PSP, KataManager: spec.KataManager}
+	if reflect.DeepEqual(deprecated, deprecatedSpec{}) {
+		return nil
+	}
+	value, err := json.Marshal(deprecated)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the deprecated ClusterPolicy fields: %w", err)
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[DeprecatedSpecAnnotationKey] = string(value)
+	return nil
+}
+
+// restoreDeprecatedSpec restores the deprecated fields of the v1 spec from the annotation of the v2 ClusterPolicy
+func restoreDeprecatedSpec(meta *metav1.ObjectMeta, spec *gpuv1.ClusterPolicySpec) error {
+	value, ok := meta.Annotations[DeprecatedSpecAnnotationKey]
+	if !ok {
+		return nil
+	}
+	deprecated := deprecatedSpec{}
+	if err := json.Unmarshal([]byte(value), &deprecated); err != nil {
+		return fmt.Errorf("failed to unmarshal the %s annotation: %w", DeprecatedSpecAnnotationKey, err)
+	}
+	spec.PSP = deprecated.PSP
+	spec.KataManager = deprecated.KataManager
+	delete(meta.Annotations, DeprecatedSpecAnnotationKey)
+	if len(meta.Annotations) == 0 {
+		meta.Annotations = nil
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package v2
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/randfill"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func newClusterPolicyFiller(seed int64) *randfill.Filler {
+	return randfill.NewWithSeed(seed).NilChance(0.2).NumElements(1, 2).Funcs(
+		// the deprecated fields are marshaled in an annotation, the quantities must survive it
+		func(q *resource.Quantity, c randfill.Continue) {
+			*q = *resource.NewQuantity(c.Int63n(1000), resource.DecimalSI)
+		},
+	)
+}
+
+func TestClusterPolicyRoundTripFromV1(t *testing.T) {
+	for seed := int64(0); seed < 200; seed++ {
+		original := &gpuv1.ClusterPolicy{}
+		newClusterPolicyFiller(seed).Fill(original)
+		original.TypeMeta = metav1.TypeMeta{}
+
+		converted := &ClusterPolicy{}
+		require.NoError(t, converted.ConvertFrom(original.DeepCopy()))
+		roundTripped := &gpuv1.ClusterPolicy{}
+		require.NoError(t, converted.ConvertTo(roundTripped))
+
+		require.Truef(t, apiequality.Semantic.DeepEqual(original, roundTripped), "seed %d: v1 ClusterPolicy changed by the round trip", seed)
+	}
+}
+
+func TestClusterPolicyRoundTripFromV2(t *testing.T) {
+	for seed := int64(0); seed < 200; seed++ {
+		original := &ClusterPolicy{}
+		newClusterPolicyFiller(seed).Fill(original)
+		original.TypeMeta = metav1.TypeMeta{}
+		delete(original.Annotations, DeprecatedSpecAnnotationKey)
+
+		hub := &gpuv1.ClusterPolicy{}
+		require.NoError(t, original.DeepCopy().ConvertTo(hub))
+		roundTripped := &ClusterPolicy{}
+		require.NoError(t, roundTripped.ConvertFrom(hub))
+
+		require.Truef(t, apiequality.Semantic.DeepEqual(original, roundTripped), "seed %d: v2 ClusterPolicy changed by the round trip", seed)
+	}
+}
+
+func TestClusterPolicyConvertFromGroupsComponents(t *testing.T) {
+	src := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy"},
+		Spec: gpuv1.ClusterPolicySpec{
+			Driver:           gpuv1.DriverSpec{Version: "570.86.15"},
+			GDRCopy:          &gpuv1.GDRCopySpec{Enabled: ptr.To(true)},
+			Toolkit:          gpuv1.ToolkitSpec{Enabled: ptr.To(false)},
+			DCGMExporter:     gpuv1.DCGMExporterSpec{Version: "4.1.1"},
+			SandboxWorkloads: gpuv1.SandboxWorkloadsSpec{Enabled: ptr.To(true), Mode: string(gpuv1.Kata)},
+			VFIOManager:      gpuv1.VFIOManagerSpec{Enabled: ptr.To(true)},
+			PSP:              gpuv1.PSPSpec{Enabled: ptr.To(true)},
+		},
+	}
+
+	dst := &ClusterPolicy{}
+	require.NoError(t, dst.ConvertFrom(src))
+
+	raw, err := json.Marshal(dst)
+	require.NoError(t, err)
+	manifest := map[string]any{}
+	require.NoError(t, json.Unmarshal(raw, &manifest))
+	spec := manifest["spec"].(map[string]any)
+
+	driver := spec["driver"].(map[string]any)
+	require.Equal(t, "570.86.15", driver["version"])
+	require.Equal(t, true, driver["gdrcopy"].(map[string]any)["enabled"])
+	require.Equal(t, false, spec["runtime"].(map[string]any)["toolkit"].(map[string]any)["enabled"])
+	require.Equal(t, "4.1.1", spec["monitoring"].(map[string]any)["dcgmExporter"].(map[string]any)["version"])
+	sandbox := spec["sandbox"].(map[string]any)
+	require.Equal(t, "kata", sandbox["mode"])
+	require.Equal(t, true, sandbox["vfioManager"].(map[string]any)["enabled"])
+	for _, field := range []string{"toolkit", "dcgmExporter", "sandboxWorkloads", "vfioManager", "gdrcopy", "psp", "kataManager"} {
+		require.NotContains(t, spec, field)
+	}
+	require.JSONEq(t, `{"psp":{"enabled":true},"kataManager":{}}`, dst.Annotations[DeprecatedSpecAnnotationKey])
+
+	// a v2 client updating the ClusterPolicy keeps the deprecated fields
+	hub := &gpuv1.ClusterPolicy{}
+	require.NoError(t, dst.ConvertTo(hub))
+	require.Equal(t, src, hub)
+}
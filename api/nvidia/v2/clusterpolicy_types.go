@@ -0,0 +1,217 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package v2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+// ClusterPolicySpec defines the desired state of ClusterPolicy. The component specs are those of the v1 API,
+// grouped by concern: the driver, the container runtime integration, the monitoring and the sandbox
+// workloads. The deprecated PSP and KataManager fields of the v1 API are dropped.
+type ClusterPolicySpec struct {
+	// Operator component spec
+	Operator gpuv1.OperatorSpec `json:"operator"`
+	// Daemonset defines common configuration for all Daemonsets
+	Daemonsets gpuv1.DaemonsetsSpec `json:"daemonsets"`
+	// NodeSelector scopes the ClusterPolicy to the GPU nodes matching the selector. The operands of these
+	// nodes are deployed as configured by the scoped ClusterPolicy, while the operands of the other GPU nodes
+	// are deployed as configured by the ClusterPolicy without node selector. The node selectors of the scoped
+	// ClusterPolicies must not match the same node.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Node Selector"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Driver groups the driver and the components loading additional kernel modules on top of it
+	Driver DriverGroupSpec `json:"driver"`
+	// Runtime groups the configuration of the container runtimes exposing the GPUs to the containers
+	// +kubebuilder:validation:Optional
+	Runtime RuntimeGroupSpec `json:"runtime,omitempty"`
+	// DevicePlugin component spec
+	DevicePlugin gpuv1.DevicePluginSpec `json:"devicePlugin"`
+	// GPUFeatureDiscovery spec
+	GPUFeatureDiscovery gpuv1.GPUFeatureDiscoverySpec `json:"gfd"`
+	// MIG spec
+	MIG gpuv1.MIGSpec `json:"mig,omitempty"`
+	// MIGManager for configuration to deploy MIG Manager
+	MIGManager gpuv1.MIGManagerSpec `json:"migManager,omitempty"`
+	// Monitoring groups the components collecting the GPU telemetry and reporting the GPU health and usage
+	// +kubebuilder:validation:Optional
+	Monitoring MonitoringGroupSpec `json:"monitoring,omitempty"`
+	// Sandbox groups the handling of the sandbox workloads (i.e. Virtual Machines) and their components
+	// +kubebuilder:validation:Optional
+	Sandbox SandboxGroupSpec `json:"sandbox,omitempty"`
+	// PSA defines spec for PodSecurityAdmission configuration
+	PSA gpuv1.PSASpec `json:"psa,omitempty"`
+	// Validator defines the spec for operator-validator daemonset
+	Validator gpuv1.ValidatorSpec `json:"validator,omitempty"`
+	// HostPaths defines various paths on the host needed by GPU Operator components
+	HostPaths gpuv1.HostPathsSpec `json:"hostPaths,omitempty"`
+	// ImageResolution defines how operand image references are resolved before rendering
+	ImageResolution *gpuv1.ImageResolutionSpec `json:"imageResolution,omitempty"`
+	// ImageMirror maps registry prefixes to the mirror prefixes replacing them in the images of all operands,
+	// including init containers, e.g. "nvcr.io/nvidia": "registry.local/nvidia". Prefixes match whole path
+	// components of the image repository, and the longest matching prefix is used.
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Image Mirror"
+	ImageMirror map[string]string `json:"imageMirror,omitempty"`
+	// ImagePullSecrets defines the image pull secrets attached to all operands, on top of the image pull
+	// secrets of each operand
+	// +kubebuilder:validation:Optional
+	ImagePullSecrets *gpuv1.ImagePullSecretsSpec `json:"imagePullSecrets,omitempty"`
+	// RegistryClient defines how the operator queries the registries, e.g. to resolve the image digests or
+	// probe the precompiled driver images
+	// +kubebuilder:validation:Optional
+	RegistryClient *gpuv1.RegistryClientSpec `json:"registryClient,omitempty"`
+	// NVLinkFabric defines the registration of the nodes of multi-node NVLink fabrics
+	NVLinkFabric *gpuv1.NVLinkFabricSpec `json:"nvlinkFabric,omitempty"`
+	// MPSControlDaemon defines the deployment of the MPS control daemon serving the GPUs shared through MPS
+	MPSControlDaemon *gpuv1.MPSControlDaemonSpec `json:"mpsControlDaemon,omitempty"`
+	// ImagePrePull defines the pre-pull of the driver and toolkit images ahead of their upgrade
+	ImagePrePull *gpuv1.ImagePrePullSpec `json:"imagePrePull,omitempty"`
+	// SafeMode defines the pause of the operand rollouts when they correlate with crash looping operand pods
+	SafeMode *gpuv1.SafeModeSpec `json:"safeMode,omitempty"`
+	// Windows defines the deployment of the device plugin and GPU Feature Discovery to the Windows GPU nodes
+	Windows *gpuv1.WindowsSpec `json:"windows,omitempty"`
+	// NodeFeatureDiscovery defines the deployment of Node Feature Discovery by the operator
+	NodeFeatureDiscovery *gpuv1.NodeFeatureDiscoverySpec `json:"nodeFeatureDiscovery,omitempty"`
+	// ExternalGates defines prerequisites managed outside of the operator, e.g. the MOFED driver deployed by
+	// the NVIDIA Network Operator, which must be met before the operands of the gated states are deployed
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	ExternalGates []gpuv1.ExternalGateSpec `json:"externalGates,omitempty"`
+	// TenantNamespaces lists the namespaces of the tenants of a multi-tenant cluster. The device plugin and
+	// DCGM Exporter of the GPU nodes of a tenant are deployed in its namespace, with namespace-scoped RBAC.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	TenantNamespaces []gpuv1.TenantNamespaceSpec `json:"tenantNamespaces,omitempty"`
+	// ConsumerGPUs defines the handling of the GPU nodes with consumer GPUs, e.g. GeForce GPUs, which do not
+	// support the datacenter features some operands rely on
+	// +kubebuilder:validation:Optional
+	ConsumerGPUs *gpuv1.ConsumerGPUsSpec `json:"consumerGPUs,omitempty"`
+	// Proxy defines the proxy and the trusted CA bundle injected into the operands downloading or building
+	// software: the driver, the container toolkit and the vGPU manager. The registry requests of the operator
+	// also go through the proxy and trust the CA bundle.
+	// +kubebuilder:validation:Optional
+	Proxy *gpuv1.ProxySpec `json:"proxy,omitempty"`
+	// ReadinessHysteresis debounces the operand readiness changes reported in the state, the conditions and the
+	// node statuses of the ClusterPolicy
+	// +kubebuilder:validation:Optional
+	ReadinessHysteresis *gpuv1.ReadinessHysteresisSpec `json:"readinessHysteresis,omitempty"`
+	// AutoscalingHints defines the publishing of the GPU resources expected on the nodes of each instance type,
+	// so that node autoscalers can scale GPU node groups from zero
+	// +kubebuilder:validation:Optional
+	AutoscalingHints *gpuv1.AutoscalingHintsSpec `json:"autoscalingHints,omitempty"`
+	// Notifications defines the HTTP endpoints notified of the lifecycle events of the GPU nodes, so that
+	// inventory and incident management systems stay in sync without polling
+	// +kubebuilder:validation:Optional
+	Notifications *gpuv1.NotificationsSpec `json:"notifications,omitempty"`
+	// Teardown defines the staged removal of the operands when the ClusterPolicy is deleted
+	// +kubebuilder:validation:Optional
+	Teardown *gpuv1.TeardownSpec `json:"teardown,omitempty"`
+	// Devel defines the settings of development clusters without GPU hardware
+	// +kubebuilder:validation:Optional
+	Devel *gpuv1.DevelSpec `json:"devel,omitempty"`
+}
+
+// DriverGroupSpec defines the driver, configured by the fields of the v1 driver spec, and the components
+// loading additional kernel modules on top of it
+type DriverGroupSpec struct {
+	gpuv1.DriverSpec `json:",inline"`
+	// GPUDirectStorage defines the spec for GDS components(Experimental)
+	GPUDirectStorage *gpuv1.GPUDirectStorageSpec `json:"gds,omitempty"`
+	// GDRCopy component spec
+	GDRCopy *gpuv1.GDRCopySpec `json:"gdrcopy,omitempty"`
+	// DownloadCache defines the in-cluster caching proxy for driver downloads
+	DownloadCache *gpuv1.DownloadCacheSpec `json:"downloadCache,omitempty"`
+}
+
+// RuntimeGroupSpec defines the configuration of the container runtimes exposing the GPUs to the containers
+type RuntimeGroupSpec struct {
+	// Toolkit component spec
+	Toolkit gpuv1.ToolkitSpec `json:"toolkit"`
+	// CDI configures how the Container Device Interface is used in the cluster
+	CDI gpuv1.CDIConfigSpec `json:"cdi,omitempty"`
+}
+
+// MonitoringGroupSpec defines the components collecting the GPU telemetry and reporting the GPU health and usage
+type MonitoringGroupSpec struct {
+	// DCGM component spec
+	DCGM gpuv1.DCGMSpec `json:"dcgm"`
+	// DCGMExporter spec
+	DCGMExporter gpuv1.DCGMExporterSpec `json:"dcgmExporter"`
+	// NodeStatusExporter spec
+	NodeStatusExporter gpuv1.NodeStatusExporterSpec `json:"nodeStatusExporter"`
+	// HealthCheck defines the GPU health check reacting to critical XID errors
+	HealthCheck *gpuv1.GPUHealthCheckSpec `json:"healthCheck,omitempty"`
+	// FleetReport defines the periodic summary report of the GPU nodes
+	FleetReport *gpuv1.FleetReportSpec `json:"fleetReport,omitempty"`
+	// UsageAccounting defines the periodic export of the GPU usage records for chargeback
+	UsageAccounting *gpuv1.UsageAccountingSpec `json:"usageAccounting,omitempty"`
+}
+
+// SandboxGroupSpec defines the handling of the sandbox workloads (i.e. Virtual Machines), configured by the
+// fields of the v1 sandboxWorkloads spec, and the components deployed for them
+type SandboxGroupSpec struct {
+	gpuv1.SandboxWorkloadsSpec `json:",inline"`
+	// VFIOManager for configuration to deploy VFIO-PCI Manager
+	VFIOManager gpuv1.VFIOManagerSpec `json:"vfioManager,omitempty"`
+	// SandboxDevicePlugin component spec
+	SandboxDevicePlugin gpuv1.SandboxDevicePluginSpec `json:"sandboxDevicePlugin,omitempty"`
+	// KataSandboxDevicePlugin component spec
+	KataSandboxDevicePlugin gpuv1.KataDevicePluginSpec `json:"kataSandboxDevicePlugin,omitempty"`
+	// VGPUManager component spec
+	VGPUManager gpuv1.VGPUManagerSpec `json:"vgpuManager,omitempty"`
+	// VGPUDeviceManager spec
+	VGPUDeviceManager gpuv1.VGPUDeviceManagerSpec `json:"vgpuDeviceManager,omitempty"`
+	// CCManager component spec
+	CCManager gpuv1.CCManagerSpec `json:"ccManager,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:unservedversion
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.state`,priority=0
+// +kubebuilder:printcolumn:name="Age",type=string,JSONPath=`.metadata.creationTimestamp`,priority=0
+
+// ClusterPolicy is the Schema for the clusterpolicies API. The v2 API is served when the conversion webhook
+// of the operator is enabled, the ClusterPolicies are stored as v1.
+type ClusterPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterPolicySpec         `json:"spec,omitempty"`
+	Status gpuv1.ClusterPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterPolicyList contains a list of ClusterPolicy
+type ClusterPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterPolicy `json:"items"`
+}
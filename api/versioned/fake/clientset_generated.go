@@ -24,6 +24,8 @@ import (
 	fakenvidiav1 "github.com/NVIDIA/gpu-operator/api/versioned/typed/nvidia/v1/fake"
 	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/versioned/typed/nvidia/v1alpha1"
 	fakenvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/versioned/typed/nvidia/v1alpha1/fake"
+	nvidiav2 "github.com/NVIDIA/gpu-operator/api/versioned/typed/nvidia/v2"
+	fakenvidiav2 "github.com/NVIDIA/gpu-operator/api/versioned/typed/nvidia/v2/fake"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
@@ -106,3 +108,8 @@ func (c *Clientset) NvidiaV1() nvidiav1.NvidiaV1Interface {
 func (c *Clientset) NvidiaV1alpha1() nvidiav1alpha1.NvidiaV1alpha1Interface {
 	return &fakenvidiav1alpha1.FakeNvidiaV1alpha1{Fake: &c.Fake}
 }
+
+// NvidiaV2 retrieves the NvidiaV2Client
+func (c *Clientset) NvidiaV2() nvidiav2.NvidiaV2Interface {
+	return &fakenvidiav2.FakeNvidiaV2{Fake: &c.Fake}
+}
@@ -21,6 +21,7 @@ package fake
 import (
 	nvidiav1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
 	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	nvidiav2 "github.com/NVIDIA/gpu-operator/api/nvidia/v2"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
@@ -34,6 +35,7 @@ var codecs = serializer.NewCodecFactory(scheme)
 var localSchemeBuilder = runtime.SchemeBuilder{
 	nvidiav1.AddToScheme,
 	nvidiav1alpha1.AddToScheme,
+	nvidiav2.AddToScheme,
 }
 
 // AddToScheme adds all types of this clientset into the given scheme. This allows composition
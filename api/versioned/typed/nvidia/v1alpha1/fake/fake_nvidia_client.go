@@ -28,6 +28,14 @@ type FakeNvidiaV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeNvidiaV1alpha1) DriverUpgrades(namespace string) v1alpha1.DriverUpgradeInterface {
+	return newFakeDriverUpgrades(c, namespace)
+}
+
+func (c *FakeNvidiaV1alpha1) GPUAllocationPolicies() v1alpha1.GPUAllocationPolicyInterface {
+	return newFakeGPUAllocationPolicies(c)
+}
+
 func (c *FakeNvidiaV1alpha1) GPUClusters() v1alpha1.GPUClusterInterface {
 	return newFakeGPUClusters(c)
 }
@@ -18,6 +18,10 @@
 
 package v1alpha1
 
+type DriverUpgradeExpansion interface{}
+
+type GPUAllocationPolicyExpansion interface{}
+
 type GPUClusterExpansion interface{}
 
 type NVIDIADriverExpansion interface{}
@@ -28,6 +28,8 @@ import (
 
 type NvidiaV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	DriverUpgradesGetter
+	GPUAllocationPoliciesGetter
 	GPUClustersGetter
 	NVIDIADriversGetter
 }
@@ -37,6 +39,14 @@ type NvidiaV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *NvidiaV1alpha1Client) DriverUpgrades(namespace string) DriverUpgradeInterface {
+	return newDriverUpgrades(c, namespace)
+}
+
+func (c *NvidiaV1alpha1Client) GPUAllocationPolicies() GPUAllocationPolicyInterface {
+	return newGPUAllocationPolicies(c)
+}
+
 func (c *NvidiaV1alpha1Client) GPUClusters() GPUClusterInterface {
 	return newGPUClusters(c)
 }
@@ -0,0 +1,331 @@
+/*
+Copyright (c), NVIDIA CORPORATION.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+const (
+	gpuResourceName       = corev1.ResourceName("nvidia.com/gpu")
+	migResourcePrefix     = "nvidia.com/mig-"
+	migStrategyLabelKey   = "nvidia.com/mig.strategy"
+	gpuProductLabelKey    = "nvidia.com/gpu.product"
+	dcgmExporterService   = "nvidia-dcgm-exporter"
+	dcgmExporterPort      = 9400
+	dcgmMetricPrefix      = "DCGM_FI_DEV_"
+	defaultPollInterval   = 5 * time.Second
+	workloadContainerName = "cuda-workload"
+)
+
+// defaultClusterPolicy is the ClusterPolicy installed when no ClusterPolicy exists and no manifest is set
+//
+//go:embed clusterpolicy.yaml
+var defaultClusterPolicy []byte
+
+// metricsFetcher returns the metrics served by the port of a service
+type metricsFetcher func(ctx context.Context, namespace, service string, port int) ([]byte, error)
+
+// serviceProxyFetcher returns a metricsFetcher scraping the services through the service proxy of the API server,
+// so that the suite can run outside of the cluster
+func serviceProxyFetcher(clientset kubernetes.Interface) metricsFetcher {
+	return func(ctx context.Context, namespace, service string, port int) ([]byte, error) {
+		return clientset.CoreV1().Services(namespace).ProxyGet("http", service, strconv.Itoa(port), "/metrics", nil).DoRaw(ctx)
+	}
+}
+
+// conformance runs the conformance checks against a cluster
+type conformance struct {
+	client       ctrlclient.Client
+	fetchMetrics metricsFetcher
+	cfg          *config
+	pollInterval time.Duration
+
+	// clusterPolicy is the ClusterPolicy under test, installed by the suite if createdClusterPolicy is set
+	clusterPolicy        *gpuv1.ClusterPolicy
+	createdClusterPolicy bool
+	createdNamespace     bool
+}
+
+func newConformance(client ctrlclient.Client, fetchMetrics metricsFetcher, cfg *config) *conformance {
+	return &conformance{
+		client:       client,
+		fetchMetrics: fetchMetrics,
+		cfg:          cfg,
+		pollInterval: defaultPollInterval,
+	}
+}
+
+// checks returns the checks of the suite, in order
+func (c *conformance) checks() []check {
+	return []check{
+		{name: "install ClusterPolicy", required: true, run: c.installClusterPolicy},
+		{name: "ClusterPolicy is ready", required: true, run: c.waitForClusterPolicyReady},
+		{name: "create workload namespace", required: true, run: c.createNamespace},
+		{name: "schedule a GPU pod", run: c.checkGPUPod},
+		{name: "schedule a pod on a MIG slice", run: c.checkMIGPod},
+		{name: "scrape GPU metrics", run: c.checkMetrics},
+	}
+}
+
+// teardownChecks returns the checks deleting the resources created by the suite
+func (c *conformance) teardownChecks() []check {
+	return []check{
+		{name: "teardown", run: c.teardown},
+	}
+}
+
+// installClusterPolicy installs the test ClusterPolicy, or uses the ClusterPolicy of the cluster if one exists,
+// in which case it is not deleted by the teardown
+func (c *conformance) installClusterPolicy(ctx context.Context) error {
+	list := &gpuv1.ClusterPolicyList{}
+	if err := c.client.List(ctx, list); err != nil {
+		return fmt.Errorf("failed to list ClusterPolicies: %w", err)
+	}
+	if len(list.Items) > 0 {
+		c.clusterPolicy = &list.Items[0]
+		logger.Infof("Using the existing ClusterPolicy %s", c.clusterPolicy.Name)
+		return nil
+	}
+
+	data := defaultClusterPolicy
+	if c.cfg.clusterPolicyFile != "" {
+		var err error
+		if data, err = os.ReadFile(c.cfg.clusterPolicyFile); err != nil {
+			return fmt.Errorf("failed to read the ClusterPolicy manifest: %w", err)
+		}
+	}
+	cp := &gpuv1.ClusterPolicy{}
+	if err := yaml.Unmarshal(data, cp); err != nil {
+		return fmt.Errorf("failed to decode the ClusterPolicy manifest: %w", err)
+	}
+	if err := c.client.Create(ctx, cp); err != nil {
+		return fmt.Errorf("failed to create the ClusterPolicy %s: %w", cp.Name, err)
+	}
+	logger.Infof("Created the ClusterPolicy %s", cp.Name)
+	c.clusterPolicy = cp
+	c.createdClusterPolicy = true
+	return nil
+}
+
+// waitForClusterPolicyReady waits for the operands of the ClusterPolicy to be ready
+func (c *conformance) waitForClusterPolicyReady(ctx context.Context) error {
+	cp := &gpuv1.ClusterPolicy{}
+	err := wait.PollUntilContextTimeout(ctx, c.pollInterval, c.cfg.timeout, true, func(ctx context.Context) (bool, error) {
+		if err := c.client.Get(ctx, ctrlclient.ObjectKeyFromObject(c.clusterPolicy), cp); err != nil {
+			return false, err
+		}
+		logger.Debugf("ClusterPolicy %s state: %s", cp.Name, cp.Status.State)
+		return cp.Status.State == gpuv1.Ready, nil
+	})
+	if err != nil {
+		return fmt.Errorf("ClusterPolicy %s not ready, state %q: %w", c.clusterPolicy.Name, cp.Status.State, err)
+	}
+	c.clusterPolicy = cp
+	return nil
+}
+
+// createNamespace creates the namespace of the workloads of the checks, unless it exists
+func (c *conformance) createNamespace(ctx context.Context) error {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: c.cfg.namespace}}
+	if err := c.client.Create(ctx, ns); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to create the namespace %s: %w", c.cfg.namespace, err)
+	}
+	c.createdNamespace = true
+	return nil
+}
+
+// checkGPUPod checks that a pod requesting a GPU is scheduled and runs the CUDA workload successfully
+func (c *conformance) checkGPUPod(ctx context.Context) error {
+	return c.runWorkload(ctx, "gpu", gpuResourceName, "")
+}
+
+// checkMIGPod checks that a pod requesting a MIG slice is scheduled and runs the CUDA workload successfully. The
+// check is skipped when no node advertises MIG slices.
+func (c *conformance) checkMIGPod(ctx context.Context) error {
+	nodes := &corev1.NodeList{}
+	if err := c.client.List(ctx, nodes); err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+	nodeName, resourceName, ok := findMIGResource(nodes.Items)
+	if !ok {
+		return skip("no node advertises MIG slices")
+	}
+	logger.Infof("Requesting %s on node %s", resourceName, nodeName)
+	return c.runWorkload(ctx, "mig", resourceName, nodeName)
+}
+
+// findMIGResource returns a node advertising MIG slices and the resource of the slices: the nvidia.com/mig-<profile>
+// resources with the mixed MIG strategy, or the nvidia.com/gpu resource of the nodes of MIG GPUs with the single
+// strategy. The nodes are considered in name order.
+func findMIGResource(nodes []corev1.Node) (string, corev1.ResourceName, bool) {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	for _, node := range nodes {
+		var migResources []string
+		for name, quantity := range node.Status.Allocatable {
+			if strings.HasPrefix(string(name), migResourcePrefix) && !quantity.IsZero() {
+				migResources = append(migResources, string(name))
+			}
+		}
+		if len(migResources) > 0 {
+			sort.Strings(migResources)
+			return node.Name, corev1.ResourceName(migResources[0]), true
+		}
+
+		gpus := node.Status.Allocatable[gpuResourceName]
+		if node.Labels[migStrategyLabelKey] == "single" && strings.Contains(node.Labels[gpuProductLabelKey], "-MIG-") && !gpus.IsZero() {
+			return node.Name, gpuResourceName, true
+		}
+	}
+	return "", "", false
+}
+
+// runWorkload runs the CUDA workload in a pod requesting one device of the resource, scheduled on the node if set,
+// and waits for its completion
+func (c *conformance) runWorkload(ctx context.Context, name string, resourceName corev1.ResourceName, nodeName string) error {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "conformance-" + name + "-",
+			Namespace:    c.cfg.namespace,
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Tolerations: []corev1.Toleration{
+				{Key: string(gpuResourceName), Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+			},
+			Containers: []corev1.Container{
+				{
+					Name:  workloadContainerName,
+					Image: c.cfg.workloadImage,
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{resourceName: resource.MustParse("1")},
+					},
+				},
+			},
+		},
+	}
+	if nodeName != "" {
+		pod.Spec.Affinity = &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchFields: []corev1.NodeSelectorRequirement{
+						{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{nodeName}},
+					},
+				}},
+			},
+		}}
+	}
+	if err := c.client.Create(ctx, pod); err != nil {
+		return fmt.Errorf("failed to create the workload pod: %w", err)
+	}
+	logger.Infof("Created the workload pod %s/%s", pod.Namespace, pod.Name)
+	return c.waitForPodCompletion(ctx, pod)
+}
+
+// waitForPodCompletion waits for the pod to succeed, an error being returned if it fails
+func (c *conformance) waitForPodCompletion(ctx context.Context, pod *corev1.Pod) error {
+	err := wait.PollUntilContextTimeout(ctx, c.pollInterval, c.cfg.timeout, true, func(ctx context.Context) (bool, error) {
+		if err := c.client.Get(ctx, ctrlclient.ObjectKeyFromObject(pod), pod); err != nil {
+			return false, err
+		}
+		switch pod.Status.Phase {
+		case corev1.PodSucceeded:
+			return true, nil
+		case corev1.PodFailed:
+			return false, fmt.Errorf("pod %s/%s failed: %s", pod.Namespace, pod.Name, podFailureReason(pod))
+		}
+		return false, nil
+	})
+	if err != nil && pod.Status.Phase != corev1.PodFailed {
+		return fmt.Errorf("pod %s/%s did not complete, phase %q: %w", pod.Namespace, pod.Name, pod.Status.Phase, err)
+	}
+	return err
+}
+
+// podFailureReason returns the reason of the failure of the workload container of a failed pod
+func podFailureReason(pod *corev1.Pod) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if terminated := status.State.Terminated; terminated != nil {
+			return fmt.Sprintf("container %s exited with code %d: %s %s", status.Name, terminated.ExitCode, terminated.Reason, terminated.Message)
+		}
+	}
+	return pod.Status.Reason + " " + pod.Status.Message
+}
+
+// checkMetrics checks that dcgm-exporter serves GPU metrics. The check is skipped when dcgm-exporter is disabled.
+func (c *conformance) checkMetrics(ctx context.Context) error {
+	if !c.clusterPolicy.Spec.DCGMExporter.IsEnabled() {
+		return skip("dcgm-exporter is disabled in the ClusterPolicy %s", c.clusterPolicy.Name)
+	}
+	namespace := c.clusterPolicy.Status.Namespace
+	var metrics []byte
+	err := wait.PollUntilContextTimeout(ctx, c.pollInterval, c.cfg.timeout, true, func(ctx context.Context) (bool, error) {
+		var err error
+		metrics, err = c.fetchMetrics(ctx, namespace, dcgmExporterService, dcgmExporterPort)
+		if err != nil {
+			logger.Debugf("Failed to scrape %s/%s: %v", namespace, dcgmExporterService, err)
+			return false, nil
+		}
+		return strings.Contains(string(metrics), dcgmMetricPrefix), nil
+	})
+	if err != nil {
+		return fmt.Errorf("no %s* metric served by %s/%s: %w", dcgmMetricPrefix, namespace, dcgmExporterService, err)
+	}
+	return nil
+}
+
+// teardown deletes the namespace and the ClusterPolicy created by the suite, unless the teardown is skipped
+func (c *conformance) teardown(ctx context.Context) error {
+	if c.cfg.skipTeardown {
+		return skip("teardown disabled")
+	}
+	if c.createdNamespace {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: c.cfg.namespace}}
+		if err := c.client.Delete(ctx, ns); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete the namespace %s: %w", c.cfg.namespace, err)
+		}
+		logger.Infof("Deleted the namespace %s", c.cfg.namespace)
+	}
+	if c.createdClusterPolicy {
+		if err := c.client.Delete(ctx, c.clusterPolicy); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete the ClusterPolicy %s: %w", c.clusterPolicy.Name, err)
+		}
+		logger.Infof("Deleted the ClusterPolicy %s", c.clusterPolicy.Name)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+/*
+Copyright (c), NVIDIA CORPORATION.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/testutil"
+)
+
+func newTestConformance(fetchMetrics metricsFetcher, objs ...ctrlclient.Object) *conformance {
+	cfg := &config{namespace: defaultNamespace, workloadImage: defaultWorkloadImage, timeout: 50 * time.Millisecond}
+	c := newConformance(testutil.NewFakeClient(objs...), fetchMetrics, cfg)
+	c.pollInterval = 10 * time.Millisecond
+	return c
+}
+
+func TestInstallClusterPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	c := newTestConformance(nil)
+	require.NoError(t, c.installClusterPolicy(ctx))
+	require.True(t, c.createdClusterPolicy)
+	require.Equal(t, "gpu-operator-conformance", c.clusterPolicy.Name)
+	require.True(t, c.clusterPolicy.Spec.DCGMExporter.IsEnabled())
+	require.NoError(t, c.client.Get(ctx, ctrlclient.ObjectKey{Name: c.clusterPolicy.Name}, &gpuv1.ClusterPolicy{}))
+
+	require.NoError(t, c.teardown(ctx))
+	err := c.client.Get(ctx, ctrlclient.ObjectKey{Name: c.clusterPolicy.Name}, &gpuv1.ClusterPolicy{})
+	require.Error(t, err)
+
+	// an existing ClusterPolicy is used and kept
+	existing := testutil.NewClusterPolicy()
+	c = newTestConformance(nil, existing)
+	require.NoError(t, c.installClusterPolicy(ctx))
+	require.False(t, c.createdClusterPolicy)
+	require.Equal(t, existing.Name, c.clusterPolicy.Name)
+	require.NoError(t, c.teardown(ctx))
+	require.NoError(t, c.client.Get(ctx, ctrlclient.ObjectKey{Name: existing.Name}, &gpuv1.ClusterPolicy{}))
+}
+
+func TestWaitForClusterPolicyReady(t *testing.T) {
+	ctx := context.Background()
+	cp := testutil.NewClusterPolicy()
+	c := newTestConformance(nil, cp)
+	c.clusterPolicy = cp
+	require.ErrorContains(t, c.waitForClusterPolicyReady(ctx), "not ready")
+
+	cp.Status.State = gpuv1.Ready
+	cp.Status.Namespace = "gpu-operator"
+	require.NoError(t, c.client.Status().Update(ctx, cp))
+	require.NoError(t, c.waitForClusterPolicyReady(ctx))
+	require.Equal(t, "gpu-operator", c.clusterPolicy.Status.Namespace)
+}
+
+func TestFindMIGResource(t *testing.T) {
+	withAllocatable := func(resources corev1.ResourceList) testutil.NodeOption {
+		return func(node *corev1.Node) {
+			node.Status.Allocatable = resources
+		}
+	}
+
+	_, _, ok := findMIGResource([]corev1.Node{*testutil.NewGPUNode("gpu-node", testutil.WithGPUs("NVIDIA-A100-SXM4-40GB", 8))})
+	require.False(t, ok)
+
+	nodes := []corev1.Node{
+		*testutil.NewGPUNode("node-b", withAllocatable(corev1.ResourceList{
+			"nvidia.com/mig-3g.20gb": resource.MustParse("2"),
+			"nvidia.com/mig-1g.5gb":  resource.MustParse("7"),
+		})),
+		*testutil.NewGPUNode("node-a", withAllocatable(corev1.ResourceList{"nvidia.com/mig-1g.5gb": resource.MustParse("0")})),
+	}
+	name, resourceName, ok := findMIGResource(nodes)
+	require.True(t, ok)
+	require.Equal(t, "node-b", name)
+	require.Equal(t, corev1.ResourceName("nvidia.com/mig-1g.5gb"), resourceName)
+
+	single := testutil.NewGPUNode("node-c",
+		testutil.WithGPUs("NVIDIA-A100-SXM4-40GB-MIG-1g.5gb", 7),
+		testutil.WithLabels(map[string]string{migStrategyLabelKey: "single"}))
+	name, resourceName, ok = findMIGResource([]corev1.Node{*single})
+	require.True(t, ok)
+	require.Equal(t, "node-c", name)
+	require.Equal(t, gpuResourceName, resourceName)
+}
+
+func TestCheckMetrics(t *testing.T) {
+	ctx := context.Background()
+	cp := testutil.NewClusterPolicy()
+	cp.Status.Namespace = "gpu-operator"
+
+	var scraped string
+	c := newTestConformance(func(_ context.Context, namespace, service string, port int) ([]byte, error) {
+		scraped = namespace + "/" + service
+		return []byte("DCGM_FI_DEV_GPU_UTIL{gpu=\"0\"} 0\n"), nil
+	})
+	c.clusterPolicy = cp
+	require.NoError(t, c.checkMetrics(ctx))
+	require.Equal(t, "gpu-operator/nvidia-dcgm-exporter", scraped)
+
+	c.fetchMetrics = func(context.Context, string, string, int) ([]byte, error) {
+		return nil, errors.New("service unavailable")
+	}
+	require.ErrorContains(t, c.checkMetrics(ctx), "no DCGM_FI_DEV_* metric")
+
+	cp.Spec.DCGMExporter.Enabled = ptr.To(false)
+	var skipErr *skipError
+	require.ErrorAs(t, c.checkMetrics(ctx), &skipErr)
+}
+
+func TestWaitForPodCompletion(t *testing.T) {
+	ctx := context.Background()
+	c := newTestConformance(nil)
+	require.NoError(t, c.createNamespace(ctx))
+	require.True(t, c.createdNamespace)
+
+	pod := &corev1.Pod{}
+	pod.Name, pod.Namespace = "workload", defaultNamespace
+	pod.Status.Phase = corev1.PodFailed
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:  workloadContainerName,
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"}},
+	}}
+	require.NoError(t, c.client.Create(ctx, pod))
+	require.ErrorContains(t, c.waitForPodCompletion(ctx, pod), "exited with code 1")
+
+	pod.Status.Phase = corev1.PodSucceeded
+	require.NoError(t, c.client.Status().Update(ctx, pod))
+	require.NoError(t, c.waitForPodCompletion(ctx, pod))
+}
@@ -0,0 +1,187 @@
+/*
+Copyright (c), NVIDIA CORPORATION.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// The conformance command certifies the integration of the GPU Operator in a cluster: it installs a test
+// ClusterPolicy, waits for the operands to be ready, runs functional checks of the GPUs and tears down the
+// resources it created, reporting the results in a JUnit report.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v3"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlconfig "sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/info"
+)
+
+const (
+	defaultNamespace     = "gpu-operator-conformance"
+	defaultWorkloadImage = "nvcr.io/nvidia/k8s/cuda-sample:vectoradd-cuda12.5.0-ubuntu22.04"
+	defaultJUnitReport   = "conformance-junit.xml"
+	defaultTimeout       = 15 * time.Minute
+)
+
+var logger = log.New()
+
+// config holds the options of the conformance suite
+type config struct {
+	debug             bool
+	kubeconfig        string
+	clusterPolicyFile string
+	namespace         string
+	workloadImage     string
+	junitReport       string
+	timeout           time.Duration
+	skipTeardown      bool
+}
+
+func main() {
+	cfg := config{}
+
+	c := cli.Command{}
+	c.Name = "conformance"
+	c.Usage = "Certify the GPU Operator integration of a cluster by running functional checks of the GPUs"
+	c.Version = info.GetVersionString()
+	c.Flags = []cli.Flag{
+		&cli.BoolFlag{
+			Name:        "debug",
+			Aliases:     []string{"d"},
+			Usage:       "Enable debug-level logging",
+			Destination: &cfg.debug,
+			Sources:     cli.EnvVars("DEBUG"),
+		},
+		&cli.StringFlag{
+			Name:        "kubeconfig",
+			Usage:       "Path of the kubeconfig of the cluster, the KUBECONFIG environment variable, the in-cluster configuration or ~/.kube/config being used when unset",
+			Destination: &cfg.kubeconfig,
+		},
+		&cli.StringFlag{
+			Name:        "clusterpolicy-file",
+			Usage:       "Path of the ClusterPolicy manifest installed when no ClusterPolicy exists, a minimal ClusterPolicy relying on the default images of the operator is installed when unset",
+			Destination: &cfg.clusterPolicyFile,
+			Sources:     cli.EnvVars("CLUSTERPOLICY_FILE"),
+		},
+		&cli.StringFlag{
+			Name:        "namespace",
+			Value:       defaultNamespace,
+			Usage:       "Namespace of the workloads of the checks, created and deleted by the suite",
+			Destination: &cfg.namespace,
+			Sources:     cli.EnvVars("NAMESPACE"),
+		},
+		&cli.StringFlag{
+			Name:        "workload-image",
+			Value:       defaultWorkloadImage,
+			Usage:       "Image of the CUDA workload run on the GPUs, which must exit successfully",
+			Destination: &cfg.workloadImage,
+			Sources:     cli.EnvVars("WORKLOAD_IMAGE"),
+		},
+		&cli.StringFlag{
+			Name:        "junit-report",
+			Value:       defaultJUnitReport,
+			Usage:       "Path of the JUnit report of the checks",
+			Destination: &cfg.junitReport,
+			Sources:     cli.EnvVars("JUNIT_REPORT"),
+		},
+		&cli.DurationFlag{
+			Name:        "timeout",
+			Value:       defaultTimeout,
+			Usage:       "Timeout of each check, e.g. of the readiness of the ClusterPolicy",
+			Destination: &cfg.timeout,
+			Sources:     cli.EnvVars("TIMEOUT"),
+		},
+		&cli.BoolFlag{
+			Name:        "skip-teardown",
+			Usage:       "Keep the ClusterPolicy and the namespace created by the suite, e.g. to troubleshoot failed checks",
+			Destination: &cfg.skipTeardown,
+			Sources:     cli.EnvVars("SKIP_TEARDOWN"),
+		},
+	}
+	c.Before = func(ctx context.Context, cli *cli.Command) (context.Context, error) {
+		logLevel := log.InfoLevel
+		if cfg.debug {
+			logLevel = log.DebugLevel
+		}
+		logger.SetLevel(logLevel)
+		return ctx, nil
+	}
+	c.Action = func(ctx context.Context, _ *cli.Command) error {
+		return run(ctx, &cfg)
+	}
+
+	err := c.Run(context.Background(), os.Args)
+	if err != nil {
+		log.Errorf("%v", err)
+		log.Exit(1)
+	}
+}
+
+// run runs the conformance suite and writes its JUnit report. An error is returned if a check failed.
+func run(ctx context.Context, cfg *config) error {
+	restConfig, err := getRESTConfig(cfg.kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return fmt.Errorf("failed to add Kubernetes types to scheme: %w", err)
+	}
+	if err := gpuv1.AddToScheme(scheme); err != nil {
+		return fmt.Errorf("failed to add ClusterPolicy types to scheme: %w", err)
+	}
+	k8sClient, err := ctrlclient.New(restConfig, ctrlclient.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create clientset: %w", err)
+	}
+
+	suite := newConformance(k8sClient, serviceProxyFetcher(clientset), cfg)
+	start := time.Now()
+	results := runChecks(ctx, suite.checks(), suite.teardownChecks())
+
+	if err := writeJUnitReport(cfg.junitReport, newJUnitReport(start, results)); err != nil {
+		return err
+	}
+	logger.Infof("JUnit report written to %s", cfg.junitReport)
+
+	if failed := countFailures(results); failed > 0 {
+		return fmt.Errorf("%d of %d conformance checks failed", failed, len(results))
+	}
+	return nil
+}
+
+// getRESTConfig returns the configuration of the cluster of the kubeconfig, or the default configuration of
+// controller-runtime when no kubeconfig is set
+func getRESTConfig(kubeconfig string) (*rest.Config, error) {
+	if kubeconfig == "" {
+		return ctrlconfig.GetConfig()
+	}
+	return clientcmd.BuildConfigFromFlags("", kubeconfig)
+}
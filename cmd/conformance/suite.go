@@ -0,0 +1,163 @@
+/*
+Copyright (c), NVIDIA CORPORATION.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+)
+
+// suiteName is the name of the test suite of the JUnit report
+const suiteName = "gpu-operator-conformance"
+
+// skipError is returned by the checks which do not apply to the cluster, e.g. the MIG checks without MIG GPUs
+type skipError struct {
+	reason string
+}
+
+func (e *skipError) Error() string {
+	return e.reason
+}
+
+// skip returns a skipError with the formatted reason
+func skip(format string, args ...any) error {
+	return &skipError{reason: fmt.Sprintf(format, args...)}
+}
+
+// check is a conformance check. The checks following a failed required check are skipped, e.g. the functional
+// checks when the ClusterPolicy is not ready.
+type check struct {
+	name     string
+	required bool
+	run      func(ctx context.Context) error
+}
+
+// result is the result of a check
+type result struct {
+	name     string
+	duration time.Duration
+	err      error
+	skipped  bool
+}
+
+// runChecks runs the checks in order, then the teardown checks, which are run even if a required check failed
+func runChecks(ctx context.Context, checks []check, teardownChecks []check) []result {
+	results := make([]result, 0, len(checks)+len(teardownChecks))
+	var blocker string
+	for _, c := range checks {
+		if blocker != "" {
+			logger.Infof("SKIPPED %s: required check %q failed", c.name, blocker)
+			results = append(results, result{name: c.name, skipped: true, err: skip("required check %q failed", blocker)})
+			continue
+		}
+		r := runCheck(ctx, c)
+		if r.err != nil && !r.skipped && c.required {
+			blocker = c.name
+		}
+		results = append(results, r)
+	}
+	for _, c := range teardownChecks {
+		results = append(results, runCheck(ctx, c))
+	}
+	return results
+}
+
+func runCheck(ctx context.Context, c check) result {
+	logger.Infof("RUNNING %s", c.name)
+	start := time.Now()
+	err := c.run(ctx)
+	r := result{name: c.name, duration: time.Since(start), err: err}
+
+	var skipErr *skipError
+	switch {
+	case errors.As(err, &skipErr):
+		r.skipped = true
+		logger.Infof("SKIPPED %s: %v", c.name, err)
+	case err != nil:
+		logger.Errorf("FAILED %s: %v", c.name, err)
+	default:
+		logger.Infof("PASSED %s (%s)", c.name, r.duration.Round(time.Second))
+	}
+	return r
+}
+
+// countFailures returns the number of failed checks
+func countFailures(results []result) int {
+	failed := 0
+	for _, r := range results {
+		if r.err != nil && !r.skipped {
+			failed++
+		}
+	}
+	return failed
+}
+
+// newJUnitReport returns the JUnit report of the results of the checks of a suite started at the given time
+func newJUnitReport(start time.Time, results []result) reporters.JUnitTestSuites {
+	suite := reporters.JUnitTestSuite{
+		Name:      suiteName,
+		Package:   suiteName,
+		Tests:     len(results),
+		Timestamp: start.Format("2006-01-02T15:04:05"),
+	}
+	for _, r := range results {
+		testCase := reporters.JUnitTestCase{
+			Name:      r.name,
+			Classname: suiteName,
+			Status:    "passed",
+			Time:      r.duration.Seconds(),
+		}
+		switch {
+		case r.skipped:
+			testCase.Status = "skipped"
+			testCase.Skipped = &reporters.JUnitSkipped{Message: "skipped - " + r.err.Error()}
+			suite.Skipped++
+		case r.err != nil:
+			testCase.Status = "failed"
+			testCase.Failure = &reporters.JUnitFailure{Message: r.err.Error(), Type: "failed"}
+			suite.Failures++
+		}
+		suite.Time += testCase.Time
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+	return reporters.JUnitTestSuites{
+		Tests:      suite.Tests,
+		Disabled:   suite.Skipped,
+		Failures:   suite.Failures,
+		Time:       suite.Time,
+		TestSuites: []reporters.JUnitTestSuite{suite},
+	}
+}
+
+// writeJUnitReport writes the JUnit report to the file at path
+func writeJUnitReport(path string, report reporters.JUnitTestSuites) error {
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode the JUnit report: %w", err)
+	}
+	data = append([]byte(xml.Header), data...)
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write the JUnit report %s: %w", path, err)
+	}
+	return nil
+}
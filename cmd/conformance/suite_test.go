@@ -0,0 +1,82 @@
+/*
+Copyright (c), NVIDIA CORPORATION.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunChecks(t *testing.T) {
+	var ran []string
+	newCheck := func(name string, required bool, err error) check {
+		return check{name: name, required: required, run: func(context.Context) error {
+			ran = append(ran, name)
+			return err
+		}}
+	}
+	checks := []check{
+		newCheck("skipped", true, skip("not applicable")),
+		newCheck("setup", true, nil),
+		newCheck("optional", false, errors.New("optional failed")),
+		newCheck("ready", true, errors.New("not ready")),
+		newCheck("functional", false, nil),
+	}
+	results := runChecks(context.Background(), checks, []check{newCheck("teardown", false, nil)})
+
+	// the checks following a failed required check are skipped, the teardown checks always run
+	require.Equal(t, []string{"skipped", "setup", "optional", "ready", "teardown"}, ran)
+	require.Len(t, results, 6)
+	require.True(t, results[0].skipped)
+	require.True(t, results[4].skipped)
+	require.ErrorContains(t, results[4].err, `required check "ready" failed`)
+	require.Equal(t, 2, countFailures(results))
+}
+
+func TestWriteJUnitReport(t *testing.T) {
+	results := []result{
+		{name: "passed", duration: time.Second},
+		{name: "failed", duration: 2 * time.Second, err: errors.New("boom")},
+		{name: "skipped", skipped: true, err: skip("no MIG")},
+	}
+	path := filepath.Join(t.TempDir(), "junit.xml")
+	require.NoError(t, writeJUnitReport(path, newJUnitReport(time.Now(), results)))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	report := reporters.JUnitTestSuites{}
+	require.NoError(t, xml.Unmarshal(data, &report))
+	require.Equal(t, 3, report.Tests)
+	require.Equal(t, 1, report.Failures)
+	require.Len(t, report.TestSuites, 1)
+
+	suite := report.TestSuites[0]
+	require.Equal(t, suiteName, suite.Name)
+	require.Equal(t, 1, suite.Skipped)
+	require.InDelta(t, 3.0, suite.Time, 0.001)
+	require.Equal(t, "passed", suite.TestCases[0].Status)
+	require.Equal(t, "boom", suite.TestCases[1].Failure.Message)
+	require.Equal(t, "skipped - no MIG", suite.TestCases[2].Skipped.Message)
+}
@@ -20,12 +20,15 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
+	uberzap "go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -37,23 +40,42 @@ import (
 	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	clusterpolicyv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
 	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	clusterpolicyv2 "github.com/NVIDIA/gpu-operator/api/nvidia/v2"
 	"github.com/NVIDIA/gpu-operator/controllers"
 	"github.com/NVIDIA/gpu-operator/controllers/clusterinfo"
+	"github.com/NVIDIA/gpu-operator/internal/apistats"
+	"github.com/NVIDIA/gpu-operator/internal/assets"
+	"github.com/NVIDIA/gpu-operator/internal/audit"
+	"github.com/NVIDIA/gpu-operator/internal/clusterpolicy"
 	"github.com/NVIDIA/gpu-operator/internal/consts"
+	"github.com/NVIDIA/gpu-operator/internal/drain"
+	"github.com/NVIDIA/gpu-operator/internal/featuregate"
+	"github.com/NVIDIA/gpu-operator/internal/hostedcluster"
 	"github.com/NVIDIA/gpu-operator/internal/info"
+	"github.com/NVIDIA/gpu-operator/internal/introspection"
+	"github.com/NVIDIA/gpu-operator/internal/nodepolicy"
+	"github.com/NVIDIA/gpu-operator/internal/notification"
+	"github.com/NVIDIA/gpu-operator/internal/operatorconfig"
+	"github.com/NVIDIA/gpu-operator/internal/partitioning"
 	"github.com/NVIDIA/gpu-operator/internal/predicates"
+	"github.com/NVIDIA/gpu-operator/internal/tracing"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -65,6 +87,7 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(clusterpolicyv1.AddToScheme(scheme))
+	utilruntime.Must(clusterpolicyv2.AddToScheme(scheme))
 	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 	utilruntime.Must(nvidiav1alpha1.AddToScheme(scheme))
 	utilruntime.Must(promv1.AddToScheme(scheme))
@@ -79,6 +102,22 @@ func main() {
 	var leaderElectionNamespace string
 	var probeAddr string
 	var renewDeadline time.Duration
+	var reconcileOptions controllers.ReconcileOptions
+	var stateTimeouts string
+	var auditRingBufferSize int
+	var enableClusterPolicyWebhook bool
+	var conversionWebhookService string
+	var assetsSource string
+	var assetsPullSecret string
+	var assetsVerificationKey string
+	var assetsDir string
+	var introspectionAddr string
+	var introspectionTokenFile string
+	var operatorConfigMapName string
+	var hostedClusterKubeconfigSecret string
+	var hostedClusterKubeconfigKey string
+	var hostedClusterNamespace string
+	var nodeMutationPolicy string
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -93,6 +132,70 @@ func main() {
 			"Only enabled when the --leader-elect flag is set. "+
 			"If undefined, the renew deadline defaults to the controller-runtime manager's default RenewDeadline. "+
 			"By setting this option, the LeaseDuration is also set as RenewDealine + 5s.")
+	flag.DurationVar(&reconcileOptions.RequeueInterval, "requeue-interval", controllers.DefaultRequeueInterval,
+		"Set the delay (e.g. \"30s\") before the ClusterPolicy is reconciled again while operands are not ready. "+
+			"Large clusters can increase it to reduce the reconciliation pressure on the API server.")
+	flag.DurationVar(&reconcileOptions.MaxErrorBackoff, "max-error-backoff", controllers.DefaultMaxErrorBackoff,
+		"Set the maximum delay (e.g. \"1m\") of the exponential backoff applied when a ClusterPolicy reconciliation fails.")
+	flag.StringVar(&stateTimeouts, "state-timeouts", "",
+		"Set a comma separated list of <state>=<duration> pairs (e.g. \"state-driver=10m,state-container-toolkit=2m\") "+
+			"bounding the duration of the reconciliation of each state. States without a timeout are not bounded.")
+	flag.IntVar(&auditRingBufferSize, "audit-ring-buffer-size", 0,
+		"Set the number of the latest object mutations performed by the operator kept in the "+audit.ConfigMapName+" ConfigMap "+
+			"of the operator namespace. The mutations are only logged when zero.")
+	flag.BoolVar(&enableClusterPolicyWebhook, "enable-clusterpolicy-webhook", false,
+		"Enable the admission webhook rejecting the ClusterPolicies whose nodeSelector overlaps with another ClusterPolicy. "+
+			"The serving certificate is read from the certificate directory of the webhook server.")
+	flag.StringVar(&conversionWebhookService, "clusterpolicy-conversion-webhook-service", "",
+		"Set the name of the Service of the operator namespace serving the webhooks to serve the v2 ClusterPolicy API. "+
+			"The ClusterPolicy CRD is configured to convert the ClusterPolicies through the conversion webhook of the operator, "+
+			"trusted with the ca.crt file of the certificate directory of the webhook server. "+
+			"Only honored when the --enable-clusterpolicy-webhook flag is set.")
+	flag.StringVar(&assetsSource, "assets-source", "",
+		"Set the source of the operand asset manifests overriding and extending the ones baked into the operator image, "+
+			"either \"oci://<artifact>\" or \"configmap://<name>\" for a ConfigMap of the operator namespace. "+
+			"The assets are loaded when the operator starts.")
+	flag.StringVar(&assetsPullSecret, "assets-pull-secret", "",
+		"Set the name of the image pull secret of the operator namespace used to pull the OCI artifact of --assets-source.")
+	flag.StringVar(&assetsVerificationKey, "assets-verification-key", "",
+		"Set the path of the PEM encoded ECDSA or Ed25519 public key verifying the signature of the assets of --assets-source. "+
+			"Required when --assets-source is set.")
+	flag.StringVar(&assetsDir, "assets-dir", "/var/lib/gpu-operator/assets",
+		"Set the writable directory the assets of --assets-source are merged with the baked-in assets into.")
+	flag.StringVar(&introspectionAddr, "introspection-bind-address", "",
+		"The address the endpoint serving the effective configuration of the operands per node group and the "+
+			"previews of MIG or vGPU partitionings per GPU model binds to "+
+			"(e.g. \"127.0.0.1:8082\"). The endpoint is disabled when empty, and only binds to a loopback address "+
+			"unless --introspection-token-file is set.")
+	flag.StringVar(&introspectionTokenFile, "introspection-token-file", "",
+		"Set the path of the file holding the bearer token required by the introspection endpoint.")
+	flag.StringVar(&operatorConfigMapName, "operator-config-map", operatorconfig.DefaultConfigMapName,
+		"Set the name of the ConfigMap of the operator namespace changing the log level, the requeue interval, "+
+			"the state timeouts and the feature gates at runtime. The settings absent from the ConfigMap keep "+
+			"the value of their flag. The ConfigMap is not watched when empty.")
+	flag.StringVar(&hostedClusterKubeconfigSecret, "hosted-cluster-kubeconfig-secret", "",
+		"Set the name of the Secret of the operator namespace holding the kubeconfig of the hosted cluster the GPU nodes "+
+			"belong to, when the operator runs in the management cluster of a hosted control plane (e.g. HyperShift or Kamaji). "+
+			"The nodes, the operands and the custom resources are then read from and written to the hosted cluster, while "+
+			"the leader election lease and the Secret stay in the management cluster. The operator exits once the kubeconfig "+
+			"changes, to be restarted with the new one.")
+	flag.StringVar(&hostedClusterKubeconfigKey, "hosted-cluster-kubeconfig-key", hostedcluster.DefaultKubeconfigKey,
+		"Set the key of the kubeconfig in the Secret of --hosted-cluster-kubeconfig-secret, e.g. \"admin.conf\" for Kamaji.")
+	flag.StringVar(&hostedClusterNamespace, "hosted-cluster-namespace", "",
+		"Set the namespace of the hosted cluster the operands are deployed into, which is created if missing. "+
+			"If undefined, the namespace defaults to the namespace the operator is running in.")
+
+	flag.StringVar(&nodeMutationPolicy, "node-mutation-policy", string(nodepolicy.LabelsAndTaints),
+		"Set the node fields the operator is allowed to mutate, one of labels-and-taints, labels-only or none. "+
+			"The features requiring a forbidden mutation, e.g. the GPU health taints and the automatic driver upgrades "+
+			"with labels-only, are disabled.")
+
+	// the --feature-gates flag applies on top of the FEATURE_GATES environment variable
+	featureGatesEnvErr := featuregate.Default.Set(os.Getenv(featuregate.EnvName))
+	flag.Var(featuregate.Default, "feature-gates",
+		"Set a comma separated list of <feature>=<bool> pairs (e.g. \"KataManagement=false\") enabling or disabling "+
+			"features, on top of the "+featuregate.EnvName+" environment variable. The feature gates can be overridden "+
+			"at runtime through the operator ConfigMap. Known features: "+strings.Join(featuregate.KnownFeatures(), ", "))
 
 	opts := zap.Options{
 		StacktraceLevel: zapcore.PanicLevel,
@@ -100,17 +203,59 @@ func main() {
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
+	// the log level can be changed at runtime through the operator ConfigMap
+	var logLevel *uberzap.AtomicLevel
+	switch level := opts.Level.(type) {
+	case nil:
+		defaultLevel := uberzap.NewAtomicLevelAt(zapcore.InfoLevel)
+		if opts.Development {
+			defaultLevel.SetLevel(zapcore.DebugLevel)
+		}
+		opts.Level = defaultLevel
+		logLevel = &defaultLevel
+	case uberzap.AtomicLevel:
+		logLevel = &level
+	}
+	operatorConfig := operatorconfig.NewStore(logLevel)
+
 	logger := zap.New(zap.UseFlagOptions(&opts))
 	ctrl.SetLogger(logger)
 
+	if featureGatesEnvErr != nil {
+		setupLog.Error(featureGatesEnvErr, "invalid "+featuregate.EnvName+" environment variable")
+		os.Exit(1)
+	}
+	setupLog.Info("Feature gates set", "featureGates", featuregate.Default.String())
+
+	timeouts, err := controllers.ParseStateTimeouts(stateTimeouts)
+	if err != nil {
+		setupLog.Error(err, "invalid --state-timeouts flag")
+		os.Exit(1)
+	}
+	reconcileOptions.StateTimeouts = timeouts
+
+	mutationPolicy, err := nodepolicy.Parse(nodeMutationPolicy)
+	if err != nil {
+		setupLog.Error(err, "invalid --node-mutation-policy flag")
+		os.Exit(1)
+	}
+	setupLog.Info("Node mutation policy set", "policy", mutationPolicy)
+
 	ctrl.Log.Info(fmt.Sprintf("version: %s", info.GetVersionString()))
 
+	// count the requests of the reconciliations, served with the metrics
+	apiStats := apistats.NewTracker(metrics.Registry)
 	metricsOptions := metricsserver.Options{
 		BindAddress: metricsAddr,
+		ExtraHandlers: map[string]http.Handler{
+			apistats.DebugPath: apiStats.Handler(),
+		},
 	}
 
+	webhookCertDir := filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs")
 	webhookServer := webhook.NewServer(webhook.Options{
-		Port: 9443,
+		Port:    9443,
+		CertDir: webhookCertDir,
 	})
 
 	operatorNamespace := os.Getenv("OPERATOR_NAMESPACE")
@@ -123,13 +268,67 @@ func main() {
 		os.Exit(1)
 	}
 
+	ctx := ctrl.SetupSignalHandler()
+
+	// trace the reconciliations when an OTLP endpoint is set
+	shutdownTracing, err := tracing.Setup(ctx, info.GetVersionParts()[0])
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+
+	// the leader election and the kubeconfig Secret of a hosted cluster stay in the management cluster
+	managementConfig := ctrl.GetConfigOrDie()
+	clusterConfig := managementConfig
+	var hostedClusterWatcher *hostedcluster.SecretWatcher
+	if hostedClusterKubeconfigSecret != "" {
+		if enableClusterPolicyWebhook {
+			setupLog.Error(nil, "the ClusterPolicy webhook is not supported with a hosted cluster, as the hosted API server cannot reach the operator")
+			os.Exit(1)
+		}
+		managementNamespace := operatorNamespace
+		if hostedClusterNamespace != "" {
+			operatorNamespace = hostedClusterNamespace
+		}
+		secret := hostedcluster.KubeconfigSecret{
+			Namespace: managementNamespace,
+			Name:      hostedClusterKubeconfigSecret,
+			Key:       hostedClusterKubeconfigKey,
+		}
+		clusterConfig, hostedClusterWatcher, err = setupHostedCluster(ctx, managementConfig, secret, operatorNamespace)
+		if err != nil {
+			setupLog.Error(err, "unable to set up the hosted cluster")
+			os.Exit(1)
+		}
+		if leaderElectionNamespace == "" {
+			leaderElectionNamespace = managementNamespace
+		}
+		setupLog.Info("managing the GPU nodes of a hosted cluster", "host", clusterConfig.Host, "namespace", operatorNamespace)
+	}
+
 	openshiftNamespace := consts.OpenshiftNamespace
+	gpuAllocationPolicySelector, err := labels.Parse(controllers.GPUAllocationPolicyLabelKey)
+	if err != nil {
+		setupLog.Error(err, "unable to build the GPUAllocationPolicy label selector")
+		os.Exit(1)
+	}
 	cacheOptions := cache.Options{
 		DefaultNamespaces: map[string]cache.Config{
 			operatorNamespace: {},
 			// Also cache resources in the openshift namespace to retrieve ImageStreams when on an openshift  cluster
 			openshiftNamespace: {},
 		},
+		ByObject: map[client.Object]cache.ByObject{
+			// GPUAllocationPolicies manage ResourceQuotas and LimitRanges in any namespace, only cache those
+			&corev1.ResourceQuota{}: {
+				Namespaces: map[string]cache.Config{cache.AllNamespaces: {}},
+				Label:      gpuAllocationPolicySelector,
+			},
+			&corev1.LimitRange{}: {
+				Namespaces: map[string]cache.Config{cache.AllNamespaces: {}},
+				Label:      gpuAllocationPolicySelector,
+			},
+		},
 	}
 
 	options := ctrl.Options{
@@ -143,6 +342,10 @@ func main() {
 		Cache:                   cacheOptions,
 	}
 
+	if hostedClusterWatcher != nil {
+		options.LeaderElectionConfig = managementConfig
+	}
+
 	if enableLeaderElection && int(renewDeadline) != 0 {
 		leaseDuration := renewDeadline + 5*time.Second
 
@@ -150,23 +353,68 @@ func main() {
 		options.LeaseDuration = &leaseDuration
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), options)
+	mgr, err := ctrl.NewManager(clusterConfig, options)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
-	ctx := ctrl.SetupSignalHandler()
+	if hostedClusterWatcher != nil {
+		if err := mgr.Add(hostedClusterWatcher); err != nil {
+			setupLog.Error(err, "unable to watch the hosted cluster kubeconfig")
+			os.Exit(1)
+		}
+	}
 
 	setupLog.Info("initializing operator metrics")
 	operatorMetrics := controllers.InitOperatorMetrics()
 
+	// record the objects created, updated and deleted by the controllers
+	auditRecorder := audit.NewRecorder(ctrl.Log.WithName("audit"), mgr.GetClient(), operatorNamespace, auditRingBufferSize)
+	auditClient := audit.NewClient(apistats.NewClient(tracing.NewClient(nodepolicy.NewClient(mgr.GetClient(), mutationPolicy)), apiStats), auditRecorder)
+	apiReader := apistats.NewReader(tracing.NewReader(mgr.GetAPIReader(), mgr.GetScheme()), mgr.GetScheme(), apiStats)
+
+	// post the lifecycle events of the GPU nodes to the sinks of the ClusterPolicy
+	notifier := notification.NewNotifier(ctrl.Log.WithName("notification"), mgr.GetAPIReader(), operatorNamespace)
+	if err := mgr.Add(notifier); err != nil {
+		setupLog.Error(err, "unable to set up the notification of the node lifecycle events")
+		os.Exit(1)
+	}
+
+	// the controllers load the baked-in assets unless an asset source is set
+	loadedAssetsDir := ""
+	if assetsSource != "" {
+		if err := loadAssets(ctx, mgr.GetAPIReader(), operatorNamespace, assetsSource, assetsPullSecret, assetsVerificationKey, assetsDir); err != nil {
+			setupLog.Error(err, "unable to load the operand assets", "source", assetsSource)
+			os.Exit(1)
+		}
+		loadedAssetsDir = assetsDir
+	}
+
+	// serve the effective configuration of the operands rendered by the ClusterPolicy controller
+	var introspectionStore *introspection.Store
+	if introspectionAddr != "" {
+		introspectionStore = introspection.NewStore()
+		if err := addIntrospectionServer(mgr, introspectionAddr, introspectionTokenFile, introspectionStore); err != nil {
+			setupLog.Error(err, "unable to set up the introspection endpoint")
+			os.Exit(1)
+		}
+	}
+
 	if err = (&controllers.ClusterPolicyReconciler{
-		Namespace:       operatorNamespace,
-		Client:          mgr.GetClient(),
-		Log:             ctrl.Log.WithName("controllers").WithName("ClusterPolicy"),
-		Scheme:          mgr.GetScheme(),
-		OperatorMetrics: operatorMetrics,
+		Namespace:          operatorNamespace,
+		Client:             auditClient,
+		Log:                ctrl.Log.WithName("controllers").WithName("ClusterPolicy"),
+		Scheme:             mgr.GetScheme(),
+		OperatorMetrics:    operatorMetrics,
+		ReconcileOptions:   reconcileOptions,
+		AssetsDir:          loadedAssetsDir,
+		Introspection:      introspectionStore,
+		APIStats:           apiStats,
+		OperatorConfig:     operatorConfig,
+		Config:             mgr.GetConfig(),
+		Notifier:           notifier,
+		NodeMutationPolicy: mutationPolicy,
 	}).SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterPolicy")
 		os.Exit(1)
@@ -188,16 +436,33 @@ func main() {
 		os.Exit(1)
 	}
 	clusterUpgradeStateManager = clusterUpgradeStateManager.
-		WithPodDeletionEnabled(gpuPodSpecFilter(ctx, mgr.GetAPIReader())).
+		WithPodDeletionEnabled(gpuPodSpecFilter(ctx, apiReader)).
 		WithValidationEnabled("app=nvidia-operator-validator").
 		WithRestartOnlyPredicate(predicates.DriverPodRestartOnly(upgradeLogger))
 
+	// replace the drain manager of the upgrade library to apply the ClusterPolicy drain policy
+	var drainManager *drain.Manager
+	if upgradeStateManager, ok := clusterUpgradeStateManager.(*upgrade.ClusterUpgradeStateManagerImpl); ok {
+		drainManager = drain.NewManager(
+			upgradeStateManager.K8sInterface,
+			upgradeStateManager.NodeUpgradeStateProvider,
+			upgradeLogger.WithName("Drain"),
+			upgradeStateManager.EventRecorder,
+			mutationPolicy,
+		)
+		upgradeStateManager.DrainManager = drainManager
+	}
+
 	if err = (&controllers.UpgradeReconciler{
-		Client:          mgr.GetClient(),
-		Log:             upgradeLogger,
-		Scheme:          mgr.GetScheme(),
-		StateManager:    clusterUpgradeStateManager,
-		OperatorMetrics: operatorMetrics,
+		Client:             auditClient,
+		Log:                upgradeLogger,
+		Scheme:             mgr.GetScheme(),
+		StateManager:       clusterUpgradeStateManager,
+		OperatorMetrics:    operatorMetrics,
+		DrainManager:       drainManager,
+		APIReader:          apiReader,
+		APIStats:           apiStats,
+		NodeMutationPolicy: mutationPolicy,
 	}).SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Upgrade")
 		os.Exit(1)
@@ -215,9 +480,11 @@ func main() {
 
 	if err = (&controllers.NVIDIADriverReconciler{
 		Namespace:   operatorNamespace,
-		Client:      mgr.GetClient(),
+		Client:      auditClient,
 		Scheme:      mgr.GetScheme(),
 		ClusterInfo: clusterInfo,
+		AssetsDir:   loadedAssetsDir,
+		APIStats:    apiStats,
 	}).SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "NVIDIADriver")
 		os.Exit(1)
@@ -225,23 +492,113 @@ func main() {
 
 	if err = (&controllers.NodeLabelingReconciler{
 		Namespace: operatorNamespace,
-		Client:    mgr.GetClient(),
+		Client:    auditClient,
 		Scheme:    mgr.GetScheme(),
 		Log:       ctrl.Log.WithName("controllers").WithName("NodeLabeling"),
+		APIStats:  apiStats,
+		Notifier:  notifier,
 	}).SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "NodeLabeling")
 		os.Exit(1)
 	}
 
+	if operatorConfigMapName != "" {
+		if err = (&controllers.OperatorConfigReconciler{
+			Namespace:     operatorNamespace,
+			Client:        auditClient,
+			Scheme:        mgr.GetScheme(),
+			Log:           ctrl.Log.WithName("controllers").WithName("OperatorConfig"),
+			ConfigMapName: operatorConfigMapName,
+			Store:         operatorConfig,
+			APIStats:      apiStats,
+		}).SetupWithManager(ctx, mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "OperatorConfig")
+			os.Exit(1)
+		}
+	}
+
+	if err = (&controllers.GPUAllocationPolicyReconciler{
+		Client:   auditClient,
+		Scheme:   mgr.GetScheme(),
+		Log:      ctrl.Log.WithName("controllers").WithName("GPUAllocationPolicy"),
+		APIStats: apiStats,
+	}).SetupWithManager(ctx, mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "GPUAllocationPolicy")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.IdleNodeHintsReconciler{
+		Namespace: operatorNamespace,
+		Client:    auditClient,
+		Scheme:    mgr.GetScheme(),
+		Log:       ctrl.Log.WithName("controllers").WithName("IdleNodeHints"),
+		APIStats:  apiStats,
+	}).SetupWithManager(ctx, mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "IdleNodeHints")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.AutoscalingHintsReconciler{
+		Namespace: operatorNamespace,
+		Client:    auditClient,
+		Scheme:    mgr.GetScheme(),
+		Log:       ctrl.Log.WithName("controllers").WithName("AutoscalingHints"),
+		APIStats:  apiStats,
+	}).SetupWithManager(ctx, mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AutoscalingHints")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.KubeletRestartRecoveryReconciler{
+		Namespace: operatorNamespace,
+		Client:    auditClient,
+		Scheme:    mgr.GetScheme(),
+		Log:       ctrl.Log.WithName("controllers").WithName("KubeletRestartRecovery"),
+		APIStats:  apiStats,
+	}).SetupWithManager(ctx, mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KubeletRestartRecovery")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.GPURemediationReconciler{
+		Namespace: operatorNamespace,
+		Client:    auditClient,
+		Scheme:    mgr.GetScheme(),
+		Log:       ctrl.Log.WithName("controllers").WithName("GPURemediation"),
+		APIStats:  apiStats,
+	}).SetupWithManager(ctx, mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "GPURemediation")
+		os.Exit(1)
+	}
+
 	if err = (&controllers.GPUClusterReconciler{
-		Namespace:   operatorNamespace,
-		Client:      mgr.GetClient(),
-		Scheme:      mgr.GetScheme(),
-		ClusterInfo: clusterInfo,
+		Namespace:      operatorNamespace,
+		Client:         auditClient,
+		Scheme:         mgr.GetScheme(),
+		ClusterInfo:    clusterInfo,
+		AssetsDir:      loadedAssetsDir,
+		APIStats:       apiStats,
+		OperatorConfig: operatorConfig,
 	}).SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "GPUCluster")
 		os.Exit(1)
 	}
+
+	if enableClusterPolicyWebhook {
+		if err = ctrl.NewWebhookManagedBy(mgr, &clusterpolicyv1.ClusterPolicy{}).
+			WithValidator(clusterpolicy.NewScopeValidator(mgr.GetClient())).
+			Complete(); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterPolicy")
+			os.Exit(1)
+		}
+		if conversionWebhookService != "" {
+			if err = enableConversionWebhook(mgr, types.NamespacedName{Namespace: operatorNamespace, Name: conversionWebhookService},
+				filepath.Join(webhookCertDir, "ca.crt")); err != nil {
+				setupLog.Error(err, "unable to enable the conversion webhook", "webhook", "ClusterPolicy")
+				os.Exit(1)
+			}
+		}
+	}
 	// +kubebuilder:scaffold:builder
 	if err := mgr.AddHealthzCheck("health", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
@@ -257,30 +614,95 @@ func main() {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+
+	// export the spans left once the manager is stopped
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		setupLog.Error(err, "unable to export the remaining traces")
+	}
 }
 
-func gpuPodSpecFilter(ctx context.Context, c client.Reader) func(pod corev1.Pod) bool {
-	return func(pod corev1.Pod) bool {
-		gpuInResourceList := func(rl corev1.ResourceList) bool {
-			for resourceName := range rl {
-				str := string(resourceName)
-				if strings.HasPrefix(str, "nvidia.com/gpu") || strings.HasPrefix(str, "nvidia.com/mig-") {
-					return true
-				}
-			}
-			return false
-		}
+// loadAssets merges the verified asset manifests of the source with the baked-in ones into assetsDir
+func loadAssets(ctx context.Context, c client.Reader, namespace string, source string, pullSecret string, verificationKey string, assetsDir string) error {
+	if verificationKey == "" {
+		return fmt.Errorf("--assets-verification-key is required to verify the assets of %s", source)
+	}
+	publicKey, err := os.ReadFile(verificationKey)
+	if err != nil {
+		return fmt.Errorf("failed to read the assets verification key: %w", err)
+	}
+	assetSource, err := assets.ParseSource(source, c, namespace, pullSecret)
+	if err != nil {
+		return err
+	}
+	bundle, err := assets.Load(ctx, assetSource, publicKey, assets.DefaultDir, assetsDir)
+	if err != nil {
+		return err
+	}
+	setupLog.Info("loaded operand assets", "source", source, "files", len(bundle.Files), "dir", assetsDir)
+	return nil
+}
 
-		//  ignore pods other than in running and pending state
-		if pod.Status.Phase != corev1.PodRunning && pod.Status.Phase != corev1.PodPending {
-			return false
-		}
+// setupHostedCluster returns the client configuration of the hosted cluster read from the kubeconfig Secret of the
+// management cluster, along with the watcher of the Secret, and creates the namespace of the operands
+func setupHostedCluster(ctx context.Context, managementConfig *rest.Config, secret hostedcluster.KubeconfigSecret, namespace string) (*rest.Config, *hostedcluster.SecretWatcher, error) {
+	managementClient, err := client.New(managementConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create the management cluster client: %w", err)
+	}
+	config, kubeconfig, err := secret.Config(ctx, managementClient)
+	if err != nil {
+		return nil, nil, err
+	}
+	hostedClient, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create the hosted cluster client: %w", err)
+	}
+	if err := hostedcluster.EnsureNamespace(ctx, hostedClient, namespace); err != nil {
+		return nil, nil, err
+	}
+	watcher := hostedcluster.NewSecretWatcher(secret, managementClient, kubeconfig,
+		hostedcluster.DefaultSecretCheckInterval, ctrl.Log.WithName("hosted-cluster"))
+	return config, watcher, nil
+}
 
-		for _, ctr := range pod.Spec.Containers {
-			if gpuInResourceList(ctr.Resources.Limits) || gpuInResourceList(ctr.Resources.Requests) {
-				return true
-			}
+// addIntrospectionServer adds the server of the introspection endpoint to the manager
+func addIntrospectionServer(mgr ctrl.Manager, addr string, tokenFile string, store *introspection.Store) error {
+	token := ""
+	if tokenFile != "" {
+		data, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return fmt.Errorf("failed to read the introspection token: %w", err)
+		}
+		token = strings.TrimSpace(string(data))
+		if token == "" {
+			return fmt.Errorf("the introspection token file %s is empty", tokenFile)
 		}
-		return controllers.PodHasNVIDIAGPUClaim(ctx, c, &pod, false)
+	}
+	server, err := introspection.NewServer(addr, token, store, ctrl.Log.WithName("introspection"))
+	if err != nil {
+		return err
+	}
+	// preview the MIG and vGPU partitionings of the GPU models of the cluster
+	server.Handle(partitioning.PreviewPath, partitioning.NewHandler(mgr.GetClient(), ctrl.Log.WithName("partitioning")))
+	return mgr.Add(server)
+}
+
+// enableConversionWebhook configures the ClusterPolicy CRD to convert the ClusterPolicies through the conversion webhook
+// served by the service, once elected leader
+func enableConversionWebhook(mgr ctrl.Manager, service types.NamespacedName, caFile string) error {
+	caBundle, err := os.ReadFile(caFile)
+	if err != nil {
+		return fmt.Errorf("failed to read the CA bundle of the webhook server: %w", err)
+	}
+	return mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		return clusterpolicy.EnableConversionWebhook(ctx, mgr.GetClient(), service, caBundle)
+	}))
+}
+
+func gpuPodSpecFilter(ctx context.Context, c client.Reader) func(pod corev1.Pod) bool {
+	return func(pod corev1.Pod) bool {
+		return controllers.IsGPUPod(ctx, c, &pod)
 	}
 }
@@ -0,0 +1,242 @@
+/**
+# Copyright (c), NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package backup
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+const (
+	// archiveKind is the kind of the backup archives
+	archiveKind = "GPUOperatorBackup"
+	// archiveVersion is the version of the format of the backup archives written by the export command. The
+	// restore command rejects the archives of other versions.
+	archiveVersion = "v1"
+)
+
+// archive is the backup of the configuration of the GPU Operator. The ConfigMaps referenced by the ClusterPolicies
+// and NVIDIADrivers, e.g. the MIG configurations, are captured with their content, while only the metadata and the
+// keys of the referenced Secrets are captured: the Secrets have to be restored from the secret store of the cluster.
+type archive struct {
+	Kind              string      `json:"kind"`
+	Version           string      `json:"version"`
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
+	// Namespace is the namespace of the operator the ConfigMaps and Secrets were exported from
+	Namespace       string                        `json:"namespace"`
+	ClusterPolicies []gpuv1.ClusterPolicy         `json:"clusterPolicies,omitempty"`
+	NVIDIADrivers   []nvidiav1alpha1.NVIDIADriver `json:"nvidiaDrivers,omitempty"`
+	ConfigMaps      []corev1.ConfigMap            `json:"configMaps,omitempty"`
+	Secrets         []secretMetadata              `json:"secrets,omitempty"`
+}
+
+// secretMetadata is the metadata of a Secret referenced by the configuration, without its data
+type secretMetadata struct {
+	Name   string            `json:"name"`
+	Type   corev1.SecretType `json:"type,omitempty"`
+	Keys   []string          `json:"keys,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// reference is a ConfigMap or Secret of the operator namespace referenced by a ClusterPolicy or an NVIDIADriver
+type reference struct {
+	// owner is the kind and name of the referencing object
+	owner string
+	// field is the path of the referencing field in the spec of the owner
+	field string
+	kind  string
+	name  string
+}
+
+func (r reference) String() string {
+	return fmt.Sprintf("%s %s referenced by %s %s", r.kind, r.name, r.owner, r.field)
+}
+
+// referenceCollector collects the references of the ClusterPolicies and NVIDIADrivers
+type referenceCollector struct {
+	owner string
+	refs  []reference
+}
+
+func (c *referenceCollector) add(field, kind, name string) {
+	if name != "" {
+		c.refs = append(c.refs, reference{owner: c.owner, field: field, kind: kind, name: name})
+	}
+}
+
+// getClusterPolicyReferences returns the ConfigMaps and Secrets referenced by the ClusterPolicy, whether the
+// referencing operands are enabled or not, as they may be enabled once restored
+func getClusterPolicyReferences(cp *gpuv1.ClusterPolicy) []reference {
+	c := &referenceCollector{owner: "ClusterPolicy " + cp.Name}
+	spec := &cp.Spec
+	driver := &spec.Driver
+	if driver.LicensingConfig != nil {
+		c.add("driver.licensingConfig.configMapName", "ConfigMap", driver.LicensingConfig.ConfigMapName)
+		c.add("driver.licensingConfig.secretName", "Secret", driver.LicensingConfig.SecretName)
+	}
+	if driver.RepoConfig != nil {
+		c.add("driver.repoConfig.configMapName", "ConfigMap", driver.RepoConfig.ConfigMapName)
+	}
+	if driver.CertConfig != nil {
+		c.add("driver.certConfig.name", "ConfigMap", driver.CertConfig.Name)
+	}
+	if driver.KernelModuleConfig != nil {
+		c.add("driver.kernelModuleConfig.name", "ConfigMap", driver.KernelModuleConfig.Name)
+	}
+	if driver.VirtualTopology != nil {
+		c.add("driver.virtualTopology.config", "ConfigMap", driver.VirtualTopology.Config)
+	}
+	c.add("driver.secretEnv", "Secret", driver.SecretEnv)
+	if spec.VGPUManager.KernelModuleConfig != nil {
+		c.add("vgpuManager.kernelModuleConfig.name", "ConfigMap", spec.VGPUManager.KernelModuleConfig.Name)
+	}
+	if spec.DevicePlugin.Config != nil {
+		c.add("devicePlugin.config.name", "ConfigMap", spec.DevicePlugin.Config.Name)
+	}
+	c.add("migManager.config.name", "ConfigMap", spec.MIGManager.Config.GetName())
+	c.add("migManager.gpuClientsConfig.name", "ConfigMap", spec.MIGManager.GPUClientsConfig.GetName())
+	c.add("vgpuDeviceManager.config.name", "ConfigMap", spec.VGPUDeviceManager.Config.GetName())
+	if spec.DCGMExporter.MetricsConfig != nil {
+		c.add("dcgmExporter.config.name", "ConfigMap", spec.DCGMExporter.MetricsConfig.Name)
+	}
+	return c.refs
+}
+
+// getNVIDIADriverReferences returns the ConfigMaps and Secrets referenced by the NVIDIADriver
+func getNVIDIADriverReferences(driver *nvidiav1alpha1.NVIDIADriver) []reference {
+	c := &referenceCollector{owner: "NVIDIADriver " + driver.Name}
+	spec := &driver.Spec
+	if spec.LicensingConfig != nil {
+		c.add("licensingConfig.name", "ConfigMap", spec.LicensingConfig.Name)
+		c.add("licensingConfig.secretName", "Secret", spec.LicensingConfig.SecretName)
+	}
+	if spec.RepoConfig != nil {
+		c.add("repoConfig.name", "ConfigMap", spec.RepoConfig.Name)
+	}
+	if spec.CertConfig != nil {
+		c.add("certConfig.name", "ConfigMap", spec.CertConfig.Name)
+	}
+	if spec.KernelModuleConfig != nil {
+		c.add("kernelModuleConfig.name", "ConfigMap", spec.KernelModuleConfig.Name)
+	}
+	if spec.VirtualTopologyConfig != nil {
+		c.add("virtualTopologyConfig.name", "ConfigMap", spec.VirtualTopologyConfig.Name)
+	}
+	c.add("secretEnv", "Secret", spec.SecretEnv)
+	if spec.ModuleCache != nil {
+		c.add("moduleCache.secretName", "Secret", spec.ModuleCache.SecretName)
+	}
+	return c.refs
+}
+
+// references returns the references of all the ClusterPolicies and NVIDIADrivers of the archive
+func (a *archive) references() []reference {
+	var refs []reference
+	for i := range a.ClusterPolicies {
+		refs = append(refs, getClusterPolicyReferences(&a.ClusterPolicies[i])...)
+	}
+	for i := range a.NVIDIADrivers {
+		refs = append(refs, getNVIDIADriverReferences(&a.NVIDIADrivers[i])...)
+	}
+	return refs
+}
+
+// validate checks that the archive is an archive of a supported version, and that all its objects are named
+func (a *archive) validate() error {
+	if a.Kind != archiveKind {
+		return fmt.Errorf("unexpected kind %q, expected %q", a.Kind, archiveKind)
+	}
+	if a.Version != archiveVersion {
+		return fmt.Errorf("unsupported archive version %q, expected %q", a.Version, archiveVersion)
+	}
+
+	var errs []error
+	for _, cp := range a.ClusterPolicies {
+		if cp.Name == "" {
+			errs = append(errs, errors.New("ClusterPolicy without a name"))
+		}
+	}
+	for _, driver := range a.NVIDIADrivers {
+		if driver.Name == "" {
+			errs = append(errs, errors.New("NVIDIADriver without a name"))
+		}
+	}
+	for _, cm := range a.ConfigMaps {
+		if cm.Name == "" {
+			errs = append(errs, errors.New("ConfigMap without a name"))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// hasConfigMap returns true if the ConfigMap of the given name is part of the archive
+func (a *archive) hasConfigMap(name string) bool {
+	return slices.ContainsFunc(a.ConfigMaps, func(cm corev1.ConfigMap) bool { return cm.Name == name })
+}
+
+// sort orders the objects of the archive by name so that the exports of the same configuration are identical
+func (a *archive) sort() {
+	slices.SortFunc(a.ClusterPolicies, func(x, y gpuv1.ClusterPolicy) int { return cmp.Compare(x.Name, y.Name) })
+	slices.SortFunc(a.NVIDIADrivers, func(x, y nvidiav1alpha1.NVIDIADriver) int { return cmp.Compare(x.Name, y.Name) })
+	slices.SortFunc(a.ConfigMaps, func(x, y corev1.ConfigMap) int { return cmp.Compare(x.Name, y.Name) })
+	slices.SortFunc(a.Secrets, func(x, y secretMetadata) int { return cmp.Compare(x.Name, y.Name) })
+}
+
+// loadArchive reads the archive from the given file, or from STDIN if the file is '-'
+func loadArchive(path string) (*archive, error) {
+	var contents []byte
+	var err error
+	if path == "-" {
+		contents, err = io.ReadAll(os.Stdin)
+	} else {
+		contents, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	a := &archive{}
+	if err := yaml.Unmarshal(contents, a); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal archive: %w", err)
+	}
+	return a, nil
+}
+
+// save writes the archive to the given file, or to STDOUT if the file is '-'
+func (a *archive) save(path string) error {
+	contents, err := yaml.Marshal(a)
+	if err != nil {
+		return fmt.Errorf("failed to marshal archive: %w", err)
+	}
+	if path == "-" {
+		_, err = os.Stdout.Write(contents)
+		return err
+	}
+	// the archive may hold sensitive configuration, e.g. the licensing ConfigMaps
+	return os.WriteFile(path, contents, 0600)
+}
@@ -0,0 +1,89 @@
+/**
+# Copyright (c), NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package backup
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	cli "github.com/urfave/cli/v3"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlconfig "sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+const defaultNamespace = "gpu-operator"
+
+type command struct {
+	logger *logrus.Logger
+}
+
+// NewCommand constructs a backup command with the specified logger
+func NewCommand(logger *logrus.Logger) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+func (m command) build() *cli.Command {
+	// Create the 'backup' command
+	backup := cli.Command{
+		Name:  "backup",
+		Usage: "Export and restore the GPU Operator configuration of a cluster",
+	}
+
+	backup.Commands = []*cli.Command{
+		m.buildExport(),
+		m.buildRestore(),
+	}
+
+	return &backup
+}
+
+// newClient returns a client of the cluster of the kubeconfig, or of the default configuration of
+// controller-runtime when no kubeconfig is set
+func newClient(kubeconfig string) (client.Client, error) {
+	var restConfig *rest.Config
+	var err error
+	if kubeconfig == "" {
+		restConfig, err = ctrlconfig.GetConfig()
+	} else {
+		restConfig, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("failed to add Kubernetes types to scheme: %w", err)
+	}
+	if err := gpuv1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("failed to add ClusterPolicy types to scheme: %w", err)
+	}
+	if err := nvidiav1alpha1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("failed to add NVIDIADriver types to scheme: %w", err)
+	}
+	return client.New(restConfig, client.Options{Scheme: scheme})
+}
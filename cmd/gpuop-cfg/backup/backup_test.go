@@ -0,0 +1,187 @@
+/**
+# Copyright (c), NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package backup
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+const testNamespace = "gpu-operator"
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+	require.NoError(t, nvidiav1alpha1.AddToScheme(scheme))
+	return scheme
+}
+
+func newTestClusterPolicy() *gpuv1.ClusterPolicy {
+	cp := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy", ResourceVersion: "42", UID: "uid"},
+	}
+	cp.Spec.Driver.LicensingConfig = &gpuv1.DriverLicensingConfigSpec{ConfigMapName: "licensing", SecretName: "licensing-token"}
+	cp.Spec.MIGManager.Config = &gpuv1.MIGPartedConfigSpec{Name: "mig-config"}
+	cp.Status.State = gpuv1.Ready
+	return cp
+}
+
+func TestExport(t *testing.T) {
+	scheme := newTestScheme(t)
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newTestClusterPolicy(),
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "mig-config", Namespace: testNamespace, ResourceVersion: "7"},
+			Data:       map[string]string{"config.yaml": "version: v1"},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "licensing-token", Namespace: testNamespace},
+			Data:       map[string][]byte{"token": []byte("secret"), "client": []byte("secret")},
+		},
+	).Build()
+
+	m := command{logger: logrus.New()}
+	a, err := m.export(context.Background(), k8sClient, testNamespace)
+	require.NoError(t, err)
+	require.NoError(t, a.validate())
+
+	require.Len(t, a.ClusterPolicies, 1)
+	require.Empty(t, a.ClusterPolicies[0].ResourceVersion)
+	require.Empty(t, a.ClusterPolicies[0].UID)
+	require.Empty(t, a.ClusterPolicies[0].Status.State)
+
+	// the missing licensing ConfigMap is skipped
+	require.Len(t, a.ConfigMaps, 1)
+	require.Equal(t, "mig-config", a.ConfigMaps[0].Name)
+	require.Equal(t, "version: v1", a.ConfigMaps[0].Data["config.yaml"])
+	require.Empty(t, a.ConfigMaps[0].ResourceVersion)
+
+	require.Equal(t, []secretMetadata{{Name: "licensing-token", Keys: []string{"client", "token"}}}, a.Secrets)
+}
+
+func TestArchiveRoundTrip(t *testing.T) {
+	a := &archive{
+		Kind:            archiveKind,
+		Version:         archiveVersion,
+		Namespace:       testNamespace,
+		ClusterPolicies: []gpuv1.ClusterPolicy{*newTestClusterPolicy()},
+	}
+	path := filepath.Join(t.TempDir(), "backup.yaml")
+	require.NoError(t, a.save(path))
+
+	loaded, err := loadArchive(path)
+	require.NoError(t, err)
+	require.NoError(t, loaded.validate())
+	require.Equal(t, "licensing", loaded.ClusterPolicies[0].Spec.Driver.LicensingConfig.ConfigMapName)
+
+	loaded.Version = "v0"
+	require.ErrorContains(t, loaded.validate(), "unsupported archive version")
+}
+
+func TestValidateReferences(t *testing.T) {
+	a := &archive{
+		Kind:            archiveKind,
+		Version:         archiveVersion,
+		Namespace:       testNamespace,
+		ClusterPolicies: []gpuv1.ClusterPolicy{*newTestClusterPolicy()},
+		ConfigMaps:      []corev1.ConfigMap{{ObjectMeta: metav1.ObjectMeta{Name: "mig-config"}}},
+	}
+	licensing := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "licensing", Namespace: testNamespace}}
+
+	testCases := []struct {
+		description string
+		objects     []client.Object
+		opts        restoreOptions
+		errContains []string
+	}{
+		{
+			description: "missing ConfigMap and Secret",
+			errContains: []string{"ConfigMap licensing", "Secret licensing-token"},
+		},
+		{
+			description: "missing Secret",
+			objects:     []client.Object{licensing},
+			errContains: []string{"Secret licensing-token"},
+		},
+		{
+			description: "missing Secret allowed",
+			objects:     []client.Object{licensing},
+			opts:        restoreOptions{allowMissingSecrets: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			k8sClient := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(tc.objects...).Build()
+			tc.opts.namespace = testNamespace
+
+			m := command{logger: logrus.New()}
+			err := m.validateReferences(context.Background(), k8sClient, a, &tc.opts)
+			if len(tc.errContains) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			for _, s := range tc.errContains {
+				require.ErrorContains(t, err, s)
+			}
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "mig-config", Namespace: "restored"},
+		Data:       map[string]string{"config.yaml": "version: v0"},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(existing).Build()
+	a := &archive{
+		Kind:            archiveKind,
+		Version:         archiveVersion,
+		Namespace:       testNamespace,
+		ClusterPolicies: []gpuv1.ClusterPolicy{*newTestClusterPolicy()},
+		ConfigMaps: []corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: "mig-config", Namespace: testNamespace},
+			Data:       map[string]string{"config.yaml": "version: v1"},
+		}},
+	}
+	a.ClusterPolicies[0].ResourceVersion = ""
+
+	m := command{logger: logrus.New()}
+	require.NoError(t, m.restore(context.Background(), k8sClient, a, &restoreOptions{namespace: "restored"}))
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Namespace: "restored", Name: "mig-config"}, cm))
+	require.Equal(t, "version: v1", cm.Data["config.yaml"])
+
+	cp := &gpuv1.ClusterPolicy{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Name: "cluster-policy"}, cp))
+	require.Equal(t, "mig-config", cp.Spec.MIGManager.Config.Name)
+}
@@ -0,0 +1,200 @@
+/**
+# Copyright (c), NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	cli "github.com/urfave/cli/v3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+type exportOptions struct {
+	kubeconfig string
+	namespace  string
+	output     string
+}
+
+func (m command) buildExport() *cli.Command {
+	opts := exportOptions{}
+
+	// Create the 'export' command
+	c := cli.Command{
+		Name:  "export",
+		Usage: "Export the ClusterPolicies, NVIDIADrivers and the ConfigMaps and Secrets they reference into an archive",
+		Action: func(ctx context.Context, cli *cli.Command) error {
+			return m.runExport(ctx, &opts)
+		},
+	}
+
+	c.Flags = []cli.Flag{
+		&cli.StringFlag{
+			Name:        "kubeconfig",
+			Usage:       "Path of the kubeconfig of the cluster, the KUBECONFIG environment variable, the in-cluster configuration or ~/.kube/config being used when unset",
+			Destination: &opts.kubeconfig,
+		},
+		&cli.StringFlag{
+			Name:        "namespace",
+			Usage:       "Namespace of the GPU Operator holding the referenced ConfigMaps and Secrets",
+			Value:       defaultNamespace,
+			Destination: &opts.namespace,
+			Sources:     cli.EnvVars("OPERATOR_NAMESPACE"),
+		},
+		&cli.StringFlag{
+			Name:        "output",
+			Aliases:     []string{"o"},
+			Usage:       "Specify the file the archive is written to. If this is '-' the archive is written to STDOUT",
+			Value:       "-",
+			Destination: &opts.output,
+		},
+	}
+
+	return &c
+}
+
+func (m command) runExport(ctx context.Context, opts *exportOptions) error {
+	k8sClient, err := newClient(opts.kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	a, err := m.export(ctx, k8sClient, opts.namespace)
+	if err != nil {
+		return err
+	}
+	if err := a.save(opts.output); err != nil {
+		return fmt.Errorf("failed to save archive: %w", err)
+	}
+	m.logger.Infof("Exported %d ClusterPolicies, %d NVIDIADrivers, %d ConfigMaps and %d Secrets",
+		len(a.ClusterPolicies), len(a.NVIDIADrivers), len(a.ConfigMaps), len(a.Secrets))
+	return nil
+}
+
+// export builds the archive of the configuration of the GPU Operator of the cluster. The references to
+// missing ConfigMaps and Secrets are logged but do not fail the export, as the ClusterPolicy does not
+// fail either.
+func (m command) export(ctx context.Context, k8sClient client.Client, namespace string) (*archive, error) {
+	a := &archive{
+		Kind:              archiveKind,
+		Version:           archiveVersion,
+		CreationTimestamp: metav1.Now(),
+		Namespace:         namespace,
+	}
+
+	cpList := &gpuv1.ClusterPolicyList{}
+	if err := k8sClient.List(ctx, cpList); err != nil {
+		return nil, fmt.Errorf("failed to list ClusterPolicies: %w", err)
+	}
+	for _, cp := range cpList.Items {
+		cp.ObjectMeta = cleanObjectMeta(cp.ObjectMeta, false)
+		cp.Status = gpuv1.ClusterPolicyStatus{}
+		a.ClusterPolicies = append(a.ClusterPolicies, cp)
+	}
+
+	driverList := &nvidiav1alpha1.NVIDIADriverList{}
+	if err := k8sClient.List(ctx, driverList); err != nil {
+		// the NVIDIADriver CRD is optional
+		if !meta.IsNoMatchError(err) {
+			return nil, fmt.Errorf("failed to list NVIDIADrivers: %w", err)
+		}
+		m.logger.Debug("NVIDIADriver CRD not installed, skipping NVIDIADrivers")
+	}
+	for _, driver := range driverList.Items {
+		driver.ObjectMeta = cleanObjectMeta(driver.ObjectMeta, false)
+		driver.Status = nvidiav1alpha1.NVIDIADriverStatus{}
+		a.NVIDIADrivers = append(a.NVIDIADrivers, driver)
+	}
+
+	for _, ref := range a.references() {
+		key := client.ObjectKey{Namespace: namespace, Name: ref.name}
+		switch ref.kind {
+		case "ConfigMap":
+			if a.hasConfigMap(ref.name) {
+				continue
+			}
+			cm := &corev1.ConfigMap{}
+			if err := k8sClient.Get(ctx, key, cm); err != nil {
+				if apierrors.IsNotFound(err) {
+					m.logger.Warnf("Skipping missing %s", ref)
+					continue
+				}
+				return nil, fmt.Errorf("failed to get %s: %w", ref, err)
+			}
+			cm.ObjectMeta = cleanObjectMeta(cm.ObjectMeta, true)
+			a.ConfigMaps = append(a.ConfigMaps, *cm)
+		case "Secret":
+			if slices.ContainsFunc(a.Secrets, func(s secretMetadata) bool { return s.Name == ref.name }) {
+				continue
+			}
+			secret := &corev1.Secret{}
+			if err := k8sClient.Get(ctx, key, secret); err != nil {
+				if apierrors.IsNotFound(err) {
+					m.logger.Warnf("Skipping missing %s", ref)
+					continue
+				}
+				return nil, fmt.Errorf("failed to get %s: %w", ref, err)
+			}
+			a.Secrets = append(a.Secrets, getSecretMetadata(secret))
+		}
+	}
+
+	a.sort()
+	return a, nil
+}
+
+// cleanObjectMeta returns the metadata of an exported object without the fields set by the API server, so
+// that the object can be created in another cluster
+func cleanObjectMeta(om metav1.ObjectMeta, namespaced bool) metav1.ObjectMeta {
+	cleaned := metav1.ObjectMeta{
+		Name:        om.Name,
+		Labels:      om.Labels,
+		Annotations: om.Annotations,
+	}
+	if namespaced {
+		cleaned.Namespace = om.Namespace
+	}
+	delete(cleaned.Annotations, corev1.LastAppliedConfigAnnotation)
+	if len(cleaned.Annotations) == 0 {
+		cleaned.Annotations = nil
+	}
+	return cleaned
+}
+
+// getSecretMetadata returns the metadata of the Secret, with its sorted keys
+func getSecretMetadata(secret *corev1.Secret) secretMetadata {
+	keys := make([]string, 0, len(secret.Data))
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return secretMetadata{
+		Name:   secret.Name,
+		Type:   secret.Type,
+		Keys:   keys,
+		Labels: secret.Labels,
+	}
+}
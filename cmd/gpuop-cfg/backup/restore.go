@@ -0,0 +1,197 @@
+/**
+# Copyright (c), NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	cli "github.com/urfave/cli/v3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type restoreOptions struct {
+	kubeconfig          string
+	namespace           string
+	input               string
+	dryRun              bool
+	allowMissingSecrets bool
+}
+
+func (m command) buildRestore() *cli.Command {
+	opts := restoreOptions{}
+
+	// Create the 'restore' command
+	c := cli.Command{
+		Name:  "restore",
+		Usage: "Validate an archive of the export command and re-apply its configuration to the cluster",
+		Action: func(ctx context.Context, cli *cli.Command) error {
+			return m.runRestore(ctx, &opts)
+		},
+	}
+
+	c.Flags = []cli.Flag{
+		&cli.StringFlag{
+			Name:        "kubeconfig",
+			Usage:       "Path of the kubeconfig of the cluster, the KUBECONFIG environment variable, the in-cluster configuration or ~/.kube/config being used when unset",
+			Destination: &opts.kubeconfig,
+		},
+		&cli.StringFlag{
+			Name:        "namespace",
+			Usage:       "Namespace of the GPU Operator the ConfigMaps are restored to, the namespace they were exported from being used when unset",
+			Destination: &opts.namespace,
+			Sources:     cli.EnvVars("OPERATOR_NAMESPACE"),
+		},
+		&cli.StringFlag{
+			Name:        "input",
+			Aliases:     []string{"i"},
+			Usage:       "Specify the file containing the archive. If this is '-' the archive is read from STDIN",
+			Value:       "-",
+			Destination: &opts.input,
+		},
+		&cli.BoolFlag{
+			Name:        "dry-run",
+			Usage:       "Validate the archive and submit the objects to the API server without persisting them",
+			Destination: &opts.dryRun,
+		},
+		&cli.BoolFlag{
+			Name:        "allow-missing-secrets",
+			Usage:       "Restore the configuration even if Secrets referenced by the archive do not exist in the cluster yet",
+			Destination: &opts.allowMissingSecrets,
+		},
+	}
+
+	return &c
+}
+
+func (m command) runRestore(ctx context.Context, opts *restoreOptions) error {
+	a, err := loadArchive(opts.input)
+	if err != nil {
+		return fmt.Errorf("failed to load archive: %w", err)
+	}
+	if err := a.validate(); err != nil {
+		return fmt.Errorf("invalid archive: %w", err)
+	}
+	if opts.namespace == "" {
+		opts.namespace = a.Namespace
+	}
+
+	k8sClient, err := newClient(opts.kubeconfig)
+	if err != nil {
+		return err
+	}
+	if err := m.validateReferences(ctx, k8sClient, a, opts); err != nil {
+		return err
+	}
+	if err := m.restore(ctx, k8sClient, a, opts); err != nil {
+		return err
+	}
+	m.logger.Infof("Restored %d ClusterPolicies, %d NVIDIADrivers and %d ConfigMaps",
+		len(a.ClusterPolicies), len(a.NVIDIADrivers), len(a.ConfigMaps))
+	return nil
+}
+
+// validateReferences checks that the ConfigMaps referenced by the archive are either part of the archive or exist
+// in the cluster, and that the referenced Secrets exist in the cluster
+func (m command) validateReferences(ctx context.Context, k8sClient client.Client, a *archive, opts *restoreOptions) error {
+	var errs []error
+	for _, ref := range a.references() {
+		if ref.kind == "ConfigMap" && a.hasConfigMap(ref.name) {
+			continue
+		}
+
+		var obj client.Object = &corev1.ConfigMap{}
+		if ref.kind == "Secret" {
+			obj = &corev1.Secret{}
+		}
+		err := k8sClient.Get(ctx, client.ObjectKey{Namespace: opts.namespace, Name: ref.name}, obj)
+		switch {
+		case err == nil:
+		case !apierrors.IsNotFound(err):
+			return fmt.Errorf("failed to get %s: %w", ref, err)
+		case ref.kind == "Secret" && opts.allowMissingSecrets:
+			m.logger.Warnf("Missing %s, it must be created for the operands to start", ref)
+		default:
+			errs = append(errs, fmt.Errorf("missing %s", ref))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid references in namespace %s: %w", opts.namespace, err)
+	}
+	return nil
+}
+
+// restore creates or updates the objects of the archive, the ConfigMaps first so that the operands referencing them
+// can start as soon as the ClusterPolicies and NVIDIADrivers are applied
+func (m command) restore(ctx context.Context, k8sClient client.Client, a *archive, opts *restoreOptions) error {
+	var objs []client.Object
+	for i := range a.ConfigMaps {
+		a.ConfigMaps[i].Namespace = opts.namespace
+		objs = append(objs, &a.ConfigMaps[i])
+	}
+	for i := range a.NVIDIADrivers {
+		objs = append(objs, &a.NVIDIADrivers[i])
+	}
+	for i := range a.ClusterPolicies {
+		objs = append(objs, &a.ClusterPolicies[i])
+	}
+
+	for _, obj := range objs {
+		if err := m.apply(ctx, k8sClient, obj, opts.dryRun); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply creates the object, or overwrites the existing object of the same name
+func (m command) apply(ctx context.Context, k8sClient client.Client, obj client.Object, dryRun bool) error {
+	kind := fmt.Sprintf("%T", obj)
+	if gvk, err := k8sClient.GroupVersionKindFor(obj); err == nil {
+		kind = gvk.Kind
+	}
+
+	var createOpts []client.CreateOption
+	var updateOpts []client.UpdateOption
+	if dryRun {
+		createOpts = append(createOpts, client.DryRunAll)
+		updateOpts = append(updateOpts, client.DryRunAll)
+	}
+
+	existing := obj.DeepCopyObject().(client.Object)
+	err := k8sClient.Get(ctx, client.ObjectKeyFromObject(obj), existing)
+	if apierrors.IsNotFound(err) {
+		m.logger.Infof("Creating %s %s", kind, obj.GetName())
+		if err := k8sClient.Create(ctx, obj, createOpts...); err != nil {
+			return fmt.Errorf("failed to create %s %s: %w", kind, obj.GetName(), err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get %s %s: %w", kind, obj.GetName(), err)
+	}
+
+	m.logger.Infof("Updating %s %s", kind, obj.GetName())
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	if err := k8sClient.Update(ctx, obj, updateOpts...); err != nil {
+		return fmt.Errorf("failed to update %s %s: %w", kind, obj.GetName(), err)
+	}
+	return nil
+}
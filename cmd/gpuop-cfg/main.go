@@ -23,6 +23,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	cli "github.com/urfave/cli/v3"
 
+	"github.com/NVIDIA/gpu-operator/cmd/gpuop-cfg/backup"
 	"github.com/NVIDIA/gpu-operator/cmd/gpuop-cfg/validate"
 )
 
@@ -66,6 +67,7 @@ func main() {
 	// Define the subcommands
 	c.Commands = []*cli.Command{
 		validate.NewCommand(logger),
+		backup.NewCommand(logger),
 	}
 
 	err := c.Run(context.Background(), os.Args)
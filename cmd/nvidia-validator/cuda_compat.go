@@ -0,0 +1,195 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+// cudaDriverCompatibility maps the CUDA runtime versions, newest first, to the minimum Linux driver version
+// supporting them, as documented in the CUDA Toolkit release notes
+var cudaDriverCompatibility = []struct {
+	cudaVersion      string
+	minDriverVersion string
+}{
+	{"13.0", "580.65.06"},
+	{"12.9", "575.51.03"},
+	{"12.8", "570.26"},
+	{"12.6", "560.28.03"},
+	{"12.5", "555.42.02"},
+	{"12.4", "550.54.14"},
+	{"12.3", "545.23.06"},
+	{"12.2", "535.54.03"},
+	{"12.1", "530.30.02"},
+	{"12.0", "525.60.13"},
+	{"11.8", "520.61.05"},
+	{"11.7", "515.43.04"},
+	{"11.6", "510.39.01"},
+	{"11.5", "495.29.05"},
+	{"11.4", "470.42.01"},
+	{"11.3", "465.19.01"},
+	{"11.2", "460.27.03"},
+	{"11.1", "455.23"},
+	{"11.0", "450.36.06"},
+}
+
+// parseVersion returns the numeric components of a dotted version, e.g. [580 65 6] for 580.65.06
+func parseVersion(version string) ([]int, error) {
+	var components []int
+	for _, field := range strings.Split(version, ".") {
+		component, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version %q", version)
+		}
+		components = append(components, component)
+	}
+	return components, nil
+}
+
+// compareVersions returns -1, 0 or 1 when a is older than, equal to or newer than b. Missing components
+// are zero, so that 570.26 equals 570.26.0.
+func compareVersions(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
+
+// maxCUDARuntimeVersion returns the newest CUDA runtime version supported by the given driver version, or
+// an empty string when the driver is older than all CUDA runtime versions known
+func maxCUDARuntimeVersion(driverVersion string) (string, error) {
+	version, err := parseVersion(driverVersion)
+	if err != nil {
+		return "", err
+	}
+	for _, entry := range cudaDriverCompatibility {
+		minVersion, err := parseVersion(entry.minDriverVersion)
+		if err != nil {
+			return "", err
+		}
+		if compareVersions(version, minVersion) >= 0 {
+			return entry.cudaVersion, nil
+		}
+	}
+	return "", nil
+}
+
+// parseDriverVersion returns the driver version from the output of 'nvidia-smi -q', e.g.
+//
+//	Driver Version                            : 580.65.06
+func parseDriverVersion(output string) (string, error) {
+	for _, line := range strings.Split(output, "\n") {
+		key, value, found := strings.Cut(line, ":")
+		if found && strings.TrimSpace(key) == "Driver Version" {
+			return strings.TrimSpace(value), nil
+		}
+	}
+	return "", fmt.Errorf("driver version not reported by nvidia-smi")
+}
+
+// getCUDACompatLabels returns the CUDA compatibility labels of a node running the given driver version. The
+// value of the labels which do not apply to the driver is nil, so that the labels are removed from the node.
+func getCUDACompatLabels(driverVersion string) (map[string]interface{}, error) {
+	cudaVersion, err := maxCUDARuntimeVersion(driverVersion)
+	if err != nil {
+		return nil, err
+	}
+	major, _, _ := strings.Cut(driverVersion, ".")
+	labels := map[string]interface{}{
+		consts.CUDADriverVersionMajorLabelKey:     major,
+		consts.CUDADriverVersionFullLabelKey:      driverVersion,
+		consts.CUDARuntimeVersionMaxLabelKey:      nil,
+		consts.CUDARuntimeVersionMaxMajorLabelKey: nil,
+		consts.CUDARuntimeVersionMaxMinorLabelKey: nil,
+	}
+	if cudaVersion != "" {
+		cudaMajor, cudaMinor, _ := strings.Cut(cudaVersion, ".")
+		labels[consts.CUDARuntimeVersionMaxLabelKey] = cudaVersion
+		labels[consts.CUDARuntimeVersionMaxMajorLabelKey] = cudaMajor
+		labels[consts.CUDARuntimeVersionMaxMinorLabelKey] = cudaMinor
+	}
+	return labels, nil
+}
+
+// labelCUDACompatibility labels the node with the version of its driver and the newest CUDA runtime version
+// supported by the driver, so that workloads requiring a newer CUDA runtime can be kept off the node
+func labelCUDACompatibility(ctx context.Context, kubeClient kubernetes.Interface, queryGPUs func() (string, error)) error {
+	output, err := queryGPUs()
+	if err != nil {
+		return err
+	}
+	driverVersion, err := parseDriverVersion(output)
+	if err != nil {
+		return err
+	}
+	labels, err := getCUDACompatLabels(driverVersion)
+	if err != nil {
+		return err
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": labels,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	err = patchNode(ctx, kubeClient, types.MergePatchType, patch)
+	if err != nil {
+		return fmt.Errorf("error labeling node %s with the CUDA compatibility of driver %s: %w", nodeNameFlag, driverVersion, err)
+	}
+	log.Infof("Labeled node %s with the CUDA compatibility of driver %s", nodeNameFlag, driverVersion)
+	return nil
+}
+
+// runCUDACompatLabeling labels the node with the CUDA compatibility of its driver, once the driver is ready
+func runCUDACompatLabeling(ctx context.Context) error {
+	kubeConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return fmt.Errorf("error getting cluster config: %w", err)
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return fmt.Errorf("error getting k8s client: %w", err)
+	}
+	return labelCUDACompatibility(ctx, kubeClient, queryNvidiaSMI)
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+func TestMaxCUDARuntimeVersion(t *testing.T) {
+	testCases := []struct {
+		driverVersion string
+		expected      string
+		expectError   bool
+	}{
+		{driverVersion: "580.65.06", expected: "13.0"},
+		{driverVersion: "575.57.08", expected: "12.9"},
+		{driverVersion: "570.26", expected: "12.8"},
+		{driverVersion: "570.25.99", expected: "12.6"},
+		{driverVersion: "535.230.02", expected: "12.2"},
+		{driverVersion: "450.36.06", expected: "11.0"},
+		{driverVersion: "418.226.00", expected: ""},
+		{driverVersion: "580.xx", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.driverVersion, func(t *testing.T) {
+			cudaVersion, err := maxCUDARuntimeVersion(tc.driverVersion)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, cudaVersion)
+		})
+	}
+}
+
+func TestParseDriverVersion(t *testing.T) {
+	output := `
+==============NVSMI LOG==============
+
+Timestamp                                 : Fri Oct 16 10:00:00 2026
+Driver Version                            : 535.230.02
+CUDA Version                              : 12.2
+`
+	driverVersion, err := parseDriverVersion(output)
+	require.NoError(t, err)
+	require.Equal(t, "535.230.02", driverVersion)
+
+	_, err = parseDriverVersion("No devices were found")
+	require.Error(t, err)
+}
+
+func TestLabelCUDACompatibility(t *testing.T) {
+	nodeNameFlag = "gpu-node"
+
+	testCases := []struct {
+		description    string
+		labels         map[string]string
+		driverVersion  string
+		expectedLabels map[string]string
+	}{
+		{
+			description:   "supported driver",
+			driverVersion: "535.230.02",
+			expectedLabels: map[string]string{
+				consts.CUDADriverVersionMajorLabelKey:     "535",
+				consts.CUDADriverVersionFullLabelKey:      "535.230.02",
+				consts.CUDARuntimeVersionMaxLabelKey:      "12.2",
+				consts.CUDARuntimeVersionMaxMajorLabelKey: "12",
+				consts.CUDARuntimeVersionMaxMinorLabelKey: "2",
+			},
+		},
+		{
+			description: "driver downgraded below all CUDA runtime versions",
+			labels: map[string]string{
+				consts.CUDADriverVersionMajorLabelKey:     "535",
+				consts.CUDADriverVersionFullLabelKey:      "535.230.02",
+				consts.CUDARuntimeVersionMaxLabelKey:      "12.2",
+				consts.CUDARuntimeVersionMaxMajorLabelKey: "12",
+				consts.CUDARuntimeVersionMaxMinorLabelKey: "2",
+				"other": "value",
+			},
+			driverVersion: "418.226.00",
+			expectedLabels: map[string]string{
+				consts.CUDADriverVersionMajorLabelKey: "418",
+				consts.CUDADriverVersionFullLabelKey:  "418.226.00",
+				"other":                               "value",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			node := &corev1.Node{ObjectMeta: meta_v1.ObjectMeta{Name: nodeNameFlag, Labels: tc.labels}}
+			clientset := fake.NewClientset(node)
+			queryGPUs := func() (string, error) {
+				return "Driver Version                            : " + tc.driverVersion + "\n", nil
+			}
+
+			err := labelCUDACompatibility(context.Background(), clientset, queryGPUs)
+			require.NoError(t, err)
+
+			updated, err := clientset.CoreV1().Nodes().Get(context.Background(), nodeNameFlag, meta_v1.GetOptions{})
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedLabels, updated.Labels)
+		})
+	}
+}
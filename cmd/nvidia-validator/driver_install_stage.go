@@ -0,0 +1,138 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+// driverInstallStages are the installation stages the driver container reports, in order
+var driverInstallStages = []string{"downloading", "building", "loading-modules", "done"}
+
+// driverInstallStageSync publishes the installation stage reported by the driver container as a node
+// annotation, so that the step a slow driver installation is at is visible from the node
+type driverInstallStageSync struct {
+	kubeClient kubernetes.Interface
+	// stageFile is the file the driver container reports its installation stage to
+	stageFile string
+	// published is the stage last published
+	published string
+}
+
+// readDriverInstallStage returns the installation stage reported by the driver container, or an empty string
+// when no stage, or an unknown stage, is reported
+func readDriverInstallStage(stageFile string) (string, error) {
+	data, err := os.ReadFile(stageFile)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error reading %s: %w", stageFile, err)
+	}
+	stage := strings.ToLower(strings.TrimSpace(string(data)))
+	for _, known := range driverInstallStages {
+		if stage == known {
+			return stage, nil
+		}
+	}
+	if stage != "" {
+		log.Warnf("Ignoring unknown driver installation stage %q", stage)
+	}
+	return "", nil
+}
+
+// sync annotates the node with the installation stage reported by the driver container, if it changed
+func (s *driverInstallStageSync) sync(ctx context.Context) error {
+	stage, err := readDriverInstallStage(s.stageFile)
+	if err != nil || stage == "" || stage == s.published {
+		return err
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{consts.DriverInstallStageAnnotationKey: stage},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if err := patchNode(ctx, s.kubeClient, types.MergePatchType, patch); err != nil {
+		return fmt.Errorf("error annotating node %s with the driver installation stage: %w", nodeNameFlag, err)
+	}
+	log.Infof("Driver installation stage of node %s: %s", nodeNameFlag, stage)
+	s.published = stage
+	return nil
+}
+
+// run publishes the installation stage every interval until the context is done, and once more before
+// returning so that the last stage reported while the driver was validated is not missed
+func (s *driverInstallStageSync) run(ctx context.Context, interval time.Duration) {
+	for {
+		if err := s.sync(ctx); err != nil {
+			log.Warnf("failed to sync the driver installation stage, retrying after %s: %v", interval, err)
+		}
+		select {
+		case <-ctx.Done():
+			syncCtx, cancel := context.WithTimeout(context.Background(), interval)
+			defer cancel()
+			if err := s.sync(syncCtx); err != nil {
+				log.Warnf("failed to sync the driver installation stage: %v", err)
+			}
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// startDriverInstallStageSync starts publishing the installation stage reported by the driver container while the
+// driver is validated, and returns a function stopping it once the last stage is published
+func startDriverInstallStageSync(ctx context.Context) (func(), error) {
+	kubeConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error getting cluster config: %w", err)
+	}
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error getting k8s client: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	s := &driverInstallStageSync{
+		kubeClient: kubeClient,
+		stageFile:  outputDirFlag + "/" + consts.DriverInstallStageFile,
+	}
+	go func() {
+		defer close(done)
+		s.run(ctx, time.Duration(sleepIntervalSecondsFlag)*time.Second)
+	}()
+	return func() {
+		cancel()
+		<-done
+	}, nil
+}
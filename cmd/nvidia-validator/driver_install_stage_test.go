@@ -0,0 +1,94 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+func TestReadDriverInstallStage(t *testing.T) {
+	testCases := []struct {
+		description string
+		content     *string
+		expected    string
+	}{
+		{description: "no stage reported", expected: ""},
+		{description: "stage reported", content: ptr.To("building\n"), expected: "building"},
+		{description: "stage reported in upper case", content: ptr.To("LOADING-MODULES"), expected: "loading-modules"},
+		{description: "unknown stage", content: ptr.To("compiling"), expected: ""},
+		{description: "empty file", content: ptr.To(""), expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			stageFile := filepath.Join(t.TempDir(), consts.DriverInstallStageFile)
+			if tc.content != nil {
+				require.NoError(t, os.WriteFile(stageFile, []byte(*tc.content), 0600))
+			}
+			stage, err := readDriverInstallStage(stageFile)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, stage)
+		})
+	}
+}
+
+func TestDriverInstallStageSync(t *testing.T) {
+	nodeNameFlag = "gpu-node"
+	defer func() { nodeNameFlag = "" }()
+
+	ctx := context.Background()
+	clientset := fake.NewClientset(&corev1.Node{ObjectMeta: meta_v1.ObjectMeta{Name: nodeNameFlag}})
+	stageFile := filepath.Join(t.TempDir(), consts.DriverInstallStageFile)
+	s := &driverInstallStageSync{kubeClient: clientset, stageFile: stageFile}
+
+	nodeStage := func() (string, bool) {
+		node, err := clientset.CoreV1().Nodes().Get(ctx, nodeNameFlag, meta_v1.GetOptions{})
+		require.NoError(t, err)
+		stage, ok := node.Annotations[consts.DriverInstallStageAnnotationKey]
+		return stage, ok
+	}
+
+	// the node is not annotated until the driver container reports a stage
+	require.NoError(t, s.sync(ctx))
+	_, ok := nodeStage()
+	require.False(t, ok)
+
+	require.NoError(t, os.WriteFile(stageFile, []byte("downloading"), 0600))
+	require.NoError(t, s.sync(ctx))
+	stage, _ := nodeStage()
+	require.Equal(t, "downloading", stage)
+
+	// the last stage reported is published when the sync stops
+	require.NoError(t, os.WriteFile(stageFile, []byte("done"), 0600))
+	stopCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	s.run(stopCtx, time.Second)
+	stage, _ = nodeStage()
+	require.Equal(t, "done", stage)
+}
@@ -0,0 +1,319 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+	"github.com/NVIDIA/gpu-operator/internal/driver"
+	"github.com/NVIDIA/gpu-operator/internal/utils"
+)
+
+const (
+	// nvlinkFabricStateReady is the value of the fabric state label of the nodes whose registration completed
+	nvlinkFabricStateReady = "ready"
+	// fabricStateCompleted is the fabric state reported by nvidia-smi once the GPU is registered
+	fabricStateCompleted = "Completed"
+	// fabricStatusSuccess is the fabric status reported by nvidia-smi for a successful registration
+	fabricStatusSuccess = "Success"
+	// fabricBandwidthFull is the fabric bandwidth reported by nvidia-smi when all NVLinks are available
+	fabricBandwidthFull = "Full"
+	// fabricNotApplicable is reported by nvidia-smi for GPUs not attached to an NVLink fabric
+	fabricNotApplicable = "N/A"
+)
+
+// NVLinkFabric represents spec to register the node in its multi-node NVLink fabric
+type NVLinkFabric struct {
+	ctx        context.Context
+	kubeClient kubernetes.Interface
+	// queryGPUs returns the output of 'nvidia-smi -q'
+	queryGPUs func() (string, error)
+}
+
+// gpuFabricInfo is the NVLink fabric registration of a GPU, as reported by nvidia-smi
+type gpuFabricInfo struct {
+	state     string
+	status    string
+	bandwidth string
+}
+
+// parseFabricInfo returns the NVLink fabric registration of every GPU from the output of 'nvidia-smi -q', e.g.
+//
+//	Fabric
+//	    State                             : Completed
+//	    Status                            : Success
+//	    CliqueId                          : 0
+//	    Health
+//	        Bandwidth                     : Full
+func parseFabricInfo(output string) []gpuFabricInfo {
+	var infos []gpuFabricInfo
+	var current *gpuFabricInfo
+	fabricIndent := 0
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		if trimmed == "Fabric" {
+			infos = append(infos, gpuFabricInfo{})
+			current = &infos[len(infos)-1]
+			fabricIndent = indent
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if indent <= fabricIndent {
+			// end of the fabric section
+			current = nil
+			continue
+		}
+		key, value, found := strings.Cut(trimmed, ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "State":
+			current.state = value
+		case "Status":
+			current.status = value
+		case "Bandwidth":
+			current.bandwidth = value
+		}
+	}
+	return infos
+}
+
+// checkFabricHealth returns an error unless the registration of every GPU attached to an NVLink fabric
+// completed successfully with the full bandwidth available. GPUs not attached to a fabric are ignored.
+func checkFabricHealth(infos []gpuFabricInfo) error {
+	for i, info := range infos {
+		if info.state == fabricNotApplicable || info.state == "" {
+			continue
+		}
+		if info.state != fabricStateCompleted {
+			return fmt.Errorf("fabric registration of GPU %d is not complete: state %s", i, info.state)
+		}
+		if info.status != fabricStatusSuccess {
+			return fmt.Errorf("fabric registration of GPU %d failed: status %s", i, info.status)
+		}
+		if info.bandwidth != "" && info.bandwidth != fabricNotApplicable && info.bandwidth != fabricBandwidthFull {
+			return fmt.Errorf("fabric of GPU %d is degraded: bandwidth %s", i, info.bandwidth)
+		}
+	}
+	return nil
+}
+
+// queryNvidiaSMI returns the output of 'nvidia-smi -q', run from the host when the driver is pre-installed
+// on the host, or from the driver container installation otherwise
+func queryNvidiaSMI() (string, error) {
+	var cmd *exec.Cmd
+	if nvidiaSMIPath, err := resolveHostNvidiaSMI("/host"); err == nil {
+		cmd = exec.Command("chroot", "/host", nvidiaSMIPath, "-q")
+	} else {
+		driverRoot := driver.Root(driverInstallDirCtrPathFlag)
+		driverLibraryPath, err := driverRoot.GetDriverLibraryPath()
+		if err != nil {
+			return "", fmt.Errorf("failed to locate driver libraries: %w", err)
+		}
+		nvidiaSMIPath, err := driverRoot.GetNvidiaSMIPath()
+		if err != nil {
+			return "", fmt.Errorf("failed to locate nvidia-smi: %w", err)
+		}
+		cmd = exec.Command(nvidiaSMIPath, "-q")
+		// In order for nvidia-smi to run, we need to update LD_PRELOAD to include the path to libnvidia-ml.so.1.
+		cmd.Env = utils.SetEnvVar(os.Environ(), "LD_PRELOAD", utils.PrependPathListEnvvar("LD_PRELOAD", driverLibraryPath))
+	}
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("error running nvidia-smi: %w", err)
+	}
+	return string(output), nil
+}
+
+func (f *NVLinkFabric) run() error {
+	kubeConfig, err := rest.InClusterConfig()
+	if err != nil {
+		log.Errorf("Error getting config cluster - %s\n", err.Error())
+		return err
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		log.Errorf("Error getting k8s client - %s\n", err.Error())
+		return err
+	}
+	f.kubeClient = kubeClient
+	f.queryGPUs = queryNvidiaSMI
+
+	if err := f.register(); err != nil {
+		return err
+	}
+	return f.watch(time.Duration(sleepIntervalSecondsFlag) * time.Second)
+}
+
+// register waits for the leaders of the NVLink fabric of the node, unless the node is a leader itself,
+// then waits for the fabric registration of all GPUs of the node to complete and reports the node ready
+func (f *NVLinkFabric) register() error {
+	statusFile := outputDirFlag + "/" + nvlinkFabricStatusFile
+	if err := deleteStatusFile(statusFile); err != nil {
+		return err
+	}
+	// the registration is started again, the members of the fabric must not rely on a previous one
+	if err := f.setFabricState(nil); err != nil {
+		return fmt.Errorf("error resetting the fabric state of node %s: %w", nodeNameFlag, err)
+	}
+
+	node, err := getNode(f.ctx, f.kubeClient)
+	if err != nil {
+		return err
+	}
+	domain := node.Labels[consts.NVLinkFabricDomainLabelKey]
+	if domain != "" && node.Labels[consts.NVLinkFabricLeaderLabelKey] != "true" {
+		if err := f.waitForLeaders(domain); err != nil {
+			return err
+		}
+	}
+
+	for {
+		err := f.checkHealth()
+		if err == nil {
+			break
+		}
+		if !withWaitFlag {
+			return err
+		}
+		log.Warnf("NVLink fabric of node %s is not ready, retrying after %d seconds: %v", nodeNameFlag, sleepIntervalSecondsFlag, err)
+		select {
+		case <-f.ctx.Done():
+			return f.ctx.Err()
+		case <-time.After(time.Duration(sleepIntervalSecondsFlag) * time.Second):
+		}
+	}
+
+	ready := nvlinkFabricStateReady
+	if err := f.setFabricState(&ready); err != nil {
+		return fmt.Errorf("error reporting the fabric state of node %s: %w", nodeNameFlag, err)
+	}
+	log.Infof("NVLink fabric registration of node %s is complete", nodeNameFlag)
+	return createStatusFile(statusFile)
+}
+
+// checkHealth returns an error unless the NVLink fabric registration of all GPUs of the node is complete
+func (f *NVLinkFabric) checkHealth() error {
+	output, err := f.queryGPUs()
+	if err != nil {
+		return err
+	}
+	return checkFabricHealth(parseFabricInfo(output))
+}
+
+// waitForLeaders waits for the registration of the leaders of the given NVLink fabric to complete. The
+// members of a fabric without leader do not wait.
+func (f *NVLinkFabric) waitForLeaders(domain string) error {
+	selector := labels.Set{
+		consts.NVLinkFabricDomainLabelKey: domain,
+		consts.NVLinkFabricLeaderLabelKey: "true",
+	}.AsSelector().String()
+	for {
+		leaders, err := f.kubeClient.CoreV1().Nodes().List(f.ctx, meta_v1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return fmt.Errorf("error listing the leaders of NVLink fabric %s: %w", domain, err)
+		}
+		if len(leaders.Items) == 0 {
+			log.Warnf("NVLink fabric %s has no leader, registering node %s", domain, nodeNameFlag)
+			return nil
+		}
+
+		var pending []string
+		for _, leader := range leaders.Items {
+			if leader.Labels[consts.NVLinkFabricStateLabelKey] != nvlinkFabricStateReady {
+				pending = append(pending, leader.Name)
+			}
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		if !withWaitFlag {
+			return fmt.Errorf("leaders of NVLink fabric %s are not registered: %s", domain, strings.Join(pending, ", "))
+		}
+		log.Infof("Waiting for the leaders of NVLink fabric %s to register: %s", domain, strings.Join(pending, ", "))
+		select {
+		case <-f.ctx.Done():
+			return f.ctx.Err()
+		case <-time.After(time.Duration(sleepIntervalSecondsFlag) * time.Second):
+		}
+	}
+}
+
+// watch creates the status file again when it is removed, e.g. when the operator validator restarts, as
+// long as the NVLink fabric of the node is healthy
+func (f *NVLinkFabric) watch(interval time.Duration) error {
+	statusFile := outputDirFlag + "/" + nvlinkFabricStatusFile
+	for {
+		select {
+		case <-f.ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+
+		if _, err := os.Stat(statusFile); err == nil {
+			continue
+		}
+		if err := f.checkHealth(); err != nil {
+			log.Warnf("NVLink fabric of node %s is not ready: %v", nodeNameFlag, err)
+			continue
+		}
+		if err := createStatusFile(statusFile); err != nil {
+			log.Warnf("failed to create status file %s: %v", statusFile, err)
+		}
+	}
+}
+
+// setFabricState sets the fabric state label of the node, or removes it when no state is given
+func (f *NVLinkFabric) setFabricState(state *string) error {
+	var value interface{}
+	if state != nil {
+		value = *state
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{consts.NVLinkFabricStateLabelKey: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	err = patchNode(f.ctx, f.kubeClient, types.MergePatchType, patch)
+	return err
+}
@@ -0,0 +1,185 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+const fabricCompletedOutput = `
+GPU 00000008:01:00.0
+    Product Name                          : NVIDIA GB200
+    Fabric
+        State                             : Completed
+        Status                            : Success
+        CliqueId                          : 32766
+        ClusterUUID                       : 6fe1a4a9-57e6-4f7e-a2cd-8c3c4e4d4e4d
+        Health
+            Bandwidth                     : Full
+    Processes                             : None
+`
+
+const fabricDegradedOutput = `
+GPU 00000008:01:00.0
+    Fabric
+        State                             : Completed
+        Status                            : Success
+        Health
+            Bandwidth                     : Degraded
+GPU 00000009:01:00.0
+    Fabric
+        State                             : In Progress
+        Status                            : N/A
+`
+
+func TestParseFabricInfo(t *testing.T) {
+	require.Equal(t, []gpuFabricInfo{{state: "Completed", status: "Success", bandwidth: "Full"}}, parseFabricInfo(fabricCompletedOutput))
+	require.Equal(t, []gpuFabricInfo{
+		{state: "Completed", status: "Success", bandwidth: "Degraded"},
+		{state: "In Progress", status: "N/A"},
+	}, parseFabricInfo(fabricDegradedOutput))
+	require.Empty(t, parseFabricInfo("GPU 00000000:3b:00.0\n    Product Name : NVIDIA A100\n"))
+}
+
+func TestCheckFabricHealth(t *testing.T) {
+	testCases := []struct {
+		description string
+		infos       []gpuFabricInfo
+		expectError bool
+	}{
+		{
+			description: "no fabric",
+		},
+		{
+			description: "GPU not attached to a fabric",
+			infos:       []gpuFabricInfo{{state: "N/A", status: "N/A"}},
+		},
+		{
+			description: "registration completed",
+			infos:       []gpuFabricInfo{{state: "Completed", status: "Success", bandwidth: "Full"}},
+		},
+		{
+			description: "registration in progress",
+			infos:       []gpuFabricInfo{{state: "In Progress", status: "N/A"}},
+			expectError: true,
+		},
+		{
+			description: "registration failed",
+			infos:       []gpuFabricInfo{{state: "Completed", status: "Failure"}},
+			expectError: true,
+		},
+		{
+			description: "degraded bandwidth",
+			infos:       []gpuFabricInfo{{state: "Completed", status: "Success", bandwidth: "Degraded"}},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := checkFabricHealth(tc.infos)
+			if tc.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNVLinkFabricRegister(t *testing.T) {
+	nodeNameFlag = "member"
+	withWaitFlag = false
+	defer func() { withWaitFlag = false }()
+
+	newNode := func(name string, labels map[string]string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: meta_v1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	member := map[string]string{consts.NVLinkFabricDomainLabelKey: "nvl72-a", consts.NVLinkFabricStateLabelKey: "ready"}
+	leader := map[string]string{consts.NVLinkFabricDomainLabelKey: "nvl72-a", consts.NVLinkFabricLeaderLabelKey: "true"}
+	readyLeader := map[string]string{consts.NVLinkFabricDomainLabelKey: "nvl72-a", consts.NVLinkFabricLeaderLabelKey: "true", consts.NVLinkFabricStateLabelKey: "ready"}
+
+	testCases := []struct {
+		description string
+		nodes       []*corev1.Node
+		output      string
+		expectError bool
+	}{
+		{
+			description: "leader registered",
+			nodes:       []*corev1.Node{newNode("member", member), newNode("leader", readyLeader)},
+			output:      fabricCompletedOutput,
+		},
+		{
+			description: "leader not registered",
+			nodes:       []*corev1.Node{newNode("member", member), newNode("leader", leader)},
+			output:      fabricCompletedOutput,
+			expectError: true,
+		},
+		{
+			description: "fabric without leader",
+			nodes:       []*corev1.Node{newNode("member", member)},
+			output:      fabricCompletedOutput,
+		},
+		{
+			description: "degraded fabric",
+			nodes:       []*corev1.Node{newNode("member", member), newNode("leader", readyLeader)},
+			output:      fabricDegradedOutput,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			outputDirFlag = t.TempDir()
+			clientset := fake.NewClientset()
+			for _, node := range tc.nodes {
+				_, err := clientset.CoreV1().Nodes().Create(context.Background(), node, meta_v1.CreateOptions{})
+				require.NoError(t, err)
+			}
+			f := &NVLinkFabric{
+				ctx:        context.Background(),
+				kubeClient: clientset,
+				queryGPUs:  func() (string, error) { return tc.output, nil },
+			}
+
+			err := f.register()
+			updated, getErr := clientset.CoreV1().Nodes().Get(context.Background(), nodeNameFlag, meta_v1.GetOptions{})
+			require.NoError(t, getErr)
+			_, statErr := os.Stat(filepath.Join(outputDirFlag, nvlinkFabricStatusFile))
+			if tc.expectError {
+				require.Error(t, err)
+				require.NotContains(t, updated.Labels, consts.NVLinkFabricStateLabelKey)
+				require.True(t, os.IsNotExist(statErr))
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, nvlinkFabricStateReady, updated.Labels[consts.NVLinkFabricStateLabelKey])
+				require.NoError(t, statErr)
+			}
+		})
+	}
+}
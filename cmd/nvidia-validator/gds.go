@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+// gdsDeviceClasses are the sysfs device classes of the storage and network devices GPUDirect Storage can
+// be used with
+var gdsDeviceClasses = []string{"nvme", "infiniband"}
+
+// GDSDetection represents spec to detect whether the node has hardware supported by GPUDirect Storage
+type GDSDetection struct {
+	ctx        context.Context
+	kubeClient kubernetes.Interface
+	// sysRoot is the mount point of sysfs
+	sysRoot string
+}
+
+// findGDSDeviceClass returns the first device class supported by GPUDirect Storage with devices on the node,
+// or an empty string when there is none
+func findGDSDeviceClass(sysRoot string) (string, error) {
+	for _, class := range gdsDeviceClasses {
+		entries, err := os.ReadDir(filepath.Join(sysRoot, "class", class))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("error listing %s devices: %w", class, err)
+		}
+		if len(entries) > 0 {
+			return class, nil
+		}
+	}
+	return "", nil
+}
+
+func (g *GDSDetection) run() error {
+	kubeConfig, err := rest.InClusterConfig()
+	if err != nil {
+		log.Errorf("Error getting config cluster - %s\n", err.Error())
+		return err
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		log.Errorf("Error getting k8s client - %s\n", err.Error())
+		return err
+	}
+	g.kubeClient = kubeClient
+	g.sysRoot = "/sys"
+
+	return g.detect()
+}
+
+// detect labels the node with whether it has hardware supported by GPUDirect Storage, and creates the
+// status file preventing the GPUDirect Storage driver from being loaded when it has none
+func (g *GDSDetection) detect() error {
+	class, err := findGDSDeviceClass(g.sysRoot)
+	if err != nil {
+		return err
+	}
+	capable := class != ""
+
+	// the detection runs before the validations of the driver, create the status directory if needed
+	if err := os.MkdirAll(outputDirFlag, 0755); err != nil {
+		return fmt.Errorf("error creating status directory %s: %w", outputDirFlag, err)
+	}
+	statusFile := filepath.Join(outputDirFlag, consts.GDSUnsupportedStatusFile)
+	if capable {
+		log.Infof("Found %s devices, GPUDirect Storage is supported on node %s", class, nodeNameFlag)
+		err = deleteStatusFile(statusFile)
+	} else {
+		log.Infof("No NVMe or InfiniBand device found, GPUDirect Storage is not supported on node %s", nodeNameFlag)
+		err = createStatusFile(statusFile)
+	}
+	if err != nil {
+		return err
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]string{consts.GDSCapableLabelKey: strconv.FormatBool(capable)},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	err = patchNode(g.ctx, g.kubeClient, types.MergePatchType, patch)
+	if err != nil {
+		return fmt.Errorf("error labeling node %s with the GPUDirect Storage capability: %w", nodeNameFlag, err)
+	}
+	return nil
+}
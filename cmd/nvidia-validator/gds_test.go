@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+func TestGDSDetection(t *testing.T) {
+	nodeNameFlag = "node"
+
+	testCases := []struct {
+		description string
+		devices     []string
+		expected    string
+	}{
+		{
+			description: "NVMe device",
+			devices:     []string{"nvme/nvme0"},
+			expected:    "true",
+		},
+		{
+			description: "InfiniBand device",
+			devices:     []string{"infiniband/mlx5_0"},
+			expected:    "true",
+		},
+		{
+			description: "no device",
+			devices:     []string{"net/eth0"},
+			expected:    "false",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			outputDirFlag = filepath.Join(t.TempDir(), "validations")
+			sysRoot := t.TempDir()
+			for _, device := range tc.devices {
+				require.NoError(t, os.MkdirAll(filepath.Join(sysRoot, "class", device), 0755))
+			}
+			clientset := fake.NewClientset(&corev1.Node{ObjectMeta: meta_v1.ObjectMeta{Name: nodeNameFlag}})
+			g := &GDSDetection{
+				ctx:        context.Background(),
+				kubeClient: clientset,
+				sysRoot:    sysRoot,
+			}
+
+			require.NoError(t, g.detect())
+			node, err := clientset.CoreV1().Nodes().Get(context.Background(), nodeNameFlag, meta_v1.GetOptions{})
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, node.Labels[consts.GDSCapableLabelKey])
+			_, statErr := os.Stat(filepath.Join(outputDirFlag, consts.GDSUnsupportedStatusFile))
+			require.Equal(t, tc.expected == "false", statErr == nil)
+		})
+	}
+}
@@ -0,0 +1,253 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+const (
+	// kmsgPath indicates the path of the kernel log device
+	kmsgPath = "/dev/kmsg"
+	// kmsgRecordMaxSize indicates the size of the buffer a kernel log record is read into
+	kmsgRecordMaxSize = 8192
+	// defaultCriticalXIDs indicates the XID errors marking a node unhealthy by default: uncorrectable
+	// memory errors (48, 64, 95), internal micro-controller halts (62), NVLink errors (74), GPUs fallen
+	// off the bus (79) and GSP errors (119, 120)
+	defaultCriticalXIDs = "48,62,64,74,79,95,119,120"
+)
+
+// xidPattern matches the XID errors logged by the NVIDIA kernel module, e.g.
+// "NVRM: Xid (PCI:0000:3b:00): 79, pid=1234, name=python, GPU has fallen off the bus."
+var xidPattern = regexp.MustCompile(`NVRM: Xid \((PCI:[0-9a-fA-F:.]+)\): (\d+),`)
+
+// HealthCheck represents spec to run the GPU health check
+type HealthCheck struct {
+	ctx          context.Context
+	kubeClient   kubernetes.Interface
+	criticalXIDs map[int]bool
+}
+
+// xidEvent is an XID error reported in the kernel log
+type xidEvent struct {
+	device string
+	xid    int
+}
+
+func (e xidEvent) String() string {
+	return fmt.Sprintf("Xid %d on %s", e.xid, e.device)
+}
+
+// recordReader returns the records added to a log since the last call
+type recordReader interface {
+	readRecords() ([]string, error)
+}
+
+// kmsgReader reads the records of the kernel log without blocking
+type kmsgReader struct {
+	fd  int
+	buf []byte
+}
+
+func openKmsg() (*kmsgReader, error) {
+	fd, err := syscall.Open(kmsgPath, syscall.O_RDONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, fmt.Errorf("error opening %s: %w", kmsgPath, err)
+	}
+	return &kmsgReader{fd: fd, buf: make([]byte, kmsgRecordMaxSize)}, nil
+}
+
+// readRecords returns the kernel log records added since the last call. Each read of the device returns
+// a single record, and the first call returns every record logged since the boot of the node.
+func (r *kmsgReader) readRecords() ([]string, error) {
+	var records []string
+	for {
+		n, err := syscall.Read(r.fd, r.buf)
+		switch err {
+		case nil:
+			records = append(records, string(r.buf[:n]))
+		case syscall.EAGAIN:
+			return records, nil
+		case syscall.EPIPE, syscall.EINTR:
+			// EPIPE reports records overwritten before being read, the next read returns the oldest record left
+			continue
+		default:
+			return records, err
+		}
+	}
+}
+
+func (r *kmsgReader) close() error {
+	return syscall.Close(r.fd)
+}
+
+// parseXIDEvent returns the XID error reported by a kernel log record, if any
+func parseXIDEvent(record string) (xidEvent, bool) {
+	matches := xidPattern.FindStringSubmatch(record)
+	if matches == nil {
+		return xidEvent{}, false
+	}
+	xid, err := strconv.Atoi(matches[2])
+	if err != nil {
+		return xidEvent{}, false
+	}
+	return xidEvent{device: matches[1], xid: xid}, true
+}
+
+// parseCriticalXIDs parses a comma separated list of XID errors
+func parseCriticalXIDs(value string) (map[int]bool, error) {
+	xids := make(map[int]bool)
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		xid, err := strconv.Atoi(field)
+		if err != nil || xid <= 0 {
+			return nil, fmt.Errorf("invalid XID %q", field)
+		}
+		xids[xid] = true
+	}
+	if len(xids) == 0 {
+		return nil, fmt.Errorf("no critical XID specified")
+	}
+	return xids, nil
+}
+
+func (h *HealthCheck) run() error {
+	criticalXIDs, err := parseCriticalXIDs(criticalXIDsFlag)
+	if err != nil {
+		return err
+	}
+	h.criticalXIDs = criticalXIDs
+
+	kubeConfig, err := rest.InClusterConfig()
+	if err != nil {
+		log.Errorf("Error getting config cluster - %s\n", err.Error())
+		return err
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		log.Errorf("Error getting k8s client - %s\n", err.Error())
+		return err
+	}
+	h.kubeClient = kubeClient
+
+	kmsg, err := openKmsg()
+	if err != nil {
+		return err
+	}
+	defer kmsg.close()
+
+	return h.watch(kmsg, time.Duration(sleepIntervalSecondsFlag)*time.Second)
+}
+
+// watch reads the kernel log until the context is done, and marks the node unhealthy once a critical
+// XID error is reported. The first read returns the records logged since the boot of the node, so a
+// node without critical XID error since its last reboot is marked healthy again.
+func (h *HealthCheck) watch(reader recordReader, interval time.Duration) error {
+	var unhealthy *xidEvent
+	synced := false
+	for {
+		records, err := reader.readRecords()
+		if err != nil {
+			return fmt.Errorf("error reading the kernel log: %w", err)
+		}
+		if event := h.findCriticalXID(records); event != nil && unhealthy == nil {
+			log.Warnf("Critical %s reported, marking node %s unhealthy", event, nodeNameFlag)
+			unhealthy = event
+			synced = false
+		}
+
+		if !synced {
+			if err := h.updateNodeHealth(unhealthy); err != nil {
+				log.Warnf("failed to update the health of node %s, retrying after %s: %v", nodeNameFlag, interval, err)
+			} else {
+				synced = true
+			}
+		}
+
+		select {
+		case <-h.ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// findCriticalXID returns the first critical XID error reported by the given records, if any
+func (h *HealthCheck) findCriticalXID(records []string) *xidEvent {
+	for _, record := range records {
+		event, ok := parseXIDEvent(record)
+		if !ok {
+			continue
+		}
+		log.Infof("%s reported", event)
+		if h.criticalXIDs[event.xid] {
+			return &event
+		}
+	}
+	return nil
+}
+
+// updateNodeHealth labels the node unhealthy with the given XID error as reason,
+// or removes the label and its reason when no XID error is given
+func (h *HealthCheck) updateNodeHealth(event *xidEvent) error {
+	var labels, annotations map[string]interface{}
+	if event != nil {
+		labels = map[string]interface{}{consts.GPUUnhealthyLabelKey: "true"}
+		annotations = map[string]interface{}{consts.GPUUnhealthyReasonAnnotationKey: event.String()}
+	} else {
+		node, err := getNode(h.ctx, h.kubeClient)
+		if err != nil {
+			return err
+		}
+		if _, ok := node.Labels[consts.GPUUnhealthyLabelKey]; !ok {
+			return nil
+		}
+		log.Infof("No critical XID error reported since the boot of node %s, marking it healthy", nodeNameFlag)
+		labels = map[string]interface{}{consts.GPUUnhealthyLabelKey: nil}
+		annotations = map[string]interface{}{consts.GPUUnhealthyReasonAnnotationKey: nil}
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels":      labels,
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	err = patchNode(h.ctx, h.kubeClient, types.MergePatchType, patch)
+	return err
+}
@@ -0,0 +1,152 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+// fakeRecordReader returns one batch of records per read, and cancels the context once all batches are read
+type fakeRecordReader struct {
+	batches [][]string
+	cancel  context.CancelFunc
+}
+
+func (r *fakeRecordReader) readRecords() ([]string, error) {
+	if len(r.batches) == 0 {
+		r.cancel()
+		return nil, nil
+	}
+	batch := r.batches[0]
+	r.batches = r.batches[1:]
+	return batch, nil
+}
+
+func TestParseXIDEvent(t *testing.T) {
+	testCases := []struct {
+		description string
+		record      string
+		expected    xidEvent
+		found       bool
+	}{
+		{
+			description: "XID error",
+			record:      "3,1234,5678901,-;NVRM: Xid (PCI:0000:3b:00): 79, pid=1234, name=python, GPU has fallen off the bus.\n",
+			expected:    xidEvent{device: "PCI:0000:3b:00", xid: 79},
+			found:       true,
+		},
+		{
+			description: "XID error without process",
+			record:      "4,42,100,-;NVRM: Xid (PCI:0000:af:00): 13, pid='<unknown>', name=<unknown>, Graphics Exception\n",
+			expected:    xidEvent{device: "PCI:0000:af:00", xid: 13},
+			found:       true,
+		},
+		{
+			description: "other record",
+			record:      "6,1235,5678902,-;NVRM: loading NVIDIA UNIX x86_64 Kernel Module  550.54.15\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			event, found := parseXIDEvent(tc.record)
+			require.Equal(t, tc.found, found)
+			require.Equal(t, tc.expected, event)
+		})
+	}
+}
+
+func TestParseCriticalXIDs(t *testing.T) {
+	xids, err := parseCriticalXIDs(defaultCriticalXIDs)
+	require.NoError(t, err)
+	require.True(t, xids[79])
+	require.False(t, xids[13])
+
+	xids, err = parseCriticalXIDs(" 13, 31 ,")
+	require.NoError(t, err)
+	require.Equal(t, map[int]bool{13: true, 31: true}, xids)
+
+	_, err = parseCriticalXIDs("13,abc")
+	require.Error(t, err)
+	_, err = parseCriticalXIDs("")
+	require.Error(t, err)
+}
+
+func TestHealthCheckWatch(t *testing.T) {
+	nodeNameFlag = "gpu-node"
+	criticalRecord := "3,10,100,-;NVRM: Xid (PCI:0000:3b:00): 79, pid=1234, name=python, GPU has fallen off the bus.\n"
+	otherRecord := "4,11,101,-;NVRM: Xid (PCI:0000:3b:00): 13, pid=1234, name=python, Graphics Exception\n"
+
+	testCases := []struct {
+		description       string
+		labels            map[string]string
+		batches           [][]string
+		expectedUnhealthy bool
+	}{
+		{
+			description: "healthy node",
+			batches:     [][]string{{otherRecord}},
+		},
+		{
+			description:       "critical XID since boot",
+			batches:           [][]string{{otherRecord, criticalRecord}},
+			expectedUnhealthy: true,
+		},
+		{
+			description:       "critical XID while running",
+			batches:           [][]string{{otherRecord}, {}, {criticalRecord}},
+			expectedUnhealthy: true,
+		},
+		{
+			description: "unhealthy node rebooted",
+			labels:      map[string]string{consts.GPUUnhealthyLabelKey: "true"},
+			batches:     [][]string{{otherRecord}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			node := &corev1.Node{ObjectMeta: meta_v1.ObjectMeta{Name: nodeNameFlag, Labels: tc.labels}}
+			clientset := fake.NewClientset(node)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			h := &HealthCheck{ctx: ctx, kubeClient: clientset, criticalXIDs: map[int]bool{79: true}}
+			err := h.watch(&fakeRecordReader{batches: tc.batches, cancel: cancel}, time.Millisecond)
+			require.NoError(t, err)
+
+			updated, err := clientset.CoreV1().Nodes().Get(context.Background(), nodeNameFlag, meta_v1.GetOptions{})
+			require.NoError(t, err)
+			if tc.expectedUnhealthy {
+				require.Equal(t, "true", updated.Labels[consts.GPUUnhealthyLabelKey])
+				require.Equal(t, "Xid 79 on PCI:0000:3b:00", updated.Annotations[consts.GPUUnhealthyReasonAnnotationKey])
+			} else {
+				require.NotContains(t, updated.Labels, consts.GPUUnhealthyLabelKey)
+				require.NotContains(t, updated.Annotations, consts.GPUUnhealthyReasonAnnotationKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// LicenseServer represents spec to check the reachability of the vGPU license server from the node. The check
+// runs in the license probe pod of the operator, which reports the outcome of the pod in the ClusterPolicy.
+type LicenseServer struct {
+	ctx context.Context
+}
+
+func (l *LicenseServer) validate() error {
+	return checkLicenseServer(l.ctx, licenseServerEndpointFlag, licenseServerTimeoutFlag)
+}
+
+// checkLicenseServer returns an error if no TCP connection can be opened to the license server endpoint
+// within the timeout
+func checkLicenseServer(ctx context.Context, endpoint string, timeout time.Duration) error {
+	if _, _, err := net.SplitHostPort(endpoint); err != nil {
+		return fmt.Errorf("invalid license server endpoint %q: %w", endpoint, err)
+	}
+	dialer := &net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", endpoint)
+	if err != nil {
+		return fmt.Errorf("license server %s unreachable: %w", endpoint, err)
+	}
+	_ = conn.Close()
+	log.Infof("License server %s reachable", endpoint)
+	return nil
+}
@@ -0,0 +1,39 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckLicenseServer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	endpoint := listener.Addr().String()
+
+	require.NoError(t, checkLicenseServer(context.Background(), endpoint, time.Second))
+
+	require.NoError(t, listener.Close())
+	require.ErrorContains(t, checkLicenseServer(context.Background(), endpoint, time.Second), "unreachable")
+
+	require.ErrorContains(t, checkLicenseServer(context.Background(), "license-server", time.Second), "invalid license server endpoint")
+}
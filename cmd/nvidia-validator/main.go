@@ -41,6 +41,7 @@ import (
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -48,9 +49,12 @@ import (
 
 	nvidiav1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
 	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
 	"github.com/NVIDIA/gpu-operator/internal/driver"
 	"github.com/NVIDIA/gpu-operator/internal/info"
+	"github.com/NVIDIA/gpu-operator/internal/nodepolicy"
 	"github.com/NVIDIA/gpu-operator/internal/utils"
+	gpulabels "github.com/NVIDIA/gpu-operator/pkg/labels"
 )
 
 // Component of GPU operator
@@ -87,7 +91,9 @@ type Plugin struct {
 }
 
 // Toolkit component
-type Toolkit struct{}
+type Toolkit struct {
+	ctx context.Context
+}
 
 // MOFED represents spec to validate MOFED driver installation
 type MOFED struct {
@@ -139,8 +145,50 @@ var (
 	driverInstallDirFlag            string
 	driverInstallDirCtrPathFlag     string
 	driverValidationSkipGPUInitFlag bool
+	runtimeFlag                     string
+	runtimeConfigFlag               string
+	runtimeDropInConfigFlag         string
+	runtimeDropInOnlyFlag           bool
+	crioSocketFlag                  string
+	runtimeHandlerFlag              string
+	runtimeSetAsDefaultFlag         bool
+	cdiEnabledFlag                  bool
+	skipValidationFlag              bool
+	validationTimeoutFlag           time.Duration
+	criticalXIDsFlag                string
+	nvlinkFabricEnabledFlag         bool
+	prePullDriverImageFlag          string
+	prePullToolkitImageFlag         string
+	cudaCompatLabelsEnabledFlag     bool
+	runtimeConfigBackupDirFlag      string
+	rollbackConfigFilesFlag         string
+	rollbackRuntimeSocketFlag       string
+	rollbackTimeoutFlag             time.Duration
+	licenseServerEndpointFlag       string
+	licenseServerTimeoutFlag        time.Duration
+	mockGPUCountFlag                int
+	mockGPUProductFlag              string
+	mockGPUMemoryFlag               int
+	nodeMutationPolicyFlag          string
 )
 
+// componentStatusFiles maps the components to the status file reporting their readiness
+var componentStatusFiles = map[string]string{
+	"driver":        driverStatusFile,
+	NVIDIAFS:        nvidiaFsStatusFile,
+	GDRCOPY:         gdrCopyStatusFile,
+	NVIDIAPEERMEM:   nvidiaPeermemStatusFile,
+	"toolkit":       toolkitStatusFile,
+	"cuda":          cudaStatusFile,
+	"plugin":        pluginStatusFile,
+	"mofed":         mofedStatusFile,
+	"vfio-pci":      vfioPCIStatusFile,
+	"vgpu-manager":  vGPUManagerStatusFile,
+	"vgpu-devices":  vGPUDevicesStatusFile,
+	"cc-manager":    ccManagerStatusFile,
+	"nvlink-fabric": nvlinkFabricStatusFile,
+}
+
 // defaultGPUWorkloadConfig is "vm-passthrough" unless
 // overridden by defaultGPUWorkloadConfigFlag
 var defaultGPUWorkloadConfig = gpuWorkloadConfigVMPassthrough
@@ -189,6 +237,10 @@ const (
 	vGPUDevicesStatusFile = "vgpu-devices-ready"
 	// ccManagerStatusFile indicates status file for cc-manager readiness
 	ccManagerStatusFile = "cc-manager-ready"
+	// nvlinkFabricStatusFile indicates status file for NVLink fabric registration readiness
+	nvlinkFabricStatusFile = "nvlink-fabric-ready"
+	// validationSkippedEnvName is set in the status file of a component whose validation was skipped
+	validationSkippedEnvName = "VALIDATION_SKIPPED"
 	// workloadTypeStatusFile is the name of the file which specifies the workload type configured for the node
 	workloadTypeStatusFile = "workload-type"
 	// podCreationWaitRetries indicates total retries to wait for plugin validation pod creation
@@ -227,13 +279,15 @@ const (
 	GPUDirectRDMAEnabledEnvName = "GPU_DIRECT_RDMA_ENABLED"
 	// UseHostMOFEDEnvname represents env name to indicate if MOFED is pre-installed on host
 	UseHostMOFEDEnvname = "USE_HOST_MOFED"
-	// TODO: create a common package to share these variables between operator and validator
-	gpuWorkloadConfigLabelKey      = "nvidia.com/gpu.workload.config"
-	gpuWorkloadConfigContainer     = "container"
-	gpuWorkloadConfigVMPassthrough = "vm-passthrough"
-	gpuWorkloadConfigVMVgpu        = "vm-vgpu"
+	// workload config labels of the GPU nodes, shared with the operator
+	gpuWorkloadConfigLabelKey      = gpulabels.WorkloadConfig
+	gpuWorkloadConfigContainer     = gpulabels.WorkloadConfigContainer
+	gpuWorkloadConfigVMPassthrough = gpulabels.WorkloadConfigVMPassthrough
+	gpuWorkloadConfigVMVgpu        = gpulabels.WorkloadConfigVMVgpu
 	// CCCapableLabelKey represents NFD label name to indicate if the node is capable to run CC workloads
 	CCCapableLabelKey = "nvidia.com/cc.capable"
+	// ccManagerDeployLabelKey indicates the label key set by the operator on nodes where cc-manager is deployed
+	ccManagerDeployLabelKey = gpulabels.DeployCCManager
 	// appComponentLabelKey indicates the label key of the component
 	appComponentLabelKey = "app.kubernetes.io/component"
 	// wslNvidiaSMIPath indicates the path to the nvidia-smi binary on WSL
@@ -395,6 +449,181 @@ func main() {
 			Destination: &driverValidationSkipGPUInitFlag,
 			Sources:     cli.EnvVars("DRIVER_VALIDATION_SKIP_GPU_INIT"),
 		},
+		&cli.StringFlag{
+			Name:        "runtime",
+			Value:       "",
+			Usage:       "the container runtime configured by the toolkit. enables the verification of the runtime configuration when set",
+			Destination: &runtimeFlag,
+			Sources:     cli.EnvVars("RUNTIME"),
+		},
+		&cli.StringFlag{
+			Name:        "runtime-config",
+			Value:       "",
+			Usage:       "the path on the host of the top-level container runtime config file",
+			Destination: &runtimeConfigFlag,
+			Sources:     cli.EnvVars("RUNTIME_CONFIG"),
+		},
+		&cli.StringFlag{
+			Name:        "runtime-drop-in-config",
+			Value:       "",
+			Usage:       "the path on the host of the container runtime drop-in config file",
+			Destination: &runtimeDropInConfigFlag,
+			Sources:     cli.EnvVars("RUNTIME_DROP_IN_CONFIG"),
+		},
+		&cli.BoolFlag{
+			Name:        "runtime-drop-in-only",
+			Value:       false,
+			Usage:       "indicates whether the NVIDIA runtime handler is expected in the drop-in config file only, and loaded by cri-o",
+			Destination: &runtimeDropInOnlyFlag,
+			Sources:     cli.EnvVars("RUNTIME_DROP_IN_ONLY"),
+		},
+		&cli.StringFlag{
+			Name:        "crio-socket",
+			Value:       defaultCRIOSocket,
+			Usage:       "the path on the host of the cri-o socket, used to read the configuration loaded by cri-o",
+			Destination: &crioSocketFlag,
+			Sources:     cli.EnvVars("CRIO_SOCKET"),
+		},
+		&cli.StringFlag{
+			Name:        "runtime-handler",
+			Value:       "nvidia",
+			Usage:       "the name of the NVIDIA runtime handler expected in the container runtime config",
+			Destination: &runtimeHandlerFlag,
+			Sources:     cli.EnvVars("NVIDIA_RUNTIME_HANDLER"),
+		},
+		&cli.BoolFlag{
+			Name:        "runtime-set-as-default",
+			Value:       false,
+			Usage:       "indicates whether the NVIDIA runtime handler is expected to be the default runtime",
+			Destination: &runtimeSetAsDefaultFlag,
+			Sources:     cli.EnvVars("NVIDIA_RUNTIME_SET_AS_DEFAULT"),
+		},
+		&cli.BoolFlag{
+			Name:        "cdi-enabled",
+			Value:       false,
+			Usage:       "indicates whether CDI is expected to be enabled in the container runtime config",
+			Destination: &cdiEnabledFlag,
+			Sources:     cli.EnvVars("CDI_ENABLED"),
+		},
+		&cli.BoolFlag{
+			Name:        "skip-validation",
+			Value:       false,
+			Usage:       "indicates to skip the validation of the component and report it as ready",
+			Destination: &skipValidationFlag,
+			Sources:     cli.EnvVars("SKIP_VALIDATION"),
+		},
+		&cli.DurationFlag{
+			Name:        "validation-timeout",
+			Value:       0,
+			Usage:       "the maximum duration of the validation of the component, no timeout when zero",
+			Destination: &validationTimeoutFlag,
+			Sources:     cli.EnvVars("VALIDATION_TIMEOUT"),
+		},
+		&cli.StringFlag{
+			Name:        "critical-xids",
+			Value:       defaultCriticalXIDs,
+			Usage:       "comma separated list of the XID errors marking the node unhealthy, used by the health-check component",
+			Destination: &criticalXIDsFlag,
+			Sources:     cli.EnvVars("CRITICAL_XIDS"),
+		},
+		&cli.BoolFlag{
+			Name:        "nvlink-fabric-enabled",
+			Value:       false,
+			Usage:       "indicates the driver is only ready once the NVLink fabric registration of the node is complete",
+			Destination: &nvlinkFabricEnabledFlag,
+			Sources:     cli.EnvVars("NVLINK_FABRIC_ENABLED"),
+		},
+		&cli.StringFlag{
+			Name:        "prepull-driver-image",
+			Value:       "",
+			Usage:       "the driver image pulled by the image-prepull component, recorded on the node",
+			Destination: &prePullDriverImageFlag,
+			Sources:     cli.EnvVars("PREPULL_DRIVER_IMAGE"),
+		},
+		&cli.StringFlag{
+			Name:        "prepull-toolkit-image",
+			Value:       "",
+			Usage:       "the toolkit image pulled by the image-prepull component, recorded on the node",
+			Destination: &prePullToolkitImageFlag,
+			Sources:     cli.EnvVars("PREPULL_TOOLKIT_IMAGE"),
+		},
+		&cli.BoolFlag{
+			Name:        "cuda-compat-labels-enabled",
+			Value:       false,
+			Usage:       "indicates the node is labeled with the CUDA compatibility of its driver once the driver is ready",
+			Destination: &cudaCompatLabelsEnabledFlag,
+			Sources:     cli.EnvVars("CUDA_COMPAT_LABELS_ENABLED"),
+		},
+		&cli.StringFlag{
+			Name:        "runtime-config-backup-dir",
+			Value:       "",
+			Usage:       "the directory the toolkit backs up the runtime configuration files to, used by the runtime-rollback component",
+			Destination: &runtimeConfigBackupDirFlag,
+			Sources:     cli.EnvVars("RUNTIME_CONFIG_BACKUP_DIR"),
+		},
+		&cli.StringFlag{
+			Name:        "rollback-config-files",
+			Value:       "",
+			Usage:       "comma separated list of the runtime configuration files restored by the runtime-rollback component",
+			Destination: &rollbackConfigFilesFlag,
+			Sources:     cli.EnvVars("ROLLBACK_CONFIG_FILES"),
+		},
+		&cli.StringFlag{
+			Name:        "rollback-runtime-socket",
+			Value:       "",
+			Usage:       "the containerd socket checked by the runtime-rollback component",
+			Destination: &rollbackRuntimeSocketFlag,
+			Sources:     cli.EnvVars("ROLLBACK_RUNTIME_SOCKET"),
+		},
+		&cli.DurationFlag{
+			Name:        "rollback-timeout",
+			Value:       2 * time.Minute,
+			Usage:       "the time containerd is given to become healthy before the runtime-rollback component restores its configuration",
+			Destination: &rollbackTimeoutFlag,
+			Sources:     cli.EnvVars("ROLLBACK_TIMEOUT"),
+		},
+		&cli.StringFlag{
+			Name:        "license-server-endpoint",
+			Value:       "",
+			Usage:       "the host:port of the vGPU license server checked by the license-server component",
+			Destination: &licenseServerEndpointFlag,
+			Sources:     cli.EnvVars("LICENSE_SERVER_ENDPOINT"),
+		},
+		&cli.DurationFlag{
+			Name:        "license-server-timeout",
+			Value:       10 * time.Second,
+			Usage:       "the time the license-server component waits for a connection to the license server",
+			Destination: &licenseServerTimeoutFlag,
+			Sources:     cli.EnvVars("LICENSE_SERVER_TIMEOUT"),
+		},
+		&cli.IntFlag{
+			Name:        "mock-gpu-count",
+			Value:       1,
+			Usage:       "the number of mock GPUs advertised on the node by the mock-gpus component",
+			Destination: &mockGPUCountFlag,
+			Sources:     cli.EnvVars("MOCK_GPU_COUNT"),
+		},
+		&cli.StringFlag{
+			Name:        "mock-gpu-product",
+			Value:       "NVIDIA-Mock-GPU",
+			Usage:       "the product of the mock GPUs advertised on the node by the mock-gpus component",
+			Destination: &mockGPUProductFlag,
+			Sources:     cli.EnvVars("MOCK_GPU_PRODUCT"),
+		},
+		&cli.IntFlag{
+			Name:        "mock-gpu-memory",
+			Value:       16384,
+			Usage:       "the memory in MiB of the mock GPUs advertised on the node by the mock-gpus component",
+			Destination: &mockGPUMemoryFlag,
+			Sources:     cli.EnvVars("MOCK_GPU_MEMORY"),
+		},
+		&cli.StringFlag{
+			Name:        "node-mutation-policy",
+			Value:       "",
+			Usage:       "the set of node fields the validator is allowed to mutate: labels-and-taints, labels-only or none",
+			Destination: &nodeMutationPolicyFlag,
+			Sources:     cli.EnvVars(nodepolicy.EnvName),
+		},
 	}
 
 	// Log version info
@@ -448,9 +677,41 @@ func validateFlags(ctx context.Context, cli *cli.Command) (context.Context, erro
 			return ctx, fmt.Errorf("invalid -n <node-name> flag: must not be empty string for metrics exporter")
 		}
 	}
+	if componentFlag == "health-check" && nodeNameFlag == "" {
+		return ctx, fmt.Errorf("invalid -n <node-name> flag: must not be empty string for the health-check component")
+	}
+	if componentFlag == "nvlink-fabric" && nodeNameFlag == "" {
+		return ctx, fmt.Errorf("invalid -n <node-name> flag: must not be empty string for nvlink-fabric registration")
+	}
+	if componentFlag == "image-prepull" && nodeNameFlag == "" {
+		return ctx, fmt.Errorf("invalid -n <node-name> flag: must not be empty string for the image-prepull component")
+	}
+	if componentFlag == "runtime-rollback" && (runtimeConfigBackupDirFlag == "" || rollbackRuntimeSocketFlag == "") {
+		return ctx, fmt.Errorf("invalid runtime-rollback configuration: the backup directory and the runtime socket must be set")
+	}
+	if componentFlag == "runtime-rollback" && nodeNameFlag == "" {
+		return ctx, fmt.Errorf("invalid -n <node-name> flag: must not be empty string for the runtime-rollback component")
+	}
+	if componentFlag == "license-server" && licenseServerEndpointFlag == "" {
+		return ctx, fmt.Errorf("invalid license-server-endpoint flag: must not be empty string for the license-server component")
+	}
+	if componentFlag == "gds-detection" && nodeNameFlag == "" {
+		return ctx, fmt.Errorf("invalid -n <node-name> flag: must not be empty string for GPUDirect Storage hardware detection")
+	}
+	if componentFlag == "rdma-topology" && nodeNameFlag == "" {
+		return ctx, fmt.Errorf("invalid -n <node-name> flag: must not be empty string for RDMA topology discovery")
+	}
+	if componentFlag == "mock-gpus" && nodeNameFlag == "" {
+		return ctx, fmt.Errorf("invalid -n <node-name> flag: must not be empty string for the mock-gpus component")
+	}
 	if nodeNameFlag == "" && (componentFlag == "vfio-pci" || componentFlag == "vgpu-manager" || componentFlag == "vgpu-devices") {
 		return ctx, fmt.Errorf("invalid -n <node-name> flag: must not be empty string for %s validation", componentFlag)
 	}
+	if nodeMutationPolicyFlag != "" {
+		if _, err := nodepolicy.Parse(nodeMutationPolicyFlag); err != nil {
+			return ctx, fmt.Errorf("invalid node-mutation-policy flag: %w", err)
+		}
+	}
 
 	return ctx, nil
 }
@@ -465,6 +726,22 @@ func isValidComponent() bool {
 		fallthrough
 	case "metrics":
 		fallthrough
+	case "health-check":
+		fallthrough
+	case "nvlink-fabric":
+		fallthrough
+	case "image-prepull":
+		fallthrough
+	case "gds-detection":
+		fallthrough
+	case "rdma-topology":
+		fallthrough
+	case "runtime-rollback":
+		fallthrough
+	case "license-server":
+		fallthrough
+	case "mock-gpus":
+		fallthrough
 	case "plugin":
 		fallthrough
 	case "mofed":
@@ -489,9 +766,7 @@ func isValidComponent() bool {
 }
 
 func isValidWorkloadConfig(config string) bool {
-	return config == gpuWorkloadConfigContainer ||
-		config == gpuWorkloadConfigVMPassthrough ||
-		config == gpuWorkloadConfigVMVgpu
+	return gpulabels.IsValidWorkloadConfig(config)
 }
 
 func getWorkloadConfig(ctx context.Context) (string, error) {
@@ -546,9 +821,45 @@ func start(ctx context.Context, cli *cli.Command) error {
 		return err
 	}
 
+	if skipValidationFlag {
+		return skipComponentValidation(componentFlag)
+	}
+	if validationTimeoutFlag > 0 {
+		return validateComponentWithTimeout(ctx, componentFlag, validationTimeoutFlag)
+	}
 	return validateComponent(ctx, componentFlag)
 }
 
+// skipComponentValidation reports the component as ready without validating it, so that the
+// components waiting for it can proceed. The status file records that the validation was skipped.
+func skipComponentValidation(component string) error {
+	statusFile, ok := componentStatusFiles[component]
+	if !ok {
+		return fmt.Errorf("validation of component %s cannot be skipped", component)
+	}
+	log.Warnf("Skipping the validation of component %s as requested", component)
+	return utils.WriteFileAtomically(filepath.Join(outputDirFlag, statusFile), validationSkippedEnvName+"=true\n")
+}
+
+// validateComponentWithTimeout fails the validation of the component when it does not complete
+// within the timeout. The validation is retried once the container is restarted.
+func validateComponentWithTimeout(ctx context.Context, component string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result := make(chan error, 1)
+	go func() {
+		result <- validateComponent(ctx, component)
+	}()
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("validation of component %s did not complete within %s", component, timeout)
+	}
+}
+
 func validateComponent(ctx context.Context, componentFlag string) error {
 	switch componentFlag {
 	case "driver":
@@ -582,7 +893,9 @@ func validateComponent(ctx context.Context, componentFlag string) error {
 		}
 		return nil
 	case "toolkit":
-		toolkit := &Toolkit{}
+		toolkit := &Toolkit{
+			ctx: ctx,
+		}
 		err := toolkit.validate()
 		if err != nil {
 			return fmt.Errorf("error validating toolkit installation: %w", err)
@@ -624,6 +937,78 @@ func validateComponent(ctx context.Context, componentFlag string) error {
 			return fmt.Errorf("error running validation-metrics exporter: %s", err)
 		}
 		return nil
+	case "health-check":
+		healthCheck := &HealthCheck{
+			ctx: ctx,
+		}
+		err := healthCheck.run()
+		if err != nil {
+			return fmt.Errorf("error running GPU health check: %w", err)
+		}
+		return nil
+	case "nvlink-fabric":
+		nvlinkFabric := &NVLinkFabric{
+			ctx: ctx,
+		}
+		err := nvlinkFabric.run()
+		if err != nil {
+			return fmt.Errorf("error registering the NVLink fabric: %w", err)
+		}
+		return nil
+	case "image-prepull":
+		imagePrePull := &ImagePrePull{
+			ctx: ctx,
+		}
+		err := imagePrePull.run()
+		if err != nil {
+			return fmt.Errorf("error recording the pre-pulled images: %w", err)
+		}
+		return nil
+	case "gds-detection":
+		gdsDetection := &GDSDetection{
+			ctx: ctx,
+		}
+		err := gdsDetection.run()
+		if err != nil {
+			return fmt.Errorf("error detecting GPUDirect Storage hardware: %w", err)
+		}
+		return nil
+	case "rdma-topology":
+		rdmaTopology := &RDMATopology{
+			ctx: ctx,
+		}
+		err := rdmaTopology.run()
+		if err != nil {
+			return fmt.Errorf("error discovering the RDMA topology: %w", err)
+		}
+		return nil
+	case "runtime-rollback":
+		runtimeRollback := &RuntimeRollback{
+			ctx: ctx,
+		}
+		err := runtimeRollback.run()
+		if err != nil {
+			return fmt.Errorf("error watching the containerd restart: %w", err)
+		}
+		return nil
+	case "license-server":
+		licenseServer := &LicenseServer{
+			ctx: ctx,
+		}
+		err := licenseServer.validate()
+		if err != nil {
+			return fmt.Errorf("error checking the vGPU license server: %w", err)
+		}
+		return nil
+	case "mock-gpus":
+		mockGPUs := &MockGPUs{
+			ctx: ctx,
+		}
+		err := mockGPUs.run()
+		if err != nil {
+			return fmt.Errorf("error advertising the mock GPUs: %w", err)
+		}
+		return nil
 	case "vfio-pci":
 		vfioPCI := &VfioPCI{
 			ctx: ctx,
@@ -858,6 +1243,14 @@ func (d *Driver) runValidation(silent bool) (driverInfo, error) {
 		return driverInfo{}, fmt.Errorf("error checking if driver is managed by GPU Operator: %w", err)
 	}
 
+	if driverManagedByOperator && withWaitFlag && nodeNameFlag != "" {
+		stopStageSync, err := startDriverInstallStageSync(d.ctx)
+		if err != nil {
+			return driverInfo{}, err
+		}
+		defer stopStageSync()
+	}
+
 	err = validateDriverContainer(silent, driverManagedByOperator)
 	if err != nil {
 		return driverInfo{}, err
@@ -903,6 +1296,13 @@ func validateAdditionalDriverComponents(ctx context.Context, statusFilePath stri
 			continue
 		}
 
+		if component == NVIDIAFS {
+			if _, err := os.Stat(outputDirFlag + "/" + consts.GDSUnsupportedStatusFile); err == nil {
+				log.Infof("GPUDirect Storage is not supported on this node, skipping %s validation", component)
+				continue
+			}
+		}
+
 		log.Infof("Validating additional driver component: %s", component)
 		if err := validateComponent(ctx, component); err != nil {
 			return err
@@ -944,9 +1344,47 @@ func (d *Driver) validate() error {
 		return fmt.Errorf("%w\n\n%s", err, msg)
 	}
 
+	if nvlinkFabricEnabledFlag {
+		err = waitForNVLinkFabric(d.ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cudaCompatLabelsEnabledFlag && nodeNameFlag != "" {
+		err = runCUDACompatLabeling(d.ctx)
+		if err != nil {
+			return fmt.Errorf("error labeling the CUDA compatibility of the driver: %w", err)
+		}
+	}
+
 	return d.createStatusFile(driverInfo)
 }
 
+// waitForNVLinkFabric waits for the NVLink fabric registration of the node to complete, so that the
+// driver is not reported ready with a degraded NVLink fabric
+func waitForNVLinkFabric(ctx context.Context) error {
+	statusFile := outputDirFlag + "/" + nvlinkFabricStatusFile
+	for {
+		_, err := os.Stat(statusFile)
+		if err == nil {
+			return nil
+		}
+		if !os.IsNotExist(err) {
+			return err
+		}
+		if !withWaitFlag {
+			return fmt.Errorf("NVLink fabric registration is not complete")
+		}
+		log.Infof("Waiting for the NVLink fabric registration, retrying after %d seconds", sleepIntervalSecondsFlag)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(sleepIntervalSecondsFlag) * time.Second):
+		}
+	}
+}
+
 func (d *Driver) createStatusFile(driverInfo driverInfo) error {
 	statusFileContent := strings.Join([]string{
 		fmt.Sprintf("IS_HOST_DRIVER=%t", driverInfo.isHostDriver),
@@ -1161,6 +1599,12 @@ func (t *Toolkit) validate() error {
 		return err
 	}
 
+	// verify the container runtime configuration applied by the toolkit
+	err = t.verifyRuntimeConfig()
+	if err != nil {
+		log.Warnf("unable to verify the container runtime configuration: %v", err)
+	}
+
 	// create toolkit status file
 	err = createStatusFile(outputDirFlag + "/" + toolkitStatusFile)
 	if err != nil {
@@ -1518,6 +1962,28 @@ func getNode(ctx context.Context, kubeClient kubernetes.Interface) (*corev1.Node
 	return node, nil
 }
 
+// patchNode patches the node of the validator, restricted to the fields allowed by the node mutation policy. The
+// patches of the subresources of the node, e.g. its status, are skipped unless the policy allows all mutations.
+func patchNode(ctx context.Context, kubeClient kubernetes.Interface, patchType types.PatchType, data []byte, subresources ...string) error {
+	policy := nodepolicy.Policy(nodeMutationPolicyFlag)
+	if len(subresources) > 0 && !policy.AllowsSpec() {
+		log.Infof("Skipping the %s patch of node %s forbidden by the %s node mutation policy", strings.Join(subresources, "/"), nodeNameFlag, policy)
+		return nil
+	}
+	allowed, dropped, err := nodepolicy.RestrictPatch(policy, data)
+	if err != nil {
+		return err
+	}
+	if len(dropped) > 0 {
+		log.Infof("Dropped the fields %v of the patch of node %s forbidden by the %s node mutation policy", dropped, nodeNameFlag, policy)
+	}
+	if allowed == nil {
+		return nil
+	}
+	_, err = kubeClient.CoreV1().Nodes().Patch(ctx, nodeNameFlag, patchType, allowed, meta_v1.PatchOptions{}, subresources...)
+	return err
+}
+
 func (c *CUDA) validate() error {
 	// delete status file is already present
 	err := deleteStatusFile(outputDirFlag + "/" + cudaStatusFile)
@@ -1865,6 +2331,12 @@ func (c *CCManager) runValidation(silent bool) error {
 		return nil
 	}
 
+	// cc-manager is not deployed to nodes without a CC-capable CPU when restricted to CC-capable nodes
+	if nodeLabels[ccManagerDeployLabelKey] != "true" {
+		log.Info("CC Manager is not deployed on the node, skipping CC Manager validation")
+		return nil
+	}
+
 	// check if the ccManager container is ready
 	err = assertCCManagerContainerReady(silent, withWaitFlag)
 	if err != nil {
@@ -376,3 +376,17 @@ UNKNOWN_FEATURE: true`,
 		})
 	}
 }
+
+func Test_skipComponentValidation(t *testing.T) {
+	outputDir := t.TempDir()
+	origOutputDir := outputDirFlag
+	outputDirFlag = outputDir
+	t.Cleanup(func() { outputDirFlag = origOutputDir })
+
+	require.NoError(t, skipComponentValidation("toolkit"))
+	content, err := os.ReadFile(filepath.Join(outputDir, toolkitStatusFile))
+	require.NoError(t, err)
+	require.Equal(t, "VALIDATION_SKIPPED=true\n", string(content))
+
+	require.Error(t, skipComponentValidation("metrics"))
+}
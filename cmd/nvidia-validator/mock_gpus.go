@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	gpulabels "github.com/NVIDIA/gpu-operator/pkg/labels"
+)
+
+// MockGPUs represents spec to advertise mock GPUs on a node without GPU. The GPUs are advertised as the
+// nvidia.com/gpu extended resource of the node, along with the labels GPU Feature Discovery sets on GPU
+// nodes, so that GPU pods can be scheduled onto the node. The node is labeled nvidia.com/gpu.mock=true.
+type MockGPUs struct {
+	ctx        context.Context
+	kubeClient kubernetes.Interface
+}
+
+func (m *MockGPUs) run() error {
+	kubeConfig, err := rest.InClusterConfig()
+	if err != nil {
+		log.Errorf("Error getting config cluster - %s\n", err.Error())
+		return err
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		log.Errorf("Error getting k8s client - %s\n", err.Error())
+		return err
+	}
+	m.kubeClient = kubeClient
+
+	for {
+		if err := m.advertise(); err != nil {
+			return err
+		}
+		if !withWaitFlag {
+			return nil
+		}
+		// advertise the GPUs again, in case the labels or the capacity of the node were reset
+		select {
+		case <-m.ctx.Done():
+			return nil
+		case <-time.After(time.Duration(sleepIntervalSecondsFlag) * time.Second):
+		}
+	}
+}
+
+// advertise sets the mock GPU labels of the node, and the mock GPUs in the capacity of the node
+func (m *MockGPUs) advertise() error {
+	if mockGPUCountFlag < 1 {
+		return fmt.Errorf("invalid mock GPU count %d: must be at least 1", mockGPUCountFlag)
+	}
+	count := strconv.Itoa(mockGPUCountFlag)
+
+	labelPatch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]string{
+				gpulabels.GPUMock:    "true",
+				gpulabels.GPUProduct: mockGPUProductFlag,
+				gpulabels.GPUCount:   count,
+				gpulabels.GPUMemory:  strconv.Itoa(mockGPUMemoryFlag),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	err = patchNode(m.ctx, m.kubeClient, types.MergePatchType, labelPatch)
+	if err != nil {
+		return fmt.Errorf("error labeling node %s with the mock GPUs: %w", nodeNameFlag, err)
+	}
+
+	statusPatch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"capacity":    map[string]string{genericGPUResourceType: count},
+			"allocatable": map[string]string{genericGPUResourceType: count},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	err = patchNode(m.ctx, m.kubeClient, types.StrategicMergePatchType, statusPatch, "status")
+	if err != nil {
+		return fmt.Errorf("error advertising the mock GPUs of node %s: %w", nodeNameFlag, err)
+	}
+	log.Infof("Advertising %d mock %s GPUs on node %s", mockGPUCountFlag, mockGPUProductFlag, nodeNameFlag)
+	return nil
+}
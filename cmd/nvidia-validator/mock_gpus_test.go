@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	gpulabels "github.com/NVIDIA/gpu-operator/pkg/labels"
+)
+
+func TestMockGPUsAdvertise(t *testing.T) {
+	nodeNameFlag = "kind-worker"
+	mockGPUCountFlag = 4
+	mockGPUProductFlag = "NVIDIA-Mock-GPU"
+	mockGPUMemoryFlag = 16384
+	defer func() {
+		mockGPUCountFlag = 1
+	}()
+
+	node := &corev1.Node{ObjectMeta: meta_v1.ObjectMeta{Name: nodeNameFlag, Labels: map[string]string{"other": "value"}}}
+	clientset := fake.NewClientset(node)
+
+	m := &MockGPUs{ctx: context.Background(), kubeClient: clientset}
+	require.NoError(t, m.advertise())
+
+	updated, err := clientset.CoreV1().Nodes().Get(context.Background(), nodeNameFlag, meta_v1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"other":              "value",
+		gpulabels.GPUMock:    "true",
+		gpulabels.GPUProduct: "NVIDIA-Mock-GPU",
+		gpulabels.GPUCount:   "4",
+		gpulabels.GPUMemory:  "16384",
+	}, updated.Labels)
+	require.True(t, resource.MustParse("4").Equal(updated.Status.Capacity[genericGPUResourceType]))
+	require.True(t, resource.MustParse("4").Equal(updated.Status.Allocatable[genericGPUResourceType]))
+
+	mockGPUCountFlag = 0
+	require.Error(t, m.advertise())
+}
+
+func TestMockGPUsAdvertiseNodeMutationPolicy(t *testing.T) {
+	nodeNameFlag = "kind-worker"
+	mockGPUCountFlag = 2
+	defer func() {
+		mockGPUCountFlag = 1
+		nodeMutationPolicyFlag = ""
+	}()
+
+	// the labels are set under the labels-only policy, but the mock GPUs are not advertised in the node status
+	nodeMutationPolicyFlag = "labels-only"
+	clientset := fake.NewClientset(&corev1.Node{ObjectMeta: meta_v1.ObjectMeta{Name: nodeNameFlag}})
+	m := &MockGPUs{ctx: context.Background(), kubeClient: clientset}
+	require.NoError(t, m.advertise())
+
+	updated, err := clientset.CoreV1().Nodes().Get(context.Background(), nodeNameFlag, meta_v1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "2", updated.Labels[gpulabels.GPUCount])
+	require.Empty(t, updated.Status.Capacity)
+
+	// the node is left untouched under the none policy
+	nodeMutationPolicyFlag = "none"
+	clientset = fake.NewClientset(&corev1.Node{ObjectMeta: meta_v1.ObjectMeta{Name: nodeNameFlag}})
+	m = &MockGPUs{ctx: context.Background(), kubeClient: clientset}
+	require.NoError(t, m.advertise())
+
+	updated, err = clientset.CoreV1().Nodes().Get(context.Background(), nodeNameFlag, meta_v1.GetOptions{})
+	require.NoError(t, err)
+	require.Empty(t, updated.Labels)
+	require.Empty(t, updated.Status.Capacity)
+	for _, action := range clientset.Actions() {
+		require.NotEqual(t, "patch", action.GetVerb())
+	}
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+// ImagePrePull represents spec to record the images pre-pulled onto the node. The images are pulled by the
+// init containers of the image pre-pull pod, which only starts this component once all of them completed.
+type ImagePrePull struct {
+	ctx        context.Context
+	kubeClient kubernetes.Interface
+}
+
+func (p *ImagePrePull) run() error {
+	kubeConfig, err := rest.InClusterConfig()
+	if err != nil {
+		log.Errorf("Error getting config cluster - %s\n", err.Error())
+		return err
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		log.Errorf("Error getting k8s client - %s\n", err.Error())
+		return err
+	}
+	p.kubeClient = kubeClient
+
+	if err := p.record(); err != nil {
+		return err
+	}
+
+	// keep running, the images would be pulled again by a new pod otherwise
+	<-p.ctx.Done()
+	return nil
+}
+
+// record sets the pre-pulled image annotations of the node, and removes the annotations of the images
+// which are no longer pre-pulled
+func (p *ImagePrePull) record() error {
+	annotations := map[string]interface{}{
+		consts.PrePulledDriverImageAnnotationKey:  nil,
+		consts.PrePulledToolkitImageAnnotationKey: nil,
+	}
+	if prePullDriverImageFlag != "" {
+		annotations[consts.PrePulledDriverImageAnnotationKey] = prePullDriverImageFlag
+	}
+	if prePullToolkitImageFlag != "" {
+		annotations[consts.PrePulledToolkitImageAnnotationKey] = prePullToolkitImageFlag
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	err = patchNode(p.ctx, p.kubeClient, types.MergePatchType, patch)
+	if err != nil {
+		return fmt.Errorf("error annotating node %s with the pre-pulled images: %w", nodeNameFlag, err)
+	}
+	log.Infof("Images pre-pulled onto node %s: driver %q, toolkit %q", nodeNameFlag, prePullDriverImageFlag, prePullToolkitImageFlag)
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+func TestImagePrePullRecord(t *testing.T) {
+	nodeNameFlag = "gpu-node"
+	defer func() {
+		prePullDriverImageFlag = ""
+		prePullToolkitImageFlag = ""
+	}()
+
+	testCases := []struct {
+		description         string
+		annotations         map[string]string
+		driverImage         string
+		toolkitImage        string
+		expectedAnnotations map[string]string
+	}{
+		{
+			description:  "driver and toolkit images",
+			driverImage:  "nvcr.io/nvidia/driver:580.65.06-ubuntu24.04",
+			toolkitImage: "nvcr.io/nvidia/k8s/container-toolkit:v1.18.0",
+			expectedAnnotations: map[string]string{
+				consts.PrePulledDriverImageAnnotationKey:  "nvcr.io/nvidia/driver:580.65.06-ubuntu24.04",
+				consts.PrePulledToolkitImageAnnotationKey: "nvcr.io/nvidia/k8s/container-toolkit:v1.18.0",
+			},
+		},
+		{
+			description: "driver image no longer pre-pulled",
+			annotations: map[string]string{
+				consts.PrePulledDriverImageAnnotationKey:  "nvcr.io/nvidia/driver:570.172.08-ubuntu24.04",
+				consts.PrePulledToolkitImageAnnotationKey: "nvcr.io/nvidia/k8s/container-toolkit:v1.17.8",
+				"other": "value",
+			},
+			toolkitImage: "nvcr.io/nvidia/k8s/container-toolkit:v1.18.0",
+			expectedAnnotations: map[string]string{
+				consts.PrePulledToolkitImageAnnotationKey: "nvcr.io/nvidia/k8s/container-toolkit:v1.18.0",
+				"other": "value",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			prePullDriverImageFlag = tc.driverImage
+			prePullToolkitImageFlag = tc.toolkitImage
+			node := &corev1.Node{ObjectMeta: meta_v1.ObjectMeta{Name: nodeNameFlag, Annotations: tc.annotations}}
+			clientset := fake.NewClientset(node)
+
+			p := &ImagePrePull{ctx: context.Background(), kubeClient: clientset}
+			require.NoError(t, p.record())
+
+			updated, err := clientset.CoreV1().Nodes().Get(context.Background(), nodeNameFlag, meta_v1.GetOptions{})
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedAnnotations, updated.Annotations)
+		})
+	}
+}
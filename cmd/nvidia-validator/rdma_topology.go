@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+	"github.com/NVIDIA/gpu-operator/internal/rdma"
+)
+
+// RDMATopology represents spec to pair the GPUs of the node with their closest RDMA NIC
+type RDMATopology struct {
+	ctx        context.Context
+	kubeClient kubernetes.Interface
+	// sysRoot is the mount point of sysfs
+	sysRoot string
+}
+
+func (r *RDMATopology) run() error {
+	kubeConfig, err := rest.InClusterConfig()
+	if err != nil {
+		log.Errorf("Error getting config cluster - %s\n", err.Error())
+		return err
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		log.Errorf("Error getting k8s client - %s\n", err.Error())
+		return err
+	}
+	r.kubeClient = kubeClient
+	r.sysRoot = "/sys"
+
+	return r.discover()
+}
+
+// discover pairs the GPUs of the node with their closest RDMA NIC, and publishes the pairs in the RDMA topology
+// annotation of the node along with a label per GPU holding its RDMA device, for topology-aware scheduling.
+// The labels of the GPUs no longer paired are removed.
+func (r *RDMATopology) discover() error {
+	pairs, err := rdma.Discover(r.sysRoot)
+	if err != nil {
+		return fmt.Errorf("error reading the PCIe topology: %w", err)
+	}
+	node, err := getNode(r.ctx, r.kubeClient)
+	if err != nil {
+		return err
+	}
+
+	nodeLabels := map[string]interface{}{}
+	for key := range node.Labels {
+		if rdma.IsNICLabelKey(key) {
+			nodeLabels[key] = nil
+		}
+	}
+	var topology interface{}
+	if len(pairs) > 0 {
+		for i, pair := range pairs {
+			log.Infof("GPU %d (%s) of node %s is paired with RDMA device %s (%s)", i, pair.GPU, nodeNameFlag, pair.RDMADevice, pair.NIC)
+			nodeLabels[rdma.NICLabelKey(i)] = pair.RDMADevice
+		}
+		value, err := json.Marshal(pairs)
+		if err != nil {
+			return err
+		}
+		topology = string(value)
+	} else {
+		log.Infof("No RDMA NIC found on node %s", nodeNameFlag)
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels":      nodeLabels,
+			"annotations": map[string]interface{}{consts.GPURDMATopologyAnnotationKey: topology},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	err = patchNode(r.ctx, r.kubeClient, types.MergePatchType, patch)
+	if err != nil {
+		return fmt.Errorf("error publishing the RDMA topology of node %s: %w", nodeNameFlag, err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+// addFakePCIDevice adds a PCI device with the given sysfs attributes and subdirectories to the fake sysfs
+func addFakePCIDevice(t *testing.T, sysRoot string, path string, attributes map[string]string, subdirs ...string) {
+	dir := filepath.Join(sysRoot, "devices", path)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	for name, value := range attributes {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(value), 0644))
+	}
+	for _, subdir := range subdirs {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, subdir), 0755))
+	}
+	busDir := filepath.Join(sysRoot, "bus", "pci", "devices")
+	require.NoError(t, os.MkdirAll(busDir, 0755))
+	require.NoError(t, os.Symlink(dir, filepath.Join(busDir, filepath.Base(path))))
+}
+
+func TestRDMATopologyDiscover(t *testing.T) {
+	nodeNameFlag = "node"
+	sysRoot := t.TempDir()
+	addFakePCIDevice(t, sysRoot, "pci0000:00/0000:00:01.0/0000:01:00.0/0000:02:00.0",
+		map[string]string{"vendor": "0x10de", "class": "0x030200"})
+	addFakePCIDevice(t, sysRoot, "pci0000:00/0000:00:01.0/0000:01:00.0/0000:03:00.0",
+		map[string]string{"vendor": "0x15b3", "class": "0x020700"}, "infiniband/mlx5_0", "net/ens1f0")
+
+	// the labels of the GPUs previously paired are removed
+	clientset := fake.NewClientset(&corev1.Node{ObjectMeta: meta_v1.ObjectMeta{
+		Name:   nodeNameFlag,
+		Labels: map[string]string{"nvidia.com/gpu-0.rdma-nic": "mlx5_4", "nvidia.com/gpu-1.rdma-nic": "mlx5_5", "nvidia.com/gpu.present": "true"},
+	}})
+	r := &RDMATopology{ctx: context.Background(), kubeClient: clientset, sysRoot: sysRoot}
+	require.NoError(t, r.discover())
+
+	node, err := clientset.CoreV1().Nodes().Get(context.Background(), nodeNameFlag, meta_v1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"nvidia.com/gpu-0.rdma-nic": "mlx5_0", "nvidia.com/gpu.present": "true"}, node.Labels)
+	require.JSONEq(t, `[{"gpu":"0000:02:00.0","nic":"0000:03:00.0","rdmaDevice":"mlx5_0","netDevice":"ens1f0"}]`,
+		node.Annotations[consts.GPURDMATopologyAnnotationKey])
+
+	// the topology is removed once the NIC is gone
+	require.NoError(t, os.Remove(filepath.Join(sysRoot, "bus", "pci", "devices", "0000:03:00.0")))
+	require.NoError(t, r.discover())
+	node, err = clientset.CoreV1().Nodes().Get(context.Background(), nodeNameFlag, meta_v1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"nvidia.com/gpu.present": "true"}, node.Labels)
+	require.NotContains(t, node.Annotations, consts.GPURDMATopologyAnnotationKey)
+}
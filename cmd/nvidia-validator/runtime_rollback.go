@@ -0,0 +1,256 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+const (
+	// runtimeConfigBackupCompleteFile is created in the backup directory by the toolkit entrypoint once the
+	// runtime configuration files are backed up
+	runtimeConfigBackupCompleteFile = "complete"
+	// runtimeConfigBackupAbsentSuffix is the suffix of the backup of a runtime configuration file which did
+	// not exist before the toolkit configured the runtime
+	runtimeConfigBackupAbsentSuffix = ".absent"
+
+	runtimeRestartFailedReason = "RuntimeRestartFailed"
+	runtimeHealthyReason       = "RuntimeHealthy"
+)
+
+// RuntimeRollback represents spec to restore the containerd configuration backed up by the toolkit when
+// containerd does not come back healthy after the toolkit restarted it
+type RuntimeRollback struct {
+	ctx        context.Context
+	kubeClient kubernetes.Interface
+	backupDir  string
+	// configFiles are the runtime configuration files updated by the toolkit
+	configFiles []string
+	timeout     time.Duration
+	// isHealthy returns true when containerd serves its socket
+	isHealthy func() bool
+	// restartRuntime restarts containerd
+	restartRuntime func() error
+
+	unhealthySince time.Time
+	reported       bool
+	rolledBack     bool
+}
+
+// backupFileName returns the name of the backup of a runtime configuration file, matching the name given
+// by the toolkit entrypoint
+func backupFileName(file string) string {
+	return strings.ReplaceAll(file, "/", "_")
+}
+
+func (r *RuntimeRollback) run() error {
+	kubeConfig, err := rest.InClusterConfig()
+	if err != nil {
+		log.Errorf("Error getting config cluster - %s\n", err.Error())
+		return err
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		log.Errorf("Error getting k8s client - %s\n", err.Error())
+		return err
+	}
+	r.kubeClient = kubeClient
+	r.backupDir = runtimeConfigBackupDirFlag
+	r.timeout = rollbackTimeoutFlag
+	for _, file := range strings.Split(rollbackConfigFilesFlag, ",") {
+		if file != "" {
+			r.configFiles = append(r.configFiles, file)
+		}
+	}
+	r.isHealthy = func() bool {
+		conn, err := net.DialTimeout("unix", rollbackRuntimeSocketFlag, 5*time.Second)
+		if err != nil {
+			return false
+		}
+		conn.Close()
+		return true
+	}
+	r.restartRuntime = func() error {
+		output, err := exec.Command("chroot", "/host", "systemctl", "restart", "containerd").CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("error restarting containerd: %w: %s", err, output)
+		}
+		return nil
+	}
+
+	interval := time.Duration(sleepIntervalSecondsFlag) * time.Second
+	for {
+		if err := r.check(time.Now()); err != nil {
+			log.Warnf("Failed to check the health of containerd on node %s: %v", nodeNameFlag, err)
+		}
+		select {
+		case <-r.ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// check restores the backed up runtime configuration when containerd has been unhealthy for longer than
+// the timeout since the toolkit updated its configuration. The configuration is restored at most once.
+func (r *RuntimeRollback) check(now time.Time) error {
+	if r.rolledBack {
+		return nil
+	}
+	if _, err := os.Stat(filepath.Join(r.backupDir, runtimeConfigBackupCompleteFile)); err != nil {
+		log.Infof("Waiting for the toolkit to back up the runtime configuration")
+		return nil
+	}
+
+	if r.isHealthy() {
+		r.unhealthySince = time.Time{}
+		if r.reported {
+			return nil
+		}
+		changed, err := r.configChanged()
+		if err != nil || !changed {
+			return err
+		}
+		// the runtime came back healthy with the configuration of the toolkit
+		if err := r.setCondition(corev1.ConditionFalse, runtimeHealthyReason, "containerd is healthy with the configuration applied by the NVIDIA Container Toolkit"); err != nil {
+			return err
+		}
+		r.reported = true
+		return nil
+	}
+
+	if r.unhealthySince.IsZero() {
+		log.Warnf("containerd is not healthy on node %s", nodeNameFlag)
+		r.unhealthySince = now
+	}
+	if now.Sub(r.unhealthySince) < r.timeout {
+		return nil
+	}
+	changed, err := r.configChanged()
+	if err != nil {
+		return err
+	}
+	if !changed {
+		log.Warnf("containerd is not healthy on node %s, but its configuration was not updated by the toolkit, skipping the rollback", nodeNameFlag)
+		return nil
+	}
+
+	log.Warnf("containerd is not healthy %s after its restart on node %s, restoring its configuration", r.timeout, nodeNameFlag)
+	if err := r.restore(); err != nil {
+		return err
+	}
+	if err := r.restartRuntime(); err != nil {
+		return err
+	}
+	r.rolledBack = true
+	message := fmt.Sprintf("containerd was not healthy %s after the NVIDIA Container Toolkit restarted it, its previous configuration was restored", r.timeout)
+	return r.setCondition(corev1.ConditionTrue, runtimeRestartFailedReason, message)
+}
+
+// configChanged returns true if any runtime configuration file differs from its backup
+func (r *RuntimeRollback) configChanged() (bool, error) {
+	for _, file := range r.configFiles {
+		backup := filepath.Join(r.backupDir, backupFileName(file))
+		current, err := os.ReadFile(file)
+		if err != nil && !os.IsNotExist(err) {
+			return false, fmt.Errorf("error reading %s: %w", file, err)
+		}
+		exists := err == nil
+
+		if _, err := os.Stat(backup + runtimeConfigBackupAbsentSuffix); err == nil {
+			if exists {
+				return true, nil
+			}
+			continue
+		}
+		previous, err := os.ReadFile(backup)
+		if err != nil {
+			return false, fmt.Errorf("error reading the backup of %s: %w", file, err)
+		}
+		if !exists || !bytes.Equal(current, previous) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// restore restores the runtime configuration files from their backup, and removes the files which did not
+// exist before the toolkit configured the runtime
+func (r *RuntimeRollback) restore() error {
+	for _, file := range r.configFiles {
+		backup := filepath.Join(r.backupDir, backupFileName(file))
+		if _, err := os.Stat(backup + runtimeConfigBackupAbsentSuffix); err == nil {
+			if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("error removing %s: %w", file, err)
+			}
+			continue
+		}
+		content, err := os.ReadFile(backup)
+		if err != nil {
+			return fmt.Errorf("error reading the backup of %s: %w", file, err)
+		}
+		if err := os.WriteFile(file, content, 0644); err != nil {
+			return fmt.Errorf("error restoring %s: %w", file, err)
+		}
+		log.Infof("Restored %s", file)
+	}
+	return nil
+}
+
+// setCondition reports on the node whether the runtime configuration was rolled back
+func (r *RuntimeRollback) setCondition(status corev1.ConditionStatus, reason string, message string) error {
+	now := meta_v1.Now()
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []corev1.NodeCondition{{
+				Type:               consts.RuntimeConfigRolledBackConditionType,
+				Status:             status,
+				Reason:             reason,
+				Message:            message,
+				LastHeartbeatTime:  now,
+				LastTransitionTime: now,
+			}},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	err = patchNode(r.ctx, r.kubeClient, types.StrategicMergePatchType, patch, "status")
+	if err != nil {
+		return fmt.Errorf("error setting condition %s on node %s: %w", consts.RuntimeConfigRolledBackConditionType, nodeNameFlag, err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+func TestRuntimeRollbackCheck(t *testing.T) {
+	nodeNameFlag = "node"
+
+	testCases := []struct {
+		description       string
+		healthy           bool
+		configChanged     bool
+		expectRollback    bool
+		expectedCondition corev1.ConditionStatus
+	}{
+		{
+			description:       "containerd healthy with the toolkit configuration",
+			healthy:           true,
+			configChanged:     true,
+			expectedCondition: corev1.ConditionFalse,
+		},
+		{
+			description:       "containerd not healthy after the toolkit configuration",
+			configChanged:     true,
+			expectRollback:    true,
+			expectedCondition: corev1.ConditionTrue,
+		},
+		{
+			description: "containerd not healthy before the toolkit configuration",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			configDir := t.TempDir()
+			backupDir := t.TempDir()
+			config := filepath.Join(configDir, "config.toml")
+			dropIn := filepath.Join(configDir, "99-nvidia.toml")
+
+			// the backup taken by the toolkit entrypoint, before the drop-in file existed
+			require.NoError(t, os.WriteFile(config, []byte("version = 2\n"), 0644))
+			require.NoError(t, os.WriteFile(filepath.Join(backupDir, backupFileName(config)), []byte("version = 2\n"), 0644))
+			require.NoError(t, os.WriteFile(filepath.Join(backupDir, backupFileName(dropIn)+runtimeConfigBackupAbsentSuffix), nil, 0644))
+			require.NoError(t, os.WriteFile(filepath.Join(backupDir, runtimeConfigBackupCompleteFile), nil, 0644))
+			if tc.configChanged {
+				require.NoError(t, os.WriteFile(config, []byte("version = 2\nimports = [\"conf.d/*.toml\"]\n"), 0644))
+				require.NoError(t, os.WriteFile(dropIn, []byte("[plugins]\n"), 0644))
+			}
+
+			clientset := fake.NewClientset(&corev1.Node{ObjectMeta: meta_v1.ObjectMeta{Name: nodeNameFlag}})
+			restarted := false
+			r := &RuntimeRollback{
+				ctx:            context.Background(),
+				kubeClient:     clientset,
+				backupDir:      backupDir,
+				configFiles:    []string{config, dropIn},
+				timeout:        time.Minute,
+				isHealthy:      func() bool { return tc.healthy },
+				restartRuntime: func() error { restarted = true; return nil },
+			}
+
+			start := time.Now()
+			require.NoError(t, r.check(start))
+			require.False(t, restarted)
+			require.NoError(t, r.check(start.Add(2*time.Minute)))
+			require.Equal(t, tc.expectRollback, restarted)
+
+			if tc.expectRollback {
+				content, err := os.ReadFile(config)
+				require.NoError(t, err)
+				require.Equal(t, "version = 2\n", string(content))
+				_, err = os.Stat(dropIn)
+				require.True(t, os.IsNotExist(err))
+			}
+
+			node, err := clientset.CoreV1().Nodes().Get(context.Background(), nodeNameFlag, meta_v1.GetOptions{})
+			require.NoError(t, err)
+			var status corev1.ConditionStatus
+			for _, condition := range node.Status.Conditions {
+				if condition.Type == consts.RuntimeConfigRolledBackConditionType {
+					status = condition.Status
+				}
+			}
+			require.Equal(t, tc.expectedCondition, status)
+		})
+	}
+}
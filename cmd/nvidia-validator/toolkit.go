@@ -0,0 +1,378 @@
+/*
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/NVIDIA/gpu-operator/internal/toml"
+)
+
+const (
+	// toolkitRuntimeConfigLabelKey is the node label reporting the result of the runtime configuration verification
+	toolkitRuntimeConfigLabelKey = "nvidia.com/gpu.toolkit.runtime-config"
+	// toolkitRuntimeConfigErrorsAnnotationKey is the node annotation listing the failed runtime configuration checks
+	toolkitRuntimeConfigErrorsAnnotationKey = "nvidia.com/gpu.toolkit.runtime-config-errors"
+	runtimeConfigValid                      = "valid"
+	runtimeConfigInvalid                    = "invalid"
+	// defaultCRIOSocket is the default path on the host of the cri-o socket
+	defaultCRIOSocket = "/run/crio/crio.sock"
+)
+
+// runtimeConfigExpectations is the container runtime configuration the toolkit was requested to apply
+type runtimeConfigExpectations struct {
+	runtime         string
+	configFiles     []string
+	handler         string
+	setAsDefault    bool
+	cdiEnabled      bool
+	checkCDIEnabled bool
+	// dropInConfigFile is set when the runtime handler is expected in this drop-in file only
+	dropInConfigFile string
+	// socket is the runtime socket serving the configuration loaded by the runtime, checked along the drop-in file
+	socket string
+}
+
+// runtimeConfig is the subset of the container runtime configuration relevant to the toolkit
+type runtimeConfig struct {
+	handlers       map[string]bool
+	defaultRuntime string
+	// cdiEnabled is nil when the configuration does not set it, and the runtime default applies
+	cdiEnabled *bool
+}
+
+// getRuntimeConfigExpectations returns the expected runtime configuration, or nil when the
+// operator did not request a verification of the runtime configuration (e.g. in NRI plugin mode
+// where the toolkit does not modify the runtime configuration)
+func getRuntimeConfigExpectations() *runtimeConfigExpectations {
+	if runtimeFlag == "" {
+		return nil
+	}
+	expected := &runtimeConfigExpectations{
+		runtime:      runtimeFlag,
+		handler:      runtimeHandlerFlag,
+		setAsDefault: runtimeSetAsDefaultFlag,
+		cdiEnabled:   cdiEnabledFlag,
+	}
+	for _, file := range []string{runtimeConfigFlag, runtimeDropInConfigFlag} {
+		if file != "" {
+			expected.configFiles = append(expected.configFiles, file)
+		}
+	}
+	// cri-o always has CDI support enabled, it cannot be toggled through its configuration
+	expected.checkCDIEnabled = runtimeFlag != "crio"
+	if runtimeDropInOnlyFlag && runtimeFlag == "crio" {
+		expected.dropInConfigFile = runtimeDropInConfigFlag
+		expected.socket = crioSocketFlag
+	}
+	return expected
+}
+
+// verifyRuntimeConfig verifies the container runtime configuration applied by the toolkit and
+// publishes the result on the node. Misconfigurations are reported, but do not fail the toolkit
+// validation: the container runtime may have been configured through other means.
+func (t *Toolkit) verifyRuntimeConfig() error {
+	ctx := t.ctx
+	expected := getRuntimeConfigExpectations()
+	if expected == nil {
+		log.Info("Runtime configuration verification not requested, skipping")
+		return nil
+	}
+
+	config, err := readRuntimeConfig(expected.runtime, "/host", expected.configFiles)
+	if err != nil {
+		return fmt.Errorf("error reading %s configuration: %w", expected.runtime, err)
+	}
+	problems := checkRuntimeConfig(expected, config)
+	if expected.dropInConfigFile != "" {
+		problems = append(problems, checkCRIODropInConfig(expected, "/host")...)
+	}
+	for _, problem := range problems {
+		log.Warnf("Runtime configuration check failed: %s", problem)
+	}
+
+	kubeConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return fmt.Errorf("error getting cluster config - %w", err)
+	}
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return fmt.Errorf("error getting k8s client - %w", err)
+	}
+	return publishRuntimeConfigResult(ctx, kubeClient, problems)
+}
+
+// checkRuntimeConfig compares the runtime configuration against the expectations and returns the failed checks
+func checkRuntimeConfig(expected *runtimeConfigExpectations, config *runtimeConfig) []string {
+	var problems []string
+	if !config.handlers[expected.handler] {
+		problems = append(problems, fmt.Sprintf("runtime handler %q is not configured", expected.handler))
+	}
+	isDefault := config.defaultRuntime == expected.handler
+	if expected.setAsDefault && !isDefault {
+		problems = append(problems, fmt.Sprintf("runtime handler %q is not the default runtime (default is %q)", expected.handler, config.defaultRuntime))
+	}
+	if !expected.setAsDefault && isDefault {
+		problems = append(problems, fmt.Sprintf("runtime handler %q is unexpectedly set as the default runtime", expected.handler))
+	}
+	if expected.checkCDIEnabled && expected.cdiEnabled && config.cdiEnabled != nil && !*config.cdiEnabled {
+		problems = append(problems, "CDI is disabled in the runtime configuration")
+	}
+	return problems
+}
+
+// checkCRIODropInConfig verifies that the runtime handler is configured by the drop-in file, rather than by the
+// main cri-o configuration, and that the drop-in file is loaded by cri-o. It returns the failed checks.
+func checkCRIODropInConfig(expected *runtimeConfigExpectations, hostRoot string) []string {
+	dropIn, err := readRuntimeConfig(expected.runtime, hostRoot, []string{expected.dropInConfigFile})
+	if err != nil {
+		return []string{fmt.Sprintf("drop-in config %s cannot be read: %v", expected.dropInConfigFile, err)}
+	}
+	if !dropIn.handlers[expected.handler] {
+		return []string{fmt.Sprintf("runtime handler %q is not configured in the drop-in config %s", expected.handler, expected.dropInConfigFile)}
+	}
+
+	loaded, err := readCRIOConfig(filepath.Join(hostRoot, expected.socket))
+	if err != nil {
+		return []string{fmt.Sprintf("configuration loaded by cri-o cannot be read: %v", err)}
+	}
+	if !loaded.handlers[expected.handler] {
+		return []string{fmt.Sprintf("runtime handler %q is not loaded by cri-o, %s is not in a configuration directory of cri-o or cri-o was not restarted", expected.handler, expected.dropInConfigFile)}
+	}
+	return nil
+}
+
+// readCRIOConfig reads the configuration loaded by cri-o, as served by the config endpoint of its socket
+func readCRIOConfig(socket string) (*runtimeConfig, error) {
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", socket)
+			},
+		},
+	}
+	resp, err := client.Get("http://crio/config")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	config := &runtimeConfig{handlers: make(map[string]bool)}
+	if err := parseCRIOConfig(data, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// readRuntimeConfig reads the runtime configuration files, relative to hostRoot. Later files take
+// precedence, matching how drop-in files override the top-level configuration.
+func readRuntimeConfig(runtime string, hostRoot string, files []string) (*runtimeConfig, error) {
+	config := &runtimeConfig{handlers: make(map[string]bool)}
+	// the containerd configuration files already parsed as an import of a previous file
+	visited := make(map[string]bool)
+	found := false
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(hostRoot, file))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		found = true
+		if visited[file] {
+			continue
+		}
+
+		switch runtime {
+		case "docker":
+			err = parseDockerRuntimeConfig(data, config)
+		case "containerd":
+			err = parseContainerdConfig(hostRoot, file, data, config, visited)
+		case "crio":
+			err = parseCRIOConfig(data, config)
+		default:
+			err = fmt.Errorf("unsupported runtime %q", runtime)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("none of the configuration files %v exist", files)
+	}
+	return config, nil
+}
+
+// parseDockerRuntimeConfig parses the docker daemon.json configuration
+func parseDockerRuntimeConfig(data []byte, config *runtimeConfig) error {
+	var daemonConfig struct {
+		Runtimes       map[string]json.RawMessage `json:"runtimes"`
+		DefaultRuntime string                     `json:"default-runtime"`
+		Features       map[string]bool            `json:"features"`
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, &daemonConfig); err != nil {
+		return err
+	}
+	for name := range daemonConfig.Runtimes {
+		config.handlers[name] = true
+	}
+	if daemonConfig.DefaultRuntime != "" {
+		config.defaultRuntime = daemonConfig.DefaultRuntime
+	}
+	if cdi, ok := daemonConfig.Features["cdi"]; ok {
+		config.cdiEnabled = &cdi
+	}
+	return nil
+}
+
+// containerdCRIPlugin returns the name of the plugin holding the CRI runtime settings in a containerd configuration
+// of the given version: the cri plugin in version 1, the CRI plugin in version 2 (containerd 1.x) and the CRI
+// runtime plugin in version 3 (containerd 2.x)
+func containerdCRIPlugin(version int64) string {
+	switch {
+	case version >= 3:
+		return "io.containerd.cri.v1.runtime"
+	case version == 2:
+		return "io.containerd.grpc.v1.cri"
+	default:
+		return "cri"
+	}
+}
+
+// parseContainerdConfig parses a containerd configuration file, at path file relative to hostRoot, followed by the
+// files it imports, which take precedence like in containerd. Relative imports are resolved against the directory
+// of the importing file. visited holds the files already parsed, which are not parsed again.
+func parseContainerdConfig(hostRoot string, file string, data []byte, config *runtimeConfig, visited map[string]bool) error {
+	visited[file] = true
+	document, err := toml.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+
+	// containerd configuration files without a version are version 1
+	version := int64(1)
+	if v, ok := document["version"].(int64); ok {
+		version = v
+	}
+	cri := toml.Table(document, "plugins", containerdCRIPlugin(version))
+	if cdi, ok := cri["enable_cdi"].(bool); ok {
+		config.cdiEnabled = &cdi
+	}
+	if name, ok := toml.Table(cri, "containerd")["default_runtime_name"].(string); ok && name != "" {
+		config.defaultRuntime = name
+	}
+	for name := range toml.Table(cri, "containerd", "runtimes") {
+		config.handlers[name] = true
+	}
+
+	imports, _ := document["imports"].([]any)
+	for _, entry := range imports {
+		pattern, ok := entry.(string)
+		if !ok {
+			return fmt.Errorf("invalid import %v", entry)
+		}
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(filepath.Dir(file), pattern)
+		}
+		matches, err := filepath.Glob(filepath.Join(hostRoot, pattern))
+		if err != nil {
+			return fmt.Errorf("invalid import %q: %w", pattern, err)
+		}
+		for _, match := range matches {
+			imported := "/" + strings.TrimPrefix(strings.TrimPrefix(match, hostRoot), "/")
+			if visited[imported] {
+				continue
+			}
+			importedData, err := os.ReadFile(match)
+			if err != nil {
+				return err
+			}
+			if err := parseContainerdConfig(hostRoot, imported, importedData, config, visited); err != nil {
+				return fmt.Errorf("failed to parse %s: %w", imported, err)
+			}
+		}
+	}
+	return nil
+}
+
+// parseCRIOConfig extracts the runtime handlers and default runtime from a cri-o configuration
+func parseCRIOConfig(data []byte, config *runtimeConfig) error {
+	document, err := toml.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	runtime := toml.Table(document, "crio", "runtime")
+	if name, ok := runtime["default_runtime"].(string); ok && name != "" {
+		config.defaultRuntime = name
+	}
+	for name := range toml.Table(runtime, "runtimes") {
+		config.handlers[name] = true
+	}
+	return nil
+}
+
+// publishRuntimeConfigResult records the outcome of the runtime configuration checks on the node
+func publishRuntimeConfigResult(ctx context.Context, kubeClient kubernetes.Interface, problems []string) error {
+	result := runtimeConfigValid
+	var errors interface{}
+	if len(problems) > 0 {
+		result = runtimeConfigInvalid
+		errors = strings.Join(problems, "; ")
+	}
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels":      map[string]interface{}{toolkitRuntimeConfigLabelKey: result},
+			"annotations": map[string]interface{}{toolkitRuntimeConfigErrorsAnnotationKey: errors},
+		},
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+	err = patchNode(ctx, kubeClient, types.MergePatchType, data)
+	if err != nil {
+		return fmt.Errorf("unable to publish runtime configuration result on node %s: %w", nodeNameFlag, err)
+	}
+	return nil
+}
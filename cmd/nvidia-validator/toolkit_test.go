@@ -0,0 +1,261 @@
+/*
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+*/
+
+package main
+
+import (
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRuntimeConfig(t *testing.T) {
+	testCases := []struct {
+		description      string
+		runtime          string
+		files            map[string]string
+		expected         runtimeConfigExpectations
+		expectedProblems []string
+	}{
+		{
+			description: "containerd drop-in config with nvidia as default runtime",
+			runtime:     "containerd",
+			files: map[string]string{
+				"/etc/containerd/config.toml": `version = 2
+imports = ["/etc/containerd/conf.d/*.toml"]
+`,
+				"/etc/containerd/conf.d/99-nvidia.toml": `version = 2
+
+[plugins]
+  [plugins."io.containerd.grpc.v1.cri"]
+    enable_cdi = true
+    [plugins."io.containerd.grpc.v1.cri".containerd]
+      default_runtime_name = "nvidia"
+      [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia]
+        runtime_type = "io.containerd.runc.v2"
+        [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia.options]
+          BinaryName = "/usr/local/nvidia/toolkit/nvidia-container-runtime"
+`,
+			},
+			expected: runtimeConfigExpectations{
+				configFiles:     []string{"/etc/containerd/config.toml", "/etc/containerd/conf.d/99-nvidia.toml"},
+				handler:         "nvidia",
+				setAsDefault:    true,
+				cdiEnabled:      true,
+				checkCDIEnabled: true,
+			},
+		},
+		{
+			description: "containerd config without the runtime handler",
+			runtime:     "containerd",
+			files: map[string]string{
+				"/etc/containerd/config.toml": `version = 2
+[plugins."io.containerd.grpc.v1.cri"]
+  enable_cdi = false
+  [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+    runtime_type = "io.containerd.runc.v2"
+`,
+			},
+			expected: runtimeConfigExpectations{
+				configFiles:     []string{"/etc/containerd/config.toml", "/etc/containerd/conf.d/99-nvidia.toml"},
+				handler:         "nvidia",
+				cdiEnabled:      true,
+				checkCDIEnabled: true,
+			},
+			expectedProblems: []string{
+				`runtime handler "nvidia" is not configured`,
+				"CDI is disabled in the runtime configuration",
+			},
+		},
+		{
+			description: "containerd 2.x config importing a relative drop-in config which overrides it",
+			runtime:     "containerd",
+			files: map[string]string{
+				"/etc/containerd/config.toml": `version = 3
+imports = ["conf.d/*.toml"]
+
+[plugins."io.containerd.cri.v1.runtime"]
+  enable_cdi = false
+  [plugins."io.containerd.cri.v1.runtime".containerd]
+    default_runtime_name = "runc"
+    # [plugins."io.containerd.cri.v1.runtime".containerd.runtimes.commented]
+`,
+				"/etc/containerd/conf.d/99-nvidia.toml": `version = 3
+
+[plugins."io.containerd.cri.v1.runtime"]
+  enable_cdi = true
+  containerd.default_runtime_name = "nvidia"
+  containerd.runtimes.nvidia = { runtime_type = "io.containerd.runc.v2" }
+`,
+			},
+			expected: runtimeConfigExpectations{
+				configFiles:     []string{"/etc/containerd/config.toml"},
+				handler:         "nvidia",
+				setAsDefault:    true,
+				cdiEnabled:      true,
+				checkCDIEnabled: true,
+			},
+		},
+		{
+			description: "containerd config version 1 ignores the tables of other versions",
+			runtime:     "containerd",
+			files: map[string]string{
+				"/etc/containerd/config.toml": `[plugins.cri.containerd]
+  default_runtime_name = "runc"
+  [plugins.cri.containerd.runtimes.nvidia]
+    runtime_type = "io.containerd.runc.v2"
+[plugins."io.containerd.grpc.v1.cri".containerd]
+  default_runtime_name = "nvidia"
+`,
+			},
+			expected: runtimeConfigExpectations{
+				configFiles:  []string{"/etc/containerd/config.toml"},
+				handler:      "nvidia",
+				setAsDefault: true,
+			},
+			expectedProblems: []string{
+				`runtime handler "nvidia" is not the default runtime (default is "runc")`,
+			},
+		},
+		{
+			description: "cri-o drop-in config with nvidia unexpectedly set as default",
+			runtime:     "crio",
+			files: map[string]string{
+				"/etc/crio/crio.conf.d/99-nvidia.toml": `[crio]
+  [crio.runtime]
+    default_runtime = "nvidia"
+    [crio.runtime.runtimes]
+      [crio.runtime.runtimes.nvidia]
+        runtime_path = "/usr/local/nvidia/toolkit/nvidia-container-runtime"
+`,
+			},
+			expected: runtimeConfigExpectations{
+				configFiles: []string{"/etc/crio/crio.conf", "/etc/crio/crio.conf.d/99-nvidia.toml"},
+				handler:     "nvidia",
+				cdiEnabled:  true,
+			},
+			expectedProblems: []string{
+				`runtime handler "nvidia" is unexpectedly set as the default runtime`,
+			},
+		},
+		{
+			description: "docker config with nvidia as default runtime",
+			runtime:     "docker",
+			files: map[string]string{
+				"/etc/docker/daemon.json": `{
+  "default-runtime": "nvidia",
+  "runtimes": {
+    "nvidia": {"path": "/usr/local/nvidia/toolkit/nvidia-container-runtime", "args": []}
+  }
+}`,
+			},
+			expected: runtimeConfigExpectations{
+				configFiles:  []string{"/etc/docker/daemon.json"},
+				handler:      "nvidia",
+				setAsDefault: true,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			hostRoot := t.TempDir()
+			for path, contents := range tc.files {
+				require.NoError(t, os.MkdirAll(filepath.Join(hostRoot, filepath.Dir(path)), 0755))
+				require.NoError(t, os.WriteFile(filepath.Join(hostRoot, path), []byte(contents), 0600))
+			}
+
+			config, err := readRuntimeConfig(tc.runtime, hostRoot, tc.expected.configFiles)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedProblems, checkRuntimeConfig(&tc.expected, config))
+		})
+	}
+}
+
+func TestReadRuntimeConfigInvalidTOML(t *testing.T) {
+	hostRoot := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(hostRoot, "/etc/containerd"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(hostRoot, "/etc/containerd/config.toml"), []byte("version = 2\n[plugins\n"), 0600))
+
+	_, err := readRuntimeConfig("containerd", hostRoot, []string{"/etc/containerd/config.toml"})
+	require.ErrorContains(t, err, "failed to parse /etc/containerd/config.toml: toml: line 2")
+}
+
+func TestCheckCRIODropInConfig(t *testing.T) {
+	const dropInConfig = `[crio.runtime.runtimes.nvidia]
+runtime_path = "/usr/local/nvidia/toolkit/nvidia-container-runtime"
+`
+	testCases := []struct {
+		description      string
+		dropInConfig     string
+		loadedConfig     string
+		expectedProblems []string
+	}{
+		{
+			description:  "drop-in config loaded by cri-o",
+			dropInConfig: dropInConfig,
+			loadedConfig: "[crio.runtime]\ndefault_runtime = \"runc\"\n\n" + dropInConfig,
+		},
+		{
+			description:  "runtime handler missing from the drop-in config",
+			dropInConfig: "[crio.runtime]\n",
+			loadedConfig: dropInConfig,
+			expectedProblems: []string{
+				`runtime handler "nvidia" is not configured in the drop-in config /etc/crio/crio.conf.d/99-nvidia.conf`,
+			},
+		},
+		{
+			description:  "drop-in config not loaded by cri-o",
+			dropInConfig: dropInConfig,
+			loadedConfig: "[crio.runtime]\ndefault_runtime = \"runc\"\n",
+			expectedProblems: []string{
+				`runtime handler "nvidia" is not loaded by cri-o, /etc/crio/crio.conf.d/99-nvidia.conf is not in a configuration directory of cri-o or cri-o was not restarted`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			hostRoot := t.TempDir()
+			expected := &runtimeConfigExpectations{
+				runtime:          "crio",
+				handler:          "nvidia",
+				dropInConfigFile: "/etc/crio/crio.conf.d/99-nvidia.conf",
+				socket:           defaultCRIOSocket,
+			}
+			dropInPath := filepath.Join(hostRoot, expected.dropInConfigFile)
+			require.NoError(t, os.MkdirAll(filepath.Dir(dropInPath), 0755))
+			require.NoError(t, os.WriteFile(dropInPath, []byte(tc.dropInConfig), 0600))
+
+			socketPath := filepath.Join(hostRoot, expected.socket)
+			require.NoError(t, os.MkdirAll(filepath.Dir(socketPath), 0755))
+			listener, err := net.Listen("unix", socketPath)
+			require.NoError(t, err)
+			server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/config", r.URL.Path)
+				_, _ = w.Write([]byte(tc.loadedConfig))
+			})}
+			go func() { _ = server.Serve(listener) }()
+			defer server.Close()
+
+			require.Equal(t, tc.expectedProblems, checkCRIODropInConfig(expected, hostRoot))
+		})
+	}
+}
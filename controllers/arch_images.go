@@ -0,0 +1,172 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"maps"
+	"slices"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/audit"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+	imageutil "github.com/NVIDIA/gpu-operator/internal/image"
+)
+
+// archImageComponent returns the component spec of the given state along with its per-architecture images, or
+// nil for the states whose component has no per-architecture images
+func archImageComponent(spec *gpuv1.ClusterPolicySpec, stateName string) (interface{}, map[string]gpuv1.ArchImageSpec) {
+	switch stateName {
+	case "state-container-toolkit":
+		return &spec.Toolkit, spec.Toolkit.ArchImages
+	case "state-device-plugin":
+		return &spec.DevicePlugin, spec.DevicePlugin.ArchImages
+	case "state-dcgm":
+		return &spec.DCGM, spec.DCGM.ArchImages
+	case "state-dcgm-exporter":
+		return &spec.DCGMExporter, spec.DCGMExporter.ArchImages
+	case "gpu-feature-discovery":
+		return &spec.GPUFeatureDiscovery, spec.GPUFeatureDiscovery.ArchImages
+	case "state-mig-manager":
+		return &spec.MIGManager, spec.MIGManager.ArchImages
+	}
+	return nil, nil
+}
+
+// archDaemonSets deploys the DaemonSet of the current state for the nodes running the default image of its
+// component, then once per architecture of the GPU nodes for which the component has an image. The DaemonSets
+// of the architectures which no longer have an image, or no longer have GPU nodes, are deleted.
+func archDaemonSets(n ClusterPolicyController) (gpuv1.State, error) {
+	n.archResolved = true
+	n.archImages = nil
+
+	_, archImages := archImageComponent(&n.singleton.Spec, n.stateNames[n.idx])
+	if n.hasGPUNodes && n.isStateEnabled(n.stateNames[n.idx]) {
+		for _, arch := range slices.Sorted(maps.Keys(archImages)) {
+			if n.gpuNodeArchs[arch] {
+				n.archImages = append(n.archImages, arch)
+			}
+		}
+	}
+
+	if err := n.deleteStaleArchDaemonSets(); err != nil {
+		return gpuv1.NotReady, err
+	}
+
+	overallState, err := DaemonSet(n)
+	if err != nil {
+		return overallState, err
+	}
+	for _, arch := range n.archImages {
+		variant := n
+		variant.archVariant = arch
+		state, err := DaemonSet(variant)
+		if err != nil {
+			return gpuv1.NotReady, err
+		}
+		if state == gpuv1.NotReady {
+			overallState = gpuv1.NotReady
+		}
+	}
+	return overallState, nil
+}
+
+// deleteStaleArchDaemonSets deletes the DaemonSets of the current state, and of the validator image and
+// container runtime being deployed, deployed for the architectures which are no longer deployed
+func (n ClusterPolicyController) deleteStaleArchDaemonSets() error {
+	items, err := n.listVariantDaemonSets(consts.OperandArchLabelKey)
+	if err != nil {
+		return err
+	}
+	for i := range items {
+		ds := &items[i]
+		if !n.ownsObject(ds.Labels) || ds.Labels[consts.ValidatorImageLabelKey] != n.validatorImage ||
+			ds.Labels[consts.ContainerRuntimeLabelKey] != n.runtimeVariant.String() {
+			continue
+		}
+		if slices.Contains(n.archImages, ds.Labels[consts.OperandArchLabelKey]) {
+			continue
+		}
+		err := n.client.Delete(audit.WithReason(n.ctx, "architecture image removed"), ds)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyArchVariant restricts the DaemonSet to the nodes of the architecture being deployed. The DaemonSet of an
+// architecture is named and labeled after it, runs the image of the architecture in place of the default image
+// of the component, and is only scheduled on the nodes of the architecture, while the DaemonSet of the default
+// image is kept off these nodes.
+func applyArchVariant(obj *appsv1.DaemonSet, n ClusterPolicyController) error {
+	if len(n.archImages) == 0 {
+		return nil
+	}
+	podSpec := &obj.Spec.Template.Spec
+	if n.archVariant == "" {
+		addRequiredNodeSelectorRequirement(podSpec, corev1.NodeSelectorRequirement{
+			Key:      corev1.LabelArchStable,
+			Operator: corev1.NodeSelectorOpNotIn,
+			Values:   n.archImages,
+		})
+		return nil
+	}
+
+	component, _ := archImageComponent(&n.singleton.Spec, n.stateNames[n.idx])
+	defaultImage, err := gpuv1.ImagePath(component)
+	if err != nil {
+		return err
+	}
+	image, err := gpuv1.ArchImagePath(component, n.archVariant)
+	if err != nil {
+		return err
+	}
+	// the images of the pod are already rewritten to the registry mirrors
+	defaultImage = imageutil.MirrorImage(defaultImage, n.singleton.Spec.ImageMirror)
+	image = imageutil.MirrorImage(image, n.singleton.Spec.ImageMirror)
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			if containers[i].Image == defaultImage {
+				containers[i].Image = image
+			}
+		}
+	}
+
+	obj.Name += "-" + n.archVariant
+	if obj.Labels == nil {
+		obj.Labels = make(map[string]string)
+	}
+	obj.Labels[consts.OperandArchLabelKey] = n.archVariant
+	if obj.Spec.Selector.MatchLabels == nil {
+		obj.Spec.Selector.MatchLabels = make(map[string]string)
+	}
+	obj.Spec.Selector.MatchLabels[consts.OperandArchLabelKey] = n.archVariant
+	if obj.Spec.Template.Labels == nil {
+		obj.Spec.Template.Labels = make(map[string]string)
+	}
+	obj.Spec.Template.Labels[consts.OperandArchLabelKey] = n.archVariant
+	addRequiredNodeSelectorRequirement(podSpec, corev1.NodeSelectorRequirement{
+		Key:      corev1.LabelArchStable,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{n.archVariant},
+	})
+	return nil
+}
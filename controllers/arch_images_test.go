@@ -0,0 +1,139 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+func newArchImagesDevicePluginSpec() gpuv1.DevicePluginSpec {
+	return gpuv1.DevicePluginSpec{
+		Repository: "nvcr.io/nvidia",
+		Image:      "k8s-device-plugin",
+		Version:    "v0.17.0",
+		ArchImages: map[string]gpuv1.ArchImageSpec{
+			"arm64": {Version: "v0.17.0-arm64"},
+		},
+	}
+}
+
+func TestArchImagePath(t *testing.T) {
+	spec := newArchImagesDevicePluginSpec()
+
+	image, err := gpuv1.ArchImagePath(&spec, "arm64")
+	require.NoError(t, err)
+	require.Equal(t, "nvcr.io/nvidia/k8s-device-plugin:v0.17.0-arm64", image)
+
+	image, err = gpuv1.ArchImagePath(&spec, "amd64")
+	require.NoError(t, err)
+	require.Equal(t, "nvcr.io/nvidia/k8s-device-plugin:v0.17.0", image)
+
+	spec.ArchImages["arm64"] = gpuv1.ArchImageSpec{Repository: "registry.example.com/grace", Image: "device-plugin"}
+	image, err = gpuv1.ArchImagePath(&spec, "arm64")
+	require.NoError(t, err)
+	require.Equal(t, "registry.example.com/grace/device-plugin:v0.17.0", image)
+
+	_, err = gpuv1.ArchImagePath(&gpuv1.DriverSpec{}, "arm64")
+	require.Error(t, err)
+}
+
+func TestValidateArchImages(t *testing.T) {
+	spec := &gpuv1.ClusterPolicySpec{DevicePlugin: newArchImagesDevicePluginSpec()}
+	require.NoError(t, spec.ValidateArchImages())
+
+	for _, arch := range []string{"ARM64", "arm64/rhel9.4", ""} {
+		spec := &gpuv1.ClusterPolicySpec{}
+		spec.DCGM.ArchImages = map[string]gpuv1.ArchImageSpec{arch: {Version: "4.2.3-arm64"}}
+		require.Error(t, spec.ValidateArchImages(), arch)
+	}
+	spec.Toolkit.ArchImages = map[string]gpuv1.ArchImageSpec{"arm64": {}}
+	require.Error(t, spec.ValidateArchImages())
+}
+
+func TestApplyArchVariant(t *testing.T) {
+	newDaemonSet := func() *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "nvidia-device-plugin-daemonset"},
+			Spec: appsv1.DaemonSetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nvidia-device-plugin-daemonset"}},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "nvidia-device-plugin-daemonset"}},
+					Spec: corev1.PodSpec{
+						InitContainers: []corev1.Container{{Name: "toolkit-validation", Image: "nvcr.io/nvidia/gpu-operator:v1.0.0"}},
+						Containers:     []corev1.Container{{Name: "nvidia-device-plugin", Image: "nvcr.io/nvidia/k8s-device-plugin:v0.17.0"}},
+					},
+				},
+			},
+		}
+	}
+	n := ClusterPolicyController{
+		singleton:  &gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{DevicePlugin: newArchImagesDevicePluginSpec()}},
+		stateNames: []string{"state-device-plugin"},
+	}
+
+	// no GPU nodes of the architectures of the images
+	ds := newDaemonSet()
+	require.NoError(t, applyArchVariant(ds, n))
+	require.Equal(t, newDaemonSet(), ds)
+
+	// default image
+	n.archImages = []string{"arm64"}
+	ds = newDaemonSet()
+	require.NoError(t, applyArchVariant(ds, n))
+	require.Equal(t, "nvidia-device-plugin-daemonset", ds.Name)
+	require.Equal(t, "nvcr.io/nvidia/k8s-device-plugin:v0.17.0", ds.Spec.Template.Spec.Containers[0].Image)
+	require.Equal(t, []corev1.NodeSelectorTerm{{
+		MatchExpressions: []corev1.NodeSelectorRequirement{{
+			Key:      corev1.LabelArchStable,
+			Operator: corev1.NodeSelectorOpNotIn,
+			Values:   []string{"arm64"},
+		}},
+	}}, ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+
+	// image of the architecture, the validator image being left as is
+	n.archVariant = "arm64"
+	ds = newDaemonSet()
+	require.NoError(t, applyArchVariant(ds, n))
+	require.Equal(t, "nvidia-device-plugin-daemonset-arm64", ds.Name)
+	require.Equal(t, "nvcr.io/nvidia/k8s-device-plugin:v0.17.0-arm64", ds.Spec.Template.Spec.Containers[0].Image)
+	require.Equal(t, "nvcr.io/nvidia/gpu-operator:v1.0.0", ds.Spec.Template.Spec.InitContainers[0].Image)
+	require.Equal(t, "arm64", ds.Labels[consts.OperandArchLabelKey])
+	require.Equal(t, "arm64", ds.Spec.Selector.MatchLabels[consts.OperandArchLabelKey])
+	require.Equal(t, "arm64", ds.Spec.Template.Labels[consts.OperandArchLabelKey])
+	require.Equal(t, []corev1.NodeSelectorTerm{{
+		MatchExpressions: []corev1.NodeSelectorRequirement{{
+			Key:      corev1.LabelArchStable,
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   []string{"arm64"},
+		}},
+	}}, ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+
+	// images rewritten to a registry mirror
+	n.singleton.Spec.ImageMirror = map[string]string{"nvcr.io/nvidia": "mirror.example.com/nvidia"}
+	ds = newDaemonSet()
+	applyImageMirror(&ds.Spec.Template.Spec, &n.singleton.Spec)
+	require.NoError(t, applyArchVariant(ds, n))
+	require.Equal(t, "mirror.example.com/nvidia/k8s-device-plugin:v0.17.0-arm64", ds.Spec.Template.Spec.Containers[0].Image)
+}
@@ -0,0 +1,446 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sigs.k8s.io/yaml"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/apistats"
+	"github.com/NVIDIA/gpu-operator/internal/audit"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+const (
+	autoscalingHintsControllerSingletonName = "cluster"
+
+	// AutoscalingHintsConfigMapName is the ConfigMap publishing the autoscaling hints of each instance type
+	AutoscalingHintsConfigMapName = "nvidia-gpu-autoscaling-hints"
+
+	gpuCountLabelKey      = "nvidia.com/gpu.count"
+	gpuResourceName       = "nvidia.com/gpu"
+	migResourceNamePrefix = "nvidia.com/mig-"
+	sharedResourceSuffix  = ".shared"
+	migPartedConfigKey    = "config.yaml"
+	migPartedAllDevices   = "all"
+)
+
+// AutoscalingHintsReconciler publishes the extended resources the NVIDIA Device Plugin is expected to advertise
+// on the GPU nodes, computed from the GPUs reported by GPU Feature Discovery and the MIG and sharing configuration
+// of the ClusterPolicy. The resources are published in the nvidia.com/gpu.autoscaling-allocatable node annotation,
+// and for each instance type in the nvidia-gpu-autoscaling-hints ConfigMap, so that node templates of Cluster
+// Autoscaler or Karpenter can be derived from them to scale GPU node groups from zero.
+type AutoscalingHintsReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Namespace string
+	Log       logr.Logger
+	// APIStats counts the requests of the reconciliations, nothing is counted when nil
+	APIStats *apistats.Tracker
+}
+
+// instanceTypeHints are the autoscaling hints of an instance type. The GPUs and the MIG configuration of the
+// latest node of the instance type are kept, so that the allocatable resources of the instance types without
+// nodes follow the changes of the ClusterPolicy.
+type instanceTypeHints struct {
+	Product     string         `json:"product,omitempty"`
+	GPUCount    int            `json:"gpuCount"`
+	MIGConfig   string         `json:"migConfig,omitempty"`
+	Allocatable map[string]int `json:"allocatable"`
+}
+
+// migPartedConfig is the part of a mig-parted configuration defining the MIG devices of each MIG configuration
+type migPartedConfig struct {
+	MIGConfigs map[string][]migPartedDeviceConfig `json:"mig-configs"`
+}
+
+// migPartedDeviceConfig defines the MIG devices of the GPUs selected by devices, either "all" or a list of
+// GPU indices
+type migPartedDeviceConfig struct {
+	Devices    json.RawMessage `json:"devices"`
+	MIGEnabled bool            `json:"mig-enabled"`
+	MIGDevices map[string]int  `json:"mig-devices,omitempty"`
+}
+
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile refreshes the autoscaling hints of the GPU nodes and of their instance types.
+func (r *AutoscalingHintsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx = audit.WithReason(ctx, "autoscaling hints")
+	ctx, done := r.APIStats.StartReconcile(ctx, "AutoscalingHints")
+	defer done()
+
+	clusterPolicy, _, err := resolveActiveConfig(ctx, r.Client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	// the latest node of an instance type is listed last
+	sort.Slice(nodes.Items, func(i, j int) bool {
+		return nodes.Items[i].CreationTimestamp.Before(&nodes.Items[j].CreationTimestamp)
+	})
+
+	if clusterPolicy == nil || !clusterPolicy.Spec.AutoscalingHints.IsEnabled() || !clusterPolicy.Spec.DevicePlugin.IsEnabled() {
+		for i := range nodes.Items {
+			if err := r.setAllocatable(ctx, &nodes.Items[i], ""); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+		return reconcile.Result{}, r.deleteHintsConfigMap(ctx)
+	}
+	spec := &clusterPolicy.Spec
+
+	migConfigs, err := r.getMIGConfigs(ctx, spec)
+	if err != nil {
+		r.Log.Info("WARNING: failed to read the MIG configurations, MIG devices are left out of the autoscaling hints", "error", err)
+	}
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: AutoscalingHintsConfigMapName, Namespace: r.Namespace}}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(cm), cm); err != nil && !apierrors.IsNotFound(err) {
+		return reconcile.Result{}, fmt.Errorf("failed to get ConfigMap %s: %w", AutoscalingHintsConfigMapName, err)
+	}
+
+	// the instance types without nodes are kept, their allocatable resources are recomputed
+	hints := make(map[string]*instanceTypeHints)
+	for instanceType, data := range cm.Data {
+		h := &instanceTypeHints{}
+		if err := json.Unmarshal([]byte(data), h); err != nil {
+			r.Log.Info("WARNING: dropping invalid autoscaling hints", "instanceType", instanceType, "error", err)
+			continue
+		}
+		h.Allocatable = computeGPUAllocatable(spec, migConfigs[h.MIGConfig], h.GPUCount)
+		hints[instanceType] = h
+	}
+
+	instanceTypeLabel := spec.AutoscalingHints.GetInstanceTypeLabel()
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		gpuCount, err := strconv.Atoi(node.Labels[gpuCountLabelKey])
+		if !hasCommonGPULabel(node.Labels) || err != nil || gpuCount <= 0 {
+			if err := r.setAllocatable(ctx, node, ""); err != nil {
+				return reconcile.Result{}, err
+			}
+			continue
+		}
+
+		h := nodeAutoscalingHints(spec, migConfigs, node.Labels, gpuCount)
+		allocatable, err := json.Marshal(h.Allocatable)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to marshal the allocatable resources of node %s: %w", node.Name, err)
+		}
+		if err := r.setAllocatable(ctx, node, string(allocatable)); err != nil {
+			return reconcile.Result{}, err
+		}
+
+		instanceType := node.Labels[instanceTypeLabel]
+		if instanceType == "" {
+			continue
+		}
+		if errs := validation.IsConfigMapKey(instanceType); len(errs) > 0 {
+			r.Log.Info("WARNING: instance type cannot be published in the autoscaling hints", "node", node.Name, "instanceType", instanceType, "errors", errs)
+			continue
+		}
+		hints[instanceType] = h
+	}
+
+	return reconcile.Result{}, r.updateHintsConfigMap(ctx, clusterPolicy, hints)
+}
+
+// getMIGConfigs returns the MIG configurations of the mig-parted configuration of the MIG manager, or nil when
+// MIG devices are not advertised
+func (r *AutoscalingHintsReconciler) getMIGConfigs(ctx context.Context, spec *gpuv1.ClusterPolicySpec) (map[string][]migPartedDeviceConfig, error) {
+	if spec.MIG.Strategy == gpuv1.MIGStrategyNone {
+		return nil, nil
+	}
+	name, _ := gpuv1.GetConfigMapName(spec.MIGManager.Config, MigPartedDefaultConfigMapName)
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: r.Namespace, Name: name}, cm); err != nil {
+		return nil, fmt.Errorf("failed to get ConfigMap %s: %w", name, err)
+	}
+	config := &migPartedConfig{}
+	if err := yaml.Unmarshal([]byte(cm.Data[migPartedConfigKey]), config); err != nil {
+		return nil, fmt.Errorf("failed to parse the mig-parted configuration of ConfigMap %s: %w", name, err)
+	}
+	return config.MIGConfigs, nil
+}
+
+// nodeAutoscalingHints returns the autoscaling hints of a GPU node
+func nodeAutoscalingHints(spec *gpuv1.ClusterPolicySpec, migConfigs map[string][]migPartedDeviceConfig, labels map[string]string, gpuCount int) *instanceTypeHints {
+	h := &instanceTypeHints{
+		Product:  labels[gpuProductLabelKey],
+		GPUCount: gpuCount,
+	}
+	migConfig := migConfigs[labels[migConfigLabelKey]]
+	if migConfig != nil {
+		h.MIGConfig = labels[migConfigLabelKey]
+		// with the single MIG strategy, GPU Feature Discovery counts the MIG devices of the partitioned GPUs
+		if spec.MIG.Strategy == gpuv1.MIGStrategySingle && strings.Contains(h.Product, "-MIG-") {
+			if perGPU := migDevicesPerGPU(migConfig); perGPU > 0 {
+				h.GPUCount = gpuCount / perGPU
+			}
+		}
+	}
+	h.Allocatable = computeGPUAllocatable(spec, migConfig, h.GPUCount)
+	return h
+}
+
+// computeGPUAllocatable returns the number of each extended resource the NVIDIA Device Plugin advertises for
+// gpuCount GPUs partitioned by the MIG configuration, once shared through time-slicing or MPS
+func computeGPUAllocatable(spec *gpuv1.ClusterPolicySpec, migConfig []migPartedDeviceConfig, gpuCount int) map[string]int {
+	allocatable := make(map[string]int)
+	remaining := gpuCount
+	if spec.MIG.Strategy != gpuv1.MIGStrategyNone {
+		for _, devices := range migConfig {
+			gpus := min(devices.gpuCount(gpuCount), remaining)
+			remaining -= gpus
+			if !devices.MIGEnabled {
+				allocatable[gpuResourceName] += gpus
+				continue
+			}
+			for profile, count := range devices.MIGDevices {
+				resource := gpuResourceName
+				if spec.MIG.Strategy == gpuv1.MIGStrategyMixed {
+					resource = migResourceNamePrefix + strings.ReplaceAll(profile, "+", ".")
+				}
+				allocatable[resource] += count * gpus
+			}
+		}
+	}
+	// the GPUs left out of the MIG configuration are not partitioned
+	if remaining > 0 {
+		allocatable[gpuResourceName] += remaining
+	}
+
+	if spec.DevicePlugin.IsTimeSlicingEnabled() {
+		replicas := make(map[string]int)
+		for _, resource := range spec.DevicePlugin.TimeSlicing.Resources {
+			replicas[resource.Name] = resource.Replicas
+		}
+		shareResources(allocatable, replicas, spec.DevicePlugin.TimeSlicing.RenameByDefault)
+	} else if spec.DevicePlugin.IsMPSSharingEnabled() {
+		replicas := make(map[string]int)
+		for _, resource := range spec.DevicePlugin.MPS.Resources {
+			replicas[resource.Name] = resource.Replicas
+		}
+		shareResources(allocatable, replicas, spec.DevicePlugin.MPS.RenameByDefault)
+	}
+	return allocatable
+}
+
+// shareResources multiplies the shared resources by their number of replicas, advertising them as
+// <resource-name>.shared when renamed
+func shareResources(allocatable map[string]int, replicas map[string]int, rename bool) {
+	for name, count := range maps.Clone(allocatable) {
+		n, ok := replicas[name]
+		if !ok {
+			continue
+		}
+		if rename {
+			delete(allocatable, name)
+			name += sharedResourceSuffix
+		}
+		allocatable[name] = count * n
+	}
+}
+
+// migDevicesPerGPU returns the number of MIG devices of each GPU partitioned by the MIG configuration
+func migDevicesPerGPU(migConfig []migPartedDeviceConfig) int {
+	for _, devices := range migConfig {
+		if !devices.MIGEnabled {
+			continue
+		}
+		perGPU := 0
+		for _, count := range devices.MIGDevices {
+			perGPU += count
+		}
+		return perGPU
+	}
+	return 0
+}
+
+// gpuCount returns the number of GPUs selected by the devices among gpuCount GPUs
+func (d migPartedDeviceConfig) gpuCount(gpuCount int) int {
+	var all string
+	if err := json.Unmarshal(d.Devices, &all); err == nil {
+		if all == migPartedAllDevices {
+			return gpuCount
+		}
+		return 0
+	}
+	var indices []int
+	if err := json.Unmarshal(d.Devices, &indices); err != nil {
+		return 0
+	}
+	count := 0
+	for _, index := range indices {
+		if index >= 0 && index < gpuCount {
+			count++
+		}
+	}
+	return count
+}
+
+// setAllocatable sets the nvidia.com/gpu.autoscaling-allocatable annotation of the node, removing it if
+// allocatable is empty
+func (r *AutoscalingHintsReconciler) setAllocatable(ctx context.Context, node *corev1.Node, allocatable string) error {
+	current, ok := node.Annotations[consts.GPUAutoscalingAllocatableAnnotationKey]
+	if (allocatable == "" && !ok) || (allocatable != "" && current == allocatable) {
+		return nil
+	}
+
+	original := node.DeepCopy()
+	if allocatable == "" {
+		delete(node.Annotations, consts.GPUAutoscalingAllocatableAnnotationKey)
+	} else {
+		if node.Annotations == nil {
+			node.Annotations = make(map[string]string)
+		}
+		node.Annotations[consts.GPUAutoscalingAllocatableAnnotationKey] = allocatable
+	}
+
+	if err := r.Patch(ctx, node, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to update %s annotation on node %s: %w", consts.GPUAutoscalingAllocatableAnnotationKey, node.Name, err)
+	}
+	return nil
+}
+
+// updateHintsConfigMap writes the autoscaling hints of each instance type to the nvidia-gpu-autoscaling-hints ConfigMap
+func (r *AutoscalingHintsReconciler) updateHintsConfigMap(ctx context.Context, clusterPolicy *gpuv1.ClusterPolicy, hints map[string]*instanceTypeHints) error {
+	data := make(map[string]string, len(hints))
+	for instanceType, h := range hints {
+		value, err := json.Marshal(h)
+		if err != nil {
+			return fmt.Errorf("failed to marshal the autoscaling hints of instance type %s: %w", instanceType, err)
+		}
+		data[instanceType] = string(value)
+	}
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: AutoscalingHintsConfigMapName, Namespace: r.Namespace}}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		cm.Data = data
+		return controllerutil.SetControllerReference(clusterPolicy, cm, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update ConfigMap %s: %w", AutoscalingHintsConfigMapName, err)
+	}
+	return nil
+}
+
+func (r *AutoscalingHintsReconciler) deleteHintsConfigMap(ctx context.Context) error {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: AutoscalingHintsConfigMapName, Namespace: r.Namespace}}
+	if err := r.Delete(ctx, cm); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete ConfigMap %s: %w", AutoscalingHintsConfigMapName, err)
+	}
+	return nil
+}
+
+// SetupWithManager registers the AutoscalingHintsReconciler with the controller-runtime manager.
+func (r *AutoscalingHintsReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	mapToSingleton := func(_ context.Context, _ client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: autoscalingHintsControllerSingletonName}}}
+	}
+
+	c, err := controller.New("autoscaling-hints-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: 1,
+		RateLimiter:             workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](minDelayCR, maxDelayCR),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating autoscaling-hints controller: %w", err)
+	}
+
+	clusterPolicyMapFn := func(ctx context.Context, cp *gpuv1.ClusterPolicy) []reconcile.Request {
+		return mapToSingleton(ctx, cp)
+	}
+	if err := c.Watch(source.Kind(
+		mgr.GetCache(),
+		&gpuv1.ClusterPolicy{},
+		handler.TypedEnqueueRequestsFromMapFunc(clusterPolicyMapFn),
+		predicate.TypedGenerationChangedPredicate[*gpuv1.ClusterPolicy]{},
+	)); err != nil {
+		return fmt.Errorf("error watching ClusterPolicy: %w", err)
+	}
+
+	// the GPUs, the MIG configuration and the instance type of the nodes are read from their labels
+	nodePredicate := predicate.TypedFuncs[*corev1.Node]{
+		UpdateFunc: func(e event.TypedUpdateEvent[*corev1.Node]) bool {
+			return !maps.Equal(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+		},
+		DeleteFunc: func(e event.TypedDeleteEvent[*corev1.Node]) bool {
+			return false
+		},
+	}
+	nodeMapFn := func(ctx context.Context, n *corev1.Node) []reconcile.Request {
+		return mapToSingleton(ctx, n)
+	}
+	if err := c.Watch(source.Kind(
+		mgr.GetCache(),
+		&corev1.Node{},
+		handler.TypedEnqueueRequestsFromMapFunc(nodeMapFn),
+		nodePredicate,
+	)); err != nil {
+		return fmt.Errorf("error watching Nodes: %w", err)
+	}
+
+	// the mig-parted configurations are read from a ConfigMap of the operator namespace
+	configMapPredicate := predicate.NewTypedPredicateFuncs(func(cm *corev1.ConfigMap) bool {
+		return cm.Namespace == r.Namespace && cm.Name != AutoscalingHintsConfigMapName
+	})
+	configMapMapFn := func(ctx context.Context, cm *corev1.ConfigMap) []reconcile.Request {
+		return mapToSingleton(ctx, cm)
+	}
+	if err := c.Watch(source.Kind(
+		mgr.GetCache(),
+		&corev1.ConfigMap{},
+		handler.TypedEnqueueRequestsFromMapFunc(configMapMapFn),
+		configMapPredicate,
+	)); err != nil {
+		return fmt.Errorf("error watching ConfigMaps: %w", err)
+	}
+
+	return nil
+}
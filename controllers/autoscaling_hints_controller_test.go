@@ -0,0 +1,265 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+const testMIGPartedConfig = `
+version: v1
+mig-configs:
+  all-disabled:
+    - devices: all
+      mig-enabled: false
+  all-1g.10gb:
+    - devices: all
+      mig-enabled: true
+      mig-devices:
+        "1g.10gb": 7
+  all-balanced:
+    - devices: all
+      mig-enabled: true
+      mig-devices:
+        "1g.10gb": 2
+        "2g.20gb": 1
+        "3g.40gb": 1
+  first-1g.10gb.me:
+    - devices: [0]
+      mig-enabled: true
+      mig-devices:
+        "1g.10gb+me": 1
+`
+
+func TestComputeGPUAllocatable(t *testing.T) {
+	var config migPartedConfig
+	require.NoError(t, yaml.Unmarshal([]byte(testMIGPartedConfig), &config))
+
+	tests := []struct {
+		name      string
+		spec      gpuv1.ClusterPolicySpec
+		migConfig string
+		gpuCount  int
+		expected  map[string]int
+	}{
+		{
+			name:     "full GPUs",
+			spec:     gpuv1.ClusterPolicySpec{MIG: gpuv1.MIGSpec{Strategy: gpuv1.MIGStrategySingle}},
+			gpuCount: 8,
+			expected: map[string]int{"nvidia.com/gpu": 8},
+		},
+		{
+			name:      "MIG disabled",
+			spec:      gpuv1.ClusterPolicySpec{MIG: gpuv1.MIGSpec{Strategy: gpuv1.MIGStrategyMixed}},
+			migConfig: "all-disabled",
+			gpuCount:  8,
+			expected:  map[string]int{"nvidia.com/gpu": 8},
+		},
+		{
+			name:      "single MIG strategy",
+			spec:      gpuv1.ClusterPolicySpec{MIG: gpuv1.MIGSpec{Strategy: gpuv1.MIGStrategySingle}},
+			migConfig: "all-1g.10gb",
+			gpuCount:  8,
+			expected:  map[string]int{"nvidia.com/gpu": 56},
+		},
+		{
+			name:      "mixed MIG strategy",
+			spec:      gpuv1.ClusterPolicySpec{MIG: gpuv1.MIGSpec{Strategy: gpuv1.MIGStrategyMixed}},
+			migConfig: "all-balanced",
+			gpuCount:  2,
+			expected:  map[string]int{"nvidia.com/mig-1g.10gb": 4, "nvidia.com/mig-2g.20gb": 2, "nvidia.com/mig-3g.40gb": 2},
+		},
+		{
+			name:      "GPUs left out of the MIG configuration",
+			spec:      gpuv1.ClusterPolicySpec{MIG: gpuv1.MIGSpec{Strategy: gpuv1.MIGStrategyMixed}},
+			migConfig: "first-1g.10gb.me",
+			gpuCount:  4,
+			expected:  map[string]int{"nvidia.com/mig-1g.10gb.me": 1, "nvidia.com/gpu": 3},
+		},
+		{
+			name: "time-slicing",
+			spec: gpuv1.ClusterPolicySpec{
+				MIG: gpuv1.MIGSpec{Strategy: gpuv1.MIGStrategySingle},
+				DevicePlugin: gpuv1.DevicePluginSpec{TimeSlicing: &gpuv1.TimeSlicingConfig{
+					Resources: []gpuv1.TimeSlicingResource{{Name: "nvidia.com/gpu", Replicas: 4}},
+				}},
+			},
+			gpuCount: 2,
+			expected: map[string]int{"nvidia.com/gpu": 8},
+		},
+		{
+			name: "renamed MPS resources",
+			spec: gpuv1.ClusterPolicySpec{
+				MIG: gpuv1.MIGSpec{Strategy: gpuv1.MIGStrategySingle},
+				DevicePlugin: gpuv1.DevicePluginSpec{MPS: &gpuv1.MPSConfig{
+					RenameByDefault: true,
+					Resources:       []gpuv1.MPSResource{{Name: "nvidia.com/gpu", Replicas: 3}},
+				}},
+			},
+			gpuCount: 2,
+			expected: map[string]int{"nvidia.com/gpu.shared": 6},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, computeGPUAllocatable(&tc.spec, config.MIGConfigs[tc.migConfig], tc.gpuCount))
+		})
+	}
+}
+
+func TestAutoscalingHintsReconcile(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+	require.NoError(t, nvidiav1alpha1.AddToScheme(scheme))
+
+	clusterPolicy := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy"},
+		Spec: gpuv1.ClusterPolicySpec{
+			MIG:              gpuv1.MIGSpec{Strategy: gpuv1.MIGStrategyMixed},
+			AutoscalingHints: &gpuv1.AutoscalingHintsSpec{Enabled: ptr.To(true)},
+		},
+	}
+	gpuNode := func(name string, instanceType string, migConfig string) *corev1.Node {
+		labels := map[string]string{
+			commonGPULabelKey:              commonGPULabelValue,
+			gpuCountLabelKey:               "8",
+			gpuProductLabelKey:             "NVIDIA-A100-SXM4-80GB",
+			corev1.LabelInstanceTypeStable: instanceType,
+		}
+		if migConfig != "" {
+			labels[migConfigLabelKey] = migConfig
+		}
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		clusterPolicy,
+		gpuNode("gpu-node", "p4de.24xlarge", ""),
+		gpuNode("mig-node", "a2-ultragpu-8g", "all-1g.10gb"),
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "cpu-node"}},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: MigPartedDefaultConfigMapName, Namespace: "gpu-operator"},
+			Data:       map[string]string{migPartedConfigKey: testMIGPartedConfig},
+		},
+	).Build()
+
+	r := &AutoscalingHintsReconciler{
+		Client:    c,
+		Scheme:    scheme,
+		Namespace: "gpu-operator",
+		Log:       logr.Discard(),
+	}
+
+	getAnnotation := func(name string) (string, bool) {
+		node := &corev1.Node{}
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Name: name}, node))
+		value, ok := node.Annotations[consts.GPUAutoscalingAllocatableAnnotationKey]
+		return value, ok
+	}
+	getHints := func() map[string]instanceTypeHints {
+		cm := &corev1.ConfigMap{}
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "gpu-operator", Name: AutoscalingHintsConfigMapName}, cm))
+		hints := make(map[string]instanceTypeHints)
+		for instanceType, data := range cm.Data {
+			h := instanceTypeHints{}
+			require.NoError(t, json.Unmarshal([]byte(data), &h))
+			hints[instanceType] = h
+		}
+		return hints
+	}
+
+	_, err := r.Reconcile(ctx, reconcile.Request{})
+	require.NoError(t, err)
+	value, ok := getAnnotation("gpu-node")
+	require.True(t, ok)
+	require.JSONEq(t, `{"nvidia.com/gpu": 8}`, value)
+	value, _ = getAnnotation("mig-node")
+	require.JSONEq(t, `{"nvidia.com/mig-1g.10gb": 56}`, value)
+	_, ok = getAnnotation("cpu-node")
+	require.False(t, ok)
+	require.Equal(t, map[string]instanceTypeHints{
+		"p4de.24xlarge": {
+			Product:     "NVIDIA-A100-SXM4-80GB",
+			GPUCount:    8,
+			Allocatable: map[string]int{"nvidia.com/gpu": 8},
+		},
+		"a2-ultragpu-8g": {
+			Product:     "NVIDIA-A100-SXM4-80GB",
+			GPUCount:    8,
+			MIGConfig:   "all-1g.10gb",
+			Allocatable: map[string]int{"nvidia.com/mig-1g.10gb": 56},
+		},
+	}, getHints())
+
+	// the hints of an instance type are kept once its nodes are scaled down to zero, and follow the
+	// changes of the ClusterPolicy
+	require.NoError(t, c.Delete(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "mig-node"}}))
+	cp := &gpuv1.ClusterPolicy{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: clusterPolicy.Name}, cp))
+	cp.Spec.MIG.Strategy = gpuv1.MIGStrategySingle
+	require.NoError(t, c.Update(ctx, cp))
+	_, err = r.Reconcile(ctx, reconcile.Request{})
+	require.NoError(t, err)
+	hints := getHints()
+	require.Len(t, hints, 2)
+	require.Equal(t, map[string]int{"nvidia.com/gpu": 56}, hints["a2-ultragpu-8g"].Allocatable)
+
+	// the annotations and the ConfigMap are removed when the autoscaling hints are disabled
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: clusterPolicy.Name}, cp))
+	cp.Spec.AutoscalingHints.Enabled = ptr.To(false)
+	require.NoError(t, c.Update(ctx, cp))
+	_, err = r.Reconcile(ctx, reconcile.Request{})
+	require.NoError(t, err)
+	_, ok = getAnnotation("gpu-node")
+	require.False(t, ok)
+	err = c.Get(ctx, types.NamespacedName{Namespace: "gpu-operator", Name: AutoscalingHintsConfigMapName}, &corev1.ConfigMap{})
+	require.True(t, apierrors.IsNotFound(err))
+}
+
+func TestNodeAutoscalingHintsSingleMIGStrategy(t *testing.T) {
+	var config migPartedConfig
+	require.NoError(t, yaml.Unmarshal([]byte(testMIGPartedConfig), &config))
+	spec := &gpuv1.ClusterPolicySpec{MIG: gpuv1.MIGSpec{Strategy: gpuv1.MIGStrategySingle}}
+
+	// GPU Feature Discovery counts the MIG devices of the GPUs partitioned with the single MIG strategy
+	h := nodeAutoscalingHints(spec, config.MIGConfigs, map[string]string{
+		gpuProductLabelKey: "NVIDIA-A100-SXM4-80GB-MIG-1g.10gb",
+		migConfigLabelKey:  "all-1g.10gb",
+	}, 56)
+	require.Equal(t, 8, h.GPUCount)
+	require.Equal(t, map[string]int{"nvidia.com/gpu": 56}, h.Allocatable)
+}
@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/go-logr/logr"
 
@@ -28,6 +29,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/workqueue"
 
 	"time"
@@ -35,6 +38,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -43,8 +47,16 @@ import (
 
 	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
 	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/apistats"
+	"github.com/NVIDIA/gpu-operator/internal/audit"
+	clusterpolicyutil "github.com/NVIDIA/gpu-operator/internal/clusterpolicy"
 	"github.com/NVIDIA/gpu-operator/internal/conditions"
 	"github.com/NVIDIA/gpu-operator/internal/consts"
+	"github.com/NVIDIA/gpu-operator/internal/introspection"
+	"github.com/NVIDIA/gpu-operator/internal/nodepolicy"
+	"github.com/NVIDIA/gpu-operator/internal/notification"
+	"github.com/NVIDIA/gpu-operator/internal/operatorconfig"
+	"github.com/NVIDIA/gpu-operator/internal/tracing"
 )
 
 const (
@@ -64,7 +76,28 @@ type ClusterPolicyReconciler struct {
 	Scheme           *runtime.Scheme
 	Namespace        string
 	OperatorMetrics  *OperatorMetrics
+	ReconcileOptions ReconcileOptions
+	// AssetsDir is the directory of the operand asset manifests, the baked-in assets are used when empty
+	AssetsDir string
+	// Introspection records the effective configuration of the operands served by the introspection endpoint,
+	// nothing is recorded when nil
+	Introspection *introspection.Store
+	// APIStats counts the requests of the reconciliations, nothing is counted when nil
+	APIStats *apistats.Tracker
+	// OperatorConfig holds the operator configuration applied at runtime, the flags apply when nil
+	OperatorConfig *operatorconfig.Store
+	// Config is the client configuration of the cluster of the GPU nodes, the configuration of the operator
+	// is used when nil
+	Config *rest.Config
+	// Notifier posts the lifecycle events of the GPU nodes to the sinks of the ClusterPolicy, nothing is posted
+	// when nil
+	Notifier *notification.Notifier
+	// NodeMutationPolicy is the set of node fields the operator is allowed to mutate, reported in the Ready
+	// condition when features are disabled by the policy
+	NodeMutationPolicy nodepolicy.Policy
+
 	conditionUpdater conditions.Updater
+	recorder         events.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=nvidia.com,resources=*,verbs=get;list;watch;create;update;patch;delete
@@ -74,6 +107,7 @@ type ClusterPolicyReconciler struct {
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles;clusterrolebindings;roles;rolebindings,verbs=*
 // +kubebuilder:rbac:groups="",resources=namespaces;serviceaccounts;pods;pods/eviction;services;services/finalizers;endpoints,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=persistentvolumeclaims;events;configmaps;secrets;nodes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=nodes/status,verbs=get;patch
 // +kubebuilder:rbac:groups=apps,resources=deployments;daemonsets;replicasets;statefulsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=get;list;watch
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;prometheusrules,verbs=get;list;watch;create;update;patch;delete
@@ -83,6 +117,12 @@ type ClusterPolicyReconciler struct {
 // +kubebuilder:rbac:groups=image.openshift.io,resources=imagestreams,verbs=get;list;watch
 // +kubebuilder:rbac:groups=node.k8s.io,resources=runtimeclasses,verbs=get;list;create;update;watch;delete
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=patch,resourceNames=clusterpolicies.nvidia.com
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations,verbs=get;list;watch
+// +kubebuilder:rbac:groups=nfd.k8s-sigs.io,resources=nodefeatures;nodefeaturerules;nodefeaturegroups,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=nfd.k8s-sigs.io,resources=nodefeaturegroups/status,verbs=patch;update
+// +kubebuilder:rbac:groups=k8s.cni.cncf.io,resources=network-attachment-definitions,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=sriovnetwork.openshift.io,resources=sriovnetworknodepolicies,verbs=get;list;watch;create;update;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -93,8 +133,13 @@ type ClusterPolicyReconciler struct {
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.7.0/pkg/reconcile
-func (r *ClusterPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *ClusterPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	_ = r.Log.WithValues("Reconciling ClusterPolicy", req.NamespacedName)
+	ctx = audit.WithReason(ctx, fmt.Sprintf("ClusterPolicy %s", req.Name))
+	ctx, done := r.APIStats.StartReconcile(ctx, "ClusterPolicy")
+	defer done()
+	ctx, span := tracing.Start(ctx, "reconcile ClusterPolicy", tracing.ObjectKey.String(req.Name))
+	defer func() { tracing.End(span, err) }()
 
 	// Fetch the ClusterPolicy instance
 	instance := &gpuv1.ClusterPolicy{}
@@ -115,6 +160,16 @@ func (r *ClusterPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return reconcile.Result{}, err
 	}
 
+	// the operands of a deleted ClusterPolicy held by the teardown finalizer are removed in stages
+	if !instance.DeletionTimestamp.IsZero() && controllerutil.ContainsFinalizer(instance, clusterPolicyTeardownFinalizer) {
+		return r.reconcileTeardown(ctx, instance, time.Now())
+	}
+
+	// ClusterPolicies with a node selector are deployed by the reconciliation of the main ClusterPolicy
+	if instance.IsScoped() {
+		return r.reconcileScoped(ctx, instance)
+	}
+
 	// TODO: Handle deletion of the main ClusterPolicy and cycle to the next one.
 	// We already have a main Clusterpolicy
 	if clusterPolicyCtrl.singleton != nil && clusterPolicyCtrl.singleton.Name != instance.Name {
@@ -124,6 +179,11 @@ func (r *ClusterPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, nil
 	}
 
+	if err := r.reconcileTeardownFinalizer(ctx, instance); err != nil {
+		r.Log.Error(err, "unable to reconcile the teardown finalizer")
+		return ctrl.Result{}, err
+	}
+
 	if err := clusterPolicyCtrl.init(ctx, r, instance); err != nil {
 		r.Log.Error(err, "unable to initialize ClusterPolicy controller")
 		if condErr := r.conditionUpdater.SetConditionsError(ctx, instance, conditions.ReconcileFailed, err.Error()); condErr != nil {
@@ -143,46 +203,166 @@ func (r *ClusterPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		r.Log.Info("No GPU node can be found in the cluster.")
 	}
 
+	if err := clusterPolicyCtrl.reconcileRegistryClient(ctx); err != nil {
+		r.Log.Error(err, "unable to configure the registry client")
+	}
+
+	if err := clusterPolicyCtrl.reconcileSafeMode(ctx); err != nil {
+		r.Log.Error(err, "unable to reconcile the safe mode")
+	}
+
+	if err := clusterPolicyCtrl.reconcileExternalGates(ctx, time.Now()); err != nil {
+		r.Log.Error(err, "unable to reconcile the external gates")
+		if condErr := r.conditionUpdater.SetConditionsError(ctx, instance, conditions.ReconcileFailed, err.Error()); condErr != nil {
+			r.Log.Error(condErr, "failed to set condition")
+		}
+		return ctrl.Result{}, err
+	}
+
+	if err := clusterPolicyCtrl.reconcileMissingReferences(ctx); err != nil {
+		r.Log.Error(err, "unable to resolve the ConfigMaps and Secrets referenced by the ClusterPolicy")
+	}
+
+	if err := clusterPolicyCtrl.reconcileInterference(ctx, time.Now()); err != nil {
+		r.Log.Error(err, "unable to scan the cluster for the sources of interference with the operands")
+	}
+
+	if err := clusterPolicyCtrl.reconcileRDMANetworks(ctx); err != nil {
+		r.Log.Error(err, "unable to reconcile the networks of the GPU-NIC pairs")
+	}
+
+	if err := clusterPolicyCtrl.reconcileMockGPUs(ctx); err != nil {
+		r.Log.Error(err, "unable to remove the mock GPUs of the nodes")
+	}
+
+	// the bootstrap taints are left on the nodes when the node mutation policy does not allow tainting them
+	if r.NodeMutationPolicy.AllowsSpec() {
+		if err := clusterPolicyCtrl.reconcileStartupTaints(ctx); err != nil {
+			r.Log.Error(err, "unable to remove the bootstrap taints of the validated nodes")
+		}
+	}
+
 	clusterPolicyCtrl.operatorMetrics.reconciliationTotal.Inc()
-	overallStatus := gpuv1.Ready
-	statesNotReady := []string{}
-	for {
-		status, statusError := clusterPolicyCtrl.step()
-		if statusError != nil {
-			clusterPolicyCtrl.operatorMetrics.reconciliationStatus.Set(reconciliationStatusNotReady)
-			clusterPolicyCtrl.operatorMetrics.reconciliationFailed.Inc()
-			updateCRState(ctx, r, req.NamespacedName, gpuv1.NotReady)
-			if condErr := r.conditionUpdater.SetConditionsError(ctx, instance, conditions.ReconcileFailed, fmt.Sprintf("Failed to reconcile %s: %s", clusterPolicyCtrl.stateNames[clusterPolicyCtrl.idx], statusError.Error())); condErr != nil {
-				r.Log.Error(condErr, "failed to set condition")
-			}
-			return ctrl.Result{}, statusError
+	overallStatus, statesNotReady, statusError := clusterPolicyCtrl.runStates()
+	if statusError != nil {
+		clusterPolicyCtrl.operatorMetrics.reconciliationStatus.Set(reconciliationStatusNotReady)
+		clusterPolicyCtrl.operatorMetrics.reconciliationFailed.Inc()
+		updateCRState(ctx, r, req.NamespacedName, gpuv1.NotReady)
+		reason := conditions.ReconcileFailed
+		message := fmt.Sprintf("Failed to reconcile %s: %s", clusterPolicyCtrl.stateNames[clusterPolicyCtrl.idx], statusError.Error())
+		// the states mounting a missing reference fail to render, report the missing reference instead
+		if missing := clusterPolicyCtrl.getMissingReferences(); len(missing) > 0 {
+			reason = conditions.MissingReference
+			message = fmt.Sprintf("%s, referenced objects not found: %s", message, strings.Join(missing, "; "))
 		}
+		if condErr := r.conditionUpdater.SetConditionsError(ctx, instance, reason, message); condErr != nil {
+			r.Log.Error(condErr, "failed to set condition")
+		}
+		return ctrl.Result{}, statusError
+	}
 
-		if status == gpuv1.NotReady {
-			overallStatus = gpuv1.NotReady
-			statesNotReady = append(statesNotReady, clusterPolicyCtrl.stateNames[clusterPolicyCtrl.idx-1])
+	// report the state of the operands deployed for the scoped ClusterPolicies
+	for _, scoped := range clusterPolicyCtrl.scopedPolicies {
+		state := gpuv1.Ready
+		if scopeState, ok := clusterPolicyCtrl.scopeStates[scoped.Name]; ok {
+			state = scopeState
 		}
-		r.Log.Info("ClusterPolicy step completed",
-			"state:", clusterPolicyCtrl.stateNames[clusterPolicyCtrl.idx-1],
-			"status", status)
+		updateCRState(ctx, r, types.NamespacedName{Name: scoped.Name}, state)
+	}
 
-		if clusterPolicyCtrl.last() {
-			break
+	// report the components reported as ready without being validated
+	updateSkippedValidations(ctx, r, req.NamespacedName, instance.Spec.Validator.GetSkippedComponents())
+
+	// report validation results per failure domain, so that a failing zone or rack can be identified
+	var failedValidationDomains []string
+	var reportRequeueAfter time.Duration
+	now := time.Now()
+	if clusterPolicyCtrl.hasGPUNodes {
+		validationDomains, err := clusterPolicyCtrl.getValidationDomains(ctx)
+		if err != nil {
+			r.Log.Error(err, "unable to get validation results per failure domain")
+		} else {
+			updateValidationDomains(ctx, r, req.NamespacedName, validationDomains)
+			failedValidationDomains = getFailedValidationDomains(validationDomains)
+		}
+
+		nodeStatuses, err := clusterPolicyCtrl.getNodeOperandStatuses(ctx)
+		if err != nil {
+			r.Log.Error(err, "unable to get the operand status of GPU nodes")
+		} else {
+			readinessRequeueAfter := clusterPolicyCtrl.debounceNodeOperandStatuses(nodeStatuses, now)
+			updateNodeOperandStatuses(ctx, r, req.NamespacedName, nodeStatuses)
+
+			reportRequeueAfter, err = clusterPolicyCtrl.reconcileFleetReport(ctx, nodeStatuses, time.Now())
+			if err != nil {
+				r.Log.Error(err, "unable to generate the GPU fleet report")
+			}
+			reportRequeueAfter = earliestRequeue(reportRequeueAfter, readinessRequeueAfter)
+		}
+
+		usageReportRequeueAfter, err := clusterPolicyCtrl.reconcileUsageAccounting(ctx, time.Now())
+		if err != nil {
+			r.Log.Error(err, "unable to generate the GPU usage report")
+		}
+		if usageReportRequeueAfter > 0 && (reportRequeueAfter == 0 || usageReportRequeueAfter < reportRequeueAfter) {
+			reportRequeueAfter = usageReportRequeueAfter
 		}
 	}
 
-	// if any state is not ready, requeue for reconcile after 5 seconds
+	licenseMonitorRequeueAfter, err := clusterPolicyCtrl.reconcileLicenseMonitor(ctx, time.Now())
+	if err != nil {
+		r.Log.Error(err, "unable to monitor the vGPU license server")
+	}
+	reportRequeueAfter = earliestRequeue(reportRequeueAfter, licenseMonitorRequeueAfter)
+
+	// keep reporting the previous readiness of the ClusterPolicy until a readiness change outlasts the
+	// readiness hysteresis, so that a restarting operand does not flap the state and the conditions
+	if ready, pendingFor := clusterPolicyCtrl.debounceReady(overallStatus == gpuv1.Ready, now); ready != (overallStatus == gpuv1.Ready) {
+		r.Log.Info("ClusterPolicy readiness change pending until the readiness hysteresis elapses",
+			"ready", overallStatus == gpuv1.Ready, "statesNotReady", statesNotReady, "requeueAfter", pendingFor)
+		return ctrl.Result{RequeueAfter: earliestRequeue(reportRequeueAfter, pendingFor)}, nil
+	}
+
+	// if any state is not ready, requeue for reconcile after the requeue interval
 	if overallStatus != gpuv1.Ready {
 		clusterPolicyCtrl.operatorMetrics.reconciliationStatus.Set(reconciliationStatusNotReady)
 		clusterPolicyCtrl.operatorMetrics.reconciliationFailed.Inc()
 
 		err := fmt.Errorf("ClusterPolicy is not ready, states not ready: %v", statesNotReady)
+		if len(failedValidationDomains) > 0 {
+			err = fmt.Errorf("%w, failure domains with nodes not validated: %v", err, failedValidationDomains)
+		}
+		reason := conditions.OperandNotReady
+		if clusterPolicyCtrl.safeMode {
+			reason = conditions.OperandCrashLoop
+			err = fmt.Errorf("%w, operand rollouts are stopped by the safe mode until the %s annotation is set", err, safeModeAcknowledgedAnnotationKey)
+		}
+		if blocked := clusterPolicyCtrl.getBlockedStates(); len(blocked) > 0 {
+			reason = conditions.PrerequisiteNotReady
+			err = fmt.Errorf("%w, states skipped until their prerequisites are ready: %s", err, strings.Join(blocked, "; "))
+		}
+		if missing := clusterPolicyCtrl.getMissingKataRuntimeClasses(); len(missing) > 0 {
+			reason = conditions.KataRuntimeClassMissing
+			err = fmt.Errorf("%w, Kata RuntimeClasses not found: %v", err, missing)
+		}
+		if missing := clusterPolicyCtrl.getMissingReferences(); len(missing) > 0 {
+			reason = conditions.MissingReference
+			err = fmt.Errorf("%w, referenced objects not found: %s", err, strings.Join(missing, "; "))
+		}
+		if rejections := clusterPolicyCtrl.getPodSecurityRejections(); len(rejections) > 0 {
+			reason = conditions.PodSecurityRejected
+			err = fmt.Errorf("%w, operand pods rejected by PodSecurity admission in namespace %s: %s",
+				err, clusterPolicyCtrl.operatorNamespace, strings.Join(rejections, "; "))
+			if !instance.Spec.PSA.IsManageNamespaceLabelsEnabled() && !instance.Spec.PSA.IsEnabled() {
+				err = fmt.Errorf("%w; set psa.manageNamespaceLabels to label the namespace with the privileged level", err)
+			}
+		}
 		r.Log.Error(err, "ClusterPolicy not yet ready")
 		updateCRState(ctx, r, req.NamespacedName, gpuv1.NotReady)
-		if condErr := r.conditionUpdater.SetConditionsError(ctx, instance, conditions.OperandNotReady, err.Error()); condErr != nil {
+		if condErr := r.conditionUpdater.SetConditionsError(ctx, instance, reason, err.Error()); condErr != nil {
 			r.Log.Error(condErr, "failed to set condition")
 		}
-		return ctrl.Result{RequeueAfter: time.Second * 5}, nil
+		return ctrl.Result{RequeueAfter: r.requeueInterval()}, nil
 	}
 
 	if !clusterPolicyCtrl.hasNFDLabels {
@@ -216,6 +396,20 @@ func (r *ClusterPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 			r.Log.Error(condErr, "failed to set condition")
 			return ctrl.Result{}, condErr
 		}
+	} else if unavailableAPIs := clusterPolicyCtrl.getUnavailableAPIs(); len(unavailableAPIs) > 0 {
+		infoStr = fmt.Sprintf("ClusterPolicy is ready, skipped resources of optional integrations whose APIs are unavailable: %s", strings.Join(unavailableAPIs, ", "))
+		r.Log.Info(infoStr)
+		if condErr := r.conditionUpdater.SetConditionsReady(ctx, instance, conditions.OptionalAPIUnavailable, infoStr); condErr != nil {
+			r.Log.Error(condErr, "failed to set condition")
+			return ctrl.Result{}, condErr
+		}
+	} else if disabledFeatures := r.NodeMutationPolicy.DisabledFeatures(); len(disabledFeatures) > 0 {
+		infoStr = fmt.Sprintf("ClusterPolicy is ready, features disabled by the %s node mutation policy: %s", r.NodeMutationPolicy, strings.Join(disabledFeatures, ", "))
+		r.Log.Info(infoStr)
+		if condErr := r.conditionUpdater.SetConditionsReady(ctx, instance, conditions.NodeMutationRestricted, infoStr); condErr != nil {
+			r.Log.Error(condErr, "failed to set condition")
+			return ctrl.Result{}, condErr
+		}
 	} else {
 		infoStr = "ClusterPolicy is ready as all resources have been successfully reconciled"
 		r.Log.Info(infoStr)
@@ -224,7 +418,49 @@ func (r *ClusterPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 			return ctrl.Result{}, condErr
 		}
 	}
-	return ctrl.Result{}, nil
+	// requeue when the next fleet or usage report is due, if enabled
+	return ctrl.Result{RequeueAfter: reportRequeueAfter}, nil
+}
+
+// reconcileScoped validates the node selector of the scoped ClusterPolicy against the older scoped
+// ClusterPolicies, and reconciles the main ClusterPolicy, which deploys the operands of every valid scoped
+// ClusterPolicy on its nodes
+func (r *ClusterPolicyReconciler) reconcileScoped(ctx context.Context, instance *gpuv1.ClusterPolicy) (ctrl.Result, error) {
+	namespacedName := types.NamespacedName{Name: instance.Name}
+
+	list := &gpuv1.ClusterPolicyList{}
+	if err := r.List(ctx, list); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list ClusterPolicy CRs: %w", err)
+	}
+	// the scoped ClusterPolicies are honored as by the node labeling and the main ClusterPolicy, the oldest
+	// winning the nodes matched by several of them
+	_, skippedScopes, err := clusterpolicyutil.HonoredScopedPolicies(ctx, r.Client, list.Items)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	for _, skipped := range skippedScopes {
+		if skipped.Name != instance.Name {
+			continue
+		}
+		r.Log.Error(skipped.Reason, "invalid node selector of ClusterPolicy", "name", instance.Name)
+		if r.recorder != nil {
+			r.recorder.Eventf(instance, nil, corev1.EventTypeWarning, "InvalidNodeSelector", "Reconcile",
+				"The node selector of the ClusterPolicy is not honored: %s", skipped.Reason.Error())
+		}
+		updateCRState(ctx, r, namespacedName, gpuv1.NotReady)
+		if condErr := r.conditionUpdater.SetConditionsError(ctx, instance, conditions.ReconcileFailed, skipped.Reason.Error()); condErr != nil {
+			r.Log.Error(condErr, "failed to set condition")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if clusterPolicyCtrl.singleton == nil {
+		var requeueAfter = time.Second * 5
+		r.Log.Info("ClusterPolicy without nodeSelector not reconciled yet, requeueing the scoped ClusterPolicy",
+			"name", instance.Name, "requeueAfter", requeueAfter)
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+	return r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: clusterPolicyCtrl.singleton.Name}})
 }
 
 func updateCRState(ctx context.Context, r *ClusterPolicyReconciler, namespacedName types.NamespacedName, state gpuv1.State) {
@@ -268,16 +504,9 @@ func (r *ClusterPolicyReconciler) enqueueAllClusterPolicies(ctx context.Context)
 	return cpToRec
 }
 
-func addWatchNewGPUNode(r *ClusterPolicyReconciler, c controller.Controller, mgr ctrl.Manager) error {
-	// Define a mapping from the Node object in the event to one or more
-	// ClusterPolicy objects to Reconcile
-	mapFn := func(ctx context.Context, n *corev1.Node) []reconcile.Request {
-		cpToRec := r.enqueueAllClusterPolicies(ctx)
-		r.Log.Info("Reconciliate ClusterPolicies after node label update", "nb", len(cpToRec))
-		return cpToRec
-	}
-
-	p := predicate.TypedFuncs[*corev1.Node]{
+// gpuNodePredicate filters the node changes affecting the rendering of the operands
+func gpuNodePredicate(log logr.Logger) predicate.TypedPredicate[*corev1.Node] {
+	return predicate.TypedFuncs[*corev1.Node]{
 		CreateFunc: func(e event.TypedCreateEvent[*corev1.Node]) bool {
 			labels := e.Object.GetLabels()
 
@@ -296,6 +525,9 @@ func addWatchNewGPUNode(r *ClusterPolicyReconciler, c controller.Controller, mgr
 			newGPUWorkloadConfig, _ := getWorkloadConfig(newLabels, true)
 			gpuWorkloadConfigLabelChanged := oldGPUWorkloadConfig != newGPUWorkloadConfig
 
+			// The sandbox mode label selects the sandbox device plugin rendered for the node.
+			sandboxModeLabelChanged := oldLabels[gpuSandboxModeLabelKey] != newLabels[gpuSandboxModeLabelKey]
+
 			oldOSTreeLabel := oldLabels[nfdOSTreeVersionLabelKey]
 			newOSTreeLabel := newLabels[nfdOSTreeVersionLabelKey]
 			osTreeLabelChanged := oldOSTreeLabel != newOSTreeLabel
@@ -304,20 +536,54 @@ func addWatchNewGPUNode(r *ClusterPolicyReconciler, c controller.Controller, mgr
 			// on operand DaemonSets, so re-render when it lands or changes.
 			modeLabelChanged := oldLabels[consts.GPUAllocationModeLabelKey] != newLabels[consts.GPUAllocationModeLabelKey]
 
+			// The owner label moves the node between the DaemonSets of the scoped ClusterPolicies.
+			ownerLabelChanged := oldLabels[consts.ClusterPolicyOwnerLabel] != newLabels[consts.ClusterPolicyOwnerLabel]
+
+			// The tenant label moves the node between the DaemonSets of the tenant namespaces.
+			tenantLabelChanged := oldLabels[consts.TenantNamespaceLabel] != newLabels[consts.TenantNamespaceLabel]
+
+			// The consumer GPU label blocks the reconciliation when consumer GPUs are blocked.
+			consumerLabelChanged := oldLabels[consts.ConsumerGPULabel] != newLabels[consts.ConsumerGPULabel]
+
+			// The container runtime label moves the node between the DaemonSets of the container runtimes.
+			runtimeLabelChanged := oldLabels[consts.ContainerRuntimeLabelKey] != newLabels[consts.ContainerRuntimeLabelKey]
+
+			// The pending operands opt-outs are reported in the node statuses.
+			operandsOptOutChanged := e.ObjectOld.GetAnnotations()[consts.OperandsDisableAfterAnnotationKey] !=
+				e.ObjectNew.GetAnnotations()[consts.OperandsDisableAfterAnnotationKey]
+
+			// The RDMA topology of the node selects the NICs of the networks of the GPU-NIC pairs.
+			rdmaTopologyChanged := e.ObjectOld.GetAnnotations()[consts.GPURDMATopologyAnnotationKey] !=
+				e.ObjectNew.GetAnnotations()[consts.GPURDMATopologyAnnotationKey]
+
 			needsUpdate := gpuCommonLabelAdded ||
 				commonOperandsLabelChanged ||
 				gpuWorkloadConfigLabelChanged ||
+				sandboxModeLabelChanged ||
 				osTreeLabelChanged ||
-				modeLabelChanged
+				modeLabelChanged ||
+				ownerLabelChanged ||
+				tenantLabelChanged ||
+				consumerLabelChanged ||
+				runtimeLabelChanged ||
+				operandsOptOutChanged ||
+				rdmaTopologyChanged
 
 			if needsUpdate {
-				r.Log.Info("Node needs an update",
+				log.Info("Node needs an update",
 					"name", nodeName,
 					"gpuCommonLabelAdded", gpuCommonLabelAdded,
 					"commonOperandsLabelChanged", commonOperandsLabelChanged,
 					"gpuWorkloadConfigLabelChanged", gpuWorkloadConfigLabelChanged,
+					"sandboxModeLabelChanged", sandboxModeLabelChanged,
 					"osTreeLabelChanged", osTreeLabelChanged,
 					"modeLabelChanged", modeLabelChanged,
+					"ownerLabelChanged", ownerLabelChanged,
+					"tenantLabelChanged", tenantLabelChanged,
+					"consumerLabelChanged", consumerLabelChanged,
+					"runtimeLabelChanged", runtimeLabelChanged,
+					"operandsOptOutChanged", operandsOptOutChanged,
+					"rdmaTopologyChanged", rdmaTopologyChanged,
 				)
 			}
 			return needsUpdate
@@ -337,31 +603,50 @@ func addWatchNewGPUNode(r *ClusterPolicyReconciler, c controller.Controller, mgr
 			return hasGPULabels(labels) && hasOSTreeLabel
 		},
 	}
+}
 
-	err := c.Watch(
-		source.Kind(mgr.GetCache(),
-			&corev1.Node{},
-			handler.TypedEnqueueRequestsFromMapFunc[*corev1.Node](mapFn),
-			p,
-		),
-	)
+// addWatchNodeInventory maintains the node inventory of the ClusterPolicy controller from the shared Node
+// informer, and requeues all ClusterPolicies on the node changes sent by the inventory. The controller thus
+// does not watch nor list all the nodes itself, while the node labels are written by NodeLabelingReconciler.
+func addWatchNodeInventory(ctx context.Context, r *ClusterPolicyReconciler, c controller.Controller, mgr ctrl.Manager) error {
+	// Define a mapping from the Node object in the event to one or more
+	// ClusterPolicy objects to Reconcile
+	mapFn := func(ctx context.Context, n *corev1.Node) []reconcile.Request {
+		cpToRec := r.enqueueAllClusterPolicies(ctx)
+		r.Log.Info("Reconciliate ClusterPolicies after node label update", "nb", len(cpToRec))
+		return cpToRec
+	}
+
+	nodes := newNodeInventory(gpuNodePredicate(r.Log))
+	informer, err := mgr.GetCache().GetInformer(ctx, &corev1.Node{})
+	if err != nil {
+		return fmt.Errorf("failed to get the Node informer: %w", err)
+	}
+	registration, err := informer.AddEventHandler(nodes)
+	if err != nil {
+		return fmt.Errorf("failed to add the node inventory event handler: %w", err)
+	}
+	nodes.hasSynced = registration.HasSynced
+	clusterPolicyCtrl.nodes = nodes
 
-	return err
+	return c.Watch(source.Channel(nodes.events, handler.TypedEnqueueRequestsFromMapFunc[*corev1.Node](mapFn)))
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterPolicyReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
 	// Create a new controller
 	c, err := controller.New("clusterpolicy-controller", mgr, controller.Options{Reconciler: r, MaxConcurrentReconciles: 1,
-		RateLimiter: workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](minDelayCR, maxDelayCR)})
+		RateLimiter: workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](minDelayCR, r.ReconcileOptions.getMaxErrorBackoff())})
 	if err != nil {
 		return err
 	}
 
 	clusterPolicyCtrl.operatorMetrics = r.OperatorMetrics
+	clusterPolicyCtrl.apiReader = mgr.GetAPIReader()
 
 	// initialize condition updater
 	r.conditionUpdater = conditions.NewClusterPolicyUpdater(mgr.GetClient())
+	r.recorder = mgr.GetEventRecorder("nvidia-gpu-operator")
 
 	// Watch for changes to primary resource ClusterPolicy
 	err = c.Watch(source.Kind(
@@ -376,7 +661,13 @@ func (r *ClusterPolicyReconciler) SetupWithManager(ctx context.Context, mgr ctrl
 	}
 
 	// Watch for changes to Node labels and requeue the owner ClusterPolicy
-	err = addWatchNewGPUNode(r, c, mgr)
+	err = addWatchNodeInventory(ctx, r, c, mgr)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the image pull secrets of the service accounts and requeue the ClusterPolicies
+	err = addWatchPullSecretServiceAccounts(r, c, mgr)
 	if err != nil {
 		return err
 	}
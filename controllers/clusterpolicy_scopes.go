@@ -0,0 +1,187 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/audit"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+// scopeOwner returns the value of the owner label of the nodes and DaemonSets of the ClusterPolicy being
+// reconciled, which is empty for the singleton
+func (n ClusterPolicyController) scopeOwner() string {
+	if n.scopedPolicy == nil {
+		return ""
+	}
+	return n.scopedPolicy.Name
+}
+
+// ownsNode returns true if the node with the given labels is managed by the ClusterPolicy being reconciled.
+// The singleton manages all GPU nodes as long as no scoped ClusterPolicy exists.
+func (n ClusterPolicyController) ownsNode(labels map[string]string) bool {
+	if n.scopedPolicy == nil && len(n.scopedPolicies) == 0 {
+		return true
+	}
+	return labels[consts.ClusterPolicyOwnerLabel] == n.scopeOwner()
+}
+
+// ownsObject returns true if the DaemonSet or pod with the given labels was deployed for the ClusterPolicy
+// being reconciled
+func (n ClusterPolicyController) ownsObject(labels map[string]string) bool {
+	return labels[consts.ClusterPolicyOwnerLabel] == n.scopeOwner()
+}
+
+// scopedDaemonSets deploys the DaemonSet of the current state for the nodes of the singleton, then for the
+// nodes of every scoped ClusterPolicy as configured by the scoped ClusterPolicy. The state of the DaemonSets
+// of the scoped ClusterPolicies is reported in their own status, and does not affect the returned state.
+func scopedDaemonSets(n ClusterPolicyController) (gpuv1.State, error) {
+	n.scopeResolved = true
+	overallState, err := DaemonSet(n)
+	if err != nil {
+		return overallState, err
+	}
+
+	stateName := n.stateNames[n.idx]
+	for i := range n.scopedPolicies {
+		scoped := n
+		scoped.singleton = &n.scopedPolicies[i]
+		scoped.scopedPolicy = &n.scopedPolicies[i]
+		name := scoped.scopedPolicy.Name
+		logger := n.logger.WithValues("ClusterPolicy", name, "state", stateName)
+
+		if !scoped.isStateEnabled(stateName) {
+			if err := scoped.deleteScopedDaemonSets(); err != nil {
+				return gpuv1.NotReady, err
+			}
+			n.introspection.Forget(name, stateName)
+			continue
+		}
+
+		pullSecrets, err := resolveImagePullSecrets(n.ctx, n.client, logger, n.operatorNamespace, scoped.singleton.Spec.ImagePullSecrets)
+		if err != nil {
+			return gpuv1.NotReady, err
+		}
+		scoped.pullSecrets = pullSecrets
+
+		scoped.preStagedKernelVersions = nil
+		if scoped.singleton.Spec.Driver.IsEnabled() && scoped.singleton.Spec.Driver.UsePrecompiledDrivers() {
+			kernelVersionMap, err := scoped.getKernelVersionsMap()
+			if err != nil {
+				return gpuv1.NotReady, fmt.Errorf("unable to obtain the kernel versions of the GPU nodes of ClusterPolicy %s: %w", name, err)
+			}
+			scoped.kernelVersionMap = kernelVersionMap
+		}
+
+		state, err := DaemonSet(scoped)
+		if err != nil {
+			logger.Error(err, "Failed to deploy the DaemonSet of the scoped ClusterPolicy")
+			n.scopeStates[name] = gpuv1.NotReady
+			continue
+		}
+		if state == gpuv1.NotReady {
+			n.scopeStates[name] = gpuv1.NotReady
+		}
+	}
+	return overallState, nil
+}
+
+// deleteScopedDaemonSets deletes the DaemonSets of the current state deployed for the scoped ClusterPolicy
+// being reconciled
+func (n ClusterPolicyController) deleteScopedDaemonSets() error {
+	opts := []client.ListOption{
+		client.InNamespace(n.operatorNamespace),
+		client.MatchingLabels{
+			appLabelKey:                    n.resources[n.idx].DaemonSet.Labels[appLabelKey],
+			consts.ClusterPolicyOwnerLabel: n.scopeOwner(),
+		},
+	}
+	list := &appsv1.DaemonSetList{}
+	if err := n.client.List(n.ctx, list, opts...); err != nil {
+		return fmt.Errorf("unable to list the DaemonSets of ClusterPolicy %s: %w", n.scopeOwner(), err)
+	}
+	for i := range list.Items {
+		err := n.client.Delete(audit.WithReason(n.ctx, "state disabled"), &list.Items[i])
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyClusterPolicyScope restricts the DaemonSet to the nodes of the ClusterPolicy being reconciled. The
+// DaemonSet of a scoped ClusterPolicy is named and labeled after it, and only scheduled on the nodes labeled
+// with its name, while the DaemonSet of the singleton is kept off these nodes.
+func applyClusterPolicyScope(obj *appsv1.DaemonSet, n ClusterPolicyController) {
+	if n.scopedPolicy == nil {
+		if len(n.scopedPolicies) > 0 {
+			addRequiredNodeSelectorRequirement(&obj.Spec.Template.Spec, corev1.NodeSelectorRequirement{
+				Key:      consts.ClusterPolicyOwnerLabel,
+				Operator: corev1.NodeSelectorOpDoesNotExist,
+			})
+		}
+		return
+	}
+
+	owner := n.scopeOwner()
+	obj.Name += "-" + owner
+	if obj.Labels == nil {
+		obj.Labels = make(map[string]string)
+	}
+	obj.Labels[consts.ClusterPolicyOwnerLabel] = owner
+	if obj.Spec.Selector.MatchLabels == nil {
+		obj.Spec.Selector.MatchLabels = make(map[string]string)
+	}
+	obj.Spec.Selector.MatchLabels[consts.ClusterPolicyOwnerLabel] = owner
+	if obj.Spec.Template.Labels == nil {
+		obj.Spec.Template.Labels = make(map[string]string)
+	}
+	obj.Spec.Template.Labels[consts.ClusterPolicyOwnerLabel] = owner
+	if obj.Spec.Template.Spec.NodeSelector == nil {
+		obj.Spec.Template.Spec.NodeSelector = make(map[string]string)
+	}
+	obj.Spec.Template.Spec.NodeSelector[consts.ClusterPolicyOwnerLabel] = owner
+}
+
+// addRequiredNodeSelectorRequirement adds the requirement to every term of the required node affinity of the
+// pod, as the terms are ORed
+func addRequiredNodeSelectorRequirement(podSpec *corev1.PodSpec, requirement corev1.NodeSelectorRequirement) {
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+	if podSpec.Affinity.NodeAffinity == nil {
+		podSpec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	nodeAffinity := podSpec.Affinity.NodeAffinity
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	required := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(required.NodeSelectorTerms) == 0 {
+		required.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+	}
+	for i := range required.NodeSelectorTerms {
+		required.NodeSelectorTerms[i].MatchExpressions = append(required.NodeSelectorTerms[i].MatchExpressions, requirement)
+	}
+}
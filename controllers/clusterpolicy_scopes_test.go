@@ -0,0 +1,92 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+func newScopedClusterPolicy(name string) gpuv1.ClusterPolicy {
+	return gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       gpuv1.ClusterPolicySpec{NodeSelector: map[string]string{"pool": name}},
+	}
+}
+
+func TestOwnsNode(t *testing.T) {
+	scoped := []gpuv1.ClusterPolicy{newScopedClusterPolicy("team-a")}
+	ownedByTeamA := map[string]string{consts.ClusterPolicyOwnerLabel: "team-a"}
+
+	require.True(t, ClusterPolicyController{}.ownsNode(ownedByTeamA))
+	require.False(t, ClusterPolicyController{scopedPolicies: scoped}.ownsNode(ownedByTeamA))
+	require.True(t, ClusterPolicyController{scopedPolicies: scoped}.ownsNode(map[string]string{}))
+	require.True(t, ClusterPolicyController{scopedPolicies: scoped, scopedPolicy: &scoped[0]}.ownsNode(ownedByTeamA))
+	require.False(t, ClusterPolicyController{scopedPolicies: scoped, scopedPolicy: &scoped[0]}.ownsNode(map[string]string{}))
+}
+
+func TestApplyClusterPolicyScope(t *testing.T) {
+	newDaemonSet := func() *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "nvidia-device-plugin-daemonset"},
+			Spec: appsv1.DaemonSetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nvidia-device-plugin-daemonset"}},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "nvidia-device-plugin-daemonset"}},
+					Spec:       corev1.PodSpec{NodeSelector: map[string]string{"nvidia.com/gpu.deploy.device-plugin": "true"}},
+				},
+			},
+		}
+	}
+	scoped := []gpuv1.ClusterPolicy{newScopedClusterPolicy("team-a")}
+
+	// singleton without scoped ClusterPolicies
+	ds := newDaemonSet()
+	applyClusterPolicyScope(ds, ClusterPolicyController{})
+	require.Equal(t, newDaemonSet(), ds)
+
+	// singleton next to scoped ClusterPolicies
+	ds = newDaemonSet()
+	applyClusterPolicyScope(ds, ClusterPolicyController{scopedPolicies: scoped})
+	require.Equal(t, "nvidia-device-plugin-daemonset", ds.Name)
+	require.Equal(t, []corev1.NodeSelectorTerm{{
+		MatchExpressions: []corev1.NodeSelectorRequirement{{
+			Key:      consts.ClusterPolicyOwnerLabel,
+			Operator: corev1.NodeSelectorOpDoesNotExist,
+		}},
+	}}, ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+
+	// scoped ClusterPolicy
+	ds = newDaemonSet()
+	applyClusterPolicyScope(ds, ClusterPolicyController{scopedPolicies: scoped, scopedPolicy: &scoped[0]})
+	require.Equal(t, "nvidia-device-plugin-daemonset-team-a", ds.Name)
+	require.Equal(t, "team-a", ds.Labels[consts.ClusterPolicyOwnerLabel])
+	require.Equal(t, "team-a", ds.Spec.Selector.MatchLabels[consts.ClusterPolicyOwnerLabel])
+	require.Equal(t, "team-a", ds.Spec.Template.Labels[consts.ClusterPolicyOwnerLabel])
+	require.Equal(t, map[string]string{
+		"nvidia.com/gpu.deploy.device-plugin": "true",
+		consts.ClusterPolicyOwnerLabel:        "team-a",
+	}, ds.Spec.Template.Spec.NodeSelector)
+	require.Nil(t, ds.Spec.Template.Spec.Affinity)
+}
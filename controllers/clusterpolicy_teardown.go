@@ -0,0 +1,363 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/audit"
+	"github.com/NVIDIA/gpu-operator/internal/utils"
+)
+
+const (
+	// clusterPolicyTeardownFinalizer holds the ClusterPolicy until its operands are removed in stages
+	clusterPolicyTeardownFinalizer = "clusterpolicy.nvidia.com/teardown"
+	// teardownPollInterval is the interval the progress of the current teardown phase is checked at
+	teardownPollInterval = 5 * time.Second
+	// moduleCheckAppLabelValue labels the pods verifying that the NVIDIA kernel modules are unloaded from the GPU
+	// nodes, which are named after it
+	moduleCheckAppLabelValue = "nvidia-module-unload-check"
+	// moduleCheckScript fails while NVIDIA kernel modules are loaded, /proc/modules listing the modules of the host
+	moduleCheckScript = `if grep -q '^nvidia' /proc/modules; then grep '^nvidia' /proc/modules | cut -d' ' -f1; exit 1; fi`
+)
+
+// reconcileTeardownFinalizer adds the teardown finalizer to the ClusterPolicy when the staged teardown is
+// enabled, and removes it once the staged teardown is disabled
+func (r *ClusterPolicyReconciler) reconcileTeardownFinalizer(ctx context.Context, instance *gpuv1.ClusterPolicy) error {
+	enabled := instance.Spec.Teardown.IsEnabled()
+	if enabled == controllerutil.ContainsFinalizer(instance, clusterPolicyTeardownFinalizer) {
+		return nil
+	}
+	if enabled {
+		controllerutil.AddFinalizer(instance, clusterPolicyTeardownFinalizer)
+	} else {
+		controllerutil.RemoveFinalizer(instance, clusterPolicyTeardownFinalizer)
+	}
+	if err := r.Update(ctx, instance); err != nil {
+		return fmt.Errorf("error updating the teardown finalizer: %w", err)
+	}
+	return nil
+}
+
+// teardownPhases returns the phases of the staged teardown, in order
+func teardownPhases(spec *gpuv1.TeardownSpec) []gpuv1.TeardownPhase {
+	var phases []gpuv1.TeardownPhase
+	if spec.IsEvictGPUWorkloadsEnabled() {
+		phases = append(phases, gpuv1.TeardownEvictingWorkloads)
+	}
+	return append(phases,
+		gpuv1.TeardownRemovingDevicePlugin,
+		gpuv1.TeardownRemovingToolkit,
+		gpuv1.TeardownRemovingDriver,
+		gpuv1.TeardownVerifyingModuleUnload,
+	)
+}
+
+// daemonSetTeardownPhase returns the teardown phase removing the operand DaemonSet of the given name. The
+// device plugin is removed along with the other operands using the GPUs, ahead of the container toolkit and
+// of the driver.
+func daemonSetTeardownPhase(name string) gpuv1.TeardownPhase {
+	switch {
+	case strings.HasPrefix(name, commonDriverDaemonsetName), strings.HasPrefix(name, commonVGPUManagerDaemonsetName):
+		return gpuv1.TeardownRemovingDriver
+	case strings.HasPrefix(name, containerToolkitAppLabelValue):
+		return gpuv1.TeardownRemovingToolkit
+	}
+	return gpuv1.TeardownRemovingDevicePlugin
+}
+
+// reconcileTeardown removes the operands of the deleted ClusterPolicy in stages, moving on to the next phase
+// once the current phase is complete or timed out, and releases the ClusterPolicy after the last phase. The
+// progress is reported in the status of the ClusterPolicy.
+func (r *ClusterPolicyReconciler) reconcileTeardown(ctx context.Context, instance *gpuv1.ClusterPolicy, now time.Time) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(instance, clusterPolicyTeardownFinalizer) {
+		return ctrl.Result{}, nil
+	}
+	ctx = audit.WithReason(ctx, "ClusterPolicy teardown")
+
+	// only the operands of the main ClusterPolicy are removed in stages
+	if instance.IsScoped() || (clusterPolicyCtrl.singleton != nil && clusterPolicyCtrl.singleton.Name != instance.Name) {
+		return ctrl.Result{}, r.removeTeardownFinalizer(ctx, instance)
+	}
+
+	spec := instance.Spec.Teardown
+	phases := teardownPhases(spec)
+	status := instance.Status.Teardown.DeepCopy()
+	if status == nil || !slices.Contains(phases, status.Phase) {
+		status = &gpuv1.TeardownStatus{Phase: phases[0], PhaseStartTime: metav1.NewTime(now)}
+		r.recordTeardownPhase(instance, status.Phase)
+	}
+
+	for {
+		pending, err := r.runTeardownPhase(ctx, instance, status.Phase)
+		if err != nil {
+			updateTeardownStatus(ctx, r, instance.Name, status)
+			return ctrl.Result{}, fmt.Errorf("teardown phase %s failed: %w", status.Phase, err)
+		}
+		if len(pending) > 0 {
+			if now.Sub(status.PhaseStartTime.Time) < spec.GetPhaseTimeout() {
+				status.Pending = pending
+				updateTeardownStatus(ctx, r, instance.Name, status)
+				return ctrl.Result{RequeueAfter: teardownPollInterval}, nil
+			}
+			r.Log.Info("Teardown phase timed out, moving on to the next phase", "phase", status.Phase, "pending", pending)
+			r.recorder.Eventf(instance, nil, corev1.EventTypeWarning, "TeardownPhaseTimedOut", "Teardown",
+				"Teardown phase %s timed out after %s, still waiting for: %s", status.Phase, spec.GetPhaseTimeout(),
+				strings.Join(pending, ", "))
+		}
+
+		next := slices.Index(phases, status.Phase) + 1
+		if next == len(phases) {
+			break
+		}
+		status = &gpuv1.TeardownStatus{Phase: phases[next], PhaseStartTime: metav1.NewTime(now)}
+		r.recordTeardownPhase(instance, status.Phase)
+	}
+
+	if err := r.deleteModuleCheckPods(ctx); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.recorder.Eventf(instance, nil, corev1.EventTypeNormal, "TeardownCompleted", "Teardown",
+		"Operands of ClusterPolicy %s removed", instance.Name)
+	return ctrl.Result{}, r.removeTeardownFinalizer(ctx, instance)
+}
+
+// recordTeardownPhase records the start of a teardown phase
+func (r *ClusterPolicyReconciler) recordTeardownPhase(instance *gpuv1.ClusterPolicy, phase gpuv1.TeardownPhase) {
+	r.Log.Info("Starting teardown phase", "ClusterPolicy", instance.Name, "phase", phase)
+	r.recorder.Eventf(instance, nil, corev1.EventTypeNormal, "TeardownPhase", "Teardown",
+		"Teardown phase %s started", phase)
+}
+
+// removeTeardownFinalizer releases the ClusterPolicy, whose remaining owned objects are garbage collected
+func (r *ClusterPolicyReconciler) removeTeardownFinalizer(ctx context.Context, instance *gpuv1.ClusterPolicy) error {
+	controllerutil.RemoveFinalizer(instance, clusterPolicyTeardownFinalizer)
+	if err := r.Update(ctx, instance); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("error removing the teardown finalizer: %w", err)
+	}
+	return nil
+}
+
+// runTeardownPhase runs a teardown phase and returns the objects the phase is waiting for, the phase being
+// complete when there is none
+func (r *ClusterPolicyReconciler) runTeardownPhase(ctx context.Context, instance *gpuv1.ClusterPolicy, phase gpuv1.TeardownPhase) ([]string, error) {
+	switch phase {
+	case gpuv1.TeardownEvictingWorkloads:
+		return r.evictTeardownGPUPods(ctx)
+	case gpuv1.TeardownVerifyingModuleUnload:
+		return r.verifyModuleUnload(ctx, instance)
+	default:
+		return r.deleteTeardownDaemonSets(ctx, instance, phase)
+	}
+}
+
+// listTeardownGPUNodes lists the GPU nodes of the cluster
+func (r *ClusterPolicyReconciler) listTeardownGPUNodes(ctx context.Context) ([]corev1.Node, error) {
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes, client.MatchingLabels{commonGPULabelKey: commonGPULabelValue}); err != nil {
+		return nil, fmt.Errorf("unable to list the GPU nodes: %w", err)
+	}
+	return nodes.Items, nil
+}
+
+// evictTeardownGPUPods evicts the GPU workloads from the GPU nodes and returns the GPU workloads still running.
+// The evictions blocked by a PodDisruptionBudget are retried until the phase times out.
+func (r *ClusterPolicyReconciler) evictTeardownGPUPods(ctx context.Context) ([]string, error) {
+	nodes, err := r.listTeardownGPUNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var running []string
+	for _, node := range nodes {
+		pods, err := evictNodeGPUPods(ctx, r.Client, r.Log.WithValues("Reason", "ClusterPolicy teardown"), node.Name)
+		if err != nil {
+			r.Log.Info("Unable to evict the GPU workloads of node, retrying", "NodeName", node.Name, "Error", err)
+		}
+		running = append(running, pods...)
+	}
+	return running, nil
+}
+
+// deleteTeardownDaemonSets deletes the operand DaemonSets of the ClusterPolicy removed by the teardown phase and
+// returns the DaemonSets not yet gone. The foreground propagation keeps each DaemonSet until its pods are gone.
+func (r *ClusterPolicyReconciler) deleteTeardownDaemonSets(ctx context.Context, instance *gpuv1.ClusterPolicy, phase gpuv1.TeardownPhase) ([]string, error) {
+	list := &appsv1.DaemonSetList{}
+	if err := r.List(ctx, list, client.MatchingFields{clusterPolicyControllerIndexKey: instance.Name}); err != nil {
+		return nil, fmt.Errorf("unable to list the DaemonSets of the ClusterPolicy: %w", err)
+	}
+	var pending []string
+	for i := range list.Items {
+		ds := &list.Items[i]
+		if !metav1.IsControlledBy(ds, instance) || daemonSetTeardownPhase(ds.Name) != phase {
+			continue
+		}
+		pending = append(pending, client.ObjectKeyFromObject(ds).String())
+		if !ds.DeletionTimestamp.IsZero() {
+			continue
+		}
+		r.Log.Info("Deleting operand DaemonSet", "phase", phase, "DaemonSet", client.ObjectKeyFromObject(ds))
+		err := r.Delete(ctx, ds, client.PropagationPolicy(metav1.DeletePropagationForeground))
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("error deleting DaemonSet %s: %w", ds.Name, err)
+		}
+	}
+	return pending, nil
+}
+
+// verifyModuleUnload runs a pod on each GPU node which was running the driver or the vGPU manager of the
+// ClusterPolicy, checking that the NVIDIA kernel modules are unloaded, and returns the GPU nodes not yet
+// verified. The check of a node is retried while its modules are loaded.
+func (r *ClusterPolicyReconciler) verifyModuleUnload(ctx context.Context, instance *gpuv1.ClusterPolicy) ([]string, error) {
+	spec := &instance.Spec
+	if spec.Driver.UseNvidiaDriverCRDType() || (!spec.Driver.IsEnabled() && !spec.VGPUManager.IsEnabled()) {
+		// the driver is not managed by the ClusterPolicy
+		return nil, nil
+	}
+	nodes, err := r.listTeardownGPUNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []string
+	for i := range nodes {
+		node := &nodes[i]
+		if node.Labels[driverDeployLabelKey] != "true" && node.Labels[vgpuManagerDeployLabelKey] != "true" {
+			continue
+		}
+		pod := &corev1.Pod{}
+		key := types.NamespacedName{Namespace: r.Namespace, Name: moduleCheckPodName(node.Name)}
+		err := r.Get(ctx, key, pod)
+		if apierrors.IsNotFound(err) {
+			pod, err = newModuleCheckPod(&spec.Validator, node, key)
+			if err != nil {
+				return nil, err
+			}
+			if err := r.Create(ctx, pod); err != nil && !apierrors.IsAlreadyExists(err) {
+				return nil, fmt.Errorf("error creating the module check pod of node %s: %w", node.Name, err)
+			}
+			pending = append(pending, node.Name)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error getting the module check pod of node %s: %w", node.Name, err)
+		}
+
+		switch pod.Status.Phase {
+		case corev1.PodSucceeded:
+			continue
+		case corev1.PodFailed:
+			// NVIDIA kernel modules are still loaded, the node is checked again
+			if err := r.Delete(ctx, pod); err != nil && !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("error deleting the module check pod of node %s: %w", node.Name, err)
+			}
+		}
+		pending = append(pending, node.Name)
+	}
+	return pending, nil
+}
+
+// moduleCheckPodName returns the name of the pod verifying that the NVIDIA kernel modules are unloaded from the
+// node, the node names being too long to be appended
+func moduleCheckPodName(nodeName string) string {
+	return fmt.Sprintf("%s-%s", moduleCheckAppLabelValue, utils.GetStringHash(nodeName))
+}
+
+// newModuleCheckPod returns the pod verifying that the NVIDIA kernel modules are unloaded from the node, which
+// runs the validator image of the node
+func newModuleCheckPod(validator *gpuv1.ValidatorSpec, node *corev1.Node, key types.NamespacedName) (*corev1.Pod, error) {
+	var image string
+	var err error
+	if imageKey := nodeValidatorImageKey(validator, node.Labels); imageKey != "" {
+		imageSpec := validator.Images[imageKey]
+		image, err = gpuv1.ImagePath(&imageSpec)
+	} else {
+		image, err = gpuv1.ImagePath(validator)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+			Labels:    map[string]string{appLabelKey: moduleCheckAppLabelValue},
+		},
+		Spec: corev1.PodSpec{
+			NodeName:      node.Name,
+			RestartPolicy: corev1.RestartPolicyNever,
+			Tolerations:   []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+			Containers: []corev1.Container{{
+				Name:            "module-unload-check",
+				Image:           image,
+				ImagePullPolicy: gpuv1.ImagePullPolicy(validator.ImagePullPolicy),
+				Command:         []string{"sh", "-c", moduleCheckScript},
+			}},
+		},
+	}
+	for _, secret := range validator.ImagePullSecrets {
+		pod.Spec.ImagePullSecrets = append(pod.Spec.ImagePullSecrets, corev1.LocalObjectReference{Name: secret})
+	}
+	return pod, nil
+}
+
+// deleteModuleCheckPods deletes the pods which verified that the NVIDIA kernel modules are unloaded
+func (r *ClusterPolicyReconciler) deleteModuleCheckPods(ctx context.Context) error {
+	pods := &corev1.PodList{}
+	err := r.List(ctx, pods, client.InNamespace(r.Namespace), client.MatchingLabels{appLabelKey: moduleCheckAppLabelValue})
+	if err != nil {
+		return fmt.Errorf("unable to list the module check pods: %w", err)
+	}
+	for i := range pods.Items {
+		if err := r.Delete(ctx, &pods.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("error deleting the module check pod %s: %w", pods.Items[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// updateTeardownStatus reports the progress of the teardown in the status of the ClusterPolicy
+func updateTeardownStatus(ctx context.Context, r *ClusterPolicyReconciler, name string, status *gpuv1.TeardownStatus) {
+	// Fetch latest instance and update status to avoid version mismatch
+	instance := &gpuv1.ClusterPolicy{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name}, instance); err != nil {
+		r.Log.Error(err, "Failed to get ClusterPolicy instance for status update")
+		return
+	}
+	if equality.Semantic.DeepEqual(instance.Status.Teardown, status) {
+		return
+	}
+	instance.Status.Teardown = status
+	if err := r.Client.Status().Update(ctx, instance); err != nil {
+		r.Log.Error(err, "Failed to update ClusterPolicy teardown status")
+	}
+}
@@ -0,0 +1,223 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+// clusterPolicyControllerIndexer mirrors the manager's DaemonSet controller index for fake clients.
+func clusterPolicyControllerIndexer(obj client.Object) []string {
+	if owner := metav1.GetControllerOf(obj); owner != nil && owner.Kind == "ClusterPolicy" {
+		return []string{owner.Name}
+	}
+	return nil
+}
+
+func newTeardownReconciler(t *testing.T, objs ...client.Object) (*ClusterPolicyReconciler, *events.FakeRecorder) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, policyv1.AddToScheme(scheme))
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+		WithStatusSubresource(&gpuv1.ClusterPolicy{}, &corev1.Pod{}).
+		WithIndex(&appsv1.DaemonSet{}, clusterPolicyControllerIndexKey, clusterPolicyControllerIndexer).
+		WithIndex(&corev1.Pod{}, podNodeNameIndexKey, podNodeNameIndexer).
+		Build()
+	recorder := events.NewFakeRecorder(20)
+	return &ClusterPolicyReconciler{
+		Client:    c,
+		Log:       logr.Discard(),
+		Scheme:    scheme,
+		Namespace: "gpu-operator",
+		recorder:  recorder,
+	}, recorder
+}
+
+func newTeardownClusterPolicy(teardown *gpuv1.TeardownSpec) *gpuv1.ClusterPolicy {
+	return &gpuv1.ClusterPolicy{
+		TypeMeta: metav1.TypeMeta{APIVersion: gpuv1.SchemeGroupVersion.String(), Kind: "ClusterPolicy"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "cluster-policy",
+			UID:               "cluster-policy-uid",
+			Finalizers:        []string{clusterPolicyTeardownFinalizer},
+			DeletionTimestamp: ptr.To(metav1.Now()),
+		},
+		Spec: gpuv1.ClusterPolicySpec{
+			Teardown:  teardown,
+			Validator: gpuv1.ValidatorSpec{Repository: "nvcr.io/nvidia/cloud-native", Image: "gpu-operator-validator", Version: "v1.0.0"},
+		},
+	}
+}
+
+func newTeardownDaemonSet(cp *gpuv1.ClusterPolicy, name string) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{
+		Name:      name,
+		Namespace: "gpu-operator",
+		OwnerReferences: []metav1.OwnerReference{{
+			APIVersion: gpuv1.SchemeGroupVersion.String(),
+			Kind:       "ClusterPolicy",
+			Name:       cp.Name,
+			UID:        cp.UID,
+			Controller: ptr.To(true),
+		}},
+	}}
+}
+
+func TestDaemonSetTeardownPhase(t *testing.T) {
+	require.Equal(t, gpuv1.TeardownRemovingDriver, daemonSetTeardownPhase("nvidia-driver-daemonset-5.15.0-generic-ubuntu22.04"))
+	require.Equal(t, gpuv1.TeardownRemovingDriver, daemonSetTeardownPhase(commonVGPUManagerDaemonsetName))
+	require.Equal(t, gpuv1.TeardownRemovingToolkit, daemonSetTeardownPhase(containerToolkitAppLabelValue+"-crio"))
+	require.Equal(t, gpuv1.TeardownRemovingDevicePlugin, daemonSetTeardownPhase(devicePluginAppLabelValue))
+	require.Equal(t, gpuv1.TeardownRemovingDevicePlugin, daemonSetTeardownPhase(operatorValidatorAppLabelValue))
+}
+
+func TestReconcileTeardown(t *testing.T) {
+	ctx := context.Background()
+	cp := newTeardownClusterPolicy(&gpuv1.TeardownSpec{Enabled: ptr.To(true)})
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   "gpu-node",
+		Labels: map[string]string{commonGPULabelKey: commonGPULabelValue, driverDeployLabelKey: "true"},
+	}}
+	r, recorder := newTeardownReconciler(t, cp, node,
+		newTeardownDaemonSet(cp, devicePluginAppLabelValue),
+		newTeardownDaemonSet(cp, containerToolkitAppLabelValue),
+		newTeardownDaemonSet(cp, commonDriverDaemonsetName),
+	)
+	now := time.Now()
+	reconcileTeardown := func() *gpuv1.TeardownStatus {
+		instance := &gpuv1.ClusterPolicy{}
+		require.NoError(t, r.Get(ctx, types.NamespacedName{Name: cp.Name}, instance))
+		_, err := r.reconcileTeardown(ctx, instance, now)
+		require.NoError(t, err)
+		if err := r.Get(ctx, types.NamespacedName{Name: cp.Name}, instance); apierrors.IsNotFound(err) {
+			return nil
+		}
+		return instance.Status.Teardown
+	}
+	daemonSetExists := func(name string) bool {
+		err := r.Get(ctx, types.NamespacedName{Namespace: "gpu-operator", Name: name}, &appsv1.DaemonSet{})
+		return err == nil
+	}
+
+	// the operands are removed one phase after the other
+	status := reconcileTeardown()
+	require.Equal(t, gpuv1.TeardownRemovingDevicePlugin, status.Phase)
+	require.False(t, daemonSetExists(devicePluginAppLabelValue))
+	require.True(t, daemonSetExists(containerToolkitAppLabelValue))
+
+	status = reconcileTeardown()
+	require.Equal(t, gpuv1.TeardownRemovingToolkit, status.Phase)
+	require.False(t, daemonSetExists(containerToolkitAppLabelValue))
+	require.True(t, daemonSetExists(commonDriverDaemonsetName))
+
+	status = reconcileTeardown()
+	require.Equal(t, gpuv1.TeardownRemovingDriver, status.Phase)
+	require.False(t, daemonSetExists(commonDriverDaemonsetName))
+
+	// the module unload is verified on the node which was running the driver
+	status = reconcileTeardown()
+	require.Equal(t, gpuv1.TeardownVerifyingModuleUnload, status.Phase)
+	require.Equal(t, []string{"gpu-node"}, status.Pending)
+	pod := &corev1.Pod{}
+	podKey := types.NamespacedName{Namespace: "gpu-operator", Name: moduleCheckPodName("gpu-node")}
+	require.NoError(t, r.Get(ctx, podKey, pod))
+	require.Equal(t, "gpu-node", pod.Spec.NodeName)
+	require.Equal(t, "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0", pod.Spec.Containers[0].Image)
+
+	// the modules are still loaded, the node is checked again
+	pod.Status.Phase = corev1.PodFailed
+	require.NoError(t, r.Status().Update(ctx, pod))
+	status = reconcileTeardown()
+	require.Equal(t, gpuv1.TeardownVerifyingModuleUnload, status.Phase)
+	require.True(t, apierrors.IsNotFound(r.Get(ctx, podKey, &corev1.Pod{})))
+
+	// the modules are unloaded, the ClusterPolicy is released
+	reconcileTeardown()
+	require.NoError(t, r.Get(ctx, podKey, pod))
+	pod.Status.Phase = corev1.PodSucceeded
+	require.NoError(t, r.Status().Update(ctx, pod))
+	require.Nil(t, reconcileTeardown())
+	require.True(t, apierrors.IsNotFound(r.Get(ctx, podKey, &corev1.Pod{})))
+
+	var reasons []string
+	for len(recorder.Events) > 0 {
+		reasons = append(reasons, <-recorder.Events)
+	}
+	require.Len(t, reasons, 5)
+	require.Contains(t, reasons[4], "TeardownCompleted")
+}
+
+func TestReconcileTeardownPhaseTimeout(t *testing.T) {
+	ctx := context.Background()
+	cp := newTeardownClusterPolicy(&gpuv1.TeardownSpec{Enabled: ptr.To(true), EvictGPUWorkloads: ptr.To(true), PhaseTimeoutSeconds: 60})
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   "gpu-node",
+		Labels: map[string]string{commonGPULabelKey: commonGPULabelValue},
+	}}
+	// the finalizer keeps the pod running after its eviction, as a blocking PodDisruptionBudget would
+	gpuPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "training", Namespace: "default", Finalizers: []string{"example.com/block"}},
+		Spec: corev1.PodSpec{
+			NodeName: "gpu-node",
+			Containers: []corev1.Container{{Name: "cuda", Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{gpuResourceName: resource.MustParse("1")},
+			}}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	r, recorder := newTeardownReconciler(t, cp, node, gpuPod)
+
+	start := time.Now()
+	instance := &gpuv1.ClusterPolicy{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: cp.Name}, instance))
+	result, err := r.reconcileTeardown(ctx, instance, start)
+	require.NoError(t, err)
+	require.Equal(t, teardownPollInterval, result.RequeueAfter)
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: cp.Name}, instance))
+	require.Equal(t, gpuv1.TeardownEvictingWorkloads, instance.Status.Teardown.Phase)
+	require.Equal(t, []string{"default/training"}, instance.Status.Teardown.Pending)
+
+	// the eviction times out, the teardown moves on and completes without operands
+	result, err = r.reconcileTeardown(ctx, instance, start.Add(time.Minute))
+	require.NoError(t, err)
+	require.Zero(t, result.RequeueAfter)
+	require.True(t, apierrors.IsNotFound(r.Get(ctx, types.NamespacedName{Name: cp.Name}, instance)))
+
+	<-recorder.Events
+	require.Contains(t, <-recorder.Events, "TeardownPhaseTimedOut")
+}
@@ -0,0 +1,77 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	driverconfig "github.com/NVIDIA/gpu-operator/internal/config"
+	"github.com/NVIDIA/gpu-operator/internal/utils"
+)
+
+// renderedOperandConfig returns the digests of the content of the ConfigMaps rendered by the operator and mounted
+// by the given pod spec, keyed by ConfigMap name. The pod templates only reference the rendered ConfigMaps, so
+// every operand mounting one, e.g. the device plugin, GFD and the MPS control daemon for the sharing
+// configuration, is restarted on changes of the content through the rendered config digest.
+func renderedOperandConfig(podSpec *corev1.PodSpec, config *gpuv1.ClusterPolicySpec) map[string]string {
+	rendered := make(map[string]string)
+	for _, volume := range podSpec.Volumes {
+		if volume.ConfigMap == nil {
+			continue
+		}
+		switch volume.ConfigMap.Name {
+		case TimeSlicingConfigMapName:
+			if !config.DevicePlugin.IsSharingManaged() {
+				continue
+			}
+			if config.DevicePlugin.IsMPSSharingEnabled() {
+				rendered[TimeSlicingConfigMapName] = utils.GetObjectHash(config.DevicePlugin.MPS)
+			} else {
+				rendered[TimeSlicingConfigMapName] = utils.GetObjectHash(config.DevicePlugin.TimeSlicing)
+			}
+		case VgpuDMDefaultConfigMapName:
+			if len(config.VGPUDeviceManager.Profiles) > 0 {
+				rendered[VgpuDMDefaultConfigMapName] = utils.GetObjectHash(config.VGPUDeviceManager.Profiles)
+			}
+		}
+	}
+	return rendered
+}
+
+// applyConfigDigest stamps the config digest of the operand on the DaemonSet, once the DaemonSet is fully
+// rendered and before the ConfigMap volumes are pointed to their generations. The digest of the content of the
+// rendered ConfigMaps is set on the pod template, restarting the pods on changes of the content. The driver
+// DaemonSets already carry the digest of their install configuration, set along with the DRIVER_CONFIG_DIGEST
+// env of the driver containers.
+func applyConfigDigest(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec) {
+	rendered := renderedOperandConfig(&obj.Spec.Template.Spec, config)
+	if len(rendered) > 0 {
+		if obj.Spec.Template.Annotations == nil {
+			obj.Spec.Template.Annotations = make(map[string]string)
+		}
+		obj.Spec.Template.Annotations[driverconfig.RenderedConfigDigestAnnotationKey] = utils.GetObjectHash(rendered)
+	}
+
+	if driverconfig.ConfigDigestFromObjectMeta(&obj.ObjectMeta) != "" {
+		return
+	}
+	state := driverconfig.ExtractOperandConfig(&obj.Spec.Template.Spec)
+	state.RenderedConfig = rendered
+	driverconfig.SetConfigDigest(utils.GetObjectHashIgnoreEmptyKeys(state), &obj.ObjectMeta)
+}
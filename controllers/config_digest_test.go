@@ -0,0 +1,106 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	driverconfig "github.com/NVIDIA/gpu-operator/internal/config"
+)
+
+func TestApplyConfigDigest(t *testing.T) {
+	cpSpec := &gpuv1.ClusterPolicySpec{}
+	newDs := func(image string) Daemonset {
+		return NewDaemonset().WithContainer(corev1.Container{Name: "nvidia-dcgm-exporter", Image: image})
+	}
+
+	ds := newDs("nvcr.io/nvidia/k8s/dcgm-exporter:4.2.3")
+	applyConfigDigest(ds.DaemonSet, cpSpec)
+	digest := ds.Annotations[driverconfig.ConfigDigestAnnotationKey]
+	require.NotEmpty(t, digest)
+	// the pod template carries no digest of its own content, nor of rendered content as none is mounted
+	require.Empty(t, ds.Spec.Template.Annotations)
+
+	// the digest is stable, and changes with the configuration of the pods
+	ds = newDs("nvcr.io/nvidia/k8s/dcgm-exporter:4.2.3")
+	applyConfigDigest(ds.DaemonSet, cpSpec)
+	require.Equal(t, digest, ds.Annotations[driverconfig.ConfigDigestAnnotationKey])
+	ds = newDs("nvcr.io/nvidia/k8s/dcgm-exporter:4.3.0")
+	applyConfigDigest(ds.DaemonSet, cpSpec)
+	require.NotEqual(t, digest, ds.Annotations[driverconfig.ConfigDigestAnnotationKey])
+
+	// the driver DaemonSets keep the digest of their install configuration
+	ds = newDs("nvcr.io/nvidia/driver:570.86.15")
+	driverconfig.SetConfigDigest("1234", &ds.ObjectMeta)
+	applyConfigDigest(ds.DaemonSet, cpSpec)
+	require.Equal(t, "1234", ds.Annotations[driverconfig.ConfigDigestAnnotationKey])
+}
+
+func TestApplyConfigDigestSharingConsumers(t *testing.T) {
+	cpSpec := &gpuv1.ClusterPolicySpec{
+		DevicePlugin: gpuv1.DevicePluginSpec{
+			Repository: "nvcr.io/nvidia",
+			Image:      "k8s-device-plugin",
+			Version:    "v0.18.1",
+			TimeSlicing: &gpuv1.TimeSlicingConfig{
+				Resources: []gpuv1.TimeSlicingResource{{Name: "nvidia.com/gpu", Replicas: 2}},
+			},
+		},
+		GPUFeatureDiscovery: gpuv1.GPUFeatureDiscoverySpec{
+			Repository: "nvcr.io/nvidia",
+			Image:      "k8s-device-plugin",
+			Version:    "v0.18.1",
+		},
+	}
+	n := ClusterPolicyController{client: fake.NewFakeClient(), runtime: gpuv1.Containerd, operatorNamespace: "test-ns",
+		logger: ctrl.Log.WithName("test")}
+
+	// every DaemonSet mounting the rendered sharing configuration is restarted when it changes
+	render := func() map[string]*appsv1.DaemonSet {
+		gfd := NewDaemonset().WithContainer(corev1.Container{Name: "gpu-feature-discovery"}).
+			WithContainer(corev1.Container{Name: "config-manager"}).
+			WithInitContainer(corev1.Container{Name: "config-manager-init"})
+		require.NoError(t, TransformGPUDiscoveryPlugin(gfd.DaemonSet, cpSpec, n))
+		mps := NewDaemonset().WithContainer(corev1.Container{Name: "mps-control-daemon-ctr"}).
+			WithContainer(corev1.Container{Name: "config-manager"}).
+			WithInitContainer(corev1.Container{Name: "config-manager-init"})
+		require.NoError(t, TransformMPSControlDaemon(mps.DaemonSet, cpSpec, n))
+		daemonSets := map[string]*appsv1.DaemonSet{"gpu-feature-discovery": gfd.DaemonSet, "mps-control-daemon": mps.DaemonSet}
+		for _, ds := range daemonSets {
+			applyConfigDigest(ds, cpSpec)
+		}
+		return daemonSets
+	}
+
+	before := render()
+	cpSpec.DevicePlugin.TimeSlicing.Resources[0].Replicas = 4
+	after := render()
+	for name := range before {
+		digest := before[name].Spec.Template.Annotations[driverconfig.RenderedConfigDigestAnnotationKey]
+		require.NotEmpty(t, digest, name)
+		require.NotEqual(t, digest, after[name].Spec.Template.Annotations[driverconfig.RenderedConfigDigestAnnotationKey], name)
+		require.NotEqual(t, before[name].Annotations[driverconfig.ConfigDigestAnnotationKey],
+			after[name].Annotations[driverconfig.ConfigDigestAnnotationKey], name)
+	}
+}
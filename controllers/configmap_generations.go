@@ -0,0 +1,163 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/NVIDIA/gpu-operator/internal/utils"
+)
+
+// configMapGenerationLabelKey is set on the immutable ConfigMaps rendered for the operands to the name of the
+// ConfigMap in the manifests, so that the previous generations of the ConfigMap can be found
+const configMapGenerationLabelKey = "nvidia.com/gpu-operator.configmap-generation-of"
+
+// applyConfigMapGeneration turns the rendered ConfigMap into an immutable generation named after the digest of
+// its content, and records the generation name so that the pod specs mounting the ConfigMap are pointed to it.
+// Creating a new ConfigMap on every change instead of updating it in place does not race with the kubelets
+// syncing the mounted ConfigMaps, and rolls the operand pods out to the new content.
+func applyConfigMapGeneration(obj *corev1.ConfigMap, n ClusterPolicyController) error {
+	content, err := json.Marshal(struct {
+		Data       map[string]string
+		BinaryData map[string][]byte
+	}{obj.Data, obj.BinaryData})
+	if err != nil {
+		return fmt.Errorf("failed to compute the digest of ConfigMap %s: %w", obj.Name, err)
+	}
+	name := obj.Name
+	obj.Name = fmt.Sprintf("%s-%s", name, utils.GetStringHash(string(content)))
+	if obj.Labels == nil {
+		obj.Labels = make(map[string]string)
+	}
+	obj.Labels[configMapGenerationLabelKey] = name
+	obj.Immutable = ptr.To(true)
+	n.configMapGenerations[name] = obj.Name
+	return nil
+}
+
+// applyConfigMapGenerations points the ConfigMap volumes of the pod spec to the generations of the ConfigMaps
+// rendered during the current reconciliation
+func applyConfigMapGenerations(podSpec *corev1.PodSpec, generations map[string]string) {
+	for i := range podSpec.Volumes {
+		volume := &podSpec.Volumes[i]
+		if volume.ConfigMap != nil {
+			if generation, ok := generations[volume.ConfigMap.Name]; ok {
+				volume.ConfigMap.Name = generation
+			}
+		}
+		if volume.Projected == nil {
+			continue
+		}
+		for j := range volume.Projected.Sources {
+			source := &volume.Projected.Sources[j]
+			if source.ConfigMap == nil {
+				continue
+			}
+			if generation, ok := generations[source.ConfigMap.Name]; ok {
+				source.ConfigMap.Name = generation
+			}
+		}
+	}
+}
+
+// deleteStaleConfigMapGenerations deletes the generations of the given ConfigMap other than the current one
+// which are no longer mounted by the pods, or by the pod templates of the DaemonSets and Deployments, of the
+// operator namespace. The generations mounted by the pods of a rollout are deleted by a later reconciliation.
+func (n ClusterPolicyController) deleteStaleConfigMapGenerations(ctx context.Context, name, current string) error {
+	list := &corev1.ConfigMapList{}
+	err := n.client.List(ctx, list, client.InNamespace(n.operatorNamespace), client.MatchingLabels{configMapGenerationLabelKey: name})
+	if err != nil {
+		return fmt.Errorf("failed to list the generations of ConfigMap %s: %w", name, err)
+	}
+	var stale []*corev1.ConfigMap
+	for i := range list.Items {
+		if list.Items[i].Name != current {
+			stale = append(stale, &list.Items[i])
+		}
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+
+	mounted, err := n.getMountedConfigMaps(ctx)
+	if err != nil {
+		return err
+	}
+	for _, cm := range stale {
+		if mounted[cm.Name] {
+			continue
+		}
+		n.logger.Info("Deleting stale ConfigMap generation", "ConfigMap", cm.Name)
+		if err := n.client.Delete(ctx, cm); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete the stale ConfigMap generation %s: %w", cm.Name, err)
+		}
+	}
+	return nil
+}
+
+// getMountedConfigMaps returns the names of the ConfigMaps mounted by the pods, DaemonSets and Deployments of
+// the operator namespace
+func (n ClusterPolicyController) getMountedConfigMaps(ctx context.Context) (map[string]bool, error) {
+	var podSpecs []*corev1.PodSpec
+	pods := &corev1.PodList{}
+	if err := n.client.List(ctx, pods, client.InNamespace(n.operatorNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	for i := range pods.Items {
+		podSpecs = append(podSpecs, &pods.Items[i].Spec)
+	}
+	daemonsets := &appsv1.DaemonSetList{}
+	if err := n.client.List(ctx, daemonsets, client.InNamespace(n.operatorNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list DaemonSets: %w", err)
+	}
+	for i := range daemonsets.Items {
+		podSpecs = append(podSpecs, &daemonsets.Items[i].Spec.Template.Spec)
+	}
+	deployments := &appsv1.DeploymentList{}
+	if err := n.client.List(ctx, deployments, client.InNamespace(n.operatorNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list Deployments: %w", err)
+	}
+	for i := range deployments.Items {
+		podSpecs = append(podSpecs, &deployments.Items[i].Spec.Template.Spec)
+	}
+
+	mounted := make(map[string]bool)
+	for _, podSpec := range podSpecs {
+		for _, volume := range podSpec.Volumes {
+			if volume.ConfigMap != nil {
+				mounted[volume.ConfigMap.Name] = true
+			}
+			if volume.Projected == nil {
+				continue
+			}
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					mounted[source.ConfigMap.Name] = true
+				}
+			}
+		}
+	}
+	return mounted, nil
+}
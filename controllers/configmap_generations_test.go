@@ -0,0 +1,127 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestApplyConfigMapGeneration(t *testing.T) {
+	n := ClusterPolicyController{configMapGenerations: map[string]string{}}
+	obj := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: TimeSlicingConfigMapName},
+		Data:       map[string]string{TimeSlicingDefaultConfigName: "version: v1"},
+	}
+	same := obj.DeepCopy()
+	changed := obj.DeepCopy()
+	changed.Data[TimeSlicingDefaultConfigName] = "version: v2"
+
+	require.NoError(t, applyConfigMapGeneration(obj, n))
+	require.Regexp(t, "^"+TimeSlicingConfigMapName+"-[a-z0-9]+$", obj.Name)
+	require.Equal(t, TimeSlicingConfigMapName, obj.Labels[configMapGenerationLabelKey])
+	require.True(t, *obj.Immutable)
+	require.Equal(t, obj.Name, n.configMapGenerations[TimeSlicingConfigMapName])
+
+	require.NoError(t, applyConfigMapGeneration(same, n))
+	require.Equal(t, obj.Name, same.Name)
+	require.NoError(t, applyConfigMapGeneration(changed, n))
+	require.NotEqual(t, obj.Name, changed.Name)
+	require.Equal(t, changed.Name, n.configMapGenerations[TimeSlicingConfigMapName])
+}
+
+func TestApplyConfigMapGenerations(t *testing.T) {
+	podSpec := &corev1.PodSpec{
+		Volumes: []corev1.Volume{
+			{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "config"},
+			}}},
+			{Name: "custom", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "custom"},
+			}}},
+			{Name: "projected", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{ConfigMap: &corev1.ConfigMapProjection{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "config"},
+				}}},
+			}}},
+		},
+	}
+
+	applyConfigMapGenerations(podSpec, map[string]string{"config": "config-abc"})
+	require.Equal(t, "config-abc", podSpec.Volumes[0].ConfigMap.Name)
+	require.Equal(t, "custom", podSpec.Volumes[1].ConfigMap.Name)
+	require.Equal(t, "config-abc", podSpec.Volumes[2].Projected.Sources[0].ConfigMap.Name)
+	// the volumes keep their names, which the volume mounts refer to
+	require.Equal(t, "config", podSpec.Volumes[0].Name)
+}
+
+func TestDeleteStaleConfigMapGenerations(t *testing.T) {
+	generation := func(name string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test-ns",
+			Labels:    map[string]string{configMapGenerationLabelKey: "config"},
+		}}
+	}
+	mountingPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "operand-old", Namespace: "test-ns"},
+		Spec: corev1.PodSpec{Volumes: []corev1.Volume{{Name: "config", VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "config-rollout"}},
+		}}}},
+	}
+	daemonset := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "operand", Namespace: "test-ns"},
+		Spec: appsv1.DaemonSetSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Volumes: []corev1.Volume{{Name: "config", VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "config-current"}},
+		}}}}}},
+	}
+	objects := []client.Object{
+		generation("config-current"), generation("config-rollout"), generation("config-stale"),
+		mountingPod, daemonset,
+	}
+	n := ClusterPolicyController{
+		client:            fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objects...).Build(),
+		logger:            logr.Discard(),
+		operatorNamespace: "test-ns",
+	}
+	ctx := context.Background()
+
+	require.NoError(t, n.deleteStaleConfigMapGenerations(ctx, "config", "config-current"))
+	list := &corev1.ConfigMapList{}
+	require.NoError(t, n.client.List(ctx, list))
+	var names []string
+	for _, cm := range list.Items {
+		names = append(names, cm.Name)
+	}
+	require.ElementsMatch(t, []string{"config-current", "config-rollout"}, names)
+
+	// all generations unmounted are deleted once the ConfigMap is rendered in place again
+	require.NoError(t, n.client.Delete(ctx, mountingPod))
+	require.NoError(t, n.deleteStaleConfigMapGenerations(ctx, "config", "config"))
+	require.NoError(t, n.client.List(ctx, list))
+	require.Len(t, list.Items, 1)
+	require.Equal(t, "config-current", list.Items[0].Name)
+}
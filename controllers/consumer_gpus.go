@@ -0,0 +1,146 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+	gpulabels "github.com/NVIDIA/gpu-operator/pkg/labels"
+)
+
+// gpuPCIDeviceLabelPrefix prefixes the PCI device ID of the NVIDIA GPUs of a node in the labels set by the
+// nvidia-gpu-pci-devices NFD rule
+const gpuPCIDeviceLabelPrefix = "nvidia.com/gpu.pci-device-"
+
+// consumerGPUDeviceIDs are the PCI device IDs of the GeForce and TITAN GPUs known by the operator
+var consumerGPUDeviceIDs = map[string]bool{
+	// GeForce RTX 50 series
+	"2b85": true, "2c02": true, "2c05": true, "2f04": true,
+	// GeForce RTX 40 series
+	"2684": true, "2702": true, "2704": true, "2705": true, "2782": true, "2783": true, "2786": true,
+	"2803": true, "2805": true, "2882": true,
+	// GeForce RTX 30 series
+	"2203": true, "2204": true, "2206": true, "2208": true, "2216": true, "2482": true, "2484": true,
+	"2486": true, "2488": true, "2489": true, "2503": true, "2504": true,
+	// GeForce RTX 20 series and TITAN RTX
+	"1e02": true, "1e04": true, "1e07": true, "1e81": true, "1e82": true, "1e87": true, "1ec2": true,
+	"1f02": true, "1f06": true, "1f07": true, "1f08": true,
+	// GeForce GTX 10 series and TITAN X/Xp
+	"1b00": true, "1b02": true, "1b06": true, "1b80": true, "1b81": true, "1b82": true, "1c03": true,
+}
+
+// datacenterOnlyStateLabelKeys are the deploy labels of the operands relying on datacenter GPU features, which
+// are not set on the GPU nodes with consumer GPUs. The MIG Manager is left out by addGPUStateLabels.
+var datacenterOnlyStateLabelKeys = map[string]bool{
+	gpulabels.DeployGPUHealthCheck: true,
+	gpulabels.DeployNVLinkFabric:   true,
+}
+
+// hasConsumerGPU returns true if the node has a consumer GPU, detected from the PCI device IDs of its GPUs, or
+// from the product name reported by GPU Feature Discovery when NFD does not label the PCI device IDs
+func hasConsumerGPU(labels map[string]string, deviceIDs []string) bool {
+	hasDeviceLabels := false
+	for key, value := range labels {
+		deviceID, ok := strings.CutPrefix(key, gpuPCIDeviceLabelPrefix)
+		if !ok || value != "true" {
+			continue
+		}
+		hasDeviceLabels = true
+		if consumerGPUDeviceIDs[deviceID] || slices.Contains(deviceIDs, deviceID) {
+			return true
+		}
+	}
+	if hasDeviceLabels {
+		return false
+	}
+
+	// check product label if the PCI device labels do not exist
+	product := strings.ToLower(labels[gpuProductLabelKey])
+	return strings.Contains(product, "geforce") || strings.Contains(product, "titan")
+}
+
+// gpuDevicesChanged returns true if the PCI device IDs or the product name of the GPUs of the node changed
+func gpuDevicesChanged(oldLabels, newLabels map[string]string) bool {
+	if oldLabels[gpuProductLabelKey] != newLabels[gpuProductLabelKey] {
+		return true
+	}
+	for _, labels := range []map[string]string{oldLabels, newLabels} {
+		for key := range labels {
+			if strings.HasPrefix(key, gpuPCIDeviceLabelPrefix) && oldLabels[key] != newLabels[key] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// reconcileConsumerGPULabel keeps nvidia.com/gpu.consumer in sync with the GPUs of the node. Returns true if
+// labels were modified.
+func (nlc *nodeLabelingController) reconcileConsumerGPULabel(labels map[string]string, nodeName string) bool {
+	consumer := hasCommonGPULabel(labels) && nlc.clusterPolicy != nil &&
+		hasConsumerGPU(labels, nlc.clusterPolicy.Spec.ConsumerGPUs.GetDeviceIDs())
+	if !consumer {
+		if _, ok := labels[consts.ConsumerGPULabel]; ok {
+			nlc.logger.Info("Deleting node label", "NodeName", nodeName, "Label", consts.ConsumerGPULabel)
+			delete(labels, consts.ConsumerGPULabel)
+			return true
+		}
+		return false
+	}
+	if labels[consts.ConsumerGPULabel] == "true" {
+		return false
+	}
+	nlc.logger.Info("Setting node label", "NodeName", nodeName, "Label", consts.ConsumerGPULabel, "Value", "true")
+	labels[consts.ConsumerGPULabel] = "true"
+	return true
+}
+
+// recordConsumerGPUEvent records a warning event on the node when consumer GPUs were detected on it, describing
+// how the node is handled
+func (nlc *nodeLabelingController) recordConsumerGPUEvent(original, node *corev1.Node) {
+	if nlc.recorder == nil || nlc.clusterPolicy == nil {
+		return
+	}
+	if original.Labels[consts.ConsumerGPULabel] == "true" || node.Labels[consts.ConsumerGPULabel] != "true" {
+		return
+	}
+	var message string
+	switch nlc.clusterPolicy.Spec.ConsumerGPUs.GetPolicy() {
+	case gpuv1.ConsumerGPUPolicySkip:
+		message = "Consumer GPUs detected, the GPU operands are not deployed to the node"
+	case gpuv1.ConsumerGPUPolicyBlock:
+		message = "Consumer GPUs detected, the reconciliation of the ClusterPolicy is blocked"
+	default:
+		message = "Consumer GPUs detected, the operands relying on datacenter GPU features are not deployed to the node"
+	}
+	nlc.recorder.Eventf(node, nil, corev1.EventTypeWarning, "ConsumerGPU", "DetectGPUs", "%s", message)
+}
+
+// validateConsumerGPUNodes returns an error when GPU nodes with consumer GPUs are found while the ClusterPolicy
+// blocks them
+func (n ClusterPolicyController) validateConsumerGPUNodes() error {
+	if n.singleton.Spec.ConsumerGPUs.GetPolicy() != gpuv1.ConsumerGPUPolicyBlock || len(n.consumerGPUNodes) == 0 {
+		return nil
+	}
+	return fmt.Errorf("consumer GPUs found on nodes %v, which are blocked by the consumer GPU policy", n.consumerGPUNodes)
+}
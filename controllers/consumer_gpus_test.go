@@ -0,0 +1,179 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+func TestHasConsumerGPU(t *testing.T) {
+	tests := []struct {
+		name      string
+		labels    map[string]string
+		deviceIDs []string
+		expected  bool
+	}{
+		{
+			name:     "no GPU labels",
+			labels:   map[string]string{},
+			expected: false,
+		},
+		{
+			name:     "datacenter GPU",
+			labels:   map[string]string{gpuPCIDeviceLabelPrefix + "2330": "true"},
+			expected: false,
+		},
+		{
+			name:     "GeForce GPU",
+			labels:   map[string]string{gpuPCIDeviceLabelPrefix + "2684": "true"},
+			expected: true,
+		},
+		{
+			name: "GeForce GPU next to a datacenter GPU",
+			labels: map[string]string{
+				gpuPCIDeviceLabelPrefix + "2330": "true",
+				gpuPCIDeviceLabelPrefix + "2684": "true",
+			},
+			expected: true,
+		},
+		{
+			name:      "device ID added by the ClusterPolicy",
+			labels:    map[string]string{gpuPCIDeviceLabelPrefix + "abcd": "true"},
+			deviceIDs: []string{"abcd"},
+			expected:  true,
+		},
+		{
+			name:     "product fallback without PCI device labels",
+			labels:   map[string]string{gpuProductLabelKey: "NVIDIA-GeForce-RTX-4090"},
+			expected: true,
+		},
+		{
+			name: "PCI device labels take precedence over the product",
+			labels: map[string]string{
+				gpuPCIDeviceLabelPrefix + "2330": "true",
+				gpuProductLabelKey:               "NVIDIA-GeForce-RTX-4090",
+			},
+			expected: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, hasConsumerGPU(tc.labels, tc.deviceIDs))
+		})
+	}
+}
+
+func TestUpdateGPUStateLabelsConsumerGPU(t *testing.T) {
+	newClusterPolicy := func(policy gpuv1.ConsumerGPUPolicy) *gpuv1.ClusterPolicy {
+		return &gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{
+			ConsumerGPUs: &gpuv1.ConsumerGPUsSpec{Policy: policy},
+		}}
+	}
+	datacenterOnlyLabels := map[string]string{}
+	for key := range datacenterOnlyStateLabelKeys {
+		datacenterOnlyLabels[key] = "true"
+	}
+
+	tests := []struct {
+		name           string
+		clusterPolicy  *gpuv1.ClusterPolicy
+		expectedLabels map[string]string
+	}{
+		{
+			name:          "deploy policy leaves out the datacenter only operands",
+			clusterPolicy: newClusterPolicy(gpuv1.ConsumerGPUPolicyDeploy),
+			expectedLabels: func() map[string]string {
+				labels := mergeLabels(gpuStateLabels[gpuWorkloadConfigContainer])
+				for key := range datacenterOnlyStateLabelKeys {
+					delete(labels, key)
+				}
+				return labels
+			}(),
+		},
+		{
+			name:           "skip policy removes all the deploy labels",
+			clusterPolicy:  newClusterPolicy(gpuv1.ConsumerGPUPolicySkip),
+			expectedLabels: map[string]string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			nlc := &nodeLabelingController{
+				client:        fake.NewClientBuilder().WithIndex(&corev1.Pod{}, podNodeNameIndexKey, podNodeNameIndexer).Build(),
+				clusterPolicy: tc.clusterPolicy,
+				logger:        logr.Discard(),
+			}
+			labels := mergeLabels(map[string]string{
+				commonGPULabelKey:                commonGPULabelValue,
+				consts.GPUAllocationModeLabelKey: string(consts.GPUAllocationModeDevicePlugin),
+				consts.ConsumerGPULabel:          "true",
+				migCapableLabelKey:               "true",
+			}, datacenterOnlyLabels)
+			expected := mergeLabels(map[string]string{
+				commonGPULabelKey:                commonGPULabelValue,
+				consts.GPUAllocationModeLabelKey: string(consts.GPUAllocationModeDevicePlugin),
+				consts.ConsumerGPULabel:          "true",
+				migCapableLabelKey:               "true",
+			}, tc.expectedLabels)
+			nlc.updateGPUStateLabels(context.Background(), labels, "test-node")
+			assert.Equal(t, expected, labels)
+		})
+	}
+}
+
+func TestReconcileConsumerGPULabel(t *testing.T) {
+	nlc := &nodeLabelingController{clusterPolicy: &gpuv1.ClusterPolicy{}, logger: logr.Discard()}
+
+	labels := map[string]string{commonGPULabelKey: commonGPULabelValue, gpuPCIDeviceLabelPrefix + "2684": "true"}
+	require.True(t, nlc.reconcileConsumerGPULabel(labels, "test-node"))
+	require.Equal(t, "true", labels[consts.ConsumerGPULabel])
+	require.False(t, nlc.reconcileConsumerGPULabel(labels, "test-node"))
+
+	// the label is removed once the consumer GPU is replaced
+	delete(labels, gpuPCIDeviceLabelPrefix+"2684")
+	labels[gpuPCIDeviceLabelPrefix+"2330"] = "true"
+	require.True(t, nlc.reconcileConsumerGPULabel(labels, "test-node"))
+	require.NotContains(t, labels, consts.ConsumerGPULabel)
+}
+
+func TestValidateConsumerGPUNodes(t *testing.T) {
+	newController := func(policy gpuv1.ConsumerGPUPolicy, nodes ...string) ClusterPolicyController {
+		return ClusterPolicyController{
+			singleton: &gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{
+				ConsumerGPUs: &gpuv1.ConsumerGPUsSpec{Policy: policy},
+			}},
+			consumerGPUNodes: nodes,
+		}
+	}
+
+	require.NoError(t, ClusterPolicyController{singleton: &gpuv1.ClusterPolicy{}, consumerGPUNodes: []string{"node-a"}}.validateConsumerGPUNodes())
+	require.NoError(t, newController(gpuv1.ConsumerGPUPolicySkip, "node-a").validateConsumerGPUNodes())
+	require.NoError(t, newController(gpuv1.ConsumerGPUPolicyBlock).validateConsumerGPUNodes())
+	require.Error(t, newController(gpuv1.ConsumerGPUPolicyBlock, "node-a").validateConsumerGPUNodes())
+}
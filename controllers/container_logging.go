@@ -0,0 +1,134 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+const (
+	// LogLevelEnvName is the name of the env propagating the log level of an operand container
+	LogLevelEnvName = "LOG_LEVEL"
+	// containerLogsVolumeName is the name of the emptyDir volume holding the rotated log files of the containers
+	containerLogsVolumeName = "nvidia-container-logs"
+	// containerLogsDir is where the rotated log files are written in the containers
+	containerLogsDir = "/var/log/nvidia-gpu-operator"
+	// containerLogWrapperName is the name the log wrapper script runs as, i.e. its $0
+	containerLogWrapperName = "nvidia-log-wrapper"
+)
+
+// containerLogWrapper runs the command given after the log file, the size limit and the number of rotated files,
+// writing its output to the log file, rotated once it reaches the size limit, and forwarding the output to the
+// container logs until the size limit is reached. It only relies on a POSIX shell, mkfifo and mv, as the operand
+// images do not all ship a logging tool. The signals stopping the container are forwarded to the command, and the
+// exit code of the command is kept.
+const containerLogWrapper = `log="$1"; max_bytes="$2"; max_files="$3"; shift 3
+rotate() {
+  i=$max_files
+  while [ "$i" -gt 1 ]; do
+    [ -f "$log.$((i - 1))" ] && mv -f "$log.$((i - 1))" "$log.$i"
+    i=$((i - 1))
+  done
+  [ -f "$log" ] && mv -f "$log" "$log.1"
+}
+# the output of the previous start is kept in the rotated files
+rotate
+fifo="${log}.fifo"
+rm -f "$fifo" && mkfifo "$fifo" || exit 1
+(
+  exec 3>"$log"
+  size=0
+  forwarded=0
+  while IFS= read -r line || [ -n "$line" ]; do
+    printf '%s\n' "$line" >&3
+    size=$((size + ${#line} + 1))
+    if [ "$size" -ge "$max_bytes" ]; then
+      exec 3>&-
+      rotate
+      exec 3>"$log"
+      size=0
+    fi
+    if [ "$forwarded" -lt "$max_bytes" ]; then
+      printf '%s\n' "$line"
+      forwarded=$((forwarded + ${#line} + 1))
+      if [ "$forwarded" -ge "$max_bytes" ]; then
+        echo "$0: log output capped, the further output is written to $log"
+      fi
+    fi
+  done < "$fifo"
+) &
+reader=$!
+"$@" > "$fifo" 2>&1 &
+pid=$!
+trap 'kill -TERM "$pid" 2>/dev/null' TERM INT
+while :; do
+  wait "$pid"
+  rc=$?
+  kill -0 "$pid" 2>/dev/null || break
+done
+wait "$reader"
+rm -f "$fifo"
+exit "$rc"
+`
+
+// applyContainerLogging propagates the log level of the given container through its env, and wraps its command
+// to cap and rotate its log output when the log rotation is enabled
+func applyContainerLogging(obj *appsv1.DaemonSet, container *corev1.Container, logging *gpuv1.ContainerLogSpec) error {
+	if logging == nil {
+		return nil
+	}
+	if logging.Level != "" {
+		setContainerEnv(container, LogLevelEnvName, logging.Level)
+	}
+	if !logging.IsRotationEnabled() {
+		return nil
+	}
+	// the entrypoint of the image is not known to the operator
+	if len(container.Command) == 0 {
+		return fmt.Errorf("the log output of container %s cannot be rotated as its command is not set", container.Name)
+	}
+
+	maxBytes := logging.Rotation.GetMaxSizeBytes()
+	maxFiles := logging.Rotation.GetMaxFiles()
+	command := append([]string{}, container.Command...)
+	command = append(command, container.Args...)
+	container.Command = []string{"/bin/sh", "-c"}
+	container.Args = append([]string{
+		containerLogWrapper,
+		containerLogWrapperName,
+		filepath.Join(containerLogsDir, container.Name+".log"),
+		strconv.FormatInt(maxBytes, 10),
+		strconv.Itoa(maxFiles),
+	}, command...)
+
+	// the current and the rotated files fit in the volume, with room for the last line written before a rotation
+	sizeLimit := resource.NewQuantity(maxBytes*int64(maxFiles+2), resource.BinarySI)
+	obj.Spec.Template.Spec.Volumes = append(obj.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name:         containerLogsVolumeName,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: sizeLimit}},
+	})
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: containerLogsVolumeName, MountPath: containerLogsDir})
+	return nil
+}
@@ -0,0 +1,91 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	driverconfig "github.com/NVIDIA/gpu-operator/internal/config"
+)
+
+func TestApplyContainerLogging(t *testing.T) {
+	ds := NewDaemonset().WithContainer(corev1.Container{Name: "nvidia-driver-ctr", Command: []string{"nvidia-driver"}, Args: []string{"init"}})
+	container := &ds.Spec.Template.Spec.Containers[0]
+	logging := &gpuv1.ContainerLogSpec{
+		Level:    "debug",
+		Rotation: &gpuv1.LogRotationSpec{Enabled: ptr.To(true), MaxSizeMB: 5, MaxFiles: 2},
+	}
+	require.NoError(t, applyContainerLogging(ds.DaemonSet, container, logging))
+
+	require.Equal(t, "debug", getContainerEnv(container, LogLevelEnvName))
+	require.Equal(t, []string{"/bin/sh", "-c"}, container.Command)
+	require.Equal(t, []string{containerLogWrapper, containerLogWrapperName, "/var/log/nvidia-gpu-operator/nvidia-driver-ctr.log",
+		"5242880", "2", "nvidia-driver", "init"}, container.Args)
+	require.Equal(t, []corev1.VolumeMount{{Name: containerLogsVolumeName, MountPath: containerLogsDir}}, container.VolumeMounts)
+	require.Equal(t, "20Mi", ds.Spec.Template.Spec.Volumes[0].EmptyDir.SizeLimit.String())
+
+	// the entrypoint of the image cannot be wrapped
+	ds = NewDaemonset().WithContainer(corev1.Container{Name: "nvidia-container-toolkit-ctr"})
+	require.Error(t, applyContainerLogging(ds.DaemonSet, &ds.Spec.Template.Spec.Containers[0], logging))
+
+	// the log level alone does not wrap the command
+	ds = NewDaemonset().WithContainer(corev1.Container{Name: "nvidia-container-toolkit-ctr"})
+	require.NoError(t, applyContainerLogging(ds.DaemonSet, &ds.Spec.Template.Spec.Containers[0], &gpuv1.ContainerLogSpec{Level: "error"}))
+	require.Empty(t, ds.Spec.Template.Spec.Containers[0].Command)
+	require.Empty(t, ds.Spec.Template.Spec.Volumes)
+}
+
+func TestTransformDriverLoggingKeepsConfigDigest(t *testing.T) {
+	initMockK8sClients()
+	newDs := func() Daemonset {
+		return NewDaemonset().
+			WithContainer(corev1.Container{Name: "nvidia-driver-ctr", Command: []string{"nvidia-driver"}, Args: []string{"init"}}).
+			WithInitContainer(corev1.Container{Name: "k8s-driver-manager"})
+	}
+	cpSpec := &gpuv1.ClusterPolicySpec{
+		Driver: gpuv1.DriverSpec{
+			Repository: "nvcr.io/nvidia",
+			Image:      "driver",
+			Version:    "570.172.08",
+			Manager: gpuv1.DriverManagerSpec{
+				Repository: "nvcr.io/nvidia/cloud-native",
+				Image:      "k8s-driver-manager",
+				Version:    "v0.8.0",
+			},
+		},
+	}
+	n := ClusterPolicyController{client: mockClientMap["secret-env-client"], runtime: gpuv1.Containerd,
+		operatorNamespace: "test-ns", logger: ctrl.Log.WithName("test"), gpuNodeOSRelease: "ubuntu", gpuNodeOSTag: "ubuntu20.04"}
+
+	ds := newDs()
+	require.NoError(t, TransformDriver(ds.DaemonSet, cpSpec, n))
+	digest := driverconfig.DriverConfigDigestFromPodSpec(&ds.Spec.Template.Spec)
+	require.NotEmpty(t, digest)
+
+	// changing the logging settings restarts the driver pods without reinstalling the driver
+	cpSpec.Driver.Logging = &gpuv1.ContainerLogSpec{Level: "debug", Rotation: &gpuv1.LogRotationSpec{Enabled: ptr.To(true)}}
+	ds = newDs()
+	require.NoError(t, TransformDriver(ds.DaemonSet, cpSpec, n))
+	require.Equal(t, digest, driverconfig.DriverConfigDigestFromPodSpec(&ds.Spec.Template.Spec))
+	require.Equal(t, "/bin/sh", ds.Spec.Template.Spec.Containers[0].Command[0])
+}
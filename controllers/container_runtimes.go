@@ -0,0 +1,147 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"slices"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/audit"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+// runtimeDependentDaemonSets are the DaemonSets rendered for the container runtime of the nodes: the toolkit
+// configures the runtime, and the validator checks the runtime configuration applied by the toolkit
+var runtimeDependentDaemonSets = []string{containerToolkitAppLabelValue, operatorValidatorAppLabelValue}
+
+// reconcileContainerRuntimeLabel keeps the container runtime label of the GPU nodes in sync with the container
+// runtime reported by the kubelet. Returns true if labels were modified.
+func (nlc *nodeLabelingController) reconcileContainerRuntimeLabel(labels map[string]string, node *corev1.Node) bool {
+	var value string
+	if hasCommonGPULabel(labels) {
+		if runtime, err := getRuntimeString(*node); err == nil {
+			value = runtime.String()
+		}
+	}
+	if labels[consts.ContainerRuntimeLabelKey] == value {
+		return false
+	}
+	if value == "" {
+		nlc.logger.Info("Deleting node label", "NodeName", node.Name, "Label", consts.ContainerRuntimeLabelKey)
+		delete(labels, consts.ContainerRuntimeLabelKey)
+		return true
+	}
+	nlc.logger.Info("Setting node label", "NodeName", node.Name, "Label", consts.ContainerRuntimeLabelKey, "Value", value)
+	labels[consts.ContainerRuntimeLabelKey] = value
+	return true
+}
+
+// runtimeDaemonSets deploys the DaemonSet of the current state for the nodes without a container runtime label,
+// then once per container runtime of the GPU nodes of a mixed-runtime cluster. The DaemonSets of the container
+// runtimes which are no longer found on the GPU nodes are deleted.
+func runtimeDaemonSets(n ClusterPolicyController) (gpuv1.State, error) {
+	n.runtimeResolved = true
+	if !n.hasGPUNodes || !n.isStateEnabled(n.stateNames[n.idx]) {
+		n.runtimes = nil
+	}
+
+	if err := n.deleteStaleRuntimeDaemonSets(); err != nil {
+		return gpuv1.NotReady, err
+	}
+
+	overallState, err := DaemonSet(n)
+	if err != nil {
+		return overallState, err
+	}
+	for _, runtime := range n.runtimes {
+		variant := n
+		variant.runtime = runtime
+		variant.runtimeVariant = runtime
+		state, err := DaemonSet(variant)
+		if err != nil {
+			return gpuv1.NotReady, err
+		}
+		if state == gpuv1.NotReady {
+			overallState = gpuv1.NotReady
+		}
+	}
+	return overallState, nil
+}
+
+// deleteStaleRuntimeDaemonSets deletes the DaemonSets of the current state, and of the validator image being
+// deployed, deployed for the container runtimes which are no longer found on the GPU nodes
+func (n ClusterPolicyController) deleteStaleRuntimeDaemonSets() error {
+	items, err := n.listVariantDaemonSets(consts.ContainerRuntimeLabelKey)
+	if err != nil {
+		return err
+	}
+	for i := range items {
+		ds := &items[i]
+		if !n.ownsObject(ds.Labels) || ds.Labels[consts.ValidatorImageLabelKey] != n.validatorImage {
+			continue
+		}
+		if slices.Contains(n.runtimes, gpuv1.Runtime(ds.Labels[consts.ContainerRuntimeLabelKey])) {
+			continue
+		}
+		err := n.client.Delete(audit.WithReason(n.ctx, "container runtime removed"), ds)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyRuntimeVariant restricts the DaemonSet to the nodes running the container runtime being deployed. The
+// DaemonSet of a container runtime is named and labeled after it and is only scheduled on the nodes labeled
+// with it, while the DaemonSet rendered for the default runtime is kept to the nodes without the label.
+func applyRuntimeVariant(obj *appsv1.DaemonSet, n ClusterPolicyController) {
+	if len(n.runtimes) == 0 {
+		return
+	}
+	podSpec := &obj.Spec.Template.Spec
+	if n.runtimeVariant == "" {
+		addRequiredNodeSelectorRequirement(podSpec, corev1.NodeSelectorRequirement{
+			Key:      consts.ContainerRuntimeLabelKey,
+			Operator: corev1.NodeSelectorOpDoesNotExist,
+		})
+		return
+	}
+
+	value := n.runtimeVariant.String()
+	obj.Name += "-" + value
+	if obj.Labels == nil {
+		obj.Labels = make(map[string]string)
+	}
+	obj.Labels[consts.ContainerRuntimeLabelKey] = value
+	if obj.Spec.Selector.MatchLabels == nil {
+		obj.Spec.Selector.MatchLabels = make(map[string]string)
+	}
+	obj.Spec.Selector.MatchLabels[consts.ContainerRuntimeLabelKey] = value
+	if obj.Spec.Template.Labels == nil {
+		obj.Spec.Template.Labels = make(map[string]string)
+	}
+	obj.Spec.Template.Labels[consts.ContainerRuntimeLabelKey] = value
+	addRequiredNodeSelectorRequirement(podSpec, corev1.NodeSelectorRequirement{
+		Key:      consts.ContainerRuntimeLabelKey,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{value},
+	})
+}
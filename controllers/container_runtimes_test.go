@@ -0,0 +1,108 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+func TestReconcileContainerRuntimeLabel(t *testing.T) {
+	nlc := &nodeLabelingController{logger: logr.Discard()}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-node"},
+		Status: corev1.NodeStatus{
+			NodeInfo: corev1.NodeSystemInfo{ContainerRuntimeVersion: "cri-o://1.30.4"},
+		},
+	}
+
+	labels := map[string]string{commonGPULabelKey: commonGPULabelValue}
+	require.True(t, nlc.reconcileContainerRuntimeLabel(labels, node))
+	require.Equal(t, "crio", labels[consts.ContainerRuntimeLabelKey])
+	require.False(t, nlc.reconcileContainerRuntimeLabel(labels, node))
+
+	node.Status.NodeInfo.ContainerRuntimeVersion = "containerd://1.7.20"
+	require.True(t, nlc.reconcileContainerRuntimeLabel(labels, node))
+	require.Equal(t, "containerd", labels[consts.ContainerRuntimeLabelKey])
+
+	// the label is removed from the nodes which are no longer GPU nodes
+	delete(labels, commonGPULabelKey)
+	require.True(t, nlc.reconcileContainerRuntimeLabel(labels, node))
+	require.NotContains(t, labels, consts.ContainerRuntimeLabelKey)
+
+	// and from the nodes running an unknown container runtime
+	labels = map[string]string{commonGPULabelKey: commonGPULabelValue, consts.ContainerRuntimeLabelKey: "docker"}
+	node.Status.NodeInfo.ContainerRuntimeVersion = "unknown://1.0"
+	require.True(t, nlc.reconcileContainerRuntimeLabel(labels, node))
+	require.NotContains(t, labels, consts.ContainerRuntimeLabelKey)
+}
+
+func TestApplyRuntimeVariant(t *testing.T) {
+	newDaemonSet := func() *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: containerToolkitAppLabelValue},
+			Spec: appsv1.DaemonSetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": containerToolkitAppLabelValue}},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": containerToolkitAppLabelValue}},
+				},
+			},
+		}
+	}
+	nodeSelectorRequirements := func(ds *appsv1.DaemonSet) []corev1.NodeSelectorRequirement {
+		terms := ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+		require.Len(t, terms, 1)
+		return terms[0].MatchExpressions
+	}
+
+	// single-runtime cluster
+	ds := newDaemonSet()
+	applyRuntimeVariant(ds, ClusterPolicyController{})
+	require.Equal(t, newDaemonSet(), ds)
+
+	// the DaemonSet of the default runtime is kept off the labeled nodes
+	n := ClusterPolicyController{runtimes: []gpuv1.Runtime{gpuv1.Containerd, gpuv1.CRIO}}
+	ds = newDaemonSet()
+	applyRuntimeVariant(ds, n)
+	require.Equal(t, containerToolkitAppLabelValue, ds.Name)
+	require.Equal(t, []corev1.NodeSelectorRequirement{{
+		Key:      consts.ContainerRuntimeLabelKey,
+		Operator: corev1.NodeSelectorOpDoesNotExist,
+	}}, nodeSelectorRequirements(ds))
+
+	// the DaemonSet of a runtime is restricted to the nodes labeled with it
+	n.runtimeVariant = gpuv1.CRIO
+	ds = newDaemonSet()
+	applyRuntimeVariant(ds, n)
+	require.Equal(t, containerToolkitAppLabelValue+"-crio", ds.Name)
+	require.Equal(t, "crio", ds.Labels[consts.ContainerRuntimeLabelKey])
+	require.Equal(t, "crio", ds.Spec.Selector.MatchLabels[consts.ContainerRuntimeLabelKey])
+	require.Equal(t, "crio", ds.Spec.Template.Labels[consts.ContainerRuntimeLabelKey])
+	require.Equal(t, []corev1.NodeSelectorRequirement{{
+		Key:      consts.ContainerRuntimeLabelKey,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{"crio"},
+	}}, nodeSelectorRequirements(ds))
+}
@@ -0,0 +1,82 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package controllertest provides a fake ClusterPolicyController, reconciling states returning scripted results
+// for the GPU nodes of a fixed snapshot, so that the state transitions of the operator can be tested without API
+// server nor operand assets, e.g. by integration tests and downstream forks. The nodes and the ClusterPolicies are
+// built with the testutil package.
+package controllertest
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/controllers"
+	"github.com/NVIDIA/gpu-operator/internal/testutil"
+)
+
+// FakeState is a state returning scripted results
+type FakeState struct {
+	Name string
+	// Results are returned by the successive syncs of the state, the last one being repeated. The state is
+	// ready when no result is scripted.
+	Results []gpuv1.State
+	// Err is returned by the syncs of the state when set
+	Err error
+	// Syncs counts the syncs of the state
+	Syncs int
+}
+
+func (s *FakeState) sync() (gpuv1.State, error) {
+	s.Syncs++
+	if s.Err != nil {
+		return gpuv1.NotReady, s.Err
+	}
+	if len(s.Results) == 0 {
+		return gpuv1.Ready, nil
+	}
+	return s.Results[min(s.Syncs, len(s.Results))-1], nil
+}
+
+// NewFakeStates returns ready fake states with the names
+func NewFakeStates(names ...string) []*FakeState {
+	states := make([]*FakeState, 0, len(names))
+	for _, name := range names {
+		states = append(states, &FakeState{Name: name})
+	}
+	return states
+}
+
+// NewFakeClusterPolicyController returns a ClusterPolicyController reconciling the fake states for a
+// ClusterPolicy with the spec, with the GPU nodes read from the given nodes. The states are ordered by their
+// dependencies as on the first reconciliation, the test failing if they cannot be.
+func NewFakeClusterPolicyController(t testing.TB, spec gpuv1.ClusterPolicySpec, states []*FakeState, nodes ...corev1.Node) *controllers.ClusterPolicyController {
+	t.Helper()
+	stateFuncs := make([]controllers.StateFunc, 0, len(states))
+	for _, state := range states {
+		stateFuncs = append(stateFuncs, controllers.StateFunc{Name: state.Name, Sync: state.sync})
+	}
+	n, err := controllers.NewClusterPolicyControllerWithStates(context.Background(), ctrl.Log.WithName("fake"),
+		testutil.NewClusterPolicy(testutil.WithSpec(spec)), stateFuncs, nodes...)
+	if err != nil {
+		t.Fatalf("error loading the fake states: %v", err)
+	}
+	return n
+}
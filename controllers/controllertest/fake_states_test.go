@@ -0,0 +1,136 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllertest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestRunStatesWithFakes(t *testing.T) {
+	errSync := errors.New("sync failed")
+	testCases := []struct {
+		description     string
+		results         map[string][]gpuv1.State
+		err             map[string]error
+		expectedState   gpuv1.State
+		expectedErr     error
+		expectedSyncs   map[string]int
+		expectedBlocked []string
+		notReady        []string
+	}{
+		{
+			description:   "all ready",
+			expectedState: gpuv1.Ready,
+			expectedSyncs: map[string]int{
+				"pre-requisites":          1,
+				"state-driver":            1,
+				"state-container-toolkit": 1,
+				"state-device-plugin":     1,
+			},
+			expectedBlocked: []string{},
+			notReady:        []string{},
+		},
+		{
+			description:   "driver not ready",
+			results:       map[string][]gpuv1.State{"state-driver": {gpuv1.NotReady}},
+			expectedState: gpuv1.NotReady,
+			expectedSyncs: map[string]int{
+				"pre-requisites":          1,
+				"state-driver":            1,
+				"state-container-toolkit": 0,
+				"state-device-plugin":     0,
+			},
+			expectedBlocked: []string{
+				"state-container-toolkit (waiting for state-driver)",
+				"state-device-plugin (waiting for state-driver, state-container-toolkit)",
+			},
+			notReady: []string{"state-driver", "state-container-toolkit", "state-device-plugin"},
+		},
+		{
+			description:   "driver disabled",
+			results:       map[string][]gpuv1.State{"state-driver": {gpuv1.Disabled}},
+			expectedState: gpuv1.Ready,
+			expectedSyncs: map[string]int{
+				"pre-requisites":          1,
+				"state-driver":            1,
+				"state-container-toolkit": 1,
+				"state-device-plugin":     1,
+			},
+			expectedBlocked: []string{},
+			notReady:        []string{},
+		},
+		{
+			description:   "toolkit failing",
+			err:           map[string]error{"state-container-toolkit": errSync},
+			expectedState: gpuv1.NotReady,
+			expectedErr:   errSync,
+			expectedSyncs: map[string]int{
+				"pre-requisites":          1,
+				"state-driver":            1,
+				"state-container-toolkit": 1,
+				"state-device-plugin":     0,
+			},
+			expectedBlocked: []string{},
+			notReady:        []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			// the states are loaded out of order, and ordered by their dependencies
+			states := NewFakeStates("state-device-plugin", "state-container-toolkit", "state-driver", "pre-requisites")
+			for _, state := range states {
+				state.Results = tc.results[state.Name]
+				state.Err = tc.err[state.Name]
+			}
+			n := NewFakeClusterPolicyController(t, gpuv1.ClusterPolicySpec{}, states)
+			require.Equal(t, []string{"pre-requisites", "state-driver", "state-container-toolkit", "state-device-plugin"}, n.StateNames())
+
+			state, notReady, err := n.RunStates()
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.Equal(t, tc.expectedState, state)
+			require.Equal(t, tc.notReady, notReady)
+			require.Equal(t, tc.expectedBlocked, n.BlockedStates())
+			for _, state := range states {
+				require.Equal(t, tc.expectedSyncs[state.Name], state.Syncs, state.Name)
+			}
+		})
+	}
+}
+
+func TestRunStatesWithFakesAcrossReconciliations(t *testing.T) {
+	states := NewFakeStates("pre-requisites", "state-driver", "state-container-toolkit")
+	states[1].Results = []gpuv1.State{gpuv1.NotReady, gpuv1.Ready}
+	n := NewFakeClusterPolicyController(t, gpuv1.ClusterPolicySpec{}, states)
+
+	state, notReady, err := n.RunStates()
+	require.NoError(t, err)
+	require.Equal(t, gpuv1.NotReady, state)
+	require.Equal(t, []string{"state-driver", "state-container-toolkit"}, notReady)
+
+	// the next reconciliation starts over from the first state, once the driver is ready
+	state, notReady, err = n.RunStates()
+	require.NoError(t, err)
+	require.Equal(t, gpuv1.Ready, state)
+	require.Empty(t, notReady)
+	require.Equal(t, []int{2, 2, 1}, []int{states[0].Syncs, states[1].Syncs, states[2].Syncs})
+}
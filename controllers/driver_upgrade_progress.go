@@ -0,0 +1,200 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/NVIDIA/k8s-operator-libs/pkg/upgrade"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=nvidia.com,resources=driverupgrades,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=nvidia.com,resources=driverupgrades/status,verbs=get;update;patch
+
+// driverUpgradeProgress collects the upgrade state of the nodes whose driver upgrades are managed through an
+// owner, the ClusterPolicy or an NVIDIADriver, to be published in the DriverUpgrade of the owner
+type driverUpgradeProgress struct {
+	owner client.Object
+	nodes []nvidiav1alpha1.DriverUpgradeNodeStatus
+}
+
+// addState adds the nodes of the upgrade state, upgraded with the driver type
+func (p *driverUpgradeProgress) addState(driverType string, state *upgrade.ClusterUpgradeState) {
+	for stateKey, nodeStates := range state.NodeStates {
+		for _, nodeState := range nodeStates {
+			p.nodes = append(p.nodes, nvidiav1alpha1.DriverUpgradeNodeStatus{
+				Name:       nodeState.Node.Name,
+				DriverType: driverType,
+				Phase:      getDriverUpgradeNodePhase(stateKey),
+				State:      stateKey,
+			})
+		}
+	}
+}
+
+// getDriverUpgradeNodePhase maps the upgrade state of a node, held by its upgrade state label, to the phase of
+// its driver upgrade. The nodes whose state is not known yet are pending.
+func getDriverUpgradeNodePhase(state string) nvidiav1alpha1.DriverUpgradeNodePhase {
+	switch state {
+	case upgrade.UpgradeStateCordonRequired, upgrade.UpgradeStateWaitForJobsRequired:
+		return nvidiav1alpha1.DriverUpgradeNodePhaseCordoned
+	case upgrade.UpgradeStatePodDeletionRequired, upgrade.UpgradeStateDrainRequired, upgrade.UpgradeStateNodeMaintenanceRequired:
+		return nvidiav1alpha1.DriverUpgradeNodePhaseDraining
+	case upgrade.UpgradeStatePostMaintenanceRequired, upgrade.UpgradeStatePodRestartRequired:
+		return nvidiav1alpha1.DriverUpgradeNodePhaseUpgrading
+	case upgrade.UpgradeStateValidationRequired, upgrade.UpgradeStateUncordonRequired:
+		return nvidiav1alpha1.DriverUpgradeNodePhaseValidating
+	case upgrade.UpgradeStateDone:
+		return nvidiav1alpha1.DriverUpgradeNodePhaseDone
+	case upgrade.UpgradeStateFailed:
+		return nvidiav1alpha1.DriverUpgradeNodePhaseFailed
+	default:
+		return nvidiav1alpha1.DriverUpgradeNodePhasePending
+	}
+}
+
+// buildDriverUpgradeProgressStatus builds the DriverUpgrade status of the nodes. The transition time of the
+// nodes whose phase is unchanged since the previous status is kept. The upgrade is failed as soon as a node
+// failed, in progress while a node is being upgraded, and done once the driver of all nodes is up to date.
+func buildDriverUpgradeProgressStatus(nodes []nvidiav1alpha1.DriverUpgradeNodeStatus, previous *nvidiav1alpha1.DriverUpgradeStatus,
+	now metav1.Time) *nvidiav1alpha1.DriverUpgradeStatus {
+	status := &nvidiav1alpha1.DriverUpgradeStatus{
+		TotalNodes: len(nodes),
+		Nodes:      slices.Clone(nodes),
+		Conditions: slices.Clone(previous.Conditions),
+	}
+	slices.SortFunc(status.Nodes, func(a, b nvidiav1alpha1.DriverUpgradeNodeStatus) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for i := range status.Nodes {
+		node := &status.Nodes[i]
+		node.LastTransitionTime = now
+		for _, previousNode := range previous.Nodes {
+			if previousNode.Name == node.Name && previousNode.Phase == node.Phase {
+				node.LastTransitionTime = previousNode.LastTransitionTime
+				break
+			}
+		}
+		switch node.Phase {
+		case nvidiav1alpha1.DriverUpgradeNodePhasePending:
+			status.PendingNodes++
+		case nvidiav1alpha1.DriverUpgradeNodePhaseDone:
+			status.DoneNodes++
+		case nvidiav1alpha1.DriverUpgradeNodePhaseFailed:
+			status.FailedNodes++
+		default:
+			status.InProgressNodes++
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:               nvidiav1alpha1.DriverUpgradeConditionComplete,
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: now,
+		Message:            fmt.Sprintf("%d of %d nodes upgraded", status.DoneNodes, status.TotalNodes),
+	}
+	switch {
+	case status.FailedNodes > 0:
+		status.Phase = nvidiav1alpha1.DriverUpgradePhaseFailed
+		condition.Reason = "UpgradeFailed"
+		condition.Message = fmt.Sprintf("%s, %d failed", condition.Message, status.FailedNodes)
+	case status.InProgressNodes > 0:
+		status.Phase = nvidiav1alpha1.DriverUpgradePhaseInProgress
+		condition.Reason = "UpgradeInProgress"
+	case status.PendingNodes > 0:
+		status.Phase = nvidiav1alpha1.DriverUpgradePhasePending
+		condition.Reason = "UpgradePending"
+	default:
+		status.Phase = nvidiav1alpha1.DriverUpgradePhaseDone
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "UpgradeDone"
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+	return status
+}
+
+// syncDriverUpgrades publishes the progress of the driver upgrades in a DriverUpgrade per owner, named after
+// the owner in the operator namespace. The DriverUpgrades of the owners no longer managing driver upgrades
+// are deleted.
+func (r *UpgradeReconciler) syncDriverUpgrades(ctx context.Context, progresses []*driverUpgradeProgress) error {
+	namespace := clusterPolicyCtrl.operatorNamespace
+	list := &nvidiav1alpha1.DriverUpgradeList{}
+	if err := r.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("failed to list DriverUpgrades: %w", err)
+	}
+
+	existing := map[string]*nvidiav1alpha1.DriverUpgrade{}
+	for i := range list.Items {
+		driverUpgrade := &list.Items[i]
+		// a DriverUpgrade left by another owner of the same name is recreated
+		if idx := slices.IndexFunc(progresses, func(p *driverUpgradeProgress) bool {
+			return p.owner.GetName() == driverUpgrade.Name
+		}); idx >= 0 && metav1.IsControlledBy(driverUpgrade, progresses[idx].owner) {
+			existing[driverUpgrade.Name] = driverUpgrade
+			continue
+		}
+		r.Log.Info("Deleting DriverUpgrade", "name", driverUpgrade.Name)
+		if err := r.Delete(ctx, driverUpgrade); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete DriverUpgrade %s: %w", driverUpgrade.Name, err)
+		}
+	}
+
+	now := metav1.Now()
+	for _, progress := range progresses {
+		driverUpgrade, ok := existing[progress.owner.GetName()]
+		if !ok {
+			driverUpgrade = &nvidiav1alpha1.DriverUpgrade{
+				ObjectMeta: metav1.ObjectMeta{Name: progress.owner.GetName(), Namespace: namespace},
+			}
+			if err := controllerutil.SetControllerReference(progress.owner, driverUpgrade, r.Scheme); err != nil {
+				return err
+			}
+			r.Log.Info("Creating DriverUpgrade", "name", driverUpgrade.Name)
+			if err := r.Create(ctx, driverUpgrade); err != nil {
+				return fmt.Errorf("failed to create DriverUpgrade %s: %w", driverUpgrade.Name, err)
+			}
+		}
+
+		status := buildDriverUpgradeProgressStatus(progress.nodes, &driverUpgrade.Status, now)
+		if equality.Semantic.DeepEqual(&driverUpgrade.Status, status) {
+			continue
+		}
+		driverUpgrade.Status = *status
+		if err := r.Status().Update(ctx, driverUpgrade); err != nil {
+			return fmt.Errorf("failed to update the status of DriverUpgrade %s: %w", driverUpgrade.Name, err)
+		}
+	}
+	return nil
+}
+
+// removeDriverUpgrades deletes the DriverUpgrades once the driver upgrades are no longer managed
+func (r *UpgradeReconciler) removeDriverUpgrades(ctx context.Context) {
+	if err := r.syncDriverUpgrades(ctx, nil); err != nil {
+		r.Log.Error(err, "Failed to remove the DriverUpgrades")
+	}
+}
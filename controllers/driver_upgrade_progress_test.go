@@ -0,0 +1,149 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/k8s-operator-libs/pkg/upgrade"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+func newDriverUpgradeProgress(owner client.Object, statesByNode map[string]string) *driverUpgradeProgress {
+	state := upgrade.NewClusterUpgradeState()
+	for name, stateKey := range statesByNode {
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		state.NodeStates[stateKey] = append(state.NodeStates[stateKey], &upgrade.NodeUpgradeState{Node: node})
+	}
+	progress := &driverUpgradeProgress{owner: owner}
+	progress.addState(string(gpuv1.DriverUpgradeTypeGPU), &state)
+	return progress
+}
+
+func TestGetDriverUpgradeNodePhase(t *testing.T) {
+	for state, phase := range map[string]nvidiav1alpha1.DriverUpgradeNodePhase{
+		upgrade.UpgradeStateUnknown:                 nvidiav1alpha1.DriverUpgradeNodePhasePending,
+		upgrade.UpgradeStateUpgradeRequired:         nvidiav1alpha1.DriverUpgradeNodePhasePending,
+		upgrade.UpgradeStateCordonRequired:          nvidiav1alpha1.DriverUpgradeNodePhaseCordoned,
+		upgrade.UpgradeStateWaitForJobsRequired:     nvidiav1alpha1.DriverUpgradeNodePhaseCordoned,
+		upgrade.UpgradeStatePodDeletionRequired:     nvidiav1alpha1.DriverUpgradeNodePhaseDraining,
+		upgrade.UpgradeStateDrainRequired:           nvidiav1alpha1.DriverUpgradeNodePhaseDraining,
+		upgrade.UpgradeStateNodeMaintenanceRequired: nvidiav1alpha1.DriverUpgradeNodePhaseDraining,
+		upgrade.UpgradeStatePostMaintenanceRequired: nvidiav1alpha1.DriverUpgradeNodePhaseUpgrading,
+		upgrade.UpgradeStatePodRestartRequired:      nvidiav1alpha1.DriverUpgradeNodePhaseUpgrading,
+		upgrade.UpgradeStateValidationRequired:      nvidiav1alpha1.DriverUpgradeNodePhaseValidating,
+		upgrade.UpgradeStateUncordonRequired:        nvidiav1alpha1.DriverUpgradeNodePhaseValidating,
+		upgrade.UpgradeStateDone:                    nvidiav1alpha1.DriverUpgradeNodePhaseDone,
+		upgrade.UpgradeStateFailed:                  nvidiav1alpha1.DriverUpgradeNodePhaseFailed,
+	} {
+		require.Equal(t, phase, getDriverUpgradeNodePhase(state), state)
+	}
+}
+
+func TestBuildDriverUpgradeProgressStatus(t *testing.T) {
+	earlier := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(earlier.Add(time.Hour))
+	progress := newDriverUpgradeProgress(nil, map[string]string{
+		"node-c": upgrade.UpgradeStateDrainRequired,
+		"node-a": upgrade.UpgradeStateDone,
+		"node-b": upgrade.UpgradeStateUpgradeRequired,
+	})
+	previous := &nvidiav1alpha1.DriverUpgradeStatus{Nodes: []nvidiav1alpha1.DriverUpgradeNodeStatus{
+		{Name: "node-a", Phase: nvidiav1alpha1.DriverUpgradeNodePhaseDone, LastTransitionTime: earlier},
+		{Name: "node-c", Phase: nvidiav1alpha1.DriverUpgradeNodePhaseCordoned, LastTransitionTime: earlier},
+	}}
+
+	status := buildDriverUpgradeProgressStatus(progress.nodes, previous, now)
+	require.Equal(t, nvidiav1alpha1.DriverUpgradePhaseInProgress, status.Phase)
+	require.Equal(t, 3, status.TotalNodes)
+	require.Equal(t, 1, status.PendingNodes)
+	require.Equal(t, 1, status.InProgressNodes)
+	require.Equal(t, 1, status.DoneNodes)
+	require.Equal(t, []nvidiav1alpha1.DriverUpgradeNodeStatus{
+		{Name: "node-a", DriverType: "gpu", Phase: nvidiav1alpha1.DriverUpgradeNodePhaseDone, State: upgrade.UpgradeStateDone, LastTransitionTime: earlier},
+		{Name: "node-b", DriverType: "gpu", Phase: nvidiav1alpha1.DriverUpgradeNodePhasePending, State: upgrade.UpgradeStateUpgradeRequired, LastTransitionTime: now},
+		{Name: "node-c", DriverType: "gpu", Phase: nvidiav1alpha1.DriverUpgradeNodePhaseDraining, State: upgrade.UpgradeStateDrainRequired, LastTransitionTime: now},
+	}, status.Nodes)
+	require.True(t, meta.IsStatusConditionFalse(status.Conditions, nvidiav1alpha1.DriverUpgradeConditionComplete))
+
+	// a failed node fails the upgrade
+	progress = newDriverUpgradeProgress(nil, map[string]string{"node-a": upgrade.UpgradeStateDone, "node-b": upgrade.UpgradeStateFailed})
+	status = buildDriverUpgradeProgressStatus(progress.nodes, status, now)
+	require.Equal(t, nvidiav1alpha1.DriverUpgradePhaseFailed, status.Phase)
+	require.Equal(t, "1 of 2 nodes upgraded, 1 failed", meta.FindStatusCondition(status.Conditions, nvidiav1alpha1.DriverUpgradeConditionComplete).Message)
+
+	progress = newDriverUpgradeProgress(nil, map[string]string{"node-a": upgrade.UpgradeStateDone})
+	status = buildDriverUpgradeProgressStatus(progress.nodes, status, now)
+	require.Equal(t, nvidiav1alpha1.DriverUpgradePhaseDone, status.Phase)
+	require.True(t, meta.IsStatusConditionTrue(status.Conditions, nvidiav1alpha1.DriverUpgradeConditionComplete))
+}
+
+func TestSyncDriverUpgrades(t *testing.T) {
+	operatorNamespace := clusterPolicyCtrl.operatorNamespace
+	clusterPolicyCtrl.operatorNamespace = "gpu-operator"
+	defer func() { clusterPolicyCtrl.operatorNamespace = operatorNamespace }()
+
+	s := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(s))
+	require.NoError(t, gpuv1.AddToScheme(s))
+	require.NoError(t, nvidiav1alpha1.AddToScheme(s))
+	nvd := &nvidiav1alpha1.NVIDIADriver{ObjectMeta: metav1.ObjectMeta{Name: "default", UID: "nvd-uid"}}
+	stale := &nvidiav1alpha1.DriverUpgrade{ObjectMeta: metav1.ObjectMeta{Name: "removed", Namespace: "gpu-operator"}}
+	k8sClient := fake.NewClientBuilder().WithScheme(s).WithObjects(nvd, stale).
+		WithStatusSubresource(&nvidiav1alpha1.DriverUpgrade{}).Build()
+	r := &UpgradeReconciler{Client: k8sClient, Scheme: s, Log: logr.Discard()}
+
+	progress := newDriverUpgradeProgress(nvd, map[string]string{
+		"node-a": upgrade.UpgradeStateDone,
+		"node-b": upgrade.UpgradeStateCordonRequired,
+	})
+	require.NoError(t, r.syncDriverUpgrades(context.Background(), []*driverUpgradeProgress{progress}))
+
+	list := &nvidiav1alpha1.DriverUpgradeList{}
+	require.NoError(t, k8sClient.List(context.Background(), list))
+	require.Len(t, list.Items, 1)
+	driverUpgrade := list.Items[0]
+	require.Equal(t, "default", driverUpgrade.Name)
+	require.Equal(t, "gpu-operator", driverUpgrade.Namespace)
+	require.True(t, metav1.IsControlledBy(&driverUpgrade, nvd))
+	require.Equal(t, nvidiav1alpha1.DriverUpgradePhaseInProgress, driverUpgrade.Status.Phase)
+	require.Len(t, driverUpgrade.Status.Nodes, 2)
+
+	// the status is not updated when unchanged
+	require.NoError(t, r.syncDriverUpgrades(context.Background(), []*driverUpgradeProgress{progress}))
+	updated := &nvidiav1alpha1.DriverUpgrade{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(&driverUpgrade), updated))
+	require.Equal(t, driverUpgrade.ResourceVersion, updated.ResourceVersion)
+
+	// the DriverUpgrades are deleted once the upgrades are no longer managed
+	r.removeDriverUpgrades(context.Background())
+	require.NoError(t, k8sClient.List(context.Background(), list))
+	require.Empty(t, list.Items)
+}
@@ -0,0 +1,171 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+// externalGatePrerequisitePrefix prefixes the name of the external gates reported as prerequisites of the states
+const externalGatePrerequisitePrefix = "external gate "
+
+// reconcileExternalGates evaluates the external gates of the ClusterPolicy, records the states held by the
+// closed gates and reports the gates in the ClusterPolicy status. A gate not met within its timeout stops
+// holding its states, and a warning event is reported.
+func (n *ClusterPolicyController) reconcileExternalGates(ctx context.Context, now time.Time) error {
+	n.closedExternalGates = nil
+	gates := n.singleton.Spec.GetExternalGates()
+
+	previous := make(map[string]gpuv1.ExternalGateStatus, len(n.singleton.Status.ExternalGates))
+	for _, status := range n.singleton.Status.ExternalGates {
+		previous[status.Name] = status
+	}
+
+	var statuses []gpuv1.ExternalGateStatus
+	for i := range gates {
+		gate := &gates[i]
+		if !gate.IsEnabled() {
+			continue
+		}
+		for _, state := range gate.States {
+			if !slices.Contains(n.stateNames, state) {
+				return fmt.Errorf("external gate %q holds unknown state %q", gate.Name, state)
+			}
+		}
+
+		met, message := n.isExternalGateMet(ctx, gate)
+		status := gpuv1.ExternalGateStatus{Name: gate.Name, Open: met}
+		if !met {
+			status.Message = message
+			status.WaitingSince = previous[gate.Name].WaitingSince
+			if status.WaitingSince == nil {
+				status.WaitingSince = &metav1.Time{Time: now}
+			}
+			if timeout := gate.GetTimeout(); timeout > 0 && now.Sub(status.WaitingSince.Time) >= timeout {
+				status.Open = true
+				status.TimedOut = true
+				if !previous[gate.Name].TimedOut {
+					n.reportExternalGateTimeout(gate, message)
+				}
+			}
+		}
+		if !status.Open {
+			if n.closedExternalGates == nil {
+				n.closedExternalGates = make(map[string][]string)
+			}
+			for _, state := range gate.States {
+				n.closedExternalGates[state] = append(n.closedExternalGates[state], externalGatePrerequisitePrefix+gate.Name)
+			}
+		}
+		statuses = append(statuses, status)
+	}
+
+	return n.updateExternalGatesStatus(ctx, statuses)
+}
+
+// isExternalGateMet returns true if the resource of the gate reports its condition, or if all GPU nodes match
+// the node selector of the gate, otherwise the reason why the gate is not met
+func (n ClusterPolicyController) isExternalGateMet(ctx context.Context, gate *gpuv1.ExternalGateSpec) (bool, string) {
+	if ref := gate.Resource; ref != nil {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(ref.APIVersion)
+		obj.SetKind(ref.Kind)
+		if err := n.client.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, fmt.Sprintf("%s %s not found", ref.Kind, ref.Name)
+			}
+			return false, fmt.Sprintf("unable to get %s %s: %v", ref.Kind, ref.Name, err)
+		}
+		if ref.ConditionType == "" {
+			return true, ""
+		}
+		conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+		for _, c := range conditions {
+			condition, ok := c.(map[string]interface{})
+			if ok && condition["type"] == ref.ConditionType && condition["status"] == string(metav1.ConditionTrue) {
+				return true, ""
+			}
+		}
+		return false, fmt.Sprintf("%s %s does not report the %s condition", ref.Kind, ref.Name, ref.ConditionType)
+	}
+
+	if gate.NodeSelector == nil {
+		return true, ""
+	}
+	selector, err := metav1.LabelSelectorAsSelector(gate.NodeSelector)
+	if err != nil {
+		return false, fmt.Sprintf("invalid node selector: %v", err)
+	}
+	nodes, err := n.listGPUNodes(nil)
+	if err != nil {
+		return false, err.Error()
+	}
+	notMatching := 0
+	for _, node := range nodes {
+		if !selector.Matches(labels.Set(node.Labels)) {
+			notMatching++
+		}
+	}
+	if notMatching > 0 {
+		return false, fmt.Sprintf("%d of %d GPU nodes do not match the node selector", notMatching, len(nodes))
+	}
+	return true, ""
+}
+
+// reportExternalGateTimeout reports that the gate stops holding its states as it was not met within its timeout
+func (n ClusterPolicyController) reportExternalGateTimeout(gate *gpuv1.ExternalGateSpec, message string) {
+	n.logger.Info("WARNING: external gate timed out, deploying the states it holds", "gate", gate.Name, "states", gate.States, "reason", message)
+	if n.recorder != nil {
+		n.recorder.Eventf(n.singleton, nil, corev1.EventTypeWarning, conditions.ExternalGateTimedOut, "DeployStates",
+			"External gate %s not met within %s (%s), deploying states %v", gate.Name, gate.GetTimeout(), message, gate.States)
+	}
+}
+
+// updateExternalGatesStatus records the status of the external gates in the ClusterPolicy status
+func (n ClusterPolicyController) updateExternalGatesStatus(ctx context.Context, statuses []gpuv1.ExternalGateStatus) error {
+	if equality.Semantic.DeepEqual(n.singleton.Status.ExternalGates, statuses) {
+		return nil
+	}
+	// Fetch latest instance and update status to avoid version mismatch
+	instance := &gpuv1.ClusterPolicy{}
+	if err := n.client.Get(ctx, types.NamespacedName{Name: n.singleton.Name}, instance); err != nil {
+		return fmt.Errorf("failed to get ClusterPolicy instance for status update: %w", err)
+	}
+	if equality.Semantic.DeepEqual(instance.Status.ExternalGates, statuses) {
+		return nil
+	}
+	instance.Status.ExternalGates = statuses
+	if err := n.client.Status().Update(ctx, instance); err != nil {
+		return fmt.Errorf("failed to update ClusterPolicy external gates status: %w", err)
+	}
+	n.singleton.Status.ExternalGates = statuses
+	return nil
+}
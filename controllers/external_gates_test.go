@@ -0,0 +1,188 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func newExternalGatesTestController(cp *gpuv1.ClusterPolicy, objects ...client.Object) (*ClusterPolicyController, *events.FakeRecorder) {
+	recorder := events.NewFakeRecorder(10)
+	n := &ClusterPolicyController{
+		ctx:               context.Background(),
+		singleton:         cp,
+		client:            fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(append(objects, cp)...).WithStatusSubresource(cp).Build(),
+		logger:            logr.Discard(),
+		operatorNamespace: "test-ns",
+		stateNames:        []string{"pre-requisites", "state-driver", "state-container-toolkit"},
+		recorder:          recorder,
+	}
+	return n, recorder
+}
+
+func gpuNode(name string, labels map[string]string) *corev1.Node {
+	nodeLabels := map[string]string{commonGPULabelKey: commonGPULabelValue}
+	for k, v := range labels {
+		nodeLabels[k] = v
+	}
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nodeLabels}}
+}
+
+func TestExternalGateResource(t *testing.T) {
+	cp := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy"},
+		Spec: gpuv1.ClusterPolicySpec{
+			ExternalGates: []gpuv1.ExternalGateSpec{{
+				Name:   "storage",
+				States: []string{"state-driver"},
+				Resource: &gpuv1.ExternalGateResourceReference{
+					APIVersion: "apps/v1", Kind: "DaemonSet", Namespace: "storage", Name: "csi-node",
+				},
+			}},
+		},
+	}
+	n, _ := newExternalGatesTestController(cp)
+	now := time.Now()
+
+	require.NoError(t, n.reconcileExternalGates(context.Background(), now))
+	require.Equal(t, []string{"external gate storage"}, n.notReadyPrerequisites("state-driver"))
+	require.Empty(t, n.notReadyPrerequisites("state-container-toolkit"))
+
+	updated := &gpuv1.ClusterPolicy{}
+	require.NoError(t, n.client.Get(context.Background(), types.NamespacedName{Name: cp.Name}, updated))
+	require.Len(t, updated.Status.ExternalGates, 1)
+	require.False(t, updated.Status.ExternalGates[0].Open)
+	require.Equal(t, "DaemonSet csi-node not found", updated.Status.ExternalGates[0].Message)
+	require.NotNil(t, updated.Status.ExternalGates[0].WaitingSince)
+
+	require.NoError(t, n.client.Create(context.Background(), &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "csi-node", Namespace: "storage"}}))
+	require.NoError(t, n.reconcileExternalGates(context.Background(), now.Add(time.Minute)))
+	require.Empty(t, n.notReadyPrerequisites("state-driver"))
+	require.Equal(t, []gpuv1.ExternalGateStatus{{Name: "storage", Open: true}}, n.singleton.Status.ExternalGates)
+}
+
+func TestExternalGateResourceCondition(t *testing.T) {
+	cp := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy"},
+		Spec: gpuv1.ClusterPolicySpec{
+			ExternalGates: []gpuv1.ExternalGateSpec{{
+				Name:   "storage",
+				States: []string{"state-driver"},
+				Resource: &gpuv1.ExternalGateResourceReference{
+					APIVersion: "v1", Kind: "Node", Name: "node-a", ConditionType: "StorageReady",
+				},
+			}},
+		},
+	}
+	node := gpuNode("node-a", nil)
+	n, _ := newExternalGatesTestController(cp, node)
+
+	require.NoError(t, n.reconcileExternalGates(context.Background(), time.Now()))
+	require.Equal(t, []string{"external gate storage"}, n.notReadyPrerequisites("state-driver"))
+	require.Equal(t, "Node node-a does not report the StorageReady condition", n.singleton.Status.ExternalGates[0].Message)
+
+	node.Status.Conditions = []corev1.NodeCondition{{Type: "StorageReady", Status: corev1.ConditionTrue}}
+	require.NoError(t, n.client.Status().Update(context.Background(), node))
+	require.NoError(t, n.reconcileExternalGates(context.Background(), time.Now()))
+	require.Empty(t, n.notReadyPrerequisites("state-driver"))
+}
+
+func TestExternalGateMOFEDTimeout(t *testing.T) {
+	cp := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy"},
+		Spec: gpuv1.ClusterPolicySpec{
+			Driver: gpuv1.DriverSpec{GPUDirectRDMA: &gpuv1.GPUDirectRDMASpec{Enabled: ptr.To(true)}},
+		},
+	}
+	n, recorder := newExternalGatesTestController(cp,
+		gpuNode("node-a", map[string]string{"network.nvidia.com/operator.mofed.wait": "true"}),
+		gpuNode("node-b", map[string]string{"network.nvidia.com/operator.mofed.wait": "false"}),
+		gpuNode("node-c", nil))
+	now := time.Now()
+
+	require.NoError(t, n.reconcileExternalGates(context.Background(), now))
+	require.Equal(t, []string{"external gate mofed"}, n.notReadyPrerequisites("state-driver"))
+	require.Equal(t, "1 of 3 GPU nodes do not match the node selector", n.singleton.Status.ExternalGates[0].Message)
+
+	// the gate keeps waiting since the time it was first found not met
+	require.NoError(t, n.reconcileExternalGates(context.Background(), now.Add(5*time.Minute)))
+	require.Equal(t, []string{"external gate mofed"}, n.notReadyPrerequisites("state-driver"))
+	require.Empty(t, recorder.Events)
+
+	require.NoError(t, n.reconcileExternalGates(context.Background(), now.Add(10*time.Minute)))
+	require.Empty(t, n.notReadyPrerequisites("state-driver"))
+	status := n.singleton.Status.ExternalGates[0]
+	require.True(t, status.Open)
+	require.True(t, status.TimedOut)
+	require.Len(t, recorder.Events, 1)
+
+	// the timeout is reported once
+	require.NoError(t, n.reconcileExternalGates(context.Background(), now.Add(11*time.Minute)))
+	require.Len(t, recorder.Events, 1)
+}
+
+func TestExternalGateOverridesMOFEDGate(t *testing.T) {
+	cp := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy"},
+		Spec: gpuv1.ClusterPolicySpec{
+			Driver: gpuv1.DriverSpec{GPUDirectRDMA: &gpuv1.GPUDirectRDMASpec{Enabled: ptr.To(true)}},
+			ExternalGates: []gpuv1.ExternalGateSpec{{
+				Name:         gpuv1.MOFEDExternalGateName,
+				Enabled:      ptr.To(false),
+				States:       []string{"state-driver"},
+				NodeSelector: &metav1.LabelSelector{},
+			}},
+		},
+	}
+	n, _ := newExternalGatesTestController(cp, gpuNode("node-a", map[string]string{"network.nvidia.com/operator.mofed.wait": "true"}))
+
+	require.NoError(t, n.reconcileExternalGates(context.Background(), time.Now()))
+	require.Empty(t, n.notReadyPrerequisites("state-driver"))
+	require.Empty(t, n.singleton.Status.ExternalGates)
+}
+
+func TestExternalGateUnknownState(t *testing.T) {
+	cp := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy"},
+		Spec: gpuv1.ClusterPolicySpec{
+			ExternalGates: []gpuv1.ExternalGateSpec{{
+				Name:         "storage",
+				States:       []string{"state-gds"},
+				NodeSelector: &metav1.LabelSelector{},
+			}},
+		},
+	}
+	n, _ := newExternalGatesTestController(cp)
+
+	require.EqualError(t, n.reconcileExternalGates(context.Background(), time.Now()), `external gate "storage" holds unknown state "state-gds"`)
+}
@@ -0,0 +1,170 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+const (
+	// fleetReportLabelKey is the label identifying the ConfigMaps holding the GPU fleet reports
+	fleetReportLabelKey = "nvidia.com/gpu-fleet-report"
+	// fleetReportGeneratedAtAnnotationKey records when a fleet report was generated
+	fleetReportGeneratedAtAnnotationKey = "nvidia.com/gpu-fleet-report.generated-at"
+	fleetReportNamePrefix               = "gpu-fleet-report-"
+	fleetReportDataKey                  = "report.json"
+)
+
+// fleetReport summarizes the state of the GPU nodes of the cluster at a point in time
+type fleetReport struct {
+	GeneratedAt time.Time `json:"generatedAt"`
+	Nodes       int       `json:"nodes"`
+	// DriverVersions is the number of nodes per installed driver version
+	DriverVersions map[string]int `json:"driverVersions"`
+	Operands       struct {
+		ToolkitReady      int `json:"toolkitReady"`
+		DevicePluginReady int `json:"devicePluginReady"`
+		Validated         int `json:"validated"`
+	} `json:"operands"`
+	// UpgradeStates is the number of nodes per driver upgrade state
+	UpgradeStates map[string]int `json:"upgradeStates"`
+	// FailedValidations lists the nodes that have not passed validation
+	FailedValidations []string `json:"failedValidations"`
+}
+
+// buildFleetReport aggregates the operand status of the GPU nodes into a fleet report
+func buildFleetReport(nodes []gpuv1.NodeOperandStatus, now time.Time) *fleetReport {
+	report := &fleetReport{
+		GeneratedAt:       now.UTC(),
+		Nodes:             len(nodes),
+		DriverVersions:    make(map[string]int),
+		UpgradeStates:     make(map[string]int),
+		FailedValidations: []string{},
+	}
+	for _, node := range nodes {
+		driverVersion := node.DriverVersion
+		if driverVersion == "" {
+			driverVersion = "unknown"
+		}
+		report.DriverVersions[driverVersion]++
+		if node.UpgradeState != "" {
+			report.UpgradeStates[node.UpgradeState]++
+		}
+		if node.ToolkitReady {
+			report.Operands.ToolkitReady++
+		}
+		if node.DevicePluginReady {
+			report.Operands.DevicePluginReady++
+		}
+		if node.Validated {
+			report.Operands.Validated++
+		} else {
+			report.FailedValidations = append(report.FailedValidations, node.Name)
+		}
+	}
+	return report
+}
+
+// reconcileFleetReport writes a new fleet report to a ConfigMap once the report interval has elapsed
+// since the latest report, and deletes the reports exceeding the history limit. It returns the time
+// remaining until the next report is due, or zero when the fleet report is disabled.
+func (n ClusterPolicyController) reconcileFleetReport(ctx context.Context, nodes []gpuv1.NodeOperandStatus, now time.Time) (time.Duration, error) {
+	spec := n.singleton.Spec.FleetReport
+	if !spec.IsEnabled() {
+		return 0, nil
+	}
+
+	list := &corev1.ConfigMapList{}
+	err := n.client.List(ctx, list, client.InNamespace(n.operatorNamespace), client.MatchingLabels{fleetReportLabelKey: "true"})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list fleet reports: %w", err)
+	}
+	reports := list.Items
+	// most recent report first
+	sort.Slice(reports, func(i, j int) bool {
+		return getFleetReportTime(&reports[i]).After(getFleetReportTime(&reports[j]))
+	})
+
+	interval := spec.GetInterval()
+	nextReport := interval
+	if len(reports) > 0 {
+		nextReport = getFleetReportTime(&reports[0]).Add(interval).Sub(now)
+	}
+	if nextReport <= 0 || len(reports) == 0 {
+		report, err := n.createFleetReport(ctx, nodes, now)
+		if err != nil {
+			return 0, err
+		}
+		reports = append([]corev1.ConfigMap{*report}, reports...)
+		nextReport = interval
+	}
+
+	for i := spec.GetHistoryLimit(); i < len(reports); i++ {
+		n.logger.Info("Deleting fleet report exceeding the history limit", "name", reports[i].Name)
+		if err := n.client.Delete(ctx, &reports[i]); err != nil && !apierrors.IsNotFound(err) {
+			return 0, fmt.Errorf("failed to delete fleet report %s: %w", reports[i].Name, err)
+		}
+	}
+	return nextReport, nil
+}
+
+func (n ClusterPolicyController) createFleetReport(ctx context.Context, nodes []gpuv1.NodeOperandStatus, now time.Time) (*corev1.ConfigMap, error) {
+	data, err := json.MarshalIndent(buildFleetReport(nodes, now), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal fleet report: %w", err)
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%s%d", fleetReportNamePrefix, now.Unix()),
+			Namespace:   n.operatorNamespace,
+			Labels:      map[string]string{fleetReportLabelKey: "true"},
+			Annotations: map[string]string{fleetReportGeneratedAtAnnotationKey: now.UTC().Format(time.RFC3339)},
+		},
+		Data: map[string]string{fleetReportDataKey: string(data)},
+	}
+	if err := controllerutil.SetControllerReference(n.singleton, cm, n.scheme); err != nil {
+		return nil, err
+	}
+
+	n.logger.Info("Creating fleet report", "name", cm.Name)
+	if err := n.client.Create(ctx, cm); err != nil {
+		return nil, fmt.Errorf("failed to create fleet report %s: %w", cm.Name, err)
+	}
+	return cm, nil
+}
+
+// getFleetReportTime returns when a fleet report was generated, falling back to the creation
+// time of the ConfigMap when the annotation is missing or invalid
+func getFleetReportTime(cm *corev1.ConfigMap) time.Time {
+	if generatedAt, err := time.Parse(time.RFC3339, cm.Annotations[fleetReportGeneratedAtAnnotationKey]); err == nil {
+		return generatedAt
+	}
+	return cm.CreationTimestamp.Time
+}
@@ -0,0 +1,155 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestBuildFleetReport(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	report := buildFleetReport([]gpuv1.NodeOperandStatus{
+		{Name: "node-a", DriverVersion: "570.124.06", ToolkitReady: true, DevicePluginReady: true, Validated: true},
+		{Name: "node-b", DriverVersion: "570.124.06", ToolkitReady: true, UpgradeState: "upgrade-required"},
+		{Name: "node-c"},
+	}, now)
+
+	expected := &fleetReport{
+		GeneratedAt:       now,
+		Nodes:             3,
+		DriverVersions:    map[string]int{"570.124.06": 2, "unknown": 1},
+		UpgradeStates:     map[string]int{"upgrade-required": 1},
+		FailedValidations: []string{"node-b", "node-c"},
+	}
+	expected.Operands.ToolkitReady = 2
+	expected.Operands.DevicePluginReady = 1
+	expected.Operands.Validated = 1
+	require.Equal(t, expected, report)
+}
+
+func TestReconcileFleetReport(t *testing.T) {
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	existingReport := func(age time.Duration) *corev1.ConfigMap {
+		generatedAt := now.Add(-age)
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        fmt.Sprintf("%s%d", fleetReportNamePrefix, generatedAt.Unix()),
+				Namespace:   "test-ns",
+				Labels:      map[string]string{fleetReportLabelKey: "true"},
+				Annotations: map[string]string{fleetReportGeneratedAtAnnotationKey: generatedAt.Format(time.RFC3339)},
+			},
+		}
+	}
+
+	testCases := []struct {
+		description          string
+		spec                 *gpuv1.FleetReportSpec
+		objects              []client.Object
+		expectedReports      int
+		expectedNewReport    bool
+		expectedRequeueAfter time.Duration
+	}{
+		{
+			description: "disabled",
+			objects:     []client.Object{existingReport(48 * time.Hour)},
+			// existing reports are left untouched
+			expectedReports: 1,
+		},
+		{
+			description:          "first report",
+			spec:                 &gpuv1.FleetReportSpec{Enabled: ptr.To(true)},
+			expectedReports:      1,
+			expectedNewReport:    true,
+			expectedRequeueAfter: 24 * time.Hour,
+		},
+		{
+			description:          "latest report is recent",
+			spec:                 &gpuv1.FleetReportSpec{Enabled: ptr.To(true)},
+			objects:              []client.Object{existingReport(20 * time.Hour)},
+			expectedReports:      1,
+			expectedRequeueAfter: 4 * time.Hour,
+		},
+		{
+			description: "report is due and history is pruned",
+			spec: &gpuv1.FleetReportSpec{
+				Enabled:      ptr.To(true),
+				Interval:     &metav1.Duration{Duration: time.Hour},
+				HistoryLimit: ptr.To[int32](2),
+			},
+			objects: []client.Object{
+				existingReport(time.Hour),
+				existingReport(2 * time.Hour),
+				existingReport(3 * time.Hour),
+			},
+			expectedReports:      2,
+			expectedNewReport:    true,
+			expectedRequeueAfter: time.Hour,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, corev1.AddToScheme(scheme))
+			require.NoError(t, gpuv1.AddToScheme(scheme))
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.objects...).Build()
+			n := ClusterPolicyController{
+				client:            c,
+				scheme:            scheme,
+				logger:            ctrl.Log.WithName("test"),
+				operatorNamespace: "test-ns",
+				singleton: &gpuv1.ClusterPolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy", UID: "uid"},
+					Spec:       gpuv1.ClusterPolicySpec{FleetReport: tc.spec},
+				},
+			}
+
+			requeueAfter, err := n.reconcileFleetReport(context.Background(), []gpuv1.NodeOperandStatus{{Name: "node-a", Validated: true}}, now)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedRequeueAfter, requeueAfter)
+
+			reports := &corev1.ConfigMapList{}
+			require.NoError(t, c.List(context.Background(), reports, client.MatchingLabels{fleetReportLabelKey: "true"}))
+			require.Len(t, reports.Items, tc.expectedReports)
+
+			newReport := &corev1.ConfigMap{}
+			err = c.Get(context.Background(), client.ObjectKey{Namespace: "test-ns", Name: fmt.Sprintf("%s%d", fleetReportNamePrefix, now.Unix())}, newReport)
+			require.Equal(t, tc.expectedNewReport, err == nil)
+			if tc.expectedNewReport {
+				report := &fleetReport{}
+				require.NoError(t, json.Unmarshal([]byte(newReport.Data[fleetReportDataKey]), report))
+				require.Equal(t, 1, report.Nodes)
+				require.Equal(t, 1, report.Operands.Validated)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// gpuPodListPageSize is the number of pods fetched per request when indexing the GPU pods of the cluster
+const gpuPodListPageSize = 500
+
+// IsGPUPod returns true if the pod is running or pending, and requests GPUs either through the resources
+// advertised by the device plugin, including the MIG resources, or through ResourceClaims allocated by the
+// NVIDIA GPU DRA driver
+func IsGPUPod(ctx context.Context, c client.Reader, pod *corev1.Pod) bool {
+	gpuInResourceList := func(rl corev1.ResourceList) bool {
+		for resourceName := range rl {
+			str := string(resourceName)
+			if strings.HasPrefix(str, "nvidia.com/gpu") || strings.HasPrefix(str, "nvidia.com/mig-") {
+				return true
+			}
+		}
+		return false
+	}
+
+	//  ignore pods other than in running and pending state
+	if pod.Status.Phase != corev1.PodRunning && pod.Status.Phase != corev1.PodPending {
+		return false
+	}
+
+	for _, ctr := range pod.Spec.Containers {
+		if gpuInResourceList(ctr.Resources.Limits) || gpuInResourceList(ctr.Resources.Requests) {
+			return true
+		}
+	}
+	return PodHasNVIDIAGPUClaim(ctx, c, pod, false)
+}
+
+// gpuPodIndex maps the node names to the number of GPU pods scheduled on the nodes
+type gpuPodIndex map[string]int
+
+// buildGPUPodIndex counts the GPU pods of every node. The pods of all namespaces are listed page by page,
+// and only the counts are kept, so that the index stays small in clusters running many pods.
+func buildGPUPodIndex(ctx context.Context, c client.Reader) (gpuPodIndex, error) {
+	index := make(gpuPodIndex)
+	opts := []client.ListOption{client.Limit(gpuPodListPageSize)}
+	for {
+		pods := &corev1.PodList{}
+		if err := c.List(ctx, pods, opts...); err != nil {
+			return nil, fmt.Errorf("failed to list pods: %w", err)
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if pod.Spec.NodeName != "" && IsGPUPod(ctx, c, pod) {
+				index[pod.Spec.NodeName]++
+			}
+		}
+		if pods.Continue == "" {
+			return index, nil
+		}
+		opts = []client.ListOption{client.Limit(gpuPodListPageSize), client.Continue(pods.Continue)}
+	}
+}
@@ -0,0 +1,70 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTestGPUPod(name, nodeName, resourceName string, phase corev1.PodPhase) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: corev1.PodSpec{
+			NodeName:   nodeName,
+			Containers: []corev1.Container{{Name: "main"}},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+	if resourceName != "" {
+		pod.Spec.Containers[0].Resources.Limits = corev1.ResourceList{
+			corev1.ResourceName(resourceName): resource.MustParse("1"),
+		}
+	}
+	return pod
+}
+
+func TestBuildGPUPodIndex(t *testing.T) {
+	objects := []client.Object{
+		newTestGPUPod("gpu", "node-a", "nvidia.com/gpu", corev1.PodRunning),
+		newTestGPUPod("mig", "node-a", "nvidia.com/mig-1g.10gb", corev1.PodPending),
+		newTestGPUPod("cpu", "node-a", "", corev1.PodRunning),
+		newTestGPUPod("completed", "node-b", "nvidia.com/gpu", corev1.PodSucceeded),
+		newTestGPUPod("unscheduled", "", "nvidia.com/gpu", corev1.PodPending),
+	}
+	// more pods than a page to cover the pagination
+	for i := 0; i < gpuPodListPageSize; i++ {
+		objects = append(objects, newTestGPUPod(fmt.Sprintf("gpu-%d", i), "node-c", "nvidia.com/gpu", corev1.PodRunning))
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	index, err := buildGPUPodIndex(context.Background(), c)
+	require.NoError(t, err)
+	require.Equal(t, gpuPodIndex{"node-a": 2, "node-c": gpuPodListPageSize}, index)
+}
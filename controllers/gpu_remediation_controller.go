@@ -0,0 +1,516 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/apistats"
+	"github.com/NVIDIA/gpu-operator/internal/audit"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+	"github.com/NVIDIA/gpu-operator/internal/utils"
+)
+
+const (
+	// gpuRemediationReset resets the GPUs of the node with nvidia-smi once the node is drained
+	gpuRemediationReset = "gpu-reset"
+	// gpuRemediationReboot drains the node, which is remediated once it was rebooted by the administrator
+	gpuRemediationReboot = "reboot-required"
+
+	// the states of a remediation, reported by the nvidia.com/gpu.remediation-state node annotation
+	gpuRemediationDraining         = "draining"
+	gpuRemediationResetting        = "resetting"
+	gpuRemediationWaitingForReboot = "waiting-for-reboot"
+	gpuRemediationSucceeded        = "succeeded"
+	gpuRemediationFailed           = "failed"
+
+	// gpuRemediationCordonedAnnotationKey records that the node was cordoned by the remediation, and is to be
+	// uncordoned once the remediation is over
+	gpuRemediationCordonedAnnotationKey = "nvidia.com/gpu.remediation.cordoned"
+	// gpuRemediationBootIDAnnotationKey records the boot ID of the node when its reboot was requested
+	gpuRemediationBootIDAnnotationKey = "nvidia.com/gpu.remediation.boot-id"
+	// gpuRemediationNodeAnnotationKey records the node of a GPU reset Job, the node names being too long for a label
+	gpuRemediationNodeAnnotationKey = "nvidia.com/gpu.remediation.node"
+
+	// gpuResetAppLabelValue labels the Jobs resetting the GPUs of a node, which are named after it
+	gpuResetAppLabelValue = "nvidia-gpu-reset"
+	// gpuResetDriverRootCtrPath is the path the driver root is mounted at in the GPU reset container
+	gpuResetDriverRootCtrPath = "/driver-root"
+	// operandPausedForRemediation is the deploy label value of the operands holding the GPUs, paused on the node
+	// while its GPUs are remediated
+	operandPausedForRemediation = "paused-for-gpu-remediation"
+	// gpuRemediationPollInterval is the interval the drain of a node and its GPU reset Job are checked at
+	gpuRemediationPollInterval = 10 * time.Second
+)
+
+// gpuRemediationPausedDeployLabelKeys are the deploy labels of the operands holding the GPUs of the node open,
+// which would fail a GPU reset
+var gpuRemediationPausedDeployLabelKeys = []string{devicePluginDeployLabelKey, dcgmDeployLabelKey, dcgmExporterDeployLabelKey}
+
+// GPURemediationReconciler remediates the GPUs of the nodes annotated with nvidia.com/gpu.remediation. The node is
+// cordoned, the operands holding its GPUs are paused and its GPU workloads are evicted. The GPUs are then reset by
+// a Job running nvidia-smi on the node, or the node waits for its reboot by the administrator. Once remediated,
+// the node is uncordoned, the operands are resumed and the node is no longer labeled and tainted as unhealthy.
+// The progress is reported by the nvidia.com/gpu.remediation-state node annotation and by events on the node.
+type GPURemediationReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Namespace string
+	Log       logr.Logger
+	// APIStats counts the requests of the reconciliations, nothing is counted when nil
+	APIStats *apistats.Tracker
+
+	recorder events.EventRecorder
+}
+
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods/eviction,verbs=create
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+
+// Reconcile moves the remediation requested on the node to its next state
+func (r *GPURemediationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx = audit.WithReason(ctx, "GPU remediation")
+	ctx, done := r.APIStats.StartReconcile(ctx, "GPURemediation")
+	defer done()
+
+	node := &corev1.Node{}
+	if err := r.Get(ctx, types.NamespacedName{Name: req.Name}, node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, r.deleteGPUResetJob(ctx, req.Name)
+		}
+		return reconcile.Result{}, fmt.Errorf("failed to get node %s: %w", req.Name, err)
+	}
+
+	action := node.Annotations[consts.GPURemediationAnnotationKey]
+	state := node.Annotations[consts.GPURemediationStateAnnotationKey]
+	if action == "" {
+		if state == "" || state == gpuRemediationSucceeded {
+			return reconcile.Result{}, nil
+		}
+		r.Log.Info("GPU remediation cancelled", "node", node.Name, "state", state)
+		return reconcile.Result{}, r.finish(ctx, node, "")
+	}
+
+	switch state {
+	case gpuRemediationFailed:
+		// the node is kept out of rotation until the request is removed
+		return reconcile.Result{}, nil
+	case "", gpuRemediationSucceeded:
+		if action != gpuRemediationReset && action != gpuRemediationReboot {
+			return reconcile.Result{}, r.fail(ctx, node, fmt.Sprintf("unknown remediation %q, expected %s or %s",
+				action, gpuRemediationReset, gpuRemediationReboot))
+		}
+		return reconcile.Result{RequeueAfter: gpuRemediationPollInterval}, r.start(ctx, node, action)
+	case gpuRemediationDraining:
+		return r.drain(ctx, node, action)
+	case gpuRemediationResetting:
+		return r.reset(ctx, node)
+	case gpuRemediationWaitingForReboot:
+		bootID := node.Annotations[gpuRemediationBootIDAnnotationKey]
+		if bootID == "" || node.Status.NodeInfo.BootID == "" || node.Status.NodeInfo.BootID == bootID {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, r.finish(ctx, node, "Node rebooted")
+	}
+	return reconcile.Result{}, r.fail(ctx, node, fmt.Sprintf("unknown remediation state %q", state))
+}
+
+// start cordons the node and pauses the operands holding its GPUs, before its GPU workloads are evicted
+func (r *GPURemediationReconciler) start(ctx context.Context, node *corev1.Node, action string) error {
+	original := node.DeepCopy()
+	if !node.Spec.Unschedulable {
+		node.Spec.Unschedulable = true
+		node.Annotations[gpuRemediationCordonedAnnotationKey] = "true"
+	}
+	for _, key := range gpuRemediationPausedDeployLabelKeys {
+		if node.Labels[key] == "true" {
+			node.Labels[key] = operandPausedForRemediation
+		}
+	}
+	if action == gpuRemediationReboot {
+		node.Annotations[gpuRemediationBootIDAnnotationKey] = node.Status.NodeInfo.BootID
+	} else {
+		delete(node.Annotations, gpuRemediationBootIDAnnotationKey)
+	}
+	node.Annotations[consts.GPURemediationStateAnnotationKey] = gpuRemediationDraining
+	if err := r.Patch(ctx, node, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})); err != nil {
+		return fmt.Errorf("failed to start the GPU remediation of node %s: %w", node.Name, err)
+	}
+	r.Log.Info("GPU remediation requested, draining the node", "node", node.Name, "remediation", action)
+	r.recordEvent(node, corev1.EventTypeNormal, "Draining the node for the %s remediation", action)
+	return nil
+}
+
+// drain evicts the GPU workloads of the node, and moves on once they are gone along with the paused operands
+func (r *GPURemediationReconciler) drain(ctx context.Context, node *corev1.Node, action string) (ctrl.Result, error) {
+	running, err := evictNodeGPUPods(ctx, r.Client, r.Log.WithValues("Reason", "GPU remediation"), node.Name)
+	if err != nil {
+		r.Log.Info("Unable to evict the GPU workloads of node, retrying", "node", node.Name, "Error", err)
+	}
+	operands, err := r.getPausedOperandPods(ctx, node.Name)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if len(running) > 0 || len(operands) > 0 {
+		r.Log.Info("Waiting for the pods of node to terminate", "node", node.Name,
+			"workloads", running, "operands", operands)
+		return reconcile.Result{RequeueAfter: gpuRemediationPollInterval}, nil
+	}
+
+	next := gpuRemediationWaitingForReboot
+	if action == gpuRemediationReset {
+		if err := r.createGPUResetJob(ctx, node); err != nil {
+			return reconcile.Result{}, err
+		}
+		next = gpuRemediationResetting
+	}
+	if err := r.setState(ctx, node, next); err != nil {
+		return reconcile.Result{}, err
+	}
+	if next == gpuRemediationWaitingForReboot {
+		r.Log.Info("Node drained, waiting for its reboot", "node", node.Name)
+		r.recordEvent(node, corev1.EventTypeWarning, "Node drained, reboot the node to complete the remediation")
+		return reconcile.Result{}, nil
+	}
+	r.Log.Info("Node drained, resetting its GPUs", "node", node.Name)
+	r.recordEvent(node, corev1.EventTypeNormal, "Node drained, resetting the GPUs")
+	return reconcile.Result{RequeueAfter: gpuRemediationPollInterval}, nil
+}
+
+// reset checks the outcome of the GPU reset Job of the node
+func (r *GPURemediationReconciler) reset(ctx context.Context, node *corev1.Node) (ctrl.Result, error) {
+	job := &batchv1.Job{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: r.Namespace, Name: gpuResetJobName(node.Name)}, job)
+	if apierrors.IsNotFound(err) {
+		// the Job was deleted before completing
+		return reconcile.Result{RequeueAfter: gpuRemediationPollInterval}, r.createGPUResetJob(ctx, node)
+	}
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to get the GPU reset Job of node %s: %w", node.Name, err)
+	}
+
+	switch {
+	case job.Status.Succeeded > 0:
+		if err := r.deleteGPUResetJob(ctx, node.Name); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, r.finish(ctx, node, "GPUs reset")
+	case job.Status.Failed > 0:
+		// the Job is kept for its logs until the request is removed
+		message, err := r.getGPUResetFailure(ctx, job)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, r.fail(ctx, node, message)
+	}
+	return reconcile.Result{RequeueAfter: gpuRemediationPollInterval}, nil
+}
+
+// finish uncordons the node and resumes its operands. Once remediated, with a non-empty message, the node is no
+// longer labeled and tainted as unhealthy and the request is removed, otherwise the remediation was cancelled.
+func (r *GPURemediationReconciler) finish(ctx context.Context, node *corev1.Node, message string) error {
+	if err := r.deleteGPUResetJob(ctx, node.Name); err != nil {
+		return err
+	}
+
+	original := node.DeepCopy()
+	if node.Annotations[gpuRemediationCordonedAnnotationKey] == "true" {
+		node.Spec.Unschedulable = false
+	}
+	for _, key := range gpuRemediationPausedDeployLabelKeys {
+		if node.Labels[key] == operandPausedForRemediation {
+			node.Labels[key] = "true"
+		}
+	}
+	delete(node.Annotations, gpuRemediationCordonedAnnotationKey)
+	delete(node.Annotations, gpuRemediationBootIDAnnotationKey)
+	delete(node.Annotations, consts.GPURemediationStateAnnotationKey)
+	if message != "" {
+		delete(node.Labels, consts.GPUUnhealthyLabelKey)
+		delete(node.Annotations, consts.GPUUnhealthyReasonAnnotationKey)
+		node.Spec.Taints = slices.DeleteFunc(node.Spec.Taints, func(taint corev1.Taint) bool {
+			return taint.Key == consts.GPUUnhealthyLabelKey
+		})
+		delete(node.Annotations, consts.GPURemediationAnnotationKey)
+		node.Annotations[consts.GPURemediationStateAnnotationKey] = gpuRemediationSucceeded
+	}
+	if err := r.Patch(ctx, node, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})); err != nil {
+		return fmt.Errorf("failed to complete the GPU remediation of node %s: %w", node.Name, err)
+	}
+	if message != "" {
+		r.Log.Info("GPU remediation succeeded", "node", node.Name, "details", message)
+		r.recordEvent(node, corev1.EventTypeNormal, "GPU remediation succeeded: %s", message)
+	}
+	return nil
+}
+
+// fail reports the failure of the remediation. The node is left cordoned with its operands paused.
+func (r *GPURemediationReconciler) fail(ctx context.Context, node *corev1.Node, message string) error {
+	if err := r.setState(ctx, node, gpuRemediationFailed); err != nil {
+		return err
+	}
+	r.Log.Info("WARNING: GPU remediation failed", "node", node.Name, "details", message)
+	r.recordEvent(node, corev1.EventTypeWarning, "GPU remediation failed, remove the %s annotation once the node "+
+		"is investigated: %s", consts.GPURemediationAnnotationKey, message)
+	return nil
+}
+
+// setState sets the nvidia.com/gpu.remediation-state annotation of the node
+func (r *GPURemediationReconciler) setState(ctx context.Context, node *corev1.Node, state string) error {
+	original := node.DeepCopy()
+	node.Annotations[consts.GPURemediationStateAnnotationKey] = state
+	if err := r.Patch(ctx, node, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to set the GPU remediation state of node %s: %w", node.Name, err)
+	}
+	return nil
+}
+
+// getPausedOperandPods returns the pods of the operator namespace still running on the node for the operands
+// paused by the remediation
+func (r *GPURemediationReconciler) getPausedOperandPods(ctx context.Context, nodeName string) ([]string, error) {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(r.Namespace), client.MatchingFields{podNodeNameIndexKey: nodeName}); err != nil {
+		return nil, fmt.Errorf("failed to list the pods of node %s: %w", nodeName, err)
+	}
+	var running []string
+	for _, pod := range pods.Items {
+		if slices.ContainsFunc(gpuRemediationPausedDeployLabelKeys, func(key string) bool { return pod.Spec.NodeSelector[key] == "true" }) {
+			running = append(running, pod.Name)
+		}
+	}
+	return running, nil
+}
+
+// gpuResetJobName returns the name of the Job resetting the GPUs of the node, the node names being too long to
+// be appended
+func gpuResetJobName(nodeName string) string {
+	return fmt.Sprintf("%s-%s", gpuResetAppLabelValue, utils.GetStringHash(nodeName))
+}
+
+// createGPUResetJob creates the Job resetting the GPUs of the drained node
+func (r *GPURemediationReconciler) createGPUResetJob(ctx context.Context, node *corev1.Node) error {
+	clusterPolicy, _, err := resolveActiveConfig(ctx, r.Client)
+	if err != nil {
+		return err
+	}
+	if clusterPolicy == nil {
+		return fmt.Errorf("no ClusterPolicy provides the validator image running the GPU reset of node %s", node.Name)
+	}
+	job, err := newGPUResetJob(&clusterPolicy.Spec, node, types.NamespacedName{Namespace: r.Namespace, Name: gpuResetJobName(node.Name)})
+	if err != nil {
+		return err
+	}
+	if err := r.Create(ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create the GPU reset Job of node %s: %w", node.Name, err)
+	}
+	return nil
+}
+
+// newGPUResetJob returns the Job resetting the GPUs of the node with the nvidia-smi of the driver root, which
+// runs the validator image of the node
+func newGPUResetJob(spec *gpuv1.ClusterPolicySpec, node *corev1.Node, key types.NamespacedName) (*batchv1.Job, error) {
+	validator := &spec.Validator
+	var image string
+	var err error
+	if imageKey := nodeValidatorImageKey(validator, node.Labels); imageKey != "" {
+		imageSpec := validator.Images[imageKey]
+		image, err = gpuv1.ImagePath(&imageSpec)
+	} else {
+		image, err = gpuv1.ImagePath(validator)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// the driver is installed in the driver root by the driver container, or on the host
+	driverRoot := spec.HostPaths.RootFS
+	if driverRoot == "" {
+		driverRoot = "/"
+	}
+	if spec.Driver.IsEnabled() {
+		driverRoot = spec.HostPaths.DriverInstallDir
+		if driverRoot == "" {
+			driverRoot = DefaultDriverInstallDir
+		}
+	}
+
+	labels := map[string]string{appLabelKey: gpuResetAppLabelValue}
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        key.Name,
+			Namespace:   key.Namespace,
+			Labels:      labels,
+			Annotations: map[string]string{gpuRemediationNodeAnnotationKey: node.Name},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To(int32(0)),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					NodeName:                     node.Name,
+					RestartPolicy:                corev1.RestartPolicyNever,
+					AutomountServiceAccountToken: ptr.To(false),
+					Tolerations:                  []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+					PriorityClassName:            spec.Daemonsets.PriorityClassName,
+					Containers: []corev1.Container{{
+						Name:                     "gpu-reset",
+						Image:                    image,
+						ImagePullPolicy:          gpuv1.ImagePullPolicy(validator.ImagePullPolicy),
+						Command:                  []string{"chroot", gpuResetDriverRootCtrPath, "nvidia-smi", "--gpu-reset"},
+						SecurityContext:          &corev1.SecurityContext{Privileged: ptr.To(true)},
+						TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+						VolumeMounts: []corev1.VolumeMount{{
+							Name:             "driver-root",
+							MountPath:        gpuResetDriverRootCtrPath,
+							MountPropagation: ptr.To(corev1.MountPropagationHostToContainer),
+						}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "driver-root",
+						VolumeSource: corev1.VolumeSource{
+							HostPath: &corev1.HostPathVolumeSource{Path: driverRoot},
+						},
+					}},
+				},
+			},
+		},
+	}
+	for _, secret := range validator.ImagePullSecrets {
+		job.Spec.Template.Spec.ImagePullSecrets = append(job.Spec.Template.Spec.ImagePullSecrets, corev1.LocalObjectReference{Name: secret})
+	}
+	return job, nil
+}
+
+// getGPUResetFailure returns the termination message of the failed GPU reset Job
+func (r *GPURemediationReconciler) getGPUResetFailure(ctx context.Context, job *batchv1.Job) (string, error) {
+	pods := &corev1.PodList{}
+	err := r.List(ctx, pods, client.InNamespace(job.Namespace), client.MatchingLabels{batchv1.JobNameLabel: job.Name})
+	if err != nil {
+		return "", fmt.Errorf("failed to list the pods of the GPU reset Job %s: %w", job.Name, err)
+	}
+	message := fmt.Sprintf("GPU reset Job %s failed", job.Name)
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			if terminated := status.State.Terminated; terminated != nil && terminated.Message != "" {
+				message = fmt.Sprintf("%s: %s", message, strings.TrimSpace(terminated.Message))
+			}
+		}
+	}
+	return message, nil
+}
+
+// deleteGPUResetJob deletes the GPU reset Job of the node, along with its pods, if it exists
+func (r *GPURemediationReconciler) deleteGPUResetJob(ctx context.Context, nodeName string) error {
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Namespace: r.Namespace, Name: gpuResetJobName(nodeName)}}
+	err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete the GPU reset Job of node %s: %w", nodeName, err)
+	}
+	return nil
+}
+
+func (r *GPURemediationReconciler) recordEvent(node *corev1.Node, eventType string, note string, args ...interface{}) {
+	if r.recorder == nil {
+		return
+	}
+	r.recorder.Eventf(node, nil, eventType, "GPURemediation", "RemediateGPUs", note, args...)
+}
+
+// SetupWithManager registers the GPURemediationReconciler with the controller-runtime manager.
+func (r *GPURemediationReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	r.recorder = mgr.GetEventRecorder("nvidia-gpu-operator")
+
+	c, err := controller.New("gpu-remediation-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: 1,
+		RateLimiter:             workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](minDelayCR, maxDelayCR),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating gpu-remediation controller: %w", err)
+	}
+
+	// the remediations are requested and tracked through the node annotations, and the reboots detected from the
+	// boot ID of the nodes
+	nodePredicate := predicate.TypedFuncs[*corev1.Node]{
+		CreateFunc: func(e event.TypedCreateEvent[*corev1.Node]) bool {
+			return e.Object.Annotations[consts.GPURemediationAnnotationKey] != "" ||
+				e.Object.Annotations[consts.GPURemediationStateAnnotationKey] != ""
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[*corev1.Node]) bool {
+			oldAnnotations, newAnnotations := e.ObjectOld.Annotations, e.ObjectNew.Annotations
+			return oldAnnotations[consts.GPURemediationAnnotationKey] != newAnnotations[consts.GPURemediationAnnotationKey] ||
+				oldAnnotations[consts.GPURemediationStateAnnotationKey] != newAnnotations[consts.GPURemediationStateAnnotationKey] ||
+				(newAnnotations[consts.GPURemediationStateAnnotationKey] == gpuRemediationWaitingForReboot &&
+					e.ObjectOld.Status.NodeInfo.BootID != e.ObjectNew.Status.NodeInfo.BootID)
+		},
+		DeleteFunc: func(e event.TypedDeleteEvent[*corev1.Node]) bool {
+			return e.Object.Annotations[consts.GPURemediationStateAnnotationKey] != ""
+		},
+	}
+	if err := c.Watch(source.Kind(
+		mgr.GetCache(),
+		&corev1.Node{},
+		&handler.TypedEnqueueRequestForObject[*corev1.Node]{},
+		nodePredicate,
+	)); err != nil {
+		return fmt.Errorf("error watching Nodes: %w", err)
+	}
+
+	// the node of a GPU reset Job is reconciled once the Job completes
+	jobMapFn := func(ctx context.Context, job *batchv1.Job) []reconcile.Request {
+		nodeName := job.Annotations[gpuRemediationNodeAnnotationKey]
+		if job.Labels[appLabelKey] != gpuResetAppLabelValue || nodeName == "" {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: nodeName}}}
+	}
+	if err := c.Watch(source.Kind(
+		mgr.GetCache(),
+		&batchv1.Job{},
+		handler.TypedEnqueueRequestsFromMapFunc(jobMapFn),
+	)); err != nil {
+		return fmt.Errorf("error watching Jobs: %w", err)
+	}
+
+	return nil
+}
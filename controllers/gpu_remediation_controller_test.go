@@ -0,0 +1,262 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+func newGPURemediationReconciler(t *testing.T, objs ...client.Object) *GPURemediationReconciler {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, batchv1.AddToScheme(scheme))
+	require.NoError(t, policyv1.AddToScheme(scheme))
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+	require.NoError(t, nvidiav1alpha1.AddToScheme(scheme))
+	cp := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy"},
+		Spec: gpuv1.ClusterPolicySpec{
+			Validator: gpuv1.ValidatorSpec{Repository: "nvcr.io/nvidia/cloud-native", Image: "gpu-operator-validator", Version: "v1.0.0"},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objs, cp)...).
+		WithStatusSubresource(&corev1.Node{}, &corev1.Pod{}, &batchv1.Job{}).
+		WithIndex(&corev1.Pod{}, podNodeNameIndexKey, podNodeNameIndexer).
+		Build()
+	return &GPURemediationReconciler{
+		Client:    c,
+		Log:       logr.Discard(),
+		Scheme:    scheme,
+		Namespace: "gpu-operator",
+		recorder:  events.NewFakeRecorder(20),
+	}
+}
+
+func newGPURemediationNode(action string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "gpu-node",
+			Labels: map[string]string{
+				commonGPULabelKey:           commonGPULabelValue,
+				devicePluginDeployLabelKey:  devicePluginPausedForGPUHealth,
+				dcgmDeployLabelKey:          "true",
+				dcgmExporterDeployLabelKey:  "true",
+				consts.GPUUnhealthyLabelKey: "true",
+			},
+			Annotations: map[string]string{
+				consts.GPURemediationAnnotationKey:     action,
+				consts.GPUUnhealthyReasonAnnotationKey: "XID 79",
+			},
+		},
+		Spec: corev1.NodeSpec{
+			Taints: []corev1.Taint{{Key: consts.GPUUnhealthyLabelKey, Value: "true", Effect: corev1.TaintEffectNoSchedule}},
+		},
+		Status: corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{BootID: "boot-1"}},
+	}
+}
+
+func TestGPURemediationReset(t *testing.T) {
+	ctx := context.Background()
+	workload := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "training", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			NodeName: "gpu-node",
+			Containers: []corev1.Container{{
+				Name:      "cuda",
+				Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")}},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	dcgm := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "nvidia-dcgm-abcde", Namespace: "gpu-operator"},
+		Spec:       corev1.PodSpec{NodeName: "gpu-node", NodeSelector: map[string]string{dcgmDeployLabelKey: "true"}},
+	}
+	r := newGPURemediationReconciler(t, newGPURemediationNode(gpuRemediationReset), workload, dcgm)
+	reconcileNode := func() (ctrl.Result, *corev1.Node) {
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "gpu-node"}})
+		require.NoError(t, err)
+		node := &corev1.Node{}
+		require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "gpu-node"}, node))
+		return result, node
+	}
+	jobKey := types.NamespacedName{Namespace: "gpu-operator", Name: gpuResetJobName("gpu-node")}
+
+	// the node is cordoned and the operands holding the GPUs are paused
+	_, node := reconcileNode()
+	require.Equal(t, gpuRemediationDraining, node.Annotations[consts.GPURemediationStateAnnotationKey])
+	require.True(t, node.Spec.Unschedulable)
+	require.Equal(t, operandPausedForRemediation, node.Labels[dcgmDeployLabelKey])
+	require.Equal(t, operandPausedForRemediation, node.Labels[dcgmExporterDeployLabelKey])
+	require.Equal(t, devicePluginPausedForGPUHealth, node.Labels[devicePluginDeployLabelKey])
+
+	// the GPU workloads are evicted, and the GPUs are not reset while the paused operands are running
+	result, node := reconcileNode()
+	require.Equal(t, gpuRemediationPollInterval, result.RequeueAfter)
+	require.Equal(t, gpuRemediationDraining, node.Annotations[consts.GPURemediationStateAnnotationKey])
+	require.True(t, apierrors.IsNotFound(r.Get(ctx, client.ObjectKeyFromObject(workload), &corev1.Pod{})))
+
+	require.NoError(t, r.Delete(ctx, dcgm))
+	_, node = reconcileNode()
+	require.Equal(t, gpuRemediationResetting, node.Annotations[consts.GPURemediationStateAnnotationKey])
+	job := &batchv1.Job{}
+	require.NoError(t, r.Get(ctx, jobKey, job))
+	podSpec := job.Spec.Template.Spec
+	require.Equal(t, "gpu-node", podSpec.NodeName)
+	require.Equal(t, "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0", podSpec.Containers[0].Image)
+	require.Equal(t, []string{"chroot", gpuResetDriverRootCtrPath, "nvidia-smi", "--gpu-reset"}, podSpec.Containers[0].Command)
+	require.Equal(t, DefaultDriverInstallDir, podSpec.Volumes[0].HostPath.Path)
+
+	// once the GPUs are reset, the node is uncordoned, resumed and no longer unhealthy
+	job.Status.Succeeded = 1
+	require.NoError(t, r.Status().Update(ctx, job))
+	_, node = reconcileNode()
+	require.Equal(t, gpuRemediationSucceeded, node.Annotations[consts.GPURemediationStateAnnotationKey])
+	require.NotContains(t, node.Annotations, consts.GPURemediationAnnotationKey)
+	require.NotContains(t, node.Annotations, gpuRemediationCordonedAnnotationKey)
+	require.NotContains(t, node.Annotations, consts.GPUUnhealthyReasonAnnotationKey)
+	require.NotContains(t, node.Labels, consts.GPUUnhealthyLabelKey)
+	require.Empty(t, node.Spec.Taints)
+	require.False(t, node.Spec.Unschedulable)
+	require.Equal(t, "true", node.Labels[dcgmDeployLabelKey])
+	require.Equal(t, "true", node.Labels[dcgmExporterDeployLabelKey])
+	// the device plugin is resumed by the node labeling once the node is no longer unhealthy
+	require.Equal(t, devicePluginPausedForGPUHealth, node.Labels[devicePluginDeployLabelKey])
+	require.True(t, apierrors.IsNotFound(r.Get(ctx, jobKey, &batchv1.Job{})))
+}
+
+func TestGPURemediationResetFailed(t *testing.T) {
+	ctx := context.Background()
+	node := newGPURemediationNode(gpuRemediationReset)
+	node.Annotations[consts.GPURemediationStateAnnotationKey] = gpuRemediationResetting
+	node.Annotations[gpuRemediationCordonedAnnotationKey] = "true"
+	node.Spec.Unschedulable = true
+	spec := &gpuv1.ClusterPolicySpec{Validator: gpuv1.ValidatorSpec{Image: "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0"}}
+	job, err := newGPUResetJob(spec, node, types.NamespacedName{Namespace: "gpu-operator", Name: gpuResetJobName(node.Name)})
+	require.NoError(t, err)
+	job.Status.Failed = 1
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: job.Name + "-abcde", Namespace: "gpu-operator", Labels: map[string]string{batchv1.JobNameLabel: job.Name}},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: "GPU 00000000:3B:00.0 is currently in use\n"}},
+		}}},
+	}
+	r := newGPURemediationReconciler(t, node, job, pod)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: node.Name}}
+
+	// the node is kept cordoned and the Job kept for its logs
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(node), node))
+	require.Equal(t, gpuRemediationFailed, node.Annotations[consts.GPURemediationStateAnnotationKey])
+	require.True(t, node.Spec.Unschedulable)
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(job), &batchv1.Job{}))
+
+	// removing the request cancels the remediation
+	delete(node.Annotations, consts.GPURemediationAnnotationKey)
+	require.NoError(t, r.Update(ctx, node))
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(node), node))
+	require.NotContains(t, node.Annotations, consts.GPURemediationStateAnnotationKey)
+	require.False(t, node.Spec.Unschedulable)
+	require.Equal(t, "true", node.Labels[consts.GPUUnhealthyLabelKey])
+	require.True(t, apierrors.IsNotFound(r.Get(ctx, client.ObjectKeyFromObject(job), &batchv1.Job{})))
+}
+
+func TestGPURemediationReboot(t *testing.T) {
+	ctx := context.Background()
+	node := newGPURemediationNode(gpuRemediationReboot)
+	node.Spec.Unschedulable = true
+	r := newGPURemediationReconciler(t, node)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: node.Name}}
+	reconcileNode := func() *corev1.Node {
+		_, err := r.Reconcile(ctx, request)
+		require.NoError(t, err)
+		require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(node), node))
+		return node
+	}
+
+	node = reconcileNode()
+	require.Equal(t, gpuRemediationDraining, node.Annotations[consts.GPURemediationStateAnnotationKey])
+	require.Equal(t, "boot-1", node.Annotations[gpuRemediationBootIDAnnotationKey])
+	// the node was already cordoned, and is not uncordoned by the remediation
+	require.NotContains(t, node.Annotations, gpuRemediationCordonedAnnotationKey)
+
+	node = reconcileNode()
+	require.Equal(t, gpuRemediationWaitingForReboot, node.Annotations[consts.GPURemediationStateAnnotationKey])
+	node = reconcileNode()
+	require.Equal(t, gpuRemediationWaitingForReboot, node.Annotations[consts.GPURemediationStateAnnotationKey])
+
+	node.Status.NodeInfo.BootID = "boot-2"
+	require.NoError(t, r.Status().Update(ctx, node))
+	node = reconcileNode()
+	require.Equal(t, gpuRemediationSucceeded, node.Annotations[consts.GPURemediationStateAnnotationKey])
+	require.NotContains(t, node.Labels, consts.GPUUnhealthyLabelKey)
+	require.True(t, node.Spec.Unschedulable)
+}
+
+func TestGPURemediationUnknownAction(t *testing.T) {
+	ctx := context.Background()
+	node := newGPURemediationNode("power-cycle")
+	r := newGPURemediationReconciler(t, node)
+
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: node.Name}})
+	require.NoError(t, err)
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(node), node))
+	require.Equal(t, gpuRemediationFailed, node.Annotations[consts.GPURemediationStateAnnotationKey])
+	require.False(t, node.Spec.Unschedulable)
+	require.Equal(t, "true", node.Labels[dcgmDeployLabelKey])
+}
+
+func TestNewGPUResetJobHostDriver(t *testing.T) {
+	spec := &gpuv1.ClusterPolicySpec{
+		Driver:    gpuv1.DriverSpec{Enabled: ptr.To(false)},
+		Validator: gpuv1.ValidatorSpec{Image: "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0"},
+	}
+	node := newGPURemediationNode(gpuRemediationReset)
+	job, err := newGPUResetJob(spec, node, types.NamespacedName{Namespace: "gpu-operator", Name: gpuResetJobName(node.Name)})
+	require.NoError(t, err)
+	require.Equal(t, "/", job.Spec.Template.Spec.Volumes[0].HostPath.Path)
+	require.Equal(t, node.Name, job.Annotations[gpuRemediationNodeAnnotationKey])
+
+	spec.HostPaths.RootFS = "/host-root"
+	job, err = newGPUResetJob(spec, node, types.NamespacedName{Namespace: "gpu-operator", Name: gpuResetJobName(node.Name)})
+	require.NoError(t, err)
+	require.Equal(t, "/host-root", job.Spec.Template.Spec.Volumes[0].HostPath.Path)
+}
@@ -0,0 +1,330 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/apistats"
+	"github.com/NVIDIA/gpu-operator/internal/audit"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+const (
+	// GPUAllocationPolicyLabelKey labels the ResourceQuota and LimitRange objects managed for a GPUAllocationPolicy
+	GPUAllocationPolicyLabelKey = "nvidia.com/gpu-allocation-policy"
+
+	gpuAllocationPolicyObjectPrefix = "nvidia-gpu-allocation-"
+)
+
+// GPUAllocationPolicyReconciler expresses the GPU limits of GPUAllocationPolicy objects as a ResourceQuota and a
+// LimitRange in each namespace selected by the policy
+type GPUAllocationPolicyReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+	// APIStats counts the requests of the reconciliations, nothing is counted when nil
+	APIStats *apistats.Tracker
+}
+
+//+kubebuilder:rbac:groups=nvidia.com,resources=gpuallocationpolicies,verbs=get;list;watch
+//+kubebuilder:rbac:groups=nvidia.com,resources=gpuallocationpolicies/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=resourcequotas;limitranges,verbs=get;list;watch;create;update;delete
+
+// Reconcile creates, updates or deletes the ResourceQuota and LimitRange objects of a GPUAllocationPolicy so that
+// they match the namespaces selected by the policy and its limits
+func (r *GPUAllocationPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx = audit.WithReason(ctx, "GPU allocation policy")
+	ctx, done := r.APIStats.StartReconcile(ctx, "GPUAllocationPolicy")
+	defer done()
+	logger := r.Log.WithValues("GPUAllocationPolicy", req.Name)
+
+	policy := &nvidiav1alpha1.GPUAllocationPolicy{}
+	if err := r.Get(ctx, req.NamespacedName, policy); err != nil {
+		// the objects of a deleted policy are garbage collected through their owner reference
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.NamespaceSelector)
+	if err != nil {
+		logger.Error(err, "invalid namespace selector")
+		return reconcile.Result{}, r.updateStatus(ctx, policy, nil, conditions.Error, "InvalidNamespaceSelector", err.Error())
+	}
+
+	namespaces := &corev1.NamespaceList{}
+	if err := r.List(ctx, namespaces, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	selected := make(map[string]bool)
+	var governed []string
+	for _, ns := range namespaces.Items {
+		if ns.Status.Phase == corev1.NamespaceTerminating {
+			continue
+		}
+		selected[ns.Name] = true
+		governed = append(governed, ns.Name)
+
+		if err := r.reconcileResourceQuota(ctx, policy, ns.Name); err != nil {
+			logger.Error(err, "failed to reconcile ResourceQuota", "namespace", ns.Name)
+			return reconcile.Result{}, r.updateStatus(ctx, policy, governed, conditions.Error, conditions.ReconcileFailed, err.Error())
+		}
+		if err := r.reconcileLimitRange(ctx, policy, ns.Name); err != nil {
+			logger.Error(err, "failed to reconcile LimitRange", "namespace", ns.Name)
+			return reconcile.Result{}, r.updateStatus(ctx, policy, governed, conditions.Error, conditions.ReconcileFailed, err.Error())
+		}
+	}
+
+	if err := r.cleanupUnselectedNamespaces(ctx, policy, selected); err != nil {
+		logger.Error(err, "failed to clean up namespaces no longer selected")
+		return reconcile.Result{}, r.updateStatus(ctx, policy, governed, conditions.Error, conditions.ReconcileFailed, err.Error())
+	}
+
+	sort.Strings(governed)
+	message := fmt.Sprintf("GPU limits applied to %d namespaces", len(governed))
+	return reconcile.Result{}, r.updateStatus(ctx, policy, governed, conditions.Ready, conditions.Reconciled, message)
+}
+
+// gpuAllocationPolicyObjectName returns the name of the ResourceQuota and LimitRange objects of a policy
+func gpuAllocationPolicyObjectName(policy *nvidiav1alpha1.GPUAllocationPolicy) string {
+	return gpuAllocationPolicyObjectPrefix + policy.Name
+}
+
+// reconcileResourceQuota creates or updates the ResourceQuota of the policy in the namespace, or deletes it if the
+// policy sets no quota
+func (r *GPUAllocationPolicyReconciler) reconcileResourceQuota(ctx context.Context, policy *nvidiav1alpha1.GPUAllocationPolicy, namespace string) error {
+	quota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: gpuAllocationPolicyObjectName(policy), Namespace: namespace},
+	}
+
+	limits := policy.Spec.Quota.ResourceList()
+	if limits == nil {
+		return client.IgnoreNotFound(r.Delete(ctx, quota))
+	}
+
+	// extended resources can only be limited through their requests in a ResourceQuota
+	hard := corev1.ResourceList{}
+	for name, quantity := range limits {
+		hard[corev1.ResourceName(corev1.DefaultResourceRequestsPrefix+string(name))] = quantity
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, quota, func() error {
+		setGPUAllocationPolicyLabel(&quota.ObjectMeta, policy)
+		quota.Spec.Hard = hard
+		return controllerutil.SetControllerReference(policy, quota, r.Scheme)
+	})
+	return err
+}
+
+// reconcileLimitRange creates or updates the LimitRange of the policy in the namespace, or deletes it if the policy
+// sets no container limits
+func (r *GPUAllocationPolicyReconciler) reconcileLimitRange(ctx context.Context, policy *nvidiav1alpha1.GPUAllocationPolicy, namespace string) error {
+	limitRange := &corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Name: gpuAllocationPolicyObjectName(policy), Namespace: namespace},
+	}
+
+	limits := policy.Spec.ContainerLimits.ResourceList()
+	if limits == nil {
+		return client.IgnoreNotFound(r.Delete(ctx, limitRange))
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, limitRange, func() error {
+		setGPUAllocationPolicyLabel(&limitRange.ObjectMeta, policy)
+		limitRange.Spec.Limits = []corev1.LimitRangeItem{{
+			Type: corev1.LimitTypeContainer,
+			Max:  limits,
+		}}
+		return controllerutil.SetControllerReference(policy, limitRange, r.Scheme)
+	})
+	return err
+}
+
+func setGPUAllocationPolicyLabel(obj *metav1.ObjectMeta, policy *nvidiav1alpha1.GPUAllocationPolicy) {
+	if obj.Labels == nil {
+		obj.Labels = make(map[string]string)
+	}
+	obj.Labels[GPUAllocationPolicyLabelKey] = policy.Name
+}
+
+// cleanupUnselectedNamespaces deletes the ResourceQuota and LimitRange objects of the policy in the namespaces it
+// no longer selects
+func (r *GPUAllocationPolicyReconciler) cleanupUnselectedNamespaces(ctx context.Context, policy *nvidiav1alpha1.GPUAllocationPolicy, selected map[string]bool) error {
+	policyLabel := client.MatchingLabels{GPUAllocationPolicyLabelKey: policy.Name}
+
+	quotas := &corev1.ResourceQuotaList{}
+	if err := r.List(ctx, quotas, policyLabel); err != nil {
+		return fmt.Errorf("failed to list ResourceQuotas: %w", err)
+	}
+	for i := range quotas.Items {
+		if selected[quotas.Items[i].Namespace] {
+			continue
+		}
+		if err := r.Delete(ctx, &quotas.Items[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete ResourceQuota %s/%s: %w", quotas.Items[i].Namespace, quotas.Items[i].Name, err)
+		}
+	}
+
+	limitRanges := &corev1.LimitRangeList{}
+	if err := r.List(ctx, limitRanges, policyLabel); err != nil {
+		return fmt.Errorf("failed to list LimitRanges: %w", err)
+	}
+	for i := range limitRanges.Items {
+		if selected[limitRanges.Items[i].Namespace] {
+			continue
+		}
+		if err := r.Delete(ctx, &limitRanges.Items[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete LimitRange %s/%s: %w", limitRanges.Items[i].Namespace, limitRanges.Items[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// updateStatus sets the governed namespaces and the conditions of the policy
+func (r *GPUAllocationPolicyReconciler) updateStatus(ctx context.Context, policy *nvidiav1alpha1.GPUAllocationPolicy, namespaces []string, statusType, reason, message string) error {
+	// Fetch latest instance and update status to avoid version mismatch
+	instance := &nvidiav1alpha1.GPUAllocationPolicy{}
+	if err := r.Get(ctx, types.NamespacedName{Name: policy.Name}, instance); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get GPUAllocationPolicy instance for status update: %w", err)
+	}
+
+	status := instance.Status.DeepCopy()
+	status.Namespaces = namespaces
+	ready, errored := metav1.ConditionTrue, metav1.ConditionFalse
+	readyReason, readyMessage, errorReason, errorMessage := reason, message, conditions.Ready, ""
+	if statusType == conditions.Error {
+		ready, errored = metav1.ConditionFalse, metav1.ConditionTrue
+		readyReason, readyMessage, errorReason, errorMessage = conditions.Error, "", reason, message
+	}
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               conditions.Ready,
+		Status:             ready,
+		Reason:             readyReason,
+		Message:            readyMessage,
+		ObservedGeneration: instance.Generation,
+	})
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               conditions.Error,
+		Status:             errored,
+		Reason:             errorReason,
+		Message:            errorMessage,
+		ObservedGeneration: instance.Generation,
+	})
+
+	if equality.Semantic.DeepEqual(instance.Status, *status) {
+		return nil
+	}
+	instance.Status = *status
+	if err := r.Status().Update(ctx, instance); err != nil {
+		return fmt.Errorf("failed to update GPUAllocationPolicy status: %w", err)
+	}
+	return nil
+}
+
+// SetupWithManager registers the GPUAllocationPolicyReconciler with the controller-runtime manager.
+func (r *GPUAllocationPolicyReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	c, err := controller.New("gpu-allocation-policy-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: 1,
+		RateLimiter:             workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](minDelayCR, maxDelayCR),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating gpu-allocation-policy controller: %w", err)
+	}
+
+	if err := c.Watch(source.Kind(
+		mgr.GetCache(),
+		&nvidiav1alpha1.GPUAllocationPolicy{},
+		&handler.TypedEnqueueRequestForObject[*nvidiav1alpha1.GPUAllocationPolicy]{},
+		predicate.TypedGenerationChangedPredicate[*nvidiav1alpha1.GPUAllocationPolicy]{},
+	)); err != nil {
+		return fmt.Errorf("error watching GPUAllocationPolicy: %w", err)
+	}
+
+	// restore the managed objects when they are modified or deleted out of band
+	if err := c.Watch(source.Kind(
+		mgr.GetCache(),
+		&corev1.ResourceQuota{},
+		handler.TypedEnqueueRequestForOwner[*corev1.ResourceQuota](mgr.GetScheme(), mgr.GetRESTMapper(), &nvidiav1alpha1.GPUAllocationPolicy{},
+			handler.OnlyControllerOwner()),
+	)); err != nil {
+		return fmt.Errorf("error watching ResourceQuota: %w", err)
+	}
+	if err := c.Watch(source.Kind(
+		mgr.GetCache(),
+		&corev1.LimitRange{},
+		handler.TypedEnqueueRequestForOwner[*corev1.LimitRange](mgr.GetScheme(), mgr.GetRESTMapper(), &nvidiav1alpha1.GPUAllocationPolicy{},
+			handler.OnlyControllerOwner()),
+	)); err != nil {
+		return fmt.Errorf("error watching LimitRange: %w", err)
+	}
+
+	// namespaces being created or relabeled may change the namespaces selected by any policy
+	namespaceMapFn := func(ctx context.Context, _ *corev1.Namespace) []reconcile.Request {
+		policies := &nvidiav1alpha1.GPUAllocationPolicyList{}
+		if err := mgr.GetClient().List(ctx, policies); err != nil {
+			r.Log.Error(err, "unable to list GPUAllocationPolicies")
+			return nil
+		}
+		requests := make([]reconcile.Request, 0, len(policies.Items))
+		for _, policy := range policies.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: policy.Name}})
+		}
+		return requests
+	}
+	if err := c.Watch(source.Kind(
+		mgr.GetCache(),
+		&corev1.Namespace{},
+		handler.TypedEnqueueRequestsFromMapFunc(namespaceMapFn),
+		predicate.TypedFuncs[*corev1.Namespace]{
+			UpdateFunc: func(e event.TypedUpdateEvent[*corev1.Namespace]) bool {
+				return !equality.Semantic.DeepEqual(e.ObjectOld.Labels, e.ObjectNew.Labels) ||
+					e.ObjectOld.Status.Phase != e.ObjectNew.Status.Phase
+			},
+			DeleteFunc: func(event.TypedDeleteEvent[*corev1.Namespace]) bool { return false },
+		},
+	)); err != nil {
+		return fmt.Errorf("error watching Namespace: %w", err)
+	}
+
+	return nil
+}
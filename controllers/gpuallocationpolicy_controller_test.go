@@ -0,0 +1,140 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+func TestGPUAllocationPolicyReconcile(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, nvidiav1alpha1.AddToScheme(scheme))
+
+	namespace := func(name string, labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	policy := &nvidiav1alpha1.GPUAllocationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "research"},
+		Spec: nvidiav1alpha1.GPUAllocationPolicySpec{
+			NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"team": "research"}},
+			Quota: &nvidiav1alpha1.GPUResourceLimits{
+				GPUs:       ptr.To[int64](8),
+				MIGDevices: map[string]int64{"1g.10gb": 14},
+			},
+			ContainerLimits: &nvidiav1alpha1.GPUResourceLimits{
+				SharedGPUs: ptr.To[int64](2),
+			},
+		},
+	}
+	// objects left behind in a namespace that is no longer selected
+	staleQuota := &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{
+		Name:      "nvidia-gpu-allocation-research",
+		Namespace: "web",
+		Labels:    map[string]string{GPUAllocationPolicyLabelKey: "research"},
+	}}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		policy,
+		namespace("ml-training", map[string]string{"team": "research"}),
+		namespace("ml-inference", map[string]string{"team": "research"}),
+		namespace("web", map[string]string{"team": "web"}),
+		staleQuota,
+	).WithStatusSubresource(policy).Build()
+	r := &GPUAllocationPolicyReconciler{Client: c, Scheme: scheme, Log: logr.Discard()}
+
+	_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: policy.Name}})
+	require.NoError(t, err)
+
+	for _, ns := range []string{"ml-training", "ml-inference"} {
+		key := types.NamespacedName{Namespace: ns, Name: "nvidia-gpu-allocation-research"}
+
+		quota := &corev1.ResourceQuota{}
+		require.NoError(t, c.Get(ctx, key, quota))
+		require.Equal(t, "research", quota.Labels[GPUAllocationPolicyLabelKey])
+		require.Len(t, quota.Spec.Hard, 2)
+		require.True(t, resource.MustParse("8").Equal(quota.Spec.Hard["requests.nvidia.com/gpu"]))
+		require.True(t, resource.MustParse("14").Equal(quota.Spec.Hard["requests.nvidia.com/mig-1g.10gb"]))
+		require.True(t, metav1.IsControlledBy(quota, policy))
+
+		limitRange := &corev1.LimitRange{}
+		require.NoError(t, c.Get(ctx, key, limitRange))
+		require.Len(t, limitRange.Spec.Limits, 1)
+		require.Equal(t, corev1.LimitTypeContainer, limitRange.Spec.Limits[0].Type)
+		require.True(t, resource.MustParse("2").Equal(limitRange.Spec.Limits[0].Max["nvidia.com/gpu.shared"]))
+	}
+	require.True(t, apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(staleQuota), &corev1.ResourceQuota{})))
+
+	updated := &nvidiav1alpha1.GPUAllocationPolicy{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: policy.Name}, updated))
+	require.Equal(t, []string{"ml-inference", "ml-training"}, updated.Status.Namespaces)
+	require.True(t, meta.IsStatusConditionTrue(updated.Status.Conditions, conditions.Ready))
+
+	// dropping the container limits deletes the LimitRanges
+	updated.Spec.ContainerLimits = nil
+	require.NoError(t, c.Update(ctx, updated))
+	_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: policy.Name}})
+	require.NoError(t, err)
+	err = c.Get(ctx, types.NamespacedName{Namespace: "ml-training", Name: "nvidia-gpu-allocation-research"}, &corev1.LimitRange{})
+	require.True(t, apierrors.IsNotFound(err))
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "ml-training", Name: "nvidia-gpu-allocation-research"}, &corev1.ResourceQuota{}))
+}
+
+func TestGPUAllocationPolicyReconcileInvalidSelector(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, nvidiav1alpha1.AddToScheme(scheme))
+
+	policy := &nvidiav1alpha1.GPUAllocationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "invalid"},
+		Spec: nvidiav1alpha1.GPUAllocationPolicySpec{
+			NamespaceSelector: metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "team", Operator: "Unknown"},
+			}},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(policy).WithStatusSubresource(policy).Build()
+	r := &GPUAllocationPolicyReconciler{Client: c, Scheme: scheme, Log: logr.Discard()}
+
+	_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: policy.Name}})
+	require.NoError(t, err)
+
+	updated := &nvidiav1alpha1.GPUAllocationPolicy{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: policy.Name}, updated))
+	require.True(t, meta.IsStatusConditionFalse(updated.Status.Conditions, conditions.Ready))
+	require.Equal(t, "InvalidNamespaceSelector", meta.FindStatusCondition(updated.Status.Conditions, conditions.Error).Reason)
+}
@@ -42,8 +42,12 @@ import (
 
 	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
 	"github.com/NVIDIA/gpu-operator/controllers/clusterinfo"
+	"github.com/NVIDIA/gpu-operator/internal/apistats"
+	"github.com/NVIDIA/gpu-operator/internal/audit"
 	"github.com/NVIDIA/gpu-operator/internal/conditions"
 	"github.com/NVIDIA/gpu-operator/internal/consts"
+	"github.com/NVIDIA/gpu-operator/internal/featuregate"
+	"github.com/NVIDIA/gpu-operator/internal/operatorconfig"
 	"github.com/NVIDIA/gpu-operator/internal/state"
 )
 
@@ -60,6 +64,12 @@ type GPUClusterReconciler struct {
 	Scheme      *runtime.Scheme
 	ClusterInfo clusterinfo.Interface
 	Namespace   string
+	// AssetsDir is the directory of the operand asset manifests, the baked-in assets are used when empty
+	AssetsDir string
+	// APIStats counts the requests of the reconciliations, nothing is counted when nil
+	APIStats *apistats.Tracker
+	// OperatorConfig holds the feature gates set at runtime, the gates set when the operator started apply when nil
+	OperatorConfig *operatorconfig.Store
 
 	stateManager     state.Manager
 	conditionUpdater conditions.Updater
@@ -81,6 +91,9 @@ type GPUClusterReconciler struct {
 func (r *GPUClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 	logger.V(consts.LogLevelInfo).Info("Reconciling GPUCluster")
+	ctx = audit.WithReason(ctx, fmt.Sprintf("GPUCluster %s", req.Name))
+	ctx, done := r.APIStats.StartReconcile(ctx, "GPUCluster")
+	defer done()
 
 	instance := &nvidiav1alpha1.GPUCluster{}
 	if err := r.Get(ctx, req.NamespacedName, instance); err != nil {
@@ -121,6 +134,18 @@ func (r *GPUClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 	r.singleton = instance
 
+	if !r.OperatorConfig.FeatureEnabled(featuregate.DRADriver) {
+		logger.Info("The DRADriver feature gate is disabled, not reconciling the GPUCluster operands")
+		if err := r.updateCRStatus(ctx, instance, nvidiav1alpha1.Disabled); err != nil {
+			return ctrl.Result{}, err
+		}
+		if condErr := r.conditionUpdater.SetConditionsError(ctx, instance, conditions.FeatureGateDisabled, "The DRADriver feature gate is disabled"); condErr != nil {
+			logger.Error(condErr, "failed to set condition")
+		}
+		// the feature gate can be enabled at runtime through the operator ConfigMap
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
 	// The operand states render ResourceClaimTemplates with adminAccess: true, which the
 	// kube-scheduler only admits from a labeled namespace; label it before syncing states.
 	if err := r.ensureAdminAccessLabel(ctx); err != nil {
@@ -285,7 +310,8 @@ func (r *GPUClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 		nvidiav1alpha1.GPUClusterCRDName,
 		r.Namespace,
 		mgr.GetClient(),
-		mgr.GetScheme())
+		mgr.GetScheme(),
+		r.AssetsDir)
 	if err != nil {
 		return fmt.Errorf("error creating state manager: %w", err)
 	}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
@@ -38,6 +39,7 @@ import (
 	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
 	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
 	"github.com/NVIDIA/gpu-operator/internal/conditions"
+	"github.com/NVIDIA/gpu-operator/internal/operatorconfig"
 	"github.com/NVIDIA/gpu-operator/internal/state"
 )
 
@@ -269,3 +271,24 @@ func TestEnqueueAllGPUClusters(t *testing.T) {
 	sort.Strings(got)
 	require.Equal(t, []string{"/config-a", "/config-b"}, got)
 }
+
+func TestGPUClusterReconcileDRADriverGateDisabled(t *testing.T) {
+	cfg := &nvidiav1alpha1.GPUCluster{ObjectMeta: metav1.ObjectMeta{Name: "config"}}
+	r, c := newGPUClusterReconciler(t, cfg)
+	r.OperatorConfig = operatorconfig.NewStore(nil)
+	_, err := r.OperatorConfig.Apply(map[string]string{operatorconfig.FeatureGatesKey: "DRADriver=false"})
+	require.NoError(t, err)
+	r.stateManager = &fakeStateManager{results: state.Results{Status: state.SyncStateError}}
+
+	res, err := r.Reconcile(t.Context(), gccRequest(cfg.Name))
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, res.RequeueAfter)
+	require.Equal(t, nvidiav1alpha1.Disabled, gccState(t, c, cfg.Name))
+
+	// the operands are reconciled once the feature gate is enabled at runtime
+	_, err = r.OperatorConfig.Apply(nil)
+	require.NoError(t, err)
+	r.stateManager = &fakeStateManager{results: state.Results{Status: state.SyncStateReady}}
+	gccReconcile(t, r, cfg.Name)
+	require.Equal(t, nvidiav1alpha1.Ready, gccState(t, c, cfg.Name))
+}
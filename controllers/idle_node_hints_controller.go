@@ -0,0 +1,345 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/apistats"
+	"github.com/NVIDIA/gpu-operator/internal/audit"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+const (
+	idleNodeHintsControllerSingletonName = "cluster"
+
+	dcgmExporterAppLabelValue = "nvidia-dcgm-exporter"
+	dcgmExporterMetricsPort   = 9400
+	dcgmExporterScrapeTimeout = 5 * time.Second
+	dcgmGPUUtilizationMetric  = "DCGM_FI_DEV_GPU_UTIL"
+)
+
+// IdleNodeHintsReconciler publishes the nvidia.com/gpu.idle-since and nvidia.com/gpu.idle-duration
+// annotations on GPU nodes whose GPUs have all been idle, based on the GPU utilization reported by
+// DCGM Exporter. Cluster-autoscaler configurations or custom controllers can use the annotations to
+// prefer scaling down idle GPU nodes. The idle-since annotation is only written when a node becomes
+// idle or busy, so the idle durations survive the restarts of the operator, and the idle duration is
+// bucketed to the sampling interval.
+type IdleNodeHintsReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Namespace string
+	Log       logr.Logger
+	// APIStats counts the requests of the reconciliations, nothing is counted when nil
+	APIStats *apistats.Tracker
+
+	// scrapeGPUUtilization returns the utilization of every GPU reported by a DCGM Exporter pod
+	scrapeGPUUtilization func(ctx context.Context, pod *corev1.Pod) ([]float64, error)
+	now                  func() time.Time
+}
+
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+
+// Reconcile samples the GPU utilization of all nodes running DCGM Exporter and refreshes the idle
+// annotations of the nodes. The annotations of the nodes without sample, e.g. whose DCGM Exporter
+// could not be scraped, are left as is.
+func (r *IdleNodeHintsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx = audit.WithReason(ctx, "idle node hints")
+	ctx, done := r.APIStats.StartReconcile(ctx, "IdleNodeHints")
+	defer done()
+	if r.scrapeGPUUtilization == nil {
+		r.scrapeGPUUtilization = scrapeDCGMExporterGPUUtilization
+	}
+	if r.now == nil {
+		r.now = time.Now
+	}
+
+	clusterPolicy, gpuCluster, err := resolveActiveConfig(ctx, r.Client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// DCGM Exporter is shared by both stacks, prefer the ClusterPolicy configuration when both exist
+	var spec *gpuv1.DCGMExporterSpec
+	if clusterPolicy != nil {
+		spec = &clusterPolicy.Spec.DCGMExporter
+	} else if gpuCluster != nil {
+		spec = gpuCluster.Spec.DCGMExporter
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	if spec == nil || !spec.IsIdleNodeHintsEnabled() {
+		for i := range nodes.Items {
+			if err := r.setIdleAnnotations(ctx, &nodes.Items[i], "", ""); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+		return reconcile.Result{}, nil
+	}
+
+	utilization := r.sampleGPUUtilization(ctx)
+	threshold := float64(spec.IdleNodeHints.UtilizationThreshold)
+	interval := time.Duration(spec.GetIdleNodeHintsIntervalSeconds()) * time.Second
+	now := r.now()
+
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if !hasCommonGPULabel(node.Labels) {
+			if err := r.setIdleAnnotations(ctx, node, "", ""); err != nil {
+				return reconcile.Result{}, err
+			}
+			continue
+		}
+		samples := utilization[node.Name]
+		if len(samples) == 0 {
+			// the annotations are left as is until the GPU utilization of the node is sampled again
+			continue
+		}
+		if !isNodeIdle(samples, threshold) {
+			if err := r.setIdleAnnotations(ctx, node, "", ""); err != nil {
+				return reconcile.Result{}, err
+			}
+			continue
+		}
+
+		// the time the node became idle is kept until a GPU of the node is busy again
+		idleSince, err := time.Parse(time.RFC3339, node.Annotations[consts.GPUIdleSinceAnnotationKey])
+		if err != nil {
+			idleSince = now
+		}
+		idleDuration := now.Sub(idleSince).Truncate(interval)
+		if err := r.setIdleAnnotations(ctx, node, idleSince.UTC().Format(time.RFC3339), strconv.Itoa(int(idleDuration.Seconds()))); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	return reconcile.Result{RequeueAfter: interval}, nil
+}
+
+// sampleGPUUtilization returns the GPU utilization reported by each running DCGM Exporter pod, keyed by node name.
+// Nodes whose DCGM Exporter pod cannot be scraped are omitted.
+func (r *IdleNodeHintsReconciler) sampleGPUUtilization(ctx context.Context) map[string][]float64 {
+	utilization := make(map[string][]float64)
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(r.Namespace), client.MatchingLabels{"app": dcgmExporterAppLabelValue}); err != nil {
+		r.Log.Error(err, "failed to list DCGM Exporter pods")
+		return utilization
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != corev1.PodRunning || pod.Spec.NodeName == "" || pod.Status.PodIP == "" {
+			continue
+		}
+		values, err := r.scrapeGPUUtilization(ctx, pod)
+		if err != nil {
+			r.Log.Info("WARNING: failed to scrape GPU utilization from DCGM Exporter", "pod", pod.Name, "node", pod.Spec.NodeName, "error", err)
+			continue
+		}
+		utilization[pod.Spec.NodeName] = values
+	}
+	return utilization
+}
+
+// isNodeIdle returns true if GPU utilization was reported for the node and no GPU exceeds the threshold
+func isNodeIdle(utilization []float64, threshold float64) bool {
+	if len(utilization) == 0 {
+		return false
+	}
+	for _, value := range utilization {
+		if value > threshold {
+			return false
+		}
+	}
+	return true
+}
+
+// setIdleAnnotations sets the nvidia.com/gpu.idle-since and nvidia.com/gpu.idle-duration annotations of the node,
+// removing them if idleSince is empty
+func (r *IdleNodeHintsReconciler) setIdleAnnotations(ctx context.Context, node *corev1.Node, idleSince, idleDuration string) error {
+	desired := map[string]string{
+		consts.GPUIdleSinceAnnotationKey:    idleSince,
+		consts.GPUIdleDurationAnnotationKey: idleDuration,
+	}
+	changed := false
+	for key, value := range desired {
+		current, ok := node.Annotations[key]
+		if (value == "" && ok) || (value != "" && current != value) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	original := node.DeepCopy()
+	for key, value := range desired {
+		if value == "" {
+			delete(node.Annotations, key)
+			continue
+		}
+		if node.Annotations == nil {
+			node.Annotations = make(map[string]string)
+		}
+		node.Annotations[key] = value
+	}
+
+	if err := r.Patch(ctx, node, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to update the idle annotations on node %s: %w", node.Name, err)
+	}
+	return nil
+}
+
+// dcgmSample is a sample of a metric reported by DCGM Exporter
+type dcgmSample struct {
+	labels map[string]string
+	value  float64
+}
+
+// scrapeDCGMExporterGPUUtilization fetches the metrics endpoint of a DCGM Exporter pod and returns
+// the value of every DCGM_FI_DEV_GPU_UTIL sample
+func scrapeDCGMExporterGPUUtilization(ctx context.Context, pod *corev1.Pod) ([]float64, error) {
+	samples, err := scrapeDCGMExporterMetric(ctx, pod, dcgmGPUUtilizationMetric)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]float64, 0, len(samples))
+	for _, sample := range samples {
+		values = append(values, sample.value)
+	}
+	return values, nil
+}
+
+// scrapeDCGMExporterMetric fetches the metrics endpoint of a DCGM Exporter pod and returns the samples
+// of the given metric
+func scrapeDCGMExporterMetric(ctx context.Context, pod *corev1.Pod, metricName string) ([]dcgmSample, error) {
+	ctx, cancel := context.WithTimeout(ctx, dcgmExporterScrapeTimeout)
+	defer cancel()
+
+	url := fmt.Sprintf("http://%s/metrics", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(dcgmExporterMetricsPort)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, url)
+	}
+
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metrics from %s: %w", url, err)
+	}
+
+	family, ok := families[metricName]
+	if !ok {
+		return nil, fmt.Errorf("metric %s not reported by %s", metricName, url)
+	}
+
+	var samples []dcgmSample
+	for _, metric := range family.GetMetric() {
+		sample := dcgmSample{labels: make(map[string]string, len(metric.GetLabel()))}
+		for _, label := range metric.GetLabel() {
+			sample.labels[label.GetName()] = label.GetValue()
+		}
+		switch {
+		case metric.GetGauge() != nil:
+			sample.value = metric.GetGauge().GetValue()
+		case metric.GetUntyped() != nil:
+			sample.value = metric.GetUntyped().GetValue()
+		default:
+			continue
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
+// SetupWithManager registers the IdleNodeHintsReconciler with the controller-runtime manager.
+func (r *IdleNodeHintsReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	mapToSingleton := func(_ context.Context, _ client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: idleNodeHintsControllerSingletonName}}}
+	}
+
+	c, err := controller.New("idle-node-hints-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: 1,
+		RateLimiter:             workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](minDelayCR, maxDelayCR),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating idle-node-hints controller: %w", err)
+	}
+
+	clusterPolicyMapFn := func(ctx context.Context, cp *gpuv1.ClusterPolicy) []reconcile.Request {
+		return mapToSingleton(ctx, cp)
+	}
+	if err := c.Watch(source.Kind(
+		mgr.GetCache(),
+		&gpuv1.ClusterPolicy{},
+		handler.TypedEnqueueRequestsFromMapFunc(clusterPolicyMapFn),
+		predicate.TypedGenerationChangedPredicate[*gpuv1.ClusterPolicy]{},
+	)); err != nil {
+		return fmt.Errorf("error watching ClusterPolicy: %w", err)
+	}
+
+	gpuClusterMapFn := func(ctx context.Context, gc *nvidiav1alpha1.GPUCluster) []reconcile.Request {
+		return mapToSingleton(ctx, gc)
+	}
+	if err := c.Watch(source.Kind(
+		mgr.GetCache(),
+		&nvidiav1alpha1.GPUCluster{},
+		handler.TypedEnqueueRequestsFromMapFunc(gpuClusterMapFn),
+		predicate.TypedGenerationChangedPredicate[*nvidiav1alpha1.GPUCluster]{},
+	)); err != nil {
+		return fmt.Errorf("error watching GPUCluster: %w", err)
+	}
+
+	return nil
+}
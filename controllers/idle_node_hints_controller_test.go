@@ -0,0 +1,184 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+func TestIdleNodeHintsReconcile(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+	require.NoError(t, nvidiav1alpha1.AddToScheme(scheme))
+
+	clusterPolicy := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy"},
+		Spec: gpuv1.ClusterPolicySpec{
+			DCGMExporter: gpuv1.DCGMExporterSpec{
+				IdleNodeHints: &gpuv1.DCGMExporterIdleNodeHintsConfig{
+					Enabled:              ptr.To(true),
+					UtilizationThreshold: 5,
+					IntervalSeconds:      30,
+				},
+			},
+		},
+	}
+	gpuNode := func(name string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{commonGPULabelKey: commonGPULabelValue},
+		}}
+	}
+	exporterPod := func(nodeName string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nvidia-dcgm-exporter-" + nodeName,
+				Namespace: "gpu-operator",
+				Labels:    map[string]string{"app": dcgmExporterAppLabelValue},
+			},
+			Spec:   corev1.PodSpec{NodeName: nodeName},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.1"},
+		}
+	}
+
+	utilization := map[string][]float64{
+		"idle-node": {0, 3},
+		"busy-node": {0, 80},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		clusterPolicy,
+		gpuNode("idle-node"),
+		gpuNode("busy-node"),
+		exporterPod("idle-node"),
+		exporterPod("busy-node"),
+	).Build()
+
+	now := time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC)
+	newReconciler := func() *IdleNodeHintsReconciler {
+		return &IdleNodeHintsReconciler{
+			Client:    c,
+			Namespace: "gpu-operator",
+			Log:       logr.Discard(),
+			scrapeGPUUtilization: func(_ context.Context, pod *corev1.Pod) ([]float64, error) {
+				return utilization[pod.Spec.NodeName], nil
+			},
+			now: func() time.Time { return now },
+		}
+	}
+	r := newReconciler()
+
+	getNode := func(name string) *corev1.Node {
+		node := &corev1.Node{}
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Name: name}, node))
+		return node
+	}
+	getAnnotation := func(name string) (string, bool) {
+		value, ok := getNode(name).Annotations[consts.GPUIdleSinceAnnotationKey]
+		return value, ok
+	}
+
+	result, err := r.Reconcile(ctx, reconcile.Request{})
+	require.NoError(t, err)
+	require.Equal(t, 30*time.Second, result.RequeueAfter)
+	value, ok := getAnnotation("idle-node")
+	require.True(t, ok)
+	require.Equal(t, "2026-10-17T08:00:00Z", value)
+	_, ok = getAnnotation("busy-node")
+	require.False(t, ok)
+
+	require.Equal(t, "0", getNode("idle-node").Annotations[consts.GPUIdleDurationAnnotationKey])
+
+	// the idle duration is bucketed to the sampling interval, and the node is not written within a bucket
+	now = now.Add(40 * time.Second)
+	_, err = r.Reconcile(ctx, reconcile.Request{})
+	require.NoError(t, err)
+	require.Equal(t, "30", getNode("idle-node").Annotations[consts.GPUIdleDurationAnnotationKey])
+	resourceVersion := getNode("idle-node").ResourceVersion
+	now = now.Add(10 * time.Second)
+	_, err = r.Reconcile(ctx, reconcile.Request{})
+	require.NoError(t, err)
+	require.Equal(t, resourceVersion, getNode("idle-node").ResourceVersion)
+
+	// the time the node became idle survives a restart of the operator
+	r = newReconciler()
+	now = now.Add(130 * time.Second)
+	_, err = r.Reconcile(ctx, reconcile.Request{})
+	require.NoError(t, err)
+	node := getNode("idle-node")
+	require.Equal(t, "2026-10-17T08:00:00Z", node.Annotations[consts.GPUIdleSinceAnnotationKey])
+	require.Equal(t, "180", node.Annotations[consts.GPUIdleDurationAnnotationKey])
+
+	// the annotations are left untouched when the GPU utilization of the node cannot be sampled
+	delete(utilization, "idle-node")
+	_, err = r.Reconcile(ctx, reconcile.Request{})
+	require.NoError(t, err)
+	require.Equal(t, node.Annotations, getNode("idle-node").Annotations)
+	utilization["idle-node"] = []float64{0}
+
+	// the annotations are removed once a GPU of the node becomes busy
+	utilization["idle-node"] = []float64{50}
+	_, err = r.Reconcile(ctx, reconcile.Request{})
+	require.NoError(t, err)
+	_, ok = getAnnotation("idle-node")
+	require.False(t, ok)
+	require.NotContains(t, getNode("idle-node").Annotations, consts.GPUIdleDurationAnnotationKey)
+
+	// idle durations restart once the node is idle again
+	utilization["idle-node"] = []float64{0}
+	_, err = r.Reconcile(ctx, reconcile.Request{})
+	require.NoError(t, err)
+	value, _ = getAnnotation("idle-node")
+	require.Equal(t, "2026-10-17T08:03:00Z", value)
+	require.Equal(t, "0", getNode("idle-node").Annotations[consts.GPUIdleDurationAnnotationKey])
+
+	// annotations are removed from all nodes when idle node hints are disabled
+	cp := &gpuv1.ClusterPolicy{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: clusterPolicy.Name}, cp))
+	cp.Spec.DCGMExporter.IdleNodeHints.Enabled = ptr.To(false)
+	require.NoError(t, c.Update(ctx, cp))
+
+	result, err = r.Reconcile(ctx, reconcile.Request{})
+	require.NoError(t, err)
+	require.Zero(t, result.RequeueAfter)
+	_, ok = getAnnotation("idle-node")
+	require.False(t, ok)
+}
+
+func TestIsNodeIdle(t *testing.T) {
+	require.False(t, isNodeIdle(nil, 0), "nodes without samples are never idle")
+	require.True(t, isNodeIdle([]float64{0, 0}, 0))
+	require.True(t, isNodeIdle([]float64{2, 5}, 5))
+	require.False(t, isNodeIdle([]float64{0, 6}, 5))
+}
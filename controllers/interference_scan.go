@@ -0,0 +1,327 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+const (
+	// interferenceScanInterval is the interval between two scans of the sources of interference with the operands,
+	// which list objects of all namespaces
+	interferenceScanInterval = 10 * time.Minute
+
+	// the sources of interference, reported by an InterferenceDetected.<source> condition
+	interferenceDevicePlugin       = "DevicePlugin"
+	interferenceOperandPodMutation = "OperandPodMutation"
+	interferenceGVisorRuntime      = "GVisorRuntime"
+
+	// gVisorHandler is the runtime handler of gVisor
+	gVisorHandler = "runsc"
+)
+
+// devicePluginImages are the names of the images of the device plugins known to advertise nvidia.com/gpu
+var devicePluginImages = []string{"k8s-device-plugin", "nvidia-device-plugin", "nvidia-gpu-device-plugin"}
+
+// interferenceFinding is a source of interference with the operands found by the interference scan
+type interferenceFinding struct {
+	// source is the kind of interference, suffixing the type of the condition reporting it
+	source string
+	// message names the interfering objects along with a remediation hint
+	message string
+}
+
+// conditionType returns the type of the InterferenceDetected condition reporting the finding
+func (f interferenceFinding) conditionType() string {
+	return conditions.InterferenceDetected + "." + f.source
+}
+
+// reconcileInterference scans the cluster for the known sources of interference with the operands once per scan
+// interval, and reports an InterferenceDetected condition per source found. Such interference, e.g. a second
+// device plugin advertising nvidia.com/gpu or a webhook stripping the privileges of the operand pods, otherwise
+// looks like a failure of the operator.
+func (n *ClusterPolicyController) reconcileInterference(ctx context.Context, now time.Time) error {
+	if !n.interferenceScannedAt.IsZero() && now.Sub(n.interferenceScannedAt) < interferenceScanInterval {
+		return nil
+	}
+
+	// objects of all namespaces and cluster-scoped objects not otherwise watched are read from the API server
+	reader := client.Reader(n.client)
+	if n.apiReader != nil {
+		reader = n.apiReader
+	}
+
+	var findings []interferenceFinding
+	scans := []func(context.Context, client.Reader) (*interferenceFinding, error){
+		n.scanDevicePlugins,
+		n.scanOperandPodMutations,
+		n.scanGVisorRuntimeClasses,
+	}
+	for _, scan := range scans {
+		finding, err := scan(ctx, reader)
+		if err != nil {
+			return err
+		}
+		if finding != nil {
+			n.logger.Info("WARNING: interference with the operands detected", "source", finding.source, "details", finding.message)
+			findings = append(findings, *finding)
+		}
+	}
+
+	if err := n.updateInterferenceConditions(ctx, findings); err != nil {
+		return err
+	}
+	n.interferenceScannedAt = now
+	return nil
+}
+
+// scanDevicePlugins finds the DaemonSets not deployed by the operator running a device plugin known to advertise
+// nvidia.com/gpu, which competes with the device plugin of the operator for the GPUs of the nodes
+func (n ClusterPolicyController) scanDevicePlugins(ctx context.Context, reader client.Reader) (*interferenceFinding, error) {
+	if !n.isStateEnabled("state-device-plugin") {
+		return nil, nil
+	}
+	list := &appsv1.DaemonSetList{}
+	if err := reader.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("unable to list DaemonSets: %w", err)
+	}
+
+	var conflicts []string
+	for i := range list.Items {
+		ds := &list.Items[i]
+		if isOperatorOwned(ds) {
+			continue
+		}
+		podSpec := &ds.Spec.Template.Spec
+		if slices.ContainsFunc(slices.Concat(podSpec.InitContainers, podSpec.Containers), isDevicePluginContainer) {
+			conflicts = append(conflicts, ds.Namespace+"/"+ds.Name)
+		}
+	}
+	if len(conflicts) == 0 {
+		return nil, nil
+	}
+	slices.Sort(conflicts)
+	return &interferenceFinding{
+		source: interferenceDevicePlugin,
+		message: fmt.Sprintf("DaemonSets %s run a device plugin advertising nvidia.com/gpu next to the one of the "+
+			"operator: remove them, or disable the device plugin of the operator with devicePlugin.enabled=false",
+			strings.Join(conflicts, ", ")),
+	}, nil
+}
+
+// isOperatorOwned returns true if the object is controlled by a ClusterPolicy or an NVIDIADriver
+func isOperatorOwned(obj metav1.Object) bool {
+	owner := metav1.GetControllerOf(obj)
+	return owner != nil && strings.HasPrefix(owner.APIVersion, gpuv1.SchemeGroupVersion.Group+"/") &&
+		(owner.Kind == "ClusterPolicy" || owner.Kind == "NVIDIADriver")
+}
+
+// isDevicePluginContainer returns true if the container runs an image of a device plugin advertising nvidia.com/gpu
+func isDevicePluginContainer(container corev1.Container) bool {
+	name := container.Image
+	if i := strings.Index(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+	name = path.Base(name)
+	if i := strings.Index(name, ":"); i >= 0 {
+		name = name[:i]
+	}
+	return slices.Contains(devicePluginImages, name)
+}
+
+// scanOperandPodMutations finds the operand pods whose privileges or NVIDIA environment variables were stripped
+// at admission, comparing them with the pod template of their DaemonSet, and names the mutating webhooks
+// intercepting pods as the suspects
+func (n ClusterPolicyController) scanOperandPodMutations(ctx context.Context, reader client.Reader) (*interferenceFinding, error) {
+	list := &appsv1.DaemonSetList{}
+	if err := n.client.List(ctx, list, client.InNamespace(n.operatorNamespace)); err != nil {
+		return nil, fmt.Errorf("unable to list the operand DaemonSets: %w", err)
+	}
+
+	var mutated []string
+	for i := range list.Items {
+		ds := &list.Items[i]
+		if !isOperatorOwned(ds) || ds.Spec.Selector == nil {
+			continue
+		}
+		pods := &corev1.PodList{}
+		err := n.client.List(ctx, pods, client.InNamespace(n.operatorNamespace), client.MatchingLabels(ds.Spec.Selector.MatchLabels))
+		if err != nil {
+			return nil, fmt.Errorf("unable to list the pods of DaemonSet %s: %w", ds.Name, err)
+		}
+		for j := range pods.Items {
+			if stripped := getStrippedFields(&ds.Spec.Template.Spec, &pods.Items[j].Spec); len(stripped) > 0 {
+				mutated = append(mutated, fmt.Sprintf("%s (%s)", ds.Name, strings.Join(stripped, ", ")))
+				break
+			}
+		}
+	}
+	if len(mutated) == 0 {
+		return nil, nil
+	}
+
+	webhooks, err := getPodMutatingWebhooks(ctx, reader)
+	if err != nil {
+		return nil, err
+	}
+	suspects := "none found"
+	if len(webhooks) > 0 {
+		suspects = strings.Join(webhooks, ", ")
+	}
+	return &interferenceFinding{
+		source: interferenceOperandPodMutation,
+		message: fmt.Sprintf("The pods of DaemonSets %s were stripped of fields at admission, mutating webhooks "+
+			"intercepting pods: %s. Exclude the %s namespace from these webhooks",
+			strings.Join(mutated, "; "), suspects, n.operatorNamespace),
+	}, nil
+}
+
+// getStrippedFields returns the privileged flags and the NVIDIA environment variables of the containers of the
+// pod template missing from the pod
+func getStrippedFields(template *corev1.PodSpec, pod *corev1.PodSpec) []string {
+	var stripped []string
+	podContainers := slices.Concat(pod.InitContainers, pod.Containers)
+	for _, container := range slices.Concat(template.InitContainers, template.Containers) {
+		i := slices.IndexFunc(podContainers, func(c corev1.Container) bool { return c.Name == container.Name })
+		if i < 0 {
+			continue
+		}
+		podContainer := &podContainers[i]
+		if isPrivileged(container.SecurityContext) && !isPrivileged(podContainer.SecurityContext) {
+			stripped = append(stripped, container.Name+" privileged")
+		}
+		for _, env := range container.Env {
+			if !strings.HasPrefix(env.Name, "NVIDIA_") {
+				continue
+			}
+			if !slices.ContainsFunc(podContainer.Env, func(e corev1.EnvVar) bool { return e.Name == env.Name }) {
+				stripped = append(stripped, container.Name+" "+env.Name)
+			}
+		}
+	}
+	return stripped
+}
+
+func isPrivileged(securityContext *corev1.SecurityContext) bool {
+	return securityContext != nil && securityContext.Privileged != nil && *securityContext.Privileged
+}
+
+// getPodMutatingWebhooks returns the names of the mutating webhooks intercepting the creation of pods, as
+// <configuration>/<webhook>
+func getPodMutatingWebhooks(ctx context.Context, reader client.Reader) ([]string, error) {
+	list := &admissionregistrationv1.MutatingWebhookConfigurationList{}
+	if err := reader.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("unable to list MutatingWebhookConfigurations: %w", err)
+	}
+	var webhooks []string
+	for _, config := range list.Items {
+		for _, webhook := range config.Webhooks {
+			if slices.ContainsFunc(webhook.Rules, interceptsPodCreation) {
+				webhooks = append(webhooks, config.Name+"/"+webhook.Name)
+			}
+		}
+	}
+	slices.Sort(webhooks)
+	return webhooks, nil
+}
+
+func interceptsPodCreation(rule admissionregistrationv1.RuleWithOperations) bool {
+	matchesOperation := slices.ContainsFunc(rule.Operations, func(op admissionregistrationv1.OperationType) bool {
+		return op == admissionregistrationv1.Create || op == admissionregistrationv1.OperationAll
+	})
+	matchesResource := slices.ContainsFunc(rule.Resources, func(resource string) bool {
+		return resource == "pods" || resource == "*" || resource == "*/*"
+	})
+	matchesGroup := slices.ContainsFunc(rule.APIGroups, func(group string) bool { return group == "" || group == "*" })
+	return matchesOperation && matchesResource && matchesGroup
+}
+
+// scanGVisorRuntimeClasses finds the RuntimeClasses running the pods with gVisor, whose sandbox does not expose
+// the GPUs to the containers unless its nvproxy is enabled
+func (n ClusterPolicyController) scanGVisorRuntimeClasses(ctx context.Context, reader client.Reader) (*interferenceFinding, error) {
+	list := &nodev1.RuntimeClassList{}
+	if err := reader.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("unable to list RuntimeClasses: %w", err)
+	}
+	var runtimeClasses []string
+	for _, runtimeClass := range list.Items {
+		if runtimeClass.Handler == gVisorHandler {
+			runtimeClasses = append(runtimeClasses, runtimeClass.Name)
+		}
+	}
+	if len(runtimeClasses) == 0 {
+		return nil, nil
+	}
+	slices.Sort(runtimeClasses)
+	return &interferenceFinding{
+		source: interferenceGVisorRuntime,
+		message: fmt.Sprintf("RuntimeClasses %s run the pods with gVisor, which does not expose the GPUs to the "+
+			"containers: do not run GPU workloads with them, or enable the nvproxy of gVisor on the GPU nodes",
+			strings.Join(runtimeClasses, ", ")),
+	}, nil
+}
+
+// updateInterferenceConditions sets the InterferenceDetected condition of every finding in the ClusterPolicy
+// status, and removes the conditions of the sources of interference no longer found
+func (n ClusterPolicyController) updateInterferenceConditions(ctx context.Context, findings []interferenceFinding) error {
+	// Fetch latest instance and update status to avoid version mismatch
+	instance := &gpuv1.ClusterPolicy{}
+	if err := n.client.Get(ctx, types.NamespacedName{Name: n.singleton.Name}, instance); err != nil {
+		return fmt.Errorf("failed to get ClusterPolicy instance for status update: %w", err)
+	}
+	conditionsBefore := slices.Clone(instance.Status.Conditions)
+
+	found := make(map[string]bool, len(findings))
+	for _, finding := range findings {
+		found[finding.conditionType()] = true
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    finding.conditionType(),
+			Status:  metav1.ConditionTrue,
+			Reason:  conditions.InterferenceDetected,
+			Message: finding.message,
+		})
+	}
+	instance.Status.Conditions = slices.DeleteFunc(instance.Status.Conditions, func(c metav1.Condition) bool {
+		return strings.HasPrefix(c.Type, conditions.InterferenceDetected+".") && !found[c.Type]
+	})
+
+	if equality.Semantic.DeepEqual(instance.Status.Conditions, conditionsBefore) {
+		return nil
+	}
+	if err := n.client.Status().Update(ctx, instance); err != nil {
+		return fmt.Errorf("failed to update ClusterPolicy interference conditions: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+func newInterferenceTestDaemonSet(namespace, name, image string, owned bool) *appsv1.DaemonSet {
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": name}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:            name,
+						Image:           image,
+						SecurityContext: &corev1.SecurityContext{Privileged: ptr.To(true)},
+						Env:             []corev1.EnvVar{{Name: "NVIDIA_VISIBLE_DEVICES", Value: "all"}, {Name: "PATH", Value: "/usr/bin"}},
+					}},
+				},
+			},
+		},
+	}
+	if owned {
+		ds.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: gpuv1.SchemeGroupVersion.String(),
+			Kind:       "ClusterPolicy",
+			Name:       "cluster-policy",
+			Controller: ptr.To(true),
+		}}
+	}
+	return ds
+}
+
+func TestIsDevicePluginContainer(t *testing.T) {
+	for image, expected := range map[string]bool{
+		"nvcr.io/nvidia/k8s-device-plugin:v0.17.0":           true,
+		"registry.example.com/nvidia-device-plugin@sha256:0": true,
+		"nvidia-gpu-device-plugin":                           true,
+		"nvcr.io/nvidia/k8s-device-plugin-extra:v1":          false,
+		"nvcr.io/nvidia/cloud-native/dcgm:4.2.3":             false,
+	} {
+		require.Equal(t, expected, isDevicePluginContainer(corev1.Container{Image: image}), image)
+	}
+}
+
+func TestScanDevicePlugins(t *testing.T) {
+	cp := &gpuv1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy"}}
+	n := newMissingReferencesTestController(cp,
+		newInterferenceTestDaemonSet("test-ns", "nvidia-device-plugin-daemonset", "nvcr.io/nvidia/k8s-device-plugin:v0.17.0", true),
+		newInterferenceTestDaemonSet("kube-system", "nvidia-device-plugin", "nvcr.io/nvidia/k8s-device-plugin:v0.14.0", false),
+		newInterferenceTestDaemonSet("kube-system", "kube-proxy", "registry.k8s.io/kube-proxy:v1.31.0", false),
+	)
+
+	finding, err := n.scanDevicePlugins(context.Background(), n.client)
+	require.NoError(t, err)
+	require.NotNil(t, finding)
+	require.Equal(t, interferenceDevicePlugin, finding.source)
+	require.Contains(t, finding.message, "kube-system/nvidia-device-plugin")
+	require.NotContains(t, finding.message, "test-ns/")
+
+	// a second device plugin does not interfere when the one of the operator is disabled
+	cp.Spec.DevicePlugin.Enabled = ptr.To(false)
+	finding, err = n.scanDevicePlugins(context.Background(), n.client)
+	require.NoError(t, err)
+	require.Nil(t, finding)
+}
+
+func TestGetStrippedFields(t *testing.T) {
+	template := newInterferenceTestDaemonSet("test-ns", "nvidia-dcgm", "dcgm", true).Spec.Template.Spec
+	pod := template.DeepCopy()
+	require.Empty(t, getStrippedFields(&template, pod))
+
+	pod.Containers[0].SecurityContext = nil
+	pod.Containers[0].Env = pod.Containers[0].Env[1:]
+	require.Equal(t, []string{"nvidia-dcgm privileged", "nvidia-dcgm NVIDIA_VISIBLE_DEVICES"}, getStrippedFields(&template, pod))
+}
+
+func TestScanOperandPodMutations(t *testing.T) {
+	ds := newInterferenceTestDaemonSet("test-ns", "nvidia-dcgm", "dcgm", true)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "nvidia-dcgm-abcde", Namespace: "test-ns", Labels: ds.Spec.Template.Labels},
+		Spec:       *ds.Spec.Template.Spec.DeepCopy(),
+	}
+	pod.Spec.Containers[0].Env = nil
+	webhooks := &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy-agent"},
+		Webhooks: []admissionregistrationv1.MutatingWebhook{
+			{
+				Name: "pods.policy-agent.example.com",
+				Rules: []admissionregistrationv1.RuleWithOperations{{
+					Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+					Rule:       admissionregistrationv1.Rule{APIGroups: []string{""}, Resources: []string{"pods"}},
+				}},
+			},
+			{
+				Name: "services.policy-agent.example.com",
+				Rules: []admissionregistrationv1.RuleWithOperations{{
+					Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.OperationAll},
+					Rule:       admissionregistrationv1.Rule{APIGroups: []string{""}, Resources: []string{"services"}},
+				}},
+			},
+		},
+	}
+	n := newMissingReferencesTestController(&gpuv1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy"}}, ds, pod, webhooks)
+
+	finding, err := n.scanOperandPodMutations(context.Background(), n.client)
+	require.NoError(t, err)
+	require.NotNil(t, finding)
+	require.Equal(t, interferenceOperandPodMutation, finding.source)
+	require.Contains(t, finding.message, "nvidia-dcgm (nvidia-dcgm NVIDIA_VISIBLE_DEVICES)")
+	require.Contains(t, finding.message, "policy-agent/pods.policy-agent.example.com")
+	require.NotContains(t, finding.message, "services.policy-agent.example.com")
+}
+
+func TestReconcileInterference(t *testing.T) {
+	cp := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy"},
+		Status: gpuv1.ClusterPolicyStatus{
+			Conditions: []metav1.Condition{{
+				Type:   conditions.InterferenceDetected + "." + interferenceDevicePlugin,
+				Status: metav1.ConditionTrue,
+				Reason: conditions.InterferenceDetected,
+			}},
+		},
+	}
+	gVisor := &nodev1.RuntimeClass{ObjectMeta: metav1.ObjectMeta{Name: "gvisor"}, Handler: gVisorHandler}
+	n := newMissingReferencesTestController(cp, gVisor)
+	now := time.Now()
+
+	getConditions := func() []metav1.Condition {
+		instance := &gpuv1.ClusterPolicy{}
+		require.NoError(t, n.client.Get(context.Background(), types.NamespacedName{Name: cp.Name}, instance))
+		return instance.Status.Conditions
+	}
+
+	require.NoError(t, n.reconcileInterference(context.Background(), now))
+	statusConditions := getConditions()
+	require.Len(t, statusConditions, 1)
+	condition := meta.FindStatusCondition(statusConditions, conditions.InterferenceDetected+"."+interferenceGVisorRuntime)
+	require.NotNil(t, condition)
+	require.Contains(t, condition.Message, "RuntimeClasses gvisor")
+
+	// the scan is not repeated before the end of the scan interval
+	require.NoError(t, n.client.Delete(context.Background(), gVisor))
+	require.NoError(t, n.reconcileInterference(context.Background(), now.Add(time.Minute)))
+	require.Len(t, getConditions(), 1)
+
+	require.NoError(t, n.reconcileInterference(context.Background(), now.Add(interferenceScanInterval)))
+	require.Empty(t, getConditions())
+}
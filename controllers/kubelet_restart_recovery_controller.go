@@ -0,0 +1,299 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/apistats"
+	"github.com/NVIDIA/gpu-operator/internal/audit"
+)
+
+const (
+	sandboxDevicePluginAppLabelValue = "nvidia-sandbox-device-plugin-daemonset"
+
+	// nvidiaResourcePrefix is the prefix of the extended resources advertised by the device plugins
+	nvidiaResourcePrefix = "nvidia.com/"
+)
+
+// kubeletRestartRecoveryState is the kubelet state last observed on a node, and the recovery of its GPU resources
+// pending after a kubelet restart
+type kubeletRestartRecoveryState struct {
+	bootID     string
+	readySince time.Time
+
+	// waitingSince is the time of the kubelet restart, or of the last restart of the device plugin pods, since
+	// which the GPU resources of the node are waited for, zero when no recovery is pending
+	waitingSince time.Time
+	restarts     int
+}
+
+// KubeletRestartRecoveryReconciler recovers the GPU resources of the nodes after kubelet restarts. A kubelet
+// restart forgets the device plugins, whose resources are not allocatable until they register again with the
+// restarted kubelet, which they may fail to do. The restarts are detected from a change of the boot ID of a node
+// or of the start of its Ready condition, when the GPU resources of the node do not reappear in time, the NVIDIA
+// Device Plugin and Sandbox Device Plugin pods of the node are restarted and events are emitted on the node.
+type KubeletRestartRecoveryReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Namespace string
+	Log       logr.Logger
+	// APIStats counts the requests of the reconciliations, nothing is counted when nil
+	APIStats *apistats.Tracker
+
+	recorder events.EventRecorder
+	now      func() time.Time
+
+	// nodes records the kubelet state of each GPU node. It is kept in memory, so the kubelet restarts happening
+	// while the operator is not running are not detected.
+	nodes map[string]*kubeletRestartRecoveryState
+}
+
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+
+// Reconcile checks that the GPU resources of a node reappear after a kubelet restart, restarting the device
+// plugin pods of the node otherwise.
+func (r *KubeletRestartRecoveryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx = audit.WithReason(ctx, "kubelet restart recovery")
+	ctx, done := r.APIStats.StartReconcile(ctx, "KubeletRestartRecovery")
+	defer done()
+	if r.now == nil {
+		r.now = time.Now
+	}
+	if r.nodes == nil {
+		r.nodes = make(map[string]*kubeletRestartRecoveryState)
+	}
+
+	clusterPolicy, _, err := resolveActiveConfig(ctx, r.Client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if clusterPolicy == nil || !clusterPolicy.Spec.DevicePlugin.IsKubeletRestartRecoveryEnabled() {
+		delete(r.nodes, req.Name)
+		return reconcile.Result{}, nil
+	}
+
+	node := &corev1.Node{}
+	if err := r.Get(ctx, types.NamespacedName{Name: req.Name}, node); err != nil {
+		if apierrors.IsNotFound(err) {
+			delete(r.nodes, req.Name)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("failed to get node %s: %w", req.Name, err)
+	}
+	if !hasCommonGPULabel(node.Labels) {
+		delete(r.nodes, node.Name)
+		return reconcile.Result{}, nil
+	}
+
+	state := r.observeKubelet(node)
+	if hasAllocatableGPUResources(node) {
+		if !state.waitingSince.IsZero() && state.restarts > 0 && r.recorder != nil {
+			r.recorder.Eventf(node, nil, corev1.EventTypeNormal, "GPUResourcesRecovered", "RestartDevicePlugin",
+				"GPU resources recovered after %d restarts of the device plugin pods", state.restarts)
+		}
+		state.waitingSince = time.Time{}
+		state.restarts = 0
+		return reconcile.Result{}, nil
+	}
+	if state.waitingSince.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	timeout := time.Duration(clusterPolicy.Spec.DevicePlugin.GetKubeletRestartRecoveryTimeoutSeconds()) * time.Second
+	if elapsed := r.now().Sub(state.waitingSince); elapsed < timeout {
+		return reconcile.Result{RequeueAfter: timeout - elapsed}, nil
+	}
+
+	maxRestarts := clusterPolicy.Spec.DevicePlugin.GetKubeletRestartRecoveryMaxRestarts()
+	if state.restarts >= maxRestarts {
+		r.Log.Info("WARNING: GPU resources still missing after restarting the device plugin pods, giving up",
+			"node", node.Name, "restarts", state.restarts)
+		if r.recorder != nil {
+			r.recorder.Eventf(node, nil, corev1.EventTypeWarning, "GPUResourcesMissing", "RestartDevicePlugin",
+				"GPU resources still missing after %d restarts of the device plugin pods, check the device plugin logs", state.restarts)
+		}
+		state.waitingSince = time.Time{}
+		return reconcile.Result{}, nil
+	}
+
+	restarted, err := r.restartDevicePlugins(ctx, node)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if len(restarted) == 0 {
+		// no device plugin runs on the node, e.g. while it is paused, the resources are not expected
+		state.waitingSince = time.Time{}
+		return reconcile.Result{}, nil
+	}
+	state.restarts++
+	state.waitingSince = r.now()
+	r.Log.Info("GPU resources missing after a kubelet restart, restarted the device plugin pods",
+		"node", node.Name, "pods", restarted, "restarts", state.restarts)
+	if r.recorder != nil {
+		r.recorder.Eventf(node, nil, corev1.EventTypeWarning, "GPUResourcesMissing", "RestartDevicePlugin",
+			"GPU resources missing %s after a kubelet restart, restarted the device plugin pods %s",
+			timeout, strings.Join(restarted, ", "))
+	}
+	return reconcile.Result{RequeueAfter: timeout}, nil
+}
+
+// observeKubelet records the kubelet state of the node, starting to wait for its GPU resources when a kubelet
+// restart is detected
+func (r *KubeletRestartRecoveryReconciler) observeKubelet(node *corev1.Node) *kubeletRestartRecoveryState {
+	bootID := node.Status.NodeInfo.BootID
+	readySince := nodeReadySince(node)
+
+	state, ok := r.nodes[node.Name]
+	if !ok {
+		state = &kubeletRestartRecoveryState{bootID: bootID, readySince: readySince}
+		r.nodes[node.Name] = state
+		return state
+	}
+
+	restarted := state.bootID != bootID || (!readySince.IsZero() && !state.readySince.Equal(readySince))
+	state.bootID = bootID
+	state.readySince = readySince
+	if restarted {
+		r.Log.Info("Kubelet restart detected, waiting for the GPU resources of the node", "node", node.Name)
+		state.waitingSince = r.now()
+		state.restarts = 0
+	}
+	return state
+}
+
+// nodeReadySince returns the time the node became Ready, zero if it is not Ready
+func nodeReadySince(node *corev1.Node) time.Time {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+			return condition.LastTransitionTime.Time
+		}
+	}
+	return time.Time{}
+}
+
+// hasAllocatableGPUResources returns true if a resource advertised by the device plugins is allocatable on the node
+func hasAllocatableGPUResources(node *corev1.Node) bool {
+	for name, quantity := range node.Status.Allocatable {
+		if strings.HasPrefix(string(name), nvidiaResourcePrefix) && !quantity.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// restartDevicePlugins deletes the NVIDIA Device Plugin and Sandbox Device Plugin pods of the node, which are
+// created again by their DaemonSets, and returns the names of the deleted pods
+func (r *KubeletRestartRecoveryReconciler) restartDevicePlugins(ctx context.Context, node *corev1.Node) ([]string, error) {
+	var restarted []string
+	for _, app := range []string{devicePluginAppLabelValue, sandboxDevicePluginAppLabelValue} {
+		pods := &corev1.PodList{}
+		if err := r.List(ctx, pods, client.InNamespace(r.Namespace), client.MatchingLabels{"app": app}); err != nil {
+			return restarted, fmt.Errorf("failed to list %s pods: %w", app, err)
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if pod.Spec.NodeName != node.Name || pod.DeletionTimestamp != nil {
+				continue
+			}
+			if err := r.Delete(ctx, pod); err != nil && !apierrors.IsNotFound(err) {
+				return restarted, fmt.Errorf("failed to delete pod %s: %w", pod.Name, err)
+			}
+			restarted = append(restarted, pod.Name)
+		}
+	}
+	return restarted, nil
+}
+
+// SetupWithManager registers the KubeletRestartRecoveryReconciler with the controller-runtime manager.
+func (r *KubeletRestartRecoveryReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	r.recorder = mgr.GetEventRecorder("nvidia-gpu-operator")
+
+	c, err := controller.New("kubelet-restart-recovery-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: 1,
+		RateLimiter:             workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](minDelayCR, maxDelayCR),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating kubelet-restart-recovery controller: %w", err)
+	}
+
+	// the kubelet restarts are detected from the boot ID and the Ready condition of the nodes, and the GPU
+	// resources from their allocatable resources
+	nodePredicate := predicate.TypedFuncs[*corev1.Node]{
+		UpdateFunc: func(e event.TypedUpdateEvent[*corev1.Node]) bool {
+			return e.ObjectOld.Status.NodeInfo.BootID != e.ObjectNew.Status.NodeInfo.BootID ||
+				!nodeReadySince(e.ObjectOld).Equal(nodeReadySince(e.ObjectNew)) ||
+				hasAllocatableGPUResources(e.ObjectOld) != hasAllocatableGPUResources(e.ObjectNew) ||
+				hasCommonGPULabel(e.ObjectOld.Labels) != hasCommonGPULabel(e.ObjectNew.Labels)
+		},
+	}
+	if err := c.Watch(source.Kind(
+		mgr.GetCache(),
+		&corev1.Node{},
+		&handler.TypedEnqueueRequestForObject[*corev1.Node]{},
+		nodePredicate,
+	)); err != nil {
+		return fmt.Errorf("error watching Nodes: %w", err)
+	}
+
+	// all the GPU nodes are checked again when the recovery is enabled or disabled
+	clusterPolicyMapFn := func(ctx context.Context, _ *gpuv1.ClusterPolicy) []reconcile.Request {
+		nodes := &corev1.NodeList{}
+		if err := mgr.GetClient().List(ctx, nodes, client.MatchingLabels{commonGPULabelKey: commonGPULabelValue}); err != nil {
+			r.Log.Error(err, "failed to list GPU nodes")
+			return nil
+		}
+		requests := make([]reconcile.Request, 0, len(nodes.Items))
+		for _, node := range nodes.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: node.Name}})
+		}
+		return requests
+	}
+	if err := c.Watch(source.Kind(
+		mgr.GetCache(),
+		&gpuv1.ClusterPolicy{},
+		handler.TypedEnqueueRequestsFromMapFunc(clusterPolicyMapFn),
+		predicate.TypedGenerationChangedPredicate[*gpuv1.ClusterPolicy]{},
+	)); err != nil {
+		return fmt.Errorf("error watching ClusterPolicy: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+func TestKubeletRestartRecoveryReconcile(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+	require.NoError(t, nvidiav1alpha1.AddToScheme(scheme))
+
+	clusterPolicy := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy"},
+		Spec: gpuv1.ClusterPolicySpec{
+			DevicePlugin: gpuv1.DevicePluginSpec{
+				KubeletRestartRecovery: &gpuv1.KubeletRestartRecoveryConfig{TimeoutSeconds: 60, MaxRestarts: 1},
+			},
+		},
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "gpu-node",
+			Labels: map[string]string{commonGPULabelKey: commonGPULabelValue},
+		},
+		Status: corev1.NodeStatus{
+			NodeInfo:    corev1.NodeSystemInfo{BootID: "boot-1"},
+			Allocatable: corev1.ResourceList{gpuResourceName: resource.MustParse("8")},
+		},
+	}
+	devicePluginPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "gpu-operator",
+				Labels:    map[string]string{"app": devicePluginAppLabelValue},
+			},
+			Spec: corev1.PodSpec{NodeName: "gpu-node"},
+		}
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		clusterPolicy, node, devicePluginPod("nvidia-device-plugin-a"),
+	).WithStatusSubresource(&corev1.Node{}).Build()
+	recorder := events.NewFakeRecorder(10)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := &KubeletRestartRecoveryReconciler{
+		Client:    c,
+		Namespace: "gpu-operator",
+		Log:       logr.Discard(),
+		recorder:  recorder,
+		now:       func() time.Time { return now },
+	}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "gpu-node"}}
+
+	setNodeStatus := func(bootID string, gpus string) {
+		current := &corev1.Node{}
+		require.NoError(t, c.Get(ctx, req.NamespacedName, current))
+		current.Status.NodeInfo.BootID = bootID
+		current.Status.Allocatable = corev1.ResourceList{gpuResourceName: resource.MustParse(gpus)}
+		require.NoError(t, c.Status().Update(ctx, current))
+	}
+	countPods := func() int {
+		pods := &corev1.PodList{}
+		require.NoError(t, c.List(ctx, pods, client.InNamespace("gpu-operator")))
+		return len(pods.Items)
+	}
+
+	// first observation of the node, nothing is pending
+	result, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Zero(t, result.RequeueAfter)
+
+	// the kubelet restarts with the node and the GPUs are not advertised
+	setNodeStatus("boot-2", "0")
+	result, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, 60*time.Second, result.RequeueAfter)
+	require.Equal(t, 1, countPods())
+
+	// the timeout elapses, the device plugin pod is restarted
+	now = now.Add(60 * time.Second)
+	result, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, 60*time.Second, result.RequeueAfter)
+	require.Zero(t, countPods())
+	require.Contains(t, <-recorder.Events, "GPUResourcesMissing")
+
+	// the restart did not help and the maximum restarts is reached
+	require.NoError(t, c.Create(ctx, devicePluginPod("nvidia-device-plugin-b")))
+	now = now.Add(60 * time.Second)
+	result, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Zero(t, result.RequeueAfter)
+	require.Equal(t, 1, countPods())
+	require.Contains(t, <-recorder.Events, "still missing after 1 restarts")
+
+	// the GPUs are advertised again after another kubelet restart
+	setNodeStatus("boot-3", "0")
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	setNodeStatus("boot-3", "8")
+	result, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Zero(t, result.RequeueAfter)
+	require.True(t, r.nodes["gpu-node"].waitingSince.IsZero())
+	require.Equal(t, 1, countPods())
+}
+
+func TestKubeletRestartRecoveryDisabled(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+	require.NoError(t, nvidiav1alpha1.AddToScheme(scheme))
+
+	clusterPolicy := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy"},
+		Spec: gpuv1.ClusterPolicySpec{
+			DevicePlugin: gpuv1.DevicePluginSpec{
+				KubeletRestartRecovery: &gpuv1.KubeletRestartRecoveryConfig{Enabled: ptr.To(false)},
+			},
+		},
+	}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   "gpu-node",
+		Labels: map[string]string{commonGPULabelKey: commonGPULabelValue},
+	}}
+	r := &KubeletRestartRecoveryReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(clusterPolicy, node).Build(),
+		Log:    logr.Discard(),
+	}
+	_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "gpu-node"}})
+	require.NoError(t, err)
+	require.Empty(t, r.nodes)
+}
+
+func TestHasAllocatableGPUResources(t *testing.T) {
+	node := &corev1.Node{Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+		corev1.ResourceCPU:  resource.MustParse("4"),
+		"nvidia.com/gpu":    resource.MustParse("0"),
+		"nvidia.com/GH100":  resource.MustParse("0"),
+		"example.com/other": resource.MustParse("1"),
+	}}}
+	require.False(t, hasAllocatableGPUResources(node))
+
+	node.Status.Allocatable["nvidia.com/GH100"] = resource.MustParse("2")
+	require.True(t, hasAllocatableGPUResources(node))
+}
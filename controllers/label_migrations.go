@@ -0,0 +1,108 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// nodeLabelKeyMigration describes a node label key renamed between operator versions.
+type nodeLabelKeyMigration struct {
+	// oldKey is the label key used by previous operator versions
+	oldKey string
+	// newKey is the label key used by the operator version introducing the rename onwards
+	newKey string
+}
+
+// nodeLabelKeyMigrations is the registry of node label key renames, ordered by the operator version introducing them.
+// When a release renames a node label key, add an entry here instead of switching the key in place:
+// the node-labeling controller then copies the value to the new key on every node before operands
+// are re-rendered with it, and only removes the old key once no operand DaemonSet in the operator
+// namespace selects on it anymore, through its node selector or node affinity, so operand pods stay schedulable throughout the upgrade.
+var nodeLabelKeyMigrations = []nodeLabelKeyMigration{}
+
+// migrateNodeLabelKeys applies the label key migrations to the node labels. The value of an old key
+// is copied to its new key unless the new key is already set; the old key is removed once it is no
+// longer referenced by the node selection of an operand DaemonSet (keysInUse). Returns whether the labels
+// were modified and whether an old key had to be retained because it is still in use.
+func migrateNodeLabelKeys(labels map[string]string, keysInUse map[string]bool, migrations []nodeLabelKeyMigration) (modified bool, pending bool) {
+	for _, migration := range migrations {
+		value, ok := labels[migration.oldKey]
+		if !ok {
+			continue
+		}
+		if _, ok := labels[migration.newKey]; !ok {
+			labels[migration.newKey] = value
+			modified = true
+		}
+		if keysInUse[migration.oldKey] {
+			pending = true
+			continue
+		}
+		delete(labels, migration.oldKey)
+		modified = true
+	}
+	return modified, pending
+}
+
+// getDaemonSetNodeSelectorKeys returns the node label keys used in the node selectors and node affinities of the
+// DaemonSets in the operator namespace
+func (nlc *nodeLabelingController) getDaemonSetNodeSelectorKeys(ctx context.Context) (map[string]bool, error) {
+	keys := make(map[string]bool)
+	if len(nodeLabelKeyMigrations) == 0 {
+		return keys, nil
+	}
+
+	list := &appsv1.DaemonSetList{}
+	if err := nlc.client.List(ctx, list, client.InNamespace(nlc.namespace)); err != nil {
+		return nil, fmt.Errorf("unable to list DaemonSets: %w", err)
+	}
+	for _, ds := range list.Items {
+		addNodeSelectionKeys(keys, &ds.Spec.Template.Spec)
+	}
+	return keys, nil
+}
+
+// addNodeSelectionKeys adds the node label keys the pod spec selects nodes on to keys. Both the required and
+// the preferred node affinity terms are considered, as removing a label referenced by either of them changes
+// where the pods are scheduled.
+func addNodeSelectionKeys(keys map[string]bool, podSpec *corev1.PodSpec) {
+	for key := range podSpec.NodeSelector {
+		keys[key] = true
+	}
+	if podSpec.Affinity == nil || podSpec.Affinity.NodeAffinity == nil {
+		return
+	}
+	nodeAffinity := podSpec.Affinity.NodeAffinity
+	var terms []corev1.NodeSelectorTerm
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		terms = append(terms, nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms...)
+	}
+	for _, preferred := range nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+		terms = append(terms, preferred.Preference)
+	}
+	for _, term := range terms {
+		for _, requirement := range term.MatchExpressions {
+			keys[requirement.Key] = true
+		}
+	}
+}
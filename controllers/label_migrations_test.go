@@ -0,0 +1,173 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestMigrateNodeLabelKeys(t *testing.T) {
+	migrations := []nodeLabelKeyMigration{
+		{oldKey: "nvidia.com/gpu.deploy.old-operand", newKey: "nvidia.com/gpu.deploy.new-operand"},
+	}
+
+	testCases := []struct {
+		description      string
+		labels           map[string]string
+		keysInUse        map[string]bool
+		expectedLabels   map[string]string
+		expectedModified bool
+		expectedPending  bool
+	}{
+		{
+			description: "new key is written while old key is still in use",
+			labels:      map[string]string{"nvidia.com/gpu.deploy.old-operand": "false"},
+			keysInUse:   map[string]bool{"nvidia.com/gpu.deploy.old-operand": true},
+			expectedLabels: map[string]string{
+				"nvidia.com/gpu.deploy.old-operand": "false",
+				"nvidia.com/gpu.deploy.new-operand": "false",
+			},
+			expectedModified: true,
+			expectedPending:  true,
+		},
+		{
+			description: "old key is removed once no longer in use",
+			labels: map[string]string{
+				"nvidia.com/gpu.deploy.old-operand": "false",
+				"nvidia.com/gpu.deploy.new-operand": "false",
+			},
+			keysInUse:        map[string]bool{"nvidia.com/gpu.deploy.new-operand": true},
+			expectedLabels:   map[string]string{"nvidia.com/gpu.deploy.new-operand": "false"},
+			expectedModified: true,
+		},
+		{
+			description: "existing new key is not overwritten",
+			labels: map[string]string{
+				"nvidia.com/gpu.deploy.old-operand": "true",
+				"nvidia.com/gpu.deploy.new-operand": "false",
+			},
+			expectedLabels:   map[string]string{"nvidia.com/gpu.deploy.new-operand": "false"},
+			expectedModified: true,
+		},
+		{
+			description:    "node without old key is unchanged",
+			labels:         map[string]string{"nvidia.com/gpu.deploy.new-operand": "true"},
+			expectedLabels: map[string]string{"nvidia.com/gpu.deploy.new-operand": "true"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			modified, pending := migrateNodeLabelKeys(tc.labels, tc.keysInUse, migrations)
+			require.Equal(t, tc.expectedModified, modified)
+			require.Equal(t, tc.expectedPending, pending)
+			require.Equal(t, tc.expectedLabels, tc.labels)
+		})
+	}
+}
+
+func TestAddNodeSelectionKeys(t *testing.T) {
+	podSpec := &corev1.PodSpec{
+		NodeSelector: map[string]string{"nvidia.com/gpu.deploy.driver": "true"},
+		Affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{MatchExpressions: []corev1.NodeSelectorRequirement{
+						{Key: "nvidia.com/gpu.deploy.old-operand", Operator: corev1.NodeSelectorOpIn, Values: []string{"true"}},
+					}},
+					{MatchFields: []corev1.NodeSelectorRequirement{
+						{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{"node-1"}},
+					}},
+				},
+			},
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{
+				{Weight: 1, Preference: corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{
+					{Key: "example.com/rack", Operator: corev1.NodeSelectorOpExists},
+				}}},
+			},
+		}},
+	}
+
+	keys := make(map[string]bool)
+	addNodeSelectionKeys(keys, podSpec)
+	require.Equal(t, map[string]bool{
+		"nvidia.com/gpu.deploy.driver":      true,
+		"nvidia.com/gpu.deploy.old-operand": true,
+		"example.com/rack":                  true,
+	}, keys)
+
+	keys = make(map[string]bool)
+	addNodeSelectionKeys(keys, &corev1.PodSpec{Affinity: &corev1.Affinity{}})
+	require.Empty(t, keys)
+}
+
+func TestRecreateDaemonSet(t *testing.T) {
+	newDaemonSet := func(selector map[string]string, podLabels map[string]string) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "nvidia-operand", Namespace: "test-ns"},
+			Spec: appsv1.DaemonSetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: selector},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		description string
+		current     *appsv1.DaemonSet
+		new         *appsv1.DaemonSet
+	}{
+		{
+			description: "existing pods match the new selector",
+			current:     newDaemonSet(map[string]string{"app": "old"}, map[string]string{"app": "old", "app.kubernetes.io/name": "new"}),
+			new:         newDaemonSet(map[string]string{"app.kubernetes.io/name": "new"}, map[string]string{"app.kubernetes.io/name": "new"}),
+		},
+		{
+			description: "existing pods do not match the new selector",
+			current:     newDaemonSet(map[string]string{"app": "old"}, map[string]string{"app": "old"}),
+			new:         newDaemonSet(map[string]string{"app": "new"}, map[string]string{"app": "new"}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithObjects(tc.current).Build()
+			n := ClusterPolicyController{client: c, logger: ctrl.Log.WithName("test")}
+
+			state, err := recreateDaemonSet(context.Background(), tc.current, tc.new, []string{"spec.selector"}, n)
+			require.NoError(t, err)
+			require.Equal(t, gpuv1.NotReady, state)
+
+			err = c.Get(context.Background(), types.NamespacedName{Name: "nvidia-operand", Namespace: "test-ns"}, &appsv1.DaemonSet{})
+			require.True(t, apierrors.IsNotFound(err))
+		})
+	}
+}
@@ -0,0 +1,292 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+const (
+	// licenseProbePodName is the name of the pod checking the reachability of the license server
+	licenseProbePodName = "nvidia-license-server-probe"
+	// licenseProbeEndpointAnnotationKey records the license server endpoint checked by the probe pod
+	licenseProbeEndpointAnnotationKey = "nvidia.com/license-server-endpoint"
+	// licenseProbePollInterval is the period the outcome of a running probe pod is polled at
+	licenseProbePollInterval = 15 * time.Second
+	// defaultLicenseServerPort is the port of the legacy license server when gridd.conf does not set ServerPort
+	defaultLicenseServerPort = "7070"
+)
+
+// reconcileLicenseMonitor runs the license probe pod on a GPU node once per monitor interval, and reports
+// whether the probe pod connected to the license server through the LicenseServerUnreachable condition and
+// the license server metric. It returns the time remaining until the next check or poll of the probe pod, or
+// zero when the license server is not monitored.
+func (n ClusterPolicyController) reconcileLicenseMonitor(ctx context.Context, now time.Time) (time.Duration, error) {
+	driver := &n.singleton.Spec.Driver
+	key := types.NamespacedName{Namespace: n.operatorNamespace, Name: licenseProbePodName}
+	if !driver.IsEnabled() || driver.UseNvidiaDriverCRDType() || !driver.LicensingConfig.IsMonitorEnabled() {
+		n.operatorMetrics.setLicenseServerReachable(licenseServerUnknown)
+		if err := n.deleteLicenseProbePod(ctx, key); err != nil {
+			return 0, err
+		}
+		return 0, n.updateLicenseServerCondition(ctx, nil)
+	}
+
+	endpoint, err := n.getLicenseServerEndpoint(ctx)
+	if err != nil {
+		n.operatorMetrics.setLicenseServerReachable(licenseServerUnknown)
+		return 0, n.updateLicenseServerCondition(ctx, &metav1.Condition{
+			Type:    conditions.LicenseServerUnreachable,
+			Status:  metav1.ConditionUnknown,
+			Reason:  conditions.LicenseServerEndpointUnknown,
+			Message: err.Error(),
+		})
+	}
+
+	interval := driver.LicensingConfig.Monitor.GetInterval()
+	pod := &corev1.Pod{}
+	err = n.client.Get(ctx, key, pod)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return 0, fmt.Errorf("failed to get the license probe pod: %w", err)
+	}
+	if err == nil {
+		// the outcome of a probe of another license server is not reported
+		if pod.Annotations[licenseProbeEndpointAnnotationKey] == endpoint {
+			nextCheck := pod.CreationTimestamp.Add(interval).Sub(now)
+			switch pod.Status.Phase {
+			case corev1.PodSucceeded, corev1.PodFailed:
+				if err := n.reportLicenseProbe(ctx, pod, endpoint); err != nil {
+					return 0, err
+				}
+				if nextCheck > 0 {
+					return nextCheck, nil
+				}
+			default:
+				// a probe pod which does not complete within the interval, e.g. as it cannot be scheduled,
+				// is replaced
+				if nextCheck > 0 {
+					return min(nextCheck, licenseProbePollInterval), nil
+				}
+			}
+		}
+		if err := n.deleteLicenseProbePod(ctx, key); err != nil {
+			return 0, err
+		}
+	}
+
+	if !n.hasGPUNodes {
+		return 0, nil
+	}
+	pod, err = n.newLicenseProbePod(key, endpoint)
+	if err != nil {
+		return 0, err
+	}
+	if err := controllerutil.SetControllerReference(n.singleton, pod, n.scheme); err != nil {
+		return 0, err
+	}
+	if err := n.client.Create(ctx, pod); err != nil && !apierrors.IsAlreadyExists(err) {
+		return 0, fmt.Errorf("failed to create the license probe pod: %w", err)
+	}
+	return licenseProbePollInterval, nil
+}
+
+// reportLicenseProbe reports the outcome of the completed license probe pod
+func (n ClusterPolicyController) reportLicenseProbe(ctx context.Context, pod *corev1.Pod, endpoint string) error {
+	if pod.Status.Phase == corev1.PodSucceeded {
+		n.operatorMetrics.setLicenseServerReachable(licenseServerReachable)
+		return n.updateLicenseServerCondition(ctx, &metav1.Condition{
+			Type:    conditions.LicenseServerUnreachable,
+			Status:  metav1.ConditionFalse,
+			Reason:  conditions.LicenseServerReachable,
+			Message: fmt.Sprintf("License server %s reachable from node %s", endpoint, pod.Spec.NodeName),
+		})
+	}
+
+	message := fmt.Sprintf("License server %s unreachable from node %s", endpoint, pod.Spec.NodeName)
+	for _, status := range pod.Status.ContainerStatuses {
+		if terminated := status.State.Terminated; terminated != nil && terminated.Message != "" {
+			message = fmt.Sprintf("%s: %s", message, strings.TrimSpace(terminated.Message))
+		}
+	}
+	n.operatorMetrics.setLicenseServerReachable(licenseServerUnreachable)
+	return n.updateLicenseServerCondition(ctx, &metav1.Condition{
+		Type:    conditions.LicenseServerUnreachable,
+		Status:  metav1.ConditionTrue,
+		Reason:  conditions.LicenseServerProbeFailed,
+		Message: message,
+	})
+}
+
+// getLicenseServerEndpoint returns the endpoint of the monitor, or the license server set by the gridd.conf of
+// the licensing configuration
+func (n ClusterPolicyController) getLicenseServerEndpoint(ctx context.Context) (string, error) {
+	licensing := n.singleton.Spec.Driver.LicensingConfig
+	if endpoint := licensing.Monitor.Endpoint; endpoint != "" {
+		if _, _, err := net.SplitHostPort(endpoint); err != nil {
+			return "", fmt.Errorf("invalid license server endpoint %q: %w", endpoint, err)
+		}
+		return endpoint, nil
+	}
+	if licensing.IsNLSEnabled() {
+		return "", fmt.Errorf("driver.licensingConfig.monitor.endpoint must be set to monitor the NLS license server")
+	}
+
+	key := types.NamespacedName{Namespace: n.operatorNamespace}
+	var griddConf string
+	switch {
+	case licensing.SecretName != "":
+		key.Name = licensing.SecretName
+		secret := &corev1.Secret{}
+		if err := n.client.Get(ctx, key, secret); err != nil {
+			return "", fmt.Errorf("failed to get the licensing Secret %s: %w", key, err)
+		}
+		griddConf = string(secret.Data[consts.VGPULicensingFileName])
+	case licensing.ConfigMapName != "":
+		key.Name = licensing.ConfigMapName
+		cm := &corev1.ConfigMap{}
+		if err := n.client.Get(ctx, key, cm); err != nil {
+			return "", fmt.Errorf("failed to get the licensing ConfigMap %s: %w", key, err)
+		}
+		griddConf = cm.Data[consts.VGPULicensingFileName]
+	default:
+		return "", fmt.Errorf("driver.licensingConfig.monitor.endpoint must be set when no licensing configuration is set")
+	}
+
+	endpoint := parseGriddConfServer(griddConf)
+	if endpoint == "" {
+		return "", fmt.Errorf("%s of %s does not set ServerAddress, driver.licensingConfig.monitor.endpoint must be set", consts.VGPULicensingFileName, key)
+	}
+	return endpoint, nil
+}
+
+// parseGriddConfServer returns the host:port of the license server set by the ServerAddress and ServerPort of
+// the given gridd.conf, or an empty string if ServerAddress is not set
+func parseGriddConfServer(griddConf string) string {
+	var address string
+	port := defaultLicenseServerPort
+	scanner := bufio.NewScanner(strings.NewReader(griddConf))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "ServerAddress":
+			address = strings.TrimSpace(value)
+		case "ServerPort":
+			if value = strings.TrimSpace(value); value != "" {
+				port = value
+			}
+		}
+	}
+	if address == "" {
+		return ""
+	}
+	return net.JoinHostPort(address, port)
+}
+
+// newLicenseProbePod returns the pod checking the reachability of the license server from a GPU node, which
+// runs the license-server component of the validator on the host network like the vGPU licensing daemon
+func (n ClusterPolicyController) newLicenseProbePod(key types.NamespacedName, endpoint string) (*corev1.Pod, error) {
+	spec := &n.singleton.Spec
+	image, err := gpuv1.ImagePath(&spec.Validator)
+	if err != nil {
+		return nil, err
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        key.Name,
+			Namespace:   key.Namespace,
+			Labels:      map[string]string{appLabelKey: licenseProbePodName},
+			Annotations: map[string]string{licenseProbeEndpointAnnotationKey: endpoint},
+		},
+		Spec: corev1.PodSpec{
+			NodeSelector:                 map[string]string{commonGPULabelKey: commonGPULabelValue},
+			RestartPolicy:                corev1.RestartPolicyNever,
+			HostNetwork:                  true,
+			DNSPolicy:                    corev1.DNSClusterFirstWithHostNet,
+			AutomountServiceAccountToken: ptr.To(false),
+			Tolerations:                  spec.Daemonsets.Tolerations,
+			PriorityClassName:            spec.Daemonsets.PriorityClassName,
+			Containers: []corev1.Container{{
+				Name:                     "license-server-probe",
+				Image:                    image,
+				ImagePullPolicy:          gpuv1.ImagePullPolicy(spec.Validator.ImagePullPolicy),
+				Command:                  []string{"nvidia-validator"},
+				Env:                      []corev1.EnvVar{{Name: "COMPONENT", Value: "license-server"}, {Name: "LICENSE_SERVER_ENDPOINT", Value: endpoint}},
+				TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+			}},
+		},
+	}
+	addPullSecrets(&pod.Spec, slices.Concat(n.pullSecrets, spec.Validator.ImagePullSecrets))
+	return pod, nil
+}
+
+// deleteLicenseProbePod deletes the license probe pod if it exists
+func (n ClusterPolicyController) deleteLicenseProbePod(ctx context.Context, key types.NamespacedName) error {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}}
+	if err := n.client.Delete(ctx, pod); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete the license probe pod: %w", err)
+	}
+	return nil
+}
+
+// updateLicenseServerCondition sets the LicenseServerUnreachable condition in the ClusterPolicy status, or
+// removes it when the condition is nil
+func (n ClusterPolicyController) updateLicenseServerCondition(ctx context.Context, condition *metav1.Condition) error {
+	// Fetch latest instance and update status to avoid version mismatch
+	instance := &gpuv1.ClusterPolicy{}
+	if err := n.client.Get(ctx, types.NamespacedName{Name: n.singleton.Name}, instance); err != nil {
+		return fmt.Errorf("failed to get ClusterPolicy instance for status update: %w", err)
+	}
+	conditionsBefore := slices.Clone(instance.Status.Conditions)
+	if condition != nil {
+		meta.SetStatusCondition(&instance.Status.Conditions, *condition)
+	} else {
+		meta.RemoveStatusCondition(&instance.Status.Conditions, conditions.LicenseServerUnreachable)
+	}
+	if equality.Semantic.DeepEqual(instance.Status.Conditions, conditionsBefore) {
+		return nil
+	}
+	if err := n.client.Status().Update(ctx, instance); err != nil {
+		return fmt.Errorf("failed to update ClusterPolicy license server condition: %w", err)
+	}
+	return nil
+}
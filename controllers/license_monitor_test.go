@@ -0,0 +1,159 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+func newLicenseMonitorTestController(t *testing.T, cp *gpuv1.ClusterPolicy, objects ...client.Object) *ClusterPolicyController {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+	return &ClusterPolicyController{
+		ctx:               context.Background(),
+		singleton:         cp,
+		client:            fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objects, cp)...).WithStatusSubresource(cp).Build(),
+		scheme:            scheme,
+		logger:            logr.Discard(),
+		operatorNamespace: "test-ns",
+		hasGPUNodes:       true,
+	}
+}
+
+func TestParseGriddConfServer(t *testing.T) {
+	require.Equal(t, "license.example.com:7070", parseGriddConfServer("# comment\nServerAddress=license.example.com\nFeatureType=1\n"))
+	require.Equal(t, "10.0.0.1:8080", parseGriddConfServer("ServerAddress = 10.0.0.1\nServerPort = 8080\n"))
+	require.Empty(t, parseGriddConfServer("#ServerAddress=license.example.com\nFeatureType=1\n"))
+}
+
+func TestReconcileLicenseMonitor(t *testing.T) {
+	cp := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy", UID: "uid"},
+		Spec: gpuv1.ClusterPolicySpec{
+			Validator: gpuv1.ValidatorSpec{Repository: "nvcr.io/nvidia/cloud-native", Image: "gpu-operator-validator", Version: "v1.0.0"},
+			Driver: gpuv1.DriverSpec{
+				LicensingConfig: &gpuv1.DriverLicensingConfigSpec{
+					SecretName: "licensing",
+					Monitor:    &gpuv1.LicenseServerMonitorSpec{Enabled: ptr.To(true), Interval: &metav1.Duration{Duration: 10 * time.Minute}},
+				},
+			},
+		},
+	}
+	licensing := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "licensing", Namespace: "test-ns"},
+		Data:       map[string][]byte{"gridd.conf": []byte("ServerAddress=license.example.com\nServerPort=7070\n")},
+	}
+	n := newLicenseMonitorTestController(t, cp, licensing)
+	ctx := context.Background()
+	// the creation timestamps have a second precision
+	now := time.Now().Truncate(time.Second)
+	key := types.NamespacedName{Namespace: "test-ns", Name: licenseProbePodName}
+
+	requeueAfter, err := n.reconcileLicenseMonitor(ctx, now)
+	require.NoError(t, err)
+	require.Equal(t, licenseProbePollInterval, requeueAfter)
+	pod := &corev1.Pod{}
+	require.NoError(t, n.client.Get(ctx, key, pod))
+	require.Equal(t, "license.example.com:7070", pod.Annotations[licenseProbeEndpointAnnotationKey])
+	require.Contains(t, pod.Spec.Containers[0].Env, corev1.EnvVar{Name: "LICENSE_SERVER_ENDPOINT", Value: "license.example.com:7070"})
+	require.Contains(t, pod.Spec.Containers[0].Env, corev1.EnvVar{Name: "COMPONENT", Value: "license-server"})
+
+	pod.CreationTimestamp = metav1.NewTime(now)
+	pod.Spec.NodeName = "node-a"
+	require.NoError(t, n.client.Update(ctx, pod))
+	pod.Status.Phase = corev1.PodFailed
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: "dial tcp: i/o timeout\n"}},
+	}}
+	require.NoError(t, n.client.Status().Update(ctx, pod))
+
+	requeueAfter, err = n.reconcileLicenseMonitor(ctx, now.Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, 9*time.Minute, requeueAfter)
+	updated := &gpuv1.ClusterPolicy{}
+	require.NoError(t, n.client.Get(ctx, types.NamespacedName{Name: cp.Name}, updated))
+	condition := meta.FindStatusCondition(updated.Status.Conditions, conditions.LicenseServerUnreachable)
+	require.NotNil(t, condition)
+	require.Equal(t, metav1.ConditionTrue, condition.Status)
+	require.Equal(t, conditions.LicenseServerProbeFailed, condition.Reason)
+	require.Equal(t, "License server license.example.com:7070 unreachable from node node-a: dial tcp: i/o timeout", condition.Message)
+
+	// the license server is checked again once the interval elapsed
+	_, err = n.reconcileLicenseMonitor(ctx, now.Add(11*time.Minute))
+	require.NoError(t, err)
+	require.NoError(t, n.client.Get(ctx, key, pod))
+	require.Empty(t, pod.Status.Phase)
+
+	// the probe pod and the condition are removed once the monitor is disabled
+	n.singleton.Spec.Driver.LicensingConfig.Monitor.Enabled = ptr.To(false)
+	requeueAfter, err = n.reconcileLicenseMonitor(ctx, now.Add(12*time.Minute))
+	require.NoError(t, err)
+	require.Zero(t, requeueAfter)
+	require.True(t, apierrors.IsNotFound(n.client.Get(ctx, key, pod)))
+	require.NoError(t, n.client.Get(ctx, types.NamespacedName{Name: cp.Name}, updated))
+	require.Nil(t, meta.FindStatusCondition(updated.Status.Conditions, conditions.LicenseServerUnreachable))
+}
+
+func TestLicenseServerEndpointNLS(t *testing.T) {
+	cp := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy"},
+		Spec: gpuv1.ClusterPolicySpec{
+			Driver: gpuv1.DriverSpec{
+				LicensingConfig: &gpuv1.DriverLicensingConfigSpec{
+					SecretName: "licensing",
+					NLSEnabled: ptr.To(true),
+					Monitor:    &gpuv1.LicenseServerMonitorSpec{Enabled: ptr.To(true)},
+				},
+			},
+		},
+	}
+	n := newLicenseMonitorTestController(t, cp)
+	ctx := context.Background()
+
+	requeueAfter, err := n.reconcileLicenseMonitor(ctx, time.Now())
+	require.NoError(t, err)
+	require.Zero(t, requeueAfter)
+	updated := &gpuv1.ClusterPolicy{}
+	require.NoError(t, n.client.Get(ctx, types.NamespacedName{Name: cp.Name}, updated))
+	condition := meta.FindStatusCondition(updated.Status.Conditions, conditions.LicenseServerUnreachable)
+	require.NotNil(t, condition)
+	require.Equal(t, metav1.ConditionUnknown, condition.Status)
+	require.Equal(t, conditions.LicenseServerEndpointUnknown, condition.Reason)
+
+	n.singleton.Spec.Driver.LicensingConfig.Monitor.Endpoint = "dls.example.com:443"
+	endpoint, err := n.getLicenseServerEndpoint(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "dls.example.com:443", endpoint)
+}
@@ -0,0 +1,174 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+// clusterPolicyReference is a ConfigMap or Secret of the operator namespace referenced by the ClusterPolicy and
+// mounted by the operand pods
+type clusterPolicyReference struct {
+	// field is the path of the referencing field in the ClusterPolicy spec
+	field string
+	kind  string
+	name  string
+}
+
+// conditionType returns the type of the MissingReference condition reported when the reference is not found
+func (r clusterPolicyReference) conditionType() string {
+	return conditions.MissingReference + "." + r.field
+}
+
+// getClusterPolicyReferences returns the ConfigMaps and Secrets referenced by the enabled states of the
+// ClusterPolicy. The ConfigMaps rendered by the operator, e.g. the default MIG Manager configuration, are not
+// references.
+func (n ClusterPolicyController) getClusterPolicyReferences() []clusterPolicyReference {
+	spec := &n.singleton.Spec
+	var refs []clusterPolicyReference
+	add := func(field, kind, name string) {
+		if name != "" {
+			refs = append(refs, clusterPolicyReference{field: field, kind: kind, name: name})
+		}
+	}
+
+	if n.isStateEnabled("state-driver") && !spec.Driver.UseNvidiaDriverCRDType() {
+		driver := &spec.Driver
+		if driver.LicensingConfig != nil {
+			add("driver.licensingConfig.configMapName", "ConfigMap", driver.LicensingConfig.ConfigMapName)
+			add("driver.licensingConfig.secretName", "Secret", driver.LicensingConfig.SecretName)
+		}
+		if driver.RepoConfig != nil {
+			add("driver.repoConfig.configMapName", "ConfigMap", driver.RepoConfig.ConfigMapName)
+		}
+		if driver.CertConfig != nil {
+			add("driver.certConfig.name", "ConfigMap", driver.CertConfig.Name)
+		}
+		if driver.KernelModuleConfig != nil {
+			add("driver.kernelModuleConfig.name", "ConfigMap", driver.KernelModuleConfig.Name)
+		}
+		if driver.VirtualTopology != nil {
+			add("driver.virtualTopology.config", "ConfigMap", driver.VirtualTopology.Config)
+		}
+		add("driver.secretEnv", "Secret", driver.SecretEnv)
+	}
+	if n.isStateEnabled("state-vgpu-manager") && spec.VGPUManager.KernelModuleConfig != nil {
+		add("vgpuManager.kernelModuleConfig.name", "ConfigMap", spec.VGPUManager.KernelModuleConfig.Name)
+	}
+	// the plugin config is shared by the device plugin and GFD, and rendered by the operator when the
+	// sharing configuration is managed
+	if (n.isStateEnabled("state-device-plugin") || n.isStateEnabled("gpu-feature-discovery")) &&
+		!spec.DevicePlugin.IsSharingManaged() && spec.DevicePlugin.Config != nil {
+		add("devicePlugin.config.name", "ConfigMap", spec.DevicePlugin.Config.Name)
+	}
+	if n.isStateEnabled("state-mig-manager") {
+		if name, isCustom := gpuv1.GetConfigMapName(spec.MIGManager.Config, MigPartedDefaultConfigMapName); isCustom {
+			add("migManager.config.name", "ConfigMap", name)
+		}
+		if name, isCustom := gpuv1.GetConfigMapName(spec.MIGManager.GPUClientsConfig, MigDefaultGPUClientsConfigMapName); isCustom {
+			add("migManager.gpuClientsConfig.name", "ConfigMap", name)
+		}
+	}
+	if n.isStateEnabled("state-vgpu-device-manager") {
+		if name, isCustom := gpuv1.GetConfigMapName(spec.VGPUDeviceManager.Config, VgpuDMDefaultConfigMapName); isCustom {
+			add("vgpuDeviceManager.config.name", "ConfigMap", name)
+		}
+	}
+	if n.isStateEnabled("state-dcgm-exporter") && spec.DCGMExporter.MetricsConfig != nil {
+		add("dcgmExporter.config.name", "ConfigMap", spec.DCGMExporter.MetricsConfig.Name)
+	}
+	return refs
+}
+
+// reconcileMissingReferences resolves the ConfigMaps and Secrets referenced by the ClusterPolicy before the
+// states are rendered, and reports a MissingReference condition per reference not found, naming the object
+// and its namespace, instead of leaving the operand pods to fail to mount it on every node.
+func (n *ClusterPolicyController) reconcileMissingReferences(ctx context.Context) error {
+	n.missingReferences = nil
+	for _, ref := range n.getClusterPolicyReferences() {
+		var obj client.Object = &corev1.ConfigMap{}
+		if ref.kind == "Secret" {
+			obj = &corev1.Secret{}
+		}
+		err := n.client.Get(ctx, types.NamespacedName{Namespace: n.operatorNamespace, Name: ref.name}, obj)
+		if apierrors.IsNotFound(err) {
+			n.missingReferences = append(n.missingReferences, ref)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get %s %s referenced by %s: %w", ref.kind, ref.name, ref.field, err)
+		}
+	}
+	return n.updateMissingReferenceConditions(ctx)
+}
+
+// getMissingReferences returns the references not found during the current reconciliation, as
+// <field>: <kind> <namespace>/<name>
+func (n ClusterPolicyController) getMissingReferences() []string {
+	missing := make([]string, 0, len(n.missingReferences))
+	for _, ref := range n.missingReferences {
+		missing = append(missing, fmt.Sprintf("%s: %s %s/%s", ref.field, ref.kind, n.operatorNamespace, ref.name))
+	}
+	return missing
+}
+
+// updateMissingReferenceConditions sets the MissingReference condition of every missing reference in the
+// ClusterPolicy status, and removes the conditions of the references found again
+func (n ClusterPolicyController) updateMissingReferenceConditions(ctx context.Context) error {
+	// Fetch latest instance and update status to avoid version mismatch
+	instance := &gpuv1.ClusterPolicy{}
+	if err := n.client.Get(ctx, types.NamespacedName{Name: n.singleton.Name}, instance); err != nil {
+		return fmt.Errorf("failed to get ClusterPolicy instance for status update: %w", err)
+	}
+	conditionsBefore := slices.Clone(instance.Status.Conditions)
+
+	missing := make(map[string]bool, len(n.missingReferences))
+	for _, ref := range n.missingReferences {
+		missing[ref.conditionType()] = true
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    ref.conditionType(),
+			Status:  metav1.ConditionTrue,
+			Reason:  ref.kind + "NotFound",
+			Message: fmt.Sprintf("%s %s/%s referenced by %s not found", ref.kind, n.operatorNamespace, ref.name, ref.field),
+		})
+	}
+	instance.Status.Conditions = slices.DeleteFunc(instance.Status.Conditions, func(c metav1.Condition) bool {
+		return strings.HasPrefix(c.Type, conditions.MissingReference+".") && !missing[c.Type]
+	})
+
+	if equality.Semantic.DeepEqual(instance.Status.Conditions, conditionsBefore) {
+		return nil
+	}
+	if err := n.client.Status().Update(ctx, instance); err != nil {
+		return fmt.Errorf("failed to update ClusterPolicy missing reference conditions: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+func newMissingReferencesTestController(cp *gpuv1.ClusterPolicy, objects ...client.Object) *ClusterPolicyController {
+	return &ClusterPolicyController{
+		ctx:               context.Background(),
+		singleton:         cp,
+		client:            fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(append(objects, cp)...).WithStatusSubresource(cp).Build(),
+		logger:            logr.Discard(),
+		operatorNamespace: "test-ns",
+	}
+}
+
+func TestGetClusterPolicyReferences(t *testing.T) {
+	spec := gpuv1.ClusterPolicySpec{
+		Driver: gpuv1.DriverSpec{
+			LicensingConfig: &gpuv1.DriverLicensingConfigSpec{SecretName: "licensing"},
+			RepoConfig:      &gpuv1.DriverRepoConfigSpec{ConfigMapName: "repo"},
+			SecretEnv:       "driver-env",
+		},
+		DevicePlugin: gpuv1.DevicePluginSpec{Config: &gpuv1.DevicePluginConfig{Name: "plugin-config"}},
+		MIGManager: gpuv1.MIGManagerSpec{
+			Enabled: ptr.To(true),
+			Config:  &gpuv1.MIGPartedConfigSpec{Name: MigPartedDefaultConfigMapName},
+		},
+		DCGMExporter: gpuv1.DCGMExporterSpec{Enabled: ptr.To(false), MetricsConfig: &gpuv1.DCGMExporterMetricsConfig{Name: "metrics"}},
+	}
+	n := ClusterPolicyController{singleton: &gpuv1.ClusterPolicy{Spec: spec}}
+
+	require.Equal(t, []clusterPolicyReference{
+		{field: "driver.licensingConfig.secretName", kind: "Secret", name: "licensing"},
+		{field: "driver.repoConfig.configMapName", kind: "ConfigMap", name: "repo"},
+		{field: "driver.secretEnv", kind: "Secret", name: "driver-env"},
+		{field: "devicePlugin.config.name", kind: "ConfigMap", name: "plugin-config"},
+	}, n.getClusterPolicyReferences())
+
+	// the driver references are resolved by the NVIDIADriver controller when the driver is managed by NVIDIADriver
+	n.singleton.Spec.Driver.UseNvidiaDriverCRD = ptr.To(true)
+	n.singleton.Spec.DevicePlugin.Config = nil
+	require.Empty(t, n.getClusterPolicyReferences())
+}
+
+func TestReconcileMissingReferences(t *testing.T) {
+	cp := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy"},
+		Spec: gpuv1.ClusterPolicySpec{
+			Driver: gpuv1.DriverSpec{
+				LicensingConfig: &gpuv1.DriverLicensingConfigSpec{SecretName: "licensing"},
+				CertConfig:      &gpuv1.DriverCertConfigSpec{Name: "certs"},
+			},
+		},
+	}
+	certs := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "certs", Namespace: "test-ns"}}
+	n := newMissingReferencesTestController(cp, certs)
+	ctx := context.Background()
+
+	require.NoError(t, n.reconcileMissingReferences(ctx))
+	require.Equal(t, []string{"driver.licensingConfig.secretName: Secret test-ns/licensing"}, n.getMissingReferences())
+
+	updated := &gpuv1.ClusterPolicy{}
+	require.NoError(t, n.client.Get(ctx, types.NamespacedName{Name: cp.Name}, updated))
+	condition := meta.FindStatusCondition(updated.Status.Conditions, conditions.MissingReference+".driver.licensingConfig.secretName")
+	require.NotNil(t, condition)
+	require.Equal(t, metav1.ConditionTrue, condition.Status)
+	require.Equal(t, "SecretNotFound", condition.Reason)
+	require.Equal(t, "Secret test-ns/licensing referenced by driver.licensingConfig.secretName not found", condition.Message)
+	require.Nil(t, meta.FindStatusCondition(updated.Status.Conditions, conditions.MissingReference+".driver.certConfig.name"))
+
+	require.NoError(t, n.client.Create(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "licensing", Namespace: "test-ns"}}))
+	require.NoError(t, n.client.Delete(ctx, certs))
+	require.NoError(t, n.reconcileMissingReferences(ctx))
+	require.Equal(t, []string{"driver.certConfig.name: ConfigMap test-ns/certs"}, n.getMissingReferences())
+
+	require.NoError(t, n.client.Get(ctx, types.NamespacedName{Name: cp.Name}, updated))
+	require.Nil(t, meta.FindStatusCondition(updated.Status.Conditions, conditions.MissingReference+".driver.licensingConfig.secretName"))
+	condition = meta.FindStatusCondition(updated.Status.Conditions, conditions.MissingReference+".driver.certConfig.name")
+	require.NotNil(t, condition)
+	require.Equal(t, "ConfigMapNotFound", condition.Reason)
+}
@@ -0,0 +1,82 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	gpulabels "github.com/NVIDIA/gpu-operator/pkg/labels"
+)
+
+// mockGPULabelKeys are the labels set on the nodes advertising mock GPUs
+var mockGPULabelKeys = []string{gpulabels.GPUMock, gpulabels.GPUProduct, gpulabels.GPUCount, gpulabels.GPUMemory}
+
+// isMockGPUNode returns true if the node with the given labels advertises the mock GPUs of the spec, i.e. the
+// mock GPUs are enabled and the node, without GPU, is selected by the node selector of the mock GPUs
+func isMockGPUNode(nodeLabels map[string]string, mockGPUs *gpuv1.MockGPUsSpec) bool {
+	if !mockGPUs.IsEnabled() || hasCommonGPULabel(nodeLabels) || hasGPULabels(nodeLabels) {
+		return false
+	}
+	return labels.SelectorFromSet(mockGPUs.NodeSelector).Matches(labels.Set(nodeLabels))
+}
+
+// reconcileMockGPUs removes the mock GPUs from the nodes no longer advertising them, e.g. once the mock GPUs
+// are disabled. The mock GPUs pods do not clean up the nodes when they are deleted, as the node would advertise
+// the GPUs again on a restart of the pod. The GPU labels of the nodes found with GPU are left to GPU Feature
+// Discovery.
+func (n ClusterPolicyController) reconcileMockGPUs(ctx context.Context) error {
+	list := &corev1.NodeList{}
+	if err := n.client.List(ctx, list, client.MatchingLabels{gpulabels.GPUMock: "true"}); err != nil {
+		return fmt.Errorf("unable to list the nodes with mock GPUs: %w", err)
+	}
+
+	mockGPUs := n.singleton.Spec.Devel.GetMockGPUs()
+	for i := range list.Items {
+		node := &list.Items[i]
+		if isMockGPUNode(node.Labels, mockGPUs) {
+			continue
+		}
+
+		n.logger.Info("Removing the mock GPUs of node", "NodeName", node.Name)
+		removedLabels := mockGPULabelKeys
+		if hasCommonGPULabel(node.Labels) || hasGPULabels(node.Labels) {
+			removedLabels = []string{gpulabels.GPUMock}
+		} else {
+			original := node.DeepCopy()
+			delete(node.Status.Capacity, gpuResourceName)
+			delete(node.Status.Allocatable, gpuResourceName)
+			if err := n.client.Status().Patch(ctx, node, client.MergeFrom(original)); err != nil {
+				return fmt.Errorf("unable to remove the mock GPUs of node %s: %w", node.Name, err)
+			}
+		}
+
+		original := node.DeepCopy()
+		for _, key := range removedLabels {
+			delete(node.Labels, key)
+		}
+		if err := n.client.Patch(ctx, node, client.MergeFrom(original)); err != nil {
+			return fmt.Errorf("unable to remove the mock GPU labels of node %s: %w", node.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	gpulabels "github.com/NVIDIA/gpu-operator/pkg/labels"
+)
+
+func mockGPUNode(name string, extraLabels map[string]string) *corev1.Node {
+	nodeLabels := map[string]string{
+		gpulabels.GPUMock:    "true",
+		gpulabels.GPUProduct: "NVIDIA-Mock-GPU",
+		gpulabels.GPUCount:   "2",
+		gpulabels.GPUMemory:  "16384",
+	}
+	for key, value := range extraLabels {
+		nodeLabels[key] = value
+	}
+	gpus := resource.MustParse("2")
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nodeLabels},
+		Status: corev1.NodeStatus{
+			Capacity:    corev1.ResourceList{gpuResourceName: gpus},
+			Allocatable: corev1.ResourceList{gpuResourceName: gpus},
+		},
+	}
+}
+
+func TestIsMockGPUNode(t *testing.T) {
+	mockGPUs := &gpuv1.MockGPUsSpec{Enabled: ptr.To(true)}
+	require.True(t, isMockGPUNode(map[string]string{"pool": "dev"}, mockGPUs))
+	require.False(t, isMockGPUNode(map[string]string{commonGPULabelKey: commonGPULabelValue}, mockGPUs))
+	require.False(t, isMockGPUNode(map[string]string{"feature.node.kubernetes.io/pci-10de.present": "true"}, mockGPUs))
+	require.False(t, isMockGPUNode(map[string]string{"pool": "dev"}, nil))
+
+	mockGPUs.NodeSelector = map[string]string{"pool": "dev"}
+	require.True(t, isMockGPUNode(map[string]string{"pool": "dev"}, mockGPUs))
+	require.False(t, isMockGPUNode(map[string]string{"pool": "prod"}, mockGPUs))
+}
+
+func TestReconcileMockGPUs(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(s))
+	require.NoError(t, gpuv1.AddToScheme(s))
+	nodes := []client.Object{
+		mockGPUNode("selected", map[string]string{"pool": "dev"}),
+		mockGPUNode("unselected", map[string]string{"pool": "prod"}),
+		mockGPUNode("gpu-node", map[string]string{commonGPULabelKey: commonGPULabelValue}),
+	}
+	cp := &gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{Devel: &gpuv1.DevelSpec{MockGPUs: &gpuv1.MockGPUsSpec{
+		Enabled:      ptr.To(true),
+		NodeSelector: map[string]string{"pool": "dev"},
+	}}}}
+	n := ClusterPolicyController{
+		ctx:       context.Background(),
+		client:    fake.NewClientBuilder().WithScheme(s).WithObjects(nodes...).WithStatusSubresource(&corev1.Node{}).Build(),
+		singleton: cp,
+		logger:    logr.Discard(),
+	}
+	getNode := func(name string) *corev1.Node {
+		node := &corev1.Node{}
+		require.NoError(t, n.client.Get(context.Background(), client.ObjectKey{Name: name}, node))
+		return node
+	}
+
+	require.NoError(t, n.reconcileMockGPUs(context.Background()))
+
+	// the mock GPUs of the selected node are kept
+	selected := getNode("selected")
+	require.Equal(t, "true", selected.Labels[gpulabels.GPUMock])
+	require.Contains(t, selected.Status.Capacity, corev1.ResourceName(gpuResourceName))
+
+	// the mock GPUs of the node no longer selected are removed
+	unselected := getNode("unselected")
+	require.Equal(t, map[string]string{"pool": "prod"}, unselected.Labels)
+	require.NotContains(t, unselected.Status.Capacity, corev1.ResourceName(gpuResourceName))
+	require.NotContains(t, unselected.Status.Allocatable, corev1.ResourceName(gpuResourceName))
+
+	// the GPUs of the GPU node are left to GPU Feature Discovery and the device plugin
+	gpuNode := getNode("gpu-node")
+	require.NotContains(t, gpuNode.Labels, gpulabels.GPUMock)
+	require.Equal(t, "NVIDIA-Mock-GPU", gpuNode.Labels[gpulabels.GPUProduct])
+	require.Contains(t, gpuNode.Status.Capacity, corev1.ResourceName(gpuResourceName))
+
+	// the mock GPUs of all nodes are removed once disabled
+	cp.Spec.Devel.MockGPUs.Enabled = ptr.To(false)
+	require.NoError(t, n.reconcileMockGPUs(context.Background()))
+	selected = getNode("selected")
+	require.Equal(t, map[string]string{"pool": "dev"}, selected.Labels)
+	require.NotContains(t, selected.Status.Capacity, corev1.ResourceName(gpuResourceName))
+}
@@ -0,0 +1,185 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// nodeInventory is the in-memory inventory of the GPU nodes read by the ClusterPolicy controller, so that a
+// reconciliation does not list all the nodes of the cluster. It is maintained from the events of the shared
+// Node informer, which runs on every operator replica regardless of leader election, so that a newly elected
+// leader starts with a warm inventory. The node changes affecting the rendering of the operands are sent to
+// the ClusterPolicy controller on the events channel.
+type nodeInventory struct {
+	mu sync.RWMutex
+	// gpuNodes holds a copy of the metadata and node info of the nodes labeled with GPUs, keyed by name
+	gpuNodes map[string]*corev1.Node
+	// nfdNodes holds the names of the nodes labeled by NFD
+	nfdNodes map[string]bool
+
+	hasSynced func() bool
+	predicate predicate.TypedPredicate[*corev1.Node]
+	events    chan event.TypedGenericEvent[*corev1.Node]
+}
+
+var _ toolscache.ResourceEventHandler = &nodeInventory{}
+
+func newNodeInventory(p predicate.TypedPredicate[*corev1.Node]) *nodeInventory {
+	return &nodeInventory{
+		gpuNodes:  make(map[string]*corev1.Node),
+		nfdNodes:  make(map[string]bool),
+		predicate: p,
+		// a single pending event is enough, as every event reconciles all ClusterPolicies against the
+		// latest inventory
+		events: make(chan event.TypedGenericEvent[*corev1.Node], 1),
+	}
+}
+
+// synced returns whether the inventory holds all the nodes of the cluster
+func (i *nodeInventory) synced() bool {
+	return i != nil && i.hasSynced != nil && i.hasSynced()
+}
+
+// hasNFDLabels returns whether any node of the cluster is labeled by NFD
+func (i *nodeInventory) hasNFDLabels() bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return len(i.nfdNodes) > 0
+}
+
+// list returns the GPU nodes matching the labels, sorted by name
+func (i *nodeInventory) list(matchingLabels map[string]string) []corev1.Node {
+	selector := labels.SelectorFromSet(matchingLabels)
+
+	i.mu.RLock()
+	nodes := make([]corev1.Node, 0, len(i.gpuNodes))
+	for _, node := range i.gpuNodes {
+		if selector.Matches(labels.Set(node.Labels)) {
+			nodes = append(nodes, *node)
+		}
+	}
+	i.mu.RUnlock()
+
+	sort.Slice(nodes, func(a, b int) bool { return nodes[a].Name < nodes[b].Name })
+	return nodes
+}
+
+func (i *nodeInventory) set(node *corev1.Node) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if hasNFDLabels(node.Labels) {
+		i.nfdNodes[node.Name] = true
+	} else {
+		delete(i.nfdNodes, node.Name)
+	}
+	if !hasCommonGPULabel(node.Labels) {
+		delete(i.gpuNodes, node.Name)
+		return
+	}
+	i.gpuNodes[node.Name] = &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        node.Name,
+			Labels:      maps.Clone(node.Labels),
+			Annotations: maps.Clone(node.Annotations),
+		},
+		Status: corev1.NodeStatus{NodeInfo: node.Status.NodeInfo},
+	}
+}
+
+func (i *nodeInventory) remove(name string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.nfdNodes, name)
+	delete(i.gpuNodes, name)
+}
+
+// notify sends the node change to the ClusterPolicy controller without blocking the informer, dropping the
+// change when another one is pending or when the controller is not started on this replica
+func (i *nodeInventory) notify(node *corev1.Node) {
+	select {
+	case i.events <- event.TypedGenericEvent[*corev1.Node]{Object: node}:
+	default:
+	}
+}
+
+func (i *nodeInventory) OnAdd(obj interface{}, isInInitialList bool) {
+	node, ok := obj.(*corev1.Node)
+	if !ok {
+		return
+	}
+	i.set(node)
+	if i.predicate.Create(event.TypedCreateEvent[*corev1.Node]{Object: node, IsInInitialList: isInInitialList}) {
+		i.notify(node)
+	}
+}
+
+func (i *nodeInventory) OnUpdate(oldObj, newObj interface{}) {
+	oldNode, ok := oldObj.(*corev1.Node)
+	if !ok {
+		return
+	}
+	newNode, ok := newObj.(*corev1.Node)
+	if !ok {
+		return
+	}
+	i.set(newNode)
+	if i.predicate.Update(event.TypedUpdateEvent[*corev1.Node]{ObjectOld: oldNode, ObjectNew: newNode}) {
+		i.notify(newNode)
+	}
+}
+
+func (i *nodeInventory) OnDelete(obj interface{}) {
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	node, ok := obj.(*corev1.Node)
+	if !ok {
+		return
+	}
+	i.remove(node.Name)
+	if i.predicate.Delete(event.TypedDeleteEvent[*corev1.Node]{Object: node}) {
+		i.notify(node)
+	}
+}
+
+// listGPUNodes returns the GPU nodes matching the labels from the node inventory, or from the API server
+// while the inventory is not synced
+func (n ClusterPolicyController) listGPUNodes(matchingLabels map[string]string) ([]corev1.Node, error) {
+	if n.nodesSynced() {
+		return n.nodes.list(matchingLabels), nil
+	}
+
+	selector := client.MatchingLabels{commonGPULabelKey: commonGPULabelValue}
+	maps.Copy(selector, matchingLabels)
+	list := &corev1.NodeList{}
+	if err := n.client.List(n.ctx, list, selector); err != nil {
+		return nil, fmt.Errorf("unable to list GPU nodes: %w", err)
+	}
+	return list.Items, nil
+}
@@ -0,0 +1,119 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newInventoryNode(name string, labels map[string]string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Status: corev1.NodeStatus{
+			NodeInfo: corev1.NodeSystemInfo{ContainerRuntimeVersion: "containerd://1.7.0"},
+		},
+	}
+}
+
+func TestNodeInventory(t *testing.T) {
+	inventory := newNodeInventory(gpuNodePredicate(ctrl.Log.WithName("test")))
+
+	// non-GPU nodes are not held, while their NFD labels are
+	inventory.OnAdd(newInventoryNode("cpu-node", map[string]string{nfdLabelPrefix + "cpu.model.family": "6"}), true)
+	require.True(t, inventory.hasNFDLabels())
+	require.Empty(t, inventory.list(nil))
+	require.Len(t, inventory.events, 0)
+
+	gpuNode := newInventoryNode("gpu-node-b", map[string]string{commonGPULabelKey: commonGPULabelValue, nfdLabelPrefix + "pci-10de.present": "true"})
+	inventory.OnAdd(gpuNode, false)
+	inventory.OnAdd(newInventoryNode("gpu-node-a", map[string]string{commonGPULabelKey: commonGPULabelValue, "zone": "a"}), false)
+	nodes := inventory.list(nil)
+	require.Len(t, nodes, 2)
+	require.Equal(t, "gpu-node-a", nodes[0].Name)
+	require.Equal(t, "containerd://1.7.0", nodes[1].Status.NodeInfo.ContainerRuntimeVersion)
+	require.Equal(t, []string{"gpu-node-a"}, nodeNames(inventory.list(map[string]string{"zone": "a"})))
+
+	// the pending event coalesces the node changes
+	require.Len(t, inventory.events, 1)
+	<-inventory.events
+
+	// the inventory holds a copy of the node labels
+	gpuNode.Labels["zone"] = "b"
+	require.Empty(t, inventory.list(map[string]string{"zone": "b"}))
+
+	// a label change which does not affect the operands updates the inventory without notifying the controller
+	updated := gpuNode.DeepCopy()
+	updated.Labels["zone"] = "c"
+	inventory.OnUpdate(gpuNode, updated)
+	require.Equal(t, []string{"gpu-node-b"}, nodeNames(inventory.list(map[string]string{"zone": "c"})))
+	require.Len(t, inventory.events, 0)
+
+	disabled := updated.DeepCopy()
+	disabled.Labels[commonOperandsLabelKey] = "false"
+	inventory.OnUpdate(updated, disabled)
+	require.Len(t, inventory.events, 1)
+	<-inventory.events
+
+	inventory.OnDelete(toolscache.DeletedFinalStateUnknown{Key: "gpu-node-b", Obj: disabled})
+	inventory.OnDelete(newInventoryNode("cpu-node", nil))
+	require.False(t, inventory.hasNFDLabels())
+	require.Equal(t, []string{"gpu-node-a"}, nodeNames(inventory.list(nil)))
+}
+
+func TestListGPUNodes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newInventoryNode("gpu-node", map[string]string{commonGPULabelKey: commonGPULabelValue}),
+		newInventoryNode("cpu-node", nil),
+	).Build()
+
+	inventory := newNodeInventory(gpuNodePredicate(ctrl.Log.WithName("test")))
+	n := ClusterPolicyController{ctx: context.Background(), client: c, nodes: inventory}
+
+	// the nodes are listed from the API server until the inventory is synced
+	nodes, err := n.listGPUNodes(nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"gpu-node"}, nodeNames(nodes))
+
+	inventory.hasSynced = func() bool { return true }
+	nodes, err = n.listGPUNodes(nil)
+	require.NoError(t, err)
+	require.Empty(t, nodes)
+
+	inventory.OnAdd(newInventoryNode("gpu-node", map[string]string{commonGPULabelKey: commonGPULabelValue}), true)
+	nodes, err = n.listGPUNodes(nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"gpu-node"}, nodeNames(nodes))
+}
+
+func nodeNames(nodes []corev1.Node) []string {
+	names := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		names = append(names, node.Name)
+	}
+	return names
+}
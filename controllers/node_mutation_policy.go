@@ -0,0 +1,104 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"github.com/NVIDIA/gpu-operator/internal/nodepolicy"
+)
+
+// validatorBinary is the binary of the validator image, run by the operands mutating their node on behalf of the
+// operator
+const validatorBinary = "nvidia-validator"
+
+// validatorClusterRoles are the ClusterRoles of the operands whose node is only mutated by the validator
+var validatorClusterRoles = map[string]bool{
+	"nvidia-operator-validator": true,
+	"nvidia-gpu-health-check":   true,
+	"nvidia-image-prepull":      true,
+	"nvidia-nvlink-fabric":      true,
+	MockGPUsName:                true,
+}
+
+// nodeWriteVerbs are the verbs of the RBAC rules mutating the nodes
+var nodeWriteVerbs = []string{"patch", "update", "*"}
+
+// applyNodeMutationPolicy passes the node mutation policy to the validator containers of the pod, which restrict
+// the mutations of their node to the fields allowed by the policy
+func applyNodeMutationPolicy(podSpec *corev1.PodSpec, policy nodepolicy.Policy) {
+	if policy == "" {
+		return
+	}
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			if runsValidator(&containers[i]) {
+				setContainerEnv(&containers[i], nodepolicy.EnvName, string(policy))
+			}
+		}
+	}
+}
+
+// runsValidator returns true if the container runs the validator, either as its command or through a shell
+func runsValidator(container *corev1.Container) bool {
+	for _, arg := range append(slices.Clone(container.Command), container.Args...) {
+		if arg == validatorBinary || strings.HasPrefix(arg, validatorBinary+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// applyNodeMutationPolicyRules removes the node write permissions forbidden by the node mutation policy from the
+// ClusterRoles of the validator operands: the status of the nodes is only written when the policy allows all
+// mutations, and the nodes are not written at all under the none policy
+func applyNodeMutationPolicyRules(obj *rbacv1.ClusterRole, policy nodepolicy.Policy) {
+	if !validatorClusterRoles[obj.Name] || policy.AllowsSpec() {
+		return
+	}
+	forbidden := func(resource string) bool {
+		return resource == "nodes/status" || (resource == "nodes" && !policy.AllowsMetadata())
+	}
+
+	var rules []rbacv1.PolicyRule
+	for _, rule := range obj.Rules {
+		if !slices.Contains(rule.APIGroups, "") || !slices.ContainsFunc(rule.Resources, forbidden) {
+			rules = append(rules, rule)
+			continue
+		}
+		// the rule is split between the forbidden resources, left with the read verbs, and the other resources
+		restricted := *rule.DeepCopy()
+		restricted.Resources = slices.DeleteFunc(slices.Clone(rule.Resources), func(resource string) bool {
+			return !forbidden(resource)
+		})
+		restricted.Verbs = slices.DeleteFunc(slices.Clone(rule.Verbs), func(verb string) bool {
+			return slices.Contains(nodeWriteVerbs, verb)
+		})
+		if len(restricted.Verbs) > 0 {
+			rules = append(rules, restricted)
+		}
+		if others := slices.DeleteFunc(slices.Clone(rule.Resources), forbidden); len(others) > 0 {
+			rule.Resources = others
+			rules = append(rules, rule)
+		}
+	}
+	obj.Rules = rules
+}
@@ -0,0 +1,112 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/NVIDIA/gpu-operator/internal/nodepolicy"
+)
+
+func TestApplyNodeMutationPolicy(t *testing.T) {
+	newPodSpec := func() *corev1.PodSpec {
+		return &corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{Name: "driver-validation", Command: []string{"sh", "-c"}, Args: []string{"nvidia-validator"}},
+			},
+			Containers: []corev1.Container{
+				{Name: "health-check", Command: []string{"nvidia-validator"}},
+				{Name: "nvidia-device-plugin", Command: []string{"nvidia-device-plugin"}},
+			},
+		}
+	}
+
+	podSpec := newPodSpec()
+	applyNodeMutationPolicy(podSpec, "")
+	require.Equal(t, newPodSpec(), podSpec)
+
+	applyNodeMutationPolicy(podSpec, nodepolicy.LabelsOnly)
+	policyEnv := []corev1.EnvVar{{Name: nodepolicy.EnvName, Value: "labels-only"}}
+	require.Equal(t, policyEnv, podSpec.InitContainers[0].Env)
+	require.Equal(t, policyEnv, podSpec.Containers[0].Env)
+	require.Empty(t, podSpec.Containers[1].Env)
+}
+
+func TestApplyNodeMutationPolicyRules(t *testing.T) {
+	newClusterRole := func(name string) *rbacv1.ClusterRole {
+		return &rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"nodes"}, Verbs: []string{"get", "list", "watch", "patch"}},
+				{APIGroups: []string{""}, Resources: []string{"nodes/status", "pods"}, Verbs: []string{"patch"}},
+				{APIGroups: []string{"nvidia.com"}, Resources: []string{"clusterpolicies/finalizers"}, Verbs: []string{"update"}},
+			},
+		}
+	}
+
+	testCases := []struct {
+		description   string
+		name          string
+		policy        nodepolicy.Policy
+		expectedRules []rbacv1.PolicyRule
+	}{
+		{
+			description:   "all mutations allowed",
+			name:          "nvidia-operator-validator",
+			policy:        nodepolicy.LabelsAndTaints,
+			expectedRules: newClusterRole("").Rules,
+		},
+		{
+			description: "labels only",
+			name:        "nvidia-operator-validator",
+			policy:      nodepolicy.LabelsOnly,
+			expectedRules: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"nodes"}, Verbs: []string{"get", "list", "watch", "patch"}},
+				{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"patch"}},
+				{APIGroups: []string{"nvidia.com"}, Resources: []string{"clusterpolicies/finalizers"}, Verbs: []string{"update"}},
+			},
+		},
+		{
+			description: "no mutation allowed",
+			name:        "nvidia-operator-validator",
+			policy:      nodepolicy.None,
+			expectedRules: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"nodes"}, Verbs: []string{"get", "list", "watch"}},
+				{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"patch"}},
+				{APIGroups: []string{"nvidia.com"}, Resources: []string{"clusterpolicies/finalizers"}, Verbs: []string{"update"}},
+			},
+		},
+		{
+			description:   "operand not mutating its node through the validator",
+			name:          "nvidia-mig-manager",
+			policy:        nodepolicy.None,
+			expectedRules: newClusterRole("").Rules,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			obj := newClusterRole(tc.name)
+			applyNodeMutationPolicyRules(obj, tc.policy)
+			require.Equal(t, tc.expectedRules, obj.Rules)
+		})
+	}
+}
@@ -0,0 +1,171 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/NVIDIA/k8s-operator-libs/pkg/upgrade"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+const (
+	containerToolkitAppLabelValue = "nvidia-container-toolkit-daemonset"
+	devicePluginAppLabelValue     = "nvidia-device-plugin-daemonset"
+
+	// driver version labels published by GPU Feature Discovery
+	gfdDriverMajorLabelKey = "nvidia.com/cuda.driver.major"
+	gfdDriverMinorLabelKey = "nvidia.com/cuda.driver.minor"
+	gfdDriverRevLabelKey   = "nvidia.com/cuda.driver.rev"
+
+	// gdsContainerName is the name of the container loading the GPUDirect Storage driver in the driver pods
+	gdsContainerName = "nvidia-fs-ctr"
+)
+
+// getNodeOperandStatuses summarizes, for each GPU node, the installed driver version, the readiness of
+// the toolkit and device plugin pods, the validation result, the driver upgrade state, the state of the
+// nvidia-fs driver when GPUDirect Storage is enabled, the vGPU type profile applied by the vGPU Device
+// Manager when it is enabled and the deadline of the pending operands opt-out, so that the state of a node can be read from the ClusterPolicy status without
+// correlating the operand pods.
+func (n ClusterPolicyController) getNodeOperandStatuses(ctx context.Context) ([]gpuv1.NodeOperandStatus, error) {
+	nodes, err := n.listGPUNodes(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	pods := &corev1.PodList{}
+	err = n.client.List(ctx, pods, client.InNamespace(n.operatorNamespace), client.HasLabels{appLabelKey})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list operand pods: %w", err)
+	}
+
+	// readyPods maps the app label of the ready operand pods to the nodes they run on
+	readyPods := make(map[string]map[string]bool)
+	// gdsLoaded holds the nodes where the nvidia-fs container reports the GPUDirect Storage driver loaded
+	gdsLoaded := make(map[string]bool)
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != "" && isContainerReady(&pod, gdsContainerName) {
+			gdsLoaded[pod.Spec.NodeName] = true
+		}
+		if pod.Spec.NodeName == "" || !isPodConditionTrue(&pod, corev1.PodReady) {
+			continue
+		}
+		app := pod.Labels[appLabelKey]
+		if readyPods[app] == nil {
+			readyPods[app] = make(map[string]bool)
+		}
+		readyPods[app][pod.Spec.NodeName] = true
+	}
+
+	gdsEnabled := n.singleton != nil && n.singleton.Spec.GPUDirectStorage != nil && n.singleton.Spec.GPUDirectStorage.IsEnabled()
+	vgpuDeviceManagerEnabled := n.singleton != nil && n.singleton.Spec.VGPUDeviceManager.IsEnabled()
+	upgradeStateLabel := upgrade.GetUpgradeStateLabelKey()
+	statuses := make([]gpuv1.NodeOperandStatus, 0, len(nodes))
+	for _, node := range nodes {
+		status := gpuv1.NodeOperandStatus{
+			Name:              node.Name,
+			DriverVersion:     getNodeDriverVersion(node.Labels),
+			ToolkitReady:      readyPods[containerToolkitAppLabelValue][node.Name],
+			DevicePluginReady: readyPods[devicePluginAppLabelValue][node.Name],
+			Validated:         readyPods[operatorValidatorAppLabelValue][node.Name],
+			UpgradeState:      node.Labels[upgradeStateLabel],
+		}
+		if gdsEnabled {
+			status.GDSState = getNodeGDSState(node.Labels, gdsLoaded[node.Name])
+		}
+		if vgpuDeviceManagerEnabled {
+			status.VGPUConfig = node.Labels[vgpuConfigLabelKey]
+			status.VGPUConfigState = node.Labels[vgpuConfigStateLabelKey]
+		}
+		if deadline, requested, _ := parseOperandsOptOutDeadline(node.Annotations); requested && time.Now().Before(deadline) {
+			status.OperandsDisableAfter = &metav1.Time{Time: deadline}
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses, nil
+}
+
+// getNodeDriverVersion returns the driver version reported by GPU Feature Discovery, or an empty string
+// when GFD has not labeled the node yet
+func getNodeDriverVersion(labels map[string]string) string {
+	major, minor, rev := labels[gfdDriverMajorLabelKey], labels[gfdDriverMinorLabelKey], labels[gfdDriverRevLabelKey]
+	if major == "" || minor == "" {
+		return ""
+	}
+	if rev == "" {
+		return fmt.Sprintf("%s.%s", major, minor)
+	}
+	return fmt.Sprintf("%s.%s.%s", major, minor, rev)
+}
+
+// getNodeGDSState returns the state of the GPUDirect Storage driver on a node. The nodes found without
+// supported hardware by the GDS hardware detection are reported unsupported.
+func getNodeGDSState(labels map[string]string, loaded bool) gpuv1.GDSState {
+	switch {
+	case labels[consts.GDSCapableLabelKey] == "false":
+		return gpuv1.GDSUnsupported
+	case loaded:
+		return gpuv1.GDSLoaded
+	default:
+		return gpuv1.GDSNotLoaded
+	}
+}
+
+// isContainerReady returns true if the pod has a ready container with the given name
+func isContainerReady(pod *corev1.Pod, name string) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == name {
+			return status.Ready
+		}
+	}
+	return false
+}
+
+func updateNodeOperandStatuses(ctx context.Context, r *ClusterPolicyReconciler, namespacedName types.NamespacedName, nodes []gpuv1.NodeOperandStatus) {
+	// Fetch latest instance and update status to avoid version mismatch
+	instance := &gpuv1.ClusterPolicy{}
+	if err := r.Get(ctx, namespacedName, instance); err != nil {
+		r.Log.Error(err, "Failed to get ClusterPolicy instance for status update")
+		return
+	}
+	if equality.Semantic.DeepEqual(instance.Status.Nodes, nodes) {
+		// node operand states are unchanged
+		return
+	}
+	previous := instance.Status.Nodes
+	instance.Status.Nodes = nodes
+	if err := r.Client.Status().Update(ctx, instance); err != nil {
+		r.Log.Error(err, "Failed to update ClusterPolicy nodes status")
+		return
+	}
+	// the events are notified once the statuses they are observed from are persisted, so that they are not
+	// notified again by the next reconciliation
+	for _, event := range nodeLifecycleEvents(instance.Name, previous, nodes) {
+		r.Notifier.Notify(instance.Spec.Notifications.GetSinks(), event)
+	}
+}
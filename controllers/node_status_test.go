@@ -0,0 +1,177 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/k8s-operator-libs/pkg/upgrade"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+func TestGetNodeOperandStatuses(t *testing.T) {
+	upgrade.SetDriverName("gpu")
+
+	operandPod := func(app string, nodeName string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      app + "-" + nodeName,
+				Namespace: "test-ns",
+				Labels:    map[string]string{appLabelKey: app},
+			},
+			Spec: corev1.PodSpec{NodeName: nodeName},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			},
+		}
+	}
+
+	objects := []client.Object{
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-b", Labels: map[string]string{
+			commonGPULabelKey:                 commonGPULabelValue,
+			gfdDriverMajorLabelKey:            "570",
+			gfdDriverMinorLabelKey:            "124",
+			gfdDriverRevLabelKey:              "06",
+			upgrade.GetUpgradeStateLabelKey(): upgrade.UpgradeStateUpgradeRequired,
+		}}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{
+			commonGPULabelKey: commonGPULabelValue,
+		}}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "cpu-node"}},
+		operandPod(containerToolkitAppLabelValue, "node-b", corev1.ConditionTrue),
+		operandPod(devicePluginAppLabelValue, "node-b", corev1.ConditionTrue),
+		operandPod(operatorValidatorAppLabelValue, "node-b", corev1.ConditionFalse),
+		operandPod(containerToolkitAppLabelValue, "node-a", corev1.ConditionFalse),
+	}
+
+	n := ClusterPolicyController{
+		client:            fake.NewClientBuilder().WithObjects(objects...).Build(),
+		operatorNamespace: "test-ns",
+	}
+	statuses, err := n.getNodeOperandStatuses(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []gpuv1.NodeOperandStatus{
+		{Name: "node-a"},
+		{
+			Name:              "node-b",
+			DriverVersion:     "570.124.06",
+			ToolkitReady:      true,
+			DevicePluginReady: true,
+			UpgradeState:      upgrade.UpgradeStateUpgradeRequired,
+		},
+	}, statuses)
+}
+
+func TestGetNodeOperandStatusesGDSState(t *testing.T) {
+	driverPod := func(nodeName string, gdsReady bool) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nvidia-driver-daemonset-" + nodeName,
+				Namespace: "test-ns",
+				Labels:    map[string]string{appLabelKey: "nvidia-driver-daemonset"},
+			},
+			Spec: corev1.PodSpec{NodeName: nodeName},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "nvidia-driver-ctr", Ready: true},
+					{Name: gdsContainerName, Ready: gdsReady},
+				},
+			},
+		}
+	}
+	gpuNode := func(name string, labels map[string]string) *corev1.Node {
+		labels[commonGPULabelKey] = commonGPULabelValue
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+
+	objects := []client.Object{
+		gpuNode("loaded", map[string]string{consts.GDSCapableLabelKey: "true"}),
+		gpuNode("not-loaded", map[string]string{}),
+		gpuNode("unsupported", map[string]string{consts.GDSCapableLabelKey: "false"}),
+		driverPod("loaded", true),
+		driverPod("not-loaded", false),
+		driverPod("unsupported", true),
+	}
+	n := ClusterPolicyController{
+		client:            fake.NewClientBuilder().WithObjects(objects...).Build(),
+		operatorNamespace: "test-ns",
+		singleton: &gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{
+			GPUDirectStorage: &gpuv1.GPUDirectStorageSpec{Enabled: newBoolPtr(true)},
+		}},
+	}
+
+	statuses, err := n.getNodeOperandStatuses(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []gpuv1.NodeOperandStatus{
+		{Name: "loaded", GDSState: gpuv1.GDSLoaded},
+		{Name: "not-loaded", GDSState: gpuv1.GDSNotLoaded},
+		{Name: "unsupported", GDSState: gpuv1.GDSUnsupported},
+	}, statuses)
+}
+
+func TestGetNodeOperandStatusesVGPUConfig(t *testing.T) {
+	objects := []client.Object{
+		gpuNode("applied", map[string]string{vgpuConfigLabelKey: "a100-4c", vgpuConfigStateLabelKey: "success"}),
+		gpuNode("pending", map[string]string{vgpuConfigLabelKey: "a100-40c", vgpuConfigStateLabelKey: "pending"}),
+		gpuNode("unlabeled", nil),
+	}
+	n := ClusterPolicyController{
+		client:            fake.NewClientBuilder().WithObjects(objects...).Build(),
+		operatorNamespace: "test-ns",
+		singleton: &gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{
+			VGPUDeviceManager: gpuv1.VGPUDeviceManagerSpec{Enabled: newBoolPtr(true)},
+		}},
+	}
+
+	statuses, err := n.getNodeOperandStatuses(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []gpuv1.NodeOperandStatus{
+		{Name: "applied", VGPUConfig: "a100-4c", VGPUConfigState: "success"},
+		{Name: "pending", VGPUConfig: "a100-40c", VGPUConfigState: "pending"},
+		{Name: "unlabeled"},
+	}, statuses)
+}
+
+func TestGetNodeOperandStatusesOperandsOptOut(t *testing.T) {
+	deadline := time.Now().Add(time.Hour).Truncate(time.Second)
+	pending := gpuNode("pending", nil)
+	pending.Annotations = map[string]string{consts.OperandsDisableAfterAnnotationKey: deadline.Format(time.RFC3339)}
+	expired := gpuNode("expired", nil)
+	expired.Annotations = map[string]string{consts.OperandsDisableAfterAnnotationKey: time.Now().Add(-time.Hour).Format(time.RFC3339)}
+	n := ClusterPolicyController{
+		client:            fake.NewClientBuilder().WithObjects(pending, expired, gpuNode("none", nil)).Build(),
+		operatorNamespace: "test-ns",
+	}
+
+	statuses, err := n.getNodeOperandStatuses(context.Background())
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+	require.Nil(t, statuses[0].OperandsDisableAfter)
+	require.Nil(t, statuses[1].OperandsDisableAfter)
+	require.Equal(t, "pending", statuses[2].Name)
+	require.NotNil(t, statuses[2].OperandsDisableAfter)
+	require.True(t, deadline.Equal(statuses[2].OperandsDisableAfter.Time))
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/NVIDIA/k8s-operator-libs/pkg/upgrade"
@@ -28,6 +29,7 @@ import (
 	resourcev1 "k8s.io/api/resource/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -40,8 +42,14 @@ import (
 
 	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
 	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/apistats"
+	"github.com/NVIDIA/gpu-operator/internal/audit"
+	clusterpolicyutil "github.com/NVIDIA/gpu-operator/internal/clusterpolicy"
 	"github.com/NVIDIA/gpu-operator/internal/consts"
+	"github.com/NVIDIA/gpu-operator/internal/kernel"
+	"github.com/NVIDIA/gpu-operator/internal/notification"
 	nvidiadriverutil "github.com/NVIDIA/gpu-operator/internal/nvidiadriver"
+	gpulabels "github.com/NVIDIA/gpu-operator/pkg/labels"
 )
 
 const nodeLabelingControllerSingletonName = "cluster"
@@ -57,6 +65,13 @@ type NodeLabelingReconciler struct {
 	Scheme    *runtime.Scheme
 	Namespace string
 	Log       logr.Logger
+	// APIStats counts the requests of the reconciliations, nothing is counted when nil
+	APIStats *apistats.Tracker
+	// Notifier posts the lifecycle events of the GPU nodes to the sinks of the ClusterPolicy, nothing is posted
+	// when nil
+	Notifier *notification.Notifier
+
+	recorder events.EventRecorder
 }
 
 // nodeLabelingController holds per-reconcile state so that helper methods don't need to
@@ -71,11 +86,22 @@ type nodeLabelingController struct {
 	gpuCluster    *nvidiav1alpha1.GPUCluster
 	defaultMode   consts.GPUAllocationMode
 	logger        logr.Logger
+	recorder      events.EventRecorder
+	notifier      *notification.Notifier
 
 	// draPluginRemovalDeferred records that gpu.deploy.dra-driver removal was skipped on
 	// at least one node because pods holding gpu.nvidia.com claims are still present; the
 	// reconciler requeues until the kubelet-plugin can drain last.
 	draPluginRemovalDeferred bool
+
+	// labelKeyMigrationPending records that a renamed node label key was retained on at least
+	// one node because an operand DaemonSet still selects on it; the reconciler requeues until
+	// the DaemonSets have been re-rendered with the new key.
+	labelKeyMigrationPending bool
+
+	// operandsOptOutRequeueAfter is the delay after which the nodes opting out of the operands are drained
+	// again, or have their operands removed at the deadline of their opt-out, zero when no opt-out is pending
+	operandsOptOutRequeueAfter time.Duration
 }
 
 // gpuNodeLabelsUpdateResult reports total node patches and the subset where GPU
@@ -96,9 +122,18 @@ type nodeLabelUpdateReasons struct {
 	modeLabelMissing             bool
 	modeLabelChanged             bool
 	gpuWorkloadConfigChanged     bool
+	sandboxModeLabelChanged      bool
 	migCapableLabelChanged       bool
+	ccCapableCPULabelChanged     bool
 	osTreeLabelChanged           bool
 	nvidiaDriverOwnerLabelChange bool
+	clusterPolicyOwnerChange     bool
+	tenantNamespaceChange        bool
+	gpuDevicesChanged            bool
+	gpuHealthLabelChanged        bool
+	kernelVersionLabelChanged    bool
+	platformLabelChanged         bool
+	containerRuntimeChanged      bool
 }
 
 // needsUpdate reports whether any tracked node-label change requires reconciliation.
@@ -110,9 +145,18 @@ func (r nodeLabelUpdateReasons) needsUpdate() bool {
 		r.modeLabelMissing ||
 		r.modeLabelChanged ||
 		r.gpuWorkloadConfigChanged ||
+		r.sandboxModeLabelChanged ||
 		r.migCapableLabelChanged ||
+		r.ccCapableCPULabelChanged ||
 		r.osTreeLabelChanged ||
-		r.nvidiaDriverOwnerLabelChange
+		r.nvidiaDriverOwnerLabelChange ||
+		r.clusterPolicyOwnerChange ||
+		r.tenantNamespaceChange ||
+		r.gpuDevicesChanged ||
+		r.gpuHealthLabelChanged ||
+		r.kernelVersionLabelChanged ||
+		r.platformLabelChanged ||
+		r.containerRuntimeChanged
 }
 
 // getNodeLabelUpdateReasons compares old and new node labels for changes that affect GPU Operator labels.
@@ -128,9 +172,25 @@ func getNodeLabelUpdateReasons(oldLabels, newLabels map[string]string) nodeLabel
 		modeLabelMissing:             hasCommonGPULabel(newLabels) && newLabels[consts.GPUAllocationModeLabelKey] == "",
 		modeLabelChanged:             oldLabels[consts.GPUAllocationModeLabelKey] != newLabels[consts.GPUAllocationModeLabelKey],
 		gpuWorkloadConfigChanged:     oldGPUWorkloadConfig != newGPUWorkloadConfig,
+		sandboxModeLabelChanged:      oldLabels[gpuSandboxModeLabelKey] != newLabels[gpuSandboxModeLabelKey],
 		migCapableLabelChanged:       hasMIGCapableGPU(oldLabels) != hasMIGCapableGPU(newLabels),
+		ccCapableCPULabelChanged:     hasCCCapableCPU(oldLabels) != hasCCCapableCPU(newLabels),
 		osTreeLabelChanged:           oldLabels[nfdOSTreeVersionLabelKey] != newLabels[nfdOSTreeVersionLabelKey],
 		nvidiaDriverOwnerLabelChange: oldLabels[consts.NVIDIADriverOwnerLabel] != newLabels[consts.NVIDIADriverOwnerLabel],
+		clusterPolicyOwnerChange:     oldLabels[consts.ClusterPolicyOwnerLabel] != newLabels[consts.ClusterPolicyOwnerLabel],
+		tenantNamespaceChange:        oldLabels[consts.TenantNamespaceLabel] != newLabels[consts.TenantNamespaceLabel],
+		gpuDevicesChanged:            gpuDevicesChanged(oldLabels, newLabels),
+		// the device plugin of an unhealthy node is paused again when its deploy label is restored, e.g. by k8s-driver-manager
+		gpuHealthLabelChanged: oldLabels[consts.GPUUnhealthyLabelKey] != newLabels[consts.GPUUnhealthyLabelKey] ||
+			(newLabels[consts.GPUUnhealthyLabelKey] == "true" && oldLabels[devicePluginDeployLabelKey] != newLabels[devicePluginDeployLabelKey]),
+		kernelVersionLabelChanged: oldLabels[nfdKernelLabelKey] != newLabels[nfdKernelLabelKey] ||
+			oldLabels[consts.KernelFlavorLabelKey] != newLabels[consts.KernelFlavorLabelKey],
+		// the validator image of the node is selected per its architecture and OS
+		platformLabelChanged: oldLabels[corev1.LabelArchStable] != newLabels[corev1.LabelArchStable] ||
+			oldLabels[nfdOSReleaseIDLabelKey] != newLabels[nfdOSReleaseIDLabelKey] ||
+			oldLabels[nfdOSVersionIDLabelKey] != newLabels[nfdOSVersionIDLabelKey] ||
+			oldLabels[consts.ValidatorImageLabelKey] != newLabels[consts.ValidatorImageLabelKey],
+		containerRuntimeChanged: oldLabels[consts.ContainerRuntimeLabelKey] != newLabels[consts.ContainerRuntimeLabelKey],
 	}
 }
 
@@ -139,6 +199,9 @@ func getNodeLabelUpdateReasons(oldLabels, newLabels map[string]string) nodeLabel
 // Reconcile applies GPU-Operator related labels and annotations to all cluster nodes.
 func (r *NodeLabelingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	r.Log.Info("Reconciling node labels")
+	ctx = audit.WithReason(ctx, "node labeling")
+	ctx, done := r.APIStats.StartReconcile(ctx, "NodeLabeling")
+	defer done()
 
 	// The ClusterPolicy (device-plugin stack) and GPUCluster (DRA stack) CRs may
 	// coexist; neither existing means there is nothing to label.
@@ -167,6 +230,8 @@ func (r *NodeLabelingReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		gpuCluster:    gpuCluster,
 		defaultMode:   resolveDefaultMode(clusterPolicy != nil, gpuCluster != nil, envDefaultMode),
 		logger:        r.Log,
+		recorder:      r.recorder,
+		notifier:      r.Notifier,
 	}
 
 	gpuLabelUpdateResult, err := nlc.labelGPUNodes(ctx)
@@ -202,6 +267,19 @@ func (r *NodeLabelingReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		}
 	}
 
+	// Route each GPU node matched by a scoped ClusterPolicy to it, the other GPU nodes are managed by the
+	// ClusterPolicy without node selector.
+	if clusterPolicy != nil {
+		if _, err := clusterpolicyutil.AssignOwners(ctx, r.Client); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to assign ClusterPolicy owners to nodes: %w", err)
+		}
+	}
+
+	// Route each GPU node matched by a tenant namespace of the ClusterPolicy to the operands of the tenant
+	if _, err := clusterpolicyutil.AssignTenants(ctx, r.Client, clusterPolicy); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to assign tenant namespaces to nodes: %w", err)
+	}
+
 	// The k8s-driver-manager init container consumes this annotation on either stack.
 	if err := nlc.applyDriverAutoUpgradeAnnotation(ctx); err != nil {
 		return reconcile.Result{}, err
@@ -212,6 +290,15 @@ func (r *NodeLabelingReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		// the kubelet-plugin label falls off even if an event is missed.
 		return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
 	}
+	if nlc.labelKeyMigrationPending {
+		// DaemonSet updates do not trigger node labeling, poll until the old label keys can be removed
+		r.Log.Info("Renamed node label keys still in use by operand DaemonSets, requeueing")
+		return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+	if nlc.operandsOptOutRequeueAfter > 0 {
+		// node updates do not trigger node labeling at the deadline of the opt-outs
+		return reconcile.Result{RequeueAfter: nlc.operandsOptOutRequeueAfter}, nil
+	}
 	return reconcile.Result{}, nil
 }
 
@@ -238,6 +325,11 @@ func (nlc *nodeLabelingController) labelGPUNodes(ctx context.Context) (gpuNodeLa
 		return result, fmt.Errorf("unable to list nodes: %w", err)
 	}
 
+	keysInUse, err := nlc.getDaemonSetNodeSelectorKeys(ctx)
+	if err != nil {
+		return result, err
+	}
+
 	for _, node := range nodeList.Items {
 		original := node.DeepCopy()
 		labels := node.GetLabels()
@@ -245,6 +337,15 @@ func (nlc *nodeLabelingController) labelGPUNodes(ctx context.Context) (gpuNodeLa
 		modeLabelModified := false
 		stateLabelsModified := false
 
+		// migrate renamed label keys first, so the state labels below are reconciled under their current keys
+		labelKeysModified, migrationPending := migrateNodeLabelKeys(labels, keysInUse, nodeLabelKeyMigrations)
+		if labelKeysModified {
+			node.SetLabels(labels)
+		}
+		if migrationPending {
+			nlc.labelKeyMigrationPending = true
+		}
+
 		if nlc.reconcileCommonGPULabel(labels, node.Name) {
 			node.SetLabels(labels)
 			gpuDiscoveryStateChanged = true
@@ -255,25 +356,141 @@ func (nlc *nodeLabelingController) labelGPUNodes(ctx context.Context) (gpuNodeLa
 			modeLabelModified = true
 		}
 
-		if nlc.updateGPUStateLabels(ctx, labels, node.Name) {
+		if nlc.reconcileKernelFlavorLabel(labels, node.Name) {
+			node.SetLabels(labels)
+			stateLabelsModified = true
+		}
+
+		if nlc.reconcileConsumerGPULabel(labels, node.Name) {
+			node.SetLabels(labels)
+			stateLabelsModified = true
+		}
+
+		if nlc.reconcileValidatorImageLabel(ctx, labels, node.Name) {
 			node.SetLabels(labels)
 			stateLabelsModified = true
 		}
 
-		modified := gpuDiscoveryStateChanged || modeLabelModified || stateLabelsModified
+		if nlc.reconcileContainerRuntimeLabel(labels, &node) {
+			node.SetLabels(labels)
+			stateLabelsModified = true
+		}
+
+		operandsOptedOut := false
+		optOutDeadline, optOutRequested := nlc.getOperandsOptOutDeadline(&node)
+		if optOutRequested && !time.Now().Before(optOutDeadline) {
+			// the deadline of the operands opt-out passed, the operands are removed from the node
+			if removeAllGPUStateLabels(labels) {
+				nlc.logger.Info("Operands opt-out deadline passed, disabling all operands for node", "NodeName", node.Name)
+				node.SetLabels(labels)
+				stateLabelsModified = true
+				operandsOptedOut = true
+			}
+		} else {
+			if nlc.updateGPUStateLabels(ctx, labels, node.Name) {
+				node.SetLabels(labels)
+				stateLabelsModified = true
+			}
+			if nlc.reconcileMIGConfigLabel(&node) {
+				stateLabelsModified = true
+			}
+		}
+
+		if nlc.reconcileOperandsOptOut(ctx, &node, optOutDeadline, optOutRequested) {
+			node.SetLabels(labels)
+			stateLabelsModified = true
+		}
+
+		healthLabelsModified, taintsModified := nlc.reconcileGPUHealth(&node)
+
+		modified := gpuDiscoveryStateChanged || modeLabelModified || stateLabelsModified || labelKeysModified ||
+			healthLabelsModified || taintsModified
 		if modified {
-			if err := nlc.client.Patch(ctx, &node, client.MergeFrom(original)); err != nil {
+			patch := client.MergeFrom(original)
+			if taintsModified {
+				// taints are patched as a whole list, fail on a concurrent update rather than dropping its taints
+				patch = client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})
+			}
+			if err := nlc.client.Patch(ctx, &node, patch); err != nil {
 				return result, fmt.Errorf("unable to label node %s: %w", node.Name, err)
 			}
 			result.totalPatchedNodeCount++
 			if gpuDiscoveryStateChanged {
 				result.gpuDiscoveryStateChangedNodeCount++
 			}
+			nlc.recordGPUHealthEvent(original, &node)
+			nlc.notifyGPUQuarantine(original, &node)
+			nlc.recordConsumerGPUEvent(original, &node)
+			if operandsOptedOut {
+				nlc.recordOperandsOptOutEvent(&node)
+			}
 		}
 	}
 	return result, nil
 }
 
+// reconcileGPUHealth pauses the device plugin on the nodes labeled unhealthy by the GPU health check, by
+// setting its deploy label to paused-for-gpu-health, and resumes it once the label is removed. Any other
+// value of the deploy label, e.g. set by k8s-driver-manager during a driver upgrade, is left alone. Unhealthy
+// nodes are also tainted when configured. Returns whether the labels and the taints were modified.
+func (nlc *nodeLabelingController) reconcileGPUHealth(node *corev1.Node) (bool, bool) {
+	if nlc.clusterPolicy == nil {
+		return false, false
+	}
+	healthCheck := nlc.clusterPolicy.Spec.HealthCheck
+	labels := node.GetLabels()
+	unhealthy := healthCheck.IsEnabled() && labels[consts.GPUUnhealthyLabelKey] == "true"
+
+	labelsModified := false
+	switch {
+	case unhealthy && labels[devicePluginDeployLabelKey] == "true":
+		nlc.logger.Info("Node is unhealthy, pausing the device plugin", "NodeName", node.Name,
+			"Reason", node.Annotations[consts.GPUUnhealthyReasonAnnotationKey])
+		labels[devicePluginDeployLabelKey] = devicePluginPausedForGPUHealth
+		labelsModified = true
+	case !unhealthy && labels[devicePluginDeployLabelKey] == devicePluginPausedForGPUHealth:
+		nlc.logger.Info("Node is healthy, resuming the device plugin", "NodeName", node.Name)
+		labels[devicePluginDeployLabelKey] = "true"
+		labelsModified = true
+	}
+
+	taintsModified := false
+	taintIndex := slices.IndexFunc(node.Spec.Taints, func(taint corev1.Taint) bool {
+		return taint.Key == consts.GPUUnhealthyLabelKey
+	})
+	if unhealthy && healthCheck.IsTaintEnabled() {
+		if taintIndex < 0 {
+			node.Spec.Taints = append(node.Spec.Taints, corev1.Taint{
+				Key:    consts.GPUUnhealthyLabelKey,
+				Value:  "true",
+				Effect: corev1.TaintEffectNoSchedule,
+			})
+			taintsModified = true
+		}
+	} else if taintIndex >= 0 {
+		node.Spec.Taints = slices.Delete(node.Spec.Taints, taintIndex, taintIndex+1)
+		taintsModified = true
+	}
+	return labelsModified, taintsModified
+}
+
+// recordGPUHealthEvent records an event on the node when its device plugin was paused or resumed
+// by reconcileGPUHealth
+func (nlc *nodeLabelingController) recordGPUHealthEvent(original, node *corev1.Node) {
+	if nlc.recorder == nil {
+		return
+	}
+	previous, current := original.Labels[devicePluginDeployLabelKey], node.Labels[devicePluginDeployLabelKey]
+	switch {
+	case previous != devicePluginPausedForGPUHealth && current == devicePluginPausedForGPUHealth:
+		nlc.recorder.Eventf(node, nil, corev1.EventTypeWarning, "GPUUnhealthy", "PauseDevicePlugin",
+			"Device plugin paused after a critical XID error: %s", node.Annotations[consts.GPUUnhealthyReasonAnnotationKey])
+	case previous == devicePluginPausedForGPUHealth && current != devicePluginPausedForGPUHealth:
+		nlc.recorder.Eventf(node, nil, corev1.EventTypeNormal, "GPUHealthy", "ResumeDevicePlugin",
+			"Device plugin resumed, the node is no longer labeled %s", consts.GPUUnhealthyLabelKey)
+	}
+}
+
 // reconcileCommonGPULabel keeps nvidia.com/gpu.present in sync with NFD GPU PCI labels.
 // Returns true if labels were modified.
 func (nlc *nodeLabelingController) reconcileCommonGPULabel(labels map[string]string, nodeName string) bool {
@@ -290,6 +507,26 @@ func (nlc *nodeLabelingController) reconcileCommonGPULabel(labels map[string]str
 	return false
 }
 
+// reconcileKernelFlavorLabel keeps the kernel flavor label of the GPU nodes in sync with the kernel version
+// reported by NFD, the driver DaemonSets being scheduled per kernel flavor. Returns true if labels were modified.
+func (nlc *nodeLabelingController) reconcileKernelFlavorLabel(labels map[string]string, nodeName string) bool {
+	kernelVersion := labels[nfdKernelLabelKey]
+	if !hasCommonGPULabel(labels) || kernelVersion == "" {
+		if _, ok := labels[consts.KernelFlavorLabelKey]; ok {
+			delete(labels, consts.KernelFlavorLabelKey)
+			return true
+		}
+		return false
+	}
+	flavor := string(kernel.GetFlavor(kernelVersion))
+	if labels[consts.KernelFlavorLabelKey] == flavor {
+		return false
+	}
+	nlc.logger.Info("Setting kernel flavor label", "NodeName", nodeName, "Label", consts.KernelFlavorLabelKey, "Value", flavor)
+	labels[consts.KernelFlavorLabelKey] = flavor
+	return true
+}
+
 // reconcileModeLabel writes nvidia.com/gpu-operator.resource-allocation.mode on GPU nodes that do not have it
 // yet. An existing value is never overwritten (or removed), whether set by a previous
 // reconcile or manually by a user: changing the cluster configuration or DEFAULT_GPU_ALLOCATION_MODE
@@ -308,9 +545,8 @@ func (nlc *nodeLabelingController) reconcileModeLabel(labels map[string]string,
 	return true
 }
 
-// updateGPUStateLabels syncs nvidia.com/gpu.deploy.* labels and sets the MIG config label when
-// appropriate. Which label set is applied follows the node's nvidia.com/gpu-operator.resource-allocation.mode
-// label; deploy labels exclusive to the other stack are swept away, while shared and
+// updateGPUStateLabels syncs nvidia.com/gpu.deploy.* labels. Which label set is applied follows
+// the node's nvidia.com/gpu-operator.resource-allocation.mode label; deploy labels exclusive to the other stack are swept away, while shared and
 // unrecognized deploy labels are left alone. If the node does not have the common GPU
 // label, all state labels are removed. Returns true if labels were modified.
 func (nlc *nodeLabelingController) updateGPUStateLabels(ctx context.Context, labels map[string]string, nodeName string) bool {
@@ -339,10 +575,30 @@ func (nlc *nodeLabelingController) updateGPUStateLabels(ctx context.Context, lab
 	}
 
 	cp := nlc.clusterPolicy
+	if isWindowsNode(labels) {
+		// only the Windows device plugin and GPU Feature Discovery are deployed to the Windows GPU nodes,
+		// the driver and the container toolkit are managed on the host
+		if !cp.Spec.Windows.IsEnabled() {
+			return removeAllGPUStateLabels(labels)
+		}
+		windowsConfig := &gpuWorkloadConfiguration{
+			config: gpuWorkloadConfigWindows,
+			node:   nodeName,
+			log:    nlc.logger,
+		}
+		return windowsConfig.updateGPUStateLabels(labels)
+	}
+	consumerGPU := labels[consts.ConsumerGPULabel] == "true"
+	if consumerGPU && cp.Spec.ConsumerGPUs.GetPolicy() == gpuv1.ConsumerGPUPolicySkip {
+		nlc.logger.Info("Consumer GPUs are skipped, disabling all operands for node", "NodeName", nodeName)
+		return removeAllGPUStateLabels(labels)
+	}
 	sandboxEnabled := cp != nil && cp.Spec.SandboxWorkloads.IsEnabled()
 	sandboxMode := ""
 	if cp != nil {
-		sandboxMode = cp.Spec.SandboxWorkloads.Mode
+		// the sandbox mode label of the node overrides the cluster-wide sandbox mode, so that
+		// KubeVirt virtual machines and Kata containers are served by different nodes of the cluster
+		sandboxMode = gpulabels.SandboxModeOf(labels, cp.Spec.SandboxWorkloads.Mode)
 	}
 
 	config, err := getWorkloadConfig(labels, sandboxEnabled)
@@ -352,10 +608,12 @@ func (nlc *nodeLabelingController) updateGPUStateLabels(ctx context.Context, lab
 			"Error", err, "defaultGPUWorkloadConfig", defaultGPUWorkloadConfig)
 	}
 	gpuWorkloadConfig := &gpuWorkloadConfiguration{
-		config:      config,
-		sandboxMode: sandboxMode,
-		node:        nodeName,
-		log:         nlc.logger,
+		config:             config,
+		sandboxMode:        sandboxMode,
+		ccCapableNodesOnly: cp != nil && cp.Spec.CCManager.IsEnabled() && cp.Spec.CCManager.IsCCCapableNodesOnly(),
+		consumerGPU:        consumerGPU,
+		node:               nodeName,
+		log:                nlc.logger,
 	}
 	// The kubelet-plugin must outlive every pod whose gpu.nvidia.com claims it has to
 	// unprepare: its DaemonSet gates only on gpu.deploy.dra-driver (not the mode label),
@@ -373,21 +631,70 @@ func (nlc *nodeLabelingController) updateGPUStateLabels(ctx context.Context, lab
 		}
 	}
 
-	if cp != nil && cp.Spec.MIGManager.IsEnabled() && hasMIGCapableGPU(labels) && !hasMIGConfigLabel(labels) {
-		migConfigDefault := ""
-		if cp.Spec.MIGManager.Config != nil {
-			migConfigDefault = cp.Spec.MIGManager.Config.Default
-		}
-		if migConfigDefault == migConfigDisabledValue {
-			nlc.logger.Info("Setting MIG config label", "NodeName", nodeName,
-				"Label", migConfigLabelKey, "Value", migConfigDisabledValue)
-			labels[migConfigLabelKey] = migConfigDisabledValue
+	if cp != nil && cp.Spec.VGPUDeviceManager.IsEnabled() && config == gpuWorkloadConfigVMVgpu {
+		// the label is managed by the operator unless it was set to a configuration not declared as a profile
+		current := labels[vgpuConfigLabelKey]
+		profile := cp.Spec.VGPUDeviceManager.GetProfileForNode(labels)
+		if profile != "" && profile != current && (current == "" || cp.Spec.VGPUDeviceManager.HasProfile(current)) {
+			nlc.logger.Info("Setting vGPU config label", "NodeName", nodeName,
+				"Label", vgpuConfigLabelKey, "Value", profile)
+			labels[vgpuConfigLabelKey] = profile
 			modified = true
 		}
 	}
 	return modified
 }
 
+// reconcileMIGConfigLabel sets the MIG config label of the MIG-capable GPU nodes of the device plugin stack to
+// the configuration of their node pool, or to the MIG Manager default when it disables MIG. The label is managed
+// by the operator while it is unset or still holds the value the operator applied, recorded in an annotation, so
+// that the nodes follow the changes of the MIG config selectors while the labels set by the users are left alone.
+// Returns true if the node was modified.
+func (nlc *nodeLabelingController) reconcileMIGConfigLabel(node *corev1.Node) bool {
+	cp := nlc.clusterPolicy
+	labels := node.Labels
+	if cp == nil || !cp.Spec.MIGManager.IsEnabled() || !hasCommonGPULabel(labels) || isWindowsNode(labels) ||
+		labels[consts.ConsumerGPULabel] == "true" || !hasMIGCapableGPU(labels) ||
+		consts.GPUAllocationMode(labels[consts.GPUAllocationModeLabelKey]) != consts.GPUAllocationModeDevicePlugin {
+		return false
+	}
+
+	// a node-pool specific MIG configuration takes precedence over the MIG Manager default
+	migConfig := cp.Spec.MIG.GetConfigForNode(labels)
+	if migConfig == "" && cp.Spec.MIGManager.Config != nil &&
+		cp.Spec.MIGManager.Config.Default == migConfigDisabledValue {
+		migConfig = migConfigDisabledValue
+	}
+
+	current := labels[migConfigLabelKey]
+	applied, recorded := node.Annotations[consts.MIGConfigAppliedAnnotationKey]
+	switch {
+	case current != "" && !recorded && current != migConfig:
+		// the label was set by other means than the operator. A label holding the configuration of the node is
+		// adopted, e.g. set by a previous version of the operator.
+		return false
+	case current != "" && recorded && current != applied:
+		// the label was changed by other means than the operator, which no longer manages it
+		delete(node.Annotations, consts.MIGConfigAppliedAnnotationKey)
+		return true
+	}
+	if migConfig == "" || (current == migConfig && applied == migConfig) {
+		return false
+	}
+
+	if migConfig != current {
+		nlc.logger.Info("Setting MIG config label", "NodeName", node.Name,
+			"Label", migConfigLabelKey, "Value", migConfig)
+		labels[migConfigLabelKey] = migConfig
+		node.SetLabels(labels)
+	}
+	if node.Annotations == nil {
+		node.Annotations = make(map[string]string)
+	}
+	node.Annotations[consts.MIGConfigAppliedAnnotationKey] = migConfig
+	return true
+}
+
 // updateGPUClusterStateLabels is the GPUCluster analogue of the ClusterPolicy
 // gpuWorkloadConfiguration state-label logic: it sets the DRA operand deploy labels on a GPU
 // node (removal once the GPUs are gone is handled by removeAllGPUStateLabels). Like the
@@ -543,17 +850,13 @@ func (nlc *nodeLabelingController) applyDriverAutoUpgradeAnnotation(ctx context.
 		return nlc.applyDriverAutoUpgradeAnnotationForNVD(ctx)
 	}
 
-	autoUpgradeEnabled := cp.Spec.Driver.IsEnabled() &&
-		cp.Spec.Driver.IsAutoUpgradeEnabled() &&
-		!cp.Spec.SandboxWorkloads.IsEnabled()
-
 	nodeList := &corev1.NodeList{}
 	if err := nlc.client.List(ctx, nodeList, client.MatchingLabels{consts.GPUPresentLabel: "true"}); err != nil {
 		return fmt.Errorf("unable to list nodes: %w", err)
 	}
 
 	for _, node := range nodeList.Items {
-		err := nlc.setDriverAutoUpgradeAnnotation(ctx, &node, autoUpgradeEnabled)
+		err := nlc.setDriverAutoUpgradeAnnotation(ctx, &node, isNodeDriverAutoUpgradeEnabled(cp, &node))
 		if err != nil {
 			return fmt.Errorf("failed to set driver auto-upgrade annotation on node %q: %w", node.Name, err)
 		}
@@ -561,6 +864,20 @@ func (nlc *nodeLabelingController) applyDriverAutoUpgradeAnnotation(ctx context.
 	return nil
 }
 
+// isNodeDriverAutoUpgradeEnabled returns true if the ClusterPolicy enables the auto upgrade of the driver type of the node.
+// Only the vGPU manager upgrades are managed when sandbox workloads are enabled.
+func isNodeDriverAutoUpgradeEnabled(cp *gpuv1.ClusterPolicy, node *corev1.Node) bool {
+	driverType := getNodeDriverUpgradeType(node)
+	if cp.Spec.SandboxWorkloads.IsEnabled() {
+		return driverType == gpuv1.DriverUpgradeTypeVGPUHostManager &&
+			cp.Spec.VGPUManager.IsEnabled() &&
+			cp.Spec.Driver.IsAutoUpgradeEnabledFor(driverType)
+	}
+	return driverType != gpuv1.DriverUpgradeTypeVGPUHostManager &&
+		cp.Spec.Driver.IsEnabled() &&
+		cp.Spec.Driver.IsAutoUpgradeEnabledFor(driverType)
+}
+
 func (nlc *nodeLabelingController) applyDriverAutoUpgradeAnnotationForNVD(ctx context.Context) error {
 	nvidiaDriverList := &nvidiav1alpha1.NVIDIADriverList{}
 	if err := nlc.client.List(ctx, nvidiaDriverList); err != nil {
@@ -678,6 +995,8 @@ func (r *NodeLabelingReconciler) SetupWithManager(ctx context.Context, mgr ctrl.
 		return fmt.Errorf("failed to add pod node-name index: %w", err)
 	}
 
+	r.recorder = mgr.GetEventRecorder("nvidia-gpu-operator")
+
 	c, err := controller.New("node-labeling-controller", mgr, controller.Options{
 		Reconciler:              r,
 		MaxConcurrentReconciles: 1,
@@ -737,7 +1056,13 @@ func (r *NodeLabelingReconciler) SetupWithManager(ctx context.Context, mgr ctrl.
 			nodeName := e.ObjectNew.GetName()
 
 			reasons := getNodeLabelUpdateReasons(oldLabels, newLabels)
-			needsUpdate := reasons.needsUpdate()
+			// the operands opt-out is requested with an annotation
+			operandsOptOutChanged := e.ObjectOld.GetAnnotations()[consts.OperandsDisableAfterAnnotationKey] !=
+				e.ObjectNew.GetAnnotations()[consts.OperandsDisableAfterAnnotationKey]
+			// the container runtime of the node is reported by the kubelet in the node info
+			containerRuntimeChanged := e.ObjectOld.Status.NodeInfo.ContainerRuntimeVersion !=
+				e.ObjectNew.Status.NodeInfo.ContainerRuntimeVersion
+			needsUpdate := reasons.needsUpdate() || operandsOptOutChanged || containerRuntimeChanged
 
 			// When an NVIDIADriver daemonset pod is running on the node, check if any
 			// label which is configured in the NVIDIADriver's node selector has changed.
@@ -759,6 +1084,32 @@ func (r *NodeLabelingReconciler) SetupWithManager(ctx context.Context, mgr ctrl.
 				}
 			}
 
+			// Check if any label configured in the node selector of a scoped ClusterPolicy or of a tenant
+			// namespace has changed, as the node may have to be routed to another ClusterPolicy or tenant.
+			clusterPolicyNodeSelectorLabelChanged := false
+			if !needsUpdate && hasCommonGPULabel(newLabels) {
+				clusterPolicies := &gpuv1.ClusterPolicyList{}
+				if err := r.List(ctx, clusterPolicies); err != nil {
+					r.Log.Error(err, "failed to list ClusterPolicies", "node", nodeName)
+					return false
+				}
+				for _, clusterPolicy := range clusterPolicies.Items {
+					selectors := []map[string]string{clusterPolicy.Spec.NodeSelector}
+					for _, tenant := range clusterPolicy.Spec.TenantNamespaces {
+						selectors = append(selectors, tenant.NodeSelector)
+					}
+					for _, selector := range selectors {
+						for key := range selector {
+							if oldLabels[key] != newLabels[key] {
+								clusterPolicyNodeSelectorLabelChanged = true
+								needsUpdate = true
+								break
+							}
+						}
+					}
+				}
+			}
+
 			if needsUpdate {
 				r.Log.Info("Node needs an update",
 					"name", nodeName,
@@ -769,10 +1120,19 @@ func (r *NodeLabelingReconciler) SetupWithManager(ctx context.Context, mgr ctrl.
 					"modeLabelMissing", reasons.modeLabelMissing,
 					"modeLabelChanged", reasons.modeLabelChanged,
 					"gpuWorkloadConfigLabelChanged", reasons.gpuWorkloadConfigChanged,
+					"sandboxModeLabelChanged", reasons.sandboxModeLabelChanged,
 					"migCapableLabelChanged", reasons.migCapableLabelChanged,
+					"ccCapableCPULabelChanged", reasons.ccCapableCPULabelChanged,
 					"osTreeLabelChanged", reasons.osTreeLabelChanged,
 					"nvidiaDriverOwnerLabelChanged", reasons.nvidiaDriverOwnerLabelChange,
+					"gpuHealthLabelChanged", reasons.gpuHealthLabelChanged,
 					"nvidiaDriverNodeSelectorLabelChanged", nvidiaDriverNodeSelectorLabelChanged,
+					"clusterPolicyOwnerLabelChanged", reasons.clusterPolicyOwnerChange,
+					"tenantNamespaceLabelChanged", reasons.tenantNamespaceChange,
+					"gpuDevicesChanged", reasons.gpuDevicesChanged,
+					"clusterPolicyNodeSelectorLabelChanged", clusterPolicyNodeSelectorLabelChanged,
+					"operandsOptOutChanged", operandsOptOutChanged,
+					"containerRuntimeChanged", containerRuntimeChanged || reasons.containerRuntimeChanged,
 				)
 			}
 			return needsUpdate
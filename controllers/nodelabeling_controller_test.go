@@ -20,7 +20,9 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	upgrade_v1alpha1 "github.com/NVIDIA/k8s-operator-libs/api/upgrade/v1alpha1"
 	"github.com/NVIDIA/k8s-operator-libs/pkg/upgrade"
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
@@ -30,6 +32,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -114,6 +117,56 @@ func TestNodeLabelingReconcileDefersDependentOperationsAfterGPULabelChanges(t *t
 	assert.Equal(t, consts.DefaultNVIDIADriverName, updatedNode.Labels[consts.NVIDIADriverOwnerLabel])
 }
 
+func TestNodeLabelingReconcileSkipsInvalidScopedClusterPolicy(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+	require.NoError(t, nvidiav1alpha1.AddToScheme(scheme))
+
+	now := time.Now()
+	clusterPolicy := &gpuv1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy", CreationTimestamp: metav1.NewTime(now)}}
+	scoped := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a", CreationTimestamp: metav1.NewTime(now)},
+		Spec:       gpuv1.ClusterPolicySpec{NodeSelector: map[string]string{"pool": "a"}},
+	}
+	// the node selector of the ClusterPolicy uses the reserved owner label
+	invalid := &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-invalid", CreationTimestamp: metav1.NewTime(now.Add(-time.Minute))},
+		Spec:       gpuv1.ClusterPolicySpec{NodeSelector: map[string]string{consts.ClusterPolicyOwnerLabel: "team-a"}},
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "gpu-node",
+			Labels: map[string]string{
+				"feature.node.kubernetes.io/pci-10de.present": "true",
+				"pool": "a",
+			},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(clusterPolicy, scoped, invalid, node).
+		Build()
+
+	reconciler := &NodeLabelingReconciler{
+		Client:    fakeClient,
+		Namespace: "test-ns",
+		Log:       logr.Discard(),
+	}
+
+	// the first reconciliation labels the GPU node, the second one assigns its owner
+	for range 2 {
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{})
+		require.NoError(t, err)
+	}
+
+	updatedNode := &corev1.Node{}
+	require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: "gpu-node"}, updatedNode))
+	assert.Equal(t, commonGPULabelValue, updatedNode.Labels[commonGPULabelKey])
+	assert.Equal(t, "team-a", updatedNode.Labels[consts.ClusterPolicyOwnerLabel])
+}
+
 func TestNodeLabelingReconcileDoesNotDeferDependentOperationsForStateLabelChanges(t *testing.T) {
 	ctx := context.Background()
 	scheme := runtime.NewScheme()
@@ -195,6 +248,16 @@ func TestNodeLabelUpdateReasonsDetectsLabelChanges(t *testing.T) {
 				assert.True(t, reasons.migCapableLabelChanged)
 			},
 		},
+		{
+			name: "CC capable CPU label changed",
+			old:  map[string]string{},
+			new: map[string]string{
+				nfdTDXEnabledLabelKey: "true",
+			},
+			assert: func(t *testing.T, reasons nodeLabelUpdateReasons) {
+				assert.True(t, reasons.ccCapableCPULabelChanged)
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -457,6 +520,31 @@ func TestUpdateGPUStateLabels(t *testing.T) {
 				getEffectiveStateLabels(gpuWorkloadConfigVMPassthrough, string(gpuv1.Kata)),
 			),
 		},
+		{
+			name: "sandboxWorkloads enabled, mode=kubevirt, node sandbox mode=kata, workloadConfig=passthrough",
+			clusterPolicy: &gpuv1.ClusterPolicy{
+				Spec: gpuv1.ClusterPolicySpec{
+					SandboxWorkloads: gpuv1.SandboxWorkloadsSpec{
+						Enabled: ptr.To(true),
+						Mode:    string(gpuv1.KubeVirt),
+					},
+				},
+			},
+			initialLabels: map[string]string{
+				commonGPULabelKey:                  commonGPULabelValue,
+				gpuWorkloadConfigLabelKey:          gpuWorkloadConfigVMPassthrough,
+				gpuSandboxModeLabelKey:             string(gpuv1.Kata),
+				kubevirtDevicePluginDeployLabelKey: "true",
+			},
+			expectedLabels: mergeLabels(
+				map[string]string{
+					commonGPULabelKey:         commonGPULabelValue,
+					gpuWorkloadConfigLabelKey: gpuWorkloadConfigVMPassthrough,
+					gpuSandboxModeLabelKey:    string(gpuv1.Kata),
+				},
+				getEffectiveStateLabels(gpuWorkloadConfigVMPassthrough, string(gpuv1.Kata)),
+			),
+		},
 		{
 			name: "sandboxWorkloads enabled, mode=kata, workloadConfig=vm-vgpu",
 			clusterPolicy: &gpuv1.ClusterPolicy{
@@ -479,6 +567,84 @@ func TestUpdateGPUStateLabels(t *testing.T) {
 				getEffectiveStateLabels(gpuWorkloadConfigVMVgpu, string(gpuv1.Kata)),
 			),
 		},
+		{
+			name: "ccManager enabled, node without CC-capable CPU, cc-manager deploy label kept by default",
+			clusterPolicy: &gpuv1.ClusterPolicy{
+				Spec: gpuv1.ClusterPolicySpec{
+					SandboxWorkloads: gpuv1.SandboxWorkloadsSpec{
+						Enabled: ptr.To(true),
+						Mode:    string(gpuv1.Kata),
+					},
+					CCManager: gpuv1.CCManagerSpec{Enabled: ptr.To(true)},
+				},
+			},
+			initialLabels: map[string]string{
+				commonGPULabelKey:         commonGPULabelValue,
+				gpuWorkloadConfigLabelKey: gpuWorkloadConfigVMPassthrough,
+			},
+			expectedLabels: mergeLabels(
+				map[string]string{
+					commonGPULabelKey:         commonGPULabelValue,
+					gpuWorkloadConfigLabelKey: gpuWorkloadConfigVMPassthrough,
+				},
+				getEffectiveStateLabels(gpuWorkloadConfigVMPassthrough, string(gpuv1.Kata)),
+			),
+		},
+		{
+			name: "ccManager enabled for CC-capable nodes only, node without CC-capable CPU, cc-manager deploy label removed",
+			clusterPolicy: &gpuv1.ClusterPolicy{
+				Spec: gpuv1.ClusterPolicySpec{
+					SandboxWorkloads: gpuv1.SandboxWorkloadsSpec{
+						Enabled: ptr.To(true),
+						Mode:    string(gpuv1.Kata),
+					},
+					CCManager: gpuv1.CCManagerSpec{Enabled: ptr.To(true), CCCapableNodesOnly: ptr.To(true)},
+				},
+			},
+			initialLabels: mergeLabels(
+				map[string]string{
+					commonGPULabelKey:         commonGPULabelValue,
+					gpuWorkloadConfigLabelKey: gpuWorkloadConfigVMPassthrough,
+				},
+				getEffectiveStateLabels(gpuWorkloadConfigVMPassthrough, string(gpuv1.Kata)),
+			),
+			expectedLabels: func() map[string]string {
+				labels := mergeLabels(
+					map[string]string{
+						commonGPULabelKey:         commonGPULabelValue,
+						gpuWorkloadConfigLabelKey: gpuWorkloadConfigVMPassthrough,
+					},
+					getEffectiveStateLabels(gpuWorkloadConfigVMPassthrough, string(gpuv1.Kata)),
+				)
+				delete(labels, ccManagerDeployLabelKey)
+				return labels
+			}(),
+		},
+		{
+			name: "ccManager enabled for CC-capable nodes only, node with SEV-SNP capable CPU, cc-manager deploy label added",
+			clusterPolicy: &gpuv1.ClusterPolicy{
+				Spec: gpuv1.ClusterPolicySpec{
+					SandboxWorkloads: gpuv1.SandboxWorkloadsSpec{
+						Enabled: ptr.To(true),
+						Mode:    string(gpuv1.Kata),
+					},
+					CCManager: gpuv1.CCManagerSpec{Enabled: ptr.To(true), CCCapableNodesOnly: ptr.To(true)},
+				},
+			},
+			initialLabels: map[string]string{
+				commonGPULabelKey:         commonGPULabelValue,
+				gpuWorkloadConfigLabelKey: gpuWorkloadConfigVMPassthrough,
+				nfdSEVSNPEnabledLabelKey:  "true",
+			},
+			expectedLabels: mergeLabels(
+				map[string]string{
+					commonGPULabelKey:         commonGPULabelValue,
+					gpuWorkloadConfigLabelKey: gpuWorkloadConfigVMPassthrough,
+					nfdSEVSNPEnabledLabelKey:  "true",
+				},
+				getEffectiveStateLabels(gpuWorkloadConfigVMPassthrough, string(gpuv1.Kata)),
+			),
+		},
 		{
 			name: "sandboxWorkloads enabled, mode=kubevirt, workloadConfig switched from container to passthrough",
 			clusterPolicy: &gpuv1.ClusterPolicy{
@@ -528,6 +694,69 @@ func TestUpdateGPUStateLabels(t *testing.T) {
 				gpuStateLabels[gpuWorkloadConfigContainer],
 			),
 		},
+		{
+			name: "MIG-capable node matching a MIG config selector",
+			clusterPolicy: &gpuv1.ClusterPolicy{
+				Spec: gpuv1.ClusterPolicySpec{
+					MIG: gpuv1.MIGSpec{
+						ConfigSelectors: []gpuv1.MIGConfigSelector{
+							{NodeSelector: map[string]string{"pool": "inference"}, Config: "all-1g.10gb"},
+							{NodeSelector: map[string]string{"pool": "training"}, Config: "all-7g.80gb"},
+						},
+					},
+					MIGManager: gpuv1.MIGManagerSpec{
+						Enabled: ptr.To(true),
+						Config:  &gpuv1.MIGPartedConfigSpec{Default: migConfigDisabledValue},
+					},
+				},
+			},
+			initialLabels: map[string]string{
+				commonGPULabelKey:  commonGPULabelValue,
+				migCapableLabelKey: migCapableLabelValue,
+				"pool":             "training",
+			},
+			expectedLabels: mergeLabels(
+				map[string]string{
+					commonGPULabelKey:  commonGPULabelValue,
+					migCapableLabelKey: migCapableLabelValue,
+					migManagerLabelKey: migManagerLabelValue,
+					migConfigLabelKey:  "all-7g.80gb",
+					"pool":             "training",
+				},
+				gpuStateLabels[gpuWorkloadConfigContainer],
+			),
+		},
+		{
+			name: "MIG-capable node not matching any MIG config selector falls back to default",
+			clusterPolicy: &gpuv1.ClusterPolicy{
+				Spec: gpuv1.ClusterPolicySpec{
+					MIG: gpuv1.MIGSpec{
+						ConfigSelectors: []gpuv1.MIGConfigSelector{
+							{NodeSelector: map[string]string{"pool": "inference"}, Config: "all-1g.10gb"},
+						},
+					},
+					MIGManager: gpuv1.MIGManagerSpec{
+						Enabled: ptr.To(true),
+						Config:  &gpuv1.MIGPartedConfigSpec{Default: migConfigDisabledValue},
+					},
+				},
+			},
+			initialLabels: map[string]string{
+				commonGPULabelKey:  commonGPULabelValue,
+				migCapableLabelKey: migCapableLabelValue,
+				"pool":             "batch",
+			},
+			expectedLabels: mergeLabels(
+				map[string]string{
+					commonGPULabelKey:  commonGPULabelValue,
+					migCapableLabelKey: migCapableLabelValue,
+					migManagerLabelKey: migManagerLabelValue,
+					migConfigLabelKey:  migConfigDisabledValue,
+					"pool":             "batch",
+				},
+				gpuStateLabels[gpuWorkloadConfigContainer],
+			),
+		},
 		{
 			name: "MIG-capable node with existing mig.config label",
 			clusterPolicy: &gpuv1.ClusterPolicy{
@@ -553,6 +782,104 @@ func TestUpdateGPUStateLabels(t *testing.T) {
 				gpuStateLabels[gpuWorkloadConfigContainer],
 			),
 		},
+		{
+			name: "vm-vgpu node matching a vGPU profile",
+			clusterPolicy: &gpuv1.ClusterPolicy{
+				Spec: gpuv1.ClusterPolicySpec{
+					SandboxWorkloads: gpuv1.SandboxWorkloadsSpec{
+						Enabled: ptr.To(true),
+						Mode:    string(gpuv1.KubeVirt),
+					},
+					VGPUDeviceManager: gpuv1.VGPUDeviceManagerSpec{
+						Enabled: ptr.To(true),
+						Profiles: []gpuv1.VGPUProfileSpec{
+							{Name: "a100-4c", NodeSelector: map[string]string{"pool": "small"}, VGPUDevices: map[string]int32{"A100-4C": 10}},
+							{Name: "a100-40c", NodeSelector: map[string]string{"pool": "large"}, VGPUDevices: map[string]int32{"A100-40C": 1}},
+						},
+					},
+				},
+			},
+			initialLabels: map[string]string{
+				commonGPULabelKey:         commonGPULabelValue,
+				gpuWorkloadConfigLabelKey: gpuWorkloadConfigVMVgpu,
+				"pool":                    "large",
+			},
+			expectedLabels: mergeLabels(
+				map[string]string{
+					commonGPULabelKey:         commonGPULabelValue,
+					gpuWorkloadConfigLabelKey: gpuWorkloadConfigVMVgpu,
+					vgpuConfigLabelKey:        "a100-40c",
+					"pool":                    "large",
+				},
+				getEffectiveStateLabels(gpuWorkloadConfigVMVgpu, string(gpuv1.KubeVirt)),
+			),
+		},
+		{
+			name: "vm-vgpu node labeled with another vGPU profile",
+			clusterPolicy: &gpuv1.ClusterPolicy{
+				Spec: gpuv1.ClusterPolicySpec{
+					SandboxWorkloads: gpuv1.SandboxWorkloadsSpec{
+						Enabled: ptr.To(true),
+						Mode:    string(gpuv1.KubeVirt),
+					},
+					VGPUDeviceManager: gpuv1.VGPUDeviceManagerSpec{
+						Enabled: ptr.To(true),
+						Profiles: []gpuv1.VGPUProfileSpec{
+							{Name: "a100-4c", NodeSelector: map[string]string{"pool": "small"}, VGPUDevices: map[string]int32{"A100-4C": 10}},
+							{Name: "a100-40c", NodeSelector: map[string]string{"pool": "large"}, VGPUDevices: map[string]int32{"A100-40C": 1}},
+						},
+					},
+				},
+			},
+			initialLabels: map[string]string{
+				commonGPULabelKey:         commonGPULabelValue,
+				gpuWorkloadConfigLabelKey: gpuWorkloadConfigVMVgpu,
+				vgpuConfigLabelKey:        "a100-4c",
+				"pool":                    "large",
+			},
+			expectedLabels: mergeLabels(
+				map[string]string{
+					commonGPULabelKey:         commonGPULabelValue,
+					gpuWorkloadConfigLabelKey: gpuWorkloadConfigVMVgpu,
+					vgpuConfigLabelKey:        "a100-40c",
+					"pool":                    "large",
+				},
+				getEffectiveStateLabels(gpuWorkloadConfigVMVgpu, string(gpuv1.KubeVirt)),
+			),
+		},
+		{
+			name: "vm-vgpu node labeled with a configuration not declared as a profile",
+			clusterPolicy: &gpuv1.ClusterPolicy{
+				Spec: gpuv1.ClusterPolicySpec{
+					SandboxWorkloads: gpuv1.SandboxWorkloadsSpec{
+						Enabled: ptr.To(true),
+						Mode:    string(gpuv1.KubeVirt),
+					},
+					VGPUDeviceManager: gpuv1.VGPUDeviceManagerSpec{
+						Enabled: ptr.To(true),
+						Profiles: []gpuv1.VGPUProfileSpec{
+							{Name: "a100-4c", NodeSelector: map[string]string{"pool": "small"}, VGPUDevices: map[string]int32{"A100-4C": 10}},
+							{Name: "a100-40c", NodeSelector: map[string]string{"pool": "large"}, VGPUDevices: map[string]int32{"A100-40C": 1}},
+						},
+					},
+				},
+			},
+			initialLabels: map[string]string{
+				commonGPULabelKey:         commonGPULabelValue,
+				gpuWorkloadConfigLabelKey: gpuWorkloadConfigVMVgpu,
+				vgpuConfigLabelKey:        "A100-2C",
+				"pool":                    "large",
+			},
+			expectedLabels: mergeLabels(
+				map[string]string{
+					commonGPULabelKey:         commonGPULabelValue,
+					gpuWorkloadConfigLabelKey: gpuWorkloadConfigVMVgpu,
+					vgpuConfigLabelKey:        "A100-2C",
+					"pool":                    "large",
+				},
+				getEffectiveStateLabels(gpuWorkloadConfigVMVgpu, string(gpuv1.KubeVirt)),
+			),
+		},
 	}
 
 	for _, tc := range tests {
@@ -569,8 +896,10 @@ func TestUpdateGPUStateLabels(t *testing.T) {
 				labels[consts.GPUAllocationModeLabelKey] = string(consts.GPUAllocationModeDevicePlugin)
 				expectedLabels[consts.GPUAllocationModeLabelKey] = string(consts.GPUAllocationModeDevicePlugin)
 			}
-			nlc.updateGPUStateLabels(context.Background(), labels, "test-node")
-			assert.Equal(t, expectedLabels, labels)
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node", Labels: labels}}
+			nlc.updateGPUStateLabels(context.Background(), labels, node.Name)
+			nlc.reconcileMIGConfigLabel(node)
+			assert.Equal(t, expectedLabels, node.Labels)
 		})
 	}
 }
@@ -623,6 +952,64 @@ func TestReconcileModeLabel(t *testing.T) {
 	}
 }
 
+func TestReconcileKernelFlavorLabel(t *testing.T) {
+	tests := []struct {
+		name           string
+		initialLabels  map[string]string
+		expectedFlavor string
+		expectModified bool
+	}{
+		{
+			name:           "realtime kernel",
+			initialLabels:  map[string]string{commonGPULabelKey: commonGPULabelValue, nfdKernelLabelKey: "6.8.1-1015-realtime"},
+			expectedFlavor: "realtime",
+			expectModified: true,
+		},
+		{
+			name: "flavor already set",
+			initialLabels: map[string]string{
+				commonGPULabelKey:           commonGPULabelValue,
+				nfdKernelLabelKey:           "6.8.0-60-generic",
+				consts.KernelFlavorLabelKey: "generic",
+			},
+			expectedFlavor: "generic",
+			expectModified: false,
+		},
+		{
+			name: "flavor updated on kernel upgrade",
+			initialLabels: map[string]string{
+				commonGPULabelKey:           commonGPULabelValue,
+				nfdKernelLabelKey:           "6.8.0-1015-aws",
+				consts.KernelFlavorLabelKey: "generic",
+			},
+			expectedFlavor: "aws",
+			expectModified: true,
+		},
+		{
+			name:           "label removed without kernel version",
+			initialLabels:  map[string]string{commonGPULabelKey: commonGPULabelValue, consts.KernelFlavorLabelKey: "generic"},
+			expectedFlavor: "",
+			expectModified: true,
+		},
+		{
+			name:           "non-GPU node is not labeled",
+			initialLabels:  map[string]string{nfdKernelLabelKey: "6.8.1-1015-realtime"},
+			expectedFlavor: "",
+			expectModified: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			nlc := &nodeLabelingController{logger: logr.Discard()}
+			labels := mergeLabels(tc.initialLabels)
+			modified := nlc.reconcileKernelFlavorLabel(labels, "test-node")
+			assert.Equal(t, tc.expectModified, modified)
+			assert.Equal(t, tc.expectedFlavor, labels[consts.KernelFlavorLabelKey])
+		})
+	}
+}
+
 func TestUpdateGPUStateLabelsPerMode(t *testing.T) {
 	clusterPolicy := &gpuv1.ClusterPolicy{}
 	gpuCluster := &nvidiav1alpha1.GPUCluster{}
@@ -695,6 +1082,60 @@ func TestUpdateGPUStateLabelsPerMode(t *testing.T) {
 	}
 }
 
+func TestUpdateGPUStateLabelsWindows(t *testing.T) {
+	windowsEnabled := &gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{
+		Windows: &gpuv1.WindowsSpec{Enabled: ptr.To(true)},
+	}}
+
+	tests := []struct {
+		name           string
+		clusterPolicy  *gpuv1.ClusterPolicy
+		initialLabels  map[string]string
+		expectedLabels map[string]string
+	}{
+		{
+			name:           "windows node gets the Windows deploy labels only",
+			clusterPolicy:  windowsEnabled,
+			expectedLabels: gpuStateLabels[gpuWorkloadConfigWindows],
+		},
+		{
+			name:          "container deploy labels are removed from a windows node",
+			clusterPolicy: windowsEnabled,
+			initialLabels: map[string]string{
+				driverDeployLabelKey:       "true",
+				devicePluginDeployLabelKey: "true",
+			},
+			expectedLabels: gpuStateLabels[gpuWorkloadConfigWindows],
+		},
+		{
+			name:          "windows node gets no deploy labels when Windows is disabled",
+			clusterPolicy: &gpuv1.ClusterPolicy{},
+			initialLabels: map[string]string{
+				"nvidia.com/gpu.deploy.windows-device-plugin": "true",
+			},
+			expectedLabels: map[string]string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			nlc := &nodeLabelingController{
+				client:        fake.NewClientBuilder().WithIndex(&corev1.Pod{}, podNodeNameIndexKey, podNodeNameIndexer).Build(),
+				clusterPolicy: tc.clusterPolicy,
+				logger:        logr.Discard(),
+			}
+			base := map[string]string{
+				commonGPULabelKey:                commonGPULabelValue,
+				consts.GPUAllocationModeLabelKey: string(consts.GPUAllocationModeDevicePlugin),
+				nodeOSLabelKey:                   nodeOSWindows,
+			}
+			labels := mergeLabels(base, tc.initialLabels)
+			nlc.updateGPUStateLabels(context.Background(), labels, "test-node")
+			assert.Equal(t, mergeLabels(base, tc.expectedLabels), labels)
+		})
+	}
+}
+
 func TestUpdateGPUStateLabelsModeSweep(t *testing.T) {
 	clusterPolicy := &gpuv1.ClusterPolicy{}
 	gpuCluster := &nvidiav1alpha1.GPUCluster{}
@@ -919,12 +1360,17 @@ func TestModeSweepDeleteSets(t *testing.T) {
 		"nvidia.com/gpu.deploy.container-toolkit",
 		"nvidia.com/gpu.deploy.device-plugin",
 		"nvidia.com/gpu.deploy.node-status-exporter",
+		"nvidia.com/gpu.deploy.gpu-health-check",
+		"nvidia.com/gpu.deploy.nvlink-fabric",
+		"nvidia.com/gpu.deploy.image-prepull",
 		"nvidia.com/gpu.deploy.operator-validator",
 		"nvidia.com/gpu.deploy.sandbox-validator",
 		"nvidia.com/gpu.deploy.vfio-manager",
 		"nvidia.com/gpu.deploy.kata-manager",
 		"nvidia.com/gpu.deploy.cc-manager",
 		"nvidia.com/gpu.deploy.vgpu-device-manager",
+		"nvidia.com/gpu.deploy.windows-device-plugin",
+		"nvidia.com/gpu.deploy.windows-gpu-feature-discovery",
 	}, keysOf(devicePluginOnlyStateLabelKeys()))
 }
 
@@ -1025,6 +1471,26 @@ func TestApplyDriverAutoUpgradeAnnotationNoClusterPolicy(t *testing.T) {
 	assert.Empty(t, updated.Annotations[driverAutoUpgradeAnnotationKey])
 }
 
+func TestIsNodeDriverAutoUpgradeEnabled(t *testing.T) {
+	enabled := &upgrade_v1alpha1.DriverUpgradePolicySpec{AutoUpgrade: true}
+	disabled := &upgrade_v1alpha1.DriverUpgradePolicySpec{AutoUpgrade: false}
+	gpuNode := &corev1.Node{}
+	vgpuNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{vgpuPresentLabelKey: "true"}}}
+	hostNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{vgpuManagerDeployLabelKey: "true"}}}
+
+	cp := &gpuv1.ClusterPolicy{}
+	cp.Spec.Driver.UpgradePolicy = enabled
+	cp.Spec.Driver.UpgradePolicies = &gpuv1.DriverUpgradePoliciesSpec{VGPU: disabled, VGPUHostManager: enabled}
+	assert.True(t, isNodeDriverAutoUpgradeEnabled(cp, gpuNode))
+	assert.False(t, isNodeDriverAutoUpgradeEnabled(cp, vgpuNode))
+	assert.False(t, isNodeDriverAutoUpgradeEnabled(cp, hostNode))
+
+	cp.Spec.SandboxWorkloads.Enabled = ptr.To(true)
+	cp.Spec.VGPUManager.Enabled = ptr.To(true)
+	assert.False(t, isNodeDriverAutoUpgradeEnabled(cp, gpuNode))
+	assert.True(t, isNodeDriverAutoUpgradeEnabled(cp, hostNode))
+}
+
 func TestLabelNodesWithOrphanedDriverPods(t *testing.T) {
 	const namespace = "test-ns"
 	const driverName = "gpu-driver"
@@ -1407,3 +1873,179 @@ func TestReconcileGPUClusterNodeLabels(t *testing.T) {
 		assert.NotContains(t, got.Labels, draDriverDeployLabelKey)
 	})
 }
+
+func TestReconcileGPUHealth(t *testing.T) {
+	unhealthyTaint := corev1.Taint{Key: consts.GPUUnhealthyLabelKey, Value: "true", Effect: corev1.TaintEffectNoSchedule}
+	otherTaint := corev1.Taint{Key: "example.com/maintenance", Effect: corev1.TaintEffectNoSchedule}
+
+	tests := []struct {
+		description            string
+		healthCheck            *gpuv1.GPUHealthCheckSpec
+		labels                 map[string]string
+		taints                 []corev1.Taint
+		expectedDevicePlugin   string
+		expectedTaints         []corev1.Taint
+		expectedLabelsModified bool
+		expectedTaintsModified bool
+	}{
+		{
+			description:          "healthy node",
+			healthCheck:          &gpuv1.GPUHealthCheckSpec{Enabled: ptr.To(true)},
+			labels:               map[string]string{devicePluginDeployLabelKey: "true"},
+			expectedDevicePlugin: "true",
+		},
+		{
+			description:            "unhealthy node",
+			healthCheck:            &gpuv1.GPUHealthCheckSpec{Enabled: ptr.To(true)},
+			labels:                 map[string]string{devicePluginDeployLabelKey: "true", consts.GPUUnhealthyLabelKey: "true"},
+			expectedDevicePlugin:   devicePluginPausedForGPUHealth,
+			expectedLabelsModified: true,
+		},
+		{
+			description:          "unhealthy node with health check disabled",
+			labels:               map[string]string{devicePluginDeployLabelKey: "true", consts.GPUUnhealthyLabelKey: "true"},
+			expectedDevicePlugin: "true",
+		},
+		{
+			description:          "unhealthy node paused by k8s-driver-manager",
+			healthCheck:          &gpuv1.GPUHealthCheckSpec{Enabled: ptr.To(true)},
+			labels:               map[string]string{devicePluginDeployLabelKey: "paused-for-driver-upgrade", consts.GPUUnhealthyLabelKey: "true"},
+			expectedDevicePlugin: "paused-for-driver-upgrade",
+		},
+		{
+			description:            "node healthy again",
+			healthCheck:            &gpuv1.GPUHealthCheckSpec{Enabled: ptr.To(true), Taint: ptr.To(true)},
+			labels:                 map[string]string{devicePluginDeployLabelKey: devicePluginPausedForGPUHealth},
+			taints:                 []corev1.Taint{otherTaint, unhealthyTaint},
+			expectedDevicePlugin:   "true",
+			expectedTaints:         []corev1.Taint{otherTaint},
+			expectedLabelsModified: true,
+			expectedTaintsModified: true,
+		},
+		{
+			description:            "unhealthy node tainted",
+			healthCheck:            &gpuv1.GPUHealthCheckSpec{Enabled: ptr.To(true), Taint: ptr.To(true)},
+			labels:                 map[string]string{devicePluginDeployLabelKey: devicePluginPausedForGPUHealth, consts.GPUUnhealthyLabelKey: "true"},
+			taints:                 []corev1.Taint{otherTaint},
+			expectedDevicePlugin:   devicePluginPausedForGPUHealth,
+			expectedTaints:         []corev1.Taint{otherTaint, unhealthyTaint},
+			expectedTaintsModified: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			nlc := &nodeLabelingController{
+				clusterPolicy: &gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{HealthCheck: tc.healthCheck}},
+				logger:        logr.Discard(),
+			}
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "gpu-node", Labels: tc.labels},
+				Spec:       corev1.NodeSpec{Taints: tc.taints},
+			}
+
+			labelsModified, taintsModified := nlc.reconcileGPUHealth(node)
+			require.Equal(t, tc.expectedLabelsModified, labelsModified)
+			require.Equal(t, tc.expectedTaintsModified, taintsModified)
+			require.Equal(t, tc.expectedDevicePlugin, node.Labels[devicePluginDeployLabelKey])
+			require.Equal(t, tc.expectedTaints, node.Spec.Taints)
+		})
+	}
+}
+
+func TestLabelGPUNodesRecordsGPUHealthEvents(t *testing.T) {
+	ctx := context.Background()
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "gpu-node",
+			Labels: mergeLabels(nfdLabels, map[string]string{
+				commonGPULabelKey:                commonGPULabelValue,
+				consts.GPUAllocationModeLabelKey: string(consts.GPUAllocationModeDevicePlugin),
+				consts.GPUUnhealthyLabelKey:      "true",
+			}),
+			Annotations: map[string]string{consts.GPUUnhealthyReasonAnnotationKey: "Xid 79 on PCI:0000:3b:00"},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(node).Build()
+	recorder := events.NewFakeRecorder(10)
+	nlc := &nodeLabelingController{
+		client: fakeClient,
+		clusterPolicy: &gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{
+			HealthCheck: &gpuv1.GPUHealthCheckSpec{Enabled: ptr.To(true)},
+		}},
+		logger:   logr.Discard(),
+		recorder: recorder,
+	}
+
+	_, err := nlc.labelGPUNodes(ctx)
+	require.NoError(t, err)
+	updated := &corev1.Node{}
+	require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: node.Name}, updated))
+	require.Equal(t, devicePluginPausedForGPUHealth, updated.Labels[devicePluginDeployLabelKey])
+	require.Equal(t, "true", updated.Labels[dcgmDeployLabelKey], "only the device plugin is paused")
+	require.Len(t, recorder.Events, 1)
+	require.Contains(t, <-recorder.Events, "Xid 79 on PCI:0000:3b:00")
+
+	// the node is marked healthy again by the health check after a reboot
+	delete(updated.Labels, consts.GPUUnhealthyLabelKey)
+	require.NoError(t, fakeClient.Update(ctx, updated))
+	_, err = nlc.labelGPUNodes(ctx)
+	require.NoError(t, err)
+	require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: node.Name}, updated))
+	require.Equal(t, "true", updated.Labels[devicePluginDeployLabelKey])
+	require.Len(t, recorder.Events, 1)
+	require.Contains(t, <-recorder.Events, "GPUHealthy")
+}
+
+func TestLabelGPUNodesFollowsMIGConfigSelectors(t *testing.T) {
+	ctx := context.Background()
+	newNode := func(name, pool string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: mergeLabels(nfdLabels, map[string]string{
+				commonGPULabelKey:                commonGPULabelValue,
+				consts.GPUAllocationModeLabelKey: string(consts.GPUAllocationModeDevicePlugin),
+				migCapableLabelKey:               migCapableLabelValue,
+				"pool":                           pool,
+			}),
+		}}
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(newNode("managed", "training"), newNode("user-labeled", "training")).Build()
+	clusterPolicy := &gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{
+		MIG: gpuv1.MIGSpec{ConfigSelectors: []gpuv1.MIGConfigSelector{
+			{NodeSelector: map[string]string{"pool": "training"}, Config: "all-7g.80gb"},
+		}},
+		MIGManager: gpuv1.MIGManagerSpec{Enabled: ptr.To(true)},
+	}}
+	nlc := &nodeLabelingController{client: fakeClient, clusterPolicy: clusterPolicy, logger: logr.Discard()}
+	getNode := func(name string) *corev1.Node {
+		node := &corev1.Node{}
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: name}, node))
+		return node
+	}
+
+	_, err := nlc.labelGPUNodes(ctx)
+	require.NoError(t, err)
+	for _, name := range []string{"managed", "user-labeled"} {
+		node := getNode(name)
+		require.Equal(t, "all-7g.80gb", node.Labels[migConfigLabelKey])
+		require.Equal(t, "all-7g.80gb", node.Annotations[consts.MIGConfigAppliedAnnotationKey])
+	}
+
+	// the label of a node is changed by a user, the operator no longer manages it
+	userLabeled := getNode("user-labeled")
+	userLabeled.Labels[migConfigLabelKey] = "all-1g.10gb"
+	require.NoError(t, fakeClient.Update(ctx, userLabeled))
+	_, err = nlc.labelGPUNodes(ctx)
+	require.NoError(t, err)
+	require.NotContains(t, getNode("user-labeled").Annotations, consts.MIGConfigAppliedAnnotationKey)
+
+	// the nodes labeled by the operator follow the change of the selector
+	clusterPolicy.Spec.MIG.ConfigSelectors[0].Config = "all-3g.40gb"
+	_, err = nlc.labelGPUNodes(ctx)
+	require.NoError(t, err)
+	managed := getNode("managed")
+	require.Equal(t, "all-3g.40gb", managed.Labels[migConfigLabelKey])
+	require.Equal(t, "all-3g.40gb", managed.Annotations[consts.MIGConfigAppliedAnnotationKey])
+	require.Equal(t, "all-1g.10gb", getNode("user-labeled").Labels[migConfigLabelKey])
+}
@@ -0,0 +1,111 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/k8s-operator-libs/pkg/upgrade"
+	corev1 "k8s.io/api/core/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+	"github.com/NVIDIA/gpu-operator/internal/notification"
+)
+
+// nodeLifecycleEvents returns the lifecycle events of the GPU nodes observed between the previous and the current
+// node operand statuses of a ClusterPolicy. A node is reported ready the first time its status is ready, while the
+// driver upgrades and the validation failures are only reported for the nodes which already had a status.
+func nodeLifecycleEvents(clusterPolicy string, previous, current []gpuv1.NodeOperandStatus) []notification.Event {
+	previousStatuses := make(map[string]*gpuv1.NodeOperandStatus, len(previous))
+	for i := range previous {
+		previousStatuses[previous[i].Name] = &previous[i]
+	}
+
+	var events []notification.Event
+	for _, status := range current {
+		prev := previousStatuses[status.Name]
+		if isGPUStackReady(&status) && (prev == nil || !isGPUStackReady(prev)) {
+			events = append(events, notification.Event{
+				Type:          gpuv1.GPUStackReadyEvent,
+				ClusterPolicy: clusterPolicy,
+				Node:          status.Name,
+				Message:       "The container toolkit and the device plugin are ready and the node passed the operator validations",
+				DriverVersion: status.DriverVersion,
+			})
+		}
+		if prev == nil {
+			continue
+		}
+		if prev.DriverVersion != "" && status.DriverVersion != "" && prev.DriverVersion != status.DriverVersion {
+			events = append(events, notification.Event{
+				Type:                  gpuv1.DriverUpgradedEvent,
+				ClusterPolicy:         clusterPolicy,
+				Node:                  status.Name,
+				Message:               fmt.Sprintf("The driver was upgraded from %s to %s", prev.DriverVersion, status.DriverVersion),
+				DriverVersion:         status.DriverVersion,
+				PreviousDriverVersion: prev.DriverVersion,
+			})
+		}
+		// the validator pods are restarted by a driver upgrade
+		if prev.Validated && !status.Validated && !isDriverUpgradeInProgress(status.UpgradeState) {
+			events = append(events, notification.Event{
+				Type:          gpuv1.ValidationFailedEvent,
+				ClusterPolicy: clusterPolicy,
+				Node:          status.Name,
+				Message:       "The node no longer passes the operator validations",
+				DriverVersion: status.DriverVersion,
+			})
+		}
+	}
+	return events
+}
+
+// isGPUStackReady returns true if the GPU workloads can be scheduled on the node with the given status
+func isGPUStackReady(status *gpuv1.NodeOperandStatus) bool {
+	return status.ToolkitReady && status.DevicePluginReady && status.Validated
+}
+
+// isDriverUpgradeInProgress returns true if the given driver upgrade state is the state of an ongoing upgrade
+func isDriverUpgradeInProgress(upgradeState string) bool {
+	switch upgradeState {
+	case upgrade.UpgradeStateUnknown, upgrade.UpgradeStateDone, upgrade.UpgradeStateFailed:
+		return false
+	default:
+		return true
+	}
+}
+
+// notifyGPUQuarantine notifies the sinks of the ClusterPolicy when the device plugin of the node was paused by
+// reconcileGPUHealth
+func (nlc *nodeLabelingController) notifyGPUQuarantine(original, node *corev1.Node) {
+	if nlc.clusterPolicy == nil {
+		return
+	}
+	previous, current := original.Labels[devicePluginDeployLabelKey], node.Labels[devicePluginDeployLabelKey]
+	if previous == devicePluginPausedForGPUHealth || current != devicePluginPausedForGPUHealth {
+		return
+	}
+	nlc.notifier.Notify(nlc.clusterPolicy.Spec.Notifications.GetSinks(), notification.Event{
+		Type:          gpuv1.GPUQuarantinedEvent,
+		ClusterPolicy: nlc.clusterPolicy.Name,
+		Node:          node.Name,
+		Message:       "The device plugin was paused after a critical XID error",
+		DriverVersion: getNodeDriverVersion(node.Labels),
+		Reason:        node.Annotations[consts.GPUUnhealthyReasonAnnotationKey],
+	})
+}
@@ -0,0 +1,63 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestNodeLifecycleEvents(t *testing.T) {
+	ready := func(name, driverVersion string) gpuv1.NodeOperandStatus {
+		return gpuv1.NodeOperandStatus{Name: name, DriverVersion: driverVersion, ToolkitReady: true, DevicePluginReady: true, Validated: true}
+	}
+	eventTypes := func(previous, current []gpuv1.NodeOperandStatus) []gpuv1.NodeLifecycleEventType {
+		var types []gpuv1.NodeLifecycleEventType
+		for _, event := range nodeLifecycleEvents("cluster-policy", previous, current) {
+			require.Equal(t, "cluster-policy", event.ClusterPolicy)
+			types = append(types, event.Type)
+		}
+		return types
+	}
+
+	// unchanged statuses
+	require.Empty(t, eventTypes([]gpuv1.NodeOperandStatus{ready("node-a", "550.54.15")}, []gpuv1.NodeOperandStatus{ready("node-a", "550.54.15")}))
+
+	// new node and node becoming ready
+	notReady := ready("node-b", "550.54.15")
+	notReady.DevicePluginReady = false
+	require.Equal(t, []gpuv1.NodeLifecycleEventType{gpuv1.GPUStackReadyEvent, gpuv1.GPUStackReadyEvent},
+		eventTypes([]gpuv1.NodeOperandStatus{notReady}, []gpuv1.NodeOperandStatus{ready("node-a", ""), ready("node-b", "550.54.15")}))
+
+	// driver upgrade
+	events := nodeLifecycleEvents("cluster-policy", []gpuv1.NodeOperandStatus{ready("node-a", "550.54.15")}, []gpuv1.NodeOperandStatus{ready("node-a", "570.86.15")})
+	require.Len(t, events, 1)
+	require.Equal(t, gpuv1.DriverUpgradedEvent, events[0].Type)
+	require.Equal(t, "570.86.15", events[0].DriverVersion)
+	require.Equal(t, "550.54.15", events[0].PreviousDriverVersion)
+
+	// validation failure, ignored during a driver upgrade
+	failed := ready("node-a", "550.54.15")
+	failed.Validated = false
+	require.Equal(t, []gpuv1.NodeLifecycleEventType{gpuv1.ValidationFailedEvent},
+		eventTypes([]gpuv1.NodeOperandStatus{ready("node-a", "550.54.15")}, []gpuv1.NodeOperandStatus{failed}))
+	failed.UpgradeState = "pod-restart-required"
+	require.Empty(t, eventTypes([]gpuv1.NodeOperandStatus{ready("node-a", "550.54.15")}, []gpuv1.NodeOperandStatus{failed}))
+}
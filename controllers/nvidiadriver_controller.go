@@ -25,6 +25,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -43,9 +44,12 @@ import (
 	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
 	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
 	"github.com/NVIDIA/gpu-operator/controllers/clusterinfo"
+	"github.com/NVIDIA/gpu-operator/internal/apistats"
+	"github.com/NVIDIA/gpu-operator/internal/audit"
 	"github.com/NVIDIA/gpu-operator/internal/conditions"
 	"github.com/NVIDIA/gpu-operator/internal/consts"
 	"github.com/NVIDIA/gpu-operator/internal/state"
+	"github.com/NVIDIA/gpu-operator/internal/tracing"
 	"github.com/NVIDIA/gpu-operator/internal/validator"
 )
 
@@ -55,6 +59,10 @@ type NVIDIADriverReconciler struct {
 	Scheme      *runtime.Scheme
 	ClusterInfo clusterinfo.Interface
 	Namespace   string
+	// AssetsDir is the directory of the operand asset manifests, the baked-in assets are used when empty
+	AssetsDir string
+	// APIStats counts the requests of the reconciliations, nothing is counted when nil
+	APIStats *apistats.Tracker
 
 	stateManager          state.Manager
 	nodeSelectorValidator validator.Validator
@@ -64,6 +72,7 @@ type NVIDIADriverReconciler struct {
 //+kubebuilder:rbac:groups=nvidia.com,resources=nvidiadrivers,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=nvidia.com,resources=nvidiadrivers/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=nvidia.com,resources=nvidiadrivers/finalizers,verbs=update
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -74,9 +83,14 @@ type NVIDIADriverReconciler struct {
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.8.3/pkg/reconcile
-func (r *NVIDIADriverReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *NVIDIADriverReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	logger := log.FromContext(ctx)
 	logger.V(consts.LogLevelInfo).Info("Reconciling NVIDIADriver")
+	ctx = audit.WithReason(ctx, fmt.Sprintf("NVIDIADriver %s", req.Name))
+	ctx, done := r.APIStats.StartReconcile(ctx, "NVIDIADriver")
+	defer done()
+	ctx, span := tracing.Start(ctx, "reconcile NVIDIADriver", tracing.ObjectKey.String(req.Name))
+	defer func() { tracing.End(span, err) }()
 
 	// Get the NvidiaDriver instance from this request
 	instance := &nvidiav1alpha1.NVIDIADriver{}
@@ -257,11 +271,15 @@ func (r *NVIDIADriverReconciler) updateCrStatus(
 		return err
 	}
 
-	// Update global State
-	if instance.Status.State == desiredState {
+	// Update global State, the precompiled driver status of each kernel and the quarantined nodes
+	if instance.Status.State == desiredState &&
+		equality.Semantic.DeepEqual(instance.Status.Precompiled, cr.Status.Precompiled) &&
+		equality.Semantic.DeepEqual(instance.Status.QuarantinedNodes, cr.Status.QuarantinedNodes) {
 		return nil
 	}
 	instance.Status.State = desiredState
+	instance.Status.Precompiled = cr.Status.Precompiled
+	instance.Status.QuarantinedNodes = cr.Status.QuarantinedNodes
 
 	// send status update request to k8s API
 	reqLogger.V(consts.LogLevelInfo).Info("Updating CR Status", "Status", instance.Status)
@@ -337,7 +355,8 @@ func (r *NVIDIADriverReconciler) SetupWithManager(ctx context.Context, mgr ctrl.
 		nvidiav1alpha1.NVIDIADriverCRDName,
 		r.Namespace,
 		mgr.GetClient(),
-		mgr.GetScheme())
+		mgr.GetScheme(),
+		r.AssetsDir)
 	if err != nil {
 		return fmt.Errorf("error creating state manager: %v", err)
 	}
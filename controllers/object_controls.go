@@ -20,9 +20,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -37,6 +39,7 @@ import (
 	nodev1 "k8s.io/api/node/v1"
 	nodev1beta1 "k8s.io/api/node/v1beta1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -45,10 +48,13 @@ import (
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
 
 	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/audit"
 	driverconfig "github.com/NVIDIA/gpu-operator/internal/config"
 	"github.com/NVIDIA/gpu-operator/internal/consts"
+	"github.com/NVIDIA/gpu-operator/internal/image"
 	"github.com/NVIDIA/gpu-operator/internal/utils"
 )
 
@@ -61,7 +67,116 @@ func applyHostNetworkConfig(podSpec *corev1.PodSpec, hostNetwork *bool) {
 	}
 }
 
+// applySchedulerName sets the scheduler placing the pods of a pod spec, if one is configured
+func applySchedulerName(podSpec *corev1.PodSpec, schedulerName string) {
+	if schedulerName != "" {
+		podSpec.SchedulerName = schedulerName
+	}
+}
+
+// applyPriorityClassName sets the priority class of the pods of a pod spec, if one is configured
+func applyPriorityClassName(podSpec *corev1.PodSpec, priorityClassName string) {
+	if priorityClassName != "" {
+		podSpec.PriorityClassName = priorityClassName
+	}
+}
+
+// applyRuntimeClassName sets the runtime class of the pods of a pod spec, if one is configured
+func applyRuntimeClassName(podSpec *corev1.PodSpec, runtimeClassName string) {
+	if runtimeClassName != "" {
+		podSpec.RuntimeClassName = &runtimeClassName
+	}
+}
+
+// applyTolerations adds the tolerations configured for an operand to the tolerations of the pods of a pod spec
+func applyTolerations(podSpec *corev1.PodSpec, tolerations []corev1.Toleration) {
+	for _, toleration := range tolerations {
+		exists := false
+		for _, t := range podSpec.Tolerations {
+			if equality.Semantic.DeepEqual(t, toleration) {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			podSpec.Tolerations = append(podSpec.Tolerations, toleration)
+		}
+	}
+}
+
+// applyNodeAffinity merges the node affinity configured for an operand into the node affinity of the pods of a pod
+// spec. The required terms are ANDed with the required terms already set, and the preferred terms are added. An
+// error is returned when a required term can never match a node selected by the node selector of the pods.
+func applyNodeAffinity(podSpec *corev1.PodSpec, nodeAffinity *corev1.NodeAffinity) error {
+	if nodeAffinity == nil {
+		return nil
+	}
+	if required := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution; required != nil {
+		for _, term := range required.NodeSelectorTerms {
+			if err := validateNodeSelectorTerm(term, podSpec.NodeSelector); err != nil {
+				return err
+			}
+		}
+	}
+
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+	if podSpec.Affinity.NodeAffinity == nil {
+		podSpec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	current := podSpec.Affinity.NodeAffinity
+	if required := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution; required != nil && len(required.NodeSelectorTerms) > 0 {
+		if current.RequiredDuringSchedulingIgnoredDuringExecution == nil || len(current.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms) == 0 {
+			current.RequiredDuringSchedulingIgnoredDuringExecution = required.DeepCopy()
+		} else {
+			// the terms are ORed, a node must match one of the current terms and one of the configured terms
+			var terms []corev1.NodeSelectorTerm
+			for _, c := range current.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+				for _, r := range required.NodeSelectorTerms {
+					term := c.DeepCopy()
+					term.MatchExpressions = append(term.MatchExpressions, r.DeepCopy().MatchExpressions...)
+					term.MatchFields = append(term.MatchFields, r.DeepCopy().MatchFields...)
+					terms = append(terms, *term)
+				}
+			}
+			current.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms = terms
+		}
+	}
+	for _, term := range nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+		current.PreferredDuringSchedulingIgnoredDuringExecution = append(current.PreferredDuringSchedulingIgnoredDuringExecution, *term.DeepCopy())
+	}
+	return nil
+}
+
+// validateNodeSelectorTerm returns an error if a requirement of the term excludes a node label required by the
+// node selector of the operand, e.g. the nvidia.com/gpu.deploy.* label the operator schedules the operand with
+func validateNodeSelectorTerm(term corev1.NodeSelectorTerm, nodeSelector map[string]string) error {
+	for _, requirement := range term.MatchExpressions {
+		value, ok := nodeSelector[requirement.Key]
+		if !ok {
+			continue
+		}
+		conflict := false
+		switch requirement.Operator {
+		case corev1.NodeSelectorOpIn:
+			conflict = !slices.Contains(requirement.Values, value)
+		case corev1.NodeSelectorOpNotIn:
+			conflict = slices.Contains(requirement.Values, value)
+		case corev1.NodeSelectorOpDoesNotExist:
+			conflict = true
+		}
+		if conflict {
+			return fmt.Errorf("node affinity requirement %s %s %v conflicts with the node label %s=%s required by the operator",
+				requirement.Key, requirement.Operator, requirement.Values, requirement.Key, value)
+		}
+	}
+	return nil
+}
+
 const (
+	// windowsHostProcessUserName is the user running the HostProcess containers of the Windows operands
+	windowsHostProcessUserName = "NT AUTHORITY\\SYSTEM"
 	// DefaultContainerdConfigFile indicates default config file path for containerd
 	DefaultContainerdConfigFile = "/etc/containerd/config.toml"
 	// DefaultContainerdDropInConfigFile indicates default drop-in config file path for containerd
@@ -106,6 +221,14 @@ const (
 	ValidatorImagePullSecretsEnvName = "VALIDATOR_IMAGE_PULL_SECRETS"
 	// ValidatorRuntimeClassEnvName indicates env name of runtime class to be applied to validator pods
 	ValidatorRuntimeClassEnvName = "VALIDATOR_RUNTIME_CLASS"
+	// ValidatorSkipValidationEnvName indicates env name to skip the validation of a component
+	ValidatorSkipValidationEnvName = "SKIP_VALIDATION"
+	// ValidatorNVLinkFabricEnabledEnvName indicates env name to report the driver ready only once the NVLink fabric is registered
+	ValidatorNVLinkFabricEnabledEnvName = "NVLINK_FABRIC_ENABLED"
+	// ValidatorCUDACompatLabelsEnabledEnvName indicates env name to label the nodes with the CUDA compatibility of their driver
+	ValidatorCUDACompatLabelsEnabledEnvName = "CUDA_COMPAT_LABELS_ENABLED"
+	// ValidatorValidationTimeoutEnvName indicates env name for the maximum duration of the validation of a component
+	ValidatorValidationTimeoutEnvName = "VALIDATION_TIMEOUT"
 	// MigStrategyEnvName indicates env name for passing MIG strategy
 	MigStrategyEnvName = "MIG_STRATEGY"
 	// MigPartedDefaultConfigMapName indicates name of ConfigMap containing default mig-parted config
@@ -140,8 +263,40 @@ const (
 	DefaultToolkitInstallDir = "/usr/local/nvidia"
 	// ToolkitInstallDirEnvName is the name of the toolkit container env for configuring where NVIDIA Container Toolkit is installed
 	ToolkitInstallDirEnvName = "ROOT"
+	// TimeSlicingConfigMapName indicates name of the ConfigMap rendered from the device plugin time-slicing or MPS
+	// sharing configuration
+	TimeSlicingConfigMapName = "nvidia-device-plugin-time-slicing-config"
+	// KernelModuleParamsConfigMapName indicates name of the ConfigMap rendered from the kernel module parameters of
+	// the driver spec
+	KernelModuleParamsConfigMapName = "nvidia-driver-kernel-module-params"
+	// TimeSlicingDefaultConfigName indicates name of the configuration in the rendered sharing ConfigMap
+	TimeSlicingDefaultConfigName = "any"
+	// KataRuntimeClassLabelKey indicates the label identifying the Kata RuntimeClasses managed by the operator
+	KataRuntimeClassLabelKey = "nvidia.com/kata-runtime-class"
+	// DownloadCacheName indicates the name of the download cache Deployment and Service
+	DownloadCacheName = "nvidia-download-cache"
+	// DownloadCacheConfigMapName indicates the name of the ConfigMap rendered from the download cache spec
+	DownloadCacheConfigMapName = "nvidia-download-cache-config"
+	// DownloadCacheConfigFileName indicates the name of the proxy configuration in the download cache ConfigMap
+	DownloadCacheConfigFileName = "squid.conf"
+	// DownloadCachePort indicates the port the download cache listens on
+	DownloadCachePort = 3128
+	// DownloadCacheConfigDigestAnnotationKey indicates the pod annotation holding the digest of the rendered
+	// download cache configuration, so that configuration changes roll out the download cache pod
+	DownloadCacheConfigDigestAnnotationKey = "nvidia.com/download-cache-config-digest"
+	// NodeFeatureDiscoveryMasterName indicates the name of the NFD master Deployment managed by the operator
+	NodeFeatureDiscoveryMasterName = "nvidia-nfd-master"
+	// NodeFeatureDiscoveryWorkerName indicates the name of the NFD worker DaemonSet managed by the operator
+	NodeFeatureDiscoveryWorkerName = "nvidia-nfd-worker"
+	// MockGPUsName indicates the name of the DaemonSet advertising mock GPUs on the nodes without GPU
+	MockGPUsName = "nvidia-mock-gpus"
+	// NodeFeatureCRDName is the name of the CRD defining the NodeFeature kind, through which the NFD workers
+	// report the features of the nodes to the NFD master
+	NodeFeatureCRDName = "nodefeatures.nfd.k8s-sigs.io"
 	// VgpuDMDefaultConfigMapName indicates name of ConfigMap containing default vGPU devices configuration
 	VgpuDMDefaultConfigMapName = "default-vgpu-devices-config"
+	// VgpuDMConfigFileName indicates the name of the vGPU devices config file in the vGPU devices ConfigMap
+	VgpuDMConfigFileName = "config.yaml"
 	// VgpuDMDefaultConfigName indicates name of default configuration in the vGPU devices config file
 	VgpuDMDefaultConfigName = "default"
 	// NvidiaCtrRuntimeModeEnvName is the name of the toolkit container env for configuring the NVIDIA Container Runtime mode
@@ -154,6 +309,14 @@ const (
 	NvidiaCDIHookPathEnvName = "NVIDIA_CDI_HOOK_PATH"
 	// CRIOConfigModeEnvName is the name of the envvar controlling how the toolkit container updates the cri-o configuration
 	CRIOConfigModeEnvName = "CRIO_CONFIG_MODE"
+	// RuntimeConfigBackupDirEnvName is the name of the toolkit container env for the directory the runtime
+	// configuration files are backed up to before the toolkit updates them
+	RuntimeConfigBackupDirEnvName = "RUNTIME_CONFIG_BACKUP_DIR"
+	// DefaultRuntimeConfigBackupDir is the default directory the runtime configuration files are backed up to
+	DefaultRuntimeConfigBackupDir = "/run/nvidia/toolkit/runtime-config-backup"
+	// DefaultRuntimeRollbackTimeoutSeconds is the default time containerd is given to become healthy after its
+	// restart before its configuration is restored
+	DefaultRuntimeRollbackTimeoutSeconds = 120
 	// CDIEnableNRIPlugin is the name of the env var for enabling NRI Plugin in the toolkit
 	CDIEnableNRIPlugin = "ENABLE_NRI_PLUGIN"
 	// DeviceListStrategyEnvName is the name of the envvar for configuring the device-list-strategy in the device-plugin
@@ -168,6 +331,12 @@ const (
 	OpenKernelModulesEnabledEnvName = "OPEN_KERNEL_MODULES_ENABLED"
 	// KernelModuleTypeEnvName is the name of the driver-container envvar to set the desired kernel module type
 	KernelModuleTypeEnvName = "KERNEL_MODULE_TYPE"
+	// ModuleCacheEnabledEnvName is the name of the driver-container envvar for enabling caching of built kernel modules
+	ModuleCacheEnabledEnvName = "MODULE_CACHE_ENABLED"
+	// ModuleCacheRepositoryEnvName is the name of the driver-container envvar for the OCI repository caching kernel modules
+	ModuleCacheRepositoryEnvName = "MODULE_CACHE_REPOSITORY"
+	// ModuleCacheAuthFileEnvName is the name of the driver-container envvar for the path to the module cache registry credentials
+	ModuleCacheAuthFileEnvName = "MODULE_CACHE_AUTH_FILE"
 	// MPSRootEnvName is the name of the envvar for configuring the MPS root
 	MPSRootEnvName = "MPS_ROOT"
 	// DefaultMPSRoot is the default MPS root path on the host
@@ -315,6 +484,24 @@ var SubscriptionPathMap = map[string](MountPathToVolumeSource){
 
 type controlFunc []func(n ClusterPolicyController) (gpuv1.State, error)
 
+// sync deploys the objects of the state in order, and returns NotReady if any of them is not ready yet. It stops
+// at the first object failing to deploy.
+func (c controlFunc) sync(n ClusterPolicyController) (gpuv1.State, error) {
+	result := gpuv1.Ready
+	for _, fs := range c {
+		stat, err := fs(n)
+		if err != nil {
+			return stat, err
+		}
+		// successfully deployed resource, now check if its ready
+		if stat != gpuv1.Ready {
+			// mark overall status of this component as not-ready and continue with other resources, while this becomes ready
+			result = stat
+		}
+	}
+	return result, nil
+}
+
 // ServiceAccount creates ServiceAccount resource
 func ServiceAccount(n ClusterPolicyController) (gpuv1.State, error) {
 	ctx := n.ctx
@@ -463,6 +650,11 @@ func ClusterRole(n ClusterPolicyController) (gpuv1.State, error) {
 	obj := n.resources[state].ClusterRole.DeepCopy()
 	obj.Namespace = n.operatorNamespace
 
+	// the ClusterRole is shared by the tenant namespaces, and deployed along with the operator namespace
+	if n.tenant != "" {
+		return gpuv1.Ready, nil
+	}
+
 	logger := n.logger.WithValues("ClusterRole", obj.Name, "Namespace", obj.Namespace)
 
 	// Check if state is disabled and cleanup resource if exists
@@ -484,6 +676,8 @@ func ClusterRole(n ClusterPolicyController) (gpuv1.State, error) {
 		return gpuv1.Disabled, nil
 	}
 
+	applyNodeMutationPolicyRules(obj, n.nodeMutationPolicy)
+
 	if err := controllerutil.SetControllerReference(n.singleton, obj, n.scheme); err != nil {
 		return gpuv1.NotReady, err
 	}
@@ -512,6 +706,7 @@ func ClusterRoleBinding(n ClusterPolicyController) (gpuv1.State, error) {
 	state := n.idx
 	obj := n.resources[state].ClusterRoleBinding.DeepCopy()
 	obj.Namespace = n.operatorNamespace
+	applyTenantClusterRoleBinding(obj, n)
 
 	logger := n.logger.WithValues("ClusterRoleBinding", obj.Name, "Namespace", obj.Namespace)
 
@@ -567,6 +762,7 @@ func createConfigMap(n ClusterPolicyController, configMapIdx int) (gpuv1.State,
 	config := n.singleton.Spec
 	obj := n.resources[state].ConfigMaps[configMapIdx].DeepCopy()
 	obj.Namespace = n.operatorNamespace
+	name := obj.Name
 
 	logger := n.logger.WithValues("ConfigMap", obj.Name, "Namespace", obj.Namespace)
 
@@ -577,6 +773,9 @@ func createConfigMap(n ClusterPolicyController, configMapIdx int) (gpuv1.State,
 			logger.Info("Couldn't delete", "Error", err)
 			return gpuv1.NotReady, err
 		}
+		if err := n.deleteStaleConfigMapGenerations(ctx, name, ""); err != nil {
+			return gpuv1.NotReady, err
+		}
 		return gpuv1.Disabled, nil
 	}
 
@@ -602,6 +801,61 @@ func createConfigMap(n ClusterPolicyController, configMapIdx int) (gpuv1.State,
 			logger.Info("Not creating resource, custom ConfigMap provided", "Name", name)
 			return gpuv1.Ready, nil
 		}
+		// add the vGPU type profiles declared in the vGPU device manager spec to the default configuration
+		if len(config.VGPUDeviceManager.Profiles) > 0 {
+			data, err := renderVGPUDevicesConfig(obj.Data[VgpuDMConfigFileName], config.VGPUDeviceManager.Profiles)
+			if err != nil {
+				return gpuv1.NotReady, err
+			}
+			obj.Data[VgpuDMConfigFileName] = data
+		}
+	}
+
+	// render the sharing ConfigMap from the device plugin spec, or remove it when not configured
+	if obj.Name == TimeSlicingConfigMapName {
+		if !config.DevicePlugin.IsSharingManaged() {
+			err := n.client.Delete(ctx, obj)
+			if err != nil && !apierrors.IsNotFound(err) {
+				logger.Info("Couldn't delete", "Error", err)
+				return gpuv1.NotReady, err
+			}
+			if err := n.deleteStaleConfigMapGenerations(ctx, name, ""); err != nil {
+				return gpuv1.NotReady, err
+			}
+			return gpuv1.Ready, nil
+		}
+		data, err := renderSharingConfig(&config.DevicePlugin)
+		if err != nil {
+			return gpuv1.NotReady, err
+		}
+		obj.Data = map[string]string{TimeSlicingDefaultConfigName: data}
+	}
+
+	// render the kernel module parameters ConfigMap from the driver spec, or remove it when not configured
+	if obj.Name == KernelModuleParamsConfigMapName {
+		if !config.Driver.KernelModuleConfig.HasModuleParameters() {
+			err := n.client.Delete(ctx, obj)
+			if err != nil && !apierrors.IsNotFound(err) {
+				logger.Info("Couldn't delete", "Error", err)
+				return gpuv1.NotReady, err
+			}
+			if err := n.deleteStaleConfigMapGenerations(ctx, name, ""); err != nil {
+				return gpuv1.NotReady, err
+			}
+			return gpuv1.Ready, nil
+		}
+		obj.Data = config.Driver.KernelModuleConfig.ModuleParameterFiles()
+	}
+
+	// render the download cache ConfigMap from the download cache spec
+	if obj.Name == DownloadCacheConfigMapName {
+		obj.Data = map[string]string{DownloadCacheConfigFileName: renderDownloadCacheConfig(config.DownloadCache)}
+	}
+
+	if config.Daemonsets.IsConfigMapImmutable() {
+		if err := applyConfigMapGeneration(obj, n); err != nil {
+			return gpuv1.NotReady, err
+		}
 	}
 
 	if err := controllerutil.SetControllerReference(n.singleton, obj, n.scheme); err != nil {
@@ -614,14 +868,20 @@ func createConfigMap(n ClusterPolicyController, configMapIdx int) (gpuv1.State,
 			return gpuv1.NotReady, err
 		}
 
-		logger.Info("Found Resource, updating...")
-		err = n.client.Update(ctx, obj)
-		if err != nil {
-			logger.Info("Couldn't update", "Error", err)
-			return gpuv1.NotReady, err
+		// a generation is named after its content, which is unchanged
+		if !config.Daemonsets.IsConfigMapImmutable() {
+			logger.Info("Found Resource, updating...")
+			err = n.client.Update(ctx, obj)
+			if err != nil {
+				logger.Info("Couldn't update", "Error", err)
+				return gpuv1.NotReady, err
+			}
 		}
 	}
 
+	if err := n.deleteStaleConfigMapGenerations(ctx, name, obj.Name); err != nil {
+		return gpuv1.NotReady, err
+	}
 	return gpuv1.Ready, nil
 }
 
@@ -644,30 +904,27 @@ func ConfigMaps(n ClusterPolicyController) (gpuv1.State, error) {
 // getKernelVersionsMap returns a map of kernel versions to their corresponding OS from all GPU nodes in the cluster
 func (n ClusterPolicyController) getKernelVersionsMap() (map[string]string, error) {
 	kernelVersionMap := make(map[string]string)
-	ctx := n.ctx
 	logger := n.logger.WithValues("Request.Namespace", "default", "Request.Name", "Node")
 
 	// Filter only GPU nodes
-	opts := []client.ListOption{
-		client.MatchingLabels{"nvidia.com/gpu.present": "true"},
-	}
-
-	list := &corev1.NodeList{}
-	err := n.client.List(ctx, list, opts...)
+	nodes, err := n.listGPUNodes(nil)
 	if err != nil {
 		logger.Info("Could not get NodeList", "ERROR", err)
 		return nil, err
 	}
 
-	if len(list.Items) == 0 {
+	if len(nodes) == 0 {
 		// none of the nodes matched nvidia GPU label
 		// either the nodes do not have GPUs, or NFD is not running
 		logger.Info("Could not get any nodes to match nvidia.com/gpu.present label")
 		return nil, nil
 	}
 
-	for _, node := range list.Items {
+	for _, node := range nodes {
 		labels := node.GetLabels()
+		if !n.ownsNode(labels) {
+			continue
+		}
 		if kernelVersion, ok := labels[nfdKernelLabelKey]; ok {
 			logger.Info("Found kernel version label", "version", kernelVersion)
 			// get OS version for this kernel
@@ -712,10 +969,47 @@ func preprocessService(obj *corev1.Service, n ClusterPolicyController) error {
 	return nil
 }
 
+// pinDaemonSetImageDigests replaces the image tags of all containers of the DaemonSet with the
+// digests they currently resolve to, authenticating with the image pull secrets of the DaemonSet
+func pinDaemonSetImageDigests(ctx context.Context, obj *appsv1.DaemonSet, n ClusterPolicyController) error {
+	var creds []image.Credential
+	for _, pullSecret := range obj.Spec.Template.Spec.ImagePullSecrets {
+		secret := &corev1.Secret{}
+		err := n.client.Get(ctx, types.NamespacedName{Namespace: n.operatorNamespace, Name: pullSecret.Name}, secret)
+		if err != nil {
+			return fmt.Errorf("failed to get image pull secret %s: %w", pullSecret.Name, err)
+		}
+		if secret.Type != corev1.SecretTypeDockerConfigJson {
+			continue
+		}
+		secretCreds, err := image.CredentialsFromDockerConfigJSON(secret.Data[corev1.DockerConfigJsonKey])
+		if err != nil {
+			return fmt.Errorf("failed to parse image pull secret %s: %w", pullSecret.Name, err)
+		}
+		creds = append(creds, secretCreds...)
+	}
+
+	podSpec := &obj.Spec.Template.Spec
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			resolved, err := n.imageResolver.Resolve(ctx, containers[i].Image, creds)
+			if err != nil {
+				return fmt.Errorf("failed to resolve digest of image %s: %w", containers[i].Image, err)
+			}
+			containers[i].Image = resolved
+		}
+	}
+	return nil
+}
+
 func preProcessDaemonSet(obj *appsv1.DaemonSet, n ClusterPolicyController) error {
 	logger := n.logger.WithValues("Daemonset", obj.Name)
 
-	applyModeSelector(obj, n)
+	// the NFD workers label all nodes, including the nodes of the other resource allocation modes, and the
+	// mock GPUs are advertised on the nodes without GPU
+	if !isGPUNodeAgnosticDaemonSet(obj.Name) {
+		applyModeSelector(obj, n)
+	}
 
 	transformations := map[string]func(*appsv1.DaemonSet, *gpuv1.ClusterPolicySpec, ClusterPolicyController) error{
 		"nvidia-driver-daemonset":                     TransformDriver,
@@ -730,11 +1024,18 @@ func preProcessDaemonSet(obj *appsv1.DaemonSet, n ClusterPolicyController) error
 		"nvidia-dcgm":                                 TransformDCGM,
 		"nvidia-dcgm-exporter":                        TransformDCGMExporter,
 		"nvidia-node-status-exporter":                 TransformNodeStatusExporter,
+		"nvidia-gpu-health-check":                     TransformGPUHealthCheck,
+		"nvidia-image-prepull":                        TransformImagePrePull,
+		"nvidia-nvlink-fabric":                        TransformNVLinkFabric,
 		"gpu-feature-discovery":                       TransformGPUDiscoveryPlugin,
 		"nvidia-mig-manager":                          TransformMIGManager,
 		"nvidia-operator-validator":                   TransformValidator,
 		"nvidia-sandbox-validator":                    TransformSandboxValidator,
 		"nvidia-cc-manager":                           TransformCCManager,
+		"nvidia-windows-device-plugin-daemonset":      TransformWindowsDevicePlugin,
+		"nvidia-windows-gpu-feature-discovery":        TransformWindowsGPUFeatureDiscovery,
+		NodeFeatureDiscoveryWorkerName:                TransformNodeFeatureDiscoveryWorker,
+		MockGPUsName:                                  TransformMockGPUs,
 	}
 
 	t, ok := transformations[obj.Name]
@@ -763,12 +1064,64 @@ func preProcessDaemonSet(obj *appsv1.DaemonSet, n ClusterPolicyController) error
 		return err
 	}
 
+	// rewrite operand images to the configured registry mirrors
+	applyImageMirror(&obj.Spec.Template.Spec, &n.singleton.Spec)
+
+	// attach the image pull secrets of all operands
+	addPullSecrets(&obj.Spec.Template.Spec, n.pullSecrets)
+
 	// apply custom Labels and Annotations to the podSpec if any
 	applyCommonDaemonsetMetadata(obj, &n.singleton.Spec.Daemonsets)
 
 	return nil
 }
 
+// applyImageMirror rewrites the images of all containers of the pod, and the images of the
+// validation workloads they spin off, with the registry mirrors configured in the ClusterPolicy
+func applyImageMirror(podSpec *corev1.PodSpec, config *gpuv1.ClusterPolicySpec) {
+	if len(config.ImageMirror) == 0 {
+		return
+	}
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			containers[i].Image = image.MirrorImage(containers[i].Image, config.ImageMirror)
+			for j := range containers[i].Env {
+				if containers[i].Env[j].Name == ValidatorImageEnvName {
+					containers[i].Env[j].Value = image.MirrorImage(containers[i].Env[j].Value, config.ImageMirror)
+				}
+			}
+		}
+	}
+}
+
+func preProcessDeployment(obj *appsv1.Deployment, n ClusterPolicyController) error {
+	logger := n.logger.WithValues("Deployment", obj.Name)
+	transformations := map[string]func(*appsv1.Deployment, *gpuv1.ClusterPolicySpec, ClusterPolicyController) error{
+		DownloadCacheName:              TransformDownloadCache,
+		NodeFeatureDiscoveryMasterName: TransformNodeFeatureDiscoveryMaster,
+	}
+
+	t, ok := transformations[obj.Name]
+	if !ok {
+		logger.V(2).Info(fmt.Sprintf("No transformation for Deployment '%s'", obj.Name))
+		return nil
+	}
+
+	err := t(obj, &n.singleton.Spec, n)
+	if err != nil {
+		logger.Error(err, "Failed to apply transformation", "resource", obj.Name)
+		return err
+	}
+
+	// rewrite operand images to the configured registry mirrors
+	applyImageMirror(&obj.Spec.Template.Spec, &n.singleton.Spec)
+
+	// attach the image pull secrets of all operands
+	addPullSecrets(&obj.Spec.Template.Spec, n.pullSecrets)
+
+	return nil
+}
+
 // applyModeSelector adds the nvidia.com/gpu-operator.resource-allocation.mode nodeSelector to a
 // ClusterPolicy operand DaemonSet, restricting it to device-plugin-stack nodes. The selector is
 // rendered only once a GPUCluster CR exists (before that there is no DRA stack to fence operands
@@ -829,10 +1182,15 @@ func applyCommonDaemonsetConfig(obj *appsv1.DaemonSet, config *gpuv1.ClusterPoli
 		obj.Spec.Template.Spec.PriorityClassName = config.Daemonsets.PriorityClassName
 	}
 
+	// set the scheduler of all operands, operands may override it
+	applySchedulerName(&obj.Spec.Template.Spec, config.Daemonsets.SchedulerName)
+
 	// set tolerations if specified
 	if len(config.Daemonsets.Tolerations) > 0 {
 		obj.Spec.Template.Spec.Tolerations = config.Daemonsets.Tolerations
 	}
+	// tolerate the startup taints of the nodes provisioned by node autoscalers
+	applyTolerations(&obj.Spec.Template.Spec, config.Daemonsets.StartupTaints.GetTolerations())
 
 	// set pod-level security context if specified (applies as defaults to all containers in the pod)
 	if config.Daemonsets.PodSecurityContext != nil {
@@ -946,6 +1304,9 @@ func TransformGPUDiscoveryPlugin(obj *appsv1.DaemonSet, config *gpuv1.ClusterPol
 		}
 	}
 
+	// set container probes
+	setContainerProbes(&(obj.Spec.Template.Spec.Containers[0]), config.GPUFeatureDiscovery.StartupProbe, config.GPUFeatureDiscovery.LivenessProbe, config.GPUFeatureDiscovery.ReadinessProbe)
+
 	// set arguments if specified for driver container
 	if len(config.GPUFeatureDiscovery.Args) > 0 {
 		obj.Spec.Template.Spec.Containers[0].Args = config.GPUFeatureDiscovery.Args
@@ -978,7 +1339,66 @@ func TransformGPUDiscoveryPlugin(obj *appsv1.DaemonSet, config *gpuv1.ClusterPol
 
 	// set hostNetwork for gpu-feature-discovery if specified
 	applyHostNetworkConfig(&obj.Spec.Template.Spec, config.GPUFeatureDiscovery.HostNetwork)
+	applySchedulerName(&obj.Spec.Template.Spec, config.GPUFeatureDiscovery.SchedulerName)
+	applyPriorityClassName(&obj.Spec.Template.Spec, config.GPUFeatureDiscovery.PriorityClassName)
+	applyRuntimeClassName(&obj.Spec.Template.Spec, config.GPUFeatureDiscovery.RuntimeClassName)
+	applyTolerations(&obj.Spec.Template.Spec, config.GPUFeatureDiscovery.Tolerations)
+	if err := applyNodeAffinity(&obj.Spec.Template.Spec, config.GPUFeatureDiscovery.NodeAffinity); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// TransformWindowsDevicePlugin transforms the device plugin daemonset of the Windows GPU nodes
+func TransformWindowsDevicePlugin(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n ClusterPolicyController) error {
+	return transformWindowsComponent(obj, config.Windows.DevicePlugin, "WINDOWS_DEVICE_PLUGIN_IMAGE")
+}
+
+// TransformWindowsGPUFeatureDiscovery transforms the GPU Feature Discovery daemonset of the Windows GPU nodes
+func TransformWindowsGPUFeatureDiscovery(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n ClusterPolicyController) error {
+	return transformWindowsComponent(obj, config.Windows.GPUFeatureDiscovery, "WINDOWS_GFD_IMAGE")
+}
+
+// transformWindowsComponent applies the configuration of a Windows operand. The Windows operands run as HostProcess
+// containers, which the pod security context of the daemonsets must not override.
+func transformWindowsComponent(obj *appsv1.DaemonSet, spec *gpuv1.WindowsComponentSpec, imagePathEnvName string) error {
+	if spec == nil {
+		spec = &gpuv1.WindowsComponentSpec{}
+	}
+	podSpec := &obj.Spec.Template.Spec
+
+	img, err := image.ImagePath(spec.Repository, spec.Image, spec.Version, imagePathEnvName)
+	if err != nil {
+		return err
+	}
+	podSpec.Containers[0].Image = img
+	podSpec.Containers[0].ImagePullPolicy = gpuv1.ImagePullPolicy(spec.ImagePullPolicy)
+
+	if len(spec.ImagePullSecrets) > 0 {
+		addPullSecrets(podSpec, spec.ImagePullSecrets)
+	}
+	if spec.Resources != nil {
+		for i := range podSpec.Containers {
+			podSpec.Containers[i].Resources.Requests = spec.Resources.Requests
+			podSpec.Containers[i].Resources.Limits = spec.Resources.Limits
+		}
+	}
+	if len(spec.Args) > 0 {
+		podSpec.Containers[0].Args = spec.Args
+	}
+	for _, env := range spec.Env {
+		setContainerEnv(&(podSpec.Containers[0]), env.Name, env.Value)
+	}
 
+	if podSpec.SecurityContext == nil {
+		podSpec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+	podSpec.SecurityContext.WindowsOptions = &corev1.WindowsSecurityContextOptions{
+		HostProcess:   ptr.To(true),
+		RunAsUserName: ptr.To(windowsHostProcessUserName),
+	}
+	podSpec.HostNetwork = true
 	return nil
 }
 
@@ -1065,7 +1485,18 @@ func TransformDriver(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n C
 	// Used by k8s-driver-manager to decide if driver cleanup is needed and by
 	// nvidia-driver container to skip full reinstall for matching configurations.
 	driverConfig := extractDriverInstallConfig(&obj.Spec.Template.Spec)
+	// the content of the rendered kernel module parameters is not part of the pod spec
+	driverConfig.KernelModuleParameters = config.Driver.KernelModuleConfig.ModuleParameterFiles()
+	// nor the content of the trusted CA bundle
+	if trustedCA := config.Proxy.GetTrustedCA(); trustedCA != nil {
+		driverConfig.TrustedCAConfigMapName = trustedCA.Name
+		driverConfig.TrustedCABundleDigest, err = n.trustedCABundleDigest(trustedCA)
+		if err != nil {
+			return err
+		}
+	}
 	configDigest := utils.GetObjectHashIgnoreEmptyKeys(driverConfig)
+	driverconfig.SetConfigDigest(configDigest, &obj.ObjectMeta)
 
 	// Set the computed digest in driver-manager initContainer
 	driverManagerContainer := findContainerByName(obj.Spec.Template.Spec.InitContainers, "k8s-driver-manager")
@@ -1085,8 +1516,23 @@ func TransformDriver(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n C
 		setContainerEnv(driverToolkitContainer, driverconfig.DriverConfigDigestEnvName, configDigest)
 	}
 
+	// the logging settings are applied once the digest is computed, so that changing them restarts the driver
+	// pods without reinstalling the driver
+	if driverContainer != nil {
+		if err := applyContainerLogging(obj, driverContainer, config.Driver.Logging); err != nil {
+			return err
+		}
+	}
+
 	// set hostNetwork for driver if specified
 	applyHostNetworkConfig(&obj.Spec.Template.Spec, config.Driver.HostNetwork)
+	applySchedulerName(&obj.Spec.Template.Spec, config.Driver.SchedulerName)
+	applyPriorityClassName(&obj.Spec.Template.Spec, config.Driver.PriorityClassName)
+	applyRuntimeClassName(&obj.Spec.Template.Spec, config.Driver.RuntimeClassName)
+	applyTolerations(&obj.Spec.Template.Spec, config.Driver.Tolerations)
+	if err := applyNodeAffinity(&obj.Spec.Template.Spec, config.Driver.NodeAffinity); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -1113,6 +1559,18 @@ func TransformVGPUManager(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec
 
 	// set hostNetwork for vgpu-manager if specified
 	applyHostNetworkConfig(&obj.Spec.Template.Spec, config.VGPUManager.HostNetwork)
+	applySchedulerName(&obj.Spec.Template.Spec, config.VGPUManager.SchedulerName)
+	applyPriorityClassName(&obj.Spec.Template.Spec, config.VGPUManager.PriorityClassName)
+	applyRuntimeClassName(&obj.Spec.Template.Spec, config.VGPUManager.RuntimeClassName)
+	applyTolerations(&obj.Spec.Template.Spec, config.VGPUManager.Tolerations)
+	if err := applyNodeAffinity(&obj.Spec.Template.Spec, config.VGPUManager.NodeAffinity); err != nil {
+		return err
+	}
+
+	// the upgrade controller deletes the vGPU manager pods node by node when the vGPU manager upgrades are managed
+	if config.Driver.IsAutoUpgradeEnabledFor(gpuv1.DriverUpgradeTypeVGPUHostManager) {
+		obj.Spec.UpdateStrategy = appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType}
+	}
 
 	return nil
 }
@@ -1120,7 +1578,7 @@ func TransformVGPUManager(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec
 // applyOCPProxySpec applies proxy settings to podSpec
 func applyOCPProxySpec(n ClusterPolicyController, podSpec *corev1.PodSpec) error {
 	// Pass HTTPS_PROXY, HTTP_PROXY and NO_PROXY env if set in clusterwide proxy for OCP
-	proxy, err := GetClusterWideProxy(n.ctx)
+	proxy, err := GetClusterWideProxy(n.ctx, n.kubeConfig())
 	if err != nil {
 		return fmt.Errorf("ERROR: failed to get clusterwide proxy object: %s", err)
 	}
@@ -1136,8 +1594,9 @@ func applyOCPProxySpec(n ClusterPolicyController, podSpec *corev1.PodSpec) error
 			continue
 		}
 
+		// the download cache and the proxy of the ClusterPolicy, when set, take precedence over the clusterwide proxy
 		proxyEnv := getProxyEnv(proxy)
-		if len(proxyEnv) != 0 {
+		if len(proxyEnv) != 0 && !n.singleton.Spec.DownloadCache.IsEnabled() && !n.singleton.Spec.Proxy.HasProxyEnv() {
 			podSpec.Containers[i].Env = append(podSpec.Containers[i].Env, proxyEnv...)
 		}
 
@@ -1230,11 +1689,16 @@ func getProxyEnv(proxyConfig *apiconfigv1.Proxy) []corev1.EnvVar {
 	if proxyConfig == nil {
 		return envVars
 	}
-	proxies := map[string]string{
+	return proxyEnvVars(map[string]string{
 		"HTTPS_PROXY": proxyConfig.Spec.HTTPSProxy,
 		"HTTP_PROXY":  proxyConfig.Spec.HTTPProxy,
 		"NO_PROXY":    proxyConfig.Spec.NoProxy,
-	}
+	})
+}
+
+// proxyEnvVars returns the upper and lower case env variables of the set proxies, in a deterministic order
+func proxyEnvVars(proxies map[string]string) []corev1.EnvVar {
+	envVars := []corev1.EnvVar{}
 	var envs []string
 	for k := range proxies {
 		envs = append(envs, k)
@@ -1318,10 +1782,13 @@ func TransformToolkit(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n
 		}
 	}
 
+	// set container probes
+	setContainerProbes(toolkitMainContainer, config.Toolkit.StartupProbe, config.Toolkit.LivenessProbe, config.Toolkit.ReadinessProbe)
+
 	// update env required for CDI support
 	if config.CDI.IsEnabled() {
 		transformToolkitCtrForCDI(toolkitMainContainer, config.CDI.IsNRIPluginEnabled())
-	} else if n.runtime == gpuv1.CRIO {
+	} else if n.runtime == gpuv1.CRIO && !config.Toolkit.IsCRIODropInConfigEnabled() {
 		// (cdesiniotis) When CDI is not enabled and cri-o is the container runtime,
 		// we continue to install the OCI prestart hook as opposed to adding nvidia
 		// runtime handlers to the cri-o configuration. Users can override this behavior
@@ -1363,6 +1830,10 @@ func TransformToolkit(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n
 		}
 	}
 
+	// set the proxy and the trusted CA bundle of the ClusterPolicy
+	applyProxyEnv(toolkitMainContainer, config.Proxy)
+	applyTrustedCABundle(&obj.Spec.Template.Spec, toolkitMainContainer, config.Proxy)
+
 	if len(config.Toolkit.Env) > 0 {
 		for _, env := range config.Toolkit.Env {
 			setContainerEnv(toolkitMainContainer, env.Name, env.Value)
@@ -1376,9 +1847,119 @@ func TransformToolkit(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n
 		return fmt.Errorf("error transforming toolkit daemonset : %w", err)
 	}
 
+	if config.Toolkit.IsRuntimeRollbackEnabled() {
+		if err := transformToolkitRuntimeRollback(obj, config, n, toolkitMainContainer); err != nil {
+			return err
+		}
+	}
+
+	if n.runtime == gpuv1.CRIO && config.Toolkit.IsCRIODropInConfigEnabled() && !config.CDI.IsNRIPluginEnabled() {
+		transformToolkitCRIODropInConfig(toolkitMainContainer)
+	}
+
+	if err := applyContainerLogging(obj, toolkitMainContainer, config.Toolkit.Logging); err != nil {
+		return err
+	}
+
 	// set hostNetwork for toolkit if specified
 	applyHostNetworkConfig(&obj.Spec.Template.Spec, config.Toolkit.HostNetwork)
+	applySchedulerName(&obj.Spec.Template.Spec, config.Toolkit.SchedulerName)
+	applyPriorityClassName(&obj.Spec.Template.Spec, config.Toolkit.PriorityClassName)
+	applyRuntimeClassName(&obj.Spec.Template.Spec, config.Toolkit.RuntimeClassName)
+	applyTolerations(&obj.Spec.Template.Spec, config.Toolkit.Tolerations)
+	if err := applyNodeAffinity(&obj.Spec.Template.Spec, config.Toolkit.NodeAffinity); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// transformToolkitCRIODropInConfig makes the toolkit container configure the NVIDIA runtime handlers of cri-o in
+// the drop-in file only: the main cri-o configuration is only read, and the drop-in file is removed when the
+// toolkit pod is deleted, e.g. when the toolkit or the drop-in mode is disabled
+func transformToolkitCRIODropInConfig(container *corev1.Container) {
+	setContainerEnv(container, CRIOConfigModeEnvName, "config")
+
+	configVolumeName := fmt.Sprintf("%s-config", gpuv1.CRIO)
+	for i := range container.VolumeMounts {
+		if container.VolumeMounts[i].Name == configVolumeName {
+			container.VolumeMounts[i].ReadOnly = true
+		}
+	}
+
+	dropInConfigFile := getContainerEnv(container, "RUNTIME_DROP_IN_CONFIG")
+	if dropInConfigFile == "" {
+		return
+	}
+	if container.Lifecycle == nil {
+		container.Lifecycle = &corev1.Lifecycle{}
+	}
+	container.Lifecycle.PreStop = &corev1.LifecycleHandler{
+		Exec: &corev1.ExecAction{Command: []string{"/bin/sh", "-c", "rm -f " + dropInConfigFile}},
+	}
+}
+
+// transformToolkitRuntimeRollback makes the toolkit container back up the containerd configuration before
+// updating it, and adds a container restoring the backup when containerd does not come back healthy within
+// the timeout after the toolkit restarted it
+func transformToolkitRuntimeRollback(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n ClusterPolicyController, toolkitContainer *corev1.Container) error {
+	if n.runtime != gpuv1.Containerd || config.CDI.IsNRIPluginEnabled() {
+		// the toolkit only restarts the runtime when it updates the containerd configuration
+		n.logger.Info("Runtime rollback is only supported when the toolkit configures containerd, skipping")
+		return nil
+	}
+
+	image, err := gpuv1.ImagePath(&config.Validator)
+	if err != nil {
+		return err
+	}
+	setContainerEnv(toolkitContainer, RuntimeConfigBackupDirEnvName, DefaultRuntimeConfigBackupDir)
+
+	var configFiles []string
+	for _, name := range []string{"RUNTIME_CONFIG", "RUNTIME_DROP_IN_CONFIG"} {
+		if file := getContainerEnv(toolkitContainer, name); file != "" {
+			configFiles = append(configFiles, file)
+		}
+	}
+	timeoutSeconds := int32(DefaultRuntimeRollbackTimeoutSeconds)
+	if config.Toolkit.RuntimeRollback.TimeoutSeconds > 0 {
+		timeoutSeconds = config.Toolkit.RuntimeRollback.TimeoutSeconds
+	}
+
+	rollbackContainer := corev1.Container{
+		Name:            "runtime-rollback",
+		Image:           image,
+		ImagePullPolicy: gpuv1.ImagePullPolicy(config.Validator.ImagePullPolicy),
+		Command:         []string{"nvidia-validator"},
+		Env: []corev1.EnvVar{
+			{Name: "COMPONENT", Value: "runtime-rollback"},
+			{
+				Name: "NODE_NAME",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"},
+				},
+			},
+			{Name: RuntimeConfigBackupDirEnvName, Value: DefaultRuntimeConfigBackupDir},
+			{Name: "ROLLBACK_CONFIG_FILES", Value: strings.Join(configFiles, ",")},
+			{Name: "ROLLBACK_RUNTIME_SOCKET", Value: getContainerEnv(toolkitContainer, "RUNTIME_SOCKET")},
+			{Name: "ROLLBACK_TIMEOUT", Value: fmt.Sprintf("%ds", timeoutSeconds)},
+		},
+		SecurityContext: &corev1.SecurityContext{Privileged: ptr.To(true)},
+	}
+	// the container restores the configuration files and restarts containerd through the mounts of the toolkit
+	runtime := n.runtime.String()
+	for _, mount := range toolkitContainer.VolumeMounts {
+		switch mount.Name {
+		case "toolkit-root", "host-root", fmt.Sprintf("%s-config", runtime), fmt.Sprintf("%s-drop-in-config", runtime), fmt.Sprintf("%s-socket", runtime):
+			rollbackContainer.VolumeMounts = append(rollbackContainer.VolumeMounts, mount)
+		}
+	}
+	transformValidatorSecurityContext(&rollbackContainer)
+	obj.Spec.Template.Spec.Containers = append(obj.Spec.Template.Spec.Containers, rollbackContainer)
 
+	if len(config.Validator.ImagePullSecrets) > 0 {
+		addPullSecrets(&obj.Spec.Template.Spec, config.Validator.ImagePullSecrets)
+	}
 	return nil
 }
 
@@ -1541,6 +2122,11 @@ func transformDevicePluginCtrForCDI(container *corev1.Container, config *gpuv1.C
 	}
 }
 
+func transformSandboxDevicePluginCtrForCDI(container *corev1.Container) {
+	setContainerEnv(container, CDIEnabledEnvName, "true")
+	setContainerEnv(container, DeviceListStrategyEnvName, "cdi-cri")
+}
+
 // TransformDevicePlugin transforms k8s-device-plugin daemonset with required config as per ClusterPolicy
 func TransformDevicePlugin(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n ClusterPolicyController) error {
 	devicePluginContainerName := "nvidia-device-plugin"
@@ -1578,6 +2164,9 @@ func TransformDevicePlugin(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpe
 			obj.Spec.Template.Spec.Containers[i].Resources.Limits = config.DevicePlugin.Resources.Limits
 		}
 	}
+
+	// set container probes
+	setContainerProbes(devicePluginMainContainer, config.DevicePlugin.StartupProbe, config.DevicePlugin.LivenessProbe, config.DevicePlugin.ReadinessProbe)
 	// set arguments if specified for device-plugin container
 	if len(config.DevicePlugin.Args) > 0 {
 		devicePluginMainContainer.Args = config.DevicePlugin.Args
@@ -1632,6 +2221,13 @@ func TransformDevicePlugin(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpe
 
 	// set hostNetwork for device-plugin if specified
 	applyHostNetworkConfig(&obj.Spec.Template.Spec, config.DevicePlugin.HostNetwork)
+	applySchedulerName(&obj.Spec.Template.Spec, config.DevicePlugin.SchedulerName)
+	applyPriorityClassName(&obj.Spec.Template.Spec, config.DevicePlugin.PriorityClassName)
+	applyRuntimeClassName(&obj.Spec.Template.Spec, config.DevicePlugin.RuntimeClassName)
+	applyTolerations(&obj.Spec.Template.Spec, config.DevicePlugin.Tolerations)
+	if err := applyNodeAffinity(&obj.Spec.Template.Spec, config.DevicePlugin.NodeAffinity); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -1677,8 +2273,30 @@ func TransformMPSControlDaemon(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolic
 		}
 	}
 
-	// apply plugin configuration through ConfigMap if one is provided
-	err = handleDevicePluginConfig(obj, config)
+	// apply the MPS control daemon spec on top of the device plugin settings
+	if mps := config.MPSControlDaemon; mps != nil {
+		if len(mps.NodeSelector) > 0 {
+			if obj.Spec.Template.Spec.NodeSelector == nil {
+				obj.Spec.Template.Spec.NodeSelector = make(map[string]string)
+			}
+			for key, value := range mps.NodeSelector {
+				obj.Spec.Template.Spec.NodeSelector[key] = value
+			}
+		}
+		if mps.Resources != nil {
+			mpsControlMainContainer.Resources.Requests = mps.Resources.Requests
+			mpsControlMainContainer.Resources.Limits = mps.Resources.Limits
+		}
+		if len(mps.Args) > 0 {
+			mpsControlMainContainer.Args = mps.Args
+		}
+		for _, env := range mps.Env {
+			setContainerEnv(mpsControlMainContainer, env.Name, env.Value)
+		}
+	}
+
+	// apply plugin configuration through ConfigMap if one is provided
+	err = handleDevicePluginConfig(obj, config)
 	if err != nil {
 		return err
 	}
@@ -1704,10 +2322,41 @@ func TransformMPSControlDaemon(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolic
 
 	// set hostNetwork for mps-control-daemon if specified
 	applyHostNetworkConfig(&obj.Spec.Template.Spec, config.DevicePlugin.HostNetwork)
+	applySchedulerName(&obj.Spec.Template.Spec, config.DevicePlugin.SchedulerName)
+	applyPriorityClassName(&obj.Spec.Template.Spec, config.DevicePlugin.PriorityClassName)
+	applyRuntimeClassName(&obj.Spec.Template.Spec, config.DevicePlugin.RuntimeClassName)
+	applyTolerations(&obj.Spec.Template.Spec, config.DevicePlugin.Tolerations)
+	// MPS cannot share the GPUs partitioned with MIG, keep the daemon off the nodes with a MIG configuration
+	if err := applyNodeAffinity(&obj.Spec.Template.Spec, migDisabledNodeAffinity()); err != nil {
+		return err
+	}
+	if err := applyNodeAffinity(&obj.Spec.Template.Spec, config.DevicePlugin.NodeAffinity); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// migDisabledNodeAffinity returns a node affinity matching the nodes without MIG configuration, or with MIG disabled
+func migDisabledNodeAffinity() *corev1.NodeAffinity {
+	return &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				{
+					MatchExpressions: []corev1.NodeSelectorRequirement{
+						{Key: migConfigLabelKey, Operator: corev1.NodeSelectorOpDoesNotExist},
+					},
+				},
+				{
+					MatchExpressions: []corev1.NodeSelectorRequirement{
+						{Key: migConfigLabelKey, Operator: corev1.NodeSelectorOpIn, Values: []string{migConfigDisabledValue}},
+					},
+				},
+			},
+		},
+	}
+}
+
 // TransformSandboxDevicePlugin transforms sandbox-device-plugin daemonset with required config as per ClusterPolicy
 func TransformSandboxDevicePlugin(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n ClusterPolicyController) error {
 	// update validation container
@@ -1747,8 +2396,20 @@ func TransformSandboxDevicePlugin(obj *appsv1.DaemonSet, config *gpuv1.ClusterPo
 		}
 	}
 
+	// generate CDI specs for VFIO-bound GPUs so that KubeVirt can request devices through CDI
+	if config.CDI.IsEnabled() && config.CDI.IsSandboxEnabled() {
+		transformSandboxDevicePluginCtrForCDI(&(obj.Spec.Template.Spec.Containers[0]))
+	}
+
 	// set hostNetwork for sandbox-device-plugin if specified
 	applyHostNetworkConfig(&obj.Spec.Template.Spec, config.SandboxDevicePlugin.HostNetwork)
+	applySchedulerName(&obj.Spec.Template.Spec, config.SandboxDevicePlugin.SchedulerName)
+	applyPriorityClassName(&obj.Spec.Template.Spec, config.SandboxDevicePlugin.PriorityClassName)
+	applyRuntimeClassName(&obj.Spec.Template.Spec, config.SandboxDevicePlugin.RuntimeClassName)
+	applyTolerations(&obj.Spec.Template.Spec, config.SandboxDevicePlugin.Tolerations)
+	if err := applyNodeAffinity(&obj.Spec.Template.Spec, config.SandboxDevicePlugin.NodeAffinity); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -1787,6 +2448,13 @@ func TransformKataDevicePlugin(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolic
 
 	// set hostNetwork for kata-device-plugin if specified
 	applyHostNetworkConfig(&obj.Spec.Template.Spec, config.KataSandboxDevicePlugin.HostNetwork)
+	applySchedulerName(&obj.Spec.Template.Spec, config.KataSandboxDevicePlugin.SchedulerName)
+	applyPriorityClassName(&obj.Spec.Template.Spec, config.KataSandboxDevicePlugin.PriorityClassName)
+	applyRuntimeClassName(&obj.Spec.Template.Spec, config.KataSandboxDevicePlugin.RuntimeClassName)
+	applyTolerations(&obj.Spec.Template.Spec, config.KataSandboxDevicePlugin.Tolerations)
+	if err := applyNodeAffinity(&obj.Spec.Template.Spec, config.KataSandboxDevicePlugin.NodeAffinity); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -1826,6 +2494,9 @@ func TransformDCGMExporter(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpe
 			obj.Spec.Template.Spec.Containers[i].Resources.Limits = config.DCGMExporter.Resources.Limits
 		}
 	}
+
+	// set container probes
+	setContainerProbes(&(obj.Spec.Template.Spec.Containers[0]), config.DCGMExporter.StartupProbe, config.DCGMExporter.LivenessProbe, config.DCGMExporter.ReadinessProbe)
 	// set arguments if specified for exporter container
 	if len(config.DCGMExporter.Args) > 0 {
 		obj.Spec.Template.Spec.Containers[0].Args = config.DCGMExporter.Args
@@ -1849,9 +2520,16 @@ func TransformDCGMExporter(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpe
 		obj.Spec.Template.Spec.HostNetwork = true
 		obj.Spec.Template.Spec.DNSPolicy = corev1.DNSClusterFirstWithHostNet
 	}
+	applySchedulerName(&obj.Spec.Template.Spec, config.DCGMExporter.SchedulerName)
 
 	setNRIPluginAnnotation(&obj.Spec.Template.ObjectMeta, &config.CDI, obj.Spec.Template.Spec.Containers[0].Name)
 	setRuntimeClassName(&obj.Spec.Template.Spec, config, n.runtime)
+	applyPriorityClassName(&obj.Spec.Template.Spec, config.DCGMExporter.PriorityClassName)
+	applyRuntimeClassName(&obj.Spec.Template.Spec, config.DCGMExporter.RuntimeClassName)
+	applyTolerations(&obj.Spec.Template.Spec, config.DCGMExporter.Tolerations)
+	if err := applyNodeAffinity(&obj.Spec.Template.Spec, config.DCGMExporter.NodeAffinity); err != nil {
+		return err
+	}
 
 	// set hostPID if specified for DCGM Exporter
 	if config.DCGMExporter.IsHostPIDEnabled() {
@@ -1929,7 +2607,14 @@ func TransformDCGMExporter(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpe
 
 	const podResourcesVolume = "pod-gpu-resources"
 	kubeletRootDir := config.HostPaths.KubeletRootDir
-	if len(kubeletRootDir) > 0 && kubeletRootDir != DefaultKubeletRootDir {
+	if config.DCGMExporter.IsPodAttributionDisabled() {
+		// without the kubelet pod-resources socket the exporter only reports GPU metrics
+		setContainerEnv(&(obj.Spec.Template.Spec.Containers[0]), "DCGM_EXPORTER_KUBERNETES", "false")
+		obj.Spec.Template.Spec.Containers[0].VolumeMounts = slices.DeleteFunc(obj.Spec.Template.Spec.Containers[0].VolumeMounts,
+			func(m corev1.VolumeMount) bool { return m.Name == podResourcesVolume })
+		obj.Spec.Template.Spec.Volumes = slices.DeleteFunc(obj.Spec.Template.Spec.Volumes,
+			func(v corev1.Volume) bool { return v.Name == podResourcesVolume })
+	} else if len(kubeletRootDir) > 0 && kubeletRootDir != DefaultKubeletRootDir {
 		for i := range obj.Spec.Template.Spec.Volumes {
 			volume := &obj.Spec.Template.Spec.Volumes[i]
 			if volume.Name == podResourcesVolume {
@@ -1938,6 +2623,12 @@ func TransformDCGMExporter(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpe
 			}
 		}
 	}
+	if config.DCGMExporter.IsPodAttributionEnabled() {
+		setContainerEnv(&(obj.Spec.Template.Spec.Containers[0]), "DCGM_EXPORTER_KUBERNETES", "true")
+		if gpuIDType := config.DCGMExporter.GetPodAttributionGPUIDType(); gpuIDType != "" {
+			setContainerEnv(&(obj.Spec.Template.Spec.Containers[0]), "DCGM_EXPORTER_KUBERNETES_GPU_ID_TYPE", gpuIDType)
+		}
+	}
 
 	for _, env := range config.DCGMExporter.Env {
 		setContainerEnv(&(obj.Spec.Template.Spec.Containers[0]), env.Name, env.Value)
@@ -1982,6 +2673,9 @@ func TransformDCGM(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n Clu
 			obj.Spec.Template.Spec.Containers[i].Resources.Limits = config.DCGM.Resources.Limits
 		}
 	}
+
+	// set container probes
+	setContainerProbes(&(obj.Spec.Template.Spec.Containers[0]), config.DCGM.StartupProbe, config.DCGM.LivenessProbe, config.DCGM.ReadinessProbe)
 	// set arguments if specified for exporter container
 	if len(config.DCGM.Args) > 0 {
 		obj.Spec.Template.Spec.Containers[0].Args = config.DCGM.Args
@@ -1998,6 +2692,13 @@ func TransformDCGM(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n Clu
 
 	// set hostNetwork for dcgm if specified
 	applyHostNetworkConfig(&obj.Spec.Template.Spec, config.DCGM.HostNetwork)
+	applySchedulerName(&obj.Spec.Template.Spec, config.DCGM.SchedulerName)
+	applyPriorityClassName(&obj.Spec.Template.Spec, config.DCGM.PriorityClassName)
+	applyRuntimeClassName(&obj.Spec.Template.Spec, config.DCGM.RuntimeClassName)
+	applyTolerations(&obj.Spec.Template.Spec, config.DCGM.Tolerations)
+	if err := applyNodeAffinity(&obj.Spec.Template.Spec, config.DCGM.NodeAffinity); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -2034,6 +2735,9 @@ func TransformMIGManager(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec,
 		}
 	}
 
+	// set container probes
+	setContainerProbes(&(obj.Spec.Template.Spec.Containers[0]), config.MIGManager.StartupProbe, config.MIGManager.LivenessProbe, config.MIGManager.ReadinessProbe)
+
 	// set arguments if specified for mig-manager container
 	if len(config.MIGManager.Args) > 0 {
 		obj.Spec.Template.Spec.Containers[0].Args = config.MIGManager.Args
@@ -2094,6 +2798,13 @@ func TransformMIGManager(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec,
 
 	// set hostNetwork for mig-manager if specified
 	applyHostNetworkConfig(&obj.Spec.Template.Spec, config.MIGManager.HostNetwork)
+	applySchedulerName(&obj.Spec.Template.Spec, config.MIGManager.SchedulerName)
+	applyPriorityClassName(&obj.Spec.Template.Spec, config.MIGManager.PriorityClassName)
+	applyRuntimeClassName(&obj.Spec.Template.Spec, config.MIGManager.RuntimeClassName)
+	applyTolerations(&obj.Spec.Template.Spec, config.MIGManager.Tolerations)
+	if err := applyNodeAffinity(&obj.Spec.Template.Spec, config.MIGManager.NodeAffinity); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -2106,6 +2817,22 @@ func TransformVFIOManager(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec
 		return fmt.Errorf("failed to transform k8s-driver-manager initContainer for VFIO Manager: %v", err)
 	}
 
+	// the cc-manager-validation initContainer holds off binding the GPUs to vfio-pci until
+	// cc-manager has applied the CC mode, so that mode changes never race the driver state
+	if !config.CCManager.IsEnabled() {
+		for i, initContainer := range obj.Spec.Template.Spec.InitContainers {
+			if initContainer.Name != "cc-manager-validation" {
+				continue
+			}
+			obj.Spec.Template.Spec.InitContainers = append(obj.Spec.Template.Spec.InitContainers[:i], obj.Spec.Template.Spec.InitContainers[i+1:]...)
+			break
+		}
+	}
+	err = transformValidationInitContainer(obj, config)
+	if err != nil {
+		return fmt.Errorf("failed to transform validation initContainers for VFIO Manager: %v", err)
+	}
+
 	// update image
 	image, err := gpuv1.ImagePath(&config.VFIOManager)
 	if err != nil {
@@ -2144,6 +2871,13 @@ func TransformVFIOManager(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec
 
 	// set hostNetwork for vfio-manager if specified
 	applyHostNetworkConfig(&obj.Spec.Template.Spec, config.VFIOManager.HostNetwork)
+	applySchedulerName(&obj.Spec.Template.Spec, config.VFIOManager.SchedulerName)
+	applyPriorityClassName(&obj.Spec.Template.Spec, config.VFIOManager.PriorityClassName)
+	applyRuntimeClassName(&obj.Spec.Template.Spec, config.VFIOManager.RuntimeClassName)
+	applyTolerations(&obj.Spec.Template.Spec, config.VFIOManager.Tolerations)
+	if err := applyNodeAffinity(&obj.Spec.Template.Spec, config.VFIOManager.NodeAffinity); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -2193,6 +2927,13 @@ func TransformCCManager(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec,
 
 	// set hostNetwork for cc-manager if specified
 	applyHostNetworkConfig(&obj.Spec.Template.Spec, config.CCManager.HostNetwork)
+	applySchedulerName(&obj.Spec.Template.Spec, config.CCManager.SchedulerName)
+	applyPriorityClassName(&obj.Spec.Template.Spec, config.CCManager.PriorityClassName)
+	applyRuntimeClassName(&obj.Spec.Template.Spec, config.CCManager.RuntimeClassName)
+	applyTolerations(&obj.Spec.Template.Spec, config.CCManager.Tolerations)
+	if err := applyNodeAffinity(&obj.Spec.Template.Spec, config.CCManager.NodeAffinity); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -2262,6 +3003,13 @@ func TransformVGPUDeviceManager(obj *appsv1.DaemonSet, config *gpuv1.ClusterPoli
 
 	// set hostNetwork for vgpu-device-manager if specified
 	applyHostNetworkConfig(&obj.Spec.Template.Spec, config.VGPUDeviceManager.HostNetwork)
+	applySchedulerName(&obj.Spec.Template.Spec, config.VGPUDeviceManager.SchedulerName)
+	applyPriorityClassName(&obj.Spec.Template.Spec, config.VGPUDeviceManager.PriorityClassName)
+	applyRuntimeClassName(&obj.Spec.Template.Spec, config.VGPUDeviceManager.RuntimeClassName)
+	applyTolerations(&obj.Spec.Template.Spec, config.VGPUDeviceManager.Tolerations)
+	if err := applyNodeAffinity(&obj.Spec.Template.Spec, config.VGPUDeviceManager.NodeAffinity); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -2287,10 +3035,15 @@ func TransformValidator(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec,
 	}
 
 	setRuntimeClassName(&obj.Spec.Template.Spec, config, n.runtime)
+	// the runtime class of the validator is also used by the workload validation pods
+	applyRuntimeClassName(&obj.Spec.Template.Spec, config.Validator.RuntimeClassName)
 
 	toolkitValidationCtr := findContainerByName(obj.Spec.Template.Spec.InitContainers, "toolkit-validation")
 	if toolkitValidationCtr != nil && len(toolkitValidationCtr.Name) > 0 {
 		setNRIPluginAnnotation(&obj.Spec.Template.ObjectMeta, &config.CDI, toolkitValidationCtr.Name)
+		if err := setToolkitValidationRuntimeConfig(toolkitValidationCtr, config, n.runtime); err != nil {
+			return err
+		}
 	}
 
 	var validatorErr error
@@ -2314,9 +3067,97 @@ func TransformValidator(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec,
 		n.logger.Info("WARN: errors transforming the validator containers: %v", validatorErr)
 	}
 
+	if config.Driver.GPUDirectRDMA != nil && config.Driver.GPUDirectRDMA.IsNetworkAttachmentsEnabled() {
+		if err := transformRDMATopologyDiscovery(obj, config); err != nil {
+			return err
+		}
+	}
+
 	// set hostNetwork for validator if specified
 	applyHostNetworkConfig(&obj.Spec.Template.Spec, config.Validator.HostNetwork)
+	applySchedulerName(&obj.Spec.Template.Spec, config.Validator.SchedulerName)
+	applyPriorityClassName(&obj.Spec.Template.Spec, config.Validator.PriorityClassName)
+	applyTolerations(&obj.Spec.Template.Spec, config.Validator.Tolerations)
+	if err := applyNodeAffinity(&obj.Spec.Template.Spec, config.Validator.NodeAffinity); err != nil {
+		return err
+	}
+
+	return nil
+}
 
+// transformRDMATopologyDiscovery adds an initContainer to the validator daemonset pairing the GPUs of the node
+// with their closest RDMA NIC, and publishing the pairs on the node for the networks of the GPU-NIC pairs. The
+// discovery reads the PCIe topology from sysfs and does not depend on the driver, so it runs first.
+func transformRDMATopologyDiscovery(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec) error {
+	image, err := gpuv1.ImagePath(&config.Validator)
+	if err != nil {
+		return err
+	}
+	discoveryContainer := corev1.Container{
+		Name:            "rdma-topology-discovery",
+		Image:           image,
+		ImagePullPolicy: gpuv1.ImagePullPolicy(config.Validator.ImagePullPolicy),
+		Command:         []string{"nvidia-validator"},
+		Env: []corev1.EnvVar{
+			{Name: "COMPONENT", Value: "rdma-topology"},
+			{
+				Name: "NODE_NAME",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"},
+				},
+			},
+		},
+	}
+	transformValidatorSecurityContext(&discoveryContainer)
+	podSpec := &obj.Spec.Template.Spec
+	podSpec.InitContainers = append([]corev1.Container{discoveryContainer}, podSpec.InitContainers...)
+	return nil
+}
+
+// setToolkitValidationRuntimeConfig passes the container runtime configuration the toolkit is expected
+// to apply to the toolkit-validation initContainer, which verifies it and publishes the result on the node
+func setToolkitValidationRuntimeConfig(container *corev1.Container, config *gpuv1.ClusterPolicySpec, runtime gpuv1.Runtime) error {
+	// The runtime configuration is not modified by the toolkit in NRI plugin mode, nor for cri-o
+	// without CDI, where the toolkit installs an OCI hook instead of a runtime handler.
+	crioDropInConfig := runtime == gpuv1.CRIO && config.Toolkit.IsCRIODropInConfigEnabled()
+	if !config.Toolkit.IsEnabled() || config.CDI.IsNRIPluginEnabled() || (runtime == gpuv1.CRIO && !config.CDI.IsEnabled() && !crioDropInConfig) {
+		return nil
+	}
+
+	// evaluate the toolkit env the same way the toolkit container does
+	toolkitCtr := &corev1.Container{}
+	if config.CDI.IsEnabled() {
+		transformToolkitCtrForCDI(toolkitCtr, false)
+	}
+	for _, env := range config.Toolkit.Env {
+		setContainerEnv(toolkitCtr, env.Name, env.Value)
+	}
+
+	topLevelConfigFile, dropInConfigFile, err := getRuntimeConfigFiles(toolkitCtr, runtime.String())
+	if err != nil {
+		return fmt.Errorf("error getting path to runtime config file: %w", err)
+	}
+
+	handler := DefaultRuntimeClass
+	if runtime == gpuv1.Containerd {
+		handler = getRuntimeClassName(config)
+	}
+	// the toolkit sets the NVIDIA runtime as the default runtime unless configured otherwise
+	setAsDefault := getContainerEnv(toolkitCtr, NvidiaRuntimeSetAsDefaultEnvName)
+	if setAsDefault == "" {
+		setAsDefault = "true"
+	}
+
+	setContainerEnv(container, "RUNTIME", runtime.String())
+	setContainerEnv(container, "RUNTIME_CONFIG", topLevelConfigFile)
+	setContainerEnv(container, "RUNTIME_DROP_IN_CONFIG", dropInConfigFile)
+	setContainerEnv(container, "NVIDIA_RUNTIME_HANDLER", handler)
+	setContainerEnv(container, NvidiaRuntimeSetAsDefaultEnvName, setAsDefault)
+	setContainerEnv(container, CDIEnabledEnvName, strconv.FormatBool(config.CDI.IsEnabled()))
+	if crioDropInConfig {
+		// the runtime handler is expected in the drop-in file only, and loaded by cri-o
+		setContainerEnv(container, "RUNTIME_DROP_IN_ONLY", "true")
+	}
 	return nil
 }
 
@@ -2348,6 +3189,13 @@ func TransformSandboxValidator(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolic
 
 	// set hostNetwork for sandbox-validator if specified
 	applyHostNetworkConfig(&obj.Spec.Template.Spec, config.Validator.HostNetwork)
+	applySchedulerName(&obj.Spec.Template.Spec, config.Validator.SchedulerName)
+	applyPriorityClassName(&obj.Spec.Template.Spec, config.Validator.PriorityClassName)
+	applyRuntimeClassName(&obj.Spec.Template.Spec, config.Validator.RuntimeClassName)
+	applyTolerations(&obj.Spec.Template.Spec, config.Validator.Tolerations)
+	if err := applyNodeAffinity(&obj.Spec.Template.Spec, config.Validator.NodeAffinity); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -2409,6 +3257,8 @@ func TransformValidatorComponent(config *gpuv1.ClusterPolicySpec, podSpec *corev
 		}
 		// update the security context for the validator container
 		transformValidatorSecurityContext(&podSpec.InitContainers[i])
+		// pass the validation options of the component
+		setValidatorComponentOptions(&podSpec.InitContainers[i], config, component)
 
 		switch component {
 		case "cuda":
@@ -2455,6 +3305,14 @@ func TransformValidatorComponent(config *gpuv1.ClusterPolicySpec, podSpec *corev
 				}
 			}
 		case "driver":
+			// report the driver ready only once the NVLink fabric registration of the node is complete
+			if config.NVLinkFabric.IsEnabled() {
+				setContainerEnv(&(podSpec.InitContainers[i]), ValidatorNVLinkFabricEnabledEnvName, "true")
+			}
+			// label the node with the CUDA compatibility of the driver once it is ready
+			if config.Validator.Driver.IsCUDACompatLabelsEnabled() {
+				setContainerEnv(&(podSpec.InitContainers[i]), ValidatorCUDACompatLabelsEnabledEnvName, "true")
+			}
 			// set/append environment variables for driver-validation container
 			if len(config.Validator.Driver.Env) > 0 {
 				for _, env := range config.Validator.Driver.Env {
@@ -2467,6 +3325,12 @@ func TransformValidatorComponent(config *gpuv1.ClusterPolicySpec, podSpec *corev
 				podSpec.InitContainers = append(podSpec.InitContainers[:i], podSpec.InitContainers[i+1:]...)
 				return nil
 			}
+			// set/append environment variables for cc-manager-validation container
+			if len(config.Validator.CCManager.Env) > 0 {
+				for _, env := range config.Validator.CCManager.Env {
+					setContainerEnv(&(podSpec.InitContainers[i]), env.Name, env.Value)
+				}
+			}
 		case "toolkit":
 			// set/append environment variables for toolkit-validation container
 			if len(config.Validator.Toolkit.Env) > 0 {
@@ -2505,6 +3369,20 @@ func TransformValidatorComponent(config *gpuv1.ClusterPolicySpec, podSpec *corev
 	return nil
 }
 
+// setValidatorComponentOptions passes the validation options of a component to the nvidia-validator container validating it
+func setValidatorComponentOptions(container *corev1.Container, config *gpuv1.ClusterPolicySpec, component string) {
+	spec := config.Validator.GetComponentSpec(component)
+	if spec == nil {
+		return
+	}
+	if !spec.IsEnabled() {
+		setContainerEnv(container, ValidatorSkipValidationEnvName, "true")
+	}
+	if spec.Timeout != nil && spec.Timeout.Duration > 0 {
+		setContainerEnv(container, ValidatorValidationTimeoutEnvName, spec.Timeout.Duration.String())
+	}
+}
+
 // TransformNodeStatusExporter transforms the node-status-exporter daemonset with required config as per ClusterPolicy
 func TransformNodeStatusExporter(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n ClusterPolicyController) error {
 	// update image
@@ -2531,6 +3409,9 @@ func TransformNodeStatusExporter(obj *appsv1.DaemonSet, config *gpuv1.ClusterPol
 		}
 	}
 
+	// set container probes
+	setContainerProbes(&(obj.Spec.Template.Spec.Containers[0]), config.NodeStatusExporter.StartupProbe, config.NodeStatusExporter.LivenessProbe, config.NodeStatusExporter.ReadinessProbe)
+
 	// set arguments if specified for driver container
 	if len(config.NodeStatusExporter.Args) > 0 {
 		obj.Spec.Template.Spec.Containers[0].Args = config.NodeStatusExporter.Args
@@ -2548,10 +3429,323 @@ func TransformNodeStatusExporter(obj *appsv1.DaemonSet, config *gpuv1.ClusterPol
 
 	// set hostNetwork for node-status-exporter if specified
 	applyHostNetworkConfig(&obj.Spec.Template.Spec, config.NodeStatusExporter.HostNetwork)
+	applySchedulerName(&obj.Spec.Template.Spec, config.NodeStatusExporter.SchedulerName)
+	applyPriorityClassName(&obj.Spec.Template.Spec, config.NodeStatusExporter.PriorityClassName)
+	applyRuntimeClassName(&obj.Spec.Template.Spec, config.NodeStatusExporter.RuntimeClassName)
+	applyTolerations(&obj.Spec.Template.Spec, config.NodeStatusExporter.Tolerations)
+	if err := applyNodeAffinity(&obj.Spec.Template.Spec, config.NodeStatusExporter.NodeAffinity); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// TransformGPUHealthCheck transforms the GPU health check daemonset with required config as per ClusterPolicy
+func TransformGPUHealthCheck(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n ClusterPolicyController) error {
+	// update image
+	image, err := gpuv1.ImagePath(config.HealthCheck)
+	if err != nil {
+		return err
+	}
+	obj.Spec.Template.Spec.Containers[0].Image = image
+
+	// update image pull policy
+	obj.Spec.Template.Spec.Containers[0].ImagePullPolicy = gpuv1.ImagePullPolicy(config.HealthCheck.ImagePullPolicy)
+
+	// set image pull secrets
+	if len(config.HealthCheck.ImagePullSecrets) > 0 {
+		addPullSecrets(&obj.Spec.Template.Spec, config.HealthCheck.ImagePullSecrets)
+	}
+
+	// set resource limits
+	if config.HealthCheck.Resources != nil {
+		obj.Spec.Template.Spec.Containers[0].Resources.Requests = config.HealthCheck.Resources.Requests
+		obj.Spec.Template.Spec.Containers[0].Resources.Limits = config.HealthCheck.Resources.Limits
+	}
+
+	// set the XID errors marking the node unhealthy, the validator defaults are used otherwise
+	if len(config.HealthCheck.CriticalXIDs) > 0 {
+		xids := make([]string, 0, len(config.HealthCheck.CriticalXIDs))
+		for _, xid := range config.HealthCheck.CriticalXIDs {
+			xids = append(xids, strconv.Itoa(int(xid)))
+		}
+		setContainerEnv(&(obj.Spec.Template.Spec.Containers[0]), "CRITICAL_XIDS", strings.Join(xids, ","))
+	}
+
+	// set/append environment variables for the health check container
+	for _, env := range config.HealthCheck.Env {
+		setContainerEnv(&(obj.Spec.Template.Spec.Containers[0]), env.Name, env.Value)
+	}
+
+	return nil
+}
+
+// TransformNVLinkFabric transforms the NVLink fabric registration daemonset with required config as per ClusterPolicy
+func TransformNVLinkFabric(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n ClusterPolicyController) error {
+	// update image
+	image, err := gpuv1.ImagePath(config.NVLinkFabric)
+	if err != nil {
+		return err
+	}
+	obj.Spec.Template.Spec.Containers[0].Image = image
+
+	// update image pull policy
+	obj.Spec.Template.Spec.Containers[0].ImagePullPolicy = gpuv1.ImagePullPolicy(config.NVLinkFabric.ImagePullPolicy)
+
+	// set image pull secrets
+	if len(config.NVLinkFabric.ImagePullSecrets) > 0 {
+		addPullSecrets(&obj.Spec.Template.Spec, config.NVLinkFabric.ImagePullSecrets)
+	}
+
+	// set resource limits
+	if config.NVLinkFabric.Resources != nil {
+		obj.Spec.Template.Spec.Containers[0].Resources.Requests = config.NVLinkFabric.Resources.Requests
+		obj.Spec.Template.Spec.Containers[0].Resources.Limits = config.NVLinkFabric.Resources.Limits
+	}
+
+	// set container probes
+	setContainerProbes(&(obj.Spec.Template.Spec.Containers[0]), config.NVLinkFabric.StartupProbe, config.NVLinkFabric.LivenessProbe, config.NVLinkFabric.ReadinessProbe)
+
+	// set/append environment variables for the NVLink fabric registration container
+	for _, env := range config.NVLinkFabric.Env {
+		setContainerEnv(&(obj.Spec.Template.Spec.Containers[0]), env.Name, env.Value)
+	}
+
+	return nil
+}
+
+// TransformMockGPUs transforms the mock GPUs daemonset with required config as per ClusterPolicy
+func TransformMockGPUs(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n ClusterPolicyController) error {
+	mockGPUs := config.Devel.GetMockGPUs()
+	container := &obj.Spec.Template.Spec.Containers[0]
+
+	// the mock GPUs are advertised by the validator
+	image, err := gpuv1.ImagePath(&config.Validator)
+	if err != nil {
+		return err
+	}
+	container.Image = image
+	container.ImagePullPolicy = gpuv1.ImagePullPolicy(config.Validator.ImagePullPolicy)
+	if len(config.Validator.ImagePullSecrets) > 0 {
+		addPullSecrets(&obj.Spec.Template.Spec, config.Validator.ImagePullSecrets)
+	}
+
+	setContainerEnv(container, "MOCK_GPU_COUNT", strconv.Itoa(mockGPUs.GetCount()))
+	setContainerEnv(container, "MOCK_GPU_PRODUCT", mockGPUs.GetProduct())
+	setContainerEnv(container, "MOCK_GPU_MEMORY", strconv.Itoa(mockGPUs.GetMemoryMiB()))
+
+	// the nodes without GPU are selected by the node affinity of the daemonset, narrowed by the node selector
+	if mockGPUs != nil && len(mockGPUs.NodeSelector) > 0 {
+		if obj.Spec.Template.Spec.NodeSelector == nil {
+			obj.Spec.Template.Spec.NodeSelector = map[string]string{}
+		}
+		maps.Copy(obj.Spec.Template.Spec.NodeSelector, mockGPUs.NodeSelector)
+	}
+	return nil
+}
+
+// TransformImagePrePull transforms the image pre-pull daemonset with required config as per ClusterPolicy
+func TransformImagePrePull(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n ClusterPolicyController) error {
+	podSpec := &obj.Spec.Template.Spec
+
+	// update image
+	image, err := gpuv1.ImagePath(config.ImagePrePull)
+	if err != nil {
+		return err
+	}
+	podSpec.Containers[0].Image = image
+
+	// update image pull policy
+	podSpec.Containers[0].ImagePullPolicy = gpuv1.ImagePullPolicy(config.ImagePrePull.ImagePullPolicy)
+
+	// set image pull secrets
+	if len(config.ImagePrePull.ImagePullSecrets) > 0 {
+		addPullSecrets(podSpec, config.ImagePrePull.ImagePullSecrets)
+	}
+
+	// set resource limits
+	if config.ImagePrePull.Resources != nil {
+		podSpec.Containers[0].Resources.Requests = config.ImagePrePull.Resources.Requests
+		podSpec.Containers[0].Resources.Limits = config.ImagePrePull.Resources.Limits
+	}
+
+	// the pre-pull must never preempt workloads, so the priority class of all operands is not inherited
+	podSpec.PriorityClassName = config.ImagePrePull.PriorityClassName
+
+	// pre-pull the driver image, unless it depends on the kernel of the node or is managed by the
+	// NVIDIADriver CRD
+	var initContainers []corev1.Container
+	if config.Driver.IsEnabled() && !config.Driver.UseNvidiaDriverCRDType() && !config.Driver.UsePrecompiledDrivers() {
+		driverContainer := findContainerByName(podSpec.InitContainers, "driver-image")
+		if driverContainer == nil {
+			return fmt.Errorf("failed to find init container driver-image in DaemonSet %s", obj.Name)
+		}
+		driverImage, err := resolveDriverTag(n, &config.Driver)
+		if err != nil {
+			return err
+		}
+		driverContainer.Image = driverImage
+		driverContainer.ImagePullPolicy = gpuv1.ImagePullPolicy(config.Driver.ImagePullPolicy)
+		addPullSecrets(podSpec, config.Driver.ImagePullSecrets)
+		setContainerEnv(&podSpec.Containers[0], "PREPULL_DRIVER_IMAGE", driverImage)
+		initContainers = append(initContainers, *driverContainer)
+	}
+
+	// pre-pull the pre-compiled driver images of the kernel versions announced for the upcoming OS upgrades
+	if config.Driver.IsEnabled() && !config.Driver.UseNvidiaDriverCRDType() && config.Driver.UsePrecompiledDrivers() &&
+		len(n.preStagedKernelVersions) > 0 {
+		driverContainer := findContainerByName(podSpec.InitContainers, "driver-image")
+		if driverContainer == nil {
+			return fmt.Errorf("failed to find init container driver-image in DaemonSet %s", obj.Name)
+		}
+		kernelVersions := make([]string, 0, len(n.preStagedKernelVersions))
+		for kernelVersion := range n.preStagedKernelVersions {
+			kernelVersions = append(kernelVersions, kernelVersion)
+		}
+		sort.Strings(kernelVersions)
+		for i, kernelVersion := range kernelVersions {
+			// n is a copy, setting the kernel version does not leak out of the transformation
+			n.currentKernelVersion = kernelVersion
+			driverImage, err := resolveDriverTag(n, &config.Driver)
+			if err != nil {
+				return err
+			}
+			container := driverContainer.DeepCopy()
+			// kernel versions are not valid container names
+			container.Name = fmt.Sprintf("driver-image-os-upgrade-%d", i)
+			container.Image = driverImage
+			container.ImagePullPolicy = gpuv1.ImagePullPolicy(config.Driver.ImagePullPolicy)
+			initContainers = append(initContainers, *container)
+		}
+		addPullSecrets(podSpec, config.Driver.ImagePullSecrets)
+	}
+
+	// pre-pull the toolkit image
+	if config.Toolkit.IsEnabled() {
+		toolkitContainer := findContainerByName(podSpec.InitContainers, "toolkit-image")
+		if toolkitContainer == nil {
+			return fmt.Errorf("failed to find init container toolkit-image in DaemonSet %s", obj.Name)
+		}
+		toolkitImage, err := gpuv1.ImagePath(&config.Toolkit)
+		if err != nil {
+			return err
+		}
+		toolkitContainer.Image = toolkitImage
+		toolkitContainer.ImagePullPolicy = gpuv1.ImagePullPolicy(config.Toolkit.ImagePullPolicy)
+		addPullSecrets(podSpec, config.Toolkit.ImagePullSecrets)
+		setContainerEnv(&podSpec.Containers[0], "PREPULL_TOOLKIT_IMAGE", toolkitImage)
+		initContainers = append(initContainers, *toolkitContainer)
+	}
+	podSpec.InitContainers = initContainers
+
+	// set/append environment variables for the image pre-pull container
+	for _, env := range config.ImagePrePull.Env {
+		setContainerEnv(&podSpec.Containers[0], env.Name, env.Value)
+	}
+
+	return nil
+}
+
+// TransformDownloadCache transforms the download cache Deployment with required config as per ClusterPolicy
+func TransformDownloadCache(obj *appsv1.Deployment, config *gpuv1.ClusterPolicySpec, n ClusterPolicyController) error {
+	podSpec := &obj.Spec.Template.Spec
+	container := findContainerByName(podSpec.Containers, DownloadCacheName)
+	if container == nil {
+		return fmt.Errorf("failed to find container %s in Deployment %s", DownloadCacheName, obj.Name)
+	}
+
+	// update image
+	image, err := gpuv1.ImagePath(config.DownloadCache)
+	if err != nil {
+		return err
+	}
+	container.Image = image
+
+	// update image pull policy
+	container.ImagePullPolicy = gpuv1.ImagePullPolicy(config.DownloadCache.ImagePullPolicy)
+
+	// set image pull secrets
+	if len(config.DownloadCache.ImagePullSecrets) > 0 {
+		addPullSecrets(podSpec, config.DownloadCache.ImagePullSecrets)
+	}
+
+	// set resource limits
+	if config.DownloadCache.Resources != nil {
+		container.Resources.Requests = config.DownloadCache.Resources.Requests
+		container.Resources.Limits = config.DownloadCache.Resources.Limits
+	}
+
+	// set/append environment variables for download cache container
+	for _, env := range config.DownloadCache.Env {
+		setContainerEnv(container, env.Name, env.Value)
+	}
+
+	// back the cache with the configured PersistentVolumeClaim, or with a size-limited emptyDir
+	storageSize := config.DownloadCache.GetStorageSize()
+	for i := range podSpec.Volumes {
+		if podSpec.Volumes[i].Name != "cache" {
+			continue
+		}
+		if config.DownloadCache.PersistentVolumeClaim != "" {
+			podSpec.Volumes[i].VolumeSource = corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: config.DownloadCache.PersistentVolumeClaim},
+			}
+		} else {
+			podSpec.Volumes[i].VolumeSource = corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: &storageSize},
+			}
+		}
+	}
+
+	// roll out the download cache pod when its configuration changes
+	if obj.Spec.Template.Annotations == nil {
+		obj.Spec.Template.Annotations = make(map[string]string)
+	}
+	obj.Spec.Template.Annotations[DownloadCacheConfigDigestAnnotationKey] = utils.GetObjectHash(renderDownloadCacheConfig(config.DownloadCache))
+
+	return nil
+}
+
+// TransformNodeFeatureDiscoveryMaster transforms the NFD master Deployment with required config as per ClusterPolicy
+func TransformNodeFeatureDiscoveryMaster(obj *appsv1.Deployment, config *gpuv1.ClusterPolicySpec, n ClusterPolicyController) error {
+	// the NFD workers report the node features through NodeFeature objects, whose CRD the operator does not install
+	exists, err := crdExists(n, NodeFeatureCRDName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("the CRD %s must be installed to deploy Node Feature Discovery", NodeFeatureCRDName)
+	}
+	return transformNodeFeatureDiscovery(&obj.Spec.Template.Spec, config.NodeFeatureDiscovery)
+}
+
+// TransformNodeFeatureDiscoveryWorker transforms the NFD worker daemonset with required config as per ClusterPolicy
+func TransformNodeFeatureDiscoveryWorker(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n ClusterPolicyController) error {
+	return transformNodeFeatureDiscovery(&obj.Spec.Template.Spec, config.NodeFeatureDiscovery)
+}
+
+// transformNodeFeatureDiscovery applies the image and the resources of the NFD spec to the NFD master or worker pods
+func transformNodeFeatureDiscovery(podSpec *corev1.PodSpec, spec *gpuv1.NodeFeatureDiscoverySpec) error {
+	if spec == nil {
+		spec = &gpuv1.NodeFeatureDiscoverySpec{}
+	}
+	img, err := gpuv1.ImagePath(spec)
+	if err != nil {
+		return err
+	}
+	podSpec.Containers[0].Image = img
+	podSpec.Containers[0].ImagePullPolicy = gpuv1.ImagePullPolicy(spec.ImagePullPolicy)
+
+	if len(spec.ImagePullSecrets) > 0 {
+		addPullSecrets(podSpec, spec.ImagePullSecrets)
+	}
+	if spec.Resources != nil {
+		podSpec.Containers[0].Resources.Requests = spec.Resources.Requests
+		podSpec.Containers[0].Resources.Limits = spec.Resources.Limits
+	}
+	return nil
+}
+
 // getRuntimeConfigFiles returns the path to the top-level and drop-in config files that
 // should be used when configuring the specified container runtime.
 func getRuntimeConfigFiles(c *corev1.Container, runtime string) (string, string, error) {
@@ -2720,17 +3914,42 @@ func setRuntimeClassName(podSpec *corev1.PodSpec, config *gpuv1.ClusterPolicySpe
 	podSpec.RuntimeClassName = &runtimeClassName
 }
 
+// setContainerProbes overrides the probes of the operand container with the probe settings of its component
+func setContainerProbes(container *corev1.Container, startup, liveness, readiness *gpuv1.ContainerProbeSpec) {
+	if startup != nil {
+		setContainerProbe(container, startup, Startup)
+	}
+	if liveness != nil {
+		setContainerProbe(container, liveness, Liveness)
+	}
+	if readiness != nil {
+		setContainerProbe(container, readiness, Readiness)
+	}
+}
+
 func setContainerProbe(container *corev1.Container, probe *gpuv1.ContainerProbeSpec, probeType ContainerProbe) {
-	var containerProbe *corev1.Probe
+	var probeRef **corev1.Probe
 
 	// determine probe type to update
 	switch probeType {
 	case Startup:
-		containerProbe = container.StartupProbe
+		probeRef = &container.StartupProbe
 	case Liveness:
-		containerProbe = container.LivenessProbe
+		probeRef = &container.LivenessProbe
 	case Readiness:
-		containerProbe = container.ReadinessProbe
+		probeRef = &container.ReadinessProbe
+	}
+
+	// a probe not defined by the operand has no handler, unless a command is set
+	if len(probe.Command) > 0 {
+		if *probeRef == nil {
+			*probeRef = &corev1.Probe{}
+		}
+		(*probeRef).ProbeHandler = corev1.ProbeHandler{Exec: &corev1.ExecAction{Command: probe.Command}}
+	}
+	containerProbe := *probeRef
+	if containerProbe == nil {
+		return
 	}
 
 	// set probe parameters if specified
@@ -2759,18 +3978,180 @@ func applyMIGConfiguration(c *corev1.Container, strategy gpuv1.MIGStrategy) {
 		return
 	}
 
-	setContainerEnv(c, "MIG_STRATEGY", string(strategy))
-	if strategy != gpuv1.MIGStrategyNone {
-		setContainerEnv(c, "NVIDIA_MIG_MONITOR_DEVICES", "all")
+	setContainerEnv(c, "MIG_STRATEGY", string(strategy))
+	if strategy != gpuv1.MIGStrategyNone {
+		setContainerEnv(c, "NVIDIA_MIG_MONITOR_DEVICES", "all")
+	}
+}
+
+// checks if custom plugin config is provided through a ConfigMap
+func isCustomPluginConfigSet(pluginConfig *gpuv1.DevicePluginConfig) bool {
+	if pluginConfig != nil && pluginConfig.Name != "" {
+		return true
+	}
+	return false
+}
+
+type timeSlicingPluginConfig struct {
+	Version string                   `json:"version"`
+	Sharing timeSlicingPluginSharing `json:"sharing"`
+}
+
+type timeSlicingPluginSharing struct {
+	TimeSlicing *timeSlicingPluginSpec `json:"timeSlicing,omitempty"`
+	MPS         *mpsPluginSpec         `json:"mps,omitempty"`
+}
+
+type timeSlicingPluginSpec struct {
+	RenameByDefault bool                        `json:"renameByDefault,omitempty"`
+	Resources       []gpuv1.TimeSlicingResource `json:"resources"`
+}
+
+type mpsPluginSpec struct {
+	RenameByDefault bool                `json:"renameByDefault,omitempty"`
+	Resources       []gpuv1.MPSResource `json:"resources"`
+}
+
+// renderSharingConfig converts the time-slicing or MPS sharing spec of the device plugin into the NVIDIA Device
+// Plugin config file format
+func renderSharingConfig(devicePlugin *gpuv1.DevicePluginSpec) (string, error) {
+	if devicePlugin.IsMPSSharingEnabled() {
+		return renderMPSConfig(devicePlugin.MPS)
+	}
+	return renderTimeSlicingConfig(devicePlugin.TimeSlicing)
+}
+
+// renderTimeSlicingConfig converts the time-slicing spec into the NVIDIA Device Plugin config file format
+func renderTimeSlicingConfig(timeSlicing *gpuv1.TimeSlicingConfig) (string, error) {
+	pluginConfig := timeSlicingPluginConfig{
+		Version: "v1",
+		Sharing: timeSlicingPluginSharing{
+			TimeSlicing: &timeSlicingPluginSpec{
+				RenameByDefault: timeSlicing.RenameByDefault,
+				Resources:       timeSlicing.Resources,
+			},
+		},
+	}
+	data, err := yaml.Marshal(pluginConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to render time-slicing config: %w", err)
+	}
+	return string(data), nil
+}
+
+// renderMPSConfig converts the MPS sharing spec into the NVIDIA Device Plugin config file format
+func renderMPSConfig(mps *gpuv1.MPSConfig) (string, error) {
+	pluginConfig := timeSlicingPluginConfig{
+		Version: "v1",
+		Sharing: timeSlicingPluginSharing{
+			MPS: &mpsPluginSpec{
+				RenameByDefault: mps.RenameByDefault,
+				Resources:       mps.Resources,
+			},
+		},
+	}
+	data, err := yaml.Marshal(pluginConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to render MPS config: %w", err)
+	}
+	return string(data), nil
+}
+
+type vgpuDevicesConfig struct {
+	Version     string                              `json:"version"`
+	VGPUConfigs map[string][]vgpuDevicesConfigEntry `json:"vgpu-configs"`
+}
+
+type vgpuDevicesConfigEntry struct {
+	Devices     interface{}      `json:"devices"`
+	VGPUDevices map[string]int32 `json:"vgpu-devices"`
+}
+
+// renderVGPUDevicesConfig adds the vGPU type profiles to the vGPU devices config file of the vGPU Device Manager.
+// A profile replaces the configuration of the same name in the file.
+func renderVGPUDevicesConfig(data string, profiles []gpuv1.VGPUProfileSpec) (string, error) {
+	devicesConfig := vgpuDevicesConfig{}
+	if err := yaml.Unmarshal([]byte(data), &devicesConfig); err != nil {
+		return "", fmt.Errorf("failed to parse vGPU devices config: %w", err)
+	}
+	if devicesConfig.Version == "" {
+		devicesConfig.Version = "v1"
+	}
+	if devicesConfig.VGPUConfigs == nil {
+		devicesConfig.VGPUConfigs = make(map[string][]vgpuDevicesConfigEntry)
+	}
+	for _, profile := range profiles {
+		devicesConfig.VGPUConfigs[profile.Name] = []vgpuDevicesConfigEntry{{Devices: "all", VGPUDevices: profile.VGPUDevices}}
+	}
+	rendered, err := yaml.Marshal(devicesConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to render vGPU devices config: %w", err)
+	}
+	return string(rendered), nil
+}
+
+// renderDownloadCacheConfig renders the squid configuration of the download cache. Downloads over plain
+// HTTP, which include the package repositories of the supported distributions, are cached. HTTPS
+// downloads are tunneled through the proxy and cannot be cached.
+func renderDownloadCacheConfig(downloadCache *gpuv1.DownloadCacheSpec) string {
+	// leave headroom on the cache volume for the swap state and the objects being written
+	storageSize := downloadCache.GetStorageSize()
+	cacheSizeMB := storageSize.Value() / (1024 * 1024) * 9 / 10
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "http_port %d\n", DownloadCachePort)
+	b.WriteString(`acl localnet src 10.0.0.0/8
+acl localnet src 172.16.0.0/12
+acl localnet src 192.168.0.0/16
+acl localnet src fc00::/7
+acl localnet src fe80::/10
+acl SSL_ports port 443
+acl Safe_ports port 80
+acl Safe_ports port 443
+acl CONNECT method CONNECT
+http_access deny !Safe_ports
+http_access deny CONNECT !SSL_ports
+http_access allow localhost
+http_access allow localnet
+http_access deny all
+`)
+	fmt.Fprintf(&b, "cache_dir ufs /var/spool/squid %d 16 256\n", cacheSizeMB)
+	b.WriteString(`maximum_object_size 8 GB
+cache_mem 256 MB
+refresh_pattern -i \.(deb|udeb|rpm|drpm|run|tar\.gz|tar\.xz)$ 129600 100% 129600 refresh-ive
+refresh_pattern -i (Release|Packages(\.gz|\.xz)?|repomd\.xml)$ 0 20% 60
+refresh_pattern . 0 20% 4320
+`)
+	if downloadCache.UpstreamProxy != "" {
+		host, port, found := strings.Cut(downloadCache.UpstreamProxy, ":")
+		if !found {
+			port = "3128"
+		}
+		fmt.Fprintf(&b, "cache_peer %s parent %s 0 no-query default\n", host, port)
+		b.WriteString("never_direct allow all\n")
+	}
+	return b.String()
+}
+
+// applyDownloadCacheProxyEnv points the proxy environment of the driver container to the download cache
+func applyDownloadCacheProxyEnv(driverContainer *corev1.Container, namespace string) {
+	proxyURL := fmt.Sprintf("http://%s.%s.svc:%d", DownloadCacheName, namespace, DownloadCachePort)
+	noProxy := "localhost,127.0.0.1,.svc,.cluster.local"
+	for _, name := range []string{"HTTP_PROXY", "HTTPS_PROXY"} {
+		setContainerEnv(driverContainer, name, proxyURL)
+		setContainerEnv(driverContainer, strings.ToLower(name), proxyURL)
 	}
+	setContainerEnv(driverContainer, "NO_PROXY", noProxy)
+	setContainerEnv(driverContainer, "no_proxy", noProxy)
 }
 
-// checks if custom plugin config is provided through a ConfigMap
-func isCustomPluginConfigSet(pluginConfig *gpuv1.DevicePluginConfig) bool {
-	if pluginConfig != nil && pluginConfig.Name != "" {
-		return true
+// getDevicePluginConfig returns the ConfigMap based plugin config to apply, which is either the custom
+// ConfigMap provided by the user or the ConfigMap rendered from the time-slicing or MPS sharing configuration
+func getDevicePluginConfig(config *gpuv1.ClusterPolicySpec) *gpuv1.DevicePluginConfig {
+	if config.DevicePlugin.IsSharingManaged() {
+		return &gpuv1.DevicePluginConfig{Name: TimeSlicingConfigMapName, Default: TimeSlicingDefaultConfigName}
 	}
-	return false
+	return config.DevicePlugin.Config
 }
 
 // adds shared volume mounts required for custom plugin config provided via a ConfigMap
@@ -2784,7 +4165,8 @@ func addSharedMountsForPluginConfig(container *corev1.Container, config *gpuv1.D
 
 // apply spec changes to make custom configurations provided via a ConfigMap available to all containers
 func handleDevicePluginConfig(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec) error {
-	if !isCustomPluginConfigSet(config.DevicePlugin.Config) {
+	pluginConfig := getDevicePluginConfig(config)
+	if !isCustomPluginConfigSet(pluginConfig) {
 		// remove config-manager-init container
 		for i, initContainer := range obj.Spec.Template.Spec.InitContainers {
 			if initContainer.Name != "config-manager-init" {
@@ -2815,7 +4197,7 @@ func handleDevicePluginConfig(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicy
 		}
 		setContainerEnv(&obj.Spec.Template.Spec.Containers[i], "CONFIG_FILE", "/config/config.yaml")
 		// setup sharedvolume(emptydir) for main container
-		addSharedMountsForPluginConfig(&obj.Spec.Template.Spec.Containers[i], config.DevicePlugin.Config)
+		addSharedMountsForPluginConfig(&obj.Spec.Template.Spec.Containers[i], pluginConfig)
 	}
 
 	// if hostPID is already set, we skip setting the shareProcessNamespace field
@@ -2826,23 +4208,23 @@ func handleDevicePluginConfig(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicy
 		obj.Spec.Template.Spec.ShareProcessNamespace = &shareProcessNamespace
 	}
 	// setup volumes from configmap and shared emptyDir
-	obj.Spec.Template.Spec.Volumes = append(obj.Spec.Template.Spec.Volumes, createConfigMapVolume(config.DevicePlugin.Config.Name, nil))
+	obj.Spec.Template.Spec.Volumes = append(obj.Spec.Template.Spec.Volumes, createConfigMapVolume(pluginConfig.Name, nil))
 	obj.Spec.Template.Spec.Volumes = append(obj.Spec.Template.Spec.Volumes, createEmptyDirVolume("config"))
 
 	// apply env/volume changes to initContainer
-	err := transformConfigManagerInitContainer(obj, config)
+	err := transformConfigManagerInitContainer(obj, config, pluginConfig)
 	if err != nil {
 		return err
 	}
 	// apply env/volume changes to sidecarContainer
-	err = transformConfigManagerSidecarContainer(obj, config)
+	err = transformConfigManagerSidecarContainer(obj, config, pluginConfig)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func transformConfigManagerInitContainer(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec) error {
+func transformConfigManagerInitContainer(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, pluginConfig *gpuv1.DevicePluginConfig) error {
 	initContainer := findContainerByName(obj.Spec.Template.Spec.InitContainers, "config-manager-init")
 	if initContainer == nil {
 		// config-manager-init container is not added to the spec, this is a no-op
@@ -2857,15 +4239,15 @@ func transformConfigManagerInitContainer(obj *appsv1.DaemonSet, config *gpuv1.Cl
 		initContainer.ImagePullPolicy = gpuv1.ImagePullPolicy(config.DevicePlugin.ImagePullPolicy)
 	}
 	// setup env
-	setContainerEnv(initContainer, "DEFAULT_CONFIG", config.DevicePlugin.Config.Default)
+	setContainerEnv(initContainer, "DEFAULT_CONFIG", pluginConfig.Default)
 	setContainerEnv(initContainer, "FALLBACK_STRATEGIES", "empty")
 
 	// setup volume mounts
-	addSharedMountsForPluginConfig(initContainer, config.DevicePlugin.Config)
+	addSharedMountsForPluginConfig(initContainer, pluginConfig)
 	return nil
 }
 
-func transformConfigManagerSidecarContainer(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec) error {
+func transformConfigManagerSidecarContainer(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, pluginConfig *gpuv1.DevicePluginConfig) error {
 	var container *corev1.Container
 	for i := range obj.Spec.Template.Spec.Containers {
 		if obj.Spec.Template.Spec.Containers[i].Name != "config-manager" {
@@ -2886,11 +4268,11 @@ func transformConfigManagerSidecarContainer(obj *appsv1.DaemonSet, config *gpuv1
 		container.ImagePullPolicy = gpuv1.ImagePullPolicy(config.DevicePlugin.ImagePullPolicy)
 	}
 	// setup env
-	setContainerEnv(container, "DEFAULT_CONFIG", config.DevicePlugin.Config.Default)
+	setContainerEnv(container, "DEFAULT_CONFIG", pluginConfig.Default)
 	setContainerEnv(container, "FALLBACK_STRATEGIES", "empty")
 
 	// setup volume mounts
-	addSharedMountsForPluginConfig(container, config.DevicePlugin.Config)
+	addSharedMountsForPluginConfig(container, pluginConfig)
 	return nil
 }
 
@@ -2970,6 +4352,10 @@ func transformPeerMemoryContainer(obj *appsv1.DaemonSet, config *gpuv1.ClusterPo
 			}
 			obj.Spec.Template.Spec.Containers[i].VolumeMounts = append(obj.Spec.Template.Spec.Containers[i].VolumeMounts, volumeMounts...)
 		}
+		if config.Driver.KernelModuleConfig.HasModuleParameters() {
+			volumeMounts, _ := kernelModuleParamsVolumeMounts(config.Driver.KernelModuleConfig.ModuleParameterFiles(), driversDir)
+			obj.Spec.Template.Spec.Containers[i].VolumeMounts = append(obj.Spec.Template.Spec.Containers[i].VolumeMounts, volumeMounts...)
+		}
 		if config.Driver.Resources != nil {
 			obj.Spec.Template.Spec.Containers[i].Resources = corev1.ResourceRequirements{
 				Requests: config.Driver.Resources.Requests,
@@ -3070,6 +4456,54 @@ func transformGDSContainer(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpe
 				Limits:   config.Driver.Resources.Limits,
 			}
 		}
+
+		if config.GPUDirectStorage.IsSupportedHardwareRequired() {
+			if err := transformGDSHardwareDetection(obj, config, gdsContainer); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// transformGDSHardwareDetection adds an initContainer to the driver daemonset detecting whether the node has
+// NVMe or InfiniBand devices, and keeps the nvidia-fs container from loading the GPUDirect Storage driver on
+// the nodes without any. The nvidia-fs container runs alongside the driver, so it cannot be scheduled on the
+// supported nodes only.
+func transformGDSHardwareDetection(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, gdsContainer *corev1.Container) error {
+	image, err := gpuv1.ImagePath(&config.Validator)
+	if err != nil {
+		return err
+	}
+	detectionContainer := corev1.Container{
+		Name:            "gds-hardware-detection",
+		Image:           image,
+		ImagePullPolicy: gpuv1.ImagePullPolicy(config.Validator.ImagePullPolicy),
+		Command:         []string{"nvidia-validator"},
+		Env: []corev1.EnvVar{
+			{Name: "COMPONENT", Value: "gds-detection"},
+			{
+				Name: "NODE_NAME",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"},
+				},
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{{Name: "run-nvidia", MountPath: "/run/nvidia"}},
+	}
+	transformValidatorSecurityContext(&detectionContainer)
+	obj.Spec.Template.Spec.InitContainers = append(obj.Spec.Template.Spec.InitContainers, detectionContainer)
+	if len(config.Validator.ImagePullSecrets) > 0 {
+		addPullSecrets(&obj.Spec.Template.Spec, config.Validator.ImagePullSecrets)
+	}
+
+	unsupportedStatusFile := filepath.Join("/run/nvidia/validations", consts.GDSUnsupportedStatusFile)
+	if len(gdsContainer.Args) > 0 {
+		gdsContainer.Args[0] = fmt.Sprintf("if [ -f %s ]; then echo GPUDirect Storage is not supported on this node; exec sleep infinity; fi; %s",
+			unsupportedStatusFile, gdsContainer.Args[0])
+	}
+	if gdsContainer.StartupProbe != nil && gdsContainer.StartupProbe.Exec != nil {
+		gdsContainer.StartupProbe.Exec.Command = []string{"sh", "-c", fmt.Sprintf("[ -f %s ] || lsmod | grep nvidia_fs", unsupportedStatusFile)}
 	}
 	return nil
 }
@@ -3448,6 +4882,25 @@ func createConfigMapVolumeMounts(n ClusterPolicyController, configMapName string
 	return volumeMounts, itemsToInclude, nil
 }
 
+// kernelModuleParamsVolumeMounts returns the mounts of the files of the rendered kernel module parameters
+// ConfigMap in the destination directory, and the items of its volume
+func kernelModuleParamsVolumeMounts(files map[string]string, destinationDir string) ([]corev1.VolumeMount, []corev1.KeyToPath) {
+	filenames := make([]string, 0, len(files))
+	for filename := range files {
+		filenames = append(filenames, filename)
+	}
+	// sort so volume mounts are added to spec in deterministic order
+	sort.Strings(filenames)
+	var itemsToInclude []corev1.KeyToPath
+	var volumeMounts []corev1.VolumeMount
+	for _, filename := range filenames {
+		volumeMounts = append(volumeMounts,
+			corev1.VolumeMount{Name: KernelModuleParamsConfigMapName, ReadOnly: true, MountPath: filepath.Join(destinationDir, filename), SubPath: filename})
+		itemsToInclude = append(itemsToInclude, corev1.KeyToPath{Key: filename, Path: filename})
+	}
+	return volumeMounts, itemsToInclude
+}
+
 func createConfigMapVolume(configMapName string, itemsToInclude []corev1.KeyToPath) corev1.Volume {
 	volumeSource := corev1.VolumeSource{
 		ConfigMap: &corev1.ConfigMapVolumeSource{
@@ -3515,6 +4968,35 @@ func applyLicensingConfig(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec
 	podSpec.Volumes = append(podSpec.Volumes, licensingConfigVol)
 }
 
+func applyModuleCacheConfig(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, driverContainer *corev1.Container) {
+	podSpec := &obj.Spec.Template.Spec
+
+	setContainerEnv(driverContainer, ModuleCacheEnabledEnvName, "true")
+	setContainerEnv(driverContainer, ModuleCacheRepositoryEnvName, config.Driver.ModuleCache.Repository)
+
+	if config.Driver.ModuleCache.SecretName == "" {
+		return
+	}
+	setContainerEnv(driverContainer, ModuleCacheAuthFileEnvName, consts.ModuleCacheAuthMountPath)
+
+	moduleCacheAuthVolMount := corev1.VolumeMount{Name: "module-cache-auth", ReadOnly: true, MountPath: consts.ModuleCacheAuthMountPath, SubPath: consts.ModuleCacheAuthFileName}
+	driverContainer.VolumeMounts = append(driverContainer.VolumeMounts, moduleCacheAuthVolMount)
+
+	moduleCacheAuthVolumeSource := corev1.VolumeSource{
+		Secret: &corev1.SecretVolumeSource{
+			SecretName: config.Driver.ModuleCache.SecretName,
+			Items: []corev1.KeyToPath{
+				{
+					Key:  consts.ModuleCacheAuthFileName,
+					Path: consts.ModuleCacheAuthFileName,
+				},
+			},
+		},
+	}
+	moduleCacheAuthVol := corev1.Volume{Name: "module-cache-auth", VolumeSource: moduleCacheAuthVolumeSource}
+	podSpec.Volumes = append(podSpec.Volumes, moduleCacheAuthVol)
+}
+
 func transformDriverContainer(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicySpec, n ClusterPolicyController) error {
 	podSpec := &obj.Spec.Template.Spec
 	driverContainer := findContainerByName(podSpec.Containers, "nvidia-driver-ctr")
@@ -3556,15 +5038,7 @@ func transformDriverContainer(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicy
 	}
 
 	// set container probe timeouts
-	if config.Driver.StartupProbe != nil {
-		setContainerProbe(driverContainer, config.Driver.StartupProbe, Startup)
-	}
-	if config.Driver.LivenessProbe != nil {
-		setContainerProbe(driverContainer, config.Driver.LivenessProbe, Liveness)
-	}
-	if config.Driver.ReadinessProbe != nil {
-		setContainerProbe(driverContainer, config.Driver.ReadinessProbe, Readiness)
-	}
+	setContainerProbes(driverContainer, config.Driver.StartupProbe, config.Driver.LivenessProbe, config.Driver.ReadinessProbe)
 
 	if config.GDRCopy != nil && config.GDRCopy.IsEnabled() {
 		// set env indicating gdrcopy is enabled
@@ -3629,6 +5103,49 @@ func transformDriverContainer(obj *appsv1.DaemonSet, config *gpuv1.ClusterPolicy
 		podSpec.Volumes = append(podSpec.Volumes, createConfigMapVolume(config.Driver.KernelModuleConfig.Name, itemsToInclude))
 	}
 
+	// mount the kernel module parameters rendered from the driver spec at /drivers
+	if config.Driver.KernelModuleConfig.HasModuleParameters() {
+		volumeMounts, itemsToInclude := kernelModuleParamsVolumeMounts(config.Driver.KernelModuleConfig.ModuleParameterFiles(), driversDir)
+		driverContainer.VolumeMounts = append(driverContainer.VolumeMounts, volumeMounts...)
+		podSpec.Volumes = append(podSpec.Volumes, createConfigMapVolume(KernelModuleParamsConfigMapName, itemsToInclude))
+	}
+
+	// set the fabric manager configuration, including the NVLink fabric partitions, if specified
+	if config.NVLinkFabric.IsEnabled() && config.NVLinkFabric.PartitionConfig != "" {
+		fabricManagerConfigVolMount := corev1.VolumeMount{Name: "fabric-manager-config", ReadOnly: true, MountPath: consts.FabricManagerConfigMountPath, SubPath: consts.FabricManagerConfigFileName}
+		driverContainer.VolumeMounts = append(driverContainer.VolumeMounts, fabricManagerConfigVolMount)
+
+		fabricManagerConfigVolumeSource := corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: config.NVLinkFabric.PartitionConfig,
+				},
+				Items: []corev1.KeyToPath{
+					{
+						Key:  consts.FabricManagerConfigFileName,
+						Path: consts.FabricManagerConfigFileName,
+					},
+				},
+			},
+		}
+		fabricManagerConfigVol := corev1.Volume{Name: "fabric-manager-config", VolumeSource: fabricManagerConfigVolumeSource}
+		podSpec.Volumes = append(podSpec.Volumes, fabricManagerConfigVol)
+	}
+
+	// push built kernel modules to, and pull cached kernel modules from, the configured OCI repository
+	if config.Driver.IsModuleCacheEnabled() {
+		applyModuleCacheConfig(obj, config, driverContainer)
+	}
+
+	// proxy the driver downloads through the in-cluster download cache, or else through the proxy of the
+	// ClusterPolicy
+	if config.DownloadCache.IsEnabled() {
+		applyDownloadCacheProxyEnv(driverContainer, n.operatorNamespace)
+	} else {
+		applyProxyEnv(driverContainer, config.Proxy)
+	}
+	applyTrustedCABundle(podSpec, driverContainer, config.Proxy)
+
 	if len(config.Driver.Env) > 0 {
 		for _, env := range config.Driver.Env {
 			setContainerEnv(&(obj.Spec.Template.Spec.Containers[0]), env.Name, env.Value)
@@ -3813,6 +5330,10 @@ func transformVGPUManagerContainer(obj *appsv1.DaemonSet, config *gpuv1.ClusterP
 		setContainerEnv(container, "OPENSHIFT_VERSION", n.openshift)
 	}
 
+	// set the proxy and the trusted CA bundle of the ClusterPolicy
+	applyProxyEnv(container, config.Proxy)
+	applyTrustedCABundle(podSpec, container, config.Proxy)
+
 	if len(config.VGPUManager.Env) > 0 {
 		for _, env := range config.VGPUManager.Env {
 			setContainerEnv(&(obj.Spec.Template.Spec.Containers[0]), env.Name, env.Value)
@@ -3901,6 +5422,10 @@ func transformValidationInitContainer(obj *appsv1.DaemonSet, config *gpuv1.Clust
 		}
 		// update the security context for the validator container
 		transformValidatorSecurityContext(&obj.Spec.Template.Spec.InitContainers[i])
+		// pass the validation options of the component validated by the initContainer, if any
+		if component := getContainerEnv(&obj.Spec.Template.Spec.InitContainers[i], "COMPONENT"); component != "" {
+			setValidatorComponentOptions(&obj.Spec.Template.Spec.InitContainers[i], config, component)
+		}
 	}
 	// add any pull secrets needed for validation image
 	if len(config.Validator.ImagePullSecrets) > 0 {
@@ -3966,6 +5491,11 @@ func isDaemonSetReady(name string, n ClusterPolicyController) gpuv1.State {
 		return gpuv1.Ready
 	}
 
+	if ds.Status.CurrentNumberScheduled < ds.Status.DesiredNumberScheduled {
+		// pods are missing, which may be due to PodSecurity admission rejecting them
+		checkPodSecurityAdmission(ds, n)
+	}
+
 	if ds.Status.NumberUnavailable != 0 {
 		n.logger.Info("daemonset not ready", "name", name)
 		return gpuv1.NotReady
@@ -4122,6 +5652,12 @@ func Deployment(n ClusterPolicyController) (gpuv1.State, error) {
 		return gpuv1.Disabled, nil
 	}
 
+	if err := preProcessDeployment(obj, n); err != nil {
+		logger.Info("Could not pre-process", "Error", err)
+		return gpuv1.NotReady, err
+	}
+	applyConfigMapGenerations(&obj.Spec.Template.Spec, n.configMapGenerations)
+
 	if err := controllerutil.SetControllerReference(n.singleton, obj, n.scheme); err != nil {
 		return gpuv1.NotReady, err
 	}
@@ -4130,6 +5666,9 @@ func Deployment(n ClusterPolicyController) (gpuv1.State, error) {
 		if apierrors.IsAlreadyExists(err) {
 			logger.Info("Found Resource, updating...")
 			err = n.client.Update(ctx, obj)
+			if fields := immutableFieldsChanged(err); len(fields) > 0 {
+				return recreateDeployment(ctx, obj, fields, n)
+			}
 			if err != nil {
 				logger.Info("Couldn't update", "Error", err)
 				return gpuv1.NotReady, err
@@ -4144,6 +5683,21 @@ func Deployment(n ClusterPolicyController) (gpuv1.State, error) {
 	return isDeploymentReady(obj.Name, n), nil
 }
 
+// recreateDeployment deletes a Deployment whose rendered change touches immutable fields, such as its
+// label selector. The ReplicaSets are orphaned when their pods match the new selector, so that the pods
+// keep running until the recreated Deployment rolls out.
+func recreateDeployment(ctx context.Context, new *appsv1.Deployment, fields []string, n ClusterPolicyController) (gpuv1.State, error) {
+	current := &appsv1.Deployment{}
+	if err := n.client.Get(ctx, types.NamespacedName{Namespace: new.Namespace, Name: new.Name}, current); err != nil {
+		return gpuv1.NotReady, client.IgnoreNotFound(err)
+	}
+	orphan, err := dependentsMatchSelector(current.Spec.Template.Labels, new.Spec.Selector)
+	if err != nil {
+		return gpuv1.NotReady, fmt.Errorf("invalid selector for Deployment %s: %w", new.Name, err)
+	}
+	return recreateOperand(ctx, n, current, "Deployment", fields, orphan)
+}
+
 func ocpHasDriverToolkitImageStream(n *ClusterPolicyController) (bool, error) {
 	ctx := n.ctx
 	found := &apiimagev1.ImageStream{}
@@ -4234,6 +5788,9 @@ func (n ClusterPolicyController) cleanupStalePrecompiledDaemonsets(ctx context.C
 
 	for idx := range list.Items {
 		ds := list.Items[idx]
+		if !n.ownsObject(ds.Labels) {
+			continue
+		}
 		name := ds.Name
 		desiredNumberScheduled := ds.Status.DesiredNumberScheduled
 		numberMisscheduled := ds.Status.NumberMisscheduled
@@ -4245,6 +5802,10 @@ func (n ClusterPolicyController) cleanupStalePrecompiledDaemonsets(ctx context.C
 		// We consider a daemonset to be stale only if it has no desired number of pods and no pods currently mis-scheduled
 		// As per the Kubernetes docs, a daemonset pod is mis-scheduled when an already scheduled pod no longer satisfies
 		// node affinity constraints or has un-tolerated taints, for e.g. "node.kubernetes.io/unreachable:NoSchedule"
+		if _, ok := n.preStagedKernelVersions[ds.Spec.Template.Spec.NodeSelector[nfdKernelLabelKey]]; ok {
+			n.logger.Info("Driver DaemonSet pre-staged for an upcoming OS upgrade, keep it.", "Name", name)
+			continue
+		}
 		if desiredNumberScheduled == 0 && numberMisscheduled == 0 {
 			n.logger.Info("Delete Driver DaemonSet", "Name", name)
 
@@ -4378,6 +5939,9 @@ func (n ClusterPolicyController) ocpCleanupStaleDriverToolkitDaemonSets(ctx cont
 	}
 
 	for idx := range list.Items {
+		if !n.ownsObject(list.Items[idx].Labels) {
+			continue
+		}
 		name := list.Items[idx].Name
 		dsRhcosVersion, versionOk := list.Items[idx].Labels[ocpDriverToolkitVersionLabel]
 		clusterHasRhcosVersion, clusterOk := n.ocpDriverToolkit.rhcosVersions[dsRhcosVersion]
@@ -4564,8 +6128,8 @@ func (n ClusterPolicyController) cleanupDriverDaemonsets(ctx context.Context, se
 		n.logger.Info("Delete DaemonSet",
 			"Name", dsList.Items[idx].Name,
 		)
-		// ignore daemonsets that doesn't match the required name
-		if !strings.HasPrefix(dsList.Items[idx].Name, namePrefix) {
+		// ignore daemonsets that doesn't match the required name or belong to another ClusterPolicy
+		if !strings.HasPrefix(dsList.Items[idx].Name, namePrefix) || !n.ownsObject(dsList.Items[idx].Labels) {
 			continue
 		}
 		if err := n.client.Delete(ctx, &dsList.Items[idx]); err != nil {
@@ -4588,8 +6152,8 @@ func (n ClusterPolicyController) cleanupDriverDaemonsets(ctx context.Context, se
 
 	podCount := 0
 	for idx := range podList.Items {
-		// ignore pods that doesn't match the required name
-		if !strings.HasPrefix(podList.Items[idx].Name, namePrefix) {
+		// ignore pods that doesn't match the required name or belong to another ClusterPolicy
+		if !strings.HasPrefix(podList.Items[idx].Name, namePrefix) || !n.ownsObject(podList.Items[idx].Labels) {
 			continue
 		}
 		podCount++
@@ -4597,6 +6161,47 @@ func (n ClusterPolicyController) cleanupDriverDaemonsets(ctx context.Context, se
 	return podCount, nil
 }
 
+// variantDaemonSetList holds the DaemonSets of the current state, across the validator images, container
+// runtimes and architectures they are deployed for
+type variantDaemonSetList struct {
+	listed bool
+	items  []appsv1.DaemonSet
+}
+
+// listVariantDaemonSets returns the DaemonSets of the current state labeled with the given variant label key.
+// The DaemonSets are listed once per state, however many variants are resolved.
+func (n ClusterPolicyController) listVariantDaemonSets(labelKey string) ([]appsv1.DaemonSet, error) {
+	variants := n.variantDaemonSets
+	if variants == nil {
+		variants = &variantDaemonSetList{}
+	}
+	if !variants.listed {
+		opts := []client.ListOption{
+			client.InNamespace(n.operatorNamespace),
+			client.MatchingLabels{appLabelKey: n.resources[n.idx].DaemonSet.Labels[appLabelKey]},
+		}
+		list := &appsv1.DaemonSetList{}
+		if err := n.client.List(n.ctx, list, opts...); err != nil {
+			return nil, fmt.Errorf("unable to list the DaemonSets of the state: %w", err)
+		}
+		variants.items = list.Items
+		variants.listed = true
+	}
+
+	var items []appsv1.DaemonSet
+	for _, ds := range variants.items {
+		if _, ok := ds.Labels[labelKey]; ok {
+			items = append(items, ds)
+		}
+	}
+	return items, nil
+}
+
+// isGPUNodeAgnosticDaemonSet returns true if the DaemonSet is deployed regardless of the GPU nodes of the cluster
+func isGPUNodeAgnosticDaemonSet(name string) bool {
+	return name == NodeFeatureDiscoveryWorkerName || name == MockGPUsName
+}
+
 // DaemonSet creates Daemonset resource
 func DaemonSet(n ClusterPolicyController) (gpuv1.State, error) {
 	ctx := n.ctx
@@ -4606,21 +6211,55 @@ func DaemonSet(n ClusterPolicyController) (gpuv1.State, error) {
 
 	logger := n.logger.WithValues("DaemonSet", obj.Name, "Namespace", obj.Namespace)
 
+	// the NFD workers label all nodes, so that the GPU nodes can be discovered from their labels, and the mock
+	// GPUs are advertised on the nodes without GPU
+	gpuNodeAgnostic := isGPUNodeAgnosticDaemonSet(obj.Name)
+
+	// the variant resolvers share the list of the DaemonSets of the current state
+	if n.variantDaemonSets == nil {
+		n.variantDaemonSets = &variantDaemonSetList{}
+	}
+
+	// the DaemonSets of the scoped ClusterPolicies are deployed next to the DaemonSet of the singleton
+	if !n.scopeResolved && len(n.scopedPolicies) > 0 && !gpuNodeAgnostic {
+		return scopedDaemonSets(n)
+	}
+
+	// the DaemonSets running the validator image are deployed once per validator image selected for the nodes,
+	// the driver DaemonSets being already deployed per OS
+	if !n.validatorImageResolved && !gpuNodeAgnostic && obj.Name != commonDriverDaemonsetName &&
+		obj.Name != commonVGPUManagerDaemonsetName {
+		return validatorImageDaemonSets(n)
+	}
+
+	// the DaemonSets configuring or validating the container runtime are deployed once per container runtime
+	// of the GPU nodes in mixed-runtime clusters
+	if !n.runtimeResolved && slices.Contains(runtimeDependentDaemonSets, obj.Name) {
+		return runtimeDaemonSets(n)
+	}
+
+	// the DaemonSets of the components with per-architecture images are deployed once per architecture of the
+	// GPU nodes with an image
+	if !n.archResolved && !gpuNodeAgnostic {
+		return archDaemonSets(n)
+	}
+
 	// Check if state is disabled and cleanup resource if exists
 	if !n.isStateEnabled(n.stateNames[n.idx]) {
-		err := n.client.Delete(ctx, obj)
+		err := n.client.Delete(audit.WithReason(ctx, "state disabled"), obj)
 		if err != nil && !apierrors.IsNotFound(err) {
 			logger.Info("Couldn't delete", "Error", err)
 			return gpuv1.NotReady, err
 		}
+		n.introspection.Forget(n.singleton.Name, n.stateNames[n.idx])
 		return gpuv1.Disabled, nil
 	}
 
-	if !n.hasGPUNodes {
+	if !n.hasGPUNodes && !gpuNodeAgnostic {
 		// multiple DaemonSets (eg, driver, dgcm-exporter) cannot be
 		// deployed without knowing the OS name, so skip their
 		// deployment for now. The operator will be notified
-		// (addWatchNewGPUNode) when new nodes will join the cluster.
+		// (addWatchNodeInventory) when new nodes will join the cluster.
 		logger.Info("No GPU node in the cluster, do not create DaemonSets")
 		return gpuv1.Ready, nil
 	}
@@ -4681,6 +6320,34 @@ func DaemonSet(n ClusterPolicyController) (gpuv1.State, error) {
 		logger.Info("Could not pre-process", "Error", err)
 		return gpuv1.NotReady, err
 	}
+	if !gpuNodeAgnostic {
+		applyClusterPolicyScope(obj, n)
+		applyTenantNamespace(obj, n)
+		if err := applyValidatorImage(obj, n); err != nil {
+			logger.Info("Could not apply the validator image", "Error", err)
+			return gpuv1.NotReady, err
+		}
+		applyRuntimeVariant(obj, n)
+		if err := applyArchVariant(obj, n); err != nil {
+			logger.Info("Could not apply the architecture image", "Error", err)
+			return gpuv1.NotReady, err
+		}
+	}
+
+	applyNodeMutationPolicy(&obj.Spec.Template.Spec, n.nodeMutationPolicy)
+
+	if n.singleton.Spec.IsDigestPinningEnabled() {
+		if err := pinDaemonSetImageDigests(ctx, obj, n); err != nil {
+			logger.Info("Could not resolve image digests", "Error", err)
+			return gpuv1.NotReady, err
+		}
+	}
+
+	// the config digest is stamped once the DaemonSet is rendered, before the ConfigMap volumes are pointed to
+	// their generations
+	applyConfigDigest(obj, &n.singleton.Spec)
+
+	applyConfigMapGenerations(&obj.Spec.Template.Spec, n.configMapGenerations)
 
 	if err := controllerutil.SetControllerReference(n.singleton, obj, n.scheme); err != nil {
 		logger.Info("SetControllerReference failed", "Error", err)
@@ -4704,16 +6371,20 @@ func DaemonSet(n ClusterPolicyController) (gpuv1.State, error) {
 		obj.Annotations[annoKey] = annoValue
 	}
 
+	n.introspection.Record(n.singleton.Name, n.stateNames[n.idx], obj)
+
 	found := &appsv1.DaemonSet{}
 	err = n.client.Get(ctx, types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}, found)
 	if err != nil && apierrors.IsNotFound(err) {
+		if n.safeMode {
+			logger.Info("Safe mode is active, DaemonSet creation paused until it is acknowledged", "Name", obj.Name)
+			return gpuv1.NotReady, nil
+		}
 		logger.Info("DaemonSet not found, creating",
 			"Name", obj.Name,
 		)
-		// generate hash for the spec to create
-		hashStr := utils.GetObjectHash(obj)
-		// add annotation to the Daemonset with hash value during creation
-		obj.Annotations[NvidiaAnnotationHashKey] = hashStr
+		// add annotation to the Daemonset with the digest of the spec to create
+		obj.Annotations[NvidiaAnnotationHashKey] = utils.GetObjectDigest(obj)
 		err = n.client.Create(ctx, obj)
 		if err != nil {
 			logger.Info("Couldn't create DaemonSet",
@@ -4730,13 +6401,29 @@ func DaemonSet(n ClusterPolicyController) (gpuv1.State, error) {
 		return gpuv1.NotReady, err
 	}
 
+	if n.safeMode {
+		if !equality.Semantic.DeepEqual(found.Spec.Selector, obj.Spec.Selector) || isDaemonsetSpecChanged(found, obj) {
+			logger.Info("Safe mode is active, DaemonSet rollout paused until it is acknowledged", "name", obj.Name)
+		}
+		return isDaemonSetReady(obj.Name, n), nil
+	}
+
+	if !equality.Semantic.DeepEqual(found.Spec.Selector, obj.Spec.Selector) {
+		return recreateDaemonSet(ctx, found, obj, []string{"spec.selector"}, n)
+	}
+
 	changed := isDaemonsetSpecChanged(found, obj)
 	if changed {
-		logger.Info("DaemonSet is different, updating", "name", obj.Name)
-		err = n.client.Update(ctx, obj)
+		rolloutChanges := annotateRolloutChanges(found, obj)
+		logger.Info("DaemonSet is different, updating", "name", obj.Name, "podTemplateChanges", rolloutChanges)
+		err = n.client.Update(audit.WithReason(ctx, "DaemonSet spec hash changed"), obj)
+		if fields := immutableFieldsChanged(err); len(fields) > 0 {
+			return recreateDaemonSet(ctx, found, obj, fields, n)
+		}
 		if err != nil {
 			return gpuv1.NotReady, err
 		}
+		n.recordRollout(obj.Name, rolloutChanges)
 		return gpuv1.NotReady, nil
 	} else {
 		logger.Info("DaemonSet identical, skipping update", "name", obj.Name)
@@ -4744,6 +6431,18 @@ func DaemonSet(n ClusterPolicyController) (gpuv1.State, error) {
 	return isDaemonSetReady(obj.Name, n), nil
 }
 
+// recreateDaemonSet deletes a DaemonSet whose rendered change touches immutable fields, such as its label
+// selector. The DaemonSet is recreated on the next reconciliation. When the existing pods already match the
+// new selector they are orphaned rather than deleted, so they keep running and are adopted by the new
+// DaemonSet instead of being recreated.
+func recreateDaemonSet(ctx context.Context, current *appsv1.DaemonSet, new *appsv1.DaemonSet, fields []string, n ClusterPolicyController) (gpuv1.State, error) {
+	orphan, err := dependentsMatchSelector(current.Spec.Template.Labels, new.Spec.Selector)
+	if err != nil {
+		return gpuv1.NotReady, fmt.Errorf("invalid selector for DaemonSet %s: %w", new.Name, err)
+	}
+	return recreateOperand(ctx, n, current, "DaemonSet", fields, orphan)
+}
+
 // isDaemonsetSpecChanged returns true if the spec has changed between existing one
 // and new Daemonset spec compared by hash.
 func isDaemonsetSpecChanged(current *appsv1.DaemonSet, new *appsv1.DaemonSet) bool {
@@ -4754,27 +6453,14 @@ func isDaemonsetSpecChanged(current *appsv1.DaemonSet, new *appsv1.DaemonSet) bo
 		panic("appsv1.DaemonSet.Annotations must be allocated prior to calling isDaemonsetSpecChanged()")
 	}
 
-	hashStr := utils.GetObjectHash(new)
-	foundHashAnnotation := false
-
-	for annotation, value := range current.Annotations {
-		if annotation == NvidiaAnnotationHashKey {
-			if value != hashStr {
-				// update annotation to be added to Daemonset as per new spec and indicate spec update is required
-				new.Annotations[NvidiaAnnotationHashKey] = hashStr
-				return true
-			}
-			foundHashAnnotation = true
-			break
-		}
-	}
-
-	if !foundHashAnnotation {
-		// update annotation to be added to Daemonset as per new spec and indicate spec update is required
-		new.Annotations[NvidiaAnnotationHashKey] = hashStr
-		return true
+	digest := utils.GetObjectDigest(new)
+	legacyHash := func() string { return utils.GetObjectHash(new) }
+	if utils.ObjectHashMatches(current.Annotations[NvidiaAnnotationHashKey], digest, legacyHash) {
+		return false
 	}
-	return false
+	// update annotation to be added to Daemonset as per new spec and indicate spec update is required
+	new.Annotations[NvidiaAnnotationHashKey] = digest
+	return true
 }
 
 // The operator starts two pods in different stages to validate
@@ -4813,6 +6499,12 @@ func SecurityContextConstraints(n ClusterPolicyController) (gpuv1.State, error)
 	obj := n.resources[state].SecurityContextConstraints.DeepCopy()
 	obj.Namespace = n.operatorNamespace
 
+	// the SecurityContextConstraints are shared by the tenant namespaces, and deployed along with the operator
+	// namespace
+	if n.tenant != "" {
+		return gpuv1.Ready, nil
+	}
+
 	logger := n.logger.WithValues("SecurityContextConstraints", obj.Name, "Namespace", "default")
 
 	// Check if state is disabled and cleanup resource if exists
@@ -4831,6 +6523,7 @@ func SecurityContextConstraints(n ClusterPolicyController) (gpuv1.State, error)
 		}
 		obj.Users[idx] = fmt.Sprintf("system:serviceaccount:%s:%s", obj.Namespace, obj.Name)
 	}
+	obj.Users = append(obj.Users, tenantSCCUsers(obj.Name, n)...)
 
 	if err := controllerutil.SetControllerReference(n.singleton, obj, n.scheme); err != nil {
 		return gpuv1.NotReady, err
@@ -4907,9 +6600,16 @@ func Service(n ClusterPolicyController) (gpuv1.State, error) {
 
 	logger.Info("Found Resource, updating...")
 	obj.ResourceVersion = found.ResourceVersion
-	obj.Spec.ClusterIP = found.Spec.ClusterIP
+	// keep the allocated cluster IP unless the rendered Service requests a specific one, such as a headless Service
+	if obj.Spec.ClusterIP == "" {
+		obj.Spec.ClusterIP = found.Spec.ClusterIP
+		obj.Spec.ClusterIPs = found.Spec.ClusterIPs
+	}
 
 	err = n.client.Update(ctx, obj)
+	if fields := immutableFieldsChanged(err); len(fields) > 0 {
+		return recreateOperand(ctx, n, found, "Service", fields, false)
+	}
 	if err != nil {
 		logger.Info("Couldn't update", "Error", err)
 		return gpuv1.NotReady, err
@@ -5180,11 +6880,23 @@ func transformRuntimeClass(n ClusterPolicyController, spec nodev1.RuntimeClass)
 	return gpuv1.Ready, nil
 }
 
+// transformKataRuntimeClasses manages the Kata RuntimeClasses when sandbox workloads run in Kata mode.
+// The Kata Manager itself is permanently switched off: the Kata artifacts are installed on the nodes by
+// a separate DaemonSet, and the RuntimeClasses are created once that DaemonSet is ready.
 func transformKataRuntimeClasses(n ClusterPolicyController) (gpuv1.State, error) {
 	ctx := n.ctx
+	kataEnabled := n.isKataManaged()
+	kata := n.singleton.Spec.SandboxWorkloads.Kata
+
+	desired := map[string]bool{}
+	if kataEnabled && kata.IsManageRuntimeClassesEnabled() {
+		for _, rc := range kata.GetRuntimeClasses() {
+			desired[rc.Name] = true
+		}
+	}
 
 	// Get all existing Kata RuntimeClasses
-	opts := []client.ListOption{&client.MatchingLabels{"nvidia.com/kata-runtime-class": "true"}}
+	opts := []client.ListOption{&client.MatchingLabels{KataRuntimeClassLabelKey: "true"}}
 	list := &nodev1.RuntimeClassList{}
 	err := n.client.List(ctx, list, opts...)
 	if err != nil {
@@ -5193,11 +6905,12 @@ func transformKataRuntimeClasses(n ClusterPolicyController) (gpuv1.State, error)
 	}
 	n.logger.V(1).Info("Kata RuntimeClasses", "Number", len(list.Items))
 
-	// Enforce deletion of Kata RuntimeClasses because Kata manager is permanently switched off
-	// Delete all Kata RuntimeClasses
-	n.logger.Info("Kata Manager disabled, deleting all Kata RuntimeClasses")
+	// Delete the Kata RuntimeClasses no longer managed by the operator
 	for _, rc := range list.Items {
 		rc := rc
+		if desired[rc.Name] {
+			continue
+		}
 		n.logger.V(1).Info("Deleting Kata RuntimeClass", "Name", rc.Name)
 		err := n.client.Delete(ctx, &rc)
 		if err != nil {
@@ -5205,9 +6918,138 @@ func transformKataRuntimeClasses(n ClusterPolicyController) (gpuv1.State, error)
 			// continue nevertheless, do not block
 		}
 	}
+
+	if !kataEnabled {
+		return gpuv1.Ready, nil
+	}
+
+	if !kata.IsManageRuntimeClassesEnabled() {
+		return checkKataRuntimeClasses(n, kata.GetRuntimeClasses())
+	}
+
+	// do not create the RuntimeClasses before their handlers are installed on the nodes
+	ready, err := isKataArtifactsDaemonSetReady(n, kata)
+	if err != nil {
+		return gpuv1.NotReady, err
+	}
+	if !ready {
+		return gpuv1.NotReady, nil
+	}
+
+	status := gpuv1.Ready
+	for _, rc := range kata.GetRuntimeClasses() {
+		stat, err := transformKataRuntimeClass(n, rc)
+		if err != nil {
+			return stat, err
+		}
+		if stat != gpuv1.Ready {
+			status = gpuv1.NotReady
+		}
+	}
+	return status, nil
+}
+
+// transformKataRuntimeClass creates or updates a Kata RuntimeClass. RuntimeClasses of the same name not
+// created by the operator, e.g. by the Kata artifacts installer, are left untouched.
+func transformKataRuntimeClass(n ClusterPolicyController, spec gpuv1.KataRuntimeClassSpec) (gpuv1.State, error) {
+	ctx := n.ctx
+	obj := &nodev1.RuntimeClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: spec.Name,
+			Labels: map[string]string{
+				"app.kubernetes.io/component": "gpu-operator",
+				KataRuntimeClassLabelKey:      "true",
+			},
+		},
+		Handler: spec.Handler,
+	}
+	if len(spec.NodeSelector) > 0 {
+		obj.Scheduling = &nodev1.Scheduling{NodeSelector: spec.NodeSelector}
+	}
+
+	logger := n.logger.WithValues("RuntimeClass", obj.Name)
+
+	if err := controllerutil.SetControllerReference(n.singleton, obj, n.scheme); err != nil {
+		return gpuv1.NotReady, err
+	}
+
+	found := &nodev1.RuntimeClass{}
+	err := n.client.Get(ctx, types.NamespacedName{Namespace: "", Name: obj.Name}, found)
+	if err != nil && apierrors.IsNotFound(err) {
+		logger.Info("Not found, creating...")
+		err = n.client.Create(ctx, obj)
+		if err != nil {
+			logger.Info("Couldn't create", "Error", err)
+			return gpuv1.NotReady, err
+		}
+		return gpuv1.Ready, nil
+	} else if err != nil {
+		return gpuv1.NotReady, err
+	}
+
+	if found.Labels[KataRuntimeClassLabelKey] != "true" {
+		logger.V(1).Info("Found Resource not managed by the operator, skipping")
+		return gpuv1.Ready, nil
+	}
+
+	logger.Info("Found Resource, updating...")
+	obj.ResourceVersion = found.ResourceVersion
+
+	err = n.client.Update(ctx, obj)
+	if err != nil {
+		logger.Info("Couldn't update", "Error", err)
+		return gpuv1.NotReady, err
+	}
 	return gpuv1.Ready, nil
 }
 
+// checkKataRuntimeClasses verifies that the Kata RuntimeClasses not managed by the operator exist,
+// recording the missing ones to report them in the ClusterPolicy conditions
+func checkKataRuntimeClasses(n ClusterPolicyController, runtimeClasses []gpuv1.KataRuntimeClassSpec) (gpuv1.State, error) {
+	status := gpuv1.Ready
+	for _, rc := range runtimeClasses {
+		err := n.client.Get(n.ctx, types.NamespacedName{Name: rc.Name}, &nodev1.RuntimeClass{})
+		if err != nil && apierrors.IsNotFound(err) {
+			n.logger.Info("Kata RuntimeClass not found", "RuntimeClass", rc.Name)
+			if n.missingKataRuntimeClasses != nil {
+				n.missingKataRuntimeClasses[rc.Name] = true
+			}
+			status = gpuv1.NotReady
+			continue
+		} else if err != nil {
+			return gpuv1.NotReady, err
+		}
+	}
+	return status, nil
+}
+
+// isKataArtifactsDaemonSetReady returns true if all pods of the DaemonSet installing the Kata artifacts are ready
+func isKataArtifactsDaemonSetReady(n ClusterPolicyController, kata *gpuv1.KataRuntimeSpec) (bool, error) {
+	name, namespace := kata.GetArtifactsDaemonSet()
+	logger := n.logger.WithValues("DaemonSet", name, "Namespace", namespace)
+
+	// the DaemonSet usually lives outside of the namespaces cached by the manager
+	reader := client.Reader(n.client)
+	if n.apiReader != nil {
+		reader = n.apiReader
+	}
+
+	ds := &appsv1.DaemonSet{}
+	err := reader.Get(n.ctx, types.NamespacedName{Namespace: namespace, Name: name}, ds)
+	if err != nil && apierrors.IsNotFound(err) {
+		logger.Info("Kata artifacts DaemonSet not found")
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("error getting the Kata artifacts DaemonSet: %w", err)
+	}
+
+	if ds.Status.NumberUnavailable != 0 || ds.Status.NumberReady != ds.Status.DesiredNumberScheduled {
+		logger.Info("Kata artifacts DaemonSet not ready", "desired", ds.Status.DesiredNumberScheduled, "ready", ds.Status.NumberReady)
+		return false, nil
+	}
+	return true, nil
+}
+
 func RuntimeClasses(n ClusterPolicyController) (gpuv1.State, error) {
 	status := gpuv1.Ready
 	state := n.idx
@@ -2407,6 +2407,118 @@ func getMIGManagerTestOutput(testCase string) map[string]interface{} {
 
 // TestMIGManager tests that the GPU Operator correctly deploys the mig-manager daemonset
 // under various scenarios/config options
+func TestKataRuntimeClasses(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, nodev1.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+
+	kataSpec := func(kata *gpuv1.KataRuntimeSpec) gpuv1.ClusterPolicySpec {
+		return gpuv1.ClusterPolicySpec{
+			SandboxWorkloads: gpuv1.SandboxWorkloadsSpec{Enabled: ptr.To(true), Mode: string(gpuv1.Kata), Kata: kata},
+		}
+	}
+	artifacts := func(ready int32) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "kata-deploy", Namespace: "kata-system"},
+			Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 2, NumberReady: ready},
+		}
+	}
+	managed := func(name string) *nodev1.RuntimeClass {
+		return &nodev1.RuntimeClass{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{KataRuntimeClassLabelKey: "true"}},
+			Handler:    name,
+		}
+	}
+
+	tests := []struct {
+		description            string
+		k8sObjects             []client.Object
+		clusterPolicySpec      gpuv1.ClusterPolicySpec
+		expectedState          gpuv1.State
+		expectedRuntimeClasses []string
+		expectedMissing        []string
+	}{
+		{
+			description:       "sandbox workloads disabled",
+			k8sObjects:        []client.Object{managed("kata-qemu-nvidia-gpu")},
+			clusterPolicySpec: gpuv1.ClusterPolicySpec{},
+			expectedState:     gpuv1.Ready,
+		},
+		{
+			description:       "Kata artifacts not installed",
+			clusterPolicySpec: kataSpec(nil),
+			expectedState:     gpuv1.NotReady,
+		},
+		{
+			description:       "Kata artifacts not ready",
+			k8sObjects:        []client.Object{artifacts(1)},
+			clusterPolicySpec: kataSpec(nil),
+			expectedState:     gpuv1.NotReady,
+		},
+		{
+			description:            "default RuntimeClasses",
+			k8sObjects:             []client.Object{artifacts(2)},
+			clusterPolicySpec:      kataSpec(nil),
+			expectedState:          gpuv1.Ready,
+			expectedRuntimeClasses: []string{"kata-qemu-nvidia-gpu", "kata-qemu-nvidia-gpu-snp", "kata-qemu-nvidia-gpu-tdx"},
+		},
+		{
+			description: "custom RuntimeClasses replace stale ones",
+			k8sObjects:  []client.Object{artifacts(2), managed("kata-qemu-nvidia-gpu-snp")},
+			clusterPolicySpec: kataSpec(&gpuv1.KataRuntimeSpec{
+				RuntimeClasses: []gpuv1.KataRuntimeClassSpec{{Name: "kata-nvidia", Handler: "kata-qemu-nvidia-gpu"}},
+			}),
+			expectedState:          gpuv1.Ready,
+			expectedRuntimeClasses: []string{"kata-nvidia"},
+		},
+		{
+			description: "unmanaged RuntimeClass missing",
+			k8sObjects:  []client.Object{&nodev1.RuntimeClass{ObjectMeta: metav1.ObjectMeta{Name: "kata-qemu-nvidia-gpu"}, Handler: "kata-qemu-nvidia-gpu"}},
+			clusterPolicySpec: kataSpec(&gpuv1.KataRuntimeSpec{
+				ManageRuntimeClasses: ptr.To(false),
+				RuntimeClasses:       []gpuv1.KataRuntimeClassSpec{{Name: "kata-qemu-nvidia-gpu"}, {Name: "kata-qemu-nvidia-gpu-snp"}},
+			}),
+			expectedState:          gpuv1.NotReady,
+			expectedRuntimeClasses: []string{"kata-qemu-nvidia-gpu"},
+			expectedMissing:        []string{"kata-qemu-nvidia-gpu-snp"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(test.k8sObjects...).
+				Build()
+
+			controller := ClusterPolicyController{
+				client:                    k8sClient,
+				ctx:                       context.Background(),
+				singleton:                 &gpuv1.ClusterPolicy{Spec: test.clusterPolicySpec},
+				scheme:                    scheme,
+				stateNames:                []string{"state-kata-manager"},
+				logger:                    ctrl.Log.WithName("test"),
+				sandboxEnabled:            test.clusterPolicySpec.SandboxWorkloads.IsEnabled(),
+				missingKataRuntimeClasses: map[string]bool{},
+			}
+
+			state, err := RuntimeClasses(controller)
+			require.NoError(t, err)
+			require.Equal(t, test.expectedState, state)
+
+			list := &nodev1.RuntimeClassList{}
+			require.NoError(t, k8sClient.List(t.Context(), list))
+			names := []string{}
+			for _, rc := range list.Items {
+				names = append(names, rc.Name)
+			}
+			require.ElementsMatch(t, test.expectedRuntimeClasses, names)
+			require.ElementsMatch(t, test.expectedMissing, controller.getMissingKataRuntimeClasses())
+		})
+	}
+}
+
 func TestMIGManager(t *testing.T) {
 	testCases := []struct {
 		description   string
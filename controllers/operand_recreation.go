@@ -0,0 +1,135 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/audit"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+	"github.com/NVIDIA/gpu-operator/internal/featuregate"
+)
+
+// maxRecreatedOperands bounds the number of recreated operand objects reported in the ClusterPolicy status
+const maxRecreatedOperands = 10
+
+// immutableFieldsChanged returns the immutable fields the API server refused to update, or nil if the error
+// is not caused by a change of an immutable field
+func immutableFieldsChanged(err error) []string {
+	if !apierrors.IsInvalid(err) {
+		return nil
+	}
+	var statusErr apierrors.APIStatus
+	if !errors.As(err, &statusErr) || statusErr.Status().Details == nil {
+		return nil
+	}
+	var fields []string
+	for _, cause := range statusErr.Status().Details.Causes {
+		if strings.Contains(cause.Message, apivalidation.FieldImmutableErrorMsg) {
+			fields = append(fields, cause.Field)
+		}
+	}
+	return fields
+}
+
+// dependentsMatchSelector returns true if the pods of the current object match the selector of the rendered
+// object, in which case they can be adopted by the recreated object
+func dependentsMatchSelector(currentTemplateLabels map[string]string, selector *metav1.LabelSelector) (bool, error) {
+	if selector == nil {
+		return false, nil
+	}
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false, fmt.Errorf("invalid selector: %w", err)
+	}
+	return s.Matches(labels.Set(currentTemplateLabels)), nil
+}
+
+// recreateOperand deletes an operand object whose rendered change touches immutable fields, so that it is
+// created again from the rendered object on the next reconciliation. The dependents of the object are orphaned
+// when orphanDependents is set, so that they keep running until adopted by the recreated object. The
+// recreation is reported by an event and in the ClusterPolicy status.
+func recreateOperand(ctx context.Context, n ClusterPolicyController, current client.Object, kind string, fields []string, orphanDependents bool) (gpuv1.State, error) {
+	logger := n.logger.WithValues(kind, current.GetName(), "Namespace", current.GetNamespace())
+	if !n.operatorConfig.FeatureEnabled(featuregate.OperandRecreation) {
+		return gpuv1.NotReady, fmt.Errorf("%s %s must be recreated as immutable fields %v changed, but the %s feature is disabled",
+			kind, current.GetName(), fields, featuregate.OperandRecreation)
+	}
+
+	propagationPolicy := metav1.DeletePropagationBackground
+	if orphanDependents {
+		propagationPolicy = metav1.DeletePropagationOrphan
+	}
+
+	logger.Info("Immutable fields changed, recreating", "fields", fields, "propagationPolicy", propagationPolicy)
+	reason := fmt.Sprintf("%s immutable fields changed", kind)
+	err := n.client.Delete(audit.WithReason(ctx, reason), current, client.PropagationPolicy(propagationPolicy))
+	if err != nil && !apierrors.IsNotFound(err) {
+		return gpuv1.NotReady, err
+	}
+
+	if n.recorder != nil && n.singleton != nil {
+		n.recorder.Eventf(n.singleton, nil, corev1.EventTypeNormal, conditions.OperandRecreated, "Recreate",
+			"%s %s recreated as immutable fields %v changed", kind, current.GetName(), fields)
+	}
+	if err := n.recordRecreatedOperand(ctx, gpuv1.RecreatedOperandStatus{
+		Kind:               kind,
+		Name:               current.GetName(),
+		Fields:             fields,
+		OrphanedDependents: orphanDependents,
+		DeletionTime:       metav1.Time{Time: time.Now()},
+	}); err != nil {
+		// the object is recreated regardless of the status update
+		logger.Info("Couldn't report the recreation in the ClusterPolicy status", "Error", err)
+	}
+	return gpuv1.NotReady, nil
+}
+
+// recordRecreatedOperand adds a recreated operand object to the ClusterPolicy status, keeping the latest ones
+func (n ClusterPolicyController) recordRecreatedOperand(ctx context.Context, status gpuv1.RecreatedOperandStatus) error {
+	if n.singleton == nil {
+		return nil
+	}
+	// Fetch latest instance and update status to avoid version mismatch
+	instance := &gpuv1.ClusterPolicy{}
+	if err := n.client.Get(ctx, types.NamespacedName{Name: n.singleton.Name}, instance); err != nil {
+		return fmt.Errorf("failed to get ClusterPolicy instance for status update: %w", err)
+	}
+	recreated := append(instance.Status.RecreatedOperands, status)
+	if len(recreated) > maxRecreatedOperands {
+		recreated = recreated[len(recreated)-maxRecreatedOperands:]
+	}
+	instance.Status.RecreatedOperands = recreated
+	if err := n.client.Status().Update(ctx, instance); err != nil {
+		return fmt.Errorf("failed to update ClusterPolicy recreated operands status: %w", err)
+	}
+	n.singleton.Status.RecreatedOperands = recreated
+	return nil
+}
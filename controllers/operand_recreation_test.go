@@ -0,0 +1,155 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/operatorconfig"
+)
+
+func TestImmutableFieldsChanged(t *testing.T) {
+	err := apierrors.NewInvalid(schema.GroupKind{Kind: "Service"}, "nvidia-dcgm-exporter", field.ErrorList{
+		field.Invalid(field.NewPath("spec", "clusterIP"), "None", apivalidation.FieldImmutableErrorMsg),
+		field.Invalid(field.NewPath("spec", "ports"), "", "must be specified"),
+	})
+	require.Equal(t, []string{"spec.clusterIP"}, immutableFieldsChanged(err))
+	require.Equal(t, []string{"spec.clusterIP"}, immutableFieldsChanged(fmt.Errorf("update failed: %w", err)))
+
+	require.Nil(t, immutableFieldsChanged(nil))
+	require.Nil(t, immutableFieldsChanged(errors.New(apivalidation.FieldImmutableErrorMsg)))
+	require.Nil(t, immutableFieldsChanged(apierrors.NewInvalid(schema.GroupKind{Kind: "Service"}, "nvidia-dcgm-exporter", field.ErrorList{
+		field.Invalid(field.NewPath("spec", "ports"), "", "must be specified"),
+	})))
+}
+
+func TestRecreateOperand(t *testing.T) {
+	cp := &gpuv1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy"}}
+	for i := 0; i < maxRecreatedOperands; i++ {
+		cp.Status.RecreatedOperands = append(cp.Status.RecreatedOperands, gpuv1.RecreatedOperandStatus{Kind: "Service", Name: fmt.Sprintf("svc-%d", i)})
+	}
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "nvidia-dcgm-exporter", Namespace: "test-ns"}}
+	recorder := events.NewFakeRecorder(1)
+	n := ClusterPolicyController{
+		client:    fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cp, svc).WithStatusSubresource(cp).Build(),
+		logger:    logr.Discard(),
+		singleton: cp,
+		recorder:  recorder,
+	}
+
+	state, err := recreateOperand(context.Background(), n, svc, "Service", []string{"spec.clusterIP"}, false)
+	require.NoError(t, err)
+	require.Equal(t, gpuv1.NotReady, state)
+	require.True(t, apierrors.IsNotFound(n.client.Get(context.Background(), client.ObjectKeyFromObject(svc), &corev1.Service{})))
+	require.Len(t, recorder.Events, 1)
+
+	updated := &gpuv1.ClusterPolicy{}
+	require.NoError(t, n.client.Get(context.Background(), types.NamespacedName{Name: cp.Name}, updated))
+	require.Len(t, updated.Status.RecreatedOperands, maxRecreatedOperands)
+	require.Equal(t, "svc-1", updated.Status.RecreatedOperands[0].Name)
+	latest := updated.Status.RecreatedOperands[maxRecreatedOperands-1]
+	require.Equal(t, "Service", latest.Kind)
+	require.Equal(t, "nvidia-dcgm-exporter", latest.Name)
+	require.Equal(t, []string{"spec.clusterIP"}, latest.Fields)
+	require.False(t, latest.OrphanedDependents)
+}
+
+func TestRecreateOperandDisabled(t *testing.T) {
+	store := operatorconfig.NewStore(nil)
+	_, err := store.Apply(map[string]string{operatorconfig.FeatureGatesKey: "OperandRecreation=false"})
+	require.NoError(t, err)
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "nvidia-dcgm-exporter", Namespace: "test-ns"}}
+	n := ClusterPolicyController{
+		client:         fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(svc).Build(),
+		logger:         logr.Discard(),
+		operatorConfig: store,
+	}
+
+	state, err := recreateOperand(context.Background(), n, svc, "Service", []string{"spec.clusterIP"}, false)
+	require.Error(t, err)
+	require.Equal(t, gpuv1.NotReady, state)
+	require.NoError(t, n.client.Get(context.Background(), client.ObjectKeyFromObject(svc), &corev1.Service{}))
+}
+
+func TestRecreateDeployment(t *testing.T) {
+	newDeployment := func(selector map[string]string, podLabels map[string]string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "nvidia-nfd-master", Namespace: "test-ns"},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: selector},
+				Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: podLabels}},
+			},
+		}
+	}
+	cp := &gpuv1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy"}}
+
+	testCases := []struct {
+		description    string
+		current        *appsv1.Deployment
+		new            *appsv1.Deployment
+		expectOrphaned bool
+	}{
+		{
+			description:    "existing pods match the new selector",
+			current:        newDeployment(map[string]string{"app": "old"}, map[string]string{"app": "old", "app.kubernetes.io/name": "new"}),
+			new:            newDeployment(map[string]string{"app.kubernetes.io/name": "new"}, map[string]string{"app.kubernetes.io/name": "new"}),
+			expectOrphaned: true,
+		},
+		{
+			description: "existing pods do not match the new selector",
+			current:     newDeployment(map[string]string{"app": "old"}, map[string]string{"app": "old"}),
+			new:         newDeployment(map[string]string{"app": "new"}, map[string]string{"app": "new"}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			policy := cp.DeepCopy()
+			n := ClusterPolicyController{
+				client:    fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(policy, tc.current).WithStatusSubresource(policy).Build(),
+				logger:    logr.Discard(),
+				singleton: policy,
+			}
+
+			state, err := recreateDeployment(context.Background(), tc.new, []string{"spec.selector"}, n)
+			require.NoError(t, err)
+			require.Equal(t, gpuv1.NotReady, state)
+			require.True(t, apierrors.IsNotFound(n.client.Get(context.Background(), client.ObjectKeyFromObject(tc.new), &appsv1.Deployment{})))
+			require.Len(t, policy.Status.RecreatedOperands, 1)
+			require.Equal(t, tc.expectOrphaned, policy.Status.RecreatedOperands[0].OrphanedDependents)
+		})
+	}
+}
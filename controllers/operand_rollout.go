@@ -0,0 +1,238 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+const (
+	// RolloutChangesAnnotationKey is the DaemonSet annotation listing the pod template fields changed by the
+	// latest update of the DaemonSet which restarted its pods
+	RolloutChangesAnnotationKey = "nvidia.com/gpu-operator.rollout-changes"
+
+	// maxRolloutChanges is the number of changed fields listed in the rollout summary
+	maxRolloutChanges = 10
+)
+
+// podTemplateChanges returns the sorted fields of the pod template changed by an update of a DaemonSet, e.g.
+// containers[nvidia-driver-ctr].env[DRIVER_CONFIG_DIGEST], so that the restarts of the operand pods can be
+// correlated with the configuration changes causing them. Only the fields set by the operator are compared,
+// the fields defaulted by the API server in the current pod template are ignored. The values are not listed as
+// they may hold credentials, e.g. in the proxy environment variables.
+func podTemplateChanges(current, new *corev1.PodTemplateSpec) []string {
+	var changes []string
+	changes = append(changes, mapChanges("labels", current.Labels, new.Labels)...)
+	changes = append(changes, mapChanges("annotations", current.Annotations, new.Annotations)...)
+
+	currentSpec, newSpec := &current.Spec, &new.Spec
+	changes = append(changes, containerChanges("initContainers", currentSpec.InitContainers, newSpec.InitContainers)...)
+	changes = append(changes, containerChanges("containers", currentSpec.Containers, newSpec.Containers)...)
+	changes = append(changes, mapChanges("volumes", volumeSources(currentSpec.Volumes), volumeSources(newSpec.Volumes))...)
+	changes = append(changes, mapChanges("nodeSelector", currentSpec.NodeSelector, newSpec.NodeSelector)...)
+
+	fields := []struct {
+		name             string
+		current, updated interface{}
+	}{
+		{"affinity", currentSpec.Affinity, newSpec.Affinity},
+		{"tolerations", currentSpec.Tolerations, newSpec.Tolerations},
+		{"imagePullSecrets", currentSpec.ImagePullSecrets, newSpec.ImagePullSecrets},
+		{"serviceAccountName", currentSpec.ServiceAccountName, newSpec.ServiceAccountName},
+		{"priorityClassName", currentSpec.PriorityClassName, newSpec.PriorityClassName},
+		{"runtimeClassName", currentSpec.RuntimeClassName, newSpec.RuntimeClassName},
+		{"hostNetwork", currentSpec.HostNetwork, newSpec.HostNetwork},
+		{"hostPID", currentSpec.HostPID, newSpec.HostPID},
+		{"hostIPC", currentSpec.HostIPC, newSpec.HostIPC},
+	}
+	for _, field := range fields {
+		if !equality.Semantic.DeepEqual(field.current, field.updated) {
+			changes = append(changes, field.name)
+		}
+	}
+
+	sort.Strings(changes)
+	if len(changes) > maxRolloutChanges {
+		changes = append(changes[:maxRolloutChanges], fmt.Sprintf("+%d more", len(changes)-maxRolloutChanges))
+	}
+	return changes
+}
+
+// containerChanges returns the fields changed between the containers with the same name, and the containers added
+// or removed
+func containerChanges(field string, current, new []corev1.Container) []string {
+	currentContainers := make(map[string]*corev1.Container, len(current))
+	for i := range current {
+		currentContainers[current[i].Name] = &current[i]
+	}
+	var changes []string
+	for i := range new {
+		updated := &new[i]
+		container, ok := currentContainers[updated.Name]
+		if !ok {
+			changes = append(changes, fmt.Sprintf("%s[+%s]", field, updated.Name))
+			continue
+		}
+		delete(currentContainers, updated.Name)
+
+		prefix := fmt.Sprintf("%s[%s]", field, updated.Name)
+		if container.Image != updated.Image {
+			changes = append(changes, prefix+".image")
+		}
+		if !equality.Semantic.DeepEqual(container.Command, updated.Command) {
+			changes = append(changes, prefix+".command")
+		}
+		if !equality.Semantic.DeepEqual(container.Args, updated.Args) {
+			changes = append(changes, prefix+".args")
+		}
+		if !equality.Semantic.DeepEqual(container.Resources, updated.Resources) {
+			changes = append(changes, prefix+".resources")
+		}
+		if !equality.Semantic.DeepEqual(container.SecurityContext, updated.SecurityContext) {
+			changes = append(changes, prefix+".securityContext")
+		}
+		changes = append(changes, mapChanges(prefix+".env", envSources(container.Env), envSources(updated.Env))...)
+		changes = append(changes, mapChanges(prefix+".volumeMounts", volumeMountTargets(container.VolumeMounts), volumeMountTargets(updated.VolumeMounts))...)
+	}
+	for name := range currentContainers {
+		changes = append(changes, fmt.Sprintf("%s[-%s]", field, name))
+	}
+	return changes
+}
+
+// mapChanges returns the keys added, removed or changed between two maps, e.g. between the maps of the names of
+// named items to a summary of their values
+func mapChanges(field string, current, new map[string]string) []string {
+	var changes []string
+	for key, value := range new {
+		currentValue, ok := current[key]
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("%s[+%s]", field, key))
+		case currentValue != value:
+			changes = append(changes, fmt.Sprintf("%s[%s]", field, key))
+		}
+	}
+	for key := range current {
+		if _, ok := new[key]; !ok {
+			changes = append(changes, fmt.Sprintf("%s[-%s]", field, key))
+		}
+	}
+	return changes
+}
+
+// envSources returns the value or the source of the value of each environment variable
+func envSources(env []corev1.EnvVar) map[string]string {
+	sources := make(map[string]string, len(env))
+	for _, e := range env {
+		source := e.Value
+		if from := e.ValueFrom; from != nil {
+			switch {
+			case from.FieldRef != nil:
+				source = "field:" + from.FieldRef.FieldPath
+			case from.ResourceFieldRef != nil:
+				source = "resource:" + from.ResourceFieldRef.Resource
+			case from.ConfigMapKeyRef != nil:
+				source = "configmap:" + from.ConfigMapKeyRef.Name + "/" + from.ConfigMapKeyRef.Key
+			case from.SecretKeyRef != nil:
+				source = "secret:" + from.SecretKeyRef.Name + "/" + from.SecretKeyRef.Key
+			}
+		}
+		sources[e.Name] = source
+	}
+	return sources
+}
+
+// volumeMountTargets returns where each volume mount is mounted
+func volumeMountTargets(mounts []corev1.VolumeMount) map[string]string {
+	targets := make(map[string]string, len(mounts))
+	for _, mount := range mounts {
+		// the same volume can be mounted several times
+		target := fmt.Sprintf("%s:%s:%t", mount.MountPath, mount.SubPath, mount.ReadOnly)
+		if previous, ok := targets[mount.Name]; ok {
+			target = previous + "," + target
+		}
+		targets[mount.Name] = target
+	}
+	return targets
+}
+
+// volumeSources returns the object or the host path backing each volume
+func volumeSources(volumes []corev1.Volume) map[string]string {
+	sources := make(map[string]string, len(volumes))
+	for _, volume := range volumes {
+		var source string
+		switch {
+		case volume.HostPath != nil:
+			source = "hostPath:" + volume.HostPath.Path
+		case volume.ConfigMap != nil:
+			source = "configmap:" + volume.ConfigMap.Name
+		case volume.Secret != nil:
+			source = "secret:" + volume.Secret.SecretName
+		case volume.PersistentVolumeClaim != nil:
+			source = "pvc:" + volume.PersistentVolumeClaim.ClaimName
+		case volume.EmptyDir != nil:
+			source = "emptyDir"
+		case volume.CSI != nil:
+			source = "csi:" + volume.CSI.Driver
+		case volume.Projected != nil:
+			var projected []string
+			for _, projection := range volume.Projected.Sources {
+				switch {
+				case projection.ConfigMap != nil:
+					projected = append(projected, "configmap:"+projection.ConfigMap.Name)
+				case projection.Secret != nil:
+					projected = append(projected, "secret:"+projection.Secret.Name)
+				}
+			}
+			source = "projected:" + strings.Join(projected, ",")
+		}
+		sources[volume.Name] = source
+	}
+	return sources
+}
+
+// annotateRolloutChanges annotates the updated DaemonSet with the pod template fields changed by the update, and
+// returns them. The annotation of the latest rollout is kept by the updates leaving the pod template unchanged.
+func annotateRolloutChanges(current, updated *appsv1.DaemonSet) []string {
+	changes := podTemplateChanges(&current.Spec.Template, &updated.Spec.Template)
+	if len(changes) == 0 {
+		if previous, ok := current.Annotations[RolloutChangesAnnotationKey]; ok {
+			updated.Annotations[RolloutChangesAnnotationKey] = previous
+		}
+		return nil
+	}
+	updated.Annotations[RolloutChangesAnnotationKey] = strings.Join(changes, ", ")
+	return changes
+}
+
+// recordRollout records an event on the ClusterPolicy when the update of a DaemonSet restarts its pods
+func (n ClusterPolicyController) recordRollout(name string, changes []string) {
+	if len(changes) == 0 || n.recorder == nil || n.singleton == nil {
+		return
+	}
+	n.recorder.Eventf(n.singleton, nil, corev1.EventTypeNormal, conditions.OperandRollout, "Update",
+		"DaemonSet %s updated, restarting its pods, as %s changed", name, strings.Join(changes, ", "))
+}
@@ -0,0 +1,109 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	driverconfig "github.com/NVIDIA/gpu-operator/internal/config"
+)
+
+func newRolloutDaemonSet() *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "nvidia-driver-daemonset", Annotations: map[string]string{}},
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "nvidia-driver-daemonset"}},
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{
+						Name:  "k8s-driver-manager",
+						Image: "nvcr.io/nvidia/cloud-native/k8s-driver-manager:v0.8.0",
+						Env:   []corev1.EnvVar{{Name: "DRIVER_CONFIG_DIGEST", Value: "1234"}},
+					}},
+					Containers: []corev1.Container{{
+						Name:  "nvidia-driver-ctr",
+						Image: "nvcr.io/nvidia/driver:550.54.15",
+						Env: []corev1.EnvVar{
+							{Name: "DRIVER_CONFIG_DIGEST", Value: "1234"},
+							{Name: "NODE_NAME", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"}}},
+						},
+						VolumeMounts: []corev1.VolumeMount{{Name: "run-nvidia", MountPath: "/run/nvidia"}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name:         "run-nvidia",
+						VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/run/nvidia"}},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func TestPodTemplateChanges(t *testing.T) {
+	// the fields defaulted by the API server are ignored
+	current := newRolloutDaemonSet()
+	podSpec := &current.Spec.Template.Spec
+	podSpec.Containers[0].ImagePullPolicy = corev1.PullIfNotPresent
+	podSpec.Containers[0].TerminationMessagePath = corev1.TerminationMessagePathDefault
+	podSpec.Containers[0].Env[1].ValueFrom.FieldRef.APIVersion = "v1"
+	podSpec.Volumes[0].HostPath.Type = ptr.To(corev1.HostPathUnset)
+	podSpec.RestartPolicy = corev1.RestartPolicyAlways
+	podSpec.DNSPolicy = corev1.DNSClusterFirst
+	podSpec.SchedulerName = corev1.DefaultSchedulerName
+	require.Empty(t, podTemplateChanges(&current.Spec.Template, &newRolloutDaemonSet().Spec.Template))
+
+	updated := newRolloutDaemonSet()
+	podSpec = &updated.Spec.Template.Spec
+	podSpec.InitContainers[0].Env[0].Value = "5678"
+	podSpec.Containers[0].Env[0].Value = "5678"
+	podSpec.Containers[0].Image = "nvcr.io/nvidia/driver:570.86.15"
+	podSpec.Containers = append(podSpec.Containers, corev1.Container{Name: "nvidia-fs-ctr"})
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{Name: "trusted-ca"})
+	podSpec.Tolerations = []corev1.Toleration{{Operator: corev1.TolerationOpExists}}
+	updated.Spec.Template.Annotations = map[string]string{driverconfig.RenderedConfigDigestAnnotationKey: "1"}
+	require.Equal(t, []string{
+		"annotations[+" + driverconfig.RenderedConfigDigestAnnotationKey + "]",
+		"containers[+nvidia-fs-ctr]",
+		"containers[nvidia-driver-ctr].env[DRIVER_CONFIG_DIGEST]",
+		"containers[nvidia-driver-ctr].image",
+		"initContainers[k8s-driver-manager].env[DRIVER_CONFIG_DIGEST]",
+		"tolerations",
+		"volumes[+trusted-ca]",
+	}, podTemplateChanges(&current.Spec.Template, &updated.Spec.Template))
+}
+
+func TestAnnotateRolloutChanges(t *testing.T) {
+	current := newRolloutDaemonSet()
+	updated := newRolloutDaemonSet()
+	updated.Spec.Template.Spec.Containers[0].Image = "nvcr.io/nvidia/driver:570.86.15"
+	require.Equal(t, []string{"containers[nvidia-driver-ctr].image"}, annotateRolloutChanges(current, updated))
+	require.Equal(t, "containers[nvidia-driver-ctr].image", updated.Annotations[RolloutChangesAnnotationKey])
+
+	// the summary of the latest rollout is kept by the updates which do not restart the pods
+	current = updated
+	updated = current.DeepCopy()
+	updated.Annotations = map[string]string{}
+	updated.Spec.UpdateStrategy.Type = appsv1.OnDeleteDaemonSetStrategyType
+	require.Empty(t, annotateRolloutChanges(current, updated))
+	require.Equal(t, "containers[nvidia-driver-ctr].image", updated.Annotations[RolloutChangesAnnotationKey])
+}
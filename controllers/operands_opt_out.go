@@ -0,0 +1,151 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+// operandsOptOutDrainInterval is the interval the GPU workloads of the nodes opting out of the operands are evicted
+// at until the deadline, the evictions blocked by a PodDisruptionBudget being retried
+const operandsOptOutDrainInterval = 30 * time.Second
+
+// parseOperandsOptOutDeadline returns the deadline of the operands opt-out requested on a node with the
+// nvidia.com/gpu.operands.disable-after annotation, and whether an opt-out is requested
+func parseOperandsOptOutDeadline(annotations map[string]string) (time.Time, bool, error) {
+	value, ok := annotations[consts.OperandsDisableAfterAnnotationKey]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	deadline, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid %s annotation %q, expected an RFC 3339 time: %w",
+			consts.OperandsDisableAfterAnnotationKey, value, err)
+	}
+	return deadline, true, nil
+}
+
+// getOperandsOptOutDeadline returns the deadline of the operands opt-out requested on the node, and whether an
+// opt-out is requested. An invalid deadline is logged and ignored.
+func (nlc *nodeLabelingController) getOperandsOptOutDeadline(node *corev1.Node) (time.Time, bool) {
+	deadline, requested, err := parseOperandsOptOutDeadline(node.Annotations)
+	if err != nil {
+		nlc.logger.Error(err, "Ignoring the operands opt-out of node", "NodeName", node.Name)
+	}
+	return deadline, requested
+}
+
+// reconcileOperandsOptOut drains the node until the deadline of its operands opt-out: the device plugin is paused,
+// so that no GPU workload is scheduled to the node anymore, and the GPU workloads running on the node are evicted.
+// The device plugin paused by an opt-out removed before its deadline is resumed. The operands are removed from the
+// node by the caller once the deadline passed. Returns true if the labels were modified.
+func (nlc *nodeLabelingController) reconcileOperandsOptOut(ctx context.Context, node *corev1.Node, deadline time.Time, requested bool) bool {
+	labels := node.GetLabels()
+	if !requested {
+		if labels[devicePluginDeployLabelKey] != devicePluginPausedForOptOut {
+			return false
+		}
+		nlc.logger.Info("Operands opt-out removed, resuming the device plugin", "NodeName", node.Name)
+		labels[devicePluginDeployLabelKey] = "true"
+		return true
+	}
+
+	remaining := time.Until(deadline)
+	if remaining <= 0 || !hasCommonGPULabel(labels) {
+		return false
+	}
+	nlc.requeueOperandsOptOut(min(remaining, operandsOptOutDrainInterval))
+
+	modified := false
+	if labels[devicePluginDeployLabelKey] == "true" {
+		nlc.logger.Info("Operands opt-out pending, pausing the device plugin", "NodeName", node.Name, "Deadline", deadline)
+		labels[devicePluginDeployLabelKey] = devicePluginPausedForOptOut
+		modified = true
+	}
+	if err := nlc.evictGPUPods(ctx, node.Name); err != nil {
+		nlc.logger.Error(err, "failed to evict the GPU workloads of node, retrying", "NodeName", node.Name,
+			"RetryAfter", operandsOptOutDrainInterval)
+	}
+	return modified
+}
+
+// requeueOperandsOptOut records that the node labeling must be reconciled again after the given delay, to drain the
+// nodes and to remove their operands at the deadline of their opt-out
+func (nlc *nodeLabelingController) requeueOperandsOptOut(after time.Duration) {
+	if nlc.operandsOptOutRequeueAfter == 0 || after < nlc.operandsOptOutRequeueAfter {
+		nlc.operandsOptOutRequeueAfter = after
+	}
+}
+
+// evictGPUPods evicts the GPU workloads running on the node opting out of the operands
+func (nlc *nodeLabelingController) evictGPUPods(ctx context.Context, nodeName string) error {
+	_, err := evictNodeGPUPods(ctx, nlc.client, nlc.logger.WithValues("Reason", "operands opt-out"), nodeName)
+	return err
+}
+
+// evictNodeGPUPods evicts the GPU workloads running on the node, honoring their PodDisruptionBudgets, and returns
+// the GPU workloads still running on the node. The pods of the DaemonSets, which would be recreated on the node,
+// are left alone.
+func evictNodeGPUPods(ctx context.Context, c client.Client, logger logr.Logger, nodeName string) ([]string, error) {
+	podList := &corev1.PodList{}
+	if err := c.List(ctx, podList, client.MatchingFields{podNodeNameIndexKey: nodeName}); err != nil {
+		return nil, fmt.Errorf("failed to list the pods of node %s: %w", nodeName, err)
+	}
+	var running []string
+	var errs []error
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if !IsGPUPod(ctx, c, pod) {
+			continue
+		}
+		if owner := metav1.GetControllerOf(pod); owner != nil && owner.Kind == "DaemonSet" {
+			continue
+		}
+		running = append(running, client.ObjectKeyFromObject(pod).String())
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		logger.Info("Evicting GPU pod", "NodeName", nodeName, "Pod", client.ObjectKeyFromObject(pod))
+		eviction := &policyv1.Eviction{ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace}}
+		if err := c.SubResource("eviction").Create(ctx, pod, eviction); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to evict pod %s/%s: %w", pod.Namespace, pod.Name, err))
+		}
+	}
+	return running, errors.Join(errs...)
+}
+
+// recordOperandsOptOutEvent records an event on the node when its operands were removed at the deadline of its
+// opt-out
+func (nlc *nodeLabelingController) recordOperandsOptOutEvent(node *corev1.Node) {
+	if nlc.recorder == nil {
+		return
+	}
+	nlc.recorder.Eventf(node, nil, corev1.EventTypeNormal, "OperandsOptedOut", "DisableOperands",
+		"Operands removed from the node at the deadline of its %s annotation", consts.OperandsDisableAfterAnnotationKey)
+}
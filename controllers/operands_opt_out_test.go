@@ -0,0 +1,137 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+func TestParseOperandsOptOutDeadline(t *testing.T) {
+	_, requested, err := parseOperandsOptOutDeadline(nil)
+	require.NoError(t, err)
+	require.False(t, requested)
+
+	deadline, requested, err := parseOperandsOptOutDeadline(map[string]string{
+		consts.OperandsDisableAfterAnnotationKey: "2026-10-17T12:00:00Z",
+	})
+	require.NoError(t, err)
+	require.True(t, requested)
+	require.Equal(t, time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC), deadline)
+
+	_, requested, err = parseOperandsOptOutDeadline(map[string]string{consts.OperandsDisableAfterAnnotationKey: "1h"})
+	require.Error(t, err)
+	require.False(t, requested)
+}
+
+func TestLabelGPUNodesOperandsOptOut(t *testing.T) {
+	optOutNode := func(name, deadline string, labels map[string]string) *corev1.Node {
+		node := gpuNode(name, map[string]string{
+			"feature.node.kubernetes.io/pci-10de.present": "true",
+			consts.GPUAllocationModeLabelKey:              string(consts.GPUAllocationModeDevicePlugin),
+		})
+		for key, value := range labels {
+			node.Labels[key] = value
+		}
+		if deadline != "" {
+			node.Annotations = map[string]string{consts.OperandsDisableAfterAnnotationKey: deadline}
+		}
+		return node
+	}
+	pod := func(name string, gpu bool, ownerKind string) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: corev1.PodSpec{
+				NodeName:   "pending",
+				Containers: []corev1.Container{{Name: "ctr"}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if gpu {
+			p.Spec.Containers[0].Resources.Limits = corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")}
+		}
+		if ownerKind != "" {
+			p.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: ownerKind, Name: "owner", UID: "uid", Controller: ptr.To(true)}}
+		}
+		return p
+	}
+
+	future := time.Now().Add(time.Hour).Format(time.RFC3339)
+	past := time.Now().Add(-time.Minute).Format(time.RFC3339)
+	fakeClient := fake.NewClientBuilder().
+		WithIndex(&corev1.Pod{}, podNodeNameIndexKey, podNodeNameIndexer).
+		WithObjects(
+			optOutNode("pending", future, map[string]string{devicePluginDeployLabelKey: "true"}),
+			optOutNode("expired", past, map[string]string{devicePluginDeployLabelKey: "true", "nvidia.com/gpu.deploy.driver": "true"}),
+			optOutNode("cancelled", "", map[string]string{devicePluginDeployLabelKey: devicePluginPausedForOptOut}),
+			optOutNode("invalid", "tomorrow", map[string]string{devicePluginDeployLabelKey: "true"}),
+			pod("gpu-workload", true, "ReplicaSet"),
+			pod("gpu-daemonset", true, "DaemonSet"),
+			pod("cpu-workload", false, ""),
+		).
+		Build()
+
+	nlc := &nodeLabelingController{
+		client:        fakeClient,
+		clusterPolicy: &gpuv1.ClusterPolicy{},
+		defaultMode:   consts.GPUAllocationModeDevicePlugin,
+		logger:        logr.Discard(),
+	}
+	ctx := context.Background()
+	_, err := nlc.labelGPUNodes(ctx)
+	require.NoError(t, err)
+
+	nodeLabels := func(name string) map[string]string {
+		node := &corev1.Node{}
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: name}, node))
+		return node.Labels
+	}
+
+	// the device plugin of the pending node is paused and its GPU workloads are evicted until the deadline
+	require.Equal(t, devicePluginPausedForOptOut, nodeLabels("pending")[devicePluginDeployLabelKey])
+	require.Positive(t, nlc.operandsOptOutRequeueAfter)
+	require.LessOrEqual(t, nlc.operandsOptOutRequeueAfter, operandsOptOutDrainInterval)
+	err = fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "gpu-workload"}, &corev1.Pod{})
+	require.True(t, apierrors.IsNotFound(err))
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "gpu-daemonset"}, &corev1.Pod{}))
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "cpu-workload"}, &corev1.Pod{}))
+
+	// the operands of the expired node are removed
+	for key := range nodeLabels("expired") {
+		require.NotContains(t, clusterPolicyStateLabelKeys(), key)
+	}
+
+	// the device plugin paused by a removed opt-out is resumed
+	require.Equal(t, "true", nodeLabels("cancelled")[devicePluginDeployLabelKey])
+
+	// an invalid deadline is ignored
+	require.Equal(t, "true", nodeLabels("invalid")[devicePluginDeployLabelKey])
+}
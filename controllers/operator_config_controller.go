@@ -0,0 +1,125 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/NVIDIA/gpu-operator/internal/apistats"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+	"github.com/NVIDIA/gpu-operator/internal/operatorconfig"
+)
+
+// OperatorConfigReconciler applies the operator ConfigMap to the operator configuration at runtime, so that the
+// log level, the requeue interval, the state timeouts and the feature gates change without restarting the operator
+type OperatorConfigReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Namespace string
+	Log       logr.Logger
+	// ConfigMapName is the name of the operator ConfigMap in the operator namespace
+	ConfigMapName string
+	// Store holds the operator configuration read by the other controllers
+	Store *operatorconfig.Store
+	// APIStats counts the requests of the reconciliations, nothing is counted when nil
+	APIStats *apistats.Tracker
+
+	recorder events.EventRecorder
+}
+
+// Reconcile validates the operator ConfigMap and applies it. An invalid ConfigMap is reported by an event and
+// leaves the current configuration unchanged. The flags apply again when the ConfigMap is deleted.
+func (r *OperatorConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, done := r.APIStats.StartReconcile(ctx, "OperatorConfig")
+	defer done()
+
+	configMap := &corev1.ConfigMap{}
+	if err := r.Get(ctx, req.NamespacedName, configMap); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to get the operator ConfigMap: %w", err)
+		}
+		configMap = nil
+	}
+
+	var data map[string]string
+	if configMap != nil {
+		data = configMap.Data
+	}
+	changed, err := r.Store.Apply(data)
+	if err != nil {
+		r.Log.Error(err, "invalid operator ConfigMap, keeping the current configuration", "ConfigMap", req.Name)
+		if r.recorder != nil {
+			r.recorder.Eventf(configMap, nil, corev1.EventTypeWarning, conditions.OperatorConfigInvalid, "Validate",
+				"The operator configuration is not applied: %v", err)
+		}
+		// the ConfigMap is validated again when it changes
+		return reconcile.Result{}, nil
+	}
+	if len(changed) == 0 {
+		return reconcile.Result{}, nil
+	}
+
+	r.Log.Info("Applied the operator configuration", "ConfigMap", req.Name, "changed", changed)
+	if r.recorder != nil && configMap != nil {
+		r.recorder.Eventf(configMap, nil, corev1.EventTypeNormal, conditions.OperatorConfigApplied, "Apply",
+			"Applied the operator configuration, changed %s", strings.Join(changed, ", "))
+	}
+	return reconcile.Result{}, nil
+}
+
+// SetupWithManager registers the OperatorConfigReconciler with the controller-runtime manager.
+func (r *OperatorConfigReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	r.recorder = mgr.GetEventRecorder("nvidia-gpu-operator")
+
+	c, err := controller.New("operator-config-controller", mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: 1,
+		RateLimiter:             workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](minDelayCR, maxDelayCR),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating operator-config controller: %w", err)
+	}
+
+	isOperatorConfig := func(cm *corev1.ConfigMap) bool {
+		return cm.Namespace == r.Namespace && cm.Name == r.ConfigMapName
+	}
+	if err := c.Watch(source.Kind(
+		mgr.GetCache(),
+		&corev1.ConfigMap{},
+		&handler.TypedEnqueueRequestForObject[*corev1.ConfigMap]{},
+		predicate.NewTypedPredicateFuncs(isOperatorConfig),
+	)); err != nil {
+		return fmt.Errorf("error watching the operator ConfigMap: %w", err)
+	}
+
+	return nil
+}
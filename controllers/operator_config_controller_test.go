@@ -0,0 +1,77 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/NVIDIA/gpu-operator/internal/operatorconfig"
+)
+
+func TestOperatorConfigReconcile(t *testing.T) {
+	ctx := context.Background()
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: operatorconfig.DefaultConfigMapName, Namespace: "gpu-operator"},
+		Data:       map[string]string{operatorconfig.RequeueIntervalKey: "30s"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(configMap).Build()
+	recorder := events.NewFakeRecorder(10)
+	store := operatorconfig.NewStore(nil)
+	r := &OperatorConfigReconciler{
+		Client:        c,
+		Namespace:     "gpu-operator",
+		Log:           logr.Discard(),
+		ConfigMapName: operatorconfig.DefaultConfigMapName,
+		Store:         store,
+		recorder:      recorder,
+	}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "gpu-operator", Name: operatorconfig.DefaultConfigMapName}}
+	cp := &ClusterPolicyReconciler{ReconcileOptions: ReconcileOptions{RequeueInterval: 5 * time.Second}, OperatorConfig: store}
+
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, 30*time.Second, cp.requeueInterval())
+	require.Len(t, recorder.Events, 1)
+	require.True(t, strings.HasPrefix(<-recorder.Events, "Normal OperatorConfigApplied"))
+
+	// an invalid change is reported and not applied
+	configMap.Data[operatorconfig.RequeueIntervalKey] = "soon"
+	require.NoError(t, c.Update(ctx, configMap))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, 30*time.Second, cp.requeueInterval())
+	require.True(t, strings.HasPrefix(<-recorder.Events, "Warning OperatorConfigInvalid"))
+
+	// the flags apply again once the ConfigMap is deleted
+	require.NoError(t, c.Delete(ctx, configMap))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, 5*time.Second, cp.requeueInterval())
+}
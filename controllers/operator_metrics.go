@@ -22,6 +22,8 @@ import (
 	promcli "github.com/prometheus/client_golang/prometheus"
 
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/NVIDIA/gpu-operator/internal/accounting"
 )
 
 // OperatorMetrics defines the Prometheus metrics exposed for the
@@ -47,6 +49,11 @@ type OperatorMetrics struct {
 	upgradesFailed           promcli.Gauge
 	upgradesAvailable        promcli.Gauge
 	upgradesPending          promcli.Gauge
+
+	usageReportLastPeriodEnd promcli.Gauge
+	usageReportGPUSeconds    *promcli.GaugeVec
+
+	licenseServerReachable promcli.Gauge
 }
 
 const (
@@ -62,6 +69,10 @@ const (
 	driverAutoUpgradeEnabled  = 1
 	driverAutoUpgradeDisabled = 0
 
+	licenseServerReachable   = 1
+	licenseServerUnreachable = 0
+	licenseServerUnknown     = -1
+
 	// operatorMetricsNamespace is the name of the namespace used for the GPU Operator metrics.
 	operatorMetricsNamespace = "gpu_operator"
 )
@@ -194,7 +205,33 @@ func InitOperatorMetrics() *OperatorMetrics {
 				Help:      "Total number of nodes on which the gpu operator pod upgrades are pending",
 			},
 		),
+		usageReportLastPeriodEnd: promcli.NewGauge(
+			promcli.GaugeOpts{
+				Namespace: operatorMetricsNamespace,
+				Name:      "usage_report_last_period_end_ts_seconds",
+				Help:      "Timestamp (in seconds) of the end of the period covered by the last GPU usage report",
+			},
+		),
+		usageReportGPUSeconds: promcli.NewGaugeVec(
+			promcli.GaugeOpts{
+				Namespace: operatorMetricsNamespace,
+				Name:      "usage_report_gpu_seconds",
+				Help:      "GPU-seconds allocated per namespace and GPU resource over the period of the last GPU usage report",
+			},
+			[]string{"namespace", "resource"},
+		),
+		licenseServerReachable: promcli.NewGauge(
+			promcli.GaugeOpts{
+				Namespace: operatorMetricsNamespace,
+				Name:      "license_server_reachable",
+				Help: fmt.Sprintf("%d if the vGPU license server was reachable from a GPU node at the last check, %d if it was unreachable, %d if it is not monitored or was not checked yet.",
+					licenseServerReachable,
+					licenseServerUnreachable,
+					licenseServerUnknown),
+			},
+		),
 	}
+	m.licenseServerReachable.Set(licenseServerUnknown)
 
 	metrics.Registry.MustRegister(
 		m.gpuNodesTotal,
@@ -217,7 +254,33 @@ func InitOperatorMetrics() *OperatorMetrics {
 		m.upgradesAvailable,
 		m.upgradesFailed,
 		m.upgradesPending,
+
+		m.usageReportLastPeriodEnd,
+		m.usageReportGPUSeconds,
+
+		m.licenseServerReachable,
 	)
 
 	return m
 }
+
+// setUsageReport exports the GPU-seconds per namespace and resource of the last usage report
+func (m *OperatorMetrics) setUsageReport(report *accounting.Report) {
+	if m == nil {
+		return
+	}
+	m.usageReportLastPeriodEnd.Set(float64(report.PeriodEnd.Unix()))
+	// namespaces without usage in the period are removed
+	m.usageReportGPUSeconds.Reset()
+	for _, total := range report.NamespaceTotals() {
+		m.usageReportGPUSeconds.WithLabelValues(total.Namespace, total.Resource).Set(total.GPUSeconds)
+	}
+}
+
+// setLicenseServerReachable exports the outcome of the last check of the license server
+func (m *OperatorMetrics) setLicenseServerReachable(status int) {
+	if m == nil {
+		return
+	}
+	m.licenseServerReachable.Set(float64(status))
+}
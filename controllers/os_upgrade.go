@@ -0,0 +1,142 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+const (
+	// the Machine Config Operator rolls out the MachineConfigPool of a node by setting its desired config,
+	// which differs from its current config until the node is updated and rebooted
+	mcoCurrentConfigAnnotationKey = "machineconfiguration.openshift.io/currentConfig"
+	mcoDesiredConfigAnnotationKey = "machineconfiguration.openshift.io/desiredConfig"
+)
+
+// getOSUpgradeTargetKernels returns the kernel versions announced for the upcoming OS upgrade of the given nodes,
+// mapped to the OS the nodes run after the upgrade. The kernel versions already run by a node, which are part of
+// the given kernel version map, are not returned.
+func getOSUpgradeTargetKernels(nodes []corev1.Node, kernelVersionMap map[string]string) (map[string]string, error) {
+	targets := make(map[string]string)
+	for _, node := range nodes {
+		kernelVersion := node.Annotations[consts.OSUpgradeTargetKernelAnnotationKey]
+		if kernelVersion == "" {
+			continue
+		}
+		nodeOS := node.Annotations[consts.OSUpgradeTargetOSAnnotationKey]
+		if nodeOS == "" {
+			// the OS version is not expected to change
+			nodeOS = node.Labels[nfdOSReleaseIDLabelKey] + node.Labels[nfdOSVersionIDLabelKey]
+		}
+		if os, ok := kernelVersionMap[kernelVersion]; ok {
+			if os != nodeOS {
+				return nil, fmt.Errorf("different OS versions found for the target kernel version %s of node %s, unsupported configuration", kernelVersion, node.Name)
+			}
+			continue
+		}
+		if os, ok := targets[kernelVersion]; ok && os != nodeOS {
+			return nil, fmt.Errorf("different OS versions found for the target kernel version %s of node %s, unsupported configuration", kernelVersion, node.Name)
+		}
+		targets[kernelVersion] = nodeOS
+	}
+	return targets, nil
+}
+
+// hasPendingMachineConfig returns true if the Machine Config Operator has yet to update the node to the desired
+// config of its MachineConfigPool
+func hasPendingMachineConfig(node *corev1.Node) bool {
+	current := node.Annotations[mcoCurrentConfigAnnotationKey]
+	desired := node.Annotations[mcoDesiredConfigAnnotationKey]
+	return current != "" && desired != "" && current != desired
+}
+
+// getOSUpgradeTargetRHCOSVersions returns the RHCOS versions the given nodes run after their upcoming OS upgrade,
+// which are not run by any node yet. The version is either announced through the node annotation, or is any
+// version of the driver-toolkit imagestream while a MachineConfigPool rollout is pending on the node, as the
+// imagestream is updated to the RHCOS version of the new OpenShift release before the nodes are.
+func getOSUpgradeTargetRHCOSVersions(nodes []corev1.Node, rhcosVersions map[string]bool, driverToolkitImages map[string]string) []string {
+	targets := make(map[string]bool)
+	for i := range nodes {
+		node := &nodes[i]
+		if version := node.Annotations[consts.OSUpgradeTargetRHCOSAnnotationKey]; version != "" {
+			targets[version] = true
+		}
+		if hasPendingMachineConfig(node) {
+			for version := range driverToolkitImages {
+				targets[version] = true
+			}
+		}
+	}
+
+	var versions []string
+	for version := range targets {
+		if _, ok := rhcosVersions[version]; !ok {
+			versions = append(versions, version)
+		}
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// preStageOSUpgradeKernels adds the kernel versions announced for the upcoming OS upgrade of the GPU nodes to the
+// kernel versions of the cluster, so that their pre-compiled driver DaemonSets are created before the nodes reboot
+func (n *ClusterPolicyController) preStageOSUpgradeKernels() error {
+	nodes, err := n.listGPUNodes(nil)
+	if err != nil {
+		return err
+	}
+	targets, err := getOSUpgradeTargetKernels(nodes, n.kernelVersionMap)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+	if n.kernelVersionMap == nil {
+		n.kernelVersionMap = make(map[string]string)
+	}
+	for kernelVersion, os := range targets {
+		n.logger.Info("Pre-staging the driver for the upcoming OS upgrade", "kernelVersion", kernelVersion, "os", os)
+		n.kernelVersionMap[kernelVersion] = os
+	}
+	n.preStagedKernelVersions = targets
+	return nil
+}
+
+// preStageOSUpgradeRHCOSVersions adds the RHCOS versions the GPU nodes run after their upcoming OS upgrade to the
+// RHCOS versions of the cluster, so that their driver-toolkit DaemonSets are created before the nodes reboot
+func (n *ClusterPolicyController) preStageOSUpgradeRHCOSVersions() error {
+	nodes, err := n.listGPUNodes(nil)
+	if err != nil {
+		return err
+	}
+	for _, version := range getOSUpgradeTargetRHCOSVersions(nodes, n.ocpDriverToolkit.rhcosVersions, n.ocpDriverToolkit.rhcosDriverToolkitImages) {
+		if n.ocpDriverToolkit.rhcosDriverToolkitImages[version] == "" {
+			n.logger.Info("WARNING: driver-toolkit image of the upcoming RHCOS version not found, skipping its pre-staging",
+				"rhcosVersion", version)
+			continue
+		}
+		n.logger.Info("Pre-staging the driver for the upcoming OS upgrade", "rhcosVersion", version)
+		n.ocpDriverToolkit.rhcosVersions[version] = true
+	}
+	return nil
+}
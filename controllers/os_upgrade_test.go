@@ -0,0 +1,199 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+func newOSUpgradeNode(name string, annotations map[string]string) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				commonGPULabelKey:      commonGPULabelValue,
+				nfdOSReleaseIDLabelKey: "ubuntu",
+				nfdOSVersionIDLabelKey: "22.04",
+			},
+			Annotations: annotations,
+		},
+	}
+}
+
+func TestGetOSUpgradeTargetKernels(t *testing.T) {
+	kernelVersionMap := map[string]string{"5.15.0-100-generic": "ubuntu22.04"}
+
+	testCases := []struct {
+		description     string
+		nodes           []corev1.Node
+		expectedTargets map[string]string
+		expectError     bool
+	}{
+		{
+			description:     "no upgrade announced",
+			nodes:           []corev1.Node{newOSUpgradeNode("node1", nil)},
+			expectedTargets: map[string]string{},
+		},
+		{
+			description: "kernel upgrade",
+			nodes: []corev1.Node{
+				newOSUpgradeNode("node1", map[string]string{consts.OSUpgradeTargetKernelAnnotationKey: "5.15.0-105-generic"}),
+				newOSUpgradeNode("node2", map[string]string{consts.OSUpgradeTargetKernelAnnotationKey: "5.15.0-105-generic"}),
+			},
+			expectedTargets: map[string]string{"5.15.0-105-generic": "ubuntu22.04"},
+		},
+		{
+			description: "OS upgrade",
+			nodes: []corev1.Node{
+				newOSUpgradeNode("node1", map[string]string{
+					consts.OSUpgradeTargetKernelAnnotationKey: "6.8.0-60-generic",
+					consts.OSUpgradeTargetOSAnnotationKey:     "ubuntu24.04",
+				}),
+			},
+			expectedTargets: map[string]string{"6.8.0-60-generic": "ubuntu24.04"},
+		},
+		{
+			description: "kernel already run by a node",
+			nodes: []corev1.Node{
+				newOSUpgradeNode("node1", map[string]string{consts.OSUpgradeTargetKernelAnnotationKey: "5.15.0-100-generic"}),
+			},
+			expectedTargets: map[string]string{},
+		},
+		{
+			description: "kernel run by a node with another OS",
+			nodes: []corev1.Node{
+				newOSUpgradeNode("node1", map[string]string{
+					consts.OSUpgradeTargetKernelAnnotationKey: "5.15.0-100-generic",
+					consts.OSUpgradeTargetOSAnnotationKey:     "ubuntu24.04",
+				}),
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			targets, err := getOSUpgradeTargetKernels(tc.nodes, kernelVersionMap)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedTargets, targets)
+		})
+	}
+}
+
+func TestGetOSUpgradeTargetRHCOSVersions(t *testing.T) {
+	rhcosVersions := map[string]bool{"417.94.202410090804-0": true}
+	driverToolkitImages := map[string]string{
+		"417.94.202410090804-0": "quay.io/openshift/driver-toolkit@sha256:1111",
+		"418.94.202501221327-0": "quay.io/openshift/driver-toolkit@sha256:2222",
+	}
+	pendingConfig := map[string]string{
+		mcoCurrentConfigAnnotationKey: "rendered-worker-1",
+		mcoDesiredConfigAnnotationKey: "rendered-worker-2",
+	}
+	updatedConfig := map[string]string{
+		mcoCurrentConfigAnnotationKey: "rendered-worker-1",
+		mcoDesiredConfigAnnotationKey: "rendered-worker-1",
+	}
+
+	testCases := []struct {
+		description      string
+		nodes            []corev1.Node
+		expectedVersions []string
+	}{
+		{
+			description: "no upgrade",
+			nodes:       []corev1.Node{newOSUpgradeNode("node1", updatedConfig)},
+		},
+		{
+			description:      "pending MachineConfigPool rollout",
+			nodes:            []corev1.Node{newOSUpgradeNode("node1", updatedConfig), newOSUpgradeNode("node2", pendingConfig)},
+			expectedVersions: []string{"418.94.202501221327-0"},
+		},
+		{
+			description: "announced version",
+			nodes: []corev1.Node{
+				newOSUpgradeNode("node1", map[string]string{consts.OSUpgradeTargetRHCOSAnnotationKey: "418.94.202501221327-0"}),
+			},
+			expectedVersions: []string{"418.94.202501221327-0"},
+		},
+		{
+			description: "announced version already run by a node",
+			nodes: []corev1.Node{
+				newOSUpgradeNode("node1", map[string]string{consts.OSUpgradeTargetRHCOSAnnotationKey: "417.94.202410090804-0"}),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expectedVersions, getOSUpgradeTargetRHCOSVersions(tc.nodes, rhcosVersions, driverToolkitImages))
+		})
+	}
+}
+
+func TestCleanupStalePrecompiledDaemonsetsKeepsPreStaged(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	precompiledDaemonset := func(name string, kernelVersion string) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "test-ns",
+				Labels:    map[string]string{precompiledIdentificationLabelKey: precompiledIdentificationLabelValue},
+			},
+			Spec: appsv1.DaemonSetSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{NodeSelector: map[string]string{nfdKernelLabelKey: kernelVersion}},
+				},
+			},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		precompiledDaemonset("nvidia-driver-daemonset-5.15.0-100-generic-ubuntu22.04", "5.15.0-100-generic"),
+		precompiledDaemonset("nvidia-driver-daemonset-5.15.0-105-generic-ubuntu22.04", "5.15.0-105-generic"),
+	).Build()
+
+	n := ClusterPolicyController{
+		client:                  c,
+		logger:                  ctrl.Log.WithName("test"),
+		preStagedKernelVersions: map[string]string{"5.15.0-105-generic": "ubuntu22.04"},
+	}
+	require.NoError(t, n.cleanupStalePrecompiledDaemonsets(context.Background()))
+
+	list := &appsv1.DaemonSetList{}
+	require.NoError(t, c.List(context.Background(), list, client.InNamespace("test-ns")))
+	require.Len(t, list.Items, 1)
+	require.Equal(t, "nvidia-driver-daemonset-5.15.0-105-generic-ubuntu22.04", list.Items[0].Name)
+}
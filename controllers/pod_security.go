@@ -0,0 +1,76 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// podSecurityViolation is part of the message of the errors returned by the PodSecurity admission plugin
+const podSecurityViolation = "violates PodSecurity"
+
+// checkPodSecurityAdmission creates a pod from the template of the DaemonSet in dry-run mode, and records
+// the PodSecurity violations rejecting it. The DaemonSet controller only reports those rejections in events,
+// leaving the DaemonSet without pods.
+func checkPodSecurityAdmission(ds *appsv1.DaemonSet, n ClusterPolicyController) {
+	if n.podSecurityRejections == nil {
+		return
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: ds.Name + "-",
+			Namespace:    ds.Namespace,
+			Labels:       ds.Spec.Template.Labels,
+			Annotations:  ds.Spec.Template.Annotations,
+		},
+		Spec: *ds.Spec.Template.Spec.DeepCopy(),
+	}
+	err := n.client.Create(n.ctx, pod, client.DryRunAll)
+	if err == nil {
+		return
+	}
+	if !apierrors.IsForbidden(err) || !strings.Contains(err.Error(), podSecurityViolation) {
+		n.logger.V(1).Info("Could not verify the admission of the daemonset pods", "name", ds.Name, "error", err.Error())
+		return
+	}
+	n.logger.Info("PodSecurity admission rejects the daemonset pods", "name", ds.Name, "error", err.Error())
+	n.podSecurityRejections[ds.Name] = err.Error()
+}
+
+// getPodSecurityRejections returns the PodSecurity violations rejecting the pods of the DaemonSets, sorted
+// by DaemonSet name
+func (n *ClusterPolicyController) getPodSecurityRejections() []string {
+	names := make([]string, 0, len(n.podSecurityRejections))
+	for name := range n.podSecurityRejections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rejections := make([]string, 0, len(names))
+	for _, name := range names {
+		rejections = append(rejections, fmt.Sprintf("%s: %s", name, n.podSecurityRejections[name]))
+	}
+	return rejections
+}
@@ -0,0 +1,129 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestCheckPodSecurityAdmission(t *testing.T) {
+	podSecurityErr := apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "nvidia-driver-daemonset-x",
+		errors.New(`violates PodSecurity "baseline:latest": privileged (container "nvidia-driver-ctr" must not set securityContext.privileged=true)`))
+	quotaErr := apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "nvidia-driver-daemonset-x", errors.New("exceeded quota"))
+
+	testCases := []struct {
+		description        string
+		createErr          error
+		expectedRejections []string
+	}{
+		{
+			description:        "pods admitted",
+			expectedRejections: []string{},
+		},
+		{
+			description:        "pods rejected by PodSecurity",
+			createErr:          podSecurityErr,
+			expectedRejections: []string{"nvidia-driver-daemonset: " + podSecurityErr.Error()},
+		},
+		{
+			description:        "pods rejected by another admission plugin",
+			createErr:          quotaErr,
+			expectedRejections: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var dryRun bool
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Create: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+						createOpts := &client.CreateOptions{}
+						createOpts.ApplyOptions(opts)
+						dryRun = len(createOpts.DryRun) > 0
+						if tc.createErr != nil {
+							return tc.createErr
+						}
+						return cl.Create(ctx, obj, opts...)
+					},
+				}).Build()
+			n := ClusterPolicyController{
+				ctx:                   context.Background(),
+				client:                c,
+				logger:                ctrl.Log.WithName("test"),
+				podSecurityRejections: map[string]string{},
+			}
+			ds := &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "nvidia-driver-daemonset", Namespace: "gpu-operator"},
+				Spec: appsv1.DaemonSetSpec{
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "nvidia-driver-daemonset"}},
+						Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "nvidia-driver-ctr"}}},
+					},
+				},
+			}
+
+			checkPodSecurityAdmission(ds, n)
+			require.True(t, dryRun)
+			require.Equal(t, tc.expectedRejections, n.getPodSecurityRejections())
+
+			pods := &corev1.PodList{}
+			require.NoError(t, c.List(context.Background(), pods))
+			require.Empty(t, pods.Items)
+		})
+	}
+}
+
+func TestSetPodSecurityLabelsForNamespace(t *testing.T) {
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "gpu-operator",
+			Labels: map[string]string{
+				"pod-security.kubernetes.io/enforce": "baseline",
+				"pod-security.kubernetes.io/warn":    "restricted",
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(namespace).Build()
+	operatorNamespace := clusterPolicyCtrl.operatorNamespace
+	clusterPolicyCtrl.operatorNamespace = "gpu-operator"
+	defer func() { clusterPolicyCtrl.operatorNamespace = operatorNamespace }()
+
+	n := &ClusterPolicyController{ctx: context.Background(), client: c, logger: ctrl.Log.WithName("test")}
+	require.NoError(t, n.setPodSecurityLabelsForNamespace([]string{podSecurityModeEnforce}))
+
+	updated := &corev1.Namespace{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: "gpu-operator"}, updated))
+	require.Equal(t, map[string]string{
+		"pod-security.kubernetes.io/enforce": "privileged",
+		"pod-security.kubernetes.io/warn":    "restricted",
+	}, updated.Labels)
+}
@@ -0,0 +1,145 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/image"
+	"github.com/NVIDIA/gpu-operator/internal/utils"
+)
+
+const (
+	// ProxyTrustedCAVolumeName indicates the volume of the CA bundle of the ClusterPolicy proxy configuration
+	ProxyTrustedCAVolumeName = "proxy-trusted-ca"
+	// ProxyTrustedCAMountDir indicates the directory the CA bundle of the proxy configuration is mounted in
+	ProxyTrustedCAMountDir = "/etc/gpu-operator/trusted-ca"
+	// ProxyTrustedCAFileName indicates the file name of the mounted CA bundle of the proxy configuration
+	ProxyTrustedCAFileName = "ca-bundle.crt"
+	// SSLCertFileEnvName indicates the env pointing the TLS clients of the operands to the trusted CA bundle
+	SSLCertFileEnvName = "SSL_CERT_FILE"
+)
+
+// applyProxyEnv sets the proxy environment variables of the ClusterPolicy proxy configuration on the container
+func applyProxyEnv(container *corev1.Container, proxy *gpuv1.ProxySpec) {
+	if !proxy.HasProxyEnv() {
+		return
+	}
+	for _, env := range proxyEnvVars(map[string]string{
+		"HTTPS_PROXY": proxy.HTTPSProxy,
+		"HTTP_PROXY":  proxy.HTTPProxy,
+		"NO_PROXY":    proxy.NoProxy,
+	}) {
+		setContainerEnv(container, env.Name, env.Value)
+	}
+}
+
+// applyTrustedCABundle mounts the CA bundle of the ClusterPolicy proxy configuration into the container, and
+// points SSL_CERT_FILE to it
+func applyTrustedCABundle(podSpec *corev1.PodSpec, container *corev1.Container, proxy *gpuv1.ProxySpec) {
+	trustedCA := proxy.GetTrustedCA()
+	if trustedCA == nil {
+		return
+	}
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      ProxyTrustedCAVolumeName,
+		ReadOnly:  true,
+		MountPath: ProxyTrustedCAMountDir,
+	})
+	setContainerEnv(container, SSLCertFileEnvName, filepath.Join(ProxyTrustedCAMountDir, ProxyTrustedCAFileName))
+
+	for _, volume := range podSpec.Volumes {
+		if volume.Name == ProxyTrustedCAVolumeName {
+			return
+		}
+	}
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: ProxyTrustedCAVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: trustedCA.Name},
+				Items:                []corev1.KeyToPath{{Key: trustedCA.GetKey(), Path: ProxyTrustedCAFileName}},
+			},
+		},
+	})
+}
+
+// trustedCABundleDigest returns the digest of the CA bundle of the ClusterPolicy proxy configuration, so that
+// the driver config digest changes with the CA bundle
+func (n ClusterPolicyController) trustedCABundleDigest(trustedCA *gpuv1.TrustedCASpec) (string, error) {
+	bundle, err := n.getTrustedCABundle(n.ctx, trustedCA)
+	if err != nil {
+		return "", err
+	}
+	return utils.GetObjectHash(bundle), nil
+}
+
+// getTrustedCABundle returns the CA bundle of the ClusterPolicy proxy configuration
+func (n ClusterPolicyController) getTrustedCABundle(ctx context.Context, trustedCA *gpuv1.TrustedCASpec) (string, error) {
+	cm := &corev1.ConfigMap{}
+	err := n.client.Get(ctx, types.NamespacedName{Namespace: n.operatorNamespace, Name: trustedCA.Name}, cm)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the trusted CA bundle ConfigMap %s: %w", trustedCA.Name, err)
+	}
+	bundle, ok := cm.Data[trustedCA.GetKey()]
+	if !ok {
+		return "", fmt.Errorf("the trusted CA bundle ConfigMap %s has no key %s", trustedCA.Name, trustedCA.GetKey())
+	}
+	return bundle, nil
+}
+
+// getRegistryOptions returns the options of the registry requests of the operator, rate limited and retried as
+// set in the registry client configuration, and sent through the proxy with the CA bundle of the proxy
+// configuration. The CA bundle is left out when it cannot be read.
+func (n ClusterPolicyController) getRegistryOptions(ctx context.Context) (image.RegistryOptions, error) {
+	spec := &n.singleton.Spec
+	opts := image.DefaultRegistryOptions()
+	opts.RequestsPerSecond = float64(spec.RegistryClient.GetRequestsPerSecond())
+	opts.Burst = spec.RegistryClient.GetBurst()
+	opts.MaxRetries = spec.RegistryClient.GetMaxRetries()
+	if spec.Proxy == nil {
+		return opts, nil
+	}
+	opts.HTTPProxy = spec.Proxy.HTTPProxy
+	opts.HTTPSProxy = spec.Proxy.HTTPSProxy
+	opts.NoProxy = spec.Proxy.NoProxy
+
+	trustedCA := spec.Proxy.GetTrustedCA()
+	if trustedCA == nil {
+		return opts, nil
+	}
+	bundle, err := n.getTrustedCABundle(ctx, trustedCA)
+	if err != nil {
+		return opts, err
+	}
+	opts.CABundle = []byte(bundle)
+	return opts, nil
+}
+
+// reconcileRegistryClient configures the registry client shared by the features of the operator querying the
+// registries, e.g. the digest resolution and the probing of the precompiled driver images
+func (n ClusterPolicyController) reconcileRegistryClient(ctx context.Context) error {
+	opts, err := n.getRegistryOptions(ctx)
+	image.SharedRegistryClient().Configure(opts)
+	return err
+}
@@ -0,0 +1,183 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	driverconfig "github.com/NVIDIA/gpu-operator/internal/config"
+	"github.com/NVIDIA/gpu-operator/internal/image"
+)
+
+func TestApplyProxyConfig(t *testing.T) {
+	proxy := &gpuv1.ProxySpec{
+		HTTPSProxy: "http://proxy.corp.local:3128",
+		NoProxy:    ".svc,.cluster.local",
+		TrustedCA:  &gpuv1.TrustedCASpec{Name: "corp-ca"},
+	}
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "a"}, {Name: "b"}}}
+	for i := range podSpec.Containers {
+		applyProxyEnv(&podSpec.Containers[i], proxy)
+		applyTrustedCABundle(podSpec, &podSpec.Containers[i], proxy)
+	}
+
+	for _, container := range podSpec.Containers {
+		require.Equal(t, []corev1.EnvVar{
+			{Name: "HTTPS_PROXY", Value: "http://proxy.corp.local:3128"},
+			{Name: "https_proxy", Value: "http://proxy.corp.local:3128"},
+			{Name: "NO_PROXY", Value: ".svc,.cluster.local"},
+			{Name: "no_proxy", Value: ".svc,.cluster.local"},
+			{Name: SSLCertFileEnvName, Value: "/etc/gpu-operator/trusted-ca/ca-bundle.crt"},
+		}, container.Env)
+		require.Equal(t, []corev1.VolumeMount{{Name: ProxyTrustedCAVolumeName, ReadOnly: true, MountPath: ProxyTrustedCAMountDir}}, container.VolumeMounts)
+	}
+	// the volume is shared by the containers
+	require.Len(t, podSpec.Volumes, 1)
+	require.Equal(t, "corp-ca", podSpec.Volumes[0].ConfigMap.Name)
+	require.Equal(t, []corev1.KeyToPath{{Key: "ca-bundle.crt", Path: ProxyTrustedCAFileName}}, podSpec.Volumes[0].ConfigMap.Items)
+
+	// nothing is injected without proxy configuration
+	container := &corev1.Container{}
+	podSpec = &corev1.PodSpec{}
+	applyProxyEnv(container, nil)
+	applyTrustedCABundle(podSpec, container, nil)
+	require.Empty(t, container.Env)
+	require.Empty(t, podSpec.Volumes)
+}
+
+func TestTransformDriverProxy(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node",
+			Labels: map[string]string{
+				nfdOSReleaseIDLabelKey: "ubuntu",
+				nfdOSVersionIDLabelKey: "20.04",
+				nfdKernelLabelKey:      "6.8.0-60-generic",
+				commonGPULabelKey:      "true",
+			},
+		},
+	}
+	caBundle := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "corp-ca", Namespace: "test-ns"},
+		Data:       map[string]string{"ca.pem": "ca-1"},
+	}
+	mockClient := fake.NewFakeClient(node, caBundle)
+	transform := func(proxy *gpuv1.ProxySpec, downloadCache *gpuv1.DownloadCacheSpec) (*appsv1.DaemonSet, error) {
+		ds := NewDaemonset().WithContainer(corev1.Container{Name: "nvidia-driver-ctr"}).
+			WithInitContainer(corev1.Container{Name: "k8s-driver-manager"})
+		cpSpec := &gpuv1.ClusterPolicySpec{
+			Driver: gpuv1.DriverSpec{
+				Repository: "nvcr.io/nvidia",
+				Image:      "driver",
+				Version:    "570.172.08",
+				Manager: gpuv1.DriverManagerSpec{
+					Repository: "nvcr.io/nvidia/cloud-native",
+					Image:      "k8s-driver-manager",
+					Version:    "v0.8.0",
+				},
+			},
+			DownloadCache: downloadCache,
+			Proxy:         proxy,
+		}
+		err := TransformDriver(ds.DaemonSet, cpSpec,
+			ClusterPolicyController{ctx: context.Background(), client: mockClient, runtime: gpuv1.Containerd,
+				singleton: &gpuv1.ClusterPolicy{Spec: *cpSpec}, operatorNamespace: "test-ns",
+				logger: ctrl.Log.WithName("test"), gpuNodeOSRelease: "ubuntu", gpuNodeOSTag: "ubuntu20.04"})
+		return ds.DaemonSet, err
+	}
+	proxy := &gpuv1.ProxySpec{
+		HTTPProxy: "http://proxy.corp.local:3128",
+		TrustedCA: &gpuv1.TrustedCASpec{Name: "corp-ca", Key: "ca.pem"},
+	}
+
+	ds, err := transform(proxy, nil)
+	require.NoError(t, err)
+	driverContainer := findContainerByName(ds.Spec.Template.Spec.Containers, "nvidia-driver-ctr")
+	require.Equal(t, "http://proxy.corp.local:3128", getContainerEnv(driverContainer, "HTTP_PROXY"))
+	require.Equal(t, "/etc/gpu-operator/trusted-ca/ca-bundle.crt", getContainerEnv(driverContainer, SSLCertFileEnvName))
+	digest := driverconfig.DriverConfigDigestFromPodSpec(&ds.Spec.Template.Spec)
+	require.NotEmpty(t, digest)
+
+	// the download cache takes precedence over the proxy, while the CA bundle is still trusted
+	ds, err = transform(proxy, &gpuv1.DownloadCacheSpec{Enabled: ptr.To(true)})
+	require.NoError(t, err)
+	driverContainer = findContainerByName(ds.Spec.Template.Spec.Containers, "nvidia-driver-ctr")
+	require.Equal(t, "http://nvidia-download-cache.test-ns.svc:3128", getContainerEnv(driverContainer, "HTTP_PROXY"))
+	require.NotEmpty(t, getContainerEnv(driverContainer, SSLCertFileEnvName))
+
+	// a change of the CA bundle changes the digest of the driver configuration
+	caBundle.Data["ca.pem"] = "ca-2"
+	require.NoError(t, mockClient.Update(context.Background(), caBundle))
+	ds, err = transform(proxy, nil)
+	require.NoError(t, err)
+	require.NotEqual(t, digest, driverconfig.DriverConfigDigestFromPodSpec(&ds.Spec.Template.Spec))
+
+	// the CA bundle must exist
+	_, err = transform(&gpuv1.ProxySpec{TrustedCA: &gpuv1.TrustedCASpec{Name: "corp-ca"}}, nil)
+	require.Error(t, err)
+}
+
+func TestGetRegistryOptions(t *testing.T) {
+	caBundle := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "corp-ca", Namespace: "test-ns"},
+		Data:       map[string]string{"ca-bundle.crt": "ca-1"},
+	}
+	getOptions := func(spec gpuv1.ClusterPolicySpec) (image.RegistryOptions, error) {
+		n := ClusterPolicyController{client: fake.NewFakeClient(caBundle), operatorNamespace: "test-ns",
+			singleton: &gpuv1.ClusterPolicy{Spec: spec}}
+		return n.getRegistryOptions(context.Background())
+	}
+
+	opts, err := getOptions(gpuv1.ClusterPolicySpec{})
+	require.NoError(t, err)
+	require.Equal(t, image.DefaultRegistryOptions(), opts)
+
+	opts, err = getOptions(gpuv1.ClusterPolicySpec{
+		RegistryClient: &gpuv1.RegistryClientSpec{RequestsPerSecond: 2, Burst: 4, MaxRetries: ptr.To(0)},
+		Proxy: &gpuv1.ProxySpec{
+			HTTPSProxy: "http://proxy.corp.local:3128",
+			NoProxy:    ".svc",
+			TrustedCA:  &gpuv1.TrustedCASpec{Name: "corp-ca"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2.0, opts.RequestsPerSecond)
+	require.Equal(t, 4, opts.Burst)
+	require.Equal(t, 0, opts.MaxRetries)
+	require.Equal(t, "http://proxy.corp.local:3128", opts.HTTPSProxy)
+	require.Equal(t, ".svc", opts.NoProxy)
+	require.Equal(t, []byte("ca-1"), opts.CABundle)
+
+	// the proxy is still used when the CA bundle cannot be read
+	opts, err = getOptions(gpuv1.ClusterPolicySpec{Proxy: &gpuv1.ProxySpec{
+		HTTPSProxy: "http://proxy.corp.local:3128",
+		TrustedCA:  &gpuv1.TrustedCASpec{Name: "missing-ca"},
+	}})
+	require.Error(t, err)
+	require.Equal(t, "http://proxy.corp.local:3128", opts.HTTPSProxy)
+	require.Empty(t, opts.CABundle)
+}
@@ -0,0 +1,103 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+// resolveImagePullSecrets returns the image pull secrets attached to all operands: the secrets listed in the
+// spec followed by the image pull secrets of the listed service accounts of the namespace, without duplicates.
+// Missing service accounts are skipped, so that operands are not blocked until they are created.
+func resolveImagePullSecrets(ctx context.Context, c client.Reader, logger logr.Logger, namespace string, spec *gpuv1.ImagePullSecretsSpec) ([]string, error) {
+	if spec == nil {
+		return nil, nil
+	}
+	var secrets []string
+	add := func(name string) {
+		if name != "" && !slices.Contains(secrets, name) {
+			secrets = append(secrets, name)
+		}
+	}
+	for _, name := range spec.Secrets {
+		add(name)
+	}
+	for _, name := range spec.GetServiceAccounts() {
+		sa := &corev1.ServiceAccount{}
+		err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, sa)
+		if apierrors.IsNotFound(err) {
+			logger.Info("WARNING: service account providing image pull secrets not found", "name", name, "namespace", namespace)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get service account %s: %w", name, err)
+		}
+		for _, ref := range sa.ImagePullSecrets {
+			add(ref.Name)
+		}
+	}
+	return secrets, nil
+}
+
+// addWatchPullSecretServiceAccounts reconciles all ClusterPolicies when the image pull secrets of a service
+// account of the operator namespace change, so that the operands roll out with the rotated secrets
+func addWatchPullSecretServiceAccounts(r *ClusterPolicyReconciler, c controller.Controller, mgr ctrl.Manager) error {
+	mapFn := func(ctx context.Context, _ *corev1.ServiceAccount) []reconcile.Request {
+		return r.enqueueAllClusterPolicies(ctx)
+	}
+	inNamespace := func(sa *corev1.ServiceAccount) bool {
+		return sa.Namespace == r.Namespace
+	}
+	p := predicate.TypedFuncs[*corev1.ServiceAccount]{
+		CreateFunc: func(e event.TypedCreateEvent[*corev1.ServiceAccount]) bool {
+			return inNamespace(e.Object) && len(e.Object.ImagePullSecrets) > 0
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[*corev1.ServiceAccount]) bool {
+			return inNamespace(e.ObjectNew) &&
+				!slices.Equal(e.ObjectOld.ImagePullSecrets, e.ObjectNew.ImagePullSecrets)
+		},
+		DeleteFunc: func(e event.TypedDeleteEvent[*corev1.ServiceAccount]) bool {
+			return inNamespace(e.Object) && len(e.Object.ImagePullSecrets) > 0
+		},
+		GenericFunc: func(e event.TypedGenericEvent[*corev1.ServiceAccount]) bool {
+			return false
+		},
+	}
+	return c.Watch(source.Kind(
+		mgr.GetCache(),
+		&corev1.ServiceAccount{},
+		handler.TypedEnqueueRequestsFromMapFunc(mapFn),
+		p,
+	))
+}
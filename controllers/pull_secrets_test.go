@@ -0,0 +1,104 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestResolveImagePullSecrets(t *testing.T) {
+	serviceAccount := func(name string, secrets ...string) *corev1.ServiceAccount {
+		sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "gpu-operator"}}
+		for _, secret := range secrets {
+			sa.ImagePullSecrets = append(sa.ImagePullSecrets, corev1.LocalObjectReference{Name: secret})
+		}
+		return sa
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		serviceAccount("default", "registry-a", "registry-b"),
+		serviceAccount("mirror", "registry-b", "registry-c"),
+	).Build()
+
+	testCases := []struct {
+		description string
+		spec        *gpuv1.ImagePullSecretsSpec
+		expected    []string
+	}{
+		{
+			description: "not configured",
+		},
+		{
+			description: "secrets aggregated with the default service account",
+			spec:        &gpuv1.ImagePullSecretsSpec{Secrets: []string{"ngc", "registry-a"}},
+			expected:    []string{"ngc", "registry-a", "registry-b"},
+		},
+		{
+			description: "secrets of several service accounts",
+			spec:        &gpuv1.ImagePullSecretsSpec{ServiceAccounts: []string{"default", "mirror"}},
+			expected:    []string{"registry-a", "registry-b", "registry-c"},
+		},
+		{
+			description: "missing service account",
+			spec:        &gpuv1.ImagePullSecretsSpec{Secrets: []string{"ngc"}, ServiceAccounts: []string{"missing"}},
+			expected:    []string{"ngc"},
+		},
+		{
+			description: "aggregation disabled",
+			spec:        &gpuv1.ImagePullSecretsSpec{Secrets: []string{"ngc"}, ServiceAccounts: []string{}},
+			expected:    []string{"ngc"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			secrets, err := resolveImagePullSecrets(context.Background(), c, ctrl.Log.WithName("test"), "gpu-operator", tc.spec)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, secrets)
+		})
+	}
+}
+
+func TestPreProcessDaemonSetPullSecrets(t *testing.T) {
+	ds := NewDaemonset().
+		WithName("nvidia-dcgm").
+		WithContainer(corev1.Container{Name: "nvidia-dcgm-ctr"})
+	n := ClusterPolicyController{
+		singleton: &gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{
+			DCGM: gpuv1.DCGMSpec{
+				Repository:       "nvcr.io/nvidia/cloud-native",
+				Image:            "dcgm",
+				Version:          "4.0.0",
+				ImagePullSecrets: []string{"ngc"},
+			},
+		}},
+		pullSecrets: []string{"ngc", "registry-a"},
+		logger:      ctrl.Log.WithName("test"),
+	}
+
+	require.NoError(t, preProcessDaemonSet(ds.DaemonSet, n))
+	require.Equal(t, []corev1.LocalObjectReference{{Name: "ngc"}, {Name: "registry-a"}}, ds.Spec.Template.Spec.ImagePullSecrets)
+}
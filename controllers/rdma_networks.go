@@ -0,0 +1,244 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/rdma"
+)
+
+const (
+	// rdmaNetworkRailLabelKey is the label of the networks rendered for the GPU-NIC pairs, holding their rail
+	rdmaNetworkRailLabelKey = "nvidia.com/gpu.rdma.rail"
+	// networkResourceNameAnnotationKey is the annotation of a NetworkAttachmentDefinition naming the resource
+	// of the devices attached to the pods by the network
+	networkResourceNameAnnotationKey = "k8s.v1.cni.cncf.io/resourceName"
+)
+
+var (
+	networkAttachmentDefinitionGVK = schema.GroupVersionKind{Group: "k8s.cni.cncf.io", Version: "v1", Kind: "NetworkAttachmentDefinition"}
+	sriovNetworkNodePolicyGVK      = schema.GroupVersionKind{Group: "sriovnetwork.openshift.io", Version: "v1", Kind: "SriovNetworkNodePolicy"}
+)
+
+// rdmaRail is a rail of GPU-NIC pairs: the GPUs of the same index of every GPU node, along with their NICs
+type rdmaRail struct {
+	index int
+	// nics are the PCI addresses of the NICs paired with the GPUs of the rail, sorted
+	nics []string
+}
+
+// name returns the name of the networks of the rail
+func (r rdmaRail) name() string {
+	return fmt.Sprintf("gpu%d-rdma", r.index)
+}
+
+// resourceName returns the name of the resource advertised by the SR-IOV device plugin for the rail, which
+// allows underscores only
+func (r rdmaRail) resourceName() string {
+	return fmt.Sprintf("gpu%d_rdma", r.index)
+}
+
+// reconcileRDMANetworks renders a NetworkAttachmentDefinition per rail of GPU-NIC pairs, and an
+// SriovNetworkNodePolicy creating the virtual functions of the NICs of the rail, from the RDMA topology
+// published on the GPU nodes by the operator validator. The objects of an API not served by the cluster are
+// skipped, and the objects of the rails no longer found, or of all rails once disabled, are deleted.
+func (n ClusterPolicyController) reconcileRDMANetworks(ctx context.Context) error {
+	spec := &n.singleton.Spec
+	var rails []rdmaRail
+	if spec.Driver.GPUDirectRDMA != nil && spec.Driver.GPUDirectRDMA.IsNetworkAttachmentsEnabled() {
+		var err error
+		if rails, err = n.getRDMARails(); err != nil {
+			return err
+		}
+	}
+
+	attachments := spec.Driver.GPUDirectRDMA.GetNetworkAttachments()
+	namespace := attachments.Namespace
+	if namespace == "" {
+		namespace = n.operatorNamespace
+	}
+	var errs []error
+	for _, gvk := range []schema.GroupVersionKind{networkAttachmentDefinitionGVK, sriovNetworkNodePolicyGVK} {
+		objNamespace := namespace
+		if gvk == sriovNetworkNodePolicyGVK {
+			objNamespace = attachments.GetSRIOVOperatorNamespace()
+		}
+		var desired []*unstructured.Unstructured
+		for _, rail := range rails {
+			obj, err := n.newRDMANetworkObject(gvk, rail, attachments)
+			if err != nil {
+				return err
+			}
+			obj.SetNamespace(objNamespace)
+			desired = append(desired, obj)
+		}
+		if err := n.syncRDMANetworkObjects(ctx, gvk, desired); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to reconcile the GPUDirect RDMA networks: %v", errs)
+	}
+	return nil
+}
+
+// getRDMARails returns the rails of GPU-NIC pairs of the GPU nodes, ordered by GPU index
+func (n ClusterPolicyController) getRDMARails() ([]rdmaRail, error) {
+	nodes, err := n.listGPUNodes(nil)
+	if err != nil {
+		return nil, err
+	}
+	var rails []rdmaRail
+	for _, node := range nodes {
+		pairs, err := rdma.ParseTopology(node.Annotations)
+		if err != nil {
+			n.logger.Info("WARNING: ignoring the RDMA topology of node", "node", node.Name, "error", err)
+			continue
+		}
+		for i, pair := range pairs {
+			if i == len(rails) {
+				rails = append(rails, rdmaRail{index: i})
+			}
+			if !slices.Contains(rails[i].nics, pair.NIC) {
+				rails[i].nics = append(rails[i].nics, pair.NIC)
+			}
+		}
+	}
+	for i := range rails {
+		slices.Sort(rails[i].nics)
+	}
+	return rails, nil
+}
+
+// newRDMANetworkObject returns the NetworkAttachmentDefinition or the SriovNetworkNodePolicy of the rail
+func (n ClusterPolicyController) newRDMANetworkObject(gvk schema.GroupVersionKind, rail rdmaRail, attachments *gpuv1.GPUDirectRDMANetworkAttachmentsSpec) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(rail.name())
+	obj.SetLabels(map[string]string{rdmaNetworkRailLabelKey: strconv.Itoa(rail.index)})
+
+	switch gvk {
+	case networkAttachmentDefinitionGVK:
+		obj.SetAnnotations(map[string]string{
+			networkResourceNameAnnotationKey: attachments.GetResourcePrefix() + "/" + rail.resourceName(),
+		})
+		cniConfig := map[string]interface{}{
+			"cniVersion": "0.3.1",
+			"name":       rail.name(),
+			"type":       "sriov",
+		}
+		if attachments.IPAM != "" {
+			var ipam map[string]interface{}
+			if err := json.Unmarshal([]byte(attachments.IPAM), &ipam); err != nil {
+				return nil, fmt.Errorf("invalid driver.rdma.networkAttachments.ipam: %w", err)
+			}
+			cniConfig["ipam"] = ipam
+		}
+		config, err := json.Marshal(cniConfig)
+		if err != nil {
+			return nil, err
+		}
+		obj.Object["spec"] = map[string]interface{}{"config": string(config)}
+	case sriovNetworkNodePolicyGVK:
+		rootDevices := make([]interface{}, 0, len(rail.nics))
+		for _, nic := range rail.nics {
+			rootDevices = append(rootDevices, nic)
+		}
+		obj.Object["spec"] = map[string]interface{}{
+			"resourceName": rail.resourceName(),
+			"nodeSelector": map[string]interface{}{commonGPULabelKey: commonGPULabelValue},
+			"numVfs":       int64(attachments.GetNumVFs()),
+			"nicSelector":  map[string]interface{}{"rootDevices": rootDevices},
+			"deviceType":   "netdevice",
+			"isRdma":       true,
+		}
+	}
+
+	if err := controllerutil.SetControllerReference(n.singleton, obj, n.scheme); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// syncRDMANetworkObjects creates or updates the desired objects of the kind, and deletes the objects of the
+// kind rendered for the rails no longer found. Nothing is done when the API of the kind is not served.
+func (n ClusterPolicyController) syncRDMANetworkObjects(ctx context.Context, gvk schema.GroupVersionKind, desired []*unstructured.Unstructured) error {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := n.client.List(ctx, list, client.HasLabels{rdmaNetworkRailLabelKey}); err != nil {
+		if isAPIUnavailableError(err) {
+			if len(desired) > 0 {
+				n.logger.V(1).Info("API not served, skipping the GPUDirect RDMA networks", "kind", gvk.Kind)
+			}
+			return nil
+		}
+		return fmt.Errorf("failed to list %s objects: %w", gvk.Kind, err)
+	}
+
+	for _, obj := range desired {
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(gvk)
+		err := n.client.Get(ctx, client.ObjectKeyFromObject(obj), existing)
+		if apierrors.IsNotFound(err) {
+			n.logger.Info("Creating GPUDirect RDMA network", "kind", gvk.Kind, "namespace", obj.GetNamespace(), "name", obj.GetName())
+			if err := n.client.Create(ctx, obj); err != nil {
+				return fmt.Errorf("failed to create %s %s: %w", gvk.Kind, obj.GetName(), err)
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get %s %s: %w", gvk.Kind, obj.GetName(), err)
+		}
+		if equality.Semantic.DeepEqual(existing.Object["spec"], obj.Object["spec"]) &&
+			equality.Semantic.DeepEqual(existing.GetLabels(), obj.GetLabels()) &&
+			equality.Semantic.DeepEqual(existing.GetAnnotations(), obj.GetAnnotations()) &&
+			equality.Semantic.DeepEqual(existing.GetOwnerReferences(), obj.GetOwnerReferences()) {
+			continue
+		}
+		obj.SetResourceVersion(existing.GetResourceVersion())
+		if err := n.client.Update(ctx, obj); err != nil {
+			return fmt.Errorf("failed to update %s %s: %w", gvk.Kind, obj.GetName(), err)
+		}
+	}
+
+	for i := range list.Items {
+		existing := &list.Items[i]
+		if !isOperatorOwned(existing) || slices.ContainsFunc(desired, func(obj *unstructured.Unstructured) bool {
+			return obj.GetNamespace() == existing.GetNamespace() && obj.GetName() == existing.GetName()
+		}) {
+			continue
+		}
+		n.logger.Info("Deleting GPUDirect RDMA network", "kind", gvk.Kind, "namespace", existing.GetNamespace(), "name", existing.GetName())
+		if err := n.client.Delete(ctx, existing); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete %s %s: %w", gvk.Kind, existing.GetName(), err)
+		}
+	}
+	return nil
+}
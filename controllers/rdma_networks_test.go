@@ -0,0 +1,139 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+// newRDMANetworksTestController returns a controller whose client serves the APIs of the given kinds, along
+// with the ClusterPolicy and the nodes
+func newRDMANetworksTestController(t *testing.T, spec gpuv1.ClusterPolicySpec, served []schema.GroupVersionKind, nodes ...*corev1.Node) *ClusterPolicyController {
+	s := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(s))
+	require.NoError(t, gpuv1.AddToScheme(s))
+	for _, gvk := range served {
+		s.AddKnownTypeWithName(gvk, &unstructured.Unstructured{})
+		s.AddKnownTypeWithName(gvk.GroupVersion().WithKind(gvk.Kind+"List"), &unstructured.UnstructuredList{})
+	}
+	cp := &gpuv1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy", UID: "uid"}, Spec: spec}
+	builder := fake.NewClientBuilder().WithScheme(s).WithObjects(cp)
+	for _, node := range nodes {
+		builder = builder.WithObjects(node)
+	}
+	return &ClusterPolicyController{
+		ctx:               context.Background(),
+		client:            builder.Build(),
+		scheme:            s,
+		singleton:         cp,
+		logger:            logr.Discard(),
+		operatorNamespace: "gpu-operator",
+	}
+}
+
+func rdmaNode(name string, topology string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:        name,
+		Labels:      map[string]string{commonGPULabelKey: commonGPULabelValue},
+		Annotations: map[string]string{consts.GPURDMATopologyAnnotationKey: topology},
+	}}
+}
+
+func listRDMANetworkObjects(t *testing.T, c client.Client, gvk schema.GroupVersionKind) []unstructured.Unstructured {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	require.NoError(t, c.List(context.Background(), list))
+	return list.Items
+}
+
+func TestReconcileRDMANetworks(t *testing.T) {
+	spec := gpuv1.ClusterPolicySpec{Driver: gpuv1.DriverSpec{GPUDirectRDMA: &gpuv1.GPUDirectRDMASpec{
+		Enabled: ptr.To(true),
+		NetworkAttachments: &gpuv1.GPUDirectRDMANetworkAttachmentsSpec{
+			Enabled: ptr.To(true),
+			IPAM:    `{"type":"whereabouts","range":"192.168.0.0/16"}`,
+		},
+	}}}
+	n := newRDMANetworksTestController(t, spec,
+		[]schema.GroupVersionKind{networkAttachmentDefinitionGVK, sriovNetworkNodePolicyGVK},
+		rdmaNode("node-a", `[{"gpu":"0000:03:00.0","nic":"0000:04:00.0","rdmaDevice":"mlx5_0"},{"gpu":"0000:41:00.0","nic":"0000:42:00.0","rdmaDevice":"mlx5_1"}]`),
+		rdmaNode("node-b", `[{"gpu":"0000:03:00.0","nic":"0000:05:00.0","rdmaDevice":"mlx5_0"}]`),
+		rdmaNode("node-c", "invalid"),
+	)
+	require.NoError(t, n.reconcileRDMANetworks(context.Background()))
+
+	nads := listRDMANetworkObjects(t, n.client, networkAttachmentDefinitionGVK)
+	require.Len(t, nads, 2)
+	nad := nads[0]
+	require.Equal(t, "gpu0-rdma", nad.GetName())
+	require.Equal(t, "gpu-operator", nad.GetNamespace())
+	require.Equal(t, "nvidia.com/gpu0_rdma", nad.GetAnnotations()[networkResourceNameAnnotationKey])
+	require.Equal(t, "cluster-policy", nad.GetOwnerReferences()[0].Name)
+	config, _, _ := unstructured.NestedString(nad.Object, "spec", "config")
+	require.JSONEq(t, `{"cniVersion":"0.3.1","name":"gpu0-rdma","type":"sriov","ipam":{"type":"whereabouts","range":"192.168.0.0/16"}}`, config)
+
+	policies := listRDMANetworkObjects(t, n.client, sriovNetworkNodePolicyGVK)
+	require.Len(t, policies, 2)
+	policy := policies[0]
+	require.Equal(t, "sriov-network-operator", policy.GetNamespace())
+	require.Equal(t, map[string]interface{}{
+		"resourceName": "gpu0_rdma",
+		"nodeSelector": map[string]interface{}{commonGPULabelKey: commonGPULabelValue},
+		"numVfs":       int64(8),
+		"nicSelector":  map[string]interface{}{"rootDevices": []interface{}{"0000:04:00.0", "0000:05:00.0"}},
+		"deviceType":   "netdevice",
+		"isRdma":       true,
+	}, policy.Object["spec"])
+	rootDevices, _, _ := unstructured.NestedStringSlice(policies[1].Object, "spec", "nicSelector", "rootDevices")
+	require.Equal(t, []string{"0000:42:00.0"}, rootDevices)
+
+	// the networks are deleted once disabled
+	n.singleton.Spec.Driver.GPUDirectRDMA.NetworkAttachments.Enabled = ptr.To(false)
+	require.NoError(t, n.reconcileRDMANetworks(context.Background()))
+	require.Empty(t, listRDMANetworkObjects(t, n.client, networkAttachmentDefinitionGVK))
+	require.Empty(t, listRDMANetworkObjects(t, n.client, sriovNetworkNodePolicyGVK))
+}
+
+func TestReconcileRDMANetworksWithoutSRIOVOperator(t *testing.T) {
+	spec := gpuv1.ClusterPolicySpec{Driver: gpuv1.DriverSpec{GPUDirectRDMA: &gpuv1.GPUDirectRDMASpec{
+		Enabled:            ptr.To(true),
+		NetworkAttachments: &gpuv1.GPUDirectRDMANetworkAttachmentsSpec{Enabled: ptr.To(true), Namespace: "workloads"},
+	}}}
+	n := newRDMANetworksTestController(t, spec, []schema.GroupVersionKind{networkAttachmentDefinitionGVK},
+		rdmaNode("node-a", `[{"gpu":"0000:03:00.0","nic":"0000:04:00.0","rdmaDevice":"mlx5_0"}]`))
+	require.NoError(t, n.reconcileRDMANetworks(context.Background()))
+
+	nads := listRDMANetworkObjects(t, n.client, networkAttachmentDefinitionGVK)
+	require.Len(t, nads, 1)
+	require.Equal(t, "workloads", nads[0].GetNamespace())
+}
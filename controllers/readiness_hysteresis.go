@@ -0,0 +1,128 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"sync"
+	"time"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+// readinessKey identifies a debounced readiness, the node is unset for the readiness of the ClusterPolicy
+type readinessKey struct {
+	node    string
+	operand string
+}
+
+// readinessDebouncer debounces the readiness changes of the operands: a change is only reported once it has
+// been observed for the delay of the readiness hysteresis, the readiness reported before being kept meanwhile.
+// A nil debouncer reports the observed readiness.
+type readinessDebouncer struct {
+	mu       sync.Mutex
+	reported map[readinessKey]bool
+	// pending holds since when the observed readiness differs from the reported one
+	pending map[readinessKey]time.Time
+}
+
+func newReadinessDebouncer() *readinessDebouncer {
+	return &readinessDebouncer{
+		reported: make(map[readinessKey]bool),
+		pending:  make(map[readinessKey]time.Time),
+	}
+}
+
+// debounce returns the readiness to report for the observed readiness, along with the duration after which
+// a pending readiness change is due to be reported, 0 if none is pending
+func (d *readinessDebouncer) debounce(key readinessKey, observed bool, now time.Time, hysteresis *gpuv1.ReadinessHysteresisSpec) (bool, time.Duration) {
+	if d == nil {
+		return observed, 0
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	reported, ok := d.reported[key]
+	if !ok || reported == observed {
+		d.reported[key] = observed
+		delete(d.pending, key)
+		return observed, 0
+	}
+
+	since, ok := d.pending[key]
+	if !ok {
+		since = now
+		d.pending[key] = now
+	}
+	if remaining := hysteresis.GetDelay(observed) - now.Sub(since); remaining > 0 {
+		return reported, remaining
+	}
+	d.reported[key] = observed
+	delete(d.pending, key)
+	return observed, 0
+}
+
+// retainNodes forgets the readiness of the nodes which are not listed
+func (d *readinessDebouncer) retainNodes(nodes map[string]bool) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for key := range d.reported {
+		if key.node != "" && !nodes[key.node] {
+			delete(d.reported, key)
+			delete(d.pending, key)
+		}
+	}
+}
+
+// debounceNodeOperandStatuses debounces the readiness of the toolkit, the device plugin and the validation
+// of every node, and returns the duration after which the next pending readiness change is due to be reported
+func (n ClusterPolicyController) debounceNodeOperandStatuses(statuses []gpuv1.NodeOperandStatus, now time.Time) time.Duration {
+	hysteresis := n.singleton.Spec.ReadinessHysteresis
+	nodes := make(map[string]bool, len(statuses))
+	var requeueAfter time.Duration
+	for i := range statuses {
+		status := &statuses[i]
+		nodes[status.Name] = true
+		for operand, ready := range map[string]*bool{
+			containerToolkitAppLabelValue:  &status.ToolkitReady,
+			devicePluginAppLabelValue:      &status.DevicePluginReady,
+			operatorValidatorAppLabelValue: &status.Validated,
+		} {
+			var pendingFor time.Duration
+			*ready, pendingFor = n.readiness.debounce(readinessKey{node: status.Name, operand: operand}, *ready, now, hysteresis)
+			requeueAfter = earliestRequeue(requeueAfter, pendingFor)
+		}
+	}
+	n.readiness.retainNodes(nodes)
+	return requeueAfter
+}
+
+// debounceReady debounces the readiness of the ClusterPolicy, and returns the readiness to report along with
+// the duration after which a pending readiness change is due to be reported
+func (n ClusterPolicyController) debounceReady(ready bool, now time.Time) (bool, time.Duration) {
+	return n.readiness.debounce(readinessKey{operand: n.singleton.Name}, ready, now, n.singleton.Spec.ReadinessHysteresis)
+}
+
+// earliestRequeue returns the earliest of two requeue durations, a zero duration meaning no requeue
+func earliestRequeue(a, b time.Duration) time.Duration {
+	if a == 0 || (b > 0 && b < a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,98 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestReadinessDebouncer(t *testing.T) {
+	hysteresis := &gpuv1.ReadinessHysteresisSpec{NotReadySeconds: 60, ReadySeconds: 30}
+	key := readinessKey{node: "node-a", operand: devicePluginAppLabelValue}
+	d := newReadinessDebouncer()
+	now := time.Now()
+
+	// the first observed readiness is reported immediately
+	ready, pendingFor := d.debounce(key, true, now, hysteresis)
+	require.True(t, ready)
+	require.Zero(t, pendingFor)
+
+	// a short not ready flap is not reported
+	ready, pendingFor = d.debounce(key, false, now.Add(10*time.Second), hysteresis)
+	require.True(t, ready)
+	require.Equal(t, 60*time.Second, pendingFor)
+	ready, pendingFor = d.debounce(key, true, now.Add(20*time.Second), hysteresis)
+	require.True(t, ready)
+	require.Zero(t, pendingFor)
+
+	// not ready is reported once it outlasts the hysteresis
+	ready, _ = d.debounce(key, false, now.Add(30*time.Second), hysteresis)
+	require.True(t, ready)
+	ready, pendingFor = d.debounce(key, false, now.Add(60*time.Second), hysteresis)
+	require.True(t, ready)
+	require.Equal(t, 30*time.Second, pendingFor)
+	ready, pendingFor = d.debounce(key, false, now.Add(90*time.Second), hysteresis)
+	require.False(t, ready)
+	require.Zero(t, pendingFor)
+
+	// ready is reported again once it outlasts its own hysteresis
+	ready, pendingFor = d.debounce(key, true, now.Add(100*time.Second), hysteresis)
+	require.False(t, ready)
+	require.Equal(t, 30*time.Second, pendingFor)
+	ready, _ = d.debounce(key, true, now.Add(130*time.Second), hysteresis)
+	require.True(t, ready)
+
+	// changes are reported immediately without hysteresis
+	ready, _ = d.debounce(key, false, now.Add(140*time.Second), nil)
+	require.False(t, ready)
+}
+
+func TestDebounceNodeOperandStatuses(t *testing.T) {
+	n := ClusterPolicyController{
+		singleton: &gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{
+			ReadinessHysteresis: &gpuv1.ReadinessHysteresisSpec{NotReadySeconds: 60},
+		}},
+		readiness: newReadinessDebouncer(),
+	}
+	now := time.Now()
+
+	statuses := []gpuv1.NodeOperandStatus{
+		{Name: "node-a", ToolkitReady: true, DevicePluginReady: true, Validated: true},
+		{Name: "node-b", ToolkitReady: true, DevicePluginReady: false, Validated: false},
+	}
+	require.Zero(t, n.debounceNodeOperandStatuses(statuses, now))
+
+	// the device plugin of node-a restarts
+	statuses = []gpuv1.NodeOperandStatus{
+		{Name: "node-a", ToolkitReady: true, DevicePluginReady: false, Validated: true},
+		{Name: "node-b", ToolkitReady: true, DevicePluginReady: true, Validated: true},
+	}
+	require.Equal(t, 60*time.Second, n.debounceNodeOperandStatuses(statuses, now.Add(time.Second)))
+	require.Equal(t, []gpuv1.NodeOperandStatus{
+		{Name: "node-a", ToolkitReady: true, DevicePluginReady: true, Validated: true},
+		{Name: "node-b", ToolkitReady: true, DevicePluginReady: true, Validated: true},
+	}, statuses)
+
+	// the readiness of the removed nodes is forgotten
+	n.debounceNodeOperandStatuses(statuses[:1], now.Add(2*time.Second))
+	require.NotContains(t, n.readiness.reported, readinessKey{node: "node-b", operand: devicePluginAppLabelValue})
+}
@@ -0,0 +1,70 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"time"
+
+	"github.com/NVIDIA/gpu-operator/internal/operatorconfig"
+)
+
+const (
+	// DefaultRequeueInterval is the delay before the ClusterPolicy is reconciled again while operands are not ready
+	DefaultRequeueInterval = 5 * time.Second
+	// DefaultMaxErrorBackoff caps the exponential backoff applied when a ClusterPolicy reconciliation fails
+	DefaultMaxErrorBackoff = maxDelayCR
+)
+
+// ReconcileOptions tunes the reconciliation pressure of the ClusterPolicy controller on the API server
+type ReconcileOptions struct {
+	// RequeueInterval is the delay before the ClusterPolicy is reconciled again while operands are not ready
+	RequeueInterval time.Duration
+	// MaxErrorBackoff caps the exponential backoff applied when a reconciliation fails
+	MaxErrorBackoff time.Duration
+	// StateTimeouts bounds the duration of the reconciliation of each state, keyed by state name.
+	// States without a timeout are not bounded.
+	StateTimeouts map[string]time.Duration
+}
+
+func (o ReconcileOptions) getRequeueInterval() time.Duration {
+	if o.RequeueInterval <= 0 {
+		return DefaultRequeueInterval
+	}
+	return o.RequeueInterval
+}
+
+// requeueInterval returns the requeue interval set by the operator ConfigMap, or by the flags if not set
+func (r *ClusterPolicyReconciler) requeueInterval() time.Duration {
+	if interval := r.OperatorConfig.RequeueInterval(); interval > 0 {
+		return interval
+	}
+	return r.ReconcileOptions.getRequeueInterval()
+}
+
+func (o ReconcileOptions) getMaxErrorBackoff() time.Duration {
+	if o.MaxErrorBackoff <= 0 {
+		return DefaultMaxErrorBackoff
+	}
+	// the backoff cannot be lower than the initial delay
+	return max(o.MaxErrorBackoff, minDelayCR)
+}
+
+// ParseStateTimeouts parses a comma separated list of state=duration pairs,
+// e.g. "state-driver=10m,state-container-toolkit=2m"
+func ParseStateTimeouts(value string) (map[string]time.Duration, error) {
+	return operatorconfig.ParseStateTimeouts(value)
+}
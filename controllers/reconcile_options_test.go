@@ -0,0 +1,102 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestParseStateTimeouts(t *testing.T) {
+	testCases := []struct {
+		description string
+		value       string
+		expected    map[string]time.Duration
+		expectError bool
+	}{
+		{
+			description: "empty",
+			expected:    map[string]time.Duration{},
+		},
+		{
+			description: "multiple states with trailing separator",
+			value:       "state-driver=10m, state-container-toolkit=90s,",
+			expected: map[string]time.Duration{
+				"state-driver":            10 * time.Minute,
+				"state-container-toolkit": 90 * time.Second,
+			},
+		},
+		{
+			description: "missing duration",
+			value:       "state-driver",
+			expectError: true,
+		},
+		{
+			description: "invalid duration",
+			value:       "state-driver=ten",
+			expectError: true,
+		},
+		{
+			description: "non-positive duration",
+			value:       "state-driver=0s",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			timeouts, err := ParseStateTimeouts(tc.value)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, timeouts)
+		})
+	}
+}
+
+func TestReconcileOptionsDefaults(t *testing.T) {
+	require.Equal(t, DefaultRequeueInterval, ReconcileOptions{}.getRequeueInterval())
+	require.Equal(t, DefaultMaxErrorBackoff, ReconcileOptions{}.getMaxErrorBackoff())
+	require.Equal(t, time.Minute, ReconcileOptions{RequeueInterval: time.Minute}.getRequeueInterval())
+	require.Equal(t, minDelayCR, ReconcileOptions{MaxErrorBackoff: time.Millisecond}.getMaxErrorBackoff())
+}
+
+func TestStepStateTimeout(t *testing.T) {
+	waitForContext := func(n ClusterPolicyController) (gpuv1.State, error) {
+		<-n.ctx.Done()
+		return gpuv1.NotReady, n.ctx.Err()
+	}
+
+	n := &ClusterPolicyController{
+		ctx:           context.Background(),
+		singleton:     &gpuv1.ClusterPolicy{},
+		stateNames:    []string{"state-slow"},
+		controls:      []stateSyncer{controlFunc{waitForContext}},
+		stateTimeouts: map[string]time.Duration{"state-slow": 10 * time.Millisecond},
+	}
+	state, err := n.step()
+	require.Equal(t, gpuv1.NotReady, state)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, "state state-slow did not complete within 10ms")
+}
@@ -32,8 +32,12 @@ import (
 
 	secv1 "github.com/openshift/api/security/v1"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/kubernetes/scheme"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
 )
 
 const (
@@ -99,6 +103,50 @@ func getAssetsFrom(n *ClusterPolicyController, path string, openshiftVersion str
 	return manifests
 }
 
+// isAPIUnavailableError returns true if err indicates that the API of a resource kind is not served
+// by the cluster, e.g. because the CRD providing it was removed while the operator is running.
+func isAPIUnavailableError(err error) bool {
+	return meta.IsNoMatchError(err) || discovery.IsGroupDiscoveryFailedError(err)
+}
+
+// optionalAPIControl wraps the control function of a resource provided by an optional integration
+// (Prometheus Operator, OpenShift). When the API of the integration disappears, e.g. after the
+// integration was uninstalled, the resource is skipped and recorded as unavailable instead of
+// failing every reconciliation with "no matches for kind" errors.
+func optionalAPIControl(kind string, control func(n ClusterPolicyController) (gpuv1.State, error)) func(n ClusterPolicyController) (gpuv1.State, error) {
+	return func(n ClusterPolicyController) (gpuv1.State, error) {
+		status, err := control(n)
+		if err == nil || !isAPIUnavailableError(err) {
+			return status, err
+		}
+		n.logger.Info("WARNING: API of optional integration is unavailable, skipping", "Kind", kind, "Error", err.Error())
+		if n.unavailableAPIs != nil {
+			n.unavailableAPIs[kind] = true
+		}
+		return gpuv1.Ready, nil
+	}
+}
+
+// getUnavailableAPIs returns the sorted list of optional API kinds skipped during the current reconciliation
+func (n *ClusterPolicyController) getUnavailableAPIs() []string {
+	kinds := make([]string, 0, len(n.unavailableAPIs))
+	for kind := range n.unavailableAPIs {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// getMissingKataRuntimeClasses returns the sorted list of Kata RuntimeClasses expected to pre-exist that were not found
+func (n *ClusterPolicyController) getMissingKataRuntimeClasses() []string {
+	names := make([]string, 0, len(n.missingKataRuntimeClasses))
+	for name := range n.missingKataRuntimeClasses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func addResourcesControls(n *ClusterPolicyController, path string) (Resources, controlFunc) {
 	res := Resources{}
 	ctrl := controlFunc{}
@@ -162,11 +210,11 @@ func addResourcesControls(n *ClusterPolicyController, path string) (Resources, c
 		case "ServiceMonitor":
 			_, _, err := s.Decode(m, nil, &res.ServiceMonitor)
 			panicIfError(err)
-			ctrl = append(ctrl, ServiceMonitor)
+			ctrl = append(ctrl, optionalAPIControl("ServiceMonitor", ServiceMonitor))
 		case "SecurityContextConstraints":
 			_, _, err := s.Decode(m, nil, &res.SecurityContextConstraints)
 			panicIfError(err)
-			ctrl = append(ctrl, SecurityContextConstraints)
+			ctrl = append(ctrl, optionalAPIControl("SecurityContextConstraints", SecurityContextConstraints))
 		case "RuntimeClass":
 			rt := nodev1.RuntimeClass{}
 			_, _, err := s.Decode(m, nil, &rt)
@@ -179,7 +227,7 @@ func addResourcesControls(n *ClusterPolicyController, path string) (Resources, c
 		case "PrometheusRule":
 			_, _, err := s.Decode(m, nil, &res.PrometheusRule)
 			panicIfError(err)
-			ctrl = append(ctrl, PrometheusRule)
+			ctrl = append(ctrl, optionalAPIControl("PrometheusRule", PrometheusRule))
 		default:
 			n.logger.Info("Unknown Resource", "Manifest", m, "Kind", kind)
 		}
@@ -0,0 +1,88 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestOptionalAPIControl(t *testing.T) {
+	noMatchErr := &meta.NoKindMatchError{
+		GroupKind:        schema.GroupKind{Group: "monitoring.coreos.com", Kind: "ServiceMonitor"},
+		SearchedVersions: []string{"v1"},
+	}
+
+	testCases := []struct {
+		description        string
+		status             gpuv1.State
+		err                error
+		expectedStatus     gpuv1.State
+		expectedError      bool
+		expectedAPIsMissed []string
+	}{
+		{
+			description:        "control succeeds",
+			status:             gpuv1.Ready,
+			expectedStatus:     gpuv1.Ready,
+			expectedAPIsMissed: []string{},
+		},
+		{
+			description:        "control fails with unrelated error",
+			status:             gpuv1.NotReady,
+			err:                errors.New("connection refused"),
+			expectedStatus:     gpuv1.NotReady,
+			expectedError:      true,
+			expectedAPIsMissed: []string{},
+		},
+		{
+			description:        "API of optional integration is no longer served",
+			status:             gpuv1.NotReady,
+			err:                noMatchErr,
+			expectedStatus:     gpuv1.Ready,
+			expectedAPIsMissed: []string{"ServiceMonitor"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			n := ClusterPolicyController{
+				logger:          ctrl.Log.WithName("test"),
+				unavailableAPIs: map[string]bool{},
+			}
+			control := optionalAPIControl("ServiceMonitor", func(ClusterPolicyController) (gpuv1.State, error) {
+				return tc.status, tc.err
+			})
+
+			status, err := control(n)
+			if tc.expectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expectedStatus, status)
+			require.Equal(t, tc.expectedAPIsMissed, n.getUnavailableAPIs())
+		})
+	}
+}
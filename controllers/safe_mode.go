@@ -0,0 +1,235 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+const (
+	// safeModeAcknowledgedAnnotationKey is set on the ClusterPolicy to acknowledge the safe mode and resume the
+	// operand rollouts. The operator removes the annotation once the acknowledgment is recorded.
+	safeModeAcknowledgedAnnotationKey = "nvidia.com/gpu-operator.safe-mode.acknowledged"
+
+	crashLoopBackOffReason = "CrashLoopBackOff"
+)
+
+// reconcileSafeMode enters the safe mode when the pods of the operand revisions rolled out by the operator
+// crash loop on a share of the GPU nodes reaching the threshold of the ClusterPolicy, and leaves it once the
+// safe mode is acknowledged through the ClusterPolicy annotation. The operand rollouts are stopped while the
+// safe mode is active.
+func (n *ClusterPolicyController) reconcileSafeMode(ctx context.Context) error {
+	n.safeMode = false
+	spec := n.singleton.Spec.SafeMode
+	status := n.singleton.Status.SafeMode.DeepCopy()
+
+	if !spec.IsEnabled() {
+		if status == nil {
+			return nil
+		}
+		return n.updateSafeModeStatus(ctx, nil, nil)
+	}
+	if status == nil {
+		status = &gpuv1.SafeModeStatus{}
+	}
+
+	_, acknowledged := n.singleton.Annotations[safeModeAcknowledgedAnnotationKey]
+	if acknowledged {
+		// the annotation is removed even when the safe mode is not active, so that a stale acknowledgment
+		// does not acknowledge the next safe mode
+		if err := n.removeSafeModeAcknowledgment(ctx); err != nil {
+			return err
+		}
+	}
+
+	if status.Active {
+		if !acknowledged {
+			n.safeMode = true
+			return nil
+		}
+		n.logger.Info("Safe mode acknowledged, resuming the operand rollouts", "revisions", status.Revisions)
+		for _, revision := range status.Revisions {
+			if !slices.Contains(status.AcknowledgedRevisions, revision) {
+				status.AcknowledgedRevisions = append(status.AcknowledgedRevisions, revision)
+			}
+		}
+		message := fmt.Sprintf("Safe mode acknowledged for revisions %s", strings.Join(status.Revisions, ", "))
+		*status = gpuv1.SafeModeStatus{AcknowledgedRevisions: status.AcknowledgedRevisions}
+		if n.recorder != nil {
+			n.recorder.Eventf(n.singleton, nil, corev1.EventTypeNormal, conditions.SafeModeAcknowledged, "ResumeRollouts", message)
+		}
+		return n.updateSafeModeStatus(ctx, status, &metav1.Condition{
+			Type:    conditions.SafeMode,
+			Status:  metav1.ConditionFalse,
+			Reason:  conditions.SafeModeAcknowledged,
+			Message: message,
+		})
+	}
+
+	if !n.hasGPUNodes {
+		return nil
+	}
+	nodes, err := n.listGPUNodes(nil)
+	if err != nil {
+		return err
+	}
+	crashLoopingNodes, revisions, err := n.getCrashLoopingRevisions(ctx, status.AcknowledgedRevisions)
+	if err != nil {
+		return err
+	}
+	if len(crashLoopingNodes) == 0 || len(crashLoopingNodes)*100 < int(spec.GetCrashLoopThreshold())*len(nodes) {
+		return nil
+	}
+
+	now := metav1.Now()
+	status.Active = true
+	status.Since = &now
+	status.CrashLoopingNodes = len(crashLoopingNodes)
+	status.GPUNodes = len(nodes)
+	status.Revisions = revisions
+	message := fmt.Sprintf("Operand pods of revisions %s crash loop on %d of %d GPU nodes, operand rollouts are stopped until the %s annotation is set on the ClusterPolicy",
+		strings.Join(revisions, ", "), len(crashLoopingNodes), len(nodes), safeModeAcknowledgedAnnotationKey)
+	n.logger.Info("WARNING: entering safe mode", "revisions", revisions, "crashLoopingNodes", len(crashLoopingNodes), "gpuNodes", len(nodes))
+	if n.recorder != nil {
+		n.recorder.Eventf(n.singleton, nil, corev1.EventTypeWarning, conditions.OperandCrashLoop, "StopRollouts", message)
+	}
+	n.safeMode = true
+	return n.updateSafeModeStatus(ctx, status, &metav1.Condition{
+		Type:    conditions.SafeMode,
+		Status:  metav1.ConditionTrue,
+		Reason:  conditions.OperandCrashLoop,
+		Message: message,
+	})
+}
+
+// getCrashLoopingRevisions returns the nodes running crash looping pods of the latest revision of the operand
+// DaemonSets, and these revisions as <DaemonSet>/<revision hash>. The pods of the previous revisions are not
+// counted, as their crash loops do not correlate with the rollouts of the operator, and neither are the pods
+// of the acknowledged revisions.
+func (n ClusterPolicyController) getCrashLoopingRevisions(ctx context.Context, acknowledged []string) (map[string]bool, []string, error) {
+	pods := &corev1.PodList{}
+	if err := n.client.List(ctx, pods, client.InNamespace(n.operatorNamespace)); err != nil {
+		return nil, nil, fmt.Errorf("failed to list operand pods: %w", err)
+	}
+	crashLoopingPods := make(map[types.UID][]corev1.Pod)
+	for _, pod := range pods.Items {
+		owner := metav1.GetControllerOf(&pod)
+		if owner == nil || owner.Kind != "DaemonSet" || pod.Spec.NodeName == "" || !isPodCrashLooping(&pod) {
+			continue
+		}
+		crashLoopingPods[owner.UID] = append(crashLoopingPods[owner.UID], pod)
+	}
+	if len(crashLoopingPods) == 0 {
+		return nil, nil, nil
+	}
+
+	daemonsets := &appsv1.DaemonSetList{}
+	if err := n.client.List(ctx, daemonsets, client.InNamespace(n.operatorNamespace)); err != nil {
+		return nil, nil, fmt.Errorf("failed to list operand DaemonSets: %w", err)
+	}
+	nodes := make(map[string]bool)
+	revisions := []string{}
+	for i := range daemonsets.Items {
+		ds := &daemonsets.Items[i]
+		owner := metav1.GetControllerOf(ds)
+		if owner == nil || owner.Kind != "ClusterPolicy" || len(crashLoopingPods[ds.UID]) == 0 {
+			continue
+		}
+		hash, err := getDaemonsetControllerRevisionHash(ctx, ds, n)
+		if err != nil {
+			return nil, nil, err
+		}
+		revision := ds.Name + "/" + hash
+		if slices.Contains(acknowledged, revision) {
+			continue
+		}
+		for _, pod := range crashLoopingPods[ds.UID] {
+			if pod.Labels[PodControllerRevisionHashLabelKey] != hash {
+				continue
+			}
+			nodes[pod.Spec.NodeName] = true
+			if !slices.Contains(revisions, revision) {
+				revisions = append(revisions, revision)
+			}
+		}
+	}
+	sort.Strings(revisions)
+	return nodes, revisions, nil
+}
+
+// isPodCrashLooping returns true if a container of the pod is waiting to be restarted after crashing
+func isPodCrashLooping(pod *corev1.Pod) bool {
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, status := range statuses {
+			if status.State.Waiting != nil && status.State.Waiting.Reason == crashLoopBackOffReason {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// removeSafeModeAcknowledgment removes the acknowledgment annotation from the ClusterPolicy
+func (n ClusterPolicyController) removeSafeModeAcknowledgment(ctx context.Context) error {
+	patch := client.MergeFrom(n.singleton.DeepCopy())
+	delete(n.singleton.Annotations, safeModeAcknowledgedAnnotationKey)
+	if err := n.client.Patch(ctx, n.singleton, patch); err != nil {
+		return fmt.Errorf("failed to remove the %s annotation: %w", safeModeAcknowledgedAnnotationKey, err)
+	}
+	return nil
+}
+
+// updateSafeModeStatus records the safe mode status and condition in the ClusterPolicy status. The SafeMode
+// condition is removed when the status is cleared.
+func (n ClusterPolicyController) updateSafeModeStatus(ctx context.Context, status *gpuv1.SafeModeStatus, condition *metav1.Condition) error {
+	// Fetch latest instance and update status to avoid version mismatch
+	instance := &gpuv1.ClusterPolicy{}
+	if err := n.client.Get(ctx, types.NamespacedName{Name: n.singleton.Name}, instance); err != nil {
+		return fmt.Errorf("failed to get ClusterPolicy instance for status update: %w", err)
+	}
+	conditionsBefore := slices.Clone(instance.Status.Conditions)
+	switch {
+	case condition != nil:
+		meta.SetStatusCondition(&instance.Status.Conditions, *condition)
+	case status == nil:
+		meta.RemoveStatusCondition(&instance.Status.Conditions, conditions.SafeMode)
+	}
+	if equality.Semantic.DeepEqual(instance.Status.SafeMode, status) &&
+		equality.Semantic.DeepEqual(instance.Status.Conditions, conditionsBefore) {
+		return nil
+	}
+	instance.Status.SafeMode = status
+	if err := n.client.Status().Update(ctx, instance); err != nil {
+		return fmt.Errorf("failed to update ClusterPolicy safe mode status: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/conditions"
+)
+
+func newSafeModeTestController(cp *gpuv1.ClusterPolicy, crashLoopingHashes ...string) (*ClusterPolicyController, *events.FakeRecorder) {
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nvidia-driver-daemonset",
+			Namespace: "test-ns",
+			UID:       "ds-uid",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "nvidia.com/v1", Kind: "ClusterPolicy", Name: cp.Name, UID: cp.UID, Controller: ptr.To(true),
+			}},
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{appLabelKey: "nvidia-driver-daemonset"}},
+		},
+	}
+	revision := func(hash string, number int64) *appsv1.ControllerRevision {
+		return &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nvidia-driver-daemonset-" + hash,
+				Namespace: "test-ns",
+				Labels:    map[string]string{appLabelKey: "nvidia-driver-daemonset"},
+			},
+			Revision: number,
+		}
+	}
+	objects := []client.Object{cp, ds, revision("old", 1), revision("new", 2)}
+	for i, name := range []string{"node-a", "node-b", "node-c"} {
+		objects = append(objects, &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{commonGPULabelKey: commonGPULabelValue},
+		}})
+		if i >= len(crashLoopingHashes) {
+			continue
+		}
+		objects = append(objects, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nvidia-driver-daemonset-" + name,
+				Namespace: "test-ns",
+				Labels: map[string]string{
+					appLabelKey:                       "nvidia-driver-daemonset",
+					PodControllerRevisionHashLabelKey: crashLoopingHashes[i],
+				},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "apps/v1", Kind: "DaemonSet", Name: ds.Name, UID: ds.UID, Controller: ptr.To(true),
+				}},
+			},
+			Spec: corev1.PodSpec{NodeName: name},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "nvidia-driver-ctr",
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: crashLoopBackOffReason}},
+				}},
+			},
+		})
+	}
+
+	recorder := events.NewFakeRecorder(10)
+	n := &ClusterPolicyController{
+		ctx:               context.Background(),
+		singleton:         cp,
+		client:            fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objects...).WithStatusSubresource(cp).Build(),
+		logger:            logr.Discard(),
+		operatorNamespace: "test-ns",
+		hasGPUNodes:       true,
+		recorder:          recorder,
+	}
+	return n, recorder
+}
+
+func newSafeModeClusterPolicy() *gpuv1.ClusterPolicy {
+	return &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy", UID: "cp-uid"},
+		Spec: gpuv1.ClusterPolicySpec{
+			SafeMode: &gpuv1.SafeModeSpec{Enabled: ptr.To(true), CrashLoopThreshold: ptr.To[int32](50)},
+		},
+	}
+}
+
+func getSafeModeClusterPolicy(t *testing.T, n *ClusterPolicyController) *gpuv1.ClusterPolicy {
+	cp := &gpuv1.ClusterPolicy{}
+	require.NoError(t, n.client.Get(context.Background(), types.NamespacedName{Name: "cluster-policy"}, cp))
+	return cp
+}
+
+func TestReconcileSafeModeEnter(t *testing.T) {
+	// the pod of the previous revision does not count, 2 of 3 nodes crash loop with the latest revision
+	n, recorder := newSafeModeTestController(newSafeModeClusterPolicy(), "new", "old", "new")
+	require.NoError(t, n.reconcileSafeMode(context.Background()))
+	require.True(t, n.safeMode)
+
+	cp := getSafeModeClusterPolicy(t, n)
+	require.True(t, cp.Status.SafeMode.IsActive())
+	require.NotNil(t, cp.Status.SafeMode.Since)
+	require.Equal(t, 2, cp.Status.SafeMode.CrashLoopingNodes)
+	require.Equal(t, 3, cp.Status.SafeMode.GPUNodes)
+	require.Equal(t, []string{"nvidia-driver-daemonset/new"}, cp.Status.SafeMode.Revisions)
+	condition := meta.FindStatusCondition(cp.Status.Conditions, conditions.SafeMode)
+	require.NotNil(t, condition)
+	require.Equal(t, metav1.ConditionTrue, condition.Status)
+	require.Equal(t, conditions.OperandCrashLoop, condition.Reason)
+	require.Len(t, recorder.Events, 1)
+	require.Contains(t, <-recorder.Events, "Warning "+conditions.OperandCrashLoop)
+
+	// the safe mode remains active until acknowledged
+	n.singleton = cp
+	require.NoError(t, n.reconcileSafeMode(context.Background()))
+	require.True(t, n.safeMode)
+	require.Empty(t, recorder.Events)
+}
+
+func TestReconcileSafeModeBelowThreshold(t *testing.T) {
+	n, recorder := newSafeModeTestController(newSafeModeClusterPolicy(), "new", "old")
+	require.NoError(t, n.reconcileSafeMode(context.Background()))
+	require.False(t, n.safeMode)
+	require.Nil(t, getSafeModeClusterPolicy(t, n).Status.SafeMode)
+	require.Empty(t, recorder.Events)
+}
+
+func TestReconcileSafeModeAcknowledge(t *testing.T) {
+	cp := newSafeModeClusterPolicy()
+	cp.Annotations = map[string]string{safeModeAcknowledgedAnnotationKey: "true"}
+	cp.Status.SafeMode = &gpuv1.SafeModeStatus{
+		Active:                true,
+		Since:                 ptr.To(metav1.Now()),
+		CrashLoopingNodes:     2,
+		GPUNodes:              3,
+		Revisions:             []string{"nvidia-driver-daemonset/new"},
+		AcknowledgedRevisions: []string{"nvidia-driver-daemonset/older"},
+	}
+	n, recorder := newSafeModeTestController(cp, "new", "new", "new")
+	require.NoError(t, n.reconcileSafeMode(context.Background()))
+	require.False(t, n.safeMode)
+
+	cp = getSafeModeClusterPolicy(t, n)
+	require.NotContains(t, cp.Annotations, safeModeAcknowledgedAnnotationKey)
+	require.Equal(t, &gpuv1.SafeModeStatus{
+		AcknowledgedRevisions: []string{"nvidia-driver-daemonset/older", "nvidia-driver-daemonset/new"},
+	}, cp.Status.SafeMode)
+	condition := meta.FindStatusCondition(cp.Status.Conditions, conditions.SafeMode)
+	require.NotNil(t, condition)
+	require.Equal(t, metav1.ConditionFalse, condition.Status)
+	require.Contains(t, <-recorder.Events, "Normal "+conditions.SafeModeAcknowledged)
+
+	// the acknowledged revision does not enter the safe mode again
+	n.singleton = cp
+	require.NoError(t, n.reconcileSafeMode(context.Background()))
+	require.False(t, n.safeMode)
+	require.False(t, getSafeModeClusterPolicy(t, n).Status.SafeMode.IsActive())
+}
+
+func TestReconcileSafeModeDisabled(t *testing.T) {
+	cp := newSafeModeClusterPolicy()
+	cp.Spec.SafeMode.Enabled = ptr.To(false)
+	cp.Status.SafeMode = &gpuv1.SafeModeStatus{Active: true, Revisions: []string{"nvidia-driver-daemonset/new"}}
+	cp.Status.Conditions = []metav1.Condition{{
+		Type: conditions.SafeMode, Status: metav1.ConditionTrue, Reason: conditions.OperandCrashLoop, LastTransitionTime: metav1.Now(),
+	}}
+	n, _ := newSafeModeTestController(cp, "new", "new", "new")
+	require.NoError(t, n.reconcileSafeMode(context.Background()))
+	require.False(t, n.safeMode)
+
+	cp = getSafeModeClusterPolicy(t, n)
+	require.Nil(t, cp.Status.SafeMode)
+	require.Nil(t, meta.FindStatusCondition(cp.Status.Conditions, conditions.SafeMode))
+}
@@ -0,0 +1,70 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileStartupTaints removes the bootstrap taints from the nodes whose operator-validator pod is ready, so
+// that the workloads are scheduled on the nodes provisioned by node autoscalers once their GPU stack passed
+// validation
+func (n ClusterPolicyController) reconcileStartupTaints(ctx context.Context) error {
+	bootstrapTaints := n.singleton.Spec.Daemonsets.StartupTaints.GetBootstrapTaints()
+	if len(bootstrapTaints) == 0 {
+		return nil
+	}
+
+	pods := &corev1.PodList{}
+	err := n.client.List(ctx, pods, client.InNamespace(n.operatorNamespace), client.MatchingLabels{appLabelKey: operatorValidatorAppLabelValue})
+	if err != nil {
+		return fmt.Errorf("failed to list operator-validator pods: %w", err)
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName == "" || !isPodConditionTrue(&pod, corev1.PodReady) {
+			continue
+		}
+		node := &corev1.Node{}
+		if err := n.client.Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, node); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get node %s: %w", pod.Spec.NodeName, err)
+		}
+
+		original := node.DeepCopy()
+		node.Spec.Taints = slices.DeleteFunc(node.Spec.Taints, func(taint corev1.Taint) bool {
+			return slices.Contains(bootstrapTaints, taint.Key)
+		})
+		if len(node.Spec.Taints) == len(original.Spec.Taints) {
+			continue
+		}
+		n.logger.Info("Removing the bootstrap taints of validated node", "NodeName", node.Name, "Taints", bootstrapTaints)
+		if err := n.client.Patch(ctx, node, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})); err != nil {
+			return fmt.Errorf("failed to remove the bootstrap taints of node %s: %w", node.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestReconcileStartupTaints(t *testing.T) {
+	taints := []corev1.Taint{
+		{Key: "example.com/bootstrap", Effect: corev1.TaintEffectNoSchedule},
+		{Key: "example.com/other", Effect: corev1.TaintEffectNoSchedule},
+	}
+	validatorPod := func(nodeName string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nvidia-operator-validator-" + nodeName,
+				Namespace: "test-ns",
+				Labels:    map[string]string{appLabelKey: operatorValidatorAppLabelValue},
+			},
+			Spec:   corev1.PodSpec{NodeName: nodeName},
+			Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}}},
+		}
+	}
+	objs := []client.Object{
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "validated"}, Spec: corev1.NodeSpec{Taints: taints}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "not-validated"}, Spec: corev1.NodeSpec{Taints: taints}},
+		validatorPod("validated", corev1.ConditionTrue),
+		validatorPod("not-validated", corev1.ConditionFalse),
+		validatorPod("deleted", corev1.ConditionTrue),
+	}
+	cp := &gpuv1.ClusterPolicy{}
+	n := ClusterPolicyController{
+		client:            fake.NewClientBuilder().WithObjects(objs...).Build(),
+		singleton:         cp,
+		operatorNamespace: "test-ns",
+		logger:            logr.Discard(),
+	}
+	getTaints := func(name string) []corev1.Taint {
+		node := &corev1.Node{}
+		require.NoError(t, n.client.Get(context.Background(), client.ObjectKey{Name: name}, node))
+		return node.Spec.Taints
+	}
+
+	// no taint is removed without bootstrap taints
+	require.NoError(t, n.reconcileStartupTaints(context.Background()))
+	require.Equal(t, taints, getTaints("validated"))
+
+	// the bootstrap taints of the validated nodes are removed
+	cp.Spec.Daemonsets.StartupTaints = &gpuv1.StartupTaintsSpec{BootstrapTaints: []string{"example.com/bootstrap"}}
+	require.NoError(t, n.reconcileStartupTaints(context.Background()))
+	require.Equal(t, taints[1:], getTaints("validated"))
+	require.Equal(t, taints, getTaints("not-validated"))
+}
@@ -0,0 +1,138 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+// stateDependencies declares the prerequisites of the states, whose operands must be ready before the operands
+// of the state are deployed. A disabled prerequisite, e.g. a driver pre-installed on the hosts, is satisfied.
+// The state-operator-validation state is not a prerequisite of the states it validates, as its pods wait for
+// their operands to be running.
+var stateDependencies = map[string][]string{
+	"state-driver":                {"pre-requisites"},
+	"state-nvlink-fabric":         {"state-driver"},
+	"state-container-toolkit":     {"state-driver"},
+	"state-operator-validation":   {"state-driver", "state-container-toolkit"},
+	"state-device-plugin":         {"state-driver", "state-container-toolkit"},
+	"state-mps-control-daemon":    {"state-device-plugin"},
+	"state-dcgm":                  {"state-driver", "state-container-toolkit"},
+	"state-dcgm-exporter":         {"state-driver", "state-container-toolkit"},
+	"gpu-feature-discovery":       {"state-driver", "state-container-toolkit"},
+	"state-mig-manager":           {"state-driver", "state-container-toolkit"},
+	"state-gpu-health-check":      {"state-driver"},
+	"state-vgpu-device-manager":   {"state-vgpu-manager"},
+	"state-sandbox-device-plugin": {"state-vfio-manager", "state-vgpu-device-manager"},
+	"state-kata-device-plugin":    {"state-vfio-manager"},
+}
+
+// orderStates sorts the states so that every state comes after its prerequisites, while the states whose
+// prerequisites are satisfied keep the order they were added in
+func (n *ClusterPolicyController) orderStates() error {
+	loaded := make(map[string]bool, len(n.stateNames))
+	for _, name := range n.stateNames {
+		loaded[name] = true
+	}
+
+	ordered := make([]int, 0, len(n.stateNames))
+	placed := make(map[string]bool, len(n.stateNames))
+	for len(ordered) < len(n.stateNames) {
+		next := -1
+		for i, name := range n.stateNames {
+			if placed[name] {
+				continue
+			}
+			ready := true
+			for _, prerequisite := range stateDependencies[name] {
+				if loaded[prerequisite] && !placed[prerequisite] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				next = i
+				break
+			}
+		}
+		if next == -1 {
+			var cyclic []string
+			for _, name := range n.stateNames {
+				if !placed[name] {
+					cyclic = append(cyclic, name)
+				}
+			}
+			return fmt.Errorf("cyclic dependencies between states %v", cyclic)
+		}
+		placed[n.stateNames[next]] = true
+		ordered = append(ordered, next)
+	}
+
+	controls := make([]stateSyncer, 0, len(ordered))
+	resources := make([]Resources, 0, len(ordered))
+	stateNames := make([]string, 0, len(ordered))
+	for _, i := range ordered {
+		controls = append(controls, n.controls[i])
+		resources = append(resources, n.resources[i])
+		stateNames = append(stateNames, n.stateNames[i])
+	}
+	n.controls, n.resources, n.stateNames = controls, resources, stateNames
+	return nil
+}
+
+// notReadyPrerequisites returns the prerequisites of the state which are not ready in the current reconciliation,
+// along with the closed external gates holding the state
+func (n ClusterPolicyController) notReadyPrerequisites(stateName string) []string {
+	var notReady []string
+	for _, prerequisite := range stateDependencies[stateName] {
+		state, ok := n.stateResults[prerequisite]
+		if ok && state != gpuv1.Ready && state != gpuv1.Disabled {
+			notReady = append(notReady, prerequisite)
+		}
+	}
+	return append(notReady, n.closedExternalGates[stateName]...)
+}
+
+// recordStateResult records the result of the state in the current reconciliation, along with its prerequisites
+// which are not ready when the state was skipped
+func (n *ClusterPolicyController) recordStateResult(stateName string, state gpuv1.State, notReadyPrerequisites []string) {
+	if n.stateResults == nil {
+		n.stateResults = make(map[string]gpuv1.State)
+	}
+	n.stateResults[stateName] = state
+	if len(notReadyPrerequisites) > 0 {
+		if n.blockedStates == nil {
+			n.blockedStates = make(map[string][]string)
+		}
+		n.blockedStates[stateName] = notReadyPrerequisites
+	}
+}
+
+// getBlockedStates returns the sorted list of states skipped in the current reconciliation, along with their
+// prerequisites which are not ready
+func (n *ClusterPolicyController) getBlockedStates() []string {
+	blocked := make([]string, 0, len(n.blockedStates))
+	for name, prerequisites := range n.blockedStates {
+		blocked = append(blocked, fmt.Sprintf("%s (waiting for %s)", name, strings.Join(prerequisites, ", ")))
+	}
+	sort.Strings(blocked)
+	return blocked
+}
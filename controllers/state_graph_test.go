@@ -0,0 +1,158 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func newStateGraphController(stateNames []string, results map[string]gpuv1.State, deployed *[]string) *ClusterPolicyController {
+	n := &ClusterPolicyController{
+		ctx:       context.Background(),
+		singleton: &gpuv1.ClusterPolicy{},
+		logger:    ctrl.Log.WithName("test"),
+	}
+	for _, name := range stateNames {
+		stateName := name
+		n.stateNames = append(n.stateNames, stateName)
+		n.resources = append(n.resources, Resources{})
+		n.controls = append(n.controls, controlFunc{func(ClusterPolicyController) (gpuv1.State, error) {
+			*deployed = append(*deployed, stateName)
+			return results[stateName], nil
+		}})
+	}
+	return n
+}
+
+func TestOrderStates(t *testing.T) {
+	var deployed []string
+	results := map[string]gpuv1.State{
+		"state-device-plugin":     gpuv1.Ready,
+		"state-operator-metrics":  gpuv1.Ready,
+		"state-container-toolkit": gpuv1.Ready,
+		"state-driver":            gpuv1.Ready,
+		"pre-requisites":          gpuv1.Ready,
+	}
+	n := newStateGraphController([]string{
+		"state-device-plugin",
+		"state-operator-metrics",
+		"state-container-toolkit",
+		"state-driver",
+		"pre-requisites",
+	}, results, &deployed)
+	require.NoError(t, n.orderStates())
+	require.Equal(t, []string{
+		"state-operator-metrics",
+		"pre-requisites",
+		"state-driver",
+		"state-container-toolkit",
+		"state-device-plugin",
+	}, n.stateNames)
+
+	// the controls and resources are reordered along with the states
+	for !n.last() {
+		_, err := n.step()
+		require.NoError(t, err)
+	}
+	require.Equal(t, n.stateNames, deployed)
+}
+
+func TestOrderStatesCycle(t *testing.T) {
+	stateDependencies["pre-requisites"] = []string{"state-container-toolkit"}
+	defer delete(stateDependencies, "pre-requisites")
+
+	var deployed []string
+	n := newStateGraphController([]string{"pre-requisites", "state-driver", "state-container-toolkit"}, nil, &deployed)
+	require.Error(t, n.orderStates())
+}
+
+func TestStepSkipsStatesWithNotReadyPrerequisites(t *testing.T) {
+	testCases := []struct {
+		description     string
+		results         map[string]gpuv1.State
+		expectedDeploys []string
+		expectedBlocked []string
+	}{
+		{
+			description: "all ready",
+			results: map[string]gpuv1.State{
+				"state-driver":            gpuv1.Ready,
+				"state-container-toolkit": gpuv1.Ready,
+				"state-device-plugin":     gpuv1.Ready,
+			},
+			expectedDeploys: []string{"state-driver", "state-container-toolkit", "state-device-plugin", "state-mps-control-daemon"},
+			expectedBlocked: []string{},
+		},
+		{
+			description: "pre-installed driver",
+			results: map[string]gpuv1.State{
+				"state-driver":            gpuv1.Disabled,
+				"state-container-toolkit": gpuv1.Ready,
+				"state-device-plugin":     gpuv1.Ready,
+			},
+			expectedDeploys: []string{"state-driver", "state-container-toolkit", "state-device-plugin", "state-mps-control-daemon"},
+			expectedBlocked: []string{},
+		},
+		{
+			description: "driver not ready",
+			results: map[string]gpuv1.State{
+				"state-driver": gpuv1.NotReady,
+			},
+			expectedDeploys: []string{"state-driver"},
+			expectedBlocked: []string{
+				"state-container-toolkit (waiting for state-driver)",
+				"state-device-plugin (waiting for state-driver, state-container-toolkit)",
+				"state-mps-control-daemon (waiting for state-device-plugin)",
+			},
+		},
+		{
+			description: "device plugin not ready",
+			results: map[string]gpuv1.State{
+				"state-driver":            gpuv1.Ready,
+				"state-container-toolkit": gpuv1.Ready,
+				"state-device-plugin":     gpuv1.NotReady,
+			},
+			expectedDeploys: []string{"state-driver", "state-container-toolkit", "state-device-plugin"},
+			expectedBlocked: []string{"state-mps-control-daemon (waiting for state-device-plugin)"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var deployed []string
+			n := newStateGraphController([]string{
+				"state-driver",
+				"state-container-toolkit",
+				"state-device-plugin",
+				"state-mps-control-daemon",
+			}, tc.results, &deployed)
+
+			for !n.last() {
+				_, err := n.step()
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expectedDeploys, deployed)
+			require.Equal(t, tc.expectedBlocked, n.getBlockedStates())
+		})
+	}
+}
@@ -0,0 +1,239 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+// stateSyncer deploys the operand objects of a state and returns whether they are ready. The controlFunc of
+// the states loaded from the operand assets implements it.
+type stateSyncer interface {
+	sync(n ClusterPolicyController) (gpuv1.State, error)
+}
+
+// stateDefinition is a state reconciled by the ClusterPolicyController
+type stateDefinition struct {
+	// name identifies the state in the state dependencies, the state timeouts and the status
+	name string
+	// resources are the operand objects of the state, read by the sync functions of the assets
+	resources Resources
+	syncer    stateSyncer
+}
+
+// stateLoader loads the states reconciled by the ClusterPolicyController, which are then ordered by their
+// dependencies. The states are loaded once, on the first reconciliation.
+type stateLoader interface {
+	load(n *ClusterPolicyController) ([]stateDefinition, error)
+}
+
+// nodeSnapshot is the view of the cluster nodes read by the ClusterPolicyController. The nodeInventory
+// maintained from the Node informer implements it.
+type nodeSnapshot interface {
+	// synced returns whether the snapshot holds all the nodes of the cluster, the nodes are listed from the
+	// API server otherwise
+	synced() bool
+	// hasNFDLabels returns whether any node of the cluster is labeled by NFD
+	hasNFDLabels() bool
+	// list returns the GPU nodes matching the labels, sorted by name
+	list(matchingLabels map[string]string) []corev1.Node
+}
+
+// StateFunc is a state reconciled by the ClusterPolicyController with a function instead of the operand assets,
+// letting the state transitions be exercised without API server, e.g. with the fake states of the controllertest
+// package
+type StateFunc struct {
+	// Name identifies the state in the state dependencies
+	Name string
+	// Sync syncs the state and returns whether it is ready
+	Sync func() (gpuv1.State, error)
+}
+
+func (s StateFunc) sync(ClusterPolicyController) (gpuv1.State, error) {
+	return s.Sync()
+}
+
+// stateFuncLoader loads the states reconciled with functions, in order
+type stateFuncLoader []StateFunc
+
+func (l stateFuncLoader) load(*ClusterPolicyController) ([]stateDefinition, error) {
+	states := make([]stateDefinition, 0, len(l))
+	for _, state := range l {
+		states = append(states, stateDefinition{name: state.Name, syncer: state})
+	}
+	return states, nil
+}
+
+// staticNodeSnapshot is a fixed snapshot of the GPU nodes
+type staticNodeSnapshot []corev1.Node
+
+func (s staticNodeSnapshot) synced() bool {
+	return true
+}
+
+func (s staticNodeSnapshot) hasNFDLabels() bool {
+	for _, node := range s {
+		if hasNFDLabels(node.Labels) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s staticNodeSnapshot) list(matchingLabels map[string]string) []corev1.Node {
+	selector := labels.SelectorFromSet(matchingLabels)
+	nodes := []corev1.Node{}
+	for _, node := range s {
+		if selector.Matches(labels.Set(node.Labels)) {
+			nodes = append(nodes, node)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	return nodes
+}
+
+// NewClusterPolicyControllerWithStates returns a ClusterPolicyController reconciling the states for the
+// ClusterPolicy, with the GPU nodes read from the given nodes. The states are ordered by their dependencies as
+// on the first reconciliation. The states only reach the API server through their own functions.
+func NewClusterPolicyControllerWithStates(ctx context.Context, logger logr.Logger, clusterPolicy *gpuv1.ClusterPolicy,
+	states []StateFunc, nodes ...corev1.Node) (*ClusterPolicyController, error) {
+	n := &ClusterPolicyController{
+		ctx:         ctx,
+		singleton:   clusterPolicy,
+		logger:      logger,
+		stateLoader: stateFuncLoader(states),
+		nodes:       staticNodeSnapshot(nodes),
+	}
+	if err := n.loadStates(""); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// RunStates reconciles all the states in order, as a reconciliation of the ClusterPolicy, and returns the overall
+// state, along with the states which are not ready. It stops at the first state failing to reconcile.
+func (n *ClusterPolicyController) RunStates() (gpuv1.State, []string, error) {
+	n.idx = 0
+	n.stateResults = make(map[string]gpuv1.State, len(n.stateNames))
+	n.blockedStates = make(map[string][]string)
+	return n.runStates()
+}
+
+// StateNames returns the names of the states, in the order they are reconciled
+func (n *ClusterPolicyController) StateNames() []string {
+	return n.stateNames
+}
+
+// BlockedStates returns the sorted list of the states skipped in the last reconciliation, along with their
+// prerequisites which are not ready
+func (n *ClusterPolicyController) BlockedStates() []string {
+	return n.getBlockedStates()
+}
+
+// assetStates lists the states loaded from the operand assets, in the order they are added
+var assetStates = []string{
+	"pre-requisites",
+	"state-node-feature-discovery",
+	"state-operator-metrics",
+	"state-download-cache",
+	"state-driver",
+	"state-nvlink-fabric",
+	"state-container-toolkit",
+	"state-operator-validation",
+	"state-device-plugin",
+	"state-mps-control-daemon",
+	"state-dcgm",
+	"state-dcgm-exporter",
+	"gpu-feature-discovery",
+	"state-mig-manager",
+	"state-node-status-exporter",
+	"state-gpu-health-check",
+	"state-image-prepull",
+	"state-windows-device-plugin",
+	"state-windows-gpu-feature-discovery",
+	"state-mock-gpus",
+	// sandbox workload states
+	"state-vgpu-manager",
+	"state-vgpu-device-manager",
+	"state-sandbox-validation",
+	"state-vfio-manager",
+	"state-sandbox-device-plugin",
+	"state-kata-device-plugin",
+	"state-kata-manager",
+	"state-cc-manager",
+}
+
+// assetStateLoader loads the states from the operand asset manifests of a directory
+type assetStateLoader struct {
+	dir string
+}
+
+func (l assetStateLoader) load(n *ClusterPolicyController) ([]stateDefinition, error) {
+	states := make([]stateDefinition, 0, len(assetStates))
+	for _, name := range assetStates {
+		res, ctrl := addResourcesControls(n, filepath.Join(l.dir, name))
+		var syncer stateSyncer = ctrl
+		if tenantStates[name] {
+			syncer = tenantSyncer{ctrl}
+		}
+		states = append(states, stateDefinition{name: name, resources: res, syncer: syncer})
+	}
+	return states, nil
+}
+
+// loadStates loads the states with the state loader of the controller, or from the operand assets of the
+// directory when it is not set, and orders them by their dependencies
+func (n *ClusterPolicyController) loadStates(assetsDir string) error {
+	loader := n.stateLoader
+	if loader == nil {
+		loader = assetStateLoader{dir: assetsDir}
+	}
+	states, err := loader.load(n)
+	if err != nil {
+		return err
+	}
+	for _, state := range states {
+		n.appendState(state)
+	}
+
+	if err := n.orderStates(); err != nil {
+		n.controls = nil
+		n.resources = nil
+		n.stateNames = nil
+		return err
+	}
+	return nil
+}
+
+func (n *ClusterPolicyController) appendState(state stateDefinition) {
+	n.controls = append(n.controls, state.syncer)
+	n.resources = append(n.resources, state.resources)
+	n.stateNames = append(n.stateNames, state.name)
+}
+
+// nodesSynced returns whether the node snapshot holds all the nodes of the cluster
+func (n ClusterPolicyController) nodesSynced() bool {
+	return n.nodes != nil && n.nodes.synced()
+}
@@ -0,0 +1,83 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/testutil"
+)
+
+// readyStates returns states which are always ready, with the names
+func readyStates(names ...string) []StateFunc {
+	states := make([]StateFunc, 0, len(names))
+	for _, name := range names {
+		states = append(states, StateFunc{Name: name, Sync: func() (gpuv1.State, error) { return gpuv1.Ready, nil }})
+	}
+	return states
+}
+
+func TestRunStatesLeavesFailingStateCurrent(t *testing.T) {
+	errSync := errors.New("sync failed")
+	states := readyStates("pre-requisites", "state-driver", "state-container-toolkit", "state-device-plugin")
+	states[2].Sync = func() (gpuv1.State, error) { return gpuv1.NotReady, errSync }
+	n, err := NewClusterPolicyControllerWithStates(context.Background(), ctrl.Log.WithName("fake"), testutil.NewClusterPolicy(), states)
+	require.NoError(t, err)
+
+	_, _, err = n.RunStates()
+	require.ErrorIs(t, err, errSync)
+	require.Equal(t, "state-container-toolkit", n.stateNames[n.idx])
+}
+
+func TestLoadStatesCycle(t *testing.T) {
+	stateDependencies["pre-requisites"] = []string{"state-container-toolkit"}
+	defer delete(stateDependencies, "pre-requisites")
+
+	n := &ClusterPolicyController{
+		stateLoader: stateFuncLoader(readyStates("pre-requisites", "state-driver", "state-container-toolkit")),
+	}
+	require.Error(t, n.loadStates(""))
+	// the states are loaded again on the next reconciliation
+	require.Empty(t, n.controls)
+	require.Empty(t, n.stateNames)
+}
+
+func TestGPUNodesFromStaticSnapshot(t *testing.T) {
+	n, err := NewClusterPolicyControllerWithStates(context.Background(), ctrl.Log.WithName("fake"), testutil.NewClusterPolicy(), nil,
+		*testutil.NewGPUNode("node-b", testutil.WithNFDLabels("ubuntu", "22.04", "5.15.0-105-generic")),
+		*testutil.NewGPUNode("node-a", testutil.WithNFDLabels("rhel", "9.4", "5.14.0-427.13.1.el9_4.x86_64")),
+	)
+	require.NoError(t, err)
+	require.True(t, n.nodes.hasNFDLabels())
+
+	nodes, err := n.listGPUNodes(map[string]string{nfdOSReleaseIDLabelKey: "ubuntu"})
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+	require.Equal(t, "node-b", nodes[0].Name)
+
+	// the OS of the first GPU node by name is used
+	release, tag, err := n.getGPUNodeOSInfo()
+	require.NoError(t, err)
+	require.Equal(t, "rhel", release)
+	require.Equal(t, "rhel9", tag)
+}
@@ -18,9 +18,13 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	apiconfigv1 "github.com/openshift/api/config/v1"
@@ -31,32 +35,50 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 
 	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
 	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/assets"
+	"github.com/NVIDIA/gpu-operator/internal/audit"
+	clusterpolicyutil "github.com/NVIDIA/gpu-operator/internal/clusterpolicy"
 	"github.com/NVIDIA/gpu-operator/internal/consts"
+	"github.com/NVIDIA/gpu-operator/internal/featuregate"
+	"github.com/NVIDIA/gpu-operator/internal/image"
+	"github.com/NVIDIA/gpu-operator/internal/introspection"
+	"github.com/NVIDIA/gpu-operator/internal/nodepolicy"
+	"github.com/NVIDIA/gpu-operator/internal/operatorconfig"
+	"github.com/NVIDIA/gpu-operator/internal/tracing"
+	gpulabels "github.com/NVIDIA/gpu-operator/pkg/labels"
 )
 
 const (
-	commonGPULabelKey                   = "nvidia.com/gpu.present"
+	commonGPULabelKey                   = gpulabels.GPUPresent
 	commonGPULabelValue                 = "true"
-	commonOperandsLabelKey              = "nvidia.com/gpu.deploy.operands"
+	commonOperandsLabelKey              = gpulabels.DeployOperands
 	commonOperandsLabelValue            = "true"
-	migManagerLabelKey                  = "nvidia.com/gpu.deploy.mig-manager"
+	migManagerLabelKey                  = gpulabels.DeployMIGManager
 	migManagerLabelValue                = "true"
-	migCapableLabelKey                  = "nvidia.com/mig.capable"
+	migCapableLabelKey                  = gpulabels.MIGCapable
 	migCapableLabelValue                = "true"
-	migConfigLabelKey                   = "nvidia.com/mig.config"
-	migConfigDisabledValue              = "all-disabled"
-	vgpuHostDriverLabelKey              = "nvidia.com/vgpu.host-driver-version"
-	gpuProductLabelKey                  = "nvidia.com/gpu.product"
+	migConfigLabelKey                   = gpulabels.MIGConfig
+	migConfigDisabledValue              = gpulabels.MIGConfigAllDisabled
+	vgpuHostDriverLabelKey              = gpulabels.VGPUHostDriverVersion
+	vgpuPresentLabelKey                 = gpulabels.VGPUPresent
+	vgpuConfigLabelKey                  = gpulabels.VGPUConfig
+	vgpuConfigStateLabelKey             = "nvidia.com/vgpu.config.state"
+	gpuProductLabelKey                  = gpulabels.GPUProduct
 	nfdLabelPrefix                      = "feature.node.kubernetes.io/"
 	nfdKernelLabelKey                   = "feature.node.kubernetes.io/kernel-version.full"
 	nfdOSTreeVersionLabelKey            = "feature.node.kubernetes.io/system-os_release.OSTREE_VERSION"
 	nfdOSReleaseIDLabelKey              = "feature.node.kubernetes.io/system-os_release.ID"
 	nfdOSVersionIDLabelKey              = "feature.node.kubernetes.io/system-os_release.VERSION_ID"
+	nfdSEVSNPEnabledLabelKey            = "feature.node.kubernetes.io/cpu-security.sev.snp.enabled"
+	nfdTDXEnabledLabelKey               = "feature.node.kubernetes.io/cpu-security.tdx.enabled"
 	ocpDriverToolkitVersionLabel        = "openshift.driver-toolkit.rhcos"
 	ocpDriverToolkitIdentificationLabel = "openshift.driver-toolkit"
 	appLabelKey                         = "app"
@@ -67,61 +89,83 @@ const (
 	precompiledIdentificationLabelValue = "true"
 	// see bundle/manifests/gpu-operator.clusterserviceversion.yaml
 	//     --> ClusterServiceVersion.metadata.annotations.operatorframework.io/suggested-namespace
-	ocpSuggestedNamespace              = "nvidia-gpu-operator"
-	gpuWorkloadConfigLabelKey          = "nvidia.com/gpu.workload.config"
-	gpuWorkloadConfigContainer         = "container"
-	gpuWorkloadConfigVMPassthrough     = "vm-passthrough"
-	gpuWorkloadConfigVMVgpu            = "vm-vgpu"
-	kubevirtDevicePluginDeployLabelKey = "nvidia.com/gpu.deploy.sandbox-device-plugin"
-	kataDevicePluginDeployLabelKey     = "nvidia.com/gpu.deploy.kata-sandbox-device-plugin"
+	ocpSuggestedNamespace          = "nvidia-gpu-operator"
+	gpuWorkloadConfigLabelKey      = gpulabels.WorkloadConfig
+	gpuWorkloadConfigContainer     = gpulabels.WorkloadConfigContainer
+	gpuWorkloadConfigVMPassthrough = gpulabels.WorkloadConfigVMPassthrough
+	gpuWorkloadConfigVMVgpu        = gpulabels.WorkloadConfigVMVgpu
+	gpuSandboxModeLabelKey         = gpulabels.SandboxMode
+	// gpuWorkloadConfigWindows is the workload config of the Windows GPU nodes. It is set from the OS of the
+	// node rather than from the nvidia.com/gpu.workload.config label.
+	gpuWorkloadConfigWindows           = "windows"
+	nodeOSLabelKey                     = "kubernetes.io/os"
+	nodeOSWindows                      = "windows"
+	kubevirtDevicePluginDeployLabelKey = gpulabels.DeploySandboxDevicePlugin
+	kataDevicePluginDeployLabelKey     = gpulabels.DeployKataSandboxDevicePlugin
 	// Deploy labels shared by the ClusterPolicy gpuStateLabels map and the GPUCluster
 	// (DRA) node-labeling path, so each key string has a single definition.
-	driverDeployLabelKey           = "nvidia.com/gpu.deploy.driver"
-	draDriverDeployLabelKey        = "nvidia.com/gpu.deploy.dra-driver"
-	draValidatorDeployLabelKey     = "nvidia.com/gpu.deploy.dra-validator"
-	gfdDeployLabelKey              = "nvidia.com/gpu.deploy.gpu-feature-discovery"
-	dcgmDeployLabelKey             = "nvidia.com/gpu.deploy.dcgm"
-	dcgmExporterDeployLabelKey     = "nvidia.com/gpu.deploy.dcgm-exporter"
-	vgpuManagerDeployLabelKey      = "nvidia.com/gpu.deploy.vgpu-manager"
+	driverDeployLabelKey           = gpulabels.DeployDriver
+	draDriverDeployLabelKey        = gpulabels.DeployDRADriver
+	draValidatorDeployLabelKey     = gpulabels.DeployDRAValidator
+	gfdDeployLabelKey              = gpulabels.DeployGPUFeatureDiscovery
+	dcgmDeployLabelKey             = gpulabels.DeployDCGM
+	dcgmExporterDeployLabelKey     = gpulabels.DeployDCGMExporter
+	vgpuManagerDeployLabelKey      = gpulabels.DeployVGPUManager
+	ccManagerDeployLabelKey        = gpulabels.DeployCCManager
 	podSecurityLabelPrefix         = "pod-security.kubernetes.io/"
 	podSecurityLevelPrivileged     = "privileged"
+	podSecurityModeEnforce         = "enforce"
 	driverAutoUpgradeAnnotationKey = "nvidia.com/gpu-driver-upgrade-enabled"
 	commonDriverDaemonsetName      = "nvidia-driver-daemonset"
 	commonVGPUManagerDaemonsetName = "nvidia-vgpu-manager-daemonset"
+	devicePluginDeployLabelKey     = gpulabels.DeployDevicePlugin
+	// devicePluginPausedForGPUHealth is the device-plugin deploy label value of the nodes labeled
+	// unhealthy by the GPU health check
+	devicePluginPausedForGPUHealth = "paused-for-gpu-health"
+	// devicePluginPausedForOptOut is the device-plugin deploy label value of the nodes whose operands are
+	// disabled at the deadline of the nvidia.com/gpu.operands.disable-after annotation
+	devicePluginPausedForOptOut = "paused-for-opt-out"
 )
 
 var (
 	defaultGPUWorkloadConfig = gpuWorkloadConfigContainer
-	podSecurityModes         = []string{"enforce", "audit", "warn"}
+	podSecurityModes         = []string{podSecurityModeEnforce, "audit", "warn"}
 )
 
 var gpuStateLabels = map[string]map[string]string{
 	gpuWorkloadConfigContainer: {
-		driverDeployLabelKey:                         "true",
-		gfdDeployLabelKey:                            "true",
-		"nvidia.com/gpu.deploy.container-toolkit":    "true",
-		"nvidia.com/gpu.deploy.device-plugin":        "true",
-		dcgmDeployLabelKey:                           "true",
-		dcgmExporterDeployLabelKey:                   "true",
-		"nvidia.com/gpu.deploy.node-status-exporter": "true",
-		"nvidia.com/gpu.deploy.operator-validator":   "true",
-		"nvidia.com/gpu.deploy.client":               "true",
+		driverDeployLabelKey:               "true",
+		gfdDeployLabelKey:                  "true",
+		gpulabels.DeployContainerToolkit:   "true",
+		devicePluginDeployLabelKey:         "true",
+		dcgmDeployLabelKey:                 "true",
+		dcgmExporterDeployLabelKey:         "true",
+		gpulabels.DeployNodeStatusExporter: "true",
+		gpulabels.DeployGPUHealthCheck:     "true",
+		gpulabels.DeployNVLinkFabric:       "true",
+		gpulabels.DeployImagePrePull:       "true",
+		gpulabels.DeployOperatorValidator:  "true",
+		gpulabels.DeployClient:             "true",
 	},
 	gpuWorkloadConfigVMPassthrough: {
-		"nvidia.com/gpu.deploy.sandbox-device-plugin": "true",
-		"nvidia.com/gpu.deploy.sandbox-validator":     "true",
-		"nvidia.com/gpu.deploy.vfio-manager":          "true",
-		"nvidia.com/gpu.deploy.kata-manager":          "true",
-		"nvidia.com/gpu.deploy.cc-manager":            "true",
-		"nvidia.com/gpu.deploy.client":                "true",
+		gpulabels.DeploySandboxDevicePlugin: "true",
+		gpulabels.DeploySandboxValidator:    "true",
+		gpulabels.DeployVFIOManager:         "true",
+		gpulabels.DeployKataManager:         "true",
+		ccManagerDeployLabelKey:             "true",
+		gpulabels.DeployClient:              "true",
 	},
 	gpuWorkloadConfigVMVgpu: {
-		"nvidia.com/gpu.deploy.sandbox-device-plugin": "true",
-		vgpuManagerDeployLabelKey:                     "true",
-		"nvidia.com/gpu.deploy.vgpu-device-manager":   "true",
-		"nvidia.com/gpu.deploy.sandbox-validator":     "true",
-		"nvidia.com/gpu.deploy.cc-manager":            "true",
-		"nvidia.com/gpu.deploy.client":                "true",
+		gpulabels.DeploySandboxDevicePlugin: "true",
+		vgpuManagerDeployLabelKey:           "true",
+		gpulabels.DeployVGPUDeviceManager:   "true",
+		gpulabels.DeploySandboxValidator:    "true",
+		ccManagerDeployLabelKey:             "true",
+		gpulabels.DeployClient:              "true",
+	},
+	gpuWorkloadConfigWindows: {
+		gpulabels.DeployWindowsDevicePlugin:        "true",
+		gpulabels.DeployWindowsGPUFeatureDiscovery: "true",
 	},
 }
 
@@ -138,8 +182,8 @@ var gpuClusterStateLabels = map[string]string{
 
 // clusterPolicyStateLabelKeys returns every deploy-label key the ClusterPolicy
 // (device-plugin) stack may set on a node. The sandbox device-plugin keys are added
-// explicitly (and the result computed per call) because getEffectiveStateLabels
-// mutates the vm-passthrough map in place; mig-manager is added explicitly because
+// explicitly because getEffectiveStateLabels sets them per sandbox mode on a copy of
+// the vm-passthrough map; mig-manager is added explicitly because
 // addGPUStateLabels sets it per MIG capability rather than via the workload-config maps.
 func clusterPolicyStateLabelKeys() map[string]bool {
 	keys := make(map[string]bool)
@@ -175,7 +219,11 @@ var gpuNodeLabels = map[string]string{
 
 type gpuWorkloadConfiguration struct {
 	config      string
-	sandboxMode string // SandboxWorkloads.Mode (e.g. "kubevirt", "kata") — only affects vm-passthrough labels
+	sandboxMode string // sandbox mode of the node (e.g. "kubevirt", "kata") — only affects vm-passthrough labels
+	// ccCapableNodesOnly limits the cc-manager deploy label to nodes with a CC-capable CPU
+	ccCapableNodesOnly bool
+	// consumerGPU leaves out the deploy labels of the operands relying on datacenter GPU features
+	consumerGPU bool
 	node        string
 	log         logr.Logger
 }
@@ -207,13 +255,68 @@ type ClusterPolicyController struct {
 	scheme            *runtime.Scheme
 	operatorNamespace string
 
-	resources            []Resources
-	controls             []controlFunc
-	stateNames           []string
+	resources  []Resources
+	controls   []stateSyncer
+	stateNames []string
+	// stateLoader loads the states on the first reconciliation, the states of the operand assets are loaded
+	// when not set
+	stateLoader stateLoader
+	// stateResults holds the result of the states reconciled so far in the current reconciliation
+	stateResults map[string]gpuv1.State
+	// closedExternalGates holds the closed external gates holding each state in the current reconciliation
+	closedExternalGates map[string][]string
+	// blockedStates holds the states skipped in the current reconciliation, along with their prerequisites
+	// which are not ready
+	blockedStates        map[string][]string
 	operatorMetrics      *OperatorMetrics
 	idx                  int
 	kernelVersionMap     map[string]string
 	currentKernelVersion string
+	// kernel versions, mapped to their OS, announced for the upcoming OS upgrade of the nodes and not run yet
+	preStagedKernelVersions map[string]string
+
+	// scoped ClusterPolicies, oldest first, whose DaemonSets are deployed next to the ones of the singleton
+	scopedPolicies []gpuv1.ClusterPolicy
+	// scopedPolicy is the scoped ClusterPolicy whose DaemonSet is being deployed, unset for the singleton
+	scopedPolicy *gpuv1.ClusterPolicy
+	// scopeResolved is set while the DaemonSets of the singleton and of the scoped ClusterPolicies are deployed
+	// one after the other
+	scopeResolved bool
+	// validatorImageResolved is set while the DaemonSets of the default validator image and of the validator
+	// images of the ClusterPolicy are deployed one after the other
+	validatorImageResolved bool
+	// validatorImages holds the validator images, keyed by the value of their node label, whose DaemonSets are
+	// deployed next to the one of the default validator image
+	validatorImages map[string]*gpuv1.ValidatorImageSpec
+	// validatorImage is the node label value of the validator image whose DaemonSet is being deployed, unset
+	// for the default validator image
+	validatorImage string
+	// runtimeResolved is set while the DaemonSets of the container runtimes of the GPU nodes are deployed one
+	// after the other
+	runtimeResolved bool
+	// runtimes holds the container runtimes of the GPU nodes, sorted, set for mixed-runtime clusters only
+	runtimes []gpuv1.Runtime
+	// runtimeVariant is the container runtime whose DaemonSet is being deployed, unset for the default one
+	runtimeVariant gpuv1.Runtime
+	// archResolved is set while the DaemonSets of the per-architecture images of the component of the current
+	// state are deployed one after the other
+	archResolved bool
+	// archImages holds the architectures of the GPU nodes, sorted, for which the component of the current state
+	// has an image, whose DaemonSets are deployed next to the one of the default image
+	archImages []string
+	// archVariant is the architecture whose DaemonSet is being deployed, unset for the default image
+	archVariant string
+	// variantDaemonSets holds the DaemonSets of the current state, listed once for the variant resolvers to
+	// find the DaemonSets of the variants which are no longer deployed
+	variantDaemonSets *variantDaemonSetList
+	// scopeStates holds the state of the DaemonSets of every scoped ClusterPolicy
+	scopeStates map[string]gpuv1.State
+	// tenant is the tenant namespace whose operands are being deployed, unset for the operator namespace
+	tenant string
+	// consumerGPUNodes are the GPU nodes labeled with consumer GPUs
+	consumerGPUNodes []string
+	// nodeMutationPolicy is the set of node fields the operator, and the validator operands, are allowed to mutate
+	nodeMutationPolicy nodepolicy.Policy
 
 	k8sVersion       string
 	openshift        string
@@ -225,25 +328,85 @@ type ClusterPolicyController struct {
 	hasGPUNodes      bool
 	hasNFDLabels     bool
 	sandboxEnabled   bool
+	// nodeSandboxModes records the sandbox modes selected on the GPU nodes with the sandbox mode label
+	nodeSandboxModes map[string]bool
+	// gpuNodeArchs records the architectures of the GPU nodes
+	gpuNodeArchs map[string]bool
 
 	// gpuClusterExists and allGPUNodesModeLabeled gate rendering of the resource-allocation
 	// mode nodeSelector on operand DaemonSets; see applyModeSelector.
 	gpuClusterExists       bool
 	allGPUNodesModeLabeled bool
+
+	// pullSecrets are the image pull secrets attached to all operands during the current reconciliation
+	pullSecrets []string
+
+	// unavailableAPIs records the kinds of optional integrations (e.g. ServiceMonitor) whose
+	// APIs were not served during the current reconciliation and were therefore skipped.
+	unavailableAPIs map[string]bool
+
+	// missingKataRuntimeClasses records the Kata RuntimeClasses expected to pre-exist that were
+	// not found during the current reconciliation
+	missingKataRuntimeClasses map[string]bool
+
+	// configMapGenerations maps the ConfigMaps rendered as immutable generations during the current
+	// reconciliation to the name of their generation
+	configMapGenerations map[string]string
+
+	// missingReferences records the ConfigMaps and Secrets referenced by the ClusterPolicy that were not found
+	// during the current reconciliation
+	missingReferences []clusterPolicyReference
+
+	// interferenceScannedAt is the time of the last scan of the sources of interference with the operands
+	interferenceScannedAt time.Time
+
+	// podSecurityRejections records, per DaemonSet, why PodSecurity admission rejects its pods during the
+	// current reconciliation
+	podSecurityRejections map[string]string
+
+	// apiReader reads objects outside of the namespaces cached by the manager
+	apiReader client.Reader
+	// nodes is the snapshot of the GPU nodes, the inventory maintained from the Node informer
+	nodes nodeSnapshot
+
+	// imageResolver resolves operand image tags to digests when digest pinning is enabled
+	imageResolver image.Resolver
+	// readiness debounces the readiness changes of the operands reported in the ClusterPolicy
+	readiness *readinessDebouncer
+
+	// stateTimeouts bounds the duration of the reconciliation of each state, keyed by state name
+	stateTimeouts map[string]time.Duration
+	// operatorConfig holds the operator configuration applied at runtime from the operator ConfigMap
+	operatorConfig *operatorconfig.Store
+
+	// introspection records the effective configuration of the rendered DaemonSets, when enabled
+	introspection *introspection.Store
+
+	// recorder records the events of the ClusterPolicy
+	recorder events.EventRecorder
+	// safeMode is set while the operand rollouts are stopped by the safe mode, see reconcileSafeMode
+	safeMode bool
+	// restConfig is the client configuration of the cluster of the GPU nodes, which is a hosted cluster when
+	// the operator runs in its management cluster
+	restConfig *rest.Config
+}
+
+// kubeConfig returns the client configuration of the cluster of the GPU nodes
+func (n ClusterPolicyController) kubeConfig() *rest.Config {
+	if n.restConfig != nil {
+		return n.restConfig
+	}
+	return config.GetConfigOrDie()
 }
 
 func addState(n *ClusterPolicyController, path string) {
 	// TODO check for path
 	res, ctrl := addResourcesControls(n, path)
-
-	n.controls = append(n.controls, ctrl)
-	n.resources = append(n.resources, res)
-	n.stateNames = append(n.stateNames, filepath.Base(path))
+	n.appendState(stateDefinition{name: filepath.Base(path), resources: res, syncer: ctrl})
 }
 
 // OpenshiftVersion fetches OCP version
-func OpenshiftVersion(ctx context.Context) (string, error) {
-	cfg := config.GetConfigOrDie()
+func OpenshiftVersion(ctx context.Context, cfg *rest.Config) (string, error) {
 	client, err := configv1.NewForConfig(cfg)
 	if err != nil {
 		return "", err
@@ -270,8 +433,7 @@ func OpenshiftVersion(ctx context.Context) (string, error) {
 }
 
 // KubernetesVersion fetches the Kubernetes API server version
-func KubernetesVersion() (string, error) {
-	cfg := config.GetConfigOrDie()
+func KubernetesVersion(cfg *rest.Config) (string, error) {
 	discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
 	if err != nil {
 		return "", fmt.Errorf("error building discovery client: %v", err)
@@ -286,8 +448,7 @@ func KubernetesVersion() (string, error) {
 }
 
 // GetClusterWideProxy returns cluster wide proxy object setup in OCP
-func GetClusterWideProxy(ctx context.Context) (*apiconfigv1.Proxy, error) {
-	cfg := config.GetConfigOrDie()
+func GetClusterWideProxy(ctx context.Context, cfg *rest.Config) (*apiconfigv1.Proxy, error) {
 	client, err := configv1.NewForConfig(cfg)
 	if err != nil {
 		return nil, err
@@ -300,24 +461,9 @@ func GetClusterWideProxy(ctx context.Context) (*apiconfigv1.Proxy, error) {
 	return proxy, nil
 }
 
-func hasMIGConfigLabel(labels map[string]string) bool {
-	if _, ok := labels[migConfigLabelKey]; ok {
-		if labels[migConfigLabelKey] != "" {
-			return true
-		}
-	}
-	return false
-}
-
 // hasCommonGPULabel returns true if common Nvidia GPU label exists among provided node labels
 func hasCommonGPULabel(labels map[string]string) bool {
-	if _, ok := labels[commonGPULabelKey]; ok {
-		if labels[commonGPULabelKey] == commonGPULabelValue {
-			// node is already labelled with common label
-			return true
-		}
-	}
-	return false
+	return gpulabels.HasGPU(labels)
 }
 
 // hasGPULabels return true if node labels contain Nvidia GPU labels
@@ -375,17 +521,16 @@ func hasMIGManagerLabel(labels map[string]string) bool {
 }
 
 func hasOperandsDisabled(labels map[string]string) bool {
-	if value, ok := labels[commonOperandsLabelKey]; ok {
-		if value == "false" {
-			return true
-		}
-	}
-	return false
+	return gpulabels.OperandsDisabled(labels)
 }
 
 func isValidWorkloadConfig(workloadConfig string) bool {
-	_, ok := gpuStateLabels[workloadConfig]
-	return ok
+	return gpulabels.IsValidWorkloadConfig(workloadConfig)
+}
+
+// isWindowsNode returns true if the node runs Windows
+func isWindowsNode(labels map[string]string) bool {
+	return labels[nodeOSLabelKey] == nodeOSWindows
 }
 
 // getWorkloadConfig returns the GPU workload configured for the node.
@@ -416,7 +561,8 @@ func getEffectiveStateLabels(config, mode string) map[string]string {
 		return labels
 	}
 
-	// update labels for the sandbox modes for passthrough
+	// update labels for the sandbox modes for passthrough, on a copy as the mode differs between the nodes
+	labels = maps.Clone(labels)
 	switch gpuv1.SandboxWorkloadsMode(mode) {
 	case gpuv1.Kata:
 		delete(labels, kubevirtDevicePluginDeployLabelKey)
@@ -428,6 +574,37 @@ func getEffectiveStateLabels(config, mode string) map[string]string {
 	return labels
 }
 
+// hasCCCapableCPU returns true if NFD reports that the host CPU supports AMD SEV-SNP or Intel TDX
+func hasCCCapableCPU(labels map[string]string) bool {
+	return labels[nfdSEVSNPEnabledLabelKey] == "true" || labels[nfdTDXEnabledLabelKey] == "true"
+}
+
+// getStateLabels returns the effective state labels for the node. The cc-manager deploy label is
+// left out on nodes without a CC-capable CPU when cc-manager is restricted to CC-capable nodes, and
+// the deploy labels of the operands relying on datacenter GPU features on nodes with consumer GPUs.
+func (w *gpuWorkloadConfiguration) getStateLabels(labels map[string]string) map[string]string {
+	effective := getEffectiveStateLabels(w.config, w.sandboxMode)
+	excluded := map[string]bool{}
+	if _, ok := effective[ccManagerDeployLabelKey]; ok && w.ccCapableNodesOnly && !hasCCCapableCPU(labels) {
+		excluded[ccManagerDeployLabelKey] = true
+	}
+	if w.consumerGPU {
+		for key := range datacenterOnlyStateLabelKeys {
+			excluded[key] = true
+		}
+	}
+	if len(excluded) == 0 {
+		return effective
+	}
+	stateLabels := make(map[string]string, len(effective))
+	for key, value := range effective {
+		if !excluded[key] {
+			stateLabels[key] = value
+		}
+	}
+	return stateLabels
+}
+
 // removeAllGPUStateLabels removes all gpuStateLabels from the provided map of node labels.
 // removeAllGPUStateLabels returns true if the labels map has been modified.
 func removeAllGPUStateLabels(labels map[string]string) bool {
@@ -480,7 +657,7 @@ func (w *gpuWorkloadConfiguration) updateGPUStateLabels(labels map[string]string
 // For vm-passthrough, uses kata-device-plugin when mode is "kata", otherwise sandbox-device-plugin.
 func (w *gpuWorkloadConfiguration) addGPUStateLabels(labels map[string]string) bool {
 	modified := false
-	effective := getEffectiveStateLabels(w.config, w.sandboxMode)
+	effective := w.getStateLabels(labels)
 	for key, value := range effective {
 		if v, ok := labels[key]; !ok || v == "" {
 			w.log.Info("Setting node label", "NodeName", w.node, "Label", key, "Value", value)
@@ -488,7 +665,7 @@ func (w *gpuWorkloadConfiguration) addGPUStateLabels(labels map[string]string) b
 			modified = true
 		}
 	}
-	if w.config == gpuWorkloadConfigContainer && hasMIGCapableGPU(labels) && !hasMIGManagerLabel(labels) {
+	if w.config == gpuWorkloadConfigContainer && !w.consumerGPU && hasMIGCapableGPU(labels) && !hasMIGManagerLabel(labels) {
 		w.log.Info("Setting node label", "NodeName", w.node, "Label", migManagerLabelKey, "Value", migManagerLabelValue)
 		labels[migManagerLabelKey] = migManagerLabelValue
 		modified = true
@@ -500,7 +677,7 @@ func (w *gpuWorkloadConfiguration) addGPUStateLabels(labels map[string]string) b
 // Uses effective labels for (config, mode) so vm-passthrough+kata keeps kata-device-plugin, not sandbox-device-plugin.
 func (w *gpuWorkloadConfiguration) removeGPUStateLabels(labels map[string]string) bool {
 	modified := false
-	effective := getEffectiveStateLabels(w.config, w.sandboxMode)
+	effective := w.getStateLabels(labels)
 	// All keys ever used as state labels, including the DRA stack's: keys not in the
 	// effective set are deleted, which also sweeps DRA leftovers off device-plugin nodes.
 	allStateKeys := clusterPolicyStateLabelKeys()
@@ -513,7 +690,7 @@ func (w *gpuWorkloadConfiguration) removeGPUStateLabels(labels map[string]string
 		}
 		// mig-manager is never in the effective set: addGPUStateLabels manages it for
 		// the container config per MIG capability, so it must not be swept there.
-		if key == migManagerLabelKey && w.config == gpuWorkloadConfigContainer {
+		if key == migManagerLabelKey && w.config == gpuWorkloadConfigContainer && !w.consumerGPU {
 			continue
 		}
 		if _, keep := effective[key]; !keep {
@@ -525,21 +702,31 @@ func (w *gpuWorkloadConfiguration) removeGPUStateLabels(labels map[string]string
 	return modified
 }
 
-// discoverGPUNodes reads all cluster nodes and returns whether any NFD labels are present
+// discoverGPUNodes reads the cluster nodes, from the node inventory once synced, and returns whether any NFD labels are present
 // and how many GPU nodes (with nvidia.com/gpu.present=true) exist. It also records in
 // n.allGPUNodesModeLabeled whether every GPU node carries the resource-allocation mode label.
 // Node label writes are handled by NodeLabelingReconciler.
 func (n *ClusterPolicyController) discoverGPUNodes() (bool, int, error) {
-	ctx := n.ctx
-	list := &corev1.NodeList{}
-	if err := n.client.List(ctx, list); err != nil {
-		return false, 0, fmt.Errorf("unable to list nodes: %w", err)
+	clusterHasNFDLabels := false
+	var nodes []corev1.Node
+	if n.nodesSynced() {
+		// the inventory only holds the GPU nodes, along with whether any node is labeled by NFD
+		clusterHasNFDLabels = n.nodes.hasNFDLabels()
+		nodes = n.nodes.list(nil)
+	} else {
+		list := &corev1.NodeList{}
+		if err := n.client.List(n.ctx, list); err != nil {
+			return false, 0, fmt.Errorf("unable to list nodes: %w", err)
+		}
+		nodes = list.Items
 	}
 
-	clusterHasNFDLabels := false
 	gpuNodesTotal := 0
 	n.allGPUNodesModeLabeled = true
-	for _, node := range list.Items {
+	n.consumerGPUNodes = nil
+	n.nodeSandboxModes = map[string]bool{}
+	n.gpuNodeArchs = map[string]bool{}
+	for _, node := range nodes {
 		labels := node.GetLabels()
 		if !clusterHasNFDLabels {
 			clusterHasNFDLabels = hasNFDLabels(labels)
@@ -551,6 +738,15 @@ func (n *ClusterPolicyController) discoverGPUNodes() (bool, int, error) {
 		if labels[consts.GPUAllocationModeLabelKey] == "" {
 			n.allGPUNodesModeLabeled = false
 		}
+		if labels[consts.ConsumerGPULabel] == "true" {
+			n.consumerGPUNodes = append(n.consumerGPUNodes, node.Name)
+		}
+		if mode := labels[gpuSandboxModeLabelKey]; gpulabels.IsValidSandboxMode(mode) {
+			n.nodeSandboxModes[mode] = true
+		}
+		if arch := labels[corev1.LabelArchStable]; arch != "" {
+			n.gpuNodeArchs[arch] = true
+		}
 		if n.ocpDriverToolkit.requested {
 			rhcosVersion, ok := labels[nfdOSTreeVersionLabelKey]
 			if ok {
@@ -587,21 +783,15 @@ func getRuntimeString(node corev1.Node) (gpuv1.Runtime, error) {
 }
 
 func (n *ClusterPolicyController) getGPUNodeOSInfo() (string, string, error) {
-	ctx := n.ctx
-	opts := []client.ListOption{
-		client.MatchingLabels(map[string]string{commonGPULabelKey: commonGPULabelValue}),
-		client.Limit(1),
-	}
-	nodeList := &corev1.NodeList{}
-	err := n.client.List(ctx, nodeList, opts...)
+	nodes, err := n.listGPUNodes(nil)
 	if err != nil {
 		return "", "", fmt.Errorf("unable to list nodes with GPU present: %w", err)
 	}
-	if len(nodeList.Items) == 0 {
+	if len(nodes) == 0 {
 		return "", "", fmt.Errorf("no nodes found with GPU present")
 	}
 
-	labels := nodeList.Items[0].Labels
+	labels := nodes[0].Labels
 	osName, ok := labels[nfdOSReleaseIDLabelKey]
 	if !ok {
 		return "", "", fmt.Errorf("unable to retrieve OS name from label %s", nfdOSReleaseIDLabelKey)
@@ -620,7 +810,9 @@ func (n *ClusterPolicyController) getGPUNodeOSInfo() (string, string, error) {
 	return osName, osTag, nil
 }
 
-func (n *ClusterPolicyController) setPodSecurityLabelsForNamespace() error {
+// setPodSecurityLabelsForNamespace sets the PodSecurity labels of the given modes of the operator namespace
+// to the privileged level
+func (n *ClusterPolicyController) setPodSecurityLabelsForNamespace(modes []string) error {
 	ctx := n.ctx
 	namespaceName := clusterPolicyCtrl.operatorNamespace
 
@@ -650,7 +842,7 @@ func (n *ClusterPolicyController) setPodSecurityLabelsForNamespace() error {
 		ns.Labels = make(map[string]string)
 		modified = true
 	}
-	for _, mode := range podSecurityModes {
+	for _, mode := range modes {
 		key := podSecurityLabelPrefix + mode
 		if val, ok := ns.Labels[key]; !ok || (val != podSecurityLevelPrivileged) {
 			ns.Labels[key] = podSecurityLevelPrivileged
@@ -735,36 +927,39 @@ func (n *ClusterPolicyController) ocpEnsureNamespaceMonitoring() error {
 // containerd -- if >=1 node is configured with containerd, set
 // clusterPolicyController.runtime = containerd
 func (n *ClusterPolicyController) getRuntime() error {
-	ctx := n.ctx
 	// assume crio for openshift clusters
 	if n.openshift != "" {
 		n.runtime = gpuv1.CRIO
 		return nil
 	}
 
-	opts := []client.ListOption{
-		client.MatchingLabels{commonGPULabelKey: "true"},
-	}
-	list := &corev1.NodeList{}
-	err := n.client.List(ctx, list, opts...)
+	nodes, err := n.listGPUNodes(nil)
 	if err != nil {
 		return fmt.Errorf("unable to list nodes prior to checking container runtime: %v", err)
 	}
 
 	var runtime gpuv1.Runtime
-	for _, node := range list.Items {
+	runtimes := make(map[gpuv1.Runtime]bool)
+	for _, node := range nodes {
 		rt, err := getRuntimeString(node)
 		if err != nil {
 			n.logger.Info(fmt.Sprintf("Unable to get runtime info for node %s: %v", node.Name, err))
 			continue
 		}
-		runtime = rt
-		if runtime == gpuv1.Containerd {
+		runtimes[rt] = true
+		if runtime != gpuv1.Containerd {
 			// default to containerd if >=1 node running containerd
-			break
+			runtime = rt
 		}
 	}
 
+	// the DaemonSets depending on the runtime are deployed once per runtime in mixed-runtime clusters
+	n.runtimes = nil
+	if len(runtimes) > 1 {
+		n.runtimes = slices.Sorted(maps.Keys(runtimes))
+		n.logger.Info("Multiple container runtimes found on the GPU nodes", "runtimes", n.runtimes)
+	}
+
 	if runtime.String() == "" {
 		n.logger.Info("Unable to get runtime info from the cluster, defaulting to containerd")
 		runtime = gpuv1.Containerd
@@ -780,17 +975,37 @@ func (n *ClusterPolicyController) init(ctx context.Context, reconciler *ClusterP
 	n.logger = reconciler.Log
 	n.client = reconciler.Client
 	n.scheme = reconciler.Scheme
+	n.restConfig = reconciler.Config
+	n.unavailableAPIs = map[string]bool{}
+	n.missingKataRuntimeClasses = map[string]bool{}
+	n.configMapGenerations = map[string]string{}
+	n.podSecurityRejections = map[string]string{}
+	n.stateTimeouts = reconciler.OperatorConfig.StateTimeouts(reconciler.ReconcileOptions.StateTimeouts)
+	n.operatorConfig = reconciler.OperatorConfig
+	// an unset cdi.enabled follows the CDIDefault feature gate, the ClusterPolicy is only written through its status
+	if clusterPolicy.Spec.CDI.Enabled == nil && !n.operatorConfig.FeatureEnabled(featuregate.CDIDefault) {
+		clusterPolicy.Spec.CDI.Enabled = ptr.To(false)
+	}
+	n.introspection = reconciler.Introspection
+	n.recorder = reconciler.recorder
+	n.nodeMutationPolicy = reconciler.NodeMutationPolicy
+	if n.imageResolver == nil {
+		n.imageResolver = image.NewCachingResolver(image.NewRegistryResolver(image.SharedRegistryClient()), image.DefaultResolveCacheTTL)
+	}
+	if n.readiness == nil {
+		n.readiness = newReadinessDebouncer()
+	}
 
 	if len(n.controls) == 0 {
 		clusterPolicyCtrl.operatorNamespace = reconciler.Namespace
 
-		version, err := OpenshiftVersion(ctx)
+		version, err := OpenshiftVersion(ctx, n.kubeConfig())
 		if err != nil && !apierrors.IsNotFound(err) {
 			return err
 		}
 		n.openshift = version
 
-		k8sVersion, err := KubernetesVersion()
+		k8sVersion, err := KubernetesVersion(n.kubeConfig())
 		if err != nil {
 			return err
 		}
@@ -805,28 +1020,16 @@ func (n *ClusterPolicyController) init(ctx context.Context, reconciler *ClusterP
 			return fmt.Errorf("error validating clusterpolicy: %w", err)
 		}
 
-		addState(n, "/opt/gpu-operator/pre-requisites")
-		addState(n, "/opt/gpu-operator/state-operator-metrics")
-		addState(n, "/opt/gpu-operator/state-driver")
-		addState(n, "/opt/gpu-operator/state-container-toolkit")
-		addState(n, "/opt/gpu-operator/state-operator-validation")
-		addState(n, "/opt/gpu-operator/state-device-plugin")
-		addState(n, "/opt/gpu-operator/state-mps-control-daemon")
-		addState(n, "/opt/gpu-operator/state-dcgm")
-		addState(n, "/opt/gpu-operator/state-dcgm-exporter")
-		addState(n, "/opt/gpu-operator/gpu-feature-discovery")
-		addState(n, "/opt/gpu-operator/state-mig-manager")
-		addState(n, "/opt/gpu-operator/state-node-status-exporter")
-		// add sandbox workload states
-		addState(n, "/opt/gpu-operator/state-vgpu-manager")
-		addState(n, "/opt/gpu-operator/state-vgpu-device-manager")
-		addState(n, "/opt/gpu-operator/state-sandbox-validation")
-		addState(n, "/opt/gpu-operator/state-vfio-manager")
-		addState(n, "/opt/gpu-operator/state-sandbox-device-plugin")
-		addState(n, "/opt/gpu-operator/state-kata-device-plugin")
-		addState(n, "/opt/gpu-operator/state-kata-manager")
-		addState(n, "/opt/gpu-operator/state-cc-manager")
+		assetsDir := reconciler.AssetsDir
+		if assetsDir == "" {
+			assetsDir = assets.DefaultDir
+		}
+		if err := n.loadStates(assetsDir); err != nil {
+			return err
+		}
 	}
+	n.stateResults = make(map[string]gpuv1.State, len(n.stateNames))
+	n.blockedStates = make(map[string][]string)
 
 	if clusterPolicy.Spec.SandboxWorkloads.IsEnabled() {
 		n.sandboxEnabled = true
@@ -864,12 +1067,34 @@ func (n *ClusterPolicyController) init(ctx context.Context, reconciler *ClusterP
 	if clusterPolicy.Spec.PSA.IsEnabled() {
 		// label namespace with Pod Security Admission levels
 		n.logger.Info("Pod Security is enabled. Adding labels to GPU Operator namespace", "namespace", n.operatorNamespace)
-		err := n.setPodSecurityLabelsForNamespace()
+		err := n.setPodSecurityLabelsForNamespace(podSecurityModes)
 		if err != nil {
 			return err
 		}
 		n.logger.Info("Pod Security Admission labels added to GPU Operator namespace", "namespace", n.operatorNamespace)
+	} else if clusterPolicy.Spec.PSA.IsManageNamespaceLabelsEnabled() {
+		// only enforcement matters for the operand pods to be admitted
+		err := n.setPodSecurityLabelsForNamespace([]string{podSecurityModeEnforce})
+		if err != nil {
+			return err
+		}
+	}
+
+	// the nodes of the scoped ClusterPolicies are labeled with their owner by NodeLabelingReconciler
+	clusterPolicies := &gpuv1.ClusterPolicyList{}
+	if err := n.client.List(ctx, clusterPolicies); err != nil {
+		return fmt.Errorf("unable to list ClusterPolicies: %w", err)
+	}
+	// the node selector of an invalid scoped ClusterPolicy, or overlapping with an older one, is not honored
+	scopedPolicies, skippedScopes, err := clusterpolicyutil.HonoredScopedPolicies(ctx, n.client, clusterPolicies.Items)
+	if err != nil {
+		return err
+	}
+	for _, skipped := range skippedScopes {
+		n.logger.Info("WARNING: ignoring scoped ClusterPolicy", "name", skipped.Name, "reason", skipped.Reason.Error())
 	}
+	n.scopedPolicies = scopedPolicies
+	n.scopeStates = make(map[string]gpuv1.State, len(n.scopedPolicies))
 
 	// discover GPU nodes (labels are written by NodeLabelingReconciler)
 	hasNFDLabels, gpuNodeCount, err := n.discoverGPUNodes()
@@ -878,6 +1103,9 @@ func (n *ClusterPolicyController) init(ctx context.Context, reconciler *ClusterP
 	}
 	n.hasGPUNodes = gpuNodeCount != 0
 	n.hasNFDLabels = hasNFDLabels
+	if err := n.validateConsumerGPUNodes(); err != nil {
+		return err
+	}
 
 	gpuClusters := &nvidiav1alpha1.GPUClusterList{}
 	if err := n.client.List(ctx, gpuClusters); err != nil {
@@ -885,6 +1113,11 @@ func (n *ClusterPolicyController) init(ctx context.Context, reconciler *ClusterP
 	}
 	n.gpuClusterExists = len(gpuClusters.Items) > 0
 
+	n.pullSecrets, err = resolveImagePullSecrets(ctx, n.client, n.logger, n.operatorNamespace, clusterPolicy.Spec.ImagePullSecrets)
+	if err != nil {
+		return err
+	}
+
 	if n.hasGPUNodes {
 		gpuNodeOSRelease, gpuNodeOSTag, err := n.getGPUNodeOSInfo()
 		if err != nil {
@@ -912,6 +1145,14 @@ func (n *ClusterPolicyController) init(ctx context.Context, reconciler *ClusterP
 			return err
 		}
 		n.kernelVersionMap = kernelVersionMap
+
+		n.preStagedKernelVersions = nil
+		if n.singleton.Spec.Driver.IsOSUpgradePreStagingEnabled() {
+			if err := n.preStageOSUpgradeKernels(); err != nil {
+				n.logger.Info("Unable to obtain the kernel versions of the upcoming OS upgrades", "err", err)
+				return err
+			}
+		}
 	}
 
 	if n.openshift != "" {
@@ -950,6 +1191,12 @@ func (n *ClusterPolicyController) initOCPParams() error {
 		n.logger.Info("OpenShift Driver Toolkit",
 			"enabled", n.ocpDriverToolkit.enabled)
 
+		if n.ocpDriverToolkit.enabled && n.singleton.Spec.Driver.IsOSUpgradePreStagingEnabled() {
+			if err := n.preStageOSUpgradeRHCOSVersions(); err != nil {
+				return err
+			}
+		}
+
 		if hasImageStream {
 			n.operatorMetrics.openshiftDriverToolkitIsMissing.Set(0)
 		} else {
@@ -969,8 +1216,6 @@ func (n *ClusterPolicyController) initOCPParams() error {
 }
 
 func (n *ClusterPolicyController) step() (gpuv1.State, error) {
-	result := gpuv1.Ready
-
 	// Skip driver daemonset states if NVIDIADriver CRD is enabled
 	// TODO:
 	//   - Properly clean up any k8s object associated with 'state-driver'
@@ -981,6 +1226,7 @@ func (n *ClusterPolicyController) step() (gpuv1.State, error) {
 	if (n.stateNames[n.idx] == "state-driver" || n.stateNames[n.idx] == "state-vgpu-manager") &&
 		n.singleton.Spec.Driver.UseNvidiaDriverCRDType() {
 		n.logger.Info("NVIDIADriver CRD is enabled, cleaning up all NVIDIA driver daemonsets owned by ClusterPolicy")
+		n.recordStateResult(n.stateNames[n.idx], gpuv1.Disabled, nil)
 		n.idx++
 		// Cleanup all driver daemonsets owned by ClusterPolicy while keeping the
 		// running driver pods available until NVIDIADriver rolls replacements.
@@ -991,17 +1237,38 @@ func (n *ClusterPolicyController) step() (gpuv1.State, error) {
 		return gpuv1.Disabled, nil
 	}
 
-	for _, fs := range n.controls[n.idx] {
-		stat, err := fs(*n)
-		if err != nil {
-			return stat, err
-		}
-		// successfully deployed resource, now check if its ready
-		if stat != gpuv1.Ready {
-			// mark overall status of this component as not-ready and continue with other resources, while this becomes ready
-			result = stat
+	// skip the state while its prerequisites are not ready, instead of deploying operands bound to fail
+	if prerequisites := n.notReadyPrerequisites(n.stateNames[n.idx]); len(prerequisites) > 0 && n.isStateEnabled(n.stateNames[n.idx]) {
+		n.logger.Info("Skipping state, prerequisites not ready", "state", n.stateNames[n.idx], "prerequisites", prerequisites)
+		n.recordStateResult(n.stateNames[n.idx], gpuv1.NotReady, prerequisites)
+		n.idx++
+		return gpuv1.NotReady, nil
+	}
+
+	// bound the reconciliation of the state, if a timeout is configured for it
+	stateCtrl := *n
+	stateCtrl.ctx = audit.WithReason(n.ctx, n.stateNames[n.idx])
+	ctx, span := tracing.Start(stateCtrl.ctx, "state "+n.stateNames[n.idx], tracing.StateKey.String(n.stateNames[n.idx]))
+	stateCtrl.ctx = ctx
+	timeout := n.stateTimeouts[n.stateNames[n.idx]]
+	if timeout > 0 {
+		ctx, cancel := context.WithTimeout(stateCtrl.ctx, timeout)
+		defer cancel()
+		stateCtrl.ctx = ctx
+	}
+
+	result, err := n.controls[n.idx].sync(stateCtrl)
+	span.SetAttributes(tracing.ResultKey.String(string(result)))
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) && timeout > 0 {
+			err = fmt.Errorf("state %s did not complete within %s: %w", n.stateNames[n.idx], timeout, err)
 		}
+		tracing.End(span, err)
+		return result, err
 	}
+	tracing.End(span, nil)
+
+	n.recordStateResult(n.stateNames[n.idx], result, nil)
 
 	// move to next state
 	n.idx++
@@ -1018,20 +1285,64 @@ func (n ClusterPolicyController) last() bool {
 	return n.idx == len(n.controls)
 }
 
+// runStates reconciles the remaining states in order and returns the overall state, along with the states
+// which are not ready. It stops at the first state failing to reconcile, which is left as the current state.
+func (n *ClusterPolicyController) runStates() (gpuv1.State, []string, error) {
+	overallStatus := gpuv1.Ready
+	statesNotReady := []string{}
+	for !n.last() {
+		status, err := n.step()
+		if err != nil {
+			return gpuv1.NotReady, statesNotReady, err
+		}
+
+		if status == gpuv1.NotReady {
+			overallStatus = gpuv1.NotReady
+			statesNotReady = append(statesNotReady, n.stateNames[n.idx-1])
+		}
+		n.logger.Info("ClusterPolicy step completed",
+			"state:", n.stateNames[n.idx-1],
+			"status", status)
+	}
+	return overallStatus, statesNotReady, nil
+}
+
+// isSandboxModeInUse returns true if sandbox workloads are enabled and the given sandbox mode is the
+// sandboxWorkloads.mode of the ClusterPolicy or is selected on a GPU node with the sandbox mode label.
+// The operands of all the sandbox modes in use are rendered, each scheduled on the nodes of its mode.
+func (n ClusterPolicyController) isSandboxModeInUse(mode gpuv1.SandboxWorkloadsMode) bool {
+	return n.sandboxEnabled &&
+		(n.singleton.Spec.SandboxWorkloads.Mode == string(mode) || n.nodeSandboxModes[string(mode)])
+}
+
+// isKataManaged returns true if sandbox workloads run in Kata mode on some nodes and are managed by the operator
+func (n ClusterPolicyController) isKataManaged() bool {
+	return n.isSandboxModeInUse(gpuv1.Kata) && n.operatorConfig.FeatureEnabled(featuregate.KataManagement)
+}
+
 func (n ClusterPolicyController) isStateEnabled(stateName string) bool {
 	clusterPolicySpec := &n.singleton.Spec
 
+	if clusterPolicySpec.Devel.IsMockGPUsEnabled() {
+		// the mock GPUs stand in for the GPU nodes of development clusters, all other operands are disabled
+		return stateName == "state-mock-gpus" || stateName == "state-operator-metrics"
+	}
+
 	switch stateName {
 	case "pre-requisites":
 		return !clusterPolicySpec.CDI.IsNRIPluginEnabled()
+	case "state-download-cache":
+		return clusterPolicySpec.Driver.IsEnabled() && clusterPolicySpec.DownloadCache.IsEnabled()
 	case "state-driver":
 		return clusterPolicySpec.Driver.IsEnabled()
+	case "state-nvlink-fabric":
+		return clusterPolicySpec.NVLinkFabric.IsEnabled()
 	case "state-container-toolkit":
 		return clusterPolicySpec.Toolkit.IsEnabled()
 	case "state-device-plugin":
 		return clusterPolicySpec.DevicePlugin.IsEnabled()
 	case "state-mps-control-daemon":
-		return clusterPolicySpec.DevicePlugin.IsEnabled()
+		return clusterPolicySpec.DevicePlugin.IsEnabled() && clusterPolicySpec.MPSControlDaemon.IsEnabled()
 	case "state-dcgm":
 		return clusterPolicySpec.DCGM.IsEnabled()
 	case "state-dcgm-exporter":
@@ -1042,10 +1353,20 @@ func (n ClusterPolicyController) isStateEnabled(stateName string) bool {
 		return clusterPolicySpec.GPUFeatureDiscovery.IsEnabled()
 	case "state-node-status-exporter":
 		return clusterPolicySpec.NodeStatusExporter.IsEnabled()
+	case "state-gpu-health-check":
+		return clusterPolicySpec.HealthCheck.IsEnabled()
+	case "state-image-prepull":
+		return clusterPolicySpec.ImagePrePull.IsEnabled()
+	case "state-node-feature-discovery":
+		return clusterPolicySpec.NodeFeatureDiscovery.IsManaged()
+	case "state-windows-device-plugin":
+		return clusterPolicySpec.Windows.IsDevicePluginEnabled()
+	case "state-windows-gpu-feature-discovery":
+		return clusterPolicySpec.Windows.IsGPUFeatureDiscoveryEnabled()
 	case "state-sandbox-device-plugin":
-		return n.sandboxEnabled && clusterPolicySpec.SandboxDevicePlugin.IsEnabled() && clusterPolicySpec.SandboxWorkloads.Mode == string(gpuv1.KubeVirt)
+		return n.isSandboxModeInUse(gpuv1.KubeVirt) && clusterPolicySpec.SandboxDevicePlugin.IsEnabled()
 	case "state-kata-device-plugin":
-		return n.sandboxEnabled && clusterPolicySpec.KataSandboxDevicePlugin.IsEnabled() && clusterPolicySpec.SandboxWorkloads.Mode == string(gpuv1.Kata)
+		return n.isKataManaged() && clusterPolicySpec.KataSandboxDevicePlugin.IsEnabled()
 	case "state-kata-manager":
 		// always return false for kata manager as it stands deprecated
 		// this means that any changes to the cluster policy CRD wrt kata manager will not be honored
@@ -1057,13 +1378,15 @@ func (n ClusterPolicyController) isStateEnabled(stateName string) bool {
 	case "state-vgpu-manager":
 		return n.sandboxEnabled && clusterPolicySpec.VGPUManager.IsEnabled()
 	case "state-cc-manager":
-		return n.sandboxEnabled && clusterPolicySpec.CCManager.IsEnabled() && clusterPolicySpec.SandboxWorkloads.Mode == string(gpuv1.Kata)
+		return n.isKataManaged() && clusterPolicySpec.CCManager.IsEnabled()
 	case "state-sandbox-validation":
 		return n.sandboxEnabled
 	case "state-operator-validation":
 		return true
 	case "state-operator-metrics":
 		return true
+	case "state-mock-gpus":
+		return clusterPolicySpec.Devel.IsMockGPUsEnabled()
 	default:
 		n.logger.Error(nil, "invalid state passed", "stateName", stateName)
 		return false
@@ -1079,5 +1402,87 @@ func validateClusterPolicySpec(spec *gpuv1.ClusterPolicySpec) error {
 		return fmt.Errorf("the NRI Plugin cannot be enabled when the Container Toolkit is disabled")
 	}
 
+	if spec.VGPUManager.KernelModuleConfig.HasModuleParameters() {
+		return fmt.Errorf("the vGPU Manager only supports kernel module parameters provided in a ConfigMap")
+	}
+
+	if err := spec.DCGMExporter.ValidatePodAttribution(spec.HostPaths.KubeletRootDir); err != nil {
+		return err
+	}
+
+	if err := spec.VGPUDeviceManager.ValidateProfiles(); err != nil {
+		return err
+	}
+
+	if err := validateMPSSharing(spec); err != nil {
+		return err
+	}
+
+	if err := validateTenantNamespaces(spec); err != nil {
+		return err
+	}
+
+	if err := spec.Validator.ValidateImages(); err != nil {
+		return err
+	}
+
+	if err := spec.ValidateArchImages(); err != nil {
+		return err
+	}
+
+	if spec.Windows.IsEnabled() {
+		if spec.SandboxWorkloads.IsEnabled() {
+			return fmt.Errorf("Windows GPU nodes cannot be enabled together with sandbox workloads")
+		}
+		if spec.Windows.IsGPUFeatureDiscoveryEnabled() && !spec.Windows.IsDevicePluginEnabled() {
+			return fmt.Errorf("the Windows GPU Feature Discovery cannot be enabled when the Windows device plugin is disabled")
+		}
+	}
+
 	return nil
 }
+
+// validateMPSSharing checks that the MPS sharing configuration of the device plugin can be served by the MPS
+// control daemon and does not target GPUs partitioned with MIG
+func validateMPSSharing(spec *gpuv1.ClusterPolicySpec) error {
+	if spec.DevicePlugin.MPS == nil || len(spec.DevicePlugin.MPS.Resources) == 0 {
+		return nil
+	}
+	if spec.DevicePlugin.TimeSlicing != nil && len(spec.DevicePlugin.TimeSlicing.Resources) > 0 {
+		return fmt.Errorf("the device plugin resources cannot be shared through both time-slicing and MPS")
+	}
+	for _, resource := range spec.DevicePlugin.MPS.Resources {
+		if strings.HasPrefix(resource.Name, nvidiav1alpha1.MIGResourcePrefix) {
+			return fmt.Errorf("the MIG devices of resource %s cannot be shared through MPS", resource.Name)
+		}
+	}
+	if !spec.MPSControlDaemon.IsEnabled() {
+		return fmt.Errorf("sharing resources through MPS requires the MPS control daemon")
+	}
+
+	var mpsNodeSelector map[string]string
+	if spec.MPSControlDaemon != nil {
+		mpsNodeSelector = spec.MPSControlDaemon.NodeSelector
+	}
+	for _, selector := range spec.MIG.ConfigSelectors {
+		if selector.Config == migConfigDisabledValue {
+			continue
+		}
+		if nodeSelectorsOverlap(mpsNodeSelector, selector.NodeSelector) {
+			return fmt.Errorf("the nodes selected for the MIG configuration %s may also share their GPUs through MPS, "+
+				"restrict the MPS control daemon to other nodes", selector.Config)
+		}
+	}
+	return nil
+}
+
+// nodeSelectorsOverlap returns true if a node can match both node selectors, which is the case unless they
+// require different values for the same label
+func nodeSelectorsOverlap(a, b map[string]string) bool {
+	for key, value := range a {
+		if other, ok := b[key]; ok && other != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,309 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// The load testing fixture below measures the node-scaling parts of a ClusterPolicy reconciliation
+// against a fake cluster of synthetic GPU nodes and operand pods. Run the benchmarks with:
+//
+//	go test ./controllers -run '^$' -bench BenchmarkStateManagerReconcile -benchmem
+//
+// API call counts are deterministic, and are checked against loadTestAPICallBudget both by the
+// benchmarks and by TestStateManagerAPICallBudget. Latency and allocations are checked against the
+// budget of the cluster size in loadTestBudgets by the benchmarks, and the allocations of the smallest
+// size by TestStateManagerAPICallBudget as well.
+
+const loadTestNamespace = "gpu-operator-load-test"
+
+// loadTestAPICallBudget is the maximum number of API calls of a steady-state reconciliation pass,
+// i.e. once nodes are labeled and operands are deployed. The budget does not depend on the number
+// of nodes: a steady-state reconciliation must not issue per-node requests. Creates are attempted
+// for every resource of a state and rejected when the resource already exists.
+var loadTestAPICallBudget = map[string]int{
+	"get":    5,
+	"list":   10,
+	"create": 10,
+	"update": 10,
+	"patch":  0,
+	"delete": 2,
+}
+
+// loadTestBudget is the latency and allocation budget of a steady-state reconciliation pass
+type loadTestBudget struct {
+	nsPerOp     time.Duration
+	allocsPerOp uint64
+}
+
+// loadTestBudgets holds the budgets per number of nodes. Allocations are deterministic and budgeted
+// about 50% above their measured value, so that an allocation per node per pass more is caught.
+// Latency depends on the machine, and is budgeted about 5 times above its value measured on a single
+// CPU, so that only regressions in complexity are caught, e.g. a quadratic pass over the nodes.
+var loadTestBudgets = map[int]loadTestBudget{
+	100:  {nsPerOp: 500 * time.Millisecond, allocsPerOp: 130_000},
+	1000: {nsPerOp: 2500 * time.Millisecond, allocsPerOp: 800_000},
+	5000: {nsPerOp: 12 * time.Second, allocsPerOp: 3_700_000},
+}
+
+// check returns an error describing the measures exceeding the budget
+func (b loadTestBudget) check(nsPerOp time.Duration, allocsPerOp uint64) error {
+	if nsPerOp > b.nsPerOp {
+		return fmt.Errorf("reconciliation pass took %s, exceeding the budget of %s", nsPerOp, b.nsPerOp)
+	}
+	if allocsPerOp > b.allocsPerOp {
+		return fmt.Errorf("reconciliation pass made %d allocations, exceeding the budget of %d", allocsPerOp, b.allocsPerOp)
+	}
+	return nil
+}
+
+// apiCallCounter counts the requests issued to the fake API server, per verb
+type apiCallCounter struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (c *apiCallCounter) inc(verb string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls[verb]++
+}
+
+func (c *apiCallCounter) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = make(map[string]int)
+}
+
+func (c *apiCallCounter) get(verb string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls[verb]
+}
+
+// checkBudget returns an error describing the verbs exceeding the budget, given the number of passes
+func (c *apiCallCounter) checkBudget(passes int) error {
+	for verb, budget := range loadTestAPICallBudget {
+		if calls := c.get(verb); calls > budget*passes {
+			return fmt.Errorf("%d %s calls over %d reconciliation passes exceed the budget of %d per pass", calls, verb, passes, budget)
+		}
+	}
+	return nil
+}
+
+func (c *apiCallCounter) funcs() interceptor.Funcs {
+	return interceptor.Funcs{
+		Get: func(ctx context.Context, cl client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			c.inc("get")
+			return cl.Get(ctx, key, obj, opts...)
+		},
+		List: func(ctx context.Context, cl client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			c.inc("list")
+			return cl.List(ctx, list, opts...)
+		},
+		Create: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			c.inc("create")
+			return cl.Create(ctx, obj, opts...)
+		},
+		Update: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			c.inc("update")
+			return cl.Update(ctx, obj, opts...)
+		},
+		Patch: func(ctx context.Context, cl client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			c.inc("patch")
+			return cl.Patch(ctx, obj, patch, opts...)
+		},
+		Delete: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			c.inc("delete")
+			return cl.Delete(ctx, obj, opts...)
+		},
+	}
+}
+
+// loadTestCluster is a fake cluster of synthetic GPU nodes, each running the operand pods
+type loadTestCluster struct {
+	ctrl    *ClusterPolicyController
+	nlc     *nodeLabelingController
+	counter *apiCallCounter
+}
+
+// newLoadTestCluster creates a fake cluster with the given number of GPU nodes. The sample
+// ClusterPolicy of the device-plugin tests is used, with the device-plugin state as the operand state.
+func newLoadTestCluster(tb testing.TB, nodes int) *loadTestCluster {
+	tb.Helper()
+
+	objects := make([]client.Object, 0, nodes*4)
+	for i := 0; i < nodes; i++ {
+		nodeName := fmt.Sprintf("gpu-node-%d", i)
+		labels := make(map[string]string, len(nfdLabels))
+		for k, v := range nfdLabels {
+			labels[k] = v
+		}
+		objects = append(objects, &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: nodeName, Labels: labels},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+			},
+		})
+		for _, app := range []string{containerToolkitAppLabelValue, devicePluginAppLabelValue, operatorValidatorAppLabelValue} {
+			objects = append(objects, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("%s-%d", app, i),
+					Namespace: loadTestNamespace,
+					Labels:    map[string]string{appLabelKey: app},
+				},
+				Spec: corev1.PodSpec{NodeName: nodeName},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+				},
+			})
+		}
+	}
+	cp := getDevicePluginTestInput("default")
+	cp.ResourceVersion = ""
+	objects = append(objects, cp)
+
+	counter := &apiCallCounter{calls: make(map[string]int)}
+	c := interceptor.NewClient(
+		fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objects...).WithStatusSubresource(cp).Build(),
+		counter.funcs(),
+	)
+
+	n := &ClusterPolicyController{
+		ctx:               context.Background(),
+		singleton:         cp,
+		client:            c,
+		logger:            logr.Discard(),
+		scheme:            scheme.Scheme,
+		operatorNamespace: loadTestNamespace,
+		operatorMetrics:   clusterPolicyController.operatorMetrics,
+		unavailableAPIs:   map[string]bool{},
+	}
+	addState(n, filepath.Join(cfg.root, devicePluginAssetsPath))
+
+	return &loadTestCluster{
+		ctrl:    n,
+		nlc:     &nodeLabelingController{client: c, namespace: loadTestNamespace, clusterPolicy: cp, logger: logr.Discard()},
+		counter: counter,
+	}
+}
+
+// reconcile runs one pass over the node-scaling parts of a ClusterPolicy reconciliation: node
+// labeling, GPU node discovery, the operand states and the per-node status reporting.
+func (c *loadTestCluster) reconcile() error {
+	ctx := c.ctrl.ctx
+	if _, err := c.nlc.labelGPUNodes(ctx); err != nil {
+		return fmt.Errorf("failed to label GPU nodes: %w", err)
+	}
+
+	hasNFDLabels, gpuNodes, err := c.ctrl.discoverGPUNodes()
+	if err != nil {
+		return err
+	}
+	c.ctrl.hasNFDLabels = hasNFDLabels
+	c.ctrl.hasGPUNodes = gpuNodes != 0
+	c.ctrl.gpuNodeOSRelease, c.ctrl.gpuNodeOSTag, err = c.ctrl.getGPUNodeOSInfo()
+	if err != nil {
+		return err
+	}
+
+	c.ctrl.idx = 0
+	for !c.ctrl.last() {
+		if _, err := c.ctrl.step(); err != nil {
+			return fmt.Errorf("failed to reconcile state %s: %w", c.ctrl.stateNames[c.ctrl.idx], err)
+		}
+	}
+
+	if _, err := c.ctrl.getValidationDomains(ctx); err != nil {
+		return err
+	}
+	if _, err := c.ctrl.getNodeOperandStatuses(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// TestStateManagerAPICallBudget verifies that a steady-state reconciliation stays within the API call budget
+func TestStateManagerAPICallBudget(t *testing.T) {
+	cluster := newLoadTestCluster(t, 100)
+	// the first pass labels the nodes and deploys the operands
+	require.NoError(t, cluster.reconcile())
+	require.Equal(t, 100, cluster.counter.get("patch"), "expected every node to be labeled once")
+
+	cluster.counter.reset()
+	require.NoError(t, cluster.reconcile())
+	require.NoError(t, cluster.counter.checkBudget(1))
+
+	allocs := testing.AllocsPerRun(1, func() {
+		require.NoError(t, cluster.reconcile())
+	})
+	require.LessOrEqual(t, allocs, float64(loadTestBudgets[100].allocsPerOp))
+}
+
+func BenchmarkStateManagerReconcile(b *testing.B) {
+	for _, nodes := range []int{100, 1000, 5000} {
+		b.Run(fmt.Sprintf("nodes=%d", nodes), func(b *testing.B) {
+			cluster := newLoadTestCluster(b, nodes)
+			if err := cluster.reconcile(); err != nil {
+				b.Fatal(err)
+			}
+			cluster.counter.reset()
+
+			var before, after runtime.MemStats
+			runtime.ReadMemStats(&before)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := cluster.reconcile(); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.StopTimer()
+			runtime.ReadMemStats(&after)
+
+			calls := 0
+			for verb := range loadTestAPICallBudget {
+				calls += cluster.counter.get(verb)
+			}
+			b.ReportMetric(float64(calls)/float64(b.N), "apicalls/op")
+			if err := cluster.counter.checkBudget(b.N); err != nil {
+				b.Fatal(err)
+			}
+			nsPerOp := b.Elapsed() / time.Duration(b.N)
+			allocsPerOp := (after.Mallocs - before.Mallocs) / uint64(b.N)
+			if err := loadTestBudgets[nodes].check(nsPerOp, allocsPerOp); err != nil {
+				b.Fatal(err)
+			}
+		})
+	}
+}
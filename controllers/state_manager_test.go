@@ -31,6 +31,7 @@ import (
 
 	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
 	"github.com/NVIDIA/gpu-operator/internal/consts"
+	"github.com/NVIDIA/gpu-operator/internal/operatorconfig"
 )
 
 func TestGetGPUNodeOSInfo(t *testing.T) {
@@ -423,6 +424,138 @@ func TestValidateClusterPolicySpec(t *testing.T) {
 			},
 			err: errors.New("the NRI Plugin cannot be enabled when the Container Toolkit is disabled"),
 		},
+		{
+			description: "valid Windows object in spec",
+			spec: &gpuv1.ClusterPolicySpec{
+				Windows: &gpuv1.WindowsSpec{Enabled: ptr.To(true)},
+			},
+		},
+		{
+			description: "Windows GPU nodes with sandbox workloads",
+			spec: &gpuv1.ClusterPolicySpec{
+				Windows:          &gpuv1.WindowsSpec{Enabled: ptr.To(true)},
+				SandboxWorkloads: gpuv1.SandboxWorkloadsSpec{Enabled: ptr.To(true)},
+			},
+			err: errors.New("Windows GPU nodes cannot be enabled together with sandbox workloads"),
+		},
+		{
+			description: "Windows GPU Feature Discovery without the Windows device plugin",
+			spec: &gpuv1.ClusterPolicySpec{
+				Windows: &gpuv1.WindowsSpec{
+					Enabled:      ptr.To(true),
+					DevicePlugin: &gpuv1.WindowsComponentSpec{Enabled: ptr.To(false)},
+				},
+			},
+			err: errors.New("the Windows GPU Feature Discovery cannot be enabled when the Windows device plugin is disabled"),
+		},
+		{
+			description: "vGPU Manager with kernel module parameters",
+			spec: &gpuv1.ClusterPolicySpec{
+				VGPUManager: gpuv1.VGPUManagerSpec{
+					KernelModuleConfig: &gpuv1.KernelModuleConfigSpec{NVIDIA: map[string]string{"NVreg_EnableGpuFirmware": "0"}},
+				},
+			},
+			err: errors.New("the vGPU Manager only supports kernel module parameters provided in a ConfigMap"),
+		},
+		{
+			description: "DCGM Exporter pod attribution with a relative kubelet root directory",
+			spec: &gpuv1.ClusterPolicySpec{
+				HostPaths: gpuv1.HostPathsSpec{KubeletRootDir: "var/lib/kubelet"},
+				DCGMExporter: gpuv1.DCGMExporterSpec{
+					PodAttribution: &gpuv1.DCGMExporterPodAttributionConfig{Enabled: ptr.To(true)},
+				},
+			},
+			err: errors.New(`the kubelet root directory "var/lib/kubelet" must be an absolute path for DCGM Exporter pod attribution`),
+		},
+		{
+			description: "DCGM Exporter pod labels with pod attribution disabled",
+			spec: &gpuv1.ClusterPolicySpec{
+				DCGMExporter: gpuv1.DCGMExporterSpec{
+					EnablePodLabels: ptr.To(true),
+					PodAttribution:  &gpuv1.DCGMExporterPodAttributionConfig{Enabled: ptr.To(false)},
+				},
+			},
+			err: errors.New("DCGM Exporter pod labels and pod UID cannot be enabled when pod attribution is disabled"),
+		},
+		{
+			description: "DCGM Exporter pod attribution overridden by an environment variable",
+			spec: &gpuv1.ClusterPolicySpec{
+				DCGMExporter: gpuv1.DCGMExporterSpec{
+					Env:            []gpuv1.EnvVar{{Name: "DCGM_EXPORTER_KUBERNETES", Value: "false"}},
+					PodAttribution: &gpuv1.DCGMExporterPodAttributionConfig{Enabled: ptr.To(true)},
+				},
+			},
+			err: errors.New("the DCGM_EXPORTER_KUBERNETES environment variable of DCGM Exporter conflicts with podAttribution.enabled"),
+		},
+		{
+			description: "vGPU type profiles with a custom vGPU devices ConfigMap",
+			spec: &gpuv1.ClusterPolicySpec{
+				VGPUDeviceManager: gpuv1.VGPUDeviceManagerSpec{
+					Config:   &gpuv1.VGPUDevicesConfigSpec{Name: "custom-vgpu-config"},
+					Profiles: []gpuv1.VGPUProfileSpec{{Name: "inference", VGPUDevices: map[string]int32{"A100-4C": 10}}},
+				},
+			},
+			err: errors.New("vGPU type profiles cannot be combined with the custom vGPU devices ConfigMap custom-vgpu-config"),
+		},
+		{
+			description: "MPS sharing on a node group without MIG configuration",
+			spec: &gpuv1.ClusterPolicySpec{
+				DevicePlugin: gpuv1.DevicePluginSpec{
+					MPS: &gpuv1.MPSConfig{Resources: []gpuv1.MPSResource{{Name: "nvidia.com/gpu", Replicas: 4}}},
+				},
+				MPSControlDaemon: &gpuv1.MPSControlDaemonSpec{NodeSelector: map[string]string{"pool": "inference"}},
+				MIG: gpuv1.MIGSpec{
+					ConfigSelectors: []gpuv1.MIGConfigSelector{
+						{NodeSelector: map[string]string{"pool": "training"}, Config: "all-1g.10gb"},
+						{NodeSelector: map[string]string{"pool": "inference"}, Config: "all-disabled"},
+					},
+				},
+			},
+		},
+		{
+			description: "MPS sharing combined with time-slicing",
+			spec: &gpuv1.ClusterPolicySpec{
+				DevicePlugin: gpuv1.DevicePluginSpec{
+					MPS:         &gpuv1.MPSConfig{Resources: []gpuv1.MPSResource{{Name: "nvidia.com/gpu", Replicas: 4}}},
+					TimeSlicing: &gpuv1.TimeSlicingConfig{Resources: []gpuv1.TimeSlicingResource{{Name: "nvidia.com/gpu", Replicas: 4}}},
+				},
+			},
+			err: errors.New("the device plugin resources cannot be shared through both time-slicing and MPS"),
+		},
+		{
+			description: "MPS sharing of MIG devices",
+			spec: &gpuv1.ClusterPolicySpec{
+				DevicePlugin: gpuv1.DevicePluginSpec{
+					MPS: &gpuv1.MPSConfig{Resources: []gpuv1.MPSResource{{Name: "nvidia.com/mig-1g.10gb", Replicas: 2}}},
+				},
+			},
+			err: errors.New("the MIG devices of resource nvidia.com/mig-1g.10gb cannot be shared through MPS"),
+		},
+		{
+			description: "MPS sharing with the MPS control daemon disabled",
+			spec: &gpuv1.ClusterPolicySpec{
+				DevicePlugin: gpuv1.DevicePluginSpec{
+					MPS: &gpuv1.MPSConfig{Resources: []gpuv1.MPSResource{{Name: "nvidia.com/gpu", Replicas: 4}}},
+				},
+				MPSControlDaemon: &gpuv1.MPSControlDaemonSpec{Enabled: ptr.To(false)},
+			},
+			err: errors.New("sharing resources through MPS requires the MPS control daemon"),
+		},
+		{
+			description: "MPS sharing on the nodes of a MIG configuration",
+			spec: &gpuv1.ClusterPolicySpec{
+				DevicePlugin: gpuv1.DevicePluginSpec{
+					MPS: &gpuv1.MPSConfig{Resources: []gpuv1.MPSResource{{Name: "nvidia.com/gpu", Replicas: 4}}},
+				},
+				MIG: gpuv1.MIGSpec{
+					ConfigSelectors: []gpuv1.MIGConfigSelector{
+						{NodeSelector: map[string]string{"pool": "training"}, Config: "all-1g.10gb"},
+					},
+				},
+			},
+			err: errors.New("the nodes selected for the MIG configuration all-1g.10gb may also share their GPUs through MPS, " +
+				"restrict the MPS control daemon to other nodes"),
+		},
 	}
 
 	for _, tc := range tests {
@@ -534,11 +667,12 @@ func TestIsStateEnabled_SandboxAndKataDevicePlugin(t *testing.T) {
 	boolTrue := ptr.To(true)
 	boolFalse := ptr.To(false)
 	tests := []struct {
-		name           string
-		sandboxEnabled bool
-		spec           gpuv1.ClusterPolicySpec
-		stateName      string
-		wantEnabled    bool
+		name             string
+		sandboxEnabled   bool
+		spec             gpuv1.ClusterPolicySpec
+		nodeSandboxModes map[string]bool
+		stateName        string
+		wantEnabled      bool
 	}{
 		{
 			name:           "state-sandbox-device-plugin enabled when sandbox+plugin+mode kubevirt",
@@ -580,6 +714,28 @@ func TestIsStateEnabled_SandboxAndKataDevicePlugin(t *testing.T) {
 			stateName:   "state-kata-device-plugin",
 			wantEnabled: false,
 		},
+		{
+			name:           "state-kata-device-plugin enabled when mode kubevirt and a node in kata mode",
+			sandboxEnabled: true,
+			spec: gpuv1.ClusterPolicySpec{
+				SandboxWorkloads:        gpuv1.SandboxWorkloadsSpec{Enabled: boolTrue, Mode: "kubevirt"},
+				KataSandboxDevicePlugin: gpuv1.KataDevicePluginSpec{ComponentCommonSpec: gpuv1.ComponentCommonSpec{Enabled: boolTrue}},
+			},
+			nodeSandboxModes: map[string]bool{"kata": true},
+			stateName:        "state-kata-device-plugin",
+			wantEnabled:      true,
+		},
+		{
+			name:           "state-sandbox-device-plugin enabled when mode kata and a node in kubevirt mode",
+			sandboxEnabled: true,
+			spec: gpuv1.ClusterPolicySpec{
+				SandboxWorkloads:    gpuv1.SandboxWorkloadsSpec{Enabled: boolTrue, Mode: "kata"},
+				SandboxDevicePlugin: gpuv1.SandboxDevicePluginSpec{Enabled: boolTrue},
+			},
+			nodeSandboxModes: map[string]bool{"kubevirt": true},
+			stateName:        "state-sandbox-device-plugin",
+			wantEnabled:      true,
+		},
 		{
 			name:           "state-kata-device-plugin disabled when KataSandboxDevicePlugin.Enabled false",
 			sandboxEnabled: true,
@@ -600,15 +756,75 @@ func TestIsStateEnabled_SandboxAndKataDevicePlugin(t *testing.T) {
 			stateName:   "state-kata-device-plugin",
 			wantEnabled: false,
 		},
+		{
+			name:        "state-mock-gpus disabled by default",
+			spec:        gpuv1.ClusterPolicySpec{},
+			stateName:   "state-mock-gpus",
+			wantEnabled: false,
+		},
+		{
+			name: "state-mock-gpus enabled when mock GPUs enabled",
+			spec: gpuv1.ClusterPolicySpec{
+				Devel: &gpuv1.DevelSpec{MockGPUs: &gpuv1.MockGPUsSpec{Enabled: boolTrue}},
+			},
+			stateName:   "state-mock-gpus",
+			wantEnabled: true,
+		},
+		{
+			name: "state-driver disabled when mock GPUs enabled",
+			spec: gpuv1.ClusterPolicySpec{
+				Driver: gpuv1.DriverSpec{Enabled: boolTrue},
+				Devel:  &gpuv1.DevelSpec{MockGPUs: &gpuv1.MockGPUsSpec{Enabled: boolTrue}},
+			},
+			stateName:   "state-driver",
+			wantEnabled: false,
+		},
+		{
+			name: "state-operator-validation disabled when mock GPUs enabled",
+			spec: gpuv1.ClusterPolicySpec{
+				Devel: &gpuv1.DevelSpec{MockGPUs: &gpuv1.MockGPUsSpec{Enabled: boolTrue}},
+			},
+			stateName:   "state-operator-validation",
+			wantEnabled: false,
+		},
+		{
+			name: "state-operator-metrics enabled when mock GPUs enabled",
+			spec: gpuv1.ClusterPolicySpec{
+				Devel: &gpuv1.DevelSpec{MockGPUs: &gpuv1.MockGPUsSpec{Enabled: boolTrue}},
+			},
+			stateName:   "state-operator-metrics",
+			wantEnabled: true,
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			n := ClusterPolicyController{
-				singleton:      &gpuv1.ClusterPolicy{Spec: tc.spec},
-				sandboxEnabled: tc.sandboxEnabled,
+				singleton:        &gpuv1.ClusterPolicy{Spec: tc.spec},
+				sandboxEnabled:   tc.sandboxEnabled,
+				nodeSandboxModes: tc.nodeSandboxModes,
 			}
 			got := n.isStateEnabled(tc.stateName)
 			require.Equal(t, tc.wantEnabled, got, "isStateEnabled(%q)", tc.stateName)
 		})
 	}
 }
+
+func TestIsStateEnabledKataManagementGate(t *testing.T) {
+	store := operatorconfig.NewStore(nil)
+	n := ClusterPolicyController{
+		singleton: &gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{
+			SandboxWorkloads:        gpuv1.SandboxWorkloadsSpec{Enabled: ptr.To(true), Mode: "kata"},
+			KataSandboxDevicePlugin: gpuv1.KataDevicePluginSpec{ComponentCommonSpec: gpuv1.ComponentCommonSpec{Enabled: ptr.To(true)}},
+			CCManager:               gpuv1.CCManagerSpec{Enabled: ptr.To(true)},
+		}},
+		sandboxEnabled: true,
+		operatorConfig: store,
+	}
+	require.True(t, n.isStateEnabled("state-kata-device-plugin"))
+	require.True(t, n.isStateEnabled("state-cc-manager"))
+
+	_, err := store.Apply(map[string]string{operatorconfig.FeatureGatesKey: "KataManagement=false"})
+	require.NoError(t, err)
+	require.False(t, n.isStateEnabled("state-kata-device-plugin"))
+	require.False(t, n.isStateEnabled("state-cc-manager"))
+}
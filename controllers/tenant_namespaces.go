@@ -0,0 +1,261 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/audit"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+// tenantStates are the states whose operands are deployed in the tenant namespaces for the GPU nodes of the
+// tenants
+var tenantStates = map[string]bool{
+	"state-device-plugin": true,
+	"state-dcgm-exporter": true,
+}
+
+// tenantSyncer deploys the operand objects of a state in the operator namespace for the GPU nodes without
+// tenant, then in every tenant namespace for the GPU nodes of the tenant. The namespaced objects of the state,
+// RBAC included, are created in the tenant namespace, while the ClusterRoles and SecurityContextConstraints are
+// shared with the operator namespace.
+type tenantSyncer struct {
+	stateSyncer
+}
+
+func (s tenantSyncer) sync(n ClusterPolicyController) (gpuv1.State, error) {
+	overallState, err := s.stateSyncer.sync(n)
+	if err != nil {
+		return overallState, err
+	}
+
+	tenants := n.tenantNamespaces()
+	if err := n.deleteRemovedTenantObjects(tenants); err != nil {
+		return gpuv1.NotReady, err
+	}
+
+	// the DaemonSets are read and the pods listed from the API server, as the manager only caches the objects
+	// of the operator namespace
+	tenantClient := n.client
+	if n.apiReader != nil {
+		tenantClient = readerClient{Client: n.client, reader: n.apiReader}
+	}
+
+	for _, tenant := range tenants {
+		logger := n.logger.WithValues("TenantNamespace", tenant.Name, "state", n.stateNames[n.idx])
+		err := tenantClient.Get(n.ctx, types.NamespacedName{Name: tenant.Name}, &corev1.Namespace{})
+		if apierrors.IsNotFound(err) {
+			if overallState != gpuv1.Disabled {
+				logger.Info("WARNING: the tenant namespace does not exist, skipping it")
+				overallState = gpuv1.NotReady
+			}
+			continue
+		}
+		if err != nil {
+			return gpuv1.NotReady, fmt.Errorf("unable to get tenant namespace %s: %w", tenant.Name, err)
+		}
+
+		t := n
+		t.client = tenantClient
+		t.tenant = tenant.Name
+		t.operatorNamespace = tenant.Name
+		// the scoped ClusterPolicies have no tenants, and the introspection only covers the operator namespace
+		t.scopeResolved = true
+		t.introspection = nil
+		state, err := s.stateSyncer.sync(t)
+		if err != nil {
+			logger.Error(err, "Failed to deploy the operands of the tenant namespace")
+			overallState = gpuv1.NotReady
+			continue
+		}
+		if state == gpuv1.NotReady {
+			overallState = gpuv1.NotReady
+		}
+	}
+	return overallState, nil
+}
+
+// tenantNamespaces returns the tenant namespaces of the ClusterPolicy being reconciled, a scoped ClusterPolicy
+// has no tenants
+func (n ClusterPolicyController) tenantNamespaces() []gpuv1.TenantNamespaceSpec {
+	if n.singleton == nil || n.singleton.IsScoped() {
+		return nil
+	}
+	return n.singleton.Spec.TenantNamespaces
+}
+
+// deleteRemovedTenantObjects deletes the DaemonSets and ClusterRoleBindings of the current state deployed for
+// the namespaces which are no longer tenants. The other objects of the state are left in these namespaces
+// until the ClusterPolicy is deleted.
+func (n ClusterPolicyController) deleteRemovedTenantObjects(tenants []gpuv1.TenantNamespaceSpec) error {
+	listed := make(map[string]bool, len(tenants))
+	for _, tenant := range tenants {
+		listed[tenant.Name] = true
+	}
+	reader := client.Reader(n.client)
+	if n.apiReader != nil {
+		reader = n.apiReader
+	}
+	ctx := audit.WithReason(n.ctx, "tenant namespace removed")
+	resources := n.resources[n.idx]
+
+	if resources.DaemonSet.Name != "" {
+		daemonSets := &appsv1.DaemonSetList{}
+		opts := []client.ListOption{
+			client.MatchingLabels{appLabelKey: resources.DaemonSet.Labels[appLabelKey]},
+			client.HasLabels{consts.TenantNamespaceLabel},
+		}
+		if err := reader.List(n.ctx, daemonSets, opts...); err != nil {
+			return fmt.Errorf("unable to list the DaemonSets of the tenant namespaces: %w", err)
+		}
+		for i := range daemonSets.Items {
+			if listed[daemonSets.Items[i].Labels[consts.TenantNamespaceLabel]] {
+				continue
+			}
+			if err := n.client.Delete(ctx, &daemonSets.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+
+	if resources.ClusterRoleBinding.Name != "" {
+		bindings := &rbacv1.ClusterRoleBindingList{}
+		if err := reader.List(n.ctx, bindings, client.HasLabels{consts.TenantNamespaceLabel}); err != nil {
+			return fmt.Errorf("unable to list the ClusterRoleBindings of the tenant namespaces: %w", err)
+		}
+		for i := range bindings.Items {
+			binding := &bindings.Items[i]
+			tenant := binding.Labels[consts.TenantNamespaceLabel]
+			if listed[tenant] || binding.Name != resources.ClusterRoleBinding.Name+"-"+tenant {
+				continue
+			}
+			if err := n.client.Delete(ctx, binding); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applyTenantNamespace restricts the DaemonSet of a tenant state to the nodes of the tenant namespace being
+// reconciled. The DaemonSet of a tenant namespace is labeled with the namespace, and only scheduled on the
+// nodes labeled with it, while the DaemonSet of the operator namespace is kept off the nodes of the tenants.
+func applyTenantNamespace(obj *appsv1.DaemonSet, n ClusterPolicyController) {
+	if n.tenant == "" {
+		if tenantStates[n.stateNames[n.idx]] && len(n.tenantNamespaces()) > 0 {
+			addRequiredNodeSelectorRequirement(&obj.Spec.Template.Spec, corev1.NodeSelectorRequirement{
+				Key:      consts.TenantNamespaceLabel,
+				Operator: corev1.NodeSelectorOpDoesNotExist,
+			})
+		}
+		return
+	}
+
+	if obj.Labels == nil {
+		obj.Labels = make(map[string]string)
+	}
+	obj.Labels[consts.TenantNamespaceLabel] = n.tenant
+	if obj.Spec.Template.Labels == nil {
+		obj.Spec.Template.Labels = make(map[string]string)
+	}
+	obj.Spec.Template.Labels[consts.TenantNamespaceLabel] = n.tenant
+	if obj.Spec.Template.Spec.NodeSelector == nil {
+		obj.Spec.Template.Spec.NodeSelector = make(map[string]string)
+	}
+	obj.Spec.Template.Spec.NodeSelector[consts.TenantNamespaceLabel] = n.tenant
+}
+
+// applyTenantClusterRoleBinding names and labels the ClusterRoleBinding of a tenant namespace after it, so that
+// the ClusterRole shared with the operator namespace is bound to the service account of every tenant
+func applyTenantClusterRoleBinding(obj *rbacv1.ClusterRoleBinding, n ClusterPolicyController) {
+	if n.tenant == "" {
+		return
+	}
+	obj.Name += "-" + n.tenant
+	if obj.Labels == nil {
+		obj.Labels = make(map[string]string)
+	}
+	obj.Labels[consts.TenantNamespaceLabel] = n.tenant
+}
+
+// tenantSCCUsers returns the service accounts of the tenant namespaces allowed to use the
+// SecurityContextConstraints of a tenant state, the service accounts being named after the
+// SecurityContextConstraints
+func tenantSCCUsers(name string, n ClusterPolicyController) []string {
+	if !tenantStates[n.stateNames[n.idx]] {
+		return nil
+	}
+	users := []string{}
+	for _, tenant := range n.tenantNamespaces() {
+		users = append(users, fmt.Sprintf("system:serviceaccount:%s:%s", tenant.Name, name))
+	}
+	return users
+}
+
+// validateTenantNamespaces checks that the tenant namespaces are named and select GPU nodes, and that they are
+// only set on the ClusterPolicy without node selector
+func validateTenantNamespaces(spec *gpuv1.ClusterPolicySpec) error {
+	if len(spec.TenantNamespaces) == 0 {
+		return nil
+	}
+	if len(spec.NodeSelector) > 0 {
+		return fmt.Errorf("tenant namespaces cannot be set on a ClusterPolicy with a node selector")
+	}
+	names := make(map[string]bool, len(spec.TenantNamespaces))
+	for _, tenant := range spec.TenantNamespaces {
+		if strings.TrimSpace(tenant.Name) == "" {
+			return fmt.Errorf("the name of a tenant namespace cannot be empty")
+		}
+		if names[tenant.Name] {
+			return fmt.Errorf("tenant namespace %s is listed more than once", tenant.Name)
+		}
+		names[tenant.Name] = true
+		if len(tenant.NodeSelector) == 0 {
+			return fmt.Errorf("the node selector of tenant namespace %s cannot be empty", tenant.Name)
+		}
+		if _, ok := tenant.NodeSelector[consts.TenantNamespaceLabel]; ok {
+			return fmt.Errorf("the node selector of tenant namespace %s cannot use the %s label", tenant.Name, consts.TenantNamespaceLabel)
+		}
+	}
+	return nil
+}
+
+// readerClient is a client reading the objects through a reader, e.g. from the API server for the objects
+// outside of the namespaces cached by the manager
+type readerClient struct {
+	client.Client
+	reader client.Reader
+}
+
+func (c readerClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.reader.Get(ctx, key, obj, opts...)
+}
+
+func (c readerClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.reader.List(ctx, list, opts...)
+}
@@ -0,0 +1,218 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+// namespaceRecorder is a state recording the namespaces it is synced in
+type namespaceRecorder struct {
+	namespaces []string
+}
+
+func (r *namespaceRecorder) sync(n ClusterPolicyController) (gpuv1.State, error) {
+	r.namespaces = append(r.namespaces, n.operatorNamespace)
+	return gpuv1.Ready, nil
+}
+
+func newTenantNamespaces(names ...string) []gpuv1.TenantNamespaceSpec {
+	tenants := make([]gpuv1.TenantNamespaceSpec, 0, len(names))
+	for _, name := range names {
+		tenants = append(tenants, gpuv1.TenantNamespaceSpec{Name: name, NodeSelector: map[string]string{"tenant": name}})
+	}
+	return tenants
+}
+
+func TestApplyTenantNamespace(t *testing.T) {
+	newDaemonSet := func() *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "nvidia-device-plugin-daemonset"},
+			Spec: appsv1.DaemonSetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nvidia-device-plugin-daemonset"}},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "nvidia-device-plugin-daemonset"}},
+					Spec:       corev1.PodSpec{NodeSelector: map[string]string{"nvidia.com/gpu.deploy.device-plugin": "true"}},
+				},
+			},
+		}
+	}
+	singleton := &gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{TenantNamespaces: newTenantNamespaces("tenant-a")}}
+	stateNames := []string{"state-device-plugin", "state-driver"}
+
+	// operator namespace without tenant namespaces
+	ds := newDaemonSet()
+	applyTenantNamespace(ds, ClusterPolicyController{singleton: &gpuv1.ClusterPolicy{}, stateNames: stateNames})
+	require.Equal(t, newDaemonSet(), ds)
+
+	// operator namespace next to tenant namespaces
+	ds = newDaemonSet()
+	applyTenantNamespace(ds, ClusterPolicyController{singleton: singleton, stateNames: stateNames})
+	require.Equal(t, []corev1.NodeSelectorTerm{{
+		MatchExpressions: []corev1.NodeSelectorRequirement{{
+			Key:      consts.TenantNamespaceLabel,
+			Operator: corev1.NodeSelectorOpDoesNotExist,
+		}},
+	}}, ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+
+	// state which is not deployed in the tenant namespaces
+	ds = newDaemonSet()
+	applyTenantNamespace(ds, ClusterPolicyController{singleton: singleton, stateNames: stateNames, idx: 1})
+	require.Equal(t, newDaemonSet(), ds)
+
+	// tenant namespace
+	ds = newDaemonSet()
+	applyTenantNamespace(ds, ClusterPolicyController{singleton: singleton, stateNames: stateNames, tenant: "tenant-a"})
+	require.Equal(t, "nvidia-device-plugin-daemonset", ds.Name)
+	require.Equal(t, "tenant-a", ds.Labels[consts.TenantNamespaceLabel])
+	require.Equal(t, "tenant-a", ds.Spec.Template.Labels[consts.TenantNamespaceLabel])
+	require.Equal(t, newDaemonSet().Spec.Selector, ds.Spec.Selector)
+	require.Equal(t, map[string]string{
+		"nvidia.com/gpu.deploy.device-plugin": "true",
+		consts.TenantNamespaceLabel:           "tenant-a",
+	}, ds.Spec.Template.Spec.NodeSelector)
+	require.Nil(t, ds.Spec.Template.Spec.Affinity)
+}
+
+func TestTenantSyncer(t *testing.T) {
+	appLabels := map[string]string{appLabelKey: "nvidia-device-plugin-daemonset"}
+	newTenantObjects := func(tenant string) []client.Object {
+		return []client.Object{
+			&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{
+				Name:      "nvidia-device-plugin-daemonset",
+				Namespace: tenant,
+				Labels:    map[string]string{appLabelKey: appLabels[appLabelKey], consts.TenantNamespaceLabel: tenant},
+			}},
+			&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{
+				Name:   "nvidia-device-plugin-" + tenant,
+				Labels: map[string]string{consts.TenantNamespaceLabel: tenant},
+			}},
+		}
+	}
+	objects := []client.Object{&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-a"}}}
+	objects = append(objects, newTenantObjects("tenant-a")...)
+	objects = append(objects, newTenantObjects("tenant-removed")...)
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objects...).Build()
+
+	recorder := &namespaceRecorder{}
+	n := ClusterPolicyController{
+		ctx:               context.Background(),
+		client:            c,
+		logger:            ctrl.Log.WithName("test"),
+		operatorNamespace: "gpu-operator",
+		singleton: &gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{
+			TenantNamespaces: newTenantNamespaces("tenant-a", "tenant-missing"),
+		}},
+		stateNames: []string{"state-device-plugin"},
+		resources: []Resources{{
+			DaemonSet:          appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "nvidia-device-plugin-daemonset", Labels: appLabels}},
+			ClusterRoleBinding: rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "nvidia-device-plugin"}},
+		}},
+	}
+
+	// the missing tenant namespace is skipped, and reported as not ready
+	state, err := tenantSyncer{recorder}.sync(n)
+	require.NoError(t, err)
+	require.Equal(t, gpuv1.NotReady, state)
+	require.Equal(t, []string{"gpu-operator", "tenant-a"}, recorder.namespaces)
+
+	// the DaemonSet and ClusterRoleBinding of the removed tenant namespace are deleted
+	err = c.Get(context.Background(), client.ObjectKey{Namespace: "tenant-removed", Name: "nvidia-device-plugin-daemonset"}, &appsv1.DaemonSet{})
+	require.True(t, apierrors.IsNotFound(err))
+	err = c.Get(context.Background(), client.ObjectKey{Name: "nvidia-device-plugin-tenant-removed"}, &rbacv1.ClusterRoleBinding{})
+	require.True(t, apierrors.IsNotFound(err))
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "tenant-a", Name: "nvidia-device-plugin-daemonset"}, &appsv1.DaemonSet{}))
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: "nvidia-device-plugin-tenant-a"}, &rbacv1.ClusterRoleBinding{}))
+
+	// the operands are only deployed in the operator namespace once the tenant namespaces are removed
+	n.singleton = &gpuv1.ClusterPolicy{}
+	recorder.namespaces = nil
+	state, err = tenantSyncer{recorder}.sync(n)
+	require.NoError(t, err)
+	require.Equal(t, gpuv1.Ready, state)
+	require.Equal(t, []string{"gpu-operator"}, recorder.namespaces)
+	err = c.Get(context.Background(), client.ObjectKey{Namespace: "tenant-a", Name: "nvidia-device-plugin-daemonset"}, &appsv1.DaemonSet{})
+	require.True(t, apierrors.IsNotFound(err))
+}
+
+func TestValidateTenantNamespaces(t *testing.T) {
+	testCases := []struct {
+		description string
+		spec        gpuv1.ClusterPolicySpec
+		expectError bool
+	}{
+		{
+			description: "no tenant namespaces",
+		},
+		{
+			description: "tenant namespaces",
+			spec:        gpuv1.ClusterPolicySpec{TenantNamespaces: newTenantNamespaces("tenant-a", "tenant-b")},
+		},
+		{
+			description: "duplicate tenant namespace",
+			spec:        gpuv1.ClusterPolicySpec{TenantNamespaces: newTenantNamespaces("tenant-a", "tenant-a")},
+			expectError: true,
+		},
+		{
+			description: "empty node selector",
+			spec:        gpuv1.ClusterPolicySpec{TenantNamespaces: []gpuv1.TenantNamespaceSpec{{Name: "tenant-a"}}},
+			expectError: true,
+		},
+		{
+			description: "reserved node selector label",
+			spec: gpuv1.ClusterPolicySpec{TenantNamespaces: []gpuv1.TenantNamespaceSpec{{
+				Name:         "tenant-a",
+				NodeSelector: map[string]string{consts.TenantNamespaceLabel: "tenant-b"},
+			}}},
+			expectError: true,
+		},
+		{
+			description: "scoped ClusterPolicy",
+			spec: gpuv1.ClusterPolicySpec{
+				NodeSelector:     map[string]string{"pool": "a"},
+				TenantNamespaces: newTenantNamespaces("tenant-a"),
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := validateTenantNamespaces(&tc.spec)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -17,17 +17,21 @@
 package controllers
 
 import (
+	"context"
 	"path"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -129,6 +133,15 @@ func (d Daemonset) WithContainer(container corev1.Container) Daemonset {
 	return d
 }
 
+// WithDriverConfigDigest stamps the config digest annotation the driver transform sets on the DaemonSet along
+// with the DRIVER_CONFIG_DIGEST env of the driver containers
+func (d Daemonset) WithDriverConfigDigest() Daemonset {
+	if digest := driverconfig.DriverConfigDigestFromPodSpec(&d.Spec.Template.Spec); digest != "" {
+		driverconfig.SetConfigDigest(digest, &d.ObjectMeta)
+	}
+	return d
+}
+
 func (d Daemonset) WithName(name string) Daemonset {
 	d.Name = name
 	return d
@@ -144,6 +157,16 @@ func (d Daemonset) WithPriorityClass(name string) Daemonset {
 	return d
 }
 
+func (d Daemonset) WithSchedulerName(name string) Daemonset {
+	d.Spec.Template.Spec.SchedulerName = name
+	return d
+}
+
+func (d Daemonset) WithAffinity(affinity *corev1.Affinity) Daemonset {
+	d.Spec.Template.Spec.Affinity = affinity
+	return d
+}
+
 func (d Daemonset) WithTolerations(tolerations []corev1.Toleration) Daemonset {
 	d.Spec.Template.Spec.Tolerations = tolerations
 	return d
@@ -669,6 +692,12 @@ func TestApplyCommonDaemonSetConfig(t *testing.T) {
 			dsSpec:      gpuv1.DaemonsetsSpec{PriorityClassName: "test-priority-class"},
 			expectedDs:  NewDaemonset().WithPriorityClass("test-priority-class"),
 		},
+		{
+			description: "schedulerName configured",
+			ds:          NewDaemonset(),
+			dsSpec:      gpuv1.DaemonsetsSpec{SchedulerName: "system-scheduler"},
+			expectedDs:  NewDaemonset().WithSchedulerName("system-scheduler"),
+		},
 		{
 			description: "toleration configured",
 			ds:          NewDaemonset(),
@@ -689,6 +718,35 @@ func TestApplyCommonDaemonSetConfig(t *testing.T) {
 				},
 			}),
 		},
+		{
+			description: "startup taints configured",
+			ds:          NewDaemonset(),
+			dsSpec: gpuv1.DaemonsetsSpec{
+				Tolerations: []corev1.Toleration{
+					{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+				},
+				StartupTaints: &gpuv1.StartupTaintsSpec{BootstrapTaints: []string{"example.com/bootstrap"}},
+			},
+			expectedDs: NewDaemonset().WithTolerations([]corev1.Toleration{
+				{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+				{Key: "karpenter.sh/unregistered", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute},
+				{Key: "node.cloudprovider.kubernetes.io/uninitialized", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+				{Key: "example.com/bootstrap", Operator: corev1.TolerationOpExists},
+			}),
+		},
+		{
+			description: "startup taints configured without autoscaler policy",
+			ds:          NewDaemonset(),
+			dsSpec: gpuv1.DaemonsetsSpec{
+				StartupTaints: &gpuv1.StartupTaintsSpec{
+					Policy:          gpuv1.StartupTaintPolicyNone,
+					BootstrapTaints: []string{"example.com/bootstrap"},
+				},
+			},
+			expectedDs: NewDaemonset().WithTolerations([]corev1.Toleration{
+				{Key: "example.com/bootstrap", Operator: corev1.TolerationOpExists},
+			}),
+		},
 		{
 			description: "invalid updatestrategy configured",
 			ds:          NewDaemonset(),
@@ -821,6 +879,53 @@ func TestApplyCommonDaemonsetMetadata(t *testing.T) {
 	}
 }
 
+func TestApplyImageMirror(t *testing.T) {
+	testCases := []struct {
+		description string
+		ds          Daemonset
+		imageMirror map[string]string
+		expectedDs  Daemonset
+	}{
+		{
+			description: "no image mirror configured",
+			ds: NewDaemonset().
+				WithContainer(corev1.Container{Name: "main", Image: "nvcr.io/nvidia/driver:550"}),
+			expectedDs: NewDaemonset().
+				WithContainer(corev1.Container{Name: "main", Image: "nvcr.io/nvidia/driver:550"}),
+		},
+		{
+			description: "containers, init containers and validation workload images are mirrored",
+			ds: NewDaemonset().
+				WithInitContainer(corev1.Container{
+					Name:  "plugin-validation",
+					Image: "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v25.3.0",
+					Env:   []corev1.EnvVar{{Name: ValidatorImageEnvName, Value: "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v25.3.0"}},
+				}).
+				WithContainer(corev1.Container{Name: "main", Image: "nvcr.io/nvidia/k8s/device-plugin:v0.17.0"}).
+				WithContainer(corev1.Container{Name: "sidecar", Image: "docker.io/library/busybox:1.36"}),
+			imageMirror: map[string]string{
+				"nvcr.io/nvidia":     "registry.local/nvidia",
+				"nvcr.io/nvidia/k8s": "registry.local/k8s",
+			},
+			expectedDs: NewDaemonset().
+				WithInitContainer(corev1.Container{
+					Name:  "plugin-validation",
+					Image: "registry.local/nvidia/cloud-native/gpu-operator-validator:v25.3.0",
+					Env:   []corev1.EnvVar{{Name: ValidatorImageEnvName, Value: "registry.local/nvidia/cloud-native/gpu-operator-validator:v25.3.0"}},
+				}).
+				WithContainer(corev1.Container{Name: "main", Image: "registry.local/k8s/device-plugin:v0.17.0"}).
+				WithContainer(corev1.Container{Name: "sidecar", Image: "docker.io/library/busybox:1.36"}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			applyImageMirror(&tc.ds.Spec.Template.Spec, &gpuv1.ClusterPolicySpec{ImageMirror: tc.imageMirror})
+			require.EqualValues(t, tc.expectedDs, tc.ds)
+		})
+	}
+}
+
 func TestTransformToolkit(t *testing.T) {
 	testCases := []struct {
 		description string
@@ -1048,6 +1153,48 @@ func TestTransformToolkit(t *testing.T) {
 				WithHostPathVolume("crio-config", "/etc/crio", ptr.To(corev1.HostPathDirectoryOrCreate)).
 				WithHostPathVolume("crio-drop-in-config", "/etc/crio/crio.conf.d", ptr.To(corev1.HostPathDirectoryOrCreate)),
 		},
+		{
+			description: "transform nvidia-container-toolkit-ctr container, cri-o runtime, drop-in config mode",
+			ds: NewDaemonset().
+				WithContainer(corev1.Container{Name: "nvidia-container-toolkit-ctr"}),
+			runtime: gpuv1.CRIO,
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Toolkit: gpuv1.ToolkitSpec{
+					Repository:     "nvcr.io/nvidia/cloud-native",
+					Image:          "nvidia-container-toolkit",
+					Version:        "v1.0.0",
+					CRIOConfigMode: gpuv1.CRIOConfigModeDropIn,
+				},
+				CDI: gpuv1.CDIConfigSpec{
+					Enabled: newBoolPtr(false),
+				},
+			},
+			expectedDs: NewDaemonset().
+				WithContainer(corev1.Container{
+					Name:            "nvidia-container-toolkit-ctr",
+					Image:           "nvcr.io/nvidia/cloud-native/nvidia-container-toolkit:v1.0.0",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Env: []corev1.EnvVar{
+						{Name: "RUNTIME", Value: gpuv1.CRIO.String()},
+						{Name: "RUNTIME_CONFIG", Value: "/runtime/config-dir/config.toml"},
+						{Name: "CRIO_CONFIG", Value: "/runtime/config-dir/config.toml"},
+						{Name: "RUNTIME_DROP_IN_CONFIG", Value: "/runtime/config-dir.d/99-nvidia.conf"},
+						{Name: "RUNTIME_DROP_IN_CONFIG_HOST_PATH", Value: "/etc/crio/crio.conf.d/99-nvidia.conf"},
+						{Name: CRIOConfigModeEnvName, Value: "config"},
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "crio-config", MountPath: DefaultRuntimeConfigTargetDir, ReadOnly: true},
+						{Name: "crio-drop-in-config", MountPath: "/runtime/config-dir.d/"},
+					},
+					Lifecycle: &corev1.Lifecycle{
+						PreStop: &corev1.LifecycleHandler{
+							Exec: &corev1.ExecAction{Command: []string{"/bin/sh", "-c", "rm -f /runtime/config-dir.d/99-nvidia.conf"}},
+						},
+					},
+				}).
+				WithHostPathVolume("crio-config", "/etc/crio", ptr.To(corev1.HostPathDirectoryOrCreate)).
+				WithHostPathVolume("crio-drop-in-config", "/etc/crio/crio.conf.d", ptr.To(corev1.HostPathDirectoryOrCreate)),
+		},
 		{
 			description: "transform with NRI enabled has just the NRI socket mount",
 			ds: NewDaemonset().
@@ -1260,7 +1407,51 @@ func TestTransformMPSControlDaemon(t *testing.T) {
 				WithHostPathVolume("mps-root", "/var/mps", ptr.To(corev1.HostPathDirectoryOrCreate)).
 				WithHostPathVolume("mps-shm", "/var/mps/shm", ptr.To(corev1.HostPathDirectoryOrCreate)).
 				WithPullSecret("secret").
-				WithRuntimeClassName("nvidia"),
+				WithRuntimeClassName("nvidia").
+				WithAffinity(&corev1.Affinity{NodeAffinity: migDisabledNodeAffinity()}),
+		},
+		{
+			description: "transform mps control daemon with a node group",
+			daemonset: NewDaemonset().
+				WithContainer(corev1.Container{Name: "mps-control-daemon-ctr"}),
+			clusterPolicySpec: &gpuv1.ClusterPolicySpec{
+				DevicePlugin: gpuv1.DevicePluginSpec{
+					Repository: "nvcr.io",
+					Image:      "mps",
+					Version:    "latest",
+					Resources: &gpuv1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+					},
+				},
+				MPSControlDaemon: &gpuv1.MPSControlDaemonSpec{
+					NodeSelector: map[string]string{"pool": "inference"},
+					Resources: &gpuv1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+					},
+					Args: []string{"--verbose"},
+					Env:  []gpuv1.EnvVar{{Name: "MPS_LOG_LEVEL", Value: "debug"}},
+				},
+			},
+			expectedDaemonset: func() Daemonset {
+				ds := NewDaemonset().
+					WithContainer(corev1.Container{
+						Name:            "mps-control-daemon-ctr",
+						Image:           "nvcr.io/mps:latest",
+						ImagePullPolicy: corev1.PullIfNotPresent,
+						Args:            []string{"--verbose"},
+						Env: []corev1.EnvVar{
+							{Name: "MPS_LOG_LEVEL", Value: "debug"},
+							{Name: "NVIDIA_MIG_MONITOR_DEVICES", Value: "all"},
+						},
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+						},
+					}).
+					WithRuntimeClassName("nvidia").
+					WithAffinity(&corev1.Affinity{NodeAffinity: migDisabledNodeAffinity()})
+				ds.Spec.Template.Spec.NodeSelector = map[string]string{"pool": "inference"}
+				return ds
+			}(),
 		},
 	}
 
@@ -1319,6 +1510,29 @@ func TestTransformDCGMExporter(t *testing.T) {
 				WithRuntimeClassName("nvidia").
 				WithHostPathVolume("pod-resources", "/var/lib/kubelet/pod-resources", nil),
 		},
+		{
+			description: "transform dcgm exporter with priority and runtime class overrides",
+			ds: NewDaemonset().
+				WithContainer(corev1.Container{Name: "dcgm-exporter"}).
+				WithPriorityClass("system-node-critical"),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				DCGMExporter: gpuv1.DCGMExporterSpec{
+					Repository:        "nvcr.io/nvidia/cloud-native",
+					Image:             "dcgm-exporter",
+					Version:           "v1.0.0",
+					PriorityClassName: "monitoring",
+					RuntimeClassName:  "nvidia-cdi",
+				},
+			},
+			expectedDs: NewDaemonset().WithContainer(corev1.Container{
+				Name:            "dcgm-exporter",
+				Image:           "nvcr.io/nvidia/cloud-native/dcgm-exporter:v1.0.0",
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Env: []corev1.EnvVar{
+					{Name: "DCGM_REMOTE_HOSTENGINE_INFO", Value: "nvidia-dcgm:5555"},
+				},
+			}).WithPriorityClass("monitoring").WithRuntimeClassName("nvidia-cdi"),
+		},
 		{
 			description: "transform dcgm exporter with hostPID enabled",
 			ds: NewDaemonset().
@@ -1713,6 +1927,75 @@ func TestTransformDCGMExporter(t *testing.T) {
 				WithRuntimeClassName("nvidia").
 				WithAutomountServiceAccountToken(true),
 		},
+		{
+			description: "transform dcgm exporter with pod attribution enabled",
+			ds: NewDaemonset().
+				WithContainer(corev1.Container{Name: "dcgm-exporter"}).
+				WithHostPathVolume("pod-gpu-resources", "/var/lib/kubelet/pod-resources", nil),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				HostPaths: gpuv1.HostPathsSpec{
+					KubeletRootDir: "/custom-kubelet",
+				},
+				DCGMExporter: gpuv1.DCGMExporterSpec{
+					Repository:      "nvcr.io/nvidia/cloud-native",
+					Image:           "dcgm-exporter",
+					Version:         "v1.0.0",
+					ImagePullPolicy: "IfNotPresent",
+					PodAttribution: &gpuv1.DCGMExporterPodAttributionConfig{
+						Enabled:   newBoolPtr(true),
+						GPUIDType: "device-name",
+					},
+				},
+			},
+			expectedDs: NewDaemonset().WithContainer(corev1.Container{
+				Name:            "dcgm-exporter",
+				Image:           "nvcr.io/nvidia/cloud-native/dcgm-exporter:v1.0.0",
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Env: []corev1.EnvVar{
+					{Name: "DCGM_REMOTE_HOSTENGINE_INFO", Value: "nvidia-dcgm:5555"},
+					{Name: "DCGM_EXPORTER_KUBERNETES", Value: "true"},
+					{Name: "DCGM_EXPORTER_KUBERNETES_GPU_ID_TYPE", Value: "device-name"},
+				},
+			}).WithRuntimeClassName("nvidia").
+				WithAutomountServiceAccountToken(true).
+				WithHostPathVolume("pod-gpu-resources", "/custom-kubelet/pod-resources", nil),
+		},
+		{
+			description: "transform dcgm exporter with pod attribution disabled",
+			ds: NewDaemonset().
+				WithContainer(corev1.Container{
+					Name: "dcgm-exporter",
+					Env:  []corev1.EnvVar{{Name: "DCGM_EXPORTER_KUBERNETES", Value: "true"}},
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "pod-gpu-resources", ReadOnly: true, MountPath: "/var/lib/kubelet/pod-resources"},
+						{Name: "run-nvidia", MountPath: "/run/nvidia"},
+					},
+				}).
+				WithHostPathVolume("pod-gpu-resources", "/var/lib/kubelet/pod-resources", nil).
+				WithHostPathVolume("run-nvidia", "/run/nvidia", nil),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				DCGMExporter: gpuv1.DCGMExporterSpec{
+					Repository:      "nvcr.io/nvidia/cloud-native",
+					Image:           "dcgm-exporter",
+					Version:         "v1.0.0",
+					ImagePullPolicy: "IfNotPresent",
+					PodAttribution:  &gpuv1.DCGMExporterPodAttributionConfig{Enabled: newBoolPtr(false)},
+				},
+			},
+			expectedDs: NewDaemonset().WithContainer(corev1.Container{
+				Name:            "dcgm-exporter",
+				Image:           "nvcr.io/nvidia/cloud-native/dcgm-exporter:v1.0.0",
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Env: []corev1.EnvVar{
+					{Name: "DCGM_EXPORTER_KUBERNETES", Value: "false"},
+					{Name: "DCGM_REMOTE_HOSTENGINE_INFO", Value: "nvidia-dcgm:5555"},
+				},
+				VolumeMounts: []corev1.VolumeMount{
+					{Name: "run-nvidia", MountPath: "/run/nvidia"},
+				},
+			}).WithRuntimeClassName("nvidia").
+				WithHostPathVolume("run-nvidia", "/run/nvidia", nil),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1800,6 +2083,42 @@ func TestTransformDCGM(t *testing.T) {
 				ImagePullPolicy: corev1.PullIfNotPresent,
 			}).WithRuntimeClassName("nvidia"),
 		},
+		{
+			description: "dcgm probes overridden",
+			daemonset: NewDaemonset().WithContainer(corev1.Container{
+				Name: "dcgm",
+				LivenessProbe: &corev1.Probe{
+					ProbeHandler:        corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(5555)}},
+					InitialDelaySeconds: 15,
+				},
+			}),
+			clusterPolicySpec: &gpuv1.ClusterPolicySpec{
+				DCGM: gpuv1.DCGMSpec{
+					Repository:     "nvcr.io/nvidia/cloud-native",
+					Image:          "dcgm",
+					Version:        "v1.0.0",
+					LivenessProbe:  &gpuv1.ContainerProbeSpec{InitialDelaySeconds: 120, FailureThreshold: 10},
+					StartupProbe:   &gpuv1.ContainerProbeSpec{Command: []string{"dcgmi", "discovery", "-l"}, PeriodSeconds: 10, FailureThreshold: 60},
+					ReadinessProbe: &gpuv1.ContainerProbeSpec{PeriodSeconds: 30},
+				},
+			},
+			// the readiness probe is not defined by the operand and has no command
+			expectedDaemonset: NewDaemonset().WithContainer(corev1.Container{
+				Name:            "dcgm",
+				Image:           "nvcr.io/nvidia/cloud-native/dcgm:v1.0.0",
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				LivenessProbe: &corev1.Probe{
+					ProbeHandler:        corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(5555)}},
+					InitialDelaySeconds: 120,
+					FailureThreshold:    10,
+				},
+				StartupProbe: &corev1.Probe{
+					ProbeHandler:     corev1.ProbeHandler{Exec: &corev1.ExecAction{Command: []string{"dcgmi", "discovery", "-l"}}},
+					PeriodSeconds:    10,
+					FailureThreshold: 60,
+				},
+			}).WithRuntimeClassName("nvidia"),
+		},
 		{
 			description: "dcgm disabled with localhost env does not change hostNetwork",
 			daemonset: NewDaemonset().WithContainer(corev1.Container{
@@ -2077,6 +2396,75 @@ func TestTransformVFIOManager(t *testing.T) {
 				}).
 				WithPullSecret(secret),
 		},
+		{
+			description: "cc-manager validation is kept when cc-manager is enabled",
+			daemonset: NewDaemonset().
+				WithContainer(corev1.Container{Name: "nvidia-vfio-manager"}).
+				WithInitContainer(corev1.Container{Name: "k8s-driver-manager"}).
+				WithInitContainer(corev1.Container{Name: "cc-manager-validation"}),
+			clusterPolicySpec: &gpuv1.ClusterPolicySpec{
+				VFIOManager: gpuv1.VFIOManagerSpec{
+					Repository: "nvcr.io/nvidia/cloud-native",
+					Image:      "vfio-pci-manager",
+					Version:    "v1.0.0",
+					DriverManager: gpuv1.DriverManagerSpec{
+						Repository: "nvcr.io/nvidia/cloud-native",
+						Image:      "k8s-driver-manager",
+						Version:    "v1.0.0",
+					},
+				},
+				CCManager: gpuv1.CCManagerSpec{Enabled: newBoolPtr(true)},
+				Validator: gpuv1.ValidatorSpec{
+					Repository: "nvcr.io/nvidia/cloud-native",
+					Image:      "gpu-operator-validator",
+					Version:    "v1.0.0",
+				},
+			},
+			expectedDaemonset: NewDaemonset().
+				WithContainer(corev1.Container{
+					Name:            "nvidia-vfio-manager",
+					Image:           "nvcr.io/nvidia/cloud-native/vfio-pci-manager:v1.0.0",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+				}).
+				WithInitContainer(corev1.Container{
+					Name:  "k8s-driver-manager",
+					Image: "nvcr.io/nvidia/cloud-native/k8s-driver-manager:v1.0.0",
+				}).
+				WithInitContainer(corev1.Container{
+					Name:            "cc-manager-validation",
+					Image:           "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0",
+					SecurityContext: &corev1.SecurityContext{RunAsUser: rootUID},
+				}),
+		},
+		{
+			description: "cc-manager validation is removed when cc-manager is disabled",
+			daemonset: NewDaemonset().
+				WithContainer(corev1.Container{Name: "nvidia-vfio-manager"}).
+				WithInitContainer(corev1.Container{Name: "k8s-driver-manager"}).
+				WithInitContainer(corev1.Container{Name: "cc-manager-validation"}),
+			clusterPolicySpec: &gpuv1.ClusterPolicySpec{
+				VFIOManager: gpuv1.VFIOManagerSpec{
+					Repository: "nvcr.io/nvidia/cloud-native",
+					Image:      "vfio-pci-manager",
+					Version:    "v1.0.0",
+					DriverManager: gpuv1.DriverManagerSpec{
+						Repository: "nvcr.io/nvidia/cloud-native",
+						Image:      "k8s-driver-manager",
+						Version:    "v1.0.0",
+					},
+				},
+			},
+			expectedDaemonset: NewDaemonset().
+				WithContainer(corev1.Container{
+					Name:            "nvidia-vfio-manager",
+					Image:           "nvcr.io/nvidia/cloud-native/vfio-pci-manager:v1.0.0",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+				}).
+				WithInitContainer(corev1.Container{
+					Name:  "k8s-driver-manager",
+					Image: "nvcr.io/nvidia/cloud-native/k8s-driver-manager:v1.0.0",
+				}),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -2694,12 +3082,75 @@ func TestTransformValidatorComponent(t *testing.T) {
 				},
 			}),
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.description, func(t *testing.T) {
-			err := TransformValidatorComponent(tc.cpSpec, &tc.pod.Spec, tc.component)
-			if tc.errorExpected {
+		{
+			description: "driver validation disabled with timeout",
+			pod:         NewPod().WithInitContainer(corev1.Container{Name: "driver-validation"}),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Validator: gpuv1.ValidatorSpec{
+					Repository:      "nvcr.io/nvidia/cloud-native",
+					Image:           "gpu-operator-validator",
+					Version:         "v1.0.0",
+					ImagePullPolicy: "IfNotPresent",
+					Driver: gpuv1.DriverValidatorSpec{
+						ValidatorComponentSpec: gpuv1.ValidatorComponentSpec{
+							Enabled: newBoolPtr(false),
+							Timeout: &metav1.Duration{Duration: 10 * time.Minute},
+						},
+					},
+				},
+			},
+			component: "driver",
+			expectedPod: NewPod().WithInitContainer(corev1.Container{
+				Name:            "driver-validation",
+				Image:           "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0",
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Env: []corev1.EnvVar{
+					{Name: ValidatorSkipValidationEnvName, Value: "true"},
+					{Name: ValidatorValidationTimeoutEnvName, Value: "10m0s"},
+				},
+				SecurityContext: &corev1.SecurityContext{
+					RunAsUser: rootUID,
+				},
+			}),
+		},
+		{
+			description: "cc-manager validation with env and timeout",
+			pod:         NewPod().WithInitContainer(corev1.Container{Name: "cc-manager-validation"}),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Validator: gpuv1.ValidatorSpec{
+					Repository:      "nvcr.io/nvidia/cloud-native",
+					Image:           "gpu-operator-validator",
+					Version:         "v1.0.0",
+					ImagePullPolicy: "IfNotPresent",
+					CCManager: gpuv1.CCManagerValidatorSpec{
+						ValidatorComponentSpec: gpuv1.ValidatorComponentSpec{
+							Timeout: &metav1.Duration{Duration: 90 * time.Second},
+						},
+						Env: []gpuv1.EnvVar{{Name: "foo", Value: "bar"}},
+					},
+				},
+				CCManager: gpuv1.CCManagerSpec{Enabled: newBoolPtr(true)},
+			},
+			component: "cc-manager",
+			expectedPod: NewPod().WithInitContainer(corev1.Container{
+				Name:            "cc-manager-validation",
+				Image:           "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0",
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Env: []corev1.EnvVar{
+					{Name: ValidatorValidationTimeoutEnvName, Value: "1m30s"},
+					{Name: "foo", Value: "bar"},
+				},
+				SecurityContext: &corev1.SecurityContext{
+					RunAsUser: rootUID,
+				},
+			}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := TransformValidatorComponent(tc.cpSpec, &tc.pod.Spec, tc.component)
+			if tc.errorExpected {
 				require.Error(t, err)
 				return
 			}
@@ -2813,6 +3264,62 @@ func TestTransformValidator(t *testing.T) {
 				}).
 				WithPullSecret("pull-secret"),
 		},
+		{
+			description: "rdma network attachments enabled",
+			ds: NewDaemonset().
+				WithInitContainer(corev1.Container{Name: "driver-validation"}).
+				WithContainer(corev1.Container{Name: "dummy"}),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Driver: gpuv1.DriverSpec{
+					GPUDirectRDMA: &gpuv1.GPUDirectRDMASpec{
+						Enabled:            newBoolPtr(true),
+						NetworkAttachments: &gpuv1.GPUDirectRDMANetworkAttachmentsSpec{Enabled: newBoolPtr(true)},
+					},
+				},
+				Validator: gpuv1.ValidatorSpec{
+					Repository:      "nvcr.io/nvidia/cloud-native",
+					Image:           "gpu-operator-validator",
+					Version:         "v1.0.0",
+					ImagePullPolicy: "IfNotPresent",
+				},
+			},
+			expectedDs: NewDaemonset().
+				WithInitContainer(corev1.Container{
+					Name:            "rdma-topology-discovery",
+					Image:           "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Command:         []string{"nvidia-validator"},
+					Env: []corev1.EnvVar{
+						{Name: "COMPONENT", Value: "rdma-topology"},
+						{
+							Name: "NODE_NAME",
+							ValueFrom: &corev1.EnvVarSource{
+								FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"},
+							},
+						},
+					},
+					SecurityContext: &corev1.SecurityContext{
+						RunAsUser: rootUID,
+					},
+				}).
+				WithInitContainer(corev1.Container{
+					Name:            "driver-validation",
+					Image:           "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					SecurityContext: &corev1.SecurityContext{
+						RunAsUser: rootUID,
+					},
+				}).
+				WithContainer(corev1.Container{
+					Name:            "dummy",
+					Image:           "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					SecurityContext: &corev1.SecurityContext{
+						RunAsUser: rootUID,
+					},
+				}).
+				WithRuntimeClassName("nvidia"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -3016,6 +3523,74 @@ func TestTransformNodeStatusExporter(t *testing.T) {
 					},
 				}),
 		},
+		{
+			description: "scheduler name override",
+			ds: NewDaemonset().
+				WithContainer(corev1.Container{Name: "dummy"}).
+				WithSchedulerName("default-scheduler"),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				NodeStatusExporter: gpuv1.NodeStatusExporterSpec{
+					Repository:    "nvcr.io/nvidia/cloud-native",
+					Image:         "node-status-exporter",
+					Version:       "v1.0.0",
+					SchedulerName: "system-scheduler",
+				},
+			},
+			expectedDs: NewDaemonset().
+				WithContainer(corev1.Container{
+					Name:            "dummy",
+					Image:           "nvcr.io/nvidia/cloud-native/node-status-exporter:v1.0.0",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					SecurityContext: &corev1.SecurityContext{
+						RunAsUser: rootUID,
+					},
+				}).
+				WithSchedulerName("system-scheduler"),
+		},
+		{
+			description: "tolerations and node affinity",
+			ds: NewDaemonset().
+				WithContainer(corev1.Container{Name: "dummy"}).
+				WithTolerations([]corev1.Toleration{{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}}),
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				NodeStatusExporter: gpuv1.NodeStatusExporterSpec{
+					Repository: "nvcr.io/nvidia/cloud-native",
+					Image:      "node-status-exporter",
+					Version:    "v1.0.0",
+					Tolerations: []corev1.Toleration{
+						{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+						{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "gpu", Effect: corev1.TaintEffectNoExecute},
+					},
+					NodeAffinity: &corev1.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+							NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+								MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"gpu"}}},
+							}},
+						},
+					},
+				},
+			},
+			expectedDs: NewDaemonset().
+				WithContainer(corev1.Container{
+					Name:            "dummy",
+					Image:           "nvcr.io/nvidia/cloud-native/node-status-exporter:v1.0.0",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					SecurityContext: &corev1.SecurityContext{
+						RunAsUser: rootUID,
+					},
+				}).
+				WithTolerations([]corev1.Toleration{
+					{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+					{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "gpu", Effect: corev1.TaintEffectNoExecute},
+				}).
+				WithAffinity(&corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+							MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"gpu"}}},
+						}},
+					},
+				}}),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -3031,6 +3606,425 @@ func TestTransformNodeStatusExporter(t *testing.T) {
 	}
 }
 
+func TestApplyNodeAffinity(t *testing.T) {
+	requirement := func(key string, op corev1.NodeSelectorOperator, values ...string) corev1.NodeSelectorRequirement {
+		return corev1.NodeSelectorRequirement{Key: key, Operator: op, Values: values}
+	}
+	required := func(terms ...[]corev1.NodeSelectorRequirement) *corev1.NodeAffinity {
+		affinity := &corev1.NodeAffinity{RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{}}
+		for _, term := range terms {
+			affinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms = append(
+				affinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms, corev1.NodeSelectorTerm{MatchExpressions: term})
+		}
+		return affinity
+	}
+	zoneA := requirement("zone", corev1.NodeSelectorOpIn, "a")
+	zoneB := requirement("zone", corev1.NodeSelectorOpIn, "b")
+	pool := requirement("pool", corev1.NodeSelectorOpExists)
+
+	testCases := []struct {
+		description  string
+		current      *corev1.NodeAffinity
+		nodeAffinity *corev1.NodeAffinity
+		expected     *corev1.NodeAffinity
+		errorMessage string
+	}{
+		{
+			description:  "no current node affinity",
+			nodeAffinity: required([]corev1.NodeSelectorRequirement{zoneA}),
+			expected:     required([]corev1.NodeSelectorRequirement{zoneA}),
+		},
+		{
+			description:  "required terms are ANDed with the current terms",
+			current:      required([]corev1.NodeSelectorRequirement{pool}),
+			nodeAffinity: required([]corev1.NodeSelectorRequirement{zoneA}, []corev1.NodeSelectorRequirement{zoneB}),
+			expected:     required([]corev1.NodeSelectorRequirement{pool, zoneA}, []corev1.NodeSelectorRequirement{pool, zoneB}),
+		},
+		{
+			description: "preferred terms are added",
+			current:     required([]corev1.NodeSelectorRequirement{pool}),
+			nodeAffinity: &corev1.NodeAffinity{PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{
+				{Weight: 10, Preference: corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{zoneA}}},
+			}},
+			expected: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: required([]corev1.NodeSelectorRequirement{pool}).RequiredDuringSchedulingIgnoredDuringExecution,
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{
+					{Weight: 10, Preference: corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{zoneA}}},
+				},
+			},
+		},
+		{
+			description:  "requirement excluding the deploy label",
+			nodeAffinity: required([]corev1.NodeSelectorRequirement{requirement(devicePluginDeployLabelKey, corev1.NodeSelectorOpNotIn, "true")}),
+			errorMessage: "conflicts with the node label nvidia.com/gpu.deploy.device-plugin=true required by the operator",
+		},
+		{
+			description:  "requirement on the absence of the deploy label",
+			nodeAffinity: required([]corev1.NodeSelectorRequirement{requirement(devicePluginDeployLabelKey, corev1.NodeSelectorOpDoesNotExist)}),
+			errorMessage: "conflicts with the node label nvidia.com/gpu.deploy.device-plugin=true required by the operator",
+		},
+		{
+			description:  "requirement matching the deploy label",
+			nodeAffinity: required([]corev1.NodeSelectorRequirement{requirement(devicePluginDeployLabelKey, corev1.NodeSelectorOpIn, "true")}),
+			expected:     required([]corev1.NodeSelectorRequirement{requirement(devicePluginDeployLabelKey, corev1.NodeSelectorOpIn, "true")}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			podSpec := &corev1.PodSpec{NodeSelector: map[string]string{devicePluginDeployLabelKey: "true"}}
+			if tc.current != nil {
+				podSpec.Affinity = &corev1.Affinity{NodeAffinity: tc.current}
+			}
+			err := applyNodeAffinity(podSpec, tc.nodeAffinity)
+			if tc.errorMessage != "" {
+				require.ErrorContains(t, err, tc.errorMessage)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, podSpec.Affinity.NodeAffinity)
+		})
+	}
+}
+
+func TestTransformGPUHealthCheck(t *testing.T) {
+	testCases := []struct {
+		description string
+		healthCheck *gpuv1.GPUHealthCheckSpec
+		expectedDs  Daemonset
+	}{
+		{
+			description: "default critical XIDs",
+			healthCheck: &gpuv1.GPUHealthCheckSpec{
+				Repository: "nvcr.io/nvidia",
+				Image:      "gpu-operator",
+				Version:    "v1.0.0",
+			},
+			expectedDs: NewDaemonset().
+				WithContainer(corev1.Container{
+					Name:            "dummy",
+					Image:           "nvcr.io/nvidia/gpu-operator:v1.0.0",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+				}),
+		},
+		{
+			description: "custom critical XIDs",
+			healthCheck: &gpuv1.GPUHealthCheckSpec{
+				Repository:   "nvcr.io/nvidia",
+				Image:        "gpu-operator",
+				Version:      "v1.0.0",
+				CriticalXIDs: []int32{79, 48},
+				Env:          []gpuv1.EnvVar{{Name: "SLEEP_INTERVAL_SECONDS", Value: "30"}},
+			},
+			expectedDs: NewDaemonset().
+				WithContainer(corev1.Container{
+					Name:            "dummy",
+					Image:           "nvcr.io/nvidia/gpu-operator:v1.0.0",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Env: []corev1.EnvVar{
+						{Name: "CRITICAL_XIDS", Value: "79,48"},
+						{Name: "SLEEP_INTERVAL_SECONDS", Value: "30"},
+					},
+				}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			ds := NewDaemonset().WithContainer(corev1.Container{Name: "dummy"})
+			err := TransformGPUHealthCheck(ds.DaemonSet, &gpuv1.ClusterPolicySpec{HealthCheck: tc.healthCheck}, ClusterPolicyController{logger: ctrl.Log.WithName("test")})
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedDs, ds)
+		})
+	}
+}
+
+func TestTransformWindowsDevicePlugin(t *testing.T) {
+	ds := NewDaemonset().
+		WithContainer(corev1.Container{Name: "dummy"}).
+		WithPodSecurityContext(&corev1.PodSecurityContext{RunAsUser: rootUID})
+	windows := &gpuv1.WindowsSpec{
+		Enabled: newBoolPtr(true),
+		DevicePlugin: &gpuv1.WindowsComponentSpec{
+			Repository:       "nvcr.io/nvidia",
+			Image:            "k8s-device-plugin",
+			Version:          "v1.0.0-ltsc2022",
+			ImagePullSecrets: []string{"pull-secret"},
+			Args:             []string{"--fail-on-init-error=false"},
+			Env:              []gpuv1.EnvVar{{Name: "PASS_DEVICE_SPECS", Value: "true"}},
+		},
+	}
+	err := TransformWindowsDevicePlugin(ds.DaemonSet, &gpuv1.ClusterPolicySpec{Windows: windows}, ClusterPolicyController{logger: ctrl.Log.WithName("test")})
+	require.NoError(t, err)
+
+	expectedDs := NewDaemonset().
+		WithContainer(corev1.Container{
+			Name:            "dummy",
+			Image:           "nvcr.io/nvidia/k8s-device-plugin:v1.0.0-ltsc2022",
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Args:            []string{"--fail-on-init-error=false"},
+			Env:             []corev1.EnvVar{{Name: "PASS_DEVICE_SPECS", Value: "true"}},
+		}).
+		WithPullSecret("pull-secret").
+		WithHostNetwork(true).
+		WithPodSecurityContext(&corev1.PodSecurityContext{
+			RunAsUser: rootUID,
+			WindowsOptions: &corev1.WindowsSecurityContextOptions{
+				HostProcess:   ptr.To(true),
+				RunAsUserName: ptr.To("NT AUTHORITY\\SYSTEM"),
+			},
+		})
+	require.EqualValues(t, expectedDs, ds)
+}
+
+func TestTransformWindowsGPUFeatureDiscoveryNoImage(t *testing.T) {
+	ds := NewDaemonset().WithContainer(corev1.Container{Name: "dummy"})
+	windows := &gpuv1.WindowsSpec{Enabled: newBoolPtr(true)}
+	err := TransformWindowsGPUFeatureDiscovery(ds.DaemonSet, &gpuv1.ClusterPolicySpec{Windows: windows}, ClusterPolicyController{logger: ctrl.Log.WithName("test")})
+	require.Error(t, err)
+}
+
+func TestTransformNVLinkFabric(t *testing.T) {
+	ds := NewDaemonset().WithContainer(corev1.Container{Name: "dummy"})
+	nvlinkFabric := &gpuv1.NVLinkFabricSpec{
+		Enabled:          newBoolPtr(true),
+		Repository:       "nvcr.io/nvidia",
+		Image:            "gpu-operator",
+		Version:          "v1.0.0",
+		ImagePullSecrets: []string{"pull-secret"},
+		Env:              []gpuv1.EnvVar{{Name: "SLEEP_INTERVAL_SECONDS", Value: "30"}},
+	}
+	err := TransformNVLinkFabric(ds.DaemonSet, &gpuv1.ClusterPolicySpec{NVLinkFabric: nvlinkFabric}, ClusterPolicyController{logger: ctrl.Log.WithName("test")})
+	require.NoError(t, err)
+
+	expectedDs := NewDaemonset().
+		WithContainer(corev1.Container{
+			Name:            "dummy",
+			Image:           "nvcr.io/nvidia/gpu-operator:v1.0.0",
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Env:             []corev1.EnvVar{{Name: "SLEEP_INTERVAL_SECONDS", Value: "30"}},
+		}).
+		WithPullSecret("pull-secret")
+	require.EqualValues(t, expectedDs, ds)
+}
+
+func TestTransformMockGPUs(t *testing.T) {
+	ds := NewDaemonset().WithContainer(corev1.Container{Name: "nvidia-mock-gpus"})
+	cpSpec := &gpuv1.ClusterPolicySpec{
+		Validator: gpuv1.ValidatorSpec{
+			Repository:       "nvcr.io/nvidia/cloud-native",
+			Image:            "gpu-operator-validator",
+			Version:          "v1.0.0",
+			ImagePullSecrets: []string{"pull-secret"},
+		},
+		Devel: &gpuv1.DevelSpec{MockGPUs: &gpuv1.MockGPUsSpec{
+			Enabled:      newBoolPtr(true),
+			NodeSelector: map[string]string{"pool": "dev"},
+			Count:        4,
+		}},
+	}
+	err := TransformMockGPUs(ds.DaemonSet, cpSpec, ClusterPolicyController{logger: ctrl.Log.WithName("test")})
+	require.NoError(t, err)
+
+	expectedDs := NewDaemonset().
+		WithContainer(corev1.Container{
+			Name:            "nvidia-mock-gpus",
+			Image:           "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0",
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Env: []corev1.EnvVar{
+				{Name: "MOCK_GPU_COUNT", Value: "4"},
+				{Name: "MOCK_GPU_PRODUCT", Value: "NVIDIA-Mock-GPU"},
+				{Name: "MOCK_GPU_MEMORY", Value: "16384"},
+			},
+		}).
+		WithPullSecret("pull-secret")
+	expectedDs.Spec.Template.Spec.NodeSelector = map[string]string{"pool": "dev"}
+	require.EqualValues(t, expectedDs, ds)
+}
+
+func TestTransformImagePrePull(t *testing.T) {
+	prePull := &gpuv1.ImagePrePullSpec{
+		Enabled:           newBoolPtr(true),
+		Repository:        "nvcr.io/nvidia",
+		Image:             "gpu-operator",
+		Version:           "v1.0.0",
+		ImagePullSecrets:  []string{"pull-secret"},
+		PriorityClassName: "low-priority",
+	}
+	driver := gpuv1.DriverSpec{
+		Repository: "nvcr.io/nvidia",
+		Image:      "driver",
+		Version:    "580.65.06",
+	}
+	toolkit := gpuv1.ToolkitSpec{
+		Repository: "nvcr.io/nvidia/k8s",
+		Image:      "container-toolkit",
+		Version:    "v1.18.0",
+	}
+	newPrePullDaemonset := func() Daemonset {
+		return NewDaemonset().
+			WithInitContainer(corev1.Container{Name: "driver-image"}).
+			WithInitContainer(corev1.Container{Name: "toolkit-image"}).
+			WithContainer(corev1.Container{Name: "nvidia-image-prepull"}).
+			WithPriorityClass("system-node-critical")
+	}
+
+	testCases := []struct {
+		description             string
+		cpSpec                  *gpuv1.ClusterPolicySpec
+		preStagedKernelVersions map[string]string
+		expectedDs              Daemonset
+	}{
+		{
+			description: "driver and toolkit images",
+			cpSpec:      &gpuv1.ClusterPolicySpec{ImagePrePull: prePull, Driver: driver, Toolkit: toolkit},
+			expectedDs: NewDaemonset().
+				WithInitContainer(corev1.Container{
+					Name:            "driver-image",
+					Image:           "nvcr.io/nvidia/driver:580.65.06-ubuntu24.04",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+				}).
+				WithInitContainer(corev1.Container{
+					Name:            "toolkit-image",
+					Image:           "nvcr.io/nvidia/k8s/container-toolkit:v1.18.0",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+				}).
+				WithContainer(corev1.Container{
+					Name:            "nvidia-image-prepull",
+					Image:           "nvcr.io/nvidia/gpu-operator:v1.0.0",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Env: []corev1.EnvVar{
+						{Name: "PREPULL_DRIVER_IMAGE", Value: "nvcr.io/nvidia/driver:580.65.06-ubuntu24.04"},
+						{Name: "PREPULL_TOOLKIT_IMAGE", Value: "nvcr.io/nvidia/k8s/container-toolkit:v1.18.0"},
+					},
+				}).
+				WithPullSecret("pull-secret").
+				WithPriorityClass("low-priority"),
+		},
+		{
+			description: "pre-compiled driver",
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				ImagePrePull: &gpuv1.ImagePrePullSpec{Enabled: newBoolPtr(true), Repository: "nvcr.io/nvidia", Image: "gpu-operator", Version: "v1.0.0"},
+				Driver: gpuv1.DriverSpec{
+					Repository:     "nvcr.io/nvidia",
+					Image:          "driver",
+					Version:        "580",
+					UsePrecompiled: newBoolPtr(true),
+				},
+				Toolkit: toolkit,
+			},
+			expectedDs: NewDaemonset().
+				WithInitContainer(corev1.Container{
+					Name:            "toolkit-image",
+					Image:           "nvcr.io/nvidia/k8s/container-toolkit:v1.18.0",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+				}).
+				WithContainer(corev1.Container{
+					Name:            "nvidia-image-prepull",
+					Image:           "nvcr.io/nvidia/gpu-operator:v1.0.0",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Env:             []corev1.EnvVar{{Name: "PREPULL_TOOLKIT_IMAGE", Value: "nvcr.io/nvidia/k8s/container-toolkit:v1.18.0"}},
+				}),
+		},
+		{
+			description: "pre-compiled driver of upcoming OS upgrades",
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				ImagePrePull: &gpuv1.ImagePrePullSpec{Enabled: newBoolPtr(true), Repository: "nvcr.io/nvidia", Image: "gpu-operator", Version: "v1.0.0"},
+				Driver: gpuv1.DriverSpec{
+					Repository:          "nvcr.io/nvidia",
+					Image:               "driver",
+					Version:             "580",
+					UsePrecompiled:      newBoolPtr(true),
+					OSUpgradePreStaging: newBoolPtr(true),
+					ImagePullSecrets:    []string{"driver-secret"},
+				},
+				Toolkit: toolkit,
+			},
+			preStagedKernelVersions: map[string]string{
+				"6.8.0-60-generic": "ubuntu24.04",
+				"6.8.0-51-generic": "ubuntu24.04",
+			},
+			expectedDs: NewDaemonset().
+				WithInitContainer(corev1.Container{
+					Name:            "driver-image-os-upgrade-0",
+					Image:           "nvcr.io/nvidia/driver:580-6.8.0-51-generic-ubuntu24.04",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+				}).
+				WithInitContainer(corev1.Container{
+					Name:            "driver-image-os-upgrade-1",
+					Image:           "nvcr.io/nvidia/driver:580-6.8.0-60-generic-ubuntu24.04",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+				}).
+				WithInitContainer(corev1.Container{
+					Name:            "toolkit-image",
+					Image:           "nvcr.io/nvidia/k8s/container-toolkit:v1.18.0",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+				}).
+				WithContainer(corev1.Container{
+					Name:            "nvidia-image-prepull",
+					Image:           "nvcr.io/nvidia/gpu-operator:v1.0.0",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Env:             []corev1.EnvVar{{Name: "PREPULL_TOOLKIT_IMAGE", Value: "nvcr.io/nvidia/k8s/container-toolkit:v1.18.0"}},
+				}).
+				WithPullSecret("driver-secret"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			ds := newPrePullDaemonset()
+			err := TransformImagePrePull(ds.DaemonSet, tc.cpSpec, ClusterPolicyController{logger: ctrl.Log.WithName("test"), gpuNodeOSTag: "ubuntu24.04", preStagedKernelVersions: tc.preStagedKernelVersions})
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedDs, ds)
+		})
+	}
+}
+
+func TestTransformGDSHardwareDetection(t *testing.T) {
+	config := &gpuv1.ClusterPolicySpec{
+		Validator: gpuv1.ValidatorSpec{
+			Repository:       "nvcr.io/nvidia",
+			Image:            "gpu-operator",
+			Version:          "v1.0.0",
+			ImagePullSecrets: []string{"pull-secret"},
+		},
+	}
+	gdsContainer := corev1.Container{
+		Name: "nvidia-fs-ctr",
+		Args: []string{"exec nvidia-gds-driver install"},
+		StartupProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{Exec: &corev1.ExecAction{Command: []string{"sh", "-c", "lsmod | grep nvidia_fs"}}},
+		},
+	}
+	ds := NewDaemonset().WithContainer(gdsContainer)
+
+	err := transformGDSHardwareDetection(ds.DaemonSet, config, &ds.Spec.Template.Spec.Containers[0])
+	require.NoError(t, err)
+
+	expectedDs := NewDaemonset().
+		WithContainer(corev1.Container{
+			Name: "nvidia-fs-ctr",
+			Args: []string{"if [ -f /run/nvidia/validations/gds-unsupported ]; then echo GPUDirect Storage is not supported on this node; exec sleep infinity; fi; exec nvidia-gds-driver install"},
+			StartupProbe: &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{Exec: &corev1.ExecAction{Command: []string{"sh", "-c", "[ -f /run/nvidia/validations/gds-unsupported ] || lsmod | grep nvidia_fs"}}},
+			},
+		}).
+		WithInitContainer(corev1.Container{
+			Name:            "gds-hardware-detection",
+			Image:           "nvcr.io/nvidia/gpu-operator:v1.0.0",
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Command:         []string{"nvidia-validator"},
+			Env: []corev1.EnvVar{
+				{Name: "COMPONENT", Value: "gds-detection"},
+				{Name: "NODE_NAME", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"}}},
+			},
+			VolumeMounts:    []corev1.VolumeMount{{Name: "run-nvidia", MountPath: "/run/nvidia"}},
+			SecurityContext: &corev1.SecurityContext{RunAsUser: rootUID},
+		}).
+		WithPullSecret("pull-secret")
+	require.EqualValues(t, expectedDs, ds)
+}
+
 func TestTransformDriver(t *testing.T) {
 	initMockK8sClients()
 	testCases := []struct {
@@ -3143,7 +4137,92 @@ func TestTransformDriver(t *testing.T) {
 			}
 			require.NoError(t, err)
 
-			require.EqualValues(t, tc.expectedDs, tc.ds)
+			require.EqualValues(t, tc.expectedDs.WithDriverConfigDigest(), tc.ds)
+		})
+	}
+}
+
+func TestTransformToolkitRuntimeRollback(t *testing.T) {
+	toolkitContainer := corev1.Container{
+		Name: "nvidia-container-toolkit-ctr",
+		Env: []corev1.EnvVar{
+			{Name: "RUNTIME_CONFIG", Value: "/runtime/config-dir/config.toml"},
+			{Name: "RUNTIME_DROP_IN_CONFIG", Value: "/runtime/config-dir.d/99-nvidia.toml"},
+			{Name: "RUNTIME_SOCKET", Value: "/runtime/sock-dir/containerd.sock"},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "toolkit-root", MountPath: "/run/nvidia/toolkit"},
+			{Name: "toolkit-install-dir", MountPath: "/usr/local/nvidia"},
+			{Name: "host-root", MountPath: "/host", ReadOnly: true},
+			{Name: "containerd-config", MountPath: "/runtime/config-dir/"},
+			{Name: "containerd-drop-in-config", MountPath: "/runtime/config-dir.d/"},
+			{Name: "containerd-socket", MountPath: "/runtime/sock-dir/"},
+		},
+	}
+	config := &gpuv1.ClusterPolicySpec{
+		Toolkit: gpuv1.ToolkitSpec{
+			RuntimeRollback: &gpuv1.RuntimeRollbackSpec{Enabled: newBoolPtr(true), TimeoutSeconds: 60},
+		},
+		Validator: gpuv1.ValidatorSpec{
+			Repository: "nvcr.io/nvidia",
+			Image:      "gpu-operator",
+			Version:    "v1.0.0",
+		},
+	}
+
+	testCases := []struct {
+		description string
+		runtime     gpuv1.Runtime
+		expectedDs  Daemonset
+	}{
+		{
+			description: "containerd",
+			runtime:     gpuv1.Containerd,
+			expectedDs: NewDaemonset().
+				WithContainer(corev1.Container{
+					Name: "nvidia-container-toolkit-ctr",
+					Env: append(append([]corev1.EnvVar{}, toolkitContainer.Env...),
+						corev1.EnvVar{Name: RuntimeConfigBackupDirEnvName, Value: DefaultRuntimeConfigBackupDir}),
+					VolumeMounts: toolkitContainer.VolumeMounts,
+				}).
+				WithContainer(corev1.Container{
+					Name:            "runtime-rollback",
+					Image:           "nvcr.io/nvidia/gpu-operator:v1.0.0",
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Command:         []string{"nvidia-validator"},
+					Env: []corev1.EnvVar{
+						{Name: "COMPONENT", Value: "runtime-rollback"},
+						{Name: "NODE_NAME", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"}}},
+						{Name: RuntimeConfigBackupDirEnvName, Value: DefaultRuntimeConfigBackupDir},
+						{Name: "ROLLBACK_CONFIG_FILES", Value: "/runtime/config-dir/config.toml,/runtime/config-dir.d/99-nvidia.toml"},
+						{Name: "ROLLBACK_RUNTIME_SOCKET", Value: "/runtime/sock-dir/containerd.sock"},
+						{Name: "ROLLBACK_TIMEOUT", Value: "60s"},
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "toolkit-root", MountPath: "/run/nvidia/toolkit"},
+						{Name: "host-root", MountPath: "/host", ReadOnly: true},
+						{Name: "containerd-config", MountPath: "/runtime/config-dir/"},
+						{Name: "containerd-drop-in-config", MountPath: "/runtime/config-dir.d/"},
+						{Name: "containerd-socket", MountPath: "/runtime/sock-dir/"},
+					},
+					SecurityContext: &corev1.SecurityContext{Privileged: ptr.To(true), RunAsUser: rootUID},
+				}),
+		},
+		{
+			description: "cri-o is not supported",
+			runtime:     gpuv1.CRIO,
+			expectedDs:  NewDaemonset().WithContainer(toolkitContainer),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctr := toolkitContainer.DeepCopy()
+			ds := NewDaemonset().WithContainer(*ctr)
+			n := ClusterPolicyController{runtime: tc.runtime, logger: ctrl.Log.WithName("test")}
+			err := transformToolkitRuntimeRollback(ds.DaemonSet, config, n, &ds.Spec.Template.Spec.Containers[0])
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedDs, ds)
 		})
 	}
 }
@@ -3262,6 +4341,57 @@ func TestTransformDevicePluginCtrForCDI(t *testing.T) {
 	}
 }
 
+func TestTransformSandboxDevicePluginForCDI(t *testing.T) {
+	testCases := []struct {
+		description string
+		cdi         gpuv1.CDIConfigSpec
+		expectedEnv []corev1.EnvVar
+	}{
+		{
+			description: "sandbox cdi not configured",
+			cdi:         gpuv1.CDIConfigSpec{},
+		},
+		{
+			description: "sandbox cdi enabled",
+			cdi: gpuv1.CDIConfigSpec{
+				SandboxEnabled: newBoolPtr(true),
+			},
+			expectedEnv: []corev1.EnvVar{
+				{Name: CDIEnabledEnvName, Value: "true"},
+				{Name: DeviceListStrategyEnvName, Value: "cdi-cri"},
+			},
+		},
+		{
+			description: "sandbox cdi enabled but cdi disabled",
+			cdi: gpuv1.CDIConfigSpec{
+				Enabled:        newBoolPtr(false),
+				SandboxEnabled: newBoolPtr(true),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			ds := NewDaemonset().
+				WithContainer(corev1.Container{Name: "nvidia-sandbox-device-plugin-ctr"})
+			cpSpec := &gpuv1.ClusterPolicySpec{
+				CDI: tc.cdi,
+				SandboxDevicePlugin: gpuv1.SandboxDevicePluginSpec{
+					Repository: "nvcr.io/nvidia",
+					Image:      "kubevirt-gpu-device-plugin",
+					Version:    "v1.0.0",
+				},
+			}
+			err := TransformSandboxDevicePlugin(ds.DaemonSet, cpSpec, ClusterPolicyController{
+				runtime: gpuv1.Containerd,
+				logger:  ctrl.Log.WithName("test"),
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedEnv, ds.Spec.Template.Spec.Containers[0].Env)
+		})
+	}
+}
+
 func TestGetRuntimeConfigFiles(t *testing.T) {
 	testCases := []struct {
 		description                string
@@ -3557,7 +4687,7 @@ func TestTransformDriverWithLicensingConfig(t *testing.T) {
 			}
 			require.NoError(t, err)
 
-			require.EqualValues(t, tc.expectedDs, tc.ds)
+			require.EqualValues(t, tc.expectedDs.WithDriverConfigDigest(), tc.ds)
 		})
 	}
 }
@@ -3689,7 +4819,7 @@ func TestTransformDriverWithResources(t *testing.T) {
 			}
 			require.NoError(t, err)
 
-			require.EqualValues(t, tc.expectedDs, tc.ds)
+			require.EqualValues(t, tc.expectedDs.WithDriverConfigDigest(), tc.ds)
 		})
 	}
 }
@@ -3772,18 +4902,168 @@ func TestTransformDriverRDMA(t *testing.T) {
 				Name:  "USE_HOST_MOFED",
 				Value: "true",
 			},
-		},
-	})
-
-	err := TransformDriver(ds.DaemonSet, cpSpec,
-		ClusterPolicyController{client: mockClient, runtime: gpuv1.Containerd,
-			operatorNamespace: "test-ns", logger: ctrl.Log.WithName("test"), gpuNodeOSRelease: "ubuntu", gpuNodeOSTag: "ubuntu20.04"})
-	require.NoError(t, err)
+		},
+	})
+
+	err := TransformDriver(ds.DaemonSet, cpSpec,
+		ClusterPolicyController{client: mockClient, runtime: gpuv1.Containerd,
+			operatorNamespace: "test-ns", logger: ctrl.Log.WithName("test"), gpuNodeOSRelease: "ubuntu", gpuNodeOSTag: "ubuntu20.04"})
+	require.NoError(t, err)
+
+	require.EqualValues(t, expectedDs.WithDriverConfigDigest(), ds)
+}
+
+func TestTransformDriverVGPUTopologyConfig(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node",
+			Labels: map[string]string{
+				nfdOSReleaseIDLabelKey: "ubuntu",
+				nfdOSVersionIDLabelKey: "20.04",
+				nfdKernelLabelKey:      "6.8.0-60-generic",
+				commonGPULabelKey:      "true",
+			},
+		},
+	}
+	mockClient := fake.NewFakeClient(node)
+	ds := NewDaemonset().WithContainer(corev1.Container{Name: "nvidia-driver-ctr"}).
+		WithInitContainer(corev1.Container{Name: "k8s-driver-manager"})
+	cpSpec := &gpuv1.ClusterPolicySpec{
+		Driver: gpuv1.DriverSpec{
+			Repository: "nvcr.io/nvidia",
+			Image:      "driver",
+			Version:    "570.172.08",
+			Manager: gpuv1.DriverManagerSpec{
+				Repository: "nvcr.io/nvidia/cloud-native",
+				Image:      "k8s-driver-manager",
+				Version:    "v0.8.0",
+			},
+			VirtualTopology: &gpuv1.VirtualTopologyConfigSpec{
+				Config: "sample-topology-config",
+			},
+		},
+	}
+
+	expectedDs := NewDaemonset().WithContainer(corev1.Container{
+		Name:            "nvidia-driver-ctr",
+		Image:           "nvcr.io/nvidia/driver:570.172.08-ubuntu20.04",
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      "topology-config",
+				ReadOnly:  true,
+				MountPath: consts.VGPUTopologyConfigMountPath,
+				SubPath:   consts.VGPUTopologyConfigFileName,
+			},
+		},
+		Env: []corev1.EnvVar{
+			{
+				Name:  "DRIVER_CONFIG_DIGEST",
+				Value: "1340033241",
+			},
+		},
+	}).WithInitContainer(corev1.Container{
+		Name:  "k8s-driver-manager",
+		Image: "nvcr.io/nvidia/cloud-native/k8s-driver-manager:v0.8.0",
+		Env: []corev1.EnvVar{
+			{
+				Name:  "DRIVER_CONFIG_DIGEST",
+				Value: "1340033241",
+			},
+		},
+	}).WithVolume(corev1.Volume{
+		Name: "topology-config",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: "sample-topology-config",
+				},
+				Items: []corev1.KeyToPath{
+					{
+						Key:  consts.VGPUTopologyConfigFileName,
+						Path: consts.VGPUTopologyConfigFileName,
+					},
+				},
+			},
+		},
+	})
+
+	err := TransformDriver(ds.DaemonSet, cpSpec,
+		ClusterPolicyController{client: mockClient, runtime: gpuv1.Containerd,
+			operatorNamespace: "test-ns", logger: ctrl.Log.WithName("test"), gpuNodeOSRelease: "ubuntu", gpuNodeOSTag: "ubuntu20.04"})
+	require.NoError(t, err)
+	require.EqualValues(t, expectedDs.WithDriverConfigDigest(), ds)
+}
+
+func TestTransformDriverKernelModuleParams(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node",
+			Labels: map[string]string{
+				nfdOSReleaseIDLabelKey: "ubuntu",
+				nfdOSVersionIDLabelKey: "20.04",
+				nfdKernelLabelKey:      "6.8.0-60-generic",
+				commonGPULabelKey:      "true",
+			},
+		},
+	}
+	mockClient := fake.NewFakeClient(node)
+	transform := func(kernelModuleConfig *gpuv1.KernelModuleConfigSpec) *appsv1.DaemonSet {
+		ds := NewDaemonset().WithContainer(corev1.Container{Name: "nvidia-driver-ctr"}).
+			WithContainer(corev1.Container{Name: "nvidia-peermem-ctr"}).
+			WithInitContainer(corev1.Container{Name: "k8s-driver-manager"})
+		cpSpec := &gpuv1.ClusterPolicySpec{
+			Driver: gpuv1.DriverSpec{
+				Repository: "nvcr.io/nvidia",
+				Image:      "driver",
+				Version:    "570.172.08",
+				Manager: gpuv1.DriverManagerSpec{
+					Repository: "nvcr.io/nvidia/cloud-native",
+					Image:      "k8s-driver-manager",
+					Version:    "v0.8.0",
+				},
+				GPUDirectRDMA:      &gpuv1.GPUDirectRDMASpec{Enabled: ptr.To(true)},
+				KernelModuleConfig: kernelModuleConfig,
+			},
+		}
+		err := TransformDriver(ds.DaemonSet, cpSpec,
+			ClusterPolicyController{client: mockClient, runtime: gpuv1.Containerd,
+				operatorNamespace: "test-ns", logger: ctrl.Log.WithName("test"), gpuNodeOSRelease: "ubuntu", gpuNodeOSTag: "ubuntu20.04"})
+		require.NoError(t, err)
+		return ds.DaemonSet
+	}
 
-	require.EqualValues(t, expectedDs, ds)
+	ds := transform(&gpuv1.KernelModuleConfigSpec{
+		NVIDIA:        map[string]string{"NVreg_EnableGpuFirmware": "0"},
+		NVIDIAPeermem: map[string]string{"peerdirect_support": "1"},
+	})
+	expectedMounts := []corev1.VolumeMount{
+		{Name: KernelModuleParamsConfigMapName, ReadOnly: true, MountPath: "/drivers/nvidia-peermem.conf", SubPath: "nvidia-peermem.conf"},
+		{Name: KernelModuleParamsConfigMapName, ReadOnly: true, MountPath: "/drivers/nvidia.conf", SubPath: "nvidia.conf"},
+	}
+	for _, name := range []string{"nvidia-driver-ctr", "nvidia-peermem-ctr"} {
+		container := findContainerByName(ds.Spec.Template.Spec.Containers, name)
+		require.NotNil(t, container)
+		for _, mount := range expectedMounts {
+			require.Contains(t, container.VolumeMounts, mount)
+		}
+	}
+	require.Contains(t, ds.Spec.Template.Spec.Volumes, createConfigMapVolume(KernelModuleParamsConfigMapName, []corev1.KeyToPath{
+		{Key: "nvidia-peermem.conf", Path: "nvidia-peermem.conf"},
+		{Key: "nvidia.conf", Path: "nvidia.conf"},
+	}))
+
+	// changing a parameter changes the digest of the driver configuration
+	digest := driverconfig.DriverConfigDigestFromPodSpec(&ds.Spec.Template.Spec)
+	require.NotEmpty(t, digest)
+	ds = transform(&gpuv1.KernelModuleConfigSpec{
+		NVIDIA:        map[string]string{"NVreg_EnableGpuFirmware": "1"},
+		NVIDIAPeermem: map[string]string{"peerdirect_support": "1"},
+	})
+	require.NotEqual(t, digest, driverconfig.DriverConfigDigestFromPodSpec(&ds.Spec.Template.Spec))
 }
 
-func TestTransformDriverVGPUTopologyConfig(t *testing.T) {
+func TestTransformDriverFabricManagerConfig(t *testing.T) {
 	node := &corev1.Node{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "test-node",
@@ -3808,61 +5088,160 @@ func TestTransformDriverVGPUTopologyConfig(t *testing.T) {
 				Image:      "k8s-driver-manager",
 				Version:    "v0.8.0",
 			},
-			VirtualTopology: &gpuv1.VirtualTopologyConfigSpec{
-				Config: "sample-topology-config",
-			},
+		},
+		NVLinkFabric: &gpuv1.NVLinkFabricSpec{
+			Enabled:         newBoolPtr(true),
+			PartitionConfig: "fabric-partitions",
 		},
 	}
 
-	expectedDs := NewDaemonset().WithContainer(corev1.Container{
-		Name:            "nvidia-driver-ctr",
-		Image:           "nvcr.io/nvidia/driver:570.172.08-ubuntu20.04",
-		ImagePullPolicy: corev1.PullIfNotPresent,
-		VolumeMounts: []corev1.VolumeMount{
-			{
-				Name:      "topology-config",
-				ReadOnly:  true,
-				MountPath: consts.VGPUTopologyConfigMountPath,
-				SubPath:   consts.VGPUTopologyConfigFileName,
-			},
-		},
-		Env: []corev1.EnvVar{
-			{
-				Name:  "DRIVER_CONFIG_DIGEST",
-				Value: "1340033241",
-			},
-		},
-	}).WithInitContainer(corev1.Container{
-		Name:  "k8s-driver-manager",
-		Image: "nvcr.io/nvidia/cloud-native/k8s-driver-manager:v0.8.0",
-		Env: []corev1.EnvVar{
-			{
-				Name:  "DRIVER_CONFIG_DIGEST",
-				Value: "1340033241",
-			},
-		},
-	}).WithVolume(corev1.Volume{
-		Name: "topology-config",
+	err := TransformDriver(ds.DaemonSet, cpSpec,
+		ClusterPolicyController{client: mockClient, runtime: gpuv1.Containerd,
+			operatorNamespace: "test-ns", logger: ctrl.Log.WithName("test"), gpuNodeOSRelease: "ubuntu", gpuNodeOSTag: "ubuntu20.04"})
+	require.NoError(t, err)
+
+	driverContainer := findContainerByName(ds.Spec.Template.Spec.Containers, "nvidia-driver-ctr")
+	require.Contains(t, driverContainer.VolumeMounts, corev1.VolumeMount{
+		Name:      "fabric-manager-config",
+		ReadOnly:  true,
+		MountPath: consts.FabricManagerConfigMountPath,
+		SubPath:   consts.FabricManagerConfigFileName,
+	})
+	require.Contains(t, ds.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "fabric-manager-config",
 		VolumeSource: corev1.VolumeSource{
 			ConfigMap: &corev1.ConfigMapVolumeSource{
 				LocalObjectReference: corev1.LocalObjectReference{
-					Name: "sample-topology-config",
+					Name: "fabric-partitions",
 				},
 				Items: []corev1.KeyToPath{
 					{
-						Key:  consts.VGPUTopologyConfigFileName,
-						Path: consts.VGPUTopologyConfigFileName,
+						Key:  consts.FabricManagerConfigFileName,
+						Path: consts.FabricManagerConfigFileName,
 					},
 				},
 			},
 		},
 	})
+}
 
-	err := TransformDriver(ds.DaemonSet, cpSpec,
-		ClusterPolicyController{client: mockClient, runtime: gpuv1.Containerd,
-			operatorNamespace: "test-ns", logger: ctrl.Log.WithName("test"), gpuNodeOSRelease: "ubuntu", gpuNodeOSTag: "ubuntu20.04"})
-	require.NoError(t, err)
-	require.EqualValues(t, expectedDs, ds)
+func TestTransformDriverModuleCache(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node",
+			Labels: map[string]string{
+				nfdOSReleaseIDLabelKey: "ubuntu",
+				nfdOSVersionIDLabelKey: "20.04",
+				nfdKernelLabelKey:      "6.8.0-60-generic",
+				commonGPULabelKey:      "true",
+			},
+		},
+	}
+
+	testCases := []struct {
+		description string
+		moduleCache *gpuv1.DriverModuleCacheSpec
+		expectedEnv map[string]string
+		expectAuth  bool
+	}{
+		{
+			description: "module cache not configured",
+		},
+		{
+			description: "module cache disabled",
+			moduleCache: &gpuv1.DriverModuleCacheSpec{
+				Enabled:    ptr.To(false),
+				Repository: "registry.local:5000/nvidia/driver-modules",
+			},
+		},
+		{
+			description: "module cache enabled without credentials",
+			moduleCache: &gpuv1.DriverModuleCacheSpec{
+				Enabled:    ptr.To(true),
+				Repository: "registry.local:5000/nvidia/driver-modules",
+			},
+			expectedEnv: map[string]string{
+				ModuleCacheEnabledEnvName:    "true",
+				ModuleCacheRepositoryEnvName: "registry.local:5000/nvidia/driver-modules",
+			},
+		},
+		{
+			description: "module cache enabled with credentials",
+			moduleCache: &gpuv1.DriverModuleCacheSpec{
+				Enabled:    ptr.To(true),
+				Repository: "registry.local:5000/nvidia/driver-modules",
+				SecretName: "module-cache-creds",
+			},
+			expectedEnv: map[string]string{
+				ModuleCacheEnabledEnvName:    "true",
+				ModuleCacheRepositoryEnvName: "registry.local:5000/nvidia/driver-modules",
+				ModuleCacheAuthFileEnvName:   consts.ModuleCacheAuthMountPath,
+			},
+			expectAuth: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			mockClient := fake.NewFakeClient(node)
+			ds := NewDaemonset().WithContainer(corev1.Container{Name: "nvidia-driver-ctr"}).
+				WithInitContainer(corev1.Container{Name: "k8s-driver-manager"})
+			cpSpec := &gpuv1.ClusterPolicySpec{
+				Driver: gpuv1.DriverSpec{
+					Repository: "nvcr.io/nvidia",
+					Image:      "driver",
+					Version:    "570.172.08",
+					Manager: gpuv1.DriverManagerSpec{
+						Repository: "nvcr.io/nvidia/cloud-native",
+						Image:      "k8s-driver-manager",
+						Version:    "v0.8.0",
+					},
+					ModuleCache: tc.moduleCache,
+				},
+			}
+
+			err := TransformDriver(ds.DaemonSet, cpSpec,
+				ClusterPolicyController{client: mockClient, runtime: gpuv1.Containerd,
+					operatorNamespace: "test-ns", logger: ctrl.Log.WithName("test"), gpuNodeOSRelease: "ubuntu", gpuNodeOSTag: "ubuntu20.04"})
+			require.NoError(t, err)
+
+			driverContainer := findContainerByName(ds.Spec.Template.Spec.Containers, "nvidia-driver-ctr")
+			require.NotNil(t, driverContainer)
+
+			actualEnv := make(map[string]string)
+			for _, env := range driverContainer.Env {
+				switch env.Name {
+				case ModuleCacheEnabledEnvName, ModuleCacheRepositoryEnvName, ModuleCacheAuthFileEnvName:
+					actualEnv[env.Name] = env.Value
+				}
+			}
+			if tc.expectedEnv == nil {
+				require.Empty(t, actualEnv)
+			} else {
+				require.Equal(t, tc.expectedEnv, actualEnv)
+			}
+
+			var authVolume *corev1.Volume
+			for i := range ds.Spec.Template.Spec.Volumes {
+				if ds.Spec.Template.Spec.Volumes[i].Name == "module-cache-auth" {
+					authVolume = &ds.Spec.Template.Spec.Volumes[i]
+				}
+			}
+			if !tc.expectAuth {
+				require.Nil(t, authVolume)
+				return
+			}
+			require.NotNil(t, authVolume)
+			require.NotNil(t, authVolume.Secret)
+			require.Equal(t, tc.moduleCache.SecretName, authVolume.Secret.SecretName)
+			require.Contains(t, driverContainer.VolumeMounts, corev1.VolumeMount{
+				Name:      "module-cache-auth",
+				ReadOnly:  true,
+				MountPath: consts.ModuleCacheAuthMountPath,
+				SubPath:   consts.ModuleCacheAuthFileName,
+			})
+		})
+	}
 }
 
 func TestTransformGPUDiscoveryPlugin(t *testing.T) {
@@ -4301,7 +5680,7 @@ func TestTransformDriverWithAdditionalConfig(t *testing.T) {
 			}
 			require.NoError(t, err)
 
-			require.EqualValues(t, tc.expectedDs, tc.ds)
+			require.EqualValues(t, tc.expectedDs.WithDriverConfigDigest(), tc.ds)
 		})
 	}
 }
@@ -4509,6 +5888,9 @@ func TestDriverConfigDigest(t *testing.T) {
 		{"priority class", false, func(s *appsv1.DaemonSetSpec) {
 			s.Template.Spec.PriorityClassName = "custom-priority"
 		}},
+		{"scheduler name", false, func(s *appsv1.DaemonSetSpec) {
+			s.Template.Spec.SchedulerName = "custom-scheduler"
+		}},
 		{"service account", false, func(s *appsv1.DaemonSetSpec) {
 			s.Template.Spec.ServiceAccountName = "different-sa"
 		}},
@@ -4703,3 +6085,402 @@ func TestHashDriverInstallConfigZeroFieldInvariant(t *testing.T) {
 	assert.NotEqual(t, originalDigest, changedDigest,
 		"a non-zero new field should change the digest")
 }
+
+func TestRenderTimeSlicingConfig(t *testing.T) {
+	data, err := renderTimeSlicingConfig(&gpuv1.TimeSlicingConfig{
+		RenameByDefault: true,
+		Resources: []gpuv1.TimeSlicingResource{
+			{Name: "nvidia.com/gpu", Replicas: 4},
+		},
+	})
+	require.NoError(t, err)
+	expected := `sharing:
+  timeSlicing:
+    renameByDefault: true
+    resources:
+    - name: nvidia.com/gpu
+      replicas: 4
+version: v1
+`
+	require.Equal(t, expected, data)
+}
+
+func TestRenderSharingConfigMPS(t *testing.T) {
+	data, err := renderSharingConfig(&gpuv1.DevicePluginSpec{
+		MPS: &gpuv1.MPSConfig{
+			Resources: []gpuv1.MPSResource{
+				{Name: "nvidia.com/gpu", Replicas: 4},
+			},
+		},
+	})
+	require.NoError(t, err)
+	expected := `sharing:
+  mps:
+    resources:
+    - name: nvidia.com/gpu
+      replicas: 4
+version: v1
+`
+	require.Equal(t, expected, data)
+}
+
+func TestRenderVGPUDevicesConfig(t *testing.T) {
+	defaultConfig := `version: v1
+vgpu-configs:
+    A100-4C:
+        - devices: all
+          vgpu-devices:
+            A100-4C: 10
+    default:
+        - devices: [0, 1]
+          vgpu-devices:
+            A100-1C: 40
+`
+	data, err := renderVGPUDevicesConfig(defaultConfig, []gpuv1.VGPUProfileSpec{
+		{Name: "inference", NodeSelector: map[string]string{"pool": "inference"}, VGPUDevices: map[string]int32{"A100-4C": 10}},
+		{Name: "default", VGPUDevices: map[string]int32{"A100-2C": 20}},
+	})
+	require.NoError(t, err)
+	expected := `version: v1
+vgpu-configs:
+  A100-4C:
+  - devices: all
+    vgpu-devices:
+      A100-4C: 10
+  default:
+  - devices: all
+    vgpu-devices:
+      A100-2C: 20
+  inference:
+  - devices: all
+    vgpu-devices:
+      A100-4C: 10
+`
+	require.Equal(t, expected, data)
+}
+
+func TestTransformVGPUDeviceManagerProfiles(t *testing.T) {
+	profiles := []gpuv1.VGPUProfileSpec{
+		{Name: "inference", NodeSelector: map[string]string{"pool": "inference"}, VGPUDevices: map[string]int32{"A100-4C": 10}},
+	}
+	newDs := func() Daemonset {
+		return NewDaemonset().
+			WithContainer(corev1.Container{Name: "nvidia-vgpu-device-manager"}).
+			WithConfigMapVolume("vgpu-config", "", 0)
+	}
+	cpSpec := &gpuv1.ClusterPolicySpec{
+		VGPUDeviceManager: gpuv1.VGPUDeviceManagerSpec{
+			Repository: "nvcr.io/nvidia/cloud-native",
+			Image:      "vgpu-device-manager",
+			Version:    "v1.0.0",
+			Profiles:   profiles,
+		},
+	}
+
+	ds := newDs()
+	require.NoError(t, TransformVGPUDeviceManager(ds.DaemonSet, cpSpec, ClusterPolicyController{logger: ctrl.Log.WithName("test")}))
+	require.Equal(t, VgpuDMDefaultConfigMapName, ds.Spec.Template.Spec.Volumes[0].ConfigMap.Name)
+	applyConfigDigest(ds.DaemonSet, cpSpec)
+	digest := ds.Spec.Template.Annotations[driverconfig.RenderedConfigDigestAnnotationKey]
+	require.NotEmpty(t, digest)
+
+	// a change of the profiles changes the digest, restarting the pods
+	cpSpec.VGPUDeviceManager.Profiles[0].VGPUDevices["A100-4C"] = 8
+	ds = newDs()
+	require.NoError(t, TransformVGPUDeviceManager(ds.DaemonSet, cpSpec, ClusterPolicyController{logger: ctrl.Log.WithName("test")}))
+	applyConfigDigest(ds.DaemonSet, cpSpec)
+	require.NotEqual(t, digest, ds.Spec.Template.Annotations[driverconfig.RenderedConfigDigestAnnotationKey])
+}
+
+func TestHandleDevicePluginConfigTimeSlicing(t *testing.T) {
+	timeSlicing := &gpuv1.TimeSlicingConfig{
+		Resources: []gpuv1.TimeSlicingResource{{Name: "nvidia.com/gpu", Replicas: 2}},
+	}
+	newDs := func() Daemonset {
+		return NewDaemonset().
+			WithContainer(corev1.Container{Name: "nvidia-device-plugin"}).
+			WithContainer(corev1.Container{Name: "config-manager"}).
+			WithInitContainer(corev1.Container{Name: "config-manager-init"})
+	}
+
+	t.Run("time-slicing config is rendered into the operator managed ConfigMap", func(t *testing.T) {
+		ds := newDs()
+		cpSpec := &gpuv1.ClusterPolicySpec{
+			DevicePlugin: gpuv1.DevicePluginSpec{
+				Repository:  "nvcr.io/nvidia",
+				Image:       "k8s-device-plugin",
+				Version:     "v0.18.1",
+				TimeSlicing: timeSlicing,
+			},
+		}
+		require.NoError(t, handleDevicePluginConfig(ds.DaemonSet, cpSpec))
+
+		podSpec := ds.Spec.Template.Spec
+		require.Contains(t, podSpec.Volumes, createConfigMapVolume(TimeSlicingConfigMapName, nil))
+		require.Equal(t, "/config/config.yaml", getContainerEnv(&podSpec.Containers[0], "CONFIG_FILE"))
+		require.Equal(t, TimeSlicingDefaultConfigName, getContainerEnv(&podSpec.Containers[1], "DEFAULT_CONFIG"))
+		require.Equal(t, TimeSlicingDefaultConfigName, getContainerEnv(&podSpec.InitContainers[0], "DEFAULT_CONFIG"))
+
+		applyConfigDigest(ds.DaemonSet, cpSpec)
+		digest := ds.Spec.Template.Annotations[driverconfig.RenderedConfigDigestAnnotationKey]
+		require.NotEmpty(t, digest)
+
+		// a change of the time-slicing configuration changes the digest, restarting the pods
+		ds = newDs()
+		cpSpec.DevicePlugin.TimeSlicing = &gpuv1.TimeSlicingConfig{
+			Resources: []gpuv1.TimeSlicingResource{{Name: "nvidia.com/gpu", Replicas: 4}},
+		}
+		require.NoError(t, handleDevicePluginConfig(ds.DaemonSet, cpSpec))
+		applyConfigDigest(ds.DaemonSet, cpSpec)
+		require.NotEqual(t, digest, ds.Spec.Template.Annotations[driverconfig.RenderedConfigDigestAnnotationKey])
+	})
+
+	t.Run("custom ConfigMap takes precedence over time-slicing config", func(t *testing.T) {
+		ds := newDs()
+		cpSpec := &gpuv1.ClusterPolicySpec{
+			DevicePlugin: gpuv1.DevicePluginSpec{
+				Repository:  "nvcr.io/nvidia",
+				Image:       "k8s-device-plugin",
+				Version:     "v0.18.1",
+				Config:      &gpuv1.DevicePluginConfig{Name: "custom-config", Default: "default"},
+				TimeSlicing: timeSlicing,
+			},
+		}
+		require.NoError(t, handleDevicePluginConfig(ds.DaemonSet, cpSpec))
+
+		podSpec := ds.Spec.Template.Spec
+		require.Contains(t, podSpec.Volumes, createConfigMapVolume("custom-config", nil))
+		require.Equal(t, "default", getContainerEnv(&podSpec.Containers[1], "DEFAULT_CONFIG"))
+		require.Empty(t, renderedOperandConfig(&podSpec, cpSpec))
+	})
+}
+
+func TestSetToolkitValidationRuntimeConfig(t *testing.T) {
+	testCases := []struct {
+		description string
+		runtime     gpuv1.Runtime
+		cpSpec      *gpuv1.ClusterPolicySpec
+		expectedEnv []corev1.EnvVar
+	}{
+		{
+			description: "containerd with CDI enabled",
+			runtime:     gpuv1.Containerd,
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				CDI: gpuv1.CDIConfigSpec{Enabled: newBoolPtr(true)},
+			},
+			expectedEnv: []corev1.EnvVar{
+				{Name: "RUNTIME", Value: "containerd"},
+				{Name: "RUNTIME_CONFIG", Value: DefaultContainerdConfigFile},
+				{Name: "RUNTIME_DROP_IN_CONFIG", Value: DefaultContainerdDropInConfigFile},
+				{Name: "NVIDIA_RUNTIME_HANDLER", Value: DefaultRuntimeClass},
+				{Name: NvidiaRuntimeSetAsDefaultEnvName, Value: "false"},
+				{Name: CDIEnabledEnvName, Value: "true"},
+			},
+		},
+		{
+			description: "containerd with a custom runtime class and config file",
+			runtime:     gpuv1.Containerd,
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				Operator: gpuv1.OperatorSpec{RuntimeClass: "nvidia-custom"},
+				CDI:      gpuv1.CDIConfigSpec{Enabled: newBoolPtr(false)},
+				Toolkit: gpuv1.ToolkitSpec{
+					Env: []gpuv1.EnvVar{{Name: "CONTAINERD_CONFIG", Value: "/var/lib/rancher/k3s/agent/etc/containerd/config.toml"}},
+				},
+			},
+			expectedEnv: []corev1.EnvVar{
+				{Name: "RUNTIME", Value: "containerd"},
+				{Name: "RUNTIME_CONFIG", Value: "/var/lib/rancher/k3s/agent/etc/containerd/config.toml"},
+				{Name: "RUNTIME_DROP_IN_CONFIG", Value: DefaultContainerdDropInConfigFile},
+				{Name: "NVIDIA_RUNTIME_HANDLER", Value: "nvidia-custom"},
+				{Name: NvidiaRuntimeSetAsDefaultEnvName, Value: "true"},
+				{Name: CDIEnabledEnvName, Value: "false"},
+			},
+		},
+		{
+			description: "cri-o without CDI uses the OCI hook and is not verified",
+			runtime:     gpuv1.CRIO,
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				CDI: gpuv1.CDIConfigSpec{Enabled: newBoolPtr(false)},
+			},
+		},
+		{
+			description: "cri-o in drop-in config mode is verified against the drop-in file",
+			runtime:     gpuv1.CRIO,
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				CDI:     gpuv1.CDIConfigSpec{Enabled: newBoolPtr(false)},
+				Toolkit: gpuv1.ToolkitSpec{CRIOConfigMode: gpuv1.CRIOConfigModeDropIn},
+			},
+			expectedEnv: []corev1.EnvVar{
+				{Name: "RUNTIME", Value: "crio"},
+				{Name: "RUNTIME_CONFIG", Value: DefaultCRIOConfigFile},
+				{Name: "RUNTIME_DROP_IN_CONFIG", Value: DefaultCRIODropInConfigFile},
+				{Name: "NVIDIA_RUNTIME_HANDLER", Value: DefaultRuntimeClass},
+				{Name: NvidiaRuntimeSetAsDefaultEnvName, Value: "true"},
+				{Name: CDIEnabledEnvName, Value: "false"},
+				{Name: "RUNTIME_DROP_IN_ONLY", Value: "true"},
+			},
+		},
+		{
+			description: "NRI plugin mode leaves the runtime config untouched and is not verified",
+			runtime:     gpuv1.Containerd,
+			cpSpec: &gpuv1.ClusterPolicySpec{
+				CDI: gpuv1.CDIConfigSpec{Enabled: newBoolPtr(true), NRIPluginEnabled: newBoolPtr(true)},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			container := &corev1.Container{Name: "toolkit-validation"}
+			err := setToolkitValidationRuntimeConfig(container, tc.cpSpec, tc.runtime)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedEnv, container.Env)
+		})
+	}
+}
+
+func TestRenderDownloadCacheConfig(t *testing.T) {
+	data := renderDownloadCacheConfig(&gpuv1.DownloadCacheSpec{
+		StorageSize: ptr.To(resource.MustParse("10Gi")),
+	})
+	require.Contains(t, data, "http_port 3128\n")
+	require.Contains(t, data, "cache_dir ufs /var/spool/squid 9216 16 256\n")
+	require.NotContains(t, data, "cache_peer")
+
+	data = renderDownloadCacheConfig(&gpuv1.DownloadCacheSpec{UpstreamProxy: "proxy.corp.local:8080"})
+	require.Contains(t, data, "cache_dir ufs /var/spool/squid 18432 16 256\n")
+	require.Contains(t, data, "cache_peer proxy.corp.local parent 8080 0 no-query default\nnever_direct allow all\n")
+}
+
+func TestTransformDownloadCache(t *testing.T) {
+	newDeployment := func() *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: DownloadCacheName},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: DownloadCacheName}},
+						Volumes: []corev1.Volume{
+							{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{}}},
+							{Name: "cache", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+						},
+					},
+				},
+			},
+		}
+	}
+	spec := gpuv1.DownloadCacheSpec{
+		Enabled:         ptr.To(true),
+		Repository:      "docker.io/ubuntu",
+		Image:           "squid",
+		Version:         "6.6-24.04_beta",
+		ImagePullPolicy: "Always",
+		Env:             []gpuv1.EnvVar{{Name: "TZ", Value: "UTC"}},
+	}
+
+	t.Run("emptyDir cache", func(t *testing.T) {
+		obj := newDeployment()
+		err := TransformDownloadCache(obj, &gpuv1.ClusterPolicySpec{DownloadCache: &spec}, ClusterPolicyController{logger: ctrl.Log.WithName("test")})
+		require.NoError(t, err)
+
+		container := obj.Spec.Template.Spec.Containers[0]
+		require.Equal(t, "docker.io/ubuntu/squid:6.6-24.04_beta", container.Image)
+		require.Equal(t, corev1.PullAlways, container.ImagePullPolicy)
+		require.Equal(t, []corev1.EnvVar{{Name: "TZ", Value: "UTC"}}, container.Env)
+		require.NotNil(t, obj.Spec.Template.Spec.Volumes[1].EmptyDir)
+		require.Equal(t, resource.MustParse("20Gi"), *obj.Spec.Template.Spec.Volumes[1].EmptyDir.SizeLimit)
+		require.Equal(t, utils.GetObjectHash(renderDownloadCacheConfig(&spec)),
+			obj.Spec.Template.Annotations[DownloadCacheConfigDigestAnnotationKey])
+	})
+
+	t.Run("PersistentVolumeClaim cache", func(t *testing.T) {
+		pvcSpec := spec
+		pvcSpec.PersistentVolumeClaim = "download-cache"
+		obj := newDeployment()
+		err := TransformDownloadCache(obj, &gpuv1.ClusterPolicySpec{DownloadCache: &pvcSpec}, ClusterPolicyController{logger: ctrl.Log.WithName("test")})
+		require.NoError(t, err)
+		require.Nil(t, obj.Spec.Template.Spec.Volumes[1].EmptyDir)
+		require.Equal(t, &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "download-cache"},
+			obj.Spec.Template.Spec.Volumes[1].PersistentVolumeClaim)
+	})
+
+	t.Run("missing image", func(t *testing.T) {
+		err := TransformDownloadCache(newDeployment(), &gpuv1.ClusterPolicySpec{DownloadCache: &gpuv1.DownloadCacheSpec{}},
+			ClusterPolicyController{logger: ctrl.Log.WithName("test")})
+		require.Error(t, err)
+	})
+}
+
+func TestTransformNodeFeatureDiscovery(t *testing.T) {
+	spec := &gpuv1.ClusterPolicySpec{
+		NodeFeatureDiscovery: &gpuv1.NodeFeatureDiscoverySpec{
+			Managed:          ptr.To(true),
+			Repository:       "registry.k8s.io/nfd",
+			Image:            "node-feature-discovery",
+			Version:          "v0.19.0",
+			ImagePullSecrets: []string{"pull-secret"},
+		},
+	}
+	newDeployment := func() *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: NodeFeatureDiscoveryMasterName},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "nfd-master"}}},
+				},
+			},
+		}
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+	newController := func(objs ...client.Object) ClusterPolicyController {
+		return ClusterPolicyController{
+			ctx:    context.Background(),
+			client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+			logger: ctrl.Log.WithName("test"),
+		}
+	}
+
+	t.Run("master without the NodeFeature CRD", func(t *testing.T) {
+		err := TransformNodeFeatureDiscoveryMaster(newDeployment(), spec, newController())
+		require.ErrorContains(t, err, NodeFeatureCRDName)
+	})
+
+	t.Run("master", func(t *testing.T) {
+		crd := &apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: NodeFeatureCRDName}}
+		obj := newDeployment()
+		err := TransformNodeFeatureDiscoveryMaster(obj, spec, newController(crd))
+		require.NoError(t, err)
+		require.Equal(t, "registry.k8s.io/nfd/node-feature-discovery:v0.19.0", obj.Spec.Template.Spec.Containers[0].Image)
+		require.Equal(t, []corev1.LocalObjectReference{{Name: "pull-secret"}}, obj.Spec.Template.Spec.ImagePullSecrets)
+	})
+
+	t.Run("worker", func(t *testing.T) {
+		ds := NewDaemonset().WithContainer(corev1.Container{Name: "nfd-worker"})
+		err := TransformNodeFeatureDiscoveryWorker(ds.DaemonSet, spec, newController())
+		require.NoError(t, err)
+		require.Equal(t, "registry.k8s.io/nfd/node-feature-discovery:v0.19.0", ds.Spec.Template.Spec.Containers[0].Image)
+		require.Equal(t, corev1.PullIfNotPresent, ds.Spec.Template.Spec.Containers[0].ImagePullPolicy)
+	})
+
+	t.Run("worker without image", func(t *testing.T) {
+		ds := NewDaemonset().WithContainer(corev1.Container{Name: "nfd-worker"})
+		err := TransformNodeFeatureDiscoveryWorker(ds.DaemonSet, &gpuv1.ClusterPolicySpec{}, newController())
+		require.Error(t, err)
+	})
+}
+func TestApplyDownloadCacheProxyEnv(t *testing.T) {
+	container := &corev1.Container{Env: []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "http://proxy.corp.local:8080"}}}
+	applyDownloadCacheProxyEnv(container, "gpu-operator")
+
+	env := make(map[string]string)
+	for _, e := range container.Env {
+		env[e.Name] = e.Value
+	}
+	require.Len(t, container.Env, 6)
+	for _, name := range []string{"HTTP_PROXY", "HTTPS_PROXY", "http_proxy", "https_proxy"} {
+		require.Equal(t, "http://nvidia-download-cache.gpu-operator.svc:3128", env[name])
+	}
+	require.Equal(t, "localhost,127.0.0.1,.svc,.cluster.local", env["NO_PROXY"])
+	require.Equal(t, "localhost,127.0.0.1,.svc,.cluster.local", env["no_proxy"])
+}
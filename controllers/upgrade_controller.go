@@ -22,6 +22,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -47,7 +48,12 @@ import (
 
 	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
 	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/apistats"
+	"github.com/NVIDIA/gpu-operator/internal/audit"
 	gpuconsts "github.com/NVIDIA/gpu-operator/internal/consts"
+	"github.com/NVIDIA/gpu-operator/internal/drain"
+	"github.com/NVIDIA/gpu-operator/internal/nodepolicy"
+	"github.com/NVIDIA/gpu-operator/internal/tracing"
 )
 
 // UpgradeReconciler reconciles Driver Daemon Sets for upgrade
@@ -57,6 +63,17 @@ type UpgradeReconciler struct {
 	Scheme          *runtime.Scheme
 	StateManager    upgrade.ClusterUpgradeStateManager
 	OperatorMetrics *OperatorMetrics
+	// DrainManager drains the nodes according to the ClusterPolicy drain policy, when set as the
+	// drain manager of the StateManager
+	DrainManager *drain.Manager
+	// APIReader reads the pods of all namespaces to estimate the GPU workloads impacted by the upgrades,
+	// the client is used if not set
+	APIReader client.Reader
+	// APIStats counts the requests of the reconciliations, nothing is counted when nil
+	APIStats *apistats.Tracker
+	// NodeMutationPolicy is the set of node fields the operator is allowed to mutate, the automatic driver
+	// upgrades are disabled when the nodes cannot be cordoned
+	NodeMutationPolicy nodepolicy.Policy
 }
 
 const (
@@ -71,25 +88,33 @@ const (
 	AppComponentLabelKey = "app.kubernetes.io/component"
 	// DriverAppComponentLabelValue indicates the label value of the NVIDIA driver component
 	DriverAppComponentLabelValue = "nvidia-driver"
+	// VGPUManagerAppComponentLabelValue indicates the label value of the NVIDIA vGPU manager component
+	VGPUManagerAppComponentLabelValue = "nvidia-vgpu-manager"
 )
 
 //nolint
 // +kubebuilder:rbac:groups=mellanox.com,resources=*,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups="",resources=pods,verbs=list
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=list
 // +kubebuilder:rbac:groups=resource.k8s.io,resources=resourceclaims,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,resources=deployments;daemonsets;replicasets;statefulsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments/finalizers,verbs=update
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
-func (r *UpgradeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *UpgradeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	reqLogger := r.Log.WithValues("upgrade", req.NamespacedName)
 	reqLogger.V(consts.LogLevelInfo).Info("Reconciling Upgrade")
+	ctx = audit.WithReason(ctx, "driver upgrade")
+	ctx, done := r.APIStats.StartReconcile(ctx, "Upgrade")
+	defer done()
+	ctx, span := tracing.Start(ctx, "reconcile driver upgrade")
+	defer func() { tracing.End(span, err) }()
 
 	// Fetch the ClusterPolicy instance
 	clusterPolicy := &gpuv1.ClusterPolicy{}
-	err := r.Get(ctx, req.NamespacedName, clusterPolicy)
+	err = r.Get(ctx, req.NamespacedName, clusterPolicy)
 	if err != nil {
 		reqLogger.Error(err, "Error getting ClusterPolicy object")
 		r.OperatorMetrics.reconciliationStatus.Set(reconciliationStatusClusterPolicyUnavailable)
@@ -103,17 +128,43 @@ func (r *UpgradeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return reconcile.Result{}, err
 	}
 
+	if clusterPolicy.Status.SafeMode.IsActive() {
+		reqLogger.Info("Safe mode is active, driver upgrades are paused until it is acknowledged")
+		return ctrl.Result{Requeue: true, RequeueAfter: plannedRequeueInterval}, nil
+	}
+
+	if !r.NodeMutationPolicy.AllowsSpec() {
+		reqLogger.V(consts.LogLevelInfo).Info("Automatic driver upgrades are disabled as the node mutation policy does "+
+			"not allow cordoning the nodes", "policy", r.NodeMutationPolicy)
+		r.OperatorMetrics.driverAutoUpgradeEnabled.Set(driverAutoUpgradeDisabled)
+		return ctrl.Result{}, nil
+	}
+
 	if clusterPolicy.Spec.SandboxWorkloads.IsEnabled() {
+		if clusterPolicy.Spec.VGPUManager.IsEnabled() &&
+			clusterPolicy.Spec.Driver.IsAutoUpgradeEnabledFor(gpuv1.DriverUpgradeTypeVGPUHostManager) {
+			return r.reconcileVGPUHostManagerUpgrades(ctx, reqLogger, clusterPolicy)
+		}
 		reqLogger.V(consts.LogLevelInfo).Info("Advanced driver upgrade policy is not supported when 'sandboxWorkloads.enabled=true'" +
 			"in ClusterPolicy, cleaning up upgrade state and skipping reconciliation")
 		r.OperatorMetrics.driverAutoUpgradeEnabled.Set(driverAutoUpgradeDisabled)
+		r.clearDriverUpgradeStatus(ctx, clusterPolicy)
+		r.removeDriverUpgrades(ctx)
 		return ctrl.Result{}, r.removeNodeUpgradeStateLabels(ctx)
 	}
 
+	if r.DrainManager != nil {
+		r.DrainManager.SetPolicy(clusterPolicy.Spec.Driver.DrainPolicy)
+		if err := r.DrainManager.ClearCompletedReboots(ctx); err != nil {
+			reqLogger.Error(err, "Failed to clear the reboot-required label of rebooted nodes")
+		}
+	}
+
 	// TODO: When integrating the NVIDIA DRA Driver for GPUs, decouple
 	// the driver-upgrade controller from ClusterPolicy. If a ClusterPolicy
 	// CR does not exist, take the NVIDIADriver code path.
 	if clusterPolicy.Spec.Driver.UseNvidiaDriverCRDType() {
+		r.clearDriverUpgradeStatus(ctx, clusterPolicy)
 		return r.reconcileNVIDIADriverUpgrades(ctx, reqLogger)
 	}
 
@@ -121,12 +172,17 @@ func (r *UpgradeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 }
 
 // reconcileClusterPolicyDriverUpgrades handles driver upgrade reconciliation when the
-// ClusterPolicy CR is used for driver management.
+// ClusterPolicy CR is used for driver management. The nodes running the vGPU guest driver are
+// upgraded with the upgrade policy of the vgpu driver type, the other nodes with the policy of the
+// gpu driver type.
 func (r *UpgradeReconciler) reconcileClusterPolicyDriverUpgrades(ctx context.Context, reqLogger logr.Logger, clusterPolicy *gpuv1.ClusterPolicy) (ctrl.Result, error) {
-	if clusterPolicy.Spec.Driver.UpgradePolicy == nil ||
-		!clusterPolicy.Spec.Driver.UpgradePolicy.AutoUpgrade {
+	driverTypes := []gpuv1.DriverUpgradeType{gpuv1.DriverUpgradeTypeGPU, gpuv1.DriverUpgradeTypeVGPU}
+	if !clusterPolicy.Spec.Driver.IsAutoUpgradeEnabledFor(gpuv1.DriverUpgradeTypeGPU) &&
+		!clusterPolicy.Spec.Driver.IsAutoUpgradeEnabledFor(gpuv1.DriverUpgradeTypeVGPU) {
 		reqLogger.V(consts.LogLevelInfo).Info("Advanced driver upgrade policy is disabled, cleaning up upgrade state and skipping reconciliation")
 		r.OperatorMetrics.driverAutoUpgradeEnabled.Set(driverAutoUpgradeDisabled)
+		r.clearDriverUpgradeStatus(ctx, clusterPolicy)
+		r.removeDriverUpgrades(ctx)
 		return ctrl.Result{}, r.removeNodeUpgradeStateLabels(ctx)
 	}
 	r.OperatorMetrics.driverAutoUpgradeEnabled.Set(driverAutoUpgradeEnabled)
@@ -157,41 +213,115 @@ func (r *UpgradeReconciler) reconcileClusterPolicyDriverUpgrades(ctx context.Con
 	reqLogger.Info("Propagate state to state manager")
 	reqLogger.V(consts.LogLevelDebug).Info("Current cluster upgrade state", "state", state)
 
-	totalNodes := r.StateManager.GetTotalManagedNodes(state)
-	maxUnavailable := totalNodes
-	if clusterPolicy.Spec.Driver.UpgradePolicy != nil && clusterPolicy.Spec.Driver.UpgradePolicy.MaxUnavailable != nil {
-		maxUnavailable, err = intstr.GetScaledValueFromIntOrPercent(clusterPolicy.Spec.Driver.UpgradePolicy.MaxUnavailable, totalNodes, true)
-		if err != nil {
-			r.Log.Error(err, "Failed to compute maxUnavailable from the current total nodes")
-			return ctrl.Result{}, err
-		}
-	}
+	statesByType := partitionUpgradeStateByDriverType(state)
+	return r.applyDriverUpgradePolicies(ctx, reqLogger, clusterPolicy, driverTypes, statesByType)
+}
 
-	// We want to skip operator itself during the drain because the upgrade process might hang
-	// if the operator is evicted and can't be rescheduled to any other node, e.g. in a single-node cluster.
-	// It's safe to do because the goal of the node draining during the upgrade is to
-	// evict pods that might use driver and operator doesn't use in its own pod.
-	if clusterPolicy.Spec.Driver.UpgradePolicy.DrainSpec == nil {
-		clusterPolicy.Spec.Driver.UpgradePolicy.DrainSpec = &upgrade_v1alpha1.DrainSpec{}
+// reconcileVGPUHostManagerUpgrades handles the upgrades of the vGPU manager DaemonSet with the upgrade
+// policy of the vgpu-host-manager driver type, when sandbox workloads are enabled in the ClusterPolicy
+func (r *UpgradeReconciler) reconcileVGPUHostManagerUpgrades(ctx context.Context, reqLogger logr.Logger, clusterPolicy *gpuv1.ClusterPolicy) (ctrl.Result, error) {
+	r.OperatorMetrics.driverAutoUpgradeEnabled.Set(driverAutoUpgradeEnabled)
+
+	state, err := r.StateManager.BuildState(ctx, clusterPolicyCtrl.operatorNamespace,
+		map[string]string{AppComponentLabelKey: VGPUManagerAppComponentLabelValue})
+	if err != nil {
+		r.Log.Error(err, "Failed to build cluster upgrade state of the vGPU manager")
+		return ctrl.Result{}, err
 	}
-	if clusterPolicy.Spec.Driver.UpgradePolicy.DrainSpec.PodSelector == "" {
-		clusterPolicy.Spec.Driver.UpgradePolicy.DrainSpec.PodSelector = UpgradeSkipDrainLabelSelector
-	} else {
-		clusterPolicy.Spec.Driver.UpgradePolicy.DrainSpec.PodSelector =
-			fmt.Sprintf("%s,%s", clusterPolicy.Spec.Driver.UpgradePolicy.DrainSpec.PodSelector, UpgradeSkipDrainLabelSelector)
+
+	reqLogger.V(consts.LogLevelDebug).Info("Current vGPU manager upgrade state", "state", state)
+
+	statesByType := map[gpuv1.DriverUpgradeType]*upgrade.ClusterUpgradeState{gpuv1.DriverUpgradeTypeVGPUHostManager: state}
+	return r.applyDriverUpgradePolicies(ctx, reqLogger, clusterPolicy,
+		[]gpuv1.DriverUpgradeType{gpuv1.DriverUpgradeTypeVGPUHostManager}, statesByType)
+}
+
+// applyDriverUpgradePolicies applies the upgrade policy of each driver type to the upgrade state of the nodes
+// running that driver type. The upgrade state labels are removed from the nodes of the driver types with auto
+// upgrade disabled.
+func (r *UpgradeReconciler) applyDriverUpgradePolicies(ctx context.Context, reqLogger logr.Logger, clusterPolicy *gpuv1.ClusterPolicy,
+	driverTypes []gpuv1.DriverUpgradeType, statesByType map[gpuv1.DriverUpgradeType]*upgrade.ClusterUpgradeState) (ctrl.Result, error) {
+	var (
+		upgradesInProgress, upgradesDone, upgradesAvailable, upgradesFailed, upgradesPending int
+	)
+
+	upgradeStatus := &gpuv1.DriverUpgradeStatus{}
+	var statusErr error
+	progress := &driverUpgradeProgress{owner: clusterPolicy}
+	for _, driverType := range driverTypes {
+		state, ok := statesByType[driverType]
+		if !ok {
+			continue
+		}
+
+		upgradePolicy := clusterPolicy.Spec.Driver.GetUpgradePolicy(driverType)
+		if upgradePolicy == nil || !upgradePolicy.AutoUpgrade {
+			reqLogger.V(consts.LogLevelInfo).Info("Auto upgrade is disabled for driver type, cleaning up upgrade state for its nodes",
+				"driverType", driverType)
+			if err := r.removeUpgradeStateLabelsFromNodes(ctx, state); err != nil {
+				return ctrl.Result{}, err
+			}
+			continue
+		}
+		// the drain pod selector is extended below, do not modify the ClusterPolicy spec
+		upgradePolicy = upgradePolicy.DeepCopy()
+
+		totalNodes := r.StateManager.GetTotalManagedNodes(state)
+		maxUnavailable := totalNodes
+		if upgradePolicy.MaxUnavailable != nil {
+			var err error
+			maxUnavailable, err = intstr.GetScaledValueFromIntOrPercent(upgradePolicy.MaxUnavailable, totalNodes, true)
+			if err != nil {
+				r.Log.Error(err, "Failed to compute maxUnavailable from the current total nodes", "driverType", driverType)
+				return ctrl.Result{}, err
+			}
+		}
+
+		// We want to skip operator itself during the drain because the upgrade process might hang
+		// if the operator is evicted and can't be rescheduled to any other node, e.g. in a single-node cluster.
+		// It's safe to do because the goal of the node draining during the upgrade is to
+		// evict pods that might use driver and operator doesn't use in its own pod.
+		setUpgradeSkipDrainPodSelector(upgradePolicy)
+
+		inProgress := r.StateManager.GetUpgradesInProgress(state)
+		available := r.StateManager.GetUpgradesAvailable(state, upgradePolicy.MaxParallelUpgrades, maxUnavailable)
+		upgradesInProgress += inProgress
+		upgradesDone += r.StateManager.GetUpgradesDone(state)
+		upgradesAvailable += available
+		upgradesFailed += r.StateManager.GetUpgradesFailed(state)
+		upgradesPending += r.StateManager.GetUpgradesPending(state)
+
+		// report the upgrade status before the state is applied, so that the next batch previews the nodes the
+		// state manager starts upgrading now
+		if statusErr == nil {
+			var typeStatus *gpuv1.DriverUpgradeStatus
+			if typeStatus, statusErr = r.getDriverUpgradeStatus(ctx, state, inProgress, available); statusErr == nil {
+				mergeDriverUpgradeStatus(upgradeStatus, typeStatus)
+			}
+		}
+		progress.addState(string(driverType), state)
+
+		reqLogger.Info("Applying upgrade policy for driver type", "driverType", driverType)
+		if err := r.StateManager.ApplyState(ctx, state, upgradePolicy); err != nil {
+			r.Log.Error(err, "Failed to apply cluster upgrade state", "driverType", driverType)
+			return ctrl.Result{}, err
+		}
 	}
 
 	// log metrics with the current state
-	r.OperatorMetrics.upgradesInProgress.Set(float64(r.StateManager.GetUpgradesInProgress(state)))
-	r.OperatorMetrics.upgradesDone.Set(float64(r.StateManager.GetUpgradesDone(state)))
-	r.OperatorMetrics.upgradesAvailable.Set(float64(r.StateManager.GetUpgradesAvailable(state, clusterPolicy.Spec.Driver.UpgradePolicy.MaxParallelUpgrades, maxUnavailable)))
-	r.OperatorMetrics.upgradesFailed.Set(float64(r.StateManager.GetUpgradesFailed(state)))
-	r.OperatorMetrics.upgradesPending.Set(float64(r.StateManager.GetUpgradesPending(state)))
+	r.OperatorMetrics.upgradesInProgress.Set(float64(upgradesInProgress))
+	r.OperatorMetrics.upgradesDone.Set(float64(upgradesDone))
+	r.OperatorMetrics.upgradesAvailable.Set(float64(upgradesAvailable))
+	r.OperatorMetrics.upgradesFailed.Set(float64(upgradesFailed))
+	r.OperatorMetrics.upgradesPending.Set(float64(upgradesPending))
 
-	err = r.StateManager.ApplyState(ctx, state, clusterPolicy.Spec.Driver.UpgradePolicy)
-	if err != nil {
-		r.Log.Error(err, "Failed to apply cluster upgrade state")
-		return ctrl.Result{}, err
+	if statusErr != nil {
+		r.Log.Error(statusErr, "Failed to estimate the GPU workloads impacted by the driver upgrades")
+	} else if err := r.updateDriverUpgradeStatus(ctx, clusterPolicy.Name, upgradeStatus); err != nil {
+		r.Log.Error(err, "Failed to update the driver upgrade status")
+	}
+	if err := r.syncDriverUpgrades(ctx, []*driverUpgradeProgress{progress}); err != nil {
+		r.Log.Error(err, "Failed to update the DriverUpgrade")
 	}
 
 	// In some cases if node state changes fail to apply, upgrade process
@@ -201,6 +331,115 @@ func (r *UpgradeReconciler) reconcileClusterPolicyDriverUpgrades(ctx context.Con
 	return ctrl.Result{Requeue: true, RequeueAfter: plannedRequeueInterval}, nil
 }
 
+// getNodeDriverUpgradeType returns the driver type upgraded on the node
+func getNodeDriverUpgradeType(node *corev1.Node) gpuv1.DriverUpgradeType {
+	if node.Labels[vgpuManagerDeployLabelKey] == "true" {
+		return gpuv1.DriverUpgradeTypeVGPUHostManager
+	}
+	if node.Labels[vgpuPresentLabelKey] == "true" {
+		return gpuv1.DriverUpgradeTypeVGPU
+	}
+	return gpuv1.DriverUpgradeTypeGPU
+}
+
+// partitionUpgradeStateByDriverType splits the cluster upgrade state by the driver type of the nodes
+func partitionUpgradeStateByDriverType(state *upgrade.ClusterUpgradeState) map[gpuv1.DriverUpgradeType]*upgrade.ClusterUpgradeState {
+	statesByType := make(map[gpuv1.DriverUpgradeType]*upgrade.ClusterUpgradeState)
+	for stateKey, nodeStates := range state.NodeStates {
+		for _, nodeState := range nodeStates {
+			driverType := getNodeDriverUpgradeType(nodeState.Node)
+			if statesByType[driverType] == nil {
+				s := upgrade.NewClusterUpgradeState()
+				statesByType[driverType] = &s
+			}
+			statesByType[driverType].NodeStates[stateKey] = append(statesByType[driverType].NodeStates[stateKey], nodeState)
+		}
+	}
+	return statesByType
+}
+
+// setUpgradeSkipDrainPodSelector excludes the pods labeled to skip the drain from the drain of the upgrade policy
+func setUpgradeSkipDrainPodSelector(upgradePolicy *upgrade_v1alpha1.DriverUpgradePolicySpec) {
+	if upgradePolicy.DrainSpec == nil {
+		upgradePolicy.DrainSpec = &upgrade_v1alpha1.DrainSpec{}
+	}
+	if upgradePolicy.DrainSpec.PodSelector == "" {
+		upgradePolicy.DrainSpec.PodSelector = UpgradeSkipDrainLabelSelector
+	} else {
+		upgradePolicy.DrainSpec.PodSelector =
+			fmt.Sprintf("%s,%s", upgradePolicy.DrainSpec.PodSelector, UpgradeSkipDrainLabelSelector)
+	}
+}
+
+// mergeDriverUpgradeStatus adds the upgrade status of a driver type to the upgrade status of the cluster
+func mergeDriverUpgradeStatus(status, typeStatus *gpuv1.DriverUpgradeStatus) {
+	status.PendingNodes += typeStatus.PendingNodes
+	status.InProgressNodes += typeStatus.InProgressNodes
+	status.PendingGPUPods += typeStatus.PendingGPUPods
+	status.NextBatch = append(status.NextBatch, typeStatus.NextBatch...)
+}
+
+// getDriverUpgradeStatus summarizes the driver upgrade state of the cluster, with the GPU pods running on
+// the nodes waiting for an upgrade. The pods are only listed when nodes are waiting for an upgrade.
+func (r *UpgradeReconciler) getDriverUpgradeStatus(ctx context.Context, state *upgrade.ClusterUpgradeState,
+	upgradesInProgress, upgradesAvailable int) (*gpuv1.DriverUpgradeStatus, error) {
+	index := gpuPodIndex{}
+	if len(state.NodeStates[upgrade.UpgradeStateUpgradeRequired]) > 0 {
+		var reader client.Reader = r.Client
+		if r.APIReader != nil {
+			reader = r.APIReader
+		}
+		var err error
+		if index, err = buildGPUPodIndex(ctx, reader); err != nil {
+			return nil, err
+		}
+	}
+	return buildDriverUpgradeStatus(state, upgradesInProgress, upgradesAvailable, index), nil
+}
+
+// buildDriverUpgradeStatus builds the driver upgrade status from the upgrade state of the cluster. The next
+// batch lists the nodes the state manager moves out of the upgrade-required state on its next pass, in the
+// order it processes them, and skips the nodes excluded from the upgrades.
+func buildDriverUpgradeStatus(state *upgrade.ClusterUpgradeState, upgradesInProgress, upgradesAvailable int,
+	index gpuPodIndex) *gpuv1.DriverUpgradeStatus {
+	status := &gpuv1.DriverUpgradeStatus{InProgressNodes: upgradesInProgress}
+	for _, nodeState := range state.NodeStates[upgrade.UpgradeStateUpgradeRequired] {
+		node := nodeState.Node
+		status.PendingNodes++
+		status.PendingGPUPods += index[node.Name]
+		if len(status.NextBatch) < upgradesAvailable && node.Labels[upgrade.GetUpgradeSkipNodeLabelKey()] != "true" {
+			status.NextBatch = append(status.NextBatch, gpuv1.DriverUpgradeNodeStatus{Name: node.Name, GPUPods: index[node.Name]})
+		}
+	}
+	return status
+}
+
+// updateDriverUpgradeStatus records the driver upgrade status in the ClusterPolicy status, the status is
+// removed when nil
+func (r *UpgradeReconciler) updateDriverUpgradeStatus(ctx context.Context, name string, status *gpuv1.DriverUpgradeStatus) error {
+	// Fetch latest instance and update status to avoid version mismatch
+	instance := &gpuv1.ClusterPolicy{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name}, instance); err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(instance.Status.DriverUpgrade, status) {
+		return nil
+	}
+	instance.Status.DriverUpgrade = status
+	return r.Status().Update(ctx, instance)
+}
+
+// clearDriverUpgradeStatus removes the driver upgrade status once the upgrades are no longer managed through
+// the ClusterPolicy
+func (r *UpgradeReconciler) clearDriverUpgradeStatus(ctx context.Context, clusterPolicy *gpuv1.ClusterPolicy) {
+	if clusterPolicy.Status.DriverUpgrade == nil {
+		return
+	}
+	if err := r.updateDriverUpgradeStatus(ctx, clusterPolicy.Name, nil); err != nil {
+		r.Log.Error(err, "Failed to clear the driver upgrade status")
+	}
+}
+
 // reconcileNVIDIADriverUpgrades handles driver upgrade reconciliation when the NVIDIADriver CRD
 // is used for driver management. Each NVIDIADriver instance may have its own upgrade policy.
 func (r *UpgradeReconciler) reconcileNVIDIADriverUpgrades(ctx context.Context, reqLogger logr.Logger) (ctrl.Result, error) {
@@ -226,6 +465,7 @@ func (r *UpgradeReconciler) reconcileNVIDIADriverUpgrades(ctx context.Context, r
 	if noAutoUpgradesEnabled {
 		reqLogger.V(consts.LogLevelInfo).Info("No NVIDIADriver instance has upgrade policy enabled, cleaning up upgrade state and skipping reconciliation")
 		r.OperatorMetrics.driverAutoUpgradeEnabled.Set(driverAutoUpgradeDisabled)
+		r.removeDriverUpgrades(ctx)
 		return ctrl.Result{}, r.removeNodeUpgradeStateLabels(ctx)
 	}
 
@@ -260,7 +500,9 @@ func (r *UpgradeReconciler) reconcileNVIDIADriverUpgrades(ctx context.Context, r
 	}
 
 	// Apply the upgrade policy for each NVIDIADriver instance using its partitioned cluster upgrade state
-	for _, nvd := range nvidiaDriverList.Items {
+	var progresses []*driverUpgradeProgress
+	for i := range nvidiaDriverList.Items {
+		nvd := &nvidiaDriverList.Items[i]
 		upgradePolicy := nvd.Spec.GetUpgradePolicyWithDefaults()
 		if !upgradePolicy.AutoUpgrade {
 			reqLogger.V(consts.LogLevelInfo).Info("Auto upgrade is disabled for NVIDIADriver, cleaning up upgrade state for nodes it manages",
@@ -272,10 +514,13 @@ func (r *UpgradeReconciler) reconcileNVIDIADriverUpgrades(ctx context.Context, r
 			continue
 		}
 
+		progress := &driverUpgradeProgress{owner: nvd}
+		progresses = append(progresses, progress)
 		state, ok := statesByNVD[nvd.Name]
 		if !ok {
 			continue
 		}
+		progress.addState(string(nvd.Spec.DriverType), state)
 
 		reqLogger.V(consts.LogLevelDebug).Info("Current cluster upgrade state for NVIDIADriver",
 			"name", nvd.Name, "state", state)
@@ -318,6 +563,10 @@ func (r *UpgradeReconciler) reconcileNVIDIADriverUpgrades(ctx context.Context, r
 	r.OperatorMetrics.upgradesFailed.Set(float64(upgradesFailed))
 	r.OperatorMetrics.upgradesPending.Set(float64(upgradesPending))
 
+	if err := r.syncDriverUpgrades(ctx, progresses); err != nil {
+		r.Log.Error(err, "Failed to update the DriverUpgrades")
+	}
+
 	// In some cases if node state changes fail to apply, upgrade process
 	// might become stuck until the new reconcile loop is scheduled.
 	// Since node/ds/clusterpolicy updates from outside of the upgrade flow
@@ -382,6 +631,28 @@ func (r *UpgradeReconciler) removeNodeUpgradeStateLabelsForNVD(ctx context.Conte
 	return nil
 }
 
+// removeUpgradeStateLabelsFromNodes removes the upgrade-state label from the nodes of the upgrade state.
+// It is used for cleanup when autoUpgrade is disabled for the driver type of the nodes.
+func (r *UpgradeReconciler) removeUpgradeStateLabelsFromNodes(ctx context.Context, state *upgrade.ClusterUpgradeState) error {
+	upgradeStateLabel := upgrade.GetUpgradeStateLabelKey()
+
+	for _, nodeStates := range state.NodeStates {
+		for _, nodeState := range nodeStates {
+			node := nodeState.Node
+			if _, present := node.Labels[upgradeStateLabel]; !present {
+				continue
+			}
+			patch := client.MergeFrom(node.DeepCopy())
+			delete(node.Labels, upgradeStateLabel)
+			if err := r.Patch(ctx, node, patch); err != nil {
+				r.Log.Error(err, "Failed to remove upgrade state label from node", "node", node.Name)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 //
 //nolint:dupl
@@ -474,6 +745,10 @@ func (r *UpgradeReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		return selector.Matches(labels.Set(ds.GetLabels()))
 	})
 
+	vgpuManagerComponentLabelSelector := predicate.NewTypedPredicateFuncs(func(ds *appsv1.DaemonSet) bool {
+		return ds.GetLabels()[AppComponentLabelKey] == VGPUManagerAppComponentLabelValue
+	})
+
 	err = c.Watch(
 		source.Kind(
 			mgr.GetCache(),
@@ -481,7 +756,7 @@ func (r *UpgradeReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 			handler.TypedEnqueueRequestsFromMapFunc[*appsv1.DaemonSet](dsMapFn),
 			predicate.And[*appsv1.DaemonSet](
 				predicate.TypedGenerationChangedPredicate[*appsv1.DaemonSet]{},
-				predicate.Or[*appsv1.DaemonSet](appLabelSelector, dtkLabelSelector, componentLabelSelector),
+				predicate.Or[*appsv1.DaemonSet](appLabelSelector, dtkLabelSelector, componentLabelSelector, vgpuManagerComponentLabelSelector),
 			),
 		))
 	if err != nil {
@@ -21,7 +21,12 @@ import (
 	"testing"
 
 	upgrade_v1alpha1 "github.com/NVIDIA/k8s-operator-libs/api/upgrade/v1alpha1"
+	"github.com/NVIDIA/k8s-operator-libs/pkg/upgrade"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
 )
 
 func TestSetDrainSpecPodSelector(t *testing.T) {
@@ -54,18 +59,89 @@ func TestSetDrainSpecPodSelector(t *testing.T) {
 				DrainSpec:   tt.drainSpec,
 			}
 
-			if upgradePolicy.DrainSpec == nil {
-				upgradePolicy.DrainSpec = &upgrade_v1alpha1.DrainSpec{}
-			}
-			if upgradePolicy.DrainSpec.PodSelector == "" {
-				upgradePolicy.DrainSpec.PodSelector = UpgradeSkipDrainLabelSelector
-			} else {
-				upgradePolicy.DrainSpec.PodSelector =
-					fmt.Sprintf("%s,%s", upgradePolicy.DrainSpec.PodSelector, UpgradeSkipDrainLabelSelector)
-			}
+			setUpgradeSkipDrainPodSelector(upgradePolicy)
 
 			assert.NotNil(t, upgradePolicy.DrainSpec)
 			assert.Equal(t, tt.expectedSelector, upgradePolicy.DrainSpec.PodSelector)
 		})
 	}
 }
+
+func TestBuildDriverUpgradeStatus(t *testing.T) {
+	newNodeState := func(name string, labels map[string]string) *upgrade.NodeUpgradeState {
+		return &upgrade.NodeUpgradeState{Node: &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}}
+	}
+	state := upgrade.NewClusterUpgradeState()
+	state.NodeStates[upgrade.UpgradeStateUpgradeRequired] = []*upgrade.NodeUpgradeState{
+		newNodeState("node-a", nil),
+		newNodeState("node-b", map[string]string{upgrade.GetUpgradeSkipNodeLabelKey(): "true"}),
+		newNodeState("node-c", nil),
+		newNodeState("node-d", nil),
+	}
+	state.NodeStates[upgrade.UpgradeStateDrainRequired] = []*upgrade.NodeUpgradeState{newNodeState("node-e", nil)}
+	index := gpuPodIndex{"node-a": 3, "node-b": 1, "node-d": 2, "node-e": 5}
+
+	status := buildDriverUpgradeStatus(&state, 1, 2, index)
+	expected := &gpuv1.DriverUpgradeStatus{
+		PendingNodes:    4,
+		InProgressNodes: 1,
+		PendingGPUPods:  6,
+		NextBatch: []gpuv1.DriverUpgradeNodeStatus{
+			{Name: "node-a", GPUPods: 3},
+			{Name: "node-c"},
+		},
+	}
+	assert.Equal(t, expected, status)
+
+	status = buildDriverUpgradeStatus(&state, 1, 0, index)
+	assert.Empty(t, status.NextBatch)
+}
+
+func TestPartitionUpgradeStateByDriverType(t *testing.T) {
+	newNodeState := func(name string, labels map[string]string) *upgrade.NodeUpgradeState {
+		return &upgrade.NodeUpgradeState{Node: &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}}
+	}
+	state := upgrade.NewClusterUpgradeState()
+	state.NodeStates[upgrade.UpgradeStateUpgradeRequired] = []*upgrade.NodeUpgradeState{
+		newNodeState("gpu-a", nil),
+		newNodeState("vgpu-a", map[string]string{vgpuPresentLabelKey: "true"}),
+	}
+	state.NodeStates[upgrade.UpgradeStateDone] = []*upgrade.NodeUpgradeState{
+		newNodeState("gpu-b", map[string]string{vgpuPresentLabelKey: "false"}),
+		newNodeState("host-a", map[string]string{vgpuManagerDeployLabelKey: "true"}),
+	}
+
+	statesByType := partitionUpgradeStateByDriverType(&state)
+	nodeNames := func(driverType gpuv1.DriverUpgradeType, stateKey string) []string {
+		var names []string
+		for _, nodeState := range statesByType[driverType].NodeStates[stateKey] {
+			names = append(names, nodeState.Node.Name)
+		}
+		return names
+	}
+	assert.Len(t, statesByType, 3)
+	assert.Equal(t, []string{"gpu-a"}, nodeNames(gpuv1.DriverUpgradeTypeGPU, upgrade.UpgradeStateUpgradeRequired))
+	assert.Equal(t, []string{"gpu-b"}, nodeNames(gpuv1.DriverUpgradeTypeGPU, upgrade.UpgradeStateDone))
+	assert.Equal(t, []string{"vgpu-a"}, nodeNames(gpuv1.DriverUpgradeTypeVGPU, upgrade.UpgradeStateUpgradeRequired))
+	assert.Empty(t, nodeNames(gpuv1.DriverUpgradeTypeVGPU, upgrade.UpgradeStateDone))
+	assert.Equal(t, []string{"host-a"}, nodeNames(gpuv1.DriverUpgradeTypeVGPUHostManager, upgrade.UpgradeStateDone))
+}
+
+func TestDriverSpecGetUpgradePolicy(t *testing.T) {
+	shared := &upgrade_v1alpha1.DriverUpgradePolicySpec{AutoUpgrade: true, MaxParallelUpgrades: 4}
+	vgpu := &upgrade_v1alpha1.DriverUpgradePolicySpec{AutoUpgrade: false}
+	hostManager := &upgrade_v1alpha1.DriverUpgradePolicySpec{AutoUpgrade: true, MaxParallelUpgrades: 1}
+
+	driver := gpuv1.DriverSpec{UpgradePolicy: shared}
+	assert.Equal(t, shared, driver.GetUpgradePolicy(gpuv1.DriverUpgradeTypeGPU))
+	assert.Equal(t, shared, driver.GetUpgradePolicy(gpuv1.DriverUpgradeTypeVGPU))
+	assert.Nil(t, driver.GetUpgradePolicy(gpuv1.DriverUpgradeTypeVGPUHostManager))
+	assert.False(t, driver.IsAutoUpgradeEnabledFor(gpuv1.DriverUpgradeTypeVGPUHostManager))
+
+	driver.UpgradePolicies = &gpuv1.DriverUpgradePoliciesSpec{VGPU: vgpu, VGPUHostManager: hostManager}
+	assert.Equal(t, shared, driver.GetUpgradePolicy(gpuv1.DriverUpgradeTypeGPU))
+	assert.Equal(t, vgpu, driver.GetUpgradePolicy(gpuv1.DriverUpgradeTypeVGPU))
+	assert.False(t, driver.IsAutoUpgradeEnabledFor(gpuv1.DriverUpgradeTypeVGPU))
+	assert.Equal(t, hostManager, driver.GetUpgradePolicy(gpuv1.DriverUpgradeTypeVGPUHostManager))
+	assert.True(t, driver.IsAutoUpgradeEnabledFor(gpuv1.DriverUpgradeTypeVGPUHostManager))
+}
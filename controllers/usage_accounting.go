@@ -0,0 +1,232 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/accounting"
+)
+
+const (
+	// usageReportLabelKey is the label identifying the ConfigMaps holding the GPU usage reports
+	usageReportLabelKey = "nvidia.com/gpu-usage-report"
+	// usageReportPeriodEndAnnotationKey records the end of the period covered by a usage report
+	usageReportPeriodEndAnnotationKey = "nvidia.com/gpu-usage-report.period-end"
+	usageReportNamePrefix             = "gpu-usage-report-"
+	usageReportJSONDataKey            = "usage.json"
+	usageReportCSVDataKey             = "usage.csv"
+)
+
+// reconcileUsageAccounting writes the usage records of the period elapsed since the latest usage report to
+// a ConfigMap once the report interval has elapsed, and deletes the reports exceeding the history limit. It
+// returns the time remaining until the next report is due, or zero when the usage accounting is disabled.
+func (n ClusterPolicyController) reconcileUsageAccounting(ctx context.Context, now time.Time) (time.Duration, error) {
+	spec := n.singleton.Spec.UsageAccounting
+	if !spec.IsEnabled() {
+		return 0, nil
+	}
+
+	list := &corev1.ConfigMapList{}
+	err := n.client.List(ctx, list, client.InNamespace(n.operatorNamespace), client.MatchingLabels{usageReportLabelKey: "true"})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list usage reports: %w", err)
+	}
+	reports := list.Items
+	// most recent report first
+	sort.Slice(reports, func(i, j int) bool {
+		return getUsageReportTime(&reports[i]).After(getUsageReportTime(&reports[j]))
+	})
+
+	interval := spec.GetInterval()
+	nextReport := interval
+	periodStart := now.Add(-interval)
+	if len(reports) > 0 {
+		periodStart = getUsageReportTime(&reports[0])
+		nextReport = periodStart.Add(interval).Sub(now)
+	}
+	if nextReport <= 0 || len(reports) == 0 {
+		report, err := n.buildUsageReport(ctx, periodStart, now)
+		if err != nil {
+			return 0, err
+		}
+		cm, err := n.createUsageReport(ctx, report, spec.GetFormat())
+		if err != nil {
+			return 0, err
+		}
+		n.operatorMetrics.setUsageReport(report)
+		reports = append([]corev1.ConfigMap{*cm}, reports...)
+		nextReport = interval
+	}
+
+	for i := spec.GetHistoryLimit(); i < len(reports); i++ {
+		n.logger.Info("Deleting usage report exceeding the history limit", "name", reports[i].Name)
+		if err := n.client.Delete(ctx, &reports[i]); err != nil && !apierrors.IsNotFound(err) {
+			return 0, fmt.Errorf("failed to delete usage report %s: %w", reports[i].Name, err)
+		}
+	}
+	return nextReport, nil
+}
+
+// buildUsageReport aggregates the GPUs allocated to the pods of the GPU nodes between start and end, with
+// the GPU utilization of the pods reported by DCGM Exporter when it is deployed
+func (n ClusterPolicyController) buildUsageReport(ctx context.Context, start time.Time, end time.Time) (*accounting.Report, error) {
+	gpuNodes, err := n.listGPUNodes(nil)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make(map[string]*corev1.Node, len(gpuNodes))
+	for i := range gpuNodes {
+		nodes[gpuNodes[i].Name] = &gpuNodes[i]
+	}
+
+	pods, err := n.listGPUAllocatingPods(ctx, nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	var utilization map[accounting.PodKey]float64
+	if n.singleton.Spec.DCGMExporter.IsEnabled() {
+		utilization = n.samplePodGPUUtilization(ctx)
+	}
+	return accounting.BuildReport(pods, nodes, utilization, start, end), nil
+}
+
+// listGPUAllocatingPods returns the pods of all namespaces bound to the given nodes and allocated GPU
+// resources. The pods are listed page by page from the API server, as the manager only caches the operator
+// namespace.
+func (n ClusterPolicyController) listGPUAllocatingPods(ctx context.Context, nodes map[string]*corev1.Node) ([]corev1.Pod, error) {
+	var reader client.Reader = n.client
+	if n.apiReader != nil {
+		reader = n.apiReader
+	}
+
+	var result []corev1.Pod
+	opts := []client.ListOption{client.Limit(gpuPodListPageSize)}
+	for {
+		pods := &corev1.PodList{}
+		if err := reader.List(ctx, pods, opts...); err != nil {
+			return nil, fmt.Errorf("failed to list pods: %w", err)
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if _, ok := nodes[pod.Spec.NodeName]; ok && len(accounting.GetAllocatedDevices(pod)) > 0 {
+				result = append(result, *pod)
+			}
+		}
+		if pods.Continue == "" {
+			return result, nil
+		}
+		opts = []client.ListOption{client.Limit(gpuPodListPageSize), client.Continue(pods.Continue)}
+	}
+}
+
+// samplePodGPUUtilization returns the GPU utilization of the pods reported by the running DCGM Exporter pods.
+// The nodes whose DCGM Exporter pod cannot be scraped are omitted.
+func (n ClusterPolicyController) samplePodGPUUtilization(ctx context.Context) map[accounting.PodKey]float64 {
+	pods := &corev1.PodList{}
+	if err := n.client.List(ctx, pods, client.InNamespace(n.operatorNamespace), client.MatchingLabels{appLabelKey: dcgmExporterAppLabelValue}); err != nil {
+		n.logger.Error(err, "failed to list DCGM Exporter pods")
+		return nil
+	}
+
+	var samples []dcgmSample
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+			continue
+		}
+		podSamples, err := scrapeDCGMExporterMetric(ctx, pod, dcgmGPUUtilizationMetric)
+		if err != nil {
+			n.logger.Info("WARNING: failed to scrape GPU utilization from DCGM Exporter", "pod", pod.Name, "node", pod.Spec.NodeName, "error", err)
+			continue
+		}
+		samples = append(samples, podSamples...)
+	}
+	return getPodGPUUtilization(samples)
+}
+
+// getPodGPUUtilization averages the GPU utilization samples of DCGM Exporter per pod. The samples of the GPUs
+// not allocated to a pod carry no pod label and are ignored.
+func getPodGPUUtilization(samples []dcgmSample) map[accounting.PodKey]float64 {
+	sums := make(map[accounting.PodKey]float64)
+	counts := make(map[accounting.PodKey]int)
+	for _, sample := range samples {
+		key := accounting.PodKey{Namespace: sample.labels["namespace"], Name: sample.labels["pod"]}
+		if key.Namespace == "" || key.Name == "" {
+			continue
+		}
+		sums[key] += sample.value
+		counts[key]++
+	}
+	utilization := make(map[accounting.PodKey]float64, len(sums))
+	for key, sum := range sums {
+		utilization[key] = sum / float64(counts[key])
+	}
+	return utilization
+}
+
+func (n ClusterPolicyController) createUsageReport(ctx context.Context, report *accounting.Report, format gpuv1.UsageAccountingFormat) (*corev1.ConfigMap, error) {
+	key := usageReportJSONDataKey
+	encode := report.JSON
+	if format == gpuv1.UsageAccountingFormatCSV {
+		key = usageReportCSVDataKey
+		encode = report.CSV
+	}
+	data, err := encode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode usage report: %w", err)
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%s%d", usageReportNamePrefix, report.PeriodEnd.Unix()),
+			Namespace:   n.operatorNamespace,
+			Labels:      map[string]string{usageReportLabelKey: "true"},
+			Annotations: map[string]string{usageReportPeriodEndAnnotationKey: report.PeriodEnd.Format(time.RFC3339)},
+		},
+		Data: map[string]string{key: string(data)},
+	}
+	if err := controllerutil.SetControllerReference(n.singleton, cm, n.scheme); err != nil {
+		return nil, err
+	}
+
+	n.logger.Info("Creating usage report", "name", cm.Name, "records", len(report.Records))
+	if err := n.client.Create(ctx, cm); err != nil {
+		return nil, fmt.Errorf("failed to create usage report %s: %w", cm.Name, err)
+	}
+	return cm, nil
+}
+
+// getUsageReportTime returns the end of the period covered by a usage report, falling back to the creation
+// time of the ConfigMap when the annotation is missing or invalid
+func getUsageReportTime(cm *corev1.ConfigMap) time.Time {
+	if periodEnd, err := time.Parse(time.RFC3339, cm.Annotations[usageReportPeriodEndAnnotationKey]); err == nil {
+		return periodEnd
+	}
+	return cm.CreationTimestamp.Time
+}
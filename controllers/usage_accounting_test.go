@@ -0,0 +1,175 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/accounting"
+)
+
+func TestReconcileUsageAccounting(t *testing.T) {
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	existingReport := func(age time.Duration) *corev1.ConfigMap {
+		periodEnd := now.Add(-age)
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        fmt.Sprintf("%s%d", usageReportNamePrefix, periodEnd.Unix()),
+				Namespace:   "test-ns",
+				Labels:      map[string]string{usageReportLabelKey: "true"},
+				Annotations: map[string]string{usageReportPeriodEndAnnotationKey: periodEnd.Format(time.RFC3339)},
+			},
+		}
+	}
+	gpuObjects := []client.Object{
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{commonGPULabelKey: commonGPULabelValue}}},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "training", Namespace: "team-a"},
+			Spec: corev1.PodSpec{
+				NodeName: "node-a",
+				Containers: []corev1.Container{{Name: "ctr", Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")},
+				}}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning, StartTime: &metav1.Time{Time: now.Add(-48 * time.Hour)}},
+		},
+	}
+
+	testCases := []struct {
+		description          string
+		spec                 *gpuv1.UsageAccountingSpec
+		objects              []client.Object
+		expectedReports      int
+		expectedNewReport    string
+		expectedGPUSeconds   float64
+		expectedRequeueAfter time.Duration
+	}{
+		{
+			description: "disabled",
+			objects:     []client.Object{existingReport(48 * time.Hour)},
+			// existing reports are left untouched
+			expectedReports: 1,
+		},
+		{
+			description:          "first report covers an interval",
+			spec:                 &gpuv1.UsageAccountingSpec{Enabled: ptr.To(true)},
+			expectedReports:      1,
+			expectedNewReport:    usageReportJSONDataKey,
+			expectedGPUSeconds:   3600,
+			expectedRequeueAfter: time.Hour,
+		},
+		{
+			description:          "latest report is recent",
+			spec:                 &gpuv1.UsageAccountingSpec{Enabled: ptr.To(true)},
+			objects:              []client.Object{existingReport(20 * time.Minute)},
+			expectedReports:      1,
+			expectedRequeueAfter: 40 * time.Minute,
+		},
+		{
+			description: "report covers the period since the latest report and history is pruned",
+			spec: &gpuv1.UsageAccountingSpec{
+				Enabled:      ptr.To(true),
+				Interval:     &metav1.Duration{Duration: time.Hour},
+				HistoryLimit: ptr.To[int32](2),
+				Format:       gpuv1.UsageAccountingFormatCSV,
+			},
+			objects: []client.Object{
+				existingReport(90 * time.Minute),
+				existingReport(150 * time.Minute),
+				existingReport(210 * time.Minute),
+			},
+			expectedReports:      2,
+			expectedNewReport:    usageReportCSVDataKey,
+			expectedGPUSeconds:   5400,
+			expectedRequeueAfter: time.Hour,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, corev1.AddToScheme(scheme))
+			require.NoError(t, gpuv1.AddToScheme(scheme))
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(tc.objects, gpuObjects...)...).Build()
+			n := ClusterPolicyController{
+				ctx:               context.Background(),
+				client:            c,
+				scheme:            scheme,
+				logger:            ctrl.Log.WithName("test"),
+				operatorNamespace: "test-ns",
+				singleton: &gpuv1.ClusterPolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: "cluster-policy", UID: "uid"},
+					Spec:       gpuv1.ClusterPolicySpec{UsageAccounting: tc.spec},
+				},
+			}
+
+			requeueAfter, err := n.reconcileUsageAccounting(context.Background(), now)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedRequeueAfter, requeueAfter)
+
+			reports := &corev1.ConfigMapList{}
+			require.NoError(t, c.List(context.Background(), reports, client.MatchingLabels{usageReportLabelKey: "true"}))
+			require.Len(t, reports.Items, tc.expectedReports)
+
+			newReport := &corev1.ConfigMap{}
+			err = c.Get(context.Background(), client.ObjectKey{Namespace: "test-ns", Name: fmt.Sprintf("%s%d", usageReportNamePrefix, now.Unix())}, newReport)
+			require.Equal(t, tc.expectedNewReport != "", err == nil)
+			switch tc.expectedNewReport {
+			case usageReportJSONDataKey:
+				report := &accounting.Report{}
+				require.NoError(t, json.Unmarshal([]byte(newReport.Data[usageReportJSONDataKey]), report))
+				require.Len(t, report.Records, 1)
+				require.Equal(t, "training", report.Records[0].Pod)
+				require.Equal(t, tc.expectedGPUSeconds, report.Records[0].GPUSeconds)
+			case usageReportCSVDataKey:
+				lines := strings.Split(strings.TrimSpace(newReport.Data[usageReportCSVDataKey]), "\n")
+				require.Len(t, lines, 2)
+				require.Contains(t, lines[1], fmt.Sprintf(",training,node-a,nvidia.com/gpu,1,1,%g,%g,", tc.expectedGPUSeconds, tc.expectedGPUSeconds))
+			}
+		})
+	}
+}
+
+func TestGetPodGPUUtilization(t *testing.T) {
+	utilization := getPodGPUUtilization([]dcgmSample{
+		{labels: map[string]string{"gpu": "0", "namespace": "team-a", "pod": "training"}, value: 80},
+		{labels: map[string]string{"gpu": "1", "namespace": "team-a", "pod": "training"}, value: 100},
+		{labels: map[string]string{"gpu": "2", "namespace": "team-b", "pod": "notebook"}, value: 10},
+		// GPU not allocated to a pod
+		{labels: map[string]string{"gpu": "3"}, value: 0},
+	})
+	require.Equal(t, map[accounting.PodKey]float64{
+		{Namespace: "team-a", Name: "training"}: 90,
+		{Namespace: "team-b", Name: "notebook"}: 10,
+	}, utilization)
+}
@@ -0,0 +1,158 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	gpulabels "github.com/NVIDIA/gpu-operator/pkg/labels"
+)
+
+const (
+	operatorValidatorAppLabelValue  = "nvidia-operator-validator"
+	operatorValidatorDeployLabelKey = gpulabels.DeployOperatorValidator
+	// unassignedFailureDomain groups the GPU nodes which do not carry the failure domain label
+	unassignedFailureDomain = "unassigned"
+	// maxFailedNodesInStatus is the maximum number of failed nodes listed per failure domain in the ClusterPolicy status
+	maxFailedNodesInStatus = 100
+)
+
+// getValidationDomains groups the validation results of the GPU nodes by failure domain. A node passes
+// validation once its operator-validator pod is ready, i.e. once all its validation workloads succeeded.
+// Reporting per domain allows a single failing zone or rack to be identified rather than only a
+// cluster-wide result.
+func (n ClusterPolicyController) getValidationDomains(ctx context.Context) ([]gpuv1.ValidationDomainStatus, error) {
+	domainLabel := n.singleton.Spec.Validator.GetFailureDomainLabel()
+
+	nodes, err := n.listGPUNodes(map[string]string{operatorValidatorDeployLabelKey: "true"})
+	if err != nil {
+		return nil, err
+	}
+
+	pods := &corev1.PodList{}
+	err = n.client.List(ctx, pods, client.InNamespace(n.operatorNamespace), client.MatchingLabels{appLabelKey: operatorValidatorAppLabelValue})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list operator-validator pods: %w", err)
+	}
+
+	validatedNodes := make(map[string]bool)
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != "" && isPodConditionTrue(&pod, corev1.PodReady) {
+			validatedNodes[pod.Spec.NodeName] = true
+		}
+	}
+	// the validation results are debounced along with the node statuses
+	now := time.Now()
+	for _, node := range nodes {
+		key := readinessKey{node: node.Name, operand: operatorValidatorAppLabelValue}
+		validatedNodes[node.Name], _ = n.readiness.debounce(key, validatedNodes[node.Name], now, n.singleton.Spec.ReadinessHysteresis)
+	}
+
+	domains := make(map[string]*gpuv1.ValidationDomainStatus)
+	for _, node := range nodes {
+		domainName := node.Labels[domainLabel]
+		if domainName == "" {
+			domainName = unassignedFailureDomain
+		}
+		domain, ok := domains[domainName]
+		if !ok {
+			domain = &gpuv1.ValidationDomainStatus{Domain: domainName}
+			domains[domainName] = domain
+		}
+		domain.Nodes++
+		if validatedNodes[node.Name] {
+			domain.ValidatedNodes++
+		} else {
+			domain.FailedNodeCount++
+			domain.FailedNodes = append(domain.FailedNodes, node.Name)
+		}
+	}
+
+	result := make([]gpuv1.ValidationDomainStatus, 0, len(domains))
+	for _, domain := range domains {
+		sort.Strings(domain.FailedNodes)
+		if len(domain.FailedNodes) > maxFailedNodesInStatus {
+			domain.FailedNodes = domain.FailedNodes[:maxFailedNodesInStatus]
+		}
+		result = append(result, *domain)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Domain < result[j].Domain })
+	return result, nil
+}
+
+// getFailedValidationDomains returns the names of the failure domains with at least one node that has not passed validation
+func getFailedValidationDomains(domains []gpuv1.ValidationDomainStatus) []string {
+	var failed []string
+	for _, domain := range domains {
+		if domain.FailedNodeCount > 0 {
+			failed = append(failed, domain.Domain)
+		}
+	}
+	return failed
+}
+
+func isPodConditionTrue(pod *corev1.Pod, conditionType corev1.PodConditionType) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func updateValidationDomains(ctx context.Context, r *ClusterPolicyReconciler, namespacedName types.NamespacedName, domains []gpuv1.ValidationDomainStatus) {
+	// Fetch latest instance and update status to avoid version mismatch
+	instance := &gpuv1.ClusterPolicy{}
+	if err := r.Get(ctx, namespacedName, instance); err != nil {
+		r.Log.Error(err, "Failed to get ClusterPolicy instance for status update")
+		return
+	}
+	if equality.Semantic.DeepEqual(instance.Status.ValidationDomains, domains) {
+		// validation results are unchanged
+		return
+	}
+	instance.Status.ValidationDomains = domains
+	if err := r.Client.Status().Update(ctx, instance); err != nil {
+		r.Log.Error(err, "Failed to update ClusterPolicy validation domains status")
+	}
+}
+
+func updateSkippedValidations(ctx context.Context, r *ClusterPolicyReconciler, namespacedName types.NamespacedName, skipped []string) {
+	// Fetch latest instance and update status to avoid version mismatch
+	instance := &gpuv1.ClusterPolicy{}
+	if err := r.Get(ctx, namespacedName, instance); err != nil {
+		r.Log.Error(err, "Failed to get ClusterPolicy instance for status update")
+		return
+	}
+	if equality.Semantic.DeepEqual(instance.Status.SkippedValidations, skipped) {
+		// skipped validations are unchanged
+		return
+	}
+	instance.Status.SkippedValidations = skipped
+	if err := r.Client.Status().Update(ctx, instance); err != nil {
+		r.Log.Error(err, "Failed to update ClusterPolicy skipped validations status")
+	}
+}
@@ -0,0 +1,133 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestGetValidationDomains(t *testing.T) {
+	gpuNode := func(name string, labels map[string]string) *corev1.Node {
+		nodeLabels := map[string]string{
+			commonGPULabelKey:               commonGPULabelValue,
+			operatorValidatorDeployLabelKey: "true",
+		}
+		for k, v := range labels {
+			nodeLabels[k] = v
+		}
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nodeLabels}}
+	}
+	validatorPod := func(nodeName string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nvidia-operator-validator-" + nodeName,
+				Namespace: "test-ns",
+				Labels:    map[string]string{appLabelKey: operatorValidatorAppLabelValue},
+			},
+			Spec: corev1.PodSpec{NodeName: nodeName},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			},
+		}
+	}
+
+	// the failed nodes listed in the status are capped, their count is not
+	largeDomain := gpuv1.ValidationDomainStatus{Domain: "zone-large", Nodes: maxFailedNodesInStatus + 2, FailedNodeCount: maxFailedNodesInStatus + 2}
+	var largeDomainNodes []client.Object
+	for i := range maxFailedNodesInStatus + 2 {
+		name := fmt.Sprintf("node-%03d", i)
+		largeDomainNodes = append(largeDomainNodes, gpuNode(name, map[string]string{corev1.LabelTopologyZone: "zone-large"}))
+		if i < maxFailedNodesInStatus {
+			largeDomain.FailedNodes = append(largeDomain.FailedNodes, name)
+		}
+	}
+
+	testCases := []struct {
+		description     string
+		validatorSpec   gpuv1.ValidatorSpec
+		objects         []client.Object
+		expectedDomains []gpuv1.ValidationDomainStatus
+	}{
+		{
+			description: "nodes grouped by zone",
+			objects: []client.Object{
+				gpuNode("node-a1", map[string]string{corev1.LabelTopologyZone: "zone-a"}),
+				gpuNode("node-a2", map[string]string{corev1.LabelTopologyZone: "zone-a"}),
+				gpuNode("node-b1", map[string]string{corev1.LabelTopologyZone: "zone-b"}),
+				gpuNode("node-none", nil),
+				validatorPod("node-a1", corev1.ConditionTrue),
+				validatorPod("node-a2", corev1.ConditionTrue),
+				validatorPod("node-b1", corev1.ConditionFalse),
+			},
+			expectedDomains: []gpuv1.ValidationDomainStatus{
+				{Domain: unassignedFailureDomain, Nodes: 1, FailedNodeCount: 1, FailedNodes: []string{"node-none"}},
+				{Domain: "zone-a", Nodes: 2, ValidatedNodes: 2},
+				{Domain: "zone-b", Nodes: 1, FailedNodeCount: 1, FailedNodes: []string{"node-b1"}},
+			},
+		},
+		{
+			description:   "nodes grouped by custom failure domain label",
+			validatorSpec: gpuv1.ValidatorSpec{FailureDomainLabel: "example.com/rack"},
+			objects: []client.Object{
+				gpuNode("node-1", map[string]string{corev1.LabelTopologyZone: "zone-a", "example.com/rack": "rack-1"}),
+				gpuNode("node-2", map[string]string{corev1.LabelTopologyZone: "zone-a", "example.com/rack": "rack-2"}),
+				validatorPod("node-1", corev1.ConditionTrue),
+				validatorPod("node-2", corev1.ConditionTrue),
+			},
+			expectedDomains: []gpuv1.ValidationDomainStatus{
+				{Domain: "rack-1", Nodes: 1, ValidatedNodes: 1},
+				{Domain: "rack-2", Nodes: 1, ValidatedNodes: 1},
+			},
+		},
+		{
+			description:     "failed nodes listed up to the limit",
+			objects:         largeDomainNodes,
+			expectedDomains: []gpuv1.ValidationDomainStatus{largeDomain},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			n := ClusterPolicyController{
+				client:            fake.NewClientBuilder().WithObjects(tc.objects...).Build(),
+				operatorNamespace: "test-ns",
+				singleton: &gpuv1.ClusterPolicy{
+					Spec: gpuv1.ClusterPolicySpec{Validator: tc.validatorSpec},
+				},
+			}
+			domains, err := n.getValidationDomains(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedDomains, domains)
+		})
+	}
+
+	require.Equal(t, []string{"zone-b", "zone-c"}, getFailedValidationDomains([]gpuv1.ValidationDomainStatus{
+		{Domain: "zone-a", Nodes: 1, ValidatedNodes: 1},
+		{Domain: "zone-b", Nodes: 1, FailedNodeCount: 1, FailedNodes: []string{"node-b1"}},
+		{Domain: "zone-c", Nodes: 1, FailedNodeCount: 1},
+	}))
+}
@@ -0,0 +1,230 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/audit"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+// nodeValidatorImage returns the value of the validator image label of the node with the given labels, which is
+// empty when the node runs the default validator image
+func nodeValidatorImage(spec *gpuv1.ValidatorSpec, labels map[string]string) string {
+	key := nodeValidatorImageKey(spec, labels)
+	if key == "" {
+		return ""
+	}
+	return gpuv1.ValidatorImageLabelValue(key)
+}
+
+// nodeValidatorImageKey returns the key of the validator image selected for the node with the given labels, which
+// is empty when the node runs the default validator image
+func nodeValidatorImageKey(spec *gpuv1.ValidatorSpec, labels map[string]string) string {
+	var os string
+	if labels[nfdOSReleaseIDLabelKey] != "" {
+		os = labels[nfdOSReleaseIDLabelKey] + labels[nfdOSVersionIDLabelKey]
+	}
+	return spec.SelectImage(labels[corev1.LabelArchStable], os)
+}
+
+// reconcileValidatorImageLabel keeps the validator image label of the GPU nodes in sync with the validator
+// images of the ClusterPolicy managing them. Returns true if labels were modified.
+func (nlc *nodeLabelingController) reconcileValidatorImageLabel(ctx context.Context, labels map[string]string, nodeName string) bool {
+	var value string
+	if clusterPolicy := nlc.nodeClusterPolicy(ctx, labels); clusterPolicy != nil && hasCommonGPULabel(labels) {
+		value = nodeValidatorImage(&clusterPolicy.Spec.Validator, labels)
+	}
+	if labels[consts.ValidatorImageLabelKey] == value {
+		return false
+	}
+	if value == "" {
+		nlc.logger.Info("Deleting node label", "NodeName", nodeName, "Label", consts.ValidatorImageLabelKey)
+		delete(labels, consts.ValidatorImageLabelKey)
+		return true
+	}
+	nlc.logger.Info("Setting node label", "NodeName", nodeName, "Label", consts.ValidatorImageLabelKey, "Value", value)
+	labels[consts.ValidatorImageLabelKey] = value
+	return true
+}
+
+// nodeClusterPolicy returns the ClusterPolicy managing the node with the given labels, which is the scoped
+// ClusterPolicy named by its owner label, or the singleton
+func (nlc *nodeLabelingController) nodeClusterPolicy(ctx context.Context, labels map[string]string) *gpuv1.ClusterPolicy {
+	owner := labels[consts.ClusterPolicyOwnerLabel]
+	if owner == "" || nlc.clusterPolicy == nil {
+		return nlc.clusterPolicy
+	}
+	clusterPolicy := &gpuv1.ClusterPolicy{}
+	if err := nlc.client.Get(ctx, types.NamespacedName{Name: owner}, clusterPolicy); err != nil {
+		nlc.logger.Error(err, "Failed to get the ClusterPolicy of the node", "ClusterPolicy", owner)
+		return nil
+	}
+	return clusterPolicy
+}
+
+// validatorImageDaemonSets deploys the DaemonSet of the current state for the nodes running the default
+// validator image, then once per validator image of the ClusterPolicy when the DaemonSet runs the validator
+// image. The DaemonSets of the validator images which are no longer set are deleted.
+func validatorImageDaemonSets(n ClusterPolicyController) (gpuv1.State, error) {
+	n.validatorImageResolved = true
+	n.validatorImages = nil
+
+	validator := &n.singleton.Spec.Validator
+	if len(validator.Images) > 0 && n.hasGPUNodes && n.isStateEnabled(n.stateNames[n.idx]) {
+		runsValidator, err := runsValidatorImage(n)
+		if err != nil {
+			return gpuv1.NotReady, err
+		}
+		if runsValidator {
+			n.validatorImages = make(map[string]*gpuv1.ValidatorImageSpec, len(validator.Images))
+			for key, image := range validator.Images {
+				n.validatorImages[gpuv1.ValidatorImageLabelValue(key)] = &image
+			}
+		}
+	}
+
+	if err := n.deleteStaleValidatorImageDaemonSets(); err != nil {
+		return gpuv1.NotReady, err
+	}
+
+	overallState, err := DaemonSet(n)
+	if err != nil {
+		return overallState, err
+	}
+	for _, value := range slices.Sorted(maps.Keys(n.validatorImages)) {
+		variant := n
+		variant.validatorImage = value
+		state, err := DaemonSet(variant)
+		if err != nil {
+			return gpuv1.NotReady, err
+		}
+		if state == gpuv1.NotReady {
+			overallState = gpuv1.NotReady
+		}
+	}
+	return overallState, nil
+}
+
+// runsValidatorImage returns true if the rendered DaemonSet of the current state runs the validator image
+func runsValidatorImage(n ClusterPolicyController) (bool, error) {
+	image, err := gpuv1.ImagePath(&n.singleton.Spec.Validator)
+	if err != nil {
+		return false, err
+	}
+	obj := n.resources[n.idx].DaemonSet.DeepCopy()
+	if err := preProcessDaemonSet(obj, n); err != nil {
+		return false, err
+	}
+	podSpec := &obj.Spec.Template.Spec
+	for _, container := range slices.Concat(podSpec.InitContainers, podSpec.Containers) {
+		if container.Image == image {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// deleteStaleValidatorImageDaemonSets deletes the DaemonSets of the current state deployed for the validator
+// images which are no longer set for the ClusterPolicy being reconciled
+func (n ClusterPolicyController) deleteStaleValidatorImageDaemonSets() error {
+	items, err := n.listVariantDaemonSets(consts.ValidatorImageLabelKey)
+	if err != nil {
+		return err
+	}
+	for i := range items {
+		ds := &items[i]
+		if !n.ownsObject(ds.Labels) || n.validatorImages[ds.Labels[consts.ValidatorImageLabelKey]] != nil {
+			continue
+		}
+		err := n.client.Delete(audit.WithReason(n.ctx, "validator image removed"), ds)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyValidatorImage restricts the DaemonSet to the nodes running the validator image being deployed. The
+// DaemonSet of a validator image is named and labeled after it, runs it in place of the default validator image,
+// and is only scheduled on the nodes labeled with it, while the DaemonSet of the default validator image is
+// kept off these nodes.
+func applyValidatorImage(obj *appsv1.DaemonSet, n ClusterPolicyController) error {
+	if len(n.validatorImages) == 0 {
+		return nil
+	}
+	podSpec := &obj.Spec.Template.Spec
+	if n.validatorImage == "" {
+		addRequiredNodeSelectorRequirement(podSpec, corev1.NodeSelectorRequirement{
+			Key:      consts.ValidatorImageLabelKey,
+			Operator: corev1.NodeSelectorOpDoesNotExist,
+		})
+		return nil
+	}
+
+	defaultImage, err := gpuv1.ImagePath(&n.singleton.Spec.Validator)
+	if err != nil {
+		return err
+	}
+	image, err := gpuv1.ImagePath(n.validatorImages[n.validatorImage])
+	if err != nil {
+		return err
+	}
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			container := &containers[i]
+			if container.Image == defaultImage {
+				container.Image = image
+			}
+			// the validation workload pods are spun off with the image passed by the validator
+			for j := range container.Env {
+				if container.Env[j].Name == ValidatorImageEnvName && container.Env[j].Value == defaultImage {
+					container.Env[j].Value = image
+				}
+			}
+		}
+	}
+
+	obj.Name += "-" + n.validatorImage
+	if obj.Labels == nil {
+		obj.Labels = make(map[string]string)
+	}
+	obj.Labels[consts.ValidatorImageLabelKey] = n.validatorImage
+	if obj.Spec.Selector.MatchLabels == nil {
+		obj.Spec.Selector.MatchLabels = make(map[string]string)
+	}
+	obj.Spec.Selector.MatchLabels[consts.ValidatorImageLabelKey] = n.validatorImage
+	if obj.Spec.Template.Labels == nil {
+		obj.Spec.Template.Labels = make(map[string]string)
+	}
+	obj.Spec.Template.Labels[consts.ValidatorImageLabelKey] = n.validatorImage
+	addRequiredNodeSelectorRequirement(podSpec, corev1.NodeSelectorRequirement{
+		Key:      consts.ValidatorImageLabelKey,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{n.validatorImage},
+	})
+	return nil
+}
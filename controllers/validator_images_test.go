@@ -0,0 +1,159 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+func newValidatorImagesSpec() gpuv1.ValidatorSpec {
+	return gpuv1.ValidatorSpec{
+		Repository: "nvcr.io/nvidia/cloud-native",
+		Image:      "gpu-operator-validator",
+		Version:    "v1.0.0",
+		Images: map[string]gpuv1.ValidatorImageSpec{
+			"arm64":         {Repository: "nvcr.io/nvidia/cloud-native", Image: "gpu-operator-validator", Version: "v1.0.0-arm64"},
+			"rhel9.4":       {Repository: "nvcr.io/nvidia/cloud-native", Image: "gpu-operator-validator", Version: "v1.0.0-rhel9.4"},
+			"arm64/rhel9.4": {Repository: "nvcr.io/nvidia/cloud-native", Image: "gpu-operator-validator", Version: "v1.0.0-arm64-rhel9.4"},
+		},
+	}
+}
+
+func TestNodeValidatorImage(t *testing.T) {
+	spec := newValidatorImagesSpec()
+	nodeLabels := func(arch, osID, osVersion string) map[string]string {
+		return map[string]string{
+			corev1.LabelArchStable: arch,
+			nfdOSReleaseIDLabelKey: osID,
+			nfdOSVersionIDLabelKey: osVersion,
+		}
+	}
+
+	require.Equal(t, "arm64-rhel9.4", nodeValidatorImage(&spec, nodeLabels("arm64", "rhel", "9.4")))
+	require.Equal(t, "rhel9.4", nodeValidatorImage(&spec, nodeLabels("amd64", "rhel", "9.4")))
+	require.Equal(t, "arm64", nodeValidatorImage(&spec, nodeLabels("arm64", "ubuntu", "22.04")))
+	require.Equal(t, "arm64", nodeValidatorImage(&spec, map[string]string{corev1.LabelArchStable: "arm64"}))
+	require.Empty(t, nodeValidatorImage(&spec, nodeLabels("amd64", "ubuntu", "22.04")))
+	require.Empty(t, nodeValidatorImage(&gpuv1.ValidatorSpec{}, nodeLabels("arm64", "rhel", "9.4")))
+}
+
+func TestValidateValidatorImages(t *testing.T) {
+	spec := newValidatorImagesSpec()
+	require.NoError(t, spec.ValidateImages())
+
+	for _, key := range []string{"arm64/rhel9.4/extra", "/rhel9.4", "ARM64", "arm64_rhel"} {
+		spec := gpuv1.ValidatorSpec{Images: map[string]gpuv1.ValidatorImageSpec{key: {Image: "gpu-operator-validator"}}}
+		require.Error(t, spec.ValidateImages(), key)
+	}
+	spec = gpuv1.ValidatorSpec{Images: map[string]gpuv1.ValidatorImageSpec{"arm64": {}}}
+	require.Error(t, spec.ValidateImages())
+}
+
+func TestReconcileValidatorImageLabel(t *testing.T) {
+	nlc := &nodeLabelingController{
+		clusterPolicy: &gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{Validator: newValidatorImagesSpec()}},
+		logger:        logr.Discard(),
+	}
+	ctx := context.Background()
+
+	labels := map[string]string{commonGPULabelKey: commonGPULabelValue, corev1.LabelArchStable: "arm64"}
+	require.True(t, nlc.reconcileValidatorImageLabel(ctx, labels, "test-node"))
+	require.Equal(t, "arm64", labels[consts.ValidatorImageLabelKey])
+	require.False(t, nlc.reconcileValidatorImageLabel(ctx, labels, "test-node"))
+
+	// the label is removed once the node runs the default validator image
+	nlc.clusterPolicy.Spec.Validator.Images = nil
+	require.True(t, nlc.reconcileValidatorImageLabel(ctx, labels, "test-node"))
+	require.NotContains(t, labels, consts.ValidatorImageLabelKey)
+}
+
+func TestApplyValidatorImage(t *testing.T) {
+	const defaultImage = "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0"
+	newDaemonSet := func() *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "nvidia-device-plugin-daemonset"},
+			Spec: appsv1.DaemonSetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nvidia-device-plugin-daemonset"}},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "nvidia-device-plugin-daemonset"}},
+					Spec: corev1.PodSpec{
+						InitContainers: []corev1.Container{{
+							Name:  "toolkit-validation",
+							Image: defaultImage,
+							Env:   []corev1.EnvVar{{Name: ValidatorImageEnvName, Value: defaultImage}},
+						}},
+						Containers: []corev1.Container{{Name: "nvidia-device-plugin", Image: "nvcr.io/nvidia/k8s-device-plugin:v0.17.0"}},
+					},
+				},
+			},
+		}
+	}
+	spec := newValidatorImagesSpec()
+	n := ClusterPolicyController{singleton: &gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{Validator: spec}}}
+
+	// no validator images
+	ds := newDaemonSet()
+	require.NoError(t, applyValidatorImage(ds, n))
+	require.Equal(t, newDaemonSet(), ds)
+
+	n.validatorImages = map[string]*gpuv1.ValidatorImageSpec{}
+	for key, image := range spec.Images {
+		n.validatorImages[gpuv1.ValidatorImageLabelValue(key)] = &image
+	}
+
+	// default validator image
+	ds = newDaemonSet()
+	require.NoError(t, applyValidatorImage(ds, n))
+	require.Equal(t, "nvidia-device-plugin-daemonset", ds.Name)
+	require.Equal(t, defaultImage, ds.Spec.Template.Spec.InitContainers[0].Image)
+	require.Equal(t, []corev1.NodeSelectorTerm{{
+		MatchExpressions: []corev1.NodeSelectorRequirement{{
+			Key:      consts.ValidatorImageLabelKey,
+			Operator: corev1.NodeSelectorOpDoesNotExist,
+		}},
+	}}, ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+
+	// validator image of a node architecture and OS
+	n.validatorImage = "arm64-rhel9.4"
+	ds = newDaemonSet()
+	require.NoError(t, applyValidatorImage(ds, n))
+	image := "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v1.0.0-arm64-rhel9.4"
+	require.Equal(t, "nvidia-device-plugin-daemonset-arm64-rhel9.4", ds.Name)
+	require.Equal(t, image, ds.Spec.Template.Spec.InitContainers[0].Image)
+	require.Equal(t, image, ds.Spec.Template.Spec.InitContainers[0].Env[0].Value)
+	require.Equal(t, "nvcr.io/nvidia/k8s-device-plugin:v0.17.0", ds.Spec.Template.Spec.Containers[0].Image)
+	require.Equal(t, "arm64-rhel9.4", ds.Labels[consts.ValidatorImageLabelKey])
+	require.Equal(t, "arm64-rhel9.4", ds.Spec.Selector.MatchLabels[consts.ValidatorImageLabelKey])
+	require.Equal(t, "arm64-rhel9.4", ds.Spec.Template.Labels[consts.ValidatorImageLabelKey])
+	require.Equal(t, []corev1.NodeSelectorTerm{{
+		MatchExpressions: []corev1.NodeSelectorRequirement{{
+			Key:      consts.ValidatorImageLabelKey,
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   []string{"arm64-rhel9.4"},
+		}},
+	}}, ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+}
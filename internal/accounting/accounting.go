@@ -0,0 +1,281 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package accounting aggregates the GPUs allocated to the pods, including the MIG slices and the shares of
+// time-sliced or MPS GPUs, into usage records suitable for chargeback.
+package accounting
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// GPUResourceName is the resource of full GPUs, or of GPU shares when the GPUs are shared without renaming
+	GPUResourceName = "nvidia.com/gpu"
+	// SharedGPUResourceName is the resource of GPU shares when the shared GPUs are renamed
+	SharedGPUResourceName = "nvidia.com/gpu.shared"
+
+	// gpuReplicasLabelKey is the number of shares of each GPU of the node, published by GPU Feature Discovery
+	gpuReplicasLabelKey = "nvidia.com/gpu.replicas"
+	// gpuProductLabelKey is the product name of the GPUs of the node, published by GPU Feature Discovery
+	gpuProductLabelKey = "nvidia.com/gpu.product"
+
+	// migComputeSlices is the number of compute slices of the GPUs supporting MIG, except the A30
+	migComputeSlices = 7
+	// a30MIGComputeSlices is the number of compute slices of the A30
+	a30MIGComputeSlices = 4
+)
+
+// migResourcePattern matches the MIG resources, e.g. nvidia.com/mig-3g.40gb, capturing the compute slices
+var migResourcePattern = regexp.MustCompile(`^nvidia\.com/mig-(\d+)g\.`)
+
+// Record is the usage of a GPU resource by a pod over the period of a report
+type Record struct {
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	Node      string `json:"node"`
+	// Resource is the resource allocated to the pod, e.g. nvidia.com/gpu or nvidia.com/mig-1g.10gb
+	Resource string `json:"resource"`
+	// Devices is the number of devices of the resource allocated to the pod: GPUs, MIG devices or GPU shares
+	Devices int64 `json:"devices"`
+	// GPUs is the allocation expressed in full GPUs, a MIG device or a GPU share counting as a fraction of a GPU
+	GPUs float64 `json:"gpus"`
+	// Seconds is the time the pod held the allocation during the period
+	Seconds float64 `json:"seconds"`
+	// GPUSeconds is the allocation in full GPUs multiplied by the time it was held
+	GPUSeconds float64 `json:"gpuSeconds"`
+	// Utilization is the average utilization in percent of the GPUs of the pod reported by DCGM when the record
+	// was generated, omitted when DCGM does not report the pod
+	Utilization *float64 `json:"utilization,omitempty"`
+}
+
+// Report lists the usage records of a period, sorted by namespace, pod and resource
+type Report struct {
+	PeriodStart time.Time `json:"periodStart"`
+	PeriodEnd   time.Time `json:"periodEnd"`
+	Records     []Record  `json:"records"`
+}
+
+// PodKey identifies a pod in the utilization reported by DCGM
+type PodKey struct {
+	Namespace string
+	Name      string
+}
+
+// BuildReport aggregates the GPUs allocated to the pods bound to the given nodes between start and end. The
+// pods must include the pods terminated during the period for their usage to be accounted. The utilization of
+// the pods is optional.
+func BuildReport(pods []corev1.Pod, nodes map[string]*corev1.Node, utilization map[PodKey]float64, start time.Time, end time.Time) *Report {
+	report := &Report{PeriodStart: start.UTC(), PeriodEnd: end.UTC(), Records: []Record{}}
+	for i := range pods {
+		pod := &pods[i]
+		node, ok := nodes[pod.Spec.NodeName]
+		if !ok {
+			continue
+		}
+		seconds := getAllocatedSeconds(pod, start, end)
+		if seconds <= 0 {
+			continue
+		}
+		for resource, devices := range GetAllocatedDevices(pod) {
+			gpus := float64(devices) * getGPUsPerDevice(resource, node)
+			record := Record{
+				Namespace:  pod.Namespace,
+				Pod:        pod.Name,
+				Node:       pod.Spec.NodeName,
+				Resource:   resource,
+				Devices:    devices,
+				GPUs:       gpus,
+				Seconds:    seconds,
+				GPUSeconds: gpus * seconds,
+			}
+			if value, ok := utilization[PodKey{Namespace: pod.Namespace, Name: pod.Name}]; ok {
+				record.Utilization = &value
+			}
+			report.Records = append(report.Records, record)
+		}
+	}
+	sort.Slice(report.Records, func(i, j int) bool {
+		a, b := report.Records[i], report.Records[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Pod != b.Pod {
+			return a.Pod < b.Pod
+		}
+		return a.Resource < b.Resource
+	})
+	return report
+}
+
+// GetAllocatedDevices returns the number of devices of each GPU resource allocated to the pod. As for the
+// scheduler, the effective allocation is the larger of the sum over the containers and of the largest init
+// container.
+func GetAllocatedDevices(pod *corev1.Pod) map[string]int64 {
+	devices := make(map[string]int64)
+	for _, ctr := range pod.Spec.Containers {
+		for resource, count := range getContainerDevices(&ctr) {
+			devices[resource] += count
+		}
+	}
+	for _, ctr := range pod.Spec.InitContainers {
+		for resource, count := range getContainerDevices(&ctr) {
+			if count > devices[resource] {
+				devices[resource] = count
+			}
+		}
+	}
+	return devices
+}
+
+// getContainerDevices returns the number of devices of each GPU resource allocated to the container. The
+// requests of extended resources default to the limits and must be equal to them when both are set.
+func getContainerDevices(ctr *corev1.Container) map[string]int64 {
+	devices := make(map[string]int64)
+	for _, resources := range []corev1.ResourceList{ctr.Resources.Requests, ctr.Resources.Limits} {
+		for resource, quantity := range resources {
+			if IsGPUResource(string(resource)) && quantity.Value() > 0 {
+				devices[string(resource)] = quantity.Value()
+			}
+		}
+	}
+	return devices
+}
+
+// IsGPUResource returns true for the GPU resources advertised by the device plugin, including the MIG resources
+func IsGPUResource(resource string) bool {
+	return resource == GPUResourceName || resource == SharedGPUResourceName || strings.HasPrefix(resource, "nvidia.com/mig-")
+}
+
+// getGPUsPerDevice returns the fraction of a GPU a device of the resource represents on the node
+func getGPUsPerDevice(resource string, node *corev1.Node) float64 {
+	if match := migResourcePattern.FindStringSubmatch(resource); match != nil {
+		slices, _ := strconv.Atoi(match[1])
+		total := migComputeSlices
+		if strings.Contains(node.Labels[gpuProductLabelKey], "A30") {
+			total = a30MIGComputeSlices
+		}
+		return float64(slices) / float64(total)
+	}
+	// the GPUs shared through time-slicing or MPS are advertised as replicas
+	if replicas, err := strconv.Atoi(node.Labels[gpuReplicasLabelKey]); err == nil && replicas > 1 {
+		return 1 / float64(replicas)
+	}
+	return 1
+}
+
+// getAllocatedSeconds returns the time the pod held its devices between start and end, from the start of the
+// pod to the termination of its last container
+func getAllocatedSeconds(pod *corev1.Pod, start time.Time, end time.Time) float64 {
+	if pod.Status.StartTime == nil {
+		return 0
+	}
+	from := pod.Status.StartTime.Time
+	to := end
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		to = time.Time{}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Terminated != nil && status.State.Terminated.FinishedAt.After(to) {
+				to = status.State.Terminated.FinishedAt.Time
+			}
+		}
+	}
+	if from.Before(start) {
+		from = start
+	}
+	if to.After(end) {
+		to = end
+	}
+	if !to.After(from) {
+		return 0
+	}
+	return to.Sub(from).Seconds()
+}
+
+// NamespaceTotal is the GPU allocation of a namespace for a resource
+type NamespaceTotal struct {
+	Namespace  string
+	Resource   string
+	GPUs       float64
+	GPUSeconds float64
+}
+
+// NamespaceTotals sums the records of the report per namespace and resource
+func (r *Report) NamespaceTotals() []NamespaceTotal {
+	index := make(map[[2]string]*NamespaceTotal)
+	totals := []*NamespaceTotal{}
+	for _, record := range r.Records {
+		key := [2]string{record.Namespace, record.Resource}
+		total, ok := index[key]
+		if !ok {
+			total = &NamespaceTotal{Namespace: record.Namespace, Resource: record.Resource}
+			index[key] = total
+			totals = append(totals, total)
+		}
+		total.GPUs += record.GPUs
+		total.GPUSeconds += record.GPUSeconds
+	}
+	result := make([]NamespaceTotal, 0, len(totals))
+	for _, total := range totals {
+		result = append(result, *total)
+	}
+	return result
+}
+
+// JSON encodes the report in JSON
+func (r *Report) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// CSV encodes the records of the report in CSV, with a header row and one row per record
+func (r *Report) CSV() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+	rows := [][]string{{"periodStart", "periodEnd", "namespace", "pod", "node", "resource", "devices", "gpus", "seconds", "gpuSeconds", "utilization"}}
+	formatFloat := func(f float64) string { return strconv.FormatFloat(f, 'f', -1, 64) }
+	for _, record := range r.Records {
+		utilization := ""
+		if record.Utilization != nil {
+			utilization = formatFloat(*record.Utilization)
+		}
+		rows = append(rows, []string{
+			r.PeriodStart.Format(time.RFC3339),
+			r.PeriodEnd.Format(time.RFC3339),
+			record.Namespace,
+			record.Pod,
+			record.Node,
+			record.Resource,
+			strconv.FormatInt(record.Devices, 10),
+			formatFloat(record.GPUs),
+			formatFloat(record.Seconds),
+			formatFloat(record.GPUSeconds),
+			utilization,
+		})
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
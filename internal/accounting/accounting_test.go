@@ -0,0 +1,118 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package accounting
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func newPod(namespace string, name string, node string, started time.Time, limits corev1.ResourceList) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: corev1.PodSpec{
+			NodeName:   node,
+			Containers: []corev1.Container{{Name: "ctr", Resources: corev1.ResourceRequirements{Limits: limits}}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, StartTime: &metav1.Time{Time: started}},
+	}
+}
+
+func TestBuildReport(t *testing.T) {
+	end := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	start := end.Add(-time.Hour)
+
+	nodes := map[string]*corev1.Node{
+		"full":   {ObjectMeta: metav1.ObjectMeta{Name: "full"}},
+		"shared": {ObjectMeta: metav1.ObjectMeta{Name: "shared", Labels: map[string]string{gpuReplicasLabelKey: "4"}}},
+		"mig":    {ObjectMeta: metav1.ObjectMeta{Name: "mig", Labels: map[string]string{gpuProductLabelKey: "NVIDIA-A100-SXM4-40GB"}}},
+		"a30":    {ObjectMeta: metav1.ObjectMeta{Name: "a30", Labels: map[string]string{gpuProductLabelKey: "NVIDIA-A30"}}},
+	}
+
+	// running for the whole period with 2 GPUs, and an init container requesting fewer GPUs
+	training := newPod("team-a", "training", "full", start.Add(-time.Hour), corev1.ResourceList{GPUResourceName: resource.MustParse("2")})
+	training.Spec.InitContainers = []corev1.Container{{Name: "init", Resources: corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{GPUResourceName: resource.MustParse("1")},
+	}}}
+	// started half way through the period on a time-sliced GPU
+	notebook := newPod("team-b", "notebook", "shared", start.Add(30*time.Minute), corev1.ResourceList{SharedGPUResourceName: resource.MustParse("1")})
+	// terminated after 15 minutes
+	job := newPod("team-a", "job", "mig", start, corev1.ResourceList{"nvidia.com/mig-3g.20gb": resource.MustParse("1")})
+	job.Status.Phase = corev1.PodSucceeded
+	job.Status.ContainerStatuses = []corev1.ContainerStatus{{State: corev1.ContainerState{
+		Terminated: &corev1.ContainerStateTerminated{FinishedAt: metav1.Time{Time: start.Add(15 * time.Minute)}},
+	}}}
+	inference := newPod("team-b", "inference", "a30", start, corev1.ResourceList{"nvidia.com/mig-1g.6gb": resource.MustParse("2")})
+	pods := []corev1.Pod{
+		training,
+		notebook,
+		job,
+		inference,
+		// no GPU
+		newPod("team-a", "web", "full", start, corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}),
+		// not a GPU node
+		newPod("team-a", "other", "cpu", start, corev1.ResourceList{GPUResourceName: resource.MustParse("1")}),
+		// started after the period
+		newPod("team-a", "later", "full", end.Add(time.Minute), corev1.ResourceList{GPUResourceName: resource.MustParse("1")}),
+	}
+	utilization := map[PodKey]float64{{Namespace: "team-a", Name: "training"}: 87.5}
+	migGPUs := 3.0 / 7
+
+	report := BuildReport(pods, nodes, utilization, start, end)
+	require.Equal(t, start, report.PeriodStart)
+	require.Equal(t, end, report.PeriodEnd)
+	require.Equal(t, []Record{
+		{Namespace: "team-a", Pod: "job", Node: "mig", Resource: "nvidia.com/mig-3g.20gb", Devices: 1, GPUs: migGPUs, Seconds: 900, GPUSeconds: migGPUs * 900},
+		{Namespace: "team-a", Pod: "training", Node: "full", Resource: GPUResourceName, Devices: 2, GPUs: 2, Seconds: 3600, GPUSeconds: 7200, Utilization: ptr.To(87.5)},
+		{Namespace: "team-b", Pod: "inference", Node: "a30", Resource: "nvidia.com/mig-1g.6gb", Devices: 2, GPUs: 0.5, Seconds: 3600, GPUSeconds: 1800},
+		{Namespace: "team-b", Pod: "notebook", Node: "shared", Resource: SharedGPUResourceName, Devices: 1, GPUs: 0.25, Seconds: 1800, GPUSeconds: 450},
+	}, report.Records)
+
+	require.ElementsMatch(t, []NamespaceTotal{
+		{Namespace: "team-a", Resource: "nvidia.com/mig-3g.20gb", GPUs: migGPUs, GPUSeconds: migGPUs * 900},
+		{Namespace: "team-a", Resource: GPUResourceName, GPUs: 2, GPUSeconds: 7200},
+		{Namespace: "team-b", Resource: "nvidia.com/mig-1g.6gb", GPUs: 0.5, GPUSeconds: 1800},
+		{Namespace: "team-b", Resource: SharedGPUResourceName, GPUs: 0.25, GPUSeconds: 450},
+	}, report.NamespaceTotals())
+}
+
+func TestReportCSV(t *testing.T) {
+	end := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	report := &Report{
+		PeriodStart: end.Add(-time.Hour),
+		PeriodEnd:   end,
+		Records: []Record{
+			{Namespace: "team-a", Pod: "training", Node: "node-a", Resource: GPUResourceName, Devices: 2, GPUs: 2, Seconds: 3600, GPUSeconds: 7200, Utilization: ptr.To(87.5)},
+			{Namespace: "team-b", Pod: "notebook", Node: "node-b", Resource: SharedGPUResourceName, Devices: 1, GPUs: 0.25, Seconds: 1800, GPUSeconds: 450},
+		},
+	}
+	data, err := report.CSV()
+	require.NoError(t, err)
+	require.Equal(t, strings.Join([]string{
+		"periodStart,periodEnd,namespace,pod,node,resource,devices,gpus,seconds,gpuSeconds,utilization",
+		"2026-01-02T11:00:00Z,2026-01-02T12:00:00Z,team-a,training,node-a,nvidia.com/gpu,2,2,3600,7200,87.5",
+		"2026-01-02T11:00:00Z,2026-01-02T12:00:00Z,team-b,notebook,node-b,nvidia.com/gpu.shared,1,0.25,1800,450,",
+		"",
+	}, "\n"), string(data))
+}
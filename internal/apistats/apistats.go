@@ -0,0 +1,283 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package apistats counts the requests the controllers make to the Kubernetes API and to the informer cache,
+// per reconciliation, so that access patterns issuing a request per node or per operand are detectable from the
+// metrics and from the debug endpoint.
+package apistats
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	promcli "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// SourceCache is the source of the reads served by the informer cache
+	SourceCache = "cache"
+	// SourceAPI is the source of the reads and writes sent to the API server
+	SourceAPI = "api"
+
+	// DebugPath is the path of the endpoint returning the statistics of the reconciliations
+	DebugPath = "/debug/apistats"
+
+	// noController is the controller label of the requests made outside of a reconciliation
+	noController = "none"
+
+	metricsNamespace = "gpu_operator"
+)
+
+// Request identifies the requests of the same verb on the same resource and from the same source
+type Request struct {
+	Verb     string `json:"verb"`
+	Resource string `json:"resource"`
+	Source   string `json:"source"`
+}
+
+// RequestCount is the number of requests of a kind
+type RequestCount struct {
+	Request
+	Count int `json:"count"`
+}
+
+// ReconcileStats are the requests made by a reconciliation
+type ReconcileStats struct {
+	StartedAt time.Time `json:"startedAt"`
+	Duration  string    `json:"duration"`
+	// Total is the number of requests, from the cache and to the API server
+	Total int `json:"total"`
+	// APIRequests is the number of requests sent to the API server
+	APIRequests int `json:"apiRequests"`
+	// CacheReads and APIReads are the number of reads served by the cache and by the API server
+	CacheReads int `json:"cacheReads"`
+	APIReads   int `json:"apiReads"`
+	// CacheHitRatio is the ratio of the reads served by the cache
+	CacheHitRatio float64 `json:"cacheHitRatio"`
+	// Requests lists the requests by verb, resource and source, the most frequent first
+	Requests []RequestCount `json:"requests"`
+}
+
+// ControllerStats are the statistics of the reconciliations of a controller
+type ControllerStats struct {
+	Controller string `json:"controller"`
+	Reconciles int    `json:"reconciles"`
+	// CacheHitRatio is the ratio of the reads served by the cache since the operator started
+	CacheHitRatio float64 `json:"cacheHitRatio"`
+	// LastReconcile are the requests made by the last reconciliation
+	LastReconcile ReconcileStats `json:"lastReconcile"`
+	// MaxAPIRequests is the largest number of requests sent to the API server by a reconciliation
+	MaxAPIRequests int `json:"maxAPIRequests"`
+}
+
+// controllerTotals accumulates the statistics of a controller
+type controllerTotals struct {
+	reconciles     int
+	cacheReads     int
+	apiReads       int
+	maxAPIRequests int
+	last           ReconcileStats
+}
+
+// Tracker counts the requests made through the clients it wraps. A nil Tracker counts nothing, so that the
+// controllers do not depend on the statistics being enabled.
+type Tracker struct {
+	requests          *promcli.CounterVec
+	reconcileRequests *promcli.HistogramVec
+
+	mu          sync.RWMutex
+	controllers map[string]*controllerTotals
+}
+
+// NewTracker returns a Tracker whose metrics are registered with the given registry
+func NewTracker(registry promcli.Registerer) *Tracker {
+	t := &Tracker{
+		requests: promcli.NewCounterVec(
+			promcli.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "api_requests_total",
+				Help:      "Number of requests of the controllers by verb and resource, served by the informer cache or sent to the API server",
+			},
+			[]string{"controller", "verb", "resource", "source"},
+		),
+		reconcileRequests: promcli.NewHistogramVec(
+			promcli.HistogramOpts{
+				Namespace: metricsNamespace,
+				Name:      "reconcile_api_requests",
+				Help:      "Number of requests made by a reconciliation, served by the informer cache or sent to the API server",
+				Buckets:   promcli.ExponentialBuckets(1, 2, 12),
+			},
+			[]string{"controller", "source"},
+		),
+		controllers: map[string]*controllerTotals{},
+	}
+	registry.MustRegister(t.requests, t.reconcileRequests)
+	return t
+}
+
+type reconcileKey struct{}
+
+// reconcile counts the requests of a reconciliation
+type reconcile struct {
+	mu         sync.Mutex
+	controller string
+	startedAt  time.Time
+	counts     map[Request]int
+}
+
+// StartReconcile returns a context counting the requests of a reconciliation of the given controller, and the
+// function recording them once the reconciliation is done
+func (t *Tracker) StartReconcile(ctx context.Context, controller string) (context.Context, func()) {
+	if t == nil {
+		return ctx, func() {}
+	}
+	r := &reconcile{controller: controller, startedAt: time.Now(), counts: map[Request]int{}}
+	return context.WithValue(ctx, reconcileKey{}, r), func() { t.finish(r) }
+}
+
+// count records a request made with the given context
+func (t *Tracker) count(ctx context.Context, request Request) {
+	if t == nil {
+		return
+	}
+	controller := noController
+	if r, ok := ctx.Value(reconcileKey{}).(*reconcile); ok {
+		controller = r.controller
+		r.mu.Lock()
+		r.counts[request]++
+		r.mu.Unlock()
+	}
+	t.requests.WithLabelValues(controller, request.Verb, request.Resource, request.Source).Inc()
+}
+
+// finish records the requests of a reconciliation
+func (t *Tracker) finish(r *reconcile) {
+	r.mu.Lock()
+	stats := newReconcileStats(r.startedAt, r.counts)
+	r.mu.Unlock()
+
+	t.reconcileRequests.WithLabelValues(r.controller, SourceCache).Observe(float64(stats.Total - stats.APIRequests))
+	t.reconcileRequests.WithLabelValues(r.controller, SourceAPI).Observe(float64(stats.APIRequests))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	totals, ok := t.controllers[r.controller]
+	if !ok {
+		totals = &controllerTotals{}
+		t.controllers[r.controller] = totals
+	}
+	totals.reconciles++
+	totals.cacheReads += stats.CacheReads
+	totals.apiReads += stats.APIReads
+	totals.maxAPIRequests = max(totals.maxAPIRequests, stats.APIRequests)
+	totals.last = stats
+}
+
+// Controllers returns the statistics of the reconciliations of the controllers, sorted by controller name
+func (t *Tracker) Controllers() []ControllerStats {
+	if t == nil {
+		return nil
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	stats := make([]ControllerStats, 0, len(t.controllers))
+	for controller, totals := range t.controllers {
+		stats = append(stats, ControllerStats{
+			Controller:     controller,
+			Reconciles:     totals.reconciles,
+			CacheHitRatio:  ratio(totals.cacheReads, totals.apiReads),
+			LastReconcile:  totals.last,
+			MaxAPIRequests: totals.maxAPIRequests,
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Controller < stats[j].Controller })
+	return stats
+}
+
+// Handler returns the handler of the debug endpoint
+func (t *Tracker) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		stats := t.Controllers()
+		if name := r.URL.Query().Get("controller"); name != "" {
+			filtered := []ControllerStats{}
+			for _, s := range stats {
+				if s.Controller == name {
+					filtered = append(filtered, s)
+				}
+			}
+			stats = filtered
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"controllers": stats})
+	})
+}
+
+func newReconcileStats(startedAt time.Time, counts map[Request]int) ReconcileStats {
+	stats := ReconcileStats{
+		StartedAt: startedAt,
+		Duration:  time.Since(startedAt).Round(time.Millisecond).String(),
+		Requests:  make([]RequestCount, 0, len(counts)),
+	}
+	for request, count := range counts {
+		stats.Total += count
+		if request.Source == SourceAPI {
+			stats.APIRequests += count
+		}
+		if isRead(request.Verb) {
+			if request.Source == SourceCache {
+				stats.CacheReads += count
+			} else {
+				stats.APIReads += count
+			}
+		}
+		stats.Requests = append(stats.Requests, RequestCount{Request: request, Count: count})
+	}
+	stats.CacheHitRatio = ratio(stats.CacheReads, stats.APIReads)
+	sort.Slice(stats.Requests, func(i, j int) bool {
+		a, b := stats.Requests[i], stats.Requests[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Resource != b.Resource {
+			return a.Resource < b.Resource
+		}
+		if a.Verb != b.Verb {
+			return a.Verb < b.Verb
+		}
+		return a.Source < b.Source
+	})
+	return stats
+}
+
+// ratio returns the ratio of the reads served by the cache, 1 when there was no read
+func ratio(cacheReads int, apiReads int) float64 {
+	if cacheReads+apiReads == 0 {
+		return 1
+	}
+	return float64(cacheReads) / float64(cacheReads+apiReads)
+}
+
+func isRead(verb string) bool {
+	return verb == verbGet || verb == verbList
+}
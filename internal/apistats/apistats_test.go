@@ -0,0 +1,155 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package apistats
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	promcli "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTestClient(t *testing.T) (client.Client, client.Reader, *Tracker) {
+	t.Helper()
+	nodes := []client.Object{
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(nodes...).Build()
+	tracker := NewTracker(promcli.NewRegistry())
+	return NewClient(c, tracker), NewReader(c, scheme.Scheme, tracker), tracker
+}
+
+func TestTrackerCountsReconcileRequests(t *testing.T) {
+	c, reader, tracker := newTestClient(t)
+
+	ctx, done := tracker.StartReconcile(context.Background(), "ClusterPolicy")
+	nodeList := &corev1.NodeList{}
+	require.NoError(t, c.List(ctx, nodeList))
+	for i := range nodeList.Items {
+		require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(&nodeList.Items[i]), &corev1.Node{}))
+	}
+	require.NoError(t, reader.Get(ctx, client.ObjectKey{Name: "node-a"}, &corev1.Node{}))
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(schema.GroupVersionKind{Version: "v1", Kind: "Node"})
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "node-b"}, u))
+	require.NoError(t, c.Create(ctx, &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "ds", Namespace: "test"}}))
+	node := &corev1.Node{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "node-a"}, node))
+	require.NoError(t, c.Status().Update(ctx, node))
+	done()
+
+	// requests made outside of a reconciliation are only counted by the metrics
+	require.NoError(t, c.List(context.Background(), &corev1.NodeList{}))
+
+	stats := tracker.Controllers()
+	require.Len(t, stats, 1)
+	require.Equal(t, "ClusterPolicy", stats[0].Controller)
+	require.Equal(t, 1, stats[0].Reconciles)
+
+	last := stats[0].LastReconcile
+	require.Equal(t, 8, last.Total)
+	require.Equal(t, 4, last.APIRequests)
+	require.Equal(t, 4, last.CacheReads)
+	require.Equal(t, 2, last.APIReads)
+	require.InDelta(t, 4.0/6.0, last.CacheHitRatio, 0.001)
+	require.Equal(t, RequestCount{Request: Request{Verb: "get", Resource: "Node", Source: SourceCache}, Count: 3}, last.Requests[0])
+	require.Contains(t, last.Requests, RequestCount{Request: Request{Verb: "list", Resource: "Node", Source: SourceCache}, Count: 1})
+	require.Contains(t, last.Requests, RequestCount{Request: Request{Verb: "get", Resource: "Node", Source: SourceAPI}, Count: 2})
+	require.Contains(t, last.Requests, RequestCount{Request: Request{Verb: "create", Resource: "DaemonSet", Source: SourceAPI}, Count: 1})
+	require.Contains(t, last.Requests, RequestCount{Request: Request{Verb: "update", Resource: "Node/status", Source: SourceAPI}, Count: 1})
+
+	require.Equal(t, 3.0, testutil.ToFloat64(tracker.requests.WithLabelValues("ClusterPolicy", "get", "Node", SourceCache)))
+	require.Equal(t, 1.0, testutil.ToFloat64(tracker.requests.WithLabelValues(noController, "list", "Node", SourceCache)))
+}
+
+func TestTrackerMaxAPIRequests(t *testing.T) {
+	c, _, tracker := newTestClient(t)
+
+	for _, creates := range []int{3, 1} {
+		ctx, done := tracker.StartReconcile(context.Background(), "NVIDIADriver")
+		for i := 0; i < creates; i++ {
+			require.NoError(t, c.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{GenerateName: "cm-", Namespace: "test"}}))
+		}
+		done()
+	}
+
+	stats := tracker.Controllers()
+	require.Len(t, stats, 1)
+	require.Equal(t, 2, stats[0].Reconciles)
+	require.Equal(t, 3, stats[0].MaxAPIRequests)
+	require.Equal(t, 1, stats[0].LastReconcile.APIRequests)
+	require.Equal(t, 1.0, stats[0].CacheHitRatio)
+}
+
+func TestNilTracker(t *testing.T) {
+	var tracker *Tracker
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	require.Same(t, c, NewClient(c, tracker))
+
+	ctx, done := tracker.StartReconcile(context.Background(), "ClusterPolicy")
+	require.NoError(t, c.List(ctx, &corev1.NodeList{}))
+	done()
+	require.Empty(t, tracker.Controllers())
+}
+
+func TestHandler(t *testing.T) {
+	c, _, tracker := newTestClient(t)
+	for _, controller := range []string{"Upgrade", "ClusterPolicy"} {
+		ctx, done := tracker.StartReconcile(context.Background(), controller)
+		require.NoError(t, c.List(ctx, &corev1.NodeList{}))
+		done()
+	}
+
+	get := func(url string) []ControllerStats {
+		rec := httptest.NewRecorder()
+		tracker.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		body := struct {
+			Controllers []ControllerStats `json:"controllers"`
+		}{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		return body.Controllers
+	}
+
+	stats := get(DebugPath)
+	require.Len(t, stats, 2)
+	require.Equal(t, "ClusterPolicy", stats[0].Controller)
+	require.Equal(t, "Upgrade", stats[1].Controller)
+
+	stats = get(DebugPath + "?controller=Upgrade")
+	require.Len(t, stats, 1)
+	require.Equal(t, "Upgrade", stats[0].Controller)
+	require.Equal(t, "list", stats[0].LastReconcile.Requests[0].Verb)
+
+	rec := httptest.NewRecorder()
+	tracker.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, DebugPath, nil))
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -0,0 +1,188 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package apistats
+
+import (
+	"context"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const (
+	verbGet         = "get"
+	verbList        = "list"
+	verbCreate      = "create"
+	verbUpdate      = "update"
+	verbPatch       = "patch"
+	verbApply       = "apply"
+	verbDelete      = "delete"
+	verbDeleteAllOf = "deletecollection"
+)
+
+// trackingClient is a client counting the requests made through it
+type trackingClient struct {
+	client.Client
+	tracker *Tracker
+}
+
+// NewClient returns a client counting the requests made through the given client of the manager. The reads of
+// structured objects are served by the informer cache of the manager, the reads of unstructured objects and the
+// writes are sent to the API server.
+func NewClient(c client.Client, tracker *Tracker) client.Client {
+	if tracker == nil {
+		return c
+	}
+	return &trackingClient{Client: c, tracker: tracker}
+}
+
+func (c *trackingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	c.tracker.count(ctx, Request{Verb: verbGet, Resource: resourceOf(obj, c.Scheme()), Source: readSource(obj)})
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func (c *trackingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	c.tracker.count(ctx, Request{Verb: verbList, Resource: resourceOf(list, c.Scheme()), Source: readSource(list)})
+	return c.Client.List(ctx, list, opts...)
+}
+
+func (c *trackingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	c.tracker.count(ctx, Request{Verb: verbCreate, Resource: resourceOf(obj, c.Scheme()), Source: SourceAPI})
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *trackingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	c.tracker.count(ctx, Request{Verb: verbUpdate, Resource: resourceOf(obj, c.Scheme()), Source: SourceAPI})
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *trackingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	c.tracker.count(ctx, Request{Verb: verbPatch, Resource: resourceOf(obj, c.Scheme()), Source: SourceAPI})
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *trackingClient) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+	c.tracker.count(ctx, Request{Verb: verbApply, Resource: "unknown", Source: SourceAPI})
+	return c.Client.Apply(ctx, obj, opts...)
+}
+
+func (c *trackingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	c.tracker.count(ctx, Request{Verb: verbDelete, Resource: resourceOf(obj, c.Scheme()), Source: SourceAPI})
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *trackingClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	c.tracker.count(ctx, Request{Verb: verbDeleteAllOf, Resource: resourceOf(obj, c.Scheme()), Source: SourceAPI})
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (c *trackingClient) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+func (c *trackingClient) SubResource(subResource string) client.SubResourceClient {
+	return &trackingSubResourceClient{
+		SubResourceClient: c.Client.SubResource(subResource),
+		client:            c,
+		subResource:       subResource,
+	}
+}
+
+// trackingSubResourceClient is a client of a subresource counting the requests made through it
+type trackingSubResourceClient struct {
+	client.SubResourceClient
+	client      *trackingClient
+	subResource string
+}
+
+func (c *trackingSubResourceClient) count(ctx context.Context, verb string, obj client.Object) {
+	c.client.tracker.count(ctx, Request{
+		Verb:     verb,
+		Resource: resourceOf(obj, c.client.Scheme()) + "/" + c.subResource,
+		Source:   SourceAPI,
+	})
+}
+
+func (c *trackingSubResourceClient) Get(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceGetOption) error {
+	c.count(ctx, verbGet, obj)
+	return c.SubResourceClient.Get(ctx, obj, subResource, opts...)
+}
+
+func (c *trackingSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	c.count(ctx, verbCreate, obj)
+	return c.SubResourceClient.Create(ctx, obj, subResource, opts...)
+}
+
+func (c *trackingSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	c.count(ctx, verbUpdate, obj)
+	return c.SubResourceClient.Update(ctx, obj, opts...)
+}
+
+func (c *trackingSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	c.count(ctx, verbPatch, obj)
+	return c.SubResourceClient.Patch(ctx, obj, patch, opts...)
+}
+
+// trackingReader is a reader counting the reads made through it, which are all sent to the API server
+type trackingReader struct {
+	client.Reader
+	scheme  *runtime.Scheme
+	tracker *Tracker
+}
+
+// NewReader returns a reader counting the reads made through the given reader of the manager bypassing the cache
+func NewReader(r client.Reader, scheme *runtime.Scheme, tracker *Tracker) client.Reader {
+	if tracker == nil {
+		return r
+	}
+	return &trackingReader{Reader: r, scheme: scheme, tracker: tracker}
+}
+
+func (r *trackingReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	r.tracker.count(ctx, Request{Verb: verbGet, Resource: resourceOf(obj, r.scheme), Source: SourceAPI})
+	return r.Reader.Get(ctx, key, obj, opts...)
+}
+
+func (r *trackingReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	r.tracker.count(ctx, Request{Verb: verbList, Resource: resourceOf(list, r.scheme), Source: SourceAPI})
+	return r.Reader.List(ctx, list, opts...)
+}
+
+// readSource returns the source of the reads of the object by the client of the manager, which does not cache
+// the unstructured objects
+func readSource(obj runtime.Object) string {
+	switch obj.(type) {
+	case *unstructured.Unstructured, *unstructured.UnstructuredList:
+		return SourceAPI
+	}
+	return SourceCache
+}
+
+// resourceOf returns the kind of the object, or of the items of a list
+func resourceOf(obj runtime.Object, scheme *runtime.Scheme) string {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if gvk, err := apiutil.GVKForObject(obj, scheme); err == nil {
+		kind = gvk.Kind
+	}
+	if kind == "" {
+		return "unknown"
+	}
+	return strings.TrimSuffix(kind, "List")
+}
@@ -0,0 +1,183 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package assets
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	// DefaultDir is the directory of the operand asset manifests baked into the operator image
+	DefaultDir = "/opt/gpu-operator"
+	// SignatureAnnotationKey annotates the ConfigMap or the OCI artifact manifest holding a bundle of asset
+	// manifests with the base64 encoded signature of the bundle digest
+	SignatureAnnotationKey = "nvidia.com/gpu-operator.assets-signature"
+	// maxBundleSize bounds the total size of the files of a bundle
+	maxBundleSize = 64 << 20
+)
+
+// Bundle holds asset manifests overriding or extending the baked-in ones, keyed by their path relative to the
+// asset directory, e.g. "state-driver/0500_daemonset.yaml" or "manifests/state-driver/0500_daemonset.yaml"
+type Bundle struct {
+	Files     map[string][]byte
+	Signature string
+}
+
+// Source provides a bundle of asset manifests
+type Source interface {
+	Fetch(ctx context.Context) (*Bundle, error)
+	String() string
+}
+
+// validatePath rejects the bundle paths which are absolute, escape the asset directory or are not part of an
+// asset directory
+func validatePath(p string) error {
+	if p == "" || path.IsAbs(p) || path.Clean(p) != p || strings.HasPrefix(p, "../") || p == ".." {
+		return fmt.Errorf("invalid asset path %q", p)
+	}
+	if !strings.Contains(p, "/") {
+		return fmt.Errorf("asset %q is not part of an asset directory", p)
+	}
+	return nil
+}
+
+// Digest returns the digest of the bundle files, which is signed by the bundle publisher. The digest is the
+// SHA-256 of the sorted lines "<path> <hex SHA-256 of the file>\n".
+func (b *Bundle) Digest() []byte {
+	paths := make([]string, 0, len(b.Files))
+	for p := range b.Files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	hasher := sha256.New()
+	for _, p := range paths {
+		fileDigest := sha256.Sum256(b.Files[p])
+		fmt.Fprintf(hasher, "%s %s\n", p, hex.EncodeToString(fileDigest[:]))
+	}
+	return hasher.Sum(nil)
+}
+
+// Verify returns an error unless the bundle signature is a valid ECDSA or Ed25519 signature of the bundle
+// digest for the PEM encoded public key
+func Verify(bundle *Bundle, publicKeyPEM []byte) error {
+	block, _ := pem.Decode(publicKeyPEM)
+	if block == nil {
+		return fmt.Errorf("no PEM encoded public key found")
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse public key: %w", err)
+	}
+	if bundle.Signature == "" {
+		return fmt.Errorf("asset bundle is not signed")
+	}
+	signature, err := base64.StdEncoding.DecodeString(bundle.Signature)
+	if err != nil {
+		return fmt.Errorf("failed to decode asset bundle signature: %w", err)
+	}
+
+	digest := bundle.Digest()
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest, signature) {
+			return fmt.Errorf("invalid asset bundle signature")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, digest, signature) {
+			return fmt.Errorf("invalid asset bundle signature")
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+	return nil
+}
+
+// Materialize copies the asset manifests of baseDir to dir, then writes the bundle files over them, so that
+// the asset loaders read the baked-in assets overridden and extended by the bundle from dir
+func Materialize(baseDir string, dir string, bundle *Bundle) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to clean up asset directory %s: %w", dir, err)
+	}
+	err := filepath.Walk(baseDir, func(src string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(baseDir, src)
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(dir, rel)
+		if info.IsDir() {
+			return os.MkdirAll(dst, 0755)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		data, err := os.ReadFile(src)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(dst, data, 0644)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to copy the assets of %s: %w", baseDir, err)
+	}
+
+	for p, data := range bundle.Files {
+		if err := validatePath(p); err != nil {
+			return err
+		}
+		dst := filepath.Join(dir, filepath.FromSlash(p))
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return fmt.Errorf("failed to create asset directory of %s: %w", p, err)
+		}
+		if err := os.WriteFile(dst, data, 0644); err != nil {
+			return fmt.Errorf("failed to write asset %s: %w", p, err)
+		}
+	}
+	return nil
+}
+
+// Load fetches the bundle of the source, verifies its signature and materializes the baked-in assets of
+// baseDir overridden by the bundle in dir
+func Load(ctx context.Context, source Source, publicKeyPEM []byte, baseDir string, dir string) (*Bundle, error) {
+	bundle, err := source.Fetch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the assets of %s: %w", source, err)
+	}
+	if err := Verify(bundle, publicKeyPEM); err != nil {
+		return nil, fmt.Errorf("failed to verify the assets of %s: %w", source, err)
+	}
+	if err := Materialize(baseDir, dir, bundle); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
@@ -0,0 +1,170 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package assets
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func encodePublicKey(t *testing.T, key any) []byte {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func newBundle() *Bundle {
+	return &Bundle{Files: map[string][]byte{
+		"state-driver/0500_daemonset.yaml":           []byte("kind: DaemonSet\n"),
+		"manifests/state-driver/0500_daemonset.yaml": []byte("kind: DaemonSet\n"),
+	}}
+}
+
+func TestVerify(t *testing.T) {
+	edPublicKey, edPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ecPrivateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	bundle := newBundle()
+	bundle.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(edPrivateKey, bundle.Digest()))
+	require.NoError(t, Verify(bundle, encodePublicKey(t, edPublicKey)))
+	require.Error(t, Verify(bundle, encodePublicKey(t, &ecPrivateKey.PublicKey)))
+
+	ecSignature, err := ecdsa.SignASN1(rand.Reader, ecPrivateKey, bundle.Digest())
+	require.NoError(t, err)
+	bundle.Signature = base64.StdEncoding.EncodeToString(ecSignature)
+	require.NoError(t, Verify(bundle, encodePublicKey(t, &ecPrivateKey.PublicKey)))
+
+	// a modified file invalidates the signature
+	bundle.Files["state-driver/0500_daemonset.yaml"] = []byte("kind: Pod\n")
+	require.Error(t, Verify(bundle, encodePublicKey(t, &ecPrivateKey.PublicKey)))
+
+	// an added file invalidates the signature
+	bundle = newBundle()
+	bundle.Signature = base64.StdEncoding.EncodeToString(ecSignature)
+	bundle.Files["state-driver/0600_configmap.yaml"] = []byte("kind: ConfigMap\n")
+	require.Error(t, Verify(bundle, encodePublicKey(t, &ecPrivateKey.PublicKey)))
+
+	bundle.Signature = ""
+	require.Error(t, Verify(bundle, encodePublicKey(t, &ecPrivateKey.PublicKey)))
+	require.Error(t, Verify(newBundle(), []byte("not a key")))
+}
+
+func TestMaterialize(t *testing.T) {
+	baseDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(baseDir, "state-driver"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(baseDir, "state-driver", "0500_daemonset.yaml"), []byte("baked-in"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(baseDir, "state-driver", "0400_configmap.yaml"), []byte("baked-in"), 0644))
+
+	dir := filepath.Join(t.TempDir(), "assets")
+	bundle := &Bundle{Files: map[string][]byte{
+		"state-driver/0500_daemonset.yaml":   []byte("hotfix"),
+		"state-custom/0100_daemonset.yaml":   []byte("custom"),
+		"manifests/state-driver/0500_ds.yml": []byte("hotfix"),
+	}}
+	require.NoError(t, Materialize(baseDir, dir, bundle))
+
+	for file, expected := range map[string]string{
+		"state-driver/0500_daemonset.yaml":   "hotfix",
+		"state-driver/0400_configmap.yaml":   "baked-in",
+		"state-custom/0100_daemonset.yaml":   "custom",
+		"manifests/state-driver/0500_ds.yml": "hotfix",
+	} {
+		data, err := os.ReadFile(filepath.Join(dir, file))
+		require.NoError(t, err)
+		require.Equal(t, expected, string(data), file)
+	}
+
+	for _, p := range []string{"../escape/file.yaml", "/etc/file.yaml", "state-driver/../../file.yaml", "file.yaml"} {
+		require.Error(t, Materialize(baseDir, dir, &Bundle{Files: map[string][]byte{p: nil}}), p)
+	}
+}
+
+func TestConfigMapSource(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "gpu-operator-assets",
+			Namespace:   "gpu-operator",
+			Annotations: map[string]string{SignatureAnnotationKey: "c2lnbmF0dXJl"},
+		},
+		Data: map[string]string{
+			"state-driver__0500_daemonset.yaml":            "kind: DaemonSet\n",
+			"manifests__state-driver__0500_daemonset.yaml": "kind: DaemonSet\n",
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cm).Build()
+
+	source, err := ParseSource("configmap://gpu-operator-assets", c, "gpu-operator", "")
+	require.NoError(t, err)
+	bundle, err := source.Fetch(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "c2lnbmF0dXJl", bundle.Signature)
+	require.Equal(t, newBundle().Files, bundle.Files)
+
+	_, err = ParseSource("https://example.com/assets", c, "gpu-operator", "")
+	require.Error(t, err)
+	_, err = ParseSource("configmap://", c, "gpu-operator", "")
+	require.Error(t, err)
+}
+
+func TestExtractArchive(t *testing.T) {
+	newArchive := func(files map[string]string) []byte {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gz)
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "./state-driver/", Typeflag: tar.TypeDir, Mode: 0755}))
+		for name, content := range files {
+			require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content))}))
+			_, err := tw.Write([]byte(content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, tw.Close())
+		require.NoError(t, gz.Close())
+		return buf.Bytes()
+	}
+
+	files, err := extractArchive(bytes.NewReader(newArchive(map[string]string{
+		"./state-driver/0500_daemonset.yaml":         "kind: DaemonSet\n",
+		"manifests/state-driver/0500_daemonset.yaml": "kind: DaemonSet\n",
+	})))
+	require.NoError(t, err)
+	require.Equal(t, newBundle().Files, files)
+
+	_, err = extractArchive(bytes.NewReader(newArchive(map[string]string{"../state-driver/0500_daemonset.yaml": ""})))
+	require.Error(t, err)
+}
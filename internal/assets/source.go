@@ -0,0 +1,224 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package assets
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/regclient/regclient/types/manifest"
+	"github.com/regclient/regclient/types/ref"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/NVIDIA/gpu-operator/internal/image"
+)
+
+const (
+	ociSourcePrefix       = "oci://"
+	configMapSourcePrefix = "configmap://"
+	// configMapPathSeparator replaces the path separator in the keys of a ConfigMap bundle, as ConfigMap keys
+	// cannot contain "/", e.g. "state-driver__0500_daemonset.yaml"
+	configMapPathSeparator = "__"
+)
+
+// ParseSource returns the source of the given reference, which is either "oci://<artifact reference>" or
+// "configmap://<name of a ConfigMap of the namespace>". The OCI artifact is pulled with the credentials of
+// the optional docker config json pull secret of the namespace.
+func ParseSource(source string, c client.Reader, namespace string, pullSecret string) (Source, error) {
+	switch {
+	case strings.HasPrefix(source, ociSourcePrefix):
+		artifact := strings.TrimPrefix(source, ociSourcePrefix)
+		if _, err := ref.New(artifact); err != nil {
+			return nil, fmt.Errorf("invalid OCI artifact reference %q: %w", artifact, err)
+		}
+		return &ociSource{client: c, namespace: namespace, artifact: artifact, pullSecret: pullSecret}, nil
+	case strings.HasPrefix(source, configMapSourcePrefix):
+		name := strings.TrimPrefix(source, configMapSourcePrefix)
+		if name == "" {
+			return nil, fmt.Errorf("missing ConfigMap name in asset source %q", source)
+		}
+		return &configMapSource{client: c, namespace: namespace, name: name}, nil
+	}
+	return nil, fmt.Errorf("unsupported asset source %q, expected %s<artifact> or %s<name>", source, ociSourcePrefix, configMapSourcePrefix)
+}
+
+// configMapSource provides the asset manifests held by a ConfigMap, whose signature is set in its annotations
+type configMapSource struct {
+	client    client.Reader
+	namespace string
+	name      string
+}
+
+func (s *configMapSource) String() string {
+	return configMapSourcePrefix + s.name
+}
+
+func (s *configMapSource) Fetch(ctx context.Context) (*Bundle, error) {
+	cm := &corev1.ConfigMap{}
+	if err := s.client.Get(ctx, types.NamespacedName{Namespace: s.namespace, Name: s.name}, cm); err != nil {
+		return nil, fmt.Errorf("failed to get ConfigMap %s: %w", s.name, err)
+	}
+	return bundleFromConfigMap(cm)
+}
+
+func bundleFromConfigMap(cm *corev1.ConfigMap) (*Bundle, error) {
+	bundle := &Bundle{
+		Files:     make(map[string][]byte),
+		Signature: cm.Annotations[SignatureAnnotationKey],
+	}
+	add := func(key string, data []byte) error {
+		p := strings.ReplaceAll(key, configMapPathSeparator, "/")
+		if err := validatePath(p); err != nil {
+			return err
+		}
+		bundle.Files[p] = data
+		return nil
+	}
+	for key, data := range cm.Data {
+		if err := add(key, []byte(data)); err != nil {
+			return nil, err
+		}
+	}
+	for key, data := range cm.BinaryData {
+		if err := add(key, data); err != nil {
+			return nil, err
+		}
+	}
+	return bundle, nil
+}
+
+// ociSource provides the asset manifests packaged as the single layer of an OCI artifact, a tar archive which
+// may be gzip compressed, whose signature is set in the annotations of the artifact manifest
+type ociSource struct {
+	client     client.Reader
+	namespace  string
+	artifact   string
+	pullSecret string
+}
+
+func (s *ociSource) String() string {
+	return ociSourcePrefix + s.artifact
+}
+
+func (s *ociSource) Fetch(ctx context.Context) (*Bundle, error) {
+	var creds []image.Credential
+	if s.pullSecret != "" {
+		secret := &corev1.Secret{}
+		if err := s.client.Get(ctx, types.NamespacedName{Namespace: s.namespace, Name: s.pullSecret}, secret); err != nil {
+			return nil, fmt.Errorf("failed to get pull secret %s: %w", s.pullSecret, err)
+		}
+		secretCreds, err := image.CredentialsFromDockerConfigJSON(secret.Data[corev1.DockerConfigJsonKey])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse pull secret %s: %w", s.pullSecret, err)
+		}
+		creds = secretCreds
+	}
+
+	artifactRef, err := ref.New(s.artifact)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OCI artifact reference %q: %w", s.artifact, err)
+	}
+	rc := image.SharedRegistryClient()
+	m, err := rc.ManifestGet(ctx, artifactRef, creds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the manifest of %s: %w", s.artifact, err)
+	}
+	imager, ok := m.(manifest.Imager)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an OCI artifact manifest", s.artifact)
+	}
+	layers, err := imager.GetLayers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the layers of %s: %w", s.artifact, err)
+	}
+	if len(layers) != 1 {
+		return nil, fmt.Errorf("expected a single layer in %s, found %d", s.artifact, len(layers))
+	}
+	var signature string
+	if annotator, ok := m.(manifest.Annotator); ok {
+		annotations, err := annotator.GetAnnotations()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the annotations of %s: %w", s.artifact, err)
+		}
+		signature = annotations[SignatureAnnotationKey]
+	}
+
+	layer, err := rc.BlobGet(ctx, artifactRef, layers[0], creds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the layer of %s: %w", s.artifact, err)
+	}
+	defer layer.Close()
+	files, err := extractArchive(layer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract the layer of %s: %w", s.artifact, err)
+	}
+	return &Bundle{Files: files, Signature: signature}, nil
+}
+
+// extractArchive returns the regular files of the tar archive, which may be gzip compressed, keyed by their path
+func extractArchive(r io.Reader) (map[string][]byte, error) {
+	br := bufio.NewReader(r)
+	var archive io.Reader = br
+	if magic, err := br.Peek(2); err == nil && bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		archive = gz
+	}
+
+	files := make(map[string][]byte)
+	var size int64
+	tr := tar.NewReader(archive)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		p := path.Clean(strings.TrimPrefix(header.Name, "./"))
+		if err := validatePath(p); err != nil {
+			return nil, err
+		}
+		size += header.Size
+		if size > maxBundleSize {
+			return nil, fmt.Errorf("asset bundle exceeds %d bytes", maxBundleSize)
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, header.Size))
+		if err != nil {
+			return nil, err
+		}
+		files[p] = data
+	}
+	return files, nil
+}
@@ -0,0 +1,252 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package audit records the objects created, updated and deleted by the operator, so that the changes
+// made to the cluster, e.g. the update of a driver DaemonSet restarting the driver pods, can be traced back
+// to the reconciliation which made them.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// OperationCreate is the operation of an entry recording the creation of an object
+	OperationCreate = "create"
+	// OperationUpdate is the operation of an entry recording the update of an object
+	OperationUpdate = "update"
+	// OperationPatch is the operation of an entry recording a patch of an object
+	OperationPatch = "patch"
+	// OperationDelete is the operation of an entry recording the deletion of an object
+	OperationDelete = "delete"
+
+	// ConfigMapName is the name of the ConfigMap holding the latest entries, when the ring buffer is enabled
+	ConfigMapName = "gpu-operator-audit-log"
+	// ConfigMapKey is the key of the ConfigMap data holding the entries, one JSON entry per line
+	ConfigMapKey = "audit.log"
+
+	// maxDiffPaths is the number of changed fields listed in the diff summary of an entry
+	maxDiffPaths = 10
+	// maxDiffDepth is the depth of the fields listed in the diff summary, the changes of deeper fields are
+	// reported on their ancestor at this depth
+	maxDiffDepth = 5
+)
+
+// Entry is the record of an object mutation performed by the operator
+type Entry struct {
+	Time      time.Time `json:"time"`
+	Operation string    `json:"operation"`
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace,omitempty"`
+	Name      string    `json:"name"`
+	// Reason is the reason of the mutation given by the controller, if any
+	Reason string `json:"reason,omitempty"`
+	// Diff lists the fields changed by an update or a patch
+	Diff []string `json:"diff,omitempty"`
+}
+
+type reasonKey struct{}
+
+// WithReason returns a context recording the mutations made with it with the given reason. The reason is
+// appended to the reason of the parent context, if any.
+func WithReason(ctx context.Context, reason string) context.Context {
+	if parent := ReasonFrom(ctx); parent != "" {
+		reason = parent + ": " + reason
+	}
+	return context.WithValue(ctx, reasonKey{}, reason)
+}
+
+// ReasonFrom returns the reason recorded in the context, or an empty string
+func ReasonFrom(ctx context.Context) string {
+	reason, _ := ctx.Value(reasonKey{}).(string)
+	return reason
+}
+
+// Recorder writes the entries to a structured log stream and, when enabled, to a ring buffer kept in a
+// ConfigMap of the operator namespace
+type Recorder struct {
+	log logr.Logger
+
+	// ringBufferSize is the number of entries kept in the ConfigMap, the ring buffer is disabled when zero
+	ringBufferSize int
+	// c writes the ConfigMap, it must not record its own mutations
+	c         client.Client
+	namespace string
+
+	mu      sync.Mutex
+	entries []Entry
+	loaded  bool
+}
+
+// NewRecorder returns a Recorder logging the entries. When ringBufferSize is positive, the latest entries
+// are also kept in a ConfigMap of the given namespace written with the given client.
+func NewRecorder(log logr.Logger, c client.Client, namespace string, ringBufferSize int) *Recorder {
+	return &Recorder{
+		log:            log,
+		c:              c,
+		namespace:      namespace,
+		ringBufferSize: ringBufferSize,
+	}
+}
+
+// Record records an entry. Failures to write the ring buffer are logged and do not fail the mutation.
+func (r *Recorder) Record(ctx context.Context, entry Entry) {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+	r.log.Info("object mutated", "operation", entry.Operation, "kind", entry.Kind, "namespace", entry.Namespace,
+		"name", entry.Name, "reason", entry.Reason, "diff", entry.Diff)
+
+	if r.ringBufferSize <= 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.writeRingBuffer(ctx, entry); err != nil {
+		r.log.Error(err, "failed to write the audit ring buffer", "ConfigMap", ConfigMapName)
+	}
+}
+
+// writeRingBuffer appends the entry to the ring buffer and writes it to the ConfigMap. The entries already
+// in the ConfigMap are loaded first, so that the ring buffer is kept across restarts of the operator.
+func (r *Recorder) writeRingBuffer(ctx context.Context, entry Entry) error {
+	cm := &corev1.ConfigMap{}
+	err := r.c.Get(ctx, types.NamespacedName{Namespace: r.namespace, Name: ConfigMapName}, cm)
+	exists := err == nil
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if !r.loaded {
+		if exists {
+			r.entries = parseEntries(cm.Data[ConfigMapKey])
+		}
+		r.loaded = true
+	}
+
+	r.entries = append(r.entries, entry)
+	if len(r.entries) > r.ringBufferSize {
+		r.entries = r.entries[len(r.entries)-r.ringBufferSize:]
+	}
+	data, err := formatEntries(r.entries)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: ConfigMapName, Namespace: r.namespace},
+			Data:       map[string]string{ConfigMapKey: data},
+		}
+		return r.c.Create(ctx, cm)
+	}
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	cm.Data[ConfigMapKey] = data
+	return r.c.Update(ctx, cm)
+}
+
+// parseEntries returns the entries of the ring buffer, skipping the lines which cannot be parsed
+func parseEntries(data string) []Entry {
+	var entries []Entry
+	for _, line := range strings.Split(data, "\n") {
+		if line == "" {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal([]byte(line), &entry); err == nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func formatEntries(entries []Entry) (string, error) {
+	var sb strings.Builder
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return "", err
+		}
+		sb.Write(line)
+		sb.WriteByte('\n')
+	}
+	return sb.String(), nil
+}
+
+// ignoredFields are the fields set by the API server, whose changes are not reported in the diff summary
+var ignoredFields = map[string]bool{
+	"status":                     true,
+	"metadata.resourceVersion":   true,
+	"metadata.generation":        true,
+	"metadata.managedFields":     true,
+	"metadata.uid":               true,
+	"metadata.creationTimestamp": true,
+}
+
+// Diff returns the sorted paths of the fields which differ between the unstructured content of two
+// versions of an object. At most maxDiffPaths paths are listed, followed by the number of omitted paths.
+func Diff(old, new map[string]interface{}) []string {
+	var paths []string
+	diff("", old, new, 0, &paths)
+	sort.Strings(paths)
+	if len(paths) > maxDiffPaths {
+		paths = append(paths[:maxDiffPaths], fmt.Sprintf("+%d more", len(paths)-maxDiffPaths))
+	}
+	return paths
+}
+
+func diff(path string, old, new interface{}, depth int, paths *[]string) {
+	if ignoredFields[path] {
+		return
+	}
+	oldMap, oldIsMap := old.(map[string]interface{})
+	newMap, newIsMap := new.(map[string]interface{})
+	if !oldIsMap || !newIsMap || depth >= maxDiffDepth {
+		if !reflect.DeepEqual(old, new) {
+			*paths = append(*paths, path)
+		}
+		return
+	}
+	keys := make(map[string]bool)
+	for key := range oldMap {
+		keys[key] = true
+	}
+	for key := range newMap {
+		keys[key] = true
+	}
+	for key := range keys {
+		child := key
+		if path != "" {
+			child = path + "." + key
+		}
+		diff(child, oldMap[key], newMap[key], depth+1, paths)
+	}
+}
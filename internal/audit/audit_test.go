@@ -0,0 +1,135 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestWithReason(t *testing.T) {
+	ctx := context.Background()
+	require.Empty(t, ReasonFrom(ctx))
+	ctx = WithReason(ctx, "ClusterPolicy cluster-policy")
+	ctx = WithReason(ctx, "state-driver")
+	require.Equal(t, "ClusterPolicy cluster-policy: state-driver", ReasonFrom(ctx))
+}
+
+func TestDiff(t *testing.T) {
+	old := map[string]interface{}{
+		"metadata": map[string]interface{}{"resourceVersion": "1", "labels": map[string]interface{}{"app": "driver"}},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{map[string]interface{}{"image": "driver:570"}},
+					"nodeSelector": map[string]interface{}{
+						"nvidia.com/gpu.deploy.driver": "true",
+					},
+				},
+			},
+		},
+		"status": map[string]interface{}{"numberReady": int64(1)},
+	}
+	new := map[string]interface{}{
+		"metadata": map[string]interface{}{"resourceVersion": "2", "labels": map[string]interface{}{"app": "driver"}},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{map[string]interface{}{"image": "driver:580"}},
+					"nodeSelector": map[string]interface{}{
+						"nvidia.com/gpu.deploy.driver": "true",
+						"nvidia.com/gpu.present":       "true",
+					},
+				},
+			},
+		},
+		"status": map[string]interface{}{"numberReady": int64(0)},
+	}
+	require.Equal(t, []string{
+		"spec.template.spec.containers",
+		"spec.template.spec.nodeSelector.nvidia.com/gpu.present",
+	}, Diff(old, new))
+	require.Empty(t, Diff(old, old))
+}
+
+func TestClient(t *testing.T) {
+	ctx := WithReason(context.Background(), "state-driver")
+	base := fake.NewClientBuilder().Build()
+	recorder := NewRecorder(logr.Discard(), base, "gpu-operator", 3)
+	c := NewClient(base, recorder)
+
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "nvidia-driver-daemonset", Namespace: "gpu-operator"},
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "nvidia-driver-ctr", Image: "driver:570"}}},
+			},
+		},
+	}
+	require.NoError(t, c.Create(ctx, ds))
+
+	// an update leaving the object unchanged is not recorded
+	require.NoError(t, c.Update(ctx, ds))
+
+	ds.Spec.Template.Spec.Containers[0].Image = "driver:580"
+	require.NoError(t, c.Update(WithReason(ctx, "DaemonSet spec hash changed"), ds))
+
+	// dry-run mutations are not recorded
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "gpu-operator"}}
+	require.NoError(t, c.Create(ctx, pod, client.DryRunAll))
+
+	require.NoError(t, c.Delete(ctx, ds))
+
+	require.Len(t, recorder.entries, 3)
+	require.Equal(t, Entry{
+		Time: recorder.entries[0].Time, Operation: OperationCreate, Kind: "DaemonSet",
+		Namespace: "gpu-operator", Name: "nvidia-driver-daemonset", Reason: "state-driver",
+	}, recorder.entries[0])
+	require.Equal(t, Entry{
+		Time: recorder.entries[1].Time, Operation: OperationUpdate, Kind: "DaemonSet",
+		Namespace: "gpu-operator", Name: "nvidia-driver-daemonset", Reason: "state-driver: DaemonSet spec hash changed",
+		Diff: []string{"spec.template.spec.containers"},
+	}, recorder.entries[1])
+	require.Equal(t, OperationDelete, recorder.entries[2].Operation)
+
+	// the ring buffer keeps the latest entries
+	require.NoError(t, c.Create(ctx, pod))
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, base.Get(ctx, types.NamespacedName{Namespace: "gpu-operator", Name: ConfigMapName}, cm))
+	entries := parseEntries(cm.Data[ConfigMapKey])
+	require.Len(t, entries, 3)
+	require.Equal(t, OperationUpdate, entries[0].Operation)
+	require.Equal(t, "Pod", entries[2].Kind)
+
+	// the entries of the ConfigMap are kept when the operator restarts
+	restarted := NewRecorder(logr.Discard(), base, "gpu-operator", 3)
+	restarted.Record(ctx, Entry{Operation: OperationDelete, Kind: "Pod", Namespace: "gpu-operator", Name: "pod"})
+	require.NoError(t, base.Get(ctx, types.NamespacedName{Namespace: "gpu-operator", Name: ConfigMapName}, cm))
+	entries = parseEntries(cm.Data[ConfigMapKey])
+	require.Len(t, entries, 3)
+	require.Equal(t, OperationDelete, entries[0].Operation)
+	require.Equal(t, OperationDelete, entries[2].Operation)
+}
@@ -0,0 +1,137 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package audit
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// auditClient is a client recording the objects it creates, updates, patches and deletes
+type auditClient struct {
+	client.Client
+	recorder *Recorder
+}
+
+// NewClient returns a client recording the mutations made through it with the given Recorder. The status
+// updates are not recorded.
+func NewClient(c client.Client, recorder *Recorder) client.Client {
+	return &auditClient{Client: c, recorder: recorder}
+}
+
+func (c *auditClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.Client.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+	createOpts := &client.CreateOptions{}
+	if len(createOpts.ApplyOptions(opts).DryRun) == 0 {
+		c.record(ctx, OperationCreate, obj, nil)
+	}
+	return nil
+}
+
+func (c *auditClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	old := c.getCurrent(ctx, obj)
+	if err := c.Client.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+	updateOpts := &client.UpdateOptions{}
+	if len(updateOpts.ApplyOptions(opts).DryRun) == 0 {
+		c.recordChange(ctx, OperationUpdate, old, obj)
+	}
+	return nil
+}
+
+func (c *auditClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	old := c.getCurrent(ctx, obj)
+	if err := c.Client.Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+	patchOpts := &client.PatchOptions{}
+	if len(patchOpts.ApplyOptions(opts).DryRun) == 0 {
+		c.recordChange(ctx, OperationPatch, old, obj)
+	}
+	return nil
+}
+
+func (c *auditClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.Client.Delete(ctx, obj, opts...); err != nil {
+		return err
+	}
+	deleteOpts := &client.DeleteOptions{}
+	if len(deleteOpts.ApplyOptions(opts).DryRun) == 0 {
+		c.record(ctx, OperationDelete, obj, nil)
+	}
+	return nil
+}
+
+// getCurrent returns the current version of the object, or nil when it cannot be read
+func (c *auditClient) getCurrent(ctx context.Context, obj client.Object) client.Object {
+	current, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return nil
+	}
+	if err := c.Client.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+		return nil
+	}
+	return current
+}
+
+// recordChange records an update or a patch, unless it left the object unchanged
+func (c *auditClient) recordChange(ctx context.Context, operation string, old client.Object, obj client.Object) {
+	if old == nil {
+		c.record(ctx, operation, obj, nil)
+		return
+	}
+	if old.GetResourceVersion() != "" && old.GetResourceVersion() == obj.GetResourceVersion() {
+		// the API server did not change the object
+		return
+	}
+	oldContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(old)
+	if err != nil {
+		c.record(ctx, operation, obj, nil)
+		return
+	}
+	newContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		c.record(ctx, operation, obj, nil)
+		return
+	}
+	paths := Diff(oldContent, newContent)
+	if len(paths) == 0 {
+		return
+	}
+	c.record(ctx, operation, obj, paths)
+}
+
+func (c *auditClient) record(ctx context.Context, operation string, obj client.Object, paths []string) {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+		kind = gvk.Kind
+	}
+	c.recorder.Record(ctx, Entry{
+		Operation: operation,
+		Kind:      kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Reason:    ReasonFrom(ctx),
+		Diff:      paths,
+	})
+}
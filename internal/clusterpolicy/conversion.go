@@ -0,0 +1,75 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package clusterpolicy
+
+import (
+	"context"
+	"fmt"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gpuv2 "github.com/NVIDIA/gpu-operator/api/nvidia/v2"
+)
+
+const (
+	// CRDName is the name of the ClusterPolicy CRD
+	CRDName = "clusterpolicies.nvidia.com"
+	// conversionWebhookPath is the path of the conversion webhook registered by the controller-runtime
+	conversionWebhookPath = "/convert"
+)
+
+// EnableConversionWebhook configures the ClusterPolicy CRD to convert the ClusterPolicies through the conversion
+// webhook of the service, trusted with the CA bundle, and serves the v2 API which requires it. The v2 API is not
+// served by the CRD shipped with the operator, as no conversion webhook is reachable until the operator runs.
+func EnableConversionWebhook(ctx context.Context, c client.Client, service types.NamespacedName, caBundle []byte) error {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := c.Get(ctx, types.NamespacedName{Name: CRDName}, crd); err != nil {
+		return fmt.Errorf("failed to get the %s CRD: %w", CRDName, err)
+	}
+	original := crd.DeepCopy()
+
+	crd.Spec.Conversion = &apiextensionsv1.CustomResourceConversion{
+		Strategy: apiextensionsv1.WebhookConverter,
+		Webhook: &apiextensionsv1.WebhookConversion{
+			ClientConfig: &apiextensionsv1.WebhookClientConfig{
+				Service: &apiextensionsv1.ServiceReference{
+					Namespace: service.Namespace,
+					Name:      service.Name,
+					Path:      ptr.To(conversionWebhookPath),
+				},
+				CABundle: caBundle,
+			},
+			ConversionReviewVersions: []string{"v1"},
+		},
+	}
+	for i := range crd.Spec.Versions {
+		if crd.Spec.Versions[i].Name == gpuv2.SchemeGroupVersion.Version {
+			crd.Spec.Versions[i].Served = true
+		}
+	}
+	if equality.Semantic.DeepEqual(original.Spec, crd.Spec) {
+		return nil
+	}
+	if err := c.Patch(ctx, crd, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to enable the conversion webhook of the %s CRD: %w", CRDName, err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package clusterpolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEnableConversionWebhook(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: CRDName},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1", Served: true, Storage: true},
+				{Name: "v2", Served: false},
+			},
+			Conversion: &apiextensionsv1.CustomResourceConversion{Strategy: apiextensionsv1.NoneConverter},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(crd).Build()
+	service := types.NamespacedName{Namespace: "gpu-operator", Name: "gpu-operator-webhook"}
+
+	require.NoError(t, EnableConversionWebhook(context.Background(), c, service, []byte("ca")))
+
+	updated := &apiextensionsv1.CustomResourceDefinition{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: CRDName}, updated))
+	require.True(t, updated.Spec.Versions[0].Served)
+	require.True(t, updated.Spec.Versions[0].Storage)
+	require.True(t, updated.Spec.Versions[1].Served)
+	require.False(t, updated.Spec.Versions[1].Storage)
+	require.Equal(t, apiextensionsv1.WebhookConverter, updated.Spec.Conversion.Strategy)
+	clientConfig := updated.Spec.Conversion.Webhook.ClientConfig
+	require.Equal(t, "gpu-operator", clientConfig.Service.Namespace)
+	require.Equal(t, "gpu-operator-webhook", clientConfig.Service.Name)
+	require.Equal(t, "/convert", *clientConfig.Service.Path)
+	require.Equal(t, []byte("ca"), clientConfig.CABundle)
+
+	// the CRD is left untouched once configured
+	require.NoError(t, EnableConversionWebhook(context.Background(), c, service, []byte("ca")))
+	unchanged := &apiextensionsv1.CustomResourceDefinition{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: CRDName}, unchanged))
+	require.Equal(t, updated.ResourceVersion, unchanged.ResourceVersion)
+}
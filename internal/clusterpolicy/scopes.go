@@ -0,0 +1,208 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package clusterpolicy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+// ScopedPolicies returns the scoped ClusterPolicies which are not being deleted, oldest first
+func ScopedPolicies(policies []gpuv1.ClusterPolicy) []gpuv1.ClusterPolicy {
+	scoped := []gpuv1.ClusterPolicy{}
+	for _, policy := range policies {
+		if !policy.IsScoped() || !policy.DeletionTimestamp.IsZero() {
+			continue
+		}
+		scoped = append(scoped, policy)
+	}
+	sort.SliceStable(scoped, func(i, j int) bool {
+		if !scoped[i].CreationTimestamp.Equal(&scoped[j].CreationTimestamp) {
+			return scoped[i].CreationTimestamp.Before(&scoped[j].CreationTimestamp)
+		}
+		return scoped[i].Name < scoped[j].Name
+	})
+	return scoped
+}
+
+// SkippedScope is a scoped ClusterPolicy whose node selector is not honored
+type SkippedScope struct {
+	Name   string
+	Reason error
+}
+
+// HonoredScopedPolicies returns the scoped ClusterPolicies whose node selector is honored, oldest first, along
+// with the skipped ones. A scoped ClusterPolicy is skipped when its node selector is invalid, or matches a GPU
+// node matched by an older honored scoped ClusterPolicy, so that a single invalid ClusterPolicy does not block
+// the nodes of the others. An error is only returned when the GPU nodes cannot be listed.
+func HonoredScopedPolicies(ctx context.Context, c client.Reader, policies []gpuv1.ClusterPolicy) ([]gpuv1.ClusterPolicy, []SkippedScope, error) {
+	scoped := ScopedPolicies(policies)
+	if len(scoped) == 0 {
+		return scoped, nil, nil
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := c.List(ctx, nodes, client.MatchingLabels{consts.GPUPresentLabel: "true"}); err != nil {
+		return nil, nil, fmt.Errorf("failed to list GPU nodes: %w", err)
+	}
+
+	honored := []gpuv1.ClusterPolicy{}
+	var skipped []SkippedScope
+	for _, policy := range scoped {
+		if err := policy.ValidateNodeSelector(); err != nil {
+			skipped = append(skipped, SkippedScope{Name: policy.Name, Reason: err})
+			continue
+		}
+		if err := overlappingScope(&policy, honored, nodes.Items); err != nil {
+			skipped = append(skipped, SkippedScope{Name: policy.Name, Reason: err})
+			continue
+		}
+		honored = append(honored, policy)
+	}
+	return honored, skipped, nil
+}
+
+// overlappingScope returns an error when a GPU node is matched by the node selectors of both the scoped
+// ClusterPolicy and one of the given scoped ClusterPolicies
+func overlappingScope(cr *gpuv1.ClusterPolicy, others []gpuv1.ClusterPolicy, nodes []corev1.Node) error {
+	for _, node := range nodes {
+		if !nodeMatchesSelector(node.Labels, cr.Spec.NodeSelector) {
+			continue
+		}
+		for _, other := range others {
+			if other.Name != cr.Name && nodeMatchesSelector(node.Labels, other.Spec.NodeSelector) {
+				return fmt.Errorf("ClusterPolicies %s and %s match the same node %s", cr.Name, other.Name, node.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// nodeMatchesSelector reports whether nodeLabels satisfy the ClusterPolicy nodeSelector.
+func nodeMatchesSelector(nodeLabels map[string]string, selector map[string]string) bool {
+	return labels.SelectorFromSet(selector).Matches(labels.Set(nodeLabels))
+}
+
+// desiredOwnerForNode returns the oldest of the honored scoped ClusterPolicies matching the node, if any
+func desiredOwnerForNode(node *corev1.Node, honored []gpuv1.ClusterPolicy) string {
+	for _, policy := range honored {
+		if nodeMatchesSelector(node.Labels, policy.Spec.NodeSelector) {
+			return policy.Name
+		}
+	}
+	return ""
+}
+
+// AssignOwners labels the GPU nodes matched by the node selector of a scoped ClusterPolicy with the name of
+// the ClusterPolicy, and removes the label from the other nodes, which are managed by the ClusterPolicy without
+// node selector. The scoped ClusterPolicies whose node selector is not honored, see HonoredScopedPolicies, are
+// skipped. It returns true when any node owner label was changed.
+func AssignOwners(ctx context.Context, c client.Client) (bool, error) {
+	policies := &gpuv1.ClusterPolicyList{}
+	if err := c.List(ctx, policies); err != nil {
+		return false, fmt.Errorf("failed to list ClusterPolicy CRs: %w", err)
+	}
+	honored, _, err := HonoredScopedPolicies(ctx, c, policies.Items)
+	if err != nil {
+		return false, err
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := c.List(ctx, nodes, client.MatchingLabels{consts.GPUPresentLabel: "true"}); err != nil {
+		return false, fmt.Errorf("failed to list GPU nodes: %w", err)
+	}
+
+	changed := false
+	for _, nodeItem := range nodes.Items {
+		node := nodeItem.DeepCopy()
+		desiredOwner := desiredOwnerForNode(node, honored)
+
+		currentOwner, hasOwnerLabel := node.Labels[consts.ClusterPolicyOwnerLabel]
+		if (desiredOwner == "" && !hasOwnerLabel) || (desiredOwner != "" && currentOwner == desiredOwner) {
+			continue
+		}
+
+		originalNode := node.DeepCopy()
+		if desiredOwner == "" {
+			delete(node.Labels, consts.ClusterPolicyOwnerLabel)
+		} else {
+			if node.Labels == nil {
+				node.Labels = map[string]string{}
+			}
+			node.Labels[consts.ClusterPolicyOwnerLabel] = desiredOwner
+		}
+
+		if err := c.Patch(ctx, node, client.MergeFrom(originalNode)); err != nil {
+			return false, fmt.Errorf("failed to update ClusterPolicy owner label for node %q: %w", node.Name, err)
+		}
+		changed = true
+	}
+
+	return changed, nil
+}
+
+// ValidateScope returns an error when the ClusterPolicy cannot be honored next to the given other
+// ClusterPolicies: its node selector is invalid, a GPU node is matched by the node selectors of both the
+// ClusterPolicy and another scoped ClusterPolicy, or both the ClusterPolicy and another ClusterPolicy have
+// no node selector
+func ValidateScope(ctx context.Context, c client.Reader, cr *gpuv1.ClusterPolicy, others []gpuv1.ClusterPolicy) error {
+	if err := cr.ValidateNodeSelector(); err != nil {
+		return err
+	}
+
+	var nodes *corev1.NodeList
+	for i := range others {
+		other := &others[i]
+		if other.Name == cr.Name || !other.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if !cr.IsScoped() {
+			if !other.IsScoped() {
+				return fmt.Errorf("ClusterPolicy %s without nodeSelector already exists", other.Name)
+			}
+			continue
+		}
+		if !other.IsScoped() {
+			continue
+		}
+
+		if nodes == nil {
+			selector := labels.Set{consts.GPUPresentLabel: "true"}
+			for key, value := range cr.Spec.NodeSelector {
+				selector[key] = value
+			}
+			nodes = &corev1.NodeList{}
+			if err := c.List(ctx, nodes, client.MatchingLabels(selector)); err != nil {
+				return fmt.Errorf("failed to list the GPU nodes of ClusterPolicy %s: %w", cr.Name, err)
+			}
+		}
+		for _, node := range nodes.Items {
+			if nodeMatchesSelector(node.Labels, other.Spec.NodeSelector) {
+				return fmt.Errorf("ClusterPolicies %s and %s match the same node %s", cr.Name, other.Name, node.Name)
+			}
+		}
+	}
+	return nil
+}
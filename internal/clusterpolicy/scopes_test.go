@@ -0,0 +1,203 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package clusterpolicy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+func newClusterPolicy(name string, created time.Time, nodeSelector map[string]string) *gpuv1.ClusterPolicy {
+	return &gpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(created)},
+		Spec:       gpuv1.ClusterPolicySpec{NodeSelector: nodeSelector},
+	}
+}
+
+func newGPUNode(name string, labels map[string]string) *corev1.Node {
+	nodeLabels := map[string]string{consts.GPUPresentLabel: "true"}
+	for key, value := range labels {
+		nodeLabels[key] = value
+	}
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nodeLabels}}
+}
+
+func newFakeClient(t *testing.T, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, gpuv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func TestScopedPolicies(t *testing.T) {
+	now := time.Now()
+	policies := []gpuv1.ClusterPolicy{
+		*newClusterPolicy("cluster-policy", now, nil),
+		*newClusterPolicy("team-b", now.Add(time.Minute), map[string]string{"pool": "b"}),
+		*newClusterPolicy("team-a", now.Add(time.Minute), map[string]string{"pool": "a"}),
+		*newClusterPolicy("team-c", now, map[string]string{"pool": "c"}),
+	}
+
+	var names []string
+	for _, policy := range ScopedPolicies(policies) {
+		names = append(names, policy.Name)
+	}
+	require.Equal(t, []string{"team-c", "team-a", "team-b"}, names)
+}
+
+func TestHonoredScopedPolicies(t *testing.T) {
+	now := time.Now()
+	c := newFakeClient(t,
+		newGPUNode("node-a", map[string]string{"pool": "a", "gpu": "a100"}),
+		newGPUNode("node-b", map[string]string{"pool": "b"}),
+	)
+	policies := []gpuv1.ClusterPolicy{
+		*newClusterPolicy("cluster-policy", now, nil),
+		*newClusterPolicy("team-gpu", now.Add(time.Minute), map[string]string{"gpu": "a100"}),
+		*newClusterPolicy("team-a", now, map[string]string{"pool": "a"}),
+		*newClusterPolicy("team-b", now.Add(time.Minute), map[string]string{"pool": "b"}),
+		*newClusterPolicy("team-d", now, map[string]string{consts.ClusterPolicyOwnerLabel: "d"}),
+	}
+
+	honored, skipped, err := HonoredScopedPolicies(context.Background(), c, policies)
+	require.NoError(t, err)
+	var names []string
+	for _, policy := range honored {
+		names = append(names, policy.Name)
+	}
+	// the invalid node selector and the node selector overlapping with an older one are skipped
+	require.Equal(t, []string{"team-a", "team-b"}, names)
+	require.Len(t, skipped, 2)
+	require.Equal(t, "team-d", skipped[0].Name)
+	require.Equal(t, "team-gpu", skipped[1].Name)
+	require.ErrorContains(t, skipped[1].Reason, "match the same node node-a")
+}
+
+func TestAssignOwners(t *testing.T) {
+	now := time.Now()
+	c := newFakeClient(t,
+		newClusterPolicy("cluster-policy", now, nil),
+		newClusterPolicy("team-a", now, map[string]string{"pool": "a"}),
+		newGPUNode("node-a", map[string]string{"pool": "a"}),
+		newGPUNode("node-b", map[string]string{"pool": "b", consts.ClusterPolicyOwnerLabel: "team-b"}),
+		newGPUNode("node-c", nil),
+	)
+
+	changed, err := AssignOwners(context.Background(), c)
+	require.NoError(t, err)
+	require.True(t, changed)
+
+	expectedOwners := map[string]string{"node-a": "team-a", "node-b": "", "node-c": ""}
+	for name, owner := range expectedOwners {
+		node := &corev1.Node{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: name}, node))
+		require.Equal(t, owner, node.Labels[consts.ClusterPolicyOwnerLabel], name)
+	}
+
+	changed, err = AssignOwners(context.Background(), c)
+	require.NoError(t, err)
+	require.False(t, changed)
+}
+
+func TestAssignOwnersSkipsInvalidScopes(t *testing.T) {
+	now := time.Now()
+	c := newFakeClient(t,
+		newClusterPolicy("team-a", now, map[string]string{"pool": "a"}),
+		newClusterPolicy("team-gpu", now.Add(time.Minute), map[string]string{"gpu": "a100"}),
+		newClusterPolicy("team-d", now, map[string]string{consts.ClusterPolicyOwnerLabel: "d"}),
+		newGPUNode("node-a", map[string]string{"pool": "a", "gpu": "a100"}),
+		newGPUNode("node-b", map[string]string{"pool": "a"}),
+	)
+
+	// the oldest ClusterPolicy wins the nodes of overlapping node selectors, the invalid ones are skipped
+	changed, err := AssignOwners(context.Background(), c)
+	require.NoError(t, err)
+	require.True(t, changed)
+	for _, name := range []string{"node-a", "node-b"} {
+		node := &corev1.Node{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: name}, node))
+		require.Equal(t, "team-a", node.Labels[consts.ClusterPolicyOwnerLabel], name)
+	}
+}
+
+func TestValidateScope(t *testing.T) {
+	now := time.Now()
+	c := newFakeClient(t,
+		newGPUNode("node-a", map[string]string{"pool": "a", "gpu": "a100"}),
+		newGPUNode("node-b", map[string]string{"pool": "b"}),
+	)
+	others := []gpuv1.ClusterPolicy{
+		*newClusterPolicy("cluster-policy", now, nil),
+		*newClusterPolicy("team-a", now, map[string]string{"pool": "a"}),
+	}
+
+	testCases := []struct {
+		description string
+		cr          *gpuv1.ClusterPolicy
+		expectError bool
+	}{
+		{
+			description: "disjoint node selector",
+			cr:          newClusterPolicy("team-b", now, map[string]string{"pool": "b"}),
+		},
+		{
+			description: "node selector matching no GPU node",
+			cr:          newClusterPolicy("team-c", now, map[string]string{"pool": "c"}),
+		},
+		{
+			description: "overlapping node selector",
+			cr:          newClusterPolicy("team-gpu", now, map[string]string{"gpu": "a100"}),
+			expectError: true,
+		},
+		{
+			description: "updated ClusterPolicy is not compared with itself",
+			cr:          newClusterPolicy("team-a", now, map[string]string{"pool": "a", "gpu": "a100"}),
+		},
+		{
+			description: "second ClusterPolicy without node selector",
+			cr:          newClusterPolicy("other-policy", now, nil),
+			expectError: true,
+		},
+		{
+			description: "reserved node selector label",
+			cr:          newClusterPolicy("team-d", now, map[string]string{consts.ClusterPolicyOwnerLabel: "team-a"}),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateScope(context.Background(), c, tc.cr, others)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package clusterpolicy
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+// desiredTenantForNode returns the tenant namespace matching the node, if any
+func desiredTenantForNode(node *corev1.Node, tenants []gpuv1.TenantNamespaceSpec) (string, error) {
+	// the nodes of the scoped ClusterPolicies do not belong to the tenants of the ClusterPolicy without node
+	// selector
+	if _, ok := node.Labels[consts.ClusterPolicyOwnerLabel]; ok {
+		return "", nil
+	}
+	matchingTenants := []string{}
+	for _, tenant := range tenants {
+		if nodeMatchesSelector(node.Labels, tenant.NodeSelector) {
+			matchingTenants = append(matchingTenants, tenant.Name)
+		}
+	}
+	if len(matchingTenants) > 1 {
+		return "", fmt.Errorf("multiple tenant namespaces match the same node %s: %v", node.Name, matchingTenants)
+	}
+	if len(matchingTenants) == 1 {
+		return matchingTenants[0], nil
+	}
+	return "", nil
+}
+
+// AssignTenants labels the GPU nodes matched by the node selector of a tenant namespace of the ClusterPolicy
+// without node selector with the name of the namespace, and removes the label from the other nodes. The tenant
+// namespaces of a scoped ClusterPolicy are ignored. Conflicts fail closed before node tenant labels are
+// changed. On success, it returns true when any node tenant label was changed.
+func AssignTenants(ctx context.Context, c client.Client, policy *gpuv1.ClusterPolicy) (bool, error) {
+	var tenants []gpuv1.TenantNamespaceSpec
+	if policy != nil && !policy.IsScoped() {
+		tenants = policy.Spec.TenantNamespaces
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := c.List(ctx, nodes, client.MatchingLabels{consts.GPUPresentLabel: "true"}); err != nil {
+		return false, fmt.Errorf("failed to list GPU nodes: %w", err)
+	}
+
+	desiredTenantsByNode := map[string]string{}
+	for i := range nodes.Items {
+		desiredTenant, err := desiredTenantForNode(&nodes.Items[i], tenants)
+		if err != nil {
+			return false, err
+		}
+		desiredTenantsByNode[nodes.Items[i].Name] = desiredTenant
+	}
+
+	changed := false
+	for _, nodeItem := range nodes.Items {
+		node := nodeItem.DeepCopy()
+		desiredTenant := desiredTenantsByNode[node.Name]
+
+		currentTenant, hasTenantLabel := node.Labels[consts.TenantNamespaceLabel]
+		if (desiredTenant == "" && !hasTenantLabel) || (desiredTenant != "" && currentTenant == desiredTenant) {
+			continue
+		}
+
+		originalNode := node.DeepCopy()
+		if desiredTenant == "" {
+			delete(node.Labels, consts.TenantNamespaceLabel)
+		} else {
+			if node.Labels == nil {
+				node.Labels = map[string]string{}
+			}
+			node.Labels[consts.TenantNamespaceLabel] = desiredTenant
+		}
+
+		if err := c.Patch(ctx, node, client.MergeFrom(originalNode)); err != nil {
+			return false, fmt.Errorf("failed to update tenant namespace label for node %q: %w", node.Name, err)
+		}
+		changed = true
+	}
+
+	return changed, nil
+}
@@ -0,0 +1,86 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package clusterpolicy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+func TestAssignTenants(t *testing.T) {
+	policy := newClusterPolicy("cluster-policy", time.Now(), nil)
+	policy.Spec.TenantNamespaces = []gpuv1.TenantNamespaceSpec{
+		{Name: "tenant-a", NodeSelector: map[string]string{"tenant": "a"}},
+		{Name: "tenant-b", NodeSelector: map[string]string{"tenant": "b"}},
+	}
+	c := newFakeClient(t,
+		newGPUNode("node-a", map[string]string{"tenant": "a"}),
+		newGPUNode("node-b", map[string]string{"tenant": "b", consts.TenantNamespaceLabel: "tenant-a"}),
+		newGPUNode("node-c", map[string]string{consts.TenantNamespaceLabel: "tenant-c"}),
+		newGPUNode("node-d", map[string]string{"tenant": "a", consts.ClusterPolicyOwnerLabel: "team-d"}),
+	)
+
+	changed, err := AssignTenants(context.Background(), c, policy)
+	require.NoError(t, err)
+	require.True(t, changed)
+
+	expectedTenants := map[string]string{"node-a": "tenant-a", "node-b": "tenant-b", "node-c": "", "node-d": ""}
+	for name, tenant := range expectedTenants {
+		node := &corev1.Node{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: name}, node))
+		require.Equal(t, tenant, node.Labels[consts.TenantNamespaceLabel], name)
+	}
+
+	changed, err = AssignTenants(context.Background(), c, policy)
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	// the labels are removed once the tenant namespaces are removed from the ClusterPolicy
+	changed, err = AssignTenants(context.Background(), c, nil)
+	require.NoError(t, err)
+	require.True(t, changed)
+	node := &corev1.Node{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: "node-a"}, node))
+	require.NotContains(t, node.Labels, consts.TenantNamespaceLabel)
+}
+
+func TestAssignTenantsFailsClosedOnConflict(t *testing.T) {
+	policy := newClusterPolicy("cluster-policy", time.Now(), nil)
+	policy.Spec.TenantNamespaces = []gpuv1.TenantNamespaceSpec{
+		{Name: "tenant-a", NodeSelector: map[string]string{"tenant": "a"}},
+		{Name: "tenant-gpu", NodeSelector: map[string]string{"gpu": "a100"}},
+	}
+	c := newFakeClient(t,
+		newGPUNode("node-a", map[string]string{"tenant": "a", "gpu": "a100"}),
+		newGPUNode("node-b", map[string]string{"tenant": "a"}),
+	)
+
+	_, err := AssignTenants(context.Background(), c, policy)
+	require.Error(t, err)
+
+	node := &corev1.Node{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: "node-b"}, node))
+	require.NotContains(t, node.Labels, consts.TenantNamespaceLabel)
+}
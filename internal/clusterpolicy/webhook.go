@@ -0,0 +1,61 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package clusterpolicy
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+// scopeValidator rejects the ClusterPolicies whose node selector overlaps with another ClusterPolicy at
+// admission time
+type scopeValidator struct {
+	client client.Reader
+}
+
+// NewScopeValidator returns the admission validator of the ClusterPolicy node selectors
+func NewScopeValidator(c client.Reader) admission.Validator[*gpuv1.ClusterPolicy] {
+	return &scopeValidator{client: c}
+}
+
+func (v *scopeValidator) validate(ctx context.Context, cr *gpuv1.ClusterPolicy) error {
+	policies := &gpuv1.ClusterPolicyList{}
+	if err := v.client.List(ctx, policies); err != nil {
+		return fmt.Errorf("failed to list ClusterPolicy CRs: %w", err)
+	}
+	return ValidateScope(ctx, v.client, cr, policies.Items)
+}
+
+// ValidateCreate validates the node selector of the created ClusterPolicy
+func (v *scopeValidator) ValidateCreate(ctx context.Context, cr *gpuv1.ClusterPolicy) (admission.Warnings, error) {
+	return nil, v.validate(ctx, cr)
+}
+
+// ValidateUpdate validates the node selector of the updated ClusterPolicy
+func (v *scopeValidator) ValidateUpdate(ctx context.Context, _ *gpuv1.ClusterPolicy, cr *gpuv1.ClusterPolicy) (admission.Warnings, error) {
+	return nil, v.validate(ctx, cr)
+}
+
+// ValidateDelete accepts the deletion of any ClusterPolicy
+func (v *scopeValidator) ValidateDelete(_ context.Context, _ *gpuv1.ClusterPolicy) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -25,6 +25,11 @@ const (
 	Ready = "Ready"
 	// Error condition type indicates one or more of the resources managed by the controller are in error state
 	Error = "Error"
+	// SafeMode condition type indicates that the operand rollouts are stopped as the operands rolled out crash loop
+	SafeMode = "SafeMode"
+	// LicenseServerUnreachable condition type indicates that the vGPU license server was unreachable from a GPU node
+	// at the last check of the license server monitor
+	LicenseServerUnreachable = "LicenseServerUnreachable"
 )
 
 // Updater interface
@@ -25,6 +25,11 @@ const (
 	NFDLabelsMissing = "NFDLabelsMissing"
 	// NoGPUNodes indicates that there are no GPU nodes in the cluster
 	NoGPUNodes = "NoGPUNodes"
+	// OptionalAPIUnavailable indicates that resources of optional integrations were skipped as their APIs are not served
+	OptionalAPIUnavailable = "OptionalAPIUnavailable"
+	// NodeMutationRestricted indicates that features were disabled as the node mutation policy forbids the node
+	// mutations they require
+	NodeMutationRestricted = "NodeMutationRestricted"
 	// NodeStatusExporterNotReady indicates that the node-status-exporter daemonset pods are not ready
 	NodeStatusExporterNotReady = "NodeStatusExporterNotReady"
 
@@ -32,4 +37,39 @@ const (
 	OperandNotReady = "OperandNotReady"
 	// DriverNotReady indicates that the driver daemonset pods are not ready
 	DriverNotReady = "DriverNotReady"
+	// KataRuntimeClassMissing indicates that Kata RuntimeClasses expected to pre-exist are missing
+	KataRuntimeClassMissing = "KataRuntimeClassMissing"
+	// MissingReference indicates that ConfigMaps or Secrets referenced by the ClusterPolicy are missing. Each
+	// missing reference is also reported by a MissingReference.<field> condition naming the missing object.
+	MissingReference = "MissingReference"
+	// InterferenceDetected indicates that a source of interference with the operands was found in the cluster,
+	// e.g. another device plugin advertising nvidia.com/gpu. Each source is reported by an
+	// InterferenceDetected.<source> condition with a remediation hint.
+	InterferenceDetected = "InterferenceDetected"
+	// PodSecurityRejected indicates that PodSecurity admission rejects operand pods
+	PodSecurityRejected = "PodSecurityRejected"
+	// PrerequisiteNotReady indicates that states were skipped as the operands they depend on are not ready
+	PrerequisiteNotReady = "PrerequisiteNotReady"
+	// OperandCrashLoop indicates that the operand pods rolled out by the operator crash loop on too many GPU nodes
+	OperandCrashLoop = "OperandCrashLoop"
+	// SafeModeAcknowledged indicates that the safe mode was acknowledged and the operand rollouts resumed
+	SafeModeAcknowledged = "SafeModeAcknowledged"
+	// ExternalGateTimedOut indicates that an external gate was not met within its timeout and stopped holding its states
+	ExternalGateTimedOut = "ExternalGateTimedOut"
+	// OperandRecreated indicates that an operand object was deleted to be recreated as immutable fields changed
+	OperandRecreated = "OperandRecreated"
+	// OperandRollout indicates that an operand DaemonSet was updated, restarting its pods, as its pod template changed
+	OperandRollout = "OperandRollout"
+	// OperatorConfigApplied indicates that a change of the operator ConfigMap was applied at runtime
+	OperatorConfigApplied = "OperatorConfigApplied"
+	// OperatorConfigInvalid indicates that the operator ConfigMap is invalid and was not applied
+	OperatorConfigInvalid = "OperatorConfigInvalid"
+	// LicenseServerReachable indicates that the license probe pod connected to the vGPU license server
+	LicenseServerReachable = "LicenseServerReachable"
+	// LicenseServerProbeFailed indicates that the license probe pod failed to connect to the vGPU license server
+	LicenseServerProbeFailed = "LicenseServerProbeFailed"
+	// LicenseServerEndpointUnknown indicates that the endpoint of the monitored vGPU license server is not known
+	LicenseServerEndpointUnknown = "LicenseServerEndpointUnknown"
+	// FeatureGateDisabled indicates that the feature gate of the resource is disabled
+	FeatureGateDisabled = "FeatureGateDisabled"
 )
@@ -0,0 +1,104 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package config
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConfigDigestAnnotationKey is the annotation the operator sets on every operand DaemonSet it renders, carrying
+// a hash of the configuration the operand pods run with, whether the configuration is visible in the pod
+// template or rendered by the operator to ConfigMaps consumed by the pods. The digest of the driver DaemonSets
+// is the DRIVER_CONFIG_DIGEST of the DriverInstallState. The annotation is only set on the DaemonSet metadata:
+// the operand pods are restarted by the changes of their pod template, which carries the DRIVER_CONFIG_DIGEST
+// of the driver pods and the RenderedConfigDigestAnnotationKey of the other operand pods.
+const ConfigDigestAnnotationKey = "nvidia.com/config-digest"
+
+// RenderedConfigDigestAnnotationKey is the pod template annotation of the operands mounting ConfigMaps rendered
+// by the operator, carrying a hash of the rendered content, so that changes of the content restart the pods.
+const RenderedConfigDigestAnnotationKey = "nvidia.com/rendered-config-digest"
+
+// OperandConfigState lists all fields that affect the configuration of an operand other than the driver.
+// Like for DriverInstallState, the digest is computed by hashing the non-zero fields in alphabetical order by
+// field name, so that adding a zero-valued field does not restart the operand pods.
+type OperandConfigState struct {
+	// Images, entrypoints, direct env values and volume mounts of the containers, in pod spec order
+	InitContainers []ContainerConfig
+	Containers     []ContainerConfig
+
+	// Volumes of the operand pods
+	Volumes []VolumeConfig
+
+	// Digests of the configuration rendered by the operator for the operand, e.g. the device plugin sharing
+	// configuration, keyed by the name of the rendered ConfigMap. The pod template only references the
+	// rendered ConfigMaps, their content is reflected by the RenderedConfigDigestAnnotationKey.
+	RenderedConfig map[string]string
+}
+
+// ContainerConfig is the digest-stable representation of a container of an operand pod.
+type ContainerConfig struct {
+	Name         string
+	Image        string
+	Command      []string
+	Args         []string
+	Env          []EnvVar
+	VolumeMounts []VolumeMountConfig
+}
+
+// ExtractOperandConfig builds the OperandConfigState of the operand running the given pod spec, without the
+// rendered configuration.
+func ExtractOperandConfig(spec *corev1.PodSpec) OperandConfigState {
+	return OperandConfigState{
+		InitContainers: ExtractContainers(spec.InitContainers),
+		Containers:     ExtractContainers(spec.Containers),
+		Volumes:        ExtractVolumes(spec.Volumes),
+	}
+}
+
+// ExtractContainers converts corev1.Containers to a digest-stable representation.
+func ExtractContainers(containers []corev1.Container) []ContainerConfig {
+	var result []ContainerConfig
+	for i := range containers {
+		ctr := &containers[i]
+		result = append(result, ContainerConfig{
+			Name:         ctr.Name,
+			Image:        ctr.Image,
+			Command:      ctr.Command,
+			Args:         ctr.Args,
+			Env:          ExtractEnvVars(ctr.Env),
+			VolumeMounts: ExtractVolumeMounts(ctr.VolumeMounts),
+		})
+	}
+	return result
+}
+
+// ConfigDigestFromObjectMeta returns the config digest annotation of an operand DaemonSet, or "" if absent.
+func ConfigDigestFromObjectMeta(meta *metav1.ObjectMeta) string {
+	if meta == nil {
+		return ""
+	}
+	return meta.Annotations[ConfigDigestAnnotationKey]
+}
+
+// SetConfigDigest sets the config digest annotation on the given object metadata of a DaemonSet.
+func SetConfigDigest(digest string, meta *metav1.ObjectMeta) {
+	if meta.Annotations == nil {
+		meta.Annotations = make(map[string]string)
+	}
+	meta.Annotations[ConfigDigestAnnotationKey] = digest
+}
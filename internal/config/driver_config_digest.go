@@ -115,10 +115,22 @@ type DriverInstallState struct {
 	RepoConfig            string
 	CertConfig            string
 
+	// Configuration files of the kernel module parameters rendered from the driver spec, keyed by file name
+	KernelModuleParameters map[string]string
+
+	// OCI repository and credentials used for caching built kernel modules
+	ModuleCacheRepository string
+	ModuleCacheSecretName string
+
 	// Pre-compiled driver settings
 	UsePrecompiled bool
 	KernelVersion  string
 
+	// Kernel flavor of the nodes and the kernel headers packages installed to build the driver, set for the
+	// kernel flavors other than generic
+	KernelFlavor          string
+	KernelHeadersPackages string
+
 	// OpenShift-specific fields
 	OpenshiftVersion string
 	DTKEnabled       bool
@@ -129,6 +141,8 @@ type DriverInstallState struct {
 	HTTPSProxy             string
 	NoProxy                string
 	TrustedCAConfigMapName string
+	// Digest of the trusted CA bundle of the ClusterPolicy proxy configuration
+	TrustedCABundleDigest string
 
 	// User-supplied volumes and mounts (licensing, certs, repo config, etc.).
 	// In the DaemonSet extraction path these capture all volumes/mounts,
@@ -61,6 +61,15 @@ const (
 	VGPUTopologyConfigMountPath = "/etc/nvidia/nvidia-topologyd.conf"
 	// VGPUTopologyConfigFileName is the vGPU topology daemon configuration filename
 	VGPUTopologyConfigFileName = "nvidia-topologyd.conf"
+	// FabricManagerConfigMountPath indicates target mount path for the fabric manager configuration file
+	FabricManagerConfigMountPath = "/usr/share/nvidia/nvswitch/fabricmanager.cfg"
+	// FabricManagerConfigFileName is the fabric manager configuration filename
+	FabricManagerConfigFileName = "fabricmanager.cfg"
+
+	// ModuleCacheAuthMountPath indicates target mount path for the kernel module cache registry credentials
+	ModuleCacheAuthMountPath = "/etc/nvidia/module-cache/config.json"
+	// ModuleCacheAuthFileName is the key of the registry credentials in a kubernetes.io/dockerconfigjson Secret
+	ModuleCacheAuthFileName = ".dockerconfigjson"
 
 	// NVIDIADriverControllerIndexKey provides quick lookups for DaemonSets owned by an NVIDIADriver instance
 	NVIDIADriverControllerIndexKey = "metadata.nvidiadriver.controller"
@@ -69,6 +78,134 @@ const (
 	DefaultNVIDIADriverName = "default"
 	// NVIDIADriverOwnerLabel is an operator-managed node label used to route each GPU node to one NVIDIADriver.
 	NVIDIADriverOwnerLabel = "nvidia.com/gpu-operator.driver.owner"
+	// ClusterPolicyOwnerLabel is an operator-managed node label used to route each GPU node matched by the node
+	// selector of a scoped ClusterPolicy to that ClusterPolicy.
+	ClusterPolicyOwnerLabel = "nvidia.com/gpu-operator.clusterpolicy.owner"
+	// TenantNamespaceLabel is an operator-managed node label used to route each GPU node matched by the node
+	// selector of a tenant namespace of the ClusterPolicy to the device plugin and DCGM Exporter of the tenant.
+	TenantNamespaceLabel = "nvidia.com/gpu-operator.tenant.namespace"
+
+	// PrecompiledDriverUnavailableLabel is an operator-managed node label set to "true" on the nodes deployed no
+	// driver by an NVIDIADriver because no precompiled driver image exists for their kernel
+	PrecompiledDriverUnavailableLabel = "nvidia.com/gpu.driver.precompiled-unavailable"
+
+	// KernelFlavorLabelKey is an operator-managed node label holding the flavor of the kernel of the GPU nodes,
+	// e.g. generic or realtime, detected from the kernel version reported by NFD
+	KernelFlavorLabelKey = "nvidia.com/gpu.kernel-flavor"
+
+	// ValidatorImageLabelKey is an operator-managed node label holding the validator image selected for the GPU
+	// node per its architecture and OS, absent when the node runs the default validator image. The DaemonSets
+	// deployed per validator image are labeled with the same key.
+	ValidatorImageLabelKey = "nvidia.com/gpu.validator-image"
+
+	// ContainerRuntimeLabelKey is an operator-managed node label holding the container runtime of the GPU node,
+	// read from the node info reported by the kubelet. The DaemonSets deployed per container runtime in
+	// mixed-runtime clusters are labeled with the same key.
+	ContainerRuntimeLabelKey = "nvidia.com/gpu.container-runtime"
+
+	// OperandArchLabelKey labels the operand DaemonSets deployed per architecture of the GPU nodes for the
+	// components with per-architecture images, and their pods, with the architecture they are scheduled on
+	OperandArchLabelKey = "nvidia.com/gpu.operand-arch"
+
+	// GPUIdleSinceAnnotationKey is an operator-managed node annotation holding the RFC 3339 time since which
+	// all GPUs of the node have been idle, as reported by DCGM Exporter. It is absent while any GPU is busy.
+	GPUIdleSinceAnnotationKey = "nvidia.com/gpu.idle-since"
+	// GPUIdleDurationAnnotationKey is an operator-managed node annotation holding the number of seconds all GPUs of
+	// the node have been idle, rounded down to the sampling interval. It is absent while any GPU is busy.
+	GPUIdleDurationAnnotationKey = "nvidia.com/gpu.idle-duration"
+
+	// GPUAutoscalingAllocatableAnnotationKey is an operator-managed node annotation holding, as a JSON object, the
+	// number of each extended resource the NVIDIA Device Plugin is expected to advertise on the node
+	GPUAutoscalingAllocatableAnnotationKey = "nvidia.com/gpu.autoscaling-allocatable"
+
+	// GPUUnhealthyLabelKey is a node label set to "true" by the GPU health check when a critical XID error
+	// was reported by the node. It is also the key of the taint of unhealthy nodes.
+	GPUUnhealthyLabelKey = "nvidia.com/gpu.unhealthy"
+	// GPUUnhealthyReasonAnnotationKey is a node annotation holding the XID error which marked the node unhealthy
+	GPUUnhealthyReasonAnnotationKey = "nvidia.com/gpu.unhealthy-reason"
+	// GPURemediationAnnotationKey is a node annotation requesting the remediation of the GPUs of the node, either
+	// "gpu-reset" or "reboot-required". It is removed by the operator once the remediation succeeded, removing it
+	// beforehand cancels the remediation.
+	GPURemediationAnnotationKey = "nvidia.com/gpu.remediation"
+	// GPURemediationStateAnnotationKey is an operator-managed node annotation holding the state of the remediation
+	// of the GPUs of the node
+	GPURemediationStateAnnotationKey = "nvidia.com/gpu.remediation-state"
+	// MIGConfigAppliedAnnotationKey is an operator-managed node annotation holding the value of the
+	// nvidia.com/mig.config label applied by the operator from the MIG config selectors. The label is only
+	// relabeled by the operator while it still holds this value.
+	MIGConfigAppliedAnnotationKey = "nvidia.com/mig.config.applied"
+
+	// ConsumerGPULabel is an operator-managed node label set to "true" on the GPU nodes with consumer GPUs,
+	// e.g. GeForce GPUs
+	ConsumerGPULabel = "nvidia.com/gpu.consumer"
+
+	// NVLinkFabricDomainLabelKey is a node label whose value is shared by the nodes of a multi-node NVLink fabric
+	NVLinkFabricDomainLabelKey = "nvidia.com/gpu.fabric.domain"
+	// NVLinkFabricLeaderLabelKey is a node label set to "true" on the nodes registering first in their NVLink fabric
+	NVLinkFabricLeaderLabelKey = "nvidia.com/gpu.fabric.leader"
+	// NVLinkFabricStateLabelKey is a node label set to "ready" by the NVLink fabric registration once the
+	// NVLink fabric registration of the node completed
+	NVLinkFabricStateLabelKey = "nvidia.com/gpu.fabric.state"
+
+	// PrePulledDriverImageAnnotationKey is a node annotation holding the driver image pre-pulled onto the node
+	PrePulledDriverImageAnnotationKey = "nvidia.com/gpu.prepull.driver-image"
+	// PrePulledToolkitImageAnnotationKey is a node annotation holding the toolkit image pre-pulled onto the node
+	PrePulledToolkitImageAnnotationKey = "nvidia.com/gpu.prepull.toolkit-image"
+
+	// OSUpgradeTargetKernelAnnotationKey is a node annotation announcing the kernel version, e.g.
+	// "5.15.0-105-generic", the node runs after its upcoming OS upgrade
+	OSUpgradeTargetKernelAnnotationKey = "nvidia.com/gpu.os-upgrade.target-kernel"
+	// OSUpgradeTargetOSAnnotationKey is a node annotation announcing the OS, e.g. "ubuntu22.04", the node runs
+	// after its upcoming OS upgrade. The current OS of the node is assumed if not set.
+	OSUpgradeTargetOSAnnotationKey = "nvidia.com/gpu.os-upgrade.target-os"
+	// OSUpgradeTargetRHCOSAnnotationKey is a node annotation announcing the RHCOS version, e.g.
+	// "418.94.202410090804-0", the OpenShift node runs after its upcoming OS upgrade
+	OSUpgradeTargetRHCOSAnnotationKey = "nvidia.com/gpu.os-upgrade.target-rhcos"
+
+	// CUDADriverVersionMajorLabelKey is a node label holding the major version of the driver of the node
+	CUDADriverVersionMajorLabelKey = "nvidia.com/cuda.driver-version.major"
+	// CUDADriverVersionFullLabelKey is a node label holding the full version of the driver of the node
+	CUDADriverVersionFullLabelKey = "nvidia.com/cuda.driver-version.full"
+	// CUDARuntimeVersionMaxLabelKey is a node label holding the newest CUDA runtime version, e.g. "12.8",
+	// supported by the driver of the node
+	CUDARuntimeVersionMaxLabelKey = "nvidia.com/cuda.runtime-version.max"
+	// CUDARuntimeVersionMaxMajorLabelKey is a node label holding the major version of the newest CUDA runtime
+	// supported by the driver of the node
+	CUDARuntimeVersionMaxMajorLabelKey = "nvidia.com/cuda.runtime-version.max.major"
+	// CUDARuntimeVersionMaxMinorLabelKey is a node label holding the minor version of the newest CUDA runtime
+	// supported by the driver of the node
+	CUDARuntimeVersionMaxMinorLabelKey = "nvidia.com/cuda.runtime-version.max.minor"
+
+	// GDSCapableLabelKey is a node label set by the GPUDirect Storage hardware detection to "true" when the
+	// node has NVMe or InfiniBand devices GPUDirect Storage can be used with, and to "false" otherwise
+	GDSCapableLabelKey = "nvidia.com/gpu.gds.capable"
+	// GDSUnsupportedStatusFile is the status file created by the GPUDirect Storage hardware detection in the
+	// validations directory of nodes without hardware supported by GPUDirect Storage
+	GDSUnsupportedStatusFile = "gds-unsupported"
+
+	// GPURDMATopologyAnnotationKey is a node annotation set by the RDMA topology discovery to the JSON list of
+	// the GPUs of the node paired with their closest RDMA NIC, ordered by GPU index
+	GPURDMATopologyAnnotationKey = "nvidia.com/gpu.rdma.topology"
+	// GPURDMANICLabelKeyPrefix prefixes the node labels set by the RDMA topology discovery to the RDMA device
+	// paired with every GPU, e.g. nvidia.com/gpu-0.rdma-nic=mlx5_0
+	GPURDMANICLabelKeyPrefix = "nvidia.com/gpu-"
+	// GPURDMANICLabelKeySuffix suffixes the node labels of the RDMA devices paired with the GPUs
+	GPURDMANICLabelKeySuffix = ".rdma-nic"
+
+	// DriverInstallStageFile is the file in the validations directory the driver container reports its current
+	// installation stage to, e.g. "building"
+	DriverInstallStageFile = ".driver-install-stage"
+	// DriverInstallStageAnnotationKey is a node annotation holding the installation stage of the driver of the
+	// node, as reported by the driver container
+	DriverInstallStageAnnotationKey = "nvidia.com/driver.install-stage"
+	// OperandsDisableAfterAnnotationKey is a node annotation holding the deadline, as an RFC 3339 time, after which
+	// the operands are removed from the node, the GPU workloads of the node being drained until the deadline
+	OperandsDisableAfterAnnotationKey = "nvidia.com/gpu.operands.disable-after"
+
+	// RuntimeConfigRolledBackConditionType is the type of the node condition reporting whether the containerd
+	// configuration applied by the Container Toolkit was restored because containerd did not come back
+	// healthy after its restart
+	RuntimeConfigRolledBackConditionType = "NVIDIARuntimeConfigRolledBack"
 
 	// GPUAllocationModeLabelKey is a node label selecting which stack serves the node's GPUs:
 	// the device plugin (ClusterPolicy) or the DRA driver (GPUCluster). Once both stacks can
@@ -0,0 +1,409 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package drain
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/k8s-operator-libs/pkg/consts"
+	"github.com/NVIDIA/k8s-operator-libs/pkg/upgrade"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	kubectldrain "k8s.io/kubectl/pkg/drain"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/nodepolicy"
+)
+
+const (
+	drainProgressAnnotationKeyFmt     = "nvidia.com/%s-driver-upgrade.drain-progress"
+	rebootRequiredLabelKeyFmt         = "nvidia.com/%s-driver-upgrade.reboot-required"
+	rebootRequiredBootIDAnnotationFmt = "nvidia.com/%s-driver-upgrade.reboot-required.boot-id"
+
+	// pdbPollInterval is the interval at which the PodDisruptionBudgets blocking a drain are checked again
+	pdbPollInterval = 10 * time.Second
+)
+
+// GetDrainProgressAnnotationKey returns the key of the node annotation reporting the progress of the node drain
+func GetDrainProgressAnnotationKey() string {
+	return fmt.Sprintf(drainProgressAnnotationKeyFmt, upgrade.DriverName)
+}
+
+// GetRebootRequiredLabelKey returns the key of the node label indicating that the node must be rebooted
+// for the upgraded driver to be loaded
+func GetRebootRequiredLabelKey() string {
+	return fmt.Sprintf(rebootRequiredLabelKeyFmt, upgrade.DriverName)
+}
+
+// getRebootRequiredBootIDAnnotationKey returns the key of the node annotation recording the boot ID of the
+// node when it was labeled as requiring a reboot
+func getRebootRequiredBootIDAnnotationKey() string {
+	return fmt.Sprintf(rebootRequiredBootIDAnnotationFmt, upgrade.DriverName)
+}
+
+// Manager drains the nodes whose driver is upgraded. It implements the upgrade.DrainManager interface and
+// extends the drain of the upgrade library with the ClusterPolicy drain policy: PodDisruptionBudgets blocking
+// the eviction of the pods are waited for before evicting any pod, the grace period of the evicted pods can be
+// overridden, namespaces can be skipped, and nodes which cannot be drained can be upgraded without drain and
+// labeled as requiring a reboot. The progress of each drain is reported in a node annotation. The node mutations
+// are restricted to the fields allowed by the node mutation policy.
+type Manager struct {
+	k8sInterface             kubernetes.Interface
+	nodeUpgradeStateProvider upgrade.NodeUpgradeStateProvider
+	log                      logr.Logger
+	eventRecorder            record.EventRecorder
+	nodeMutationPolicy       nodepolicy.Policy
+
+	mu            sync.Mutex
+	policy        *gpuv1.DriverDrainPolicySpec
+	drainingNodes map[string]bool
+}
+
+var _ upgrade.DrainManager = &Manager{}
+
+// NewManager creates a drain Manager
+func NewManager(k8sInterface kubernetes.Interface, nodeUpgradeStateProvider upgrade.NodeUpgradeStateProvider,
+	log logr.Logger, eventRecorder record.EventRecorder, nodeMutationPolicy nodepolicy.Policy) *Manager {
+	return &Manager{
+		k8sInterface:             k8sInterface,
+		nodeUpgradeStateProvider: nodeUpgradeStateProvider,
+		log:                      log,
+		eventRecorder:            eventRecorder,
+		nodeMutationPolicy:       nodeMutationPolicy,
+		drainingNodes:            make(map[string]bool),
+	}
+}
+
+// SetPolicy sets the drain policy applied to the drains scheduled from now on
+func (m *Manager) SetPolicy(policy *gpuv1.DriverDrainPolicySpec) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.policy = policy.DeepCopy()
+}
+
+func (m *Manager) getPolicy() *gpuv1.DriverDrainPolicySpec {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.policy.DeepCopy()
+}
+
+// startDraining marks the node as being drained, and returns false if it is already being drained
+func (m *Manager) startDraining(nodeName string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.drainingNodes[nodeName] {
+		return false
+	}
+	m.drainingNodes[nodeName] = true
+	return true
+}
+
+func (m *Manager) stopDraining(nodeName string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.drainingNodes, nodeName)
+}
+
+// ScheduleNodesDrain schedules the drain of each node of the configuration which is not being drained yet.
+// Once drained, a node moves to the pod-restart-required state. A node which cannot be drained moves to the
+// upgrade-failed state, or to the pod-restart-required state with the reboot-required label when the drain
+// policy falls back to a reboot. The drain is denied when the node mutation policy does not allow cordoning the
+// nodes.
+func (m *Manager) ScheduleNodesDrain(ctx context.Context, drainConfig *upgrade.DrainConfiguration) error {
+	if len(drainConfig.Nodes) == 0 {
+		m.log.V(consts.LogLevelInfo).Info("Drain Manager, no nodes scheduled to drain")
+		return nil
+	}
+	if drainConfig.Spec == nil {
+		return fmt.Errorf("drain spec should not be empty")
+	}
+	if !drainConfig.Spec.Enable {
+		m.log.V(consts.LogLevelInfo).Info("Drain Manager, drain is disabled")
+		return nil
+	}
+	if !m.nodeMutationPolicy.AllowsSpec() {
+		return fmt.Errorf("%w: drain of %d nodes under the %s policy", nodepolicy.ErrMutationDenied, len(drainConfig.Nodes), m.nodeMutationPolicy)
+	}
+
+	policy := m.getPolicy()
+	timeout := time.Duration(drainConfig.Spec.TimeoutSecond) * time.Second
+	for _, node := range drainConfig.Nodes {
+		if !m.startDraining(node.Name) {
+			m.log.V(consts.LogLevelInfo).Info("Node is already being drained, skipping", "node", node.Name)
+			continue
+		}
+		m.log.V(consts.LogLevelInfo).Info("Schedule drain for node", "node", node.Name)
+		m.eventRecorder.Event(node, corev1.EventTypeNormal, upgrade.GetEventReason(), "Scheduling drain of the node")
+
+		helper := m.newDrainHelper(ctx, drainConfig, policy)
+		go func() {
+			defer m.stopDraining(node.Name)
+			err := m.drainNode(ctx, helper, node, timeout)
+			if err != nil {
+				m.handleDrainFailure(ctx, node, policy, err)
+				return
+			}
+			m.log.V(consts.LogLevelInfo).Info("Drained the node", "node", node.Name)
+			m.eventRecorder.Event(node, corev1.EventTypeNormal, upgrade.GetEventReason(), "Successfully drained the node")
+			m.setDrainProgress(ctx, node, "drained")
+			_ = m.nodeUpgradeStateProvider.ChangeNodeUpgradeState(ctx, node, upgrade.UpgradeStatePodRestartRequired)
+		}()
+	}
+	return nil
+}
+
+func (m *Manager) newDrainHelper(ctx context.Context, drainConfig *upgrade.DrainConfiguration, policy *gpuv1.DriverDrainPolicySpec) *kubectldrain.Helper {
+	helper := &kubectldrain.Helper{
+		Ctx:    ctx,
+		Client: m.k8sInterface,
+		Force:  drainConfig.Spec.Force,
+		// driver pods are part of a DaemonSet, so DaemonSet pods must be ignored
+		IgnoreAllDaemonSets: true,
+		DeleteEmptyDirData:  drainConfig.Spec.DeleteEmptyDir,
+		GracePeriodSeconds:  policy.GetGracePeriodSeconds(),
+		PodSelector:         drainConfig.Spec.PodSelector,
+		Out:                 io.Discard,
+		ErrOut:              io.Discard,
+	}
+	if policy != nil && len(policy.SkipNamespaces) > 0 {
+		helper.AdditionalFilters = append(helper.AdditionalFilters, skipNamespacesFilter(policy.SkipNamespaces))
+	}
+	return helper
+}
+
+// drainNode cordons the node, waits for the PodDisruptionBudgets of its pods to allow their eviction, and
+// evicts the pods. The whole drain is bounded by the timeout, zero meaning no timeout.
+func (m *Manager) drainNode(ctx context.Context, helper *kubectldrain.Helper, node *corev1.Node, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+		helper.Ctx = ctx
+	}
+
+	if err := kubectldrain.RunCordonOrUncordon(helper, node, true); err != nil {
+		return fmt.Errorf("failed to cordon the node: %w", err)
+	}
+	m.setDrainProgress(ctx, node, "cordoned")
+
+	list, errs := helper.GetPodsForDeletion(node.Name)
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to list the pods to evict: %w", utilerrors.NewAggregate(errs))
+	}
+	pods := list.Pods()
+
+	err := wait.PollUntilContextCancel(ctx, pdbPollInterval, true, func(ctx context.Context) (bool, error) {
+		pdb, err := m.getBlockingPodDisruptionBudget(ctx, pods)
+		if err != nil {
+			return false, err
+		}
+		if pdb == nil {
+			return true, nil
+		}
+		m.log.V(consts.LogLevelInfo).Info("Waiting for PodDisruptionBudget to allow evictions", "node", node.Name,
+			"pdb", types.NamespacedName{Namespace: pdb.Namespace, Name: pdb.Name})
+		m.setDrainProgress(ctx, node, fmt.Sprintf("waiting for PodDisruptionBudget %s/%s", pdb.Namespace, pdb.Name))
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to wait for PodDisruptionBudgets to allow the eviction of the pods: %w", err)
+	}
+
+	var mu sync.Mutex
+	evicted := 0
+	m.setDrainProgress(ctx, node, fmt.Sprintf("evicted %d/%d pods", evicted, len(pods)))
+	helper.OnPodDeletionOrEvictionFinished = func(pod *corev1.Pod, usingEviction bool, err error) {
+		log := m.log.WithValues("node", node.Name, "pod", pod.Name, "namespace", pod.Namespace, "using-eviction", usingEviction)
+		if err != nil {
+			log.V(consts.LogLevelWarning).Info("Drain Pod failed", "error", err)
+			return
+		}
+		log.V(consts.LogLevelInfo).Info("Drain Pod finished")
+		mu.Lock()
+		defer mu.Unlock()
+		evicted++
+		m.setDrainProgress(ctx, node, fmt.Sprintf("evicted %d/%d pods", evicted, len(pods)))
+	}
+	if timeout > 0 {
+		deadline, _ := ctx.Deadline()
+		helper.Timeout = time.Until(deadline)
+	}
+	if err := helper.DeleteOrEvictPods(pods); err != nil {
+		return fmt.Errorf("failed to evict the pods: %w", err)
+	}
+	return nil
+}
+
+// handleDrainFailure moves a node which could not be drained to the upgrade-failed state, or labels it as
+// requiring a reboot and proceeds with the upgrade when the drain policy falls back to a reboot
+func (m *Manager) handleDrainFailure(ctx context.Context, node *corev1.Node, policy *gpuv1.DriverDrainPolicySpec, drainErr error) {
+	m.log.V(consts.LogLevelError).Error(drainErr, "Failed to drain node", "node", node.Name)
+
+	if policy.GetFailurePolicy() != gpuv1.DrainFailurePolicyRebootRequired {
+		m.eventRecorder.Eventf(node, corev1.EventTypeWarning, upgrade.GetEventReason(), "Failed to drain the node, %s", drainErr.Error())
+		m.setDrainProgress(ctx, node, fmt.Sprintf("failed: %s", drainErr.Error()))
+		_ = m.nodeUpgradeStateProvider.ChangeNodeUpgradeState(ctx, node, upgrade.UpgradeStateFailed)
+		return
+	}
+
+	// use a fresh context, the drain may have failed because the drain context timed out
+	patchCtx := context.WithoutCancel(ctx)
+	patch := fmt.Sprintf(`{"metadata":{"labels":{%q:"true"},"annotations":{%q:%q}}}`,
+		GetRebootRequiredLabelKey(), getRebootRequiredBootIDAnnotationKey(), node.Status.NodeInfo.BootID)
+	err := m.patchNode(patchCtx, node.Name, []byte(patch))
+	if err != nil {
+		m.log.V(consts.LogLevelError).Error(err, "Failed to label node as requiring a reboot", "node", node.Name)
+		m.eventRecorder.Eventf(node, corev1.EventTypeWarning, upgrade.GetEventReason(), "Failed to drain the node, %s", drainErr.Error())
+		_ = m.nodeUpgradeStateProvider.ChangeNodeUpgradeState(patchCtx, node, upgrade.UpgradeStateFailed)
+		return
+	}
+	m.eventRecorder.Eventf(node, corev1.EventTypeWarning, upgrade.GetEventReason(),
+		"Failed to drain the node, upgrading the driver without drain, the node must be rebooted: %s", drainErr.Error())
+	m.setDrainProgress(patchCtx, node, "reboot-required")
+	_ = m.nodeUpgradeStateProvider.ChangeNodeUpgradeState(patchCtx, node, upgrade.UpgradeStatePodRestartRequired)
+}
+
+// setDrainProgress reports the progress of the drain in a node annotation. Failures are only logged, as the
+// annotation is informational.
+func (m *Manager) setDrainProgress(ctx context.Context, node *corev1.Node, progress string) {
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, GetDrainProgressAnnotationKey(), progress)
+	err := m.patchNode(context.WithoutCancel(ctx), node.Name, []byte(patch))
+	if err != nil {
+		m.log.V(consts.LogLevelWarning).Info("Failed to update drain progress", "node", node.Name, "error", err)
+	}
+}
+
+// patchNode applies the merge patch to the node, restricted to the fields allowed by the node mutation policy. The
+// patch is skipped when no field is allowed.
+func (m *Manager) patchNode(ctx context.Context, nodeName string, patch []byte) error {
+	allowed, dropped, err := nodepolicy.RestrictPatch(m.nodeMutationPolicy, patch)
+	if err != nil {
+		return err
+	}
+	if len(dropped) > 0 {
+		m.log.V(consts.LogLevelDebug).Info("Dropped node fields forbidden by the node mutation policy",
+			"node", nodeName, "policy", m.nodeMutationPolicy, "fields", dropped)
+	}
+	if allowed == nil {
+		return nil
+	}
+	_, err = m.k8sInterface.CoreV1().Nodes().Patch(ctx, nodeName, types.MergePatchType, allowed, metav1.PatchOptions{})
+	return err
+}
+
+// getBlockingPodDisruptionBudget returns a PodDisruptionBudget which currently does not allow the eviction of
+// one of the pods, or nil if all pods can be evicted
+func (m *Manager) getBlockingPodDisruptionBudget(ctx context.Context, pods []corev1.Pod) (*policyv1.PodDisruptionBudget, error) {
+	pdbs := make(map[string][]policyv1.PodDisruptionBudget)
+	for i := range pods {
+		namespace := pods[i].Namespace
+		if _, ok := pdbs[namespace]; !ok {
+			list, err := m.k8sInterface.PolicyV1().PodDisruptionBudgets(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list PodDisruptionBudgets in namespace %s: %w", namespace, err)
+			}
+			pdbs[namespace] = list.Items
+		}
+		if pdb := getBlockingPodDisruptionBudget(&pods[i], pdbs[namespace]); pdb != nil {
+			return pdb, nil
+		}
+	}
+	return nil, nil
+}
+
+// getBlockingPodDisruptionBudget returns the PodDisruptionBudget selecting the pod which does not allow any
+// disruption. Pods which are not ready are not considered blocked, as they do not count against the budget.
+func getBlockingPodDisruptionBudget(pod *corev1.Pod, pdbs []policyv1.PodDisruptionBudget) *policyv1.PodDisruptionBudget {
+	if !isPodReady(pod) {
+		return nil
+	}
+	for i := range pdbs {
+		if pdbs[i].Namespace != pod.Namespace || pdbs[i].Status.DisruptionsAllowed > 0 {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdbs[i].Spec.Selector)
+		if err != nil || selector.Empty() || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		return &pdbs[i]
+	}
+	return nil
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// skipNamespacesFilter is a drain filter skipping the pods of the given namespaces
+func skipNamespacesFilter(namespaces []string) kubectldrain.PodFilter {
+	return func(pod corev1.Pod) kubectldrain.PodDeleteStatus {
+		if slices.Contains(namespaces, pod.Namespace) {
+			return kubectldrain.MakePodDeleteStatusSkip()
+		}
+		return kubectldrain.MakePodDeleteStatusOkay()
+	}
+}
+
+// ClearCompletedReboots removes the reboot-required label from the nodes which have been rebooted since
+// they were labeled, i.e. whose boot ID has changed
+func (m *Manager) ClearCompletedReboots(ctx context.Context) error {
+	nodes, err := m.k8sInterface.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: GetRebootRequiredLabelKey() + "=true"})
+	if err != nil {
+		return fmt.Errorf("failed to list nodes requiring a reboot: %w", err)
+	}
+	for _, node := range nodes.Items {
+		if !isRebooted(&node) {
+			continue
+		}
+		m.log.V(consts.LogLevelInfo).Info("Node has been rebooted, removing the reboot-required label", "node", node.Name)
+		patch := fmt.Sprintf(`{"metadata":{"labels":{%q:null},"annotations":{%q:null}}}`,
+			GetRebootRequiredLabelKey(), getRebootRequiredBootIDAnnotationKey())
+		if err := m.patchNode(ctx, node.Name, []byte(patch)); err != nil {
+			return fmt.Errorf("failed to remove the reboot-required label from node %s: %w", node.Name, err)
+		}
+	}
+	return nil
+}
+
+// isRebooted returns true if the boot ID of the node differs from the one recorded when it was labeled as
+// requiring a reboot
+func isRebooted(node *corev1.Node) bool {
+	bootID, ok := node.Annotations[getRebootRequiredBootIDAnnotationKey()]
+	if !ok || bootID == "" {
+		// the boot ID was not known when the node was labeled, the reboot cannot be detected
+		return false
+	}
+	return node.Status.NodeInfo.BootID != "" && node.Status.NodeInfo.BootID != bootID
+}
@@ -0,0 +1,277 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package drain
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	upgrade_v1alpha1 "github.com/NVIDIA/k8s-operator-libs/api/upgrade/v1alpha1"
+	"github.com/NVIDIA/k8s-operator-libs/pkg/upgrade"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/NVIDIA/gpu-operator/internal/nodepolicy"
+)
+
+// fakeNodeUpgradeStateProvider records the upgrade state of the nodes
+type fakeNodeUpgradeStateProvider struct {
+	mu     sync.Mutex
+	states map[string]string
+}
+
+func (p *fakeNodeUpgradeStateProvider) GetNode(_ context.Context, _ string) (*corev1.Node, error) {
+	return nil, nil
+}
+
+func (p *fakeNodeUpgradeStateProvider) ChangeNodeUpgradeState(_ context.Context, node *corev1.Node, state string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.states[node.Name] = state
+	return nil
+}
+
+func (p *fakeNodeUpgradeStateProvider) ChangeNodeUpgradeAnnotation(_ context.Context, _ *corev1.Node, _ string, _ string) error {
+	return nil
+}
+
+func (p *fakeNodeUpgradeStateProvider) getState(nodeName string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.states[nodeName]
+}
+
+func newTestPod(namespace, name, nodeName string, labels map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    labels,
+			// pods without a controller are only evicted by a forced drain
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: name, UID: "uid", Controller: ptr.To(true)}},
+		},
+		Spec: corev1.PodSpec{NodeName: nodeName},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+func newTestPDB(namespace, name string, labels map[string]string, disruptionsAllowed int32) *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: labels}},
+		Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: disruptionsAllowed},
+	}
+}
+
+func newTestManager(objects ...runtime.Object) (*Manager, *fake.Clientset, *fakeNodeUpgradeStateProvider) {
+	upgrade.SetDriverName("gpu")
+	clientset := fake.NewClientset(objects...)
+	// the fake API server does not serve the eviction subresource, pods are deleted instead
+	clientset.Resources = []*metav1.APIResourceList{{GroupVersion: "v1"}}
+	provider := &fakeNodeUpgradeStateProvider{states: make(map[string]string)}
+	return NewManager(clientset, provider, logr.Discard(), record.NewFakeRecorder(100), ""), clientset, provider
+}
+
+func TestGetBlockingPodDisruptionBudget(t *testing.T) {
+	pod := newTestPod("ns", "pod", "node", map[string]string{"app": "training"})
+	notReadyPod := pod.DeepCopy()
+	notReadyPod.Status.Conditions = nil
+
+	testCases := []struct {
+		description string
+		pod         *corev1.Pod
+		pdbs        []policyv1.PodDisruptionBudget
+		expected    string
+	}{
+		{
+			description: "no PodDisruptionBudget",
+			pod:         pod,
+		},
+		{
+			description: "PodDisruptionBudget allowing disruptions",
+			pod:         pod,
+			pdbs:        []policyv1.PodDisruptionBudget{*newTestPDB("ns", "pdb", map[string]string{"app": "training"}, 1)},
+		},
+		{
+			description: "PodDisruptionBudget selecting other pods",
+			pod:         pod,
+			pdbs:        []policyv1.PodDisruptionBudget{*newTestPDB("ns", "pdb", map[string]string{"app": "inference"}, 0)},
+		},
+		{
+			description: "PodDisruptionBudget of another namespace",
+			pod:         pod,
+			pdbs:        []policyv1.PodDisruptionBudget{*newTestPDB("other", "pdb", map[string]string{"app": "training"}, 0)},
+		},
+		{
+			description: "PodDisruptionBudget not allowing disruptions",
+			pod:         pod,
+			pdbs: []policyv1.PodDisruptionBudget{
+				*newTestPDB("ns", "allowing", map[string]string{"app": "training"}, 1),
+				*newTestPDB("ns", "blocking", map[string]string{"app": "training"}, 0),
+			},
+			expected: "blocking",
+		},
+		{
+			description: "pod not ready",
+			pod:         notReadyPod,
+			pdbs:        []policyv1.PodDisruptionBudget{*newTestPDB("ns", "pdb", map[string]string{"app": "training"}, 0)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			pdb := getBlockingPodDisruptionBudget(tc.pod, tc.pdbs)
+			if tc.expected == "" {
+				require.Nil(t, pdb)
+				return
+			}
+			require.NotNil(t, pdb)
+			require.Equal(t, tc.expected, pdb.Name)
+		})
+	}
+}
+
+func TestScheduleNodesDrain(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}}
+	node.Status.NodeInfo.BootID = "boot-1"
+	drainSpec := &upgrade_v1alpha1.DrainSpec{Enable: true, TimeoutSecond: 1}
+
+	t.Run("pods are evicted except in skipped namespaces", func(t *testing.T) {
+		m, clientset, provider := newTestManager(node.DeepCopy(),
+			newTestPod("workloads", "training", "node", nil),
+			newTestPod("monitoring", "agent", "node", nil))
+		m.SetPolicy(&gpuv1.DriverDrainPolicySpec{SkipNamespaces: []string{"monitoring"}})
+
+		err := m.ScheduleNodesDrain(context.Background(), &upgrade.DrainConfiguration{Spec: drainSpec, Nodes: []*corev1.Node{node}})
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			return provider.getState("node") == upgrade.UpgradeStatePodRestartRequired
+		}, 5*time.Second, 10*time.Millisecond)
+
+		pods, err := clientset.CoreV1().Pods("").List(context.Background(), metav1.ListOptions{})
+		require.NoError(t, err)
+		require.Len(t, pods.Items, 1)
+		require.Equal(t, "monitoring", pods.Items[0].Namespace)
+
+		drained, err := clientset.CoreV1().Nodes().Get(context.Background(), "node", metav1.GetOptions{})
+		require.NoError(t, err)
+		require.True(t, drained.Spec.Unschedulable)
+		require.Equal(t, "drained", drained.Annotations[GetDrainProgressAnnotationKey()])
+	})
+
+	t.Run("blocked drain fails the upgrade", func(t *testing.T) {
+		m, clientset, provider := newTestManager(node.DeepCopy(),
+			newTestPod("workloads", "training", "node", map[string]string{"app": "training"}),
+			newTestPDB("workloads", "training", map[string]string{"app": "training"}, 0))
+
+		err := m.ScheduleNodesDrain(context.Background(), &upgrade.DrainConfiguration{Spec: drainSpec, Nodes: []*corev1.Node{node}})
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			return provider.getState("node") == upgrade.UpgradeStateFailed
+		}, 5*time.Second, 10*time.Millisecond)
+
+		_, err = clientset.CoreV1().Pods("workloads").Get(context.Background(), "training", metav1.GetOptions{})
+		require.NoError(t, err, "the pod protected by the PodDisruptionBudget must not be evicted")
+		failed, err := clientset.CoreV1().Nodes().Get(context.Background(), "node", metav1.GetOptions{})
+		require.NoError(t, err)
+		require.NotContains(t, failed.Labels, GetRebootRequiredLabelKey())
+	})
+
+	t.Run("blocked drain falls back to a reboot", func(t *testing.T) {
+		m, clientset, provider := newTestManager(node.DeepCopy(),
+			newTestPod("workloads", "training", "node", map[string]string{"app": "training"}),
+			newTestPDB("workloads", "training", map[string]string{"app": "training"}, 0))
+		m.SetPolicy(&gpuv1.DriverDrainPolicySpec{FailurePolicy: gpuv1.DrainFailurePolicyRebootRequired})
+
+		err := m.ScheduleNodesDrain(context.Background(), &upgrade.DrainConfiguration{Spec: drainSpec, Nodes: []*corev1.Node{node}})
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			return provider.getState("node") == upgrade.UpgradeStatePodRestartRequired
+		}, 5*time.Second, 10*time.Millisecond)
+
+		labeled, err := clientset.CoreV1().Nodes().Get(context.Background(), "node", metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, "true", labeled.Labels[GetRebootRequiredLabelKey()])
+		require.Equal(t, "reboot-required", labeled.Annotations[GetDrainProgressAnnotationKey()])
+
+		// the label is kept until the node is rebooted
+		require.NoError(t, m.ClearCompletedReboots(context.Background()))
+		labeled, err = clientset.CoreV1().Nodes().Get(context.Background(), "node", metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Contains(t, labeled.Labels, GetRebootRequiredLabelKey())
+
+		labeled.Status.NodeInfo.BootID = "boot-2"
+		_, err = clientset.CoreV1().Nodes().Update(context.Background(), labeled, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		require.NoError(t, m.ClearCompletedReboots(context.Background()))
+		rebooted, err := clientset.CoreV1().Nodes().Get(context.Background(), "node", metav1.GetOptions{})
+		require.NoError(t, err)
+		require.NotContains(t, rebooted.Labels, GetRebootRequiredLabelKey())
+	})
+	t.Run("drain denied by the node mutation policy", func(t *testing.T) {
+		// the nodes of the previous drains are cordoned
+		schedulable := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}}
+		m, clientset, provider := newTestManager(schedulable.DeepCopy(), newTestPod("workloads", "training", "node", nil))
+		m.nodeMutationPolicy = nodepolicy.LabelsOnly
+
+		err := m.ScheduleNodesDrain(context.Background(), &upgrade.DrainConfiguration{Spec: drainSpec, Nodes: []*corev1.Node{schedulable}})
+		require.ErrorIs(t, err, nodepolicy.ErrMutationDenied)
+		require.Empty(t, provider.getState("node"))
+
+		_, err = clientset.CoreV1().Pods("workloads").Get(context.Background(), "training", metav1.GetOptions{})
+		require.NoError(t, err)
+		untouched, err := clientset.CoreV1().Nodes().Get(context.Background(), "node", metav1.GetOptions{})
+		require.NoError(t, err)
+		require.False(t, untouched.Spec.Unschedulable)
+	})
+}
+
+func TestClearCompletedRebootsNodeMutationPolicy(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:        "node",
+		Labels:      map[string]string{"nvidia.com/gpu-driver-upgrade.reboot-required": "true"},
+		Annotations: map[string]string{"nvidia.com/gpu-driver-upgrade.reboot-required.boot-id": "boot-1"},
+	}}
+	node.Status.NodeInfo.BootID = "boot-2"
+
+	m, clientset, _ := newTestManager(node)
+	m.nodeMutationPolicy = nodepolicy.None
+	require.NoError(t, m.ClearCompletedReboots(context.Background()))
+
+	// the node is not written under the none policy
+	for _, action := range clientset.Actions() {
+		require.NotEqual(t, "patch", action.GetVerb())
+	}
+
+	m.nodeMutationPolicy = nodepolicy.LabelsOnly
+	require.NoError(t, m.ClearCompletedReboots(context.Background()))
+	rebooted, err := clientset.CoreV1().Nodes().Get(context.Background(), "node", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotContains(t, rebooted.Labels, GetRebootRequiredLabelKey())
+}
@@ -0,0 +1,168 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package featuregate defines the features of the operator which can be enabled or disabled without code
+// changes, so that experimental features ship disabled until they mature. The gates are set when the operator
+// starts, through the --feature-gates flag or the FEATURE_GATES environment variable, and can be overridden at
+// runtime through the operator ConfigMap.
+package featuregate
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// EnvName is the environment variable setting the feature gates when the operator starts, overridden by the
+// --feature-gates flag
+const EnvName = "FEATURE_GATES"
+
+// Feature is the name of a feature gate
+type Feature string
+
+// Stage is the maturity of a feature
+type Stage string
+
+const (
+	// Alpha features are experimental and disabled by default
+	Alpha Stage = "ALPHA"
+	// Beta features are well tested and usually enabled by default
+	Beta Stage = "BETA"
+	// GA features are stable and always enabled, their gate is kept for compatibility
+	GA Stage = "GA"
+	// Deprecated features are going to be removed
+	Deprecated Stage = "DEPRECATED"
+)
+
+const (
+	// OperandRecreation recreates the operand objects whose rendered changes touch immutable fields. When
+	// disabled, the update errors are reported instead.
+	OperandRecreation Feature = "OperandRecreation"
+	// DRADriver reconciles the GPUCluster deploying the DRA-based stack. When disabled, the GPUCluster operands
+	// are left as they are.
+	DRADriver Feature = "DRADriver"
+	// CDIDefault enables CDI when the ClusterPolicy leaves cdi.enabled unset
+	CDIDefault Feature = "CDIDefault"
+	// KataManagement manages the sandbox workloads running in Kata mode: the Kata device plugin, the CC manager
+	// and the Kata RuntimeClasses. When disabled, they are left to be managed outside of the operator.
+	KataManagement Feature = "KataManagement"
+)
+
+// FeatureSpec describes a feature gate
+type FeatureSpec struct {
+	// Default is whether the feature is enabled when its gate is not set
+	Default bool
+	// Stage is the maturity of the feature
+	Stage Stage
+}
+
+// defaultFeatures lists the known features
+var defaultFeatures = map[Feature]FeatureSpec{
+	OperandRecreation: {Default: true, Stage: Beta},
+	DRADriver:         {Default: true, Stage: Beta},
+	CDIDefault:        {Default: true, Stage: Beta},
+	KataManagement:    {Default: true, Stage: Beta},
+}
+
+// Default holds the feature gates set when the operator starts
+var Default = New()
+
+// Parse parses a comma separated list of feature=bool pairs, e.g. "OperandRecreation=false,KataManagement=true"
+func Parse(value string) (map[Feature]bool, error) {
+	gates := make(map[Feature]bool)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, enabled, ok := strings.Cut(pair, "=")
+		feature := Feature(strings.TrimSpace(name))
+		if !ok || feature == "" {
+			return nil, fmt.Errorf("invalid feature gate %q, expected <feature>=<bool>", pair)
+		}
+		if _, known := defaultFeatures[feature]; !known {
+			return nil, fmt.Errorf("unknown feature %s", feature)
+		}
+		value, err := strconv.ParseBool(strings.TrimSpace(enabled))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for feature %s: %w", feature, err)
+		}
+		gates[feature] = value
+	}
+	return gates, nil
+}
+
+// KnownFeatures returns the description of the known features, sorted by name, e.g.
+// "KataManagement=true|false (BETA - default=true)"
+func KnownFeatures() []string {
+	known := make([]string, 0, len(defaultFeatures))
+	for _, feature := range slices.Sorted(maps.Keys(defaultFeatures)) {
+		spec := defaultFeatures[feature]
+		known = append(known, fmt.Sprintf("%s=true|false (%s - default=%t)", feature, spec.Stage, spec.Default))
+	}
+	return known
+}
+
+// FeatureGate holds the features enabled or disabled, the other features keep their default. It implements
+// flag.Value to be set from the command line.
+type FeatureGate struct {
+	mu      sync.RWMutex
+	enabled map[Feature]bool
+}
+
+// New returns a FeatureGate leaving every feature to its default
+func New() *FeatureGate {
+	return &FeatureGate{enabled: make(map[Feature]bool)}
+}
+
+// Set parses a comma separated list of feature=bool pairs and applies them on top of the current gates
+func (g *FeatureGate) Set(value string) error {
+	gates, err := Parse(value)
+	if err != nil {
+		return err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	maps.Copy(g.enabled, gates)
+	return nil
+}
+
+// String returns the features set, as a comma separated list of feature=bool pairs sorted by feature
+func (g *FeatureGate) String() string {
+	if g == nil {
+		return ""
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	pairs := make([]string, 0, len(g.enabled))
+	for _, feature := range slices.Sorted(maps.Keys(g.enabled)) {
+		pairs = append(pairs, fmt.Sprintf("%s=%t", feature, g.enabled[feature]))
+	}
+	return strings.Join(pairs, ",")
+}
+
+// Enabled returns true if the feature is enabled by its gate, or by default. Unknown features are disabled.
+func (g *FeatureGate) Enabled(feature Feature) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if enabled, ok := g.enabled[feature]; ok {
+		return enabled
+	}
+	return defaultFeatures[feature].Default
+}
@@ -0,0 +1,61 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package featuregate
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	gates, err := Parse(" KataManagement=false, CDIDefault=true,")
+	require.NoError(t, err)
+	require.Equal(t, map[Feature]bool{KataManagement: false, CDIDefault: true}, gates)
+
+	_, err = Parse("Unknown=true")
+	require.Error(t, err)
+	_, err = Parse("KataManagement")
+	require.Error(t, err)
+	_, err = Parse("KataManagement=maybe")
+	require.Error(t, err)
+}
+
+func TestFeatureGate(t *testing.T) {
+	g := New()
+	require.True(t, g.Enabled(OperandRecreation))
+	require.True(t, g.Enabled(KataManagement))
+	require.False(t, g.Enabled("Unknown"))
+
+	// the flag applies on top of the gates already set, e.g. by the environment
+	require.NoError(t, g.Set("KataManagement=false,DRADriver=false"))
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(g, "feature-gates", "")
+	require.NoError(t, fs.Parse([]string{"--feature-gates=DRADriver=true"}))
+	require.False(t, g.Enabled(KataManagement))
+	require.True(t, g.Enabled(DRADriver))
+	require.Equal(t, "DRADriver=true,KataManagement=false", g.String())
+
+	// an invalid value leaves the gates unchanged
+	require.Error(t, g.Set("CDIDefault=false,Unknown=true"))
+	require.True(t, g.Enabled(CDIDefault))
+}
+
+func TestKnownFeatures(t *testing.T) {
+	require.Contains(t, KnownFeatures(), "KataManagement=true|false (BETA - default=true)")
+}
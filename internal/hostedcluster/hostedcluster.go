@@ -0,0 +1,144 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package hostedcluster supports the hosted control plane topologies, e.g. HyperShift or Kamaji, where the
+// operator runs in the management cluster while the GPU nodes and the operands belong to a hosted cluster. The
+// hosted cluster is reached through a kubeconfig stored in a Secret of the operator namespace of the management
+// cluster.
+package hostedcluster
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultKubeconfigKey is the default key of the kubeconfig in the Secret, as written by HyperShift. Kamaji
+	// writes the kubeconfig under the admin.conf key.
+	DefaultKubeconfigKey = "kubeconfig"
+
+	// DefaultSecretCheckInterval is the default interval between two checks of the kubeconfig Secret
+	DefaultSecretCheckInterval = time.Minute
+)
+
+// KubeconfigSecret is the Secret of the management cluster holding the kubeconfig of the hosted cluster
+type KubeconfigSecret struct {
+	Namespace string
+	Name      string
+	Key       string
+}
+
+func (s KubeconfigSecret) String() string {
+	return fmt.Sprintf("%s/%s[%s]", s.Namespace, s.Name, s.Key)
+}
+
+// Kubeconfig returns the kubeconfig held by the Secret
+func (s KubeconfigSecret) Kubeconfig(ctx context.Context, c client.Reader) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: s.Namespace, Name: s.Name}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get the hosted cluster kubeconfig Secret %s: %w", s, err)
+	}
+	kubeconfig, ok := secret.Data[s.Key]
+	if !ok || len(kubeconfig) == 0 {
+		return nil, fmt.Errorf("the hosted cluster kubeconfig Secret %s has no %s key", s, s.Key)
+	}
+	return kubeconfig, nil
+}
+
+// Config returns the client configuration of the hosted cluster, along with the kubeconfig it is built from
+func (s KubeconfigSecret) Config(ctx context.Context, c client.Reader) (*rest.Config, []byte, error) {
+	kubeconfig, err := s.Kubeconfig(ctx, c)
+	if err != nil {
+		return nil, nil, err
+	}
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid hosted cluster kubeconfig in Secret %s: %w", s, err)
+	}
+	return config, kubeconfig, nil
+}
+
+// EnsureNamespace creates the namespace the operands are deployed into in the hosted cluster, unless it exists
+func EnsureNamespace(ctx context.Context, c client.Client, name string) error {
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if err := c.Create(ctx, namespace); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create namespace %s in the hosted cluster: %w", name, err)
+	}
+	return nil
+}
+
+// SecretWatcher stops the operator when the kubeconfig of the hosted cluster changes, e.g. once its credentials
+// are rotated, for the operator to be restarted with the new kubeconfig. It implements the Runnable interface of
+// the controller-runtime manager.
+type SecretWatcher struct {
+	secret     KubeconfigSecret
+	reader     client.Reader
+	kubeconfig []byte
+	interval   time.Duration
+	log        logr.Logger
+}
+
+// NewSecretWatcher returns a SecretWatcher checking the Secret every interval against the kubeconfig the
+// operator was started with
+func NewSecretWatcher(secret KubeconfigSecret, reader client.Reader, kubeconfig []byte, interval time.Duration, log logr.Logger) *SecretWatcher {
+	return &SecretWatcher{secret: secret, reader: reader, kubeconfig: kubeconfig, interval: interval, log: log}
+}
+
+// Start checks the Secret until the context is done, and returns an error once the kubeconfig changed
+func (w *SecretWatcher) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			changed, err := w.changed(ctx)
+			if err != nil {
+				// the operator keeps running with the current kubeconfig while the Secret cannot be read
+				w.log.Error(err, "unable to check the hosted cluster kubeconfig")
+				continue
+			}
+			if changed {
+				return fmt.Errorf("the hosted cluster kubeconfig in Secret %s changed, restarting", w.secret)
+			}
+		}
+	}
+}
+
+// NeedLeaderElection returns false, the kubeconfig is checked by all the operator replicas
+func (w *SecretWatcher) NeedLeaderElection() bool {
+	return false
+}
+
+func (w *SecretWatcher) changed(ctx context.Context) (bool, error) {
+	kubeconfig, err := w.secret.Kubeconfig(ctx, w.reader)
+	if err != nil {
+		return false, err
+	}
+	return !bytes.Equal(kubeconfig, w.kubeconfig), nil
+}
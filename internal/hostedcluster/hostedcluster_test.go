@@ -0,0 +1,127 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package hostedcluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newKubeconfig(t *testing.T, server string) []byte {
+	config := clientcmdapi.NewConfig()
+	config.Clusters["hosted"] = &clientcmdapi.Cluster{Server: server}
+	config.AuthInfos["admin"] = &clientcmdapi.AuthInfo{Token: "token"}
+	config.Contexts["hosted"] = &clientcmdapi.Context{Cluster: "hosted", AuthInfo: "admin"}
+	config.CurrentContext = "hosted"
+	data, err := clientcmd.Write(*config)
+	require.NoError(t, err)
+	return data
+}
+
+func newSecret(data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "hosted-kubeconfig", Namespace: "clusters-gpu"},
+		Data:       data,
+	}
+}
+
+func TestKubeconfigSecretConfig(t *testing.T) {
+	secret := KubeconfigSecret{Namespace: "clusters-gpu", Name: "hosted-kubeconfig", Key: DefaultKubeconfigKey}
+	kubeconfig := newKubeconfig(t, "https://api.hosted.example.com:6443")
+
+	testCases := []struct {
+		description    string
+		objects        []client.Object
+		expectedServer string
+		expectedErr    string
+	}{
+		{
+			description:    "kubeconfig",
+			objects:        []client.Object{newSecret(map[string][]byte{DefaultKubeconfigKey: kubeconfig})},
+			expectedServer: "https://api.hosted.example.com:6443",
+		},
+		{
+			description: "missing secret",
+			expectedErr: "failed to get the hosted cluster kubeconfig Secret clusters-gpu/hosted-kubeconfig[kubeconfig]",
+		},
+		{
+			description: "missing key",
+			objects:     []client.Object{newSecret(map[string][]byte{"admin.conf": kubeconfig})},
+			expectedErr: "the hosted cluster kubeconfig Secret clusters-gpu/hosted-kubeconfig[kubeconfig] has no kubeconfig key",
+		},
+		{
+			description: "invalid kubeconfig",
+			objects:     []client.Object{newSecret(map[string][]byte{DefaultKubeconfigKey: []byte("clusters: [")})},
+			expectedErr: "invalid hosted cluster kubeconfig",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(tc.objects...).Build()
+			config, data, err := secret.Config(context.Background(), c)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedServer, config.Host)
+			require.Equal(t, "token", config.BearerToken)
+			require.Equal(t, kubeconfig, data)
+		})
+	}
+}
+
+func TestEnsureNamespace(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	require.NoError(t, EnsureNamespace(context.Background(), c, "gpu-operator"))
+	// an existing namespace is left as is
+	require.NoError(t, EnsureNamespace(context.Background(), c, "gpu-operator"))
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: "gpu-operator"}, &corev1.Namespace{}))
+}
+
+func TestSecretWatcher(t *testing.T) {
+	secret := KubeconfigSecret{Namespace: "clusters-gpu", Name: "hosted-kubeconfig", Key: DefaultKubeconfigKey}
+	kubeconfig := newKubeconfig(t, "https://api.hosted.example.com:6443")
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).
+		WithObjects(newSecret(map[string][]byte{DefaultKubeconfigKey: kubeconfig})).
+		Build()
+	watcher := NewSecretWatcher(secret, c, kubeconfig, time.Millisecond, logr.Discard())
+	require.False(t, watcher.NeedLeaderElection())
+
+	changed, err := watcher.changed(context.Background())
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	// the operator stops once the credentials are rotated
+	rotated := newSecret(map[string][]byte{DefaultKubeconfigKey: newKubeconfig(t, "https://api.hosted.example.com:443")})
+	require.NoError(t, c.Update(context.Background(), rotated))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.ErrorContains(t, watcher.Start(ctx), "changed, restarting")
+}
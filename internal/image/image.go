@@ -52,3 +52,25 @@ func ImagePath(repository string, image string, version string, imagePathEnvName
 	// 3. If both are not set, error out
 	return "", fmt.Errorf("empty image path provided through both CR and ENV %s", imagePathEnvName)
 }
+
+// MirrorImage rewrites the registry prefix of an image reference with the mirror prefix configured
+// for it. Prefixes match whole path components of the image repository, e.g. "nvcr.io/nvidia"
+// matches "nvcr.io/nvidia/driver:550" but not "nvcr.io/nvidia-cloud/driver:550". When several
+// prefixes match, the longest one is used. The image is returned unchanged when no prefix matches.
+func MirrorImage(image string, mirrors map[string]string) string {
+	var matchedPrefix, matchedMirror string
+	for prefix, mirror := range mirrors {
+		prefix = strings.TrimSuffix(prefix, "/")
+		if prefix == "" || len(prefix) <= len(matchedPrefix) || !strings.HasPrefix(image, prefix) {
+			continue
+		}
+		if rest := image[len(prefix):]; rest != "" && !strings.ContainsAny(rest[:1], "/:@") {
+			continue
+		}
+		matchedPrefix, matchedMirror = prefix, mirror
+	}
+	if matchedPrefix == "" {
+		return image
+	}
+	return strings.TrimSuffix(matchedMirror, "/") + image[len(matchedPrefix):]
+}
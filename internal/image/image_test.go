@@ -0,0 +1,52 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package image
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMirrorImage(t *testing.T) {
+	mirrors := map[string]string{
+		"nvcr.io/nvidia":        "registry.local/nvidia",
+		"nvcr.io/nvidia/k8s":    "registry.local/k8s/",
+		"docker.io/":            "registry.local/docker",
+		"registry.k8s.io/pause": "registry.local/pause",
+	}
+	testCases := []struct {
+		image    string
+		expected string
+	}{
+		{"nvcr.io/nvidia/driver:550.54.15", "registry.local/nvidia/driver:550.54.15"},
+		{"nvcr.io/nvidia/k8s/device-plugin:v0.17.0", "registry.local/k8s/device-plugin:v0.17.0"},
+		{"nvcr.io/nvidia/cloud-native/gpu-operator-validator@" + testDigest, "registry.local/nvidia/cloud-native/gpu-operator-validator@" + testDigest},
+		{"nvcr.io/nvidia-cloud/driver:550", "nvcr.io/nvidia-cloud/driver:550"},
+		{"docker.io/library/busybox:1.36", "registry.local/docker/library/busybox:1.36"},
+		{"registry.k8s.io/pause:3.9", "registry.local/pause:3.9"},
+		{"registry.k8s.io/pause-amd64:3.9", "registry.k8s.io/pause-amd64:3.9"},
+		{"ghcr.io/nvidia/driver:550", "ghcr.io/nvidia/driver:550"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.image, func(t *testing.T) {
+			require.Equal(t, tc.expected, MirrorImage(tc.image, mirrors))
+		})
+	}
+
+	require.Equal(t, "nvcr.io/nvidia/driver:550", MirrorImage("nvcr.io/nvidia/driver:550", nil))
+}
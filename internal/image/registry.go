@@ -0,0 +1,313 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package image
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/regclient/regclient"
+	"github.com/regclient/regclient/config"
+	"github.com/regclient/regclient/scheme/reg"
+	"github.com/regclient/regclient/types/blob"
+	"github.com/regclient/regclient/types/descriptor"
+	"github.com/regclient/regclient/types/errs"
+	"github.com/regclient/regclient/types/manifest"
+	"github.com/regclient/regclient/types/ref"
+	"golang.org/x/time/rate"
+)
+
+// maxCachedRegClients bounds the registry clients cached per set of credentials, the cache is emptied when
+// exceeded, e.g. after many rotations of the image pull secrets
+const maxCachedRegClients = 32
+
+// RegistryOptions defines how the registries are queried by the operator
+type RegistryOptions struct {
+	// RequestsPerSecond limits the rate of the requests sent to each registry, unlimited when zero
+	RequestsPerSecond float64
+	// Burst is the number of requests sent at once to a registry before the rate limit applies
+	Burst int
+	// MaxRetries is the number of times a failed request is retried
+	MaxRetries int
+	// RetryDelay is the delay before the first retry, doubled on each retry up to MaxRetryDelay. The delays
+	// are jittered so that the requests failed at once are not retried at once.
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+	// HTTPProxy, HTTPSProxy and NoProxy configure the proxy of the requests, the proxy environment variables
+	// of the operator are used when none is set
+	HTTPProxy  string
+	HTTPSProxy string
+	NoProxy    string
+	// CABundle is the PEM encoded CA bundle trusted in addition to the system CAs
+	CABundle []byte
+}
+
+// DefaultRegistryOptions returns the options the registries are queried with unless configured otherwise
+func DefaultRegistryOptions() RegistryOptions {
+	return RegistryOptions{
+		RequestsPerSecond: 10,
+		Burst:             10,
+		MaxRetries:        3,
+		RetryDelay:        time.Second,
+		MaxRetryDelay:     30 * time.Second,
+	}
+}
+
+// RegistryClient queries the registries on behalf of the features of the operator, e.g. the digest resolution
+// and the probing of the precompiled driver images. The registry clients, and so the authentication tokens they
+// obtained, are reused across requests with the same credentials. The failed requests are retried with jittered
+// exponential backoff, and the requests are rate limited per registry so that reconcile storms do not hammer
+// the registries.
+type RegistryClient struct {
+	mu       sync.Mutex
+	opts     RegistryOptions
+	clients  map[string]*regclient.RegClient
+	limiters map[string]*rate.Limiter
+
+	// sleep waits for the given duration unless the context is done, overridden in tests
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+var sharedRegistryClient = NewRegistryClient(DefaultRegistryOptions())
+
+// SharedRegistryClient returns the registry client shared by all features of the operator
+func SharedRegistryClient() *RegistryClient {
+	return sharedRegistryClient
+}
+
+// NewRegistryClient returns a registry client querying the registries with the given options
+func NewRegistryClient(opts RegistryOptions) *RegistryClient {
+	return &RegistryClient{
+		opts:     opts,
+		clients:  make(map[string]*regclient.RegClient),
+		limiters: make(map[string]*rate.Limiter),
+		sleep:    sleepContext,
+	}
+}
+
+// Configure updates the options of the registry client. The cached registry clients and rate limiters are
+// dropped when the options change.
+func (c *RegistryClient) Configure(opts RegistryOptions) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if reflect.DeepEqual(c.opts, opts) {
+		return
+	}
+	c.opts = opts
+	c.clients = make(map[string]*regclient.RegClient)
+	c.limiters = make(map[string]*rate.Limiter)
+}
+
+// ManifestHead returns the manifest of the image without its content, with the digest if the registry reports it
+func (c *RegistryClient) ManifestHead(ctx context.Context, r ref.Ref, creds []Credential) (manifest.Manifest, error) {
+	var m manifest.Manifest
+	err := c.do(ctx, r, creds, func(rc *regclient.RegClient) error {
+		var err error
+		m, err = rc.ManifestHead(ctx, r)
+		return err
+	})
+	return m, err
+}
+
+// ManifestGet returns the manifest of the image
+func (c *RegistryClient) ManifestGet(ctx context.Context, r ref.Ref, creds []Credential) (manifest.Manifest, error) {
+	var m manifest.Manifest
+	err := c.do(ctx, r, creds, func(rc *regclient.RegClient) error {
+		var err error
+		m, err = rc.ManifestGet(ctx, r)
+		return err
+	})
+	return m, err
+}
+
+// BlobGet returns a reader of the blob of the repository. The request is retried until the blob is being
+// received, the reader is not.
+func (c *RegistryClient) BlobGet(ctx context.Context, r ref.Ref, d descriptor.Descriptor, creds []Credential) (blob.Reader, error) {
+	var b blob.Reader
+	err := c.do(ctx, r, creds, func(rc *regclient.RegClient) error {
+		var err error
+		b, err = rc.BlobGet(ctx, r, d)
+		return err
+	})
+	return b, err
+}
+
+// do runs the request against the registry of the reference, rate limited and retried on failure
+func (c *RegistryClient) do(ctx context.Context, r ref.Ref, creds []Credential, request func(rc *regclient.RegClient) error) error {
+	rc, limiter, opts := c.get(r.Registry, creds)
+	for attempt := 0; ; attempt++ {
+		if limiter != nil {
+			if err := limiter.Wait(ctx); err != nil {
+				return err
+			}
+		}
+		err := request(rc)
+		if err == nil || attempt >= opts.MaxRetries || !isRetryable(err) {
+			return err
+		}
+		if err := c.sleep(ctx, retryDelay(opts, attempt)); err != nil {
+			return err
+		}
+	}
+}
+
+// get returns the registry client of the credentials, and the rate limiter of the registry
+func (c *RegistryClient) get(registry string, creds []Credential) (*regclient.RegClient, *rate.Limiter, RegistryOptions) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := credentialsKey(creds)
+	rc, ok := c.clients[key]
+	if !ok {
+		if len(c.clients) >= maxCachedRegClients {
+			c.clients = make(map[string]*regclient.RegClient)
+		}
+		rc = newRegClient(creds, c.opts)
+		c.clients[key] = rc
+	}
+
+	limiter, ok := c.limiters[registry]
+	if !ok && c.opts.RequestsPerSecond > 0 {
+		limiter = rate.NewLimiter(rate.Limit(c.opts.RequestsPerSecond), max(c.opts.Burst, 1))
+		c.limiters[registry] = limiter
+	}
+	return rc, limiter, c.opts
+}
+
+// newRegClient returns a registry client authenticating with the given credentials
+func newRegClient(creds []Credential, opts RegistryOptions) *regclient.RegClient {
+	var hosts []config.Host
+	for _, cred := range creds {
+		host := config.HostNewName(cred.Registry)
+		host.User = cred.Username
+		host.Pass = cred.Password
+		if cred.Insecure {
+			host.TLS = config.TLSDisabled
+		}
+		hosts = append(hosts, *host)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxyFunc(opts)
+	regOpts := []reg.Opts{reg.WithTransport(transport)}
+	if len(opts.CABundle) > 0 {
+		regOpts = append(regOpts, reg.WithCerts([][]byte{opts.CABundle}))
+	}
+	// the requests are retried by the RegistryClient, with jitter
+	return regclient.New(regclient.WithConfigHosts(hosts), regclient.WithRegOpts(regOpts...), regclient.WithRetryLimit(1))
+}
+
+// credentialsKey returns the key of the registry client of the credentials, independent of their order
+func credentialsKey(creds []Credential) string {
+	entries := make([]string, 0, len(creds))
+	for _, cred := range creds {
+		entries = append(entries, strings.Join([]string{cred.Registry, cred.Username, cred.Password, strconv.FormatBool(cred.Insecure)}, "\x00"))
+	}
+	slices.Sort(entries)
+	sum := sha256.Sum256([]byte(strings.Join(entries, "\x01")))
+	return hex.EncodeToString(sum[:])
+}
+
+// isRetryable returns true if the request may succeed when retried. The missing images and the rejected
+// credentials are not retried.
+func isRetryable(err error) bool {
+	return !errors.Is(err, errs.ErrNotFound) && !errors.Is(err, errs.ErrHTTPUnauthorized) &&
+		!errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// retryDelay returns the delay before the retry following the given attempt, picked at random between half
+// and the whole of the exponential backoff
+func retryDelay(opts RegistryOptions, attempt int) time.Duration {
+	delay := opts.RetryDelay << min(attempt, 16)
+	if opts.MaxRetryDelay > 0 && (delay > opts.MaxRetryDelay || delay <= 0) {
+		delay = opts.MaxRetryDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// proxyFunc returns the proxy selection of the requests of the options, falling back to the proxy environment
+// variables of the operator
+func proxyFunc(opts RegistryOptions) func(*http.Request) (*url.URL, error) {
+	if opts.HTTPProxy == "" && opts.HTTPSProxy == "" && opts.NoProxy == "" {
+		return http.ProxyFromEnvironment
+	}
+	return func(req *http.Request) (*url.URL, error) {
+		proxy := opts.HTTPProxy
+		if req.URL.Scheme == "https" {
+			proxy = opts.HTTPSProxy
+		}
+		if proxy == "" || isNoProxy(req.URL.Hostname(), opts.NoProxy) {
+			return nil, nil
+		}
+		return url.Parse(proxy)
+	}
+}
+
+// isNoProxy returns true if the host matches an entry of the comma-separated no proxy list: a host name also
+// matching its subdomains, a domain with a leading dot, an IP address, a CIDR, or * matching all hosts
+func isNoProxy(host string, noProxy string) bool {
+	ip := net.ParseIP(host)
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if entry == "*" {
+			return true
+		}
+		if _, cidr, err := net.ParseCIDR(entry); err == nil {
+			if ip != nil && cidr.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if entryHost, _, err := net.SplitHostPort(entry); err == nil {
+			entry = entryHost
+		}
+		domain := strings.TrimPrefix(entry, ".")
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package image
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/regclient/regclient"
+	"github.com/regclient/regclient/types/errs"
+	"github.com/regclient/regclient/types/ref"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRegistryClient(opts RegistryOptions) (*RegistryClient, *[]time.Duration) {
+	var delays []time.Duration
+	c := NewRegistryClient(opts)
+	c.sleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	return c, &delays
+}
+
+func TestRegistryClientRetries(t *testing.T) {
+	opts := DefaultRegistryOptions()
+	opts.RequestsPerSecond = 0
+	c, delays := newTestRegistryClient(opts)
+	r, err := ref.New("nvcr.io/nvidia/driver:570")
+	require.NoError(t, err)
+
+	// the failed requests are retried with jittered exponential backoff
+	calls := 0
+	err = c.do(context.Background(), r, nil, func(_ *regclient.RegClient) error {
+		calls++
+		if calls < 3 {
+			return fmt.Errorf("request failed: %w", errs.ErrHTTPStatus)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, calls)
+	require.Len(t, *delays, 2)
+	require.GreaterOrEqual(t, (*delays)[0], 500*time.Millisecond)
+	require.LessOrEqual(t, (*delays)[0], time.Second)
+	require.GreaterOrEqual(t, (*delays)[1], time.Second)
+	require.LessOrEqual(t, (*delays)[1], 2*time.Second)
+
+	// the retries are bounded
+	calls = 0
+	err = c.do(context.Background(), r, nil, func(_ *regclient.RegClient) error {
+		calls++
+		return errs.ErrHTTPRateLimit
+	})
+	require.ErrorIs(t, err, errs.ErrHTTPRateLimit)
+	require.Equal(t, opts.MaxRetries+1, calls)
+
+	// missing images and rejected credentials are not retried
+	for _, failure := range []error{errs.ErrNotFound, errs.ErrHTTPUnauthorized, context.Canceled} {
+		calls = 0
+		err = c.do(context.Background(), r, nil, func(_ *regclient.RegClient) error {
+			calls++
+			return fmt.Errorf("request failed: %w", failure)
+		})
+		require.True(t, errors.Is(err, failure))
+		require.Equal(t, 1, calls, failure.Error())
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	opts := RegistryOptions{RetryDelay: time.Second, MaxRetryDelay: 5 * time.Second}
+	for attempt, backoff := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		delay := retryDelay(opts, attempt)
+		require.GreaterOrEqual(t, delay, backoff/2)
+		require.LessOrEqual(t, delay, backoff)
+	}
+	require.LessOrEqual(t, retryDelay(opts, 100), 5*time.Second)
+	require.Zero(t, retryDelay(RegistryOptions{}, 0))
+}
+
+func TestRegistryClientCache(t *testing.T) {
+	c, _ := newTestRegistryClient(DefaultRegistryOptions())
+	creds := []Credential{
+		{Registry: "nvcr.io", Username: "$oauthtoken", Password: "token"},
+		{Registry: "docker.io", Username: "user", Password: "password"},
+	}
+
+	// the registry clients are reused for the same credentials, in any order
+	rc, limiter, _ := c.get("nvcr.io", creds)
+	rc2, limiter2, _ := c.get("nvcr.io", []Credential{creds[1], creds[0]})
+	require.Same(t, rc, rc2)
+	require.Same(t, limiter, limiter2)
+
+	// the requests are rate limited per registry
+	rc2, limiter2, _ = c.get("docker.io", creds)
+	require.Same(t, rc, rc2)
+	require.NotSame(t, limiter, limiter2)
+
+	rc2, _, _ = c.get("nvcr.io", creds[:1])
+	require.NotSame(t, rc, rc2)
+
+	// the same options keep the cache, new options drop it
+	c.Configure(DefaultRegistryOptions())
+	rc2, _, _ = c.get("nvcr.io", creds)
+	require.Same(t, rc, rc2)
+
+	opts := DefaultRegistryOptions()
+	opts.HTTPSProxy = "http://proxy.corp.local:3128"
+	c.Configure(opts)
+	rc2, limiter2, _ = c.get("nvcr.io", creds)
+	require.NotSame(t, rc, rc2)
+	require.NotSame(t, limiter, limiter2)
+
+	// the requests are not rate limited without rate
+	opts.RequestsPerSecond = 0
+	c.Configure(opts)
+	_, limiter, _ = c.get("nvcr.io", creds)
+	require.Nil(t, limiter)
+}
+
+func TestProxyFunc(t *testing.T) {
+	proxy := proxyFunc(RegistryOptions{
+		HTTPProxy:  "http://proxy.corp.local:3128",
+		HTTPSProxy: "http://secure-proxy.corp.local:3128",
+		NoProxy:    "registry.local, .corp.local,10.0.0.0/8,mirror.internal:5000",
+	})
+	for target, expected := range map[string]string{
+		"https://nvcr.io/v2/":                "http://secure-proxy.corp.local:3128",
+		"http://nvcr.io/v2/":                 "http://proxy.corp.local:3128",
+		"https://registry.local/v2/":         "",
+		"https://cache.registry.local/v2/":   "",
+		"https://harbor.corp.local/v2/":      "",
+		"https://10.1.2.3:5000/v2/":          "",
+		"https://11.1.2.3/v2/":               "http://secure-proxy.corp.local:3128",
+		"https://mirror.internal:5000/v2/":   "",
+		"https://notregistry.local/v2/":      "http://secure-proxy.corp.local:3128",
+		"https://registry.local.example/v2/": "http://secure-proxy.corp.local:3128",
+	} {
+		req, err := http.NewRequest(http.MethodGet, target, nil)
+		require.NoError(t, err)
+		proxyURL, err := proxy(req)
+		require.NoError(t, err)
+		if expected == "" {
+			require.Nil(t, proxyURL, target)
+			continue
+		}
+		require.Equal(t, expected, proxyURL.String(), target)
+	}
+
+	require.True(t, isNoProxy("nvcr.io", "*"))
+	require.False(t, isNoProxy("nvcr.io", ""))
+}
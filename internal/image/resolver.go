@@ -0,0 +1,200 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package image
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/regclient/regclient/types/errs"
+	"github.com/regclient/regclient/types/manifest"
+	"github.com/regclient/regclient/types/ref"
+)
+
+// DefaultResolveCacheTTL is the duration a resolved digest is reused before the registry is queried again
+const DefaultResolveCacheTTL = 10 * time.Minute
+
+// Credential represents the credentials used to authenticate against a registry. Insecure registries are
+// accessed over plain HTTP.
+type Credential struct {
+	Registry string
+	Username string
+	Password string
+	Insecure bool
+}
+
+// Resolver resolves image references to references pinned to a digest
+type Resolver interface {
+	// Resolve returns the image reference pinned to the digest the image currently resolves to.
+	// Image references already pinned to a digest are returned unchanged.
+	Resolve(ctx context.Context, image string, creds []Credential) (string, error)
+}
+
+// IsDigestReference returns true if the image reference is pinned to a digest
+func IsDigestReference(image string) bool {
+	return strings.Contains(image, "@sha256:")
+}
+
+// IsNotFound returns true if the image could not be resolved because it does not exist in the registry
+func IsNotFound(err error) bool {
+	return errors.Is(err, errs.ErrNotFound)
+}
+
+type registryResolver struct {
+	client *RegistryClient
+}
+
+// NewRegistryResolver returns a Resolver which queries the remote registry for the image digest through the
+// given registry client
+func NewRegistryResolver(client *RegistryClient) Resolver {
+	return &registryResolver{client: client}
+}
+
+func (r *registryResolver) Resolve(ctx context.Context, image string, creds []Credential) (string, error) {
+	if IsDigestReference(image) {
+		return image, nil
+	}
+
+	imageRef, err := ref.New(image)
+	if err != nil {
+		return "", fmt.Errorf("failed to construct an image reference for %s: %w", image, err)
+	}
+
+	// a HEAD request is sufficient for most registries, fall back to a GET
+	// if the registry does not return the digest in the HEAD response
+	m, err := r.client.ManifestHead(ctx, imageRef, creds)
+	if err != nil || manifest.GetDigest(m) == "" {
+		m, err = r.client.ManifestGet(ctx, imageRef, creds)
+		if err != nil {
+			return "", fmt.Errorf("failed to get image manifest for %s: %w", image, err)
+		}
+	}
+
+	digest := manifest.GetDigest(m)
+	if digest == "" {
+		return "", fmt.Errorf("registry did not return a digest for %s", image)
+	}
+	return imageRef.AddDigest(digest.String()).CommonName(), nil
+}
+
+type cacheEntry struct {
+	image   string
+	expires time.Time
+}
+
+type cachingResolver struct {
+	resolver Resolver
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+// NewCachingResolver returns a Resolver which reuses digests resolved by the given
+// resolver for the provided ttl, avoiding registry requests on every reconciliation
+func NewCachingResolver(resolver Resolver, ttl time.Duration) Resolver {
+	return &cachingResolver{
+		resolver: resolver,
+		ttl:      ttl,
+		now:      time.Now,
+		entries:  make(map[string]cacheEntry),
+	}
+}
+
+func (c *cachingResolver) Resolve(ctx context.Context, image string, creds []Credential) (string, error) {
+	if IsDigestReference(image) {
+		return image, nil
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[image]
+	c.mu.Unlock()
+	if ok && c.now().Before(entry.expires) {
+		return entry.image, nil
+	}
+
+	resolved, err := c.resolver.Resolve(ctx, image, creds)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	c.entries[image] = cacheEntry{image: resolved, expires: c.now().Add(c.ttl)}
+	c.mu.Unlock()
+	return resolved, nil
+}
+
+type dockerConfigJSON struct {
+	Auths map[string]dockerConfigEntry `json:"auths"`
+}
+
+type dockerConfigEntry struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Auth     string `json:"auth,omitempty"`
+}
+
+// CredentialsFromDockerConfigJSON returns the registry credentials contained in the
+// content of a kubernetes.io/dockerconfigjson Secret
+func CredentialsFromDockerConfigJSON(data []byte) ([]Credential, error) {
+	cfg := dockerConfigJSON{}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse docker config json: %w", err)
+	}
+
+	var creds []Credential
+	for registry, entry := range cfg.Auths {
+		cred := Credential{
+			Registry: normalizeRegistry(registry),
+			Username: entry.Username,
+			Password: entry.Password,
+		}
+		if entry.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode auth for registry %s: %w", registry, err)
+			}
+			username, password, found := strings.Cut(string(decoded), ":")
+			if !found {
+				return nil, fmt.Errorf("invalid auth for registry %s", registry)
+			}
+			cred.Username = username
+			cred.Password = password
+		}
+		creds = append(creds, cred)
+	}
+	return creds, nil
+}
+
+// normalizeRegistry strips the scheme and path from a docker config registry key,
+// e.g. https://index.docker.io/v1/ is normalized to docker.io
+func normalizeRegistry(registry string) string {
+	registry = strings.TrimPrefix(registry, "https://")
+	registry = strings.TrimPrefix(registry, "http://")
+	registry, _, _ = strings.Cut(registry, "/")
+	if registry == "index.docker.io" {
+		return "docker.io"
+	}
+	return registry
+}
@@ -0,0 +1,85 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package image
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+type fakeResolver struct {
+	calls int
+}
+
+func (f *fakeResolver) Resolve(_ context.Context, image string, _ []Credential) (string, error) {
+	f.calls++
+	return image + "@" + testDigest, nil
+}
+
+func TestCachingResolver(t *testing.T) {
+	fake := &fakeResolver{}
+	resolver := NewCachingResolver(fake, time.Minute).(*cachingResolver)
+	now := time.Now()
+	resolver.now = func() time.Time { return now }
+
+	resolved, err := resolver.Resolve(context.Background(), "nvcr.io/nvidia/driver:570", nil)
+	require.NoError(t, err)
+	require.Equal(t, "nvcr.io/nvidia/driver:570@"+testDigest, resolved)
+	require.Equal(t, 1, fake.calls)
+
+	_, err = resolver.Resolve(context.Background(), "nvcr.io/nvidia/driver:570", nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, fake.calls, "expected cached digest to be reused")
+
+	now = now.Add(2 * time.Minute)
+	_, err = resolver.Resolve(context.Background(), "nvcr.io/nvidia/driver:570", nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, fake.calls, "expected expired digest to be resolved again")
+
+	pinned := "nvcr.io/nvidia/driver@" + testDigest
+	resolved, err = resolver.Resolve(context.Background(), pinned, nil)
+	require.NoError(t, err)
+	require.Equal(t, pinned, resolved)
+	require.Equal(t, 2, fake.calls, "expected pinned image not to be resolved")
+}
+
+func TestCredentialsFromDockerConfigJSON(t *testing.T) {
+	auth := base64.StdEncoding.EncodeToString([]byte("user:pa:ss"))
+	data := []byte(`{"auths":{"https://index.docker.io/v1/":{"auth":"` + auth + `"},"nvcr.io":{"username":"$oauthtoken","password":"token"}}}`)
+
+	creds, err := CredentialsFromDockerConfigJSON(data)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Credential{
+		{Registry: "docker.io", Username: "user", Password: "pa:ss"},
+		{Registry: "nvcr.io", Username: "$oauthtoken", Password: "token"},
+	}, creds)
+
+	_, err = CredentialsFromDockerConfigJSON([]byte(`{"auths":{"nvcr.io":{"auth":"not-base64!"}}}`))
+	require.Error(t, err)
+}
+
+func TestIsDigestReference(t *testing.T) {
+	require.True(t, IsDigestReference("nvcr.io/nvidia/driver@"+testDigest))
+	require.True(t, IsDigestReference("nvcr.io/nvidia/driver:570@"+testDigest))
+	require.False(t, IsDigestReference("nvcr.io/nvidia/driver:570"))
+}
@@ -0,0 +1,200 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package introspection exposes the effective configuration of the operands, as rendered by the controllers
+// after the ClusterPolicy, the node inventory and the defaults were resolved, so that the images, arguments,
+// environment and labels deployed on a group of nodes can be explained without reading the DaemonSets.
+package introspection
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// NodeGroup is the effective configuration of the operands deployed on the nodes of a ClusterPolicy
+type NodeGroup struct {
+	// Name is the name of the ClusterPolicy managing the nodes of the group
+	Name string `json:"name"`
+	// Workloads lists the operand DaemonSets rendered for the nodes of the group, sorted by name
+	Workloads []Workload `json:"workloads"`
+}
+
+// Workload is the effective configuration of an operand DaemonSet
+type Workload struct {
+	// Name is the name of the DaemonSet
+	Name string `json:"name"`
+	// State is the operator state deploying the DaemonSet
+	State string `json:"state"`
+	// RenderedAt is the time the DaemonSet was last rendered by the operator
+	RenderedAt time.Time `json:"renderedAt"`
+	// Labels are the labels of the DaemonSet
+	Labels map[string]string `json:"labels,omitempty"`
+	// PodLabels are the labels of the pods of the DaemonSet
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+	// NodeSelector selects the nodes the pods of the DaemonSet run on
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// InitContainers and Containers are the containers of the pods of the DaemonSet
+	InitContainers []Container `json:"initContainers,omitempty"`
+	Containers     []Container `json:"containers"`
+}
+
+// Container is the effective configuration of an operand container
+type Container struct {
+	Name    string   `json:"name"`
+	Image   string   `json:"image"`
+	Command []string `json:"command,omitempty"`
+	Args    []string `json:"args,omitempty"`
+	// Env lists the environment variables of the container. The variables set from a ConfigMap, a Secret or
+	// a field reference only report their source, so that the secrets are not exposed.
+	Env []EnvVar `json:"env,omitempty"`
+}
+
+// EnvVar is an environment variable of an operand container
+type EnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value,omitempty"`
+	// ValueFrom describes the source of the value when it is not set inline, e.g. "secretKeyRef:name/key"
+	ValueFrom string `json:"valueFrom,omitempty"`
+}
+
+// Store holds the effective configuration of the operands rendered by the controllers. A nil Store records
+// nothing, so that the controllers do not depend on the introspection endpoint being enabled.
+type Store struct {
+	mu     sync.RWMutex
+	groups map[string]map[string]Workload
+	now    func() time.Time
+}
+
+// NewStore returns an empty Store
+func NewStore() *Store {
+	return &Store{
+		groups: make(map[string]map[string]Workload),
+		now:    time.Now,
+	}
+}
+
+// Record records the DaemonSet rendered by the given state for the nodes of the given group
+func (s *Store) Record(group string, state string, ds *appsv1.DaemonSet) {
+	if s == nil {
+		return
+	}
+	workload := Workload{
+		Name:           ds.Name,
+		State:          state,
+		RenderedAt:     s.now().UTC(),
+		Labels:         copyMap(ds.Labels),
+		PodLabels:      copyMap(ds.Spec.Template.Labels),
+		NodeSelector:   copyMap(ds.Spec.Template.Spec.NodeSelector),
+		InitContainers: newContainers(ds.Spec.Template.Spec.InitContainers),
+		Containers:     newContainers(ds.Spec.Template.Spec.Containers),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.groups[group] == nil {
+		s.groups[group] = make(map[string]Workload)
+	}
+	s.groups[group][ds.Name] = workload
+}
+
+// Forget removes the DaemonSets of the given state from the group, once the state is disabled
+func (s *Store) Forget(group string, state string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for name, workload := range s.groups[group] {
+		if workload.State == state {
+			delete(s.groups[group], name)
+		}
+	}
+	if len(s.groups[group]) == 0 {
+		delete(s.groups, group)
+	}
+}
+
+// NodeGroups returns the effective configuration of every group, sorted by name
+func (s *Store) NodeGroups() []NodeGroup {
+	if s == nil {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	groups := make([]NodeGroup, 0, len(s.groups))
+	for name, workloads := range s.groups {
+		group := NodeGroup{Name: name, Workloads: make([]Workload, 0, len(workloads))}
+		for _, workload := range workloads {
+			group.Workloads = append(group.Workloads, workload)
+		}
+		sort.Slice(group.Workloads, func(i, j int) bool { return group.Workloads[i].Name < group.Workloads[j].Name })
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups
+}
+
+func newContainers(containers []corev1.Container) []Container {
+	if len(containers) == 0 {
+		return nil
+	}
+	result := make([]Container, 0, len(containers))
+	for _, c := range containers {
+		container := Container{
+			Name:    c.Name,
+			Image:   c.Image,
+			Command: append([]string(nil), c.Command...),
+			Args:    append([]string(nil), c.Args...),
+		}
+		for _, env := range c.Env {
+			container.Env = append(container.Env, EnvVar{Name: env.Name, Value: env.Value, ValueFrom: describeEnvSource(env.ValueFrom)})
+		}
+		result = append(result, container)
+	}
+	return result
+}
+
+// describeEnvSource describes the source of an environment variable without resolving its value
+func describeEnvSource(source *corev1.EnvVarSource) string {
+	switch {
+	case source == nil:
+		return ""
+	case source.SecretKeyRef != nil:
+		return "secretKeyRef:" + source.SecretKeyRef.Name + "/" + source.SecretKeyRef.Key
+	case source.ConfigMapKeyRef != nil:
+		return "configMapKeyRef:" + source.ConfigMapKeyRef.Name + "/" + source.ConfigMapKeyRef.Key
+	case source.FieldRef != nil:
+		return "fieldRef:" + source.FieldRef.FieldPath
+	case source.ResourceFieldRef != nil:
+		return "resourceFieldRef:" + source.ResourceFieldRef.Resource
+	}
+	return "unknown"
+}
+
+func copyMap(m map[string]string) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	return result
+}
@@ -0,0 +1,184 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package introspection
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newDaemonSet(name string, image string) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"app": name}},
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": name}},
+				Spec: corev1.PodSpec{
+					NodeSelector: map[string]string{"nvidia.com/gpu.deploy.driver": "true"},
+					Containers: []corev1.Container{{
+						Name:  "ctr",
+						Image: image,
+						Args:  []string{"init"},
+						Env: []corev1.EnvVar{
+							{Name: "DEBUG", Value: "true"},
+							{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "creds"},
+									Key:                  "token",
+								},
+							}},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func TestStore(t *testing.T) {
+	store := NewStore()
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	store.Record("cluster-policy", "state-driver", newDaemonSet("nvidia-driver-daemonset", "driver:580"))
+	store.Record("cluster-policy", "state-device-plugin", newDaemonSet("nvidia-device-plugin-daemonset", "device-plugin:0.18"))
+	store.Record("a100-pool", "state-driver", newDaemonSet("nvidia-driver-daemonset-a100-pool", "driver:570"))
+	// a new rendering replaces the previous one
+	store.Record("cluster-policy", "state-driver", newDaemonSet("nvidia-driver-daemonset", "driver:590"))
+
+	groups := store.NodeGroups()
+	require.Len(t, groups, 2)
+	require.Equal(t, "a100-pool", groups[0].Name)
+	require.Equal(t, "cluster-policy", groups[1].Name)
+	require.Len(t, groups[1].Workloads, 2)
+
+	driver := groups[1].Workloads[1]
+	require.Equal(t, Workload{
+		Name:         "nvidia-driver-daemonset",
+		State:        "state-driver",
+		RenderedAt:   now,
+		Labels:       map[string]string{"app": "nvidia-driver-daemonset"},
+		PodLabels:    map[string]string{"app": "nvidia-driver-daemonset"},
+		NodeSelector: map[string]string{"nvidia.com/gpu.deploy.driver": "true"},
+		Containers: []Container{{
+			Name:  "ctr",
+			Image: "driver:590",
+			Args:  []string{"init"},
+			Env: []EnvVar{
+				{Name: "DEBUG", Value: "true"},
+				{Name: "TOKEN", ValueFrom: "secretKeyRef:creds/token"},
+			},
+		}},
+	}, driver)
+
+	store.Forget("cluster-policy", "state-driver")
+	store.Forget("a100-pool", "state-driver")
+	groups = store.NodeGroups()
+	require.Len(t, groups, 1)
+	require.Len(t, groups[0].Workloads, 1)
+	require.Equal(t, "nvidia-device-plugin-daemonset", groups[0].Workloads[0].Name)
+
+	var nilStore *Store
+	nilStore.Record("cluster-policy", "state-driver", newDaemonSet("nvidia-driver-daemonset", "driver:580"))
+	nilStore.Forget("cluster-policy", "state-driver")
+	require.Empty(t, nilStore.NodeGroups())
+}
+
+func TestNewServer(t *testing.T) {
+	for _, addr := range []string{"127.0.0.1:8082", "localhost:8082", "[::1]:8082"} {
+		_, err := NewServer(addr, "", NewStore(), logr.Discard())
+		require.NoError(t, err, addr)
+	}
+	_, err := NewServer(":8082", "", NewStore(), logr.Discard())
+	require.Error(t, err)
+	_, err = NewServer("0.0.0.0:8082", "", NewStore(), logr.Discard())
+	require.Error(t, err)
+	_, err = NewServer(":8082", "secret", NewStore(), logr.Discard())
+	require.NoError(t, err)
+}
+
+func TestServeConfig(t *testing.T) {
+	store := NewStore()
+	store.Record("cluster-policy", "state-driver", newDaemonSet("nvidia-driver-daemonset", "driver:580"))
+	store.Record("a100-pool", "state-driver", newDaemonSet("nvidia-driver-daemonset-a100-pool", "driver:570"))
+	server, err := NewServer(":8082", "secret", store, logr.Discard())
+	require.NoError(t, err)
+	handler := server.Handler()
+
+	get := func(target string, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	require.Equal(t, http.StatusUnauthorized, get(ConfigPath, "").Code)
+	require.Equal(t, http.StatusUnauthorized, get(ConfigPath, "wrong").Code)
+
+	rec := get(ConfigPath, "secret")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var response struct {
+		NodeGroups []NodeGroup `json:"nodeGroups"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Len(t, response.NodeGroups, 2)
+
+	rec = get(ConfigPath+"?group=a100-pool", "secret")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Len(t, response.NodeGroups, 1)
+	require.Equal(t, "driver:570", response.NodeGroups[0].Workloads[0].Containers[0].Image)
+
+	require.Equal(t, http.StatusNotFound, get(ConfigPath+"?group=unknown", "secret").Code)
+
+	req := httptest.NewRequest(http.MethodPost, ConfigPath, nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestServerHandle(t *testing.T) {
+	server, err := NewServer(":8082", "secret", NewStore(), logr.Discard())
+	require.NoError(t, err)
+	server.Handle("/extra", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	handler := server.Handler()
+
+	req := httptest.NewRequest(http.MethodGet, "/extra", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+}
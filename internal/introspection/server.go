@@ -0,0 +1,155 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package introspection
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// ConfigPath is the path of the endpoint returning the effective configuration of the node groups. The
+// configuration of a single group is returned when the group query parameter is set.
+const ConfigPath = "/config"
+
+// Server serves the effective configuration recorded in a Store. It implements the Runnable interface of the
+// controller-runtime manager.
+type Server struct {
+	addr  string
+	token string
+	store *Store
+	log   logr.Logger
+	// handlers are the additional endpoints served with the effective configuration, keyed by path
+	handlers map[string]http.Handler
+}
+
+// NewServer returns a Server listening on addr. When token is empty, the server only accepts to listen on a
+// loopback address, otherwise the requests must carry the token as a bearer token.
+func NewServer(addr string, token string, store *Store, log logr.Logger) (*Server, error) {
+	if token == "" {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid introspection address %q: %w", addr, err)
+		}
+		ip := net.ParseIP(host)
+		if host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			return nil, fmt.Errorf("introspection address %q is not a loopback address, a token is required", addr)
+		}
+	}
+	return &Server{addr: addr, token: token, store: store, log: log}, nil
+}
+
+// Handler returns the handler of the introspection endpoint
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(ConfigPath, s.serveConfig)
+	for path, handler := range s.handlers {
+		mux.Handle(path, s.requireAuthorization(handler))
+	}
+	return mux
+}
+
+// Handle adds an endpoint served with the effective configuration, requiring the same authorization. It must be
+// called before the server is started.
+func (s *Server) Handle(path string, handler http.Handler) {
+	if s.handlers == nil {
+		s.handlers = make(map[string]http.Handler)
+	}
+	s.handlers[path] = handler
+}
+
+// Start serves the introspection endpoint until the context is canceled
+func (s *Server) Start(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:              s.addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			s.log.Error(err, "failed to shut down the introspection server")
+		}
+	}()
+
+	s.log.Info("serving the effective configuration", "address", s.addr, "path", ConfigPath)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *Server) serveConfig(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	groups := s.store.NodeGroups()
+	if name := r.URL.Query().Get("group"); name != "" {
+		filtered := []NodeGroup{}
+		for _, group := range groups {
+			if group.Name == name {
+				filtered = append(filtered, group)
+			}
+		}
+		if len(filtered) == 0 {
+			http.Error(w, fmt.Sprintf("node group %q not found", name), http.StatusNotFound)
+			return
+		}
+		groups = filtered
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{"nodeGroups": groups}); err != nil {
+		s.log.Error(err, "failed to write the effective configuration")
+	}
+}
+
+// requireAuthorization rejects the requests not carrying the token of the server, if any
+func (s *Server) requireAuthorization(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.authorized(r) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// authorized returns true if the request carries the token of the server, if any
+func (s *Server) authorized(r *http.Request) bool {
+	if s.token == "" {
+		return true
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
@@ -0,0 +1,96 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package kernel detects the flavor of the kernel of the nodes, e.g. realtime or cloud kernels, which the driver
+// build environment needs to install the matching kernel headers.
+package kernel
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Flavor is the flavor of a kernel
+type Flavor string
+
+const (
+	// FlavorGeneric is the flavor of the default kernels, e.g. Ubuntu -generic or SLES -default kernels
+	FlavorGeneric Flavor = "generic"
+	// FlavorRealtime is the flavor of the PREEMPT_RT kernels
+	FlavorRealtime Flavor = "realtime"
+	// FlavorLowLatency is the flavor of the Ubuntu low latency kernels
+	FlavorLowLatency Flavor = "lowlatency"
+	// FlavorAWS is the flavor of the kernels tuned for AWS
+	FlavorAWS Flavor = "aws"
+	// FlavorAzure is the flavor of the kernels tuned for Azure
+	FlavorAzure Flavor = "azure"
+	// FlavorGCP is the flavor of the kernels tuned for Google Cloud
+	FlavorGCP Flavor = "gcp"
+	// FlavorOracle is the flavor of the kernels tuned for Oracle Cloud
+	FlavorOracle Flavor = "oracle"
+)
+
+var (
+	// realtimePattern matches the realtime kernels of Ubuntu (6.8.1-1015-realtime), RHEL
+	// (5.14.0-284.30.1.rt14.315.el9_2.x86_64) and SLES (5.14.21-150500.13-rt)
+	realtimePattern = regexp.MustCompile(`-realtime(-\d+k)?$|[.-]rt(\d+)?([.-]|$)`)
+	// flavorSuffixPattern matches the flavor suffix of the kernels, optionally followed by the page size,
+	// e.g. 6.8.0-1015-aws or 6.8.0-1010-lowlatency-64k
+	flavorSuffixPattern = regexp.MustCompile(`-(lowlatency|aws|azure|gcp|oracle)(-\d+k)?$`)
+)
+
+// NonGenericFlavors lists the flavors other than generic
+func NonGenericFlavors() []Flavor {
+	return []Flavor{FlavorRealtime, FlavorLowLatency, FlavorAWS, FlavorAzure, FlavorGCP, FlavorOracle}
+}
+
+// GetFlavor returns the flavor of the kernel of the given version, as reported by uname -r. The kernels
+// without a known flavor are generic.
+func GetFlavor(kernelVersion string) Flavor {
+	version := strings.ToLower(kernelVersion)
+	if realtimePattern.MatchString(version) {
+		return FlavorRealtime
+	}
+	if match := flavorSuffixPattern.FindStringSubmatch(version); match != nil {
+		return Flavor(match[1])
+	}
+	return FlavorGeneric
+}
+
+// GetHeadersPackages returns the names of the packages providing the headers of the kernels of the flavor on
+// the given distribution, to be suffixed with the kernel version by the driver build environment. Nil is
+// returned for the distributions whose kernel headers packages do not depend on the flavor.
+func GetHeadersPackages(osID string, flavor Flavor) []string {
+	switch osID {
+	case "ubuntu", "debian":
+		// the version of the Ubuntu kernels includes the flavor, e.g. linux-headers-6.8.1-1015-realtime
+		return []string{"linux-headers"}
+	case "rhel", "rocky", "centos", "almalinux", "ol":
+		if flavor == FlavorRealtime {
+			return []string{"kernel-rt-devel", "kernel-rt-core"}
+		}
+		return []string{"kernel-devel", "kernel-headers"}
+	case "sles", "sl-micro":
+		switch flavor {
+		case FlavorRealtime:
+			return []string{"kernel-rt-devel"}
+		case FlavorAzure:
+			return []string{"kernel-azure-devel"}
+		}
+		return []string{"kernel-default-devel"}
+	}
+	return nil
+}
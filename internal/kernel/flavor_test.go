@@ -0,0 +1,101 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package kernel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFlavor(t *testing.T) {
+	tests := []struct {
+		kernelVersion string
+		expected      Flavor
+	}{
+		{kernelVersion: "6.8.0-60-generic", expected: FlavorGeneric},
+		{kernelVersion: "5.14.0-427.13.1.el9_4.x86_64", expected: FlavorGeneric},
+		{kernelVersion: "5.14.21-150500.55.65-default", expected: FlavorGeneric},
+		{kernelVersion: "6.8.1-1015-realtime", expected: FlavorRealtime},
+		{kernelVersion: "6.8.1-1015-realtime-64k", expected: FlavorRealtime},
+		{kernelVersion: "5.14.0-284.30.1.rt14.315.el9_2.x86_64", expected: FlavorRealtime},
+		{kernelVersion: "5.14.21-150500.13-rt", expected: FlavorRealtime},
+		{kernelVersion: "6.8.0-1010-lowlatency-64k", expected: FlavorLowLatency},
+		{kernelVersion: "6.8.0-1015-aws", expected: FlavorAWS},
+		{kernelVersion: "6.8.0-1021-Azure", expected: FlavorAzure},
+		{kernelVersion: "6.8.0-1019-gcp", expected: FlavorGCP},
+		{kernelVersion: "6.8.0-1013-oracle", expected: FlavorOracle},
+		{kernelVersion: "", expected: FlavorGeneric},
+	}
+
+	for _, test := range tests {
+		t.Run(test.kernelVersion, func(t *testing.T) {
+			require.Equal(t, test.expected, GetFlavor(test.kernelVersion))
+		})
+	}
+}
+
+func TestGetHeadersPackages(t *testing.T) {
+	tests := []struct {
+		description string
+		osID        string
+		flavor      Flavor
+		expected    []string
+	}{
+		{
+			description: "ubuntu realtime",
+			osID:        "ubuntu",
+			flavor:      FlavorRealtime,
+			expected:    []string{"linux-headers"},
+		},
+		{
+			description: "rhel realtime",
+			osID:        "rhel",
+			flavor:      FlavorRealtime,
+			expected:    []string{"kernel-rt-devel", "kernel-rt-core"},
+		},
+		{
+			description: "rocky generic",
+			osID:        "rocky",
+			flavor:      FlavorGeneric,
+			expected:    []string{"kernel-devel", "kernel-headers"},
+		},
+		{
+			description: "sles realtime",
+			osID:        "sles",
+			flavor:      FlavorRealtime,
+			expected:    []string{"kernel-rt-devel"},
+		},
+		{
+			description: "sles azure",
+			osID:        "sles",
+			flavor:      FlavorAzure,
+			expected:    []string{"kernel-azure-devel"},
+		},
+		{
+			description: "unknown distribution",
+			osID:        "talos",
+			flavor:      FlavorRealtime,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			require.Equal(t, test.expected, GetHeadersPackages(test.osID, test.flavor))
+		})
+	}
+}
@@ -0,0 +1,188 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package nodepolicy constrains the fields of the nodes the operator mutates, for clusters where the node objects
+// are owned by external tooling, e.g. infrastructure-as-code, reporting unexpected mutations as drift. The features
+// requiring a forbidden mutation are disabled, and the forbidden mutations left in the requests of the other
+// features are dropped.
+package nodepolicy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Policy is the set of node fields the operator is allowed to mutate
+type Policy string
+
+const (
+	// None forbids any mutation of the nodes
+	None Policy = "none"
+	// LabelsOnly allows setting the labels and the annotations of the nodes, but neither tainting nor cordoning them
+	LabelsOnly Policy = "labels-only"
+	// LabelsAndTaints allows all the node mutations of the operator: the labels, the annotations, the taints and
+	// the cordoning of the nodes
+	LabelsAndTaints Policy = "labels-and-taints"
+)
+
+// EnvName is the name of the environment variable passing the node mutation policy to the operands mutating their
+// node
+const EnvName = "NODE_MUTATION_POLICY"
+
+// ErrMutationDenied is returned for the node mutations which cannot be restricted to the fields allowed by the policy
+var ErrMutationDenied = errors.New("node mutation denied by the node mutation policy")
+
+// Parse parses a node mutation policy
+func Parse(value string) (Policy, error) {
+	switch policy := Policy(value); policy {
+	case None, LabelsOnly, LabelsAndTaints:
+		return policy, nil
+	}
+	return "", fmt.Errorf("invalid node mutation policy %q, expected one of %s, %s or %s", value, LabelsAndTaints, LabelsOnly, None)
+}
+
+// AllowsMetadata returns true if the policy allows setting the labels and the annotations of the nodes
+func (p Policy) AllowsMetadata() bool {
+	return p != None
+}
+
+// AllowsSpec returns true if the policy allows tainting and cordoning the nodes. All mutations are allowed when
+// no policy is set.
+func (p Policy) AllowsSpec() bool {
+	return p == "" || p == LabelsAndTaints
+}
+
+// DisabledFeatures returns the features of the operator disabled by the policy
+func (p Policy) DisabledFeatures() []string {
+	var features []string
+	if !p.AllowsMetadata() {
+		features = append(features, "node labeling (the GPU nodes must be labeled externally)")
+	}
+	if !p.AllowsSpec() {
+		features = append(features, "automatic driver upgrades", "GPU health taints", "bootstrap taint removal")
+	}
+	return features
+}
+
+// nodeClient drops the node mutations forbidden by the policy
+type nodeClient struct {
+	client.Client
+	policy Policy
+}
+
+// NewClient returns a client restricting the node patches made through the given client to the fields allowed by
+// the policy. The given client is returned when the policy allows all mutations.
+func NewClient(c client.Client, policy Policy) client.Client {
+	if policy.AllowsSpec() {
+		return c
+	}
+	return &nodeClient{Client: c, policy: policy}
+}
+
+// Update denies the updates of the nodes, which replace all the fields of the node
+func (c *nodeClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if _, ok := obj.(*corev1.Node); ok {
+		return fmt.Errorf("%w: update of node %s", ErrMutationDenied, obj.GetName())
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+// Patch removes the fields forbidden by the policy from the merge patches of the nodes, and skips the patches left
+// empty. The other patch types of the nodes are denied.
+func (c *nodeClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if _, ok := obj.(*corev1.Node); !ok {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	}
+	if patch.Type() != types.MergePatchType {
+		return fmt.Errorf("%w: %s patch of node %s", ErrMutationDenied, patch.Type(), obj.GetName())
+	}
+	data, err := patch.Data(obj)
+	if err != nil {
+		return err
+	}
+	allowed, dropped, err := RestrictPatch(c.policy, data)
+	if err != nil {
+		return err
+	}
+	if len(dropped) > 0 {
+		log.FromContext(ctx).V(1).Info("Dropped node fields forbidden by the node mutation policy",
+			"node", obj.GetName(), "policy", c.policy, "fields", dropped)
+	}
+	if allowed == nil {
+		return nil
+	}
+	return c.Client.Patch(ctx, obj, client.RawPatch(types.MergePatchType, allowed), opts...)
+}
+
+// RestrictPatch returns the merge patch of a node restricted to the fields allowed by the policy, or nil if no field
+// is allowed, along with the fields dropped from the patch. The patch is returned as is when the policy allows all
+// mutations.
+func RestrictPatch(policy Policy, data []byte) ([]byte, []string, error) {
+	if policy.AllowsSpec() {
+		return data, nil, nil
+	}
+
+	var patch map[string]interface{}
+	if err := json.Unmarshal(data, &patch); err != nil {
+		return nil, nil, fmt.Errorf("error decoding the node patch: %w", err)
+	}
+
+	var dropped []string
+	allowed := map[string]interface{}{}
+	for field, value := range patch {
+		if field != "metadata" {
+			dropped = append(dropped, field)
+			continue
+		}
+		metadata, ok := value.(map[string]interface{})
+		if !ok {
+			dropped = append(dropped, field)
+			continue
+		}
+		allowedMetadata := map[string]interface{}{}
+		for metadataField, metadataValue := range metadata {
+			switch metadataField {
+			case "labels", "annotations":
+				if policy.AllowsMetadata() {
+					allowedMetadata[metadataField] = metadataValue
+					continue
+				}
+			case "resourceVersion":
+				// the precondition of the optimistic lock is kept with the allowed fields
+				continue
+			}
+			dropped = append(dropped, "metadata."+metadataField)
+		}
+		if len(allowedMetadata) > 0 {
+			if resourceVersion, ok := metadata["resourceVersion"]; ok {
+				allowedMetadata["resourceVersion"] = resourceVersion
+			}
+			allowed["metadata"] = allowedMetadata
+		}
+	}
+	if len(allowed) == 0 {
+		return nil, dropped, nil
+	}
+	data, err := json.Marshal(allowed)
+	return data, dropped, err
+}
@@ -0,0 +1,113 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nodepolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParse(t *testing.T) {
+	for _, value := range []string{"none", "labels-only", "labels-and-taints"} {
+		policy, err := Parse(value)
+		require.NoError(t, err)
+		require.Equal(t, Policy(value), policy)
+	}
+	_, err := Parse("taints-only")
+	require.Error(t, err)
+}
+
+func TestNewClient(t *testing.T) {
+	newNode := func() *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "gpu-node", Labels: map[string]string{"a": "b"}}}
+	}
+	taint := corev1.Taint{Key: "nvidia.com/gpu.unhealthy", Effect: corev1.TaintEffectNoSchedule}
+
+	t.Run("all mutations allowed", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithObjects(newNode()).Build()
+		require.Equal(t, c, NewClient(c, LabelsAndTaints))
+		require.Equal(t, c, NewClient(c, ""))
+	})
+
+	testCases := []struct {
+		policy         Policy
+		expectedLabels map[string]string
+	}{
+		{policy: LabelsOnly, expectedLabels: map[string]string{"a": "b", "nvidia.com/gpu.present": "true"}},
+		{policy: None, expectedLabels: map[string]string{"a": "b"}},
+	}
+	for _, tc := range testCases {
+		t.Run(string(tc.policy), func(t *testing.T) {
+			ctx := context.Background()
+			fakeClient := fake.NewClientBuilder().WithObjects(newNode()).Build()
+			c := NewClient(fakeClient, tc.policy)
+
+			node := &corev1.Node{}
+			require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "gpu-node"}, node))
+			patch := client.MergeFrom(node.DeepCopy())
+			node.Labels["nvidia.com/gpu.present"] = "true"
+			node.Spec.Taints = append(node.Spec.Taints, taint)
+			node.Spec.Unschedulable = true
+			require.NoError(t, c.Patch(ctx, node, patch))
+
+			updated := &corev1.Node{}
+			require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: "gpu-node"}, updated))
+			require.Equal(t, tc.expectedLabels, updated.Labels)
+			require.Empty(t, updated.Spec.Taints)
+			require.False(t, updated.Spec.Unschedulable)
+
+			// patches of the other objects are not restricted
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default"}}
+			require.NoError(t, c.Create(ctx, pod))
+			podPatch := client.MergeFrom(pod.DeepCopy())
+			pod.Spec.NodeName = "gpu-node"
+			require.NoError(t, c.Patch(ctx, pod, podPatch))
+
+			require.ErrorIs(t, c.Update(ctx, updated), ErrMutationDenied)
+			require.ErrorIs(t, c.Patch(ctx, updated, client.RawPatch(types.StrategicMergePatchType, []byte("{}"))), ErrMutationDenied)
+		})
+	}
+}
+
+func TestRestrictPatch(t *testing.T) {
+	data := []byte(`{"metadata":{"labels":{"a":"b"},"resourceVersion":"1"},"spec":{"unschedulable":true},"status":{}}`)
+
+	allowed, dropped, err := RestrictPatch(LabelsAndTaints, data)
+	require.NoError(t, err)
+	require.Equal(t, data, allowed)
+	require.Empty(t, dropped)
+
+	allowed, dropped, err = RestrictPatch(LabelsOnly, data)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"metadata":{"labels":{"a":"b"},"resourceVersion":"1"}}`, string(allowed))
+	require.ElementsMatch(t, []string{"spec", "status"}, dropped)
+
+	allowed, dropped, err = RestrictPatch(None, data)
+	require.NoError(t, err)
+	require.Nil(t, allowed)
+	require.ElementsMatch(t, []string{"metadata.labels", "spec", "status"}, dropped)
+
+	_, _, err = RestrictPatch(None, []byte("not json"))
+	require.Error(t, err)
+}
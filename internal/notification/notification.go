@@ -0,0 +1,183 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package notification posts the lifecycle events of the GPU nodes, e.g. a driver upgrade, as JSON to the HTTP
+// sinks of the ClusterPolicy, so that external inventory and incident management systems stay in sync without
+// polling the cluster. The events are posted asynchronously and never block nor fail a reconciliation.
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+const (
+	// queueSize is the number of events waiting to be posted, the events notified while the queue is full are
+	// dropped
+	queueSize = 1024
+	// maxAttempts is the number of times an event is posted to a sink before it is dropped
+	maxAttempts = 3
+	// retryDelay is the delay before the second attempt to post an event, doubled at each attempt
+	retryDelay = 2 * time.Second
+)
+
+// Event is a lifecycle event of a GPU node, posted as the JSON body of the requests to the sinks
+type Event struct {
+	Type gpuv1.NodeLifecycleEventType `json:"type"`
+	Time time.Time                    `json:"time"`
+	// ClusterPolicy is the name of the ClusterPolicy managing the node
+	ClusterPolicy string `json:"clusterPolicy,omitempty"`
+	Node          string `json:"node"`
+	Message       string `json:"message"`
+	// DriverVersion is the version of the driver installed on the node, if known
+	DriverVersion string `json:"driverVersion,omitempty"`
+	// PreviousDriverVersion is the version of the driver installed on the node before a driver upgrade
+	PreviousDriverVersion string `json:"previousDriverVersion,omitempty"`
+	// Reason is the cause of a failure or of a quarantine, if known
+	Reason string `json:"reason,omitempty"`
+}
+
+type delivery struct {
+	sink  gpuv1.NotificationSinkSpec
+	event Event
+}
+
+// Notifier posts the events to the sinks from a background loop. A nil Notifier drops the events.
+type Notifier struct {
+	log logr.Logger
+	// reader reads the Secrets holding the headers of the sinks
+	reader     client.Reader
+	namespace  string
+	httpClient *http.Client
+	queue      chan delivery
+}
+
+// NewNotifier returns a Notifier reading the header Secrets of the sinks from the given namespace. The events
+// are only posted once the Notifier is started.
+func NewNotifier(log logr.Logger, reader client.Reader, namespace string) *Notifier {
+	return &Notifier{
+		log:        log,
+		reader:     reader,
+		namespace:  namespace,
+		httpClient: &http.Client{},
+		queue:      make(chan delivery, queueSize),
+	}
+}
+
+// Notify queues the event for the sinks accepting its type without blocking
+func (n *Notifier) Notify(sinks []gpuv1.NotificationSinkSpec, event Event) {
+	if n == nil {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+	for _, sink := range sinks {
+		if !sink.Accepts(event.Type) {
+			continue
+		}
+		select {
+		case n.queue <- delivery{sink: sink, event: event}:
+		default:
+			n.log.Info("notification queue full, dropping the event", "sink", sink.Name, "type", event.Type,
+				"node", event.Node)
+		}
+	}
+}
+
+// Start posts the queued events until the context is done
+func (n *Notifier) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case d := <-n.queue:
+			n.deliver(ctx, d)
+		}
+	}
+}
+
+// deliver posts an event to a sink, retrying with an exponential backoff. The event is dropped once the
+// attempts are exhausted.
+func (n *Notifier) deliver(ctx context.Context, d delivery) {
+	delay := retryDelay
+	for attempt := 1; ; attempt++ {
+		err := n.post(ctx, d.sink, d.event)
+		if err == nil {
+			return
+		}
+		if attempt == maxAttempts {
+			n.log.Error(err, "failed to post the event", "sink", d.sink.Name, "type", d.event.Type,
+				"node", d.event.Node)
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// post posts an event to a sink, with the headers held by the Secret of the sink
+func (n *Notifier) post(ctx context.Context, sink gpuv1.NotificationSinkSpec, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, sink.GetTimeout())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sink.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if sink.HeadersSecretName != "" {
+		secret := &corev1.Secret{}
+		key := types.NamespacedName{Namespace: n.namespace, Name: sink.HeadersSecretName}
+		if err := n.reader.Get(ctx, key, secret); err != nil {
+			return fmt.Errorf("unable to get the headers Secret %s: %w", sink.HeadersSecretName, err)
+		}
+		for name, value := range secret.Data {
+			req.Header.Set(name, string(value))
+		}
+	}
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return nil
+}
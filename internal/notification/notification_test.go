@@ -0,0 +1,78 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+type request struct {
+	authorization string
+	event         Event
+}
+
+func TestNotifier(t *testing.T) {
+	requests := make(chan request, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event Event
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		requests <- request{authorization: r.Header.Get("Authorization"), event: event}
+	}))
+	defer server.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cmdb-credentials", Namespace: "gpu-operator"},
+		Data:       map[string][]byte{"Authorization": []byte("Bearer token")},
+	}
+	notifier := NewNotifier(logr.Discard(), fake.NewClientBuilder().WithObjects(secret).Build(), "gpu-operator")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = notifier.Start(ctx) }()
+
+	sinks := []gpuv1.NotificationSinkSpec{
+		{Name: "cmdb", URL: server.URL, Events: []gpuv1.NodeLifecycleEventType{gpuv1.DriverUpgradedEvent}, HeadersSecretName: "cmdb-credentials"},
+		{Name: "incidents", URL: server.URL + "/incidents", Events: []gpuv1.NodeLifecycleEventType{gpuv1.GPUQuarantinedEvent}},
+	}
+	notifier.Notify(sinks, Event{Type: gpuv1.DriverUpgradedEvent, Node: "node-a", DriverVersion: "570.86.15"})
+
+	received := <-requests
+	require.Equal(t, "Bearer token", received.authorization)
+	require.Equal(t, gpuv1.DriverUpgradedEvent, received.event.Type)
+	require.Equal(t, "node-a", received.event.Node)
+	require.Equal(t, "570.86.15", received.event.DriverVersion)
+	require.False(t, received.event.Time.IsZero())
+	// the sink of the quarantines does not accept the event
+	require.Empty(t, requests)
+}
+
+func TestNilNotifier(t *testing.T) {
+	var notifier *Notifier
+	notifier.Notify([]gpuv1.NotificationSinkSpec{{Name: "cmdb", URL: "http://localhost"}}, Event{Type: gpuv1.GPUStackReadyEvent})
+}
@@ -0,0 +1,237 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package operatorconfig holds the operator settings that can be changed at runtime through the operator
+// ConfigMap, on top of the command line flags. A setting absent from the ConfigMap keeps the value of its flag.
+package operatorconfig
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/NVIDIA/gpu-operator/internal/featuregate"
+)
+
+const (
+	// DefaultConfigMapName is the default name of the operator ConfigMap in the operator namespace
+	DefaultConfigMapName = "gpu-operator-config"
+
+	// LogLevelKey sets the verbosity of the operator logs, one of debug, info, error or a positive integer
+	LogLevelKey = "logLevel"
+	// RequeueIntervalKey sets the delay before the ClusterPolicy is reconciled again while operands are not ready
+	RequeueIntervalKey = "requeueInterval"
+	// StateTimeoutsKey sets the timeouts bounding the reconciliation of states, as <state>=<duration> pairs
+	StateTimeoutsKey = "stateTimeouts"
+	// FeatureGatesKey enables or disables features, as <feature>=<bool> pairs
+	FeatureGatesKey = "featureGates"
+)
+
+// Config is the operator configuration read from the operator ConfigMap. Zero values leave the settings of the
+// command line flags unchanged.
+type Config struct {
+	// LogLevel is the verbosity of the operator logs
+	LogLevel *zapcore.Level
+	// RequeueInterval is the delay before the ClusterPolicy is reconciled again while operands are not ready
+	RequeueInterval time.Duration
+	// StateTimeouts bounds the duration of the reconciliation of each state, keyed by state name
+	StateTimeouts map[string]time.Duration
+	// FeatureGates enables or disables features, overriding the gates set when the operator started
+	FeatureGates map[featuregate.Feature]bool
+}
+
+// Parse validates the data of the operator ConfigMap and returns the configuration it holds
+func Parse(data map[string]string) (Config, error) {
+	config := Config{}
+	for key, value := range data {
+		value = strings.TrimSpace(value)
+		var err error
+		switch key {
+		case LogLevelKey:
+			var level zapcore.Level
+			level, err = ParseLogLevel(value)
+			config.LogLevel = &level
+		case RequeueIntervalKey:
+			config.RequeueInterval, err = time.ParseDuration(value)
+			if err == nil && config.RequeueInterval <= 0 {
+				err = fmt.Errorf("must be positive")
+			}
+		case StateTimeoutsKey:
+			config.StateTimeouts, err = ParseStateTimeouts(value)
+		case FeatureGatesKey:
+			config.FeatureGates, err = featuregate.Parse(value)
+		default:
+			err = fmt.Errorf("unknown setting")
+		}
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid %s: %w", key, err)
+		}
+	}
+	return config, nil
+}
+
+// ParseLogLevel parses a log level the same way as the --zap-log-level flag: debug, info, error, panic or a
+// positive integer for custom debug levels of increasing verbosity
+func ParseLogLevel(value string) (zapcore.Level, error) {
+	switch strings.ToLower(value) {
+	case "debug":
+		return zapcore.DebugLevel, nil
+	case "info":
+		return zapcore.InfoLevel, nil
+	case "error":
+		return zapcore.ErrorLevel, nil
+	case "panic":
+		return zapcore.PanicLevel, nil
+	}
+	verbosity, err := strconv.Atoi(value)
+	if err != nil || verbosity <= 0 || verbosity > 127 {
+		return 0, fmt.Errorf("%q is not one of debug, info, error, panic or a positive integer", value)
+	}
+	return zapcore.Level(int8(-verbosity)), nil
+}
+
+// ParseStateTimeouts parses a comma separated list of state=duration pairs,
+// e.g. "state-driver=10m,state-container-toolkit=2m"
+func ParseStateTimeouts(value string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		state, duration, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(state) == "" {
+			return nil, fmt.Errorf("invalid state timeout %q, expected <state>=<duration>", pair)
+		}
+		timeout, err := time.ParseDuration(strings.TrimSpace(duration))
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout for state %s: %w", state, err)
+		}
+		if timeout <= 0 {
+			return nil, fmt.Errorf("invalid timeout for state %s: must be positive", state)
+		}
+		timeouts[strings.TrimSpace(state)] = timeout
+	}
+	return timeouts, nil
+}
+
+// Store holds the operator configuration applied at runtime. A nil Store holds no configuration, so that the
+// controllers keep the settings of the command line flags when the operator ConfigMap is not watched.
+type Store struct {
+	mu     sync.RWMutex
+	config Config
+
+	// logLevel is the level of the operator logger, initialLevel is its level set by the command line flags
+	logLevel     *zap.AtomicLevel
+	initialLevel zapcore.Level
+}
+
+// NewStore returns a Store applying the log level of the configuration to the given logger level, which is
+// left unchanged when nil
+func NewStore(logLevel *zap.AtomicLevel) *Store {
+	s := &Store{logLevel: logLevel}
+	if logLevel != nil {
+		s.initialLevel = logLevel.Level()
+	}
+	return s
+}
+
+// Apply validates the data of the operator ConfigMap and applies the configuration it holds. The settings
+// missing from the data are reverted to their command line flags. It returns the settings that changed, or an
+// error leaving the current configuration unchanged if the data is invalid.
+func (s *Store) Apply(data map[string]string) ([]string, error) {
+	config, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var changed []string
+	if !reflect.DeepEqual(s.config.LogLevel, config.LogLevel) {
+		changed = append(changed, LogLevelKey)
+	}
+	if s.config.RequeueInterval != config.RequeueInterval {
+		changed = append(changed, RequeueIntervalKey)
+	}
+	if !maps.Equal(s.config.StateTimeouts, config.StateTimeouts) {
+		changed = append(changed, StateTimeoutsKey)
+	}
+	if !maps.Equal(s.config.FeatureGates, config.FeatureGates) {
+		changed = append(changed, FeatureGatesKey)
+	}
+	sort.Strings(changed)
+
+	s.config = config
+	if s.logLevel != nil {
+		if config.LogLevel != nil {
+			s.logLevel.SetLevel(*config.LogLevel)
+		} else {
+			s.logLevel.SetLevel(s.initialLevel)
+		}
+	}
+	return changed, nil
+}
+
+// RequeueInterval returns the requeue interval set by the operator ConfigMap, or zero if not set
+func (s *Store) RequeueInterval() time.Duration {
+	if s == nil {
+		return 0
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.config.RequeueInterval
+}
+
+// StateTimeouts returns the given state timeouts overridden by the ones set by the operator ConfigMap
+func (s *Store) StateTimeouts(timeouts map[string]time.Duration) map[string]time.Duration {
+	if s == nil {
+		return timeouts
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.config.StateTimeouts) == 0 {
+		return timeouts
+	}
+	merged := maps.Clone(timeouts)
+	if merged == nil {
+		merged = make(map[string]time.Duration, len(s.config.StateTimeouts))
+	}
+	maps.Copy(merged, s.config.StateTimeouts)
+	return merged
+}
+
+// FeatureEnabled returns true if the feature is enabled by the operator ConfigMap, or else by the feature gates
+// set when the operator started
+func (s *Store) FeatureEnabled(feature featuregate.Feature) bool {
+	if s != nil {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		if enabled, ok := s.config.FeatureGates[feature]; ok {
+			return enabled
+		}
+	}
+	return featuregate.Default.Enabled(feature)
+}
@@ -0,0 +1,141 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package operatorconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"k8s.io/utils/ptr"
+
+	"github.com/NVIDIA/gpu-operator/internal/featuregate"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		description string
+		data        map[string]string
+		expected    Config
+		expectError bool
+	}{
+		{
+			description: "empty",
+			expected:    Config{},
+		},
+		{
+			description: "all settings",
+			data: map[string]string{
+				LogLevelKey:        "3",
+				RequeueIntervalKey: "30s",
+				StateTimeoutsKey:   "state-driver=10m",
+				FeatureGatesKey:    "OperandRecreation=false",
+			},
+			expected: Config{
+				LogLevel:        ptr.To(zapcore.Level(-3)),
+				RequeueInterval: 30 * time.Second,
+				StateTimeouts:   map[string]time.Duration{"state-driver": 10 * time.Minute},
+				FeatureGates:    map[featuregate.Feature]bool{featuregate.OperandRecreation: false},
+			},
+		},
+		{
+			description: "unknown setting",
+			data:        map[string]string{"requeueIntervall": "30s"},
+			expectError: true,
+		},
+		{
+			description: "invalid log level",
+			data:        map[string]string{LogLevelKey: "verbose"},
+			expectError: true,
+		},
+		{
+			description: "negative requeue interval",
+			data:        map[string]string{RequeueIntervalKey: "-1s"},
+			expectError: true,
+		},
+		{
+			description: "unknown feature",
+			data:        map[string]string{FeatureGatesKey: "Unknown=true"},
+			expectError: true,
+		},
+		{
+			description: "invalid feature gate value",
+			data:        map[string]string{FeatureGatesKey: "OperandRecreation=maybe"},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			config, err := Parse(tc.data)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, config)
+		})
+	}
+}
+
+func TestStoreApply(t *testing.T) {
+	level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	s := NewStore(&level)
+	flagTimeouts := map[string]time.Duration{"state-driver": time.Minute, "state-container-toolkit": time.Minute}
+
+	changed, err := s.Apply(map[string]string{
+		LogLevelKey:        "debug",
+		RequeueIntervalKey: "1m",
+		StateTimeoutsKey:   "state-driver=10m",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{LogLevelKey, RequeueIntervalKey, StateTimeoutsKey}, changed)
+	require.Equal(t, zapcore.DebugLevel, level.Level())
+	require.Equal(t, time.Minute, s.RequeueInterval())
+	require.Equal(t, map[string]time.Duration{"state-driver": 10 * time.Minute, "state-container-toolkit": time.Minute}, s.StateTimeouts(flagTimeouts))
+	require.True(t, s.FeatureEnabled(featuregate.OperandRecreation))
+
+	// an invalid configuration leaves the current one unchanged
+	_, err = s.Apply(map[string]string{LogLevelKey: "verbose"})
+	require.Error(t, err)
+	require.Equal(t, zapcore.DebugLevel, level.Level())
+	require.Equal(t, time.Minute, s.RequeueInterval())
+
+	changed, err = s.Apply(map[string]string{FeatureGatesKey: "OperandRecreation=false"})
+	require.NoError(t, err)
+	require.Equal(t, []string{FeatureGatesKey, LogLevelKey, RequeueIntervalKey, StateTimeoutsKey}, changed)
+	require.False(t, s.FeatureEnabled(featuregate.OperandRecreation))
+	// the settings absent from the ConfigMap revert to their flags
+	require.Equal(t, zapcore.InfoLevel, level.Level())
+	require.Zero(t, s.RequeueInterval())
+	require.Equal(t, flagTimeouts, s.StateTimeouts(flagTimeouts))
+
+	changed, err = s.Apply(map[string]string{FeatureGatesKey: "OperandRecreation=false"})
+	require.NoError(t, err)
+	require.Empty(t, changed)
+}
+
+func TestNilStore(t *testing.T) {
+	var s *Store
+	flagTimeouts := map[string]time.Duration{"state-driver": time.Minute}
+	require.Zero(t, s.RequeueInterval())
+	require.Equal(t, flagTimeouts, s.StateTimeouts(flagTimeouts))
+	require.True(t, s.FeatureEnabled(featuregate.OperandRecreation))
+	require.False(t, s.FeatureEnabled("Unknown"))
+}
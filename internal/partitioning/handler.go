@@ -0,0 +1,74 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package partitioning
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PreviewPath is the path of the endpoint previewing a partitioning of the GPUs of the cluster. The partitioning
+// is set by either the mig or the vgpu query parameter, e.g. ?mig=3g.40gb=1,2g.20gb=2 or ?vgpu=A100-10C=4.
+const PreviewPath = "/partitioning"
+
+type handler struct {
+	reader client.Reader
+	log    logr.Logger
+}
+
+// NewHandler returns the handler of the preview endpoint, reading the GPU nodes of the cluster from reader
+func NewHandler(reader client.Reader, log logr.Logger) http.Handler {
+	return &handler{reader: reader, log: log}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	if query.Has(string(MIG)) == query.Has(string(VGPU)) {
+		http.Error(w, "exactly one of the mig and vgpu query parameters is required", http.StatusBadRequest)
+		return
+	}
+	kind := MIG
+	if query.Has(string(VGPU)) {
+		kind = VGPU
+	}
+	request, err := ParseRequest(kind, query.Get(string(kind)))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := h.reader.List(r.Context(), nodes, client.HasLabels{gpuProductLabelKey}); err != nil {
+		h.log.Error(err, "failed to list the GPU nodes")
+		http.Error(w, "failed to list the GPU nodes", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{"models": Preview(nodes.Items, request)}); err != nil {
+		h.log.Error(err, "failed to write the partitioning preview")
+	}
+}
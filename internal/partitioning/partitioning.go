@@ -0,0 +1,325 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package partitioning previews the devices resulting from a MIG or vGPU configuration on each GPU model of the
+// cluster, computed from capability tables embedded in the operator, so that configurations can be evaluated
+// before they are applied.
+package partitioning
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	gpuProductLabelKey = "nvidia.com/gpu.product"
+	gpuCountLabelKey   = "nvidia.com/gpu.count"
+
+	migResourcePrefix = "nvidia.com/mig-"
+	// allPrefix requests the maximum number of instances of a MIG profile, as in the mig-parted configurations
+	allPrefix = "all-"
+)
+
+//go:embed capabilities.yaml
+var capabilitiesYAML []byte
+
+// capabilities holds the partitioning capabilities of the known GPU models, keyed by product
+var capabilities = mustLoadCapabilities(capabilitiesYAML)
+
+// GPUModel describes the partitioning capabilities of a GPU model
+type GPUModel struct {
+	// Products are the values of the nvidia.com/gpu.product label of the model
+	Products []string `json:"products"`
+	// MemoryGB is the memory of the GPU
+	MemoryGB int `json:"memoryGB"`
+	// MIG describes the MIG capabilities of the model, nil if MIG is not supported
+	MIG *MIGCapabilities `json:"mig,omitempty"`
+	// VGPUTypePrefix prefixes the names of the vGPU types of the model, vGPU is not supported when empty
+	VGPUTypePrefix string `json:"vgpuTypePrefix,omitempty"`
+	// VGPUFramebuffersGB lists the framebuffer sizes of the vGPU types of the model
+	VGPUFramebuffersGB []int `json:"vgpuFramebuffersGB,omitempty"`
+}
+
+// MIGCapabilities describes the slices of a MIG capable GPU and the profiles partitioning them
+type MIGCapabilities struct {
+	ComputeSlices int          `json:"computeSlices"`
+	MemorySlices  int          `json:"memorySlices"`
+	Profiles      []MIGProfile `json:"profiles"`
+}
+
+// MIGProfile describes the slices consumed by the devices of a MIG profile
+type MIGProfile struct {
+	Name          string `json:"name"`
+	ComputeSlices int    `json:"computeSlices"`
+	MemorySlices  int    `json:"memorySlices"`
+	MaxInstances  int    `json:"maxInstances"`
+}
+
+func mustLoadCapabilities(data []byte) map[string]*GPUModel {
+	var models []*GPUModel
+	if err := yaml.Unmarshal(data, &models); err != nil {
+		panic(fmt.Sprintf("invalid GPU capabilities: %v", err))
+	}
+	byProduct := make(map[string]*GPUModel)
+	for _, model := range models {
+		for _, product := range model.Products {
+			byProduct[product] = model
+		}
+	}
+	return byProduct
+}
+
+// lookupModel returns the capabilities of a product, ignoring the suffixes GPU Feature Discovery appends to the
+// product of shared or MIG partitioned GPUs
+func lookupModel(product string) *GPUModel {
+	if model, ok := capabilities[product]; ok {
+		return model
+	}
+	for _, suffix := range []string{"-SHARED", "-MIG-"} {
+		if i := strings.Index(product, suffix); i > 0 {
+			if model, ok := capabilities[product[:i]]; ok {
+				return model
+			}
+		}
+	}
+	return nil
+}
+
+// Kind is the kind of partitioning previewed
+type Kind string
+
+const (
+	// MIG partitions the GPUs into MIG devices
+	MIG Kind = "mig"
+	// VGPU partitions the GPUs into time-sliced vGPUs
+	VGPU Kind = "vgpu"
+)
+
+// Request is a partitioning of the GPUs to preview
+type Request struct {
+	Kind Kind
+	// Devices maps the MIG profiles or vGPU types to the number of devices of each GPU. A negative count
+	// requests the maximum number of devices of the profile.
+	Devices map[string]int
+}
+
+// ParseRequest parses a comma separated list of <profile>=<count> pairs, or all-<profile> items requesting the
+// maximum number of devices of a profile, e.g. "3g.40gb=1,2g.20gb=2" or "all-1g.10gb"
+func ParseRequest(kind Kind, value string) (Request, error) {
+	request := Request{Kind: kind, Devices: make(map[string]int)}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, count, ok := strings.Cut(item, "=")
+		name = strings.TrimSpace(name)
+		if !ok {
+			profile, all := strings.CutPrefix(item, allPrefix)
+			if !all || profile == "" {
+				return Request{}, fmt.Errorf("invalid device %q, expected <name>=<count> or %s<name>", item, allPrefix)
+			}
+			request.Devices[profile] = -1
+			continue
+		}
+		if name == "" {
+			return Request{}, fmt.Errorf("invalid device %q, expected <name>=<count>", item)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(count))
+		if err != nil || n <= 0 {
+			return Request{}, fmt.Errorf("invalid count for %s, expected a positive integer", name)
+		}
+		request.Devices[name] += n
+	}
+	if len(request.Devices) == 0 {
+		return Request{}, fmt.Errorf("no device requested")
+	}
+	return request, nil
+}
+
+// ModelPreview is the result of a partitioning on the GPUs of a model present in the cluster
+type ModelPreview struct {
+	// Product is the value of the nvidia.com/gpu.product label of the GPUs
+	Product string `json:"product"`
+	// Nodes and GPUs count the nodes and the GPUs of the model
+	Nodes int `json:"nodes"`
+	GPUs  int `json:"gpus"`
+	// Error explains why the partitioning cannot be applied to the model, the devices are not set when not empty
+	Error string `json:"error,omitempty"`
+	// Devices lists the devices of each kind resulting from the partitioning, sorted by name
+	Devices []DevicePreview `json:"devices,omitempty"`
+	// UnusedComputeSlices and UnusedMemorySlices count the slices of each GPU not used by the MIG devices
+	UnusedComputeSlices int `json:"unusedComputeSlices,omitempty"`
+	UnusedMemorySlices  int `json:"unusedMemorySlices,omitempty"`
+}
+
+// DevicePreview counts the devices of a MIG profile or a vGPU type
+type DevicePreview struct {
+	// Name is the MIG profile or the vGPU type
+	Name string `json:"name"`
+	// Resource is the extended resource advertising the MIG devices with the mixed MIG strategy
+	Resource string `json:"resource,omitempty"`
+	// MemoryGB is the memory of each device
+	MemoryGB int `json:"memoryGB"`
+	// PerGPU is the number of devices of each GPU, Total the number of devices of all the GPUs of the model
+	PerGPU int `json:"perGPU"`
+	Total  int `json:"total"`
+}
+
+// Preview returns the devices resulting from the partitioning on each GPU model of the nodes, sorted by product
+func Preview(nodes []corev1.Node, request Request) []ModelPreview {
+	inventory := make(map[string]*ModelPreview)
+	for _, node := range nodes {
+		product := node.Labels[gpuProductLabelKey]
+		if product == "" {
+			continue
+		}
+		count, err := strconv.Atoi(node.Labels[gpuCountLabelKey])
+		if err != nil || count < 0 {
+			count = 0
+		}
+		preview, ok := inventory[product]
+		if !ok {
+			preview = &ModelPreview{Product: product}
+			inventory[product] = preview
+		}
+		preview.Nodes++
+		preview.GPUs += count
+	}
+
+	previews := make([]ModelPreview, 0, len(inventory))
+	for _, preview := range inventory {
+		model := lookupModel(preview.Product)
+		var err error
+		switch {
+		case model == nil:
+			err = fmt.Errorf("the partitioning capabilities of the GPU model are not known")
+		case request.Kind == MIG:
+			err = previewMIG(model, request, preview)
+		case request.Kind == VGPU:
+			err = previewVGPU(model, request, preview)
+		default:
+			err = fmt.Errorf("unknown partitioning %q", request.Kind)
+		}
+		if err != nil {
+			preview.Error = err.Error()
+			preview.Devices = nil
+			preview.UnusedComputeSlices, preview.UnusedMemorySlices = 0, 0
+		}
+		previews = append(previews, *preview)
+	}
+	sort.Slice(previews, func(i, j int) bool { return previews[i].Product < previews[j].Product })
+	return previews
+}
+
+func previewMIG(model *GPUModel, request Request, preview *ModelPreview) error {
+	if model.MIG == nil {
+		return fmt.Errorf("MIG is not supported by the GPU model")
+	}
+	profiles := make(map[string]MIGProfile, len(model.MIG.Profiles))
+	for _, profile := range model.MIG.Profiles {
+		profiles[profile.Name] = profile
+	}
+
+	computeSlices, memorySlices := 0, 0
+	for _, name := range sortedNames(request.Devices) {
+		profile, ok := profiles[name]
+		if !ok {
+			return fmt.Errorf("MIG profile %s is not supported by the GPU model", name)
+		}
+		count := request.Devices[name]
+		if count < 0 {
+			count = profile.MaxInstances
+		}
+		if count > profile.MaxInstances {
+			return fmt.Errorf("a GPU holds at most %d %s MIG devices, %d requested", profile.MaxInstances, name, count)
+		}
+		computeSlices += count * profile.ComputeSlices
+		memorySlices += count * profile.MemorySlices
+		preview.Devices = append(preview.Devices, DevicePreview{
+			Name:     name,
+			Resource: migResourcePrefix + name,
+			MemoryGB: profileMemoryGB(name),
+			PerGPU:   count,
+			Total:    count * preview.GPUs,
+		})
+	}
+	if computeSlices > model.MIG.ComputeSlices {
+		return fmt.Errorf("the MIG devices require %d compute slices, a GPU has %d", computeSlices, model.MIG.ComputeSlices)
+	}
+	if memorySlices > model.MIG.MemorySlices {
+		return fmt.Errorf("the MIG devices require %d memory slices, a GPU has %d", memorySlices, model.MIG.MemorySlices)
+	}
+	preview.UnusedComputeSlices = model.MIG.ComputeSlices - computeSlices
+	preview.UnusedMemorySlices = model.MIG.MemorySlices - memorySlices
+	return nil
+}
+
+func previewVGPU(model *GPUModel, request Request, preview *ModelPreview) error {
+	if model.VGPUTypePrefix == "" {
+		return fmt.Errorf("vGPU is not supported by the GPU model")
+	}
+	if len(request.Devices) > 1 {
+		return fmt.Errorf("the time-sliced vGPUs of a GPU must be of a single type")
+	}
+	for name, count := range request.Devices {
+		framebuffer := 0
+		for _, fb := range model.VGPUFramebuffersGB {
+			if name == fmt.Sprintf("%s-%dC", model.VGPUTypePrefix, fb) {
+				framebuffer = fb
+			}
+		}
+		if framebuffer == 0 {
+			return fmt.Errorf("vGPU type %s is not supported by the GPU model", name)
+		}
+		maxInstances := model.MemoryGB / framebuffer
+		if count < 0 {
+			count = maxInstances
+		}
+		if count > maxInstances {
+			return fmt.Errorf("a GPU holds at most %d %s vGPUs, %d requested", maxInstances, name, count)
+		}
+		preview.Devices = append(preview.Devices, DevicePreview{
+			Name:     name,
+			MemoryGB: framebuffer,
+			PerGPU:   count,
+			Total:    count * preview.GPUs,
+		})
+	}
+	return nil
+}
+
+// profileMemoryGB returns the memory of the devices of a MIG profile, e.g. 10 for 1g.10gb
+func profileMemoryGB(profile string) int {
+	_, memory, _ := strings.Cut(profile, ".")
+	memoryGB, _ := strconv.Atoi(strings.TrimSuffix(memory, "gb"))
+	return memoryGB
+}
+
+func sortedNames(devices map[string]int) []string {
+	names := make([]string, 0, len(devices))
+	for name := range devices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
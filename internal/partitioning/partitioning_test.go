@@ -0,0 +1,178 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package partitioning
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func gpuNode(name string, product string, count string) corev1.Node {
+	return corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   name,
+		Labels: map[string]string{gpuProductLabelKey: product, gpuCountLabelKey: count},
+	}}
+}
+
+func TestParseRequest(t *testing.T) {
+	request, err := ParseRequest(MIG, "3g.40gb=1, 2g.20gb=2,all-1g.10gb")
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"3g.40gb": 1, "2g.20gb": 2, "1g.10gb": -1}, request.Devices)
+
+	for _, value := range []string{"", "3g.40gb", "3g.40gb=0", "=1", "all-"} {
+		_, err := ParseRequest(MIG, value)
+		require.Error(t, err, value)
+	}
+}
+
+func TestPreview(t *testing.T) {
+	nodes := []corev1.Node{
+		gpuNode("a100-1", "NVIDIA-A100-SXM4-80GB", "8"),
+		gpuNode("a100-2", "NVIDIA-A100-SXM4-80GB-MIG-1g.10gb", "8"),
+		gpuNode("a30", "NVIDIA-A30", "4"),
+		gpuNode("l4", "NVIDIA-L4", "1"),
+		gpuNode("unknown", "NVIDIA-Unknown", "2"),
+	}
+
+	testCases := []struct {
+		description string
+		kind        Kind
+		value       string
+		expected    []ModelPreview
+	}{
+		{
+			description: "mixed MIG profiles",
+			kind:        MIG,
+			value:       "3g.40gb=1,2g.20gb=2",
+			expected: []ModelPreview{
+				{
+					Product: "NVIDIA-A100-SXM4-80GB", Nodes: 1, GPUs: 8,
+					Devices: []DevicePreview{
+						{Name: "2g.20gb", Resource: "nvidia.com/mig-2g.20gb", MemoryGB: 20, PerGPU: 2, Total: 16},
+						{Name: "3g.40gb", Resource: "nvidia.com/mig-3g.40gb", MemoryGB: 40, PerGPU: 1, Total: 8},
+					},
+				},
+				{
+					Product: "NVIDIA-A100-SXM4-80GB-MIG-1g.10gb", Nodes: 1, GPUs: 8,
+					Devices: []DevicePreview{
+						{Name: "2g.20gb", Resource: "nvidia.com/mig-2g.20gb", MemoryGB: 20, PerGPU: 2, Total: 16},
+						{Name: "3g.40gb", Resource: "nvidia.com/mig-3g.40gb", MemoryGB: 40, PerGPU: 1, Total: 8},
+					},
+				},
+				{Product: "NVIDIA-A30", Nodes: 1, GPUs: 4, Error: "MIG profile 2g.20gb is not supported by the GPU model"},
+				{Product: "NVIDIA-L4", Nodes: 1, GPUs: 1, Error: "MIG is not supported by the GPU model"},
+				{Product: "NVIDIA-Unknown", Nodes: 1, GPUs: 2, Error: "the partitioning capabilities of the GPU model are not known"},
+			},
+		},
+		{
+			description: "all devices of a profile",
+			kind:        MIG,
+			value:       "all-1g.6gb",
+			expected: []ModelPreview{
+				{Product: "NVIDIA-A100-SXM4-80GB", Nodes: 1, GPUs: 8, Error: "MIG profile 1g.6gb is not supported by the GPU model"},
+				{Product: "NVIDIA-A100-SXM4-80GB-MIG-1g.10gb", Nodes: 1, GPUs: 8, Error: "MIG profile 1g.6gb is not supported by the GPU model"},
+				{
+					Product: "NVIDIA-A30", Nodes: 1, GPUs: 4,
+					Devices: []DevicePreview{{Name: "1g.6gb", Resource: "nvidia.com/mig-1g.6gb", MemoryGB: 6, PerGPU: 4, Total: 16}},
+				},
+				{Product: "NVIDIA-L4", Nodes: 1, GPUs: 1, Error: "MIG is not supported by the GPU model"},
+				{Product: "NVIDIA-Unknown", Nodes: 1, GPUs: 2, Error: "the partitioning capabilities of the GPU model are not known"},
+			},
+		},
+		{
+			description: "MIG devices exceeding the memory slices",
+			kind:        MIG,
+			value:       "3g.40gb=2,1g.10gb=1",
+			expected: []ModelPreview{
+				{Product: "NVIDIA-A100-SXM4-80GB", Nodes: 1, GPUs: 8, Error: "the MIG devices require 9 memory slices, a GPU has 8"},
+				{Product: "NVIDIA-A100-SXM4-80GB-MIG-1g.10gb", Nodes: 1, GPUs: 8, Error: "the MIG devices require 9 memory slices, a GPU has 8"},
+				{Product: "NVIDIA-A30", Nodes: 1, GPUs: 4, Error: "MIG profile 1g.10gb is not supported by the GPU model"},
+				{Product: "NVIDIA-L4", Nodes: 1, GPUs: 1, Error: "MIG is not supported by the GPU model"},
+				{Product: "NVIDIA-Unknown", Nodes: 1, GPUs: 2, Error: "the partitioning capabilities of the GPU model are not known"},
+			},
+		},
+		{
+			description: "vGPU type",
+			kind:        VGPU,
+			value:       "L4-6C=4",
+			expected: []ModelPreview{
+				{Product: "NVIDIA-A100-SXM4-80GB", Nodes: 1, GPUs: 8, Error: "vGPU type L4-6C is not supported by the GPU model"},
+				{Product: "NVIDIA-A100-SXM4-80GB-MIG-1g.10gb", Nodes: 1, GPUs: 8, Error: "vGPU type L4-6C is not supported by the GPU model"},
+				{Product: "NVIDIA-A30", Nodes: 1, GPUs: 4, Error: "vGPU type L4-6C is not supported by the GPU model"},
+				{
+					Product: "NVIDIA-L4", Nodes: 1, GPUs: 1,
+					Devices: []DevicePreview{{Name: "L4-6C", MemoryGB: 6, PerGPU: 4, Total: 4}},
+				},
+				{Product: "NVIDIA-Unknown", Nodes: 1, GPUs: 2, Error: "the partitioning capabilities of the GPU model are not known"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			request, err := ParseRequest(tc.kind, tc.value)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, Preview(nodes, request))
+		})
+	}
+}
+
+func TestPreviewUnusedSlices(t *testing.T) {
+	request, err := ParseRequest(MIG, "3g.40gb=1")
+	require.NoError(t, err)
+	previews := Preview([]corev1.Node{gpuNode("h100", "NVIDIA-H100-80GB-HBM3", "8")}, request)
+	require.Len(t, previews, 1)
+	require.Equal(t, 4, previews[0].UnusedComputeSlices)
+	require.Equal(t, 4, previews[0].UnusedMemorySlices)
+}
+
+func TestHandler(t *testing.T) {
+	node := gpuNode("l40s", "NVIDIA-L40S", "2")
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(&node).Build()
+	h := NewHandler(c, logr.Discard())
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, PreviewPath+"?vgpu=L40S-12C=4", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body struct {
+		Models []ModelPreview `json:"models"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, []ModelPreview{{
+		Product: "NVIDIA-L40S", Nodes: 1, GPUs: 2,
+		Devices: []DevicePreview{{Name: "L40S-12C", MemoryGB: 12, PerGPU: 4, Total: 8}},
+	}}, body.Models)
+
+	for _, query := range []string{"", "?mig=1g.10gb=1&vgpu=L40S-12C=4", "?mig=1g.10gb"} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, PreviewPath+query, nil))
+		require.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, PreviewPath+"?mig=1g.10gb=1", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -0,0 +1,213 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package rdma discovers the PCIe topology of the GPUs and the RDMA NICs of a node, and pairs every GPU with
+// its closest NIC, so that the GPUDirect RDMA workloads use the NIC sharing a PCIe switch with their GPU.
+package rdma
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+)
+
+const (
+	// nvidiaVendorID is the PCI vendor ID of NVIDIA
+	nvidiaVendorID = "0x10de"
+	// PCI classes of the GPUs: VGA compatible and 3D controllers
+	pciClassVGA = "0x0300"
+	pciClass3D  = "0x0302"
+)
+
+// Pair is a GPU paired with its closest RDMA NIC
+type Pair struct {
+	// GPU is the PCI address of the GPU
+	GPU string `json:"gpu"`
+	// NIC is the PCI address of the NIC
+	NIC string `json:"nic"`
+	// RDMADevice is the name of the RDMA device of the NIC, e.g. mlx5_0
+	RDMADevice string `json:"rdmaDevice"`
+	// NetDevice is the name of the network interface of the NIC, when it has one
+	NetDevice string `json:"netDevice,omitempty"`
+}
+
+// pciDevice is a PCI device along with its location in the PCIe hierarchy
+type pciDevice struct {
+	address string
+	// path holds the root complex and the bridges up to the device, e.g. [pci0000:00 0000:00:01.0 0000:01:00.0]
+	path     []string
+	numaNode int
+	// rdmaDevice and netDevice are set for the RDMA NICs
+	rdmaDevice string
+	netDevice  string
+}
+
+// Discover pairs every GPU of the node with its closest RDMA NIC from the PCI devices of sysfs mounted at
+// sysRoot. The GPUs are ordered by PCI address, i.e. by GPU index, and are paired with the NIC sharing their
+// deepest PCIe switch, then with a NIC on their NUMA node. No pair is returned when the node has no RDMA NIC.
+func Discover(sysRoot string) ([]Pair, error) {
+	devicesDir := filepath.Join(sysRoot, "bus", "pci", "devices")
+	entries, err := os.ReadDir(devicesDir)
+	if err != nil {
+		return nil, fmt.Errorf("error listing PCI devices: %w", err)
+	}
+
+	var gpus, nics []pciDevice
+	for _, entry := range entries {
+		dir := filepath.Join(devicesDir, entry.Name())
+		isGPU := readAttribute(dir, "vendor") == nvidiaVendorID && isGPUClass(readAttribute(dir, "class"))
+		rdmaDevice := firstEntry(filepath.Join(dir, "infiniband"))
+		if !isGPU && rdmaDevice == "" {
+			continue
+		}
+		// the virtual functions of the NICs are paired through their physical function
+		if _, err := os.Lstat(filepath.Join(dir, "physfn")); err == nil {
+			continue
+		}
+		device, err := newPCIDevice(sysRoot, dir, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		if isGPU {
+			gpus = append(gpus, device)
+			continue
+		}
+		device.rdmaDevice = rdmaDevice
+		device.netDevice = firstEntry(filepath.Join(dir, "net"))
+		nics = append(nics, device)
+	}
+	if len(nics) == 0 {
+		return nil, nil
+	}
+
+	// the entries are sorted by PCI address
+	pairs := make([]Pair, 0, len(gpus))
+	for _, gpu := range gpus {
+		nic := slices.MinFunc(nics, func(a, b pciDevice) int { return compareDistance(gpu, a, b) })
+		pairs = append(pairs, Pair{GPU: gpu.address, NIC: nic.address, RDMADevice: nic.rdmaDevice, NetDevice: nic.netDevice})
+	}
+	return pairs, nil
+}
+
+func newPCIDevice(sysRoot string, dir string, address string) (pciDevice, error) {
+	resolved, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return pciDevice{}, fmt.Errorf("error resolving the PCIe path of device %s: %w", address, err)
+	}
+	devicesRoot, err := filepath.EvalSymlinks(filepath.Join(sysRoot, "devices"))
+	if err != nil {
+		return pciDevice{}, fmt.Errorf("error resolving the sysfs devices directory: %w", err)
+	}
+	relative, err := filepath.Rel(devicesRoot, resolved)
+	if err != nil {
+		return pciDevice{}, fmt.Errorf("error resolving the PCIe path of device %s: %w", address, err)
+	}
+	numaNode, err := strconv.Atoi(readAttribute(dir, "numa_node"))
+	if err != nil {
+		numaNode = -1
+	}
+	return pciDevice{address: address, path: strings.Split(relative, string(filepath.Separator)), numaNode: numaNode}, nil
+}
+
+// compareDistance orders the NICs a and b by distance to the GPU: the NIC sharing the deepest PCIe ancestor
+// with the GPU first, then the NIC on the NUMA node of the GPU, then the NIC with the fewest hops to the GPU
+func compareDistance(gpu pciDevice, a pciDevice, b pciDevice) int {
+	depthA, depthB := commonDepth(gpu.path, a.path), commonDepth(gpu.path, b.path)
+	if depthA != depthB {
+		return depthB - depthA
+	}
+	sameNUMAA, sameNUMAB := gpu.numaNode >= 0 && a.numaNode == gpu.numaNode, gpu.numaNode >= 0 && b.numaNode == gpu.numaNode
+	if sameNUMAA != sameNUMAB {
+		if sameNUMAA {
+			return -1
+		}
+		return 1
+	}
+	if hopsA, hopsB := len(a.path)-depthA, len(b.path)-depthB; hopsA != hopsB {
+		return hopsA - hopsB
+	}
+	return strings.Compare(a.address, b.address)
+}
+
+// commonDepth returns the number of PCIe ancestors shared by the two paths
+func commonDepth(a []string, b []string) int {
+	depth := 0
+	// the devices themselves are not ancestors
+	for depth < len(a)-1 && depth < len(b)-1 && a[depth] == b[depth] {
+		depth++
+	}
+	return depth
+}
+
+func isGPUClass(class string) bool {
+	return strings.HasPrefix(class, pciClassVGA) || strings.HasPrefix(class, pciClass3D)
+}
+
+// readAttribute returns the trimmed content of a sysfs attribute of the device, or an empty string
+func readAttribute(dir string, name string) string {
+	content, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(content))
+}
+
+// firstEntry returns the name of the first entry of the directory, or an empty string
+func firstEntry(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) == 0 {
+		return ""
+	}
+	return entries[0].Name()
+}
+
+// ParseTopology returns the GPU-NIC pairs of the RDMA topology annotation of a node
+func ParseTopology(annotations map[string]string) ([]Pair, error) {
+	value, ok := annotations[consts.GPURDMATopologyAnnotationKey]
+	if !ok || value == "" {
+		return nil, nil
+	}
+	var pairs []Pair
+	if err := json.Unmarshal([]byte(value), &pairs); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", consts.GPURDMATopologyAnnotationKey, err)
+	}
+	return pairs, nil
+}
+
+// NICLabelKey returns the key of the node label holding the RDMA device paired with the GPU of the given index
+func NICLabelKey(gpuIndex int) string {
+	return fmt.Sprintf("%s%d%s", consts.GPURDMANICLabelKeyPrefix, gpuIndex, consts.GPURDMANICLabelKeySuffix)
+}
+
+// IsNICLabelKey returns true if the key is the one of a node label holding the RDMA device paired with a GPU
+func IsNICLabelKey(key string) bool {
+	index, found := strings.CutPrefix(key, consts.GPURDMANICLabelKeyPrefix)
+	if !found {
+		return false
+	}
+	index, found = strings.CutSuffix(index, consts.GPURDMANICLabelKeySuffix)
+	if !found {
+		return false
+	}
+	_, err := strconv.Atoi(index)
+	return err == nil
+}
@@ -0,0 +1,121 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package rdma
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDevice is a PCI device of a fake sysfs
+type fakeDevice struct {
+	// path is the PCIe path of the device under the devices directory
+	path       string
+	vendor     string
+	class      string
+	numaNode   string
+	rdmaDevice string
+	netDevice  string
+	virtualFn  bool
+}
+
+func newFakeSysfs(t *testing.T, devices ...fakeDevice) string {
+	sysRoot := t.TempDir()
+	busDir := filepath.Join(sysRoot, "bus", "pci", "devices")
+	require.NoError(t, os.MkdirAll(busDir, 0755))
+	for _, device := range devices {
+		dir := filepath.Join(sysRoot, "devices", device.path)
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		attributes := map[string]string{"vendor": device.vendor, "class": device.class, "numa_node": device.numaNode}
+		for name, value := range attributes {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(value+"\n"), 0644))
+		}
+		if device.rdmaDevice != "" {
+			require.NoError(t, os.MkdirAll(filepath.Join(dir, "infiniband", device.rdmaDevice), 0755))
+		}
+		if device.netDevice != "" {
+			require.NoError(t, os.MkdirAll(filepath.Join(dir, "net", device.netDevice), 0755))
+		}
+		if device.virtualFn {
+			require.NoError(t, os.Symlink(dir, filepath.Join(dir, "physfn")))
+		}
+		require.NoError(t, os.Symlink(dir, filepath.Join(busDir, filepath.Base(device.path))))
+	}
+	return sysRoot
+}
+
+func gpu(path string, numaNode string) fakeDevice {
+	return fakeDevice{path: path, vendor: "0x10de", class: "0x030200", numaNode: numaNode}
+}
+
+func nic(path string, numaNode string, rdmaDevice string, netDevice string) fakeDevice {
+	return fakeDevice{path: path, vendor: "0x15b3", class: "0x020700", numaNode: numaNode, rdmaDevice: rdmaDevice, netDevice: netDevice}
+}
+
+func TestDiscover(t *testing.T) {
+	sysRoot := newFakeSysfs(t,
+		// GPU 0 and mlx5_0 share a PCIe switch of the first root complex, mlx5_2 hangs off the root port
+		gpu("pci0000:00/0000:00:01.0/0000:01:00.0/0000:02:00.0/0000:03:00.0", "0"),
+		nic("pci0000:00/0000:00:01.0/0000:01:00.0/0000:02:01.0/0000:04:00.0", "0", "mlx5_0", "ens1f0"),
+		nic("pci0000:00/0000:00:02.0/0000:05:00.0", "0", "mlx5_2", "ens2f0"),
+		// GPU 1 shares no switch with a NIC, the NIC on its NUMA node is preferred
+		gpu("pci0000:40/0000:40:01.0/0000:41:00.0", "1"),
+		nic("pci0000:80/0000:80:01.0/0000:81:00.0", "1", "mlx5_1", ""),
+		// the virtual functions and the other devices are ignored
+		fakeDevice{path: "pci0000:00/0000:00:01.0/0000:01:00.0/0000:02:00.0/0000:03:00.1", vendor: "0x15b3", class: "0x020700", rdmaDevice: "mlx5_3", virtualFn: true},
+		fakeDevice{path: "pci0000:00/0000:00:1f.0", vendor: "0x8086", class: "0x060100"},
+	)
+
+	pairs, err := Discover(sysRoot)
+	require.NoError(t, err)
+	require.Equal(t, []Pair{
+		{GPU: "0000:03:00.0", NIC: "0000:04:00.0", RDMADevice: "mlx5_0", NetDevice: "ens1f0"},
+		{GPU: "0000:41:00.0", NIC: "0000:81:00.0", RDMADevice: "mlx5_1"},
+	}, pairs)
+}
+
+func TestDiscoverWithoutNIC(t *testing.T) {
+	sysRoot := newFakeSysfs(t, gpu("pci0000:00/0000:00:01.0/0000:01:00.0", "0"))
+	pairs, err := Discover(sysRoot)
+	require.NoError(t, err)
+	require.Empty(t, pairs)
+}
+
+func TestParseTopology(t *testing.T) {
+	pairs, err := ParseTopology(map[string]string{
+		"nvidia.com/gpu.rdma.topology": `[{"gpu":"0000:03:00.0","nic":"0000:04:00.0","rdmaDevice":"mlx5_0"}]`,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []Pair{{GPU: "0000:03:00.0", NIC: "0000:04:00.0", RDMADevice: "mlx5_0"}}, pairs)
+
+	pairs, err = ParseTopology(nil)
+	require.NoError(t, err)
+	require.Empty(t, pairs)
+
+	_, err = ParseTopology(map[string]string{"nvidia.com/gpu.rdma.topology": "mlx5_0"})
+	require.Error(t, err)
+}
+
+func TestNICLabelKey(t *testing.T) {
+	require.Equal(t, "nvidia.com/gpu-3.rdma-nic", NICLabelKey(3))
+	require.True(t, IsNICLabelKey(NICLabelKey(12)))
+	require.False(t, IsNICLabelKey("nvidia.com/gpu-x.rdma-nic"))
+	require.False(t, IsNICLabelKey("nvidia.com/gpu.product"))
+}
@@ -94,6 +94,10 @@ func buildDCGMExporterRenderData(ctx context.Context, s *configurableState, cr *
 		}
 	}
 
+	if err := spec.ValidatePodAttribution(cr.Spec.HostPaths.KubeletRootDir); err != nil {
+		return nil, err
+	}
+
 	kubeletRootDir := cr.Spec.HostPaths.KubeletRootDir
 	if kubeletRootDir == "" {
 		kubeletRootDir = dcgmExporterDefaultKubeletRootDir
@@ -139,6 +143,8 @@ func buildDCGMExporterRenderData(ctx context.Context, s *configurableState, cr *
 		MetricsConfigName:            metricsConfigName,
 		ServiceMonitorEnabled:        serviceMonitorEnabled,
 		PodResourcesDir:              filepath.Join(kubeletRootDir, "pod-resources"),
+		PodAttributionDisabled:       spec.IsPodAttributionDisabled(),
+		GPUIDType:                    spec.GetPodAttributionGPUIDType(),
 		ServiceType:                  serviceType,
 		ServiceInternalTrafficPolicy: serviceInternalTrafficPolicy,
 	}, nil
@@ -156,6 +156,48 @@ func TestDCGMExporterPodMetadataEnrichment(t *testing.T) {
 	assert.Equal(t, "^app$,^team$", env["DCGM_EXPORTER_KUBERNETES_POD_LABEL_ALLOWLIST_REGEX"])
 }
 
+func TestDCGMExporterPodAttributionDisabled(t *testing.T) {
+	s := newTestDCGMExporterState(t, false)
+	cr := exporterCR(&nvidiav1.DCGMExporterSpec{
+		PodAttribution: &nvidiav1.DCGMExporterPodAttributionConfig{Enabled: ptr.To(false)},
+	})
+
+	objs, err := s.getManifestObjects(context.Background(), cr, draSupportedCatalog())
+	require.NoError(t, err)
+	assert.Equal(t, 0, kindCounts(objs)["ClusterRole"])
+
+	ds := findDaemonSet(t, objs)
+	podSpec := ds.Spec.Template.Spec
+	env := envMap(podSpec.Containers[0].Env)
+	assert.Equal(t, "false", env["DCGM_EXPORTER_KUBERNETES"])
+	assert.Empty(t, podSpec.Containers[0].VolumeMounts)
+	for _, vol := range podSpec.Volumes {
+		assert.NotEqual(t, "pod-gpu-resources", vol.Name)
+	}
+}
+
+func TestDCGMExporterPodAttributionEnabled(t *testing.T) {
+	s := newTestDCGMExporterState(t, false)
+	cr := exporterCR(&nvidiav1.DCGMExporterSpec{
+		PodAttribution: &nvidiav1.DCGMExporterPodAttributionConfig{Enabled: ptr.To(true), GPUIDType: "device-name"},
+	})
+
+	objs, err := s.getManifestObjects(context.Background(), cr, draSupportedCatalog())
+	require.NoError(t, err)
+	assert.Equal(t, 1, kindCounts(objs)["ClusterRole"])
+
+	ds := findDaemonSet(t, objs)
+	podSpec := ds.Spec.Template.Spec
+	require.NotNil(t, podSpec.AutomountServiceAccountToken)
+	assert.True(t, *podSpec.AutomountServiceAccountToken)
+	env := envMap(podSpec.Containers[0].Env)
+	assert.Equal(t, "true", env["DCGM_EXPORTER_KUBERNETES"])
+	assert.Equal(t, "device-name", env["DCGM_EXPORTER_KUBERNETES_GPU_ID_TYPE"])
+	vol := findVolume(t, ds, "pod-gpu-resources")
+	require.NotNil(t, vol.HostPath)
+	assert.Equal(t, "/var/lib/kubelet/pod-resources", vol.HostPath.Path)
+}
+
 func TestDCGMExporterCustomMetricsConfig(t *testing.T) {
 	s := newTestDCGMExporterState(t, false)
 	cr := exporterCR(&nvidiav1.DCGMExporterSpec{
@@ -21,11 +21,13 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 
 	configv1 "github.com/openshift/api/config/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -46,6 +48,7 @@ import (
 	driverconfig "github.com/NVIDIA/gpu-operator/internal/config"
 	"github.com/NVIDIA/gpu-operator/internal/consts"
 	"github.com/NVIDIA/gpu-operator/internal/image"
+	"github.com/NVIDIA/gpu-operator/internal/kernel"
 	"github.com/NVIDIA/gpu-operator/internal/render"
 	"github.com/NVIDIA/gpu-operator/internal/utils"
 )
@@ -60,8 +63,14 @@ const (
 	DriverAppComponentLabelValue = "nvidia-driver"
 )
 
+// maxQuarantinedNodesInStatus is the maximum number of quarantined nodes listed in the NVIDIADriver status
+const maxQuarantinedNodesInStatus = 100
+
 type stateDriver struct {
 	stateSkel
+	// imageResolver checks the existence of the precompiled driver images when fallbacks are configured, and
+	// resolves the digests of the images when the ClusterPolicy pins them
+	imageResolver image.Resolver
 }
 
 var _ State = (*stateDriver)(nil)
@@ -83,21 +92,39 @@ type precompiledSpec struct {
 	SanitizedKernelVersion string
 }
 
+// kernelFlavorSpec describes the kernel flavor of the nodes of a node pool. ExcludedFlavors is set for the node pools
+// of generic kernels, whose DaemonSet must not be scheduled on the nodes of the other kernel flavors.
+type kernelFlavorSpec struct {
+	Flavor          string
+	HeadersPackages string
+	ExcludedFlavors []string
+}
+
+// kernelModuleParamsSpec holds the configuration files of the kernel module parameters of the NVIDIADriver, rendered
+// in a ConfigMap mounted in the driver containers
+type kernelModuleParamsSpec struct {
+	ConfigMapName string
+	Files         map[string]string
+}
+
 type additionalConfigs struct {
 	VolumeMounts []corev1.VolumeMount
 	Volumes      []corev1.Volume
 }
 
 type driverRenderData struct {
-	Driver            *driverSpec
-	GDS               *gdsDriverSpec
-	GPUDirectRDMA     *nvidiav1alpha1.GPUDirectRDMASpec
-	GDRCopy           *gdrcopyDriverSpec
-	Runtime           *driverRuntimeSpec
-	Openshift         *openshiftSpec
-	Precompiled       *precompiledSpec
-	AdditionalConfigs *additionalConfigs
-	HostRoot          string
+	Driver             *driverSpec
+	GDS                *gdsDriverSpec
+	GPUDirectRDMA      *nvidiav1alpha1.GPUDirectRDMASpec
+	GDRCopy            *gdrcopyDriverSpec
+	Runtime            *driverRuntimeSpec
+	Openshift          *openshiftSpec
+	Precompiled        *precompiledSpec
+	KernelFlavor       *kernelFlavorSpec
+	KernelModuleParams *kernelModuleParamsSpec
+	AdditionalConfigs  *additionalConfigs
+	HostRoot           string
+	StartupTolerations []corev1.Toleration
 }
 
 // ConfigDigest computes a hash of all driver-install-relevant fields.
@@ -127,6 +154,7 @@ func NewStateDriver(
 			scheme:      scheme,
 			renderer:    renderer,
 		},
+		imageResolver: image.NewCachingResolver(image.NewRegistryResolver(image.SharedRegistryClient()), image.DefaultResolveCacheTTL),
 	}
 	return state, nil
 }
@@ -180,6 +208,16 @@ func (s *stateDriver) GetWatchSources(mgr ctrlManager) map[string]SyncingSource
 			&nvidiav1alpha1.NVIDIADriver{}, handler.OnlyControllerOwner()),
 		nvDriverPredicate,
 	)
+	driverBuildPredicate := predicate.NewTypedPredicateFuncs(func(job *batchv1.Job) bool {
+		return job.GetLabels()[AppComponentLabelKey] == DriverBuildAppComponentLabelValue
+	})
+	wr["Job"] = source.Kind(
+		mgr.GetCache(),
+		&batchv1.Job{},
+		handler.TypedEnqueueRequestForOwner[*batchv1.Job](mgr.GetScheme(), mgr.GetRESTMapper(),
+			&nvidiav1alpha1.NVIDIADriver{}, handler.OnlyControllerOwner()),
+		driverBuildPredicate,
+	)
 	return wr
 }
 
@@ -270,10 +308,17 @@ func (s *stateDriver) getManifestObjects(ctx context.Context, cr *nvidiav1alpha1
 	}
 
 	isOpenshift := runtimeSpec.OpenshiftVersion != ""
-	nodePools, err := getNodePools(ctx, s.client, cr, isOpenshift)
+	nodePools, quarantinedNodes, err := getNodePools(ctx, s.client, cr, isOpenshift)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get node pools: %w", err)
 	}
+	if len(quarantinedNodes) > 0 {
+		logger.Info("WARNING: Quarantined nodes missing the labels needed to deploy the driver", "CR", cr.Name, "Count", len(quarantinedNodes))
+	}
+	if len(quarantinedNodes) > maxQuarantinedNodesInStatus {
+		quarantinedNodes = quarantinedNodes[:maxQuarantinedNodesInStatus]
+	}
+	cr.Status.QuarantinedNodes = quarantinedNodes
 
 	gpuDirectRDMASpec := cr.Spec.GPUDirectRDMA
 
@@ -281,10 +326,13 @@ func (s *stateDriver) getManifestObjects(ctx context.Context, cr *nvidiav1alpha1
 		GPUDirectRDMA: gpuDirectRDMASpec,
 		Runtime:       runtimeSpec,
 		HostRoot:      clusterPolicy.Spec.HostPaths.RootFS,
+		// tolerate the startup taints of the ClusterPolicy like all other operands
+		StartupTolerations: clusterPolicy.Spec.Daemonsets.StartupTaints.GetTolerations(),
 	}
 
 	if len(nodePools) == 0 {
 		logger.Info("No nodes matching the given node selector", "CR", cr.Name)
+		cr.Status.Precompiled = nil
 		return []*unstructured.Unstructured{}, nil
 	}
 
@@ -293,29 +341,61 @@ func (s *stateDriver) getManifestObjects(ctx context.Context, cr *nvidiav1alpha1
 	// Render kubernetes objects for each node pool.
 	// We deploy one DaemonSet per node pool.
 	var objs []*unstructured.Unstructured
+	var precompiledStatuses []nvidiav1alpha1.PrecompiledKernelStatus
 	for _, nodePool := range nodePools {
+		poolCR := cr
+		if cr.Spec.UsePrecompiledDrivers() {
+			status, err := s.getPrecompiledKernelStatus(ctx, cr, nodePool)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get precompiled driver status of node pool %s: %w", nodePool.name, err)
+			}
+			precompiledStatuses = append(precompiledStatuses, status)
+			err = s.setPrecompiledUnavailableLabel(ctx, nodePool, status.Image == "")
+			if err != nil {
+				return nil, err
+			}
+			switch status.Mode {
+			case nvidiav1alpha1.SkipFallback:
+				logger.Info("Skipping node pool without precompiled driver image", "NodePool", nodePool.name, "Reason", status.Message)
+				continue
+			case nvidiav1alpha1.CompileFromSourceFallback:
+				logger.Info("Compiling the driver from source for node pool without precompiled driver image", "NodePool", nodePool.name, "Reason", status.Message)
+				poolCR = getCompileFromSourceDriver(cr)
+			case nvidiav1alpha1.BuildFallback:
+				if status.Image == "" {
+					logger.Info("Skipping node pool until its driver image is built", "NodePool", nodePool.name, "Reason", status.Message)
+					continue
+				}
+				poolCR = getBuildDriver(cr)
+			}
+		}
+
 		// Construct a unique driver spec per node pool. Each node pool
 		// should have a unique nodeSelector and name.
-		driverSpec, err := getDriverSpec(cr, nodePool)
+		driverSpec, err := getDriverSpec(poolCR, nodePool)
 		if err != nil {
 			return nil, fmt.Errorf("failed to construct driver spec: %w", err)
 		}
 		renderData.Driver = driverSpec
 
-		if cr.Spec.UsePrecompiledDrivers() {
+		renderData.Precompiled = nil
+		if poolCR.Spec.UsePrecompiledDrivers() {
 			renderData.Precompiled = &precompiledSpec{
 				KernelVersion:          nodePool.kernel,
 				SanitizedKernelVersion: getSanitizedKernelVersion(nodePool.kernel),
 			}
 		}
 
-		gdsSpec, err := getGDSSpec(&cr.Spec, nodePool)
+		renderData.KernelFlavor = getKernelFlavorSpec(poolCR, nodePool, runtimeSpec.OpenshiftDriverToolkitEnabled)
+		renderData.KernelModuleParams = getKernelModuleParamsSpec(poolCR)
+
+		gdsSpec, err := getGDSSpec(&poolCR.Spec, nodePool)
 		if err != nil {
 			return nil, fmt.Errorf("failed to construct GDS spec: %w", err)
 		}
 		renderData.GDS = gdsSpec
 
-		gdrcopySpec, err := getGDRCopySpec(&cr.Spec, nodePool)
+		gdrcopySpec, err := getGDRCopySpec(&poolCR.Spec, nodePool)
 		if err != nil {
 			return nil, fmt.Errorf("failed to construct GDRCopy spec: %w", err)
 		}
@@ -328,7 +408,7 @@ func (s *stateDriver) getManifestObjects(ctx context.Context, cr *nvidiav1alpha1
 			}
 		}
 
-		renderData.AdditionalConfigs, err = s.getDriverAdditionalConfigs(ctx, cr, clusterInfo, nodePool)
+		renderData.AdditionalConfigs, err = s.getDriverAdditionalConfigs(ctx, poolCR, clusterInfo, nodePool)
 		if err != nil {
 			logger.Error(err, "error rendering addition driver volume", "NodePool", nodePool.name)
 		}
@@ -339,14 +419,21 @@ func (s *stateDriver) getManifestObjects(ctx context.Context, cr *nvidiav1alpha1
 			logger.Error(err, "error rendering manifests for node pool", "NodePool", nodePool.name)
 			return nil, err
 		}
-		manifestObjs, err = s.handleDefaultImagesInObjects(ctx, manifestObjs, cr, *renderData)
+		manifestObjs, err = s.handleDefaultImagesInObjects(ctx, manifestObjs, poolCR, *renderData)
 		if err != nil {
 			logger.Error(err, "error handling default images in manifests", "NodePool", nodePool.name)
 			return nil, err
 		}
+		if clusterPolicy.Spec.IsDigestPinningEnabled() {
+			if err := s.pinImageDigests(ctx, manifestObjs); err != nil {
+				return nil, fmt.Errorf("failed to pin image digests of node pool %s: %w", nodePool.name, err)
+			}
+		}
 		objs = append(objs, manifestObjs...)
 
 	}
+	sortPrecompiledKernelStatuses(precompiledStatuses)
+	cr.Status.Precompiled = precompiledStatuses
 	return objs, nil
 }
 
@@ -368,6 +455,60 @@ func (s *stateDriver) renderManifestObjects(ctx context.Context, renderData *dri
 	return objs, nil
 }
 
+// pinImageDigests replaces the image tags of all containers of the rendered DaemonSets with the digests they
+// currently resolve to, authenticating with the image pull secrets of the DaemonSets
+func (s *stateDriver) pinImageDigests(ctx context.Context, objs []*unstructured.Unstructured) error {
+	for _, obj := range objs {
+		if obj.GetKind() != "DaemonSet" {
+			continue
+		}
+		podSpec, _, err := unstructured.NestedMap(obj.Object, "spec", "template", "spec")
+		if err != nil {
+			return fmt.Errorf("failed to get pod spec of DaemonSet %s: %w", obj.GetName(), err)
+		}
+
+		var pullSecrets []string
+		secretRefs, _, _ := unstructured.NestedSlice(podSpec, "imagePullSecrets")
+		for _, ref := range secretRefs {
+			if secretRef, ok := ref.(map[string]interface{}); ok {
+				if name, ok := secretRef["name"].(string); ok {
+					pullSecrets = append(pullSecrets, name)
+				}
+			}
+		}
+		creds, err := s.getImagePullCredentials(ctx, pullSecrets)
+		if err != nil {
+			return err
+		}
+
+		for _, field := range []string{"initContainers", "containers"} {
+			containers, found, err := unstructured.NestedSlice(podSpec, field)
+			if err != nil || !found {
+				continue
+			}
+			for _, c := range containers {
+				container, ok := c.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				img, ok := container["image"].(string)
+				if !ok || img == "" {
+					continue
+				}
+				resolved, err := s.imageResolver.Resolve(ctx, img, creds)
+				if err != nil {
+					return fmt.Errorf("failed to resolve digest of image %s: %w", img, err)
+				}
+				container["image"] = resolved
+			}
+			if err := unstructured.SetNestedSlice(obj.Object, containers, "spec", "template", "spec", field); err != nil {
+				return fmt.Errorf("failed to set %s of DaemonSet %s: %w", field, obj.GetName(), err)
+			}
+		}
+	}
+	return nil
+}
+
 func (s *stateDriver) handleDefaultImagesInObjects(
 	ctx context.Context,
 	desiredObjs []*unstructured.Unstructured,
@@ -435,10 +576,10 @@ func (s *stateDriver) handleDefaultImagesInObjects(
 	s.addStateSpecificLabels(obj)
 
 	// Compute the hash and compare with the hash of the current DaemonSet deployed
-	newHash := utils.GetObjectHash(obj)
+	newHash := utils.GetObjectDigest(obj)
 	currentHash := currentDs.GetAnnotations()[consts.NvidiaAnnotationHashKey]
 	logger.V(consts.LogLevelDebug).Info("Calculating obj hash with old k8s-driver-manager image", "currentHash", currentHash, "newHash", newHash)
-	if newHash == currentHash {
+	if utils.ObjectHashMatches(currentHash, newHash, legacyObjectHash(obj)) {
 		// Hash is same when we use the same driver manager image.
 		// Thus, the driver spec has not changed. Do not update
 		// the driver-manager image.
@@ -498,7 +639,8 @@ func getDriverName(cr *nvidiav1alpha1.NVIDIADriver, osVersion string) string {
 // The hash string <string> is calculated from the NVIDIADriver CR UID.
 //
 // The '-<kernelVersion>' or '-<rhcosVersion>' suffix may also be used to calculate the hash if precompiled drivers
-// are enabled or the OpenShift Driver Toolkit is used.
+// are enabled or the OpenShift Driver Toolkit is used. Otherwise, the '-<kernelFlavor>' suffix is used for the node
+// pools of kernel flavors other than generic.
 func getDriverAppName(cr *nvidiav1alpha1.NVIDIADriver, pool nodePool) string {
 	const (
 		appNamePrefixFormat = "nvidia-%s-driver-%s"
@@ -517,6 +659,8 @@ func getDriverAppName(cr *nvidiav1alpha1.NVIDIADriver, pool nodePool) string {
 		hashBuilder.WriteString("-" + pool.kernel)
 	} else if pool.rhcosVersion != "" {
 		hashBuilder.WriteString("-" + pool.rhcosVersion)
+	} else if pool.kernelFlavor != "" && pool.kernelFlavor != kernel.FlavorGeneric {
+		hashBuilder.WriteString("-" + string(pool.kernelFlavor))
 	}
 
 	hash := utils.GetStringHash(hashBuilder.String())
@@ -530,6 +674,49 @@ func getDriverAppName(cr *nvidiav1alpha1.NVIDIADriver, pool nodePool) string {
 	return appName
 }
 
+// getKernelFlavorSpec returns the kernel flavor of a node pool. Nil is returned for the node pools of precompiled
+// drivers or of the OpenShift Driver Toolkit, as the kernel version of the node pool already determines its flavor.
+func getKernelFlavorSpec(cr *nvidiav1alpha1.NVIDIADriver, pool nodePool, openshiftDriverToolkitEnabled bool) *kernelFlavorSpec {
+	if cr.Spec.UsePrecompiledDrivers() || openshiftDriverToolkitEnabled || pool.kernelFlavor == "" {
+		return nil
+	}
+	if pool.kernelFlavor == kernel.FlavorGeneric {
+		var excluded []string
+		for _, flavor := range kernel.NonGenericFlavors() {
+			excluded = append(excluded, string(flavor))
+		}
+		return &kernelFlavorSpec{Flavor: string(pool.kernelFlavor), ExcludedFlavors: excluded}
+	}
+	return &kernelFlavorSpec{
+		Flavor:          string(pool.kernelFlavor),
+		HeadersPackages: strings.Join(kernel.GetHeadersPackages(pool.osRelease, pool.kernelFlavor), ","),
+	}
+}
+
+// getKernelModuleParamsSpec returns the kernel module parameters set in the NVIDIADriver spec, nil when the parameters
+// are not set or provided in a ConfigMap
+func getKernelModuleParamsSpec(cr *nvidiav1alpha1.NVIDIADriver) *kernelModuleParamsSpec {
+	if !cr.Spec.KernelModuleConfig.HasModuleParameters() {
+		return nil
+	}
+	return &kernelModuleParamsSpec{
+		ConfigMapName: getKernelModuleParamsConfigMapName(cr),
+		Files:         cr.Spec.KernelModuleConfig.ModuleParameterFiles(),
+	}
+}
+
+// getKernelModuleParamsConfigMapName returns the name of the ConfigMap of the kernel module parameters of an
+// NVIDIADriver in the format nvidia-<driverType>-driver-<crName>-kernel-module-params
+func getKernelModuleParamsConfigMapName(cr *nvidiav1alpha1.NVIDIADriver) string {
+	const nameMaxLength = 253
+
+	name := fmt.Sprintf("nvidia-%s-driver-%s-kernel-module-params", cr.Spec.DriverType, cr.Name)
+	if len(name) > nameMaxLength {
+		name = name[:nameMaxLength]
+	}
+	return name
+}
+
 func getDefaultStartupProbe(spec *nvidiav1alpha1.NVIDIADriverSpec) *nvidiav1alpha1.ContainerProbeSpec {
 	initialDelaySeconds := int32(60)
 	if spec.UsePrecompiledDrivers() {
@@ -584,6 +771,7 @@ func getDriverSpec(cr *nvidiav1alpha1.NVIDIADriver, nodePool nodePool) (*driverS
 	}
 
 	spec.NodeSelector = nodePool.nodeSelector
+	spec.Env = getNodePoolEnv(spec, nodePool)
 
 	managerImagePath, err := image.ImagePath(spec.Manager.Repository, spec.Manager.Image, spec.Manager.Version, "DRIVER_MANAGER_IMAGE")
 	if err != nil {
@@ -606,6 +794,35 @@ func getDriverSpec(cr *nvidiav1alpha1.NVIDIADriver, nodePool nodePool) (*driverS
 	}, nil
 }
 
+// getNodePoolEnv returns the environment variables of the driver container on a node pool: the variables of the
+// overrides matching the node pool, in order, override the variables of the same name of spec.env. The DaemonSets of
+// the other node pools are thus not changed by an override, and their nodes not upgraded.
+func getNodePoolEnv(spec *nvidiav1alpha1.NVIDIADriverSpec, pool nodePool) []nvidiav1alpha1.EnvVar {
+	env := spec.Env
+	for _, override := range spec.NodePoolOverrides {
+		if !nodePoolOverrideMatches(override, pool) {
+			continue
+		}
+		for _, envVar := range override.Env {
+			idx := slices.IndexFunc(env, func(e nvidiav1alpha1.EnvVar) bool { return e.Name == envVar.Name })
+			if idx < 0 {
+				env = append(env, envVar)
+				continue
+			}
+			env[idx] = envVar
+		}
+	}
+	return env
+}
+
+// nodePoolOverrideMatches returns true if the override applies to the node pool
+func nodePoolOverrideMatches(override nvidiav1alpha1.DriverNodePoolOverrideSpec, pool nodePool) bool {
+	if override.OS != "" && override.OS != pool.osTag {
+		return false
+	}
+	return override.KernelFlavor == "" || override.KernelFlavor == string(pool.kernelFlavor)
+}
+
 func getGDSSpec(spec *nvidiav1alpha1.NVIDIADriverSpec, pool nodePool) (*gdsDriverSpec, error) {
 	if spec == nil || !spec.IsGDSEnabled() {
 		// note: GDS is optional in the NvidiaDriver CRD
@@ -756,6 +973,7 @@ func buildDriverInstallConfig(data *driverRenderData) *driverconfig.DriverInstal
 		}
 		if data.Driver.Spec.KernelModuleConfig != nil {
 			config.KernelModuleConfig = data.Driver.Spec.KernelModuleConfig.Name
+			config.KernelModuleParameters = data.Driver.Spec.KernelModuleConfig.ModuleParameterFiles()
 		}
 		if data.Driver.Spec.RepoConfig != nil {
 			config.RepoConfig = data.Driver.Spec.RepoConfig.Name
@@ -763,6 +981,10 @@ func buildDriverInstallConfig(data *driverRenderData) *driverconfig.DriverInstal
 		if data.Driver.Spec.CertConfig != nil {
 			config.CertConfig = data.Driver.Spec.CertConfig.Name
 		}
+		if data.Driver.Spec.IsModuleCacheEnabled() {
+			config.ModuleCacheRepository = data.Driver.Spec.ModuleCache.Repository
+			config.ModuleCacheSecretName = data.Driver.Spec.ModuleCache.SecretName
+		}
 	}
 
 	if data.GPUDirectRDMA != nil && data.GPUDirectRDMA.Enabled != nil && *data.GPUDirectRDMA.Enabled {
@@ -818,6 +1040,11 @@ func buildDriverInstallConfig(data *driverRenderData) *driverconfig.DriverInstal
 		config.KernelVersion = data.Precompiled.KernelVersion
 	}
 
+	if data.KernelFlavor != nil && data.KernelFlavor.Flavor != string(kernel.FlavorGeneric) {
+		config.KernelFlavor = data.KernelFlavor.Flavor
+		config.KernelHeadersPackages = data.KernelFlavor.HeadersPackages
+	}
+
 	if data.AdditionalConfigs != nil {
 		config.AdditionalVolumeMounts = driverconfig.ExtractVolumeMounts(data.AdditionalConfigs.VolumeMounts)
 		config.AdditionalVolumes = driverconfig.ExtractVolumes(data.AdditionalConfigs.Volumes)
@@ -0,0 +1,255 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package state
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/regclient/regclient/types/ref"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/image"
+	"github.com/NVIDIA/gpu-operator/internal/utils"
+)
+
+const (
+	// DriverBuildAppComponentLabelValue indicates the label value of the Jobs building the precompiled driver images
+	DriverBuildAppComponentLabelValue = "nvidia-driver-build"
+
+	defaultDriverBuilderImage = "gcr.io/kaniko-project/executor:v1.23.2"
+	defaultDriverBuildContext = "git://github.com/NVIDIA/gpu-driver-container.git#refs/heads/main"
+	driverBuildBackoffLimit   = 2
+	driverBuildContainerName  = "build"
+	driverBuildSecretVolume   = "push-secret"
+	kanikoDockerConfigDir     = "/kaniko/.docker"
+)
+
+// getBuildDriver returns a copy of the NVIDIADriver deploying the precompiled driver images built on the cluster,
+// pulled from the repository of the build fallback
+func getBuildDriver(cr *nvidiav1alpha1.NVIDIADriver) *nvidiav1alpha1.NVIDIADriver {
+	buildCR := cr.DeepCopy()
+	if build := buildCR.Spec.GetDriverBuildSpec(); build != nil {
+		buildCR.Spec.Repository = build.Repository
+	}
+	return buildCR
+}
+
+// getDriverBuildStatus returns the status of the node pool with the build fallback: the built driver image is
+// deployed once it exists in the repository, otherwise the Job building it is created and no driver is deployed
+func (s *stateDriver) getDriverBuildStatus(ctx context.Context, cr *nvidiav1alpha1.NVIDIADriver, pool nodePool,
+	status nvidiav1alpha1.PrecompiledKernelStatus, message string, creds []image.Credential) (nvidiav1alpha1.PrecompiledKernelStatus, error) {
+	build := cr.Spec.GetDriverBuildSpec()
+	builtImage, err := getDriverImagePath(&getBuildDriver(cr).Spec, pool)
+	if err != nil {
+		return status, fmt.Errorf("failed to get built driver image path: %w", err)
+	}
+	buildCreds, err := s.getDriverBuildCredentials(ctx, build, builtImage)
+	if err != nil {
+		return status, err
+	}
+	exists, err := s.imageExists(ctx, builtImage, append(creds, buildCreds...))
+	if err != nil {
+		return status, err
+	}
+
+	status.Mode = nvidiav1alpha1.BuildFallback
+	jobName := getDriverBuildJobName(cr, build, builtImage)
+	if exists {
+		status.Image = builtImage
+		status.Message = fmt.Sprintf("%s, driver image built on the cluster", message)
+		return status, s.deleteDriverBuildJob(ctx, jobName)
+	}
+
+	job, err := s.getOrCreateDriverBuildJob(ctx, cr, pool, jobName, builtImage)
+	if err != nil {
+		return status, err
+	}
+	status.Image = ""
+	status.BuildJob = job.Name
+	if isJobFailed(job) {
+		status.Message = fmt.Sprintf("%s, Job %s failed to build driver image %s, delete the Job to retry", message, job.Name, builtImage)
+	} else {
+		status.Message = fmt.Sprintf("%s, building driver image %s", message, builtImage)
+	}
+	return status, nil
+}
+
+// getDriverBuildCredentials returns the credentials of the repository of the built driver images: the credentials
+// of the push secret, and the registry being accessed over plain HTTP when insecure
+func (s *stateDriver) getDriverBuildCredentials(ctx context.Context, build *nvidiav1alpha1.DriverBuildSpec, builtImage string) ([]image.Credential, error) {
+	var creds []image.Credential
+	if build.PushSecret != "" {
+		var err error
+		if creds, err = s.getImagePullCredentials(ctx, []string{build.PushSecret}); err != nil {
+			return nil, err
+		}
+	}
+	if !build.Insecure {
+		return creds, nil
+	}
+	imageRef, err := ref.New(builtImage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse built driver image path %s: %w", builtImage, err)
+	}
+	for i := range creds {
+		if creds[i].Registry == imageRef.Registry {
+			creds[i].Insecure = true
+			return creds, nil
+		}
+	}
+	return append(creds, image.Credential{Registry: imageRef.Registry, Insecure: true}), nil
+}
+
+// getDriverBuildJobName returns the name of the Job building the driver image, unique per NVIDIADriver, image and
+// build context so that a Job is created again when they change, Jobs being immutable
+func getDriverBuildJobName(cr *nvidiav1alpha1.NVIDIADriver, build *nvidiav1alpha1.DriverBuildSpec, builtImage string) string {
+	hash := utils.GetStringHash(strings.Join([]string{string(cr.UID), builtImage, build.BuilderImage, build.Context}, "-"))
+	return fmt.Sprintf("%s-%s", DriverBuildAppComponentLabelValue, hash)
+}
+
+// getOrCreateDriverBuildJob returns the Job building the driver image, which is created if it does not exist
+func (s *stateDriver) getOrCreateDriverBuildJob(ctx context.Context, cr *nvidiav1alpha1.NVIDIADriver, pool nodePool,
+	jobName string, builtImage string) (*batchv1.Job, error) {
+	logger := log.FromContext(ctx)
+
+	job := &batchv1.Job{}
+	err := s.client.Get(ctx, types.NamespacedName{Namespace: s.namespace, Name: jobName}, job)
+	if err == nil {
+		return job, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get driver build Job %s: %w", jobName, err)
+	}
+
+	job = newDriverBuildJob(cr, pool, s.namespace, jobName, builtImage)
+	if err := controllerutil.SetControllerReference(cr, job, s.scheme); err != nil {
+		return nil, fmt.Errorf("failed to set controller reference for driver build Job: %w", err)
+	}
+	logger.Info("Creating Job building the driver image", "NodePool", pool.name, "Job", jobName, "Image", builtImage)
+	if err := s.client.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to create driver build Job %s: %w", jobName, err)
+	}
+	return job, nil
+}
+
+// deleteDriverBuildJob deletes the Job which built a driver image, along with its pods
+func (s *stateDriver) deleteDriverBuildJob(ctx context.Context, jobName string) error {
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Namespace: s.namespace, Name: jobName}}
+	err := s.client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete driver build Job %s: %w", jobName, err)
+	}
+	return nil
+}
+
+// newDriverBuildJob returns the Job building the precompiled driver image of the kernel of the node pool with
+// Kaniko, from the precompiled Dockerfile of the OS of the node pool. The Job runs on the nodes of the node pool so
+// that the image is built for their architecture.
+func newDriverBuildJob(cr *nvidiav1alpha1.NVIDIADriver, pool nodePool, namespace string, name string, builtImage string) *batchv1.Job {
+	build := cr.Spec.GetDriverBuildSpec()
+	builderImage := build.BuilderImage
+	if builderImage == "" {
+		builderImage = defaultDriverBuilderImage
+	}
+	buildContext := build.Context
+	if buildContext == "" {
+		buildContext = defaultDriverBuildContext
+	}
+
+	args := []string{
+		"--context=" + buildContext,
+		fmt.Sprintf("--context-sub-path=%s/precompiled", pool.osTag),
+		"--destination=" + builtImage,
+		"--build-arg=DRIVER_BRANCH=" + strings.Split(cr.Spec.Version, ".")[0],
+		"--build-arg=KERNEL_VERSION=" + pool.kernel,
+	}
+	if strings.Contains(cr.Spec.Version, ".") {
+		args = append(args, "--build-arg=DRIVER_VERSION="+cr.Spec.Version)
+	}
+	if build.Insecure {
+		args = append(args, "--insecure", "--skip-tls-verify")
+	}
+
+	container := corev1.Container{
+		Name:  driverBuildContainerName,
+		Image: builderImage,
+		Args:  args,
+	}
+	if build.Resources != nil {
+		container.Resources = corev1.ResourceRequirements{Limits: build.Resources.Limits, Requests: build.Resources.Requests}
+	}
+
+	podSpec := corev1.PodSpec{
+		RestartPolicy: corev1.RestartPolicyNever,
+		NodeSelector:  pool.nodeSelector,
+		Tolerations:   cr.Spec.Tolerations,
+	}
+	for _, secret := range cr.Spec.ImagePullSecrets {
+		podSpec.ImagePullSecrets = append(podSpec.ImagePullSecrets, corev1.LocalObjectReference{Name: secret})
+	}
+	if build.PushSecret != "" {
+		container.VolumeMounts = []corev1.VolumeMount{{Name: driverBuildSecretVolume, MountPath: kanikoDockerConfigDir, ReadOnly: true}}
+		podSpec.Volumes = []corev1.Volume{{
+			Name: driverBuildSecretVolume,
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+				SecretName: build.PushSecret,
+				Items:      []corev1.KeyToPath{{Key: corev1.DockerConfigJsonKey, Path: "config.json"}},
+			}},
+		}}
+	}
+	podSpec.Containers = []corev1.Container{container}
+
+	labels := map[string]string{
+		AppComponentLabelKey: DriverBuildAppComponentLabelValue,
+		"app":                name,
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To(int32(driverBuildBackoffLimit)),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       podSpec,
+			},
+		},
+	}
+}
+
+// isJobFailed returns true if the Job failed, exceeding its backoff limit
+func isJobFailed(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,173 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package state
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+const (
+	testBuildNamespace = "gpu-operator"
+	testBuiltImage     = "registry.local:5000/nvidia/driver:580-6.8.0-60-generic-ubuntu24.04"
+)
+
+func newDriverBuildTestState(t *testing.T, images ...string) *stateDriver {
+	s := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(s))
+	require.NoError(t, nvidiav1alpha1.AddToScheme(s))
+	resolver := &fakeImageResolver{images: map[string]bool{}}
+	for _, img := range images {
+		resolver.images[img] = true
+	}
+	return &stateDriver{
+		stateSkel: stateSkel{
+			client:    fake.NewClientBuilder().WithScheme(s).Build(),
+			scheme:    s,
+			namespace: testBuildNamespace,
+		},
+		imageResolver: resolver,
+	}
+}
+
+func newDriverBuildTestDriver() *nvidiav1alpha1.NVIDIADriver {
+	cr := newPrecompiledTestDriver(nvidiav1alpha1.BuildFallback, nvidiav1alpha1.SkipFallback)
+	cr.UID = "0123"
+	cr.Spec.PrecompiledFallback.Build = &nvidiav1alpha1.DriverBuildSpec{
+		Repository: "registry.local:5000/nvidia",
+		Insecure:   true,
+		PushSecret: "registry-secret",
+	}
+	return cr
+}
+
+func TestGetPrecompiledKernelStatusBuild(t *testing.T) {
+	pool := nodePool{
+		name:         "ubuntu24.04-6.8.0-60-generic",
+		osTag:        "ubuntu24.04",
+		kernel:       "6.8.0-60-generic",
+		nodeSelector: map[string]string{"feature.node.kubernetes.io/kernel-version.full": "6.8.0-60-generic"},
+	}
+	precompiledImage := "nvcr.io/nvidia/driver:580-6.8.0-60-generic-ubuntu24.04"
+
+	t.Run("not configured", func(t *testing.T) {
+		s := newDriverBuildTestState(t)
+		cr := newDriverBuildTestDriver()
+		cr.Spec.PrecompiledFallback.Build = nil
+		status, err := s.getPrecompiledKernelStatus(context.Background(), cr, pool)
+		require.NoError(t, err)
+		require.Equal(t, nvidiav1alpha1.SkipFallback, status.Mode)
+		require.Equal(t, "precompiled driver image "+precompiledImage+" does not exist, driver image build not configured", status.Message)
+	})
+
+	t.Run("building", func(t *testing.T) {
+		s := newDriverBuildTestState(t)
+		s.client = fake.NewClientBuilder().WithScheme(s.scheme).WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "registry-secret", Namespace: testBuildNamespace},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{"registry.local:5000":{"username":"user","password":"pass"}}}`)},
+		}).Build()
+		cr := newDriverBuildTestDriver()
+		status, err := s.getPrecompiledKernelStatus(context.Background(), cr, pool)
+		require.NoError(t, err)
+		require.Equal(t, nvidiav1alpha1.BuildFallback, status.Mode)
+		require.Empty(t, status.Image)
+		require.NotEmpty(t, status.BuildJob)
+		require.Equal(t, "precompiled driver image "+precompiledImage+" does not exist, building driver image "+testBuiltImage, status.Message)
+
+		job := &batchv1.Job{}
+		require.NoError(t, s.client.Get(context.Background(), types.NamespacedName{Namespace: testBuildNamespace, Name: status.BuildJob}, job))
+		require.Equal(t, DriverBuildAppComponentLabelValue, job.Labels[AppComponentLabelKey])
+		require.Len(t, job.OwnerReferences, 1)
+		require.Equal(t, cr.Name, job.OwnerReferences[0].Name)
+
+		// The Job is not created again and its failure is reported
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}
+		require.NoError(t, s.client.Status().Update(context.Background(), job))
+		status, err = s.getPrecompiledKernelStatus(context.Background(), cr, pool)
+		require.NoError(t, err)
+		require.Equal(t, job.Name, status.BuildJob)
+		require.Contains(t, status.Message, "failed to build driver image")
+	})
+
+	t.Run("built", func(t *testing.T) {
+		s := newDriverBuildTestState(t, testBuiltImage)
+		cr := newDriverBuildTestDriver()
+		cr.Spec.PrecompiledFallback.Build.PushSecret = ""
+		jobName := getDriverBuildJobName(cr, cr.Spec.GetDriverBuildSpec(), testBuiltImage)
+		require.NoError(t, s.client.Create(context.Background(), newDriverBuildJob(cr, pool, testBuildNamespace, jobName, testBuiltImage)))
+
+		status, err := s.getPrecompiledKernelStatus(context.Background(), cr, pool)
+		require.NoError(t, err)
+		require.Equal(t, nvidiav1alpha1.BuildFallback, status.Mode)
+		require.Equal(t, testBuiltImage, status.Image)
+		require.Empty(t, status.BuildJob)
+
+		err = s.client.Get(context.Background(), types.NamespacedName{Namespace: testBuildNamespace, Name: jobName}, &batchv1.Job{})
+		require.True(t, apierrors.IsNotFound(err))
+	})
+}
+
+func TestNewDriverBuildJob(t *testing.T) {
+	pool := nodePool{
+		osTag:        "ubuntu24.04",
+		kernel:       "6.8.0-60-generic",
+		nodeSelector: map[string]string{"feature.node.kubernetes.io/kernel-version.full": "6.8.0-60-generic"},
+	}
+	cr := newDriverBuildTestDriver()
+	cr.Spec.Version = "580.95.05"
+	cr.Spec.ImagePullSecrets = []string{"pull-secret"}
+
+	job := newDriverBuildJob(cr, pool, testBuildNamespace, "nvidia-driver-build-abc", testBuiltImage)
+	podSpec := job.Spec.Template.Spec
+	require.Equal(t, corev1.RestartPolicyNever, podSpec.RestartPolicy)
+	require.Equal(t, pool.nodeSelector, podSpec.NodeSelector)
+	require.Equal(t, []corev1.LocalObjectReference{{Name: "pull-secret"}}, podSpec.ImagePullSecrets)
+	require.Len(t, podSpec.Containers, 1)
+	require.Equal(t, defaultDriverBuilderImage, podSpec.Containers[0].Image)
+	require.Equal(t, []string{
+		"--context=" + defaultDriverBuildContext,
+		"--context-sub-path=ubuntu24.04/precompiled",
+		"--destination=" + testBuiltImage,
+		"--build-arg=DRIVER_BRANCH=580",
+		"--build-arg=KERNEL_VERSION=6.8.0-60-generic",
+		"--build-arg=DRIVER_VERSION=580.95.05",
+		"--insecure",
+		"--skip-tls-verify",
+	}, podSpec.Containers[0].Args)
+	require.Equal(t, kanikoDockerConfigDir, podSpec.Containers[0].VolumeMounts[0].MountPath)
+	require.Equal(t, "registry-secret", podSpec.Volumes[0].Secret.SecretName)
+}
+
+func TestGetBuildDriver(t *testing.T) {
+	cr := newDriverBuildTestDriver()
+	buildCR := getBuildDriver(cr)
+	require.Equal(t, "registry.local:5000/nvidia", buildCR.Spec.Repository)
+	require.Equal(t, "nvcr.io/nvidia", cr.Spec.Repository)
+}
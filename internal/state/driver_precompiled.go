@@ -0,0 +1,183 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package state
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+	"github.com/NVIDIA/gpu-operator/internal/image"
+)
+
+// getPrecompiledKernelStatus returns how the driver is deployed to a node pool of a NVIDIADriver using precompiled
+// drivers. When the NVIDIADriver defines fallbacks and the precompiled driver image of the kernel does not exist,
+// the fallbacks are tried in order. The precompiled driver image is kept when no fallback applies.
+func (s *stateDriver) getPrecompiledKernelStatus(ctx context.Context, cr *nvidiav1alpha1.NVIDIADriver, pool nodePool) (nvidiav1alpha1.PrecompiledKernelStatus, error) {
+	status := nvidiav1alpha1.PrecompiledKernelStatus{
+		KernelVersion: pool.kernel,
+		OSVersion:     pool.osTag,
+		Mode:          nvidiav1alpha1.PrecompiledMode,
+	}
+	precompiledImage, err := getDriverImagePath(&cr.Spec, pool)
+	if err != nil {
+		return status, fmt.Errorf("failed to get driver image path: %w", err)
+	}
+	status.Image = precompiledImage
+
+	fallbacks := cr.Spec.GetPrecompiledFallbacks()
+	if len(fallbacks) == 0 {
+		return status, nil
+	}
+
+	creds, err := s.getImagePullCredentials(ctx, cr.Spec.ImagePullSecrets)
+	if err != nil {
+		return status, err
+	}
+	exists, err := s.imageExists(ctx, precompiledImage, creds)
+	if err != nil || exists {
+		return status, err
+	}
+
+	message := fmt.Sprintf("precompiled driver image %s does not exist", precompiledImage)
+	for _, fallback := range fallbacks {
+		switch fallback {
+		case nvidiav1alpha1.CompileFromSourceFallback:
+			sourceCR := getCompileFromSourceDriver(cr)
+			sourceImage, err := getDriverImagePath(&sourceCR.Spec, pool)
+			if err != nil {
+				return status, fmt.Errorf("failed to get driver image path: %w", err)
+			}
+			exists, err := s.imageExists(ctx, sourceImage, creds)
+			if err != nil {
+				return status, err
+			}
+			if exists {
+				status.Mode = nvidiav1alpha1.CompileFromSourceFallback
+				status.Image = sourceImage
+				status.Message = message
+				return status, nil
+			}
+			message = fmt.Sprintf("%s, driver image %s does not exist", message, sourceImage)
+		case nvidiav1alpha1.BuildFallback:
+			if cr.Spec.GetDriverBuildSpec() == nil {
+				message = fmt.Sprintf("%s, driver image build not configured", message)
+				continue
+			}
+			return s.getDriverBuildStatus(ctx, cr, pool, status, message, creds)
+		case nvidiav1alpha1.SkipFallback:
+			status.Mode = nvidiav1alpha1.SkipFallback
+			status.Image = ""
+			status.Message = message
+			return status, nil
+		}
+	}
+	status.Message = fmt.Sprintf("%s, no fallback applies", message)
+	return status, nil
+}
+
+// getCompileFromSourceDriver returns a copy of the NVIDIADriver deploying the driver image compiling the driver on
+// the node, of the fallback version when set
+func getCompileFromSourceDriver(cr *nvidiav1alpha1.NVIDIADriver) *nvidiav1alpha1.NVIDIADriver {
+	sourceCR := cr.DeepCopy()
+	sourceCR.Spec.UsePrecompiled = ptr.To(false)
+	if sourceCR.Spec.PrecompiledFallback != nil && sourceCR.Spec.PrecompiledFallback.Version != "" {
+		sourceCR.Spec.Version = sourceCR.Spec.PrecompiledFallback.Version
+	}
+	return sourceCR
+}
+
+// imageExists returns true if the image exists in the registry. Errors other than the absence of the image, e.g.
+// the registry being unreachable, are returned so that the deployed driver is not replaced on transient failures.
+func (s *stateDriver) imageExists(ctx context.Context, img string, creds []image.Credential) (bool, error) {
+	_, err := s.imageResolver.Resolve(ctx, img, creds)
+	if err == nil {
+		return true, nil
+	}
+	if image.IsNotFound(err) {
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to check the existence of image %s: %w", img, err)
+}
+
+// getImagePullCredentials returns the registry credentials of the given image pull secrets of the operator namespace
+func (s *stateDriver) getImagePullCredentials(ctx context.Context, pullSecrets []string) ([]image.Credential, error) {
+	var creds []image.Credential
+	for _, name := range pullSecrets {
+		secret := &corev1.Secret{}
+		err := s.client.Get(ctx, types.NamespacedName{Namespace: s.namespace, Name: name}, secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get image pull secret %s: %w", name, err)
+		}
+		if secret.Type != corev1.SecretTypeDockerConfigJson {
+			continue
+		}
+		secretCreds, err := image.CredentialsFromDockerConfigJSON(secret.Data[corev1.DockerConfigJsonKey])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse image pull secret %s: %w", name, err)
+		}
+		creds = append(creds, secretCreds...)
+	}
+	return creds, nil
+}
+
+// setPrecompiledUnavailableLabel sets or removes the label of the nodes of a node pool deployed no driver because
+// their precompiled driver image does not exist
+func (s *stateDriver) setPrecompiledUnavailableLabel(ctx context.Context, pool nodePool, unavailable bool) error {
+	logger := log.FromContext(ctx)
+
+	nodeList := &corev1.NodeList{}
+	if err := s.client.List(ctx, nodeList, client.MatchingLabels(pool.nodeSelector)); err != nil {
+		return fmt.Errorf("failed to list nodes of node pool %s: %w", pool.name, err)
+	}
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		_, labeled := node.Labels[consts.PrecompiledDriverUnavailableLabel]
+		if labeled == unavailable {
+			continue
+		}
+		patch := client.MergeFrom(node.DeepCopy())
+		if unavailable {
+			node.Labels[consts.PrecompiledDriverUnavailableLabel] = "true"
+		} else {
+			delete(node.Labels, consts.PrecompiledDriverUnavailableLabel)
+		}
+		logger.Info("Updating precompiled driver availability label", "Node", node.Name, "Unavailable", unavailable)
+		if err := s.client.Patch(ctx, node, patch); err != nil {
+			return fmt.Errorf("failed to update label %s of node %s: %w", consts.PrecompiledDriverUnavailableLabel, node.Name, err)
+		}
+	}
+	return nil
+}
+
+// sortPrecompiledKernelStatuses sorts the statuses by OS and kernel version, as the node pools are not ordered
+func sortPrecompiledKernelStatuses(statuses []nvidiav1alpha1.PrecompiledKernelStatus) {
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].OSVersion != statuses[j].OSVersion {
+			return statuses[i].OSVersion < statuses[j].OSVersion
+		}
+		return statuses[i].KernelVersion < statuses[j].KernelVersion
+	})
+}
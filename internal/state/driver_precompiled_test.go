@@ -0,0 +1,202 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/regclient/regclient/types/errs"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/consts"
+	"github.com/NVIDIA/gpu-operator/internal/image"
+)
+
+// fakeImageResolver resolves the images present in the registry and fails for the others
+type fakeImageResolver struct {
+	images map[string]bool
+	err    error
+}
+
+func (r *fakeImageResolver) Resolve(_ context.Context, img string, _ []image.Credential) (string, error) {
+	if r.err != nil {
+		return "", r.err
+	}
+	if !r.images[img] {
+		return "", fmt.Errorf("failed to get image manifest for %s: %w", img, errs.ErrNotFound)
+	}
+	return img + "@sha256:0123", nil
+}
+
+func newPrecompiledTestDriver(fallbacks ...nvidiav1alpha1.PrecompiledFallbackMode) *nvidiav1alpha1.NVIDIADriver {
+	return &nvidiav1alpha1.NVIDIADriver{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-driver"},
+		Spec: nvidiav1alpha1.NVIDIADriverSpec{
+			DriverType:     nvidiav1alpha1.GPU,
+			UsePrecompiled: ptr.To(true),
+			Repository:     "nvcr.io/nvidia",
+			Image:          "driver",
+			Version:        "580",
+			PrecompiledFallback: &nvidiav1alpha1.PrecompiledFallbackSpec{
+				Fallbacks: fallbacks,
+				Version:   "580.95.05",
+			},
+		},
+	}
+}
+
+func TestGetPrecompiledKernelStatus(t *testing.T) {
+	const (
+		precompiledImage = "nvcr.io/nvidia/driver:580-6.8.0-60-generic-ubuntu24.04"
+		sourceImage      = "nvcr.io/nvidia/driver:580.95.05-ubuntu24.04"
+	)
+	pool := nodePool{name: "ubuntu24.04-6.8.0-60-generic", osTag: "ubuntu24.04", kernel: "6.8.0-60-generic"}
+
+	testCases := []struct {
+		description string
+		fallbacks   []nvidiav1alpha1.PrecompiledFallbackMode
+		images      []string
+		expected    nvidiav1alpha1.PrecompiledKernelStatus
+	}{
+		{
+			description: "no fallbacks, the image is not checked",
+			expected:    nvidiav1alpha1.PrecompiledKernelStatus{Mode: nvidiav1alpha1.PrecompiledMode, Image: precompiledImage},
+		},
+		{
+			description: "precompiled image exists",
+			fallbacks:   []nvidiav1alpha1.PrecompiledFallbackMode{nvidiav1alpha1.CompileFromSourceFallback},
+			images:      []string{precompiledImage, sourceImage},
+			expected:    nvidiav1alpha1.PrecompiledKernelStatus{Mode: nvidiav1alpha1.PrecompiledMode, Image: precompiledImage},
+		},
+		{
+			description: "compile from source",
+			fallbacks:   []nvidiav1alpha1.PrecompiledFallbackMode{nvidiav1alpha1.CompileFromSourceFallback, nvidiav1alpha1.SkipFallback},
+			images:      []string{sourceImage},
+			expected: nvidiav1alpha1.PrecompiledKernelStatus{
+				Mode:    nvidiav1alpha1.CompileFromSourceFallback,
+				Image:   sourceImage,
+				Message: "precompiled driver image " + precompiledImage + " does not exist",
+			},
+		},
+		{
+			description: "skip when the source image does not exist either",
+			fallbacks:   []nvidiav1alpha1.PrecompiledFallbackMode{nvidiav1alpha1.CompileFromSourceFallback, nvidiav1alpha1.SkipFallback},
+			expected: nvidiav1alpha1.PrecompiledKernelStatus{
+				Mode:    nvidiav1alpha1.SkipFallback,
+				Message: "precompiled driver image " + precompiledImage + " does not exist, driver image " + sourceImage + " does not exist",
+			},
+		},
+		{
+			description: "no fallback applies",
+			fallbacks:   []nvidiav1alpha1.PrecompiledFallbackMode{nvidiav1alpha1.CompileFromSourceFallback},
+			expected: nvidiav1alpha1.PrecompiledKernelStatus{
+				Mode:    nvidiav1alpha1.PrecompiledMode,
+				Image:   precompiledImage,
+				Message: "precompiled driver image " + precompiledImage + " does not exist, driver image " + sourceImage + " does not exist, no fallback applies",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			resolver := &fakeImageResolver{images: map[string]bool{}}
+			for _, img := range tc.images {
+				resolver.images[img] = true
+			}
+			s := &stateDriver{
+				stateSkel:     stateSkel{client: fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()},
+				imageResolver: resolver,
+			}
+			status, err := s.getPrecompiledKernelStatus(context.Background(), newPrecompiledTestDriver(tc.fallbacks...), pool)
+			require.NoError(t, err)
+			tc.expected.KernelVersion = pool.kernel
+			tc.expected.OSVersion = pool.osTag
+			require.Equal(t, tc.expected, status)
+		})
+	}
+
+	t.Run("registry failure", func(t *testing.T) {
+		s := &stateDriver{
+			stateSkel:     stateSkel{client: fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()},
+			imageResolver: &fakeImageResolver{err: errors.New("connection refused")},
+		}
+		_, err := s.getPrecompiledKernelStatus(context.Background(), newPrecompiledTestDriver(nvidiav1alpha1.SkipFallback), pool)
+		require.Error(t, err)
+	})
+}
+
+func TestGetCompileFromSourceDriver(t *testing.T) {
+	cr := newPrecompiledTestDriver(nvidiav1alpha1.CompileFromSourceFallback)
+	sourceCR := getCompileFromSourceDriver(cr)
+	require.False(t, sourceCR.Spec.UsePrecompiledDrivers())
+	require.Equal(t, "580.95.05", sourceCR.Spec.Version)
+	require.True(t, cr.Spec.UsePrecompiledDrivers())
+	require.Equal(t, "580", cr.Spec.Version)
+
+	cr.Spec.PrecompiledFallback.Version = ""
+	require.Equal(t, "580", getCompileFromSourceDriver(cr).Spec.Version)
+}
+
+func TestSetPrecompiledUnavailableLabel(t *testing.T) {
+	pool := nodePool{
+		name:         "ubuntu24.04-6.8.0-60-generic",
+		nodeSelector: map[string]string{nfdKernelLabelKey: "6.8.0-60-generic"},
+	}
+	nodes := []*corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{nfdKernelLabelKey: "6.8.0-60-generic"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-b", Labels: map[string]string{nfdKernelLabelKey: "6.8.0-59-generic"}}},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(nodes[0], nodes[1]).Build()
+	s := &stateDriver{stateSkel: stateSkel{client: k8sClient}}
+
+	getLabels := func(name string) map[string]string {
+		node := &corev1.Node{}
+		require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Name: name}, node))
+		return node.Labels
+	}
+
+	require.NoError(t, s.setPrecompiledUnavailableLabel(context.Background(), pool, true))
+	require.Equal(t, "true", getLabels("node-a")[consts.PrecompiledDriverUnavailableLabel])
+	require.NotContains(t, getLabels("node-b"), consts.PrecompiledDriverUnavailableLabel)
+
+	require.NoError(t, s.setPrecompiledUnavailableLabel(context.Background(), pool, false))
+	require.NotContains(t, getLabels("node-a"), consts.PrecompiledDriverUnavailableLabel)
+}
+
+func TestSortPrecompiledKernelStatuses(t *testing.T) {
+	statuses := []nvidiav1alpha1.PrecompiledKernelStatus{
+		{OSVersion: "ubuntu24.04", KernelVersion: "6.8.0-60-generic"},
+		{OSVersion: "ubuntu22.04", KernelVersion: "5.15.0-140-generic"},
+		{OSVersion: "ubuntu24.04", KernelVersion: "6.8.0-59-generic"},
+	}
+	sortPrecompiledKernelStatuses(statuses)
+	require.Equal(t, []nvidiav1alpha1.PrecompiledKernelStatus{
+		{OSVersion: "ubuntu22.04", KernelVersion: "5.15.0-140-generic"},
+		{OSVersion: "ubuntu24.04", KernelVersion: "6.8.0-59-generic"},
+		{OSVersion: "ubuntu24.04", KernelVersion: "6.8.0-60-generic"},
+	}, statuses)
+}
@@ -19,6 +19,7 @@ package state
 import (
 	"bytes"
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -27,10 +28,12 @@ import (
 	configv1 "github.com/openshift/api/config/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer/json"
 	apitypes "k8s.io/apimachinery/pkg/types"
@@ -38,8 +41,11 @@ import (
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
 	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
 	"github.com/NVIDIA/gpu-operator/internal/consts"
+	"github.com/NVIDIA/gpu-operator/internal/image"
+	"github.com/NVIDIA/gpu-operator/internal/kernel"
 	"github.com/NVIDIA/gpu-operator/internal/render"
 )
 
@@ -199,6 +205,138 @@ func TestDriverHostSysDevicesSystemVolumeUsesStableParentDirectory(t *testing.T)
 	assert.Empty(t, hostSysDevicesSystemMount.SubPath)
 }
 
+func TestDriverStartupTolerations(t *testing.T) {
+	state, err := NewStateDriver(nil, "", nil, manifestDir)
+	require.Nil(t, err)
+	stateDriver, ok := state.(*stateDriver)
+	require.True(t, ok)
+
+	renderData := getMinimalDriverRenderData()
+	renderData.StartupTolerations = []corev1.Toleration{
+		{Key: "karpenter.sh/unregistered", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute},
+	}
+	objs, err := stateDriver.renderer.RenderObjects(&render.TemplatingData{Data: renderData})
+	require.Nil(t, err)
+	ds, err := getDaemonsetFromObjects(objs)
+	require.Nil(t, err)
+	assert.Equal(t, []corev1.Toleration{
+		{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+		{Key: "karpenter.sh/unregistered", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute},
+	}, ds.Spec.Template.Spec.Tolerations)
+}
+
+func TestDriverKernelFlavor(t *testing.T) {
+	state, err := NewStateDriver(nil, "", nil, manifestDir)
+	require.Nil(t, err)
+	stateDriver, ok := state.(*stateDriver)
+	require.True(t, ok)
+
+	getDriverContainerEnv := func(ds *appsv1.DaemonSet) map[string]string {
+		env := map[string]string{}
+		for _, ctr := range ds.Spec.Template.Spec.Containers {
+			if ctr.Name != "nvidia-driver-ctr" {
+				continue
+			}
+			for _, e := range ctr.Env {
+				env[e.Name] = e.Value
+			}
+		}
+		return env
+	}
+
+	renderData := getMinimalDriverRenderData()
+	renderData.KernelFlavor = &kernelFlavorSpec{Flavor: "realtime", HeadersPackages: "kernel-rt-devel,kernel-rt-core"}
+	objs, err := stateDriver.renderer.RenderObjects(&render.TemplatingData{Data: renderData})
+	require.Nil(t, err)
+	ds, err := getDaemonsetFromObjects(objs)
+	require.Nil(t, err)
+	env := getDriverContainerEnv(ds)
+	assert.Equal(t, "realtime", env["KERNEL_FLAVOR"])
+	assert.Equal(t, "kernel-rt-devel,kernel-rt-core", env["KERNEL_HEADERS_PACKAGES"])
+	assert.Nil(t, ds.Spec.Template.Spec.Affinity.NodeAffinity)
+
+	renderData.KernelFlavor = &kernelFlavorSpec{Flavor: "generic", ExcludedFlavors: []string{"realtime", "aws"}}
+	objs, err = stateDriver.renderer.RenderObjects(&render.TemplatingData{Data: renderData})
+	require.Nil(t, err)
+	ds, err = getDaemonsetFromObjects(objs)
+	require.Nil(t, err)
+	assert.NotContains(t, getDriverContainerEnv(ds), "KERNEL_FLAVOR")
+	require.NotNil(t, ds.Spec.Template.Spec.Affinity.NodeAffinity)
+	terms := ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	require.Len(t, terms, 1)
+	assert.Equal(t, []corev1.NodeSelectorRequirement{{
+		Key:      consts.KernelFlavorLabelKey,
+		Operator: corev1.NodeSelectorOpNotIn,
+		Values:   []string{"realtime", "aws"},
+	}}, terms[0].MatchExpressions)
+}
+
+func TestDriverKernelModuleParams(t *testing.T) {
+	state, err := NewStateDriver(nil, "", nil, manifestDir)
+	require.Nil(t, err)
+	stateDriver, ok := state.(*stateDriver)
+	require.True(t, ok)
+
+	renderData := getMinimalDriverRenderData()
+	objs, err := stateDriver.renderer.RenderObjects(&render.TemplatingData{Data: renderData})
+	require.Nil(t, err)
+	for _, obj := range objs {
+		assert.NotContains(t, obj.GetName(), "kernel-module-params")
+	}
+	digest := renderData.ConfigDigest()
+
+	cr := &nvidiav1alpha1.NVIDIADriver{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec: nvidiav1alpha1.NVIDIADriverSpec{
+			DriverType: nvidiav1alpha1.GPU,
+			KernelModuleConfig: &nvidiav1alpha1.KernelModuleConfigSpec{
+				NVIDIA:    map[string]string{"NVreg_EnableGpuFirmware": "0"},
+				NVIDIAUVM: map[string]string{"uvm_disable_hmm": "1"},
+			},
+		},
+	}
+	renderData.KernelModuleParams = getKernelModuleParamsSpec(cr)
+	require.NotNil(t, renderData.KernelModuleParams)
+	assert.Equal(t, "nvidia-gpu-driver-default-kernel-module-params", renderData.KernelModuleParams.ConfigMapName)
+	volumeMounts, volume := kernelModuleParamsVolume(renderData.KernelModuleParams, "/drivers")
+	renderData.AdditionalConfigs = &additionalConfigs{VolumeMounts: volumeMounts, Volumes: []corev1.Volume{volume}}
+
+	objs, err = stateDriver.renderer.RenderObjects(&render.TemplatingData{Data: renderData})
+	require.Nil(t, err)
+	var cm *corev1.ConfigMap
+	for _, obj := range objs {
+		if obj.GetKind() == "ConfigMap" && obj.GetName() == renderData.KernelModuleParams.ConfigMapName {
+			cm = &corev1.ConfigMap{}
+			require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, cm))
+		}
+	}
+	require.NotNil(t, cm)
+	assert.Equal(t, map[string]string{
+		"nvidia.conf":     "NVreg_EnableGpuFirmware=0\n",
+		"nvidia-uvm.conf": "uvm_disable_hmm=1\n",
+	}, cm.Data)
+
+	ds, err := getDaemonsetFromObjects(objs)
+	require.Nil(t, err)
+	for _, ctr := range ds.Spec.Template.Spec.Containers {
+		if ctr.Name != "nvidia-driver-ctr" {
+			continue
+		}
+		assert.Contains(t, ctr.VolumeMounts, corev1.VolumeMount{Name: "kernel-module-params", ReadOnly: true, MountPath: "/drivers/nvidia.conf", SubPath: "nvidia.conf"})
+		assert.Contains(t, ctr.VolumeMounts, corev1.VolumeMount{Name: "kernel-module-params", ReadOnly: true, MountPath: "/drivers/nvidia-uvm.conf", SubPath: "nvidia-uvm.conf"})
+	}
+
+	// the digest changes with the parameters, so that the driver pods are restarted
+	renderData.Driver.Spec.KernelModuleConfig = cr.Spec.KernelModuleConfig
+	withParams := renderData.ConfigDigest()
+	assert.NotEqual(t, digest, withParams)
+	renderData.Driver.Spec.KernelModuleConfig = &nvidiav1alpha1.KernelModuleConfigSpec{
+		NVIDIA:    map[string]string{"NVreg_EnableGpuFirmware": "1"},
+		NVIDIAUVM: map[string]string{"uvm_disable_hmm": "1"},
+	}
+	assert.NotEqual(t, withParams, renderData.ConfigDigest())
+}
+
 func TestDriverHostNetwork(t *testing.T) {
 	const (
 		testName = "driver-hostnetwork"
@@ -705,13 +843,92 @@ func TestGetNodePoolsDoesNotAllowSelectorToOverrideOwnerLabel(t *testing.T) {
 		},
 	}
 
-	nodePools, err := getNodePools(context.Background(), k8sClient, driver, false)
+	nodePools, _, err := getNodePools(context.Background(), k8sClient, driver, false)
 
 	require.NoError(t, err)
 	require.Len(t, nodePools, 1)
 	require.Equal(t, "driver-a", nodePools[0].nodeSelector[consts.NVIDIADriverOwnerLabel])
 }
 
+func TestDriverPinImageDigests(t *testing.T) {
+	require.NoError(t, corev1.AddToScheme(scheme.Scheme))
+
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name: "gpu-node",
+		Labels: map[string]string{
+			consts.GPUPresentLabel:        "true",
+			consts.NVIDIADriverOwnerLabel: "test-driver",
+			nfdOSReleaseIDLabelKey:        "ubuntu",
+			nfdOSVersionIDLabelKey:        "22.04",
+			nfdKernelLabelKey:             "5.15.0-105-generic",
+		},
+	}}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(node).Build()
+	state, err := NewStateDriver(k8sClient, "test-operator", scheme.Scheme, manifestDir)
+	require.NoError(t, err)
+	stateDriver, ok := state.(*stateDriver)
+	require.True(t, ok)
+
+	cr := &nvidiav1alpha1.NVIDIADriver{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-driver"},
+		Spec: nvidiav1alpha1.NVIDIADriverSpec{
+			DriverType: nvidiav1alpha1.GPU,
+			Repository: "nvcr.io/nvidia",
+			Image:      "driver",
+			Version:    "580.95.05",
+			Manager: nvidiav1alpha1.DriverManagerSpec{
+				Repository: "nvcr.io/nvidia/cloud-native",
+				Image:      "k8s-driver-manager",
+				Version:    "v0.8.1",
+			},
+		},
+	}
+	getImages := func(objs []*unstructured.Unstructured) []string {
+		ds, err := getDaemonsetFromObjects(objs)
+		require.NoError(t, err)
+		var images []string
+		for _, c := range append(ds.Spec.Template.Spec.InitContainers, ds.Spec.Template.Spec.Containers...) {
+			images = append(images, c.Image)
+		}
+		return images
+	}
+	getCatalog := func(policy gpuv1.ImageResolutionPolicy) InfoCatalog {
+		catalog := NewInfoCatalog()
+		catalog.Add(InfoTypeClusterInfo, testClusterInfo{})
+		catalog.Add(InfoTypeClusterPolicyCR, gpuv1.ClusterPolicy{Spec: gpuv1.ClusterPolicySpec{
+			ImageResolution: &gpuv1.ImageResolutionSpec{Policy: policy},
+		}})
+		return catalog
+	}
+
+	// the image tags are kept by default
+	stateDriver.imageResolver = &fakeImageResolver{err: errors.New("unexpected image resolution")}
+	objs, err := stateDriver.getManifestObjects(context.Background(), cr, getCatalog(gpuv1.ImageResolutionTag))
+	require.NoError(t, err)
+	tagged := getImages(objs)
+	require.NotEmpty(t, tagged)
+
+	// all images of the driver DaemonSet are pinned to their digests when the ClusterPolicy resolves them
+	resolver := &fakeImageResolver{images: map[string]bool{}}
+	for _, img := range tagged {
+		require.False(t, image.IsDigestReference(img), img)
+		resolver.images[img] = true
+	}
+	stateDriver.imageResolver = resolver
+	objs, err = stateDriver.getManifestObjects(context.Background(), cr, getCatalog(gpuv1.ImageResolutionDigest))
+	require.NoError(t, err)
+	pinned := getImages(objs)
+	require.Len(t, pinned, len(tagged))
+	for i, img := range pinned {
+		require.Equal(t, tagged[i]+"@sha256:0123", img)
+	}
+
+	// the DaemonSet is not rendered with mutable tags when the digests cannot be resolved
+	stateDriver.imageResolver = &fakeImageResolver{err: errors.New("connection refused")}
+	_, err = stateDriver.getManifestObjects(context.Background(), cr, getCatalog(gpuv1.ImageResolutionDigest))
+	require.Error(t, err)
+}
+
 func TestDriverPrecompiled(t *testing.T) {
 	const (
 		testName = "driver-precompiled"
@@ -804,6 +1021,46 @@ func TestGetDriverAppName(t *testing.T) {
 	assert.Equal(t, "nvidia-gpu-driver-rhel10-59b779bcc5", actual)
 }
 
+func TestGetDriverAppNameKernelFlavor(t *testing.T) {
+	cr := &nvidiav1alpha1.NVIDIADriver{
+		ObjectMeta: metav1.ObjectMeta{
+			UID: apitypes.UID("bfac7359-6033-45ce-88d6-53db0078526e"),
+		},
+		Spec: nvidiav1alpha1.NVIDIADriverSpec{
+			DriverType: nvidiav1alpha1.GPU,
+		},
+	}
+	pool := nodePool{osRelease: "ubuntu", osVersion: "20.04", osTag: "ubuntu20.04"}
+	genericAppName := getDriverAppName(cr, pool)
+
+	// the app name of the generic kernels is unchanged
+	pool.kernelFlavor = kernel.FlavorGeneric
+	assert.Equal(t, genericAppName, getDriverAppName(cr, pool))
+
+	pool.kernelFlavor = kernel.FlavorRealtime
+	realtimeAppName := getDriverAppName(cr, pool)
+	assert.NotEqual(t, genericAppName, realtimeAppName)
+	assert.True(t, strings.HasPrefix(realtimeAppName, "nvidia-gpu-driver-ubuntu20.04-"))
+}
+
+func TestGetKernelFlavorSpec(t *testing.T) {
+	cr := &nvidiav1alpha1.NVIDIADriver{}
+	pool := nodePool{osRelease: "rhel", kernelFlavor: kernel.FlavorRealtime}
+
+	spec := getKernelFlavorSpec(cr, pool, false)
+	require.Equal(t, &kernelFlavorSpec{Flavor: "realtime", HeadersPackages: "kernel-rt-devel,kernel-rt-core"}, spec)
+
+	pool.kernelFlavor = kernel.FlavorGeneric
+	spec = getKernelFlavorSpec(cr, pool, false)
+	require.Equal(t, "generic", spec.Flavor)
+	require.Empty(t, spec.HeadersPackages)
+	require.Contains(t, spec.ExcludedFlavors, "realtime")
+
+	require.Nil(t, getKernelFlavorSpec(cr, pool, true))
+	cr.Spec.UsePrecompiled = ptr.To(true)
+	require.Nil(t, getKernelFlavorSpec(cr, pool, false))
+}
+
 func TestGetDriverAppNameRHCOS(t *testing.T) {
 	cr := &nvidiav1alpha1.NVIDIADriver{
 		ObjectMeta: metav1.ObjectMeta{
@@ -1171,6 +1428,64 @@ func TestDriverSecretEnv(t *testing.T) {
 
 }
 
+func TestDriverModuleCache(t *testing.T) {
+	const (
+		testName = "driver-module-cache"
+	)
+
+	state, err := NewStateDriver(nil, "", nil, manifestDir)
+	require.Nil(t, err)
+	stateDriver, ok := state.(*stateDriver)
+	require.True(t, ok)
+
+	renderData := getMinimalDriverRenderData()
+	renderData.Driver.Spec.ModuleCache = &nvidiav1alpha1.DriverModuleCacheSpec{
+		Enabled:    ptr.To(true),
+		Repository: "registry.local:5000/nvidia/driver-modules",
+		SecretName: "module-cache-creds",
+	}
+	renderData.AdditionalConfigs = &additionalConfigs{
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      "module-cache-auth",
+				ReadOnly:  true,
+				MountPath: consts.ModuleCacheAuthMountPath,
+				SubPath:   consts.ModuleCacheAuthFileName,
+			},
+		},
+		Volumes: []corev1.Volume{
+			{
+				Name: "module-cache-auth",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: "module-cache-creds",
+						Items: []corev1.KeyToPath{
+							{
+								Key:  consts.ModuleCacheAuthFileName,
+								Path: consts.ModuleCacheAuthFileName,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	objs, err := stateDriver.renderer.RenderObjects(
+		&render.TemplatingData{
+			Data: renderData,
+		})
+	require.Nil(t, err)
+
+	actual, err := getYAMLString(objs)
+	require.Nil(t, err)
+
+	o, err := os.ReadFile(filepath.Join(manifestResultDir, testName+".yaml"))
+	require.Nil(t, err)
+
+	require.Equal(t, string(o), actual)
+}
+
 func TestGetSanitizedKernelVersion(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -1258,3 +1573,60 @@ func TestGetDriverSpecMultipleNodePools(t *testing.T) {
 	_, exists := spec2.Spec.NodeSelector["test-key"]
 	assert.False(t, exists)
 }
+
+func TestGetDriverSpecNodePoolOverrides(t *testing.T) {
+	cr := &nvidiav1alpha1.NVIDIADriver{
+		ObjectMeta: metav1.ObjectMeta{
+			UID: apitypes.UID("test-uid-overrides"),
+		},
+		Spec: nvidiav1alpha1.NVIDIADriverSpec{
+			DriverType: nvidiav1alpha1.GPU,
+			Repository: "nvcr.io/nvidia",
+			Image:      "driver",
+			Version:    "535.104.05",
+			Manager: nvidiav1alpha1.DriverManagerSpec{
+				Repository: "nvcr.io/nvidia/cloud-native",
+				Image:      "k8s-driver-manager",
+				Version:    "v0.6.2",
+			},
+			Env: []nvidiav1alpha1.EnvVar{{Name: "FOO", Value: "foo"}},
+		},
+	}
+	generic := nodePool{osRelease: "ubuntu", osVersion: "22.04", osTag: "ubuntu22.04", kernelFlavor: kernel.FlavorGeneric}
+	aws := nodePool{osRelease: "ubuntu", osVersion: "22.04", osTag: "ubuntu22.04", kernelFlavor: kernel.FlavorAWS}
+	rhel := nodePool{osRelease: "rhel", osVersion: "9.4", osTag: "rhel9.4", kernelFlavor: kernel.FlavorAWS}
+
+	digests := func() map[string]string {
+		result := map[string]string{}
+		for name, pool := range map[string]nodePool{"generic": generic, "aws": aws, "rhel": rhel} {
+			spec, err := getDriverSpec(cr, pool)
+			require.NoError(t, err)
+			result[name] = (&driverRenderData{Driver: spec}).ConfigDigest()
+		}
+		return result
+	}
+	before := digests()
+
+	cr.Spec.NodePoolOverrides = []nvidiav1alpha1.DriverNodePoolOverrideSpec{
+		{OS: "ubuntu22.04", KernelFlavor: "aws", Env: []nvidiav1alpha1.EnvVar{{Name: "FOO", Value: "bar"}, {Name: "BAZ", Value: "baz"}}},
+	}
+	spec, err := getDriverSpec(cr, aws)
+	require.NoError(t, err)
+	require.Equal(t, []nvidiav1alpha1.EnvVar{{Name: "FOO", Value: "bar"}, {Name: "BAZ", Value: "baz"}}, spec.Spec.Env)
+	require.Equal(t, []nvidiav1alpha1.EnvVar{{Name: "FOO", Value: "foo"}}, cr.Spec.Env)
+
+	// only the DaemonSet of the node pool of the override changes
+	after := digests()
+	require.NotEqual(t, before["aws"], after["aws"])
+	require.Equal(t, before["generic"], after["generic"])
+	require.Equal(t, before["rhel"], after["rhel"])
+
+	// the overrides without OS apply to the kernel flavor on all the OSes
+	cr.Spec.NodePoolOverrides[0].OS = ""
+	spec, err = getDriverSpec(cr, rhel)
+	require.NoError(t, err)
+	require.Equal(t, "bar", spec.Spec.Env[0].Value)
+	spec, err = getDriverSpec(cr, generic)
+	require.NoError(t, err)
+	require.Equal(t, []nvidiav1alpha1.EnvVar{{Name: "FOO", Value: "foo"}}, spec.Spec.Env)
+}
@@ -19,6 +19,7 @@ package state
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"sort"
 
 	corev1 "k8s.io/api/core/v1"
@@ -255,6 +256,13 @@ func (s *stateDriver) getDriverAdditionalConfigs(ctx context.Context, cr *v1alph
 		additionalCfgs.Volumes = append(additionalCfgs.Volumes, createConfigMapVolume(cr.Spec.KernelModuleConfig.Name, itemsToInclude))
 	}
 
+	// mount the kernel module parameters rendered from the NVIDIADriver spec at /drivers
+	if params := getKernelModuleParamsSpec(cr); params != nil {
+		volumeMounts, volume := kernelModuleParamsVolume(params, "/drivers")
+		additionalCfgs.VolumeMounts = append(additionalCfgs.VolumeMounts, volumeMounts...)
+		additionalCfgs.Volumes = append(additionalCfgs.Volumes, volume)
+	}
+
 	// set any licensing configuration required
 	if cr.Spec.IsVGPULicensingEnabled() {
 		licensingConfigVolMount := corev1.VolumeMount{Name: "licensing-config", ReadOnly: true,
@@ -323,6 +331,26 @@ func (s *stateDriver) getDriverAdditionalConfigs(ctx context.Context, cr *v1alph
 		additionalCfgs.Volumes = append(additionalCfgs.Volumes, topologyConfigVol)
 	}
 
+	// mount the registry credentials used for pushing and pulling cached kernel modules
+	if cr.Spec.IsModuleCacheEnabled() && cr.Spec.ModuleCache.SecretName != "" {
+		moduleCacheAuthVolMount := corev1.VolumeMount{Name: "module-cache-auth", ReadOnly: true, MountPath: consts.ModuleCacheAuthMountPath, SubPath: consts.ModuleCacheAuthFileName}
+		additionalCfgs.VolumeMounts = append(additionalCfgs.VolumeMounts, moduleCacheAuthVolMount)
+
+		moduleCacheAuthVolumeSource := corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: cr.Spec.ModuleCache.SecretName,
+				Items: []corev1.KeyToPath{
+					{
+						Key:  consts.ModuleCacheAuthFileName,
+						Path: consts.ModuleCacheAuthFileName,
+					},
+				},
+			},
+		}
+		moduleCacheAuthVol := corev1.Volume{Name: "module-cache-auth", VolumeSource: moduleCacheAuthVolumeSource}
+		additionalCfgs.Volumes = append(additionalCfgs.Volumes, moduleCacheAuthVol)
+	}
+
 	return additionalCfgs, nil
 }
 
@@ -350,3 +378,26 @@ func getSubscriptionPathsToVolumeSources(os string) (map[string]corev1.VolumeSou
 	}
 	return nil, fmt.Errorf("distribution %s not supported", os)
 }
+
+// kernelModuleParamsVolume returns the volume of the rendered kernel module parameters ConfigMap and the mounts of
+// its files in the destination directory
+func kernelModuleParamsVolume(params *kernelModuleParamsSpec, destinationDir string) ([]corev1.VolumeMount, corev1.Volume) {
+	const volumeName = "kernel-module-params"
+
+	filenames := make([]string, 0, len(params.Files))
+	for filename := range params.Files {
+		filenames = append(filenames, filename)
+	}
+	// sort so volume mounts are added to spec in deterministic order
+	sort.Strings(filenames)
+	var itemsToInclude []corev1.KeyToPath
+	var volumeMounts []corev1.VolumeMount
+	for _, filename := range filenames {
+		volumeMounts = append(volumeMounts,
+			corev1.VolumeMount{Name: volumeName, ReadOnly: true, MountPath: filepath.Join(destinationDir, filename), SubPath: filename})
+		itemsToInclude = append(itemsToInclude, corev1.KeyToPath{Key: filename, Path: filename})
+	}
+	volume := createConfigMapVolume(params.ConfigMapName, itemsToInclude)
+	volume.Name = volumeName
+	return volumeMounts, volume
+}
@@ -19,13 +19,16 @@ package state
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/NVIDIA/gpu-operator/internal/assets"
 	"github.com/NVIDIA/gpu-operator/internal/consts"
+	"github.com/NVIDIA/gpu-operator/internal/tracing"
 )
 
 type Manager interface {
@@ -41,8 +44,13 @@ type stateManager struct {
 
 var _ Manager = (*stateManager)(nil)
 
-func NewManager(crdKind string, namespace string, k8sClient client.Client, scheme *runtime.Scheme) (Manager, error) {
-	states, err := newStates(crdKind, namespace, k8sClient, scheme)
+// NewManager returns the state manager of the given CRD kind, whose manifests are loaded from the manifests
+// directory of assetsDir, or of the baked-in assets when empty
+func NewManager(crdKind string, namespace string, k8sClient client.Client, scheme *runtime.Scheme, assetsDir string) (Manager, error) {
+	if assetsDir == "" {
+		assetsDir = assets.DefaultDir
+	}
+	states, err := newStates(crdKind, namespace, k8sClient, scheme, filepath.Join(assetsDir, "manifests"))
 	if err != nil {
 		return nil, fmt.Errorf("failed to add states: %v", err)
 	}
@@ -86,7 +94,10 @@ func (m *stateManager) SyncState(ctx context.Context, customResource interface{}
 	for _, state := range m.states {
 		logger.V(consts.LogLevelInfo).Info("Sync State", "Name", state.Name(), "Description", state.Description())
 		stateCtx := log.IntoContext(ctx, logger.WithName("state").WithName(state.Name()))
+		stateCtx, span := tracing.Start(stateCtx, "state "+state.Name(), tracing.StateKey.String(state.Name()))
 		ss, err := state.Sync(stateCtx, customResource, infoCatalog)
+		span.SetAttributes(tracing.ResultKey.String(string(ss)))
+		tracing.End(span, err)
 		result := Result{StateName: state.Name(), Status: ss, ErrInfo: err}
 		managerResult.StatesStatus = append(managerResult.StatesStatus, result)
 
@@ -110,20 +121,20 @@ func (m *stateManager) SyncState(ctx context.Context, customResource interface{}
 	return managerResult
 }
 
-func newStates(crdKind string, namespace string, k8sClient client.Client, scheme *runtime.Scheme) ([]State, error) {
+func newStates(crdKind string, namespace string, k8sClient client.Client, scheme *runtime.Scheme, manifestsDir string) ([]State, error) {
 	switch crdKind {
 	case nvidiav1alpha1.NVIDIADriverCRDName:
-		return newNVIDIADriverStates(k8sClient, namespace, scheme)
+		return newNVIDIADriverStates(k8sClient, namespace, scheme, manifestsDir)
 	case nvidiav1alpha1.GPUClusterCRDName:
-		return newGPUClusterStates(k8sClient, namespace, scheme)
+		return newGPUClusterStates(k8sClient, namespace, scheme, manifestsDir)
 	default:
 		break
 	}
 	return nil, fmt.Errorf("unsupported CRD for state manager factory: %s", crdKind)
 }
 
-func newNVIDIADriverStates(k8sClient client.Client, namespace string, scheme *runtime.Scheme) ([]State, error) {
-	driverState, err := NewStateDriver(k8sClient, namespace, scheme, "/opt/gpu-operator/manifests/state-driver")
+func newNVIDIADriverStates(k8sClient client.Client, namespace string, scheme *runtime.Scheme, manifestsDir string) ([]State, error) {
+	driverState, err := NewStateDriver(k8sClient, namespace, scheme, filepath.Join(manifestsDir, "state-driver"))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create NVIDIA driver state: %v", err)
 	}
@@ -132,21 +143,21 @@ func newNVIDIADriverStates(k8sClient client.Client, namespace string, scheme *ru
 }
 
 // newGPUClusterStates returns the states reconciled for a GPUCluster.
-func newGPUClusterStates(k8sClient client.Client, namespace string, scheme *runtime.Scheme) ([]State, error) {
+func newGPUClusterStates(k8sClient client.Client, namespace string, scheme *runtime.Scheme, manifestsDir string) ([]State, error) {
 	operands := []struct {
 		name        string
 		manifestDir string
 		newState    func(client.Client, string, *runtime.Scheme, string) (State, error)
 	}{
-		{"DRA driver", "/opt/gpu-operator/manifests/state-dra-driver", NewStateDRADriver},
-		{"DCGM", "/opt/gpu-operator/manifests/state-dcgm", NewStateDCGM},
-		{"DCGM Exporter", "/opt/gpu-operator/manifests/state-dcgm-exporter", NewStateDCGMExporter},
-		{"DRA validator", "/opt/gpu-operator/manifests/state-dra-validation", NewStateDRAValidation},
+		{"DRA driver", "state-dra-driver", NewStateDRADriver},
+		{"DCGM", "state-dcgm", NewStateDCGM},
+		{"DCGM Exporter", "state-dcgm-exporter", NewStateDCGMExporter},
+		{"DRA validator", "state-dra-validation", NewStateDRAValidation},
 	}
 
 	states := make([]State, 0, len(operands))
 	for _, operand := range operands {
-		state, err := operand.newState(k8sClient, namespace, scheme, operand.manifestDir)
+		state, err := operand.newState(k8sClient, namespace, scheme, filepath.Join(manifestsDir, operand.manifestDir))
 		if err != nil {
 			return nil, fmt.Errorf("failed to create %s state: %v", operand.name, err)
 		}
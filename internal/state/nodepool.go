@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"sort"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -28,6 +29,7 @@ import (
 
 	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
 	"github.com/NVIDIA/gpu-operator/internal/consts"
+	"github.com/NVIDIA/gpu-operator/internal/kernel"
 )
 
 const (
@@ -44,6 +46,7 @@ type nodePool struct {
 	osTag        string
 	rhcosVersion string
 	kernel       string
+	kernelFlavor kernel.Flavor
 	nodeSelector map[string]string
 }
 
@@ -53,12 +56,18 @@ type nodePool struct {
 // Nodes can be partitioned in the following ways:
 //  1. When precompiled drivers are enabled, we create one node pool per osVersion-kernelVersion pair.
 //  2. When running on OpenShift and precompiled is disabled, we create one node pool per rhcosVersion.
-//  3. Otherwise, we create one node pool per osVersion.
+//  3. Otherwise, we create one node pool per osVersion and kernel flavor, the generic kernels sharing
+//     the node pool named after the osVersion.
 //
 // Each nodePool object contains information needed to identify the corresonding node pool.
 // Most importantly, it contains a nodeSelector used to identify the node pool.
-func getNodePools(ctx context.Context, k8sClient client.Client, cr *nvidiav1alpha1.NVIDIADriver, openshift bool) ([]nodePool, error) {
+//
+// Nodes missing the NFD labels needed to partition them are quarantined rather than failing the
+// partitioning of the other nodes. They are returned sorted by name with the reason of the quarantine,
+// and join a node pool once the labels are set, as the label update triggers a new reconciliation.
+func getNodePools(ctx context.Context, k8sClient client.Client, cr *nvidiav1alpha1.NVIDIADriver, openshift bool) ([]nodePool, []nvidiav1alpha1.QuarantinedNode, error) {
 	nodePoolMap := make(map[string]nodePool)
+	var quarantined []nvidiav1alpha1.QuarantinedNode
 
 	logger := log.FromContext(ctx)
 
@@ -71,57 +80,77 @@ func getNodePools(ctx context.Context, k8sClient client.Client, cr *nvidiav1alph
 	err := k8sClient.List(ctx, nodeList, client.MatchingLabels(nodeSelector))
 	if err != nil {
 		logger.Error(err, "failed to list nodes")
-		return nil, err
+		return nil, nil, err
 	}
 
 	for _, node := range nodeList.Items {
 		node := node
 		nodeLabels := node.GetLabels()
 
+		requiredLabels := []string{nfdOSReleaseIDLabelKey, nfdOSVersionIDLabelKey}
+		if cr.Spec.UsePrecompiledDrivers() {
+			requiredLabels = append(requiredLabels, nfdKernelLabelKey)
+		} else if openshift {
+			requiredLabels = append(requiredLabels, nfdOSTreeVersionLabelKey)
+		}
+		if missing := getMissingLabels(nodeLabels, requiredLabels); len(missing) > 0 {
+			logger.Info("WARNING: Could not find NFD labels for node. Is NFD installed?", "Node", node.Name, "MissingLabels", missing)
+			quarantined = append(quarantined, nvidiav1alpha1.QuarantinedNode{
+				Name:   node.Name,
+				Reason: fmt.Sprintf("missing NFD labels: %s", strings.Join(missing, ", ")),
+			})
+			continue
+		}
+
 		nodePool := nodePool{}
 		nodePool.nodeSelector = make(map[string]string)
 		maps.Copy(nodePool.nodeSelector, nodeSelector)
 
-		osID, ok := nodeLabels[nfdOSReleaseIDLabelKey]
-		if !ok {
-			logger.Info("WARNING: Could not find NFD labels for node. Is NFD installed?", "Node", node.Name)
-			continue
-		}
+		osID := nodeLabels[nfdOSReleaseIDLabelKey]
 		nodePool.nodeSelector[nfdOSReleaseIDLabelKey] = osID
 
-		osVersion, ok := nodeLabels[nfdOSVersionIDLabelKey]
-		if !ok {
-			logger.Info("WARNING: Could not find NFD labels for node. Is NFD installed?", "Node", node.Name)
-			continue
-		}
+		osVersion := nodeLabels[nfdOSVersionIDLabelKey]
 		nodePool.nodeSelector[nfdOSVersionIDLabelKey] = osVersion
 		nodePool.osRelease = osID
 		nodePool.osVersion = osVersion
 
 		osTag, err := getOSTag(osID, osVersion)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get OS info for node %s: %w", node.Name, err)
+			logger.Info("WARNING: Could not get OS info for node", "Node", node.Name, "Error", err)
+			quarantined = append(quarantined, nvidiav1alpha1.QuarantinedNode{
+				Name:   node.Name,
+				Reason: fmt.Sprintf("failed to get OS info: %v", err),
+			})
+			continue
 		}
 		nodePool.osTag = osTag
 		nodePool.name = osTag
 
 		if cr.Spec.UsePrecompiledDrivers() {
-			kernelVersion, ok := nodeLabels[nfdKernelLabelKey]
-			if !ok {
-				logger.Info("WARNING: Could not find NFD labels for node. Is NFD installed?", "Node", node.Name)
-				continue
-			}
+			kernelVersion := nodeLabels[nfdKernelLabelKey]
 			nodePool.nodeSelector[nfdKernelLabelKey] = kernelVersion
 			nodePool.kernel = kernelVersion
 			nodePool.name = fmt.Sprintf("%s-%s", nodePool.name, getSanitizedKernelVersion(kernelVersion))
 		}
 
-		if !cr.Spec.UsePrecompiledDrivers() && openshift {
-			rhcosVersion, ok := nodeLabels[nfdOSTreeVersionLabelKey]
-			if !ok {
-				logger.Info("WARNING: Could not find NFD labels for node. Is NFD installed?", "Node", node.Name)
+		nodePool.kernelFlavor = kernel.GetFlavor(nodeLabels[nfdKernelLabelKey])
+		if !cr.Spec.UsePrecompiledDrivers() && !openshift && nodePool.kernelFlavor != kernel.FlavorGeneric {
+			// the DaemonSet of the node pool selects the nodes by the kernel flavor label, which the nodes
+			// get from the node labeling controller
+			if nodeLabels[consts.KernelFlavorLabelKey] != string(nodePool.kernelFlavor) {
+				logger.Info("WARNING: Kernel flavor label not set for node", "Node", node.Name, "KernelFlavor", nodePool.kernelFlavor)
+				quarantined = append(quarantined, nvidiav1alpha1.QuarantinedNode{
+					Name:   node.Name,
+					Reason: fmt.Sprintf("label %s not set to kernel flavor %s", consts.KernelFlavorLabelKey, nodePool.kernelFlavor),
+				})
 				continue
 			}
+			nodePool.nodeSelector[consts.KernelFlavorLabelKey] = string(nodePool.kernelFlavor)
+			nodePool.name = fmt.Sprintf("%s-%s", nodePool.name, nodePool.kernelFlavor)
+		}
+
+		if !cr.Spec.UsePrecompiledDrivers() && openshift {
+			rhcosVersion := nodeLabels[nfdOSTreeVersionLabelKey]
 			nodePool.nodeSelector[nfdOSTreeVersionLabelKey] = rhcosVersion
 			nodePool.rhcosVersion = rhcosVersion
 			nodePool.name = rhcosVersion
@@ -137,8 +166,22 @@ func getNodePools(ctx context.Context, k8sClient client.Client, cr *nvidiav1alph
 	for _, nodePool := range nodePoolMap {
 		nodePools = append(nodePools, nodePool)
 	}
+	sort.Slice(quarantined, func(i, j int) bool {
+		return quarantined[i].Name < quarantined[j].Name
+	})
+
+	return nodePools, quarantined, nil
+}
 
-	return nodePools, nil
+// getMissingLabels returns the labels of the given keys which are unset or empty
+func getMissingLabels(nodeLabels map[string]string, keys []string) []string {
+	var missing []string
+	for _, key := range keys {
+		if nodeLabels[key] == "" {
+			missing = append(missing, key)
+		}
+	}
+	return missing
 }
 
 func getOSTag(osRelease, osVersion string) (string, error) {
@@ -29,6 +29,7 @@ import (
 
 	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
 	"github.com/NVIDIA/gpu-operator/internal/consts"
+	"github.com/NVIDIA/gpu-operator/internal/kernel"
 )
 
 func TestGetOSTag(t *testing.T) {
@@ -150,7 +151,7 @@ func TestGetNodePoolsGroupsNodesByOSTag(t *testing.T) {
 		},
 	}
 
-	nodePools, err := getNodePools(context.Background(), k8sClient, driver, false)
+	nodePools, _, err := getNodePools(context.Background(), k8sClient, driver, false)
 
 	require.NoError(t, err)
 	require.Len(t, nodePools, 2)
@@ -195,10 +196,54 @@ func TestGetNodePoolsSkipsNodesMissingNFDOSLabels(t *testing.T) {
 		ObjectMeta: metav1.ObjectMeta{Name: "driver-a"},
 	}
 
-	nodePools, err := getNodePools(context.Background(), k8sClient, driver, false)
+	nodePools, quarantined, err := getNodePools(context.Background(), k8sClient, driver, false)
 
 	require.NoError(t, err)
 	require.Empty(t, nodePools)
+	require.Equal(t, []nvidiav1alpha1.QuarantinedNode{
+		{Name: "missing-os-release", Reason: "missing NFD labels: " + nfdOSReleaseIDLabelKey},
+		{Name: "missing-os-version", Reason: "missing NFD labels: " + nfdOSVersionIDLabelKey},
+	}, quarantined)
+}
+
+func TestGetNodePoolsQuarantinesNodesWithEmptyNFDLabels(t *testing.T) {
+	require.NoError(t, corev1.AddToScheme(scheme.Scheme))
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name: "labeled-node",
+				Labels: map[string]string{
+					consts.GPUPresentLabel:        "true",
+					consts.NVIDIADriverOwnerLabel: "driver-a",
+					nfdOSReleaseIDLabelKey:        "ubuntu",
+					nfdOSVersionIDLabelKey:        "22.04",
+				},
+			}},
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name: "empty-labels-node",
+				Labels: map[string]string{
+					consts.GPUPresentLabel:        "true",
+					consts.NVIDIADriverOwnerLabel: "driver-a",
+					nfdOSReleaseIDLabelKey:        "",
+					nfdOSVersionIDLabelKey:        "",
+				},
+			}},
+		).
+		Build()
+	driver := &nvidiav1alpha1.NVIDIADriver{
+		ObjectMeta: metav1.ObjectMeta{Name: "driver-a"},
+	}
+
+	nodePools, quarantined, err := getNodePools(context.Background(), k8sClient, driver, false)
+
+	require.NoError(t, err)
+	require.Len(t, nodePools, 1)
+	require.Equal(t, "ubuntu22.04", nodePools[0].name)
+	require.Equal(t, []nvidiav1alpha1.QuarantinedNode{
+		{Name: "empty-labels-node", Reason: "missing NFD labels: " + nfdOSReleaseIDLabelKey + ", " + nfdOSVersionIDLabelKey},
+	}, quarantined)
 }
 
 func TestGetNodePoolsPartitionsPrecompiledNodesByKernel(t *testing.T) {
@@ -235,10 +280,13 @@ func TestGetNodePoolsPartitionsPrecompiledNodesByKernel(t *testing.T) {
 		},
 	}
 
-	nodePools, err := getNodePools(context.Background(), k8sClient, driver, false)
+	nodePools, quarantined, err := getNodePools(context.Background(), k8sClient, driver, false)
 
 	require.NoError(t, err)
 	require.Len(t, nodePools, 1)
+	require.Equal(t, []nvidiav1alpha1.QuarantinedNode{
+		{Name: "missing-kernel-node", Reason: "missing NFD labels: " + nfdKernelLabelKey},
+	}, quarantined)
 	require.Equal(t, "ubuntu22.04-5.15.0-70-generic", nodePools[0].name)
 	require.Equal(t, "5.15.0-70-generic_x86_64", nodePools[0].kernel)
 	require.Equal(t, "5.15.0-70-generic_x86_64", nodePools[0].nodeSelector[nfdKernelLabelKey])
@@ -275,7 +323,7 @@ func TestGetNodePoolsPartitionsOpenShiftNodesByRHCOSVersion(t *testing.T) {
 		ObjectMeta: metav1.ObjectMeta{Name: "driver-a"},
 	}
 
-	nodePools, err := getNodePools(context.Background(), k8sClient, driver, true)
+	nodePools, _, err := getNodePools(context.Background(), k8sClient, driver, true)
 
 	require.NoError(t, err)
 	require.Len(t, nodePools, 1)
@@ -284,6 +332,48 @@ func TestGetNodePoolsPartitionsOpenShiftNodesByRHCOSVersion(t *testing.T) {
 	require.Equal(t, "414.92.202309282257", nodePools[0].nodeSelector[nfdOSTreeVersionLabelKey])
 }
 
+func TestGetNodePoolsPartitionsNodesByKernelFlavor(t *testing.T) {
+	require.NoError(t, corev1.AddToScheme(scheme.Scheme))
+
+	newNode := func(name, kernelVersion, flavor string) *corev1.Node {
+		labels := map[string]string{
+			consts.GPUPresentLabel:        "true",
+			consts.NVIDIADriverOwnerLabel: "driver-a",
+			nfdOSReleaseIDLabelKey:        "ubuntu",
+			nfdOSVersionIDLabelKey:        "24.04",
+			nfdKernelLabelKey:             kernelVersion,
+		}
+		if flavor != "" {
+			labels[consts.KernelFlavorLabelKey] = flavor
+		}
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(
+			newNode("generic-node", "6.8.0-60-generic", "generic"),
+			newNode("realtime-node", "6.8.1-1015-realtime", "realtime"),
+			newNode("unlabeled-realtime-node", "6.8.1-1015-realtime", ""),
+		).
+		Build()
+	driver := &nvidiav1alpha1.NVIDIADriver{
+		ObjectMeta: metav1.ObjectMeta{Name: "driver-a"},
+	}
+
+	nodePools, quarantined, err := getNodePools(context.Background(), k8sClient, driver, false)
+
+	require.NoError(t, err)
+	require.Equal(t, []nvidiav1alpha1.QuarantinedNode{
+		{Name: "unlabeled-realtime-node", Reason: "label " + consts.KernelFlavorLabelKey + " not set to kernel flavor realtime"},
+	}, quarantined)
+	poolsByName := nodePoolsByName(nodePools)
+	require.Len(t, poolsByName, 2)
+	require.Equal(t, kernel.FlavorGeneric, poolsByName["ubuntu24.04"].kernelFlavor)
+	require.NotContains(t, poolsByName["ubuntu24.04"].nodeSelector, consts.KernelFlavorLabelKey)
+	require.Equal(t, kernel.FlavorRealtime, poolsByName["ubuntu24.04-realtime"].kernelFlavor)
+	require.Equal(t, "realtime", poolsByName["ubuntu24.04-realtime"].nodeSelector[consts.KernelFlavorLabelKey])
+}
+
 func nodePoolsByName(nodePools []nodePool) map[string]nodePool {
 	poolsByName := make(map[string]nodePool, len(nodePools))
 	for _, pool := range nodePools {
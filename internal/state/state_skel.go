@@ -314,8 +314,10 @@ func (s *stateSkel) createOrUpdateObjs(
 		s.addStateSpecificLabels(desiredObj)
 
 		var desiredObjectHash string
+		var legacyHash func() string
 		if desiredObj.GetKind() == "DaemonSet" {
-			desiredObjectHash = utils.GetObjectHash(desiredObj)
+			desiredObjectHash = utils.GetObjectDigest(desiredObj)
+			legacyHash = legacyObjectHash(desiredObj)
 			annotations := desiredObj.GetAnnotations()
 			if annotations == nil {
 				annotations = make(map[string]string)
@@ -342,7 +344,7 @@ func (s *stateSkel) createOrUpdateObjs(
 
 		if desiredObj.GetKind() == "DaemonSet" {
 			if currentObjHash, ok := currentObj.GetAnnotations()[consts.NvidiaAnnotationHashKey]; ok {
-				if desiredObjectHash == currentObjHash {
+				if utils.ObjectHashMatches(currentObjHash, desiredObjectHash, legacyHash) {
 					reqLogger.V(consts.LogLevelDebug).Info("Object is unchanged, so skipping update",
 						"Kind", desiredObj.GetKind(), "Name", desiredObj.GetName())
 					continue
@@ -362,6 +364,21 @@ func (s *stateSkel) createOrUpdateObjs(
 	return nil
 }
 
+// legacyObjectHash returns the function computing the legacy hash of the object, as stored in the last applied
+// hash annotation by the previous releases of the operator, from the object without the annotation
+func legacyObjectHash(obj *unstructured.Unstructured) func() string {
+	return func() string {
+		unannotated := obj.DeepCopy()
+		annotations := unannotated.GetAnnotations()
+		delete(annotations, consts.NvidiaAnnotationHashKey)
+		if len(annotations) == 0 {
+			annotations = nil
+		}
+		unannotated.SetAnnotations(annotations)
+		return utils.GetObjectHash(unannotated)
+	}
+}
+
 func (s *stateSkel) addStateSpecificLabels(obj *unstructured.Unstructured) {
 	labels := obj.GetLabels()
 	if labels == nil {
@@ -111,6 +111,8 @@ type dcgmExporterRenderData struct {
 	MetricsConfigName            string
 	ServiceMonitorEnabled        bool
 	PodResourcesDir              string
+	PodAttributionDisabled       bool
+	GPUIDType                    string
 	ServiceType                  string
 	ServiceInternalTrafficPolicy string
 }
@@ -0,0 +1,69 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package testutil
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiav1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+)
+
+// PodNodeNameIndexKey is the key of the index of the pods by node name, registered by the operator manager
+const PodNodeNameIndexKey = "spec.nodeName"
+
+// NewScheme returns a scheme registering the Kubernetes APIs and the APIs of the operator
+func NewScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
+	utilruntime.Must(gpuv1.AddToScheme(scheme))
+	utilruntime.Must(nvidiav1alpha1.AddToScheme(scheme))
+	return scheme
+}
+
+// NewFakeClientBuilder returns a builder of fake clients registering the APIs of the operator, serving the status
+// of the custom resources of the operator as a subresource and indexing the pods by node name as the operator
+// manager does
+func NewFakeClientBuilder() *fake.ClientBuilder {
+	return fake.NewClientBuilder().
+		WithScheme(NewScheme()).
+		WithStatusSubresource(
+			&gpuv1.ClusterPolicy{},
+			&nvidiav1alpha1.NVIDIADriver{},
+			&nvidiav1alpha1.GPUCluster{},
+			&nvidiav1alpha1.GPUAllocationPolicy{},
+		).
+		WithIndex(&corev1.Pod{}, PodNodeNameIndexKey, func(obj client.Object) []string {
+			pod := obj.(*corev1.Pod)
+			if pod.Spec.NodeName == "" {
+				return nil
+			}
+			return []string{pod.Spec.NodeName}
+		})
+}
+
+// NewFakeClient returns a fake client built by NewFakeClientBuilder, holding the given objects
+func NewFakeClient(objs ...client.Object) client.WithWatch {
+	return NewFakeClientBuilder().WithObjects(objs...).Build()
+}
@@ -0,0 +1,103 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package testutil
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+// DefaultClusterPolicyName is the name of the ClusterPolicies built by NewClusterPolicy
+const DefaultClusterPolicyName = "cluster-policy"
+
+// sampleClusterPolicyPath is the path of the sample ClusterPolicy, relative to the root of the repository
+const sampleClusterPolicyPath = "config/samples/v1_clusterpolicy.yaml"
+
+// ClusterPolicyOption customizes a ClusterPolicy built by NewClusterPolicy
+type ClusterPolicyOption func(*gpuv1.ClusterPolicy)
+
+// NewClusterPolicy returns a ClusterPolicy with an empty spec, the defaults of the operator applying, and the given
+// options applied
+func NewClusterPolicy(opts ...ClusterPolicyOption) *gpuv1.ClusterPolicy {
+	cp := &gpuv1.ClusterPolicy{
+		TypeMeta:   metav1.TypeMeta{APIVersion: gpuv1.SchemeGroupVersion.String(), Kind: "ClusterPolicy"},
+		ObjectMeta: metav1.ObjectMeta{Name: DefaultClusterPolicyName},
+	}
+	for _, opt := range opts {
+		opt(cp)
+	}
+	return cp
+}
+
+// WithClusterPolicyName sets the name of the ClusterPolicy
+func WithClusterPolicyName(name string) ClusterPolicyOption {
+	return func(cp *gpuv1.ClusterPolicy) {
+		cp.Name = name
+	}
+}
+
+// WithSpec sets the spec of the ClusterPolicy
+func WithSpec(spec gpuv1.ClusterPolicySpec) ClusterPolicyOption {
+	return func(cp *gpuv1.ClusterPolicy) {
+		cp.Spec = spec
+	}
+}
+
+// WithSpecUpdate updates the spec of the ClusterPolicy in place, e.g. to enable a component of the sample spec
+func WithSpecUpdate(update func(spec *gpuv1.ClusterPolicySpec)) ClusterPolicyOption {
+	return func(cp *gpuv1.ClusterPolicy) {
+		update(&cp.Spec)
+	}
+}
+
+// WithClusterPolicyNodeSelector scopes the ClusterPolicy to the GPU nodes matching the node selector
+func WithClusterPolicyNodeSelector(nodeSelector map[string]string) ClusterPolicyOption {
+	return func(cp *gpuv1.ClusterPolicy) {
+		cp.Spec.NodeSelector = nodeSelector
+	}
+}
+
+// SampleClusterPolicy returns the sample ClusterPolicy of config/samples, which configures all the operands, with the
+// given options applied. The images of the operands are not set by the sample.
+func SampleClusterPolicy(opts ...ClusterPolicyOption) (*gpuv1.ClusterPolicy, error) {
+	path := filepath.Join(RepositoryRoot(), sampleClusterPolicyPath)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the sample ClusterPolicy: %w", err)
+	}
+	cp := &gpuv1.ClusterPolicy{}
+	if err := yaml.Unmarshal(data, cp); err != nil {
+		return nil, fmt.Errorf("error decoding the sample ClusterPolicy %s: %w", path, err)
+	}
+	for _, opt := range opts {
+		opt(cp)
+	}
+	return cp, nil
+}
+
+// RepositoryRoot returns the root directory of the repository, the fixtures being read from the source tree
+func RepositoryRoot() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..")
+}
@@ -0,0 +1,71 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package testutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+)
+
+// envtestAssetsEnvName is the environment variable holding the directory of the binaries of the API server and of
+// etcd started by envtest, as installed by setup-envtest
+const envtestAssetsEnvName = "KUBEBUILDER_ASSETS"
+
+// CRDDirectory returns the directory of the CRDs of the operator
+func CRDDirectory() string {
+	return filepath.Join(RepositoryRoot(), "config", "crd", "bases")
+}
+
+// NewEnvironment returns an envtest environment installing the CRDs of the operator
+func NewEnvironment() *envtest.Environment {
+	return &envtest.Environment{
+		CRDDirectoryPaths:     []string{CRDDirectory()},
+		ErrorIfCRDPathMissing: true,
+	}
+}
+
+// StartEnvironment starts an API server with the CRDs of the operator installed, stopped once the test completes,
+// and returns its configuration along with a client registering the APIs of the operator. The test is skipped when
+// the envtest binaries are not installed, KUBEBUILDER_ASSETS being unset.
+func StartEnvironment(t testing.TB) (*rest.Config, client.Client) {
+	t.Helper()
+	if os.Getenv(envtestAssetsEnvName) == "" {
+		t.Skipf("%s is not set, install the envtest binaries with setup-envtest", envtestAssetsEnvName)
+	}
+
+	env := NewEnvironment()
+	cfg, err := env.Start()
+	if err != nil {
+		t.Fatalf("error starting the envtest environment: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := env.Stop(); err != nil {
+			t.Errorf("error stopping the envtest environment: %v", err)
+		}
+	})
+
+	c, err := client.New(cfg, client.Options{Scheme: NewScheme()})
+	if err != nil {
+		t.Fatalf("error creating the client of the envtest environment: %v", err)
+	}
+	return cfg, c
+}
@@ -0,0 +1,134 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package testutil provides the fixtures of the tests of the operator: builders of the nodes labeled by NFD and of
+// the ClusterPolicies, a fake client registering the operator APIs, and the helpers starting an API server with the
+// CRDs of the operator installed through envtest. The package does not depend on the controllers, so that the tests
+// of the controllers can use it; the fake ClusterPolicyController is provided by the controllers/controllertest
+// package, built on the constructor hooks exported by the controllers.
+package testutil
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Labels set on the nodes by Node Feature Discovery and by GPU Feature Discovery
+const (
+	NFDNvidiaPCILabelKey   = "feature.node.kubernetes.io/pci-10de.present"
+	NFDKernelLabelKey      = "feature.node.kubernetes.io/kernel-version.full"
+	NFDOSReleaseIDLabelKey = "feature.node.kubernetes.io/system-os_release.ID"
+	NFDOSVersionIDLabelKey = "feature.node.kubernetes.io/system-os_release.VERSION_ID"
+	GPUPresentLabelKey     = "nvidia.com/gpu.present"
+	GPUProductLabelKey     = "nvidia.com/gpu.product"
+	GPUCountLabelKey       = "nvidia.com/gpu.count"
+)
+
+// NodeOption customizes a node built by NewNode
+type NodeOption func(*corev1.Node)
+
+// NewNode returns a node with the given options applied
+func NewNode(name string, opts ...NodeOption) *corev1.Node {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{corev1.LabelHostname: name},
+		},
+	}
+	for _, opt := range opts {
+		opt(node)
+	}
+	return node
+}
+
+// NewGPUNode returns a node with an NVIDIA GPU, labeled by NFD as running Ubuntu 22.04 and labeled with
+// nvidia.com/gpu.present as done by the operator. The options are applied after the default labels.
+func NewGPUNode(name string, opts ...NodeOption) *corev1.Node {
+	defaults := []NodeOption{
+		WithNFDLabels("ubuntu", "22.04", "5.15.0-105-generic"),
+		WithLabels(map[string]string{NFDNvidiaPCILabelKey: "true", GPUPresentLabelKey: "true"}),
+	}
+	return NewNode(name, append(defaults, opts...)...)
+}
+
+// WithLabels sets labels on the node
+func WithLabels(labels map[string]string) NodeOption {
+	return func(node *corev1.Node) {
+		if node.Labels == nil {
+			node.Labels = make(map[string]string, len(labels))
+		}
+		for key, value := range labels {
+			node.Labels[key] = value
+		}
+	}
+}
+
+// WithoutLabels removes labels from the node
+func WithoutLabels(keys ...string) NodeOption {
+	return func(node *corev1.Node) {
+		for _, key := range keys {
+			delete(node.Labels, key)
+		}
+	}
+}
+
+// WithAnnotations sets annotations on the node
+func WithAnnotations(annotations map[string]string) NodeOption {
+	return func(node *corev1.Node) {
+		if node.Annotations == nil {
+			node.Annotations = make(map[string]string, len(annotations))
+		}
+		for key, value := range annotations {
+			node.Annotations[key] = value
+		}
+	}
+}
+
+// WithNFDLabels sets the OS and the kernel version labels of NFD on the node
+func WithNFDLabels(osID, osVersion, kernelVersion string) NodeOption {
+	return WithLabels(map[string]string{
+		NFDOSReleaseIDLabelKey: osID,
+		NFDOSVersionIDLabelKey: osVersion,
+		NFDKernelLabelKey:      kernelVersion,
+	})
+}
+
+// WithGPUs sets the GPU product and count labels of GPU Feature Discovery on the node, along with the allocatable
+// GPUs advertised by the device plugin
+func WithGPUs(product string, count int) NodeOption {
+	return func(node *corev1.Node) {
+		WithLabels(map[string]string{GPUProductLabelKey: product, GPUCountLabelKey: strconv.Itoa(count)})(node)
+		quantity := *resource.NewQuantity(int64(count), resource.DecimalSI)
+		if node.Status.Capacity == nil {
+			node.Status.Capacity = corev1.ResourceList{}
+		}
+		if node.Status.Allocatable == nil {
+			node.Status.Allocatable = corev1.ResourceList{}
+		}
+		node.Status.Capacity["nvidia.com/gpu"] = quantity
+		node.Status.Allocatable["nvidia.com/gpu"] = quantity
+	}
+}
+
+// WithTaints adds taints to the node
+func WithTaints(taints ...corev1.Taint) NodeOption {
+	return func(node *corev1.Node) {
+		node.Spec.Taints = append(node.Spec.Taints, taints...)
+	}
+}
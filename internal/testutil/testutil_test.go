@@ -0,0 +1,85 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package testutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+)
+
+func TestNewGPUNode(t *testing.T) {
+	node := NewGPUNode("gpu-node",
+		WithGPUs("NVIDIA-A100-SXM4-80GB", 8),
+		WithoutLabels(NFDKernelLabelKey),
+		WithAnnotations(map[string]string{"example.com/owner": "team-a"}),
+	)
+	require.Equal(t, "gpu-node", node.Labels[corev1.LabelHostname])
+	require.Equal(t, "true", node.Labels[NFDNvidiaPCILabelKey])
+	require.Equal(t, "true", node.Labels[GPUPresentLabelKey])
+	require.Equal(t, "ubuntu", node.Labels[NFDOSReleaseIDLabelKey])
+	require.NotContains(t, node.Labels, NFDKernelLabelKey)
+	require.Equal(t, "8", node.Labels[GPUCountLabelKey])
+	require.Equal(t, int64(8), node.Status.Allocatable.Name("nvidia.com/gpu", "").Value())
+	require.Equal(t, "team-a", node.Annotations["example.com/owner"])
+
+	require.NotContains(t, NewNode("cpu-node").Labels, NFDNvidiaPCILabelKey)
+}
+
+func TestSampleClusterPolicy(t *testing.T) {
+	cp, err := SampleClusterPolicy(WithClusterPolicyName("sample"))
+	require.NoError(t, err)
+	require.Equal(t, "sample", cp.Name)
+	require.True(t, cp.Spec.Driver.IsEnabled())
+	require.NotNil(t, cp.Spec.Driver.UpgradePolicy)
+}
+
+func TestNewFakeClient(t *testing.T) {
+	ctx := context.Background()
+	cp := NewClusterPolicy(WithClusterPolicyNodeSelector(map[string]string{"team": "a"}))
+	c := NewFakeClient(cp, NewGPUNode("gpu-node"))
+
+	// the status is served as a subresource
+	cp.Status.State = gpuv1.Ready
+	require.NoError(t, c.Status().Update(ctx, cp))
+	cp.Spec.NodeSelector = nil
+	require.NoError(t, c.Status().Update(ctx, cp))
+	stored := &gpuv1.ClusterPolicy{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: DefaultClusterPolicyName}, stored))
+	require.Equal(t, gpuv1.Ready, stored.Status.State)
+	require.Equal(t, map[string]string{"team": "a"}, stored.Spec.NodeSelector)
+
+	// the pods are indexed by node name
+	pod := &corev1.Pod{}
+	pod.Name, pod.Namespace, pod.Spec.NodeName = "pod", "default", "gpu-node"
+	require.NoError(t, c.Create(ctx, pod))
+	pods := &corev1.PodList{}
+	require.NoError(t, c.List(ctx, pods, client.MatchingFields{PodNodeNameIndexKey: "gpu-node"}))
+	require.Len(t, pods.Items, 1)
+}
+
+func TestStartEnvironment(t *testing.T) {
+	_, c := StartEnvironment(t)
+	ctx := context.Background()
+	require.NoError(t, c.Create(ctx, NewClusterPolicy()))
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: DefaultClusterPolicyName}, &gpuv1.ClusterPolicy{}))
+}
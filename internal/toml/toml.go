@@ -0,0 +1,682 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package toml decodes TOML v1.0.0 documents, such as the configuration files of containerd and cri-o, into
+// generic values. Tables are decoded as map[string]any, arrays as []any, strings as string, integers as int64,
+// floats as float64 and booleans as bool. Date and time values are decoded as their string representation.
+package toml
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Unmarshal decodes the TOML document in data into its root table
+func Unmarshal(data []byte) (map[string]any, error) {
+	p := &parser{
+		src:         string(data),
+		root:        make(map[string]any),
+		defined:     make(map[uintptr]bool),
+		frozen:      make(map[uintptr]bool),
+		arrayTables: make(map[arrayTableKey]bool),
+	}
+	p.current = p.root
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	return p.root, nil
+}
+
+// Table returns the table at the path of keys below table, or nil if a key is missing or not a table
+func Table(table map[string]any, keys ...string) map[string]any {
+	for _, key := range keys {
+		next, ok := table[key].(map[string]any)
+		if !ok {
+			return nil
+		}
+		table = next
+	}
+	return table
+}
+
+type parser struct {
+	src string
+	pos int
+
+	root    map[string]any
+	current map[string]any
+	// defined holds the tables defined by a table header or a dotted key, which cannot be defined again
+	defined map[uintptr]bool
+	// frozen holds the inline tables, which cannot be extended
+	frozen map[uintptr]bool
+	// arrayTables holds the arrays of tables defined by array of tables headers, which unlike the arrays
+	// defined as values can be extended
+	arrayTables map[arrayTableKey]bool
+}
+
+// arrayTableKey identifies an array of tables by its parent table and key
+type arrayTableKey struct {
+	table uintptr
+	key   string
+}
+
+func tableID(table map[string]any) uintptr {
+	return reflect.ValueOf(table).Pointer()
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	line := strings.Count(p.src[:p.pos], "\n") + 1
+	return fmt.Errorf("toml: line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *parser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *parser) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(p.src[p.pos:], prefix)
+}
+
+func (p *parser) skipWhitespace() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.pos++
+	}
+}
+
+// skipComment skips a comment up to, but excluding, the end of the line
+func (p *parser) skipComment() error {
+	if p.peek() != '#' {
+		return nil
+	}
+	for !p.eof() && p.peek() != '\n' {
+		if c := p.peek(); c < 0x20 && c != '\t' && !p.hasPrefix("\r\n") || c == 0x7f {
+			return p.errorf("control character %q in comment", c)
+		}
+		p.pos++
+	}
+	return nil
+}
+
+// skipNewline consumes a newline, returning false if there is none at the current position
+func (p *parser) skipNewline() bool {
+	switch {
+	case p.hasPrefix("\n"):
+		p.pos++
+	case p.hasPrefix("\r\n"):
+		p.pos += 2
+	default:
+		return false
+	}
+	return true
+}
+
+// endOfLine consumes the rest of a line holding an expression, which may only contain whitespace and a comment
+func (p *parser) endOfLine() error {
+	p.skipWhitespace()
+	if err := p.skipComment(); err != nil {
+		return err
+	}
+	if !p.eof() && !p.skipNewline() {
+		return p.errorf("unexpected %q at the end of the line", p.peek())
+	}
+	return nil
+}
+
+// skipArrayWhitespace skips the whitespace, newlines and comments allowed between the values of an array
+func (p *parser) skipArrayWhitespace() error {
+	for {
+		p.skipWhitespace()
+		if err := p.skipComment(); err != nil {
+			return err
+		}
+		if !p.skipNewline() {
+			return nil
+		}
+	}
+}
+
+func (p *parser) parse() error {
+	for {
+		p.skipWhitespace()
+		if p.eof() {
+			return nil
+		}
+		var err error
+		switch p.peek() {
+		case '#', '\n', '\r':
+		case '[':
+			err = p.parseTableHeader()
+		default:
+			err = p.parseKeyValue(p.current)
+		}
+		if err != nil {
+			return err
+		}
+		if err := p.endOfLine(); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *parser) parseTableHeader() error {
+	arrayTable := p.hasPrefix("[[")
+	closing := "]"
+	if arrayTable {
+		closing = "]]"
+	}
+	p.pos += len(closing)
+	p.skipWhitespace()
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	p.skipWhitespace()
+	if !p.hasPrefix(closing) {
+		return p.errorf("expected %q at the end of the table header", closing)
+	}
+	p.pos += len(closing)
+
+	table := p.root
+	for _, key := range keys[:len(keys)-1] {
+		if table, err = p.descend(table, key, false); err != nil {
+			return err
+		}
+	}
+	name := strings.Join(keys, ".")
+	key := keys[len(keys)-1]
+	existing, exists := table[key]
+
+	if arrayTable {
+		id := arrayTableKey{table: tableID(table), key: key}
+		if exists && !p.arrayTables[id] {
+			return p.errorf("%s is not an array of tables", name)
+		}
+		array, _ := existing.([]any)
+		next := make(map[string]any)
+		table[key] = append(array, next)
+		p.arrayTables[id] = true
+		p.current = next
+		return nil
+	}
+
+	next, ok := existing.(map[string]any)
+	switch {
+	case !exists:
+		next = make(map[string]any)
+		table[key] = next
+	case !ok:
+		return p.errorf("%s is already defined as a value", name)
+	case p.defined[tableID(next)] || p.frozen[tableID(next)]:
+		return p.errorf("table %s is already defined", name)
+	}
+	p.defined[tableID(next)] = true
+	p.current = next
+	return nil
+}
+
+// descend returns the table under key, creating it if needed. Within an array of tables, the last table is
+// returned. dotted is set when descending through the keys of a dotted key, which define the tables they create.
+func (p *parser) descend(table map[string]any, key string, dotted bool) (map[string]any, error) {
+	switch existing := table[key].(type) {
+	case nil:
+		next := make(map[string]any)
+		table[key] = next
+		if dotted {
+			p.defined[tableID(next)] = true
+		}
+		return next, nil
+	case map[string]any:
+		if p.frozen[tableID(existing)] {
+			return nil, p.errorf("inline table %s cannot be extended", key)
+		}
+		return existing, nil
+	case []any:
+		if dotted || !p.arrayTables[arrayTableKey{table: tableID(table), key: key}] {
+			return nil, p.errorf("array %s cannot be extended", key)
+		}
+		return existing[len(existing)-1].(map[string]any), nil
+	default:
+		return nil, p.errorf("%s is already defined as a value", key)
+	}
+}
+
+func (p *parser) parseKeyValue(table map[string]any) error {
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	p.skipWhitespace()
+	if p.peek() != '=' {
+		return p.errorf("expected '=' after the key %s", strings.Join(keys, "."))
+	}
+	p.pos++
+	p.skipWhitespace()
+	value, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys[:len(keys)-1] {
+		if table, err = p.descend(table, key, true); err != nil {
+			return err
+		}
+	}
+	key := keys[len(keys)-1]
+	if _, exists := table[key]; exists {
+		return p.errorf("key %s is already defined", strings.Join(keys, "."))
+	}
+	table[key] = value
+	return nil
+}
+
+// parseKey parses a simple or dotted key into its keys
+func (p *parser) parseKey() ([]string, error) {
+	var keys []string
+	for {
+		p.skipWhitespace()
+		var key string
+		var err error
+		switch c := p.peek(); {
+		case c == '"':
+			key, err = p.parseBasicString()
+		case c == '\'':
+			key, err = p.parseLiteralString()
+		default:
+			start := p.pos
+			for !p.eof() && isBareKeyChar(p.peek()) {
+				p.pos++
+			}
+			if p.pos == start {
+				return nil, p.errorf("invalid key starting with %q", c)
+			}
+			key = p.src[start:p.pos]
+		}
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+		p.skipWhitespace()
+		if p.peek() != '.' {
+			return keys, nil
+		}
+		p.pos++
+	}
+}
+
+func isBareKeyChar(c byte) bool {
+	return c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+func (p *parser) parseValue() (any, error) {
+	switch {
+	case p.hasPrefix(`"""`):
+		return p.parseMultilineBasicString()
+	case p.hasPrefix(`"`):
+		return p.parseBasicString()
+	case p.hasPrefix("'''"):
+		return p.parseMultilineLiteralString()
+	case p.hasPrefix("'"):
+		return p.parseLiteralString()
+	case p.hasPrefix("["):
+		return p.parseArray()
+	case p.hasPrefix("{"):
+		return p.parseInlineTable()
+	default:
+		return p.parseScalar()
+	}
+}
+
+func (p *parser) parseBasicString() (string, error) {
+	p.pos++
+	var b strings.Builder
+	for {
+		if p.eof() || p.peek() == '\n' || p.hasPrefix("\r\n") {
+			return "", p.errorf("unterminated string")
+		}
+		switch c := p.peek(); {
+		case c == '"':
+			p.pos++
+			return b.String(), nil
+		case c == '\\':
+			if err := p.parseEscape(&b); err != nil {
+				return "", err
+			}
+		default:
+			if err := p.parseChar(&b); err != nil {
+				return "", err
+			}
+		}
+	}
+}
+
+func (p *parser) parseMultilineBasicString() (string, error) {
+	p.pos += 3
+	p.skipNewline()
+	var b strings.Builder
+	for {
+		switch {
+		case p.eof():
+			return "", p.errorf("unterminated multi-line string")
+		case p.hasPrefix(`"""`):
+			// up to two quotes are allowed right before the closing delimiter
+			for i := 0; i < 2 && p.hasPrefix(`""""`); i++ {
+				b.WriteByte('"')
+				p.pos++
+			}
+			p.pos += 3
+			return b.String(), nil
+		case p.hasPrefix("\\"):
+			if p.lineEndingBackslash() {
+				continue
+			}
+			if err := p.parseEscape(&b); err != nil {
+				return "", err
+			}
+		case p.hasPrefix("\n"), p.hasPrefix("\r\n"):
+			p.skipNewline()
+			b.WriteByte('\n')
+		default:
+			if err := p.parseChar(&b); err != nil {
+				return "", err
+			}
+		}
+	}
+}
+
+// lineEndingBackslash consumes a backslash ending a line of a multi-line basic string, along with the
+// whitespace and newlines following it. Returns false if the backslash does not end the line.
+func (p *parser) lineEndingBackslash() bool {
+	pos := p.pos + 1
+	for pos < len(p.src) && (p.src[pos] == ' ' || p.src[pos] == '\t') {
+		pos++
+	}
+	if pos < len(p.src) && p.src[pos] != '\n' && !strings.HasPrefix(p.src[pos:], "\r\n") {
+		return false
+	}
+	for pos < len(p.src) && strings.ContainsRune(" \t\r\n", rune(p.src[pos])) {
+		pos++
+	}
+	p.pos = pos
+	return true
+}
+
+func (p *parser) parseEscape(b *strings.Builder) error {
+	p.pos++
+	if p.eof() {
+		return p.errorf("unterminated string")
+	}
+	c := p.peek()
+	p.pos++
+	switch c {
+	case 'b':
+		b.WriteByte('\b')
+	case 't':
+		b.WriteByte('\t')
+	case 'n':
+		b.WriteByte('\n')
+	case 'f':
+		b.WriteByte('\f')
+	case 'r':
+		b.WriteByte('\r')
+	case '"':
+		b.WriteByte('"')
+	case '\\':
+		b.WriteByte('\\')
+	case 'u', 'U':
+		digits := 4
+		if c == 'U' {
+			digits = 8
+		}
+		if p.pos+digits > len(p.src) {
+			return p.errorf("invalid unicode escape")
+		}
+		code, err := strconv.ParseUint(p.src[p.pos:p.pos+digits], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return p.errorf("invalid unicode escape %q", p.src[p.pos:p.pos+digits])
+		}
+		b.WriteRune(rune(code))
+		p.pos += digits
+	default:
+		return p.errorf("invalid escape sequence \\%c", c)
+	}
+	return nil
+}
+
+// parseChar copies a character of a string, rejecting the control characters other than tab
+func (p *parser) parseChar(b *strings.Builder) error {
+	r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+	if r == utf8.RuneError && size <= 1 {
+		return p.errorf("invalid UTF-8 in string")
+	}
+	if r < 0x20 && r != '\t' || r == 0x7f {
+		return p.errorf("control character %q in string", r)
+	}
+	b.WriteRune(r)
+	p.pos += size
+	return nil
+}
+
+func (p *parser) parseLiteralString() (string, error) {
+	p.pos++
+	start := p.pos
+	for !p.eof() && p.peek() != '\'' {
+		if c := p.peek(); c == '\n' || c < 0x20 && c != '\t' || c == 0x7f {
+			return "", p.errorf("unterminated string")
+		}
+		p.pos++
+	}
+	if p.eof() {
+		return "", p.errorf("unterminated string")
+	}
+	value := p.src[start:p.pos]
+	p.pos++
+	return value, nil
+}
+
+func (p *parser) parseMultilineLiteralString() (string, error) {
+	p.pos += 3
+	p.skipNewline()
+	end := strings.Index(p.src[p.pos:], "'''")
+	if end < 0 {
+		p.pos = len(p.src)
+		return "", p.errorf("unterminated multi-line string")
+	}
+	end += p.pos
+	// up to two quotes are allowed right before the closing delimiter
+	for i := 0; i < 2 && strings.HasPrefix(p.src[end:], "''''"); i++ {
+		end++
+	}
+	value := strings.ReplaceAll(p.src[p.pos:end], "\r\n", "\n")
+	p.pos = end + 3
+	return value, nil
+}
+
+func (p *parser) parseArray() ([]any, error) {
+	p.pos++
+	array := []any{}
+	for {
+		if err := p.skipArrayWhitespace(); err != nil {
+			return nil, err
+		}
+		if p.peek() == ']' {
+			p.pos++
+			return array, nil
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		array = append(array, value)
+		if err := p.skipArrayWhitespace(); err != nil {
+			return nil, err
+		}
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ']':
+		default:
+			return nil, p.errorf("expected ',' or ']' in array")
+		}
+	}
+}
+
+func (p *parser) parseInlineTable() (map[string]any, error) {
+	p.pos++
+	table := make(map[string]any)
+	p.skipWhitespace()
+	if p.peek() == '}' {
+		p.pos++
+		p.frozen[tableID(table)] = true
+		return table, nil
+	}
+	for {
+		if err := p.parseKeyValue(table); err != nil {
+			return nil, err
+		}
+		p.skipWhitespace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			p.freeze(table)
+			return table, nil
+		default:
+			return nil, p.errorf("expected ',' or '}' in inline table")
+		}
+	}
+}
+
+// freeze marks an inline table, and the tables defined by dotted keys within it, as not extensible
+func (p *parser) freeze(table map[string]any) {
+	p.frozen[tableID(table)] = true
+	for _, value := range table {
+		if nested, ok := value.(map[string]any); ok {
+			p.freeze(nested)
+		}
+	}
+}
+
+// parseScalar parses a boolean, number or date and time value
+func (p *parser) parseScalar() (any, error) {
+	start := p.pos
+	for !p.eof() && isScalarChar(p.peek()) {
+		p.pos++
+	}
+	// a space may separate the date and the time of a date and time value
+	if p.pos-start == 10 && p.src[start+4] == '-' && p.peek() == ' ' &&
+		p.pos+1 < len(p.src) && p.src[p.pos+1] >= '0' && p.src[p.pos+1] <= '9' {
+		p.pos++
+		for !p.eof() && isScalarChar(p.peek()) {
+			p.pos++
+		}
+	}
+	token := p.src[start:p.pos]
+	switch token {
+	case "":
+		return nil, p.errorf("missing value")
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "inf", "+inf":
+		return math.Inf(1), nil
+	case "-inf":
+		return math.Inf(-1), nil
+	case "nan", "+nan", "-nan":
+		return math.NaN(), nil
+	}
+	if isDateTime(token) {
+		return token, nil
+	}
+	if strings.HasPrefix(token, "0x") || strings.HasPrefix(token, "0o") || strings.HasPrefix(token, "0b") {
+		digits, err := p.stripUnderscores(token[2:])
+		if err != nil {
+			return nil, err
+		}
+		base := map[byte]int{'x': 16, 'o': 8, 'b': 2}[token[1]]
+		value, err := strconv.ParseInt(digits, base, 64)
+		if err != nil {
+			return nil, p.errorf("invalid integer %s", token)
+		}
+		return value, nil
+	}
+
+	number, err := p.stripUnderscores(token)
+	if err != nil {
+		return nil, err
+	}
+	unsigned := strings.TrimLeft(number, "+-")
+	if len(unsigned) > 1 && unsigned[0] == '0' && unsigned[1] >= '0' && unsigned[1] <= '9' {
+		return nil, p.errorf("leading zero in number %s", token)
+	}
+	if strings.ContainsAny(number, ".eE") {
+		if strings.HasPrefix(unsigned, ".") || strings.Contains(number, "._") || strings.HasSuffix(number, ".") ||
+			strings.Contains(number, ".e") || strings.Contains(number, ".E") {
+			return nil, p.errorf("invalid float %s", token)
+		}
+		value, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			return nil, p.errorf("invalid float %s", token)
+		}
+		return value, nil
+	}
+	value, err := strconv.ParseInt(number, 10, 64)
+	if err != nil {
+		return nil, p.errorf("invalid value %s", token)
+	}
+	return value, nil
+}
+
+func isScalarChar(c byte) bool {
+	return isBareKeyChar(c) || c == '+' || c == '.' || c == ':'
+}
+
+// stripUnderscores removes the underscores separating the digits of a number
+func (p *parser) stripUnderscores(token string) (string, error) {
+	if strings.HasPrefix(token, "_") || strings.HasSuffix(token, "_") || strings.Contains(token, "__") {
+		return "", p.errorf("invalid number %s", token)
+	}
+	for i := strings.IndexByte(token, '_'); i >= 0; i = strings.IndexByte(token, '_') {
+		if !isDigit(token[i-1]) || !isDigit(token[i+1]) {
+			return "", p.errorf("invalid number %s", token)
+		}
+		token = token[:i] + token[i+1:]
+	}
+	return token, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+// isDateTime returns true if the token is a local date (1979-05-27), a local time (07:32:00) or a date and time
+func isDateTime(token string) bool {
+	isDate := len(token) >= 10 && token[4] == '-' && token[7] == '-'
+	isTime := len(token) >= 8 && token[2] == ':' && token[5] == ':'
+	return isDate || isTime
+}
@@ -0,0 +1,199 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package toml
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshal(t *testing.T) {
+	testCases := []struct {
+		description string
+		document    string
+		expected    map[string]any
+	}{
+		{
+			description: "containerd configuration",
+			document: `version = 2
+imports = ["/etc/containerd/conf.d/*.toml"] # drop-in files
+
+[plugins]
+  [plugins."io.containerd.grpc.v1.cri"]
+    enable_cdi = true
+    cdi_spec_dirs = [
+      "/etc/cdi",
+      "/var/run/cdi", # generated specs
+    ]
+    [plugins."io.containerd.grpc.v1.cri".containerd]
+      default_runtime_name = "nvidia"
+      [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia]
+        runtime_type = 'io.containerd.runc.v2'
+        options = { BinaryName = "/usr/local/nvidia/toolkit/nvidia-container-runtime", SystemdCgroup = true }
+`,
+			expected: map[string]any{
+				"version": int64(2),
+				"imports": []any{"/etc/containerd/conf.d/*.toml"},
+				"plugins": map[string]any{
+					"io.containerd.grpc.v1.cri": map[string]any{
+						"enable_cdi":    true,
+						"cdi_spec_dirs": []any{"/etc/cdi", "/var/run/cdi"},
+						"containerd": map[string]any{
+							"default_runtime_name": "nvidia",
+							"runtimes": map[string]any{
+								"nvidia": map[string]any{
+									"runtime_type": "io.containerd.runc.v2",
+									"options": map[string]any{
+										"BinaryName":    "/usr/local/nvidia/toolkit/nvidia-container-runtime",
+										"SystemdCgroup": true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "dotted keys and arrays of tables",
+			document: `a.b.c = 1
+"quoted.key".'literal' = "x"
+
+[[runtimes]]
+name = "runc"
+[runtimes.options]
+debug = false
+
+[[runtimes]]
+name = "nvidia"
+`,
+			expected: map[string]any{
+				"a":          map[string]any{"b": map[string]any{"c": int64(1)}},
+				"quoted.key": map[string]any{"literal": "x"},
+				"runtimes": []any{
+					map[string]any{"name": "runc", "options": map[string]any{"debug": false}},
+					map[string]any{"name": "nvidia"},
+				},
+			},
+		},
+		{
+			description: "strings",
+			document: "basic = \"tab\\there \\\"quoted\\\" \\u00e9\"\n" +
+				"literal = 'C:\\path # not a comment'\n" +
+				"multiline = \"\"\"\nfirst \\\n    second\nthird\"\"\"\n" +
+				"multiline_literal = '''\nraw \\n ''text'''''\n" +
+				"crlf = \"\"\"\r\nline\r\n\"\"\"\r\n",
+			expected: map[string]any{
+				"basic":             "tab\there \"quoted\" é",
+				"literal":           `C:\path # not a comment`,
+				"multiline":         "first second\nthird",
+				"multiline_literal": `raw \n ''text''`,
+				"crlf":              "line\n",
+			},
+		},
+		{
+			description: "numbers, booleans and dates",
+			document: `int = +1_000
+negative = -17
+hex = 0xdead_beef
+octal = 0o755
+binary = 0b1101
+float = 6.626e-34
+exponent = 5e+22
+infinite = -inf
+off = false
+date = 1979-05-27T07:32:00Z
+spaced = 1979-05-27 07:32:00.999999-07:00
+local_date = 1979-05-27
+local_time = 07:32:00
+`,
+			expected: map[string]any{
+				"int":        int64(1000),
+				"negative":   int64(-17),
+				"hex":        int64(0xdeadbeef),
+				"octal":      int64(0o755),
+				"binary":     int64(13),
+				"float":      6.626e-34,
+				"exponent":   5e+22,
+				"infinite":   math.Inf(-1),
+				"off":        false,
+				"date":       "1979-05-27T07:32:00Z",
+				"spaced":     "1979-05-27 07:32:00.999999-07:00",
+				"local_date": "1979-05-27",
+				"local_time": "07:32:00",
+			},
+		},
+		{
+			description: "super table defined after its sub-table",
+			document: `[x.y.z]
+a = 1
+[x]
+b = 2
+`,
+			expected: map[string]any{
+				"x": map[string]any{"y": map[string]any{"z": map[string]any{"a": int64(1)}}, "b": int64(2)},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			document, err := Unmarshal([]byte(tc.document))
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, document)
+		})
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	testCases := []struct {
+		description string
+		document    string
+		expectedErr string
+	}{
+		{description: "duplicate key", document: "a = 1\na = 2\n", expectedErr: "line 2: key a is already defined"},
+		{description: "duplicate table", document: "[a]\n[b]\n[a]\n", expectedErr: "line 3: table a is already defined"},
+		{description: "table defined by dotted keys", document: "a.b = 1\n[a]\n", expectedErr: "table a is already defined"},
+		{description: "inline table extended", document: "a = {b = 1}\n[a.c]\n", expectedErr: "inline table a cannot be extended"},
+		{description: "static array extended", document: "a = []\n[[a]]\n", expectedErr: "a is not an array of tables"},
+		{description: "value redefined as table", document: "a = 1\n[a]\n", expectedErr: "a is already defined as a value"},
+		{description: "unterminated string", document: "a = \"b\n", expectedErr: "unterminated string"},
+		{description: "invalid escape", document: `a = "\q"`, expectedErr: `invalid escape sequence \q`},
+		{description: "missing value", document: "a =\n", expectedErr: "missing value"},
+		{description: "leading zero", document: "a = 01\n", expectedErr: "leading zero in number 01"},
+		{description: "trailing content", document: "a = 1 b = 2\n", expectedErr: "line 1: unexpected 'b' at the end of the line"},
+		{description: "unclosed array", document: "a = [1, 2\n", expectedErr: "expected ',' or ']' in array"},
+		{description: "unclosed header", document: "[a\n", expectedErr: `expected "]" at the end of the table header`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			_, err := Unmarshal([]byte(tc.document))
+			require.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestTable(t *testing.T) {
+	document := map[string]any{"a": map[string]any{"b": map[string]any{"c": int64(1)}, "d": "value"}}
+	require.Equal(t, map[string]any{"c": int64(1)}, Table(document, "a", "b"))
+	require.Nil(t, Table(document, "a", "d"))
+	require.Nil(t, Table(document, "missing", "b"))
+	require.Equal(t, document, Table(document))
+}
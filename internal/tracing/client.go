@@ -0,0 +1,189 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const (
+	resourceKey  = attribute.Key("k8s.resource")
+	namespaceKey = attribute.Key("k8s.namespace")
+	nameKey      = attribute.Key("k8s.name")
+)
+
+// tracingClient is a client starting a span per request made through it
+type tracingClient struct {
+	client.Client
+}
+
+// NewClient returns a client starting a span per request made through the given client, as a child of the span
+// of the context of the request. The given client is returned when the traces are not exported.
+func NewClient(c client.Client) client.Client {
+	if !Enabled() {
+		return c
+	}
+	return &tracingClient{Client: c}
+}
+
+// start starts the span of a request on the given object
+func start(ctx context.Context, scheme *runtime.Scheme, verb string, obj runtime.Object, key client.ObjectKey) (context.Context, trace.Span) {
+	resource := resourceOf(obj, scheme)
+	attributes := []attribute.KeyValue{resourceKey.String(resource)}
+	if key.Namespace != "" {
+		attributes = append(attributes, namespaceKey.String(key.Namespace))
+	}
+	if key.Name != "" {
+		attributes = append(attributes, nameKey.String(key.Name))
+	}
+	return Start(ctx, verb+" "+resource, attributes...)
+}
+
+func (c *tracingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) (err error) {
+	ctx, span := start(ctx, c.Scheme(), "get", obj, key)
+	defer func() { End(span, err) }()
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func (c *tracingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) (err error) {
+	ctx, span := start(ctx, c.Scheme(), "list", list, client.ObjectKey{})
+	defer func() { End(span, err) }()
+	return c.Client.List(ctx, list, opts...)
+}
+
+func (c *tracingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) (err error) {
+	ctx, span := start(ctx, c.Scheme(), "create", obj, client.ObjectKeyFromObject(obj))
+	defer func() { End(span, err) }()
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *tracingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) (err error) {
+	ctx, span := start(ctx, c.Scheme(), "update", obj, client.ObjectKeyFromObject(obj))
+	defer func() { End(span, err) }()
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *tracingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) (err error) {
+	ctx, span := start(ctx, c.Scheme(), "patch", obj, client.ObjectKeyFromObject(obj))
+	defer func() { End(span, err) }()
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *tracingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) (err error) {
+	ctx, span := start(ctx, c.Scheme(), "delete", obj, client.ObjectKeyFromObject(obj))
+	defer func() { End(span, err) }()
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *tracingClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) (err error) {
+	ctx, span := start(ctx, c.Scheme(), "deletecollection", obj, client.ObjectKey{})
+	defer func() { End(span, err) }()
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (c *tracingClient) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+func (c *tracingClient) SubResource(subResource string) client.SubResourceClient {
+	return &tracingSubResourceClient{
+		SubResourceClient: c.Client.SubResource(subResource),
+		scheme:            c.Scheme(),
+		subResource:       subResource,
+	}
+}
+
+// tracingSubResourceClient is a client of a subresource starting a span per request made through it
+type tracingSubResourceClient struct {
+	client.SubResourceClient
+	scheme      *runtime.Scheme
+	subResource string
+}
+
+func (c *tracingSubResourceClient) start(ctx context.Context, verb string, obj client.Object) (context.Context, trace.Span) {
+	return start(ctx, c.scheme, verb+" "+c.subResource+" of", obj, client.ObjectKeyFromObject(obj))
+}
+
+func (c *tracingSubResourceClient) Get(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceGetOption) (err error) {
+	ctx, span := c.start(ctx, "get", obj)
+	defer func() { End(span, err) }()
+	return c.SubResourceClient.Get(ctx, obj, subResource, opts...)
+}
+
+func (c *tracingSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) (err error) {
+	ctx, span := c.start(ctx, "create", obj)
+	defer func() { End(span, err) }()
+	return c.SubResourceClient.Create(ctx, obj, subResource, opts...)
+}
+
+func (c *tracingSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) (err error) {
+	ctx, span := c.start(ctx, "update", obj)
+	defer func() { End(span, err) }()
+	return c.SubResourceClient.Update(ctx, obj, opts...)
+}
+
+func (c *tracingSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) (err error) {
+	ctx, span := c.start(ctx, "patch", obj)
+	defer func() { End(span, err) }()
+	return c.SubResourceClient.Patch(ctx, obj, patch, opts...)
+}
+
+// tracingReader is a reader starting a span per read made through it
+type tracingReader struct {
+	client.Reader
+	scheme *runtime.Scheme
+}
+
+// NewReader returns a reader starting a span per read made through the given reader, as a child of the span of
+// the context of the read. The given reader is returned when the traces are not exported.
+func NewReader(r client.Reader, scheme *runtime.Scheme) client.Reader {
+	if !Enabled() {
+		return r
+	}
+	return &tracingReader{Reader: r, scheme: scheme}
+}
+
+func (r *tracingReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) (err error) {
+	ctx, span := start(ctx, r.scheme, "get", obj, key)
+	defer func() { End(span, err) }()
+	return r.Reader.Get(ctx, key, obj, opts...)
+}
+
+func (r *tracingReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) (err error) {
+	ctx, span := start(ctx, r.scheme, "list", list, client.ObjectKey{})
+	defer func() { End(span, err) }()
+	return r.Reader.List(ctx, list, opts...)
+}
+
+// resourceOf returns the kind of the object, or of the items of a list
+func resourceOf(obj runtime.Object, scheme *runtime.Scheme) string {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if gvk, err := apiutil.GVKForObject(obj, scheme); err == nil {
+		kind = gvk.Kind
+	}
+	if kind == "" {
+		return "unknown"
+	}
+	return strings.TrimSuffix(kind, "List")
+}
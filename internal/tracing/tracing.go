@@ -0,0 +1,103 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package tracing traces the reconciliations of the controllers with OpenTelemetry: a span per reconciliation,
+// per state and per request to the Kubernetes API, so that the states dominating the reconciliation latency are
+// visible on large clusters. The spans are exported through OTLP over HTTP when an OTLP endpoint is set with the
+// standard OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT environment variables, which along
+// with the other OTEL_* variables (e.g. OTEL_TRACES_SAMPLER) configure the exporter and the sampling.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// StateKey is the attribute holding the name of the state reconciled by a span
+	StateKey = attribute.Key("gpu_operator.state")
+	// ResultKey is the attribute holding the result of the state reconciled by a span
+	ResultKey = attribute.Key("gpu_operator.result")
+	// ObjectKey is the attribute holding the name of the custom resource reconciled by a span
+	ObjectKey = attribute.Key("gpu_operator.object")
+)
+
+const (
+	// EndpointEnvName is the environment variable setting the OTLP endpoint of all signals
+	EndpointEnvName = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	// TracesEndpointEnvName is the environment variable setting the OTLP endpoint of the traces
+	TracesEndpointEnvName = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+
+	serviceName = "gpu-operator"
+	tracerName  = "github.com/NVIDIA/gpu-operator"
+)
+
+// Enabled returns true if an OTLP endpoint is set to export the traces
+func Enabled() bool {
+	return os.Getenv(EndpointEnvName) != "" || os.Getenv(TracesEndpointEnvName) != ""
+}
+
+// Setup installs the tracer provider exporting the spans to the OTLP endpoint, and returns the function
+// flushing the spans left and stopping the export. Nothing is exported when no OTLP endpoint is set.
+func Setup(ctx context.Context, version string) (func(context.Context) error, error) {
+	if !Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the OTLP trace exporter: %w", err)
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("unable to describe the operator in the traces: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// Start starts a span of the operator, child of the span of the context if any. The span does nothing when the
+// traces are not exported.
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// End ends the span, recording the error which ended it if any
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
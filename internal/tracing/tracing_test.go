@@ -0,0 +1,94 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// recordSpans installs a tracer provider recording the ended spans for the duration of the test
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func TestSetupDisabled(t *testing.T) {
+	t.Setenv(EndpointEnvName, "")
+	t.Setenv(TracesEndpointEnvName, "")
+	require.False(t, Enabled())
+
+	shutdown, err := Setup(context.Background(), "v1.0.0")
+	require.NoError(t, err)
+	require.NoError(t, shutdown(context.Background()))
+
+	c := fake.NewClientBuilder().Build()
+	require.Equal(t, c, NewClient(c))
+}
+
+func TestEnd(t *testing.T) {
+	recorder := recordSpans(t)
+
+	_, span := Start(context.Background(), "state state-driver", StateKey.String("state-driver"))
+	End(span, errors.New("driver not ready"))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "state state-driver", spans[0].Name())
+	require.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Equal(t, "driver not ready", spans[0].Status().Description)
+}
+
+func TestClient(t *testing.T) {
+	t.Setenv(EndpointEnvName, "http://localhost:4318")
+	recorder := recordSpans(t)
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "gpu-operator"}}
+	c := NewClient(fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(ns).Build())
+
+	ctx, parent := Start(context.Background(), "reconcile ClusterPolicy")
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(ns), &corev1.Namespace{}))
+	require.NoError(t, c.List(ctx, &corev1.NodeList{}))
+	require.Error(t, c.Delete(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "missing", Namespace: "gpu-operator"}}))
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 4)
+	names := []string{}
+	for _, span := range spans[:3] {
+		names = append(names, span.Name())
+		require.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	}
+	require.Equal(t, []string{"get Namespace", "list Node", "delete ConfigMap"}, names)
+	require.Contains(t, spans[0].Attributes(), nameKey.String("gpu-operator"))
+	require.Equal(t, codes.Error, spans[2].Status().Code)
+}
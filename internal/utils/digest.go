@@ -0,0 +1,344 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package utils
+
+import (
+	"cmp"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"hash/fnv"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// objectDigestPrefix prefixes the digests returned by GetObjectDigest, telling them apart from the legacy hashes
+// returned by GetObjectHash which are stored by the previous releases of the operator
+const objectDigestPrefix = "v2-"
+
+// value tags written before each value, so that values of different kinds, or a nil and an empty value, do
+// not produce the same stream
+const (
+	tagNil byte = iota
+	tagBool
+	tagInt
+	tagUint
+	tagFloat
+	tagComplex
+	tagString
+	tagBytes
+	tagList
+	tagMap
+	tagPointer
+	tagInterface
+	tagStruct
+	tagOpaque
+)
+
+// digestEncoder streams the canonical encoding of a value to a hash through a fixed buffer, without building the
+// encoding in memory
+type digestEncoder struct {
+	hash hash.Hash64
+	buf  [512]byte
+	n    int
+	// pointers holds the pointers being encoded, to stop at cyclic references
+	pointers []uintptr
+}
+
+var digestEncoderPool = sync.Pool{
+	New: func() interface{} {
+		return &digestEncoder{hash: fnv.New64a()}
+	},
+}
+
+// encoderFunc encodes a value of a given type
+type encoderFunc func(e *digestEncoder, v reflect.Value)
+
+// encoderCache caches the encoderFunc of each type
+var encoderCache sync.Map
+
+// GetObjectDigest returns a digest of the full object (all fields), computed from a canonical encoding of the object
+// streamed to an FNV-64a hash. The encoding walks the object with reflection, the encoder of each type being cached,
+// and the map keys are sorted. Unlike GetObjectHash, no textual representation of the object is built, which keeps
+// the digests of the operand objects cheap to compute on every reconciliation.
+func GetObjectDigest(obj interface{}) string {
+	e := newDigestEncoder()
+	defer digestEncoderPool.Put(e)
+	e.encode(reflect.ValueOf(obj))
+	sum := e.sum()
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], sum)
+	return objectDigestPrefix + hex.EncodeToString(buf[:])
+}
+
+// ObjectHashMatches returns true if the hash stored for an object, e.g. in its last applied hash annotation, is the
+// hash of the object. The digests returned by GetObjectDigest are compared with the given digest of the object, while
+// the legacy hashes stored by the previous releases of the operator are compared with the hash returned by
+// legacyHash, so that the objects left unchanged by an upgrade of the operator are not updated just to replace their
+// hash. legacyHash is only called for the legacy hashes.
+func ObjectHashMatches(stored, digest string, legacyHash func() string) bool {
+	if stored == digest {
+		return true
+	}
+	if stored == "" || strings.HasPrefix(stored, objectDigestPrefix) {
+		return false
+	}
+	return stored == legacyHash()
+}
+
+// newDigestEncoder returns a reset encoder from the pool
+func newDigestEncoder() *digestEncoder {
+	e := digestEncoderPool.Get().(*digestEncoder)
+	e.hash.Reset()
+	e.n = 0
+	e.pointers = e.pointers[:0]
+	return e
+}
+
+// sum returns the hash of the encoding written so far
+func (e *digestEncoder) sum() uint64 {
+	e.flush()
+	return e.hash.Sum64()
+}
+
+func (e *digestEncoder) flush() {
+	_, _ = e.hash.Write(e.buf[:e.n])
+	e.n = 0
+}
+
+func (e *digestEncoder) writeTag(tag byte) {
+	if e.n == len(e.buf) {
+		e.flush()
+	}
+	e.buf[e.n] = tag
+	e.n++
+}
+
+func (e *digestEncoder) writeUint(tag byte, u uint64) {
+	if len(e.buf)-e.n < binary.MaxVarintLen64+1 {
+		e.flush()
+	}
+	e.buf[e.n] = tag
+	e.n += 1 + binary.PutUvarint(e.buf[e.n+1:], u)
+}
+
+// writeString writes a string or the content of a byte slice, copied to the buffer without conversion
+func writeString[T string | []byte](e *digestEncoder, tag byte, s T) {
+	e.writeUint(tag, uint64(len(s)))
+	for len(s) > 0 {
+		if e.n == len(e.buf) {
+			e.flush()
+		}
+		copied := copy(e.buf[e.n:], s)
+		e.n += copied
+		s = s[copied:]
+	}
+}
+
+func (e *digestEncoder) encode(v reflect.Value) {
+	if !v.IsValid() {
+		e.writeTag(tagNil)
+		return
+	}
+	typeEncoder(v.Type())(e, v)
+}
+
+// typeEncoder returns the cached encoderFunc of a type, building it on first use
+func typeEncoder(t reflect.Type) encoderFunc {
+	if f, ok := encoderCache.Load(t); ok {
+		return f.(encoderFunc)
+	}
+	// recursive types resolve their own encoder through the cache while it is being built
+	var (
+		wg sync.WaitGroup
+		f  encoderFunc
+	)
+	wg.Add(1)
+	fi, loaded := encoderCache.LoadOrStore(t, encoderFunc(func(e *digestEncoder, v reflect.Value) {
+		wg.Wait()
+		f(e, v)
+	}))
+	if loaded {
+		return fi.(encoderFunc)
+	}
+	f = newTypeEncoder(t)
+	wg.Done()
+	encoderCache.Store(t, f)
+	return f
+}
+
+func newTypeEncoder(t reflect.Type) encoderFunc {
+	switch t.Kind() {
+	case reflect.Bool:
+		return func(e *digestEncoder, v reflect.Value) {
+			if v.Bool() {
+				e.writeUint(tagBool, 1)
+			} else {
+				e.writeUint(tagBool, 0)
+			}
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(e *digestEncoder, v reflect.Value) {
+			e.writeUint(tagInt, uint64(v.Int()))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(e *digestEncoder, v reflect.Value) {
+			e.writeUint(tagUint, v.Uint())
+		}
+	case reflect.Float32, reflect.Float64:
+		return func(e *digestEncoder, v reflect.Value) {
+			e.writeUint(tagFloat, math.Float64bits(v.Float()))
+		}
+	case reflect.Complex64, reflect.Complex128:
+		return func(e *digestEncoder, v reflect.Value) {
+			c := v.Complex()
+			e.writeUint(tagComplex, math.Float64bits(real(c)))
+			e.writeUint(tagComplex, math.Float64bits(imag(c)))
+		}
+	case reflect.String:
+		return func(e *digestEncoder, v reflect.Value) {
+			writeString(e, tagString, v.String())
+		}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return func(e *digestEncoder, v reflect.Value) {
+				if v.IsNil() {
+					e.writeTag(tagNil)
+					return
+				}
+				writeString(e, tagBytes, v.Bytes())
+			}
+		}
+		elem := typeEncoder(t.Elem())
+		return func(e *digestEncoder, v reflect.Value) {
+			if v.IsNil() {
+				e.writeTag(tagNil)
+				return
+			}
+			e.writeUint(tagList, uint64(v.Len()))
+			for i := 0; i < v.Len(); i++ {
+				elem(e, v.Index(i))
+			}
+		}
+	case reflect.Array:
+		elem := typeEncoder(t.Elem())
+		return func(e *digestEncoder, v reflect.Value) {
+			e.writeUint(tagList, uint64(v.Len()))
+			for i := 0; i < v.Len(); i++ {
+				elem(e, v.Index(i))
+			}
+		}
+	case reflect.Map:
+		return newMapEncoder(t)
+	case reflect.Pointer:
+		elem := typeEncoder(t.Elem())
+		return func(e *digestEncoder, v reflect.Value) {
+			if v.IsNil() {
+				e.writeTag(tagNil)
+				return
+			}
+			ptr := v.Pointer()
+			if slices.Contains(e.pointers, ptr) {
+				// cyclic reference
+				e.writeTag(tagOpaque)
+				return
+			}
+			e.pointers = append(e.pointers, ptr)
+			e.writeTag(tagPointer)
+			elem(e, v.Elem())
+			e.pointers = e.pointers[:len(e.pointers)-1]
+		}
+	case reflect.Interface:
+		return func(e *digestEncoder, v reflect.Value) {
+			if v.IsNil() {
+				e.writeTag(tagNil)
+				return
+			}
+			e.writeTag(tagInterface)
+			e.encode(v.Elem())
+		}
+	case reflect.Struct:
+		fields := make([]encoderFunc, t.NumField())
+		for i := range fields {
+			fields[i] = typeEncoder(t.Field(i).Type)
+		}
+		return func(e *digestEncoder, v reflect.Value) {
+			e.writeUint(tagStruct, uint64(len(fields)))
+			for i, field := range fields {
+				field(e, v.Field(i))
+			}
+		}
+	default:
+		// channels, functions and unsafe pointers are only told apart from nil
+		return func(e *digestEncoder, v reflect.Value) {
+			if v.IsNil() {
+				e.writeTag(tagNil)
+				return
+			}
+			e.writeTag(tagOpaque)
+		}
+	}
+}
+
+// mapEntry is an entry of a map being encoded
+type mapEntry struct {
+	// digest is the digest of the encoding of a key which is not a string
+	digest     uint64
+	key, value reflect.Value
+}
+
+// newMapEncoder returns the encoder of a map type, writing the entries sorted by key. The string keys are sorted
+// directly, the other keys by the digest of their encoding.
+func newMapEncoder(t reflect.Type) encoderFunc {
+	key, elem := typeEncoder(t.Key()), typeEncoder(t.Elem())
+	stringKeys := t.Key().Kind() == reflect.String
+	return func(e *digestEncoder, v reflect.Value) {
+		if v.IsNil() {
+			e.writeTag(tagNil)
+			return
+		}
+		entries := make([]mapEntry, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			entry := mapEntry{key: iter.Key(), value: iter.Value()}
+			if !stringKeys {
+				keyEncoder := newDigestEncoder()
+				key(keyEncoder, entry.key)
+				entry.digest = keyEncoder.sum()
+				digestEncoderPool.Put(keyEncoder)
+			}
+			entries = append(entries, entry)
+		}
+		if stringKeys {
+			slices.SortFunc(entries, func(a, b mapEntry) int {
+				return strings.Compare(a.key.String(), b.key.String())
+			})
+		} else {
+			slices.SortFunc(entries, func(a, b mapEntry) int {
+				return cmp.Compare(a.digest, b.digest)
+			})
+		}
+		e.writeUint(tagMap, uint64(len(entries)))
+		for _, entry := range entries {
+			key(e, entry.key)
+			elem(e, entry.value)
+		}
+	}
+}
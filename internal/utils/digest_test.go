@@ -0,0 +1,161 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newDigestDaemonSet() *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "nvidia-device-plugin-daemonset",
+			Namespace:   "gpu-operator",
+			Labels:      map[string]string{"app": "nvidia-device-plugin-daemonset", "helm.sh/chart": "gpu-operator"},
+			Annotations: map[string]string{"openshift.io/scc": "restricted-readonly"},
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nvidia-device-plugin-daemonset"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "nvidia-device-plugin-daemonset"}},
+				Spec: corev1.PodSpec{
+					NodeSelector: map[string]string{"nvidia.com/gpu.deploy.device-plugin": "true"},
+					Tolerations:  []corev1.Toleration{{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}},
+					InitContainers: []corev1.Container{{
+						Name:    "toolkit-validation",
+						Image:   "nvcr.io/nvidia/cloud-native/gpu-operator-validator:v25.3.0",
+						Command: []string{"sh", "-c"},
+						Args:    []string{"until [ -f /run/nvidia/validations/toolkit-ready ]; do sleep 5; done"},
+					}},
+					Containers: []corev1.Container{{
+						Name:  "nvidia-device-plugin",
+						Image: "nvcr.io/nvidia/k8s-device-plugin:v0.17.0",
+						Env: []corev1.EnvVar{
+							{Name: "PASS_DEVICE_SPECS", Value: "true"},
+							{Name: "NODE_NAME", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"}}},
+						},
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+						},
+						VolumeMounts: []corev1.VolumeMount{{Name: "device-plugin", MountPath: "/var/lib/kubelet/device-plugins"}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name:         "device-plugin",
+						VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/lib/kubelet/device-plugins"}},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func TestGetObjectDigest(t *testing.T) {
+	t.Run("deterministic", func(t *testing.T) {
+		digest := GetObjectDigest(newDigestDaemonSet())
+		assert.True(t, strings.HasPrefix(digest, objectDigestPrefix))
+		for i := 0; i < 10; i++ {
+			// the maps are iterated in a random order
+			assert.Equal(t, digest, GetObjectDigest(newDigestDaemonSet()))
+		}
+	})
+
+	t.Run("different values produce different digests", func(t *testing.T) {
+		ds := newDigestDaemonSet()
+		ds.Spec.Template.Spec.Containers[0].Env[0].Value = "false"
+		assert.NotEqual(t, GetObjectDigest(newDigestDaemonSet()), GetObjectDigest(ds))
+
+		ds = newDigestDaemonSet()
+		ds.Spec.Template.Spec.Containers[0].Resources.Limits[corev1.ResourceMemory] = resource.MustParse("1Gi")
+		assert.NotEqual(t, GetObjectDigest(newDigestDaemonSet()), GetObjectDigest(ds))
+	})
+
+	t.Run("nil and empty values produce different digests", func(t *testing.T) {
+		assert.NotEqual(t, GetObjectDigest([]string(nil)), GetObjectDigest([]string{}))
+		assert.NotEqual(t, GetObjectDigest(map[string]string(nil)), GetObjectDigest(map[string]string{}))
+		assert.NotEqual(t, GetObjectDigest([]string{"ab"}), GetObjectDigest([]string{"a", "b"}))
+	})
+
+	t.Run("non-string map keys", func(t *testing.T) {
+		m := map[int]string{1: "a", 2: "b", 3: "c"}
+		assert.Equal(t, GetObjectDigest(m), GetObjectDigest(map[int]string{3: "c", 2: "b", 1: "a"}))
+		assert.NotEqual(t, GetObjectDigest(m), GetObjectDigest(map[int]string{1: "b", 2: "a", 3: "c"}))
+	})
+
+	t.Run("unstructured objects", func(t *testing.T) {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(newDigestDaemonSet())
+		assert.NoError(t, err)
+		obj := &unstructured.Unstructured{Object: content}
+		assert.Equal(t, GetObjectDigest(obj), GetObjectDigest(obj.DeepCopy()))
+
+		updated := obj.DeepCopy()
+		updated.SetLabels(map[string]string{"app": "nvidia-device-plugin-daemonset"})
+		assert.NotEqual(t, GetObjectDigest(obj), GetObjectDigest(updated))
+	})
+
+	t.Run("cyclic references", func(t *testing.T) {
+		type node struct {
+			Name string
+			Next *node
+		}
+		a := &node{Name: "a"}
+		a.Next = &node{Name: "b", Next: a}
+		assert.Equal(t, GetObjectDigest(a), GetObjectDigest(a))
+	})
+}
+
+func TestObjectHashMatches(t *testing.T) {
+	ds := newDigestDaemonSet()
+	digest := GetObjectDigest(ds)
+	legacyHash := func() string { return GetObjectHash(ds) }
+
+	assert.True(t, ObjectHashMatches(digest, digest, legacyHash))
+	assert.False(t, ObjectHashMatches("", digest, legacyHash))
+	assert.False(t, ObjectHashMatches(objectDigestPrefix+"0123456789abcdef", digest, legacyHash))
+
+	// the hashes stored by the previous releases are compared with the legacy hash
+	assert.True(t, ObjectHashMatches(GetObjectHash(ds), digest, legacyHash))
+	assert.False(t, ObjectHashMatches("1234", digest, legacyHash))
+	assert.True(t, ObjectHashMatches(digest, digest, func() string {
+		t.Fatal("the legacy hash is only computed for the legacy hashes")
+		return ""
+	}))
+}
+
+func BenchmarkGetObjectHash(b *testing.B) {
+	ds := newDigestDaemonSet()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		GetObjectHash(ds)
+	}
+}
+
+func BenchmarkGetObjectDigest(b *testing.B) {
+	ds := newDigestDaemonSet()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		GetObjectDigest(ds)
+	}
+}
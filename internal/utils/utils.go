@@ -68,7 +68,10 @@ var spewPrinter = spew.ConfigState{
 	SpewKeys:       true,
 }
 
-// GetObjectHash returns an FNV-32a hash of the full object (all fields).
+// GetObjectHash returns an FNV-32a hash of the full object (all fields), computed from its spew representation.
+// It computes the digests embedded in the operand pod templates, which must not change across releases as that
+// would restart the operand pods, while the last applied hashes of the operand objects are computed by the faster
+// GetObjectDigest.
 func GetObjectHash(obj interface{}) string {
 	hasher := fnv.New32a()
 	spewPrinter.Fprintf(hasher, "%#v", obj)
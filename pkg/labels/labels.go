@@ -0,0 +1,172 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package labels defines the nvidia.com node labels read and set by the GPU Operator, and helpers interpreting
+// them the way the operator does, so that other controllers do not hard-code the label keys and values.
+package labels
+
+const (
+	// GPUPresent is set to "true" by the operator on the nodes with NVIDIA GPUs
+	GPUPresent = "nvidia.com/gpu.present"
+	// GPUProduct is the product name of the GPUs of the node, set by GPU Feature Discovery
+	GPUProduct = "nvidia.com/gpu.product"
+	// GPUCount is the number of GPUs of the node, set by GPU Feature Discovery
+	GPUCount = "nvidia.com/gpu.count"
+	// GPUMemory is the memory of the GPUs of the node in MiB, set by GPU Feature Discovery
+	GPUMemory = "nvidia.com/gpu.memory"
+	// GPUMock is set to "true" on the nodes advertising mock GPUs, which are not backed by GPU hardware
+	GPUMock = "nvidia.com/gpu.mock"
+
+	// DeployOperands disables all operands on the node when set to "false"
+	DeployOperands = "nvidia.com/gpu.deploy.operands"
+
+	// DeployDriver schedules the driver on the node when set to "true"
+	DeployDriver = "nvidia.com/gpu.deploy.driver"
+	// DeployContainerToolkit schedules the container toolkit on the node when set to "true"
+	DeployContainerToolkit = "nvidia.com/gpu.deploy.container-toolkit"
+	// DeployDevicePlugin schedules the device plugin on the node when set to "true"
+	DeployDevicePlugin = "nvidia.com/gpu.deploy.device-plugin"
+	// DeployGPUFeatureDiscovery schedules GPU Feature Discovery on the node when set to "true"
+	DeployGPUFeatureDiscovery = "nvidia.com/gpu.deploy.gpu-feature-discovery"
+	// DeployDCGM schedules DCGM on the node when set to "true"
+	DeployDCGM = "nvidia.com/gpu.deploy.dcgm"
+	// DeployDCGMExporter schedules DCGM Exporter on the node when set to "true"
+	DeployDCGMExporter = "nvidia.com/gpu.deploy.dcgm-exporter"
+	// DeployNodeStatusExporter schedules the node status exporter on the node when set to "true"
+	DeployNodeStatusExporter = "nvidia.com/gpu.deploy.node-status-exporter"
+	// DeployGPUHealthCheck schedules the GPU health check on the node when set to "true"
+	DeployGPUHealthCheck = "nvidia.com/gpu.deploy.gpu-health-check"
+	// DeployNVLinkFabric schedules the NVLink fabric registration on the node when set to "true"
+	DeployNVLinkFabric = "nvidia.com/gpu.deploy.nvlink-fabric"
+	// DeployImagePrePull schedules the pre-pull of the driver and toolkit images on the node when set to "true"
+	DeployImagePrePull = "nvidia.com/gpu.deploy.image-prepull"
+	// DeployOperatorValidator schedules the operator validator on the node when set to "true"
+	DeployOperatorValidator = "nvidia.com/gpu.deploy.operator-validator"
+	// DeployClient schedules the operator clients, e.g. the GPU workloads of the operator, on the node when set to "true"
+	DeployClient = "nvidia.com/gpu.deploy.client"
+	// DeployMIGManager schedules the MIG Manager on the node when set to "true"
+	DeployMIGManager = "nvidia.com/gpu.deploy.mig-manager"
+	// DeploySandboxDevicePlugin schedules the KubeVirt sandbox device plugin on the node when set to "true"
+	DeploySandboxDevicePlugin = "nvidia.com/gpu.deploy.sandbox-device-plugin"
+	// DeployKataSandboxDevicePlugin schedules the Kata sandbox device plugin on the node when set to "true"
+	DeployKataSandboxDevicePlugin = "nvidia.com/gpu.deploy.kata-sandbox-device-plugin"
+	// DeploySandboxValidator schedules the sandbox validator on the node when set to "true"
+	DeploySandboxValidator = "nvidia.com/gpu.deploy.sandbox-validator"
+	// DeployVFIOManager schedules the VFIO Manager on the node when set to "true"
+	DeployVFIOManager = "nvidia.com/gpu.deploy.vfio-manager"
+	// DeployKataManager schedules the Kata Manager on the node when set to "true"
+	DeployKataManager = "nvidia.com/gpu.deploy.kata-manager"
+	// DeployCCManager schedules the Confidential Computing Manager on the node when set to "true"
+	DeployCCManager = "nvidia.com/gpu.deploy.cc-manager"
+	// DeployVGPUManager schedules the vGPU Manager on the node when set to "true"
+	DeployVGPUManager = "nvidia.com/gpu.deploy.vgpu-manager"
+	// DeployVGPUDeviceManager schedules the vGPU Device Manager on the node when set to "true"
+	DeployVGPUDeviceManager = "nvidia.com/gpu.deploy.vgpu-device-manager"
+	// DeployWindowsDevicePlugin schedules the Windows device plugin on the node when set to "true"
+	DeployWindowsDevicePlugin = "nvidia.com/gpu.deploy.windows-device-plugin"
+	// DeployWindowsGPUFeatureDiscovery schedules the Windows GPU Feature Discovery on the node when set to "true"
+	DeployWindowsGPUFeatureDiscovery = "nvidia.com/gpu.deploy.windows-gpu-feature-discovery"
+	// DeployDRADriver schedules the DRA driver of the GPUCluster on the node when set to "true"
+	DeployDRADriver = "nvidia.com/gpu.deploy.dra-driver"
+	// DeployDRAValidator schedules the DRA validator of the GPUCluster on the node when set to "true"
+	DeployDRAValidator = "nvidia.com/gpu.deploy.dra-validator"
+
+	// MIGCapable is set to "true" by GPU Feature Discovery on the nodes with MIG capable GPUs
+	MIGCapable = "nvidia.com/mig.capable"
+	// MIGConfig selects the MIG configuration applied by the MIG Manager to the GPUs of the node
+	MIGConfig = "nvidia.com/mig.config"
+	// MIGConfigAllDisabled is the MIG configuration disabling MIG on all the GPUs of the node
+	MIGConfigAllDisabled = "all-disabled"
+
+	// VGPUHostDriverVersion is the version of the vGPU host driver of the node
+	VGPUHostDriverVersion = "nvidia.com/vgpu.host-driver-version"
+	// VGPUPresent is set to "true" on the nodes with vGPU devices
+	VGPUPresent = "nvidia.com/vgpu.present"
+	// VGPUConfig selects the vGPU configuration applied by the vGPU Device Manager to the GPUs of the node
+	VGPUConfig = "nvidia.com/vgpu.config"
+
+	// WorkloadConfig selects the workload running on the GPUs of the node when sandbox workloads are enabled
+	WorkloadConfig = "nvidia.com/gpu.workload.config"
+	// WorkloadConfigContainer runs containers on the GPUs of the node
+	WorkloadConfigContainer = "container"
+	// WorkloadConfigVMPassthrough passes the GPUs of the node through to virtual machines
+	WorkloadConfigVMPassthrough = "vm-passthrough"
+	// WorkloadConfigVMVgpu shares the GPUs of the node with virtual machines as vGPU devices
+	WorkloadConfigVMVgpu = "vm-vgpu"
+
+	// SandboxMode selects the sandbox mode of the virtual machines of the node, overriding the
+	// sandboxWorkloads.mode of the ClusterPolicy
+	SandboxMode = "nvidia.com/gpu.sandbox.mode"
+	// SandboxModeKubeVirt runs the virtual machines of the node with KubeVirt
+	SandboxModeKubeVirt = "kubevirt"
+	// SandboxModeKata runs the virtual machines of the node as Kata containers
+	SandboxModeKata = "kata"
+)
+
+// HasGPU returns true if the node with the given labels is labeled by the operator as a GPU node
+func HasGPU(nodeLabels map[string]string) bool {
+	return nodeLabels[GPUPresent] == "true"
+}
+
+// HasMockGPUs returns true if the node with the given labels advertises mock GPUs
+func HasMockGPUs(nodeLabels map[string]string) bool {
+	return nodeLabels[GPUMock] == "true"
+}
+
+// OperandsDisabled returns true if all operands are disabled on the node with the given labels
+func OperandsDisabled(nodeLabels map[string]string) bool {
+	return nodeLabels[DeployOperands] == "false"
+}
+
+// IsValidWorkloadConfig returns true if the given value is a workload config of the GPU nodes
+func IsValidWorkloadConfig(config string) bool {
+	switch config {
+	case WorkloadConfigContainer, WorkloadConfigVMPassthrough, WorkloadConfigVMVgpu:
+		return true
+	}
+	return false
+}
+
+// WorkloadConfigOf returns the workload config of the node with the given labels when sandbox workloads are
+// enabled, falling back to the given default workload config, i.e. the sandboxWorkloads.defaultWorkload of the
+// ClusterPolicy, when the node has no valid workload config label. The GPUs of all nodes run containers when
+// sandbox workloads are disabled.
+func WorkloadConfigOf(nodeLabels map[string]string, defaultConfig string) string {
+	if config := nodeLabels[WorkloadConfig]; IsValidWorkloadConfig(config) {
+		return config
+	}
+	return defaultConfig
+}
+
+// IsValidSandboxMode returns true if the given value is a sandbox mode of the GPU nodes
+func IsValidSandboxMode(mode string) bool {
+	return mode == SandboxModeKubeVirt || mode == SandboxModeKata
+}
+
+// SandboxModeOf returns the sandbox mode of the node with the given labels, falling back to the given default
+// sandbox mode, i.e. the sandboxWorkloads.mode of the ClusterPolicy, when the node has no valid sandbox mode label
+func SandboxModeOf(nodeLabels map[string]string, defaultMode string) string {
+	if mode := nodeLabels[SandboxMode]; IsValidSandboxMode(mode) {
+		return mode
+	}
+	return defaultMode
+}
+
+// IsDeployed returns true if the node with the given labels is labeled to run the operand of the given
+// nvidia.com/gpu.deploy.* label
+func IsDeployed(nodeLabels map[string]string, deployLabel string) bool {
+	return nodeLabels[deployLabel] == "true" && !OperandsDisabled(nodeLabels)
+}
@@ -0,0 +1,54 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package labels
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHasGPU(t *testing.T) {
+	require.True(t, HasGPU(map[string]string{GPUPresent: "true"}))
+	require.False(t, HasGPU(map[string]string{GPUPresent: "false"}))
+	require.False(t, HasGPU(nil))
+}
+
+func TestWorkloadConfigOf(t *testing.T) {
+	require.Equal(t, WorkloadConfigVMPassthrough,
+		WorkloadConfigOf(map[string]string{WorkloadConfig: WorkloadConfigVMPassthrough}, WorkloadConfigContainer))
+	require.Equal(t, WorkloadConfigContainer,
+		WorkloadConfigOf(map[string]string{WorkloadConfig: "vm"}, WorkloadConfigContainer))
+	require.Equal(t, WorkloadConfigVMVgpu, WorkloadConfigOf(nil, WorkloadConfigVMVgpu))
+}
+
+func TestSandboxModeOf(t *testing.T) {
+	require.Equal(t, SandboxModeKata, SandboxModeOf(map[string]string{SandboxMode: SandboxModeKata}, SandboxModeKubeVirt))
+	require.Equal(t, SandboxModeKubeVirt, SandboxModeOf(map[string]string{SandboxMode: "vm"}, SandboxModeKubeVirt))
+	require.Empty(t, SandboxModeOf(nil, ""))
+}
+
+func TestIsDeployed(t *testing.T) {
+	nodeLabels := map[string]string{DeployDriver: "true", DeployDevicePlugin: "false"}
+	require.True(t, IsDeployed(nodeLabels, DeployDriver))
+	require.False(t, IsDeployed(nodeLabels, DeployDevicePlugin))
+	require.False(t, IsDeployed(nodeLabels, DeployDCGM))
+
+	nodeLabels[DeployOperands] = "false"
+	require.True(t, OperandsDisabled(nodeLabels))
+	require.False(t, IsDeployed(nodeLabels, DeployDriver))
+}